@@ -0,0 +1,217 @@
+// Package client is the Go client of the milvus-backup server, over its REST api or grpc MilvusBackupService.
+//
+//	c, err := client.NewHTTPClient("http://localhost:8080", client.WithAPIKey(key))
+//	resp, err := c.CreateBackup(ctx, &backuppb.CreateBackupRequest{BackupName: "daily", Async: true})
+//	job, err := c.WaitJob(ctx, resp.GetData().GetId(), nil)
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"time"
+
+	"google.golang.org/grpc"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// Client calls the api of a milvus-backup server. The methods return a *ResponseError together with the response
+// if the server doesn't respond success, so the details of a failed verify or restore can still be read.
+// The methods which only read are retried on transient errors, the ones which change backups are not.
+type Client interface {
+	CreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.BackupInfoResponse, error)
+	GetBackup(ctx context.Context, request *backuppb.GetBackupRequest) (*backuppb.BackupInfoResponse, error)
+	ListBackups(ctx context.Context, request *backuppb.ListBackupsRequest) (*backuppb.ListBackupsResponse, error)
+	DeleteBackup(ctx context.Context, request *backuppb.DeleteBackupRequest) (*backuppb.DeleteBackupResponse, error)
+	PauseBackup(ctx context.Context, request *backuppb.PauseBackupRequest) (*backuppb.PauseBackupResponse, error)
+	ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) (*backuppb.BackupInfoResponse, error)
+	PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) (*backuppb.PruneBackupsResponse, error)
+	GarbageCollect(ctx context.Context, request *backuppb.GarbageCollectRequest) (*backuppb.GarbageCollectResponse, error)
+	VerifyBackup(ctx context.Context, request *backuppb.VerifyBackupRequest) (*backuppb.VerifyBackupResponse, error)
+	EstimateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.EstimateBackupResponse, error)
+	RestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest) (*backuppb.RestoreBackupResponse, error)
+	GetRestore(ctx context.Context, request *backuppb.GetRestoreStateRequest) (*backuppb.RestoreBackupResponse, error)
+	GetJob(ctx context.Context, request *backuppb.GetJobRequest) (*backuppb.JobResponse, error)
+	CancelJob(ctx context.Context, request *backuppb.CancelJobRequest) (*backuppb.JobResponse, error)
+	ListJobs(ctx context.Context, request *backuppb.ListJobsRequest) (*backuppb.ListJobsResponse, error)
+	ResumeJob(ctx context.Context, request *backuppb.ResumeJobRequest) (*backuppb.JobResponse, error)
+	// GetSchedule is only served by the REST api
+	GetSchedule(ctx context.Context) (*backuppb.GetScheduleResponse, error)
+	// GetRecoveryPoint is only served by the REST api
+	GetRecoveryPoint(ctx context.Context) (*backuppb.GetRecoveryPointResponse, error)
+	// Check returns the report of the connections of the server to milvus and storage
+	Check(ctx context.Context) (string, error)
+	CheckCompatibility(ctx context.Context, request *backuppb.CheckCompatibilityRequest) (*backuppb.CheckCompatibilityResponse, error)
+
+	// WaitJob blocks until the backup or restore job ends and returns it, onProgress is called with the job
+	// whenever its state or progress changes if it is not nil. The error is a *ResponseError if the job fails.
+	WaitJob(ctx context.Context, jobID string, onProgress func(*backuppb.JobInfo)) (*backuppb.JobInfo, error)
+
+	Close() error
+}
+
+// ResponseError is returned if the server doesn't respond success
+type ResponseError struct {
+	// http status, 200 if the server responds a code other than success, 0 over grpc
+	StatusCode int
+	Code       backuppb.ResponseCode
+	Msg        string
+	RequestID  string
+}
+
+func (e *ResponseError) Error() string {
+	if e.RequestID == "" {
+		return fmt.Sprintf("milvus-backup responds %s: %s", e.Code, e.Msg)
+	}
+	return fmt.Sprintf("milvus-backup responds %s: %s, requestId: %s", e.Code, e.Msg, e.RequestID)
+}
+
+// response is the common part of the responses of the api
+type response interface {
+	GetCode() backuppb.ResponseCode
+	GetMsg() string
+	GetRequestId() string
+}
+
+// checkResponse returns a *ResponseError if the response is not success
+func checkResponse(resp response) error {
+	if resp.GetCode() == backuppb.ResponseCode_Success {
+		return nil
+	}
+	return &ResponseError{StatusCode: http.StatusOK, Code: resp.GetCode(), Msg: resp.GetMsg(), RequestID: resp.GetRequestId()}
+}
+
+type options struct {
+	apiKey      string
+	bearerToken string
+	username    string
+	password    string
+
+	tlsConfig   *tls.Config
+	httpClient  *http.Client
+	dialOptions []grpc.DialOption
+
+	maxAttempts  int
+	retryBackoff time.Duration
+	pollInterval time.Duration
+}
+
+func newDefaultOptions() *options {
+	return &options{
+		maxAttempts:  3,
+		retryBackoff: 200 * time.Millisecond,
+		pollInterval: time.Second,
+	}
+}
+
+// Option configures the client
+type Option func(*options)
+
+// WithAPIKey authenticates by the api key of http.auth.apiKeys of the server
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+// WithBearerToken authenticates by a jwt
+func WithBearerToken(token string) Option {
+	return func(o *options) {
+		o.bearerToken = token
+	}
+}
+
+// WithBasicAuth authenticates by the user of http.auth.users of the server
+func WithBasicAuth(username, password string) Option {
+	return func(o *options) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithTLSConfig connects over tls by the config, like the ca of the server or the client certificate of mtls
+func WithTLSConfig(config *tls.Config) Option {
+	return func(o *options) {
+		o.tlsConfig = config
+	}
+}
+
+// WithHTTPClient sends the requests of the REST client by the http client, WithTLSConfig is ignored if it is set
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.httpClient = client
+	}
+}
+
+// WithDialOptions appends the options of the connection of the grpc client, like interceptors
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
+
+// WithRetry retries the methods which only read up to maxAttempts times in total, the backoff doubles after each attempt
+func WithRetry(maxAttempts int, backoff time.Duration) Option {
+	return func(o *options) {
+		if maxAttempts < 1 {
+			maxAttempts = 1
+		}
+		o.maxAttempts = maxAttempts
+		o.retryBackoff = backoff
+	}
+}
+
+// WithPollInterval is how often WaitJob gets the job over the REST api, grpc streams the progress instead
+func WithPollInterval(interval time.Duration) Option {
+	return func(o *options) {
+		o.pollInterval = interval
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := newDefaultOptions()
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// retry calls fn until it succeeds, attempts run out or the error isn't retryable
+func (o *options) retry(ctx context.Context, retryable func(error) bool, fn func() error) error {
+	backoff := o.retryBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		if err = fn(); err == nil || attempt >= o.maxAttempts || !retryable(err) {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+func isJobEnded(state backuppb.JobStateCode) bool {
+	return state == backuppb.JobStateCode_JOB_SUCCESS ||
+		state == backuppb.JobStateCode_JOB_FAIL ||
+		state == backuppb.JobStateCode_JOB_CANCELED ||
+		state == backuppb.JobStateCode_JOB_INTERRUPTED
+}
+
+// endedJob returns the job with a *ResponseError if it didn't succeed
+func endedJob(resp *backuppb.JobResponse) (*backuppb.JobInfo, error) {
+	job := resp.GetData()
+	if job.GetStateCode() == backuppb.JobStateCode_JOB_SUCCESS {
+		return job, nil
+	}
+	return job, &ResponseError{
+		StatusCode: http.StatusOK,
+		Code:       backuppb.ResponseCode_Fail,
+		Msg:        fmt.Sprintf("job %s ends as %s: %s", job.GetId(), job.GetStateCode(), job.GetErrorMessage()),
+		RequestID:  resp.GetRequestId(),
+	}
+}
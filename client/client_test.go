@@ -0,0 +1,224 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestHTTPClient(t *testing.T) {
+	ctx := context.Background()
+	var mu sync.Mutex
+	var requests []*http.Request
+	jobPolls := 0
+	listFailures := 2
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		requests = append(requests, r)
+		if r.Header.Get("X-API-Key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			json.NewEncoder(w).Encode(map[string]string{"error": "unauthenticated"})
+			return
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "POST /api/v1/create":
+			var request backuppb.CreateBackupRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+			json.NewEncoder(w).Encode(&backuppb.BackupInfoResponse{
+				Code: backuppb.ResponseCode_Success,
+				Data: &backuppb.BackupInfo{Id: "backup1", Name: request.GetBackupName()},
+			})
+		case "GET /api/v1/list":
+			if listFailures > 0 {
+				listFailures--
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			json.NewEncoder(w).Encode(&backuppb.ListBackupsResponse{
+				Code: backuppb.ResponseCode_Success,
+				Data: []*backuppb.BackupInfo{{Name: "b1"}, {Name: "b2"}},
+			})
+		case "DELETE /api/v1/delete":
+			json.NewEncoder(w).Encode(&backuppb.DeleteBackupResponse{
+				Code:      backuppb.ResponseCode_Request_Object_Not_Found,
+				Msg:       "backup not found",
+				RequestId: r.Header.Get("request_id"),
+			})
+		case "GET /api/v1/jobs/backup1":
+			jobPolls++
+			job := &backuppb.JobInfo{Id: "backup1", StateCode: backuppb.JobStateCode_JOB_RUNNING, Progress: int32(jobPolls * 30)}
+			if jobPolls == 3 {
+				job.StateCode = backuppb.JobStateCode_JOB_SUCCESS
+				job.Progress = 100
+			}
+			json.NewEncoder(w).Encode(&backuppb.JobResponse{Code: backuppb.ResponseCode_Success, Data: job})
+		case "GET /api/v1/check":
+			json.NewEncoder(w).Encode("Succeed to connect to milvus and storage.")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	_, err := NewHTTPClient("localhost:8080")
+	assert.Error(t, err)
+	c, err := NewHTTPClient(server.URL, WithAPIKey("key"), WithRetry(3, time.Millisecond), WithPollInterval(time.Millisecond))
+	assert.NoError(t, err)
+	defer c.Close()
+
+	backup, err := c.CreateBackup(ctx, &backuppb.CreateBackupRequest{BackupName: "daily", Async: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "daily", backup.GetData().GetName())
+	assert.Equal(t, "application/json", requests[0].Header.Get("Content-Type"))
+
+	// reads are retried once the server is available again
+	list, err := c.ListBackups(ctx, &backuppb.ListBackupsRequest{
+		CollectionName: "coll",
+		Databases:      []string{"db1", "db2"},
+		States:         []backuppb.BackupTaskStateCode{backuppb.BackupTaskStateCode_BACKUP_SUCCESS},
+		Limit:          10,
+	})
+	assert.NoError(t, err)
+	assert.Len(t, list.GetData(), 2)
+	assert.Len(t, requests, 4)
+	query := requests[3].URL.Query()
+	assert.Equal(t, "coll", query.Get("collection_name"))
+	assert.Equal(t, []string{"db1", "db2"}, query["databases"])
+	assert.Equal(t, []string{"BACKUP_SUCCESS"}, query["states"])
+	assert.Equal(t, "10", query.Get("limit"))
+	assert.False(t, query.Has("offset"))
+
+	// the response is returned with the error if it is not success
+	deleted, err := c.DeleteBackup(ctx, &backuppb.DeleteBackupRequest{RequestId: "req1", BackupName: "daily"})
+	var respErr *ResponseError
+	assert.True(t, errors.As(err, &respErr))
+	assert.Equal(t, http.StatusOK, respErr.StatusCode)
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, respErr.Code)
+	assert.Equal(t, "req1", respErr.RequestID)
+	assert.Equal(t, "backup not found", deleted.GetMsg())
+	assert.Equal(t, "daily", requests[4].URL.Query().Get("backup_name"))
+
+	var progress []int32
+	job, err := c.WaitJob(ctx, "backup1", func(job *backuppb.JobInfo) {
+		progress = append(progress, job.GetProgress())
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.JobStateCode_JOB_SUCCESS, job.GetStateCode())
+	assert.Equal(t, []int32{30, 60, 100}, progress)
+
+	report, err := c.Check(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, "Succeed to connect to milvus and storage.", report)
+
+	unauthenticated, err := NewHTTPClient(server.URL)
+	assert.NoError(t, err)
+	_, err = unauthenticated.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.True(t, errors.As(err, &respErr))
+	assert.Equal(t, http.StatusUnauthorized, respErr.StatusCode)
+	assert.Equal(t, backuppb.ResponseCode_No_Permission, respErr.Code)
+	assert.Equal(t, "unauthenticated", respErr.Msg)
+}
+
+type fakeBackupService struct {
+	backuppb.UnimplementedMilvusBackupServiceServer
+	getJobFailures int
+}
+
+func (s *fakeBackupService) GetJob(ctx context.Context, request *backuppb.GetJobRequest) (*backuppb.JobResponse, error) {
+	if md, _ := metadata.FromIncomingContext(ctx); len(md.Get("x-api-key")) == 0 {
+		return nil, status.Error(codes.Unauthenticated, "unauthenticated")
+	}
+	if s.getJobFailures > 0 {
+		s.getJobFailures--
+		return nil, status.Error(codes.Unavailable, "unavailable")
+	}
+	return &backuppb.JobResponse{Code: backuppb.ResponseCode_Success, Data: &backuppb.JobInfo{Id: request.GetJobId()}}, nil
+}
+
+func (s *fakeBackupService) CancelJob(ctx context.Context, request *backuppb.CancelJobRequest) (*backuppb.JobResponse, error) {
+	return &backuppb.JobResponse{Code: backuppb.ResponseCode_Parameter_Error, Msg: "job can't be canceled"}, nil
+}
+
+func (s *fakeBackupService) WatchJob(request *backuppb.GetJobRequest, stream backuppb.MilvusBackupService_WatchJobServer) error {
+	if request.GetJobId() != "backup1" {
+		return stream.Send(&backuppb.JobResponse{Code: backuppb.ResponseCode_Request_Object_Not_Found, Msg: "job not found"})
+	}
+	for _, progress := range []int32{50, 100} {
+		job := &backuppb.JobInfo{Id: "backup1", StateCode: backuppb.JobStateCode_JOB_RUNNING, Progress: progress}
+		if progress == 100 {
+			job.StateCode = backuppb.JobStateCode_JOB_FAIL
+			job.ErrorMessage = "no space left"
+		}
+		if err := stream.Send(&backuppb.JobResponse{Code: backuppb.ResponseCode_Success, Data: job}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestGRPCClient(t *testing.T) {
+	ctx := context.Background()
+	listener := bufconn.Listen(1024 * 1024)
+	service := &fakeBackupService{getJobFailures: 1}
+	server := grpc.NewServer()
+	backuppb.RegisterMilvusBackupServiceServer(server, service)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	dialer := WithDialOptions(grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }))
+	c, err := NewGRPCClient(ctx, "bufnet", WithAPIKey("key"), WithRetry(3, time.Millisecond), dialer)
+	assert.NoError(t, err)
+	defer c.Close()
+
+	job, err := c.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.NoError(t, err)
+	assert.Equal(t, "backup1", job.GetData().GetId())
+
+	_, err = c.CancelJob(ctx, &backuppb.CancelJobRequest{JobId: "backup1"})
+	var respErr *ResponseError
+	assert.True(t, errors.As(err, &respErr))
+	assert.Equal(t, 0, respErr.StatusCode)
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, respErr.Code)
+
+	var progress []int32
+	ended, err := c.WaitJob(ctx, "backup1", func(job *backuppb.JobInfo) {
+		progress = append(progress, job.GetProgress())
+	})
+	assert.True(t, errors.As(err, &respErr))
+	assert.Contains(t, respErr.Msg, "no space left")
+	assert.Equal(t, backuppb.JobStateCode_JOB_FAIL, ended.GetStateCode())
+	assert.Equal(t, []int32{50, 100}, progress)
+
+	_, err = c.WaitJob(ctx, "not-exist", nil)
+	assert.True(t, errors.As(err, &respErr))
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, respErr.Code)
+
+	_, err = c.GetSchedule(ctx)
+	assert.True(t, errors.As(err, &respErr))
+	assert.Equal(t, backuppb.ResponseCode_Not_Support, respErr.Code)
+	_, err = c.GetRecoveryPoint(ctx)
+	assert.True(t, errors.As(err, &respErr))
+	assert.Equal(t, backuppb.ResponseCode_Not_Support, respErr.Code)
+
+	unauthenticated, err := NewGRPCClient(ctx, "bufnet", dialer)
+	assert.NoError(t, err)
+	defer unauthenticated.Close()
+	_, err = unauthenticated.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
@@ -0,0 +1,249 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+type grpcClient struct {
+	opts    *options
+	conn    *grpc.ClientConn
+	service backuppb.MilvusBackupServiceClient
+}
+
+// NewGRPCClient returns the client of the grpc MilvusBackupService of the server at address, like localhost:50051
+func NewGRPCClient(ctx context.Context, address string, opts ...Option) (Client, error) {
+	o := newOptions(opts)
+	creds := insecure.NewCredentials()
+	if o.tlsConfig != nil {
+		creds = credentials.NewTLS(o.tlsConfig)
+	}
+	options := []grpc.DialOption{
+		grpc.WithTransportCredentials(creds),
+		grpc.WithChainUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, callOpts ...grpc.CallOption) error {
+			return invoker(o.outgoingContext(ctx), method, req, reply, cc, callOpts...)
+		}),
+		grpc.WithChainStreamInterceptor(func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, callOpts ...grpc.CallOption) (grpc.ClientStream, error) {
+			return streamer(o.outgoingContext(ctx), desc, cc, method, callOpts...)
+		}),
+	}
+	conn, err := grpc.DialContext(ctx, address, append(options, o.dialOptions...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &grpcClient{opts: o, conn: conn, service: backuppb.NewMilvusBackupServiceClient(conn)}, nil
+}
+
+// outgoingContext carries the credentials in the metadata, the same as the headers of the REST api
+func (o *options) outgoingContext(ctx context.Context) context.Context {
+	switch {
+	case o.apiKey != "":
+		return metadata.AppendToOutgoingContext(ctx, "x-api-key", o.apiKey)
+	case o.bearerToken != "":
+		return metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+o.bearerToken)
+	case o.username != "":
+		req := &http.Request{Header: http.Header{}}
+		req.SetBasicAuth(o.username, o.password)
+		return metadata.AppendToOutgoingContext(ctx, "authorization", req.Header.Get("Authorization"))
+	}
+	return ctx
+}
+
+func (c *grpcClient) CreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	resp, err := c.service.CreateBackup(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) GetBackup(ctx context.Context, request *backuppb.GetBackupRequest) (resp *backuppb.BackupInfoResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.GetBackup(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) ListBackups(ctx context.Context, request *backuppb.ListBackupsRequest) (resp *backuppb.ListBackupsResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.ListBackups(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) DeleteBackup(ctx context.Context, request *backuppb.DeleteBackupRequest) (*backuppb.DeleteBackupResponse, error) {
+	resp, err := c.service.DeleteBackup(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) PauseBackup(ctx context.Context, request *backuppb.PauseBackupRequest) (*backuppb.PauseBackupResponse, error) {
+	resp, err := c.service.PauseBackup(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	resp, err := c.service.ResumeBackup(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) (*backuppb.PruneBackupsResponse, error) {
+	resp, err := c.service.PruneBackups(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) GarbageCollect(ctx context.Context, request *backuppb.GarbageCollectRequest) (*backuppb.GarbageCollectResponse, error) {
+	resp, err := c.service.GarbageCollect(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) VerifyBackup(ctx context.Context, request *backuppb.VerifyBackupRequest) (resp *backuppb.VerifyBackupResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.VerifyBackup(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) EstimateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (resp *backuppb.EstimateBackupResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.EstimateBackup(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) RestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest) (*backuppb.RestoreBackupResponse, error) {
+	resp, err := c.service.RestoreBackup(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) GetRestore(ctx context.Context, request *backuppb.GetRestoreStateRequest) (resp *backuppb.RestoreBackupResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.GetRestore(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) GetJob(ctx context.Context, request *backuppb.GetJobRequest) (resp *backuppb.JobResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.GetJob(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) CancelJob(ctx context.Context, request *backuppb.CancelJobRequest) (*backuppb.JobResponse, error) {
+	resp, err := c.service.CancelJob(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) ListJobs(ctx context.Context, request *backuppb.ListJobsRequest) (resp *backuppb.ListJobsResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.ListJobs(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) ResumeJob(ctx context.Context, request *backuppb.ResumeJobRequest) (*backuppb.JobResponse, error) {
+	resp, err := c.service.ResumeJob(ctx, request)
+	return resp, grpcError(resp, err)
+}
+
+func (c *grpcClient) GetSchedule(ctx context.Context) (*backuppb.GetScheduleResponse, error) {
+	return nil, &ResponseError{Code: backuppb.ResponseCode_Not_Support, Msg: "schedule is only served by the REST api"}
+}
+
+func (c *grpcClient) GetRecoveryPoint(ctx context.Context) (*backuppb.GetRecoveryPointResponse, error) {
+	return nil, &ResponseError{Code: backuppb.ResponseCode_Not_Support, Msg: "recovery point is only served by the REST api"}
+}
+
+func (c *grpcClient) Check(ctx context.Context) (string, error) {
+	var resp *backuppb.CheckResponse
+	err := c.opts.retry(ctx, isRetryableGRPC, func() (err error) {
+		resp, err = c.service.Check(ctx, &backuppb.CheckRequest{})
+		return err
+	})
+	return resp.GetMsg(), err
+}
+
+func (c *grpcClient) CheckCompatibility(ctx context.Context, request *backuppb.CheckCompatibilityRequest) (resp *backuppb.CheckCompatibilityResponse, err error) {
+	err = c.opts.retry(ctx, isRetryableGRPC, func() error {
+		resp, err = c.service.CheckCompatibility(ctx, request)
+		return err
+	})
+	return resp, grpcError(resp, err)
+}
+
+// WaitJob follows the progress streamed by WatchJob, the stream is opened again if the server is unavailable
+func (c *grpcClient) WaitJob(ctx context.Context, jobID string, onProgress func(*backuppb.JobInfo)) (*backuppb.JobInfo, error) {
+	var last *backuppb.JobResponse
+	err := c.opts.retry(ctx, isRetryableGRPC, func() error {
+		stream, err := c.service.WatchJob(ctx, &backuppb.GetJobRequest{JobId: jobID})
+		if err != nil {
+			return err
+		}
+		for {
+			resp, err := stream.Recv()
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			last = resp
+			if resp.GetCode() != backuppb.ResponseCode_Success {
+				return nil
+			}
+			if onProgress != nil {
+				onProgress(resp.GetData())
+			}
+		}
+	})
+	if err != nil {
+		return last.GetData(), err
+	}
+	if last == nil {
+		return nil, &ResponseError{Code: backuppb.ResponseCode_Fail, Msg: "job stream of " + jobID + " ends without response"}
+	}
+	if err := grpcError(last, nil); err != nil {
+		return nil, err
+	}
+	return endedJob(last)
+}
+
+func (c *grpcClient) Close() error {
+	return c.conn.Close()
+}
+
+// grpcError returns err of the call, or a *ResponseError if the response is not success
+func grpcError(resp response, err error) error {
+	if err != nil {
+		return err
+	}
+	if err := checkResponse(resp); err != nil {
+		err.(*ResponseError).StatusCode = 0
+		return err
+	}
+	return nil
+}
+
+// isRetryableGRPC returns whether the call may succeed if sent again: the server is unreachable or overloaded
+func isRetryableGRPC(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
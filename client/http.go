@@ -0,0 +1,364 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// apiPrefix is the prefix of the REST api of the server
+const apiPrefix = "/api/v1"
+
+type httpClient struct {
+	opts    *options
+	baseURL string
+	client  *http.Client
+}
+
+// NewHTTPClient returns the client of the REST api of the server at address, like http://localhost:8080
+func NewHTTPClient(address string, opts ...Option) (Client, error) {
+	o := newOptions(opts)
+	u, err := url.Parse(address)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return nil, fmt.Errorf("invalid address %s, should start with http:// or https://", address)
+	}
+	client := o.httpClient
+	if client == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = o.tlsConfig
+		client = &http.Client{Transport: transport}
+	}
+	return &httpClient{
+		opts:    o,
+		baseURL: strings.TrimSuffix(address, "/") + apiPrefix,
+		client:  client,
+	}, nil
+}
+
+func (c *httpClient) CreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	resp := &backuppb.BackupInfoResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/create", nil, request, resp, false)
+}
+
+func (c *httpClient) GetBackup(ctx context.Context, request *backuppb.GetBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	query := url.Values{}
+	setQuery(query, "backup_name", request.GetBackupName())
+	setQuery(query, "backup_id", request.GetBackupId())
+	resp := &backuppb.BackupInfoResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/get_backup", query, request, resp, true)
+}
+
+func (c *httpClient) ListBackups(ctx context.Context, request *backuppb.ListBackupsRequest) (*backuppb.ListBackupsResponse, error) {
+	query := url.Values{}
+	setQuery(query, "collection_name", request.GetCollectionName())
+	query["databases"] = request.GetDatabases()
+	setQuery(query, "order_by", request.GetOrderBy())
+	setQueryInt(query, "start_time", request.GetStartTime())
+	setQueryInt(query, "end_time", request.GetEndTime())
+	setQueryInt(query, "offset", int64(request.GetOffset()))
+	setQueryInt(query, "limit", int64(request.GetLimit()))
+	setQueryBool(query, "desc", request.GetDesc())
+	setQueryBool(query, "without_detail", request.GetWithoutDetail())
+	for _, state := range request.GetStates() {
+		query.Add("states", state.String())
+	}
+	resp := &backuppb.ListBackupsResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/list", query, request, resp, true)
+}
+
+func (c *httpClient) DeleteBackup(ctx context.Context, request *backuppb.DeleteBackupRequest) (*backuppb.DeleteBackupResponse, error) {
+	query := url.Values{}
+	setQuery(query, "backup_name", request.GetBackupName())
+	setQueryBool(query, "force", request.GetForce())
+	resp := &backuppb.DeleteBackupResponse{}
+	return resp, c.call(ctx, http.MethodDelete, "/delete", query, request, resp, false)
+}
+
+func (c *httpClient) PauseBackup(ctx context.Context, request *backuppb.PauseBackupRequest) (*backuppb.PauseBackupResponse, error) {
+	resp := &backuppb.PauseBackupResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/pause", nil, request, resp, false)
+}
+
+func (c *httpClient) ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	resp := &backuppb.BackupInfoResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/resume", nil, request, resp, false)
+}
+
+func (c *httpClient) PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) (*backuppb.PruneBackupsResponse, error) {
+	query := url.Values{}
+	setQueryBool(query, "dry_run", request.GetDryRun())
+	resp := &backuppb.PruneBackupsResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/prune", query, request, resp, false)
+}
+
+func (c *httpClient) GarbageCollect(ctx context.Context, request *backuppb.GarbageCollectRequest) (*backuppb.GarbageCollectResponse, error) {
+	query := url.Values{}
+	setQueryBool(query, "dry_run", request.GetDryRun())
+	resp := &backuppb.GarbageCollectResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/gc", query, request, resp, false)
+}
+
+func (c *httpClient) VerifyBackup(ctx context.Context, request *backuppb.VerifyBackupRequest) (*backuppb.VerifyBackupResponse, error) {
+	query := url.Values{}
+	setQuery(query, "backup_name", request.GetBackupName())
+	resp := &backuppb.VerifyBackupResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/verify", query, request, resp, true)
+}
+
+func (c *httpClient) EstimateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.EstimateBackupResponse, error) {
+	resp := &backuppb.EstimateBackupResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/estimate", nil, request, resp, true)
+}
+
+func (c *httpClient) RestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest) (*backuppb.RestoreBackupResponse, error) {
+	resp := &backuppb.RestoreBackupResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/restore", nil, request, resp, false)
+}
+
+func (c *httpClient) GetRestore(ctx context.Context, request *backuppb.GetRestoreStateRequest) (*backuppb.RestoreBackupResponse, error) {
+	query := url.Values{}
+	setQuery(query, "id", request.GetId())
+	resp := &backuppb.RestoreBackupResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/get_restore", query, request, resp, true)
+}
+
+func (c *httpClient) GetJob(ctx context.Context, request *backuppb.GetJobRequest) (*backuppb.JobResponse, error) {
+	resp := &backuppb.JobResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/jobs/"+url.PathEscape(request.GetJobId()), nil, request, resp, true)
+}
+
+func (c *httpClient) CancelJob(ctx context.Context, request *backuppb.CancelJobRequest) (*backuppb.JobResponse, error) {
+	resp := &backuppb.JobResponse{}
+	return resp, c.call(ctx, http.MethodDelete, "/jobs/"+url.PathEscape(request.GetJobId()), nil, request, resp, false)
+}
+
+func (c *httpClient) ListJobs(ctx context.Context, request *backuppb.ListJobsRequest) (*backuppb.ListJobsResponse, error) {
+	query := url.Values{}
+	setQuery(query, "type", request.GetType())
+	resp := &backuppb.ListJobsResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/jobs", query, request, resp, true)
+}
+
+func (c *httpClient) ResumeJob(ctx context.Context, request *backuppb.ResumeJobRequest) (*backuppb.JobResponse, error) {
+	resp := &backuppb.JobResponse{}
+	return resp, c.call(ctx, http.MethodPost, "/jobs/"+url.PathEscape(request.GetJobId())+"/resume", nil, request, resp, false)
+}
+
+func (c *httpClient) GetSchedule(ctx context.Context) (*backuppb.GetScheduleResponse, error) {
+	resp := &backuppb.GetScheduleResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/schedule", nil, nil, resp, true)
+}
+
+func (c *httpClient) GetRecoveryPoint(ctx context.Context) (*backuppb.GetRecoveryPointResponse, error) {
+	resp := &backuppb.GetRecoveryPointResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/recovery_point", nil, nil, resp, true)
+}
+
+func (c *httpClient) Check(ctx context.Context) (string, error) {
+	var report string
+	err := c.opts.retry(ctx, isRetryableHTTP, func() error {
+		return c.do(ctx, http.MethodGet, "/check", nil, nil, &report)
+	})
+	return report, err
+}
+
+func (c *httpClient) CheckCompatibility(ctx context.Context, request *backuppb.CheckCompatibilityRequest) (*backuppb.CheckCompatibilityResponse, error) {
+	query := url.Values{}
+	setQuery(query, "backup_name", request.GetBackupName())
+	setQuery(query, "target_version", request.GetTargetVersion())
+	setQuery(query, "bucket_name", request.GetBucketName())
+	setQuery(query, "path", request.GetPath())
+	setQuery(query, "collection_names", strings.Join(request.GetCollectionNames(), ","))
+	setQuery(query, "target_db_name", request.GetTargetDbName())
+	resp := &backuppb.CheckCompatibilityResponse{}
+	return resp, c.call(ctx, http.MethodGet, "/check_compatibility", query, request, resp, true)
+}
+
+func (c *httpClient) WaitJob(ctx context.Context, jobID string, onProgress func(*backuppb.JobInfo)) (*backuppb.JobInfo, error) {
+	ticker := time.NewTicker(c.opts.pollInterval)
+	defer ticker.Stop()
+	var last *backuppb.JobInfo
+	for {
+		resp, err := c.GetJob(ctx, &backuppb.GetJobRequest{JobId: jobID})
+		if err != nil {
+			return nil, err
+		}
+		if onProgress != nil && !proto.Equal(last, resp.GetData()) {
+			onProgress(resp.GetData())
+		}
+		last = resp.GetData()
+		if isJobEnded(last.GetStateCode()) {
+			return endedJob(resp)
+		}
+		select {
+		case <-ctx.Done():
+			return last, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *httpClient) Close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
+
+// call sends the request as the json body of POST or by the query of the others, and decodes the response,
+// the sse customer key of verify and the request id are sent in headers as the server expects
+func (c *httpClient) call(ctx context.Context, method, path string, query url.Values, request proto.Message, resp response, idempotent bool) error {
+	var body interface{}
+	if method == http.MethodPost && request != nil {
+		body = request
+	}
+	send := func() error {
+		// fields of a failed attempt are not kept
+		resp.(proto.Message).Reset()
+		return c.do(ctx, method, path, query, body, resp, headersOf(request)...)
+	}
+	var err error
+	if idempotent {
+		err = c.opts.retry(ctx, isRetryableHTTP, send)
+	} else {
+		err = send()
+	}
+	if err != nil {
+		return err
+	}
+	return checkResponse(resp)
+}
+
+// httpHeader is a header of a request
+type httpHeader struct {
+	key   string
+	value string
+}
+
+func headersOf(request interface{}) []httpHeader {
+	var headers []httpHeader
+	if r, ok := request.(interface{ GetRequestId() string }); ok && r.GetRequestId() != "" {
+		headers = append(headers, httpHeader{"request_id", r.GetRequestId()})
+	}
+	if r, ok := request.(interface{ GetSseCustomerKey() string }); ok && r.GetSseCustomerKey() != "" {
+		headers = append(headers, httpHeader{"sse_customer_key", r.GetSseCustomerKey()})
+	}
+	return headers
+}
+
+func (c *httpClient) do(ctx context.Context, method, path string, query url.Values, body interface{}, out interface{}, headers ...httpHeader) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+	u := c.baseURL + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, reader)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, header := range headers {
+		req.Header.Set(header.key, header.value)
+	}
+	c.authenticate(req)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return statusError(resp.StatusCode, data)
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (c *httpClient) authenticate(req *http.Request) {
+	switch {
+	case c.opts.apiKey != "":
+		req.Header.Set("X-API-Key", c.opts.apiKey)
+	case c.opts.bearerToken != "":
+		req.Header.Set("Authorization", "Bearer "+c.opts.bearerToken)
+	case c.opts.username != "":
+		req.SetBasicAuth(c.opts.username, c.opts.password)
+	}
+}
+
+// statusError is the *ResponseError of a response other than 200, like an invalid request or a rejected caller
+func statusError(statusCode int, body []byte) error {
+	var errBody struct {
+		Error string `json:"error"`
+	}
+	msg := strings.TrimSpace(string(body))
+	if json.Unmarshal(body, &errBody) == nil && errBody.Error != "" {
+		msg = errBody.Error
+	}
+	code := backuppb.ResponseCode_Fail
+	switch statusCode {
+	case http.StatusBadRequest:
+		code = backuppb.ResponseCode_Parameter_Error
+	case http.StatusUnauthorized, http.StatusForbidden:
+		code = backuppb.ResponseCode_No_Permission
+	case http.StatusNotFound:
+		code = backuppb.ResponseCode_Request_Object_Not_Found
+	}
+	return &ResponseError{StatusCode: statusCode, Code: code, Msg: msg}
+}
+
+// isRetryableHTTP returns whether the request may succeed if sent again: the server is unreachable or overloaded
+func isRetryableHTTP(err error) bool {
+	var respErr *ResponseError
+	if !errors.As(err, &respErr) {
+		// canceled by the caller
+		return !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+	switch respErr.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+func setQuery(query url.Values, key, value string) {
+	if value != "" {
+		query.Set(key, value)
+	}
+}
+
+func setQueryInt(query url.Values, key string, value int64) {
+	if value != 0 {
+		query.Set(key, strconv.FormatInt(value, 10))
+	}
+}
+
+func setQueryBool(query url.Values, key string, value bool) {
+	if value {
+		query.Set(key, "true")
+	}
+}
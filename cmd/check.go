@@ -3,30 +3,113 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/zilliztech/milvus-backup/core"
 	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	checkBackupName      string
+	checkTargetVersion   string
+	checkCollectionNames string
+	checkTargetDatabase  string
 )
 
 var checkCmd = &cobra.Command{
 	Use:   "check",
-	Short: "check if the connects is right.",
+	Short: "check the connection to milvus and the endpoint, tls, credentials, region and permissions of the milvus and backup buckets, or if a backup can be restored into the target milvus version with --name.",
 
 	Run: func(cmd *cobra.Command, args []string) {
 		var params paramtable.BackupParams
-		fmt.Println("config:" + config)
+		printText("config:" + config)
 		params.GlobalInitWithYaml(config)
 		params.Init()
 
 		context := context.Background()
 		backupContext := core.CreateBackupContext(context, params)
 
-		resp := backupContext.Check(context)
-		fmt.Println(resp)
+		if checkBackupName != "" {
+			checkCompatibility(context, backupContext)
+			return
+		}
+		if checkTargetVersion != "" {
+			printError("--target should be set with the backup to check by --name")
+			return
+		}
+
+		resp := backupContext.CheckConnections(context)
+		if jsonOutput() {
+			printJSONResult(resp)
+		} else {
+			fmt.Println(resp.GetMsg())
+		}
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			os.Exit(1)
+		}
 	},
 }
 
+// checkCompatibility reports the features of the backup the target milvus doesn't support, it exits with 1 if any
+// collection can't be restored even with --downgrade_features
+func checkCompatibility(ctx context.Context, backupContext *core.BackupContext) {
+	request := &backuppb.CheckCompatibilityRequest{
+		BackupName:    checkBackupName,
+		TargetVersion: checkTargetVersion,
+		TargetDbName:  checkTargetDatabase,
+	}
+	if checkCollectionNames != "" {
+		request.CollectionNames = strings.Split(checkCollectionNames, ",")
+	}
+	resp := backupContext.CheckCompatibility(ctx, request)
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		printResponse(resp)
+		os.Exit(1)
+	}
+	downgradable := true
+	for _, issue := range resp.GetIssues() {
+		downgradable = downgradable && issue.GetDowngradable()
+	}
+	if jsonOutput() {
+		printJSON(resp)
+	} else {
+		fmt.Println("target milvus: " + resp.GetTargetVersion())
+		for _, issue := range resp.GetIssues() {
+			fmt.Println(formatCompatIssue(issue))
+		}
+		switch {
+		case len(resp.GetIssues()) == 0:
+			fmt.Println("compatible")
+		case downgradable:
+			fmt.Println("compatible with --downgrade_features, the features above are not restored")
+		default:
+			fmt.Println("incompatible")
+		}
+	}
+	if !downgradable {
+		os.Exit(1)
+	}
+}
+
+// formatCompatIssue formats a feature not supported by the target milvus in a line
+func formatCompatIssue(issue *backuppb.CompatIssue) string {
+	mode := "incompatible"
+	if issue.GetDowngradable() {
+		mode = "downgradable"
+	}
+	return fmt.Sprintf("%s: %s since %s, %s, %s", issue.GetCollection(), issue.GetFeature(), issue.GetSinceVersion(), mode, issue.GetDetail())
+}
+
 func init() {
+	checkCmd.Flags().StringVarP(&checkBackupName, "name", "n", "", "backup to check against the target milvus version, only the connections are checked if unset")
+	checkCmd.Flags().StringVarP(&checkTargetVersion, "target", "", "", "milvus version to check the backup against, like v2.3.5, the version of the connected milvus if unset")
+	checkCmd.Flags().StringVarP(&checkCollectionNames, "collections", "c", "", "collections of the backup to check, use ',' to connect multiple collections, all collections if unset")
+	checkCmd.Flags().StringVarP(&checkTargetDatabase, "target_database", "", "", "database the collections are restored into, their original databases if unset")
+	checkCmd.Flags().SortFlags = false
+	checkCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+
 	rootCmd.AddCommand(checkCmd)
 }
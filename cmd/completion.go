@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+var completionCmd = &cobra.Command{
+	Use:   "completion [bash|zsh|fish]",
+	Short: "completion subcommand generate the autocompletion script of milvus-backup for the shell.",
+	Long: `completion subcommand generate the autocompletion script of milvus-backup for the shell.
+
+Backup names and collection names are completed by querying the backup storage and milvus of --config.
+
+bash:
+  source <(./milvus-backup completion bash)
+
+zsh:
+  ./milvus-backup completion zsh > "${fpath[1]}/_milvus-backup"
+
+fish:
+  ./milvus-backup completion fish > ~/.config/fish/completions/milvus-backup.fish
+`,
+	ValidArgs:             []string{"bash", "zsh", "fish"},
+	Args:                  cobra.ExactValidArgs(1),
+	DisableFlagsInUseLine: true,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		switch args[0] {
+		case "bash":
+			cmd.Root().GenBashCompletionV2(os.Stdout, true)
+		case "zsh":
+			cmd.Root().GenZshCompletion(os.Stdout)
+		case "fish":
+			cmd.Root().GenFishCompletion(os.Stdout, true)
+		}
+	},
+}
+
+// completionContext creates the backup context of --config to query the completions.
+// Console logs are written to stderr, as the shell reads the completions from stdout.
+func completionContext() (context.Context, *core.BackupContext) {
+	log.SetConsoleOutput("stderr")
+	var params paramtable.BackupParams
+	params.GlobalInitWithYaml(config)
+	params.Init()
+
+	ctx := context.Background()
+	return ctx, core.CreateBackupContext(ctx, params)
+}
+
+// completeBackupNames completes the names of backups in the backup storage
+func completeBackupNames(cmd *cobra.Command, args []string, toComplete string) (names []string, directive cobra.ShellCompDirective) {
+	// the context panics if milvus or storage can't be connected, no completion is given then
+	defer func() {
+		if recover() != nil {
+			names, directive = nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}()
+	ctx, backupContext := completionContext()
+	resp := backupContext.ListBackups(ctx, &backuppb.ListBackupsRequest{WithoutDetail: true})
+	for _, backup := range resp.GetData() {
+		if strings.HasPrefix(backup.GetName(), toComplete) {
+			names = append(names, backup.GetName())
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeBackupNameArg completes the backup name given as the only argument
+func completeBackupNameArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeBackupNames(cmd, args, toComplete)
+}
+
+// completeCollectionNames completes the collections in milvus, for flags of collections connected by ','
+func completeCollectionNames(cmd *cobra.Command, args []string, toComplete string) (names []string, directive cobra.ShellCompDirective) {
+	defer func() {
+		if recover() != nil {
+			names, directive = nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}()
+	ctx, backupContext := completionContext()
+	collections, err := backupContext.ListCollectionNames(ctx)
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeList(collections, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeBackupCollectionNames completes the collections in the backup of the name flag, for restore
+func completeBackupCollectionNames(cmd *cobra.Command, args []string, toComplete string) (names []string, directive cobra.ShellCompDirective) {
+	defer func() {
+		if recover() != nil {
+			names, directive = nil, cobra.ShellCompDirectiveNoFileComp
+		}
+	}()
+	backupName, _ := cmd.Flags().GetString("name")
+	if backupName == "" {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	ctx, backupContext := completionContext()
+	resp := backupContext.GetBackup(ctx, &backuppb.GetBackupRequest{BackupName: backupName, WithoutDetail: true})
+	collections := make([]string, 0)
+	for _, collection := range resp.GetData().GetCollectionBackups() {
+		if collection.GetDbName() == "" || collection.GetDbName() == "default" {
+			collections = append(collections, collection.GetCollectionName())
+		} else {
+			collections = append(collections, collection.GetDbName()+"."+collection.GetCollectionName())
+		}
+	}
+	return completeList(collections, toComplete), cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeList completes the last item of a list connected by ',', items already in the list are skipped
+func completeList(candidates []string, toComplete string) []string {
+	prefix, last := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, last = toComplete[:i+1], toComplete[i+1:]
+	}
+	chosen := make(map[string]bool)
+	for _, item := range strings.Split(prefix, ",") {
+		chosen[item] = true
+	}
+	completions := make([]string, 0)
+	for _, candidate := range candidates {
+		if strings.HasPrefix(candidate, last) && !chosen[candidate] {
+			completions = append(completions, prefix+candidate)
+		}
+	}
+	return completions
+}
+
+func init() {
+	rootCmd.AddCommand(completionCmd)
+}
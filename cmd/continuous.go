@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+var (
+	continuousPort string
+)
+
+var continuousCmd = &cobra.Command{
+	Use:   "continuous",
+	Short: "continuous subcommand start milvus-backup RESTAPI server, keep a chain of incremental backups by the continuous section of config and serve the recovery point.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		fmt.Println("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		server, err := core.NewServer(context, params, core.Port(continuousPort), core.Continuous(true))
+		if err != nil {
+			fmt.Println("fail to create backup server, " + err.Error())
+			return
+		}
+		server.Init()
+		server.Start()
+	},
+}
+
+func init() {
+	continuousCmd.Flags().StringVarP(&continuousPort, "port", "p", "8080", "Port to listen")
+
+	rootCmd.AddCommand(continuousCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+var (
+	controllerPort string
+)
+
+var controllerCmd = &cobra.Command{
+	Use:   "controller",
+	Short: "controller subcommand start milvus-backup RESTAPI server in kubernetes and run the backups of the MilvusBackup and MilvusBackupSchedule objects.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		fmt.Println("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		server, err := core.NewServer(context, params, core.Port(controllerPort), core.Controller(true))
+		if err != nil {
+			fmt.Println("fail to create backup server, " + err.Error())
+			return
+		}
+		server.Init()
+		server.Start()
+	},
+}
+
+func init() {
+	controllerCmd.Flags().StringVarP(&controllerPort, "port", "p", "8080", "Port to listen")
+
+	rootCmd.AddCommand(controllerCmd)
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
@@ -20,7 +21,34 @@ var (
 	databases       string
 	dbCollections   string
 	force           bool
+	flushPolicy     string
+	flushTimeout    int32
 	metaOnly        bool
+	incremental     bool
+	baseBackupName  string
+	compression     string
+	resume          bool
+	rbac            bool
+	etcdSnapshot    bool
+	collectionRegex string
+	partitions      []string
+	createDryRun    bool
+	sseType         string
+	sseKmsKeyId     string
+	sseCustomerKey  string
+	allowPartial    bool
+	nameTemplate    string
+	labels          string
+	forceUnlock     bool
+	checkRowCount   bool
+	sampleRows      int32
+	scanBinlogs     bool
+)
+
+// exit codes of create when the backup fails, or is partial with some collections failed by --allow_partial
+const (
+	exitCodeFail    = 1
+	exitCodePartial = 2
 )
 
 var createBackupCmd = &cobra.Command{
@@ -29,7 +57,7 @@ var createBackupCmd = &cobra.Command{
 
 	Run: func(cmd *cobra.Command, args []string) {
 		var params paramtable.BackupParams
-		fmt.Println("config:" + config)
+		printText("config:" + config)
 		params.GlobalInitWithYaml(config)
 		params.Init()
 
@@ -53,33 +81,118 @@ var createBackupCmd = &cobra.Command{
 			completeDbCollections, err := jsoniter.MarshalToString(dbCollectionDict)
 			dbCollections = completeDbCollections
 			if err != nil {
-				fmt.Println("illegal databases input")
+				printError("illegal databases input")
 				return
 			}
 		}
-		resp := backupContext.CreateBackup(context, &backuppb.CreateBackupRequest{
+		partitionDict, err := parsePartitions(partitions)
+		if err != nil {
+			printError(err.Error())
+			return
+		}
+		labelDict, err := parseLabels(labels)
+		if err != nil {
+			printError(err.Error())
+			return
+		}
+		request := &backuppb.CreateBackupRequest{
 			BackupName:      backupName,
 			CollectionNames: collectionNameArr,
 			DbCollections:   utils.WrapDBCollections(dbCollections),
 			Force:           force,
+			FlushPolicy:     flushPolicy,
+			FlushTimeout:    flushTimeout,
 			MetaOnly:        metaOnly,
-		})
+			Incremental:     incremental,
+			BaseBackupName:  baseBackupName,
+			Compression:     compression,
+			Resume:          resume,
+			Rbac:            rbac,
+			EtcdSnapshot:    etcdSnapshot,
+			CollectionRegex: collectionRegex,
+			Partitions:      partitionDict,
+			SseType:         sseType,
+			SseKmsKeyId:     sseKmsKeyId,
+			SseCustomerKey:  sseCustomerKey,
+			AllowPartial:    allowPartial,
+			NameTemplate:    nameTemplate,
+			Labels:          labelDict,
+			ForceUnlock:     forceUnlock,
+			CheckRowCount:   checkRowCount,
+			SampleRows:      sampleRows,
+			ScanBinlogs:     scanBinlogs,
+		}
 
-		fmt.Println(resp.GetMsg())
-		duration := time.Now().Unix() - start
-		fmt.Println(fmt.Sprintf("duration:%d s", duration))
+		if createDryRun {
+			estimateResp := backupContext.EstimateBackup(context, request)
+			printJSON(estimateResp)
+			if estimateResp.GetCode() != backuppb.ResponseCode_Success && jsonOutput() {
+				os.Exit(1)
+			}
+			return
+		}
+
+		// executed asynchronously to show the progress
+		request.Async = true
+		resp := backupContext.CreateBackup(context, request)
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			printResponse(resp)
+			return
+		}
+		job := waitJob(context, backupContext, resp.GetJobId())
+		result := backupJobResult(context, backupContext, job)
+		if jsonOutput() {
+			printJSON(result)
+		} else {
+			printJobResult(job)
+			printRowCountMismatches(core.BackupRowCountMismatches(result.GetData()))
+			printBinlogScanFindings(core.BackupBinlogScanFindings(result.GetData()))
+			printRunSummaryOf(context, backupContext, result.GetData().GetName(), "")
+			duration := time.Now().Unix() - start
+			fmt.Println(fmt.Sprintf("duration:%d s", duration))
+		}
+		switch {
+		case result.GetData().GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_PARTIAL:
+			os.Exit(exitCodePartial)
+		case result.GetCode() != backuppb.ResponseCode_Success:
+			os.Exit(exitCodeFail)
+		}
 	},
 }
 
 func init() {
 	createBackupCmd.Flags().StringVarP(&backupName, "name", "n", "", "backup name, if unset will generate a name automatically")
-	createBackupCmd.Flags().StringVarP(&collectionNames, "colls", "c", "", "collectionNames to backup, use ',' to connect multiple collections")
+	createBackupCmd.Flags().StringVarP(&nameTemplate, "name_template", "", "", "template of the name generated if --name is unset, support {{date}}, {{time}} and {{cluster}}, like 'nightly-{{date}}-{{cluster}}', if unset will use backup.nameTemplate in config")
+	createBackupCmd.Flags().StringVarP(&labels, "labels", "l", "", "key/value labels attached to the backup, like 'env=prod,app=search'")
+	createBackupCmd.Flags().StringVarP(&collectionNames, "colls", "c", "", "collectionNames to backup, use ',' to connect multiple collections, support wildcard patterns like 'prod_*'")
+	createBackupCmd.Flags().StringVarP(&collectionRegex, "colls_regex", "", "", "backup collections of all databases whose names match the regex")
+	createBackupCmd.Flags().StringArrayVarP(&partitions, "partitions", "p", []string{}, "partitions to backup, format: collection:partition1,partition2, can be set more than once for multiple collections")
 	createBackupCmd.Flags().StringVarP(&databases, "databases", "d", "", "databases to backup")
 	createBackupCmd.Flags().StringVarP(&dbCollections, "database_collections", "a", "", "databases and collections to backup, json format: {\"db1\":[\"c1\", \"c2\"],\"db2\":[]}")
 	createBackupCmd.Flags().BoolVarP(&force, "force", "f", false, "force backup, will skip flush, should make sure data has been stored into disk when using it")
+	createBackupCmd.Flags().StringVarP(&flushPolicy, "flush", "", "", "flush policy before backup, support wait, skip and timeout, wait by default, skip if force is set")
+	createBackupCmd.Flags().Int32VarP(&flushTimeout, "flush_timeout", "", 0, "seconds to wait for the flush of each collection with flush policy timeout, only the data already persisted is backed up if it times out")
 	createBackupCmd.Flags().BoolVarP(&metaOnly, "meta_only", "", false, "only backup collection meta instead of data")
+	createBackupCmd.Flags().BoolVarP(&incremental, "incremental", "", false, "incremental backup, only copy segments added or compacted since the base backup")
+	createBackupCmd.Flags().StringVarP(&baseBackupName, "base", "", "", "base backup name of incremental backup")
+	createBackupCmd.Flags().StringVarP(&compression, "compression", "", "", "compress binlogs while copying, support zstd and gzip, if unset will use backup.compression in config")
+	createBackupCmd.Flags().BoolVarP(&resume, "resume", "", false, "resume the interrupted backup with the given name, segments copied before will be skipped")
+
+	createBackupCmd.Flags().BoolVarP(&rbac, "rbac", "", false, "backup users, roles and grants as well")
+	createBackupCmd.Flags().BoolVarP(&etcdSnapshot, "etcd_snapshot", "", false, "snapshot the etcd meta of the collections after they are flushed, read from etcd in config")
+	createBackupCmd.Flags().BoolVarP(&checkRowCount, "check_row_count", "", false, "compare the rows of each partition in milvus after the flush with the rows backed up, mismatches are printed")
+	createBackupCmd.Flags().Int32VarP(&sampleRows, "sample_rows", "", 0, "rows to sample at random from each loaded collection into backup, to verify a restore by restore --verify_sample_rows")
+	createBackupCmd.Flags().BoolVarP(&scanBinlogs, "scan_binlogs", "", false, "scan the binlogs of each collection in the bucket of milvus after the flush, binlogs of segments not persistent and persistent segments without binlogs are printed")
+	createBackupCmd.Flags().StringVarP(&sseType, "sse_type", "", "", "server side encryption of backup files, support kms, customer and none, if unset will use backup.serverSideEncryption.type in config")
+	createBackupCmd.Flags().StringVarP(&sseKmsKeyId, "sse_kms_key_id", "", "", "kms key of sse type kms, aws kms key id or arn, or cloud kms key name for gcs")
+	createBackupCmd.Flags().StringVarP(&sseCustomerKey, "sse_customer_key", "", "", "customer key of sse type customer, base64 or hex encoded 32 bytes, or reference like env:NAME and file:PATH")
+	createBackupCmd.Flags().BoolVarP(&allowPartial, "allow_partial", "", false, "a failed collection doesn't fail the others, the backup is partial and exits with code 2 if only some collections fail")
+	createBackupCmd.Flags().BoolVarP(&forceUnlock, "force_unlock", "", false, "take over the locks of the cluster and the backup name held by another process, only use it if the process is known to be gone")
+	createBackupCmd.Flags().BoolVarP(&createDryRun, "dry_run", "", false, "only list the collections to backup with their segment numbers and sizes, nothing is written")
 
 	createBackupCmd.Flags().SortFlags = false
+	createBackupCmd.RegisterFlagCompletionFunc("colls", completeCollectionNames)
+	createBackupCmd.RegisterFlagCompletionFunc("base", completeBackupNames)
 
 	rootCmd.AddCommand(createBackupCmd)
 }
@@ -3,6 +3,7 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/zilliztech/milvus-backup/core"
@@ -12,6 +13,7 @@ import (
 
 var (
 	deleteBackName string
+	deleteForce    bool
 )
 
 var deleteBackupCmd = &cobra.Command{
@@ -26,16 +28,31 @@ var deleteBackupCmd = &cobra.Command{
 		context := context.Background()
 		backupContext := core.CreateBackupContext(context, params)
 
+		prompt := "delete backup %s?"
+		if deleteForce {
+			prompt = "delete backup %s and the incremental backups referencing it?"
+		}
+		if !confirm(prompt, deleteBackName) {
+			printCanceled()
+			return
+		}
 		resp := backupContext.DeleteBackup(context, &backuppb.DeleteBackupRequest{
 			BackupName: deleteBackName,
+			Force:      deleteForce,
 		})
 
-		fmt.Println(resp.GetMsg())
+		if resp.GetCode() != backuppb.ResponseCode_Success || jsonOutput() {
+			printResponse(resp)
+			return
+		}
+		fmt.Println("deleted backups: " + strings.Join(resp.GetDeletedBackups(), ", "))
 	},
 }
 
 func init() {
 	deleteBackupCmd.Flags().StringVarP(&deleteBackName, "name", "n", "", "get backup with this name")
+	deleteBackupCmd.Flags().BoolVarP(&deleteForce, "force", "f", false, "delete the incremental backups referencing this backup as well")
+	deleteBackupCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
 
 	rootCmd.AddCommand(deleteBackupCmd)
 }
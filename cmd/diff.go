@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var diffChangedOnly bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <from_backup_name> <to_backup_name>",
+	Short: "diff subcommand compare two backups by collections added and removed, schema changes, segments, rows and sizes.",
+	Args:  cobra.ExactArgs(2),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) > 1 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return completeBackupNames(cmd, args, toComplete)
+	},
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		diff, err := backupContext.DiffBackups(context, args[0], args[1])
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		if diffChangedOnly {
+			collections := make([]*backuppb.CollectionDiff, 0)
+			for _, collection := range diff.GetCollections() {
+				if collection.GetChange() != core.DiffUnchanged {
+					collections = append(collections, collection)
+				}
+			}
+			diff.Collections = collections
+		}
+		if jsonOutput() {
+			printJSON(diff)
+			return
+		}
+		printBackupDiff(diff)
+	},
+}
+
+// printBackupDiff prints the totals of the diff, a table of the collections and the schema changes of them
+func printBackupDiff(diff *backuppb.BackupDiff) {
+	fmt.Printf("diff from %s to %s\n", diff.GetFromBackupName(), diff.GetToBackupName())
+	if diff.GetIncrementalOf() {
+		fmt.Printf("  %s is an incremental backup based on %s\n", diff.GetToBackupName(), diff.GetFromBackupName())
+	}
+	fmt.Printf("  size: %s -> %s (%s)\n", formatSize(diff.GetFromSize()), formatSize(diff.GetToSize()), formatSizeDelta(diff.GetToSize()-diff.GetFromSize()))
+	fmt.Printf("  rows: %d -> %d (%+d)\n", diff.GetFromRows(), diff.GetToRows(), diff.GetToRows()-diff.GetFromRows())
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "COLLECTION\tCHANGE\tROWS\tSIZE\tSEGMENTS\tPARTITIONS")
+	for _, collection := range diff.GetCollections() {
+		segments := fmt.Sprintf("%d -> %d (+%d -%d ~%d)", collection.GetFromSegments(), collection.GetToSegments(),
+			collection.GetAddedSegments(), collection.GetRemovedSegments(), collection.GetChangedSegments())
+		partitions := make([]string, 0)
+		for _, partition := range collection.GetAddedPartitions() {
+			partitions = append(partitions, "+"+partition)
+		}
+		for _, partition := range collection.GetRemovedPartitions() {
+			partitions = append(partitions, "-"+partition)
+		}
+		if len(partitions) == 0 {
+			partitions = append(partitions, "-")
+		}
+		fmt.Fprintf(w, "%s.%s\t%s\t%+d\t%s\t%s\t%s\n", collection.GetDbName(), collection.GetCollectionName(), collection.GetChange(),
+			collection.GetToRows()-collection.GetFromRows(), formatSizeDelta(collection.GetToSize()-collection.GetFromSize()),
+			segments, strings.Join(partitions, ","))
+	}
+	w.Flush()
+
+	for _, collection := range diff.GetCollections() {
+		for _, change := range collection.GetSchemaChanges() {
+			fmt.Printf("schema of %s.%s: %s\n", collection.GetDbName(), collection.GetCollectionName(), change)
+		}
+	}
+}
+
+// formatSizeDelta formats the growth of size with its sign, like +1.5 MB or -20 B
+func formatSizeDelta(delta int64) string {
+	if delta < 0 {
+		return "-" + formatSize(-delta)
+	}
+	return "+" + formatSize(delta)
+}
+
+func init() {
+	diffCmd.Flags().BoolVarP(&diffChangedOnly, "changed_only", "", false, "only show the collections added, removed or changed")
+
+	rootCmd.AddCommand(diffCmd)
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+var (
+	exportFormat          string
+	exportDir             string
+	exportCollectionNames string
+	exportSSECustomerKey  string
+)
+
+var exportCmd = &cobra.Command{
+	Use:               "export <backup_name>",
+	Short:             "export subcommand convert the binlogs of a backup into parquet or jsonl files of local dirs, a dir per collection with its schema.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if exportFormat != core.ExportFormatParquet && exportFormat != core.ExportFormatJSONL {
+			printError(fmt.Sprintf("illegal format %s, support %s and %s", exportFormat, core.ExportFormatParquet, core.ExportFormatJSONL))
+			return
+		}
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		opts := core.ExportOptions{
+			Format:         exportFormat,
+			OutputDir:      exportDir,
+			SseCustomerKey: exportSSECustomerKey,
+		}
+		if exportCollectionNames != "" {
+			opts.Collections = strings.Split(exportCollectionNames, ",")
+		}
+		exported, err := backupContext.ExportBackup(context, args[0], opts)
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		if jsonOutput() {
+			printJSON(exported)
+			return
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "COLLECTION\tROWS\tDELETED\tFILES\tDIR")
+		for _, collection := range exported {
+			fmt.Fprintf(w, "%s.%s\t%d\t%d\t%d\t%s\n", collection.DbName, collection.CollectionName, collection.Rows,
+				collection.DeletedRows, len(collection.Files), collection.Dir)
+		}
+		w.Flush()
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringVarP(&exportFormat, "format", "f", core.ExportFormatParquet, "format of the files exported, support parquet and jsonl")
+	exportCmd.Flags().StringVarP(&exportDir, "dir", "d", ".", "local dir to export into, collections are in dirs of database/collection")
+	exportCmd.Flags().StringVarP(&exportCollectionNames, "collections", "c", "", "collections of the backup to export, like db.collection, use ',' to connect multiple collections, all collections if unset")
+	exportCmd.Flags().StringVarP(&exportSSECustomerKey, "sse_customer_key", "", "", "customer key of backup encrypted by sse type customer, if unset will use backup.serverSideEncryption.customerKey in config")
+
+	rootCmd.AddCommand(exportCmd)
+}
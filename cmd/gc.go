@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	gcDryRun bool
+)
+
+var gcCmd = &cobra.Command{
+	Use:   "gc",
+	Short: "gc subcommand remove orphaned files in backup storage not referenced by any backup.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		if interactive && !gcDryRun {
+			// list the orphaned files to ask before removing them
+			plan := backupContext.GarbageCollect(context, &backuppb.GarbageCollectRequest{DryRun: true})
+			if plan.GetCode() != backuppb.ResponseCode_Success {
+				printResponse(plan)
+				return
+			}
+			if len(plan.GetRemovedFiles()) > 0 && !confirm("remove %d orphaned files of %s?", len(plan.GetRemovedFiles()), formatSize(plan.GetRemovedSize())) {
+				printCanceled()
+				return
+			}
+		}
+		resp := backupContext.GarbageCollect(context, &backuppb.GarbageCollectRequest{
+			DryRun: gcDryRun,
+		})
+
+		if resp.GetCode() != backuppb.ResponseCode_Success || jsonOutput() {
+			printResponse(resp)
+			return
+		}
+		for _, file := range resp.GetRemovedFiles() {
+			if gcDryRun {
+				fmt.Println("to remove: " + file)
+			} else {
+				fmt.Println("removed: " + file)
+			}
+		}
+		fmt.Printf("%d orphaned files, %s\n", len(resp.GetRemovedFiles()), formatSize(resp.GetRemovedSize()))
+	},
+}
+
+func init() {
+	gcCmd.Flags().BoolVarP(&gcDryRun, "dry_run", "", false, "only print the orphaned files, do not remove them")
+
+	rootCmd.AddCommand(gcCmd)
+}
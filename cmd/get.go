@@ -22,7 +22,7 @@ var getBackupCmd = &cobra.Command{
 
 	Run: func(cmd *cobra.Command, args []string) {
 		var params paramtable.BackupParams
-		fmt.Println("config:" + config)
+		printText("config:" + config)
 		params.GlobalInitWithYaml(config)
 		params.Init()
 
@@ -34,6 +34,10 @@ var getBackupCmd = &cobra.Command{
 			WithoutDetail: !getDetail,
 		})
 
+		if jsonOutput() {
+			printJSONResult(resp)
+			return
+		}
 		output, _ := json.MarshalIndent(resp.GetData(), "", "    ")
 		fmt.Println(string(output))
 		fmt.Println(resp.GetCode())
@@ -42,6 +46,7 @@ var getBackupCmd = &cobra.Command{
 
 func init() {
 	getBackupCmd.Flags().StringVarP(&getBackName, "name", "n", "", "get backup with this name")
+	getBackupCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
 	getBackupCmd.Flags().BoolVarP(&getDetail, "detail", "d", false, "get complete backup info")
 
 	rootCmd.AddCommand(getBackupCmd)
@@ -4,8 +4,12 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
 )
 
 func ExecuteCommand(name string, subname string, args ...string) (string, error) {
@@ -21,3 +25,50 @@ func Error(cmd *cobra.Command, args []string, err error) {
 	fmt.Fprintf(os.Stderr, "execute %s args:%v error:%v\n", cmd.Name(), args, err)
 	os.Exit(1)
 }
+
+// parsePartitions parses partitions of collections like ["coll1:p1,p2", "db1.coll2:p3"]
+func parsePartitions(values []string) (map[string]*backuppb.PartitionNames, error) {
+	partitions := make(map[string]*backuppb.PartitionNames, len(values))
+	for _, value := range values {
+		splits := strings.SplitN(value, ":", 2)
+		if len(splits) != 2 || splits[0] == "" || splits[1] == "" {
+			return nil, fmt.Errorf("illegal partitions: %s, format: collection:partition1,partition2", value)
+		}
+		names := partitions[splits[0]]
+		if names == nil {
+			names = &backuppb.PartitionNames{}
+			partitions[splits[0]] = names
+		}
+		names.Names = append(names.Names, strings.Split(splits[1], ",")...)
+	}
+	return partitions, nil
+}
+
+// parseLabels parses labels like env=prod,app=search
+func parseLabels(value string) (map[string]string, error) {
+	labels := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		splits := strings.SplitN(item, "=", 2)
+		if len(splits) != 2 || strings.TrimSpace(splits[0]) == "" {
+			return nil, fmt.Errorf("illegal label: %s, format: key=value", item)
+		}
+		labels[strings.TrimSpace(splits[0])] = strings.TrimSpace(splits[1])
+	}
+	return labels, nil
+}
+
+// readRenameFile reads the collection renames from a YAML file of old name to new name
+func readRenameFile(file string) (map[string]string, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read rename file %s: %w", file, err)
+	}
+	renames := make(map[string]string)
+	if err := yaml.Unmarshal(data, &renames); err != nil {
+		return nil, fmt.Errorf("illegal rename file %s: %w", file, err)
+	}
+	return renames, nil
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+var (
+	inspectBackupName   string
+	inspectFormat       string
+	inspectEtcdSnapshot bool
+)
+
+var inspectCmd = &cobra.Command{
+	Use:               "inspect [backup_name]",
+	Short:             "inspect subcommand print the complete meta of a backup, with collections, schemas, partitions and segments.",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			inspectBackupName = args[0]
+		}
+		if inspectFormat != formatJSON && inspectFormat != formatYAML {
+			printError(fmt.Sprintf("illegal format %s, support json and yaml", inspectFormat))
+			return
+		}
+		// stdout only contains the meta
+		log.SetConsoleOutput("stderr")
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		var data []byte
+		var err error
+		if inspectEtcdSnapshot {
+			var snapshot *backuppb.EtcdSnapshot
+			snapshot, err = backupContext.ReadEtcdSnapshot(context, inspectBackupName)
+			if err == nil {
+				data, err = json.Marshal(snapshot)
+			}
+		} else {
+			data, err = backupContext.ExportBackupMeta(context, inspectBackupName)
+		}
+		if err == nil {
+			data, err = formatMeta(data, inspectFormat)
+		}
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		fmt.Println(string(data))
+	},
+}
+
+// formatMeta indents the json meta of a backup, or converts it into yaml with the fields in the same order
+func formatMeta(data []byte, format string) ([]byte, error) {
+	if format == formatYAML {
+		return jsonToYAML(data)
+	}
+	return indentJSON(data)
+}
+
+// printFailure prints the error of a command which fails, and exits with 1
+func printFailure(err error) {
+	result := &commandResult{Code: backuppb.ResponseCode_Fail, Msg: err.Error()}
+	if jsonOutput() {
+		printJSONResult(result)
+		return
+	}
+	fmt.Fprintln(os.Stderr, result.GetMsg())
+	os.Exit(1)
+}
+
+func init() {
+	inspectCmd.Flags().StringVarP(&inspectBackupName, "name", "n", "", "inspect backup with this name")
+	inspectCmd.Flags().StringVarP(&inspectFormat, "format", "", formatJSON, "format of the meta, support json and yaml")
+	inspectCmd.Flags().BoolVarP(&inspectEtcdSnapshot, "etcd_snapshot", "", false, "print the etcd snapshot of the backup instead, values are base64 encoded")
+	inspectCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+
+	rootCmd.AddCommand(inspectCmd)
+}
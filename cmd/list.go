@@ -3,6 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zilliztech/milvus-backup/core"
@@ -12,6 +17,15 @@ import (
 
 var (
 	collectionName string
+	listDatabases  string
+	listStartTime  string
+	listEndTime    string
+	listStates     string
+	listOrderBy    string
+	listDesc       bool
+	listOffset     int32
+	listLimit      int32
+	listSelector   string
 )
 
 var listBackupCmd = &cobra.Command{
@@ -20,26 +34,113 @@ var listBackupCmd = &cobra.Command{
 
 	Run: func(cmd *cobra.Command, args []string) {
 		var params paramtable.BackupParams
-		fmt.Println("config:" + config)
+		printText("config:" + config)
 		params.GlobalInitWithYaml(config)
 		params.Init()
 
 		context := context.Background()
 		backupContext := core.CreateBackupContext(context, params)
 
+		var databaseArr []string
+		if listDatabases != "" {
+			databaseArr = strings.Split(listDatabases, ",")
+		}
+		startTime, err := parseListTime(listStartTime)
+		if err != nil {
+			printError(err.Error())
+			return
+		}
+		endTime, err := parseListTime(listEndTime)
+		if err != nil {
+			printError(err.Error())
+			return
+		}
+		var states []backuppb.BackupTaskStateCode
+		if listStates != "" {
+			for _, state := range strings.Split(listStates, ",") {
+				code, ok := backuppb.BackupTaskStateCode_value["BACKUP_"+strings.ToUpper(state)]
+				if !ok {
+					printError("illegal state " + state + ", support initial, executing, success, fail, timeout and paused")
+					return
+				}
+				states = append(states, backuppb.BackupTaskStateCode(code))
+			}
+		}
 		backups := backupContext.ListBackups(context, &backuppb.ListBackupsRequest{
 			CollectionName: collectionName,
+			Databases:      databaseArr,
+			StartTime:      startTime,
+			EndTime:        endTime,
+			States:         states,
+			OrderBy:        listOrderBy,
+			Desc:           listDesc,
+			Offset:         listOffset,
+			Limit:          listLimit,
+			WithoutDetail:  true,
+			LabelSelector:  listSelector,
 		})
+		if backups.GetCode() != backuppb.ResponseCode_Success || jsonOutput() {
+			printResponse(backups)
+			return
+		}
 
 		fmt.Println(">> Backups:")
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "NAME\tSTATE\tSTART TIME\tSIZE\tDURATION\tLABELS")
 		for _, backup := range backups.GetData() {
-			fmt.Println(backup.GetName())
+			startTime := time.UnixMilli(backup.GetStartTime()).Format("2006-01-02 15:04:05")
+			duration := "-"
+			if backup.GetEndTime() > 0 {
+				duration = fmt.Sprintf("%ds", (backup.GetEndTime()-backup.GetStartTime())/1000)
+			}
+			state := strings.ToLower(strings.TrimPrefix(backup.GetStateCode().String(), "BACKUP_"))
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\t%s\n", backup.GetName(), state, startTime, backup.GetSize(), duration, formatLabels(backup.GetLabels()))
 		}
+		w.Flush()
+		fmt.Printf("%d of %d backups\n", len(backups.GetData()), backups.GetTotal())
 	},
 }
 
+// formatLabels formats labels like app=search,env=prod sorted by key, - if none
+func formatLabels(labels map[string]string) string {
+	if len(labels) == 0 {
+		return "-"
+	}
+	items := make([]string, 0, len(labels))
+	for key, value := range labels {
+		items = append(items, key+"="+value)
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+// parseListTime parses a local time like 2006-01-02 or 2006-01-02 15:04:05 into unix seconds, 0 if empty
+func parseListTime(value string) (int64, error) {
+	if value == "" {
+		return 0, nil
+	}
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t.Unix(), nil
+		}
+	}
+	return 0, fmt.Errorf("illegal time %s, format: 2006-01-02 or 2006-01-02 15:04:05", value)
+}
+
 func init() {
 	listBackupCmd.Flags().StringVarP(&collectionName, "collection", "c", "", "only list backups contains a certain collection")
+	listBackupCmd.Flags().StringVarP(&listDatabases, "databases", "d", "", "only list backups contains collections in these databases, use ',' to connect multiple databases")
+	listBackupCmd.Flags().StringVarP(&listStartTime, "start_time", "", "", "only list backups started at or after this time, format: 2006-01-02 or 2006-01-02 15:04:05")
+	listBackupCmd.Flags().StringVarP(&listEndTime, "end_time", "", "", "only list backups started before this time, format: 2006-01-02 or 2006-01-02 15:04:05")
+	listBackupCmd.Flags().StringVarP(&listStates, "states", "", "", "only list backups in these states, support initial, executing, success, fail, timeout and paused, use ',' to connect multiple states")
+	listBackupCmd.Flags().StringVarP(&listOrderBy, "order_by", "", "", "sort backups by name, start_time or size, default is name")
+	listBackupCmd.Flags().BoolVarP(&listDesc, "desc", "", false, "sort backups in descending order")
+	listBackupCmd.Flags().Int32VarP(&listOffset, "offset", "", 0, "number of backups to skip")
+	listBackupCmd.Flags().Int32VarP(&listLimit, "limit", "", 0, "max number of backups to list, 0 means no limit")
+	listBackupCmd.Flags().StringVarP(&listSelector, "selector", "l", "", "only list backups whose labels match the selector, like 'env=prod,app!=search'")
+
+	listBackupCmd.Flags().SortFlags = false
+	listBackupCmd.RegisterFlagCompletionFunc("collection", completeCollectionNames)
 
 	rootCmd.AddCommand(listBackupCmd)
 }
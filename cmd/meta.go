@@ -0,0 +1,179 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+var (
+	metaBackupName string
+	metaFile       string
+	metaForce      bool
+	metaAll        bool
+	metaDryRun     bool
+)
+
+var metaCmd = &cobra.Command{
+	Use:   "meta",
+	Short: "meta subcommand export, import or migrate the meta of backups in the backup storage of --config.",
+}
+
+var metaExportCmd = &cobra.Command{
+	Use:               "export [backup_name]",
+	Short:             "export subcommand write the complete meta of a backup as json into a file, or stdout if --file is unset.",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			metaBackupName = args[0]
+		}
+		if metaFile == "" {
+			// stdout only contains the meta
+			log.SetConsoleOutput("stderr")
+		}
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		data, err := backupContext.ExportBackupMeta(context, metaBackupName)
+		if err == nil {
+			data, err = indentJSON(data)
+		}
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		if metaFile == "" {
+			fmt.Println(string(data))
+			return
+		}
+		if err := os.WriteFile(metaFile, data, 0o644); err != nil {
+			printFailure(err)
+			return
+		}
+		printResponse(&commandResult{Code: backuppb.ResponseCode_Success, Msg: fmt.Sprintf("exported meta of %s into %s", metaBackupName, metaFile)})
+	},
+}
+
+var metaImportCmd = &cobra.Command{
+	Use:   "import",
+	Short: "import subcommand write the meta exported by meta export into the backup storage, the binlogs of the backup should be there already.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if metaFile == "" {
+			printError("meta file is required")
+			return
+		}
+		data, err := os.ReadFile(metaFile)
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		backup, err := backupContext.ImportBackupMeta(context, data, metaForce)
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		printResponse(&commandResult{Code: backuppb.ResponseCode_Success, Msg: fmt.Sprintf("imported meta of %s", backup.GetName())})
+	},
+}
+
+var metaMigrateCmd = &cobra.Command{
+	Use:               "migrate [backup_name]",
+	Short:             "migrate subcommand rewrite the meta of backups written by an older milvus-backup in the current layout.",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) > 0 {
+			metaBackupName = args[0]
+		}
+		if metaBackupName == "" && !metaAll {
+			printError("backup name or --all is required")
+			return
+		}
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		names := []string{metaBackupName}
+		if metaAll {
+			resp := backupContext.ListBackups(context, &backuppb.ListBackupsRequest{WithoutDetail: true})
+			if resp.GetCode() != backuppb.ResponseCode_Success {
+				printResponse(resp)
+				return
+			}
+			names = names[:0]
+			for _, backup := range resp.GetData() {
+				names = append(names, backup.GetName())
+			}
+		}
+
+		migrated, failed := 0, 0
+		for _, name := range names {
+			version, err := backupContext.MigrateBackupMeta(context, name, metaDryRun)
+			switch {
+			case err != nil:
+				failed++
+				printText(fmt.Sprintf("%s: %s", name, err.Error()))
+			case version < core.BACKUP_META_VERSION:
+				migrated++
+				printText(fmt.Sprintf("%s: meta version %d -> %d", name, version, core.BACKUP_META_VERSION))
+			}
+		}
+		result := &commandResult{Code: backuppb.ResponseCode_Success}
+		if metaDryRun {
+			result.Msg = fmt.Sprintf("%d of %d backups to migrate, dry run", migrated, len(names))
+		} else {
+			result.Msg = fmt.Sprintf("migrated %d of %d backups", migrated, len(names))
+		}
+		if failed > 0 {
+			result.Code = backuppb.ResponseCode_Fail
+			result.Msg += fmt.Sprintf(", %d failed", failed)
+		}
+		printResponse(result)
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	metaExportCmd.Flags().StringVarP(&metaBackupName, "name", "n", "", "export meta of the backup with this name")
+	metaExportCmd.Flags().StringVarP(&metaFile, "file", "f", "", "file to write the meta into, if unset will print to stdout")
+	metaExportCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+
+	metaImportCmd.Flags().StringVarP(&metaFile, "file", "f", "", "meta file written by meta export")
+	metaImportCmd.Flags().BoolVarP(&metaForce, "force", "", false, "overwrite the meta of an existing backup with the same name")
+
+	metaMigrateCmd.Flags().StringVarP(&metaBackupName, "name", "n", "", "migrate meta of the backup with this name")
+	metaMigrateCmd.Flags().BoolVarP(&metaAll, "all", "", false, "migrate meta of all backups in the backup storage")
+	metaMigrateCmd.Flags().BoolVarP(&metaDryRun, "dry_run", "", false, "only list the backups to migrate")
+	metaMigrateCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+
+	metaCmd.AddCommand(metaExportCmd, metaImportCmd, metaMigrateCmd)
+	rootCmd.AddCommand(metaCmd)
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	jsoniter "github.com/json-iterator/go"
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+var (
+	migrateTargetConfig         string
+	migrateTargetProfile        string
+	migrateBackupName           string
+	migrateCollectionNames      string
+	migrateDatabases            string
+	migrateDatabaseCollections  string
+	migrateForce                bool
+	migrateSuffix               string
+	migrateRename               string
+	migrateRestoreIndex         bool
+	migrateIndexMode            string
+	migrateAliases              string
+	migrateUseAutoIndex         bool
+	migrateDropExistCollection  bool
+	migrateDropExistIndex       bool
+	migrateSkipCreateCollection bool
+	migrateKeepBackups          bool
+	migrateCollectionRegex      string
+	migratePartitions           []string
+	migrateTargetDatabase       string
+)
+
+var migrateCmd = &cobra.Command{
+	Use:   "migrate",
+	Short: "migrate subcommand migrate collections from the milvus of --config to the milvus of --target_config or --target_profile.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if migrateTargetConfig == "" && migrateTargetProfile == "" {
+			printError("target config or target profile is required")
+			return
+		}
+
+		var params paramtable.BackupParams
+		printText("source config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		var targetParams paramtable.BackupParams
+		if migrateTargetProfile != "" {
+			printText("target profile:" + migrateTargetProfile)
+			targetParams.InitWithProfile(migrateTargetConfig, migrateTargetProfile)
+		} else {
+			printText("target config:" + migrateTargetConfig)
+			targetParams.InitWithYaml(migrateTargetConfig)
+		}
+		core.MigrateTargetParams(&params, &targetParams)
+
+		if migrateDropExistCollection && !confirm("drop existing collections in the target milvus before migrating into them?") {
+			printCanceled()
+			return
+		}
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+		targetContext := core.CreateBackupContext(context, targetParams)
+
+		start := time.Now().Unix()
+		var collectionNameArr []string
+		if migrateCollectionNames != "" {
+			collectionNameArr = strings.Split(migrateCollectionNames, ",")
+		}
+
+		if migrateDatabaseCollections == "" && migrateDatabases != "" {
+			dbCollectionDict := make(map[string][]string)
+			for _, db := range strings.Split(migrateDatabases, ",") {
+				dbCollectionDict[db] = []string{}
+			}
+			completeDbCollections, err := jsoniter.MarshalToString(dbCollectionDict)
+			if err != nil {
+				printError("illegal databases input")
+				return
+			}
+			migrateDatabaseCollections = completeDbCollections
+		}
+
+		renameMap := make(map[string]string, 0)
+		if migrateRename != "" {
+			for _, rename := range strings.Split(migrateRename, ",") {
+				splits := strings.Split(rename, ":")
+				if len(splits) != 2 {
+					printError("illegal rename parameter")
+					return
+				}
+				renameMap[splits[0]] = splits[1]
+			}
+		}
+
+		partitionDict, err := parsePartitions(migratePartitions)
+		if err != nil {
+			printError(err.Error())
+			return
+		}
+
+		err = backupContext.Migrate(context, targetContext, &backuppb.CreateBackupRequest{
+			BackupName:      migrateBackupName,
+			CollectionNames: collectionNameArr,
+			DbCollections:   utils.WrapDBCollections(migrateDatabaseCollections),
+			Force:           migrateForce,
+			CollectionRegex: migrateCollectionRegex,
+			Partitions:      partitionDict,
+		}, &backuppb.RestoreBackupRequest{
+			CollectionSuffix:     migrateSuffix,
+			CollectionRenames:    renameMap,
+			RestoreIndex:         migrateRestoreIndex,
+			IndexMode:            migrateIndexMode,
+			RestoreAliases:       migrateAliases,
+			UseAutoIndex:         migrateUseAutoIndex,
+			DropExistCollection:  migrateDropExistCollection,
+			DropExistIndex:       migrateDropExistIndex,
+			SkipCreateCollection: migrateSkipCreateCollection,
+			TargetDbName:         migrateTargetDatabase,
+		}, migrateKeepBackups)
+
+		result := &commandResult{Code: backuppb.ResponseCode_Success, Msg: "success"}
+		if err != nil {
+			result = &commandResult{Code: backuppb.ResponseCode_Fail, Msg: err.Error()}
+		}
+		if jsonOutput() {
+			printJSONResult(result)
+			return
+		}
+		fmt.Println(result.GetMsg())
+		duration := time.Now().Unix() - start
+		fmt.Println(fmt.Sprintf("duration:%d s", duration))
+	},
+}
+
+func init() {
+	migrateCmd.Flags().StringVarP(&migrateTargetConfig, "target_config", "t", "", "config YAML file of the target milvus to migrate into")
+	migrateCmd.Flags().StringVarP(&migrateTargetProfile, "target_profile", "", "", "profile of the target milvus to migrate into, in --target_config or else --config")
+	migrateCmd.Flags().StringVarP(&migrateBackupName, "name", "n", "", "name prefix of the backups taken during migration, if unset will generate one automatically")
+	migrateCmd.Flags().StringVarP(&migrateCollectionNames, "colls", "c", "", "collectionNames to migrate, use ',' to connect multiple collections, support wildcard patterns like 'prod_*'")
+	migrateCmd.Flags().StringVarP(&migrateCollectionRegex, "colls_regex", "", "", "migrate collections of all databases whose names match the regex")
+	migrateCmd.Flags().StringArrayVarP(&migratePartitions, "partitions", "p", []string{}, "partitions to migrate, format: collection:partition1,partition2, can be set more than once for multiple collections")
+	migrateCmd.Flags().StringVarP(&migrateDatabases, "databases", "d", "", "databases to migrate")
+	migrateCmd.Flags().StringVarP(&migrateDatabaseCollections, "database_collections", "a", "", "databases and collections to migrate, json format: {\"db1\":[\"c1\", \"c2\"],\"db2\":[]}")
+	migrateCmd.Flags().BoolVarP(&migrateForce, "force", "f", false, "force backup, will skip flush, should make sure data has been stored into disk when using it")
+	migrateCmd.Flags().StringVarP(&migrateSuffix, "suffix", "s", "", "add a suffix to collection name in target milvus")
+	migrateCmd.Flags().StringVarP(&migrateRename, "rename", "r", "", "rename collections in target milvus, format: db1.collection1:db2.collection1_new,db1.collection2:db2.collection2_new")
+	migrateCmd.Flags().StringVarP(&migrateTargetDatabase, "target_database", "", "", "migrate collections into this database of target milvus instead of their original ones")
+	migrateCmd.Flags().BoolVarP(&migrateRestoreIndex, "restore_index", "", false, "if true, restore index")
+	migrateCmd.Flags().StringVarP(&migrateAliases, "restore_aliases", "", "", "how to restore the aliases of collections, repoint, create or skip, default skip")
+	migrateCmd.Flags().StringVarP(&migrateIndexMode, "index_mode", "", "", "when to create the indexes, immediate before data, deferred after data or skip, default immediate if --restore_index else skip")
+	migrateCmd.Flags().BoolVarP(&migrateUseAutoIndex, "use_auto_index", "", false, "if true, replace vector index with autoindex")
+	migrateCmd.Flags().BoolVarP(&migrateDropExistCollection, "drop_exist_collection", "", false, "if true, drop existing target collection before create")
+	migrateCmd.Flags().BoolVarP(&migrateDropExistIndex, "drop_exist_index", "", false, "if true, drop existing index of target collection before create")
+	migrateCmd.Flags().BoolVarP(&migrateSkipCreateCollection, "skip_create_collection", "", false, "if true, will skip collection, use when collection exist, restore data")
+	migrateCmd.Flags().BoolVarP(&migrateKeepBackups, "keep_backups", "", false, "if true, keep the backups taken during migration instead of removing them after restored")
+
+	migrateCmd.Flags().SortFlags = false
+	migrateCmd.RegisterFlagCompletionFunc("colls", completeCollectionNames)
+
+	rootCmd.AddCommand(migrateCmd)
+}
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const (
+	outputText = "text"
+	outputJSON = "json"
+)
+
+var (
+	output string
+)
+
+// initOutput validates the --output flag. Console logs are written to stderr if output is json, so that stdout is valid json
+func initOutput() error {
+	switch output {
+	case outputText:
+	case outputJSON:
+		log.SetConsoleOutput("stderr")
+	default:
+		return fmt.Errorf("illegal output %s, support text and json", output)
+	}
+	return nil
+}
+
+func jsonOutput() bool {
+	return output == outputJSON
+}
+
+// printText prints a line of the human-oriented output, it is skipped if output is json
+func printText(a ...interface{}) {
+	if !jsonOutput() {
+		fmt.Println(a...)
+	}
+}
+
+// printJSON prints the result of the command as json, which is the same as the response of the REST API
+func printJSON(v interface{}) {
+	data, err := json.MarshalIndent(v, "", "    ")
+	if err != nil {
+		fmt.Printf("{\"code\": %d, \"msg\": %q}\n", backuppb.ResponseCode_Fail, err.Error())
+		return
+	}
+	fmt.Println(string(data))
+}
+
+// response is the common part of the responses of all apis
+type response interface {
+	GetCode() backuppb.ResponseCode
+	GetMsg() string
+}
+
+// commandResult is printed for illegal input or commands without an api response, in the same format as the responses
+type commandResult struct {
+	Code backuppb.ResponseCode `json:"code"`
+	Msg  string                `json:"msg"`
+}
+
+func (r *commandResult) GetCode() backuppb.ResponseCode { return r.Code }
+func (r *commandResult) GetMsg() string                 { return r.Msg }
+
+// printJSONResult prints the response as json, and exits with 1 if the response is not success
+func printJSONResult(resp response) {
+	printJSON(resp)
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		os.Exit(1)
+	}
+}
+
+// printError prints the error of illegal input of a command
+func printError(msg string) {
+	if jsonOutput() {
+		printJSONResult(&commandResult{Code: backuppb.ResponseCode_Parameter_Error, Msg: msg})
+		return
+	}
+	fmt.Println(msg)
+}
+
+// printResponse prints the response as json, or only its msg if output is text
+func printResponse(resp response) {
+	if jsonOutput() {
+		printJSONResult(resp)
+		return
+	}
+	fmt.Println(resp.GetMsg())
+}
+
+// indentJSON indents the json for reading
+func indentJSON(data []byte) ([]byte, error) {
+	var out bytes.Buffer
+	if err := json.Indent(&out, data, "", "    "); err != nil {
+		return nil, err
+	}
+	return out.Bytes(), nil
+}
+
+// jsonToYAML converts the json into block style yaml, keeping the order of the fields
+func jsonToYAML(data []byte) ([]byte, error) {
+	// json is valid yaml, parsed into nodes so that the fields are not sorted like a map
+	var node yaml.Node
+	if err := yaml.Unmarshal(data, &node); err != nil {
+		return nil, err
+	}
+	clearYAMLStyle(&node)
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&node); err != nil {
+		return nil, err
+	}
+	if err := encoder.Close(); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(out.Bytes(), []byte("\n")), nil
+}
+
+// clearYAMLStyle drops the flow style and quotes of json, strings which need quotes are still quoted by the encoder
+func clearYAMLStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearYAMLStyle(child)
+	}
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+var (
+	packageSchemaFile     string
+	packageFiles          string
+	packageDbName         string
+	packageCollectionName string
+)
+
+var packageCmd = &cobra.Command{
+	Use:   "package <backup_name>",
+	Short: "package subcommand package parquet, json, jsonl or numpy files with a schema into a backup of a collection, which is restored by restore subcommand like a backup of milvus.",
+	Args:  cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if packageSchemaFile == "" || packageFiles == "" {
+			printError("--schema and --files are required")
+			return
+		}
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		backup, err := backupContext.PackageBackup(context, core.PackageOptions{
+			BackupName:     args[0],
+			DbName:         packageDbName,
+			CollectionName: packageCollectionName,
+			SchemaFile:     packageSchemaFile,
+			Files:          strings.Split(packageFiles, ","),
+		})
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		if jsonOutput() {
+			printJSON(backup)
+			return
+		}
+		collection := backup.GetCollectionBackups()[0]
+		var rows int64
+		segments := collection.GetPartitionBackups()[0].GetSegmentBackups()
+		for _, segment := range segments {
+			rows += segment.GetNumOfRows()
+		}
+		fmt.Printf("packaged backup %s, collection %s.%s, %d rows in %d segments, %d bytes\n", backup.GetName(),
+			collection.GetDbName(), collection.GetCollectionName(), rows, len(segments), backup.GetSize())
+	},
+}
+
+func init() {
+	packageCmd.Flags().StringVarP(&packageSchemaFile, "schema", "s", "", "json file of the schema of the collection, like the _schema.json written by export")
+	packageCmd.Flags().StringVarP(&packageFiles, "files", "f", "", "parquet, json or jsonl files of rows, or dirs of a numpy file per field named by the field, use ',' to connect multiple files, a segment per file")
+	packageCmd.Flags().StringVarP(&packageDbName, "database", "d", "", "database of the collection, default if unset")
+	packageCmd.Flags().StringVarP(&packageCollectionName, "collection", "c", "", "name of the collection, the name in schema if unset")
+
+	rootCmd.AddCommand(packageCmd)
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	pauseBackupName string
+)
+
+var pauseBackupCmd = &cobra.Command{
+	Use:   "pause",
+	Short: "pause subcommand pause an executing backup by name, it can be continued by resume subcommand.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		resp := backupContext.PauseBackup(context, &backuppb.PauseBackupRequest{
+			BackupName: pauseBackupName,
+		})
+
+		printResponse(resp)
+	},
+}
+
+func init() {
+	pauseBackupCmd.Flags().StringVarP(&pauseBackupName, "name", "n", "", "pause backup with this name")
+	pauseBackupCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+
+	rootCmd.AddCommand(pauseBackupCmd)
+}
@@ -0,0 +1,134 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+const (
+	progressInterval = time.Second
+	progressBarWidth = 40
+)
+
+// waitJob waits until the job of an asynchronous backup or restore ends, printing a progress bar whenever the progress changes
+func waitJob(ctx context.Context, backupContext *core.BackupContext, jobId string) *backuppb.JobInfo {
+	lastProgress := int32(-1)
+	ticker := time.NewTicker(progressInterval)
+	defer ticker.Stop()
+	for {
+		job := backupContext.GetJob(ctx, &backuppb.GetJobRequest{JobId: jobId}).GetData()
+		if job.GetProgress() != lastProgress && !jsonOutput() {
+			fmt.Println(progressBar(job))
+			lastProgress = job.GetProgress()
+		}
+		if job.GetStateCode() != backuppb.JobStateCode_JOB_RUNNING && job.GetStateCode() != backuppb.JobStateCode_JOB_CANCELING {
+			return job
+		}
+		<-ticker.C
+	}
+}
+
+// progressBar renders the progress of a job like [=========>          ] 45% 1.2 GB/2.6 GB
+func progressBar(job *backuppb.JobInfo) string {
+	return renderProgressBar(int(job.GetProgress()), job.GetCopiedSize(), job.GetSize())
+}
+
+func renderProgressBar(progress int, done int64, total int64) string {
+	filled := progress * progressBarWidth / 100
+	bar := strings.Repeat("=", filled)
+	if filled < progressBarWidth {
+		bar += ">" + strings.Repeat(" ", progressBarWidth-filled-1)
+	}
+	return fmt.Sprintf("[%s] %3d%% %s/%s", bar, progress, formatSize(done), formatSize(total))
+}
+
+// formatSize formats bytes in the largest unit less than it
+func formatSize(size int64) string {
+	units := []string{"B", "KB", "MB", "GB", "TB"}
+	value := float64(size)
+	unit := 0
+	for value >= 1024 && unit < len(units)-1 {
+		value /= 1024
+		unit++
+	}
+	if unit == 0 {
+		return fmt.Sprintf("%d B", size)
+	}
+	return fmt.Sprintf("%.1f %s", value, units[unit])
+}
+
+// printJobResult prints success or the error of the ended job
+func printJobResult(job *backuppb.JobInfo) {
+	if job.GetStateCode() == backuppb.JobStateCode_JOB_SUCCESS {
+		fmt.Println("success")
+		return
+	}
+	fmt.Println(job.GetErrorMessage())
+}
+
+// printRowCountMismatches prints the row count checks mismatched, requested by --check_row_count
+func printRowCountMismatches(mismatches []string) {
+	for _, mismatch := range mismatches {
+		fmt.Println("row count mismatch: " + mismatch)
+	}
+}
+
+// printBinlogScanFindings prints the orphan binlogs and missing segments found in the bucket of milvus, requested by
+// --scan_binlogs
+func printBinlogScanFindings(findings []string) {
+	for _, finding := range findings {
+		fmt.Println("binlog scan: " + finding)
+	}
+}
+
+// printSampleVerifications prints the scores of the rows sampled verified, requested by --verify_sample_rows
+func printSampleVerifications(restore *backuppb.RestoreBackupTask) {
+	for _, collection := range restore.GetCollectionRestoreTasks() {
+		verification := collection.GetSampleVerification()
+		if verification == nil {
+			continue
+		}
+		name := collection.GetTargetDbName() + "." + collection.GetTargetCollectionName()
+		if verification.GetState() != core.SampleVerified {
+			fmt.Println(fmt.Sprintf("sample verification of %s skipped: %s", name, verification.GetDetail()))
+			continue
+		}
+		fmt.Println(fmt.Sprintf("sample verification of %s: score %.2f, %d of %d rows match", name,
+			verification.GetScore(), verification.GetMatchedRows(), verification.GetCheckedRows()))
+		for _, pk := range verification.GetMissingPks() {
+			fmt.Println("  missing: " + pk)
+		}
+		for _, row := range verification.GetMismatchedRows() {
+			fmt.Println("  mismatch: " + row)
+		}
+	}
+}
+
+// backupJobResult returns the backup executed by the job in the response format of get_backup
+func backupJobResult(ctx context.Context, backupContext *core.BackupContext, job *backuppb.JobInfo) *backuppb.BackupInfoResponse {
+	resp := core.SimpleBackupResponse(backupContext.GetBackup(ctx, &backuppb.GetBackupRequest{BackupId: job.GetId()}))
+	resp.JobId = job.GetId()
+	resp.Code, resp.Msg = jobResult(job)
+	return resp
+}
+
+// restoreJobResult returns the restore task executed by the job in the response format of get_restore
+func restoreJobResult(ctx context.Context, backupContext *core.BackupContext, job *backuppb.JobInfo) *backuppb.RestoreBackupResponse {
+	resp := core.SimpleRestoreResponse(backupContext.GetRestore(ctx, &backuppb.GetRestoreStateRequest{Id: job.GetId()}))
+	resp.JobId = job.GetId()
+	resp.Code, resp.Msg = jobResult(job)
+	return resp
+}
+
+// jobResult returns the code and msg of a response by the state of the ended job
+func jobResult(job *backuppb.JobInfo) (backuppb.ResponseCode, string) {
+	if job.GetStateCode() == backuppb.JobStateCode_JOB_SUCCESS {
+		return backuppb.ResponseCode_Success, "success"
+	}
+	return backuppb.ResponseCode_Fail, job.GetErrorMessage()
+}
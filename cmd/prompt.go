@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	interactive bool
+)
+
+// confirm asks the user before a destructive operation if --interactive is set, it returns true without asking otherwise.
+// The prompt is written to stderr to leave stdout to the result.
+func confirm(format string, a ...interface{}) bool {
+	if !interactive {
+		return true
+	}
+	fmt.Fprintf(os.Stderr, format+" [y/N]: ", a...)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	}
+	return false
+}
+
+// printCanceled prints the result of an operation canceled by the user at the prompt
+func printCanceled() {
+	printResponse(&commandResult{Code: backuppb.ResponseCode_Fail, Msg: "canceled"})
+}
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	pruneDryRun   bool
+	pruneSelector string
+)
+
+var pruneBackupCmd = &cobra.Command{
+	Use:   "prune",
+	Short: "prune subcommand delete backups expired by the retention policy.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		if interactive && !pruneDryRun {
+			// list the expired backups to ask before deleting them
+			plan := backupContext.PruneBackups(context, &backuppb.PruneBackupsRequest{DryRun: true, LabelSelector: pruneSelector})
+			if plan.GetCode() != backuppb.ResponseCode_Success {
+				printResponse(plan)
+				return
+			}
+			if len(plan.GetDeletedBackups()) > 0 && !confirm("delete backups %s?", strings.Join(plan.GetDeletedBackups(), ", ")) {
+				printCanceled()
+				return
+			}
+		}
+		resp := backupContext.PruneBackups(context, &backuppb.PruneBackupsRequest{
+			DryRun:        pruneDryRun,
+			LabelSelector: pruneSelector,
+		})
+
+		if resp.GetCode() != backuppb.ResponseCode_Success || jsonOutput() {
+			printResponse(resp)
+			return
+		}
+		if pruneDryRun {
+			fmt.Println("backups to delete: " + strings.Join(resp.GetDeletedBackups(), ", "))
+		} else {
+			fmt.Println("deleted backups: " + strings.Join(resp.GetDeletedBackups(), ", "))
+		}
+		fmt.Println("kept backups: " + strings.Join(resp.GetKeptBackups(), ", "))
+	},
+}
+
+func init() {
+	pruneBackupCmd.Flags().BoolVarP(&pruneDryRun, "dry_run", "", false, "only print the backups to delete, do not delete them")
+	pruneBackupCmd.Flags().StringVarP(&pruneSelector, "selector", "l", "", "only apply the retention policy to the backups whose labels match the selector, like 'env=prod,app!=search'")
+
+	rootCmd.AddCommand(pruneBackupCmd)
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var reportRestoreId string
+
+var reportCmd = &cobra.Command{
+	Use:               "report <backup_name>",
+	Short:             "report subcommand prints the summaries of the backup run and the restore runs from a backup, with bytes, throughput per phase, slowest collections and retries.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		var summaries []*backuppb.RunSummary
+		var err error
+		if reportRestoreId != "" {
+			var summary *backuppb.RunSummary
+			summary, err = backupContext.ReadRunSummary(context, args[0], reportRestoreId)
+			summaries = []*backuppb.RunSummary{summary}
+		} else {
+			summaries, err = backupContext.ReadRunSummaries(context, args[0])
+		}
+		if err != nil {
+			printFailure(err)
+			return
+		}
+		if jsonOutput() {
+			printJSON(summaries)
+			return
+		}
+		for i, summary := range summaries {
+			if i > 0 {
+				fmt.Println()
+			}
+			printRunSummary(summary)
+		}
+	},
+}
+
+// printRunSummary prints the summary of a backup or restore run
+func printRunSummary(summary *backuppb.RunSummary) {
+	duration := time.Duration(summary.GetEndTime()-summary.GetStartTime()) * time.Millisecond
+	if summary.GetKind() == core.RunKindRestore {
+		fmt.Printf("restore %s from %s: %s\n", summary.GetRestoreId(), summary.GetBackupName(), summary.GetState())
+	} else {
+		fmt.Printf("backup %s: %s\n", summary.GetBackupName(), summary.GetState())
+	}
+	if summary.GetErrorMessage() != "" {
+		fmt.Println("  error: " + summary.GetErrorMessage())
+	}
+	fmt.Printf("  started: %s, duration: %s\n", time.UnixMilli(summary.GetStartTime()).Format("2006-01-02 15:04:05"), duration.Round(time.Millisecond))
+	fmt.Printf("  total: %s in %d files, %s/s\n", formatSize(summary.GetTotalBytes()), summary.GetFileCount(), formatSize(summary.GetThroughput()))
+	for _, phase := range summary.GetPhases() {
+		line := fmt.Sprintf("  phase %s: %s", phase.GetName(), (time.Duration(phase.GetDurationMs()) * time.Millisecond).String())
+		if phase.GetBytes() > 0 {
+			line += fmt.Sprintf(", %s, %s/s", formatSize(phase.GetBytes()), formatSize(phase.GetThroughput()))
+		}
+		fmt.Println(line)
+	}
+	for _, collection := range summary.GetSlowestCollections() {
+		fmt.Printf("  slow collection %s.%s: %s, %s\n", collection.GetDbName(), collection.GetCollectionName(),
+			(time.Duration(collection.GetDurationMs()) * time.Millisecond).String(), formatSize(collection.GetSize()))
+	}
+	retries := summary.GetRetries()
+	fmt.Printf("  retries: storage %d, compacted segments %d, bulk insert %d\n", retries.GetStorage(), retries.GetCompactedSegments(), retries.GetBulkInsert())
+}
+
+// printRunSummaryOf prints the summary of a run which has just ended, it is skipped if the summary is not written
+func printRunSummaryOf(ctx context.Context, backupContext *core.BackupContext, backupName string, restoreId string) {
+	summary, err := backupContext.ReadRunSummary(ctx, backupName, restoreId)
+	if err != nil {
+		return
+	}
+	printRunSummary(summary)
+}
+
+func init() {
+	reportCmd.Flags().StringVarP(&reportRestoreId, "restore_id", "", "", "only print the summary of the restore task, all the summaries of the backup if unset")
+
+	rootCmd.AddCommand(reportCmd)
+}
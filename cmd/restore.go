@@ -3,9 +3,11 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"os"
 	"strings"
 	"time"
 
+	"github.com/golang/protobuf/proto"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/spf13/cobra"
 	"github.com/zilliztech/milvus-backup/core"
@@ -30,6 +32,29 @@ var (
 	restoreDropExistCollection  bool
 	restoreDropExistIndex       bool
 	restoreSkipCreateCollection bool
+	restoreTimestamp            uint64
+	restoreResumeTaskId         string
+	restoreRbac                 bool
+	restoreRbacUserPassword     string
+	restorePartitions           []string
+	renameFile                  string
+	restoreNameTemplate         string
+	restoreTargetDatabase       string
+	restoreDryRun               bool
+	restoreSSECustomerKey       string
+	restoreSchemaPolicy         string
+	restoreFieldMappings        string
+	restoreIndexMode            string
+	restoreAliases              string
+	restoreProperties           string
+	restoreLoadCollection       bool
+	restoreReplicaNumber        int32
+	restoreResourceGroups       string
+	restoreCheckManifest        bool
+	restoreSelector             string
+	restoreDowngradeFeatures    bool
+	restoreCheckRowCount        bool
+	restoreVerifySampleRows     int32
 )
 
 var restoreBackupCmd = &cobra.Command{
@@ -53,20 +78,58 @@ var restoreBackupCmd = &cobra.Command{
 		}
 
 		renameMap := make(map[string]string, 0)
+		if renameFile != "" {
+			fileRenames, err := readRenameFile(renameFile)
+			if err != nil {
+				printError(err.Error())
+				return
+			}
+			for oldName, newName := range fileRenames {
+				renameMap[oldName] = newName
+			}
+		}
 		if renameCollectionNames != "" {
-			fmt.Println("rename: " + renameCollectionNames)
+			printText("rename: " + renameCollectionNames)
 			renameArr := strings.Split(renameCollectionNames, ",")
 			for _, rename := range renameArr {
 				if strings.Contains(rename, ":") {
 					splits := strings.Split(rename, ":")
 					renameMap[splits[0]] = splits[1]
 				} else {
-					fmt.Println("illegal rename parameter")
+					printError("illegal rename parameter")
+					return
+				}
+			}
+		}
+
+		fieldMappings := make(map[string]string, 0)
+		if restoreFieldMappings != "" {
+			for _, mapping := range strings.Split(restoreFieldMappings, ",") {
+				splits := strings.Split(mapping, ":")
+				if len(splits) != 2 {
+					printError("illegal field_mappings parameter")
 					return
 				}
+				fieldMappings[splits[0]] = splits[1]
 			}
 		}
 
+		properties := make(map[string]string, 0)
+		if restoreProperties != "" {
+			for _, property := range strings.Split(restoreProperties, ",") {
+				splits := strings.SplitN(property, "=", 2)
+				if len(splits) != 2 {
+					printError("illegal collection_properties parameter")
+					return
+				}
+				properties[splits[0]] = splits[1]
+			}
+		}
+		var resourceGroups []string
+		if restoreResourceGroups != "" {
+			resourceGroups = strings.Split(restoreResourceGroups, ",")
+		}
+
 		if restoreDatabaseCollections == "" && restoreDatabases != "" {
 			dbCollectionDict := make(map[string][]string)
 			splits := strings.Split(restoreDatabases, ",")
@@ -76,47 +139,208 @@ var restoreBackupCmd = &cobra.Command{
 			completeDbCollections, err := jsoniter.MarshalToString(dbCollectionDict)
 			restoreDatabaseCollections = completeDbCollections
 			if err != nil {
-				fmt.Println("illegal databases input")
+				printError("illegal databases input")
+				return
+			}
+		}
+		partitionDict, err := parsePartitions(restorePartitions)
+		if err != nil {
+			printError(err.Error())
+			return
+		}
+		request := &backuppb.RestoreBackupRequest{
+			BackupName:             restoreBackupName,
+			CollectionNames:        collectionNameArr,
+			CollectionSuffix:       renameSuffix,
+			CollectionRenames:      renameMap,
+			DbCollections:          utils.WrapDBCollections(restoreDatabaseCollections),
+			MetaOnly:               restoreMetaOnly,
+			RestoreIndex:           restoreRestoreIndex,
+			IndexMode:              restoreIndexMode,
+			RestoreAliases:         restoreAliases,
+			CollectionProperties:   properties,
+			LoadCollection:         restoreLoadCollection,
+			ReplicaNumber:          restoreReplicaNumber,
+			ResourceGroups:         resourceGroups,
+			CheckManifest:          restoreCheckManifest,
+			LabelSelector:          restoreSelector,
+			UseAutoIndex:           restoreUseAutoIndex,
+			DropExistCollection:    restoreDropExistCollection,
+			DropExistIndex:         restoreDropExistIndex,
+			SkipCreateCollection:   restoreSkipCreateCollection,
+			Timestamp:              restoreTimestamp,
+			ResumeTaskId:           restoreResumeTaskId,
+			Rbac:                   restoreRbac,
+			RbacUserPassword:       restoreRbacUserPassword,
+			Partitions:             partitionDict,
+			CollectionNameTemplate: restoreNameTemplate,
+			TargetDbName:           restoreTargetDatabase,
+			DryRun:                 restoreDryRun,
+			SseCustomerKey:         restoreSSECustomerKey,
+			SchemaPolicy:           restoreSchemaPolicy,
+			FieldMappings:          fieldMappings,
+			DowngradeFeatures:      restoreDowngradeFeatures,
+			CheckRowCount:          restoreCheckRowCount,
+			VerifySampleRows:       restoreVerifySampleRows,
+			// executed asynchronously to show the progress
+			Async: !restoreDryRun,
+		}
+		if interactive && !restoreDryRun && !confirmRestore(context, backupContext, request) {
+			printCanceled()
+			return
+		}
+		resp := backupContext.RestoreBackup(context, request)
+
+		if restoreDryRun {
+			if jsonOutput() {
+				printJSONResult(resp)
 				return
 			}
+			printRestorePlan(resp)
+			if resp.GetCode() != backuppb.ResponseCode_Success {
+				os.Exit(1)
+			}
+			return
+		}
+
+		if restoreBackupName == "" && resp.GetData().GetBackupName() != "" {
+			printText(fmt.Sprintf("restore backup: %s", resp.GetData().GetBackupName()))
+		}
+		if resp.GetData().GetId() != "" {
+			printText(fmt.Sprintf("restore task id: %s", resp.GetData().GetId()))
+		}
+		for _, collection := range resp.GetData().GetSkippedCollections() {
+			printText("skip: " + collection)
+		}
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			printResponse(resp)
+			return
 		}
-		resp := backupContext.RestoreBackup(context, &backuppb.RestoreBackupRequest{
-			BackupName:           restoreBackupName,
-			CollectionNames:      collectionNameArr,
-			CollectionSuffix:     renameSuffix,
-			CollectionRenames:    renameMap,
-			DbCollections:        utils.WrapDBCollections(restoreDatabaseCollections),
-			MetaOnly:             restoreMetaOnly,
-			RestoreIndex:         restoreRestoreIndex,
-			UseAutoIndex:         restoreUseAutoIndex,
-			DropExistCollection:  restoreDropExistIndex,
-			DropExistIndex:       restoreDropExistIndex,
-			SkipCreateCollection: restoreSkipCreateCollection,
-		})
-
-		fmt.Println(resp.GetMsg())
+		job := waitJob(context, backupContext, resp.GetJobId())
+		if jsonOutput() {
+			printJSONResult(restoreJobResult(context, backupContext, job))
+			return
+		}
+		printJobResult(job)
+		if restoreCheckRowCount || restoreVerifySampleRows > 0 {
+			result := restoreJobResult(context, backupContext, job).GetData()
+			printRowCountMismatches(core.RestoreRowCountMismatches(result))
+			printSampleVerifications(result)
+		}
+		printRunSummaryOf(context, backupContext, resp.GetData().GetBackupName(), resp.GetData().GetId())
 		duration := time.Now().Unix() - start
 		fmt.Println(fmt.Sprintf("duration:%d s", duration))
 	},
 }
 
+// confirmRestore asks the user before a restore dropping existing collections or indexes,
+// the collections to be dropped are found by a dry run of the restore
+func confirmRestore(ctx context.Context, backupContext *core.BackupContext, request *backuppb.RestoreBackupRequest) bool {
+	if request.GetDropExistCollection() {
+		dryRunRequest := proto.Clone(request).(*backuppb.RestoreBackupRequest)
+		dryRunRequest.DryRun = true
+		dryRunRequest.Async = false
+		conflicts := backupContext.RestoreBackup(ctx, dryRunRequest).GetDryRunReport().GetConflicts()
+		if len(conflicts) > 0 && !confirm("drop existing collections %s and restore the backup into them?", strings.Join(conflicts, ", ")) {
+			return false
+		}
+	}
+	if request.GetDropExistIndex() {
+		return confirm("drop existing indexes of the collections to restore?")
+	}
+	return true
+}
+
+// printRestorePlan prints the collections and partitions a dry run restore would restore and the problems found
+func printRestorePlan(resp *backuppb.RestoreBackupResponse) {
+	for _, collTask := range resp.GetData().GetCollectionRestoreTasks() {
+		collBackup := collTask.GetCollBackup()
+		fmt.Printf("restore %s.%s -> %s.%s, size: %d bytes\n", collBackup.GetDbName(), collBackup.GetCollectionName(),
+			collTask.GetTargetDbName(), collTask.GetTargetCollectionName(), collTask.GetToRestoreSize())
+		for _, partition := range collBackup.GetPartitionBackups() {
+			fmt.Printf("  partition %s, size: %d bytes\n", partition.GetPartitionName(), partition.GetSize())
+		}
+	}
+	for _, collection := range resp.GetData().GetSkippedCollections() {
+		fmt.Println("skip: " + collection)
+	}
+	report := resp.GetDryRunReport()
+	for _, db := range report.GetDatabasesToCreate() {
+		fmt.Println("database to create: " + db)
+	}
+	for _, conflict := range report.GetConflicts() {
+		fmt.Println("collection exists: " + conflict)
+	}
+	for _, alias := range report.GetAliasConflicts() {
+		fmt.Println("alias exists: " + alias)
+	}
+	for _, collection := range report.GetRepartitioned() {
+		fmt.Println("dispatch by partition key: " + collection)
+	}
+	for _, mismatch := range report.GetSchemaMismatches() {
+		fmt.Println("schema mismatch: " + mismatch)
+	}
+	for _, file := range report.GetMissingFiles() {
+		fmt.Println("missing: " + file)
+	}
+	for _, file := range report.GetCorruptedFiles() {
+		fmt.Println("corrupted: " + file)
+	}
+	for _, issue := range report.GetCompatIssues() {
+		fmt.Println("not supported by target milvus: " + formatCompatIssue(issue))
+	}
+	if resp.GetData() != nil {
+		fmt.Printf("total size: %d bytes\n", resp.GetData().GetToRestoreSize())
+	}
+	if report.GetEstimatedSeconds() > 0 {
+		fmt.Printf("estimated duration: %d s\n", report.GetEstimatedSeconds())
+	}
+	fmt.Println(resp.GetMsg())
+}
+
 func init() {
 	restoreBackupCmd.Flags().StringVarP(&restoreBackupName, "name", "n", "", "backup name to restore")
-	restoreBackupCmd.Flags().StringVarP(&restoreCollectionNames, "collections", "c", "", "collectionNames to restore")
+	restoreBackupCmd.Flags().StringVarP(&restoreSelector, "selector", "l", "", "restore the latest successful backup whose labels match the selector if --name is unset, like 'env=prod,app=search'")
+	restoreBackupCmd.Flags().StringVarP(&restoreCollectionNames, "collections", "c", "", "collectionNames to restore, use ',' to connect multiple collections, the others in backup are skipped")
 	restoreBackupCmd.Flags().StringVarP(&renameSuffix, "suffix", "s", "", "add a suffix to collection name to restore")
 	restoreBackupCmd.Flags().StringVarP(&renameCollectionNames, "rename", "r", "", "rename collections to new names, format: db1.collection1:db2.collection1_new,db1.collection2:db2.collection2_new")
+	restoreBackupCmd.Flags().StringVarP(&renameFile, "rename_file", "", "", "YAML file mapping collections to new names, like 'db1.collection1: db2.collection1_new', --rename has higher priority")
+	restoreBackupCmd.Flags().StringVarP(&restoreNameTemplate, "name_template", "", "", "template of new collection names, support {{db}}, {{name}} and {{date}}, like '{{name}}_restored_{{date}}'")
 	restoreBackupCmd.Flags().StringVarP(&restoreDatabases, "databases", "d", "", "databases to restore, if not set, restore all databases")
 	restoreBackupCmd.Flags().StringVarP(&restoreDatabaseCollections, "database_collections", "a", "", "databases and collections to restore, json format: {\"db1\":[\"c1\", \"c2\"],\"db2\":[]}")
+	restoreBackupCmd.Flags().StringVarP(&restoreTargetDatabase, "target_database", "", "", "restore collections into this database instead of their original ones, will be created if not exist")
+	restoreBackupCmd.Flags().StringArrayVarP(&restorePartitions, "partitions", "p", []string{}, "partitions to restore, format: collection:partition1,partition2, can be set more than once for multiple collections")
 
 	restoreBackupCmd.Flags().BoolVarP(&restoreMetaOnly, "meta_only", "", false, "if true, restore meta only")
 	restoreBackupCmd.Flags().BoolVarP(&restoreRestoreIndex, "restore_index", "", false, "if true, restore index")
+	restoreBackupCmd.Flags().StringVarP(&restoreIndexMode, "index_mode", "", "", "when to create the indexes in backup, immediate before data, deferred after data or skip, default immediate if --restore_index else skip")
 	restoreBackupCmd.Flags().BoolVarP(&restoreUseAutoIndex, "use_auto_index", "", false, "if true, replace vector index with autoindex")
 	restoreBackupCmd.Flags().BoolVarP(&restoreDropExistCollection, "drop_exist_collection", "", false, "if true, drop existing target collection before create")
 	restoreBackupCmd.Flags().BoolVarP(&restoreDropExistIndex, "drop_exist_index", "", false, "if true, drop existing index of target collection before create")
 	restoreBackupCmd.Flags().BoolVarP(&restoreSkipCreateCollection, "skip_create_collection", "", false, "if true, will skip collection, use when collection exist, restore index or data")
+	restoreBackupCmd.Flags().StringVarP(&restoreAliases, "restore_aliases", "", "", "how to restore the aliases of collections in backup, repoint, create or skip, repoint alters existing aliases to the restored collections, default skip")
+	restoreBackupCmd.Flags().StringVarP(&restoreProperties, "collection_properties", "", "", "collection properties to set over the properties in backup, format: collection.ttl.seconds=0,mmap.enabled=true")
+	restoreBackupCmd.Flags().BoolVarP(&restoreLoadCollection, "load_collection", "", false, "if true, load the restored collections loaded when backed up, after data and indexes are restored")
+	restoreBackupCmd.Flags().Int32VarP(&restoreReplicaNumber, "replica_number", "", 0, "replicas to load the collections with, the replica number in backup if not set")
+	restoreBackupCmd.Flags().StringVarP(&restoreResourceGroups, "resource_groups", "", "", "resource groups to load the collections into, the resource groups in backup if not set, format: rg1,rg2")
+	restoreBackupCmd.Flags().BoolVarP(&restoreCheckManifest, "check_manifest", "", false, "if true, check the sizes of the files to restore against the manifest of backup before restoring anything")
+	restoreBackupCmd.Flags().StringVarP(&restoreSchemaPolicy, "schema_policy", "", "", "how the schema of an existing collection may differ from backup with skip_create_collection, strict or compatible, compatible skips fields not in the collection, default strict")
+	restoreBackupCmd.Flags().StringVarP(&restoreFieldMappings, "field_mappings", "", "", "restore fields of backup into fields of other names of an existing collection, format: backup_field1:field1,backup_field2:field2")
+	restoreBackupCmd.Flags().BoolVarP(&restoreCheckRowCount, "check_row_count", "", false, "if true, compare the rows of each partition restored in milvus with the rows in backup after the data is restored, mismatches are printed")
+	restoreBackupCmd.Flags().Int32VarP(&restoreVerifySampleRows, "verify_sample_rows", "", 0, "if set, query up to the rows sampled by create --sample_rows in each restored collection once it is loaded, and compare them with backup, the scores are printed")
+	restoreBackupCmd.Flags().BoolVarP(&restoreDowngradeFeatures, "downgrade_features", "", false, "if true, restore collections using features the target milvus doesn't support without them, like fields of types not supported, check them by 'check --name'")
+	restoreBackupCmd.Flags().Uint64VarP(&restoreTimestamp, "timestamp", "", 0, "restore data to a point in time, unix timestamp in seconds, should not be later than the backup timestamp of the collections")
+	restoreBackupCmd.Flags().StringVarP(&restoreResumeTaskId, "resume", "", "", "id of an interrupted restore task to resume, the data already restored will be skipped")
+
+	restoreBackupCmd.Flags().BoolVarP(&restoreRbac, "rbac", "", false, "if true, restore users, roles and grants in backup as well")
+	restoreBackupCmd.Flags().StringVarP(&restoreRbacUserPassword, "rbac_user_password", "", "", "password of the users created by rbac restore, users not exist are skipped if not set")
+	restoreBackupCmd.Flags().BoolVarP(&restoreDryRun, "dry_run", "", false, "if true, only check the restore and print the plan, nothing is written to milvus")
+	restoreBackupCmd.Flags().StringVarP(&restoreSSECustomerKey, "sse_customer_key", "", "", "customer key of backup encrypted by sse type customer, if unset will use backup.serverSideEncryption.customerKey in config")
 
 	// won't print flags in character order
 	restoreBackupCmd.Flags().SortFlags = false
+	restoreBackupCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+	restoreBackupCmd.RegisterFlagCompletionFunc("collections", completeBackupCollectionNames)
 
 	rootCmd.AddCommand(restoreBackupCmd)
 }
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	resumeBackupName string
+)
+
+var resumeBackupCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "resume subcommand continue a paused or interrupted backup by name from its checkpoint.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		start := time.Now().Unix()
+		resp := backupContext.ResumeBackup(context, &backuppb.ResumeBackupRequest{
+			BackupName: resumeBackupName,
+			Async:      true,
+		})
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			printResponse(resp)
+			return
+		}
+		job := waitJob(context, backupContext, resp.GetJobId())
+		if jsonOutput() {
+			printJSONResult(backupJobResult(context, backupContext, job))
+			return
+		}
+		printJobResult(job)
+		duration := time.Now().Unix() - start
+		fmt.Println(fmt.Sprintf("duration:%d s", duration))
+	},
+}
+
+func init() {
+	resumeBackupCmd.Flags().StringVarP(&resumeBackupName, "name", "n", "", "resume backup with this name")
+	resumeBackupCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+
+	rootCmd.AddCommand(resumeBackupCmd)
+}
@@ -1,16 +1,30 @@
 package cmd
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
+	"go.uber.org/zap"
 
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
 	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/trace"
 )
 
 var (
-	config string
+	config  string
+	profile string
+
+	logLevel   string
+	logFormat  string
+	logFile    string
+	logModules string
+
+	settings []string
 )
 
 var rootCmd = &cobra.Command{
@@ -20,16 +34,80 @@ var rootCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		Error(cmd, args, errors.New("unrecognized command"))
 	},
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if profile != "" {
+			paramtable.Override(paramtable.ProfileKey, profile)
+		}
+		if err := initSettings(); err != nil {
+			return err
+		}
+		if err := initLogFlags(); err != nil {
+			return err
+		}
+		return initOutput()
+	},
+	PersistentPostRun: func(cmd *cobra.Command, args []string) {
+		// export the spans not sent yet before exit
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := trace.Shutdown(ctx); err != nil {
+			log.Warn("fail to export the remaining spans", zap.Error(err))
+		}
+	},
 }
 
 func Execute() {
 	rootCmd.PersistentFlags().StringVarP(&config, "config", "", "backup.yaml", "config YAML file of milvus")
+	rootCmd.PersistentFlags().StringVarP(&profile, "profile", "", "", "profile of the config to use, like prod or staging in the profiles section, profile in config if unset")
+	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", outputText, "output format of the result, support text and json")
+	rootCmd.PersistentFlags().StringVarP(&logLevel, "log_level", "", "", "log level, like debug, info and warn, log.level in config if unset")
+	rootCmd.PersistentFlags().StringVarP(&logFormat, "log_format", "", "", "log format, support text, console and json, log.format in config if unset")
+	rootCmd.PersistentFlags().StringVarP(&logFile, "log_file", "", "", "file to write logs into, log.file.rootPath in config if unset")
+	rootCmd.PersistentFlags().StringVarP(&logModules, "log_modules", "", "", "log levels of modules, like 'storage=debug,http=warn', support storage, milvus and http")
+	rootCmd.PersistentFlags().StringArrayVarP(&settings, "set", "", nil, "set a config key, like --set minio.address=localhost, can be repeated, overriding env and config")
+	rootCmd.PersistentFlags().BoolVarP(&interactive, "interactive", "i", false, "ask before destructive operations, like delete, prune, gc and restores dropping existing collections")
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 	rootCmd.Execute()
 }
 
+// initSettings overrides the config with the keys set by --set, the log flags are applied after and win
+func initSettings() error {
+	for _, setting := range settings {
+		key, value, err := paramtable.ParseOverride(setting)
+		if err != nil {
+			return err
+		}
+		paramtable.Override(key, value)
+	}
+	return nil
+}
+
+// initLogFlags overrides the log config with the log flags set
+func initLogFlags() error {
+	if logLevel != "" {
+		paramtable.Override("log.level", logLevel)
+	}
+	if logFormat != "" {
+		paramtable.Override("log.format", logFormat)
+	}
+	if logFile != "" {
+		paramtable.Override("log.file.rootPath", logFile)
+	}
+	if logModules != "" {
+		params, err := paramtable.ParseLogModules(logModules)
+		if err != nil {
+			return err
+		}
+		for key, value := range params {
+			paramtable.Override(key, value)
+		}
+	}
+	return nil
+}
+
 func SetVersionInfo(version, commit, date string) {
 	rootCmd.Version = fmt.Sprintf("%s (Built on %s from Git SHA %s)", version, date, commit)
+	core.SetBuildInfo(version, commit, date)
 	println(rootCmd.Version)
 	log.Info(fmt.Sprintf("Milvus backup version: %s", rootCmd.Version))
 }
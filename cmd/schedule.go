@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+var (
+	schedulePort string
+)
+
+var scheduleCmd = &cobra.Command{
+	Use:   "schedule",
+	Short: "schedule subcommand start milvus-backup RESTAPI server and create backups by the schedule jobs in config.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		fmt.Println("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		if len(params.ScheduleCfg.Jobs) == 0 {
+			fmt.Println("no schedule job is configured, add jobs into the schedule section of config")
+			return
+		}
+
+		context := context.Background()
+		server, err := core.NewServer(context, params, core.Port(schedulePort), core.Schedule(true))
+		if err != nil {
+			fmt.Println("fail to create backup server, " + err.Error())
+			return
+		}
+		server.Init()
+		server.Start()
+	},
+}
+
+func init() {
+	scheduleCmd.Flags().StringVarP(&schedulePort, "port", "p", "8080", "Port to listen")
+
+	rootCmd.AddCommand(scheduleCmd)
+}
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	transferDir            string
+	transferBandwidthLimit int
+	transferSSECustomerKey string
+	transferForce          bool
+	transferVerify         bool
+	copyTargetConfig       string
+	copyTargetProfile      string
+)
+
+var downloadCmd = &cobra.Command{
+	Use:               "download <backup_name>",
+	Short:             "download subcommand download a complete backup, meta and binlogs, from the backup storage into a local dir, resumed by downloading again.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if transferDir == "" {
+			printError("local dir is required")
+			return
+		}
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		start := time.Now().Unix()
+		result, err := backupContext.DownloadBackup(context, args[0], transferDir, transferOptions())
+		printTransferResult(err, func() string {
+			return fmt.Sprintf("downloaded %s into %s: %d files, %s, %d files skipped as downloaded already",
+				args[0], transferDir, result.Files, formatSize(result.Size), result.SkippedFiles)
+		}, start)
+	},
+}
+
+var uploadCmd = &cobra.Command{
+	Use:   "upload <backup_name>",
+	Short: "upload subcommand upload a backup downloaded by download from a local dir into the backup storage, resumed by uploading again.",
+	Args:  cobra.ExactArgs(1),
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if transferDir == "" {
+			printError("local dir is required")
+			return
+		}
+		if transferForce && !confirm("overwrite backup %s if it exists in the backup storage?", args[0]) {
+			printCanceled()
+			return
+		}
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		start := time.Now().Unix()
+		result, err := backupContext.UploadBackup(context, transferDir, args[0], transferOptions())
+		printTransferResult(err, func() string {
+			return fmt.Sprintf("uploaded %s from %s: %d files, %s, %d files skipped as uploaded already",
+				args[0], transferDir, result.Files, formatSize(result.Size), result.SkippedFiles)
+		}, start)
+	},
+}
+
+var copyCmd = &cobra.Command{
+	Use:               "copy <backup_name>",
+	Short:             "copy subcommand copy a backup, meta and binlogs, into the backup storage of --target_config or --target_profile, checking the checksums, without backing up milvus again.",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		if copyTargetConfig == "" && copyTargetProfile == "" {
+			printError("target config or target profile is required")
+			return
+		}
+		if transferForce && !confirm("overwrite backup %s if it exists in the target backup storage?", args[0]) {
+			printCanceled()
+			return
+		}
+		var params paramtable.BackupParams
+		printText("source config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		var targetParams paramtable.BackupParams
+		if copyTargetProfile != "" {
+			printText("target profile:" + copyTargetProfile)
+			targetParams.InitWithProfile(copyTargetConfig, copyTargetProfile)
+		} else {
+			printText("target config:" + copyTargetConfig)
+			targetParams.InitWithYaml(copyTargetConfig)
+		}
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+		targetContext := core.CreateBackupContext(context, targetParams)
+
+		start := time.Now().Unix()
+		result, err := backupContext.CopyBackup(context, targetContext, args[0], transferOptions())
+		printTransferResult(err, func() string {
+			return fmt.Sprintf("copied %s: %d files, %s, %d files skipped as copied already",
+				args[0], result.Files, formatSize(result.Size), result.SkippedFiles)
+		}, start)
+	},
+}
+
+// transferOptions returns the options of the flags, printing a progress bar whenever the progress changes
+func transferOptions() core.TransferOptions {
+	lastProgress := -1
+	return core.TransferOptions{
+		BandwidthLimit: transferBandwidthLimit,
+		SseCustomerKey: transferSSECustomerKey,
+		Force:          transferForce,
+		Verify:         transferVerify,
+		Progress: func(done int64, total int64) {
+			progress := 100
+			if total > 0 {
+				progress = int(done * 100 / total)
+			}
+			if progress != lastProgress && !jsonOutput() {
+				fmt.Println(renderProgressBar(progress, done, total))
+				lastProgress = progress
+			}
+		},
+	}
+}
+
+func printTransferResult(err error, msg func() string, start int64) {
+	if err != nil {
+		printFailure(err)
+		return
+	}
+	printResponse(&commandResult{Code: backuppb.ResponseCode_Success, Msg: msg()})
+	if !jsonOutput() {
+		fmt.Println(fmt.Sprintf("duration:%d s", time.Now().Unix()-start))
+	}
+}
+
+func init() {
+	downloadCmd.Flags().StringVarP(&transferDir, "to", "", "", "local dir to download the backup into, in the layout of the backup root")
+	uploadCmd.Flags().StringVarP(&transferDir, "from", "", "", "local dir the backup is downloaded into by download")
+	uploadCmd.Flags().BoolVarP(&transferForce, "force", "", false, "upload a backup existing in the backup storage, the files of the same sizes are not uploaded again")
+	copyCmd.Flags().StringVarP(&copyTargetConfig, "target_config", "t", "", "config YAML file of the backup storage to copy into")
+	copyCmd.Flags().StringVarP(&copyTargetProfile, "target_profile", "", "", "profile of the backup storage to copy into, in --target_config or else --config")
+	copyCmd.Flags().BoolVarP(&transferForce, "force", "", false, "copy a backup existing in the target backup storage, the files of the same sizes are not copied again")
+	copyCmd.Flags().BoolVarP(&transferVerify, "verify", "", true, "read the files copied back from the target to compare their checksums")
+	for _, cmd := range []*cobra.Command{downloadCmd, uploadCmd, copyCmd} {
+		cmd.Flags().IntVarP(&transferBandwidthLimit, "bandwidth_limit", "", 0, "max bandwidth in MB/s of the data transferred, not limited other than by backup.bandwidthLimit in config if 0")
+		cmd.Flags().StringVarP(&transferSSECustomerKey, "sse_customer_key", "", "", "customer key of a backup encrypted by sse type customer, the key in config if unset")
+	}
+
+	rootCmd.AddCommand(downloadCmd, uploadCmd, copyCmd)
+}
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/kube"
+)
+
+var (
+	veleroBackupName      string
+	veleroNameTemplate    string
+	veleroCollectionNames string
+	veleroMetaOnly        bool
+	veleroLabels          string
+	veleroConfigMap       string
+	veleroNamespace       string
+	veleroRestoreIndex    bool
+)
+
+var veleroCmd = &cobra.Command{
+	Use:   "velero",
+	Short: "velero subcommand runs the backup and restore hooks of Velero in a pod of the namespace of milvus.",
+}
+
+var veleroPreBackupCmd = &cobra.Command{
+	Use:   "pre-backup",
+	Short: "pre-backup flushes the collections and creates a backup before Velero snapshots the volumes, the backup is recorded in a configmap backed up by Velero.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		labelDict, err := parseLabels(veleroLabels)
+		if err != nil {
+			veleroFail(err.Error())
+		}
+		collectionNameArr := []string{}
+		if veleroCollectionNames != "" {
+			collectionNameArr = strings.Split(veleroCollectionNames, ",")
+		}
+		// the collections are flushed by the backup, so that the data in the volume snapshots is persisted
+		resp := backupContext.CreateBackup(context, &backuppb.CreateBackupRequest{
+			BackupName:      veleroBackupName,
+			NameTemplate:    veleroNameTemplate,
+			CollectionNames: collectionNameArr,
+			MetaOnly:        veleroMetaOnly,
+			Labels:          labelDict,
+			Async:           true,
+		})
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			veleroFail(resp.GetMsg())
+		}
+		job := waitJob(context, backupContext, resp.GetJobId())
+		result := backupJobResult(context, backupContext, job)
+		if result.GetCode() != backuppb.ResponseCode_Success {
+			veleroFail(result.GetMsg())
+		}
+		printText(fmt.Sprintf("backup: %s", result.GetData().GetName()))
+
+		if veleroConfigMap != "" {
+			item := core.NewVeleroBackupItem(result.GetData(), params.MinioCfg.BackupBucketName, params.MinioCfg.BackupRootPath, veleroMetaOnly)
+			data, err := item.Marshal()
+			if err != nil {
+				veleroFail(err.Error())
+			}
+			client, namespace, err := veleroKubeClient()
+			if err != nil {
+				veleroFail(err.Error())
+			}
+			err = client.ApplyConfigMap(context, &kube.ConfigMap{
+				Metadata: kube.ObjectMeta{
+					Name:      veleroConfigMap,
+					Namespace: namespace,
+					Labels:    map[string]string{"app.kubernetes.io/managed-by": "milvus-backup"},
+				},
+				Data: map[string]string{core.VeleroBackupItemKey: data},
+			})
+			if err != nil {
+				veleroFail(fmt.Sprintf("fail to record backup in configmap %s/%s: %s", namespace, veleroConfigMap, err))
+			}
+			printText(fmt.Sprintf("recorded in configmap: %s/%s", namespace, veleroConfigMap))
+		}
+		if jsonOutput() {
+			printJSON(result)
+		}
+	},
+}
+
+var veleroPostRestoreCmd = &cobra.Command{
+	Use:   "post-restore",
+	Short: "post-restore restores the collections missing after Velero restores the volumes, from the backup recorded in the configmap by pre-backup.",
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		printText("config:" + config)
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		client, namespace, err := veleroKubeClient()
+		if err != nil {
+			veleroFail(err.Error())
+		}
+		configMap, err := client.GetConfigMap(context, namespace, veleroConfigMap)
+		if err != nil {
+			veleroFail(fmt.Sprintf("fail to read configmap %s/%s: %s", namespace, veleroConfigMap, err))
+		}
+		item, err := core.UnmarshalVeleroBackupItem(configMap.Data[core.VeleroBackupItemKey])
+		if err != nil {
+			veleroFail(err.Error())
+		}
+		printText(fmt.Sprintf("backup: %s", item.BackupName))
+
+		missing, existing, err := backupContext.VeleroMissingCollections(context, item)
+		if err != nil {
+			veleroFail(err.Error())
+		}
+		for _, collection := range existing {
+			printText("exists: " + collection)
+		}
+		if len(missing) == 0 {
+			printResponse(&commandResult{Code: backuppb.ResponseCode_Success, Msg: "success"})
+			return
+		}
+		if item.MetaOnly {
+			veleroFail(fmt.Sprintf("collections %s are missing, backup %s has no data to restore them, restore the volumes of milvus with velero",
+				strings.Join(missing, ", "), item.BackupName))
+		}
+
+		resp := backupContext.RestoreBackup(context, &backuppb.RestoreBackupRequest{
+			BackupName:      item.BackupName,
+			CollectionNames: missing,
+			BucketName:      item.BucketName,
+			Path:            item.Path,
+			RestoreIndex:    veleroRestoreIndex,
+			Async:           true,
+		})
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			veleroFail(resp.GetMsg())
+		}
+		for _, collection := range missing {
+			printText("restore: " + collection)
+		}
+		job := waitJob(context, backupContext, resp.GetJobId())
+		result := restoreJobResult(context, backupContext, job)
+		if jsonOutput() {
+			printJSONResult(result)
+			return
+		}
+		printJobResult(job)
+		if result.GetCode() != backuppb.ResponseCode_Success {
+			os.Exit(1)
+		}
+	},
+}
+
+// veleroKubeClient returns the client of the pod running the hook, and the namespace of the configmap
+func veleroKubeClient() (*kube.Client, string, error) {
+	client, err := kube.NewInClusterClient()
+	if err != nil {
+		return nil, "", err
+	}
+	namespace := veleroNamespace
+	if namespace == "" {
+		namespace = client.Namespace()
+	}
+	return client, namespace, nil
+}
+
+// veleroFail prints the error and exits with 1, so that Velero fails the hook
+func veleroFail(msg string) {
+	printError(msg)
+	os.Exit(1)
+}
+
+func init() {
+	veleroPreBackupCmd.Flags().StringVarP(&veleroBackupName, "name", "n", "", "backup name, if unset will generate a name by --name_template")
+	veleroPreBackupCmd.Flags().StringVarP(&veleroNameTemplate, "name_template", "", "velero_{{date}}_{{time}}", "template of the name generated if --name is unset, support {{date}}, {{time}} and {{cluster}}")
+	veleroPreBackupCmd.Flags().StringVarP(&veleroCollectionNames, "colls", "c", "", "collectionNames to backup, use ',' to connect multiple collections, all collections if unset")
+	veleroPreBackupCmd.Flags().BoolVarP(&veleroMetaOnly, "meta_only", "", true, "only backup collection meta, the data is in the volume snapshots of Velero. set false to copy the data into backup storage as well")
+	veleroPreBackupCmd.Flags().StringVarP(&veleroLabels, "labels", "l", "source=velero", "key/value labels attached to the backup, like 'env=prod,app=search'")
+	veleroPreBackupCmd.Flags().StringVarP(&veleroConfigMap, "configmap", "", "milvus-backup-velero", "configmap to record the backup in, not recorded if empty")
+	veleroPreBackupCmd.Flags().SortFlags = false
+
+	veleroPostRestoreCmd.Flags().StringVarP(&veleroConfigMap, "configmap", "", "milvus-backup-velero", "configmap recorded by pre-backup, restored by Velero")
+	veleroPostRestoreCmd.Flags().BoolVarP(&veleroRestoreIndex, "restore_index", "", true, "restore the indexes of the collections restored from backup")
+
+	veleroCmd.PersistentFlags().StringVarP(&veleroNamespace, "namespace", "", "", "namespace of the configmap, the namespace of the pod if unset")
+	veleroCmd.AddCommand(veleroPreBackupCmd)
+	veleroCmd.AddCommand(veleroPostRestoreCmd)
+	rootCmd.AddCommand(veleroCmd)
+}
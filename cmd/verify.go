@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/zilliztech/milvus-backup/core"
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+var (
+	verifyBackupName     string
+	verifySSECustomerKey string
+)
+
+var verifyBackupCmd = &cobra.Command{
+	Use:               "verify [backup_name]",
+	Short:             "verify subcommand check the existence, size and checksum of all files of a backup.",
+	Args:              cobra.MaximumNArgs(1),
+	ValidArgsFunction: completeBackupNameArg,
+
+	Run: func(cmd *cobra.Command, args []string) {
+		var params paramtable.BackupParams
+		params.GlobalInitWithYaml(config)
+		params.Init()
+
+		if len(args) > 0 {
+			verifyBackupName = args[0]
+		}
+
+		context := context.Background()
+		backupContext := core.CreateBackupContext(context, params)
+
+		resp := backupContext.VerifyBackup(context, &backuppb.VerifyBackupRequest{
+			BackupName:     verifyBackupName,
+			SseCustomerKey: verifySSECustomerKey,
+		})
+
+		if jsonOutput() {
+			printJSONResult(resp)
+			return
+		}
+		for _, file := range resp.GetMissingFiles() {
+			fmt.Println("missing: " + file)
+		}
+		for _, file := range resp.GetCorruptedFiles() {
+			fmt.Println("corrupted: " + file)
+		}
+		if resp.GetManifest() {
+			fmt.Printf("checked %d files of manifest, %d by checksum\n", resp.GetCheckedFiles(), resp.GetChecksumFiles())
+		} else {
+			fmt.Printf("checked %d files, %d by checksum\n", resp.GetCheckedFiles(), resp.GetChecksumFiles())
+		}
+		fmt.Println(resp.GetMsg())
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	verifyBackupCmd.Flags().StringVarP(&verifyBackupName, "name", "n", "", "verify backup with this name")
+	verifyBackupCmd.Flags().StringVarP(&verifySSECustomerKey, "sse_customer_key", "", "", "customer key of backup encrypted by sse type customer, if unset will use backup.serverSideEncryption.customerKey in config")
+	verifyBackupCmd.RegisterFlagCompletionFunc("name", completeBackupNames)
+
+	rootCmd.AddCommand(verifyBackupCmd)
+}
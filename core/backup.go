@@ -15,10 +15,32 @@ type Backup interface {
 	ListBackups(context.Context, *backuppb.ListBackupsRequest) *backuppb.ListBackupsResponse
 	// Delete backuppb by given backuppb name
 	DeleteBackup(context.Context, *backuppb.DeleteBackupRequest) *backuppb.DeleteBackupResponse
+	// Pause a running backup, it can be resumed from the checkpoint later
+	PauseBackup(context.Context, *backuppb.PauseBackupRequest) *backuppb.PauseBackupResponse
+	// Resume a paused or interrupted backup from the checkpoint
+	ResumeBackup(context.Context, *backuppb.ResumeBackupRequest) *backuppb.BackupInfoResponse
+	// Delete backups expired by the retention policy
+	PruneBackups(context.Context, *backuppb.PruneBackupsRequest) *backuppb.PruneBackupsResponse
+	// Remove orphaned files in backup storage not referenced by any backup
+	GarbageCollect(context.Context, *backuppb.GarbageCollectRequest) *backuppb.GarbageCollectResponse
+	// Check the existence, size and checksum of all files of a backup
+	VerifyBackup(context.Context, *backuppb.VerifyBackupRequest) *backuppb.VerifyBackupResponse
+	// Estimate the collections, segments and size a backup would contain
+	EstimateBackup(context.Context, *backuppb.CreateBackupRequest) *backuppb.EstimateBackupResponse
 	// Restore the backup data into milvus
 	RestoreBackup(context.Context, *backuppb.RestoreBackupRequest) *backuppb.RestoreBackupResponse
 	// Get restore state by given id
 	GetRestore(context.Context, *backuppb.GetRestoreStateRequest) *backuppb.RestoreBackupResponse
+	// Get the state and progress of a backup or restore job
+	GetJob(context.Context, *backuppb.GetJobRequest) *backuppb.JobResponse
+	// Cancel a running backup or restore job
+	CancelJob(context.Context, *backuppb.CancelJobRequest) *backuppb.JobResponse
+	// List the backup and restore jobs, including the ones recorded in backup storage by other servers
+	ListJobs(context.Context, *backuppb.ListJobsRequest) *backuppb.ListJobsResponse
+	// Resume an interrupted, failed or canceled job from its checkpoint
+	ResumeJob(context.Context, *backuppb.ResumeJobRequest) *backuppb.JobResponse
+	// Check the features used by the collections of a backup against a target milvus version
+	CheckCompatibility(context.Context, *backuppb.CheckCompatibilityRequest) *backuppb.CheckCompatibilityResponse
 	// Copy backuppb between buckets
 	//CopyBackup(context.Context, *backuppb.CopyBackupRequest) (*backuppb.CopyBackupResponse, error)
 }
@@ -0,0 +1,187 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+// SegmentDir is a dir of the binlogs of a segment under a root path of milvus
+type SegmentDir struct {
+	RootPath string
+	// ends with the separator
+	Dir string
+}
+
+// BinlogLayout resolves where milvus stores the binlogs of segments in its bucket. The default one is configured by
+// minio.rootPath, minio.extraRootPaths and minio.binlogPathTemplate, another one is set by SetBinlogLayout.
+type BinlogLayout interface {
+	// SegmentDirs returns the candidate dirs of the logs of logType, insert_log or delta_log, of a segment, in the
+	// order to look them up
+	SegmentDirs(logType string, collectionID, partitionID, segmentID int64) []SegmentDir
+	// Detected records the root path the segments of a collection are found under, its dirs are returned first
+	// by SegmentDirs afterwards
+	Detected(collectionID int64, rootPath string)
+	// RelativePath returns the path of a binlog of the segment relative to its root path in the standard layout of
+	// milvus, like insert_log/collection_id/partition_id/segment_id/field_id/log_id, false if it isn't in the layout
+	RelativePath(logPath string, collectionID, partitionID, segmentID int64) (string, bool)
+	// CollectionDirs returns the dirs holding the insert and delta logs of all the segments of a collection, under
+	// every root path, to scan the binlogs of the collection in the bucket
+	CollectionDirs(collectionID int64) []string
+	// ParseSegment returns the log type, partition and segment of an insert or delta log of the collection listed
+	// under CollectionDirs, false if the path isn't one in the layout
+	ParseSegment(logPath string, collectionID int64) (logType string, partitionID, segmentID int64, ok bool)
+}
+
+// templateBinlogLayout renders the dirs of segments by a template under a list of root paths, the first one is
+// the root path of milvus
+type templateBinlogLayout struct {
+	template  string
+	rootPaths []string
+
+	// index of the root path detected by collection id
+	detected sync.Map
+
+	patternsOnce sync.Once
+	// patterns of the insert and delta logs under each root path
+	patterns []*regexp.Regexp
+}
+
+func newTemplateBinlogLayout(rootPath string, cfg paramtable.MinioConfig) *templateBinlogLayout {
+	template := cfg.BinlogPathTemplate
+	if template == "" {
+		template = paramtable.DefaultBinlogPathTemplate
+	}
+	rootPaths := []string{strings.Trim(rootPath, SEPERATOR)}
+	for _, rootPath := range cfg.ExtraRootPaths {
+		if rootPath != rootPaths[0] {
+			rootPaths = append(rootPaths, rootPath)
+		}
+	}
+	return &templateBinlogLayout{template: template, rootPaths: rootPaths}
+}
+
+func (l *templateBinlogLayout) render(rootPath string, logType string, collectionID, partitionID, segmentID int64) string {
+	dir := strings.NewReplacer(
+		paramtable.BinlogPathRootPath, rootPath,
+		paramtable.BinlogPathLogType, logType,
+		paramtable.BinlogPathCollectionID, strconv.FormatInt(collectionID, 10),
+		paramtable.BinlogPathPartitionID, strconv.FormatInt(partitionID, 10),
+		paramtable.BinlogPathSegmentID, strconv.FormatInt(segmentID, 10),
+	).Replace(l.template)
+	// an empty root path leaves a leading separator
+	return strings.TrimPrefix(dir, SEPERATOR) + SEPERATOR
+}
+
+// orderedRootPaths returns the root paths with the one detected for the collection first
+func (l *templateBinlogLayout) orderedRootPaths(collectionID int64) []string {
+	value, ok := l.detected.Load(collectionID)
+	if !ok || value.(int) == 0 {
+		return l.rootPaths
+	}
+	index := value.(int)
+	ordered := make([]string, 0, len(l.rootPaths))
+	ordered = append(ordered, l.rootPaths[index])
+	ordered = append(ordered, l.rootPaths[:index]...)
+	return append(ordered, l.rootPaths[index+1:]...)
+}
+
+func (l *templateBinlogLayout) SegmentDirs(logType string, collectionID, partitionID, segmentID int64) []SegmentDir {
+	rootPaths := l.orderedRootPaths(collectionID)
+	dirs := make([]SegmentDir, 0, len(rootPaths))
+	for _, rootPath := range rootPaths {
+		dirs = append(dirs, SegmentDir{RootPath: rootPath, Dir: l.render(rootPath, logType, collectionID, partitionID, segmentID)})
+	}
+	return dirs
+}
+
+func (l *templateBinlogLayout) Detected(collectionID int64, rootPath string) {
+	for i, candidate := range l.rootPaths {
+		if candidate == rootPath {
+			l.detected.Store(collectionID, i)
+			return
+		}
+	}
+}
+
+func (l *templateBinlogLayout) RelativePath(logPath string, collectionID, partitionID, segmentID int64) (string, bool) {
+	for _, rootPath := range l.orderedRootPaths(collectionID) {
+		for _, logType := range []string{INSERT_LOG_DIR, DELTA_LOG_DIR, STATS_LOG_DIR} {
+			dir := l.render(rootPath, logType, collectionID, partitionID, segmentID)
+			if strings.HasPrefix(logPath, dir) {
+				return fmt.Sprintf("%s/%d/%d/%d/%s", logType, collectionID, partitionID, segmentID, strings.TrimPrefix(logPath, dir)), true
+			}
+		}
+	}
+	return "", false
+}
+
+func (l *templateBinlogLayout) CollectionDirs(collectionID int64) []string {
+	dirs := make([]string, 0, len(l.rootPaths)*2)
+	seen := make(map[string]bool)
+	for _, rootPath := range l.rootPaths {
+		for _, logType := range []string{INSERT_LOG_DIR, DELTA_LOG_DIR} {
+			dir := strings.NewReplacer(
+				paramtable.BinlogPathRootPath, rootPath,
+				paramtable.BinlogPathLogType, logType,
+				paramtable.BinlogPathCollectionID, strconv.FormatInt(collectionID, 10),
+			).Replace(l.template)
+			// cut at the dir of the first placeholder left, of partitions or segments
+			if i := strings.Index(dir, "{{"); i >= 0 {
+				dir = dir[:strings.LastIndex(dir[:i], SEPERATOR)+1]
+			}
+			dir = strings.TrimPrefix(dir, SEPERATOR)
+			if !strings.HasSuffix(dir, SEPERATOR) && dir != "" {
+				dir += SEPERATOR
+			}
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
+			}
+		}
+	}
+	return dirs
+}
+
+func (l *templateBinlogLayout) ParseSegment(logPath string, collectionID int64) (string, int64, int64, bool) {
+	l.patternsOnce.Do(func() {
+		for _, rootPath := range l.rootPaths {
+			pattern := strings.NewReplacer(
+				regexp.QuoteMeta(paramtable.BinlogPathRootPath), regexp.QuoteMeta(rootPath),
+				regexp.QuoteMeta(paramtable.BinlogPathLogType), fmt.Sprintf("(?P<logType>%s|%s)", INSERT_LOG_DIR, DELTA_LOG_DIR),
+				regexp.QuoteMeta(paramtable.BinlogPathCollectionID), `(?P<collectionID>\d+)`,
+				regexp.QuoteMeta(paramtable.BinlogPathPartitionID), `(?P<partitionID>\d+)`,
+				regexp.QuoteMeta(paramtable.BinlogPathSegmentID), `(?P<segmentID>\d+)`,
+			).Replace(regexp.QuoteMeta(l.template))
+			l.patterns = append(l.patterns, regexp.MustCompile("^"+strings.TrimPrefix(pattern, SEPERATOR)+SEPERATOR))
+		}
+	})
+	for _, pattern := range l.patterns {
+		match := pattern.FindStringSubmatch(logPath)
+		if match == nil {
+			continue
+		}
+		var logType string
+		var ids [3]int64
+		for i, name := range pattern.SubexpNames() {
+			switch name {
+			case "logType":
+				logType = match[i]
+			case "collectionID":
+				ids[0], _ = strconv.ParseInt(match[i], 10, 64)
+			case "partitionID":
+				ids[1], _ = strconv.ParseInt(match[i], 10, 64)
+			case "segmentID":
+				ids[2], _ = strconv.ParseInt(match[i], 10, 64)
+			}
+		}
+		if ids[0] == collectionID {
+			return logType, ids[1], ids[2], true
+		}
+	}
+	return "", 0, 0, false
+}
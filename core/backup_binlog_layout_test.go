@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+// dirChunkManager lists the files and dirs directly under the prefix when not recursive, like minio
+type dirChunkManager struct {
+	memoryChunkManager
+}
+
+func (m *dirChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	if recursive {
+		return m.memoryChunkManager.ListWithPrefix(ctx, bucketName, prefix, recursive)
+	}
+	children := make(map[string]int64)
+	for file, content := range m.files {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		rest := strings.TrimPrefix(file, prefix)
+		if index := strings.Index(rest, SEPERATOR); index >= 0 {
+			children[prefix+rest[:index+1]] = 0
+		} else {
+			children[file] = int64(len(content))
+		}
+	}
+	paths := make([]string, 0, len(children))
+	for path := range children {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	sizes := make([]int64, 0, len(paths))
+	for _, path := range paths {
+		sizes = append(sizes, children[path])
+	}
+	return paths, sizes, nil
+}
+
+func TestTemplateBinlogLayout(t *testing.T) {
+	layout := newTemplateBinlogLayout("files", paramtable.MinioConfig{ExtraRootPaths: []string{"tenant-a/files", "files"}})
+	assert.Equal(t, []SegmentDir{
+		{RootPath: "files", Dir: "files/insert_log/1/2/3/"},
+		{RootPath: "tenant-a/files", Dir: "tenant-a/files/insert_log/1/2/3/"},
+	}, layout.SegmentDirs(INSERT_LOG_DIR, 1, 2, 3))
+
+	layout.Detected(1, "tenant-a/files")
+	assert.Equal(t, "tenant-a/files/delta_log/1/2/3/", layout.SegmentDirs(DELTA_LOG_DIR, 1, 2, 3)[0].Dir)
+	assert.Equal(t, "files/insert_log/4/2/3/", layout.SegmentDirs(INSERT_LOG_DIR, 4, 2, 3)[0].Dir)
+
+	relativePath, ok := layout.RelativePath("tenant-a/files/delta_log/1/2/3/100/5", 1, 2, 3)
+	assert.True(t, ok)
+	assert.Equal(t, "delta_log/1/2/3/100/5", relativePath)
+	_, ok = layout.RelativePath("other/insert_log/1/2/3/100/5", 1, 2, 3)
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"files/insert_log/1/", "files/delta_log/1/", "tenant-a/files/insert_log/1/", "tenant-a/files/delta_log/1/"},
+		layout.CollectionDirs(1))
+	logType, partitionID, segmentID, ok := layout.ParseSegment("tenant-a/files/delta_log/1/2/3/100/5", 1)
+	assert.True(t, ok)
+	assert.Equal(t, DELTA_LOG_DIR, logType)
+	assert.Equal(t, []int64{2, 3}, []int64{partitionID, segmentID})
+	_, _, _, ok = layout.ParseSegment("files/insert_log/4/2/3/100/5", 1)
+	assert.False(t, ok)
+	_, _, _, ok = layout.ParseSegment("files/stats_log/1/2/3/100/5", 1)
+	assert.False(t, ok)
+
+	// the logs of each partition under its own dir before the log type
+	layout = newTemplateBinlogLayout("", paramtable.MinioConfig{BinlogPathTemplate: "{{rootPath}}/c{{collectionID}}/{{logType}}/{{partitionID}}/{{segmentID}}"})
+	assert.Equal(t, "c1/insert_log/2/3/", layout.SegmentDirs(INSERT_LOG_DIR, 1, 2, 3)[0].Dir)
+	relativePath, ok = layout.RelativePath("c1/insert_log/2/3/100/5", 1, 2, 3)
+	assert.True(t, ok)
+	assert.Equal(t, "insert_log/1/2/3/100/5", relativePath)
+	assert.Equal(t, []string{"c1/insert_log/", "c1/delta_log/"}, layout.CollectionDirs(1))
+	logType, partitionID, segmentID, ok = layout.ParseSegment("c1/insert_log/2/3/100/5", 1)
+	assert.True(t, ok)
+	assert.Equal(t, INSERT_LOG_DIR, logType)
+	assert.Equal(t, []int64{2, 3}, []int64{partitionID, segmentID})
+}
+
+func TestFillSegmentBackupInfoRootPaths(t *testing.T) {
+	chunkManager := &dirChunkManager{memoryChunkManager{files: map[string][]byte{
+		"files/insert_log/1/2/3/100/10":          []byte("pk"),
+		"tenant-a/files/insert_log/4/5/6/100/10": []byte("pk"),
+		"tenant-a/files/insert_log/4/5/6/101/11": []byte("vector"),
+		"tenant-a/files/delta_log/4/5/6/100/12":  []byte("delete"),
+		"files/delta_log/4/5/6/100/13":           []byte("not this root"),
+	}}}
+	var storageClient storage.ChunkManager = chunkManager
+	b := &BackupContext{storageClient: &storageClient, milvusRootPath: "files", backupRootPath: "backup"}
+	b.params.MinioCfg.ExtraRootPaths = []string{"tenant-a/files"}
+	ctx := context.Background()
+
+	segment, err := b.fillSegmentBackupInfo(ctx, &backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 3})
+	assert.NoError(t, err)
+	assert.Equal(t, "files/insert_log/1/2/3/100/10", segment.GetBinlogs()[0].GetBinlogs()[0].GetLogPath())
+
+	segment, err = b.fillSegmentBackupInfo(ctx, &backuppb.SegmentBackupInfo{CollectionId: 4, PartitionId: 5, SegmentId: 6})
+	assert.NoError(t, err)
+	assert.Len(t, segment.GetBinlogs(), 2)
+	assert.Equal(t, int64(101), segment.GetBinlogs()[1].GetFieldID())
+	assert.Equal(t, "tenant-a/files/delta_log/4/5/6/100/12", segment.GetDeltalogs()[0].GetBinlogs()[0].GetLogPath())
+	assert.Equal(t, int64(14), segment.GetSize())
+	// stored in the same layout of backup as the binlogs of the root path of milvus
+	assert.Equal(t, "backup/test_backup/binlogs/insert_log/4/5/6/101/11",
+		b.binlogBackupPath("test_backup", nil, segment, "tenant-a/files/insert_log/4/5/6/101/11"))
+
+	_, err = b.fillSegmentBackupInfo(ctx, &backuppb.SegmentBackupInfo{CollectionId: 7, PartitionId: 8, SegmentId: 9})
+	assert.ErrorContains(t, err, "files/insert_log/7/8/9/, tenant-a/files/insert_log/7/8/9/")
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// states of a binlog scan
+const (
+	BinlogScanScanned = "scanned"
+	BinlogScanSkipped = "skipped"
+)
+
+// orphan segment ids recorded in a binlog scan at most
+const maxOrphanSegmentIDs = 100
+
+// scanCollectionBinlogs compares the insert and delta logs of a collection in the bucket of milvus with its
+// persistent segments, called right after the collection is flushed. A failure of the scan doesn't fail the backup,
+// the scan is just recorded as skipped.
+func (b *BackupContext) scanCollectionBinlogs(ctx context.Context, collectionBackup *backuppb.CollectionBackupInfo) {
+	db, collectionName := collectionBackup.GetDbName(), collectionBackup.GetCollectionName()
+	segments, err := b.getMilvusClient().GetPersistentSegmentInfo(ctx, db, collectionName)
+	if err != nil {
+		collectionBackup.BinlogScan = &backuppb.BinlogScan{State: BinlogScanSkipped, Detail: "fail to get the persistent segments: " + err.Error()}
+		log.Warn("fail to scan binlogs of collection", zap.String("db", db), zap.String("collection", collectionName), zap.Error(err))
+		return
+	}
+	segmentIDs := make([]int64, 0, len(segments))
+	for _, segment := range segments {
+		segmentIDs = append(segmentIDs, segment.ID)
+	}
+	scan, err := scanBinlogs(ctx, b.getStorageClient(), b.milvusBucketName, b.getBinlogLayout(), collectionBackup.GetCollectionId(), segmentIDs)
+	if err != nil {
+		collectionBackup.BinlogScan = &backuppb.BinlogScan{State: BinlogScanSkipped, Detail: "fail to list the binlogs: " + err.Error()}
+		log.Warn("fail to scan binlogs of collection", zap.String("db", db), zap.String("collection", collectionName), zap.Error(err))
+		return
+	}
+	collectionBackup.BinlogScan = scan
+	log.Info("scan binlogs of collection", zap.String("db", db), zap.String("collection", collectionName),
+		zap.Int64("referenced_files", scan.GetReferencedFiles()), zap.Int64("referenced_size", scan.GetReferencedSize()),
+		zap.Int64("orphan_files", scan.GetOrphanFiles()), zap.Int64("orphan_size", scan.GetOrphanSize()))
+	for _, finding := range binlogScanFindings(db, collectionName, scan) {
+		log.Warn("binlog scan finding", zap.String("detail", finding))
+	}
+}
+
+// scanBinlogs walks the insert and delta logs of a collection under the dirs of the layout, the logs of the persistent
+// segments are referenced and the others are orphans, like the leftovers of compaction and dropped segments the gc
+// of milvus hasn't collected. Persistent segments without insert logs are missing.
+func scanBinlogs(ctx context.Context, client storage.ChunkManager, bucketName string, layout BinlogLayout, collectionID int64, segmentIDs []int64) (*backuppb.BinlogScan, error) {
+	persistent := make(map[int64]bool, len(segmentIDs))
+	for _, id := range segmentIDs {
+		persistent[id] = true
+	}
+	inserted := make(map[int64]bool)
+	orphans := make(map[int64]bool)
+	scan := &backuppb.BinlogScan{State: BinlogScanScanned}
+	// a file may be listed twice if the dirs overlap
+	seen := make(map[string]bool)
+	for _, dir := range layout.CollectionDirs(collectionID) {
+		err := client.WalkWithPrefix(ctx, bucketName, dir, true, func(filePath string, size int64) error {
+			if seen[filePath] {
+				return nil
+			}
+			seen[filePath] = true
+			logType, _, segmentID, ok := layout.ParseSegment(filePath, collectionID)
+			if !ok {
+				return nil
+			}
+			if persistent[segmentID] {
+				scan.ReferencedFiles++
+				scan.ReferencedSize += size
+				if logType == INSERT_LOG_DIR {
+					inserted[segmentID] = true
+				}
+				return nil
+			}
+			scan.OrphanFiles++
+			scan.OrphanSize += size
+			orphans[segmentID] = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	scan.OrphanSegments = int64(len(orphans))
+	for id := range orphans {
+		scan.OrphanSegmentIds = append(scan.OrphanSegmentIds, id)
+	}
+	sort.Slice(scan.OrphanSegmentIds, func(i, j int) bool { return scan.OrphanSegmentIds[i] < scan.OrphanSegmentIds[j] })
+	if len(scan.OrphanSegmentIds) > maxOrphanSegmentIDs {
+		scan.OrphanSegmentIds = scan.OrphanSegmentIds[:maxOrphanSegmentIDs]
+	}
+	for _, id := range segmentIDs {
+		if !inserted[id] {
+			scan.MissingSegmentIds = append(scan.MissingSegmentIds, id)
+		}
+	}
+	sort.Slice(scan.MissingSegmentIds, func(i, j int) bool { return scan.MissingSegmentIds[i] < scan.MissingSegmentIds[j] })
+	return scan, nil
+}
+
+// binlogScanFindings returns the orphan binlogs and missing segments of a scan as db.collection: detail
+func binlogScanFindings(db, collectionName string, scan *backuppb.BinlogScan) []string {
+	findings := make([]string, 0)
+	name := db + "." + collectionName
+	if scan.GetState() == BinlogScanSkipped {
+		return append(findings, name+": binlogs are not scanned, "+scan.GetDetail())
+	}
+	if scan.GetOrphanFiles() > 0 {
+		findings = append(findings, fmt.Sprintf("%s: %d binlogs of %d bytes of %d segments not persistent, like segments %v, %d binlogs of %d bytes referenced",
+			name, scan.GetOrphanFiles(), scan.GetOrphanSize(), scan.GetOrphanSegments(), scan.GetOrphanSegmentIds(), scan.GetReferencedFiles(), scan.GetReferencedSize()))
+	}
+	if len(scan.GetMissingSegmentIds()) > 0 {
+		findings = append(findings, fmt.Sprintf("%s: persistent segments %v have no insert logs in the bucket", name, scan.GetMissingSegmentIds()))
+	}
+	return findings
+}
+
+// BackupBinlogScanFindings returns the orphan binlogs and missing segments found by the binlog scans of all collections
+// of a backup
+func BackupBinlogScanFindings(backup *backuppb.BackupInfo) []string {
+	findings := make([]string, 0)
+	for _, collection := range backup.GetCollectionBackups() {
+		if collection.GetBinlogScan() == nil {
+			continue
+		}
+		findings = append(findings, binlogScanFindings(collection.GetDbName(), collection.GetCollectionName(), collection.GetBinlogScan())...)
+	}
+	return findings
+}
@@ -0,0 +1,52 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestScanBinlogs(t *testing.T) {
+	files := map[string][]byte{
+		// segment 3 is persistent, 4 is compacted into 3 and not collected yet
+		"files/insert_log/1/2/3/100/1": make([]byte, 10),
+		"files/insert_log/1/2/3/101/2": make([]byte, 10),
+		"files/delta_log/1/2/3/100/3":  make([]byte, 5),
+		"files/stats_log/1/2/3/100/4":  make([]byte, 5),
+		"files/insert_log/1/2/4/100/5": make([]byte, 20),
+		"files/delta_log/1/2/4/100/6":  make([]byte, 1),
+		// the other collection
+		"files/insert_log/10/2/7/100/7": make([]byte, 30),
+	}
+	client := &memoryChunkManager{files: files}
+	layout := newTemplateBinlogLayout("files", paramtable.MinioConfig{})
+
+	scan, err := scanBinlogs(context.Background(), client, "bucket", layout, 1, []int64{3, 5})
+	assert.NoError(t, err)
+	assert.Equal(t, BinlogScanScanned, scan.GetState())
+	assert.Equal(t, int64(3), scan.GetReferencedFiles())
+	assert.Equal(t, int64(25), scan.GetReferencedSize())
+	assert.Equal(t, int64(2), scan.GetOrphanFiles())
+	assert.Equal(t, int64(21), scan.GetOrphanSize())
+	assert.Equal(t, int64(1), scan.GetOrphanSegments())
+	assert.Equal(t, []int64{4}, scan.GetOrphanSegmentIds())
+	assert.Equal(t, []int64{5}, scan.GetMissingSegmentIds())
+
+	findings := BackupBinlogScanFindings(&backuppb.BackupInfo{CollectionBackups: []*backuppb.CollectionBackupInfo{
+		{DbName: "default", CollectionName: "coll", BinlogScan: scan},
+		{DbName: "default", CollectionName: "unscanned"},
+	}})
+	assert.Equal(t, []string{
+		"default.coll: 2 binlogs of 21 bytes of 1 segments not persistent, like segments [4], 3 binlogs of 25 bytes referenced",
+		"default.coll: persistent segments [5] have no insert logs in the bucket",
+	}, findings)
+
+	scan, err = scanBinlogs(context.Background(), client, "bucket", layout, 1, []int64{3, 4})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(0), scan.GetOrphanFiles())
+	assert.Empty(t, binlogScanFindings("default", "coll", scan))
+}
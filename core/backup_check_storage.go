@@ -0,0 +1,423 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+// states of a storage check
+const (
+	StorageCheckPass = "pass"
+	StorageCheckWarn = "warn"
+	StorageCheckFail = "fail"
+	StorageCheckSkip = "skip"
+)
+
+const (
+	// timeout of each storage check
+	storageCheckTimeout = 10 * time.Second
+	// a certificate expiring in the duration is warned
+	certificateExpiryWarning = 7 * 24 * time.Hour
+)
+
+// storageTarget is a bucket checked by CheckStorage
+type storageTarget struct {
+	// milvus or backup
+	name string
+	// the section of config of the storage, to point the hints at
+	section     string
+	storageType string
+	address     string
+	port        string
+	useSSL      bool
+	bucket      string
+	rootPath    string
+	client      func() storage.ChunkManager
+}
+
+// storageTargets returns the bucket of milvus and the backup bucket, which is in the storage of milvus unless
+// backupStorage is configured
+func (b *BackupContext) storageTargets() []storageTarget {
+	minioCfg := b.params.MinioCfg
+	targets := []storageTarget{{
+		name:        "milvus",
+		section:     "minio",
+		storageType: minioCfg.StorageType,
+		address:     minioCfg.Address,
+		port:        minioCfg.Port,
+		useSSL:      minioCfg.UseSSL,
+		bucket:      b.milvusBucketName,
+		rootPath:    b.milvusRootPath,
+		client:      b.getStorageClient,
+	}}
+	backup := targets[0]
+	backup.name = "backup"
+	backup.bucket = b.backupBucketName
+	backup.rootPath = b.backupRootPath
+	if backupCfg := b.params.BackupStorageCfg; backupCfg.Enabled() {
+		backup.section = "backupStorage"
+		backup.storageType = backupCfg.StorageType
+		backup.address = backupCfg.Address
+		backup.port = backupCfg.Port
+		backup.useSSL = backupCfg.UseSSL
+		backup.client = b.getBackupStorageClient
+	}
+	return append(targets, backup)
+}
+
+// CheckStorage checks the bucket of milvus and the backup bucket: the endpoint is reachable, tls is right, the
+// credentials are accepted in the region of the bucket, objects can be listed, written, read and deleted under the
+// root paths, and copied from the bucket of milvus to the backup bucket if they are in the same storage.
+// Each failed check has a hint to fix it.
+func (b *BackupContext) CheckStorage(ctx context.Context) []*backuppb.StorageCheck {
+	checks := make([]*backuppb.StorageCheck, 0)
+	targets := b.storageTargets()
+	for _, target := range targets {
+		checks = append(checks, checkStorageTarget(ctx, target)...)
+	}
+	if !b.params.BackupStorageCfg.Enabled() {
+		checks = append(checks, b.checkStorageCopy(ctx, targets[0], targets[1], checks))
+	}
+	return checks
+}
+
+func checkStorageTarget(ctx context.Context, target storageTarget) []*backuppb.StorageCheck {
+	checks := make([]*backuppb.StorageCheck, 0)
+	endpoint := checkStorageEndpoint(ctx, target)
+	checks = append(checks, endpoint, checkStorageTLS(ctx, target))
+	if endpoint.GetState() == StorageCheckFail {
+		for _, name := range []string{"credentials", "region", "list", "write", "read", "delete"} {
+			checks = append(checks, skippedStorageCheck(target, name, "the endpoint is not reachable"))
+		}
+		return checks
+	}
+	return append(checks, checkBucketAccess(ctx, target)...)
+}
+
+func skippedStorageCheck(target storageTarget, name string, reason string) *backuppb.StorageCheck {
+	return &backuppb.StorageCheck{Storage: target.name, Name: name, State: StorageCheckSkip, Detail: reason}
+}
+
+// runStorageCheck runs a check with timeout, the check returns the detail of a passed check. A check panics if the
+// client of a storage can't be created.
+func runStorageCheck(ctx context.Context, target storageTarget, name string, check func(ctx context.Context) (string, error)) (result *backuppb.StorageCheck, err error) {
+	ctx, cancel := context.WithTimeout(ctx, storageCheckTimeout)
+	defer cancel()
+	start := time.Now()
+	result = &backuppb.StorageCheck{Storage: target.name, Name: name, State: StorageCheckPass}
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+		result.DurationMs = time.Since(start).Milliseconds()
+		if err != nil {
+			result.State = StorageCheckFail
+			result.Detail = err.Error()
+			result.Hint = storageErrorHint(target, err)
+		}
+	}()
+	result.Detail, err = check(ctx)
+	return result, err
+}
+
+// endpointHost returns the host and port of the endpoint of a storage, empty if the storage has no endpoint of
+// its own to dial, like local and azure of which the endpoint is in the connection string
+func endpointHost(target storageTarget) (string, string) {
+	switch target.storageType {
+	case paramtable.Local, paramtable.CloudProviderAzure:
+		return "", ""
+	}
+	host, port := target.address, target.port
+	if target.storageType == paramtable.CloudProviderGCP && (host == "" || strings.Contains(host, storage.GcsDefaultAddress)) {
+		host, port = storage.GcsDefaultAddress, "443"
+	}
+	return host, port
+}
+
+func checkStorageEndpoint(ctx context.Context, target storageTarget) *backuppb.StorageCheck {
+	host, port := endpointHost(target)
+	if host == "" {
+		return skippedStorageCheck(target, "endpoint", target.storageType+" storage has no endpoint to dial")
+	}
+	check, _ := runStorageCheck(ctx, target, "endpoint", func(ctx context.Context) (string, error) {
+		address := net.JoinHostPort(host, port)
+		conn, err := (&net.Dialer{}).DialContext(ctx, "tcp", address)
+		if err != nil {
+			return "", fmt.Errorf("dial %s: %w", address, err)
+		}
+		defer conn.Close()
+		return address + " is reachable", nil
+	})
+	return check
+}
+
+func checkStorageTLS(ctx context.Context, target storageTarget) *backuppb.StorageCheck {
+	host, port := endpointHost(target)
+	if host == "" {
+		return skippedStorageCheck(target, "tls", target.storageType+" storage has no endpoint to dial")
+	}
+	if !target.useSSL {
+		if port == "443" {
+			return &backuppb.StorageCheck{Storage: target.name, Name: "tls", State: StorageCheckWarn,
+				Detail: "useSSL is false but the port is 443, which usually serves https",
+				Hint:   fmt.Sprintf("set %s.useSSL: true if %s serves https", target.section, host)}
+		}
+		return skippedStorageCheck(target, "tls", "useSSL is false")
+	}
+	var notAfter time.Time
+	check, err := runStorageCheck(ctx, target, "tls", func(ctx context.Context) (string, error) {
+		dialer := &tls.Dialer{Config: &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}}
+		conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(host, port))
+		if err != nil {
+			return "", fmt.Errorf("tls handshake with %s: %w", host, err)
+		}
+		defer conn.Close()
+		certs := conn.(*tls.Conn).ConnectionState().PeerCertificates
+		if len(certs) == 0 {
+			return "tls handshake succeeded", nil
+		}
+		notAfter = certs[0].NotAfter
+		return fmt.Sprintf("certificate of %s is valid until %s", certs[0].Subject.CommonName, notAfter.Format(time.RFC3339)), nil
+	})
+	switch {
+	case err != nil:
+		check.Hint = fmt.Sprintf("if %s serves http set %s.useSSL: false, otherwise trust the CA of its certificate "+
+			"in the system roots and make sure the address matches the name in the certificate", host, target.section)
+	case !notAfter.IsZero() && time.Until(notAfter) < certificateExpiryWarning:
+		check.State = StorageCheckWarn
+		check.Hint = "renew the certificate of " + host + ", requests fail once it expires"
+	}
+	return check
+}
+
+// checkBucketAccess checks the credentials by listing the root path, then writes, reads and deletes a probe object
+func checkBucketAccess(ctx context.Context, target storageTarget) []*backuppb.StorageCheck {
+	prefix := target.rootPath + SEPERATOR
+	where := target.bucket + SEPERATOR + prefix
+	list, listErr := runStorageCheck(ctx, target, "list", func(ctx context.Context) (string, error) {
+		paths, _, err := target.client().ListWithPrefix(ctx, target.bucket, prefix, false)
+		if err != nil {
+			return "", fmt.Errorf("list %s: %w", where, err)
+		}
+		return fmt.Sprintf("%d objects under %s", len(paths), where), nil
+	})
+	if listErr == nil && target.name == "milvus" && strings.HasPrefix(list.GetDetail(), "0 objects") {
+		list.State = StorageCheckWarn
+		list.Hint = "the root path of milvus is empty, make sure the cluster is really empty, otherwise " +
+			"minio.address, minio.port, minio.bucketName and minio.rootPath may not be the ones of milvus"
+	}
+	checks := []*backuppb.StorageCheck{credentialsCheck(target, listErr), regionCheck(target, listErr), list}
+
+	probe := prefix + "milvus_backup_check_" + time.Now().Format("20060102150405.000000000")
+	content := []byte("milvus-backup storage check")
+	write, writeErr := runStorageCheck(ctx, target, "write", func(ctx context.Context) (string, error) {
+		if err := target.client().Write(ctx, target.bucket, probe, content); err != nil {
+			return "", fmt.Errorf("write %s/%s: %w", target.bucket, probe, err)
+		}
+		return "wrote " + target.bucket + SEPERATOR + probe, nil
+	})
+	checks = append(checks, write)
+	if writeErr != nil {
+		return append(checks, skippedStorageCheck(target, "read", "the probe object is not written"),
+			skippedStorageCheck(target, "delete", "the probe object is not written"))
+	}
+	read, _ := runStorageCheck(ctx, target, "read", func(ctx context.Context) (string, error) {
+		data, err := target.client().Read(ctx, target.bucket, probe)
+		if err != nil {
+			return "", fmt.Errorf("read %s/%s: %w", target.bucket, probe, err)
+		}
+		if !bytes.Equal(data, content) {
+			return "", fmt.Errorf("read %d bytes of %s/%s, not the %d bytes written", len(data), target.bucket, probe, len(content))
+		}
+		return "read back the probe object", nil
+	})
+	remove, _ := runStorageCheck(ctx, target, "delete", func(ctx context.Context) (string, error) {
+		if err := target.client().Remove(ctx, target.bucket, probe); err != nil {
+			return "", fmt.Errorf("delete %s/%s: %w", target.bucket, probe, err)
+		}
+		exist, err := target.client().Exist(ctx, target.bucket, probe)
+		if err == nil && exist {
+			return "", fmt.Errorf("%s/%s still exists after deleted", target.bucket, probe)
+		}
+		return "deleted the probe object", nil
+	})
+	return append(checks, read, remove)
+}
+
+// credentialsCheck tells whether the credentials are accepted by the list of the root path
+func credentialsCheck(target storageTarget, listErr error) *backuppb.StorageCheck {
+	check := &backuppb.StorageCheck{Storage: target.name, Name: "credentials", State: StorageCheckPass, Detail: "accepted by the storage"}
+	if listErr == nil {
+		return check
+	}
+	switch kind := storageErrorKindOf(listErr); kind {
+	case storageErrorExpired, storageErrorCredentials:
+		check.State = StorageCheckFail
+		check.Detail = listErr.Error()
+		check.Hint = storageErrorHint(target, listErr)
+	default:
+		check.State = StorageCheckSkip
+		check.Detail = "unknown as the list failed"
+	}
+	return check
+}
+
+// regionCheck tells whether the bucket is in the region of the endpoint by the list of the root path
+func regionCheck(target storageTarget, listErr error) *backuppb.StorageCheck {
+	check := &backuppb.StorageCheck{Storage: target.name, Name: "region", State: StorageCheckPass, Detail: "the bucket is served by the endpoint"}
+	if listErr == nil {
+		return check
+	}
+	if storageErrorKindOf(listErr) == storageErrorRegion {
+		check.State = StorageCheckFail
+		check.Detail = listErr.Error()
+		check.Hint = storageErrorHint(target, listErr)
+	} else {
+		check.State = StorageCheckSkip
+		check.Detail = "unknown as the list failed"
+	}
+	return check
+}
+
+// checkStorageCopy checks the objects of milvus are copied into the backup bucket by the storage, skipped if
+// either bucket can't be written
+func (b *BackupContext) checkStorageCopy(ctx context.Context, milvus, backup storageTarget, checks []*backuppb.StorageCheck) *backuppb.StorageCheck {
+	for _, check := range checks {
+		if check.GetName() == "write" && check.GetState() != StorageCheckPass {
+			return skippedStorageCheck(backup, "copy", "the "+check.GetStorage()+" bucket can't be written")
+		}
+	}
+	from := milvus.rootPath + SEPERATOR + "milvus_backup_check_copy_" + time.Now().Format("20060102150405.000000000")
+	to := backup.rootPath + SEPERATOR + from[strings.LastIndex(from, SEPERATOR)+1:]
+	check, _ := runStorageCheck(ctx, backup, "copy", func(ctx context.Context) (string, error) {
+		client := milvus.client()
+		if err := client.Write(ctx, milvus.bucket, from, []byte{1}); err != nil {
+			return "", fmt.Errorf("write %s/%s: %w", milvus.bucket, from, err)
+		}
+		defer client.Remove(ctx, milvus.bucket, from)
+		if err := client.Copy(ctx, milvus.bucket, backup.bucket, from, to); err != nil {
+			return "", fmt.Errorf("copy %s/%s to %s/%s: %w", milvus.bucket, from, backup.bucket, to, err)
+		}
+		defer client.Remove(ctx, backup.bucket, to)
+		return "copied from " + milvus.bucket + " to " + backup.bucket + " by the storage", nil
+	})
+	return check
+}
+
+// kinds of the errors of storage, told apart for the hints
+const (
+	storageErrorOther = iota
+	storageErrorExpired
+	storageErrorCredentials
+	storageErrorRegion
+	storageErrorPermission
+	storageErrorNoBucket
+	storageErrorUnreachable
+)
+
+var expectedRegionPattern = regexp.MustCompile(`expecting '([a-z0-9-]+)'|region: ([a-z0-9-]+)|in the '([a-z0-9-]+)' region`)
+
+func storageErrorKindOf(err error) int {
+	var response minio.ErrorResponse
+	code := ""
+	if errors.As(err, &response) {
+		code = response.Code
+	}
+	msg := code + " " + err.Error()
+	contains := func(words ...string) bool {
+		for _, word := range words {
+			if strings.Contains(msg, word) {
+				return true
+			}
+		}
+		return false
+	}
+	switch {
+	case contains("ExpiredToken", "TokenRefreshRequired", "RequestExpired", "token expired", "Signed expiry time", "expired token"):
+		return storageErrorExpired
+	case contains("AuthorizationHeaderMalformed", "PermanentRedirect", "IllegalLocationConstraintException", "wrong region", "is wrong; expecting", "301 Moved Permanently"):
+		return storageErrorRegion
+	case contains("InvalidAccessKeyId", "SignatureDoesNotMatch", "InvalidClientTokenId", "AuthenticationFailed", "invalid_grant", "invalid_client", "InvalidAuthenticationInfo"):
+		return storageErrorCredentials
+	case contains("NoSuchBucket", "ContainerNotFound", "bucket doesn't exist", "bucket does not exist", "notFound"):
+		return storageErrorNoBucket
+	case contains("AccessDenied", "AuthorizationPermissionMismatch", "AuthorizationFailure", "Forbidden", "403"):
+		return storageErrorPermission
+	case contains("connection refused", "no such host", "i/o timeout", "deadline exceeded", "network is unreachable"):
+		return storageErrorUnreachable
+	}
+	return storageErrorOther
+}
+
+// storageErrorHint returns how to fix the error of a check of target
+func storageErrorHint(target storageTarget, err error) string {
+	section := target.section
+	bucketKey := section + ".bucketName"
+	if target.name == "backup" {
+		bucketKey = "minio.backupBucketName"
+	}
+	switch storageErrorKindOf(err) {
+	case storageErrorExpired:
+		return fmt.Sprintf("the credentials of %s have expired, rotate them or refresh the temporary credentials of the role, "+
+			"references like env:, file: or vault:// are read again every minio.credentialRefreshInterval", section)
+	case storageErrorCredentials:
+		return fmt.Sprintf("the storage rejects the credentials, check the access key and secret of %s and that they "+
+			"belong to the account of the %s storage", section, target.storageType)
+	case storageErrorRegion:
+		region := ""
+		var response minio.ErrorResponse
+		if errors.As(err, &response) && response.Region != "" {
+			region = response.Region
+		} else if match := expectedRegionPattern.FindStringSubmatch(err.Error()); match != nil {
+			region = match[1] + match[2] + match[3]
+		}
+		if region != "" {
+			return fmt.Sprintf("bucket %s is in region %s, set %s.address to the endpoint of %s, like s3.%s.amazonaws.com", target.bucket, region, section, region, region)
+		}
+		return fmt.Sprintf("bucket %s is not in the region of %s, set %s.address to the endpoint of its region", target.bucket, target.address, section)
+	case storageErrorNoBucket:
+		return fmt.Sprintf("bucket %s doesn't exist, create it or fix %s", target.bucket, bucketKey)
+	case storageErrorPermission:
+		return fmt.Sprintf("the credentials of %s have no permission on bucket %s, grant list, get, put and delete of objects "+
+			"under %s/ in its policy", section, target.bucket, target.rootPath)
+	case storageErrorUnreachable:
+		return fmt.Sprintf("%s:%s is not reachable, check %s.address and %s.port, the dns and the firewall between "+
+			"milvus-backup and the storage", target.address, target.port, section, section)
+	}
+	return ""
+}
+
+// storageChecksPassed is false if any check fails, warnings pass
+func storageChecksPassed(checks []*backuppb.StorageCheck) bool {
+	for _, check := range checks {
+		if check.GetState() == StorageCheckFail {
+			return false
+		}
+	}
+	return true
+}
+
+// formatStorageChecks formats the checks one per line, with the hints of those not passed
+func formatStorageChecks(checks []*backuppb.StorageCheck) string {
+	var report strings.Builder
+	for _, check := range checks {
+		report.WriteString(fmt.Sprintf("[%s] %s storage %s: %s\n", check.GetState(), check.GetStorage(), check.GetName(), check.GetDetail()))
+		if check.GetHint() != "" {
+			report.WriteString("    hint: " + check.GetHint() + "\n")
+		}
+	}
+	return report.String()
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+// deniedChunkManager rejects every request with err
+type deniedChunkManager struct {
+	memoryChunkManager
+	err error
+}
+
+func (m *deniedChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	return nil, nil, m.err
+}
+
+func (m *deniedChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	return m.err
+}
+
+func checkOf(checks []*backuppb.StorageCheck, name string) *backuppb.StorageCheck {
+	for _, check := range checks {
+		if check.GetName() == name {
+			return check
+		}
+	}
+	return nil
+}
+
+func TestCheckBucketAccess(t *testing.T) {
+	manager := &memoryChunkManager{files: map[string][]byte{"files/insert_log/1": []byte("log")}}
+	target := storageTarget{name: "milvus", section: "minio", storageType: paramtable.Local, bucket: "a-bucket", rootPath: "files",
+		client: func() storage.ChunkManager { return manager }}
+	checks := checkStorageTarget(context.Background(), target)
+	assert.Len(t, checks, 8)
+	assert.Equal(t, StorageCheckSkip, checkOf(checks, "endpoint").GetState())
+	for _, name := range []string{"credentials", "region", "list", "write", "read", "delete"} {
+		assert.Equal(t, StorageCheckPass, checkOf(checks, name).GetState(), name)
+	}
+	assert.True(t, storageChecksPassed(checks))
+	// the probe object is deleted
+	assert.Len(t, manager.files, 1)
+
+	// an empty root path of milvus is warned
+	target.rootPath = "empty"
+	checks = checkBucketAccess(context.Background(), target)
+	assert.Equal(t, StorageCheckWarn, checkOf(checks, "list").GetState())
+	assert.True(t, storageChecksPassed(checks))
+
+	denied := &deniedChunkManager{err: minio.ErrorResponse{Code: "InvalidAccessKeyId", Message: "The Access Key Id you provided does not exist in our records."}}
+	target.client = func() storage.ChunkManager { return denied }
+	checks = checkBucketAccess(context.Background(), target)
+	assert.False(t, storageChecksPassed(checks))
+	assert.Equal(t, StorageCheckFail, checkOf(checks, "credentials").GetState())
+	assert.Contains(t, checkOf(checks, "credentials").GetHint(), "access key and secret of minio")
+	assert.Equal(t, StorageCheckSkip, checkOf(checks, "region").GetState())
+	assert.Equal(t, StorageCheckFail, checkOf(checks, "write").GetState())
+	assert.Equal(t, StorageCheckSkip, checkOf(checks, "read").GetState())
+
+	report := formatStorageChecks(checks)
+	assert.Contains(t, report, "[fail] milvus storage credentials: ")
+	assert.Contains(t, report, "\n    hint: the storage rejects the credentials")
+}
+
+func TestStorageErrorHint(t *testing.T) {
+	target := storageTarget{name: "backup", section: "backupStorage", storageType: paramtable.CloudProviderAWS,
+		address: "s3.us-east-1.amazonaws.com", port: "443", bucket: "backup-bucket", rootPath: "backup"}
+	cases := []struct {
+		err  error
+		kind int
+		hint string
+	}{
+		{minio.ErrorResponse{Code: "ExpiredToken"}, storageErrorExpired, "have expired"},
+		{errors.New("The authorization header is malformed; the region 'us-east-1' is wrong; expecting 'eu-west-1'"),
+			storageErrorRegion, "bucket backup-bucket is in region eu-west-1, set backupStorage.address to the endpoint of eu-west-1"},
+		{minio.ErrorResponse{Code: "AuthorizationHeaderMalformed", Region: "ap-south-1"}, storageErrorRegion, "in region ap-south-1"},
+		{minio.ErrorResponse{Code: "NoSuchBucket"}, storageErrorNoBucket, "fix minio.backupBucketName"},
+		{minio.ErrorResponse{Code: "AccessDenied"}, storageErrorPermission, "grant list, get, put and delete"},
+		{errors.New("dial tcp 10.0.0.1:443: connect: connection refused"), storageErrorUnreachable, "check backupStorage.address"},
+		{errors.New("something else"), storageErrorOther, ""},
+	}
+	for _, c := range cases {
+		assert.Equal(t, c.kind, storageErrorKindOf(c.err), c.err.Error())
+		if c.hint == "" {
+			assert.Empty(t, storageErrorHint(target, c.err))
+		} else {
+			assert.Contains(t, storageErrorHint(target, c.err), c.hint)
+		}
+	}
+}
+
+func TestCheckStorageEndpoint(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	host, port, _ := net.SplitHostPort(listener.Addr().String())
+	target := storageTarget{name: "milvus", section: "minio", storageType: paramtable.Minio, address: host, port: port}
+	check := checkStorageEndpoint(context.Background(), target)
+	assert.Equal(t, StorageCheckPass, check.GetState())
+	assert.Equal(t, StorageCheckSkip, checkStorageTLS(context.Background(), target).GetState())
+
+	listener.Close()
+	check = checkStorageEndpoint(context.Background(), target)
+	assert.Equal(t, StorageCheckFail, check.GetState())
+	assert.True(t, strings.HasPrefix(check.GetHint(), host+":"+port+" is not reachable"))
+
+	// the rest are skipped once the endpoint is not reachable
+	checks := checkStorageTarget(context.Background(), target)
+	assert.Equal(t, StorageCheckSkip, checkOf(checks, "write").GetState())
+
+	target.port = "443"
+	assert.Equal(t, StorageCheckWarn, checkStorageTLS(context.Background(), target).GetState())
+}
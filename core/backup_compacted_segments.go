@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// errBinlogNotExist means binlogs to backup are deleted, usually by the gc of milvus after their segments are compacted
+var errBinlogNotExist = errors.New("binlog file not exist")
+
+// missingSegments collects the segments whose binlogs are missing while copying
+type missingSegments struct {
+	mu  sync.Mutex
+	ids map[int64]bool
+}
+
+func (m *missingSegments) add(id int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.ids[id] = true
+}
+
+func (m *missingSegments) list() []int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	ids := make([]int64, 0, len(m.ids))
+	for id := range m.ids {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	return ids
+}
+
+// binlogDeleted returns true if the binlog failed to copy doesn't exist anymore
+func (b *BackupContext) binlogDeleted(ctx context.Context, binlog *backuppb.Binlog) bool {
+	exist, err := b.getStorageClient().Exist(ctx, b.milvusBucketName, binlog.GetLogPath())
+	return err == nil && !exist
+}
+
+// replaceCompactedSegments replaces the segments of collection backup not persistent in milvus anymore with
+// the persistent segments not in backup yet. Only the partitions with segments dropped are changed, all their
+// segments not in backup are added, since the segments compacted to are not known otherwise.
+// The segments dropped and added are returned.
+func replaceCompactedSegments(collectionBackup *backuppb.CollectionBackupInfo, persistentSegments []*entity.Segment) ([]*backuppb.SegmentBackupInfo, []*backuppb.SegmentBackupInfo) {
+	persistent := make(map[int64]bool, len(persistentSegments))
+	for _, segment := range persistentSegments {
+		persistent[segment.ID] = true
+	}
+	dropped := make([]*backuppb.SegmentBackupInfo, 0)
+	added := make([]*backuppb.SegmentBackupInfo, 0)
+	for _, partition := range collectionBackup.GetPartitionBackups() {
+		inBackup := make(map[int64]bool)
+		kept := make([]*backuppb.SegmentBackupInfo, 0, len(partition.GetSegmentBackups()))
+		partitionDropped := make([]*backuppb.SegmentBackupInfo, 0)
+		for _, segment := range partition.GetSegmentBackups() {
+			inBackup[segment.GetSegmentId()] = true
+			if persistent[segment.GetSegmentId()] {
+				kept = append(kept, segment)
+			} else {
+				partitionDropped = append(partitionDropped, segment)
+			}
+		}
+		if len(partitionDropped) == 0 {
+			continue
+		}
+		for _, segment := range persistentSegments {
+			if segment.ParititionID != partition.GetPartitionId() || inBackup[segment.ID] {
+				continue
+			}
+			segmentInfo := &backuppb.SegmentBackupInfo{
+				SegmentId:    segment.ID,
+				CollectionId: segment.CollectionID,
+				PartitionId:  segment.ParititionID,
+				NumOfRows:    segment.NumRows,
+			}
+			kept = append(kept, segmentInfo)
+			added = append(added, segmentInfo)
+		}
+		partition.SegmentBackups = kept
+		dropped = append(dropped, partitionDropped...)
+	}
+	return dropped, added
+}
+
+// refreshCompactedSegments lists the persistent segments of the collection again after binlogs to backup are
+// deleted, replaces the segments compacted with the segments compacted to, and returns all segments of collection.
+// Files of the dropped segments already copied are removed from backup.
+func (b *BackupContext) refreshCompactedSegments(ctx context.Context, backupInfo *backuppb.BackupInfo, collectionBackup *backuppb.CollectionBackupInfo) ([]*backuppb.SegmentBackupInfo, error) {
+	persistentSegments, err := b.getMilvusClient().GetPersistentSegmentInfo(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
+	if err != nil {
+		return nil, err
+	}
+	dropped, added := replaceCompactedSegments(collectionBackup, persistentSegments)
+	if len(dropped) == 0 {
+		return nil, errors.New("binlog files not exist but the segments are still persistent in milvus, " + collectionBackup.GetCollectionName())
+	}
+	for _, segment := range added {
+		if _, err := b.fillSegmentBackupInfo(ctx, segment); err != nil {
+			return nil, err
+		}
+	}
+
+	var droppedSize, droppedCopiedSize, addedSize int64
+	for _, segment := range dropped {
+		droppedSize += segment.GetSize()
+		if !segment.GetBackuped() {
+			continue
+		}
+		droppedCopiedSize += segment.GetSize()
+		// binlogs referenced from base backup are not removed
+		if segment.GetRefBackupName() != "" {
+			continue
+		}
+		for _, binlogs := range append(append([]*backuppb.FieldBinlog{}, segment.GetBinlogs()...), segment.GetDeltalogs()...) {
+			for _, binlog := range binlogs.GetBinlogs() {
+				// dedup objects may be shared by other backups, they are deleted by gc if not referenced
+				if binlog.GetObjectName() != "" {
+					continue
+				}
+				targetPath := b.binlogBackupPath(backupInfo.GetName(), collectionBackup, segment, binlog.GetLogPath())
+				if err := b.getBackupStorageClient().Remove(ctx, b.backupBucketName, targetPath); err != nil {
+					log.Warn("fail to remove binlog of compacted segment, it can be removed by gc",
+						zap.String("file", targetPath), zap.Error(err))
+				}
+			}
+		}
+	}
+	for _, segment := range added {
+		addedSize += segment.GetSize()
+	}
+	log.Info("replace compacted segments",
+		zap.String("collectionName", collectionBackup.GetCollectionName()),
+		zap.Int("droppedSegmentNum", len(dropped)),
+		zap.Int("addedSegmentNum", len(added)))
+
+	segments := make([]*backuppb.SegmentBackupInfo, 0)
+	for _, partition := range collectionBackup.GetPartitionBackups() {
+		partition.Size = segmentGroupSize(partition, -1)
+		segments = append(segments, partition.GetSegmentBackups()...)
+	}
+	b.progressMu.Lock()
+	collectionBackup.Size += addedSize - droppedSize
+	collectionBackup.CopiedSize -= droppedCopiedSize
+	collectionBackup.Progress = progressPercent(collectionBackup.GetCopiedSize(), collectionBackup.GetSize())
+	backupInfo.Size += addedSize - droppedSize
+	backupInfo.CopiedSize -= droppedCopiedSize
+	backupInfo.Progress = progressPercent(backupInfo.GetCopiedSize(), backupInfo.GetSize())
+	b.progressMu.Unlock()
+	b.refreshBackupCache(backupInfo)
+	return segments, nil
+}
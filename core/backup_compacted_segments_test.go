@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestReplaceCompactedSegments(t *testing.T) {
+	collectionBackup := &backuppb.CollectionBackupInfo{PartitionBackups: []*backuppb.PartitionBackupInfo{
+		{PartitionId: 1, SegmentBackups: []*backuppb.SegmentBackupInfo{
+			{SegmentId: 11, PartitionId: 1, Backuped: true},
+			{SegmentId: 12, PartitionId: 1},
+			{SegmentId: 13, PartitionId: 1},
+		}},
+		{PartitionId: 2, SegmentBackups: []*backuppb.SegmentBackupInfo{
+			{SegmentId: 21, PartitionId: 2},
+		}},
+	}}
+	// 11 and 12 are compacted to 14, 22 is flushed after backup in partition 2 which is unchanged
+	dropped, added := replaceCompactedSegments(collectionBackup, []*entity.Segment{
+		{ID: 13, ParititionID: 1},
+		{ID: 14, ParititionID: 1, NumRows: 100},
+		{ID: 21, ParititionID: 2},
+		{ID: 22, ParititionID: 2},
+	})
+	assert.Len(t, dropped, 2)
+	assert.Equal(t, int64(11), dropped[0].GetSegmentId())
+	assert.Equal(t, int64(12), dropped[1].GetSegmentId())
+	assert.Len(t, added, 1)
+	assert.Equal(t, int64(14), added[0].GetSegmentId())
+	assert.Equal(t, int64(100), added[0].GetNumOfRows())
+
+	var ids []int64
+	for _, segment := range collectionBackup.GetPartitionBackups()[0].GetSegmentBackups() {
+		ids = append(ids, segment.GetSegmentId())
+	}
+	assert.Equal(t, []int64{13, 14}, ids)
+	assert.Len(t, collectionBackup.GetPartitionBackups()[1].GetSegmentBackups(), 1)
+
+	dropped, added = replaceCompactedSegments(collectionBackup, []*entity.Segment{{ID: 13, ParititionID: 1}, {ID: 14, ParititionID: 1}, {ID: 21, ParititionID: 2}})
+	assert.Empty(t, dropped)
+	assert.Empty(t, added)
+
+	missing := &missingSegments{ids: make(map[int64]bool)}
+	missing.add(12)
+	missing.add(11)
+	missing.add(12)
+	assert.Equal(t, []int64{11, 12}, missing.list())
+	err := fmt.Errorf("%w, segments: %v", errBinlogNotExist, missing.list())
+	assert.True(t, errors.Is(err, errBinlogNotExist))
+}
+
+func TestPauseMilvusGC(t *testing.T) {
+	var mu sync.Mutex
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.URL.RequestURI())
+		if strings.HasSuffix(r.URL.Path, "/resume") {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.Write([]byte(`{"msg": "OK"}`))
+	}))
+	defer server.Close()
+
+	b := &BackupContext{}
+	b.pauseMilvusGC(context.Background())()
+	assert.Empty(t, calls)
+
+	b.params.BackupCfg.GCPauseEnable = true
+	b.params.BackupCfg.GCPauseSeconds = 60
+	b.params.BackupCfg.GCPauseAddress = server.URL
+	// failing to resume doesn't fail the backup
+	b.pauseMilvusGC(context.Background())()
+	assert.Equal(t, []string{gcPausePath + "?pause_seconds=60", gcResumePath}, calls)
+
+	assert.Error(t, callMilvusGC(context.Background(), server.URL, gcResumePath, ""))
+	b.params.BackupCfg.GCPauseAddress = "http://127.0.0.1:0"
+	b.pauseMilvusGC(context.Background())()
+}
@@ -0,0 +1,345 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// features of collections which older milvus doesn't support
+const (
+	FeatureDatabase        = "database"
+	FeatureJSONField       = "json field"
+	FeatureDynamicField    = "dynamic field"
+	FeaturePartitionKey    = "partition key"
+	FeatureArrayField      = "array field"
+	FeatureSparseVector    = "sparse vector field"
+	FeatureMultipleVectors = "multiple vector fields"
+	FeatureFunctions       = "functions"
+)
+
+// milvusVersion is the major.minor.patch of a milvus server, like v2.4.5 or 2.3.0-beta
+type milvusVersion struct {
+	major int
+	minor int
+	patch int
+}
+
+// the first milvus versions supporting the features
+var (
+	versionDatabase        = milvusVersion{2, 2, 9}
+	versionJSONField       = milvusVersion{2, 2, 9}
+	versionDynamicField    = milvusVersion{2, 2, 9}
+	versionPartitionKey    = milvusVersion{2, 2, 9}
+	versionArrayField      = milvusVersion{2, 3, 0}
+	versionSparseVector    = milvusVersion{2, 4, 0}
+	versionMultipleVectors = milvusVersion{2, 4, 0}
+	versionFunctions       = milvusVersion{2, 5, 0}
+)
+
+var milvusVersionPattern = regexp.MustCompile(`^v?(\d+)\.(\d+)(?:\.(\d+))?`)
+
+// parseMilvusVersion parses the version returned by milvus, false if it is not a release version, like a dev build
+func parseMilvusVersion(version string) (milvusVersion, bool) {
+	matches := milvusVersionPattern.FindStringSubmatch(strings.TrimSpace(version))
+	if matches == nil {
+		return milvusVersion{}, false
+	}
+	major, _ := strconv.Atoi(matches[1])
+	minor, _ := strconv.Atoi(matches[2])
+	patch := 0
+	if matches[3] != "" {
+		patch, _ = strconv.Atoi(matches[3])
+	}
+	return milvusVersion{major: major, minor: minor, patch: patch}, true
+}
+
+func (v milvusVersion) less(other milvusVersion) bool {
+	if v.major != other.major {
+		return v.major < other.major
+	}
+	if v.minor != other.minor {
+		return v.minor < other.minor
+	}
+	return v.patch < other.patch
+}
+
+func (v milvusVersion) String() string {
+	return fmt.Sprintf("v%d.%d.%d", v.major, v.minor, v.patch)
+}
+
+// targetMilvusVersion returns the version of the connected milvus, false if it is unknown, then nothing is adapted to
+// it, as before the versions are checked
+func (b *BackupContext) targetMilvusVersion(ctx context.Context) (milvusVersion, bool) {
+	version, err := b.getMilvusClient().GetVersion(ctx)
+	if err != nil {
+		log.Warn("fail to get milvus version, restore without checking the features supported", zap.Error(err))
+		return milvusVersion{}, false
+	}
+	parsed, ok := parseMilvusVersion(version)
+	if !ok {
+		log.Warn("unknown milvus version, restore without checking the features supported", zap.String("version", version))
+	}
+	return parsed, ok
+}
+
+func isVectorType(dataType backuppb.DataType) bool {
+	return dataType == backuppb.DataType_BinaryVector || dataType == backuppb.DataType_FloatVector ||
+		dataType == backuppb.DataType_SparseFloatVector
+}
+
+// collectionCompatIssues returns the features of the collection restored into targetDB which the target version
+// doesn't support. A feature is downgradable if the collection can be created without it, fields of types not
+// supported are not restored as long as a vector field is left.
+func collectionCompatIssues(collection *backuppb.CollectionBackupInfo, targetDB string, target milvusVersion) []*backuppb.CompatIssue {
+	db := collection.GetDbName()
+	if db == "" {
+		db = "default"
+	}
+	name := db + "." + collection.GetCollectionName()
+	schema := collection.GetSchema()
+	issues := make([]*backuppb.CompatIssue, 0)
+	addIssue := func(feature string, since milvusVersion, downgradable bool, detail string) {
+		issues = append(issues, &backuppb.CompatIssue{
+			Collection:   name,
+			Feature:      feature,
+			SinceVersion: since.String(),
+			Downgradable: downgradable,
+			Detail:       detail,
+		})
+	}
+
+	if targetDB != "" && targetDB != "default" && target.less(versionDatabase) {
+		addIssue(FeatureDatabase, versionDatabase, false,
+			fmt.Sprintf("database %s can't be created, restore into database default by target_db_name", targetDB))
+	}
+	if schema.GetEnableDynamicField() && target.less(versionDynamicField) {
+		addIssue(FeatureDynamicField, versionDynamicField, true, "values of the dynamic field are not restored")
+	}
+
+	skipped := make(map[int64]bool)
+	skipFields := func(feature string, since milvusVersion, matches func(field *backuppb.FieldSchema) bool) {
+		if !target.less(since) {
+			return
+		}
+		names := make([]string, 0)
+		for _, field := range schema.GetFields() {
+			if !field.GetIsDynamic() && matches(field) {
+				skipped[field.GetFieldID()] = true
+				names = append(names, field.GetName())
+			}
+		}
+		if len(names) > 0 {
+			addIssue(feature, since, true, fmt.Sprintf("fields %s are not restored", strings.Join(names, ", ")))
+		}
+	}
+	skipFields(FeatureJSONField, versionJSONField, func(field *backuppb.FieldSchema) bool {
+		return field.GetDataType() == backuppb.DataType_Json
+	})
+	skipFields(FeatureArrayField, versionArrayField, func(field *backuppb.FieldSchema) bool {
+		return field.GetDataType() == backuppb.DataType_Array
+	})
+	skipFields(FeatureSparseVector, versionSparseVector, func(field *backuppb.FieldSchema) bool {
+		return field.GetDataType() == backuppb.DataType_SparseFloatVector
+	})
+	// only the first vector field left is restored
+	vectorFields := 0
+	skipFields(FeatureMultipleVectors, versionMultipleVectors, func(field *backuppb.FieldSchema) bool {
+		if !isVectorType(field.GetDataType()) || skipped[field.GetFieldID()] {
+			return false
+		}
+		vectorFields++
+		return vectorFields > 1
+	})
+
+	if len(schema.GetFunctions()) > 0 && target.less(versionFunctions) {
+		names := make([]string, 0, len(schema.GetFunctions()))
+		for _, function := range schema.GetFunctions() {
+			names = append(names, function.GetName())
+		}
+		addIssue(FeatureFunctions, versionFunctions, true,
+			fmt.Sprintf("functions %s are not restored, their output fields are restored as data", strings.Join(names, ", ")))
+	}
+	for _, field := range schema.GetFields() {
+		if field.GetIsPartitionKey() && target.less(versionPartitionKey) {
+			addIssue(FeaturePartitionKey, versionPartitionKey, false,
+				fmt.Sprintf("field %s is the partition key, its partitions can't be created", field.GetName()))
+		}
+	}
+
+	hasVector := false
+	for _, field := range schema.GetFields() {
+		hasVector = hasVector || (isVectorType(field.GetDataType()) && !skipped[field.GetFieldID()])
+	}
+	if !hasVector && len(skipped) > 0 {
+		// a collection can't be created without vector fields
+		for _, issue := range issues {
+			if issue.GetDowngradable() && issue.GetFeature() != FeatureDynamicField && issue.GetFeature() != FeatureFunctions {
+				issue.Downgradable = false
+				issue.Detail = issue.GetDetail() + ", no vector field is left"
+			}
+		}
+	}
+	return issues
+}
+
+// downgradeCollection returns a copy of the collection without the features of the downgradable issues, and the ids
+// of the fields whose data is not restored
+func downgradeCollection(collection *backuppb.CollectionBackupInfo, issues []*backuppb.CompatIssue, target milvusVersion) (*backuppb.CollectionBackupInfo, []int64) {
+	if len(issues) == 0 {
+		return collection, nil
+	}
+	collection = proto.Clone(collection).(*backuppb.CollectionBackupInfo)
+	schema := collection.GetSchema()
+	features := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		features[issue.GetFeature()] = true
+	}
+
+	vectorFields := 0
+	skippedIds := make([]int64, 0)
+	skippedNames := make(map[string]bool)
+	fields := make([]*backuppb.FieldSchema, 0, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		skip := false
+		switch {
+		case field.GetIsDynamic():
+			skip = features[FeatureDynamicField]
+		case field.GetDataType() == backuppb.DataType_Json:
+			skip = features[FeatureJSONField]
+		case field.GetDataType() == backuppb.DataType_Array:
+			skip = features[FeatureArrayField]
+		case field.GetDataType() == backuppb.DataType_SparseFloatVector && features[FeatureSparseVector]:
+			skip = true
+		case isVectorType(field.GetDataType()):
+			vectorFields++
+			skip = features[FeatureMultipleVectors] && vectorFields > 1
+		}
+		if skip {
+			skippedIds = append(skippedIds, field.GetFieldID())
+			skippedNames[field.GetName()] = true
+			continue
+		}
+		if features[FeatureFunctions] {
+			field.IsFunctionOutput = false
+		}
+		fields = append(fields, field)
+	}
+	schema.Fields = fields
+	if features[FeatureDynamicField] {
+		schema.EnableDynamicField = false
+	}
+	if features[FeatureFunctions] {
+		schema.Functions = nil
+	}
+
+	// indexes of the fields not restored can't be created
+	indexes := make([]*backuppb.IndexInfo, 0, len(collection.GetIndexInfos()))
+	for _, index := range collection.GetIndexInfos() {
+		if !skippedNames[index.GetFieldName()] {
+			indexes = append(indexes, index)
+		}
+	}
+	collection.IndexInfos = indexes
+	log.Info("downgrade the collection for the target milvus",
+		zap.String("collection", collection.GetDbName()+"."+collection.GetCollectionName()),
+		zap.String("targetVersion", target.String()),
+		zap.Int64s("skippedFieldIds", skippedIds))
+	return collection, skippedIds
+}
+
+// formatCompatIssues formats the issues in the error of a restore
+func formatCompatIssues(issues []*backuppb.CompatIssue) string {
+	items := make([]string, 0, len(issues))
+	for _, issue := range issues {
+		items = append(items, fmt.Sprintf("%s uses %s supported since %s: %s",
+			issue.GetCollection(), issue.GetFeature(), issue.GetSinceVersion(), issue.GetDetail()))
+	}
+	return strings.Join(items, "; ")
+}
+
+// CheckCompatibility checks the features used by the collections of a backup against the target milvus version
+func (b *BackupContext) CheckCompatibility(ctx context.Context, request *backuppb.CheckCompatibilityRequest) *backuppb.CheckCompatibilityResponse {
+	resp := &backuppb.CheckCompatibilityResponse{
+		RequestId: request.GetRequestId(),
+	}
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+
+	var target milvusVersion
+	if request.GetTargetVersion() != "" {
+		parsed, ok := parseMilvusVersion(request.GetTargetVersion())
+		if !ok {
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = fmt.Sprintf("illegal target version %s, format: v2.4.0", request.GetTargetVersion())
+			return resp
+		}
+		target = parsed
+	} else {
+		version, err := b.getMilvusClient().GetVersion(ctx)
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = "fail to get milvus version: " + err.Error()
+			return resp
+		}
+		parsed, ok := parseMilvusVersion(version)
+		if !ok {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("unknown milvus version %s, set the target version", version)
+			return resp
+		}
+		target = parsed
+	}
+	resp.TargetVersion = target.String()
+
+	getResp := b.GetBackup(ctx, &backuppb.GetBackupRequest{
+		BackupName: request.GetBackupName(),
+		BucketName: request.GetBucketName(),
+		Path:       request.GetPath(),
+	})
+	if getResp.GetCode() != backuppb.ResponseCode_Success {
+		resp.Code = getResp.GetCode()
+		resp.Msg = getResp.GetMsg()
+		return resp
+	}
+
+	selected := make(map[string]bool, len(request.GetCollectionNames()))
+	for _, name := range request.GetCollectionNames() {
+		if !strings.Contains(name, ".") {
+			name = "default." + name
+		}
+		selected[name] = true
+	}
+	issues := make([]*backuppb.CompatIssue, 0)
+	for _, collection := range getResp.GetData().GetCollectionBackups() {
+		db := collection.GetDbName()
+		if db == "" {
+			db = "default"
+		}
+		if len(selected) > 0 && !selected[db+"."+collection.GetCollectionName()] {
+			continue
+		}
+		targetDB := db
+		if request.GetTargetDbName() != "" {
+			targetDB = request.GetTargetDbName()
+		}
+		issues = append(issues, collectionCompatIssues(collection, targetDB, target)...)
+	}
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].GetCollection() < issues[j].GetCollection() })
+	resp.Issues = issues
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	return resp
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestParseMilvusVersion(t *testing.T) {
+	cases := []struct {
+		version string
+		want    milvusVersion
+		ok      bool
+	}{
+		{"v2.4.5", milvusVersion{2, 4, 5}, true},
+		{"2.3.0-beta", milvusVersion{2, 3, 0}, true},
+		{"v2.2.16-hotfix", milvusVersion{2, 2, 16}, true},
+		{"v2.5", milvusVersion{2, 5, 0}, true},
+		{"master-20240101-abcdef", milvusVersion{}, false},
+		{"", milvusVersion{}, false},
+	}
+	for _, c := range cases {
+		version, ok := parseMilvusVersion(c.version)
+		assert.Equal(t, c.ok, ok, c.version)
+		assert.Equal(t, c.want, version, c.version)
+	}
+	assert.True(t, milvusVersion{2, 2, 16}.less(versionArrayField))
+	assert.False(t, milvusVersion{2, 3, 0}.less(versionArrayField))
+	assert.Equal(t, "v2.2.9", versionDatabase.String())
+}
+
+// compatCollection uses the features of milvus 2.5 but partition key
+func compatCollection() *backuppb.CollectionBackupInfo {
+	return &backuppb.CollectionBackupInfo{
+		DbName:         "db1",
+		CollectionName: "docs",
+		Schema: &backuppb.CollectionSchema{
+			Name:               "docs",
+			EnableDynamicField: true,
+			Fields: []*backuppb.FieldSchema{
+				{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: backuppb.DataType_Int64},
+				{FieldID: 101, Name: "text", DataType: backuppb.DataType_VarChar},
+				{FieldID: 102, Name: "meta", DataType: backuppb.DataType_Json},
+				{FieldID: 103, Name: "tags", DataType: backuppb.DataType_Array, ElementType: backuppb.DataType_VarChar},
+				{FieldID: 104, Name: "dense", DataType: backuppb.DataType_FloatVector},
+				{FieldID: 105, Name: "image", DataType: backuppb.DataType_FloatVector},
+				{FieldID: 106, Name: "sparse", DataType: backuppb.DataType_SparseFloatVector, IsFunctionOutput: true},
+				{FieldID: 107, Name: "$meta", DataType: backuppb.DataType_Json, IsDynamic: true},
+			},
+			Functions: []*backuppb.FunctionSchema{{Name: "bm25", Type: backuppb.FunctionType_BM25, OutputFieldNames: []string{"sparse"}}},
+		},
+		IndexInfos: []*backuppb.IndexInfo{{FieldName: "dense"}, {FieldName: "image"}, {FieldName: "sparse"}},
+	}
+}
+
+func compatFeatures(issues []*backuppb.CompatIssue) map[string]bool {
+	features := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		features[issue.GetFeature()] = issue.GetDowngradable()
+	}
+	return features
+}
+
+func TestCollectionCompatIssues(t *testing.T) {
+	collection := compatCollection()
+	assert.Empty(t, collectionCompatIssues(collection, "db1", milvusVersion{2, 5, 0}))
+
+	issues := collectionCompatIssues(collection, "db1", milvusVersion{2, 4, 0})
+	assert.Equal(t, map[string]bool{FeatureFunctions: true}, compatFeatures(issues))
+	assert.Equal(t, "db1.docs", issues[0].GetCollection())
+	assert.Equal(t, "v2.5.0", issues[0].GetSinceVersion())
+
+	issues = collectionCompatIssues(collection, "db1", milvusVersion{2, 3, 5})
+	assert.Equal(t, map[string]bool{FeatureSparseVector: true, FeatureMultipleVectors: true, FeatureFunctions: true}, compatFeatures(issues))
+
+	// databases are not supported, neither are the fields skipped restoring into default
+	issues = collectionCompatIssues(collection, "db1", milvusVersion{2, 2, 8})
+	assert.Equal(t, map[string]bool{
+		FeatureDatabase:        false,
+		FeatureDynamicField:    true,
+		FeatureJSONField:       true,
+		FeatureArrayField:      true,
+		FeatureSparseVector:    true,
+		FeatureMultipleVectors: true,
+		FeatureFunctions:       true,
+	}, compatFeatures(issues))
+	assert.NotContains(t, compatFeatures(collectionCompatIssues(collection, "default", milvusVersion{2, 2, 8})), FeatureDatabase)
+
+	// a collection can't be created without vector fields
+	sparseOnly := compatCollection()
+	sparseOnly.Schema.Fields = []*backuppb.FieldSchema{
+		{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: backuppb.DataType_Int64},
+		{FieldID: 106, Name: "sparse", DataType: backuppb.DataType_SparseFloatVector},
+	}
+	sparseOnly.Schema.Functions = nil
+	issues = collectionCompatIssues(sparseOnly, "db1", milvusVersion{2, 3, 0})
+	assert.Equal(t, map[string]bool{FeatureSparseVector: false}, compatFeatures(issues))
+
+	partitionKey := compatCollection()
+	partitionKey.Schema.Fields[1].IsPartitionKey = true
+	assert.False(t, compatFeatures(collectionCompatIssues(partitionKey, "default", milvusVersion{2, 2, 0}))[FeaturePartitionKey])
+}
+
+func TestDowngradeCollection(t *testing.T) {
+	collection := compatCollection()
+	target := milvusVersion{2, 2, 8}
+	issues := collectionCompatIssues(collection, "default", target)
+	downgraded, skipped := downgradeCollection(collection, issues, target)
+	assert.ElementsMatch(t, []int64{102, 103, 105, 106, 107}, skipped)
+	names := make([]string, 0)
+	for _, field := range downgraded.GetSchema().GetFields() {
+		names = append(names, field.GetName())
+	}
+	assert.Equal(t, []string{"id", "text", "dense"}, names)
+	assert.False(t, downgraded.GetSchema().GetEnableDynamicField())
+	assert.Empty(t, downgraded.GetSchema().GetFunctions())
+	assert.Equal(t, []*backuppb.IndexInfo{{FieldName: "dense"}}, downgraded.GetIndexInfos())
+	// the collection in backup is not changed
+	assert.Len(t, collection.GetSchema().GetFields(), 8)
+
+	// the output of functions is restored as data
+	target = milvusVersion{2, 4, 0}
+	downgraded, skipped = downgradeCollection(collection, collectionCompatIssues(collection, "db1", target), target)
+	assert.Empty(t, skipped)
+	assert.Len(t, downgraded.GetSchema().GetFields(), 8)
+	assert.False(t, downgraded.GetSchema().GetFields()[6].GetIsFunctionOutput())
+	assert.Empty(t, downgraded.GetSchema().GetFunctions())
+
+	unchanged, skipped := downgradeCollection(collection, nil, target)
+	assert.Same(t, collection, unchanged)
+	assert.Empty(t, skipped)
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	ctx := context.Background()
+	b := newManifestBackupContext(&memoryChunkManager{files: make(map[string][]byte)})
+	b.started = true
+	other := compatCollection()
+	other.CollectionName = "plain"
+	other.Schema = &backuppb.CollectionSchema{Name: "plain", Fields: []*backuppb.FieldSchema{
+		{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: backuppb.DataType_Int64},
+		{FieldID: 101, Name: "vector", DataType: backuppb.DataType_FloatVector},
+	}}
+	output, err := serialize(&backuppb.BackupInfo{
+		Name:              "compat",
+		StateCode:         backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{compatCollection(), other},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, b.writeBackupMeta(ctx, "compat", output))
+
+	resp := b.CheckCompatibility(ctx, &backuppb.CheckCompatibilityRequest{BackupName: "compat", TargetVersion: "2.3.0"})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, "v2.3.0", resp.GetTargetVersion())
+	assert.Len(t, resp.GetIssues(), 3)
+
+	resp = b.CheckCompatibility(ctx, &backuppb.CheckCompatibilityRequest{BackupName: "compat", TargetVersion: "v2.2.0", CollectionNames: []string{"db1.plain"}})
+	assert.Equal(t, FeatureDatabase, resp.GetIssues()[0].GetFeature())
+	resp = b.CheckCompatibility(ctx, &backuppb.CheckCompatibilityRequest{BackupName: "compat", TargetVersion: "v2.2.0", CollectionNames: []string{"db1.plain"}, TargetDbName: "default"})
+	assert.Empty(t, resp.GetIssues())
+
+	resp = b.CheckCompatibility(ctx, &backuppb.CheckCompatibilityRequest{BackupName: "compat", TargetVersion: "latest"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	resp = b.CheckCompatibility(ctx, &backuppb.CheckCompatibilityRequest{TargetVersion: "v2.3.0"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+}
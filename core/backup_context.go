@@ -4,12 +4,16 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
+	"google.golang.org/grpc"
 
 	"github.com/zilliztech/milvus-backup/core/paramtable"
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
@@ -17,20 +21,30 @@ import (
 	"github.com/zilliztech/milvus-backup/core/utils"
 	"github.com/zilliztech/milvus-backup/internal/common"
 	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/notify"
+	"github.com/zilliztech/milvus-backup/internal/trace"
 )
 
 const (
-	BULKINSERT_TIMEOUT        = 60 * 60
-	BULKINSERT_SLEEP_INTERVAL = 5
-	BACKUP_NAME               = "BACKUP_NAME"
-	COLLECTION_RENAME_SUFFIX  = "COLLECTION_RENAME_SUFFIX"
-	WORKER_NUM                = 100
-	RPS                       = 1000
+	BACKUP_NAME              = "BACKUP_NAME"
+	COLLECTION_RENAME_SUFFIX = "COLLECTION_RENAME_SUFFIX"
+	COLLECTION_NAME          = "COLLECTION_NAME"
+	DATABASE_NAME            = "DATABASE_NAME"
+	WORKER_NUM               = 100
+	RPS                      = 1000
+
+	BACKUP_CHECKPOINT_INTERVAL = 10 * time.Second
+	// interval to check whether the executing backup is paused by another process
+	BACKUP_PAUSE_CHECK_INTERVAL = 10 * time.Second
+	// times to list the segments of a collection again if binlogs are deleted by compaction during backup
+	COMPACTED_SEGMENT_RETRY = 3
 )
 
 // makes sure BackupContext implements `Backup`
 var _ Backup = (*BackupContext)(nil)
 
+var errDatabaseOutOfScope = errors.New("database out of the scope of backups")
+
 type BackupContext struct {
 	ctx context.Context
 	// lock to make sure only one backup is creating or restoring
@@ -40,6 +54,12 @@ type BackupContext struct {
 
 	// milvus client
 	milvusClient *MilvusClient
+	// the context whose milvus client and worker pools are used instead of its own, like the base context of the
+	// contexts of tenants, nil if not shared
+	shared *BackupContext
+	// the databases the collections are backed up from and restored into, like the databases of a tenant, all
+	// databases if empty
+	databases map[string]bool
 
 	// data storage client
 	storageClient    *storage.ChunkManager
@@ -47,34 +67,101 @@ type BackupContext struct {
 	backupBucketName string
 	milvusRootPath   string
 	backupRootPath   string
+	// client of backupStorage, only used if backup data is not stored in the storage of milvus
+	backupStorageClient *storage.ChunkManager
 
 	backupNameIdDict sync.Map //map[string]string
 	backupTasks      sync.Map //map[string]*backuppb.BackupInfo
 
 	restoreTasks map[string]*backuppb.RestoreBackupTask
+	// backup and restore jobs by task id, map[string]*backupJob
+	jobs sync.Map
+	// pauses the executing backups by name, map[string]func()
+	backupPauses sync.Map
 
 	backupCollectionWorkerPool *common.WorkerPool
 	backupCopyDataWorkerPool   *common.WorkerPool
-	bulkinsertWorkerPool       *common.WorkerPool
+	// worker pools of the parallelism set by requests, see getRequestWorkerPool
+	requestWorkerPools   map[string]*common.WorkerPool
+	requestWorkerPoolsMu sync.Mutex
+
+	// key to encrypt backup files, nil means not encrypt
+	encryptionKey []byte
+
+	// sends the events of finished and slow tasks to webhooks
+	notifier *notify.Notifier
+
+	// lock to update the copied size of executing backup
+	progressMu sync.Mutex
+
+	// lock to write the checkpoint of executing backup
+	checkpointMu       sync.Mutex
+	lastCheckpointTime time.Time
+	// limits the copy parallelism of executing backup by the load of milvus, nil means not limited
+	copyLimiter *common.AdaptiveLimiter
+	// limits the import tasks of executing restore in milvus, nil means not limited
+	bulkInsertLimiter *common.AdaptiveLimiter
+	// limits the binlog data held in memory by backups and restores, see withMemory
+	memoryBudget     *common.MemoryBudget
+	memoryBudgetOnce sync.Once
+
+	// lock to update and write the checkpoint of executing restore
+	restoreCheckpointMu sync.Mutex
+
+	// lock to update the dedup index, and the dedup objects held by executing backups by backup name,
+	// which are not deleted until the backups are counted into the index
+	dedupMu   sync.Mutex
+	dedupHeld map[string]map[string]bool
+
+	// lock to acquire and release the lock objects of backups in backup storage, the lock of cluster is shared by the
+	// backups executing in this process
+	lockMu      sync.Mutex
+	clusterLock *storageLock
+
+	// reads the etcd of milvus for etcd snapshots, connected by etcd in config if nil
+	etcdReader etcdReader
+
+	// where the binlogs of milvus are, the layout of minio in config if nil
+	binlogLayoutMu sync.Mutex
+	binlogLayout   BinlogLayout
+
+	// client of the readiness checks of milvus, connected on the first check
+	healthMu           sync.Mutex
+	healthMilvusClient gomilvus.Client
 }
 
 func CreateMilvusClient(ctx context.Context, params paramtable.BackupParams) (gomilvus.Client, error) {
 	milvusEndpoint := params.MilvusCfg.Address + ":" + params.MilvusCfg.Port
 	log.Debug("Start Milvus client", zap.String("endpoint", milvusEndpoint))
-	var c gomilvus.Client
-	var err error
+	// trace the grpc calls as children of the spans of backup and restore
+	dialOptions := make([]grpc.DialOption, len(gomilvus.DefaultGrpcOpts), len(gomilvus.DefaultGrpcOpts)+2)
+	copy(dialOptions, gomilvus.DefaultGrpcOpts)
+	dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(otelgrpc.UnaryClientInterceptor()))
+	config := gomilvus.Config{
+		Address:     milvusEndpoint,
+		DialOptions: dialOptions,
+	}
+	tlsConfig, err := milvusTLSConfig(params.MilvusCfg)
+	if err != nil {
+		log.Error("invalid milvus tls config", zap.Error(err))
+		return nil, err
+	}
+	if tlsConfig != nil {
+		config.DialOptions = append(config.DialOptions, withTLSDialer(tlsConfig))
+	} else if params.MilvusCfg.TLSMode != 0 {
+		config.EnableTLSAuth = true
+	}
 	if params.MilvusCfg.AuthorizationEnabled && params.MilvusCfg.User != "" && params.MilvusCfg.Password != "" {
-		if params.MilvusCfg.TLSMode == 0 {
-			c, err = gomilvus.NewDefaultGrpcClientWithAuth(ctx, milvusEndpoint, params.MilvusCfg.User, params.MilvusCfg.Password)
-		} else if params.MilvusCfg.TLSMode == 1 || params.MilvusCfg.TLSMode == 2 {
-			c, err = gomilvus.NewDefaultGrpcClientWithTLSAuth(ctx, milvusEndpoint, params.MilvusCfg.User, params.MilvusCfg.Password)
-		} else {
-			log.Error("milvus.TLSMode is not illegal, support value 0, 1, 2")
-			return nil, errors.New("milvus.TLSMode is not illegal, support value 0, 1, 2")
+		// the password is read when connecting, a password rotated afterwards is used by the next connection
+		password, err := utils.ResolveSecret(params.MilvusCfg.Password)
+		if err != nil {
+			log.Error("failed to read milvus password", zap.Error(err))
+			return nil, err
 		}
-	} else {
-		c, err = gomilvus.NewGrpcClient(ctx, milvusEndpoint)
+		config.Username = params.MilvusCfg.User
+		config.Password = password
 	}
+	c, err := gomilvus.NewClient(ctx, config)
 	if err != nil {
 		log.Error("failed to connect to milvus", zap.Error(err))
 		return nil, err
@@ -96,8 +183,36 @@ func (b *BackupContext) Start() error {
 	b.backupTasks = sync.Map{}
 	b.backupNameIdDict = sync.Map{}
 	b.restoreTasks = make(map[string]*backuppb.RestoreBackupTask)
+	if b.params.BackupCfg.EncryptionKey != "" {
+		key, err := utils.ParseEncryptionKey(b.params.BackupCfg.EncryptionKey)
+		if err != nil {
+			log.Error("failed to parse backup encryption key", zap.Error(err))
+			return err
+		}
+		b.encryptionKey = key
+	}
+	b.notifier = newNotifier(b.params.NotificationCfg)
 	b.started = true
-	log.Info(fmt.Sprintf("%+v", b.params.BackupCfg))
+	traceCfg := b.params.TraceCfg
+	err := trace.Init(b.ctx, trace.Config{
+		Endpoint:       traceCfg.Endpoint,
+		Insecure:       traceCfg.Insecure,
+		SampleFraction: traceCfg.SampleFraction,
+		ServiceName:    traceCfg.ServiceName,
+	})
+	if err != nil {
+		// backup and restore work without traces
+		log.Warn("fail to init trace exporter, spans will not be exported", zap.Error(err))
+	}
+	// never print the encryption key
+	backupCfg := b.params.BackupCfg
+	if backupCfg.EncryptionKey != "" {
+		backupCfg.EncryptionKey = "******"
+	}
+	if backupCfg.SSECustomerKey != "" {
+		backupCfg.SSECustomerKey = "******"
+	}
+	log.Info(fmt.Sprintf("%+v", backupCfg))
 	log.Info(fmt.Sprintf("%+v", b.params.HTTPCfg))
 	return nil
 }
@@ -123,6 +238,9 @@ func CreateBackupContext(ctx context.Context, params paramtable.BackupParams) *B
 }
 
 func (b *BackupContext) getMilvusClient() *MilvusClient {
+	if b.shared != nil {
+		return b.shared.getMilvusClient()
+	}
 	if b.milvusClient == nil {
 		milvusClient, err := CreateMilvusClient(b.ctx, b.params)
 		if err != nil {
@@ -136,6 +254,52 @@ func (b *BackupContext) getMilvusClient() *MilvusClient {
 	return b.milvusClient
 }
 
+// checkDatabaseScope returns errDatabaseOutOfScope if the collections of db are not backed up or restored by the context
+func (b *BackupContext) checkDatabaseScope(db string) error {
+	if len(b.databases) > 0 && !b.databases[db] {
+		return fmt.Errorf("%w: %s", errDatabaseOutOfScope, db)
+	}
+	return nil
+}
+
+// listScopeDatabases lists the databases whose collections are backed up if no collection is named, the databases of
+// the context if it is limited to some
+func (b *BackupContext) listScopeDatabases() ([]string, error) {
+	dbNames := make([]string, 0, len(b.databases))
+	if len(b.databases) > 0 {
+		for db := range b.databases {
+			dbNames = append(dbNames, db)
+		}
+		sort.Strings(dbNames)
+		return dbNames, nil
+	}
+	dbs, err := b.getMilvusClient().ListDatabases(b.ctx)
+	if err != nil {
+		log.Error("fail in ListDatabases", zap.Error(err))
+		return nil, err
+	}
+	for _, db := range dbs {
+		dbNames = append(dbNames, db.Name)
+	}
+	return dbNames, nil
+}
+
+// SetBinlogLayout replaces the layout of the binlogs of milvus configured by minio in config
+func (b *BackupContext) SetBinlogLayout(layout BinlogLayout) {
+	b.binlogLayoutMu.Lock()
+	defer b.binlogLayoutMu.Unlock()
+	b.binlogLayout = layout
+}
+
+func (b *BackupContext) getBinlogLayout() BinlogLayout {
+	b.binlogLayoutMu.Lock()
+	defer b.binlogLayoutMu.Unlock()
+	if b.binlogLayout == nil {
+		b.binlogLayout = newTemplateBinlogLayout(b.milvusRootPath, b.params.MinioCfg)
+	}
+	return b.binlogLayout
+}
+
 func (b *BackupContext) getStorageClient() storage.ChunkManager {
 	if b.storageClient == nil {
 		storageClient, err := CreateStorageClient(b.ctx, b.params)
@@ -148,13 +312,46 @@ func (b *BackupContext) getStorageClient() storage.ChunkManager {
 	return *b.storageClient
 }
 
+// getBackupStorageClient returns the client to access backup bucket, which is the storage client of milvus
+// unless backupStorage is configured
+func (b *BackupContext) getBackupStorageClient() storage.ChunkManager {
+	if !b.params.BackupStorageCfg.Enabled() {
+		return b.getStorageClient()
+	}
+	if b.backupStorageClient == nil {
+		log.Debug("Start backup storage client",
+			zap.String("storageType", b.params.BackupStorageCfg.StorageType),
+			zap.String("address", b.params.BackupStorageCfg.Address+":"+b.params.BackupStorageCfg.Port),
+			zap.String("backupBucket", b.params.MinioCfg.BackupBucketName))
+		storageClient, err := storage.NewBackupChunkManager(b.ctx, b.params)
+		if err != nil {
+			log.Error("failed to initial backup storage client", zap.Error(err))
+			panic(err)
+		}
+		b.backupStorageClient = &storageClient
+	}
+	return *b.backupStorageClient
+}
+
+// backupStorageType returns the storage type of backup bucket
+func (b *BackupContext) backupStorageType() string {
+	if b.params.BackupStorageCfg.Enabled() {
+		return b.params.BackupStorageCfg.StorageType
+	}
+	return b.params.MinioCfg.StorageType
+}
+
 func (b *BackupContext) getBackupCollectionWorkerPool() *common.WorkerPool {
+	if b.shared != nil {
+		return b.shared.getBackupCollectionWorkerPool()
+	}
 	if b.backupCollectionWorkerPool == nil {
 		wp, err := common.NewWorkerPool(b.ctx, b.params.BackupCfg.BackupCollectionParallelism, RPS)
 		if err != nil {
 			log.Error("failed to initial collection backup worker pool", zap.Error(err))
 			panic(err)
 		}
+		wp.SetName("backupCollection")
 		b.backupCollectionWorkerPool = wp
 		b.backupCollectionWorkerPool.Start()
 	}
@@ -162,29 +359,49 @@ func (b *BackupContext) getBackupCollectionWorkerPool() *common.WorkerPool {
 }
 
 func (b *BackupContext) getCopyDataWorkerPool() *common.WorkerPool {
+	if b.shared != nil {
+		return b.shared.getCopyDataWorkerPool()
+	}
 	if b.backupCopyDataWorkerPool == nil {
 		wp, err := common.NewWorkerPool(b.ctx, b.params.BackupCfg.BackupCopyDataParallelism, RPS)
 		if err != nil {
 			log.Error("failed to initial copy data worker pool", zap.Error(err))
 			panic(err)
 		}
+		wp.SetName("copydata")
 		b.backupCopyDataWorkerPool = wp
 		b.backupCopyDataWorkerPool.Start()
 	}
 	return b.backupCopyDataWorkerPool
 }
 
-func (b *BackupContext) getRestoreWorkerPool() *common.WorkerPool {
-	if b.bulkinsertWorkerPool == nil {
-		wp, err := common.NewWorkerPool(b.ctx, b.params.BackupCfg.RestoreParallelism, RPS)
-		if err != nil {
-			log.Error("failed to initial copy data worker pool", zap.Error(err))
-			panic(err)
-		}
-		b.bulkinsertWorkerPool = wp
-		b.bulkinsertWorkerPool.Start()
+// getRequestWorkerPool returns the shared pool if the parallelism is not set by request, otherwise the pool of
+// the parallelism. Pools of request parallelism are created once and reused by the requests of the same parallelism.
+func (b *BackupContext) getRequestWorkerPool(name string, parallelism int32, shared func() *common.WorkerPool) *common.WorkerPool {
+	if parallelism <= 0 {
+		return shared()
+	}
+	if b.shared != nil {
+		return b.shared.getRequestWorkerPool(name, parallelism, shared)
+	}
+	b.requestWorkerPoolsMu.Lock()
+	defer b.requestWorkerPoolsMu.Unlock()
+	key := fmt.Sprintf("%s-%d", name, parallelism)
+	if wp, ok := b.requestWorkerPools[key]; ok {
+		return wp
+	}
+	wp, err := common.NewWorkerPool(b.ctx, int(parallelism), RPS)
+	if err != nil {
+		log.Error("failed to initial request worker pool", zap.String("name", name), zap.Int32("parallelism", parallelism), zap.Error(err))
+		panic(err)
 	}
-	return b.bulkinsertWorkerPool
+	wp.SetName(name)
+	wp.Start()
+	if b.requestWorkerPools == nil {
+		b.requestWorkerPools = make(map[string]*common.WorkerPool)
+	}
+	b.requestWorkerPools[key] = wp
+	return wp
 }
 
 func (b *BackupContext) GetBackup(ctx context.Context, request *backuppb.GetBackupRequest) *backuppb.BackupInfoResponse {
@@ -248,7 +465,10 @@ func (b *BackupContext) GetBackup(ctx context.Context, request *backuppb.GetBack
 			}
 
 			resp.Data = backup
-			if backup == nil {
+			if errors.Is(err, errUnsupportedMetaVersion) {
+				// the backup exists but can't be read by this version
+				resp.Code = backuppb.ResponseCode_Not_Support
+			} else if backup == nil {
 				resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
 				resp.Msg = "not found"
 			} else {
@@ -288,7 +508,16 @@ func (b *BackupContext) ListBackups(ctx context.Context, request *backuppb.ListB
 	}
 	log.Info("receive ListBackupsRequest",
 		zap.String("requestId", request.GetRequestId()),
-		zap.String("collectionName", request.GetCollectionName()))
+		zap.String("collectionName", request.GetCollectionName()),
+		zap.Strings("databases", request.GetDatabases()),
+		zap.Int64("startTime", request.GetStartTime()),
+		zap.Int64("endTime", request.GetEndTime()),
+		zap.Any("states", request.GetStates()),
+		zap.String("orderBy", request.GetOrderBy()),
+		zap.Bool("desc", request.GetDesc()),
+		zap.Int32("offset", request.GetOffset()),
+		zap.Int32("limit", request.GetLimit()),
+		zap.String("labelSelector", request.GetLabelSelector()))
 
 	resp := &backuppb.ListBackupsResponse{
 		RequestId: request.GetRequestId(),
@@ -303,8 +532,25 @@ func (b *BackupContext) ListBackups(ctx context.Context, request *backuppb.ListB
 		}
 	}
 
+	if request.GetOffset() < 0 || request.GetLimit() < 0 {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "offset and limit should not be negative"
+		return resp
+	}
+	if !validListBackupsOrder[request.GetOrderBy()] {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("illegal order_by %s, support name, start_time and size", request.GetOrderBy())
+		return resp
+	}
+	selector, err := parseLabelSelector(request.GetLabelSelector())
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
 	// 1, trigger inner sync to get the newest backup list in the milvus cluster
-	backupPaths, _, err := b.getStorageClient().ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
+	backupPaths, _, err := b.getBackupStorageClient().ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
 	if err != nil {
 		log.Error("Fail to list backup directory", zap.Error(err))
 		resp.Code = backuppb.ResponseCode_Fail
@@ -312,13 +558,47 @@ func (b *BackupContext) ListBackups(ctx context.Context, request *backuppb.ListB
 		return resp
 	}
 
+	// dedup objects shared by backups, locks of backups and records of jobs are not a backup
+	dirs := backupPaths
+	backupPaths = make([]string, 0, len(dirs))
+	for _, backupPath := range dirs {
+		switch BackupPathToName(b.backupRootPath, backupPath) {
+		case DEDUP_DIR, LOCK_DIR, JOB_DIR:
+		default:
+			backupPaths = append(backupPaths, backupPath)
+		}
+	}
 	log.Info("List Backups' path", zap.Strings("backup_paths", backupPaths))
+
+	// read meta of backups concurrently
+	wp, err := common.NewWorkerPool(ctx, b.params.BackupCfg.ListMetaParallelism, RPS)
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	wp.Start()
+	backupResps := make([]*backuppb.BackupInfoResponse, len(backupPaths))
+	for i, backupPath := range backupPaths {
+		i, backupPath := i, backupPath
+		wp.Submit(func(ctx context.Context) error {
+			backupResps[i] = b.GetBackup(ctx, &backuppb.GetBackupRequest{
+				BackupName: BackupPathToName(b.backupRootPath, backupPath),
+			})
+			return nil
+		})
+	}
+	wp.Done()
+	if err := wp.Wait(); err != nil {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+
 	backupInfos := make([]*backuppb.BackupInfo, 0)
 	backupNames := make([]string, 0)
-	for _, backupPath := range backupPaths {
-		backupResp := b.GetBackup(ctx, &backuppb.GetBackupRequest{
-			BackupName: BackupPathToName(b.backupRootPath, backupPath),
-		})
+	for i, backupPath := range backupPaths {
+		backupResp := backupResps[i]
 		if backupResp.GetCode() != backuppb.ResponseCode_Success {
 			log.Warn("Fail to read backup",
 				zap.String("path", backupPath),
@@ -331,41 +611,127 @@ func (b *BackupContext) ListBackups(ctx context.Context, request *backuppb.ListB
 		}
 
 		// 2, list wanted backup
-		if backupResp.GetData() != nil {
-			if request.GetCollectionName() != "" {
-				// if request.GetCollectionName() is defined only return backups contains the certain collection
-				for _, collectionMeta := range backupResp.GetData().GetCollectionBackups() {
-					if collectionMeta.GetCollectionName() == request.GetCollectionName() {
-						backupInfos = append(backupInfos, backupResp.GetData())
-						backupNames = append(backupNames, backupResp.GetData().GetName())
-					}
-				}
-			} else {
-				backupInfos = append(backupInfos, backupResp.GetData())
-				backupNames = append(backupNames, backupResp.GetData().GetName())
-			}
+		if backupResp.GetData() != nil && matchListBackupsRequest(request, backupResp.GetData()) && selector.matchesBackup(backupResp.GetData()) {
+			backupInfos = append(backupInfos, backupResp.GetData())
+			backupNames = append(backupNames, backupResp.GetData().GetName())
 		}
 	}
 
-	// 3, return
+	// 3, sort and page
+	sortBackups(backupInfos, request.GetOrderBy(), request.GetDesc())
+	resp.Total = int32(len(backupInfos))
+	backupInfos = pageBackups(backupInfos, request.GetOffset(), request.GetLimit())
+	backupNames = make([]string, 0, len(backupInfos))
+	for _, backup := range backupInfos {
+		backupNames = append(backupNames, backup.GetName())
+	}
+
+	// 4, return
 	resp.Code = backuppb.ResponseCode_Success
 	resp.Msg = "success"
 	resp.Data = backupInfos
+	if request.GetWithoutDetail() {
+		resp = SimpleListBackupsResponse(resp)
+	}
 	log.Info("return ListBackupsResponse",
 		zap.String("requestId", resp.GetRequestId()),
 		zap.Int32("code", int32(resp.GetCode())),
 		zap.String("msg", resp.GetMsg()),
+		zap.Int32("total", resp.GetTotal()),
 		zap.Strings("data: list_backup_names", backupNames))
 	return resp
 }
 
+// validListBackupsOrder contains the supported order_by of ListBackupsRequest, empty means name
+var validListBackupsOrder = map[string]bool{"": true, "name": true, "start_time": true, "size": true}
+
+// sortBackups sorts backups by name, start_time or size, backups with the same key are sorted by name
+func sortBackups(backups []*backuppb.BackupInfo, orderBy string, desc bool) {
+	sort.SliceStable(backups, func(i, j int) bool {
+		a, b := backups[i], backups[j]
+		if desc {
+			a, b = b, a
+		}
+		switch orderBy {
+		case "start_time":
+			if a.GetStartTime() != b.GetStartTime() {
+				return a.GetStartTime() < b.GetStartTime()
+			}
+		case "size":
+			if a.GetSize() != b.GetSize() {
+				return a.GetSize() < b.GetSize()
+			}
+		}
+		return a.GetName() < b.GetName()
+	})
+}
+
+// pageBackups returns at most limit backups after skipping offset ones, limit 0 means no limit
+func pageBackups(backups []*backuppb.BackupInfo, offset int32, limit int32) []*backuppb.BackupInfo {
+	if int(offset) >= len(backups) {
+		return []*backuppb.BackupInfo{}
+	}
+	backups = backups[offset:]
+	if limit > 0 && int(limit) < len(backups) {
+		backups = backups[:limit]
+	}
+	return backups
+}
+
+// matchListBackupsRequest returns whether the backup matches the start time range and states of the request,
+// and contains a collection matching the collection name and databases, empty conditions match all
+func matchListBackupsRequest(request *backuppb.ListBackupsRequest, backup *backuppb.BackupInfo) bool {
+	// start time of backup is in milliseconds
+	startTime := backup.GetStartTime() / 1000
+	if request.GetStartTime() > 0 && startTime < request.GetStartTime() {
+		return false
+	}
+	if request.GetEndTime() > 0 && startTime >= request.GetEndTime() {
+		return false
+	}
+	if len(request.GetStates()) > 0 {
+		matched := false
+		for _, state := range request.GetStates() {
+			if backup.GetStateCode() == state {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	if request.GetCollectionName() == "" && len(request.GetDatabases()) == 0 {
+		return true
+	}
+	databases := make(map[string]bool, len(request.GetDatabases()))
+	for _, db := range request.GetDatabases() {
+		databases[db] = true
+	}
+	for _, collectionMeta := range backup.GetCollectionBackups() {
+		if request.GetCollectionName() != "" && collectionMeta.GetCollectionName() != request.GetCollectionName() {
+			continue
+		}
+		dbName := collectionMeta.GetDbName()
+		if dbName == "" {
+			dbName = "default"
+		}
+		if len(databases) > 0 && !databases[dbName] {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
 func (b *BackupContext) DeleteBackup(ctx context.Context, request *backuppb.DeleteBackupRequest) *backuppb.DeleteBackupResponse {
 	if request.GetRequestId() == "" {
 		request.RequestId = utils.UUID()
 	}
 	log.Info("receive DeleteBackupRequest",
 		zap.String("requestId", request.GetRequestId()),
-		zap.String("backupName", request.GetBackupName()))
+		zap.String("backupName", request.GetBackupName()),
+		zap.Bool("force", request.GetForce()))
 
 	resp := &backuppb.DeleteBackupResponse{
 		RequestId: request.GetRequestId(),
@@ -406,29 +772,138 @@ func (b *BackupContext) DeleteBackup(ctx context.Context, request *backuppb.Dele
 		return resp
 	}
 
-	err := b.getStorageClient().RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, request.GetBackupName()))
+	// incremental backups can't be restored without the backups they reference
+	listResp := b.ListBackups(ctx, &backuppb.ListBackupsRequest{})
+	if listResp.GetCode() != backuppb.ResponseCode_Success {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = listResp.GetMsg()
+		return resp
+	}
+	dependents := dependentBackups(listResp.GetData(), request.GetBackupName())
+	if len(dependents) > 0 && !request.GetForce() {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("backup %s is referenced by incremental backups %s, delete them first or set force to delete them together",
+			request.GetBackupName(), strings.Join(dependents, ", "))
+		return resp
+	}
+
+	// object lock refuses deleting the locked versions, the delete would only hide the backup behind delete markers
+	toDelete := map[string]bool{request.GetBackupName(): true}
+	for _, dependent := range dependents {
+		toDelete[dependent] = true
+	}
+	now := time.Now()
+	backups := map[string]*backuppb.BackupInfo{request.GetBackupName(): getResp.GetData()}
+	for _, backup := range listResp.GetData() {
+		name := backup.GetName()
+		if toDelete[name] && backups[name] == nil {
+			backups[name] = backup
+		}
+		if toDelete[name] && isObjectLocked(backup, now) {
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = fmt.Sprintf("backup %s is locked in %s mode until %s", name, backup.GetObjectLockMode(),
+				time.Unix(backup.GetObjectLockRetainUntil(), 0).Format(time.RFC3339))
+			return resp
+		}
+	}
 
-	if err != nil {
-		log.Error("Fail to delete backup", zap.String("backupName", request.GetBackupName()), zap.Error(err))
-		return nil
+	// dependents are sorted so that a backup is always deleted before the backups it references
+	for _, backupName := range append(dependents, request.GetBackupName()) {
+		err := b.getBackupStorageClient().RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, backupName))
+		if err != nil {
+			log.Error("Fail to delete backup", zap.String("backupName", backupName), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("fail to delete backup %s: %s", backupName, err.Error())
+			return resp
+		}
+		// dedup objects are deleted with the last backup referencing them
+		b.unrefDedupObjects(ctx, backups[backupName])
+		resp.DeletedBackups = append(resp.DeletedBackups, backupName)
 	}
 
 	resp.Code = backuppb.ResponseCode_Success
 	resp.Msg = "success"
 	log.Info("return DeleteBackupResponse",
 		zap.String("requestId", resp.GetRequestId()),
-		zap.Int32("code", int32(resp.GetCode())))
+		zap.Int32("code", int32(resp.GetCode())),
+		zap.Strings("deleted", resp.GetDeletedBackups()))
 	return resp
 }
 
+// writeBackupFile writes a file into backup bucket, the file is encrypted if encryption is enabled
+func (b *BackupContext) writeBackupFile(ctx context.Context, filePath string, data []byte) error {
+	data, err := b.encodeBackupFile(data)
+	if err != nil {
+		return err
+	}
+	return b.getBackupStorageClient().Write(ctx, b.backupBucketName, filePath, data)
+}
+
+// encodeBackupFile returns the content to store in backup bucket, encrypted if encryption is enabled
+func (b *BackupContext) encodeBackupFile(data []byte) ([]byte, error) {
+	if b.encryptionKey == nil {
+		return data, nil
+	}
+	return utils.Encrypt(b.encryptionKey, data)
+}
+
+// readBackupFile reads a file of backup, the file is decrypted if it is encrypted
+func (b *BackupContext) readBackupFile(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	data, _, err := b.readBackupFileEncrypted(ctx, bucketName, filePath)
+	return data, err
+}
+
+// readBackupFileEncrypted reads a file of backup like readBackupFile, and returns whether the file is encrypted
+func (b *BackupContext) readBackupFileEncrypted(ctx context.Context, bucketName string, filePath string) ([]byte, bool, error) {
+	data, err := b.getBackupStorageClient().Read(ctx, bucketName, filePath)
+	if err != nil {
+		return nil, false, err
+	}
+	if !utils.IsEncrypted(data) {
+		return data, false, nil
+	}
+	if b.encryptionKey == nil {
+		return nil, true, fmt.Errorf("backup file %s is encrypted, but backup.encryption.key is not configured", filePath)
+	}
+	data, err = utils.Decrypt(b.encryptionKey, data)
+	return data, true, err
+}
+
+// readSegmentFile reads a binlog of segment in backup, the binlog of an encrypted segment is rejected unless it is
+// encrypted, so that it can't be replaced by a plain one
+func (b *BackupContext) readSegmentFile(ctx context.Context, segment *backuppb.SegmentBackupInfo, bucketName string, filePath string) ([]byte, error) {
+	data, encrypted, err := b.readBackupFileEncrypted(ctx, bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if segment.GetEncrypted() && !encrypted {
+		return nil, fmt.Errorf("binlog %s of encrypted segment %d is not encrypted", filePath, segment.GetSegmentId())
+	}
+	return data, nil
+}
+
+// readBackup reads the meta of a backup upgraded into the current version, nil if the backup doesn't exist
 func (b *BackupContext) readBackup(ctx context.Context, bucketName string, backupPath string) (*backuppb.BackupInfo, error) {
+	backup, err := b.readBackupMeta(ctx, bucketName, backupPath)
+	if err != nil || backup == nil {
+		return backup, err
+	}
+	if _, err := upgradeBackupMeta(backup); err != nil {
+		log.Warn("Fail to upgrade backup meta", zap.String("backupPath", backupPath), zap.Error(err))
+		return nil, err
+	}
+	return backup, nil
+}
+
+// readBackupMeta reads the meta of a backup as it is stored, nil if the backup doesn't exist
+func (b *BackupContext) readBackupMeta(ctx context.Context, bucketName string, backupPath string) (*backuppb.BackupInfo, error) {
 	backupMetaDirPath := backupPath + SEPERATOR + META_PREFIX
 	backupMetaPath := backupMetaDirPath + SEPERATOR + BACKUP_META_FILE
 	collectionMetaPath := backupMetaDirPath + SEPERATOR + COLLECTION_META_FILE
 	partitionMetaPath := backupMetaDirPath + SEPERATOR + PARTITION_META_FILE
 	segmentMetaPath := backupMetaDirPath + SEPERATOR + SEGMENT_META_FILE
 
-	exist, err := b.getStorageClient().Exist(ctx, bucketName, backupMetaPath)
+	exist, err := b.getBackupStorageClient().Exist(ctx, bucketName, backupMetaPath)
 	if err != nil {
 		log.Error("check backup meta file failed", zap.String("path", backupMetaPath), zap.Error(err))
 		return nil, err
@@ -438,22 +913,31 @@ func (b *BackupContext) readBackup(ctx context.Context, bucketName string, backu
 		return nil, err
 	}
 
-	backupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, backupMetaPath)
+	// the meta files of an encrypted backup are all encrypted
+	plainMetaPaths := make([]string, 0)
+	readMeta := func(metaPath string) ([]byte, error) {
+		data, encrypted, err := b.readBackupFileEncrypted(ctx, bucketName, metaPath)
+		if err == nil && !encrypted {
+			plainMetaPaths = append(plainMetaPaths, metaPath)
+		}
+		return data, err
+	}
+	backupMetaBytes, err := readMeta(backupMetaPath)
 	if err != nil {
 		log.Error("Read backup meta failed", zap.String("path", backupMetaPath), zap.Error(err))
 		return nil, err
 	}
-	collectionBackupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, collectionMetaPath)
+	collectionBackupMetaBytes, err := readMeta(collectionMetaPath)
 	if err != nil {
 		log.Error("Read collection meta failed", zap.String("path", collectionMetaPath), zap.Error(err))
 		return nil, err
 	}
-	partitionBackupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, partitionMetaPath)
+	partitionBackupMetaBytes, err := readMeta(partitionMetaPath)
 	if err != nil {
 		log.Error("Read partition meta failed", zap.String("path", partitionMetaPath), zap.Error(err))
 		return nil, err
 	}
-	segmentBackupMetaBytes, err := b.getStorageClient().Read(ctx, bucketName, segmentMetaPath)
+	segmentBackupMetaBytes, err := readMeta(segmentMetaPath)
 	if err != nil {
 		log.Error("Read segment meta failed", zap.String("path", segmentMetaPath), zap.Error(err))
 		return nil, err
@@ -471,6 +955,9 @@ func (b *BackupContext) readBackup(ctx context.Context, bucketName string, backu
 		log.Error("Fail to deserialize backup info", zap.String("backupPath", backupPath), zap.Error(err))
 		return nil, err
 	}
+	if backupInfo.GetEncrypted() && len(plainMetaPaths) > 0 {
+		return nil, fmt.Errorf("meta files %s of encrypted backup %s are not encrypted", strings.Join(plainMetaPaths, ", "), backupPath)
+	}
 
 	return backupInfo, nil
 }
@@ -507,17 +994,37 @@ func (b *BackupContext) GetRestore(ctx context.Context, request *backuppb.GetRes
 		resp.Msg = "success"
 		resp.Data = UpdateRestoreBackupTask(value)
 		return resp
-	} else {
+	}
+
+	// the restore may be run by another server sharing the backup storage
+	task, err := b.restoreTaskOfJob(ctx, request.GetId())
+	if err != nil {
+		log.Error("fail to read restore task of job", zap.String("id", request.GetId()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if task == nil {
 		resp.Code = backuppb.ResponseCode_Fail
 		resp.Msg = "restore id not exist in context"
 		return resp
 	}
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	resp.Data = UpdateRestoreBackupTask(task)
+	return resp
 }
 
 func (b *BackupContext) Check(ctx context.Context) string {
+	return b.CheckConnections(ctx).GetMsg()
+}
+
+// CheckConnections checks the connection to milvus and runs the storage checks, the message reports every check
+// with the hints of the ones not passed
+func (b *BackupContext) CheckConnections(ctx context.Context) *backuppb.CheckResponse {
 	version, err := b.getMilvusClient().GetVersion(ctx)
 	if err != nil {
-		return "Failed to connect to milvus " + err.Error()
+		return &backuppb.CheckResponse{Code: backuppb.ResponseCode_Fail, Msg: "Failed to connect to milvus " + err.Error()}
 	}
 
 	info := fmt.Sprintf(
@@ -529,37 +1036,10 @@ func (b *BackupContext) Check(ctx context.Context) string {
 			"backup-rootpath: %s\n",
 		version, b.milvusBucketName, b.milvusRootPath, b.backupBucketName, b.backupRootPath)
 
-	paths, _, err := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR, false)
-	if err != nil {
-		return "Failed to connect to storage milvus path\n" + info + err.Error()
-	}
-
-	if len(paths) == 0 {
-		return "Milvus storage is empty. Please verify whether your cluster is really empty. If not, the configs(minio address, port, bucket, rootPath) may be wrong\n" + info
-	}
-
-	paths, _, err = b.getStorageClient().ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
-	if err != nil {
-		return "Failed to connect to storage backup path " + info + err.Error()
-	}
-
-	CHECK_PATH := "milvus_backup_check_" + time.Now().String()
-
-	err = b.getStorageClient().Write(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH, []byte{1})
-	if err != nil {
-		return "Failed to connect to storage milvus path\n" + info + err.Error()
-	}
-	defer func() {
-		b.getStorageClient().Remove(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH)
-	}()
-
-	err = b.getStorageClient().Copy(ctx, b.milvusBucketName, b.backupBucketName, b.milvusRootPath+SEPERATOR+CHECK_PATH, b.backupRootPath+SEPERATOR+CHECK_PATH)
-	if err != nil {
-		return "Failed to copy file from milvus storage to backup storage\n" + info + err.Error()
+	checks := b.CheckStorage(ctx)
+	report := info + formatStorageChecks(checks)
+	if !storageChecksPassed(checks) {
+		return &backuppb.CheckResponse{Code: backuppb.ResponseCode_Fail, Msg: "Failed to pass the storage checks\n" + report, StorageChecks: checks}
 	}
-	defer func() {
-		b.getStorageClient().Remove(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+CHECK_PATH)
-	}()
-
-	return "Succeed to connect to milvus and storage.\n" + info
+	return &backuppb.CheckResponse{Code: backuppb.ResponseCode_Success, Msg: "Succeed to connect to milvus and storage.\n" + report, StorageChecks: checks}
 }
@@ -302,3 +302,56 @@ func TestCreateAndRestoreBackup(t *testing.T) {
 		BackupName: randBackupName,
 	})
 }
+
+func TestMatchListBackupsRequest(t *testing.T) {
+	backup := &backuppb.BackupInfo{
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{DbName: "", CollectionName: "coll1"},
+			{DbName: "tenant1", CollectionName: "coll2"},
+		},
+	}
+	assert.True(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{}, backup))
+	assert.True(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{Databases: []string{"default"}}, backup))
+	assert.True(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{Databases: []string{"tenant1", "tenant2"}}, backup))
+	assert.False(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{Databases: []string{"tenant2"}}, backup))
+	assert.True(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{CollectionName: "coll2", Databases: []string{"tenant1"}}, backup))
+	assert.False(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{CollectionName: "coll1", Databases: []string{"tenant1"}}, backup))
+}
+
+func TestMatchListBackupsRequestByTimeAndState(t *testing.T) {
+	backup := &backuppb.BackupInfo{
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		StartTime: 1700000000500,
+	}
+	assert.True(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{StartTime: 1700000000, EndTime: 1700000001}, backup))
+	assert.False(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{StartTime: 1700000001}, backup))
+	assert.False(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{EndTime: 1700000000}, backup))
+	assert.True(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{States: []backuppb.BackupTaskStateCode{backuppb.BackupTaskStateCode_BACKUP_FAIL, backuppb.BackupTaskStateCode_BACKUP_SUCCESS}}, backup))
+	assert.False(t, matchListBackupsRequest(&backuppb.ListBackupsRequest{States: []backuppb.BackupTaskStateCode{backuppb.BackupTaskStateCode_BACKUP_FAIL}}, backup))
+}
+
+func TestSortAndPageBackups(t *testing.T) {
+	backups := []*backuppb.BackupInfo{
+		{Name: "b", StartTime: 1, Size: 30},
+		{Name: "c", StartTime: 3, Size: 10},
+		{Name: "a", StartTime: 2, Size: 30},
+	}
+	names := func(backups []*backuppb.BackupInfo) []string {
+		res := make([]string, 0, len(backups))
+		for _, backup := range backups {
+			res = append(res, backup.GetName())
+		}
+		return res
+	}
+
+	sortBackups(backups, "", false)
+	assert.Equal(t, []string{"a", "b", "c"}, names(backups))
+	sortBackups(backups, "start_time", true)
+	assert.Equal(t, []string{"c", "a", "b"}, names(backups))
+	sortBackups(backups, "size", false)
+	assert.Equal(t, []string{"c", "a", "b"}, names(backups))
+
+	assert.Equal(t, []string{"a", "b"}, names(pageBackups(backups, 1, 0)))
+	assert.Equal(t, []string{"c", "a"}, names(pageBackups(backups, 0, 2)))
+	assert.Empty(t, pageBackups(backups, 3, 1))
+}
@@ -0,0 +1,239 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// ContinuousBackup keeps a backup close to milvus by a chain of incremental backups: a full backup starts the chain,
+// then every interval an incremental backup based on the last backup of the chain copies the segments and delta logs
+// added since it. the recovery point, up to which the data is backed up, is the backup time of the last backup.
+type ContinuousBackup struct {
+	backupContext *BackupContext
+	config        paramtable.ContinuousConfig
+
+	stop chan struct{}
+	done chan struct{}
+
+	mu     sync.RWMutex
+	chain  []*backuppb.BackupInfo
+	status *backuppb.ContinuousBackupStatus
+}
+
+// NewContinuousBackup creates a continuous backup by the continuous section of config, return error if the name is invalid
+func NewContinuousBackup(backupContext *BackupContext, cfg paramtable.ContinuousConfig) (*ContinuousBackup, error) {
+	if err := utils.ValidateBackupName(cfg.Name, BACKUP_NAME); err != nil {
+		return nil, fmt.Errorf("invalid continuous backup name: %w", err)
+	}
+	return &ContinuousBackup{
+		backupContext: backupContext,
+		config:        cfg,
+		stop:          make(chan struct{}),
+		done:          make(chan struct{}),
+		status: &backuppb.ContinuousBackupStatus{
+			Name:            cfg.Name,
+			Interval:        int64(cfg.Interval / time.Second),
+			FullInterval:    int64(cfg.FullInterval / time.Second),
+			CollectionNames: cfg.CollectionNames,
+		},
+	}, nil
+}
+
+// Start continues the latest chain of the backups in backup storage if any, and creates backups every interval in background
+func (c *ContinuousBackup) Start() {
+	log.Info("start continuous backup",
+		zap.String("name", c.config.Name),
+		zap.Duration("interval", c.config.Interval),
+		zap.Duration("fullInterval", c.config.FullInterval))
+	go c.loop()
+}
+
+// Stop stops creating backups and waits for the running backup to finish
+func (c *ContinuousBackup) Stop() {
+	close(c.stop)
+	<-c.done
+	log.Info("continuous backup stopped")
+}
+
+// Status returns the status of continuous backup, including the current recovery point
+func (c *ContinuousBackup) Status() *backuppb.ContinuousBackupStatus {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	status := *c.status
+	if status.GetRecoveryTime() > 0 {
+		status.RecoveryLag = time.Now().Unix() - status.GetRecoveryTime()
+	}
+	return &status
+}
+
+func (c *ContinuousBackup) loop() {
+	defer close(c.done)
+	c.recoverChain()
+	ticker := time.NewTicker(c.config.Interval)
+	defer ticker.Stop()
+	for {
+		c.run()
+		c.updateStatus(func(status *backuppb.ContinuousBackupStatus) {
+			status.NextRunTime = time.Now().Add(c.config.Interval).Unix()
+		})
+		select {
+		case <-c.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// recoverChain loads the latest chain created before restart, so that it is continued instead of starting a new one
+func (c *ContinuousBackup) recoverChain() {
+	resp := c.backupContext.ListBackups(c.backupContext.ctx, &backuppb.ListBackupsRequest{
+		States: []backuppb.BackupTaskStateCode{backuppb.BackupTaskStateCode_BACKUP_SUCCESS},
+	})
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		log.Warn("fail to list backups to continue, start a new chain", zap.String("msg", resp.GetMsg()))
+		return
+	}
+	c.setChain(latestBackupChain(resp.GetData(), c.config.Name+"_"))
+}
+
+func (c *ContinuousBackup) run() {
+	c.mu.RLock()
+	chain := c.chain
+	c.mu.RUnlock()
+
+	request := &backuppb.CreateBackupRequest{
+		BackupName:      c.config.Name + "_" + time.Now().UTC().Format("2006_01_02_15_04_05"),
+		CollectionNames: c.config.CollectionNames,
+	}
+	if !needFullBackup(chain, c.config.FullInterval, time.Now()) {
+		request.Incremental = true
+		request.BaseBackupName = chain[len(chain)-1].GetName()
+	}
+	log.Info("continuous backup start",
+		zap.String("backupName", request.GetBackupName()),
+		zap.String("baseBackupName", request.GetBaseBackupName()))
+	c.updateStatus(func(status *backuppb.ContinuousBackupStatus) {
+		status.Running = true
+		status.LastStartTime = time.Now().Unix()
+		status.LastBackupName = request.GetBackupName()
+	})
+
+	code, msg := c.backupAndPrune(request, chain)
+
+	log.Info("continuous backup finish",
+		zap.String("backupName", request.GetBackupName()),
+		zap.Int32("code", int32(code)),
+		zap.String("msg", msg))
+	c.updateStatus(func(status *backuppb.ContinuousBackupStatus) {
+		status.Running = false
+		status.LastEndTime = time.Now().Unix()
+		status.LastCode = code
+		status.LastMsg = msg
+	})
+}
+
+func (c *ContinuousBackup) backupAndPrune(request *backuppb.CreateBackupRequest, chain []*backuppb.BackupInfo) (backuppb.ResponseCode, string) {
+	ctx := c.backupContext.ctx
+	createResp := c.backupContext.CreateBackup(ctx, request)
+	if createResp.GetCode() != backuppb.ResponseCode_Success {
+		return createResp.GetCode(), createResp.GetMsg()
+	}
+	// a failed incremental backup is retried on the same base at the next run, so only a success extends the chain
+	if request.GetIncremental() {
+		chain = append(append([]*backuppb.BackupInfo{}, chain...), createResp.GetData())
+	} else {
+		chain = []*backuppb.BackupInfo{createResp.GetData()}
+	}
+	c.setChain(chain)
+
+	if !c.config.Prune || !c.backupContext.params.RetentionCfg.Enabled() {
+		return createResp.GetCode(), createResp.GetMsg()
+	}
+	pruneResp := c.backupContext.PruneBackups(ctx, &backuppb.PruneBackupsRequest{})
+	if pruneResp.GetCode() != backuppb.ResponseCode_Success {
+		return pruneResp.GetCode(), "backup success but fail to prune: " + pruneResp.GetMsg()
+	}
+	return pruneResp.GetCode(), pruneResp.GetMsg()
+}
+
+func (c *ContinuousBackup) setChain(chain []*backuppb.BackupInfo) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.chain = chain
+	if len(chain) == 0 {
+		return
+	}
+	last := chain[len(chain)-1]
+	c.status.ChainBaseName = chain[0].GetName()
+	c.status.ChainLength = int32(len(chain))
+	c.status.RecoveryBackupName = last.GetName()
+	c.status.RecoveryTime = backupRecoveryTime(last)
+}
+
+func (c *ContinuousBackup) updateStatus(update func(status *backuppb.ContinuousBackupStatus)) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	update(c.status)
+}
+
+// latestBackupChain returns the chain of the latest backup with the name prefix, from the full backup it is based on
+// to itself. a chain whose full backup is missing is returned from the earliest backup found.
+func latestBackupChain(backups []*backuppb.BackupInfo, prefix string) []*backuppb.BackupInfo {
+	byName := make(map[string]*backuppb.BackupInfo)
+	var latest *backuppb.BackupInfo
+	for _, backup := range backups {
+		if !strings.HasPrefix(backup.GetName(), prefix) || backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS {
+			continue
+		}
+		byName[backup.GetName()] = backup
+		// backup names end with the UTC time they are created
+		if latest == nil || backup.GetName() > latest.GetName() {
+			latest = backup
+		}
+	}
+	chain := make([]*backuppb.BackupInfo, 0)
+	for backup := latest; backup != nil && len(chain) <= len(byName); backup = byName[backup.GetBaseBackupName()] {
+		chain = append(chain, backup)
+	}
+	sort.SliceStable(chain, func(i, j int) bool {
+		return chain[i].GetName() < chain[j].GetName()
+	})
+	return chain
+}
+
+// needFullBackup returns whether a new chain should be started by a full backup, when there is no chain, the full
+// backup of the chain is missing or it is older than fullInterval
+func needFullBackup(chain []*backuppb.BackupInfo, fullInterval time.Duration, now time.Time) bool {
+	if len(chain) == 0 || chain[0].GetBaseBackupName() != "" {
+		return true
+	}
+	// start time of a backup is in milliseconds
+	return fullInterval > 0 && now.Sub(time.UnixMilli(chain[0].GetStartTime())) >= fullInterval
+}
+
+// backupRecoveryTime returns the unix time in seconds up to which the data of all collections is in the backup,
+// the time collections are flushed at
+func backupRecoveryTime(backup *backuppb.BackupInfo) int64 {
+	var recoveryTime int64
+	for _, collection := range backup.GetCollectionBackups() {
+		physical := int64(collection.GetBackupPhysicalTimestamp())
+		if physical > 0 && (recoveryTime == 0 || physical < recoveryTime) {
+			recoveryTime = physical
+		}
+	}
+	if recoveryTime == 0 {
+		// backup timestamp of a backup is in milliseconds
+		recoveryTime = int64(backup.GetBackupTimestamp() / 1000)
+	}
+	return recoveryTime
+}
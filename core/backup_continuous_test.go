@@ -0,0 +1,88 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestLatestBackupChain(t *testing.T) {
+	success := backuppb.BackupTaskStateCode_BACKUP_SUCCESS
+	backups := []*backuppb.BackupInfo{
+		{Name: "cb_2024_01_01_00_10_00", BaseBackupName: "cb_2024_01_01_00_05_00", StateCode: success},
+		{Name: "cb_2024_01_01_00_00_00", StateCode: success},
+		{Name: "cb_2024_01_01_00_05_00", BaseBackupName: "cb_2024_01_01_00_00_00", StateCode: success},
+		{Name: "cb_2024_01_01_00_15_00", BaseBackupName: "cb_2024_01_01_00_10_00", StateCode: backuppb.BackupTaskStateCode_BACKUP_FAIL},
+		{Name: "daily_2024_01_02_00_00_00", StateCode: success},
+	}
+	names := func(chain []*backuppb.BackupInfo) []string {
+		result := make([]string, 0)
+		for _, backup := range chain {
+			result = append(result, backup.GetName())
+		}
+		return result
+	}
+	chain := latestBackupChain(backups, "cb_")
+	assert.Equal(t, []string{"cb_2024_01_01_00_00_00", "cb_2024_01_01_00_05_00", "cb_2024_01_01_00_10_00"}, names(chain))
+	assert.Empty(t, latestBackupChain(backups, "other_"))
+
+	// a new full backup starts a new chain
+	backups = append(backups, &backuppb.BackupInfo{Name: "cb_2024_01_01_00_20_00", StateCode: success})
+	assert.Equal(t, []string{"cb_2024_01_01_00_20_00"}, names(latestBackupChain(backups, "cb_")))
+}
+
+func TestNeedFullBackup(t *testing.T) {
+	now := time.Now()
+	full := &backuppb.BackupInfo{Name: "full", StartTime: now.Add(-time.Hour).UnixMilli()}
+	incremental := &backuppb.BackupInfo{Name: "incr", BaseBackupName: "full"}
+
+	assert.True(t, needFullBackup(nil, time.Hour, now))
+	assert.False(t, needFullBackup([]*backuppb.BackupInfo{full, incremental}, 2*time.Hour, now))
+	assert.True(t, needFullBackup([]*backuppb.BackupInfo{full, incremental}, time.Hour, now))
+	assert.False(t, needFullBackup([]*backuppb.BackupInfo{full}, 0, now))
+	// the full backup of the chain is missing
+	assert.True(t, needFullBackup([]*backuppb.BackupInfo{incremental}, 0, now))
+}
+
+func TestBackupRecoveryTime(t *testing.T) {
+	backup := &backuppb.BackupInfo{
+		BackupTimestamp: 1700000100000,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{BackupPhysicalTimestamp: 1700000050},
+			{BackupPhysicalTimestamp: 1700000020},
+			{},
+		},
+	}
+	assert.Equal(t, int64(1700000020), backupRecoveryTime(backup))
+	backup.CollectionBackups = nil
+	assert.Equal(t, int64(1700000100), backupRecoveryTime(backup))
+}
+
+func TestContinuousBackupStatus(t *testing.T) {
+	_, err := NewContinuousBackup(nil, paramtable.ContinuousConfig{Name: "1cb", Interval: time.Minute})
+	assert.Error(t, err)
+
+	continuous, err := NewContinuousBackup(nil, paramtable.ContinuousConfig{Name: "cb", Interval: time.Minute, FullInterval: time.Hour})
+	assert.NoError(t, err)
+	status := continuous.Status()
+	assert.Equal(t, int64(60), status.GetInterval())
+	assert.Equal(t, int64(3600), status.GetFullInterval())
+	assert.Zero(t, status.GetRecoveryTime())
+	assert.Zero(t, status.GetRecoveryLag())
+
+	recoveryTime := time.Now().Add(-time.Minute).Unix()
+	continuous.setChain([]*backuppb.BackupInfo{
+		{Name: "cb_1"},
+		{Name: "cb_2", BaseBackupName: "cb_1", CollectionBackups: []*backuppb.CollectionBackupInfo{{BackupPhysicalTimestamp: uint64(recoveryTime)}}},
+	})
+	status = continuous.Status()
+	assert.Equal(t, "cb_1", status.GetChainBaseName())
+	assert.Equal(t, int32(2), status.GetChainLength())
+	assert.Equal(t, "cb_2", status.GetRecoveryBackupName())
+	assert.Equal(t, recoveryTime, status.GetRecoveryTime())
+	assert.GreaterOrEqual(t, status.GetRecoveryLag(), int64(60))
+}
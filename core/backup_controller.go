@@ -0,0 +1,552 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/kube"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const (
+	controllerAPIVersion = "backup.milvus.io/v1alpha1"
+	controllerComponent  = "milvus-backup-controller"
+	milvusBackupKind     = "MilvusBackup"
+	milvusScheduleKind   = "MilvusBackupSchedule"
+	// label of the MilvusBackup objects created by a schedule, the name of the schedule
+	scheduleLabel = "backup.milvus.io/schedule"
+	// maximum runs of a schedule looked through for the latest missed one
+	maxMissedRuns = 1000
+
+	BackupPhasePending   = "Pending"
+	BackupPhaseRunning   = "Running"
+	BackupPhaseSucceeded = "Succeeded"
+	BackupPhaseFailed    = "Failed"
+
+	// True if the backup succeeded, False if it failed, Unknown while it runs
+	conditionComplete = "Complete"
+	// True if the schedule creates backups, False if it is suspended or invalid
+	conditionScheduled = "Scheduled"
+)
+
+// milvusBackup is a MilvusBackup object, a backup to create once
+type milvusBackup struct {
+	APIVersion string             `json:"apiVersion"`
+	Kind       string             `json:"kind"`
+	Metadata   kube.ObjectMeta    `json:"metadata"`
+	Spec       milvusBackupSpec   `json:"spec"`
+	Status     milvusBackupStatus `json:"status,omitempty"`
+}
+
+type milvusBackupSpec struct {
+	// name of the backup, the name of the object if empty
+	BackupName      string            `json:"backupName,omitempty"`
+	CollectionNames []string          `json:"collectionNames,omitempty"`
+	CollectionRegex string            `json:"collectionRegex,omitempty"`
+	MetaOnly        bool              `json:"metaOnly,omitempty"`
+	Incremental     bool              `json:"incremental,omitempty"`
+	BaseBackupName  string            `json:"baseBackupName,omitempty"`
+	Rbac            bool              `json:"rbac,omitempty"`
+	AllowPartial    bool              `json:"allowPartial,omitempty"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	// prune the backups expired by the retention policy after the backup succeeds
+	Prune bool `json:"prune,omitempty"`
+}
+
+type milvusBackupStatus struct {
+	Phase              string           `json:"phase,omitempty"`
+	BackupName         string           `json:"backupName,omitempty"`
+	JobId              string           `json:"jobId,omitempty"`
+	Progress           int32            `json:"progress,omitempty"`
+	Size               int64            `json:"size,omitempty"`
+	StartTime          *time.Time       `json:"startTime,omitempty"`
+	CompletionTime     *time.Time       `json:"completionTime,omitempty"`
+	Message            string           `json:"message,omitempty"`
+	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
+	Conditions         []kube.Condition `json:"conditions,omitempty"`
+}
+
+type milvusBackupList struct {
+	Items []*milvusBackup `json:"items"`
+}
+
+// milvusBackupSchedule is a MilvusBackupSchedule object, which creates MilvusBackup objects by the cron expression
+type milvusBackupSchedule struct {
+	APIVersion string                     `json:"apiVersion"`
+	Kind       string                     `json:"kind"`
+	Metadata   kube.ObjectMeta            `json:"metadata"`
+	Spec       milvusBackupScheduleSpec   `json:"spec"`
+	Status     milvusBackupScheduleStatus `json:"status,omitempty"`
+}
+
+type milvusBackupScheduleSpec struct {
+	// cron expression, like the schedule jobs in config
+	Schedule string `json:"schedule"`
+	Suspend  bool   `json:"suspend,omitempty"`
+	// spec of the backups created, the backups are named <schedule name>-<UTC time> if backupName is empty
+	Template milvusBackupSpec `json:"template"`
+}
+
+type milvusBackupScheduleStatus struct {
+	LastScheduleTime   *time.Time       `json:"lastScheduleTime,omitempty"`
+	LastBackup         string           `json:"lastBackup,omitempty"`
+	ObservedGeneration int64            `json:"observedGeneration,omitempty"`
+	Conditions         []kube.Condition `json:"conditions,omitempty"`
+}
+
+type milvusBackupScheduleList struct {
+	Items []*milvusBackupSchedule `json:"items"`
+}
+
+// kubeAPI is the part of the kubernetes client used by the controller
+type kubeAPI interface {
+	Get(ctx context.Context, path string, out interface{}) error
+	Create(ctx context.Context, path string, in interface{}, out interface{}) error
+	Update(ctx context.Context, path string, in interface{}, out interface{}) error
+	RecordEvent(ctx context.Context, object kube.ObjectReference, eventType string, reason string, message string, component string) error
+}
+
+// BackupController runs the backups of the MilvusBackup objects in a namespace, and creates the MilvusBackup objects
+// of the MilvusBackupSchedule objects, so that backups are managed as kubernetes objects. The objects are listed and
+// reconciled every resync interval by the replica holding the lease if leader election is enabled.
+type BackupController struct {
+	backup    Backup
+	kube      kubeAPI
+	client    *kube.Client
+	cfg       paramtable.ControllerConfig
+	namespace string
+	// ctx of the backups started, they go on if the replica stops leading, the next leader waits for them by their locks
+	ctx context.Context
+	now func() time.Time
+}
+
+// NewBackupController creates a controller of the objects in the namespace of config, or of the pod if not configured
+func NewBackupController(backup Backup, client *kube.Client, cfg paramtable.ControllerConfig) *BackupController {
+	namespace := cfg.Namespace
+	if namespace == "" {
+		namespace = client.Namespace()
+	}
+	return &BackupController{
+		backup:    backup,
+		kube:      client,
+		client:    client,
+		cfg:       cfg,
+		namespace: namespace,
+		ctx:       context.Background(),
+		now:       time.Now,
+	}
+}
+
+// Run reconciles the objects until ctx is done
+func (c *BackupController) Run(ctx context.Context) {
+	c.ctx = ctx
+	log.Info("start backup controller",
+		zap.String("namespace", c.namespace),
+		zap.Bool("leaderElection", c.cfg.LeaderElection))
+	if !c.cfg.LeaderElection {
+		c.lead(ctx)
+		return
+	}
+	host, _ := os.Hostname()
+	elector := &kube.LeaderElector{
+		Client:        c.client,
+		Namespace:     c.namespace,
+		Name:          c.cfg.LeaseName,
+		Identity:      host + "_" + utils.UUID(),
+		LeaseDuration: c.cfg.LeaseDuration,
+		RenewDeadline: c.cfg.RenewDeadline,
+		RetryPeriod:   c.cfg.RetryPeriod,
+	}
+	elector.Run(ctx, c.lead)
+}
+
+func (c *BackupController) lead(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.ResyncInterval)
+	defer ticker.Stop()
+	for {
+		c.resync(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *BackupController) resourcePath(resource string) string {
+	return "/apis/" + controllerAPIVersion + "/namespaces/" + c.namespace + "/" + resource
+}
+
+// resync reconciles all objects once
+func (c *BackupController) resync(ctx context.Context) {
+	backups := &milvusBackupList{}
+	if err := c.kube.Get(ctx, c.resourcePath("milvusbackups"), backups); err != nil {
+		log.Warn("fail to list MilvusBackup", zap.String("namespace", c.namespace), zap.Error(err))
+		return
+	}
+	for _, backup := range backups.Items {
+		c.reconcileBackup(ctx, backup)
+	}
+
+	schedules := &milvusBackupScheduleList{}
+	if err := c.kube.Get(ctx, c.resourcePath("milvusbackupschedules"), schedules); err != nil {
+		log.Warn("fail to list MilvusBackupSchedule", zap.String("namespace", c.namespace), zap.Error(err))
+		return
+	}
+	for _, schedule := range schedules.Items {
+		c.reconcileSchedule(ctx, schedule, backups.Items)
+	}
+}
+
+// reconcileBackup starts the backup of a new object, and follows the backup until it ends
+func (c *BackupController) reconcileBackup(ctx context.Context, backup *milvusBackup) {
+	switch backup.Status.Phase {
+	case "":
+		now := c.now().UTC().Truncate(time.Second)
+		backup.Status.Phase = BackupPhasePending
+		backup.Status.BackupName = backup.Spec.BackupName
+		if backup.Status.BackupName == "" {
+			backup.Status.BackupName = backup.Metadata.Name
+		}
+		backup.Status.StartTime = &now
+		backup.Status.ObservedGeneration = backup.Metadata.Generation
+		kube.SetCondition(&backup.Status.Conditions, kube.Condition{
+			Type:   conditionComplete,
+			Status: kube.ConditionUnknown,
+			Reason: BackupPhasePending,
+		}, now)
+		// the backup is only created once its name is recorded, so that it is found by the next leader
+		if !c.updateBackupStatus(ctx, backup) {
+			return
+		}
+		c.startBackup(ctx, backup)
+	case BackupPhasePending:
+		c.startBackup(ctx, backup)
+	case BackupPhaseRunning:
+		c.trackBackup(ctx, backup)
+	}
+}
+
+// startBackup creates the backup of a pending object, the leader recording it may be gone after creating it
+func (c *BackupController) startBackup(ctx context.Context, backup *milvusBackup) {
+	getResp := c.backup.GetBackup(ctx, &backuppb.GetBackupRequest{BackupName: backup.Status.BackupName, WithoutDetail: true})
+	if getResp.GetCode() == backuppb.ResponseCode_Success && getResp.GetData() != nil {
+		backup.Status.Phase = BackupPhaseRunning
+		c.trackBackup(ctx, backup)
+		return
+	}
+	c.createBackup(ctx, backup)
+}
+
+func (c *BackupController) createBackup(ctx context.Context, backup *milvusBackup) {
+	spec := backup.Spec
+	resp := c.backup.CreateBackup(c.ctx, &backuppb.CreateBackupRequest{
+		BackupName:      backup.Status.BackupName,
+		CollectionNames: spec.CollectionNames,
+		CollectionRegex: spec.CollectionRegex,
+		MetaOnly:        spec.MetaOnly,
+		Incremental:     spec.Incremental,
+		BaseBackupName:  spec.BaseBackupName,
+		Rbac:            spec.Rbac,
+		AllowPartial:    spec.AllowPartial,
+		Labels:          spec.Labels,
+		Async:           true,
+	})
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		c.completeBackup(ctx, backup, false, "CreateFailed", resp.GetMsg())
+		return
+	}
+	backup.Status.Phase = BackupPhaseRunning
+	backup.Status.JobId = resp.GetJobId()
+	kube.SetCondition(&backup.Status.Conditions, kube.Condition{
+		Type:   conditionComplete,
+		Status: kube.ConditionUnknown,
+		Reason: BackupPhaseRunning,
+	}, c.now())
+	if c.updateBackupStatus(ctx, backup) {
+		c.recordEvent(ctx, c.backupReference(backup), kube.EventTypeNormal, "BackupStarted",
+			fmt.Sprintf("backup %s is started, job %s", backup.Status.BackupName, resp.GetJobId()))
+	}
+}
+
+// trackBackup follows the job of a running backup. A backup whose job is interrupted or not of this process, like
+// the one of a leader gone, is resumed from its checkpoint, or completed by its state in backup storage.
+func (c *BackupController) trackBackup(ctx context.Context, backup *milvusBackup) {
+	if backup.Status.JobId != "" {
+		jobResp := c.backup.GetJob(ctx, &backuppb.GetJobRequest{JobId: backup.Status.JobId})
+		if jobResp.GetCode() == backuppb.ResponseCode_Success {
+			job := jobResp.GetData()
+			switch job.GetStateCode() {
+			case backuppb.JobStateCode_JOB_RUNNING, backuppb.JobStateCode_JOB_CANCELING:
+				if backup.Status.Progress != job.GetProgress() || backup.Status.Size != job.GetSize() {
+					backup.Status.Progress = job.GetProgress()
+					backup.Status.Size = job.GetSize()
+					c.updateBackupStatus(ctx, backup)
+				}
+				return
+			case backuppb.JobStateCode_JOB_SUCCESS:
+				backup.Status.Size = job.GetSize()
+				c.completeBackup(ctx, backup, true, BackupPhaseSucceeded, "")
+				return
+			case backuppb.JobStateCode_JOB_FAIL, backuppb.JobStateCode_JOB_CANCELED:
+				c.completeBackup(ctx, backup, false, BackupPhaseFailed, job.GetErrorMessage())
+				return
+			}
+		}
+	}
+
+	getResp := c.backup.GetBackup(ctx, &backuppb.GetBackupRequest{BackupName: backup.Status.BackupName, WithoutDetail: true})
+	switch {
+	case getResp.GetCode() == backuppb.ResponseCode_Request_Object_Not_Found:
+		// failed before anything is written
+		c.createBackup(ctx, backup)
+		return
+	case getResp.GetCode() != backuppb.ResponseCode_Success:
+		log.Warn("fail to get backup of MilvusBackup",
+			zap.String("name", backup.Metadata.Name),
+			zap.String("backupName", backup.Status.BackupName),
+			zap.String("msg", getResp.GetMsg()))
+		return
+	}
+	switch info := getResp.GetData(); {
+	case isFinishedBackup(info):
+		backup.Status.Size = info.GetSize()
+		c.completeBackup(ctx, backup, true, BackupPhaseSucceeded, "")
+	case info.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_FAIL:
+		c.completeBackup(ctx, backup, false, BackupPhaseFailed, info.GetErrorMessage())
+	default:
+		// resuming fails while the backup is locked by the process running it, it is retried at the next resync
+		resp := c.backup.ResumeBackup(c.ctx, &backuppb.ResumeBackupRequest{BackupName: backup.Status.BackupName, Async: true})
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			log.Info("fail to resume backup of MilvusBackup, retry later",
+				zap.String("name", backup.Metadata.Name),
+				zap.String("backupName", backup.Status.BackupName),
+				zap.String("msg", resp.GetMsg()))
+			return
+		}
+		backup.Status.JobId = resp.GetJobId()
+		if c.updateBackupStatus(ctx, backup) {
+			c.recordEvent(ctx, c.backupReference(backup), kube.EventTypeNormal, "BackupResumed",
+				fmt.Sprintf("backup %s is resumed from its checkpoint, job %s", backup.Status.BackupName, resp.GetJobId()))
+		}
+	}
+}
+
+// completeBackup records the end of the backup, and prunes the expired backups if the backup succeeded with prune
+func (c *BackupController) completeBackup(ctx context.Context, backup *milvusBackup, succeeded bool, reason string, message string) {
+	now := c.now().UTC().Truncate(time.Second)
+	backup.Status.CompletionTime = &now
+	backup.Status.Message = message
+	condition := kube.Condition{Type: conditionComplete, Reason: reason, Message: message}
+	eventType, eventReason := kube.EventTypeNormal, "BackupSucceeded"
+	eventMessage := fmt.Sprintf("backup %s succeeded", backup.Status.BackupName)
+	if succeeded {
+		backup.Status.Phase = BackupPhaseSucceeded
+		backup.Status.Progress = 100
+		condition.Status = kube.ConditionTrue
+	} else {
+		backup.Status.Phase = BackupPhaseFailed
+		condition.Status = kube.ConditionFalse
+		eventType, eventReason = kube.EventTypeWarning, "BackupFailed"
+		eventMessage = fmt.Sprintf("backup %s failed: %s", backup.Status.BackupName, message)
+	}
+	kube.SetCondition(&backup.Status.Conditions, condition, now)
+	if !c.updateBackupStatus(ctx, backup) {
+		return
+	}
+	c.recordEvent(ctx, c.backupReference(backup), eventType, eventReason, eventMessage)
+
+	if succeeded && backup.Spec.Prune {
+		pruneResp := c.backup.PruneBackups(ctx, &backuppb.PruneBackupsRequest{})
+		if pruneResp.GetCode() != backuppb.ResponseCode_Success {
+			c.recordEvent(ctx, c.backupReference(backup), kube.EventTypeWarning, "PruneFailed", pruneResp.GetMsg())
+		}
+	}
+}
+
+// updateBackupStatus writes the status of the object, false if it fails, like the object is changed since listed,
+// then it is reconciled again at the next resync
+func (c *BackupController) updateBackupStatus(ctx context.Context, backup *milvusBackup) bool {
+	updated := &milvusBackup{}
+	if err := c.kube.Update(ctx, c.resourcePath("milvusbackups")+"/"+backup.Metadata.Name+"/status", backup, updated); err != nil {
+		log.Warn("fail to update status of MilvusBackup", zap.String("name", backup.Metadata.Name), zap.Error(err))
+		return false
+	}
+	if updated.Metadata.ResourceVersion != "" {
+		backup.Metadata.ResourceVersion = updated.Metadata.ResourceVersion
+	}
+	return true
+}
+
+// reconcileSchedule creates a MilvusBackup object of the latest run of the schedule due, the run is skipped if a
+// backup of the schedule is still running
+func (c *BackupController) reconcileSchedule(ctx context.Context, schedule *milvusBackupSchedule, backups []*milvusBackup) {
+	now := c.now()
+	sched, err := cron.ParseStandard(schedule.Spec.Schedule)
+	if err != nil {
+		c.setScheduled(ctx, schedule, kube.ConditionFalse, "InvalidSchedule", err.Error())
+		return
+	}
+	if schedule.Spec.Suspend {
+		c.setScheduled(ctx, schedule, kube.ConditionFalse, "Suspended", "")
+		return
+	}
+	last := now
+	if schedule.Status.LastScheduleTime != nil {
+		last = *schedule.Status.LastScheduleTime
+	} else if schedule.Metadata.CreationTimestamp != nil {
+		last = *schedule.Metadata.CreationTimestamp
+	}
+	scheduled := latestScheduledTime(sched, last, now)
+	if scheduled.IsZero() {
+		c.setScheduled(ctx, schedule, kube.ConditionTrue, "Scheduled", "")
+		return
+	}
+
+	kube.SetCondition(&schedule.Status.Conditions, kube.Condition{
+		Type:   conditionScheduled,
+		Status: kube.ConditionTrue,
+		Reason: "Scheduled",
+	}, now)
+	schedule.Status.LastScheduleTime = &scheduled
+	schedule.Status.ObservedGeneration = schedule.Metadata.Generation
+	if active := activeScheduledBackup(schedule.Metadata.Name, backups); active != "" {
+		if c.updateScheduleStatus(ctx, schedule) {
+			c.recordEvent(ctx, c.scheduleReference(schedule), kube.EventTypeWarning, "BackupSkipped",
+				fmt.Sprintf("skip the backup scheduled at %s, backup %s is still running", scheduled.Format(time.RFC3339), active))
+		}
+		return
+	}
+
+	backup := c.scheduledBackup(schedule, scheduled)
+	if err := c.kube.Create(ctx, c.resourcePath("milvusbackups"), backup, nil); err != nil && !kube.IsConflict(err) {
+		log.Warn("fail to create MilvusBackup of schedule", zap.String("schedule", schedule.Metadata.Name), zap.Error(err))
+		c.recordEvent(ctx, c.scheduleReference(schedule), kube.EventTypeWarning, "CreateFailed", err.Error())
+		return
+	}
+	schedule.Status.LastBackup = backup.Metadata.Name
+	if c.updateScheduleStatus(ctx, schedule) {
+		c.recordEvent(ctx, c.scheduleReference(schedule), kube.EventTypeNormal, "BackupCreated",
+			fmt.Sprintf("create MilvusBackup %s", backup.Metadata.Name))
+	}
+}
+
+// latestScheduledTime returns the latest run of the schedule after last and not after now, zero if none
+func latestScheduledTime(sched cron.Schedule, last time.Time, now time.Time) time.Time {
+	var scheduled time.Time
+	for i := 0; i < maxMissedRuns; i++ {
+		next := sched.Next(last)
+		if next.IsZero() || next.After(now) {
+			break
+		}
+		scheduled, last = next, next
+	}
+	return scheduled.UTC()
+}
+
+// activeScheduledBackup returns the name of a backup of the schedule not ended, empty if none
+func activeScheduledBackup(scheduleName string, backups []*milvusBackup) string {
+	for _, backup := range backups {
+		if backup.Metadata.Labels[scheduleLabel] != scheduleName {
+			continue
+		}
+		switch backup.Status.Phase {
+		case "", BackupPhasePending, BackupPhaseRunning:
+			return backup.Metadata.Name
+		}
+	}
+	return ""
+}
+
+// scheduledBackup returns the MilvusBackup object of the run, which is named by the scheduled time so that the run
+// is only created once, and deleted with the schedule
+func (c *BackupController) scheduledBackup(schedule *milvusBackupSchedule, scheduled time.Time) *milvusBackup {
+	return &milvusBackup{
+		APIVersion: controllerAPIVersion,
+		Kind:       milvusBackupKind,
+		Metadata: kube.ObjectMeta{
+			Name:      schedule.Metadata.Name + "-" + scheduled.UTC().Format("20060102150405"),
+			Namespace: c.namespace,
+			Labels:    map[string]string{scheduleLabel: schedule.Metadata.Name},
+			OwnerReferences: []kube.OwnerReference{{
+				APIVersion: controllerAPIVersion,
+				Kind:       milvusScheduleKind,
+				Name:       schedule.Metadata.Name,
+				UID:        schedule.Metadata.UID,
+				Controller: true,
+			}},
+		},
+		Spec: schedule.Spec.Template,
+	}
+}
+
+// setScheduled updates the Scheduled condition of the schedule if it changes
+func (c *BackupController) setScheduled(ctx context.Context, schedule *milvusBackupSchedule, status string, reason string, message string) {
+	changed := kube.SetCondition(&schedule.Status.Conditions, kube.Condition{
+		Type:    conditionScheduled,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	}, c.now())
+	if !changed && schedule.Status.ObservedGeneration == schedule.Metadata.Generation {
+		return
+	}
+	schedule.Status.ObservedGeneration = schedule.Metadata.Generation
+	if c.updateScheduleStatus(ctx, schedule) && status == kube.ConditionFalse && changed {
+		c.recordEvent(ctx, c.scheduleReference(schedule), kube.EventTypeWarning, reason, message)
+	}
+}
+
+func (c *BackupController) updateScheduleStatus(ctx context.Context, schedule *milvusBackupSchedule) bool {
+	updated := &milvusBackupSchedule{}
+	if err := c.kube.Update(ctx, c.resourcePath("milvusbackupschedules")+"/"+schedule.Metadata.Name+"/status", schedule, updated); err != nil {
+		log.Warn("fail to update status of MilvusBackupSchedule", zap.String("name", schedule.Metadata.Name), zap.Error(err))
+		return false
+	}
+	if updated.Metadata.ResourceVersion != "" {
+		schedule.Metadata.ResourceVersion = updated.Metadata.ResourceVersion
+	}
+	return true
+}
+
+func (c *BackupController) backupReference(backup *milvusBackup) kube.ObjectReference {
+	return kube.ObjectReference{
+		APIVersion:      controllerAPIVersion,
+		Kind:            milvusBackupKind,
+		Namespace:       c.namespace,
+		Name:            backup.Metadata.Name,
+		UID:             backup.Metadata.UID,
+		ResourceVersion: backup.Metadata.ResourceVersion,
+	}
+}
+
+func (c *BackupController) scheduleReference(schedule *milvusBackupSchedule) kube.ObjectReference {
+	return kube.ObjectReference{
+		APIVersion:      controllerAPIVersion,
+		Kind:            milvusScheduleKind,
+		Namespace:       c.namespace,
+		Name:            schedule.Metadata.Name,
+		UID:             schedule.Metadata.UID,
+		ResourceVersion: schedule.Metadata.ResourceVersion,
+	}
+}
+
+// recordEvent creates the event, failure is only logged
+func (c *BackupController) recordEvent(ctx context.Context, object kube.ObjectReference, eventType string, reason string, message string) {
+	log.Info("controller event",
+		zap.String("kind", object.Kind),
+		zap.String("name", object.Name),
+		zap.String("reason", reason),
+		zap.String("message", message))
+	if err := c.kube.RecordEvent(ctx, object, eventType, reason, message, controllerComponent); err != nil {
+		log.Warn("fail to record event", zap.String("name", object.Name), zap.String("reason", reason), zap.Error(err))
+	}
+}
@@ -0,0 +1,299 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/kube"
+)
+
+// fakeKube keeps the objects of the controller in memory
+type fakeKube struct {
+	backups   []*milvusBackup
+	schedules []*milvusBackupSchedule
+	updates   int
+	events    []string
+}
+
+func (k *fakeKube) Get(ctx context.Context, path string, out interface{}) error {
+	var list interface{}
+	switch {
+	case strings.HasSuffix(path, "/milvusbackups"):
+		list = &milvusBackupList{Items: k.backups}
+	case strings.HasSuffix(path, "/milvusbackupschedules"):
+		list = &milvusBackupScheduleList{Items: k.schedules}
+	default:
+		return &kube.StatusError{Code: http.StatusNotFound}
+	}
+	data, err := json.Marshal(list)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, out)
+}
+
+func (k *fakeKube) Create(ctx context.Context, path string, in interface{}, out interface{}) error {
+	backup := in.(*milvusBackup)
+	for _, existing := range k.backups {
+		if existing.Metadata.Name == backup.Metadata.Name {
+			return &kube.StatusError{Code: http.StatusConflict, Reason: "AlreadyExists"}
+		}
+	}
+	k.backups = append(k.backups, backup)
+	return nil
+}
+
+// Update replaces the object of the same name, like the status written by the controller
+func (k *fakeKube) Update(ctx context.Context, path string, in interface{}, out interface{}) error {
+	k.updates++
+	switch object := in.(type) {
+	case *milvusBackup:
+		for i, existing := range k.backups {
+			if existing.Metadata.Name == object.Metadata.Name {
+				k.backups[i] = object
+			}
+		}
+	case *milvusBackupSchedule:
+		for i, existing := range k.schedules {
+			if existing.Metadata.Name == object.Metadata.Name {
+				k.schedules[i] = object
+			}
+		}
+	}
+	return nil
+}
+
+func (k *fakeKube) RecordEvent(ctx context.Context, object kube.ObjectReference, eventType string, reason string, message string, component string) error {
+	k.events = append(k.events, reason)
+	return nil
+}
+
+// fakeBackup responds the requests of the controller, the methods not set are not called
+type fakeBackup struct {
+	Backup
+	created []*backuppb.CreateBackupRequest
+	resumed []string
+	pruned  int
+	backups map[string]*backuppb.BackupInfo
+	jobs    map[string]*backuppb.JobInfo
+	// code of ResumeBackup, success if not set
+	resumeCode backuppb.ResponseCode
+}
+
+func (f *fakeBackup) CreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) *backuppb.BackupInfoResponse {
+	f.created = append(f.created, request)
+	f.jobs["job_"+request.GetBackupName()] = &backuppb.JobInfo{StateCode: backuppb.JobStateCode_JOB_RUNNING}
+	return &backuppb.BackupInfoResponse{Code: backuppb.ResponseCode_Success, JobId: "job_" + request.GetBackupName()}
+}
+
+func (f *fakeBackup) GetBackup(ctx context.Context, request *backuppb.GetBackupRequest) *backuppb.BackupInfoResponse {
+	backup, ok := f.backups[request.GetBackupName()]
+	if !ok {
+		return &backuppb.BackupInfoResponse{Code: backuppb.ResponseCode_Request_Object_Not_Found}
+	}
+	return &backuppb.BackupInfoResponse{Code: backuppb.ResponseCode_Success, Data: backup}
+}
+
+func (f *fakeBackup) GetJob(ctx context.Context, request *backuppb.GetJobRequest) *backuppb.JobResponse {
+	job, ok := f.jobs[request.GetJobId()]
+	if !ok {
+		return &backuppb.JobResponse{Code: backuppb.ResponseCode_Request_Object_Not_Found}
+	}
+	return &backuppb.JobResponse{Code: backuppb.ResponseCode_Success, Data: job}
+}
+
+func (f *fakeBackup) ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) *backuppb.BackupInfoResponse {
+	if f.resumeCode != backuppb.ResponseCode_Success {
+		return &backuppb.BackupInfoResponse{Code: f.resumeCode, Msg: "backup lock is held"}
+	}
+	f.resumed = append(f.resumed, request.GetBackupName())
+	return &backuppb.BackupInfoResponse{Code: backuppb.ResponseCode_Success, JobId: "resumed_" + request.GetBackupName()}
+}
+
+func (f *fakeBackup) PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) *backuppb.PruneBackupsResponse {
+	f.pruned++
+	return &backuppb.PruneBackupsResponse{Code: backuppb.ResponseCode_Success}
+}
+
+func newTestController(now time.Time) (*BackupController, *fakeKube, *fakeBackup) {
+	k := &fakeKube{}
+	b := &fakeBackup{backups: make(map[string]*backuppb.BackupInfo), jobs: make(map[string]*backuppb.JobInfo)}
+	c := &BackupController{
+		backup:    b,
+		kube:      k,
+		cfg:       paramtable.ControllerConfig{ResyncInterval: time.Second},
+		namespace: "ns",
+		ctx:       context.Background(),
+		now:       func() time.Time { return now },
+	}
+	return c, k, b
+}
+
+func TestControllerBackup(t *testing.T) {
+	now := time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC)
+	c, k, b := newTestController(now)
+	ctx := context.Background()
+	k.backups = []*milvusBackup{{
+		Metadata: kube.ObjectMeta{Name: "nightly", Generation: 1},
+		Spec:     milvusBackupSpec{CollectionNames: []string{"coll1"}, Labels: map[string]string{"env": "prod"}, Prune: true},
+	}}
+
+	c.resync(ctx)
+	backup := k.backups[0]
+	assert.Len(t, b.created, 1)
+	// the backup is named by the object
+	assert.Equal(t, "nightly", b.created[0].GetBackupName())
+	assert.Equal(t, []string{"coll1"}, b.created[0].GetCollectionNames())
+	assert.Equal(t, map[string]string{"env": "prod"}, b.created[0].GetLabels())
+	assert.True(t, b.created[0].GetAsync())
+	assert.Equal(t, BackupPhaseRunning, backup.Status.Phase)
+	assert.Equal(t, "job_nightly", backup.Status.JobId)
+	assert.Equal(t, int64(1), backup.Status.ObservedGeneration)
+	assert.Equal(t, []string{"BackupStarted"}, k.events)
+
+	b.jobs["job_nightly"] = &backuppb.JobInfo{StateCode: backuppb.JobStateCode_JOB_RUNNING, Progress: 40, Size: 100}
+	c.reconcileBackup(ctx, backup)
+	assert.Equal(t, int32(40), backup.Status.Progress)
+	updates := k.updates
+	c.reconcileBackup(ctx, backup)
+	assert.Equal(t, updates, k.updates)
+
+	b.jobs["job_nightly"] = &backuppb.JobInfo{StateCode: backuppb.JobStateCode_JOB_SUCCESS, Progress: 100, Size: 100}
+	c.reconcileBackup(ctx, backup)
+	assert.Equal(t, BackupPhaseSucceeded, backup.Status.Phase)
+	assert.Equal(t, int32(100), backup.Status.Progress)
+	assert.Equal(t, now, *backup.Status.CompletionTime)
+	assert.Equal(t, kube.ConditionTrue, backup.Status.Conditions[0].Status)
+	assert.Equal(t, []string{"BackupStarted", "BackupSucceeded"}, k.events)
+	assert.Equal(t, 1, b.pruned)
+
+	// ended backups are not reconciled again
+	c.reconcileBackup(ctx, backup)
+	assert.Len(t, b.created, 1)
+	assert.Equal(t, 1, b.pruned)
+
+	failed := &milvusBackup{
+		Metadata: kube.ObjectMeta{Name: "failed"},
+		Status:   milvusBackupStatus{Phase: BackupPhaseRunning, BackupName: "failed", JobId: "job_failed"},
+	}
+	b.jobs["job_failed"] = &backuppb.JobInfo{StateCode: backuppb.JobStateCode_JOB_FAIL, ErrorMessage: "milvus is down"}
+	c.reconcileBackup(ctx, failed)
+	assert.Equal(t, BackupPhaseFailed, failed.Status.Phase)
+	assert.Equal(t, "milvus is down", failed.Status.Message)
+	assert.Equal(t, kube.ConditionFalse, failed.Status.Conditions[0].Status)
+}
+
+func TestControllerResumeBackup(t *testing.T) {
+	c, k, b := newTestController(time.Now())
+	ctx := context.Background()
+
+	// the job of the leader gone is not found, the backup is resumed from its checkpoint
+	backup := &milvusBackup{
+		Metadata: kube.ObjectMeta{Name: "nightly"},
+		Status:   milvusBackupStatus{Phase: BackupPhaseRunning, BackupName: "nightly", JobId: "gone"},
+	}
+	b.backups["nightly"] = &backuppb.BackupInfo{Name: "nightly", StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING}
+	b.resumeCode = backuppb.ResponseCode_Fail
+	c.reconcileBackup(ctx, backup)
+	assert.Equal(t, "gone", backup.Status.JobId)
+	assert.Empty(t, k.events)
+
+	b.resumeCode = backuppb.ResponseCode_Success
+	c.reconcileBackup(ctx, backup)
+	assert.Equal(t, []string{"nightly"}, b.resumed)
+	assert.Equal(t, "resumed_nightly", backup.Status.JobId)
+	assert.Equal(t, []string{"BackupResumed"}, k.events)
+
+	// interrupted jobs as well, a backup already finished is completed
+	b.jobs["resumed_nightly"] = &backuppb.JobInfo{StateCode: backuppb.JobStateCode_JOB_INTERRUPTED}
+	b.backups["nightly"].StateCode = backuppb.BackupTaskStateCode_BACKUP_SUCCESS
+	c.reconcileBackup(ctx, backup)
+	assert.Equal(t, BackupPhaseSucceeded, backup.Status.Phase)
+
+	// a pending backup created by the leader gone isn't created again
+	pending := &milvusBackup{
+		Metadata: kube.ObjectMeta{Name: "pending"},
+		Status:   milvusBackupStatus{Phase: BackupPhasePending, BackupName: "pending"},
+	}
+	b.backups["pending"] = &backuppb.BackupInfo{Name: "pending", StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING}
+	c.reconcileBackup(ctx, pending)
+	assert.Empty(t, b.created)
+	assert.Equal(t, []string{"nightly", "pending"}, b.resumed)
+	assert.Equal(t, BackupPhaseRunning, pending.Status.Phase)
+}
+
+func TestControllerSchedule(t *testing.T) {
+	created := time.Date(2024, 1, 1, 0, 30, 0, 0, time.UTC)
+	now := time.Date(2024, 1, 1, 3, 10, 0, 0, time.UTC)
+	c, k, b := newTestController(now)
+	ctx := context.Background()
+	schedule := &milvusBackupSchedule{
+		Metadata: kube.ObjectMeta{Name: "hourly", UID: "uid1", CreationTimestamp: &created},
+		Spec: milvusBackupScheduleSpec{
+			Schedule: "0 * * * *",
+			Template: milvusBackupSpec{CollectionNames: []string{"coll1"}},
+		},
+	}
+	k.schedules = []*milvusBackupSchedule{schedule}
+
+	// only the latest missed run is created
+	c.reconcileSchedule(ctx, schedule, k.backups)
+	assert.Len(t, k.backups, 1)
+	child := k.backups[0]
+	assert.Equal(t, "hourly-20240101030000", child.Metadata.Name)
+	assert.Equal(t, "hourly", child.Metadata.Labels[scheduleLabel])
+	assert.Equal(t, "uid1", child.Metadata.OwnerReferences[0].UID)
+	assert.Equal(t, []string{"coll1"}, child.Spec.CollectionNames)
+	assert.Equal(t, time.Date(2024, 1, 1, 3, 0, 0, 0, time.UTC), *schedule.Status.LastScheduleTime)
+	assert.Equal(t, "hourly-20240101030000", schedule.Status.LastBackup)
+	assert.Equal(t, []string{"BackupCreated"}, k.events)
+
+	// nothing is due
+	c.reconcileSchedule(ctx, schedule, k.backups)
+	assert.Len(t, k.backups, 1)
+
+	// the run is skipped while the backup of the last run is running
+	c.now = func() time.Time { return now.Add(time.Hour) }
+	c.reconcileSchedule(ctx, schedule, k.backups)
+	assert.Len(t, k.backups, 1)
+	assert.Equal(t, []string{"BackupCreated", "BackupSkipped"}, k.events)
+	assert.Equal(t, time.Date(2024, 1, 1, 4, 0, 0, 0, time.UTC), *schedule.Status.LastScheduleTime)
+
+	// the backups created are run by the next resync
+	c.now = func() time.Time { return now.Add(2 * time.Hour) }
+	c.resync(ctx)
+	assert.Len(t, b.created, 1)
+	assert.Equal(t, "hourly-20240101030000", b.created[0].GetBackupName())
+	assert.Len(t, k.backups, 1)
+
+	schedule.Spec.Suspend = true
+	k.events = nil
+	c.reconcileSchedule(ctx, schedule, nil)
+	assert.Equal(t, []string{"Suspended"}, k.events)
+	assert.Equal(t, kube.ConditionFalse, schedule.Status.Conditions[0].Status)
+	c.reconcileSchedule(ctx, schedule, nil)
+	assert.Equal(t, []string{"Suspended"}, k.events)
+
+	schedule.Spec.Suspend = false
+	schedule.Spec.Schedule = "every hour"
+	c.reconcileSchedule(ctx, schedule, nil)
+	assert.Equal(t, []string{"Suspended", "InvalidSchedule"}, k.events)
+	assert.Equal(t, "InvalidSchedule", schedule.Status.Conditions[0].Reason)
+}
+
+func TestLatestScheduledTime(t *testing.T) {
+	sched, err := cron.ParseStandard("@every 1h")
+	assert.NoError(t, err)
+	last := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.True(t, latestScheduledTime(sched, last, last.Add(30*time.Minute)).IsZero())
+	assert.Equal(t, last.Add(3*time.Hour), latestScheduledTime(sched, last, last.Add(3*time.Hour+time.Minute)))
+}
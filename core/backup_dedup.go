@@ -0,0 +1,233 @@
+package core
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// dedupIndex counts the references of dedup objects by the names of the backups referencing every object,
+// it is stored in DedupIndexPath of backup root
+type dedupIndex struct {
+	Objects map[string][]string `json:"objects"`
+}
+
+// addRefs adds the references of a backup to objects, adding a reference twice doesn't change the count
+func (idx *dedupIndex) addRefs(backupName string, objects []string) {
+	for _, object := range objects {
+		refs := idx.Objects[object]
+		if !containsString(refs, backupName) {
+			idx.Objects[object] = append(refs, backupName)
+		}
+	}
+}
+
+// removeRefs removes the references of a backup to objects, and returns the objects not referenced anymore
+func (idx *dedupIndex) removeRefs(backupName string, objects []string) []string {
+	unreferenced := make([]string, 0)
+	for _, object := range objects {
+		refs := make([]string, 0, len(idx.Objects[object]))
+		for _, ref := range idx.Objects[object] {
+			if ref != backupName {
+				refs = append(refs, ref)
+			}
+		}
+		if len(refs) > 0 {
+			idx.Objects[object] = refs
+			continue
+		}
+		delete(idx.Objects, object)
+		unreferenced = append(unreferenced, object)
+	}
+	return unreferenced
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupObjectName returns the name of a binlog stored as dedup object, the hex encoded SHA-256 of the content
+func dedupObjectName(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// dedupBinlogs returns true if the binlogs of segment are stored as dedup objects. Encrypted binlogs differ in
+// every write, and the objects encrypted by customer keys can't be read by the backups of other keys.
+func (b *BackupContext) dedupBinlogs(backupInfo *backuppb.BackupInfo, segment *backuppb.SegmentBackupInfo) bool {
+	return b.params.BackupCfg.DedupEnable && !segment.GetEncrypted() && backupInfo.GetSseType() != storage.SSETypeCustomer
+}
+
+// segmentDedupObjects returns the dedup objects storing the binlogs of segment
+func segmentDedupObjects(segment *backuppb.SegmentBackupInfo) []string {
+	objects := make([]string, 0)
+	for _, fieldBinlog := range append(append([]*backuppb.FieldBinlog{}, segment.GetBinlogs()...), segment.GetDeltalogs()...) {
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			if binlog.GetObjectName() != "" {
+				objects = append(objects, binlog.GetObjectName())
+			}
+		}
+	}
+	return objects
+}
+
+// backupDedupObjects returns the sorted dedup objects referenced by backup
+func backupDedupObjects(backup *backuppb.BackupInfo) []string {
+	seen := make(map[string]bool)
+	objects := make([]string, 0)
+	for _, collection := range backup.GetCollectionBackups() {
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				for _, object := range segmentDedupObjects(segment) {
+					if !seen[object] {
+						seen[object] = true
+						objects = append(objects, object)
+					}
+				}
+			}
+		}
+	}
+	sort.Strings(objects)
+	return objects
+}
+
+// holdDedupObjects keeps dedup objects referenced by an executing backup from being deleted
+func (b *BackupContext) holdDedupObjects(backupName string, objects ...string) {
+	b.dedupMu.Lock()
+	defer b.dedupMu.Unlock()
+	if b.dedupHeld == nil {
+		b.dedupHeld = make(map[string]map[string]bool)
+	}
+	if b.dedupHeld[backupName] == nil {
+		b.dedupHeld[backupName] = make(map[string]bool)
+	}
+	for _, object := range objects {
+		b.dedupHeld[backupName][object] = true
+	}
+}
+
+// isDedupObjectHeld returns true if an executing backup references the object, dedupMu must be held
+func (b *BackupContext) isDedupObjectHeld(object string) bool {
+	for _, objects := range b.dedupHeld {
+		if objects[object] {
+			return true
+		}
+	}
+	return false
+}
+
+// readDedupIndex reads the dedup index of backup root, empty if it doesn't exist
+func (b *BackupContext) readDedupIndex(ctx context.Context) (*dedupIndex, error) {
+	idx := &dedupIndex{Objects: make(map[string][]string)}
+	indexPath := DedupIndexPath(b.backupRootPath)
+	exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, indexPath)
+	if err != nil || !exist {
+		return idx, err
+	}
+	data, err := b.readBackupFile(ctx, b.backupBucketName, indexPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, idx); err != nil {
+		return nil, err
+	}
+	if idx.Objects == nil {
+		idx.Objects = make(map[string][]string)
+	}
+	return idx, nil
+}
+
+func (b *BackupContext) writeDedupIndex(ctx context.Context, idx *dedupIndex) error {
+	data, err := json.Marshal(idx)
+	if err != nil {
+		return err
+	}
+	return b.writeBackupFile(ctx, DedupIndexPath(b.backupRootPath), data)
+}
+
+// registerDedupObjects counts the references of an executed backup into the dedup index, and releases the objects held
+// by the backup. The backup is counted also if it fails, its objects are deleted with it. Failure is only logged,
+// the index is rebuilt from the backup meta by gc.
+func (b *BackupContext) registerDedupObjects(ctx context.Context, backupInfo *backuppb.BackupInfo) {
+	b.dedupMu.Lock()
+	defer b.dedupMu.Unlock()
+	defer delete(b.dedupHeld, backupInfo.GetName())
+
+	objects := backupDedupObjects(backupInfo)
+	if len(objects) == 0 {
+		return
+	}
+	// the index is read by every backup, like meta it is not encrypted by the customer key of a backup
+	ctx = storage.WithoutCustomerKey(ctx)
+	idx, err := b.readDedupIndex(ctx)
+	if err != nil {
+		log.Warn("fail to read dedup index, references of backup are counted by gc", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+		return
+	}
+	idx.addRefs(backupInfo.GetName(), objects)
+	if err := b.writeDedupIndex(ctx, idx); err != nil {
+		log.Warn("fail to write dedup index, references of backup are counted by gc", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+		return
+	}
+	log.Info("count dedup objects of backup", zap.String("backupName", backupInfo.GetName()), zap.Int("objects", len(objects)))
+}
+
+// unrefDedupObjects removes the references of a deleted backup from the dedup index, and deletes the objects not
+// referenced by any backup. Failure is only logged, the objects left are deleted by gc.
+func (b *BackupContext) unrefDedupObjects(ctx context.Context, backup *backuppb.BackupInfo) {
+	objects := backupDedupObjects(backup)
+	if len(objects) == 0 {
+		return
+	}
+	ctx = storage.WithoutCustomerKey(ctx)
+	b.dedupMu.Lock()
+	defer b.dedupMu.Unlock()
+
+	idx, err := b.readDedupIndex(ctx)
+	if err != nil {
+		log.Warn("fail to read dedup index, objects of deleted backup are left to gc", zap.String("backupName", backup.GetName()), zap.Error(err))
+		return
+	}
+	unreferenced := idx.removeRefs(backup.GetName(), objects)
+	if err := b.writeDedupIndex(ctx, idx); err != nil {
+		log.Warn("fail to write dedup index, objects of deleted backup are left to gc", zap.String("backupName", backup.GetName()), zap.Error(err))
+		return
+	}
+	removed := 0
+	for _, object := range unreferenced {
+		if b.isDedupObjectHeld(object) {
+			continue
+		}
+		if err := b.getBackupStorageClient().Remove(ctx, b.backupBucketName, DedupObjectPath(b.backupRootPath, object)); err != nil {
+			log.Warn("fail to delete dedup object, it is deleted by gc", zap.String("object", object), zap.Error(err))
+			continue
+		}
+		removed++
+	}
+	log.Info("delete dedup objects of backup",
+		zap.String("backupName", backup.GetName()),
+		zap.Int("objects", len(objects)),
+		zap.Int("removed", removed))
+}
+
+// rebuildDedupIndex counts the references of dedup objects again from the meta of all backups
+func (b *BackupContext) rebuildDedupIndex(ctx context.Context, backups []*backuppb.BackupInfo) error {
+	idx := &dedupIndex{Objects: make(map[string][]string)}
+	for _, backup := range backups {
+		idx.addRefs(backup.GetName(), backupDedupObjects(backup))
+	}
+	return b.writeDedupIndex(storage.WithoutCustomerKey(ctx), idx)
+}
@@ -0,0 +1,146 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func newDedupBackup(name string, state backuppb.BackupTaskStateCode, objects ...string) *backuppb.BackupInfo {
+	binlogs := make([]*backuppb.Binlog, 0, len(objects))
+	for _, object := range objects {
+		binlogs = append(binlogs, &backuppb.Binlog{LogPath: "files/insert_log/1/2/3/100/" + object, ObjectName: object})
+	}
+	return &backuppb.BackupInfo{
+		Name:      name,
+		StateCode: state,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{SegmentBackups: []*backuppb.SegmentBackupInfo{{
+				PartitionId: 2,
+				SegmentId:   3,
+				GroupId:     3,
+				Binlogs:     []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: binlogs}},
+			}}}},
+		}},
+	}
+}
+
+func TestDedupIndex(t *testing.T) {
+	idx := &dedupIndex{Objects: make(map[string][]string)}
+	idx.addRefs("daily_1", []string{"aa01", "bb02"})
+	idx.addRefs("daily_2", []string{"aa01", "cc03"})
+	idx.addRefs("daily_2", []string{"aa01"})
+	assert.Equal(t, []string{"daily_1", "daily_2"}, idx.Objects["aa01"])
+
+	assert.Equal(t, []string{"bb02"}, idx.removeRefs("daily_1", []string{"aa01", "bb02"}))
+	assert.Equal(t, []string{"daily_2"}, idx.Objects["aa01"])
+	assert.Equal(t, []string{"aa01", "cc03"}, idx.removeRefs("daily_2", []string{"aa01", "cc03"}))
+	assert.Empty(t, idx.Objects)
+
+	assert.Equal(t, dedupObjectName([]byte("binlog")), dedupObjectName([]byte("binlog")))
+	assert.NotEqual(t, dedupObjectName([]byte("binlog")), dedupObjectName([]byte("other")))
+	assert.Len(t, dedupObjectName([]byte("binlog")), 64)
+	assert.Equal(t, "backup/dedup-objects/aa/aa01", DedupObjectPath("backup", "aa01"))
+}
+
+func TestDedupObjectsRefCount(t *testing.T) {
+	chunkManager := &memoryChunkManager{files: make(map[string][]byte)}
+	var storageClient storage.ChunkManager = chunkManager
+	b := &BackupContext{storageClient: &storageClient, backupRootPath: "backup"}
+	ctx := context.Background()
+	for _, object := range []string{"aa01", "bb02", "cc03"} {
+		chunkManager.files[DedupObjectPath("backup", object)] = []byte(object)
+	}
+
+	daily1 := newDedupBackup("daily_1", backuppb.BackupTaskStateCode_BACKUP_SUCCESS, "aa01", "bb02")
+	daily2 := newDedupBackup("daily_2", backuppb.BackupTaskStateCode_BACKUP_SUCCESS, "aa01", "cc03")
+	b.holdDedupObjects("daily_1", "aa01", "bb02")
+	b.registerDedupObjects(ctx, daily1)
+	b.registerDedupObjects(ctx, daily2)
+	assert.Empty(t, b.dedupHeld)
+	idx, err := b.readDedupIndex(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"daily_1", "daily_2"}, idx.Objects["aa01"])
+
+	// objects shared with daily_2 are kept
+	b.unrefDedupObjects(ctx, daily1)
+	assert.Contains(t, chunkManager.files, DedupObjectPath("backup", "aa01"))
+	assert.NotContains(t, chunkManager.files, DedupObjectPath("backup", "bb02"))
+
+	// objects held by an executing backup are kept
+	b.holdDedupObjects("daily_3", "cc03")
+	b.unrefDedupObjects(ctx, daily2)
+	assert.NotContains(t, chunkManager.files, DedupObjectPath("backup", "aa01"))
+	assert.Contains(t, chunkManager.files, DedupObjectPath("backup", "cc03"))
+	idx, err = b.readDedupIndex(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, idx.Objects)
+}
+
+func TestSelectOrphanedDedupObjects(t *testing.T) {
+	b := &BackupContext{milvusRootPath: "files", backupRootPath: "backup"}
+	backups := []*backuppb.BackupInfo{
+		newDedupBackup("daily_1", backuppb.BackupTaskStateCode_BACKUP_SUCCESS, "aa01"),
+		newDedupBackup("daily_2", backuppb.BackupTaskStateCode_BACKUP_FAIL, "aa01", "bb02"),
+	}
+	paths := []string{
+		"backup/daily_1/meta/backup_meta.json",
+		"backup/daily_2/meta/backup_meta.json",
+		"backup/dedup-objects/index.json",
+		"backup/dedup-objects/aa/aa01",
+		"backup/dedup-objects/bb/bb02",
+		"backup/dedup-objects/cc/cc03",
+		"backup/dedup-objects/dd/dd04",
+	}
+	b.holdDedupObjects("daily_3", "dd04")
+	dirs, files := b.selectOrphanedFiles(paths, backups, map[string]bool{})
+	assert.Empty(t, dirs)
+	assert.Equal(t, []string{"backup/dedup-objects/cc/cc03"}, files)
+
+	// objects may not be recorded in the meta of executing backups yet
+	executing := append(backups, newDedupBackup("daily_4", backuppb.BackupTaskStateCode_BACKUP_EXECUTING))
+	_, files = b.selectOrphanedFiles(paths, executing, map[string]bool{})
+	assert.Empty(t, files)
+	_, files = b.selectOrphanedFiles(paths, backups, map[string]bool{"unreadable": true})
+	assert.Empty(t, files)
+	assert.True(t, hasDedupObjects("backup", paths))
+	assert.False(t, hasDedupObjects("backup", paths[:2]))
+}
+
+func TestMaterializeDedupGroup(t *testing.T) {
+	var storageClient storage.ChunkManager = &memoryChunkManager{files: map[string][]byte{
+		"backup/dedup-objects/aa/aa01": []byte("insert"),
+		"backup/dedup-objects/bb/bb02": []byte("delta"),
+	}}
+	b := &BackupContext{storageClient: &storageClient, backupRootPath: "backup"}
+	partition := &backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2, SegmentBackups: []*backuppb.SegmentBackupInfo{{
+		SegmentId: 3,
+		GroupId:   3,
+		Binlogs: []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: []*backuppb.Binlog{
+			{LogPath: "files/insert_log/1/2/3/100/1", ObjectName: "aa01"},
+		}}},
+		Deltalogs: []*backuppb.FieldBinlog{{Binlogs: []*backuppb.Binlog{
+			{LogPath: "files/delta_log/1/2/3/1", ObjectName: "bb02"},
+		}}},
+	}}}
+	task := &backuppb.RestoreCollectionTask{FieldIdMappings: map[int64]int64{100: 101}}
+	files, err := b.materializeDedupGroup(context.Background(), "", "backup/daily_1", partition, 3, "restore-temp/", task)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"restore-temp/backup/daily_1/binlogs/insert_log/1/2/3/",
+		"restore-temp/backup/daily_1/binlogs/delta_log/1/2/3/",
+	}, files)
+	data, err := storageClient.Read(context.Background(), "", "restore-temp/backup/daily_1/binlogs/insert_log/1/2/3/3/101/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("insert"), data)
+	data, err = storageClient.Read(context.Background(), "", "restore-temp/backup/daily_1/binlogs/delta_log/1/2/3/3/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("delta"), data)
+
+	assert.Equal(t, map[int64]bool{3: true}, collectDedupGroupsFromSegments(partition.GetSegmentBackups()))
+	assert.Equal(t, "3/100/1", dedupBinlogRelativePath("files/insert_log/1/2/3/100/1", INSERT_LOG_DIR))
+}
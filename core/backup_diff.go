@@ -0,0 +1,282 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+const (
+	DiffAdded     = "added"
+	DiffRemoved   = "removed"
+	DiffChanged   = "changed"
+	DiffUnchanged = "unchanged"
+)
+
+// DiffBackups compares the backup toName with the backup fromName, by collections, schemas, segments, rows and sizes
+func (b *BackupContext) DiffBackups(ctx context.Context, fromName string, toName string) (*backuppb.BackupDiff, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return nil, err
+		}
+	}
+	backups := make([]*backuppb.BackupInfo, 0, 2)
+	for _, name := range []string{fromName, toName} {
+		if name == "" {
+			return nil, fmt.Errorf("empty backup name")
+		}
+		backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+name)
+		if err != nil {
+			return nil, err
+		}
+		if backup == nil {
+			return nil, fmt.Errorf("backup does not exist: %s", name)
+		}
+		backups = append(backups, backup)
+	}
+	diff := diffBackups(backups[0], backups[1])
+
+	// follow the base backups of the to backup to find whether the chain reaches the from backup
+	visited := map[string]bool{toName: true}
+	for base := backups[1].GetBaseBackupName(); base != "" && !visited[base]; {
+		if base == fromName {
+			diff.IncrementalOf = true
+			break
+		}
+		visited[base] = true
+		baseBackup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+base)
+		if err != nil {
+			return nil, err
+		}
+		base = baseBackup.GetBaseBackupName()
+	}
+	return diff, nil
+}
+
+func diffBackups(from, to *backuppb.BackupInfo) *backuppb.BackupDiff {
+	diff := &backuppb.BackupDiff{
+		FromBackupName:      from.GetName(),
+		ToBackupName:        to.GetName(),
+		FromBackupTimestamp: from.GetBackupTimestamp(),
+		ToBackupTimestamp:   to.GetBackupTimestamp(),
+		FromSize:            from.GetSize(),
+		ToSize:              to.GetSize(),
+	}
+	fromCollections := make(map[string]*backuppb.CollectionBackupInfo)
+	for _, collection := range from.GetCollectionBackups() {
+		fromCollections[collection.GetDbName()+"."+collection.GetCollectionName()] = collection
+	}
+	toCollections := make(map[string]*backuppb.CollectionBackupInfo)
+	for _, collection := range to.GetCollectionBackups() {
+		toCollections[collection.GetDbName()+"."+collection.GetCollectionName()] = collection
+	}
+	for name, collection := range fromCollections {
+		diff.Collections = append(diff.Collections, diffCollection(collection, toCollections[name]))
+	}
+	for name, collection := range toCollections {
+		if _, ok := fromCollections[name]; !ok {
+			diff.Collections = append(diff.Collections, diffCollection(nil, collection))
+		}
+	}
+	sort.Slice(diff.Collections, func(i, j int) bool {
+		if diff.Collections[i].GetDbName() != diff.Collections[j].GetDbName() {
+			return diff.Collections[i].GetDbName() < diff.Collections[j].GetDbName()
+		}
+		return diff.Collections[i].GetCollectionName() < diff.Collections[j].GetCollectionName()
+	})
+	for _, collection := range diff.Collections {
+		diff.FromRows += collection.GetFromRows()
+		diff.ToRows += collection.GetToRows()
+	}
+	return diff
+}
+
+// diffCollection compares the collection in two backups, from or to is nil if the collection is only in the other
+func diffCollection(from, to *backuppb.CollectionBackupInfo) *backuppb.CollectionDiff {
+	diff := &backuppb.CollectionDiff{}
+	fromSegments := collectionSegments(from)
+	toSegments := collectionSegments(to)
+	for _, collection := range []*backuppb.CollectionBackupInfo{from, to} {
+		if collection != nil {
+			diff.DbName = collection.GetDbName()
+			diff.CollectionName = collection.GetCollectionName()
+		}
+	}
+	diff.FromSize, diff.ToSize = from.GetSize(), to.GetSize()
+	diff.FromSegments, diff.ToSegments = int32(len(fromSegments)), int32(len(toSegments))
+	for id, segment := range fromSegments {
+		diff.FromRows += segment.GetNumOfRows()
+		toSegment, ok := toSegments[id]
+		if !ok {
+			diff.RemovedSegments++
+		} else if !isSameSegmentFiles(segment, toSegment) {
+			diff.ChangedSegments++
+		}
+	}
+	for id, segment := range toSegments {
+		diff.ToRows += segment.GetNumOfRows()
+		if _, ok := fromSegments[id]; !ok {
+			diff.AddedSegments++
+		}
+	}
+
+	switch {
+	case from == nil:
+		diff.Change = DiffAdded
+		return diff
+	case to == nil:
+		diff.Change = DiffRemoved
+		return diff
+	}
+	diff.SchemaChanges = diffSchema(from.GetSchema(), to.GetSchema())
+	diff.AddedPartitions, diff.RemovedPartitions = diffPartitions(from, to)
+	diff.Change = DiffUnchanged
+	if len(diff.GetSchemaChanges()) > 0 || len(diff.GetAddedPartitions()) > 0 || len(diff.GetRemovedPartitions()) > 0 ||
+		diff.GetAddedSegments() > 0 || diff.GetRemovedSegments() > 0 || diff.GetChangedSegments() > 0 ||
+		diff.GetFromRows() != diff.GetToRows() || diff.GetFromSize() != diff.GetToSize() {
+		diff.Change = DiffChanged
+	}
+	return diff
+}
+
+func collectionSegments(collection *backuppb.CollectionBackupInfo) map[int64]*backuppb.SegmentBackupInfo {
+	segments := make(map[int64]*backuppb.SegmentBackupInfo)
+	for _, partition := range collection.GetPartitionBackups() {
+		for _, segment := range partition.GetSegmentBackups() {
+			segments[segment.GetSegmentId()] = segment
+		}
+	}
+	return segments
+}
+
+func diffPartitions(from, to *backuppb.CollectionBackupInfo) (added []string, removed []string) {
+	names := func(collection *backuppb.CollectionBackupInfo) map[string]bool {
+		partitions := make(map[string]bool)
+		for _, partition := range collection.GetPartitionBackups() {
+			partitions[partition.GetPartitionName()] = true
+		}
+		return partitions
+	}
+	fromPartitions, toPartitions := names(from), names(to)
+	for name := range toPartitions {
+		if !fromPartitions[name] {
+			added = append(added, name)
+		}
+	}
+	for name := range fromPartitions {
+		if !toPartitions[name] {
+			removed = append(removed, name)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+	return added, removed
+}
+
+// diffSchema describes the changes of the schema, in the order of the fields of the from schema then the new fields
+func diffSchema(from, to *backuppb.CollectionSchema) []string {
+	changes := make([]string, 0)
+	if from.GetDescription() != to.GetDescription() {
+		changes = append(changes, fmt.Sprintf("description: %q -> %q", from.GetDescription(), to.GetDescription()))
+	}
+	if from.GetEnableDynamicField() != to.GetEnableDynamicField() {
+		changes = append(changes, fmt.Sprintf("dynamic field: %t -> %t", from.GetEnableDynamicField(), to.GetEnableDynamicField()))
+	}
+
+	toFields := make(map[string]*backuppb.FieldSchema)
+	for _, field := range to.GetFields() {
+		toFields[field.GetName()] = field
+	}
+	fromFields := make(map[string]bool)
+	for _, fromField := range from.GetFields() {
+		fromFields[fromField.GetName()] = true
+		toField, ok := toFields[fromField.GetName()]
+		if !ok {
+			changes = append(changes, "field "+fromField.GetName()+": "+DiffRemoved)
+			continue
+		}
+		for _, change := range diffField(fromField, toField) {
+			changes = append(changes, "field "+fromField.GetName()+": "+change)
+		}
+	}
+	for _, toField := range to.GetFields() {
+		if !fromFields[toField.GetName()] {
+			changes = append(changes, fmt.Sprintf("field %s: %s, %s", toField.GetName(), DiffAdded, toField.GetDataType()))
+		}
+	}
+
+	toFunctions := make(map[string]bool)
+	for _, function := range to.GetFunctions() {
+		toFunctions[function.GetName()] = true
+	}
+	fromFunctions := make(map[string]bool)
+	for _, function := range from.GetFunctions() {
+		fromFunctions[function.GetName()] = true
+		if !toFunctions[function.GetName()] {
+			changes = append(changes, "function "+function.GetName()+": "+DiffRemoved)
+		}
+	}
+	for _, function := range to.GetFunctions() {
+		if !fromFunctions[function.GetName()] {
+			changes = append(changes, "function "+function.GetName()+": "+DiffAdded)
+		}
+	}
+	return changes
+}
+
+func diffField(from, to *backuppb.FieldSchema) []string {
+	changes := make([]string, 0)
+	if from.GetDataType() != to.GetDataType() {
+		changes = append(changes, fmt.Sprintf("data type %s -> %s", from.GetDataType(), to.GetDataType()))
+	}
+	if from.GetElementType() != to.GetElementType() {
+		changes = append(changes, fmt.Sprintf("element type %s -> %s", from.GetElementType(), to.GetElementType()))
+	}
+	flags := []struct {
+		name     string
+		from, to bool
+	}{
+		{"primary key", from.GetIsPrimaryKey(), to.GetIsPrimaryKey()},
+		{"auto id", from.GetAutoID(), to.GetAutoID()},
+		{"partition key", from.GetIsPartitionKey(), to.GetIsPartitionKey()},
+	}
+	for _, flag := range flags {
+		if flag.from != flag.to {
+			changes = append(changes, fmt.Sprintf("%s %t -> %t", flag.name, flag.from, flag.to))
+		}
+	}
+
+	fromParams := make(map[string]string)
+	for _, param := range from.GetTypeParams() {
+		fromParams[param.GetKey()] = param.GetValue()
+	}
+	toParams := make(map[string]string)
+	for _, param := range to.GetTypeParams() {
+		toParams[param.GetKey()] = param.GetValue()
+	}
+	keys := make([]string, 0)
+	for key := range fromParams {
+		keys = append(keys, key)
+	}
+	for key := range toParams {
+		if _, ok := fromParams[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if fromParams[key] != toParams[key] {
+			changes = append(changes, fmt.Sprintf("%s %s -> %s", key, paramValue(fromParams, key), paramValue(toParams, key)))
+		}
+	}
+	return changes
+}
+
+func paramValue(params map[string]string, key string) string {
+	if value, ok := params[key]; ok {
+		return value
+	}
+	return "unset"
+}
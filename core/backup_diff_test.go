@@ -0,0 +1,103 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func diffTestCollection(name string, fields []*backuppb.FieldSchema, partitions ...*backuppb.PartitionBackupInfo) *backuppb.CollectionBackupInfo {
+	return &backuppb.CollectionBackupInfo{DbName: "default", CollectionName: name,
+		Schema: &backuppb.CollectionSchema{Fields: fields}, PartitionBackups: partitions}
+}
+
+func TestDiffSchema(t *testing.T) {
+	from := &backuppb.CollectionSchema{Fields: []*backuppb.FieldSchema{
+		{Name: "id", DataType: backuppb.DataType_Int64, IsPrimaryKey: true},
+		{Name: "vector", DataType: backuppb.DataType_FloatVector, TypeParams: []*backuppb.KeyValuePair{{Key: "dim", Value: "128"}}},
+		{Name: "age", DataType: backuppb.DataType_Int32},
+	}}
+	to := &backuppb.CollectionSchema{EnableDynamicField: true, Fields: []*backuppb.FieldSchema{
+		{Name: "id", DataType: backuppb.DataType_Int64, IsPrimaryKey: true},
+		{Name: "vector", DataType: backuppb.DataType_FloatVector, TypeParams: []*backuppb.KeyValuePair{{Key: "dim", Value: "256"}}},
+		{Name: "title", DataType: backuppb.DataType_VarChar, TypeParams: []*backuppb.KeyValuePair{{Key: "max_length", Value: "64"}}},
+	}}
+	assert.Equal(t, []string{
+		"dynamic field: false -> true",
+		"field vector: dim 128 -> 256",
+		"field age: removed",
+		"field title: added, VarChar",
+	}, diffSchema(from, to))
+	assert.Empty(t, diffSchema(from, from))
+}
+
+func TestDiffBackups(t *testing.T) {
+	fields := []*backuppb.FieldSchema{{Name: "id", DataType: backuppb.DataType_Int64, IsPrimaryKey: true}}
+	from := &backuppb.BackupInfo{Name: "from", Size: 300, CollectionBackups: []*backuppb.CollectionBackupInfo{
+		diffTestCollection("kept", fields, &backuppb.PartitionBackupInfo{PartitionName: "_default", SegmentBackups: []*backuppb.SegmentBackupInfo{
+			{SegmentId: 1, NumOfRows: 10, Size: 100},
+			{SegmentId: 2, NumOfRows: 20, Size: 100},
+		}}),
+		diffTestCollection("dropped", fields),
+		diffTestCollection("same", fields),
+	}}
+	to := &backuppb.BackupInfo{Name: "to", Size: 500, CollectionBackups: []*backuppb.CollectionBackupInfo{
+		diffTestCollection("kept", fields,
+			&backuppb.PartitionBackupInfo{PartitionName: "_default", SegmentBackups: []*backuppb.SegmentBackupInfo{
+				{SegmentId: 1, NumOfRows: 10, Size: 100},
+				{SegmentId: 3, NumOfRows: 25, Size: 120},
+			}},
+			&backuppb.PartitionBackupInfo{PartitionName: "p1"}),
+		diffTestCollection("created", fields),
+		diffTestCollection("same", fields),
+	}}
+	diff := diffBackups(from, to)
+	assert.Equal(t, int64(30), diff.GetFromRows())
+	assert.Equal(t, int64(35), diff.GetToRows())
+	assert.Equal(t, int64(200), diff.GetToSize()-diff.GetFromSize())
+	changes := make(map[string]string)
+	for _, collection := range diff.GetCollections() {
+		changes[collection.GetCollectionName()] = collection.GetChange()
+	}
+	assert.Equal(t, map[string]string{"kept": DiffChanged, "dropped": DiffRemoved, "created": DiffAdded, "same": DiffUnchanged}, changes)
+
+	kept := diff.GetCollections()[2]
+	assert.Equal(t, "kept", kept.GetCollectionName())
+	assert.Equal(t, []string{"p1"}, kept.GetAddedPartitions())
+	assert.Equal(t, int32(1), kept.GetAddedSegments())
+	assert.Equal(t, int32(1), kept.GetRemovedSegments())
+	assert.Equal(t, int32(0), kept.GetChangedSegments())
+	assert.Equal(t, int64(5), kept.GetToRows()-kept.GetFromRows())
+}
+
+func TestDiffIncrementalBackups(t *testing.T) {
+	ctx := context.Background()
+	var client storage.ChunkManager = &memoryChunkManager{files: make(map[string][]byte)}
+	b := &BackupContext{storageClient: &client, backupRootPath: "backup", started: true,
+		params: paramtable.BackupParams{MinioCfg: paramtable.MinioConfig{StorageType: paramtable.Minio}}}
+	for _, backup := range []*backuppb.BackupInfo{
+		{Name: "full", StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS},
+		{Name: "incr1", BaseBackupName: "full", StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS},
+		{Name: "incr2", BaseBackupName: "incr1", StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS},
+		{Name: "other", StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS},
+	} {
+		assert.NoError(t, b.writeBackupCheckpoint(ctx, backup, true))
+	}
+
+	diff, err := b.DiffBackups(ctx, "full", "incr2")
+	assert.NoError(t, err)
+	assert.True(t, diff.GetIncrementalOf())
+	diff, err = b.DiffBackups(ctx, "other", "incr2")
+	assert.NoError(t, err)
+	assert.False(t, diff.GetIncrementalOf())
+	diff, err = b.DiffBackups(ctx, "incr2", "full")
+	assert.NoError(t, err)
+	assert.False(t, diff.GetIncrementalOf())
+	_, err = b.DiffBackups(ctx, "full", "not_exist")
+	assert.Error(t, err)
+}
@@ -0,0 +1,293 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// etcdReader reads the keys of milvus meta in etcd
+type etcdReader interface {
+	// Get returns the keys equal to key, or with the prefix key, at revision and the revision read at.
+	// A revision of 0 reads the latest one.
+	Get(ctx context.Context, key string, prefix bool, revision int64) ([]*backuppb.EtcdKeyValue, int64, error)
+	Close() error
+}
+
+type etcdClientReader struct {
+	client *clientv3.Client
+}
+
+func (r *etcdClientReader) Get(ctx context.Context, key string, prefix bool, revision int64) ([]*backuppb.EtcdKeyValue, int64, error) {
+	opts := make([]clientv3.OpOption, 0, 2)
+	if prefix {
+		opts = append(opts, clientv3.WithPrefix())
+	}
+	if revision > 0 {
+		opts = append(opts, clientv3.WithRev(revision))
+	}
+	resp, err := r.client.Get(ctx, key, opts...)
+	if err != nil {
+		return nil, 0, err
+	}
+	kvs := make([]*backuppb.EtcdKeyValue, 0, len(resp.Kvs))
+	for _, kv := range resp.Kvs {
+		kvs = append(kvs, &backuppb.EtcdKeyValue{Key: string(kv.Key), Value: kv.Value, ModRevision: kv.ModRevision})
+	}
+	return kvs, resp.Header.GetRevision(), nil
+}
+
+func (r *etcdClientReader) Close() error {
+	return r.client.Close()
+}
+
+// newEtcdReader connects to the etcd of milvus in config
+func newEtcdReader(cfg paramtable.EtcdConfig) (etcdReader, error) {
+	if len(cfg.Endpoints) == 0 {
+		return nil, errors.New("etcd.endpoints is required by etcd snapshot")
+	}
+	password, err := utils.ResolveSecret(cfg.Password)
+	if err != nil {
+		return nil, fmt.Errorf("read etcd password: %w", err)
+	}
+	config := clientv3.Config{
+		Endpoints:   cfg.Endpoints,
+		DialTimeout: cfg.DialTimeout,
+		Username:    cfg.Username,
+		Password:    password,
+	}
+	if cfg.SSLEnabled {
+		tlsConfig := &tls.Config{MinVersion: tls.VersionTLS12}
+		if cfg.TLSCACert != "" {
+			pem, err := os.ReadFile(cfg.TLSCACert)
+			if err != nil {
+				return nil, fmt.Errorf("read etcd ca cert: %w", err)
+			}
+			tlsConfig.RootCAs = x509.NewCertPool()
+			if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+				return nil, errors.New("no certificate in etcd ca cert " + cfg.TLSCACert)
+			}
+		}
+		if cfg.TLSCert != "" {
+			cert, err := tls.LoadX509KeyPair(cfg.TLSCert, cfg.TLSKey)
+			if err != nil {
+				return nil, fmt.Errorf("load etcd client cert: %w", err)
+			}
+			tlsConfig.Certificates = []tls.Certificate{cert}
+		}
+		config.TLS = tlsConfig
+	}
+	client, err := clientv3.New(config)
+	if err != nil {
+		return nil, err
+	}
+	return &etcdClientReader{client: client}, nil
+}
+
+// the keys of milvus meta of a collection, relative to the meta root path, %d is the collection id
+var (
+	// keys equal to the collection key
+	etcdCollectionKeys = []string{
+		"root-coord/collection/%d",
+		"querycoord-collection-loadinfo/%d",
+	}
+	// keys under the collection dir
+	etcdCollectionPrefixes = []string{
+		"root-coord/fields/%d/",
+		"root-coord/partitions/%d/",
+		"root-coord/functions/%d/",
+		"datacoord-meta/s/%d/",
+		"datacoord-meta/binlog/%d/",
+		"datacoord-meta/deltalog/%d/",
+		"datacoord-meta/statslog/%d/",
+		"datacoord-meta/field-index/%d/",
+		"datacoord-meta/segment-index/%d/",
+		"querycoord-partition-loadinfo/%d/",
+	}
+)
+
+const (
+	// root-coord/database/collection-info/{db id}/{collection id}, the collections of databases since milvus 2.2.9
+	etcdDatabaseCollectionPrefix = "root-coord/database/collection-info/"
+	// root-coord/database/db-info/{db id}, all of them are kept to resolve the db ids
+	etcdDatabasePrefix = "root-coord/database/db-info/"
+	// datacoord-meta/channel-cp/{vchannel}, vchannel is like by-dev-rootcoord-dml_0_{collection id}v0
+	etcdChannelCheckpointPrefix = "datacoord-meta/channel-cp/"
+)
+
+// isCollectionChannel returns whether the vchannel belongs to the collection
+func isCollectionChannel(vchannel string, collectionID int64) bool {
+	index := strings.LastIndex(vchannel, "_"+strconv.FormatInt(collectionID, 10)+"v")
+	if index < 0 {
+		return false
+	}
+	_, err := strconv.Atoi(vchannel[index+len(strconv.FormatInt(collectionID, 10))+2:])
+	return err == nil
+}
+
+// takeEtcdSnapshot reads the meta of the collections in etcd at one revision, so that the states of segments,
+// channel checkpoints and collection meta are consistent with each other
+func takeEtcdSnapshot(ctx context.Context, reader etcdReader, metaRootPath string, collectionIDs []int64) (*backuppb.EtcdSnapshot, error) {
+	root := strings.TrimSuffix(metaRootPath, "/") + "/"
+	snapshot := &backuppb.EtcdSnapshot{MetaRootPath: strings.TrimSuffix(root, "/"), CreateTime: time.Now().Unix()}
+	seen := make(map[string]bool)
+	add := func(kvs []*backuppb.EtcdKeyValue, keep func(key string) bool) {
+		for _, kv := range kvs {
+			key := strings.TrimPrefix(kv.GetKey(), root)
+			if seen[key] || (keep != nil && !keep(key)) {
+				continue
+			}
+			seen[key] = true
+			snapshot.Kvs = append(snapshot.Kvs, &backuppb.EtcdKeyValue{Key: key, Value: kv.GetValue(), ModRevision: kv.GetModRevision()})
+		}
+	}
+	get := func(key string, prefix bool) ([]*backuppb.EtcdKeyValue, error) {
+		kvs, revision, err := reader.Get(ctx, root+key, prefix, snapshot.Revision)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read %s from etcd: %w", root+key, err)
+		}
+		// the first read decides the revision of all the others
+		if snapshot.Revision == 0 {
+			snapshot.Revision = revision
+		}
+		return kvs, nil
+	}
+
+	kvs, err := get(etcdDatabasePrefix, true)
+	if err != nil {
+		return nil, err
+	}
+	add(kvs, nil)
+
+	ids := make(map[string]bool, len(collectionIDs))
+	for _, id := range collectionIDs {
+		ids[strconv.FormatInt(id, 10)] = true
+	}
+	kvs, err = get(etcdDatabaseCollectionPrefix, true)
+	if err != nil {
+		return nil, err
+	}
+	add(kvs, func(key string) bool {
+		return ids[key[strings.LastIndex(key, "/")+1:]]
+	})
+	kvs, err = get(etcdChannelCheckpointPrefix, true)
+	if err != nil {
+		return nil, err
+	}
+	add(kvs, func(key string) bool {
+		for _, id := range collectionIDs {
+			if isCollectionChannel(strings.TrimPrefix(key, etcdChannelCheckpointPrefix), id) {
+				return true
+			}
+		}
+		return false
+	})
+
+	for _, id := range collectionIDs {
+		for _, key := range etcdCollectionKeys {
+			kvs, err := get(fmt.Sprintf(key, id), false)
+			if err != nil {
+				return nil, err
+			}
+			add(kvs, nil)
+		}
+		for _, prefix := range etcdCollectionPrefixes {
+			kvs, err := get(fmt.Sprintf(prefix, id), true)
+			if err != nil {
+				return nil, err
+			}
+			add(kvs, nil)
+		}
+	}
+	sort.Slice(snapshot.Kvs, func(i, j int) bool { return snapshot.Kvs[i].GetKey() < snapshot.Kvs[j].GetKey() })
+	return snapshot, nil
+}
+
+// backupEtcdSnapshot snapshots the etcd meta of the collections in backup into its meta dir
+func (b *BackupContext) backupEtcdSnapshot(ctx context.Context, backupInfo *backuppb.BackupInfo) (*backuppb.EtcdSnapshotInfo, error) {
+	reader := b.etcdReader
+	if reader == nil {
+		var err error
+		reader, err = newEtcdReader(b.params.EtcdCfg)
+		if err != nil {
+			log.Error("fail to connect to etcd", zap.Strings("endpoints", b.params.EtcdCfg.Endpoints), zap.Error(err))
+			return nil, err
+		}
+		defer reader.Close()
+	}
+
+	collectionIDs := make([]int64, 0, len(backupInfo.GetCollectionBackups()))
+	for _, collection := range backupInfo.GetCollectionBackups() {
+		if isFailedCollection(collection) {
+			continue
+		}
+		collectionIDs = append(collectionIDs, collection.GetCollectionId())
+	}
+	snapshot, err := takeEtcdSnapshot(ctx, reader, b.params.EtcdCfg.MetaRootPath(), collectionIDs)
+	if err != nil {
+		log.Error("fail to snapshot etcd", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+		return nil, err
+	}
+	snapshot.BackupName = backupInfo.GetName()
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	// like meta, the snapshot is read without the customer key of the backup
+	ctx = storage.WithoutCustomerKey(ctx)
+	if err := b.writeBackupFile(ctx, EtcdSnapshotPath(b.backupRootPath, backupInfo.GetName()), data); err != nil {
+		log.Error("fail to write etcd snapshot", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+		return nil, err
+	}
+
+	info := &backuppb.EtcdSnapshotInfo{
+		Revision:     snapshot.GetRevision(),
+		MetaRootPath: snapshot.GetMetaRootPath(),
+		KeyCount:     int32(len(snapshot.GetKvs())),
+	}
+	for _, kv := range snapshot.GetKvs() {
+		info.Size += int64(len(kv.GetValue()))
+	}
+	log.Info("snapshot etcd",
+		zap.String("backupName", backupInfo.GetName()),
+		zap.Int64("revision", info.GetRevision()),
+		zap.Int32("keys", info.GetKeyCount()))
+	return info, nil
+}
+
+// ReadEtcdSnapshot reads the etcd snapshot of a backup
+func (b *BackupContext) ReadEtcdSnapshot(ctx context.Context, backupName string) (*backuppb.EtcdSnapshot, error) {
+	exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, EtcdSnapshotPath(b.backupRootPath, backupName))
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, fmt.Errorf("backup %s has no etcd snapshot", backupName)
+	}
+	data, err := b.readBackupFile(storage.WithoutCustomerKey(ctx), b.backupBucketName, EtcdSnapshotPath(b.backupRootPath, backupName))
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &backuppb.EtcdSnapshot{}
+	if err := json.Unmarshal(data, snapshot); err != nil {
+		return nil, fmt.Errorf("fail to parse etcd snapshot of backup %s: %w", backupName, err)
+	}
+	return snapshot, nil
+}
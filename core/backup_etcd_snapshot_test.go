@@ -0,0 +1,131 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// fakeEtcdReader serves the kvs at revision, and records the revisions requested
+type fakeEtcdReader struct {
+	kvs       map[string]string
+	revision  int64
+	revisions []int64
+	err       error
+}
+
+func (r *fakeEtcdReader) Get(ctx context.Context, key string, prefix bool, revision int64) ([]*backuppb.EtcdKeyValue, int64, error) {
+	if r.err != nil {
+		return nil, 0, r.err
+	}
+	r.revisions = append(r.revisions, revision)
+	kvs := make([]*backuppb.EtcdKeyValue, 0)
+	for k, v := range r.kvs {
+		if k == key || (prefix && strings.HasPrefix(k, key)) {
+			kvs = append(kvs, &backuppb.EtcdKeyValue{Key: k, Value: []byte(v), ModRevision: r.revision - 1})
+		}
+	}
+	return kvs, r.revision, nil
+}
+
+func (r *fakeEtcdReader) Close() error {
+	return nil
+}
+
+func newFakeEtcdReader() *fakeEtcdReader {
+	return &fakeEtcdReader{revision: 100, kvs: map[string]string{
+		"by-dev/meta/root-coord/database/db-info/1":                          "default",
+		"by-dev/meta/root-coord/database/collection-info/1/447":              "coll1",
+		"by-dev/meta/root-coord/database/collection-info/1/448":              "coll2",
+		"by-dev/meta/root-coord/fields/447/100":                              "pk",
+		"by-dev/meta/root-coord/fields/4470/100":                             "other",
+		"by-dev/meta/root-coord/partitions/447/449":                          "_default",
+		"by-dev/meta/datacoord-meta/s/447/449/450":                           "segment",
+		"by-dev/meta/datacoord-meta/binlog/447/449/450/100":                  "binlog",
+		"by-dev/meta/datacoord-meta/channel-cp/by-dev-rootcoord-dml_0_447v0": "checkpoint",
+		"by-dev/meta/datacoord-meta/channel-cp/by-dev-rootcoord-dml_1_448v0": "other",
+		"by-dev/meta/querycoord-collection-loadinfo/447":                     "loaded",
+		"by-dev/meta/snapshots/root-coord/collection/447_1":                  "snapshot",
+		"by-dev/kv/gid/timestamp":                                            "ts",
+	}}
+}
+
+func TestIsCollectionChannel(t *testing.T) {
+	assert.True(t, isCollectionChannel("by-dev-rootcoord-dml_0_447v0", 447))
+	assert.True(t, isCollectionChannel("by-dev-rootcoord-dml_15_447v12", 447))
+	assert.False(t, isCollectionChannel("by-dev-rootcoord-dml_0_4470v0", 447))
+	assert.False(t, isCollectionChannel("by-dev-rootcoord-dml_0_1447v0", 447))
+	assert.False(t, isCollectionChannel("by-dev-rootcoord-dml_0_447v", 447))
+}
+
+func TestTakeEtcdSnapshot(t *testing.T) {
+	reader := newFakeEtcdReader()
+	snapshot, err := takeEtcdSnapshot(context.Background(), reader, "by-dev/meta/", []int64{447})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), snapshot.GetRevision())
+	assert.Equal(t, "by-dev/meta", snapshot.GetMetaRootPath())
+	keys := make([]string, 0)
+	for _, kv := range snapshot.GetKvs() {
+		keys = append(keys, kv.GetKey())
+	}
+	assert.Equal(t, []string{
+		"datacoord-meta/binlog/447/449/450/100",
+		"datacoord-meta/channel-cp/by-dev-rootcoord-dml_0_447v0",
+		"datacoord-meta/s/447/449/450",
+		"querycoord-collection-loadinfo/447",
+		"root-coord/database/collection-info/1/447",
+		"root-coord/database/db-info/1",
+		"root-coord/fields/447/100",
+		"root-coord/partitions/447/449",
+	}, keys)
+	assert.Equal(t, []byte("segment"), snapshot.GetKvs()[2].GetValue())
+	// all the keys but the first ones are read at the revision of the first read
+	assert.Equal(t, int64(0), reader.revisions[0])
+	for _, revision := range reader.revisions[1:] {
+		assert.Equal(t, int64(100), revision)
+	}
+
+	reader.err = errors.New("etcdserver: mvcc: required revision has been compacted")
+	_, err = takeEtcdSnapshot(context.Background(), reader, "by-dev/meta", []int64{447})
+	assert.Error(t, err)
+}
+
+func TestBackupEtcdSnapshot(t *testing.T) {
+	ctx := context.Background()
+	b := newManifestBackupContext(&memoryChunkManager{files: make(map[string][]byte)})
+	b.params.EtcdCfg.RootPath = "by-dev"
+	b.params.EtcdCfg.MetaSubPath = "meta"
+	b.etcdReader = newFakeEtcdReader()
+	backupInfo := &backuppb.BackupInfo{
+		Name: "etcd",
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{CollectionName: "coll1", CollectionId: 447},
+			{CollectionName: "coll2", CollectionId: 448, StateCode: backuppb.BackupTaskStateCode_BACKUP_FAIL},
+		},
+	}
+	info, err := b.backupEtcdSnapshot(ctx, backupInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(100), info.GetRevision())
+	assert.Equal(t, "by-dev/meta", info.GetMetaRootPath())
+	assert.Equal(t, int32(8), info.GetKeyCount())
+
+	snapshot, err := b.ReadEtcdSnapshot(ctx, "etcd")
+	assert.NoError(t, err)
+	assert.Equal(t, "etcd", snapshot.GetBackupName())
+	assert.Len(t, snapshot.GetKvs(), 8)
+	var size int64
+	for _, kv := range snapshot.GetKvs() {
+		size += int64(len(kv.GetValue()))
+		// the failed collection is not snapshot
+		assert.NotContains(t, kv.GetKey(), "448")
+	}
+	assert.Equal(t, size, info.GetSize())
+
+	_, err = b.ReadEtcdSnapshot(ctx, "missing")
+	assert.Error(t, err)
+}
@@ -0,0 +1,515 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/parquet"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// formats of the files of exported collections
+const (
+	ExportFormatParquet = "parquet"
+	ExportFormatJSONL   = "jsonl"
+)
+
+// name of the schema file in the dir of an exported collection, the leading underscore makes readers of datasets
+// like spark and pyarrow skip it
+const exportSchemaFile = "_schema.json"
+
+// ExportOptions selects the collections of a backup to export and the format of the files written
+type ExportOptions struct {
+	Format string
+	// local dir the collections are exported into, as dirs of database/collection
+	OutputDir string
+	// db.collection, or collection of the default database, all the collections if empty
+	Collections    []string
+	SseCustomerKey string
+}
+
+// ExportedCollection is a collection exported from a backup
+type ExportedCollection struct {
+	DbName         string
+	CollectionName string
+	Dir            string
+	// rows exported, rows deleted by the delta logs of the backup are not exported
+	Rows        int64
+	DeletedRows int64
+	Files       []string
+}
+
+// ExportBackup converts the binlogs of the collections of a backup into parquet or jsonl files of local dirs, a file
+// per segment with the schema of the collection beside them, so the data can be read without milvus
+func (b *BackupContext) ExportBackup(ctx context.Context, backupName string, opts ExportOptions) ([]*ExportedCollection, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return nil, err
+		}
+	}
+	if backupName == "" {
+		return nil, fmt.Errorf("empty backup name")
+	}
+	if opts.Format != ExportFormatParquet && opts.Format != ExportFormatJSONL {
+		return nil, fmt.Errorf("unsupported export format: %s, support: %s, %s", opts.Format, ExportFormatParquet, ExportFormatJSONL)
+	}
+	if opts.OutputDir == "" {
+		return nil, fmt.Errorf("empty export output dir")
+	}
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil {
+		return nil, fmt.Errorf("backup does not exist: %s", backupName)
+	}
+	sse, err := b.backupServerSideEncryption(backup, opts.SseCustomerKey)
+	if err != nil {
+		return nil, err
+	}
+	ctx = storage.WithSourceServerSideEncryption(ctx, sse)
+
+	selected := make(map[string]bool, len(opts.Collections))
+	for _, name := range opts.Collections {
+		if !strings.Contains(name, ".") {
+			name = "default." + name
+		}
+		selected[name] = true
+	}
+	exported := make([]*ExportedCollection, 0)
+	for _, collection := range backup.GetCollectionBackups() {
+		db := collection.GetDbName()
+		if db == "" {
+			db = "default"
+		}
+		name := db + "." + collection.GetCollectionName()
+		if len(selected) > 0 && !selected[name] {
+			continue
+		}
+		delete(selected, name)
+		// binlogs of the collections failed in a partial backup are not all copied
+		if isFailedCollection(collection) {
+			log.Warn("skip exporting failed collection", zap.String("collection", name))
+			continue
+		}
+		result, err := b.exportCollection(ctx, backup, db, collection, opts)
+		if err != nil {
+			return exported, fmt.Errorf("fail to export collection %s: %w", name, err)
+		}
+		exported = append(exported, result)
+	}
+	for name := range selected {
+		return exported, fmt.Errorf("collection %s is not in backup %s", name, backupName)
+	}
+	return exported, nil
+}
+
+func (b *BackupContext) exportCollection(ctx context.Context, backup *backuppb.BackupInfo, db string, collection *backuppb.CollectionBackupInfo, opts ExportOptions) (*ExportedCollection, error) {
+	result := &ExportedCollection{
+		DbName:         db,
+		CollectionName: collection.GetCollectionName(),
+		Dir:            filepath.Join(opts.OutputDir, db, collection.GetCollectionName()),
+	}
+	if err := os.MkdirAll(result.Dir, 0o755); err != nil {
+		return nil, err
+	}
+	schema, err := json.MarshalIndent(collection.GetSchema(), "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(filepath.Join(result.Dir, exportSchemaFile), schema, 0o644); err != nil {
+		return nil, err
+	}
+
+	fields := make([]*backuppb.FieldSchema, 0)
+	var pkField *backuppb.FieldSchema
+	for _, field := range collection.GetSchema().GetFields() {
+		if field.GetFieldID() < common.StartOfUserFieldID {
+			continue
+		}
+		fields = append(fields, field)
+		if field.GetIsPrimaryKey() {
+			pkField = field
+		}
+	}
+	if pkField == nil {
+		return nil, fmt.Errorf("no primary key in schema")
+	}
+
+	// deletions of a segment can be in the delta logs of any segment of the collection, like L0 segments
+	deletes := make(map[string]uint64)
+	for _, partition := range collection.GetPartitionBackups() {
+		for _, segment := range partition.GetSegmentBackups() {
+			for _, fieldBinlog := range segment.GetDeltalogs() {
+				for _, binlog := range fieldBinlog.GetBinlogs() {
+					data, err := b.readExportBinlog(ctx, backup, collection, segment, binlog)
+					if err != nil {
+						return nil, err
+					}
+					segmentDeletes, err := readDeltalogFile(data)
+					if err != nil {
+						return nil, fmt.Errorf("fail to read delta log %s: %w", binlog.GetLogPath(), err)
+					}
+					for pk, ts := range segmentDeletes {
+						if ts > deletes[pk] {
+							deletes[pk] = ts
+						}
+					}
+				}
+			}
+		}
+	}
+
+	for _, partition := range collection.GetPartitionBackups() {
+		for _, segment := range partition.GetSegmentBackups() {
+			if len(segment.GetBinlogs()) == 0 {
+				continue
+			}
+			err := b.withMemory(ctx, 2*segment.GetSize(), func() error {
+				columns, deleted, err := b.readExportSegment(ctx, backup, collection, segment, fields, pkField, deletes)
+				if err != nil {
+					return err
+				}
+				result.DeletedRows += deleted
+				if len(columns) == 0 || len(columns[0]) == 0 {
+					return nil
+				}
+				file := filepath.Join(result.Dir, fmt.Sprintf("segment_%d.%s", segment.GetSegmentId(), opts.Format))
+				if err := writeExportFile(file, opts.Format, fields, columns); err != nil {
+					os.Remove(file)
+					return fmt.Errorf("fail to write %s: %w", file, err)
+				}
+				result.Rows += int64(len(columns[0]))
+				result.Files = append(result.Files, file)
+				return nil
+			})
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	log.Info("export collection",
+		zap.String("collection", db+"."+collection.GetCollectionName()),
+		zap.String("dir", result.Dir),
+		zap.Int64("rows", result.Rows),
+		zap.Int64("deletedRows", result.DeletedRows),
+		zap.Int("files", len(result.Files)))
+	return result, nil
+}
+
+// readExportBinlog reads a binlog of a segment in backup decrypted and decompressed
+func (b *BackupContext) readExportBinlog(ctx context.Context, backup *backuppb.BackupInfo, collection *backuppb.CollectionBackupInfo, segment *backuppb.SegmentBackupInfo, binlog *backuppb.Binlog) ([]byte, error) {
+	// binlogs of an incremental backup may be stored in its base backups
+	backupName := backup.GetName()
+	if segment.GetRefBackupName() != "" {
+		backupName = segment.GetRefBackupName()
+	}
+	filePath := b.binlogBackupPath(backupName, collection, segment, binlog.GetLogPath())
+	if binlog.GetObjectName() != "" {
+		filePath = DedupObjectPath(b.backupRootPath, binlog.GetObjectName())
+	}
+	data, err := b.readSegmentFile(ctx, segment, b.backupBucketName, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read binlog %s: %w", filePath, err)
+	}
+	decompressed, err := utils.Decompress(segment.GetCompression(), data)
+	if err != nil {
+		return nil, fmt.Errorf("fail to decompress binlog %s: %w", filePath, err)
+	}
+	return decompressed, nil
+}
+
+// readExportSegment returns the values of the fields of the rows of a segment not deleted, converted by
+// exportValue, and the rows deleted
+func (b *BackupContext) readExportSegment(ctx context.Context, backup *backuppb.BackupInfo, collection *backuppb.CollectionBackupInfo, segment *backuppb.SegmentBackupInfo, fields []*backuppb.FieldSchema, pkField *backuppb.FieldSchema, deletes map[string]uint64) ([][]interface{}, int64, error) {
+	raw := make(map[int64][]interface{})
+	for _, fieldBinlog := range segment.GetBinlogs() {
+		fieldID := fieldBinlog.GetFieldID()
+		if fieldID == common.RowIDField || (fieldID == common.TimeStampField && len(deletes) == 0) {
+			continue
+		}
+		for _, binlog := range fieldBinlog.GetBinlogs() {
+			data, err := b.readExportBinlog(ctx, backup, collection, segment, binlog)
+			if err != nil {
+				return nil, 0, err
+			}
+			file, err := readBinlogFile(data)
+			if err != nil {
+				return nil, 0, fmt.Errorf("fail to read binlog %s: %w", binlog.GetLogPath(), err)
+			}
+			raw[fieldID] = append(raw[fieldID], file.values...)
+		}
+	}
+	pks, ok := raw[pkField.GetFieldID()]
+	if !ok {
+		return nil, 0, fmt.Errorf("no binlogs of primary key of segment %d", segment.GetSegmentId())
+	}
+	numRows := len(pks)
+	for fieldID, values := range raw {
+		if len(values) != numRows {
+			return nil, 0, fmt.Errorf("%d rows of field %d of segment %d, expect %d", len(values), fieldID, segment.GetSegmentId(), numRows)
+		}
+	}
+
+	// a row is deleted by a deletion of its primary key after it is inserted
+	keep := make([]bool, numRows)
+	timestamps := raw[common.TimeStampField]
+	var deleted int64
+	for i, pk := range pks {
+		keep[i] = true
+		if len(deletes) == 0 {
+			continue
+		}
+		var key string
+		switch v := pk.(type) {
+		case int64:
+			key = strconv.FormatInt(v, 10)
+		case []byte:
+			key = string(v)
+		}
+		ts, _ := timestamps[i].(int64)
+		if deleteTs, ok := deletes[key]; ok && uint64(ts) < deleteTs {
+			keep[i] = false
+			deleted++
+		}
+	}
+
+	columns := make([][]interface{}, 0, len(fields))
+	for _, field := range fields {
+		values := raw[field.GetFieldID()]
+		column := make([]interface{}, 0, numRows-int(deleted))
+		for i := 0; i < numRows; i++ {
+			if !keep[i] {
+				continue
+			}
+			// fields added after the segment is written have no binlogs
+			if values == nil {
+				column = append(column, nil)
+				continue
+			}
+			value, err := exportValue(field, values[i])
+			if err != nil {
+				return nil, 0, fmt.Errorf("illegal value of field %s of segment %d: %w", field.GetName(), segment.GetSegmentId(), err)
+			}
+			column = append(column, value)
+		}
+		columns = append(columns, column)
+	}
+	return columns, deleted, nil
+}
+
+// exportValue converts a value of a field read from binlog: strings into string, json, arrays and sparse vectors
+// into json.RawMessage, float vectors into []float32, others as they are
+func exportValue(field *backuppb.FieldSchema, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	data, isBytes := value.([]byte)
+	switch field.GetDataType() {
+	case backuppb.DataType_VarChar, backuppb.DataType_String:
+		if isBytes {
+			return string(data), nil
+		}
+	case backuppb.DataType_Json:
+		if isBytes {
+			if !json.Valid(data) {
+				return nil, fmt.Errorf("illegal json %q", data)
+			}
+			return json.RawMessage(data), nil
+		}
+	case backuppb.DataType_Array:
+		if isBytes {
+			return arrayJSON(data)
+		}
+	case backuppb.DataType_FloatVector:
+		if isBytes && len(data)%4 == 0 {
+			vector := make([]float32, len(data)/4)
+			for i := range vector {
+				vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+			}
+			return vector, nil
+		}
+	case backuppb.DataType_SparseFloatVector:
+		if isBytes {
+			return sparseVectorJSON(data)
+		}
+	case backuppb.DataType_BinaryVector:
+		if isBytes {
+			return data, nil
+		}
+	default:
+		if !isBytes {
+			return value, nil
+		}
+	}
+	return nil, fmt.Errorf("unexpected value %T of %s", value, field.GetDataType())
+}
+
+// arrayJSON returns the json array of an array field stored as schemapb.ScalarField
+func arrayJSON(data []byte) (json.RawMessage, error) {
+	scalar := &schemapb.ScalarField{}
+	if err := proto.Unmarshal(data, scalar); err != nil {
+		return nil, fmt.Errorf("illegal array: %w", err)
+	}
+	var elements interface{}
+	switch {
+	case scalar.GetBoolData() != nil:
+		elements = scalar.GetBoolData().GetData()
+	case scalar.GetIntData() != nil:
+		elements = scalar.GetIntData().GetData()
+	case scalar.GetLongData() != nil:
+		elements = scalar.GetLongData().GetData()
+	case scalar.GetFloatData() != nil:
+		elements = scalar.GetFloatData().GetData()
+	case scalar.GetDoubleData() != nil:
+		elements = scalar.GetDoubleData().GetData()
+	case scalar.GetStringData() != nil:
+		elements = scalar.GetStringData().GetData()
+	default:
+		return json.RawMessage("[]"), nil
+	}
+	array, err := json.Marshal(elements)
+	if err != nil {
+		return nil, err
+	}
+	// nil slices of empty arrays
+	if string(array) == "null" {
+		return json.RawMessage("[]"), nil
+	}
+	return array, nil
+}
+
+// sparseVectorJSON returns a sparse vector of pairs of uint32 index and float32 value as a json object of
+// index to value, in the order of indices
+func sparseVectorJSON(data []byte) (json.RawMessage, error) {
+	if len(data)%8 != 0 {
+		return nil, fmt.Errorf("illegal sparse vector of %d bytes", len(data))
+	}
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i := 0; i < len(data); i += 8 {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteByte('"')
+		buf.WriteString(strconv.FormatUint(uint64(binary.LittleEndian.Uint32(data[i:])), 10))
+		buf.WriteString(`":`)
+		buf.WriteString(strconv.FormatFloat(float64(math.Float32frombits(binary.LittleEndian.Uint32(data[i+4:]))), 'g', -1, 32))
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// exportColumn returns the parquet column of a field, json, arrays and sparse vectors are json strings
+func exportColumn(field *backuppb.FieldSchema) (parquet.Column, error) {
+	column := parquet.Column{Name: field.GetName(), Optional: !field.GetIsPrimaryKey()}
+	switch field.GetDataType() {
+	case backuppb.DataType_Bool:
+		column.Type = parquet.Boolean
+	case backuppb.DataType_Int8:
+		column.Type, column.LogicalType = parquet.Int32, parquet.LogicalInt8
+	case backuppb.DataType_Int16:
+		column.Type, column.LogicalType = parquet.Int32, parquet.LogicalInt16
+	case backuppb.DataType_Int32:
+		column.Type = parquet.Int32
+	case backuppb.DataType_Int64:
+		column.Type = parquet.Int64
+	case backuppb.DataType_Float:
+		column.Type = parquet.Float
+	case backuppb.DataType_Double:
+		column.Type = parquet.Double
+	case backuppb.DataType_VarChar, backuppb.DataType_String, backuppb.DataType_Json, backuppb.DataType_Array, backuppb.DataType_SparseFloatVector:
+		column.Type, column.LogicalType = parquet.ByteArray, parquet.LogicalString
+	case backuppb.DataType_FloatVector:
+		column.Type, column.List = parquet.Float, true
+	case backuppb.DataType_BinaryVector:
+		column.Type = parquet.ByteArray
+	default:
+		return column, fmt.Errorf("unsupported data type %s of field %s", field.GetDataType(), field.GetName())
+	}
+	return column, nil
+}
+
+// writeExportFile writes the values of the fields into a parquet file of a row group, or a jsonl file of an object
+// per row
+func writeExportFile(file string, format string, fields []*backuppb.FieldSchema, columns [][]interface{}) error {
+	f, err := os.Create(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	w := bufio.NewWriter(f)
+	if format == ExportFormatParquet {
+		parquetColumns := make([]parquet.Column, 0, len(fields))
+		for _, field := range fields {
+			column, err := exportColumn(field)
+			if err != nil {
+				return err
+			}
+			parquetColumns = append(parquetColumns, column)
+		}
+		for _, column := range columns {
+			for i, value := range column {
+				if raw, ok := value.(json.RawMessage); ok {
+					column[i] = []byte(raw)
+				}
+			}
+		}
+		pw, err := parquet.NewWriter(w, parquetColumns)
+		if err != nil {
+			return err
+		}
+		if err := pw.WriteRowGroup(columns); err != nil {
+			return err
+		}
+		if err := pw.Close(); err != nil {
+			return err
+		}
+	} else {
+		names := make([][]byte, 0, len(fields))
+		for _, field := range fields {
+			name, err := json.Marshal(field.GetName())
+			if err != nil {
+				return err
+			}
+			names = append(names, name)
+		}
+		for row := range columns[0] {
+			w.WriteByte('{')
+			for i, column := range columns {
+				if i > 0 {
+					w.WriteByte(',')
+				}
+				value, err := json.Marshal(column[row])
+				if err != nil {
+					return fmt.Errorf("illegal value of field %s: %w", fields[i].GetName(), err)
+				}
+				w.Write(names[i])
+				w.WriteByte(':')
+				w.Write(value)
+			}
+			w.WriteString("}\n")
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	return f.Close()
+}
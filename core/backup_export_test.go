@@ -0,0 +1,183 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/parquet"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+// testBinlogFile writes a binlog file of an insert event of the values like milvus
+func testBinlogFile(t *testing.T, fieldID int64, dataType backuppb.DataType, column parquet.Column, values ...interface{}) []byte {
+	data, err := writeBinlogFile(binlogDescriptor{fieldID: fieldID, dataType: dataType}, binlogInsertEvent, column, values)
+	assert.NoError(t, err)
+	return data
+}
+
+func appendUint32(data []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(data, b[:]...)
+}
+
+func float32Bytes(values ...float32) []byte {
+	data := make([]byte, 0, 4*len(values))
+	for _, v := range values {
+		data = appendUint32(data, math.Float32bits(v))
+	}
+	return data
+}
+
+func TestExportBackup(t *testing.T) {
+	ctx := context.Background()
+	files := make(map[string][]byte)
+	var client storage.ChunkManager = &memoryChunkManager{files: files}
+	b := &BackupContext{storageClient: &client, backupRootPath: "backup", started: true}
+
+	tags, err := proto.Marshal(&schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", "b"}}}})
+	assert.NoError(t, err)
+	binlogs := []struct {
+		fieldID  int64
+		dataType backuppb.DataType
+		column   parquet.Column
+		values   [][]interface{}
+	}{
+		{0, backuppb.DataType_Int64, parquet.Column{Name: "val", Type: parquet.Int64}, [][]interface{}{{int64(1), int64(2), int64(3)}}},
+		{1, backuppb.DataType_Int64, parquet.Column{Name: "val", Type: parquet.Int64}, [][]interface{}{{int64(10), int64(10), int64(30)}}},
+		{100, backuppb.DataType_Int64, parquet.Column{Name: "val", Type: parquet.Int64}, [][]interface{}{{int64(1), int64(2)}, {int64(3)}}},
+		{101, backuppb.DataType_VarChar, parquet.Column{Name: "val", Type: parquet.ByteArray, LogicalType: parquet.LogicalString}, [][]interface{}{{"one", "two", "three"}}},
+		{102, backuppb.DataType_FloatVector, parquet.Column{Name: "val", Type: parquet.FixedLenByteArray, TypeLength: 8},
+			[][]interface{}{{float32Bytes(1, 2), float32Bytes(3, 4), float32Bytes(0.5, 6)}}},
+		{103, backuppb.DataType_Json, parquet.Column{Name: "val", Type: parquet.ByteArray}, [][]interface{}{{`{"k":1}`, `[]`, `"s"`}}},
+		{104, backuppb.DataType_Array, parquet.Column{Name: "val", Type: parquet.ByteArray}, [][]interface{}{{tags, tags, tags}}},
+		{105, backuppb.DataType_Bool, parquet.Column{Name: "val", Type: parquet.Boolean, Optional: true}, [][]interface{}{{true, nil, false}}},
+	}
+	segment := &backuppb.SegmentBackupInfo{SegmentId: 11, CollectionId: 1, PartitionId: 2, NumOfRows: 3}
+	for _, binlog := range binlogs {
+		fieldBinlog := &backuppb.FieldBinlog{FieldID: binlog.fieldID}
+		for i, values := range binlog.values {
+			logPath := strings.Join([]string{"insert_log/1/2/11", strconv.FormatInt(binlog.fieldID, 10), strconv.Itoa(i)}, "/")
+			files[b.binlogBackupPath("test_backup", nil, segment, logPath)] = testBinlogFile(t, binlog.fieldID, binlog.dataType, binlog.column, values...)
+			fieldBinlog.Binlogs = append(fieldBinlog.Binlogs, &backuppb.Binlog{LogPath: logPath})
+		}
+		segment.Binlogs = append(segment.Binlogs, fieldBinlog)
+	}
+	// a L0 segment deletes pk 2 after it is inserted, and pk 3 before it is inserted
+	deleteSegment := &backuppb.SegmentBackupInfo{SegmentId: 12, CollectionId: 1, PartitionId: 2,
+		Deltalogs: []*backuppb.FieldBinlog{{Binlogs: []*backuppb.Binlog{{LogPath: "delta_log/1/2/12/1"}}}}}
+	files[b.binlogBackupPath("test_backup", nil, deleteSegment, "delta_log/1/2/12/1")] = testBinlogFile(t, 0, backuppb.DataType_String,
+		parquet.Column{Name: "val", Type: parquet.ByteArray}, `{"pk":2,"ts":20,"pkType":5}`, "3,20")
+
+	backupInfo := &backuppb.BackupInfo{
+		Name:      "test_backup",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId:   1,
+			DbName:         "db1",
+			CollectionName: "coll",
+			Schema: &backuppb.CollectionSchema{Name: "coll", Fields: []*backuppb.FieldSchema{
+				{FieldID: 0, Name: "RowID", DataType: backuppb.DataType_Int64},
+				{FieldID: 1, Name: "Timestamp", DataType: backuppb.DataType_Int64},
+				{FieldID: 100, Name: "id", DataType: backuppb.DataType_Int64, IsPrimaryKey: true},
+				{FieldID: 101, Name: "name", DataType: backuppb.DataType_VarChar},
+				{FieldID: 102, Name: "vector", DataType: backuppb.DataType_FloatVector},
+				{FieldID: 103, Name: "meta", DataType: backuppb.DataType_Json},
+				{FieldID: 104, Name: "tags", DataType: backuppb.DataType_Array, ElementType: backuppb.DataType_VarChar},
+				{FieldID: 105, Name: "flag", DataType: backuppb.DataType_Bool},
+				// added after the segment is written
+				{FieldID: 106, Name: "score", DataType: backuppb.DataType_Float},
+			}},
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:    2,
+				CollectionId:   1,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{segment, deleteSegment},
+			}},
+		}},
+	}
+	assert.NoError(t, b.writeBackupCheckpoint(ctx, backupInfo, true))
+
+	dir := t.TempDir()
+	exported, err := b.ExportBackup(ctx, "test_backup", ExportOptions{Format: ExportFormatJSONL, OutputDir: dir})
+	assert.NoError(t, err)
+	assert.Len(t, exported, 1)
+	assert.Equal(t, int64(2), exported[0].Rows)
+	assert.Equal(t, int64(1), exported[0].DeletedRows)
+	assert.Equal(t, []string{filepath.Join(dir, "db1", "coll", "segment_11.jsonl")}, exported[0].Files)
+	data, err := os.ReadFile(exported[0].Files[0])
+	assert.NoError(t, err)
+	assert.Equal(t, `{"id":1,"name":"one","vector":[1,2],"meta":{"k":1},"tags":["a","b"],"flag":true,"score":null}`+"\n"+
+		`{"id":3,"name":"three","vector":[0.5,6],"meta":"s","tags":["a","b"],"flag":false,"score":null}`+"\n", string(data))
+	schema, err := os.ReadFile(filepath.Join(dir, "db1", "coll", exportSchemaFile))
+	assert.NoError(t, err)
+	assert.Contains(t, string(schema), `"name": "vector"`)
+
+	exported, err = b.ExportBackup(ctx, "test_backup", ExportOptions{Format: ExportFormatParquet, OutputDir: dir, Collections: []string{"db1.coll"}})
+	assert.NoError(t, err)
+	data, err = os.ReadFile(exported[0].Files[0])
+	assert.NoError(t, err)
+	f, err := parquet.Open(data)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), f.NumRows())
+	columns := f.Columns()
+	assert.Equal(t, parquet.Column{Name: "id", Type: parquet.Int64}, columns[0])
+	assert.True(t, columns[2].List)
+	names, err := f.ReadColumn(1)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]byte("one"), []byte("three")}, names)
+	meta, err := f.ReadColumn(3)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]byte(`{"k":1}`), []byte(`"s"`)}, meta)
+	scores, err := f.ReadColumn(6)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{nil, nil}, scores)
+
+	_, err = b.ExportBackup(ctx, "test_backup", ExportOptions{Format: ExportFormatParquet, OutputDir: dir, Collections: []string{"coll"}})
+	assert.ErrorContains(t, err, "collection default.coll is not in backup test_backup")
+	_, err = b.ExportBackup(ctx, "test_backup", ExportOptions{Format: "csv", OutputDir: dir})
+	assert.ErrorContains(t, err, "unsupported export format")
+	_, err = b.ExportBackup(ctx, "missing", ExportOptions{Format: ExportFormatParquet, OutputDir: dir})
+	assert.ErrorContains(t, err, "backup does not exist")
+}
+
+func TestParseDeleteLog(t *testing.T) {
+	pk, ts, err := parseDeleteLog([]byte(`{"pk":"abc","ts":449000000000000001,"pkType":21}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "abc", pk)
+	assert.Equal(t, uint64(449000000000000001), ts)
+	pk, ts, err = parseDeleteLog([]byte(`{"pk":9007199254740993,"ts":1}`))
+	assert.NoError(t, err)
+	assert.Equal(t, "9007199254740993", pk)
+	assert.Equal(t, uint64(1), ts)
+	pk, _, err = parseDeleteLog([]byte("a,b,5"))
+	assert.NoError(t, err)
+	assert.Equal(t, "a,b", pk)
+	_, _, err = parseDeleteLog([]byte("5"))
+	assert.Error(t, err)
+
+	_, err = readBinlogFile([]byte("not a binlog"))
+	assert.Error(t, err)
+}
+
+func TestSparseVectorJSON(t *testing.T) {
+	data := appendUint32(nil, 3)
+	data = append(data, float32Bytes(0.5)...)
+	data = appendUint32(data, 10)
+	data = append(data, float32Bytes(2)...)
+	vector, err := sparseVectorJSON(data)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"3":0.5,"10":2}`, string(vector))
+	_, err = sparseVectorJSON(data[:5])
+	assert.Error(t, err)
+}
@@ -0,0 +1,249 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// grpcReadMethods are the methods of MilvusBackupService callable by the read role, the others require admin
+var grpcReadMethods = map[string]bool{
+	"GetBackup":          true,
+	"ListBackups":        true,
+	"VerifyBackup":       true,
+	"EstimateBackup":     true,
+	"GetRestore":         true,
+	"GetJob":             true,
+	"WatchJob":           true,
+	"ListJobs":           true,
+	"Check":              true,
+	"CheckCompatibility": true,
+}
+
+// grpcHandlers implements MilvusBackupService by the BackupContext, the same as the http Handlers
+type grpcHandlers struct {
+	backuppb.UnimplementedMilvusBackupServiceServer
+
+	backupContext *BackupContext
+	// how often WatchJob checks the progress of the job
+	watchInterval time.Duration
+}
+
+func newGRPCHandlers(backupContext *BackupContext, watchInterval time.Duration) *grpcHandlers {
+	return &grpcHandlers{
+		backupContext: backupContext,
+		watchInterval: watchInterval,
+	}
+}
+
+func (h *grpcHandlers) CreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	return h.backupContext.CreateBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) GetBackup(ctx context.Context, request *backuppb.GetBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	return h.backupContext.GetBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) ListBackups(ctx context.Context, request *backuppb.ListBackupsRequest) (*backuppb.ListBackupsResponse, error) {
+	return h.backupContext.ListBackups(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) DeleteBackup(ctx context.Context, request *backuppb.DeleteBackupRequest) (*backuppb.DeleteBackupResponse, error) {
+	return h.backupContext.DeleteBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) PauseBackup(ctx context.Context, request *backuppb.PauseBackupRequest) (*backuppb.PauseBackupResponse, error) {
+	return h.backupContext.PauseBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) (*backuppb.BackupInfoResponse, error) {
+	return h.backupContext.ResumeBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) (*backuppb.PruneBackupsResponse, error) {
+	return h.backupContext.PruneBackups(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) GarbageCollect(ctx context.Context, request *backuppb.GarbageCollectRequest) (*backuppb.GarbageCollectResponse, error) {
+	return h.backupContext.GarbageCollect(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) VerifyBackup(ctx context.Context, request *backuppb.VerifyBackupRequest) (*backuppb.VerifyBackupResponse, error) {
+	return h.backupContext.VerifyBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) EstimateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) (*backuppb.EstimateBackupResponse, error) {
+	return h.backupContext.EstimateBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) RestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest) (*backuppb.RestoreBackupResponse, error) {
+	return h.backupContext.RestoreBackup(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) GetRestore(ctx context.Context, request *backuppb.GetRestoreStateRequest) (*backuppb.RestoreBackupResponse, error) {
+	return h.backupContext.GetRestore(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) GetJob(ctx context.Context, request *backuppb.GetJobRequest) (*backuppb.JobResponse, error) {
+	return h.backupContext.GetJob(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) CancelJob(ctx context.Context, request *backuppb.CancelJobRequest) (*backuppb.JobResponse, error) {
+	return h.backupContext.CancelJob(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) ListJobs(ctx context.Context, request *backuppb.ListJobsRequest) (*backuppb.ListJobsResponse, error) {
+	return h.backupContext.ListJobs(h.backupContext.ctx, request), nil
+}
+
+func (h *grpcHandlers) ResumeJob(ctx context.Context, request *backuppb.ResumeJobRequest) (*backuppb.JobResponse, error) {
+	return h.backupContext.ResumeJob(h.backupContext.ctx, request), nil
+}
+
+// WatchJob sends the job whenever its state or progress changes, the stream ends with the job,
+// or with the response if the job is not found
+func (h *grpcHandlers) WatchJob(request *backuppb.GetJobRequest, stream backuppb.MilvusBackupService_WatchJobServer) error {
+	ticker := time.NewTicker(h.watchInterval)
+	defer ticker.Stop()
+	var last *backuppb.JobResponse
+	for {
+		resp := h.backupContext.GetJob(stream.Context(), request)
+		if last == nil || resp.GetCode() != last.GetCode() || !proto.Equal(resp.GetData(), last.GetData()) {
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+			last = resp
+		}
+		if resp.GetCode() != backuppb.ResponseCode_Success || isJobEnded(resp.GetData().GetStateCode()) {
+			return nil
+		}
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func (h *grpcHandlers) Check(ctx context.Context, request *backuppb.CheckRequest) (*backuppb.CheckResponse, error) {
+	return h.backupContext.CheckConnections(h.backupContext.ctx), nil
+}
+
+func (h *grpcHandlers) CheckCompatibility(ctx context.Context, request *backuppb.CheckCompatibilityRequest) (*backuppb.CheckCompatibilityResponse, error) {
+	return h.backupContext.CheckCompatibility(h.backupContext.ctx, request), nil
+}
+
+func isJobEnded(state backuppb.JobStateCode) bool {
+	return state == backuppb.JobStateCode_JOB_SUCCESS ||
+		state == backuppb.JobStateCode_JOB_FAIL ||
+		state == backuppb.JobStateCode_JOB_CANCELED ||
+		state == backuppb.JobStateCode_JOB_INTERRUPTED
+}
+
+// grpcMethodRole returns the role required by the full method name, like /milvus.proto.backup.MilvusBackupService/ListBackups
+func grpcMethodRole(fullMethod string) string {
+	if grpcReadMethods[fullMethod[strings.LastIndex(fullMethod, "/")+1:]] {
+		return paramtable.AuthRoleRead
+	}
+	return paramtable.AuthRoleAdmin
+}
+
+// grpcAuthenticate authenticates the caller by the metadata, which carries the same credentials as
+// the headers of the http api: x-api-key or authorization of basic auth or a bearer token
+func grpcAuthenticate(ctx context.Context, auth *authenticator, role string) (principal, error) {
+	header := http.Header{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for key, values := range md {
+		for _, value := range values {
+			header.Add(key, value)
+		}
+	}
+	caller, err := auth.authenticate(&http.Request{Header: header})
+	if errors.Is(err, errForbidden) {
+		return caller, status.Error(codes.PermissionDenied, err.Error())
+	}
+	if err != nil {
+		return caller, status.Error(codes.Unauthenticated, err.Error())
+	}
+	if !caller.can(role) {
+		return caller, status.Error(codes.PermissionDenied, errForbidden.Error())
+	}
+	// the backups of tenants are only served by the http api
+	if caller.Tenant != "" {
+		return caller, status.Error(codes.PermissionDenied, errTenantForbidden.Error())
+	}
+	return caller, nil
+}
+
+// grpcUnaryAuthorize is the grpc counterpart of authorize, the callers of admin methods and the rejected ones are audit logged
+func grpcUnaryAuthorize(auth *authenticator) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if auth == nil {
+			return handler(ctx, req)
+		}
+		role := grpcMethodRole(info.FullMethod)
+		caller, err := grpcAuthenticate(ctx, auth, role)
+		if err != nil {
+			grpcAudit(ctx, caller, info.FullMethod, req, status.Code(err))
+			return nil, err
+		}
+		resp, err := handler(ctx, req)
+		if role == paramtable.AuthRoleAdmin {
+			grpcAudit(ctx, caller, info.FullMethod, req, status.Code(err))
+		}
+		return resp, err
+	}
+}
+
+// grpcStreamAuthorize authenticates the callers of the streaming methods, which only read
+func grpcStreamAuthorize(auth *authenticator) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if auth == nil {
+			return handler(srv, stream)
+		}
+		caller, err := grpcAuthenticate(stream.Context(), auth, grpcMethodRole(info.FullMethod))
+		if err != nil {
+			grpcAudit(stream.Context(), caller, info.FullMethod, nil, status.Code(err))
+			return err
+		}
+		return handler(srv, stream)
+	}
+}
+
+func grpcAudit(ctx context.Context, caller principal, method string, req interface{}, code codes.Code) {
+	var backupName, requestID, clientIP string
+	if r, ok := req.(interface{ GetBackupName() string }); ok {
+		backupName = r.GetBackupName()
+	}
+	if r, ok := req.(interface{ GetRequestId() string }); ok {
+		requestID = r.GetRequestId()
+	}
+	if p, ok := peer.FromContext(ctx); ok {
+		clientIP = p.Addr.String()
+	}
+	log.Info("audit",
+		zap.String("principal", caller.Name),
+		zap.String("role", caller.Role),
+		zap.String("auth", caller.Method),
+		zap.String("tenant", caller.Tenant),
+		zap.String("method", "grpc"),
+		zap.String("path", method),
+		zap.String("backup", backupName),
+		zap.String("requestId", requestID),
+		zap.String("clientIP", clientIP),
+		zap.String("code", code.String()))
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+func TestGRPCServer(t *testing.T) {
+	ctx := context.Background()
+	b := &BackupContext{ctx: ctx, restoreTasks: make(map[string]*backuppb.RestoreBackupTask)}
+	auth := newAuthenticator(paramtable.HTTPAuthConfig{
+		APIKeys: []paramtable.AuthCredentialConfig{
+			{Name: "ops", Secret: "admin-key", Role: paramtable.AuthRoleAdmin},
+			{Name: "dashboard", Secret: "read-key", Role: paramtable.AuthRoleRead},
+		},
+	})
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(grpcUnaryAuthorize(auth)),
+		grpc.ChainStreamInterceptor(grpcStreamAuthorize(auth)))
+	backuppb.RegisterMilvusBackupServiceServer(server, newGRPCHandlers(b, time.Millisecond))
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.DialContext(ctx, "bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return listener.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	assert.NoError(t, err)
+	defer conn.Close()
+	client := backuppb.NewMilvusBackupServiceClient(conn)
+	withKey := func(key string) context.Context {
+		return metadata.AppendToOutgoingContext(ctx, "x-api-key", key)
+	}
+
+	b.backupTasks.Store("backup1", &backuppb.BackupInfo{Id: "backup1", Size: 400, CopiedSize: 100, Progress: 25})
+	_, finish := b.startJob(ctx, "backup1", metrics.BackupTaskLabel, "b1", "", "")
+
+	_, err = client.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	resp, err := client.GetJob(withKey("read-key"), &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.NoError(t, err)
+	assert.Equal(t, int32(25), resp.GetData().GetProgress())
+	_, err = client.CancelJob(withKey("read-key"), &backuppb.CancelJobRequest{JobId: "backup1"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	stream, err := client.WatchJob(withKey("read-key"), &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.NoError(t, err)
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(25), resp.GetData().GetProgress())
+	// progress is streamed once it changes, until the job ends
+	b.backupTasks.Store("backup1", &backuppb.BackupInfo{Id: "backup1", Size: 400, CopiedSize: 200, Progress: 50})
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, int32(50), resp.GetData().GetProgress())
+	finish(nil)
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.JobStateCode_JOB_SUCCESS, resp.GetData().GetStateCode())
+	assert.Equal(t, int32(100), resp.GetData().GetProgress())
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+
+	stream, err = client.WatchJob(withKey("read-key"), &backuppb.GetJobRequest{JobId: "not-exist"})
+	assert.NoError(t, err)
+	resp, err = stream.Recv()
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, resp.GetCode())
+	_, err = stream.Recv()
+	assert.Equal(t, io.EOF, err)
+	stream, err = client.WatchJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// admin can call all the methods
+	resp, err = client.CancelJob(withKey("admin-key"), &backuppb.CancelJobRequest{JobId: "backup1"})
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+
+	assert.Equal(t, paramtable.AuthRoleRead, grpcMethodRole("/milvus.proto.backup.MilvusBackupService/ListBackups"))
+	assert.Equal(t, paramtable.AuthRoleAdmin, grpcMethodRole("/milvus.proto.backup.MilvusBackupService/RestoreBackup"))
+}
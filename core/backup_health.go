@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"time"
+)
+
+// timeout of each readiness check
+const readinessCheckTimeout = 5 * time.Second
+
+// names of the readiness checks
+const (
+	ReadinessMilvus        = "milvus"
+	ReadinessStorage       = "storage"
+	ReadinessBackupStorage = "backupStorage"
+)
+
+// BuildInfo is the version of the backup tool and how it is built
+type BuildInfo struct {
+	Version   string `json:"version"`
+	Commit    string `json:"commit"`
+	Date      string `json:"date"`
+	GoVersion string `json:"goVersion"`
+}
+
+var buildInfo = BuildInfo{Version: "dev", GoVersion: runtime.Version()}
+
+// SetBuildInfo sets the build info served by the version api, set by main from the flags of the build
+func SetBuildInfo(version, commit, date string) {
+	buildInfo.Version = version
+	buildInfo.Commit = commit
+	buildInfo.Date = date
+}
+
+// GetBuildInfo returns the build info of the backup tool
+func GetBuildInfo() BuildInfo {
+	return buildInfo
+}
+
+// ReadinessResult is the result of a readiness check
+type ReadinessResult struct {
+	Name       string `json:"name"`
+	Ready      bool   `json:"ready"`
+	Error      string `json:"error,omitempty"`
+	DurationMs int64  `json:"durationMs"`
+}
+
+// readinessCheck checks a dependency the backup server needs to work
+type readinessCheck struct {
+	name  string
+	check func(ctx context.Context) error
+}
+
+// readinessChecks returns the checks of milvus, the bucket of milvus and the backup bucket, which is only checked
+// apart if it is in backupStorage
+func (b *BackupContext) readinessChecks() []readinessCheck {
+	checks := []readinessCheck{
+		{name: ReadinessMilvus, check: b.pingMilvus},
+		{name: ReadinessStorage, check: func(ctx context.Context) error {
+			_, _, err := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, b.milvusRootPath+SEPERATOR, false)
+			return err
+		}},
+	}
+	if b.params.BackupStorageCfg.Enabled() || b.backupBucketName != b.milvusBucketName {
+		checks = append(checks, readinessCheck{name: ReadinessBackupStorage, check: func(ctx context.Context) error {
+			_, _, err := b.getBackupStorageClient().ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, false)
+			return err
+		}})
+	}
+	return checks
+}
+
+// CheckReadiness checks whether milvus is connected and the buckets are accessible, each check fails after
+// readinessCheckTimeout. The checks are run one by one, as the storage clients are created by the first use.
+func (b *BackupContext) CheckReadiness(ctx context.Context) []ReadinessResult {
+	return runReadinessChecks(ctx, b.readinessChecks())
+}
+
+func runReadinessChecks(ctx context.Context, checks []readinessCheck) []ReadinessResult {
+	results := make([]ReadinessResult, 0, len(checks))
+	for _, check := range checks {
+		start := time.Now()
+		err := runReadinessCheck(ctx, check)
+		result := ReadinessResult{Name: check.name, Ready: err == nil, DurationMs: time.Since(start).Milliseconds()}
+		if err != nil {
+			result.Error = err.Error()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// runReadinessCheck runs a check with timeout, a check panics if the client of a storage can't be created
+func runReadinessCheck(ctx context.Context, check readinessCheck) (err error) {
+	ctx, cancel := context.WithTimeout(ctx, readinessCheckTimeout)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				done <- fmt.Errorf("%v", r)
+			}
+		}()
+		done <- check.check(ctx)
+	}()
+	select {
+	case err = <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("%s is not checked in %s", check.name, readinessCheckTimeout)
+	}
+}
+
+// pingMilvus gets the version of milvus by a client of its own, so that a milvus down doesn't break the client of
+// backups, the client is connected again after a failure
+func (b *BackupContext) pingMilvus(ctx context.Context) error {
+	b.healthMu.Lock()
+	defer b.healthMu.Unlock()
+	if b.healthMilvusClient == nil {
+		client, err := CreateMilvusClient(ctx, b.params)
+		if err != nil {
+			return err
+		}
+		b.healthMilvusClient = client
+	}
+	if _, err := b.healthMilvusClient.GetVersion(ctx); err != nil {
+		b.healthMilvusClient.Close()
+		b.healthMilvusClient = nil
+		return err
+	}
+	return nil
+}
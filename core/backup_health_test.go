@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func TestRunReadinessChecks(t *testing.T) {
+	results := runReadinessChecks(context.Background(), []readinessCheck{
+		{name: "ok", check: func(ctx context.Context) error { return nil }},
+		{name: "down", check: func(ctx context.Context) error { return errors.New("connection refused") }},
+		{name: "panic", check: func(ctx context.Context) error { panic("fail to create storage client") }},
+	})
+	assert.Len(t, results, 3)
+	assert.True(t, results[0].Ready)
+	assert.Empty(t, results[0].Error)
+	assert.False(t, results[1].Ready)
+	assert.Equal(t, "connection refused", results[1].Error)
+	assert.False(t, results[2].Ready)
+	assert.Equal(t, "fail to create storage client", results[2].Error)
+}
+
+func TestReadinessChecks(t *testing.T) {
+	var storageClient storage.ChunkManager = &memoryChunkManager{files: map[string][]byte{"files/insert_log/1": []byte("log")}}
+	b := &BackupContext{storageClient: &storageClient, milvusBucketName: "a-bucket", milvusRootPath: "files",
+		backupBucketName: "a-bucket", backupRootPath: "backup"}
+	checks := b.readinessChecks()
+	assert.Len(t, checks, 2)
+	assert.Equal(t, ReadinessMilvus, checks[0].name)
+	// the backup bucket is the bucket of milvus
+	results := runReadinessChecks(context.Background(), checks[1:])
+	assert.Equal(t, ReadinessStorage, results[0].Name)
+	assert.True(t, results[0].Ready)
+
+	b.backupBucketName = "backup-bucket"
+	checks = b.readinessChecks()
+	assert.Len(t, checks, 3)
+	assert.Equal(t, ReadinessBackupStorage, checks[2].name)
+}
+
+func TestHealthHandlers(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET(HEALTHZ_API, wrapHandler(handleHealthz))
+	router.GET(VERSION_API, wrapHandler(handleVersion))
+	ready := true
+	router.GET(READYZ_API, func(c *gin.Context) {
+		writeReadiness(c, []ReadinessResult{{Name: ReadinessMilvus, Ready: true}, {Name: ReadinessStorage, Ready: ready}})
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, HEALTHZ_API, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	SetBuildInfo("v1.2.3", "abcdef", "2024-01-02")
+	defer SetBuildInfo("dev", "", "")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, VERSION_API, nil))
+	var info BuildInfo
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &info))
+	assert.Equal(t, "v1.2.3", info.Version)
+	assert.Equal(t, "abcdef", info.Commit)
+	assert.NotEmpty(t, info.GoVersion)
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, READYZ_API, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	ready = false
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, READYZ_API, nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+	var body struct {
+		Ready  bool              `json:"ready"`
+		Checks []ReadinessResult `json:"checks"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &body))
+	assert.False(t, body.Ready)
+	assert.Len(t, body.Checks, 2)
+}
@@ -4,20 +4,64 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	jsoniter "github.com/json-iterator/go"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
+	"github.com/zilliztech/milvus-backup/core/paramtable"
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
 	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/common"
 	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+	"github.com/zilliztech/milvus-backup/internal/trace"
 )
 
+const (
+	// FlushPolicyWait flushes the collections and waits until the data is persisted
+	FlushPolicyWait = "wait"
+	// FlushPolicySkip doesn't flush the collections, only the data already persisted is backed up
+	FlushPolicySkip = "skip"
+	// FlushPolicyTimeout flushes the collections and waits at most flush timeout, the data already
+	// persisted is backed up if the flush times out
+	FlushPolicyTimeout = "timeout"
+)
+
+var validFlushPolicies = map[string]bool{"": true, FlushPolicyWait: true, FlushPolicySkip: true, FlushPolicyTimeout: true}
+
+// flush states recorded in the collection backup
+const (
+	FlushStateFlushed = "flushed"
+	FlushStateSkipped = "skipped"
+	FlushStateTimeout = "timeout"
+)
+
+// backupFlushPolicy returns the flush policy of the request, force means skip if the policy is not set
+func backupFlushPolicy(request *backuppb.CreateBackupRequest) string {
+	if request.GetFlushPolicy() != "" {
+		return request.GetFlushPolicy()
+	}
+	if request.GetForce() {
+		return FlushPolicySkip
+	}
+	if request.GetFlushTimeout() > 0 {
+		return FlushPolicyTimeout
+	}
+	return FlushPolicyWait
+}
+
 func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) *backuppb.BackupInfoResponse {
 	if request.GetRequestId() == "" {
 		request.RequestId = utils.UUID()
@@ -29,7 +73,24 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		zap.String("databaseCollections", utils.GetCreateDBCollections(request)),
 		zap.Bool("async", request.GetAsync()),
 		zap.Bool("force", request.GetForce()),
-		zap.Bool("metaOnly", request.GetMetaOnly()))
+		zap.String("flushPolicy", request.GetFlushPolicy()),
+		zap.Int32("flushTimeout", request.GetFlushTimeout()),
+		zap.Bool("metaOnly", request.GetMetaOnly()),
+		zap.Bool("incremental", request.GetIncremental()),
+		zap.String("baseBackupName", request.GetBaseBackupName()),
+		zap.String("compression", request.GetCompression()),
+		zap.Int32("collectionParallelism", request.GetCollectionParallelism()),
+		zap.Int32("copyParallelism", request.GetCopyParallelism()),
+		zap.Bool("rbac", request.GetRbac()),
+		zap.Bool("etcdSnapshot", request.GetEtcdSnapshot()),
+		zap.Bool("allowPartial", request.GetAllowPartial()),
+		zap.String("nameTemplate", request.GetNameTemplate()),
+		zap.Any("labels", request.GetLabels()),
+		zap.Bool("forceUnlock", request.GetForceUnlock()),
+		zap.String("collectionRegex", request.GetCollectionRegex()),
+		zap.Any("partitions", request.GetPartitions()),
+		zap.String("sseType", request.GetSseType()),
+		zap.String("sseKmsKeyId", request.GetSseKmsKeyId()))
 
 	resp := &backuppb.BackupInfoResponse{
 		RequestId: request.GetRequestId(),
@@ -44,12 +105,75 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		}
 	}
 
+	if request.GetCollectionParallelism() < 0 || request.GetCopyParallelism() < 0 {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "parallelism should not be negative"
+		return resp
+	}
+
+	if !validFlushPolicies[request.GetFlushPolicy()] {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("illegal flush policy %s, support wait, skip and timeout", request.GetFlushPolicy())
+		return resp
+	}
+	if request.GetFlushTimeout() < 0 {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "flush timeout should not be negative"
+		return resp
+	}
+	if backupFlushPolicy(request) == FlushPolicyTimeout && request.GetFlushTimeout() == 0 {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "flush timeout should be set with flush policy timeout"
+		return resp
+	}
+
+	if request.GetResume() {
+		return b.resumeCreateBackup(ctx, request)
+	}
+
+	if err := validateCollectionPatterns(request); err != nil {
+		log.Error("illegal collection pattern", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+	if err := validateBackupLabels(request.GetLabels()); err != nil {
+		log.Error("illegal backup labels", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+	// a context limited to some databases rejects the backups out of them before submitting, the rbac and the etcd
+	// of milvus are out of any database
+	if len(b.databases) > 0 {
+		if request.GetRbac() || request.GetEtcdSnapshot() {
+			resp.Code = backuppb.ResponseCode_No_Permission
+			resp.Msg = fmt.Sprintf("%s: rbac and etcd snapshot", errDatabaseOutOfScope.Error())
+			return resp
+		}
+		if _, err := b.parseBackupCollections(request); err != nil {
+			log.Error("parse backup collections from request failed", zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			if errors.Is(err, errDatabaseOutOfScope) {
+				resp.Code = backuppb.ResponseCode_No_Permission
+			}
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
 	// backup name validate
 	if request.GetBackupName() == "" {
-		request.BackupName = "backup_" + fmt.Sprint(time.Now().UTC().Format("2006_01_02_15_04_05_")) + fmt.Sprint(time.Now().Nanosecond())
-	}
-	if request.GetBackupName() != "" {
-		exist, err := b.getStorageClient().Exist(b.ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+request.GetBackupName())
+		name, err := b.generateBackupName(b.ctx, request.GetNameTemplate())
+		if err != nil {
+			log.Error("fail to generate backup name", zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		request.BackupName = name
+	} else {
+		exist, err := b.getBackupStorageClient().Exist(b.ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+request.GetBackupName())
 		if err != nil {
 			errMsg := fmt.Sprintf("fail to check whether exist backup with name: %s", request.GetBackupName())
 			log.Error(errMsg, zap.Error(err))
@@ -65,13 +189,82 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 			return resp
 		}
 	}
-	err := utils.ValidateType(request.GetBackupName(), BACKUP_NAME)
+	err := utils.ValidateBackupName(request.GetBackupName(), BACKUP_NAME)
 	if err != nil {
 		log.Error("illegal backup name", zap.Error(err))
 		resp.Code = backuppb.ResponseCode_Parameter_Error
 		resp.Msg = err.Error()
 		return resp
 	}
+	if request.GetBackupName() == DEDUP_DIR {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("backup name %s is reserved", DEDUP_DIR)
+		return resp
+	}
+
+	// base backup validate
+	if request.GetIncremental() {
+		if request.GetBaseBackupName() == "" {
+			errMsg := "base backup name is required for incremental backup"
+			log.Error(errMsg)
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = errMsg
+			return resp
+		}
+		exist, err := b.getBackupStorageClient().Exist(b.ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, request.GetBaseBackupName()))
+		if err != nil {
+			errMsg := fmt.Sprintf("fail to check whether exist base backup with name: %s", request.GetBaseBackupName())
+			log.Error(errMsg, zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = errMsg + "/n" + err.Error()
+			return resp
+		}
+		if !exist {
+			errMsg := fmt.Sprintf("base backup not exist with the name: %s", request.GetBaseBackupName())
+			log.Error(errMsg)
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = errMsg
+			return resp
+		}
+	}
+
+	// compression validate
+	compression := request.GetCompression()
+	if compression == "" {
+		compression = b.params.BackupCfg.Compression
+	}
+	if err := utils.ValidateCompression(compression); err != nil {
+		log.Error("illegal compression", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	// object lock is only supported by s3
+	backupStorageType := b.backupStorageType()
+	if b.params.BackupCfg.ObjectLockMode != "" && !paramtable.IsS3Compatible(backupStorageType) {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("object lock is not supported by backup storage %s", backupStorageType)
+		return resp
+	}
+
+	// the key is checked before copying, a backup shouldn't fail after copying for long for a kms permission
+	sse, err := b.requestServerSideEncryption(request)
+	if err == nil {
+		err = validateServerSideEncryption(backupStorageType, sse)
+	}
+	if err != nil {
+		log.Error("illegal server side encryption", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+	if err := b.checkServerSideEncryption(ctx, request.GetBackupName(), sse); err != nil {
+		log.Error("fail to access the key of server side encryption", zap.String("sseType", sse.Type), zap.String("kmsKeyId", sse.KMSKeyID), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = fmt.Sprintf("fail to access the key of server side encryption: %s", err.Error())
+		return resp
+	}
 
 	var name string = request.BackupName
 
@@ -89,21 +282,136 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		StartTime:     time.Now().UnixNano() / int64(time.Millisecond),
 		Name:          name,
 		MilvusVersion: milvusVersion,
+		Compression:   compression,
+		Encrypted:     b.encryptionKey != nil,
+		Labels:        request.GetLabels(),
+	}
+	if request.GetIncremental() {
+		backup.BaseBackupName = request.GetBaseBackupName()
+	}
+	if b.params.BackupCfg.ObjectLockMode != "" {
+		backup.ObjectLockMode = b.params.BackupCfg.ObjectLockMode
+		backup.ObjectLockRetainUntil = time.Now().AddDate(0, 0, b.params.BackupCfg.ObjectLockRetentionDays).Unix()
+	}
+	backup.SseType = sse.Type
+	backup.SseKmsKeyId = sse.KMSKeyID
+	backup.SseCustomerKeyMd5 = sse.CustomerKeyMD5()
+	return b.submitCreateBackup(ctx, request, backup)
+}
+
+// resumeCreateBackup continues an interrupted backup from the checkpoint written during its execution
+func (b *BackupContext) resumeCreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) *backuppb.BackupInfoResponse {
+	resp := &backuppb.BackupInfoResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "backup name is required to resume backup"
+		return resp
+	}
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+request.GetBackupName())
+	if err != nil {
+		log.Error("fail to read backup checkpoint", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if backup == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = fmt.Sprintf("no checkpoint of backup: %s", request.GetBackupName())
+		return resp
+	}
+	if isFinishedBackup(backup) {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("backup %s has completed, no need to resume", request.GetBackupName())
+		return resp
+	}
+	if backup.GetEncrypted() != (b.encryptionKey != nil) {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("backup %s is encrypted: %t, but encryption is enabled: %t", request.GetBackupName(), backup.GetEncrypted(), b.encryptionKey != nil)
+		return resp
+	}
+
+	copied := 0
+	total := 0
+	for _, collection := range backup.GetCollectionBackups() {
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				total++
+				if segment.GetBackuped() {
+					copied++
+				}
+			}
+		}
+	}
+	log.Info("resume backup from checkpoint",
+		zap.String("backupName", backup.GetName()),
+		zap.String("state", backup.GetStateCode().String()),
+		zap.Int("copiedSegmentNum", copied),
+		zap.Int("segmentNum", total))
+
+	if err := b.removePausedFile(ctx, backup.GetName()); err != nil {
+		log.Error("fail to remove paused file", zap.String("backupName", backup.GetName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	backup.Id = request.GetRequestId()
+	backup.StateCode = backuppb.BackupTaskStateCode_BACKUP_INITIAL
+	backup.ErrorMessage = ""
+	return b.submitCreateBackup(ctx, request, backup)
+}
+
+func (b *BackupContext) submitCreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest, backup *backuppb.BackupInfo) *backuppb.BackupInfoResponse {
+	resp := &backuppb.BackupInfoResponse{
+		RequestId: request.GetRequestId(),
+		JobId:     backup.GetId(),
+	}
+	ctx, unlock, err := b.acquireBackupLocks(ctx, backup.GetName(), request.GetForceUnlock())
+	if err != nil {
+		log.Error("fail to acquire backup lock", zap.String("backupName", backup.GetName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
 	}
+	// the backup may be created by another process before the lock is acquired
+	if !request.GetResume() {
+		exist, err := b.getBackupStorageClient().Exist(b.ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, backup.GetName()))
+		if err != nil || exist {
+			unlock()
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("backup already exist with the name: %s", backup.GetName())
+			if err != nil {
+				resp.Msg = fmt.Sprintf("fail to check whether exist backup with name: %s, %s", backup.GetName(), err.Error())
+			}
+			return resp
+		}
+	}
+
 	b.backupTasks.Store(request.GetRequestId(), backup)
-	b.backupNameIdDict.Store(name, request.GetRequestId())
+	b.backupNameIdDict.Store(backup.GetName(), request.GetRequestId())
 
+	ctx, finishJob := b.startJob(ctx, backup.GetId(), metrics.BackupTaskLabel, backup.GetName(), "", "")
 	if request.Async {
-		go b.executeCreateBackup(ctx, request, backup)
+		go func() {
+			_, err := b.runCreateBackup(ctx, request, backup)
+			unlock()
+			finishJob(err)
+		}()
 		asyncResp := &backuppb.BackupInfoResponse{
 			RequestId: request.GetRequestId(),
 			Code:      backuppb.ResponseCode_Success,
 			Msg:       "create backup is executing asynchronously",
 			Data:      backup,
+			JobId:     backup.GetId(),
 		}
 		return asyncResp
 	} else {
-		task, err := b.executeCreateBackup(ctx, request, backup)
+		task, err := b.runCreateBackup(ctx, request, backup)
+		unlock()
+		finishJob(err)
 		resp.Data = task
 		if err != nil {
 			resp.Code = backuppb.ResponseCode_Fail
@@ -111,11 +419,58 @@ func (b *BackupContext) CreateBackup(ctx context.Context, request *backuppb.Crea
 		} else {
 			resp.Code = backuppb.ResponseCode_Success
 			resp.Msg = "success"
+			if mismatches := BackupRowCountMismatches(task); len(mismatches) > 0 {
+				resp.Msg = "success, row counts mismatch: " + strings.Join(mismatches, "; ")
+			}
+			if findings := BackupBinlogScanFindings(task); len(findings) > 0 {
+				resp.Msg += ", binlog scan: " + strings.Join(findings, "; ")
+			}
 		}
 		return resp
 	}
 }
 
+// runCreateBackup executes the backup, records the result in metrics and notifies the webhooks.
+// A paused backup is not finished, it is neither recorded nor notified.
+func (b *BackupContext) runCreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest, backupInfo *backuppb.BackupInfo) (*backuppb.BackupInfo, error) {
+	start := time.Now()
+	ctx, span := trace.Start(ctx, "CreateBackup",
+		attribute.String("backup.name", backupInfo.GetName()),
+		attribute.String("backup.request_id", request.GetRequestId()))
+	stopWatch := b.watchBackup(backupInfo, start)
+	ctx, stopPause := b.watchPause(ctx, backupInfo)
+	recorder := newRunRecorder()
+	task, err := b.executeCreateBackup(withRunRecorder(ctx, recorder), request, backupInfo)
+	paused := stopPause()
+	stopWatch()
+	if paused && err != nil && b.pauseCreateBackup(task) == nil {
+		err = fmt.Errorf("backup %s is paused", task.GetName())
+		b.writeBackupRunSummary(recorder, task)
+		trace.End(span, err)
+		return task, err
+	}
+	b.writeBackupRunSummary(recorder, task)
+	if err := b.removePausedFile(b.ctx, backupInfo.GetName()); err != nil {
+		log.Warn("fail to remove paused file", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+	}
+	trace.End(span, err)
+	metrics.ObserveTask(metrics.BackupTaskLabel, start, err)
+	b.notifyBackup(task, start, err)
+	return task, err
+}
+
+// writeBackupRunSummary writes the summary of the backup run beside its meta, a backup failed before any meta is
+// written only has the summary logged
+func (b *BackupContext) writeBackupRunSummary(recorder *runRecorder, backupInfo *backuppb.BackupInfo) {
+	summary := backupRunSummary(recorder, backupInfo)
+	exist, err := b.getBackupStorageClient().Exist(b.ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, backupInfo.GetName()))
+	if err != nil || !exist {
+		logRunSummary(summary)
+		return
+	}
+	b.writeRunSummary(withBackupObjectLock(b.ctx, backupInfo), b.backupBucketName, b.backupRootPath+SEPERATOR+backupInfo.GetName(), summary)
+}
+
 func (b *BackupContext) refreshBackupMeta(id string, backupInfo *backuppb.BackupInfo, leveledBackupInfo *LeveledBackupInfo) (*backuppb.BackupInfo, error) {
 	log.Debug("call refreshBackupMeta", zap.String("id", id))
 	backup, err := levelToTree(leveledBackupInfo)
@@ -141,10 +496,15 @@ type collectionStruct struct {
 // For backward compatibility：
 //   1，parse dbCollections first,
 //   2，if dbCollections not set, use collectionNames
+// Collection names can be wildcard patterns like prod_*, and collectionRegex selects collections of all
+// databases by regex. Patterns are matched against the collections in milvus when the backup is executed.
+// A context limited to some databases, like the context of a tenant, takes them as all databases and rejects
+// the collections of other databases.
 func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupRequest) ([]collectionStruct, error) {
 	log.Debug("Request collection names",
 		zap.Strings("request_collection_names", request.GetCollectionNames()),
 		zap.String("request_db_collections", utils.GetCreateDBCollections(request)),
+		zap.String("request_collection_regex", request.GetCollectionRegex()),
 		zap.Int("length", len(request.GetCollectionNames())))
 	var toBackupCollections []collectionStruct
 
@@ -158,6 +518,9 @@ func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupReq
 			return nil, err
 		}
 		for db, collections := range dbCollections {
+			if err := b.checkDatabaseScope(db); err != nil {
+				return nil, err
+			}
 			if len(collections) == 0 {
 				collections, err := b.getMilvusClient().ListCollections(b.ctx, db)
 				if err != nil {
@@ -170,39 +533,67 @@ func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupReq
 				}
 			} else {
 				for _, coll := range collections {
+					if isCollectionPattern(coll) {
+						matched, err := b.matchCollections(db, coll)
+						if err != nil {
+							return nil, err
+						}
+						toBackupCollections = append(toBackupCollections, matched...)
+						continue
+					}
 					toBackupCollections = append(toBackupCollections, collectionStruct{db, coll})
 				}
 			}
 		}
 		log.Debug("Parsed backup collections from request.db_collections", zap.Int("length", len(toBackupCollections)))
-		return toBackupCollections, nil
+		return dedupCollections(toBackupCollections), nil
+	}
+
+	collectionNames := request.GetCollectionNames()
+	if len(collectionNames) == 0 && request.GetCollectionRegex() == "" {
+		// only backup the collections of the selected partitions
+		for collectionName := range request.GetPartitions() {
+			collectionNames = append(collectionNames, collectionName)
+		}
+		sort.Strings(collectionNames)
 	}
 
-	if request.GetCollectionNames() == nil || len(request.GetCollectionNames()) == 0 {
-		dbs, err := b.getMilvusClient().ListDatabases(b.ctx)
+	if len(collectionNames) == 0 && request.GetCollectionRegex() == "" {
+		dbs, err := b.listScopeDatabases()
 		if err != nil {
-			log.Error("fail in ListDatabases", zap.Error(err))
 			return nil, err
 		}
 		for _, db := range dbs {
-			collections, err := b.getMilvusClient().ListCollections(b.ctx, db.Name)
+			collections, err := b.getMilvusClient().ListCollections(b.ctx, db)
 			if err != nil {
 				log.Error("fail in ListCollections", zap.Error(err))
 				return nil, err
 			}
 			for _, coll := range collections {
-				toBackupCollections = append(toBackupCollections, collectionStruct{db.Name, coll.Name})
+				toBackupCollections = append(toBackupCollections, collectionStruct{db, coll.Name})
 			}
 		}
 		log.Debug(fmt.Sprintf("List %v collections", len(toBackupCollections)))
 	} else {
-		for _, collectionName := range request.GetCollectionNames() {
+		for _, collectionName := range collectionNames {
 			var dbName = "default"
 			if strings.Contains(collectionName, ".") {
 				splits := strings.Split(collectionName, ".")
 				dbName = splits[0]
 				collectionName = splits[1]
 			}
+			if err := b.checkDatabaseScope(dbName); err != nil {
+				return nil, err
+			}
+
+			if isCollectionPattern(collectionName) {
+				matched, err := b.matchCollections(dbName, collectionName)
+				if err != nil {
+					return nil, err
+				}
+				toBackupCollections = append(toBackupCollections, matched...)
+				continue
+			}
 
 			exist, err := b.getMilvusClient().HasCollection(b.ctx, dbName, collectionName)
 			if err != nil {
@@ -216,33 +607,187 @@ func (b *BackupContext) parseBackupCollections(request *backuppb.CreateBackupReq
 			}
 			toBackupCollections = append(toBackupCollections, collectionStruct{dbName, collectionName})
 		}
+
+		if request.GetCollectionRegex() != "" {
+			matched, err := b.matchCollectionsByRegex(request.GetCollectionRegex())
+			if err != nil {
+				return nil, err
+			}
+			toBackupCollections = append(toBackupCollections, matched...)
+		}
+	}
+
+	return dedupCollections(toBackupCollections), nil
+}
+
+// validateCollectionPatterns checks the syntax of the wildcard patterns in collection names and the regex
+func validateCollectionPatterns(request *backuppb.CreateBackupRequest) error {
+	for _, collectionName := range request.GetCollectionNames() {
+		if isCollectionPattern(collectionName) {
+			if _, err := path.Match(collectionName, ""); err != nil {
+				return fmt.Errorf("illegal collection pattern %s: %w", collectionName, err)
+			}
+		}
+	}
+	if request.GetCollectionRegex() != "" {
+		if _, err := regexp.Compile(request.GetCollectionRegex()); err != nil {
+			return fmt.Errorf("illegal collection regex %s: %w", request.GetCollectionRegex(), err)
+		}
+	}
+	return nil
+}
+
+// isCollectionPattern returns whether the collection name is a wildcard pattern
+func isCollectionPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+// matchCollections lists the collections in db matching the wildcard pattern
+func (b *BackupContext) matchCollections(db string, pattern string) ([]collectionStruct, error) {
+	collections, err := b.getMilvusClient().ListCollections(b.ctx, db)
+	if err != nil {
+		log.Error("fail in ListCollections", zap.Error(err))
+		return nil, err
+	}
+	matched := make([]collectionStruct, 0)
+	for _, coll := range collections {
+		ok, err := path.Match(pattern, coll.Name)
+		if err != nil {
+			log.Error("illegal collection pattern", zap.String("pattern", pattern), zap.Error(err))
+			return nil, err
+		}
+		if ok {
+			matched = append(matched, collectionStruct{db, coll.Name})
+		}
+	}
+	if len(matched) == 0 {
+		log.Warn("no collection matches the pattern", zap.String("db", db), zap.String("pattern", pattern))
+	}
+	return matched, nil
+}
+
+// matchCollectionsByRegex lists the collections of all databases whose names match the regex
+func (b *BackupContext) matchCollectionsByRegex(expr string) ([]collectionStruct, error) {
+	re, err := regexp.Compile(expr)
+	if err != nil {
+		log.Error("illegal collection regex", zap.String("regex", expr), zap.Error(err))
+		return nil, err
+	}
+	dbs, err := b.listScopeDatabases()
+	if err != nil {
+		return nil, err
+	}
+	matched := make([]collectionStruct, 0)
+	for _, db := range dbs {
+		collections, err := b.getMilvusClient().ListCollections(b.ctx, db)
+		if err != nil {
+			log.Error("fail in ListCollections", zap.Error(err))
+			return nil, err
+		}
+		for _, coll := range collections {
+			if re.MatchString(coll.Name) {
+				matched = append(matched, collectionStruct{db, coll.Name})
+			}
+		}
+	}
+	if len(matched) == 0 {
+		log.Warn("no collection matches the regex", zap.String("regex", expr))
+	}
+	return matched, nil
+}
+
+// ListCollectionNames lists the collections of all databases in milvus, collections not in the default db are named db.collection
+func (b *BackupContext) ListCollectionNames(ctx context.Context) ([]string, error) {
+	collections, err := b.matchCollectionsByRegex("")
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(collections))
+	for _, coll := range collections {
+		if coll.db == "" || coll.db == "default" {
+			names = append(names, coll.collectionName)
+		} else {
+			names = append(names, coll.db+"."+coll.collectionName)
+		}
+	}
+	return names, nil
+}
+
+// requestPartitions returns the partitions selected for the collection, nil means all partitions.
+// Partitions are keyed by collection name or db.collection_name, a collection name without db means the default db.
+func requestPartitions(partitions map[string]*backuppb.PartitionNames, db string, collectionName string) []string {
+	if names, ok := partitions[db+"."+collectionName]; ok {
+		return names.GetNames()
+	}
+	if db == "" || db == "default" {
+		if names, ok := partitions[collectionName]; ok {
+			return names.GetNames()
+		}
 	}
+	return nil
+}
 
-	return toBackupCollections, nil
+// dedupCollections removes the collections selected more than once, like by both a name and a pattern
+func dedupCollections(collections []collectionStruct) []collectionStruct {
+	seen := make(map[collectionStruct]bool, len(collections))
+	result := make([]collectionStruct, 0, len(collections))
+	for _, coll := range collections {
+		if seen[coll] {
+			continue
+		}
+		seen[coll] = true
+		result = append(result, coll)
+	}
+	return result
 }
 
-func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo *backuppb.BackupInfo, collection collectionStruct, force bool) error {
+// backupCollectionPrepare builds the meta of the collection and the segments to copy,
+// only the partitions in partitionNames are backed up if it is not empty,
+// the collection is flushed before backup according to flushPolicy
+func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo *backuppb.BackupInfo, collection collectionStruct, flushPolicy string, flushTimeout time.Duration, partitionNames []string) (err error) {
+	ctx, span := trace.Start(ctx, "backupCollectionPrepare",
+		attribute.String("collection.db", collection.db),
+		attribute.String("collection.name", collection.collectionName))
+	defer func() { trace.End(span, err) }()
 	log.Info("start backup collection", zap.String("db", collection.db), zap.String("collection", collection.collectionName))
 	// list collection result is not complete
-	completeCollection, err := b.getMilvusClient().DescribeCollection(b.ctx, collection.db, collection.collectionName)
+	completeCollection, err := b.getMilvusClient().DescribeCollection(ctx, collection.db, collection.collectionName)
 	if err != nil {
 		log.Error("fail in DescribeCollection", zap.Error(err))
 		return err
 	}
+	// sdk doesn't describe the functions of the schema and which fields are their output
+	collectionInfo, err := b.getMilvusClient().DescribeCollectionInfo(ctx, collection.db, collection.collectionName)
+	if err != nil {
+		log.Error("fail to describe aliases, properties and functions of collection", zap.Error(err))
+		return err
+	}
+	functions, err := readSchemaFunctions(collectionInfo.GetSchema())
+	if err != nil {
+		log.Error("fail to read functions of collection", zap.Error(err))
+		return err
+	}
+	functionOutputs := make(map[int64]bool)
+	for _, field := range collectionInfo.GetSchema().GetFields() {
+		if readFunctionOutput(field) {
+			functionOutputs[field.GetFieldID()] = true
+		}
+	}
 	fields := make([]*backuppb.FieldSchema, 0)
 	for _, field := range completeCollection.Schema.Fields {
 		fields = append(fields, &backuppb.FieldSchema{
-			FieldID:        field.ID,
-			Name:           field.Name,
-			IsPrimaryKey:   field.PrimaryKey,
-			Description:    field.Description,
-			AutoID:         field.AutoID,
-			DataType:       backuppb.DataType(field.DataType),
-			TypeParams:     utils.MapToKVPair(field.TypeParams),
-			IndexParams:    utils.MapToKVPair(field.IndexParams),
-			IsDynamic:      field.IsDynamic,
-			IsPartitionKey: field.IsPartitionKey,
-			ElementType:    backuppb.DataType(field.ElementType),
+			FieldID:          field.ID,
+			Name:             field.Name,
+			IsPrimaryKey:     field.PrimaryKey,
+			Description:      field.Description,
+			AutoID:           field.AutoID,
+			DataType:         backuppb.DataType(field.DataType),
+			TypeParams:       utils.MapToKVPair(field.TypeParams),
+			IndexParams:      utils.MapToKVPair(field.IndexParams),
+			IsDynamic:        field.IsDynamic,
+			IsPartitionKey:   field.IsPartitionKey,
+			ElementType:      backuppb.DataType(field.ElementType),
+			IsFunctionOutput: functionOutputs[field.ID],
 		})
 	}
 	schema := &backuppb.CollectionSchema{
@@ -251,6 +796,7 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		AutoID:             completeCollection.Schema.AutoID,
 		Fields:             fields,
 		EnableDynamicField: completeCollection.Schema.EnableDynamicField,
+		Functions:          functions,
 	}
 
 	indexInfos := make([]*backuppb.IndexInfo, 0)
@@ -261,7 +807,7 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		//if field.DataType != entity.FieldTypeBinaryVector && field.DataType != entity.FieldTypeFloatVector {
 		//	continue
 		//}
-		fieldIndex, err := b.getMilvusClient().DescribeIndex(b.ctx, collection.db, completeCollection.Name, field.Name)
+		fieldIndex, err := b.getMilvusClient().DescribeIndex(ctx, collection.db, completeCollection.Name, field.Name)
 		if err != nil {
 			if strings.Contains(err.Error(), "index not found") ||
 				strings.HasPrefix(err.Error(), "index doesn't exist") {
@@ -283,11 +829,13 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 			if _, ok := indexDict[index.Name()]; ok {
 				continue
 			} else {
+				mmapEnabled, _ := strconv.ParseBool(index.Params()[MMAP_ENABLED_KEY])
 				indexInfo := &backuppb.IndexInfo{
-					FieldName: index.FieldName(),
-					IndexName: index.Name(),
-					IndexType: string(index.IndexType()),
-					Params:    index.Params(),
+					FieldName:   index.FieldName(),
+					IndexName:   index.Name(),
+					IndexType:   string(index.IndexType()),
+					Params:      index.Params(),
+					MmapEnabled: mmapEnabled,
 				}
 				indexInfos = append(indexInfos, indexInfo)
 				indexDict[index.Name()] = indexInfo
@@ -295,28 +843,51 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		}
 	}
 
+	aliases := collectionInfo.GetAliases()
+	properties := make([]*backuppb.KeyValuePair, 0, len(collectionInfo.GetProperties()))
+	for _, property := range collectionInfo.GetProperties() {
+		properties = append(properties, &backuppb.KeyValuePair{Key: property.GetKey(), Value: property.GetValue()})
+	}
+	log.Info("collection aliases and properties",
+		zap.String("collection_name", completeCollection.Name),
+		zap.Strings("aliases", aliases),
+		zap.Any("properties", properties))
+
 	collectionBackup := &backuppb.CollectionBackupInfo{
-		Id:               utils.UUID(),
-		StateCode:        backuppb.BackupTaskStateCode_BACKUP_INITIAL,
-		StartTime:        time.Now().Unix(),
-		CollectionId:     completeCollection.ID,
-		DbName:           collection.db, // todo currently db_name is not used in many places
-		CollectionName:   completeCollection.Name,
-		Schema:           schema,
-		ShardsNum:        completeCollection.ShardNum,
-		ConsistencyLevel: backuppb.ConsistencyLevel(completeCollection.ConsistencyLevel),
-		HasIndex:         len(indexInfos) > 0,
-		IndexInfos:       indexInfos,
+		Id:                 utils.UUID(),
+		StateCode:          backuppb.BackupTaskStateCode_BACKUP_INITIAL,
+		StartTime:          time.Now().Unix(),
+		CollectionId:       completeCollection.ID,
+		DbName:             collection.db, // todo currently db_name is not used in many places
+		CollectionName:     completeCollection.Name,
+		Schema:             schema,
+		ShardsNum:          completeCollection.ShardNum,
+		ConsistencyLevel:   backuppb.ConsistencyLevel(completeCollection.ConsistencyLevel),
+		HasIndex:           len(indexInfos) > 0,
+		IndexInfos:         indexInfos,
+		Aliases:            aliases,
+		Properties:         properties,
+		BackupPathTemplate: b.params.MinioCfg.BackupPathTemplate,
 	}
+	// collections are prepared concurrently
+	b.progressMu.Lock()
 	backupInfo.CollectionBackups = append(backupInfo.CollectionBackups, collectionBackup)
+	b.progressMu.Unlock()
 
 	b.refreshBackupCache(backupInfo)
 	partitionBackupInfos := make([]*backuppb.PartitionBackupInfo, 0)
-	partitions, err := b.getMilvusClient().ShowPartitions(b.ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
+	partitions, err := b.getMilvusClient().ShowPartitions(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
 	if err != nil {
 		log.Error("fail to ShowPartitions", zap.Error(err))
 		return err
 	}
+	if len(partitionNames) > 0 {
+		partitions, err = filterPartitions(collectionBackup, partitions, partitionNames)
+		if err != nil {
+			log.Error("fail to select partitions", zap.Strings("partitions", partitionNames), zap.Error(err))
+			return err
+		}
+	}
 
 	// use GetLoadingProgress currently, GetLoadState is a new interface @20230104  milvus pr#21515
 	collectionLoadProgress, err := b.getMilvusClient().GetLoadingProgress(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName(), []string{})
@@ -357,7 +928,8 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 
 	// fill segments
 	unfilledSegments := make([]*entity.Segment, 0)
-	if !force {
+	collectionBackup.FlushState = FlushStateSkipped
+	if flushPolicy != FlushPolicySkip {
 		// Flush
 		segmentEntitiesBeforeFlush, err := b.getMilvusClient().GetPersistentSegmentInfo(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
 		if err != nil {
@@ -366,54 +938,72 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 		log.Info("GetPersistentSegmentInfo before flush from milvus",
 			zap.String("collectionName", collectionBackup.GetCollectionName()),
 			zap.Int("segmentNumBeforeFlush", len(segmentEntitiesBeforeFlush)))
-		newSealedSegmentIDs, flushedSegmentIDs, timeOfSeal, err := b.getMilvusClient().FlushV2(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName(), false)
-		if err != nil {
-			log.Error(fmt.Sprintf("fail to flush the collection: %s", collectionBackup.GetCollectionName()))
-			return err
+		timeoutCtx, cancel := context.WithCancel(ctx)
+		if flushPolicy == FlushPolicyTimeout {
+			timeoutCtx, cancel = context.WithTimeout(ctx, flushTimeout)
 		}
-		log.Info("flush segments",
-			zap.String("collectionName", collectionBackup.GetCollectionName()),
-			zap.Int64s("newSealedSegmentIDs", newSealedSegmentIDs),
-			zap.Int64s("flushedSegmentIDs", flushedSegmentIDs),
-			zap.Int64("timeOfSeal", timeOfSeal))
-		collectionBackup.BackupTimestamp = utils.ComposeTS(timeOfSeal, 0)
-		collectionBackup.BackupPhysicalTimestamp = uint64(timeOfSeal)
-
-		flushSegmentIDs := append(newSealedSegmentIDs, flushedSegmentIDs...)
-		segmentEntitiesAfterFlush, err := b.getMilvusClient().GetPersistentSegmentInfo(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
-		if err != nil {
+		defer cancel()
+		flushCtx, flushSpan := trace.Start(timeoutCtx, "flush")
+		collectionBackup.FlushStartTime = time.Now().UnixMilli()
+		newSealedSegmentIDs, flushedSegmentIDs, timeOfSeal, err := b.getMilvusClient().FlushV2(flushCtx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName(), false)
+		collectionBackup.FlushEndTime = time.Now().UnixMilli()
+		trace.End(flushSpan, err)
+		if err != nil && ctx.Err() == nil && errors.Is(timeoutCtx.Err(), context.DeadlineExceeded) {
+			log.Warn("flush timeout, only the data already persisted is backed up",
+				zap.String("collectionName", collectionBackup.GetCollectionName()),
+				zap.Duration("flushTimeout", flushTimeout))
+			collectionBackup.FlushState = FlushStateTimeout
+		} else if err != nil {
+			log.Error(fmt.Sprintf("fail to flush the collection: %s", collectionBackup.GetCollectionName()))
 			return err
 		}
-		log.Info("GetPersistentSegmentInfo after flush from milvus",
-			zap.String("collectionName", collectionBackup.GetCollectionName()),
-			zap.Int("segmentNumBeforeFlush", len(segmentEntitiesBeforeFlush)),
-			zap.Int("segmentNumAfterFlush", len(segmentEntitiesAfterFlush)))
-		segmentDict := utils.ArrayToMap(flushSegmentIDs)
-		for _, seg := range segmentEntitiesAfterFlush {
-			sid := seg.ID
-			if _, ok := segmentDict[sid]; ok {
-				delete(segmentDict, sid)
-				unfilledSegments = append(unfilledSegments, seg)
-			} else {
-				log.Debug("this may be new segments after flush, skip it", zap.Int64("id", sid))
+		if collectionBackup.GetFlushState() != FlushStateTimeout {
+			collectionBackup.FlushState = FlushStateFlushed
+			log.Info("flush segments",
+				zap.String("collectionName", collectionBackup.GetCollectionName()),
+				zap.Int64s("newSealedSegmentIDs", newSealedSegmentIDs),
+				zap.Int64s("flushedSegmentIDs", flushedSegmentIDs),
+				zap.Int64("timeOfSeal", timeOfSeal))
+			collectionBackup.BackupTimestamp = utils.ComposeTS(timeOfSeal, 0)
+			collectionBackup.BackupPhysicalTimestamp = uint64(timeOfSeal)
+
+			flushSegmentIDs := append(newSealedSegmentIDs, flushedSegmentIDs...)
+			segmentEntitiesAfterFlush, err := b.getMilvusClient().GetPersistentSegmentInfo(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
+			if err != nil {
+				return err
 			}
-		}
-		for _, seg := range segmentEntitiesBeforeFlush {
-			sid := seg.ID
-			if _, ok := segmentDict[sid]; ok {
-				delete(segmentDict, sid)
-				unfilledSegments = append(unfilledSegments, seg)
-			} else {
-				log.Debug("this may be old segments before flush, skip it", zap.Int64("id", sid))
+			log.Info("GetPersistentSegmentInfo after flush from milvus",
+				zap.String("collectionName", collectionBackup.GetCollectionName()),
+				zap.Int("segmentNumBeforeFlush", len(segmentEntitiesBeforeFlush)),
+				zap.Int("segmentNumAfterFlush", len(segmentEntitiesAfterFlush)))
+			segmentDict := utils.ArrayToMap(flushSegmentIDs)
+			for _, seg := range segmentEntitiesAfterFlush {
+				sid := seg.ID
+				if _, ok := segmentDict[sid]; ok {
+					delete(segmentDict, sid)
+					unfilledSegments = append(unfilledSegments, seg)
+				} else {
+					log.Debug("this may be new segments after flush, skip it", zap.Int64("id", sid))
+				}
+			}
+			for _, seg := range segmentEntitiesBeforeFlush {
+				sid := seg.ID
+				if _, ok := segmentDict[sid]; ok {
+					delete(segmentDict, sid)
+					unfilledSegments = append(unfilledSegments, seg)
+				} else {
+					log.Debug("this may be old segments before flush, skip it", zap.Int64("id", sid))
+				}
+			}
+			if len(segmentDict) > 0 {
+				// very rare situation, segments return in flush doesn't exist in either segmentEntitiesBeforeFlush and segmentEntitiesAfterFlush
+				errorMsg := "Segment return in Flush not exist in GetPersistentSegmentInfo. segment ids: " + fmt.Sprint(utils.MapKeyArray(segmentDict))
+				log.Warn(errorMsg)
 			}
 		}
-		if len(segmentDict) > 0 {
-			// very rare situation, segments return in flush doesn't exist in either segmentEntitiesBeforeFlush and segmentEntitiesAfterFlush
-			errorMsg := "Segment return in Flush not exist in GetPersistentSegmentInfo. segment ids: " + fmt.Sprint(utils.MapKeyArray(segmentDict))
-			log.Warn(errorMsg)
-		}
-	} else {
-		// Flush
+	}
+	if collectionBackup.GetFlushState() != FlushStateFlushed {
+		// data already persisted
 		segmentEntitiesBeforeFlush, err := b.getMilvusClient().GetPersistentSegmentInfo(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
 		if err != nil {
 			return err
@@ -467,6 +1057,14 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 
 	collectionBackup.PartitionBackups = partitionBackupInfos
 	collectionBackup.LoadState = collectionLoadState
+	if collectionLoadState != LoadState_NotLoad {
+		replicas, err := b.getMilvusClient().GetReplicas(ctx, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
+		if err != nil {
+			log.Error("fail to get replicas of collection", zap.Error(err))
+			return err
+		}
+		collectionBackup.ReplicaNumber, collectionBackup.ResourceGroups = replicaPlacement(replicas)
+	}
 	b.refreshBackupCache(backupInfo)
 	log.Info("finish build partition info",
 		zap.String("collectionName", collectionBackup.GetCollectionName()),
@@ -482,14 +1080,34 @@ func (b *BackupContext) backupCollectionPrepare(ctx context.Context, backupInfo
 	return nil
 }
 
-func (b *BackupContext) backupCollectionExecute(ctx context.Context, backupInfo *backuppb.BackupInfo, collection collectionStruct) error {
-	var collectionBackup *backuppb.CollectionBackupInfo
-	for _, coll := range backupInfo.GetCollectionBackups() {
-		if coll.GetCollectionName() == collection.collectionName && coll.DbName == collection.db {
-			collectionBackup = coll
-			break
+// filterPartitions selects the partitions by names, partitions of the collection with partition key can't be selected
+func filterPartitions(collectionBackup *backuppb.CollectionBackupInfo, partitions []*entity.Partition, partitionNames []string) ([]*entity.Partition, error) {
+	for _, field := range collectionBackup.GetSchema().GetFields() {
+		if field.GetIsPartitionKey() {
+			return nil, fmt.Errorf("can not select partitions of collection %s.%s with partition key", collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
+		}
+	}
+	partitionDict := make(map[string]*entity.Partition, len(partitions))
+	for _, partition := range partitions {
+		partitionDict[partition.Name] = partition
+	}
+	selected := make([]*entity.Partition, 0, len(partitionNames))
+	for _, name := range partitionNames {
+		partition, ok := partitionDict[name]
+		if !ok {
+			return nil, fmt.Errorf("partition %s does not exist in collection %s.%s", name, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
 		}
+		selected = append(selected, partition)
 	}
+	return selected, nil
+}
+
+func (b *BackupContext) backupCollectionExecute(ctx context.Context, backupInfo *backuppb.BackupInfo, collection collectionStruct, baseSegments map[int64]*backuppb.SegmentBackupInfo, copyPool *common.WorkerPool) (err error) {
+	ctx, span := trace.Start(ctx, "backupCollectionExecute",
+		attribute.String("collection.db", collection.db),
+		attribute.String("collection.name", collection.collectionName))
+	defer func() { trace.End(span, err) }()
+	collectionBackup := findCollectionBackup(backupInfo, collection)
 
 	var segmentBackupInfos []*backuppb.SegmentBackupInfo
 	for _, part := range collectionBackup.GetPartitionBackups() {
@@ -500,12 +1118,27 @@ func (b *BackupContext) backupCollectionExecute(ctx context.Context, backupInfo
 		zap.String("collectionName", collectionBackup.GetCollectionName()),
 		zap.Int("segmentNum", len(segmentBackupInfos)))
 
-	sort.SliceStable(segmentBackupInfos, func(i, j int) bool {
-		return segmentBackupInfos[i].Size < segmentBackupInfos[j].Size
-	})
-	err := b.copySegments(ctx, segmentBackupInfos, backupInfo)
-	if err != nil {
-		return err
+	for retry := 0; ; retry++ {
+		sort.SliceStable(segmentBackupInfos, func(i, j int) bool {
+			return segmentBackupInfos[i].Size < segmentBackupInfos[j].Size
+		})
+		err = b.copySegments(ctx, segmentBackupInfos, backupInfo, collectionBackup, baseSegments, copyPool)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, errBinlogNotExist) || retry >= COMPACTED_SEGMENT_RETRY {
+			return err
+		}
+		// binlogs are deleted by gc of milvus after the segments are compacted, backup the segments compacted to instead
+		runRecorderFromContext(ctx).retryCompactedSegments()
+		log.Warn("binlogs deleted during backup, list the segments of collection again",
+			zap.String("collectionName", collectionBackup.GetCollectionName()),
+			zap.Int("retry", retry),
+			zap.Error(err))
+		segmentBackupInfos, err = b.refreshCompactedSegments(ctx, backupInfo, collectionBackup)
+		if err != nil {
+			return err
+		}
 	}
 
 	collectionBackup.EndTime = time.Now().Unix()
@@ -517,61 +1150,258 @@ func (b *BackupContext) backupCollectionExecute(ctx context.Context, backupInfo
 	return nil
 }
 
+func findCollectionBackup(backupInfo *backuppb.BackupInfo, collection collectionStruct) *backuppb.CollectionBackupInfo {
+	for _, coll := range backupInfo.GetCollectionBackups() {
+		if coll.GetCollectionName() == collection.collectionName && coll.DbName == collection.db {
+			return coll
+		}
+	}
+	return nil
+}
+
+// listCollectionFiles fills the binlogs and sizes of the segments of a collection which are not copied yet
+func (b *BackupContext) listCollectionFiles(ctx context.Context, backupInfo *backuppb.BackupInfo, collection collectionStruct) error {
+	collectionBackup := findCollectionBackup(backupInfo, collection)
+	for _, partition := range collectionBackup.GetPartitionBackups() {
+		for _, segment := range partition.GetSegmentBackups() {
+			if segment.GetBackuped() {
+				continue
+			}
+			listCtx, listSpan := trace.Start(ctx, "listSegmentFiles", attribute.Int64("segment.id", segment.GetSegmentId()))
+			_, err := b.fillSegmentBackupInfo(listCtx, segment)
+			trace.End(listSpan, err)
+			if err != nil {
+				log.Error("Fail to fill segment backup info",
+					zap.Int64("collection_id", segment.GetCollectionId()),
+					zap.Int64("segment_id", segment.GetSegmentId()),
+					zap.Error(err))
+				return err
+			}
+		}
+	}
+	return nil
+}
+
 func (b *BackupContext) executeCreateBackup(ctx context.Context, request *backuppb.CreateBackupRequest, backupInfo *backuppb.BackupInfo) (*backuppb.BackupInfo, error) {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
-	backupInfo.BackupTimestamp = uint64(time.Now().UnixNano() / int64(time.Millisecond))
 	backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_EXECUTING
 
 	defer b.refreshBackupCache(backupInfo)
 
-	// 1, get collection level meta
-	toBackupCollections, err := b.parseBackupCollections(request)
+	// all the files written by the backup are locked with the retention recorded in meta, also when resumed
+	ctx = withBackupObjectLock(ctx, backupInfo)
+	sse, err := b.backupServerSideEncryption(backupInfo, request.GetSseCustomerKey())
 	if err != nil {
-		log.Error("parse backup collections from request failed", zap.Error(err))
+		log.Error("fail to get server side encryption of backup", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
 		backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
 		backupInfo.ErrorMessage = err.Error()
 		return backupInfo, err
 	}
-	collectionNames := make([]string, len(toBackupCollections))
-	for i, coll := range toBackupCollections {
-		collectionNames[i] = coll.collectionName
-	}
-	log.Info("collections to backup", zap.Strings("collections", collectionNames))
+	ctx = storage.WithServerSideEncryption(ctx, sse)
+
+	// persist the failure into checkpoint, so that the backup can be resumed
+	checkpointed := false
+	defer func() {
+		if checkpointed && backupInfo.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_FAIL {
+			// ctx may have been canceled, the checkpoint is still written so that the backup can be resumed
+			b.writeBackupCheckpoint(withBackupObjectLock(b.ctx, backupInfo), backupInfo, true)
+		}
+	}()
 
+	collectionPool := b.getRequestWorkerPool("backupCollection", request.GetCollectionParallelism(), b.getBackupCollectionWorkerPool)
+	copyPool := b.getRequestWorkerPool("copydata", request.GetCopyParallelism(), b.getCopyDataWorkerPool)
+
+	recorder := runRecorderFromContext(ctx)
+	var toBackupCollections []collectionStruct
 	jobIds := make([]int64, 0)
-	for _, collection := range toBackupCollections {
-		collectionClone := collection
-		job := func(ctx context.Context) error {
-			err := b.backupCollectionPrepare(ctx, backupInfo, collectionClone, request.GetForce())
-			return err
-		}
-		jobId := b.getBackupCollectionWorkerPool().SubmitWithId(job)
-		jobIds = append(jobIds, jobId)
+	// with allow_partial, a failed collection is recorded here and the others go on
+	failures := newCollectionFailures()
+	isolate := func(collection collectionStruct, job common.Job) common.Job {
+		return common.WithRequest(ctx, b.isolateCollectionJob(ctx, request.GetAllowPartial(), backupInfo, collection, failures, job))
 	}
-	err = b.getBackupCollectionWorkerPool().WaitJobs(jobIds)
+	if request.GetResume() {
+		// collections have been prepared before interrupted, except those failed before their meta is backed up
+		for _, coll := range backupInfo.GetCollectionBackups() {
+			collection := collectionStruct{coll.GetDbName(), coll.GetCollectionName()}
+			if isFailedCollection(coll) && coll.GetSchema() == nil {
+				failures.add(collection, errors.New(coll.GetErrorMessage()))
+				continue
+			}
+			coll.StateCode = backuppb.BackupTaskStateCode_BACKUP_INITIAL
+			coll.ErrorMessage = ""
+			toBackupCollections = append(toBackupCollections, collection)
+		}
+		checkpointed = true
+	} else {
+		recorder.startPhase("prepare")
+		backupInfo.BackupTimestamp = uint64(time.Now().UnixNano() / int64(time.Millisecond))
 
-	if err != nil {
-		backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
-		backupInfo.ErrorMessage = err.Error()
-		return backupInfo, err
-	}
+		// 1, get collection level meta
+		toBackupCollections, err = b.parseBackupCollections(request)
+		if err != nil {
+			log.Error("parse backup collections from request failed", zap.Error(err))
+			backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+			backupInfo.ErrorMessage = err.Error()
+			return backupInfo, err
+		}
+		collectionNames := make([]string, len(toBackupCollections))
+		for i, coll := range toBackupCollections {
+			collectionNames[i] = coll.collectionName
+		}
+		log.Info("collections to backup", zap.Strings("collections", collectionNames))
+
+		for _, collection := range toBackupCollections {
+			collectionClone := collection
+			job := func(ctx context.Context) error {
+				partitionNames := requestPartitions(request.GetPartitions(), collectionClone.db, collectionClone.collectionName)
+				err := b.backupCollectionPrepare(ctx, backupInfo, collectionClone, backupFlushPolicy(request), time.Duration(request.GetFlushTimeout())*time.Second, partitionNames)
+				if err == nil && request.GetCheckRowCount() && !request.GetMetaOnly() {
+					countMilvusRows(ctx, findCollectionBackup(backupInfo, collectionClone), b.milvusRowCounter(collectionClone.db, collectionClone.collectionName))
+				}
+				if err == nil && request.GetSampleRows() > 0 && !request.GetMetaOnly() {
+					b.sampleCollectionRows(ctx, backupInfo.GetName(), findCollectionBackup(backupInfo, collectionClone), int(request.GetSampleRows()))
+				}
+				if err == nil && request.GetScanBinlogs() && !request.GetMetaOnly() {
+					b.scanCollectionBinlogs(ctx, findCollectionBackup(backupInfo, collectionClone))
+				}
+				return err
+			}
+			jobId := collectionPool.SubmitWithId(isolate(collectionClone, job))
+			jobIds = append(jobIds, jobId)
+		}
+		err = collectionPool.WaitJobs(jobIds)
+		if err == nil {
+			toBackupCollections, err = remainingCollections(toBackupCollections, failures)
+		}
+
+		if err != nil {
+			backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+			backupInfo.ErrorMessage = err.Error()
+			return backupInfo, err
+		}
 
-	log.Info("Finish flush all collections")
+		log.Info("Finish flush all collections")
+
+		if request.GetRbac() {
+			backupInfo.RbacMeta, err = b.backupRBAC(ctx)
+			if err != nil {
+				backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+				backupInfo.ErrorMessage = err.Error()
+				return backupInfo, err
+			}
+		}
+
+		// taken after all the collections are flushed, so that the snapshot sees the segments listed to backup
+		if request.GetEtcdSnapshot() {
+			backupInfo.EtcdSnapshot, err = b.backupEtcdSnapshot(ctx, backupInfo)
+			if err != nil {
+				backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+				backupInfo.ErrorMessage = err.Error()
+				return backupInfo, err
+			}
+		}
+	}
 
 	if !request.GetMetaOnly() {
+		// binlogs are not deleted by gc of milvus while they are listed and copied
+		defer b.pauseMilvusGC(ctx)()
+		// ctx may have been canceled, the dedup objects referenced are still counted
+		defer b.registerDedupObjects(b.ctx, backupInfo)
+		copyParallelism := int(request.GetCopyParallelism())
+		if copyParallelism <= 0 {
+			copyParallelism = b.params.BackupCfg.BackupCopyDataParallelism
+		}
+		var stopThrottle func()
+		b.copyLimiter, stopThrottle = b.startLoadThrottle(ctx, copyParallelism)
+		defer func() {
+			stopThrottle()
+			b.copyLimiter = nil
+		}()
+
+		if !checkpointed {
+			// record the segments to backup, so that the backup can be resumed if interrupted
+			if err = b.writeBackupCheckpoint(ctx, backupInfo, true); err != nil {
+				log.Error("fail to write backup checkpoint", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+				backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+				backupInfo.ErrorMessage = err.Error()
+				return backupInfo, err
+			}
+			checkpointed = true
+		}
+
+		// segments of base backup, used by incremental backup to skip unchanged segments
+		var baseSegments map[int64]*backuppb.SegmentBackupInfo
+		if backupInfo.GetBaseBackupName() != "" {
+			baseBackup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupInfo.GetBaseBackupName())
+			if err == nil && baseBackup == nil {
+				err = errors.New(fmt.Sprintf("base backup not exist with the name: %s", backupInfo.GetBaseBackupName()))
+			}
+			// binlogs referenced from base backup are restored with the customer key of the incremental backup
+			if err == nil && baseBackup.GetSseCustomerKeyMd5() != backupInfo.GetSseCustomerKeyMd5() {
+				err = fmt.Errorf("incremental backup should be encrypted by the same customer key as base backup %s", backupInfo.GetBaseBackupName())
+			}
+			if err != nil {
+				log.Error("fail to read base backup", zap.String("baseBackupName", backupInfo.GetBaseBackupName()), zap.Error(err))
+				backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+				backupInfo.ErrorMessage = err.Error()
+				return backupInfo, err
+			}
+			baseSegments = baseBackupSegments(baseBackup, backupInfo)
+			log.Info("incremental backup based on backup",
+				zap.String("baseBackupName", backupInfo.GetBaseBackupName()),
+				zap.Int("baseSegmentNum", len(baseSegments)))
+		}
+
+		// list binlogs of all segments before copy, so that the size to copy is known for the progress
+		recorder.startPhase("list")
 		for _, collection := range toBackupCollections {
 			collectionClone := collection
 			job := func(ctx context.Context) error {
-				err := b.backupCollectionExecute(ctx, backupInfo, collectionClone)
+				return b.listCollectionFiles(ctx, backupInfo, collectionClone)
+			}
+			jobId := collectionPool.SubmitWithId(isolate(collectionClone, job))
+			jobIds = append(jobIds, jobId)
+		}
+		err = collectionPool.WaitJobs(jobIds)
+		if err == nil {
+			toBackupCollections, err = remainingCollections(toBackupCollections, failures)
+		}
+		if err != nil {
+			backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+			backupInfo.ErrorMessage = err.Error()
+			return backupInfo, err
+		}
+		initBackupProgress(backupInfo)
+		b.refreshBackupCache(backupInfo)
+		log.Info("finish list segment files",
+			zap.String("backupName", backupInfo.GetName()),
+			zap.Int64("size", backupInfo.GetSize()),
+			zap.Int64("copiedSize", backupInfo.GetCopiedSize()))
+
+		recorder.startPhase("copy")
+		copiedBefore := backupInfo.GetCopiedSize()
+		jobIds = make([]int64, 0)
+		for _, collection := range toBackupCollections {
+			collectionClone := collection
+			job := func(ctx context.Context) error {
+				err := b.backupCollectionExecute(ctx, backupInfo, collectionClone, baseSegments, copyPool)
+				// the rows in milvus are counted before the copy, also recorded in the checkpoint of a resumed backup
+				if collectionBackup := findCollectionBackup(backupInfo, collectionClone); err == nil && len(collectionBackup.GetRowCountChecks()) > 0 {
+					compareBackupRows(collectionBackup)
+				}
 				return err
 			}
-			jobId := b.getBackupCollectionWorkerPool().SubmitWithId(job)
+			jobId := collectionPool.SubmitWithId(isolate(collectionClone, job))
 			jobIds = append(jobIds, jobId)
 		}
 
-		err = b.getBackupCollectionWorkerPool().WaitJobs(jobIds)
+		err = collectionPool.WaitJobs(jobIds)
+		recorder.endPhase(backupInfo.GetCopiedSize() - copiedBefore)
+		if err == nil {
+			_, err = remainingCollections(toBackupCollections, failures)
+		}
 		if err != nil {
 			backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
 			backupInfo.ErrorMessage = err.Error()
@@ -589,25 +1419,34 @@ func (b *BackupContext) executeCreateBackup(ctx context.Context, request *backup
 			backupSize += coll.GetSize()
 		}
 		backupInfo.Size = backupSize
+		finishBackupProgress(backupInfo)
 		backupInfo.EndTime = time.Now().UnixNano() / int64(time.Millisecond)
 		backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_SUCCESS
 	} else {
 		log.Info("skip copy data because it is a metaOnly backup request")
+		finishBackupProgress(backupInfo)
+	}
+	var partialErr error
+	if failures.len() > 0 {
+		partialErr = fmt.Errorf("backup %s is partial, collections failed: %s", backupInfo.GetName(), strings.Join(failures.names(), ", "))
+		backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_PARTIAL
+		backupInfo.ErrorMessage = partialErr.Error()
 	}
 	b.refreshBackupCache(backupInfo)
 
 	// 7, write meta data
+	recorder.startPhase("meta")
 	output, _ := serialize(backupInfo)
 	log.Debug("backup meta", zap.String("value", string(output.BackupMetaBytes)))
 	log.Debug("collection meta", zap.String("value", string(output.CollectionMetaBytes)))
 	log.Debug("partition meta", zap.String("value", string(output.PartitionMetaBytes)))
 	log.Debug("segment meta", zap.String("value", string(output.SegmentMetaBytes)))
 
-	b.getStorageClient().Write(ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, backupInfo.GetName()), output.BackupMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, CollectionMetaPath(b.backupRootPath, backupInfo.GetName()), output.CollectionMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, PartitionMetaPath(b.backupRootPath, backupInfo.GetName()), output.PartitionMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, SegmentMetaPath(b.backupRootPath, backupInfo.GetName()), output.SegmentMetaBytes)
-	b.getStorageClient().Write(ctx, b.backupBucketName, FullMetaPath(b.backupRootPath, backupInfo.GetName()), output.FullMetaBytes)
+	// the manifest is written before the meta of succeeded backup, verify and restore fall back to meta without it
+	if err := b.writeBackupManifest(ctx, backupInfo); err != nil {
+		log.Warn("fail to write backup manifest", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+	}
+	b.writeBackupMeta(ctx, backupInfo.GetName(), output)
 
 	log.Info("finish executeCreateBackup",
 		zap.String("requestId", request.GetRequestId()),
@@ -615,47 +1454,100 @@ func (b *BackupContext) executeCreateBackup(ctx context.Context, request *backup
 		zap.Strings("collections", request.GetCollectionNames()),
 		zap.Bool("async", request.GetAsync()),
 		zap.String("backup meta", string(output.BackupMetaBytes)))
-	return backupInfo, nil
+	return backupInfo, partialErr
 }
 
-func (b *BackupContext) copySegments(ctx context.Context, segments []*backuppb.SegmentBackupInfo, backupInfo *backuppb.BackupInfo) error {
-	dstPath := BackupBinlogDirPath(b.backupRootPath, backupInfo.GetName())
-
-	// generate target path
-	// milvus_rootpath/insert_log/collection_id/partition_id/segment_id/ =>
-	// backup_rootpath/backup_name/binlog/insert_log/collection_id/partition_id/group_id/segment_id
-	backupPathFunc := func(binlogPath, rootPath, backupBinlogPath string) string {
-		if rootPath == "" {
-			return dstPath + SEPERATOR + binlogPath
-		} else {
-			return strings.Replace(binlogPath, rootPath, dstPath, 1)
-		}
-	}
-
+// copySegments copies the binlogs of segments into backup, a binlog not existing doesn't fail the copy pool,
+// the segments of missing binlogs are returned in errBinlogNotExist after the other binlogs are copied
+func (b *BackupContext) copySegments(ctx context.Context, segments []*backuppb.SegmentBackupInfo, backupInfo *backuppb.BackupInfo, collectionBackup *backuppb.CollectionBackupInfo, baseSegments map[int64]*backuppb.SegmentBackupInfo, copyPool *common.WorkerPool) error {
 	jobIds := make([]int64, 0)
+	missing := &missingSegments{ids: make(map[int64]bool)}
+	limiter := b.copyLimiter
+	// the copy pool is shared by collections, a failed copy cancels the other copies of the collection only
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var copyErr error
+	var copyErrOnce sync.Once
+	isolate := func(job common.Job) common.Job {
+		return common.WithRequest(ctx, func(ctx context.Context) error {
+			err := job(ctx)
+			if err != nil {
+				copyErrOnce.Do(func() {
+					copyErr = err
+					cancel()
+				})
+			}
+			return err
+		})
+	}
 	for _, segment := range segments {
 		log := log.With(zap.Int64("collection_id", segment.GetCollectionId()),
 			zap.Int64("partition_id", segment.GetPartitionId()),
 			zap.Int64("segment_id", segment.GetSegmentId()),
 			zap.Int64("group_id", segment.GetGroupId()))
 		log.Debug("copy segment")
-		_, err := b.fillSegmentBackupInfo(ctx, segment)
-		if err != nil {
-			log.Error("Fail to fill segment backup info", zap.Error(err))
-			return err
+		if segment.GetBackuped() {
+			log.Debug("segment has been copied before the backup is interrupted, skip copy")
+			continue
 		}
-		// insert log
+		// the results of segments are written under checkpointMu, as they are serialized by the checkpoints written by
+		// the copies of other segments
+		b.checkpointMu.Lock()
+		// incremental backup, skip copy if the segment is unchanged since base backup
+		if baseSegment, ok := baseSegments[segment.GetSegmentId()]; ok && isSameSegmentFiles(baseSegment, segment) {
+			segment.GroupId = baseSegment.GetGroupId()
+			// keep the backup size and checksum of binlogs recorded in base backup
+			segment.Binlogs = baseSegment.GetBinlogs()
+			segment.Deltalogs = baseSegment.GetDeltalogs()
+			segment.Compression = baseSegment.GetCompression()
+			segment.Encrypted = baseSegment.GetEncrypted()
+			if objects := segmentDedupObjects(baseSegment); len(objects) > 0 {
+				// binlogs stored as dedup objects are referenced by the backup itself instead of the base backup
+				b.holdDedupObjects(backupInfo.GetName(), objects...)
+			} else if baseSegment.GetRefBackupName() != "" {
+				segment.RefBackupName = baseSegment.GetRefBackupName()
+			} else {
+				segment.RefBackupName = backupInfo.GetBaseBackupName()
+			}
+			segment.Backuped = true
+			b.checkpointMu.Unlock()
+			b.addCopiedSize(backupInfo, collectionBackup, segment.GetSize())
+			log.Debug("segment unchanged since base backup, skip copy", zap.String("ref_backup_name", segment.GetRefBackupName()))
+			continue
+		}
+		segment.Compression = backupInfo.GetCompression()
+		segment.Encrypted = backupInfo.GetEncrypted()
 		for _, binlogs := range segment.GetBinlogs() {
-			for _, binlog := range binlogs.GetBinlogs() {
-				targetPath := backupPathFunc(binlog.GetLogPath(), b.milvusRootPath, dstPath)
+			if len(binlogs.GetBinlogs()) > 0 {
 				// use segmentID as group id
 				segment.GroupId = segment.SegmentId
-				if segment.GetGroupId() != 0 {
-					targetPath = strings.Replace(targetPath,
-						strconv.FormatInt(segment.GetPartitionId(), 10),
-						strconv.FormatInt(segment.GetPartitionId(), 10)+"/"+strconv.FormatInt(segment.GetGroupId(), 10),
-						1)
-				}
+				break
+			}
+		}
+
+		// mark the segment copied after all its binlogs are copied
+		remainingFiles := int32(0)
+		for _, binlogs := range append(append([]*backuppb.FieldBinlog{}, segment.GetBinlogs()...), segment.GetDeltalogs()...) {
+			remainingFiles += int32(len(binlogs.GetBinlogs()))
+		}
+		segment := segment
+		onFileCopied := func(ctx context.Context, size int64) {
+			b.addCopiedSize(backupInfo, collectionBackup, size)
+			if atomic.AddInt32(&remainingFiles, -1) == 0 {
+				b.checkpointMu.Lock()
+				segment.Backuped = true
+				b.checkpointMu.Unlock()
+				b.writeBackupCheckpoint(ctx, backupInfo, false)
+			}
+		}
+		if remainingFiles == 0 {
+			segment.Backuped = true
+		}
+		b.checkpointMu.Unlock()
+		// insert log
+		for _, binlogs := range segment.GetBinlogs() {
+			for _, binlog := range binlogs.GetBinlogs() {
+				targetPath := b.binlogBackupPath(backupInfo.GetName(), collectionBackup, segment, binlog.GetLogPath())
 				if targetPath == binlog.GetLogPath() {
 					return errors.New(fmt.Sprintf("copy src path and dst path can not be the same, src: %s dst: %s", binlog.GetLogPath(), targetPath))
 				}
@@ -670,13 +1562,23 @@ func (b *BackupContext) copySegments(ctx context.Context, segments []*backuppb.S
 						return err
 					}
 					if !exist {
-						log.Error("Binlog file not exist",
-							zap.Error(err),
+						log.Warn("Binlog file not exist, the segment may be compacted",
 							zap.String("file", binlog.GetLogPath()))
-						return err
+						missing.add(segment.GetSegmentId())
+						return nil
 					}
 
-					err = b.getStorageClient().Copy(ctx, b.milvusBucketName, b.backupBucketName, binlog.GetLogPath(), targetPath)
+					if err := limiter.Acquire(ctx); err != nil {
+						return err
+					}
+					err = b.copyBinlogFile(ctx, backupInfo, segment, binlog, targetPath)
+					limiter.Release()
+					if err != nil && b.binlogDeleted(ctx, binlog) {
+						log.Warn("Binlog file deleted while copying, the segment may be compacted",
+							zap.String("file", binlog.GetLogPath()))
+						missing.add(segment.GetSegmentId())
+						return nil
+					}
 					if err != nil {
 						log.Info("Fail to copy file",
 							zap.Error(err),
@@ -688,23 +1590,18 @@ func (b *BackupContext) copySegments(ctx context.Context, segments []*backuppb.S
 							zap.String("from", binlog.GetLogPath()),
 							zap.String("to", targetPath))
 					}
+					onFileCopied(ctx, binlog.GetLogSize())
 
 					return nil
 				}
-				jobId := b.getCopyDataWorkerPool().SubmitWithId(job)
+				jobId := copyPool.SubmitWithId(isolate(job))
 				jobIds = append(jobIds, jobId)
 			}
 		}
 		// delta log
 		for _, binlogs := range segment.GetDeltalogs() {
 			for _, binlog := range binlogs.GetBinlogs() {
-				targetPath := backupPathFunc(binlog.GetLogPath(), b.milvusRootPath, dstPath)
-				if segment.GetGroupId() != 0 {
-					targetPath = strings.Replace(targetPath,
-						strconv.FormatInt(segment.GetPartitionId(), 10),
-						strconv.FormatInt(segment.GetPartitionId(), 10)+"/"+strconv.FormatInt(segment.GetGroupId(), 10),
-						1)
-				}
+				targetPath := b.binlogBackupPath(backupInfo.GetName(), collectionBackup, segment, binlog.GetLogPath())
 				if targetPath == binlog.GetLogPath() {
 					return errors.New(fmt.Sprintf("copy src path and dst path can not be the same, src: %s dst: %s", binlog.GetLogPath(), targetPath))
 				}
@@ -719,12 +1616,22 @@ func (b *BackupContext) copySegments(ctx context.Context, segments []*backuppb.S
 						return err
 					}
 					if !exist {
-						log.Error("Binlog file not exist",
-							zap.Error(err),
+						log.Warn("Binlog file not exist, the segment may be compacted",
+							zap.String("file", binlog.GetLogPath()))
+						missing.add(segment.GetSegmentId())
+						return nil
+					}
+					if err := limiter.Acquire(ctx); err != nil {
+						return err
+					}
+					err = b.copyBinlogFile(ctx, backupInfo, segment, binlog, targetPath)
+					limiter.Release()
+					if err != nil && b.binlogDeleted(ctx, binlog) {
+						log.Warn("Binlog file deleted while copying, the segment may be compacted",
 							zap.String("file", binlog.GetLogPath()))
-						return errors.New("Binlog file not exist " + binlog.GetLogPath())
+						missing.add(segment.GetSegmentId())
+						return nil
 					}
-					err = b.getStorageClient().Copy(ctx, b.milvusBucketName, b.backupBucketName, binlog.GetLogPath(), targetPath)
 					if err != nil {
 						log.Info("Fail to copy file",
 							zap.Error(err),
@@ -736,39 +1643,207 @@ func (b *BackupContext) copySegments(ctx context.Context, segments []*backuppb.S
 							zap.String("from", binlog.GetLogPath()),
 							zap.String("to", targetPath))
 					}
-					return err
+					onFileCopied(ctx, binlog.GetLogSize())
+					return nil
 				}
-				jobId := b.getCopyDataWorkerPool().SubmitWithId(job)
+				jobId := copyPool.SubmitWithId(isolate(job))
 				jobIds = append(jobIds, jobId)
 			}
 		}
 	}
 
-	err := b.getCopyDataWorkerPool().WaitJobs(jobIds)
-	return err
+	// the copy pool is shared by all backups, a job is submitted for every binlog
+	defer copyPool.ForgetJobs(jobIds)
+	err := copyPool.WaitJobs(jobIds)
+	if err != nil {
+		// the other copies fail with context canceled after the first failure
+		if copyErr != nil {
+			return copyErr
+		}
+		return err
+	}
+	if ids := missing.list(); len(ids) > 0 {
+		return fmt.Errorf("%w, segments: %v", errBinlogNotExist, ids)
+	}
+	return nil
 }
 
-func (b *BackupContext) fillSegmentBackupInfo(ctx context.Context, segmentBackupInfo *backuppb.SegmentBackupInfo) (*backuppb.SegmentBackupInfo, error) {
-	var size int64 = 0
-	var rootPath string
+// writeBackupMeta writes all meta files of a backup
+func (b *BackupContext) writeBackupMeta(ctx context.Context, backupName string, output *BackupMetaBytes) error {
+	// meta is read by list and get without the customer key of the backup
+	ctx = storage.WithoutCustomerKey(ctx)
+	metaFiles := []struct {
+		path string
+		data []byte
+	}{
+		{BackupMetaPath(b.backupRootPath, backupName), output.BackupMetaBytes},
+		{CollectionMetaPath(b.backupRootPath, backupName), output.CollectionMetaBytes},
+		{PartitionMetaPath(b.backupRootPath, backupName), output.PartitionMetaBytes},
+		{SegmentMetaPath(b.backupRootPath, backupName), output.SegmentMetaBytes},
+		{FullMetaPath(b.backupRootPath, backupName), output.FullMetaBytes},
+	}
+	for _, metaFile := range metaFiles {
+		if err := b.writeBackupFile(ctx, metaFile.path, metaFile.data); err != nil {
+			log.Error("fail to write backup meta", zap.String("path", metaFile.path), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
 
-	if b.params.MinioCfg.RootPath != "" {
-		rootPath = fmt.Sprintf("%s/", b.params.MinioCfg.RootPath)
+// writeBackupCheckpoint writes the meta of an executing backup, including which segments have been copied,
+// so that an interrupted backup can be resumed. The write is skipped if the last checkpoint is written
+// within BACKUP_CHECKPOINT_INTERVAL, unless force is true.
+// The results of segments and binlogs are written under checkpointMu, and the progress under progressMu.
+func (b *BackupContext) writeBackupCheckpoint(ctx context.Context, backupInfo *backuppb.BackupInfo, force bool) error {
+	b.checkpointMu.Lock()
+	defer b.checkpointMu.Unlock()
+	if !force && time.Since(b.lastCheckpointTime) < BACKUP_CHECKPOINT_INTERVAL {
+		return nil
+	}
+	b.progressMu.Lock()
+	output, err := serialize(backupInfo)
+	b.progressMu.Unlock()
+	if err != nil {
+		return err
+	}
+	if err := b.writeBackupMeta(ctx, backupInfo.GetName(), output); err != nil {
+		return err
+	}
+	b.lastCheckpointTime = time.Now()
+	log.Debug("write backup checkpoint", zap.String("backupName", backupInfo.GetName()))
+	return nil
+}
+
+// binlogBackupPath returns the path of a binlog in the backup
+// milvus_rootpath/insert_log/collection_id/partition_id/segment_id/ =>
+// backup_rootpath/backup_name/binlog/insert_log/collection_id/partition_id/group_id/segment_id
+// Binlogs in other layouts of milvus are stored in the same layout of backup, and the dirs of segment groups are
+// rendered by the path template of the collection.
+func (b *BackupContext) binlogBackupPath(backupName string, collection *backuppb.CollectionBackupInfo, segment *backuppb.SegmentBackupInfo, binlogPath string) string {
+	relativePath, ok := b.getBinlogLayout().RelativePath(binlogPath, segment.GetCollectionId(), segment.GetPartitionId(), segment.GetSegmentId())
+	if ok && segment.GetGroupId() != 0 {
+		// log_type/collection_id/partition_id/segment_id/field_id/log_id
+		parts := strings.SplitN(relativePath, SEPERATOR, 4)
+		return BinlogGroupDirPath(b.backupRootPath, backupName, collection, parts[0], segment.GetCollectionId(), segment.GetPartitionId(), segment.GetGroupId()) + parts[3]
+	}
+	dstPath := BackupBinlogDirPath(b.backupRootPath, backupName)
+	var targetPath string
+	if ok {
+		targetPath = dstPath + SEPERATOR + relativePath
+	} else if b.milvusRootPath == "" {
+		targetPath = dstPath + SEPERATOR + binlogPath
 	} else {
-		rootPath = ""
+		targetPath = strings.Replace(binlogPath, b.milvusRootPath, dstPath, 1)
 	}
+	if segment.GetGroupId() != 0 {
+		targetPath = strings.Replace(targetPath,
+			strconv.FormatInt(segment.GetPartitionId(), 10),
+			strconv.FormatInt(segment.GetPartitionId(), 10)+"/"+strconv.FormatInt(segment.GetGroupId(), 10),
+			1)
+	}
+	return targetPath
+}
+
+// copyBinlogFile copies a binlog file from milvus bucket to backup bucket, the file is read
+// and compressed/encrypted locally if the segment needs, checksum is enabled or backup data is stored in
+// another storage, otherwise copied by storage.
+// The size and checksum of the file written are recorded into binlog when read locally.
+// Binlogs stored as dedup objects are written to the object of their content instead of toPath, unless it exists.
+func (b *BackupContext) copyBinlogFile(ctx context.Context, backupInfo *backuppb.BackupInfo, segment *backuppb.SegmentBackupInfo, binlog *backuppb.Binlog, toPath string) (err error) {
+	ctx, span := trace.Start(ctx, "copyBinlogFile",
+		attribute.String("file.from", binlog.GetLogPath()),
+		attribute.String("file.to", toPath),
+		attribute.Int64("file.size", binlog.GetLogSize()))
+	defer func() { trace.End(span, err) }()
+	// only binlogs go to the storage class, meta is read by every list and restore
+	ctx = storage.WithStorageClass(ctx, b.params.BackupCfg.StorageClass)
+	dedup := b.dedupBinlogs(backupInfo, segment)
+	if segment.GetCompression() == utils.CompressionNone && !segment.GetEncrypted() && !b.params.BackupCfg.Checksum && !b.params.BackupStorageCfg.Enabled() && !dedup {
+		if err := b.getStorageClient().Copy(ctx, b.milvusBucketName, b.backupBucketName, binlog.GetLogPath(), toPath); err != nil {
+			return err
+		}
+		metrics.CopiedBytes.WithLabelValues(metrics.BackupTaskLabel).Add(float64(binlog.GetLogSize()))
+		return nil
+	}
+	// the binlog and its encoded copy are held in memory until written
+	return b.withMemory(ctx, 2*binlog.GetLogSize(), func() error {
+		data, err := b.getStorageClient().Read(ctx, b.milvusBucketName, binlog.GetLogPath())
+		if err != nil {
+			return err
+		}
+		compressed, err := utils.Compress(segment.GetCompression(), data)
+		if err != nil {
+			return err
+		}
+		encoded, err := b.encodeBackupFile(compressed)
+		if err != nil {
+			return err
+		}
+		var objectName string
+		if dedup {
+			objectName = dedupObjectName(encoded)
+			// held before checking, so that the object is not deleted with another backup after it is found
+			b.holdDedupObjects(backupInfo.GetName(), objectName)
+			objectPath := DedupObjectPath(b.backupRootPath, objectName)
+			exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, objectPath)
+			if err != nil {
+				return err
+			}
+			if exist {
+				metrics.DedupBytes.Add(float64(len(encoded)))
+			} else if err := b.getBackupStorageClient().Write(ctx, b.backupBucketName, objectPath, encoded); err != nil {
+				return err
+			}
+		} else if err := b.getBackupStorageClient().Write(ctx, b.backupBucketName, toPath, encoded); err != nil {
+			return err
+		}
+		crc := utils.Crc32c(encoded)
+		// serialized by the checkpoints written by the copies of other binlogs
+		b.checkpointMu.Lock()
+		if dedup {
+			binlog.ObjectName = objectName
+		}
+		binlog.BackupSize = int64(len(encoded))
+		binlog.Crc32C = crc
+		b.checkpointMu.Unlock()
+		metrics.CopiedBytes.WithLabelValues(metrics.BackupTaskLabel).Add(float64(len(data)))
+		return nil
+	})
+}
 
-	insertPath := fmt.Sprintf("%s%s/%v/%v/%v/", rootPath, "insert_log", segmentBackupInfo.GetCollectionId(), segmentBackupInfo.GetPartitionId(), segmentBackupInfo.GetSegmentId())
-	log.Debug("insertPath", zap.String("bucket", b.milvusBucketName), zap.String("insertPath", insertPath))
-	fieldsLogDir, _, err := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, insertPath, false)
+func (b *BackupContext) fillSegmentBackupInfo(ctx context.Context, segmentBackupInfo *backuppb.SegmentBackupInfo) (*backuppb.SegmentBackupInfo, error) {
+	var size int64 = 0
+
+	// the binlogs of a segment are under one of the root paths of the layout, the one of its collection is detected
+	// by the first segment found
+	layout := b.getBinlogLayout()
+	insertDirs := layout.SegmentDirs(INSERT_LOG_DIR, segmentBackupInfo.GetCollectionId(), segmentBackupInfo.GetPartitionId(), segmentBackupInfo.GetSegmentId())
+	var insertPath, rootPath string
+	var fieldsLogDir []string
+	for _, insertDir := range insertDirs {
+		insertPath = insertDir.Dir
+		log.Debug("insertPath", zap.String("bucket", b.milvusBucketName), zap.String("insertPath", insertPath))
+		var err error
+		fieldsLogDir, _, err = b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, insertPath, false)
+		if err != nil {
+			log.Error("Fail to list segment path", zap.String("insertPath", insertPath), zap.Error(err))
+			return segmentBackupInfo, err
+		}
+		if len(fieldsLogDir) > 0 {
+			rootPath = insertDir.RootPath
+			layout.Detected(segmentBackupInfo.GetCollectionId(), rootPath)
+			break
+		}
+	}
 	if len(fieldsLogDir) == 0 {
-		msg := fmt.Sprint("Get empty input path, but segment should not be empty, %s", insertPath)
+		paths := make([]string, 0, len(insertDirs))
+		for _, insertDir := range insertDirs {
+			paths = append(paths, insertDir.Dir)
+		}
+		msg := fmt.Sprintf("Get empty input path, but segment should not be empty, %s", strings.Join(paths, ", "))
 		return segmentBackupInfo, errors.New(msg)
 	}
-	if err != nil {
-		log.Error("Fail to list segment path", zap.String("insertPath", insertPath), zap.Error(err))
-		return segmentBackupInfo, err
-	}
 	log.Debug("fieldsLogDir", zap.String("bucket", b.milvusBucketName), zap.Any("fieldsLogDir", fieldsLogDir))
 	insertLogs := make([]*backuppb.FieldBinlog, 0)
 	for _, fieldLogDir := range fieldsLogDir {
@@ -789,8 +1864,18 @@ func (b *BackupContext) fillSegmentBackupInfo(ctx context.Context, segmentBackup
 		})
 	}
 
-	deltaLogPath := fmt.Sprintf("%s%s/%v/%v/%v/", rootPath, "delta_log", segmentBackupInfo.GetCollectionId(), segmentBackupInfo.GetPartitionId(), segmentBackupInfo.GetSegmentId())
-	deltaFieldsLogDir, _, _ := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, deltaLogPath, false)
+	// delta logs are under the root path of the insert logs
+	var deltaLogPath string
+	for _, deltaDir := range layout.SegmentDirs(DELTA_LOG_DIR, segmentBackupInfo.GetCollectionId(), segmentBackupInfo.GetPartitionId(), segmentBackupInfo.GetSegmentId()) {
+		if deltaDir.RootPath == rootPath {
+			deltaLogPath = deltaDir.Dir
+			break
+		}
+	}
+	var deltaFieldsLogDir []string
+	if deltaLogPath != "" {
+		deltaFieldsLogDir, _, _ = b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, deltaLogPath, false)
+	}
 	deltaLogs := make([]*backuppb.FieldBinlog, 0)
 	for _, deltaFieldLogDir := range deltaFieldsLogDir {
 		binlogPaths, sizes, _ := b.getStorageClient().ListWithPrefix(ctx, b.milvusBucketName, deltaFieldLogDir, false)
@@ -841,3 +1926,174 @@ func (b *BackupContext) fillSegmentBackupInfo(ctx context.Context, segmentBackup
 	segmentBackupInfo.Size = size
 	return segmentBackupInfo, nil
 }
+
+// baseBackupSegments returns all segments of the base backup indexed by segment id
+// withBackupObjectLock returns a context with which the files written are locked with the object lock of the backup
+func withBackupObjectLock(ctx context.Context, backup *backuppb.BackupInfo) context.Context {
+	return storage.WithObjectLock(ctx, storage.ObjectLock{
+		Mode:        backup.GetObjectLockMode(),
+		RetainUntil: time.Unix(backup.GetObjectLockRetainUntil(), 0),
+	})
+}
+
+// isObjectLocked returns whether the files of the backup can't be deleted at now
+func isObjectLocked(backup *backuppb.BackupInfo, now time.Time) bool {
+	return backup.GetObjectLockMode() != "" && now.Before(time.Unix(backup.GetObjectLockRetainUntil(), 0))
+}
+
+// requestServerSideEncryption returns the server side encryption of the backup to create, the fields set in request
+// override backup.serverSideEncryption in config, sse type none disables the encryption of config
+func (b *BackupContext) requestServerSideEncryption(request *backuppb.CreateBackupRequest) (storage.ServerSideEncryption, error) {
+	cfg := b.params.BackupCfg
+	sse := storage.ServerSideEncryption{Type: strings.ToLower(request.GetSseType()), KMSKeyID: request.GetSseKmsKeyId()}
+	if sse.Type == "" {
+		sse.Type = cfg.SSEType
+	}
+	if sse.KMSKeyID == "" {
+		sse.KMSKeyID = cfg.SSEKMSKeyID
+	}
+	keyRef := request.GetSseCustomerKey()
+	if keyRef == "" {
+		keyRef = cfg.SSECustomerKey
+	}
+	switch sse.Type {
+	case "", "none":
+		return storage.ServerSideEncryption{}, nil
+	case storage.SSETypeKMS:
+		return sse, nil
+	case storage.SSETypeCustomer:
+		if keyRef == "" {
+			return sse, errors.New("customer key is required by sse type customer")
+		}
+		key, err := utils.ParseEncryptionKey(keyRef)
+		if err != nil {
+			return sse, err
+		}
+		return storage.ServerSideEncryption{Type: sse.Type, CustomerKey: key}, nil
+	default:
+		return sse, fmt.Errorf("unsupported sse type: %s", sse.Type)
+	}
+}
+
+// backupServerSideEncryption returns the server side encryption recorded in the meta of backup. The customer key is
+// parsed from keyRef, backup.serverSideEncryption.customerKey in config if empty, and must match the recorded MD5.
+func (b *BackupContext) backupServerSideEncryption(backup *backuppb.BackupInfo, keyRef string) (storage.ServerSideEncryption, error) {
+	sse := storage.ServerSideEncryption{Type: backup.GetSseType(), KMSKeyID: backup.GetSseKmsKeyId()}
+	if sse.Type != storage.SSETypeCustomer {
+		return sse, nil
+	}
+	if keyRef == "" {
+		keyRef = b.params.BackupCfg.SSECustomerKey
+	}
+	if keyRef == "" {
+		return sse, fmt.Errorf("backup %s is encrypted by customer key, but the key is not provided", backup.GetName())
+	}
+	key, err := utils.ParseEncryptionKey(keyRef)
+	if err != nil {
+		return sse, err
+	}
+	sse.CustomerKey = key
+	if sse.CustomerKeyMD5() != backup.GetSseCustomerKeyMd5() {
+		return sse, fmt.Errorf("customer key doesn't match the key encrypting backup %s", backup.GetName())
+	}
+	return sse, nil
+}
+
+// validateServerSideEncryption returns an error if sse is not supported by the storage
+func validateServerSideEncryption(storageType string, sse storage.ServerSideEncryption) error {
+	switch {
+	case sse.Type == "", paramtable.IsS3Compatible(storageType):
+		return nil
+	case storageType == paramtable.CloudProviderGCP:
+		if sse.Type == storage.SSETypeKMS && sse.KMSKeyID == "" {
+			return errors.New("kms key id is required by sse type kms on gcs")
+		}
+		return nil
+	default:
+		return fmt.Errorf("server side encryption is not supported by backup storage %s", storageType)
+	}
+}
+
+// checkServerSideEncryption writes and reads a file with sse, it fails if the key can't be used to encrypt or decrypt,
+// e.g. denied by the policy of kms key
+func (b *BackupContext) checkServerSideEncryption(ctx context.Context, backupName string, sse storage.ServerSideEncryption) error {
+	if sse.Type == "" {
+		return nil
+	}
+	checkPath := b.backupRootPath + SEPERATOR + "milvus_backup_sse_check_" + backupName
+	if err := b.getBackupStorageClient().Write(storage.WithServerSideEncryption(ctx, sse), b.backupBucketName, checkPath, []byte{1}); err != nil {
+		return err
+	}
+	defer b.getBackupStorageClient().Remove(ctx, b.backupBucketName, checkPath)
+	_, err := b.getBackupStorageClient().Read(storage.WithSourceServerSideEncryption(ctx, sse), b.backupBucketName, checkPath)
+	return err
+}
+
+func baseBackupSegments(baseBackup *backuppb.BackupInfo, backup *backuppb.BackupInfo) map[int64]*backuppb.SegmentBackupInfo {
+	current := make(map[int64]*backuppb.CollectionBackupInfo, len(backup.GetCollectionBackups()))
+	for _, collection := range backup.GetCollectionBackups() {
+		current[collection.GetCollectionId()] = collection
+	}
+	segments := make(map[int64]*backuppb.SegmentBackupInfo)
+	for _, collection := range baseBackup.GetCollectionBackups() {
+		// binlogs of the collections failed in a partial base backup may not be copied
+		if isFailedCollection(collection) {
+			continue
+		}
+		// binlogs referenced from base backup are looked up by the path template of the incremental backup, the
+		// segments are copied again if the collection is renamed into other dirs or the template is changed
+		if collectionBackup, ok := current[collection.GetCollectionId()]; ok && !sameBinlogGroupDirs(collection, collectionBackup) {
+			continue
+		}
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				segments[segment.GetSegmentId()] = segment
+			}
+		}
+	}
+	return segments
+}
+
+// isSameSegmentFiles checks whether two backups of one segment hold the same binlog files.
+// Segment is immutable after flush except for new delta logs, and compaction generates new segments,
+// so comparing log paths and sizes is enough to find out whether the segment changed.
+func isSameSegmentFiles(base, current *backuppb.SegmentBackupInfo) bool {
+	if base.GetSegmentId() != current.GetSegmentId() || base.GetSize() != current.GetSize() {
+		return false
+	}
+	logFiles := func(fieldBinlogs []*backuppb.FieldBinlog) map[string]int64 {
+		files := make(map[string]int64)
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				files[binlog.GetLogPath()] = binlog.GetLogSize()
+			}
+		}
+		return files
+	}
+	sameFiles := func(a, b map[string]int64) bool {
+		if len(a) != len(b) {
+			return false
+		}
+		for path, size := range a {
+			if otherSize, ok := b[path]; !ok || otherSize != size {
+				return false
+			}
+		}
+		return true
+	}
+	return sameFiles(logFiles(base.GetBinlogs()), logFiles(current.GetBinlogs())) &&
+		sameFiles(logFiles(base.GetDeltalogs()), logFiles(current.GetDeltalogs()))
+}
+
+// replicaPlacement returns the number of replicas of a loaded collection and the resource groups they are in
+func replicaPlacement(replicas []*milvuspb.ReplicaInfo) (int32, []string) {
+	resourceGroups := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, replica := range replicas {
+		if name := replica.GetResourceGroupName(); name != "" && !seen[name] {
+			seen[name] = true
+			resourceGroups = append(resourceGroups, name)
+		}
+	}
+	return int32(len(replicas)), resourceGroups
+}
@@ -0,0 +1,242 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func TestWriteBackupCheckpoint(t *testing.T) {
+	chunkManager := &memoryChunkManager{files: make(map[string][]byte)}
+	var storageClient storage.ChunkManager = chunkManager
+	b := &BackupContext{storageClient: &storageClient, backupRootPath: "backup"}
+	ctx := context.Background()
+
+	segment := &backuppb.SegmentBackupInfo{SegmentId: 1, CollectionId: 1, PartitionId: 1}
+	backupInfo := &backuppb.BackupInfo{
+		Name:      "test_backup",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_EXECUTING,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId: 1,
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:    1,
+				CollectionId:   1,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{segment},
+			}},
+		}},
+	}
+
+	assert.NoError(t, b.writeBackupCheckpoint(ctx, backupInfo, true))
+	checkpoint, err := b.readBackup(ctx, "", "backup/test_backup")
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_EXECUTING, checkpoint.GetStateCode())
+	assert.False(t, checkpoint.GetCollectionBackups()[0].GetPartitionBackups()[0].GetSegmentBackups()[0].GetBackuped())
+
+	// skipped within checkpoint interval
+	segment.Backuped = true
+	assert.NoError(t, b.writeBackupCheckpoint(ctx, backupInfo, false))
+	checkpoint, err = b.readBackup(ctx, "", "backup/test_backup")
+	assert.NoError(t, err)
+	assert.False(t, checkpoint.GetCollectionBackups()[0].GetPartitionBackups()[0].GetSegmentBackups()[0].GetBackuped())
+
+	assert.NoError(t, b.writeBackupCheckpoint(ctx, backupInfo, true))
+	checkpoint, err = b.readBackup(ctx, "", "backup/test_backup")
+	assert.NoError(t, err)
+	assert.True(t, checkpoint.GetCollectionBackups()[0].GetPartitionBackups()[0].GetSegmentBackups()[0].GetBackuped())
+}
+
+// syncChunkManager serializes the calls to memoryChunkManager used by the copies of binlogs
+type syncChunkManager struct {
+	*memoryChunkManager
+	mu sync.Mutex
+}
+
+func (m *syncChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.memoryChunkManager.Read(ctx, bucketName, filePath)
+}
+
+func (m *syncChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.memoryChunkManager.Write(ctx, bucketName, filePath, content)
+}
+
+// the results of binlogs are recorded while checkpoints serialize the backup, run with -race
+func TestCopyBinlogFileWithCheckpoint(t *testing.T) {
+	chunkManager := &syncChunkManager{memoryChunkManager: &memoryChunkManager{files: make(map[string][]byte)}}
+	var storageClient storage.ChunkManager = chunkManager
+	b := &BackupContext{storageClient: &storageClient, backupRootPath: "backup"}
+	b.params.BackupCfg.Checksum = true
+	ctx := context.Background()
+
+	segment := &backuppb.SegmentBackupInfo{SegmentId: 1, CollectionId: 1, PartitionId: 1, Binlogs: []*backuppb.FieldBinlog{{FieldID: 100}}}
+	for i := 0; i < 20; i++ {
+		logPath := fmt.Sprintf("files/insert_log/1/1/1/100/%d", i)
+		chunkManager.files[logPath] = []byte(logPath)
+		segment.Binlogs[0].Binlogs = append(segment.Binlogs[0].Binlogs, &backuppb.Binlog{LogPath: logPath, LogSize: int64(len(logPath))})
+	}
+	backupInfo := &backuppb.BackupInfo{
+		Name: "test_backup",
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId: 1,
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:    1,
+				CollectionId:   1,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{segment},
+			}},
+		}},
+	}
+
+	var wg sync.WaitGroup
+	for _, binlog := range segment.GetBinlogs()[0].GetBinlogs() {
+		binlog := binlog
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, b.copyBinlogFile(ctx, backupInfo, segment, binlog, "backup/test_backup/binlogs/"+binlog.GetLogPath()))
+		}()
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, b.writeBackupCheckpoint(ctx, backupInfo, true))
+		}()
+	}
+	wg.Wait()
+
+	assert.NoError(t, b.writeBackupCheckpoint(ctx, backupInfo, true))
+	checkpoint, err := b.readBackup(ctx, "", "backup/test_backup")
+	assert.NoError(t, err)
+	for _, binlog := range checkpoint.GetCollectionBackups()[0].GetPartitionBackups()[0].GetSegmentBackups()[0].GetBinlogs()[0].GetBinlogs() {
+		assert.Equal(t, binlog.GetLogSize(), binlog.GetBackupSize())
+		assert.NotZero(t, binlog.GetCrc32C())
+	}
+}
+
+func TestCollectionPatterns(t *testing.T) {
+	assert.True(t, isCollectionPattern("prod_*"))
+	assert.True(t, isCollectionPattern("coll_[0-9]"))
+	assert.False(t, isCollectionPattern("prod_coll"))
+
+	assert.NoError(t, validateCollectionPatterns(&backuppb.CreateBackupRequest{
+		CollectionNames: []string{"coll", "db1.prod_*"},
+		CollectionRegex: "^prod_.*$",
+	}))
+	assert.Error(t, validateCollectionPatterns(&backuppb.CreateBackupRequest{CollectionNames: []string{"coll_[0-9"}}))
+	assert.Error(t, validateCollectionPatterns(&backuppb.CreateBackupRequest{CollectionRegex: "prod_("}))
+
+	collections := dedupCollections([]collectionStruct{{"default", "a"}, {"db1", "a"}, {"default", "a"}})
+	assert.Equal(t, []collectionStruct{{"default", "a"}, {"db1", "a"}}, collections)
+}
+
+func TestSelectPartitions(t *testing.T) {
+	partitions := map[string]*backuppb.PartitionNames{
+		"coll1":     {Names: []string{"p1"}},
+		"db1.coll2": {Names: []string{"p2", "p3"}},
+	}
+	assert.Equal(t, []string{"p1"}, requestPartitions(partitions, "default", "coll1"))
+	assert.Equal(t, []string{"p2", "p3"}, requestPartitions(partitions, "db1", "coll2"))
+	assert.Nil(t, requestPartitions(partitions, "db1", "coll1"))
+
+	collection := &backuppb.CollectionBackupInfo{DbName: "default", CollectionName: "coll1", Schema: &backuppb.CollectionSchema{}}
+	all := []*entity.Partition{{ID: 1, Name: "_default"}, {ID: 2, Name: "p1"}}
+	selected, err := filterPartitions(collection, all, []string{"p1"})
+	assert.NoError(t, err)
+	assert.Equal(t, []*entity.Partition{{ID: 2, Name: "p1"}}, selected)
+	_, err = filterPartitions(collection, all, []string{"p2"})
+	assert.Error(t, err)
+
+	collection.Schema.Fields = []*backuppb.FieldSchema{{Name: "key", IsPartitionKey: true}}
+	_, err = filterPartitions(collection, all, []string{"p1"})
+	assert.Error(t, err)
+}
+
+func TestServerSideEncryption(t *testing.T) {
+	b := &BackupContext{}
+	b.params.BackupCfg.SSEType = storage.SSETypeKMS
+	b.params.BackupCfg.SSEKMSKeyID = "config-key"
+
+	sse, err := b.requestServerSideEncryption(&backuppb.CreateBackupRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, storage.ServerSideEncryption{Type: storage.SSETypeKMS, KMSKeyID: "config-key"}, sse)
+	sse, err = b.requestServerSideEncryption(&backuppb.CreateBackupRequest{SseKmsKeyId: "request-key"})
+	assert.NoError(t, err)
+	assert.Equal(t, "request-key", sse.KMSKeyID)
+	sse, err = b.requestServerSideEncryption(&backuppb.CreateBackupRequest{SseType: "none"})
+	assert.NoError(t, err)
+	assert.Equal(t, "", sse.Type)
+	_, err = b.requestServerSideEncryption(&backuppb.CreateBackupRequest{SseType: "customer"})
+	assert.Error(t, err)
+	_, err = b.requestServerSideEncryption(&backuppb.CreateBackupRequest{SseType: "aes256"})
+	assert.Error(t, err)
+
+	key := strings.Repeat("01", 32)
+	sse, err = b.requestServerSideEncryption(&backuppb.CreateBackupRequest{SseType: "customer", SseCustomerKey: key})
+	assert.NoError(t, err)
+	assert.Equal(t, storage.SSETypeCustomer, sse.Type)
+	assert.Equal(t, "", sse.KMSKeyID)
+	assert.Len(t, sse.CustomerKey, 32)
+
+	assert.NoError(t, validateServerSideEncryption("aws", sse))
+	assert.NoError(t, validateServerSideEncryption("gcp", sse))
+	assert.Error(t, validateServerSideEncryption("gcp", storage.ServerSideEncryption{Type: storage.SSETypeKMS}))
+	assert.Error(t, validateServerSideEncryption("azure", sse))
+	assert.NoError(t, validateServerSideEncryption("azure", storage.ServerSideEncryption{}))
+
+	// the customer key of backup is checked against the MD5 in meta
+	backup := &backuppb.BackupInfo{Name: "b1", SseType: sse.Type, SseCustomerKeyMd5: sse.CustomerKeyMD5()}
+	_, err = b.backupServerSideEncryption(backup, "")
+	assert.Error(t, err)
+	restored, err := b.backupServerSideEncryption(backup, key)
+	assert.NoError(t, err)
+	assert.Equal(t, sse, restored)
+	_, err = b.backupServerSideEncryption(backup, strings.Repeat("02", 32))
+	assert.Error(t, err)
+	b.params.BackupCfg.SSECustomerKey = key
+	_, err = b.backupServerSideEncryption(backup, "")
+	assert.NoError(t, err)
+
+	restored, err = b.backupServerSideEncryption(&backuppb.BackupInfo{SseType: storage.SSETypeKMS, SseKmsKeyId: "k"}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, storage.ServerSideEncryption{Type: storage.SSETypeKMS, KMSKeyID: "k"}, restored)
+}
+
+func TestReplicaPlacement(t *testing.T) {
+	replicaNumber, resourceGroups := replicaPlacement([]*milvuspb.ReplicaInfo{
+		{ReplicaID: 1, ResourceGroupName: "rg1"},
+		{ReplicaID: 2, ResourceGroupName: "rg2"},
+		{ReplicaID: 3, ResourceGroupName: "rg1"},
+	})
+	assert.Equal(t, int32(3), replicaNumber)
+	assert.Equal(t, []string{"rg1", "rg2"}, resourceGroups)
+
+	replicaNumber, resourceGroups = replicaPlacement(nil)
+	assert.Equal(t, int32(0), replicaNumber)
+	assert.Empty(t, resourceGroups)
+}
+
+func TestFlushPolicy(t *testing.T) {
+	assert.Equal(t, FlushPolicyWait, backupFlushPolicy(&backuppb.CreateBackupRequest{}))
+	assert.Equal(t, FlushPolicySkip, backupFlushPolicy(&backuppb.CreateBackupRequest{Force: true}))
+	assert.Equal(t, FlushPolicyTimeout, backupFlushPolicy(&backuppb.CreateBackupRequest{FlushTimeout: 10}))
+	assert.Equal(t, FlushPolicyWait, backupFlushPolicy(&backuppb.CreateBackupRequest{Force: true, FlushPolicy: FlushPolicyWait}))
+
+	b := &BackupContext{started: true}
+	for _, request := range []*backuppb.CreateBackupRequest{
+		{FlushPolicy: "async"},
+		{FlushTimeout: -1},
+		{FlushPolicy: FlushPolicyTimeout},
+	} {
+		resp := b.CreateBackup(context.Background(), request)
+		assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	}
+}
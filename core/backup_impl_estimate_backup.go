@@ -0,0 +1,134 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// EstimateBackup lists the collections a CreateBackupRequest would backup with their segment numbers and binlog sizes.
+// Collections are not flushed and nothing is written, so data not persisted yet is not counted.
+func (b *BackupContext) EstimateBackup(ctx context.Context, request *backuppb.CreateBackupRequest) *backuppb.EstimateBackupResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive EstimateBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.Strings("collections", request.GetCollectionNames()),
+		zap.String("databaseCollections", utils.GetCreateDBCollections(request)),
+		zap.String("collectionRegex", request.GetCollectionRegex()),
+		zap.Any("partitions", request.GetPartitions()))
+
+	resp := &backuppb.EstimateBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if err := validateCollectionPatterns(request); err != nil {
+		log.Error("illegal collection pattern", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+	collections, err := b.parseBackupCollections(request)
+	if err != nil {
+		log.Error("parse backup collections from request failed", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	for _, collection := range collections {
+		partitionNames := requestPartitions(request.GetPartitions(), collection.db, collection.collectionName)
+		estimate, err := b.estimateCollection(ctx, collection, partitionNames)
+		if err != nil {
+			log.Error("fail to estimate collection", zap.String("db", collection.db), zap.String("collection", collection.collectionName), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		resp.Collections = append(resp.Collections, estimate)
+		resp.SegmentNum += estimate.GetSegmentNum()
+		resp.Size += estimate.GetSize()
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	log.Info("return EstimateBackupResponse",
+		zap.String("requestId", resp.GetRequestId()),
+		zap.Int("collections", len(resp.GetCollections())),
+		zap.Int64("segmentNum", resp.GetSegmentNum()),
+		zap.Int64("size", resp.GetSize()))
+	return resp
+}
+
+// estimateCollection sums up the persisted segments of the partitions to backup of a collection
+func (b *BackupContext) estimateCollection(ctx context.Context, collection collectionStruct, partitionNames []string) (*backuppb.CollectionEstimate, error) {
+	partitions, err := b.getMilvusClient().ShowPartitions(ctx, collection.db, collection.collectionName)
+	if err != nil {
+		return nil, err
+	}
+	if len(partitionNames) > 0 {
+		completeCollection, err := b.getMilvusClient().DescribeCollection(ctx, collection.db, collection.collectionName)
+		if err != nil {
+			return nil, err
+		}
+		fields := make([]*backuppb.FieldSchema, 0, len(completeCollection.Schema.Fields))
+		for _, field := range completeCollection.Schema.Fields {
+			fields = append(fields, &backuppb.FieldSchema{Name: field.Name, IsPartitionKey: field.IsPartitionKey})
+		}
+		collectionBackup := &backuppb.CollectionBackupInfo{
+			DbName:         collection.db,
+			CollectionName: collection.collectionName,
+			Schema:         &backuppb.CollectionSchema{Fields: fields},
+		}
+		partitions, err = filterPartitions(collectionBackup, partitions, partitionNames)
+		if err != nil {
+			return nil, err
+		}
+	}
+	partitionIDs := make(map[int64]bool, len(partitions))
+	for _, partition := range partitions {
+		partitionIDs[partition.ID] = true
+	}
+
+	segments, err := b.getMilvusClient().GetPersistentSegmentInfo(ctx, collection.db, collection.collectionName)
+	if err != nil {
+		return nil, err
+	}
+	estimate := &backuppb.CollectionEstimate{
+		DbName:         collection.db,
+		CollectionName: collection.collectionName,
+		PartitionNum:   int32(len(partitions)),
+	}
+	for _, segment := range segments {
+		if !partitionIDs[segment.ParititionID] {
+			continue
+		}
+		segmentBackupInfo, err := b.fillSegmentBackupInfo(ctx, &backuppb.SegmentBackupInfo{
+			SegmentId:    segment.ID,
+			CollectionId: segment.CollectionID,
+			PartitionId:  segment.ParititionID,
+			NumOfRows:    segment.NumRows,
+		})
+		if err != nil {
+			return nil, err
+		}
+		estimate.SegmentNum++
+		estimate.NumOfRows += segment.NumRows
+		estimate.Size += segmentBackupInfo.GetSize()
+	}
+	return estimate, nil
+}
@@ -0,0 +1,261 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// GarbageCollect removes the files in backup storage not referenced by any backup: directories without backup meta,
+// left by backups deleted or failed halfway, binlogs in successful backups not recorded in their meta, and dedup
+// objects not referenced by any backup.
+// Unfinished backups and backups whose meta can't be read are never touched.
+func (b *BackupContext) GarbageCollect(ctx context.Context, request *backuppb.GarbageCollectRequest) *backuppb.GarbageCollectResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive GarbageCollectRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.Bool("dryRun", request.GetDryRun()))
+
+	resp := &backuppb.GarbageCollectResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	// files are listed before the meta is read, files of the backups started later are not listed.
+	// A backup writes its checkpoint before copying any binlog, so the files of executing backups always have meta.
+	paths, sizes, err := b.getBackupStorageClient().ListWithPrefix(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR, true)
+	if err != nil {
+		log.Error("fail to list backup storage", zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	fileSizes := make(map[string]int64, len(paths))
+	for i, path := range paths {
+		fileSizes[path] = sizes[i]
+	}
+
+	backups := make([]*backuppb.BackupInfo, 0)
+	unreadable := make(map[string]bool)
+	for _, path := range paths {
+		backupName, _ := splitBackupPath(b.backupRootPath, path)
+		if backupName == "" || path != BackupMetaPath(b.backupRootPath, backupName) {
+			continue
+		}
+		getResp := b.GetBackup(ctx, &backuppb.GetBackupRequest{BackupName: backupName})
+		if getResp.GetCode() != backuppb.ResponseCode_Success || getResp.GetData() == nil {
+			log.Warn("fail to read backup, its files are kept", zap.String("backupName", backupName), zap.String("msg", getResp.GetMsg()))
+			unreadable[backupName] = true
+			continue
+		}
+		backups = append(backups, getResp.GetData())
+	}
+
+	orphanedDirs, orphanedFiles := b.selectOrphanedFiles(paths, backups, unreadable)
+	resp.RemovedFiles = orphanedFiles
+	for _, file := range orphanedFiles {
+		resp.RemovedSize += fileSizes[file]
+	}
+	log.Info("select orphaned files",
+		zap.Strings("dirs", orphanedDirs),
+		zap.Int("files", len(orphanedFiles)),
+		zap.Int64("size", resp.GetRemovedSize()))
+
+	if request.GetDryRun() {
+		resp.Code = backuppb.ResponseCode_Success
+		resp.Msg = "success"
+		return resp
+	}
+
+	removedDirs := make(map[string]bool, len(orphanedDirs))
+	for _, dir := range orphanedDirs {
+		if err := b.getBackupStorageClient().RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, dir)); err != nil {
+			log.Error("fail to remove orphaned dir", zap.String("dir", dir), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("fail to remove orphaned dir %s: %s", dir, err.Error())
+			return resp
+		}
+		removedDirs[dir] = true
+	}
+	for _, file := range orphanedFiles {
+		if backupName, _ := splitBackupPath(b.backupRootPath, file); removedDirs[backupName] {
+			continue
+		}
+		if err := b.getBackupStorageClient().Remove(ctx, b.backupBucketName, file); err != nil {
+			log.Error("fail to remove orphaned file", zap.String("file", file), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("fail to remove orphaned file %s: %s", file, err.Error())
+			return resp
+		}
+	}
+
+	// references of dedup objects are counted again, in case the index is not updated by failed backups and deletes
+	if hasDedupObjects(b.backupRootPath, paths) && dedupObjectsCollectable(backups, unreadable) {
+		b.dedupMu.Lock()
+		err := b.rebuildDedupIndex(ctx, backups)
+		b.dedupMu.Unlock()
+		if err != nil {
+			log.Error("fail to rebuild dedup index", zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("fail to rebuild dedup index: %s", err.Error())
+			return resp
+		}
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	log.Info("return GarbageCollectResponse",
+		zap.String("requestId", resp.GetRequestId()),
+		zap.Int32("code", int32(resp.GetCode())),
+		zap.Int("removedFiles", len(resp.GetRemovedFiles())),
+		zap.Int64("removedSize", resp.GetRemovedSize()))
+	return resp
+}
+
+// selectOrphanedFiles returns the backup dirs without meta and all orphaned files in the listed paths.
+// Binlogs of a successful or partial backup are orphaned if they are not referenced by any backup, the binlogs of the
+// collections failed in a partial backup are never referenced. If any binlog recorded
+// in the meta of a backup is not found, like the milvus root path is changed after backup, the binlog paths of the
+// backup can't be trusted and its files are kept.
+func (b *BackupContext) selectOrphanedFiles(paths []string, backups []*backuppb.BackupInfo, unreadable map[string]bool) ([]string, []string) {
+	listed := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		listed[path] = true
+	}
+
+	withMeta := make(map[string]bool, len(backups)+len(unreadable))
+	for backupName := range unreadable {
+		withMeta[backupName] = true
+	}
+	referenced := make(map[string]bool)
+	collectable := make(map[string]bool)
+	for _, backup := range backups {
+		withMeta[backup.GetName()] = true
+		complete := true
+		for _, collection := range backup.GetCollectionBackups() {
+			// files copied for the collections failed in a partial backup are never restored
+			if backup.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_PARTIAL && isFailedCollection(collection) {
+				continue
+			}
+			for _, partition := range collection.GetPartitionBackups() {
+				for _, segment := range partition.GetSegmentBackups() {
+					backupName := backup.GetName()
+					if segment.GetRefBackupName() != "" {
+						backupName = segment.GetRefBackupName()
+					}
+					fieldBinlogs := append(append(append([]*backuppb.FieldBinlog{}, segment.GetBinlogs()...), segment.GetDeltalogs()...), segment.GetStatslogs()...)
+					for _, fieldBinlog := range fieldBinlogs {
+						for _, binlog := range fieldBinlog.GetBinlogs() {
+							filePath := b.binlogBackupPath(backupName, collection, segment, binlog.GetLogPath())
+							if binlog.GetObjectName() != "" {
+								filePath = DedupObjectPath(b.backupRootPath, binlog.GetObjectName())
+							}
+							referenced[filePath] = true
+							if segment.GetRefBackupName() == "" && !listed[filePath] {
+								complete = false
+							}
+						}
+					}
+				}
+			}
+		}
+		if !isFinishedBackup(backup) {
+			continue
+		}
+		if !complete {
+			log.Warn("binlogs recorded in backup meta are not found, skip collecting its files", zap.String("backupName", backup.GetName()))
+			continue
+		}
+		collectable[backup.GetName()] = true
+	}
+
+	objectsCollectable := dedupObjectsCollectable(backups, unreadable)
+	b.dedupMu.Lock()
+	defer b.dedupMu.Unlock()
+	dirs := make(map[string]bool)
+	files := make([]string, 0)
+	for _, path := range paths {
+		backupName, _ := splitBackupPath(b.backupRootPath, path)
+		switch {
+		case backupName == DEDUP_DIR:
+			if objectsCollectable && path != DedupIndexPath(b.backupRootPath) && !referenced[path] && !b.isDedupObjectHeld(filepath.Base(path)) {
+				files = append(files, path)
+			}
+		case backupName == LOCK_DIR:
+			// locks of executing backups are removed when they finish, stale ones are taken over by the next backups
+		case backupName == JOB_DIR:
+			// records of jobs are removed after the retention of job state
+		case backupName == "":
+			// files directly under the root path are not part of any backup
+			files = append(files, path)
+		case !withMeta[backupName]:
+			dirs[backupName] = true
+			files = append(files, path)
+		case collectable[backupName] && !strings.HasPrefix(path, BackupMetaDirPath(b.backupRootPath, backupName)+SEPERATOR) && !referenced[path]:
+			// files of a backup besides its meta are binlogs, in the dirs of the path templates of its collections
+			files = append(files, path)
+		}
+	}
+	orphanedDirs := make([]string, 0, len(dirs))
+	for dir := range dirs {
+		orphanedDirs = append(orphanedDirs, dir)
+	}
+	sort.Strings(orphanedDirs)
+	sort.Strings(files)
+	return orphanedDirs, files
+}
+
+// dedupObjectsCollectable returns true if the dedup objects not referenced by any backup can be collected. Objects are
+// shared by all backups, they are kept if the meta of any backup can't be read, or any backup is executing whose
+// objects written may not be recorded in its meta yet.
+func dedupObjectsCollectable(backups []*backuppb.BackupInfo, unreadable map[string]bool) bool {
+	if len(unreadable) > 0 {
+		return false
+	}
+	for _, backup := range backups {
+		switch backup.GetStateCode() {
+		case backuppb.BackupTaskStateCode_BACKUP_INITIAL, backuppb.BackupTaskStateCode_BACKUP_EXECUTING:
+			return false
+		}
+	}
+	return true
+}
+
+// hasDedupObjects returns true if any listed path is in the dedup dir
+func hasDedupObjects(backupRootPath string, paths []string) bool {
+	for _, path := range paths {
+		if backupName, _ := splitBackupPath(backupRootPath, path); backupName == DEDUP_DIR {
+			return true
+		}
+	}
+	return false
+}
+
+// splitBackupPath splits a path in backup storage into the backup name and the path relative to the backup dir,
+// the backup name is empty for files directly under the root path
+func splitBackupPath(backupRootPath, path string) (string, string) {
+	relative := strings.TrimPrefix(path, backupRootPath+SEPERATOR)
+	i := strings.Index(relative, SEPERATOR)
+	if i < 0 {
+		return "", relative
+	}
+	return relative[:i], relative[i+1:]
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestSelectOrphanedFiles(t *testing.T) {
+	b := &BackupContext{milvusRootPath: "files", backupRootPath: "backup"}
+	newBackup := func(name string, state backuppb.BackupTaskStateCode, segments ...*backuppb.SegmentBackupInfo) *backuppb.BackupInfo {
+		return &backuppb.BackupInfo{
+			Name:      name,
+			StateCode: state,
+			CollectionBackups: []*backuppb.CollectionBackupInfo{{
+				PartitionBackups: []*backuppb.PartitionBackupInfo{{SegmentBackups: segments}},
+			}},
+		}
+	}
+	newSegment := func(id string, ref string) *backuppb.SegmentBackupInfo {
+		return &backuppb.SegmentBackupInfo{
+			PartitionId:   2,
+			RefBackupName: ref,
+			Binlogs: []*backuppb.FieldBinlog{{
+				Binlogs: []*backuppb.Binlog{{LogPath: "files/insert_log/1/2/" + id + "/100/1"}},
+			}},
+		}
+	}
+
+	backups := []*backuppb.BackupInfo{
+		newBackup("full", backuppb.BackupTaskStateCode_BACKUP_SUCCESS, newSegment("3", "")),
+		newBackup("inc", backuppb.BackupTaskStateCode_BACKUP_SUCCESS, newSegment("3", "full"), newSegment("4", "")),
+		newBackup("executing", backuppb.BackupTaskStateCode_BACKUP_EXECUTING),
+		// the binlog of segment 6 is missing, so the paths of the backup are not trusted
+		newBackup("moved", backuppb.BackupTaskStateCode_BACKUP_SUCCESS, newSegment("6", "")),
+	}
+	paths := []string{
+		"backup/full/meta/backup_meta.json",
+		"backup/full/binlogs/insert_log/1/2/3/100/1",
+		"backup/full/binlogs/insert_log/1/2/5/100/1",
+		"backup/inc/meta/backup_meta.json",
+		"backup/inc/binlogs/insert_log/1/2/4/100/1",
+		"backup/executing/meta/backup_meta.json",
+		"backup/executing/binlogs/insert_log/1/2/7/100/1",
+		"backup/moved/meta/backup_meta.json",
+		"backup/moved/binlogs/insert_log/1/2/8/100/1",
+		"backup/encrypted/meta/backup_meta.json",
+		"backup/encrypted/binlogs/insert_log/1/2/9/100/1",
+		"backup/deleted/binlogs/insert_log/1/2/10/100/1",
+		"backup/milvus_backup_check",
+	}
+
+	dirs, files := b.selectOrphanedFiles(paths, backups, map[string]bool{"encrypted": true})
+	assert.Equal(t, []string{"deleted"}, dirs)
+	assert.Equal(t, []string{
+		"backup/deleted/binlogs/insert_log/1/2/10/100/1",
+		"backup/full/binlogs/insert_log/1/2/5/100/1",
+		"backup/milvus_backup_check",
+	}, files)
+}
+
+func TestSplitBackupPath(t *testing.T) {
+	name, relative := splitBackupPath("backup", "backup/full/meta/backup_meta.json")
+	assert.Equal(t, "full", name)
+	assert.Equal(t, "meta/backup_meta.json", relative)
+
+	name, relative = splitBackupPath("backup", "backup/milvus_backup_check")
+	assert.Equal(t, "", name)
+	assert.Equal(t, "milvus_backup_check", relative)
+}
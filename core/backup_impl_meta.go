@@ -0,0 +1,80 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// ExportBackupMeta returns the complete meta of a backup in the backup storage as json, the same as full_meta.json,
+// with collections, schemas, partitions and segments. It is read from the meta files without connecting to milvus,
+// so a backup can be inspected, or its meta moved into another backup storage by ImportBackupMeta.
+func (b *BackupContext) ExportBackupMeta(ctx context.Context, backupName string) ([]byte, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return nil, err
+		}
+	}
+	if backupName == "" {
+		return nil, fmt.Errorf("empty backup name")
+	}
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil {
+		return nil, fmt.Errorf("backup does not exist: %s", backupName)
+	}
+	return json.Marshal(backup)
+}
+
+// ImportBackupMeta writes the meta exported by ExportBackupMeta into the backup storage, encrypted if encryption
+// is enabled. Only meta is written, the binlogs of the backup should be in the backup storage already.
+// An existing backup of the same name is overwritten only if force is true.
+func (b *BackupContext) ImportBackupMeta(ctx context.Context, data []byte, force bool) (*backuppb.BackupInfo, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return nil, err
+		}
+	}
+	backup := &backuppb.BackupInfo{}
+	if err := json.Unmarshal(data, backup); err != nil {
+		return nil, fmt.Errorf("illegal backup meta: %w", err)
+	}
+	if backup.GetName() == "" {
+		return nil, fmt.Errorf("illegal backup meta: empty backup name")
+	}
+	// the meta of an encrypted backup would be written in plain, and its binlogs can't be read
+	if backup.GetEncrypted() && b.encryptionKey == nil {
+		return nil, fmt.Errorf("backup %s is encrypted, but backup.encryption.key is not configured", backup.GetName())
+	}
+	// meta exported by an older milvus-backup is imported in the current layout
+	if _, err := upgradeBackupMeta(backup); err != nil {
+		return nil, err
+	}
+	exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, backup.GetName()))
+	if err != nil {
+		return nil, err
+	}
+	if exist && !force {
+		return nil, fmt.Errorf("backup already exists: %s", backup.GetName())
+	}
+
+	output, err := serialize(backup)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.writeBackupMeta(ctx, backup.GetName(), output); err != nil {
+		return nil, err
+	}
+	log.Info("import backup meta",
+		zap.String("backupName", backup.GetName()),
+		zap.Int("collections", len(backup.GetCollectionBackups())),
+		zap.Bool("overwrite", exist))
+	return backup, nil
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+func TestExportImportBackupMeta(t *testing.T) {
+	ctx := context.Background()
+	var sourceClient storage.ChunkManager = &memoryChunkManager{files: make(map[string][]byte)}
+	source := &BackupContext{storageClient: &sourceClient, backupRootPath: "backup", started: true}
+	var targetClient storage.ChunkManager = &memoryChunkManager{files: make(map[string][]byte)}
+	target := &BackupContext{storageClient: &targetClient, backupRootPath: "other", started: true}
+
+	backupInfo := &backuppb.BackupInfo{
+		Name:            "test_backup",
+		StateCode:       backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		BackupTimestamp: 449000000000000000,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId:   1,
+			CollectionName: "coll",
+			Schema:         &backuppb.CollectionSchema{Name: "coll"},
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:    1,
+				CollectionId:   1,
+				Size:           100,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: 1, CollectionId: 1, PartitionId: 1, Size: 100}},
+			}},
+		}},
+	}
+	assert.NoError(t, source.writeBackupCheckpoint(ctx, backupInfo, true))
+
+	_, err := source.ExportBackupMeta(ctx, "not_exist")
+	assert.Error(t, err)
+	data, err := source.ExportBackupMeta(ctx, "test_backup")
+	assert.NoError(t, err)
+
+	imported, err := target.ImportBackupMeta(ctx, data, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "test_backup", imported.GetName())
+	restored, err := target.readBackup(ctx, "", "other/test_backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "coll", restored.GetCollectionBackups()[0].GetSchema().GetName())
+	assert.Equal(t, int64(100), restored.GetCollectionBackups()[0].GetSize())
+	assert.Equal(t, int64(1), restored.GetCollectionBackups()[0].GetPartitionBackups()[0].GetSegmentBackups()[0].GetSegmentId())
+	assert.Equal(t, uint64(449000000000000000), restored.GetBackupTimestamp())
+
+	// an existing backup is only overwritten by force
+	_, err = target.ImportBackupMeta(ctx, data, false)
+	assert.Error(t, err)
+	_, err = target.ImportBackupMeta(ctx, data, true)
+	assert.NoError(t, err)
+
+	_, err = target.ImportBackupMeta(ctx, []byte("{}"), true)
+	assert.Error(t, err)
+	_, err = target.ImportBackupMeta(ctx, []byte("not json"), true)
+	assert.Error(t, err)
+}
+
+func TestReadEncryptedBackup(t *testing.T) {
+	ctx := context.Background()
+	files := make(map[string][]byte)
+	var client storage.ChunkManager = &memoryChunkManager{files: files}
+	key := make([]byte, utils.EncryptionKeySize)
+	b := &BackupContext{storageClient: &client, backupRootPath: "backup", started: true, encryptionKey: key}
+
+	segment := &backuppb.SegmentBackupInfo{SegmentId: 1, CollectionId: 1, PartitionId: 1, Encrypted: true}
+	backupInfo := &backuppb.BackupInfo{
+		Name:      "test_backup",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		Encrypted: true,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId: 1,
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:    1,
+				CollectionId:   1,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{segment},
+			}},
+		}},
+	}
+	assert.NoError(t, b.writeBackupCheckpoint(ctx, backupInfo, true))
+	backup, err := b.readBackup(ctx, "", "backup/test_backup")
+	assert.NoError(t, err)
+	assert.True(t, backup.GetEncrypted())
+
+	// a meta file of the encrypted backup replaced by a plain one
+	plain, err := b.readBackupFile(ctx, "", SegmentMetaPath("backup", "test_backup"))
+	assert.NoError(t, err)
+	files[SegmentMetaPath("backup", "test_backup")] = plain
+	_, err = b.readBackup(ctx, "", "backup/test_backup")
+	assert.ErrorContains(t, err, "are not encrypted")
+
+	// a binlog of the encrypted segment replaced by a plain one
+	assert.NoError(t, b.writeBackupFile(ctx, "backup/test_backup/binlogs/insert_log/1/1/1/100/1", []byte("binlog")))
+	data, err := b.readSegmentFile(ctx, segment, "", "backup/test_backup/binlogs/insert_log/1/1/1/100/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("binlog"), data)
+	files["backup/test_backup/binlogs/insert_log/1/1/1/100/1"] = []byte("binlog")
+	_, err = b.readSegmentFile(ctx, segment, "", "backup/test_backup/binlogs/insert_log/1/1/1/100/1")
+	assert.ErrorContains(t, err, "is not encrypted")
+	// plain binlogs of the segments not encrypted are read as they are
+	data, err = b.readSegmentFile(ctx, &backuppb.SegmentBackupInfo{SegmentId: 2}, "", "backup/test_backup/binlogs/insert_log/1/1/1/100/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("binlog"), data)
+
+	// encrypted backups can't be imported without the key
+	exported, err := json.Marshal(backupInfo)
+	assert.NoError(t, err)
+	b.encryptionKey = nil
+	_, err = b.ImportBackupMeta(ctx, exported, true)
+	assert.ErrorContains(t, err, "backup.encryption.key is not configured")
+}
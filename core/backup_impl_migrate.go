@@ -0,0 +1,201 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// MigrateTargetParams makes target read backups from the backup bucket of source.
+// If the backup bucket is not in the storage of target milvus, it is accessed by the backupStorage of target.
+func MigrateTargetParams(source *paramtable.BackupParams, target *paramtable.BackupParams) {
+	target.MinioCfg.BackupBucketName = source.MinioCfg.BackupBucketName
+	target.MinioCfg.BackupRootPath = source.MinioCfg.BackupRootPath
+
+	base := target.BackupStorageCfg.Base
+	if source.BackupStorageCfg.Enabled() {
+		target.BackupStorageCfg = source.BackupStorageCfg
+		target.BackupStorageCfg.Base = base
+		target.MergeBackupStorage()
+		return
+	}
+
+	sourceCfg := source.MinioCfg
+	if sourceCfg.StorageType == target.MinioCfg.StorageType &&
+		sourceCfg.Address == target.MinioCfg.Address &&
+		sourceCfg.Port == target.MinioCfg.Port &&
+		sourceCfg.LocalPath == target.MinioCfg.LocalPath {
+		// the same storage, backup files are copied by storage server side
+		target.MinioCfg.BackupAccessKeyID = sourceCfg.BackupAccessKeyID
+		target.MinioCfg.BackupSecretAccessKey = sourceCfg.BackupSecretAccessKey
+		target.MinioCfg.BackupAzureConnectionString = sourceCfg.BackupAzureConnectionString
+		target.MinioCfg.BackupAzureSASToken = sourceCfg.BackupAzureSASToken
+		target.BackupStorageCfg = paramtable.BackupStorageConfig{Base: base}
+		return
+	}
+
+	target.BackupStorageCfg = paramtable.BackupStorageConfig{
+		Base:                        base,
+		StorageType:                 sourceCfg.StorageType,
+		Address:                     sourceCfg.Address,
+		Port:                        sourceCfg.Port,
+		AccessKeyID:                 sourceCfg.AccessKeyID,
+		SecretAccessKey:             sourceCfg.SecretAccessKey,
+		UseSSL:                      sourceCfg.UseSSL,
+		UseIAM:                      sourceCfg.UseIAM,
+		IAMEndpoint:                 sourceCfg.IAMEndpoint,
+		LocalPath:                   sourceCfg.LocalPath,
+		AzureConnectionString:       sourceCfg.BackupAzureConnectionString,
+		AzureSASToken:               sourceCfg.BackupAzureSASToken,
+		GcpCredentialJSON:           sourceCfg.GcpCredentialJSON,
+		GcpKmsKeyName:               sourceCfg.GcpKmsKeyName,
+		GcpUniformBucketLevelAccess: sourceCfg.GcpUniformBucketLevelAccess,
+		GcpUploadChunkSize:          sourceCfg.GcpUploadChunkSize,
+		MultipartPartSize:           sourceCfg.MultipartPartSize,
+		MultipartConcurrency:        sourceCfg.MultipartConcurrency,
+		DisableMultipart:            sourceCfg.DisableMultipart,
+	}
+	if sourceCfg.BackupAccessKeyID != "" {
+		target.BackupStorageCfg.AccessKeyID = sourceCfg.BackupAccessKeyID
+		target.BackupStorageCfg.SecretAccessKey = sourceCfg.BackupSecretAccessKey
+	}
+}
+
+// Migrate moves collections from the milvus of b into the milvus of target, target should be created with
+// MigrateTargetParams. Collections are backed up one by one, each into a backup of its own, and every backup
+// is restored into target while the next collection is being backed up, so that backup and restore are pipelined.
+// The backups are removed after restored unless keepBackups is set.
+func (b *BackupContext) Migrate(ctx context.Context, target *BackupContext, request *backuppb.CreateBackupRequest, restoreRequest *backuppb.RestoreBackupRequest, keepBackups bool) error {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return err
+		}
+	}
+	if !target.started {
+		if err := target.Start(); err != nil {
+			return err
+		}
+	}
+
+	name := request.GetBackupName()
+	if name == "" {
+		name = "migrate_" + fmt.Sprint(time.Now().UTC().Format("2006_01_02_15_04_05_")) + fmt.Sprint(time.Now().Nanosecond())
+	}
+	if err := utils.ValidateBackupName(name, BACKUP_NAME); err != nil {
+		log.Error("illegal backup name", zap.Error(err))
+		return err
+	}
+
+	if err := validateCollectionPatterns(request); err != nil {
+		log.Error("illegal collection pattern", zap.Error(err))
+		return err
+	}
+	collections, err := b.parseBackupCollections(request)
+	if err != nil {
+		log.Error("parse migrate collections from request failed", zap.Error(err))
+		return err
+	}
+	if len(collections) == 0 {
+		return errors.New("no collection to migrate")
+	}
+	log.Info("start migrate", zap.String("backupName", name), zap.Int("collectionNum", len(collections)))
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// unbuffered, at most one collection is backed up ahead of the restore
+	backupNames := make(chan string)
+	restoreErr := make(chan error, 1)
+	go func() {
+		defer close(restoreErr)
+		for backupName := range backupNames {
+			if err := b.migrateRestore(ctx, target, restoreRequest, backupName, keepBackups); err != nil {
+				restoreErr <- err
+				cancel()
+				// drain the backups so that the producer is not blocked
+				for range backupNames {
+				}
+				return
+			}
+		}
+	}()
+
+	var backupErr error
+	for i, collection := range collections {
+		backupName := fmt.Sprintf("%s_%d", name, i)
+		createRequest := proto.Clone(request).(*backuppb.CreateBackupRequest)
+		createRequest.RequestId = ""
+		createRequest.BackupName = backupName
+		createRequest.CollectionNames = []string{collection.db + "." + collection.collectionName}
+		createRequest.DbCollections = nil
+		createRequest.CollectionRegex = ""
+		createRequest.Async = false
+		createRequest.Incremental = false
+		createRequest.Resume = false
+
+		log.Info("migrate collection", zap.String("db", collection.db), zap.String("collection", collection.collectionName), zap.String("backupName", backupName))
+		resp := b.CreateBackup(ctx, createRequest)
+		if resp.GetCode() != backuppb.ResponseCode_Success {
+			backupErr = fmt.Errorf("fail to backup collection %s.%s: %s", collection.db, collection.collectionName, resp.GetMsg())
+			break
+		}
+		select {
+		case backupNames <- backupName:
+		case <-ctx.Done():
+		}
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	close(backupNames)
+
+	if err, ok := <-restoreErr; ok {
+		log.Error("fail to migrate", zap.String("backupName", name), zap.Error(err))
+		return err
+	}
+	if backupErr != nil {
+		log.Error("fail to migrate", zap.String("backupName", name), zap.Error(backupErr))
+		return backupErr
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	log.Info("finish migrate", zap.String("backupName", name), zap.Int("collectionNum", len(collections)))
+	return nil
+}
+
+// migrateRestore restores a backup of a single collection into target and removes the backup after it is restored
+func (b *BackupContext) migrateRestore(ctx context.Context, target *BackupContext, restoreRequest *backuppb.RestoreBackupRequest, backupName string, keepBackup bool) error {
+	request := proto.Clone(restoreRequest).(*backuppb.RestoreBackupRequest)
+	request.RequestId = ""
+	request.BackupName = backupName
+	request.CollectionNames = nil
+	request.DbCollections = nil
+	request.Async = false
+	request.ResumeTaskId = ""
+	request.Path = ""
+	request.BucketName = ""
+
+	resp := target.RestoreBackup(ctx, request)
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		return fmt.Errorf("fail to restore backup %s: %s", backupName, resp.GetMsg())
+	}
+	if keepBackup {
+		return nil
+	}
+	deleteResp := b.DeleteBackup(ctx, &backuppb.DeleteBackupRequest{BackupName: backupName})
+	if deleteResp.GetCode() != backuppb.ResponseCode_Success {
+		// the collection has been migrated, the backup can be removed manually
+		log.Warn("fail to remove migrated backup", zap.String("backupName", backupName), zap.String("msg", deleteResp.GetMsg()))
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+func TestMigrateTargetParams(t *testing.T) {
+	source := &paramtable.BackupParams{}
+	source.MinioCfg = paramtable.MinioConfig{
+		StorageType:      "minio",
+		Address:          "source",
+		Port:             "9000",
+		AccessKeyID:      "ak",
+		SecretAccessKey:  "sk",
+		BackupBucketName: "backup-bucket",
+		BackupRootPath:   "backup",
+	}
+
+	// the same storage, copied by storage server side
+	target := &paramtable.BackupParams{}
+	target.MinioCfg = paramtable.MinioConfig{StorageType: "minio", Address: "source", Port: "9000", BackupBucketName: "other"}
+	MigrateTargetParams(source, target)
+	assert.Equal(t, "backup-bucket", target.MinioCfg.BackupBucketName)
+	assert.Equal(t, "backup", target.MinioCfg.BackupRootPath)
+	assert.False(t, target.BackupStorageCfg.Enabled())
+
+	// different storage, read by backupStorage
+	target.MinioCfg.StorageType = "gcp"
+	target.MinioCfg.Address = "storage.googleapis.com"
+	MigrateTargetParams(source, target)
+	assert.True(t, target.BackupStorageCfg.Enabled())
+	assert.Equal(t, "minio", target.BackupStorageCfg.StorageType)
+	assert.Equal(t, "source", target.BackupStorageCfg.Address)
+	assert.Equal(t, "ak", target.BackupStorageCfg.AccessKeyID)
+
+	// backupStorage of source is used as it is
+	source.BackupStorageCfg = paramtable.BackupStorageConfig{StorageType: "s3", Address: "s3.amazonaws.com"}
+	MigrateTargetParams(source, target)
+	assert.Equal(t, "s3", target.BackupStorageCfg.StorageType)
+	assert.Equal(t, "s3.amazonaws.com", target.BackupStorageCfg.Address)
+
+	// backupStorage of source is the storage of target, copied by storage server side
+	target.MinioCfg = paramtable.MinioConfig{StorageType: "aws", Address: "s3.amazonaws.com"}
+	MigrateTargetParams(source, target)
+	assert.False(t, target.BackupStorageCfg.Enabled())
+}
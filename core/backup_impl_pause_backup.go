@@ -0,0 +1,166 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// PauseBackup stops an executing backup, the backup keeps its checkpoint and turns to BACKUP_PAUSED.
+// A paused file is written into the meta dir of the backup, so a backup executing in another process sharing
+// the backup storage is paused as well once it finds the file.
+func (b *BackupContext) PauseBackup(ctx context.Context, request *backuppb.PauseBackupRequest) *backuppb.PauseBackupResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive PauseBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()))
+
+	resp := &backuppb.PauseBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+
+	// only the backups copying data have checkpoints to resume from
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+request.GetBackupName())
+	if err != nil {
+		log.Error("fail to read backup checkpoint", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if backup == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = fmt.Sprintf("no checkpoint of backup: %s, only backups copying data can be paused", request.GetBackupName())
+		return resp
+	}
+	if backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_EXECUTING {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("backup %s is not executing, state: %s", request.GetBackupName(), backup.GetStateCode())
+		return resp
+	}
+
+	pausedFilePath := BackupPausedFilePath(b.backupRootPath, request.GetBackupName())
+	if err := b.getBackupStorageClient().Write(ctx, b.backupBucketName, pausedFilePath, []byte(request.GetRequestId())); err != nil {
+		log.Error("fail to write paused file", zap.String("backupName", request.GetBackupName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if pause, ok := b.backupPauses.Load(request.GetBackupName()); ok {
+		pause.(func())()
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	log.Info("return PauseBackupResponse",
+		zap.String("requestId", resp.GetRequestId()),
+		zap.Int32("code", int32(resp.GetCode())))
+	return resp
+}
+
+// ResumeBackup continues a paused or interrupted backup from its checkpoint, same as CreateBackup with resume
+func (b *BackupContext) ResumeBackup(ctx context.Context, request *backuppb.ResumeBackupRequest) *backuppb.BackupInfoResponse {
+	return b.CreateBackup(ctx, &backuppb.CreateBackupRequest{
+		RequestId:  request.GetRequestId(),
+		BackupName: request.GetBackupName(),
+		Async:      request.GetAsync(),
+		Resume:     true,
+	})
+}
+
+// watchPause returns a ctx canceled once the backup is paused, either by PauseBackup of this process
+// or by the paused file found in the backup storage. stop should be called when the backup ends,
+// it returns whether the backup has been paused.
+func (b *BackupContext) watchPause(ctx context.Context, backupInfo *backuppb.BackupInfo) (context.Context, func() bool) {
+	ctx, cancel := context.WithCancel(ctx)
+	var paused int32
+	pause := func() {
+		if !atomic.CompareAndSwapInt32(&paused, 0, 1) {
+			return
+		}
+		log.Info("pause backup", zap.String("backupName", backupInfo.GetName()))
+		// the job ends as canceled instead of failed
+		if job, ok := b.jobs.Load(backupInfo.GetId()); ok {
+			job.(*backupJob).markCanceling()
+		}
+		cancel()
+	}
+	b.backupPauses.Store(backupInfo.GetName(), pause)
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(BACKUP_PAUSE_CHECK_INTERVAL)
+		defer ticker.Stop()
+		pausedFilePath := BackupPausedFilePath(b.backupRootPath, backupInfo.GetName())
+		for {
+			select {
+			case <-done:
+				return
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, pausedFilePath)
+				if err != nil {
+					log.Warn("fail to check paused file", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+					continue
+				}
+				if exist {
+					pause()
+					return
+				}
+			}
+		}
+	}()
+
+	stop := func() bool {
+		close(done)
+		b.backupPauses.Delete(backupInfo.GetName())
+		cancel()
+		return atomic.LoadInt32(&paused) == 1
+	}
+	return ctx, stop
+}
+
+// pauseCreateBackup records the backup interrupted by pause as BACKUP_PAUSED in its checkpoint
+func (b *BackupContext) pauseCreateBackup(backupInfo *backuppb.BackupInfo) error {
+	state, errorMessage := backupInfo.GetStateCode(), backupInfo.GetErrorMessage()
+	backupInfo.StateCode = backuppb.BackupTaskStateCode_BACKUP_PAUSED
+	backupInfo.ErrorMessage = fmt.Sprintf("backup %s is paused", backupInfo.GetName())
+	// ctx of the backup has been canceled, the checkpoint is written by the background ctx
+	if err := b.writeBackupCheckpoint(b.ctx, backupInfo, true); err != nil {
+		log.Error("fail to write checkpoint of paused backup", zap.String("backupName", backupInfo.GetName()), zap.Error(err))
+		backupInfo.StateCode, backupInfo.ErrorMessage = state, errorMessage
+		return err
+	}
+	b.refreshBackupCache(backupInfo)
+	log.Info("backup is paused", zap.String("backupName", backupInfo.GetName()))
+	return nil
+}
+
+// removePausedFile removes the paused file of the backup, so that it is not paused again after resumed
+func (b *BackupContext) removePausedFile(ctx context.Context, backupName string) error {
+	return b.getBackupStorageClient().Remove(ctx, b.backupBucketName, BackupPausedFilePath(b.backupRootPath, backupName))
+}
@@ -0,0 +1,36 @@
+package core
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+func TestWatchPause(t *testing.T) {
+	b := &BackupContext{}
+	backupInfo := &backuppb.BackupInfo{Id: "backup1", Name: "b1"}
+	jobCtx, finish := b.startJob(context.Background(), "backup1", metrics.BackupTaskLabel, "b1", "", "")
+
+	ctx, stop := b.watchPause(jobCtx, backupInfo)
+	pause, ok := b.backupPauses.Load("b1")
+	assert.True(t, ok)
+	pause.(func())()
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+	// the job itself is not canceled, only the backup under it
+	assert.NoError(t, jobCtx.Err())
+	assert.True(t, stop())
+	_, ok = b.backupPauses.Load("b1")
+	assert.False(t, ok)
+
+	finish(ctx.Err())
+	resp := b.GetJob(context.Background(), &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.Equal(t, backuppb.JobStateCode_JOB_CANCELED, resp.GetData().GetStateCode())
+
+	// backups not paused
+	_, stop = b.watchPause(context.Background(), &backuppb.BackupInfo{Id: "backup2", Name: "b2"})
+	assert.False(t, stop())
+}
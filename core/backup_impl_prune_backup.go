@@ -0,0 +1,261 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+func (b *BackupContext) PruneBackups(ctx context.Context, request *backuppb.PruneBackupsRequest) *backuppb.PruneBackupsResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive PruneBackupsRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.Bool("dryRun", request.GetDryRun()),
+		zap.String("labelSelector", request.GetLabelSelector()))
+
+	resp := &backuppb.PruneBackupsResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	retention := b.params.RetentionCfg
+	if !retention.Enabled() {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "no retention policy is configured"
+		return resp
+	}
+
+	selector, err := parseLabelSelector(request.GetLabelSelector())
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	listResp := b.ListBackups(ctx, &backuppb.ListBackupsRequest{})
+	if listResp.GetCode() != backuppb.ResponseCode_Success {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = listResp.GetMsg()
+		return resp
+	}
+
+	kept, expired := selectExpiredBackups(listResp.GetData(), retention, selector)
+	resp.KeptBackups = kept
+	log.Info("select expired backups",
+		zap.Strings("kept", kept),
+		zap.Strings("expired", expired))
+
+	if request.GetDryRun() {
+		resp.DeletedBackups = expired
+		resp.Code = backuppb.ResponseCode_Success
+		resp.Msg = "success"
+		return resp
+	}
+
+	backups := make(map[string]*backuppb.BackupInfo, len(listResp.GetData()))
+	for _, backup := range listResp.GetData() {
+		backups[backup.GetName()] = backup
+	}
+	// expired backups are sorted from new to old so that an incremental backup is always deleted before its base
+	for _, backupName := range expired {
+		err := b.getBackupStorageClient().RemoveWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, backupName))
+		if err != nil {
+			log.Error("Fail to delete backup", zap.String("backupName", backupName), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("fail to delete backup %s: %s", backupName, err.Error())
+			return resp
+		}
+		b.unrefDedupObjects(ctx, backups[backupName])
+		resp.DeletedBackups = append(resp.DeletedBackups, backupName)
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	log.Info("return PruneBackupsResponse",
+		zap.String("requestId", resp.GetRequestId()),
+		zap.Int32("code", int32(resp.GetCode())),
+		zap.Strings("deleted", resp.GetDeletedBackups()))
+	return resp
+}
+
+// selectExpiredBackups applies the retention policy to the backups matching selector, returns the names of the kept
+// and the expired ones, both sorted from new to old. Only successful backups are considered, the others are neither
+// kept nor expired. Backups referenced by a kept incremental backup, or by a backup not matching selector, are kept
+// as well.
+func selectExpiredBackups(backups []*backuppb.BackupInfo, retention paramtable.RetentionConfig, selector labelSelector) ([]string, []string) {
+	candidates := make([]*backuppb.BackupInfo, 0, len(backups))
+	// successful backups not matching selector are out of the retention policy
+	unselected := make([]*backuppb.BackupInfo, 0)
+	for _, backup := range backups {
+		if backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS {
+			continue
+		}
+		if selector.matchesBackup(backup) {
+			candidates = append(candidates, backup)
+		} else {
+			unselected = append(unselected, backup)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return backupTime(candidates[i]).After(backupTime(candidates[j]))
+	})
+
+	keep := make(map[string]bool, len(candidates))
+	if !retention.Enabled() {
+		for _, backup := range candidates {
+			keep[backup.GetName()] = true
+		}
+	}
+
+	// keep the newest backup of each of the latest count periods
+	keepPeriods := func(count int, period func(backup *backuppb.BackupInfo) string) {
+		last := ""
+		for _, backup := range candidates {
+			if count <= 0 {
+				return
+			}
+			current := period(backup)
+			if current == last {
+				continue
+			}
+			last = current
+			keep[backup.GetName()] = true
+			count--
+		}
+	}
+	keepPeriods(retention.KeepLast, func(backup *backuppb.BackupInfo) string {
+		return backup.GetName()
+	})
+	keepPeriods(retention.KeepDaily, func(backup *backuppb.BackupInfo) string {
+		return backupTime(backup).Format("2006-01-02")
+	})
+	keepPeriods(retention.KeepWeekly, func(backup *backuppb.BackupInfo) string {
+		year, week := backupTime(backup).ISOWeek()
+		return fmt.Sprintf("%d-%d", year, week)
+	})
+	keepPeriods(retention.KeepMonthly, func(backup *backuppb.BackupInfo) string {
+		return backupTime(backup).Format("2006-01")
+	})
+
+	// locked backups can't be deleted until the retention of object lock expires
+	now := time.Now()
+	for _, backup := range candidates {
+		if isObjectLocked(backup, now) {
+			keep[backup.GetName()] = true
+		}
+	}
+
+	// keep the whole chain of the kept incremental backups
+	byName := make(map[string]*backuppb.BackupInfo, len(backups))
+	for _, backup := range backups {
+		byName[backup.GetName()] = backup
+	}
+	var keepRefs func(backup *backuppb.BackupInfo)
+	keepRefs = func(backup *backuppb.BackupInfo) {
+		for _, ref := range referencedBackups(backup) {
+			if keep[ref] {
+				continue
+			}
+			keep[ref] = true
+			if base, ok := byName[ref]; ok {
+				keepRefs(base)
+			}
+		}
+	}
+	for _, backup := range candidates {
+		if keep[backup.GetName()] {
+			keepRefs(backup)
+		}
+	}
+	for _, backup := range unselected {
+		keepRefs(backup)
+	}
+
+	kept := make([]string, 0)
+	expired := make([]string, 0)
+	for _, backup := range candidates {
+		if keep[backup.GetName()] {
+			kept = append(kept, backup.GetName())
+		} else {
+			expired = append(expired, backup.GetName())
+		}
+	}
+	return kept, expired
+}
+
+// referencedBackups returns the names of the backups whose data is referenced by an incremental backup
+func referencedBackups(backup *backuppb.BackupInfo) []string {
+	refs := make([]string, 0)
+	if backup.GetBaseBackupName() != "" {
+		refs = append(refs, backup.GetBaseBackupName())
+	}
+	for _, collection := range backup.GetCollectionBackups() {
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				if segment.GetRefBackupName() != "" {
+					refs = append(refs, segment.GetRefBackupName())
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// dependentBackups returns the backups referencing the named backup directly or through other incremental backups,
+// sorted so that a backup always comes before the backups it references
+func dependentBackups(backups []*backuppb.BackupInfo, name string) []string {
+	referencedBy := make(map[string][]string)
+	for _, backup := range backups {
+		refs := make(map[string]bool)
+		for _, ref := range referencedBackups(backup) {
+			if ref == backup.GetName() || refs[ref] {
+				continue
+			}
+			refs[ref] = true
+			referencedBy[ref] = append(referencedBy[ref], backup.GetName())
+		}
+	}
+
+	dependents := make([]string, 0)
+	visited := map[string]bool{name: true}
+	// dependents of a backup are appended before itself
+	var visit func(name string)
+	visit = func(name string) {
+		for _, dependent := range referencedBy[name] {
+			if visited[dependent] {
+				continue
+			}
+			visited[dependent] = true
+			visit(dependent)
+			dependents = append(dependents, dependent)
+		}
+	}
+	visit(name)
+	return dependents
+}
+
+func backupTime(backup *backuppb.BackupInfo) time.Time {
+	ts := backup.GetBackupTimestamp()
+	if ts == 0 {
+		ts = uint64(backup.GetStartTime())
+	}
+	return time.UnixMilli(int64(ts))
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestSelectExpiredBackups(t *testing.T) {
+	day := 24 * time.Hour
+	now := time.Date(2023, 6, 30, 12, 0, 0, 0, time.Local)
+	newBackup := func(name string, at time.Time, base string) *backuppb.BackupInfo {
+		return &backuppb.BackupInfo{
+			Name:            name,
+			StateCode:       backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+			BackupTimestamp: uint64(at.UnixMilli()),
+			BaseBackupName:  base,
+		}
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		backups := []*backuppb.BackupInfo{
+			newBackup("a", now.Add(-day), ""),
+			newBackup("b", now, ""),
+		}
+		kept, expired := selectExpiredBackups(backups, paramtable.RetentionConfig{}, nil)
+		assert.Equal(t, []string{"b", "a"}, kept)
+		assert.Empty(t, expired)
+	})
+
+	t.Run("keep last", func(t *testing.T) {
+		backups := []*backuppb.BackupInfo{
+			newBackup("a", now.Add(-2*day), ""),
+			newBackup("c", now, ""),
+			newBackup("b", now.Add(-day), ""),
+		}
+		kept, expired := selectExpiredBackups(backups, paramtable.RetentionConfig{KeepLast: 2}, nil)
+		assert.Equal(t, []string{"c", "b"}, kept)
+		assert.Equal(t, []string{"a"}, expired)
+	})
+
+	t.Run("keep daily and monthly", func(t *testing.T) {
+		backups := []*backuppb.BackupInfo{
+			newBackup("today_2", now, ""),
+			newBackup("today_1", now.Add(-time.Hour), ""),
+			newBackup("yesterday", now.Add(-day), ""),
+			newBackup("last_month", now.Add(-31*day), ""),
+			newBackup("two_months_ago", now.Add(-62*day), ""),
+		}
+		kept, expired := selectExpiredBackups(backups, paramtable.RetentionConfig{KeepDaily: 2, KeepMonthly: 2}, nil)
+		assert.Equal(t, []string{"today_2", "yesterday", "last_month"}, kept)
+		assert.Equal(t, []string{"today_1", "two_months_ago"}, expired)
+	})
+
+	t.Run("keep incremental chain", func(t *testing.T) {
+		backups := []*backuppb.BackupInfo{
+			newBackup("full", now.Add(-3*day), ""),
+			newBackup("inc_1", now.Add(-2*day), "full"),
+			newBackup("inc_2", now.Add(-day), "inc_1"),
+			newBackup("other", now.Add(-4*day), ""),
+		}
+		kept, expired := selectExpiredBackups(backups, paramtable.RetentionConfig{KeepLast: 1}, nil)
+		assert.Equal(t, []string{"inc_2", "inc_1", "full"}, kept)
+		assert.Equal(t, []string{"other"}, expired)
+	})
+
+	t.Run("keep referenced segments", func(t *testing.T) {
+		inc := newBackup("inc", now, "")
+		inc.CollectionBackups = []*backuppb.CollectionBackupInfo{{
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{RefBackupName: "full"}},
+			}},
+		}}
+		backups := []*backuppb.BackupInfo{inc, newBackup("full", now.Add(-day), "")}
+		kept, expired := selectExpiredBackups(backups, paramtable.RetentionConfig{KeepLast: 1}, nil)
+		assert.Equal(t, []string{"inc", "full"}, kept)
+		assert.Empty(t, expired)
+	})
+
+	t.Run("keep locked backups", func(t *testing.T) {
+		locked := newBackup("locked", now.Add(-2*day), "")
+		locked.ObjectLockMode = "COMPLIANCE"
+		locked.ObjectLockRetainUntil = time.Now().Add(day).Unix()
+		unlocked := newBackup("unlocked", now.Add(-3*day), "")
+		unlocked.ObjectLockMode = "COMPLIANCE"
+		unlocked.ObjectLockRetainUntil = time.Now().Add(-day).Unix()
+		backups := []*backuppb.BackupInfo{locked, unlocked, newBackup("b", now, "")}
+		kept, expired := selectExpiredBackups(backups, paramtable.RetentionConfig{KeepLast: 1}, nil)
+		assert.Equal(t, []string{"b", "locked"}, kept)
+		assert.Equal(t, []string{"unlocked"}, expired)
+	})
+
+	t.Run("skip unfinished backups", func(t *testing.T) {
+		failed := newBackup("failed", now.Add(-day), "")
+		failed.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+		backups := []*backuppb.BackupInfo{failed, newBackup("a", now.Add(-2*day), ""), newBackup("b", now, "")}
+		kept, expired := selectExpiredBackups(backups, paramtable.RetentionConfig{KeepLast: 1}, nil)
+		assert.Equal(t, []string{"b"}, kept)
+		assert.Equal(t, []string{"a"}, expired)
+	})
+
+	t.Run("label selector", func(t *testing.T) {
+		prod1 := newBackup("prod_1", now.Add(-3*day), "")
+		prod2 := newBackup("prod_2", now.Add(-2*day), "")
+		prod3 := newBackup("prod_3", now, "")
+		// an incremental backup of another app based on an expired prod backup
+		search := newBackup("search_1", now.Add(-day), "prod_1")
+		for _, backup := range []*backuppb.BackupInfo{prod1, prod2, prod3} {
+			backup.Labels = map[string]string{"env": "prod"}
+		}
+		search.Labels = map[string]string{"env": "prod", "app": "search"}
+		selector, err := parseLabelSelector("env=prod,!app")
+		assert.NoError(t, err)
+		kept, expired := selectExpiredBackups([]*backuppb.BackupInfo{prod1, prod2, prod3, search}, paramtable.RetentionConfig{KeepLast: 1}, selector)
+		assert.Equal(t, []string{"prod_3", "prod_1"}, kept)
+		assert.Equal(t, []string{"prod_2"}, expired)
+	})
+}
+
+func TestDependentBackups(t *testing.T) {
+	backups := []*backuppb.BackupInfo{
+		{Name: "full"},
+		{Name: "inc_1", BaseBackupName: "full"},
+		{Name: "inc_2", BaseBackupName: "inc_1", CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{RefBackupName: "full"}, {RefBackupName: "inc_1"}},
+			}},
+		}}},
+		{Name: "other"},
+	}
+
+	assert.Equal(t, []string{"inc_2", "inc_1"}, dependentBackups(backups, "full"))
+	assert.Equal(t, []string{"inc_2"}, dependentBackups(backups, "inc_1"))
+	assert.Empty(t, dependentBackups(backups, "inc_2"))
+	assert.Empty(t, dependentBackups(backups, "other"))
+}
@@ -0,0 +1,143 @@
+package core
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// builtin roles and users are created by milvus
+var builtinRoles = map[string]bool{"admin": true, "public": true}
+var builtinUsers = map[string]bool{"root": true}
+
+// backupRBAC reads users, roles and grants of all databases from milvus
+func (b *BackupContext) backupRBAC(ctx context.Context) (*backuppb.RBACMeta, error) {
+	rbac := &backuppb.RBACMeta{}
+
+	users, err := b.getMilvusClient().SelectUsers(ctx)
+	if err != nil {
+		log.Error("fail to list users", zap.Error(err))
+		return nil, err
+	}
+	for _, user := range users {
+		roles := make([]string, 0, len(user.GetRoles()))
+		for _, role := range user.GetRoles() {
+			roles = append(roles, role.GetName())
+		}
+		rbac.Users = append(rbac.Users, &backuppb.UserEntity{User: user.GetUser().GetName(), Roles: roles})
+	}
+
+	roles, err := b.getMilvusClient().ListRoles(ctx)
+	if err != nil {
+		log.Error("fail to list roles", zap.Error(err))
+		return nil, err
+	}
+	dbs, err := b.getMilvusClient().ListDatabases(ctx)
+	if err != nil {
+		log.Error("fail to list databases", zap.Error(err))
+		return nil, err
+	}
+	for _, role := range roles {
+		rbac.Roles = append(rbac.Roles, &backuppb.RoleEntity{Name: role.Name})
+		for _, db := range dbs {
+			grants, err := b.getMilvusClient().SelectGrants(ctx, db.Name, role.Name)
+			if err != nil {
+				log.Error("fail to list grants", zap.String("role", role.Name), zap.String("db", db.Name), zap.Error(err))
+				return nil, err
+			}
+			for _, grant := range grants {
+				rbac.Grants = append(rbac.Grants, &backuppb.GrantEntity{
+					Role:       role.Name,
+					Object:     grant.GetObject().GetName(),
+					ObjectName: grant.GetObjectName(),
+					Privilege:  grant.GetGrantor().GetPrivilege().GetName(),
+					DbName:     db.Name,
+				})
+			}
+		}
+	}
+	log.Info("backup rbac",
+		zap.Int("users", len(rbac.GetUsers())),
+		zap.Int("roles", len(rbac.GetRoles())),
+		zap.Int("grants", len(rbac.GetGrants())))
+	return rbac, nil
+}
+
+// restoreRBAC creates the roles and users not exist in milvus, then grants privileges and roles as in backup.
+// Passwords are not backed up, users not exist are created with password, or skipped if password is empty.
+func (b *BackupContext) restoreRBAC(ctx context.Context, rbac *backuppb.RBACMeta, password string) error {
+	existRoles, err := b.getMilvusClient().ListRoles(ctx)
+	if err != nil {
+		log.Error("fail to list roles", zap.Error(err))
+		return err
+	}
+	roleSet := make(map[string]bool, len(existRoles))
+	for _, role := range existRoles {
+		roleSet[role.Name] = true
+	}
+	for _, role := range rbac.GetRoles() {
+		if roleSet[role.GetName()] || builtinRoles[role.GetName()] {
+			continue
+		}
+		if err := b.getMilvusClient().CreateRole(ctx, role.GetName()); err != nil {
+			log.Error("fail to create role", zap.String("role", role.GetName()), zap.Error(err))
+			return err
+		}
+		roleSet[role.GetName()] = true
+	}
+
+	// granting an existing privilege again is a no-op in milvus
+	for _, grant := range rbac.GetGrants() {
+		// admin has all privileges and can't be granted
+		if grant.GetRole() == "admin" {
+			continue
+		}
+		err := b.getMilvusClient().GrantPrivilege(ctx, grant.GetDbName(), grant.GetRole(), grant.GetObject(), grant.GetObjectName(), grant.GetPrivilege())
+		if err != nil {
+			log.Error("fail to grant privilege",
+				zap.String("role", grant.GetRole()),
+				zap.String("object", grant.GetObject()),
+				zap.String("objectName", grant.GetObjectName()),
+				zap.String("privilege", grant.GetPrivilege()),
+				zap.String("db", grant.GetDbName()),
+				zap.Error(err))
+			return err
+		}
+	}
+
+	existUsers, err := b.getMilvusClient().ListCredUsers(ctx)
+	if err != nil {
+		log.Error("fail to list users", zap.Error(err))
+		return err
+	}
+	userSet := make(map[string]bool, len(existUsers))
+	for _, user := range existUsers {
+		userSet[user] = true
+	}
+	for _, user := range rbac.GetUsers() {
+		if !userSet[user.GetUser()] && !builtinUsers[user.GetUser()] {
+			if password == "" {
+				log.Warn("skip restore user not exist, rbac user password is not set", zap.String("user", user.GetUser()))
+				continue
+			}
+			if err := b.getMilvusClient().CreateCredential(ctx, user.GetUser(), password); err != nil {
+				log.Error("fail to create user", zap.String("user", user.GetUser()), zap.Error(err))
+				return err
+			}
+		}
+		for _, role := range user.GetRoles() {
+			if err := b.getMilvusClient().AddUserRole(ctx, user.GetUser(), role); err != nil {
+				log.Error("fail to grant role to user", zap.String("user", user.GetUser()), zap.String("role", role), zap.Error(err))
+				return err
+			}
+		}
+	}
+	log.Info("restore rbac",
+		zap.Int("users", len(rbac.GetUsers())),
+		zap.Int("roles", len(rbac.GetRoles())),
+		zap.Int("grants", len(rbac.GetGrants())))
+	return nil
+}
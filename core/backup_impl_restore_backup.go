@@ -2,23 +2,93 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"math"
+	"path"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/golang/protobuf/proto"
 	jsoniter "github.com/json-iterator/go"
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
 	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
 
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
 	"github.com/zilliztech/milvus-backup/core/utils"
 	"github.com/zilliztech/milvus-backup/internal/common"
 	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+	"github.com/zilliztech/milvus-backup/internal/trace"
 	"github.com/zilliztech/milvus-backup/internal/util/retry"
 )
 
+const (
+	// SchemaPolicyStrict restores into existing collections of the same fields as backup
+	SchemaPolicyStrict = "strict"
+	// SchemaPolicyCompatible restores the fields in both backup and existing collections, fields only in
+	// collections are filled by milvus
+	SchemaPolicyCompatible = "compatible"
+)
+
+var validSchemaPolicies = map[string]bool{"": true, SchemaPolicyStrict: true, SchemaPolicyCompatible: true}
+
+const (
+	// IndexModeImmediate creates the indexes with the collection before data is restored
+	IndexModeImmediate = "immediate"
+	// IndexModeDeferred creates the indexes after all data of the collection is restored
+	IndexModeDeferred = "deferred"
+	// IndexModeSkip doesn't restore the indexes
+	IndexModeSkip = "skip"
+)
+
+var validIndexModes = map[string]bool{"": true, IndexModeImmediate: true, IndexModeDeferred: true, IndexModeSkip: true}
+
+const (
+	// AliasModeRepoint creates the aliases in backup, or alters them to point at the restored collections if they exist
+	AliasModeRepoint = "repoint"
+	// AliasModeCreate creates the aliases in backup, fails if an alias exists
+	AliasModeCreate = "create"
+	// AliasModeSkip doesn't restore the aliases
+	AliasModeSkip = "skip"
+)
+
+var validAliasModes = map[string]bool{"": true, AliasModeRepoint: true, AliasModeCreate: true, AliasModeSkip: true}
+
+// states of BulkInsertJob
+const (
+	BulkInsertJobExecuting = "executing"
+	BulkInsertJobPersisted = "persisted"
+	BulkInsertJobCompleted = "completed"
+	BulkInsertJobFailed    = "failed"
+	BulkInsertJobTimeout   = "timeout"
+)
+
+// errBulkInsertFailed means the import task failed in milvus, the data it imported is not visible
+var errBulkInsertFailed = errors.New("bulk insert fail")
+
+// MMAP_ENABLED_KEY is the index param of milvus to memory map the index
+const MMAP_ENABLED_KEY = "mmap.enabled"
+
+// restoreIndexMode is the index mode of a restore request, restore_index is used if index_mode is not set
+func restoreIndexMode(request *backuppb.RestoreBackupRequest) string {
+	if request.GetIndexMode() != "" {
+		return request.GetIndexMode()
+	}
+	if request.GetRestoreIndex() {
+		return IndexModeImmediate
+	}
+	return IndexModeSkip
+}
+
 func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest) *backuppb.RestoreBackupResponse {
 	if request.GetRequestId() == "" {
 		request.RequestId = utils.UUID()
@@ -28,16 +98,33 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		zap.String("backupName", request.GetBackupName()),
 		zap.Bool("onlyMeta", request.GetMetaOnly()),
 		zap.Bool("restoreIndex", request.GetRestoreIndex()),
+		zap.String("indexMode", request.GetIndexMode()),
 		zap.Bool("useAutoIndex", request.GetUseAutoIndex()),
 		zap.Bool("dropExistCollection", request.GetDropExistCollection()),
 		zap.Bool("dropExistIndex", request.GetDropExistIndex()),
 		zap.Bool("skipCreateCollection", request.GetSkipCreateCollection()),
+		zap.String("schemaPolicy", request.GetSchemaPolicy()),
+		zap.String("restoreAliases", request.GetRestoreAliases()),
+		zap.Any("collectionProperties", request.GetCollectionProperties()),
+		zap.Bool("loadCollection", request.GetLoadCollection()),
+		zap.Int32("replicaNumber", request.GetReplicaNumber()),
+		zap.Strings("resourceGroups", request.GetResourceGroups()),
+		zap.Any("fieldMappings", request.GetFieldMappings()),
+		zap.Uint64("timestamp", request.GetTimestamp()),
+		zap.String("resumeTaskId", request.GetResumeTaskId()),
+		zap.Int32("collectionParallelism", request.GetCollectionParallelism()),
+		zap.Int32("bulkinsertParallelism", request.GetBulkinsertParallelism()),
+		zap.Bool("rbac", request.GetRbac()),
+		zap.Any("partitions", request.GetPartitions()),
 		zap.Strings("collections", request.GetCollectionNames()),
 		zap.String("CollectionSuffix", request.GetCollectionSuffix()),
+		zap.String("CollectionNameTemplate", request.GetCollectionNameTemplate()),
+		zap.String("TargetDbName", request.GetTargetDbName()),
 		zap.Any("CollectionRenames", request.GetCollectionRenames()),
 		zap.Bool("async", request.GetAsync()),
 		zap.String("bucketName", request.GetBucketName()),
 		zap.String("path", request.GetPath()),
+		zap.String("labelSelector", request.GetLabelSelector()),
 		zap.String("databaseCollections", utils.GetRestoreDBCollections(request)))
 
 	resp := &backuppb.RestoreBackupResponse{
@@ -54,6 +141,16 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 	}
 
 	// 1, get and validate
+	if request.GetReplicaNumber() < 0 {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "replica number should not be negative"
+		return resp
+	}
+	if request.GetCollectionParallelism() < 0 || request.GetBulkinsertParallelism() < 0 {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "parallelism should not be negative"
+		return resp
+	}
 	if request.GetCollectionSuffix() != "" {
 		err := utils.ValidateType(request.GetCollectionSuffix(), COLLECTION_RENAME_SUFFIX)
 		if err != nil {
@@ -63,6 +160,80 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			return resp
 		}
 	}
+	if request.GetTargetDbName() != "" {
+		if err := utils.ValidateType(request.GetTargetDbName(), DATABASE_NAME); err != nil {
+			log.Error("illegal target database name", zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+	if request.GetDryRun() && request.GetResumeTaskId() != "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "dry run can't be used to resume a restore task"
+		return resp
+	}
+	// the rbac of milvus is out of any database
+	if request.GetRbac() && len(b.databases) > 0 {
+		resp.Code = backuppb.ResponseCode_No_Permission
+		resp.Msg = fmt.Sprintf("%s: rbac", errDatabaseOutOfScope.Error())
+		return resp
+	}
+	if request.GetCollectionNameTemplate() != "" && request.GetCollectionSuffix() != "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "collection name template and collection suffix can't be set at the same time"
+		return resp
+	}
+	if !validIndexModes[request.GetIndexMode()] {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("illegal index mode %s, support immediate, deferred and skip", request.GetIndexMode())
+		return resp
+	}
+	if !validAliasModes[request.GetRestoreAliases()] {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("illegal restore aliases %s, support repoint, create and skip", request.GetRestoreAliases())
+		return resp
+	}
+	if !validSchemaPolicies[request.GetSchemaPolicy()] {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("illegal schema policy %s, support strict and compatible", request.GetSchemaPolicy())
+		return resp
+	}
+	if len(request.GetFieldMappings()) > 0 && !request.GetSkipCreateCollection() {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "field mappings only apply to existing collections, with skip_create_collection"
+		return resp
+	}
+
+	selector, err := parseLabelSelector(request.GetLabelSelector())
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+	if request.GetBackupName() == "" && len(selector) > 0 {
+		if request.GetBucketName() != "" || request.GetPath() != "" {
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = "backups can only be selected by labels in the backup bucket of config"
+			return resp
+		}
+		listResp := b.ListBackups(ctx, &backuppb.ListBackupsRequest{LabelSelector: request.GetLabelSelector()})
+		if listResp.GetCode() != backuppb.ResponseCode_Success {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = listResp.GetMsg()
+			return resp
+		}
+		latest := latestBackupMatchingLabels(listResp.GetData(), selector)
+		if latest == nil {
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = fmt.Sprintf("no successful backup matches label selector %s", request.GetLabelSelector())
+			return resp
+		}
+		log.Info("select the latest backup matching labels",
+			zap.String("labelSelector", request.GetLabelSelector()),
+			zap.String("backupName", latest.GetName()))
+		request.BackupName = latest.GetName()
+	}
 
 	getResp := b.GetBackup(ctx, &backuppb.GetBackupRequest{
 		BackupName: request.GetBackupName(),
@@ -96,18 +267,38 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 	}
 
 	backup := getResp.GetData()
+	if !selector.matchesBackup(backup) {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("labels of backup %s don't match label selector %s", backup.GetName(), request.GetLabelSelector())
+		return resp
+	}
+
+	// binlogs encrypted by customer key can't be read without the key
+	if _, err := b.backupServerSideEncryption(backup, request.GetSseCustomerKey()); err != nil {
+		log.Error("fail to get server side encryption of backup", zap.String("backupName", backup.GetName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+
+	if request.GetResumeTaskId() != "" {
+		return b.resumeRestoreBackup(ctx, request, backupBucketName, backupPath, backup)
+	}
 
 	id := utils.UUID()
 
 	task := &backuppb.RestoreBackupTask{
-		Id:        id,
-		StateCode: backuppb.RestoreTaskStateCode_INITIAL,
-		StartTime: time.Now().Unix(),
-		Progress:  0,
+		Id:         id,
+		StateCode:  backuppb.RestoreTaskStateCode_INITIAL,
+		StartTime:  time.Now().Unix(),
+		Progress:   0,
+		BackupName: backup.GetName(),
 	}
 
 	// 2, initial restoreCollectionTasks
 	toRestoreCollectionBackups := make([]*backuppb.CollectionBackupInfo, 0)
+	// collections requested by name but not in backup
+	missingCollections := make([]string, 0)
 
 	dbCollectionsStr := utils.GetRestoreDBCollections(request)
 	if dbCollectionsStr != "" {
@@ -123,24 +314,38 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		}
 		for db, collections := range dbCollections {
 			if len(collections) == 0 {
+				found := false
 				for _, collectionBackup := range backup.GetCollectionBackups() {
 					if collectionBackup.GetDbName() == "" {
 						collectionBackup.DbName = "default"
 					}
 					if collectionBackup.GetDbName() == db {
 						toRestoreCollectionBackups = append(toRestoreCollectionBackups, collectionBackup)
+						found = true
 					}
 				}
+				if !found {
+					errorMsg := fmt.Sprintf("database %s does not exist in backup %s", db, backup.GetName())
+					log.Error(errorMsg)
+					resp.Code = backuppb.ResponseCode_Parameter_Error
+					resp.Msg = errorMsg
+					return resp
+				}
 			} else {
 				for _, coll := range collections {
+					found := false
 					for _, collectionBackup := range backup.GetCollectionBackups() {
 						if collectionBackup.GetDbName() == "" {
 							collectionBackup.DbName = "default"
 						}
 						if collectionBackup.GetDbName() == db && collectionBackup.CollectionName == coll {
 							toRestoreCollectionBackups = append(toRestoreCollectionBackups, collectionBackup)
+							found = true
 						}
 					}
+					if !found {
+						missingCollections = append(missingCollections, db+"."+coll)
+					}
 				}
 			}
 		}
@@ -162,6 +367,7 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			}
 			collectionNameDict[fullCollectionName] = true
 		}
+		found := make(map[string]bool, len(collectionNameDict))
 		for _, collectionBackup := range backup.GetCollectionBackups() {
 			if collectionBackup.GetDbName() == "" {
 				collectionBackup.DbName = "default"
@@ -169,10 +375,61 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			fullCollectionName := collectionBackup.GetDbName() + "." + collectionBackup.GetCollectionName()
 			if collectionNameDict[fullCollectionName] {
 				toRestoreCollectionBackups = append(toRestoreCollectionBackups, collectionBackup)
+				found[fullCollectionName] = true
+			}
+		}
+		for fullCollectionName := range collectionNameDict {
+			if !found[fullCollectionName] {
+				missingCollections = append(missingCollections, fullCollectionName)
+			}
+		}
+	}
+	if len(missingCollections) > 0 {
+		sort.Strings(missingCollections)
+		errorMsg := fmt.Sprintf("collections %s do not exist in backup %s", strings.Join(missingCollections, ", "), backup.GetName())
+		log.Error(errorMsg)
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = errorMsg
+		return resp
+	}
+	// collections failed in a partial backup have no complete data to restore
+	restorable := make([]*backuppb.CollectionBackupInfo, 0, len(toRestoreCollectionBackups))
+	for _, collectionBackup := range toRestoreCollectionBackups {
+		if isFailedCollection(collectionBackup) {
+			log.Warn("skip restoring the collection failed in backup",
+				zap.String("db", collectionBackup.GetDbName()),
+				zap.String("collection", collectionBackup.GetCollectionName()),
+				zap.String("error", collectionBackup.GetErrorMessage()))
+			continue
+		}
+		restorable = append(restorable, collectionBackup)
+	}
+	toRestoreCollectionBackups = restorable
+	task.SkippedCollections = skippedCollections(backup, toRestoreCollectionBackups)
+	log.Info("Collections to restore",
+		zap.Int("collection_num", len(toRestoreCollectionBackups)),
+		zap.Strings("skipped", task.GetSkippedCollections()))
+
+	// point in time restore, the timestamp should not be later than the backup timestamp of any collection
+	if request.GetTimestamp() != 0 {
+		for _, restoreCollection := range toRestoreCollectionBackups {
+			backupPhysicalTimestamp := restoreCollection.GetBackupPhysicalTimestamp()
+			if backupPhysicalTimestamp == 0 {
+				log.Warn("collection backup has no backup timestamp, can not make sure the data is consistent with restore timestamp",
+					zap.String("collectionName", restoreCollection.GetCollectionName()),
+					zap.Uint64("timestamp", request.GetTimestamp()))
+				continue
+			}
+			if request.GetTimestamp() > backupPhysicalTimestamp {
+				errorMsg := fmt.Sprintf("restore timestamp %d is later than the backup timestamp %d of collection %s.%s",
+					request.GetTimestamp(), backupPhysicalTimestamp, restoreCollection.GetDbName(), restoreCollection.GetCollectionName())
+				log.Error(errorMsg)
+				resp.Code = backuppb.ResponseCode_Parameter_Error
+				resp.Msg = errorMsg
+				return resp
 			}
 		}
 	}
-	log.Info("Collections to restore", zap.Int("collection_num", len(toRestoreCollectionBackups)))
 
 	// add default db in collection_renames if not set
 	collectionRenames := make(map[string]string)
@@ -197,12 +454,23 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		collectionRenames[fullCollectionName] = fullCollectionNewName
 	}
 
+	restoreDate := time.Now().Format("20060102")
+	dryRunReport := &backuppb.RestoreDryRunReport{}
+	// databases not exist are not created by dry run
+	dryRunDatabases := make(map[string]bool)
+	// target collection to the collection in backup restored into it
+	targetCollections := make(map[string]string)
 	restoreCollectionTasks := make([]*backuppb.RestoreCollectionTask, 0)
+	indexMode := restoreIndexMode(request)
+	// the features the target milvus doesn't support are checked if its version is known
+	targetVersion, knownVersion := b.targetMilvusVersion(ctx)
 	for _, restoreCollection := range toRestoreCollectionBackups {
 		backupDBCollectionName := restoreCollection.DbName + "." + restoreCollection.GetSchema().GetName()
 		targetDBName := restoreCollection.DbName
 		targetCollectionName := restoreCollection.GetSchema().GetName()
-		if value, ok := dbRenames[restoreCollection.DbName]; ok {
+		if request.GetTargetDbName() != "" {
+			targetDBName = request.GetTargetDbName()
+		} else if value, ok := dbRenames[restoreCollection.DbName]; ok {
 			targetDBName = value
 		}
 		// rename collection, rename map has higher priority then suffix
@@ -211,26 +479,85 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			targetCollectionName = strings.Split(collectionRenames[backupDBCollectionName], ".")[1]
 		} else if request.GetCollectionSuffix() != "" {
 			targetCollectionName = targetCollectionName + request.GetCollectionSuffix()
+		} else if request.GetCollectionNameTemplate() != "" {
+			targetCollectionName = renderCollectionName(request.GetCollectionNameTemplate(), restoreCollection.GetDbName(), targetCollectionName, restoreDate)
+			if err := utils.ValidateType(targetCollectionName, COLLECTION_NAME); err != nil {
+				log.Error("illegal collection name rendered by template", zap.String("template", request.GetCollectionNameTemplate()), zap.Error(err))
+				resp.Code = backuppb.ResponseCode_Parameter_Error
+				resp.Msg = err.Error()
+				return resp
+			}
 		}
 		targetDBCollectionName := targetDBName + "." + targetCollectionName
-
-		// check if the database exist, if not, create it first
-		dbs, err := b.getMilvusClient().ListDatabases(ctx)
-		if err != nil {
-			errorMsg := fmt.Sprintf("fail to list databases, err: %s", err)
+		// a context limited to some databases doesn't restore, or drop by drop_exist_collection, collections out of them
+		if err := b.checkDatabaseScope(targetDBName); err != nil {
+			resp.Code = backuppb.ResponseCode_No_Permission
+			resp.Msg = fmt.Sprintf("fail to restore collection %s: %s", targetDBCollectionName, err.Error())
+			log.Error(resp.Msg)
+			return resp
+		}
+		// collections of different databases can't be restored into the same one
+		if source, ok := targetCollections[targetDBCollectionName]; ok {
+			errorMsg := fmt.Sprintf("collections %s and %s are restored into the same collection %s", source, backupDBCollectionName, targetDBCollectionName)
 			log.Error(errorMsg)
-			resp.Code = backuppb.ResponseCode_Fail
+			resp.Code = backuppb.ResponseCode_Parameter_Error
 			resp.Msg = errorMsg
 			return resp
 		}
+		targetCollections[targetDBCollectionName] = backupDBCollectionName
+
+		var compatIssues []*backuppb.CompatIssue
+		var downgradedFieldIds []int64
+		if knownVersion {
+			compatIssues = collectionCompatIssues(restoreCollection, targetDBName, targetVersion)
+			if request.GetDryRun() {
+				dryRunReport.CompatIssues = append(dryRunReport.CompatIssues, compatIssues...)
+			} else if len(compatIssues) > 0 {
+				downgradable := true
+				for _, issue := range compatIssues {
+					downgradable = downgradable && issue.GetDowngradable()
+				}
+				if !downgradable || !request.GetDowngradeFeatures() {
+					errorMsg := fmt.Sprintf("target milvus %s doesn't support the features of backup, %s", targetVersion, formatCompatIssues(compatIssues))
+					if downgradable {
+						errorMsg += ", set downgrade_features to restore without them"
+					}
+					log.Error(errorMsg)
+					resp.Code = backuppb.ResponseCode_Parameter_Error
+					resp.Msg = errorMsg
+					return resp
+				}
+			}
+			restoreCollection, downgradedFieldIds = downgradeCollection(restoreCollection, compatIssues, targetVersion)
+		}
+
+		// check if the database exist, if not, create it first
 		var hasDatabase = false
-		for _, db := range dbs {
-			if db.Name == targetDBName {
-				hasDatabase = true
-				break
+		if knownVersion && targetVersion.less(versionDatabase) {
+			// databases are not supported, only the default database exists
+			hasDatabase = targetDBName == "default"
+		} else {
+			dbs, err := b.getMilvusClient().ListDatabases(ctx)
+			if err != nil {
+				errorMsg := fmt.Sprintf("fail to list databases, err: %s", err)
+				log.Error(errorMsg)
+				resp.Code = backuppb.ResponseCode_Fail
+				resp.Msg = errorMsg
+				return resp
+			}
+			for _, db := range dbs {
+				if db.Name == targetDBName {
+					hasDatabase = true
+					break
+				}
 			}
 		}
-		if !hasDatabase {
+		if !hasDatabase && request.GetDryRun() {
+			if !dryRunDatabases[targetDBName] {
+				dryRunDatabases[targetDBName] = true
+				dryRunReport.DatabasesToCreate = append(dryRunReport.DatabasesToCreate, targetDBName)
+			}
+		} else if !hasDatabase {
 			err := b.getMilvusClient().CreateDatabase(ctx, targetDBName)
 			if err != nil {
 				errorMsg := fmt.Sprintf("fail to create database %s, err: %s", targetDBName, err)
@@ -243,7 +570,15 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 		}
 
 		// check if the collection exist, if exist, will not restore
-		if !request.GetSkipCreateCollection() {
+		if request.GetDryRun() {
+			if err := b.dryRunCheckCollection(ctx, request, restoreCollection, hasDatabase, targetDBName, targetCollectionName, dryRunReport); err != nil {
+				errorMsg := fmt.Sprintf("fail to check the collection to restore, collection_name: %s, err: %s", targetDBCollectionName, err)
+				log.Error(errorMsg)
+				resp.Code = backuppb.ResponseCode_Fail
+				resp.Msg = errorMsg
+				return resp
+			}
+		} else if !request.GetSkipCreateCollection() {
 			exist, err := b.getMilvusClient().HasCollection(ctx, targetDBName, targetCollectionName)
 			if err != nil {
 				errorMsg := fmt.Sprintf("fail to check whether the collection is exist, collection_name: %s, err: %s", targetDBCollectionName, err)
@@ -263,9 +598,20 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			log.Info("skip check collection exist")
 		}
 
+		// restore the selected partitions only
+		if partitionNames := requestPartitions(request.GetPartitions(), restoreCollection.GetDbName(), restoreCollection.GetCollectionName()); len(partitionNames) > 0 {
+			restoreCollection, err = selectBackupPartitions(restoreCollection, partitionNames)
+			if err != nil {
+				log.Error("fail to select partitions to restore", zap.Strings("partitions", partitionNames), zap.Error(err))
+				resp.Code = backuppb.ResponseCode_Parameter_Error
+				resp.Msg = err.Error()
+				return resp
+			}
+		}
+
 		var toRestoreSize int64 = 0
 		for _, partitionBackup := range restoreCollection.GetPartitionBackups() {
-			toRestoreSize += partitionBackup.GetSize()
+			toRestoreSize += segmentGroupSize(partitionBackup, -1)
 		}
 		id := utils.UUID()
 
@@ -281,112 +627,662 @@ func (b *BackupContext) RestoreBackup(ctx context.Context, request *backuppb.Res
 			RestoredSize:          0,
 			Progress:              0,
 			MetaOnly:              request.GetMetaOnly(),
-			RestoreIndex:          request.GetRestoreIndex(),
+			RestoreIndex:          indexMode != IndexModeSkip,
+			IndexMode:             indexMode,
+			RestoreAliases:        request.GetRestoreAliases(),
+			Properties:            restoreCollectionProperties(restoreCollection, request.GetCollectionProperties()),
+			LoadCollection:        request.GetLoadCollection() && restoreCollection.GetLoadState() != "" && restoreCollection.GetLoadState() != LoadState_NotLoad,
+			ReplicaNumber:         restoreCollection.GetReplicaNumber(),
+			ResourceGroups:        restoreCollection.GetResourceGroups(),
 			UseAutoIndex:          request.GetUseAutoIndex(),
 			DropExistCollection:   request.GetDropExistCollection(),
 			DropExistIndex:        request.GetDropExistIndex(),
 			SkipCreateCollection:  request.GetSkipCreateCollection(),
+			RestoreTimestamp:      request.GetTimestamp(),
+			CheckRowCount:         request.GetCheckRowCount() && !request.GetMetaOnly(),
+			VerifySampleRows:      verifySampleRows(request),
+		}
+		// the strict schema is left to bulk insert to check, as before schema policies
+		if request.GetReplicaNumber() > 0 {
+			restoreCollectionTask.ReplicaNumber = request.GetReplicaNumber()
+		}
+		if len(request.GetResourceGroups()) > 0 {
+			restoreCollectionTask.ResourceGroups = request.GetResourceGroups()
+		}
+		if !request.GetDryRun() && request.GetSkipCreateCollection() && (request.GetSchemaPolicy() == SchemaPolicyCompatible || len(request.GetFieldMappings()) > 0) {
+			coll, err := b.getMilvusClient().DescribeCollection(ctx, targetDBName, targetCollectionName)
+			if err != nil {
+				errorMsg := fmt.Sprintf("fail to describe the collection to restore into, collection_name: %s, err: %s", targetDBCollectionName, err)
+				log.Error(errorMsg)
+				resp.Code = backuppb.ResponseCode_Fail
+				resp.Msg = errorMsg
+				return resp
+			}
+			fieldIDs, skippedFields, mismatches := mapCollectionSchema(restoreCollection.GetSchema(), coll.Schema, request.GetSchemaPolicy(), request.GetFieldMappings())
+			if len(mismatches) > 0 {
+				resp.Code = backuppb.ResponseCode_Parameter_Error
+				resp.Msg = fmt.Sprintf("schema of collection %s is not compatible with backup: %s", targetDBCollectionName, strings.Join(mismatches, "; "))
+				log.Error(resp.Msg)
+				return resp
+			}
+			restoreCollectionTask.FieldIdMappings = fieldIDs
+			restoreCollectionTask.SkippedFieldIds = skippedFields
+			log.Info("map fields of backup into the existing collection",
+				zap.String("collection", targetDBCollectionName),
+				zap.Any("fieldIdMappings", fieldIDs),
+				zap.Int64s("skippedFieldIds", skippedFields))
+		}
+		if len(compatIssues) > 0 {
+			restoreCollectionTask.DowngradedFeatures = compatIssues
+			restoreCollectionTask.SkippedFieldIds = append(restoreCollectionTask.GetSkippedFieldIds(), downgradedFieldIds...)
 		}
 		restoreCollectionTasks = append(restoreCollectionTasks, restoreCollectionTask)
 		task.CollectionRestoreTasks = restoreCollectionTasks
 		task.ToRestoreSize = task.GetToRestoreSize() + toRestoreSize
 	}
 
-	if request.Async {
-		go b.executeRestoreBackupTask(ctx, backupBucketName, backupPath, backup, task)
-		asyncResp := &backuppb.RestoreBackupResponse{
-			RequestId: request.GetRequestId(),
-			Code:      backuppb.ResponseCode_Success,
-			Msg:       "restore backup is executing asynchronously",
-			Data:      task,
-		}
-		return asyncResp
-	} else {
-		endTask, err := b.executeRestoreBackupTask(ctx, backupBucketName, backupPath, backup, task)
-		resp.Data = endTask
+	if request.GetCheckManifest() && !request.GetMetaOnly() {
+		checkResult, err := b.checkRestoreManifest(ctx, backupBucketName, backupPath, task)
 		if err != nil {
+			log.Error("fail to check the manifest of backup", zap.String("backupName", request.GetBackupName()), zap.Error(err))
 			resp.Code = backuppb.ResponseCode_Fail
-			log.Error("execute restore collection fail", zap.String("backupId", backup.GetId()), zap.Error(err))
 			resp.Msg = err.Error()
-		} else {
-			resp.Code = backuppb.ResponseCode_Success
-			resp.Msg = "success"
+			return resp
+		}
+		if checkResult == nil {
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = fmt.Sprintf("backup %s has no manifest to check", request.GetBackupName())
+			return resp
+		}
+		if request.GetDryRun() {
+			dryRunReport.MissingFiles = append(dryRunReport.MissingFiles, checkResult.missingFiles...)
+			dryRunReport.CorruptedFiles = append(dryRunReport.CorruptedFiles, checkResult.corruptedFiles...)
+		} else if len(checkResult.missingFiles) > 0 || len(checkResult.corruptedFiles) > 0 {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = fmt.Sprintf("files of backup don't match its manifest, %d missing files, %d corrupted files",
+				len(checkResult.missingFiles), len(checkResult.corruptedFiles))
+			log.Error(resp.Msg,
+				zap.Strings("missingFiles", checkResult.missingFiles),
+				zap.Strings("corruptedFiles", checkResult.corruptedFiles))
+			return resp
 		}
-		return resp
 	}
+
+	if request.GetDryRun() {
+		return b.dryRunRestoreBackup(ctx, request, backupBucketName, backupPath, task, dryRunReport)
+	}
+	return b.submitRestoreBackup(ctx, request, backupBucketName, backupPath, backup, task)
 }
 
-func (b *BackupContext) executeRestoreBackupTask(ctx context.Context, backupBucketName string, backupPath string, backup *backuppb.BackupInfo, task *backuppb.RestoreBackupTask) (*backuppb.RestoreBackupTask, error) {
-	b.mu.Lock()
-	defer b.mu.Unlock()
+// checkRestoreManifest checks the sizes of the files of the collections to restore against the manifest of backup,
+// nil if the backup has no manifest
+func (b *BackupContext) checkRestoreManifest(ctx context.Context, backupBucketName string, backupPath string, task *backuppb.RestoreBackupTask) (*manifestCheckResult, error) {
+	manifest, err := b.readBackupManifest(ctx, backupBucketName, backupPath)
+	if err != nil || manifest == nil {
+		return nil, err
+	}
+	collectionIds := make(map[int64]bool)
+	for _, collTask := range task.GetCollectionRestoreTasks() {
+		collectionIds[collTask.GetCollBackup().GetCollectionId()] = true
+	}
+	filter := func(collectionId int64) bool { return collectionIds[collectionId] }
+	// paths in manifest are relative to the backup root, binlogs of incremental backups may be in their base backups
+	return b.checkManifestFiles(ctx, backupBucketName, path.Dir(backupPath), manifest, filter, false)
+}
 
-	wp, err := common.NewWorkerPool(ctx, b.params.BackupCfg.RestoreParallelism, RPS)
+// dryRunCheckCollection records the problems of restoring a collection into the target collection without creating it.
+// An existing target collection conflicts unless skip_create_collection, in which case its schema is compared with backup.
+func (b *BackupContext) dryRunCheckCollection(ctx context.Context, request *backuppb.RestoreBackupRequest, collBackup *backuppb.CollectionBackupInfo, hasDatabase bool, targetDBName string, targetCollectionName string, report *backuppb.RestoreDryRunReport) error {
+	targetDBCollectionName := targetDBName + "." + targetCollectionName
+	exist := false
+	if hasDatabase {
+		var err error
+		exist, err = b.getMilvusClient().HasCollection(ctx, targetDBName, targetCollectionName)
+		if err != nil {
+			return err
+		}
+		if request.GetRestoreAliases() == AliasModeCreate {
+			for _, alias := range collBackup.GetAliases() {
+				// an alias is described as the collection it points at
+				aliasExist, err := b.getMilvusClient().HasCollection(ctx, targetDBName, alias)
+				if err != nil {
+					return err
+				}
+				if aliasExist {
+					report.AliasConflicts = append(report.AliasConflicts, targetDBName+"."+alias)
+				}
+			}
+		}
+	}
+	if !request.GetSkipCreateCollection() {
+		if exist {
+			report.Conflicts = append(report.Conflicts, targetDBCollectionName)
+		}
+		return nil
+	}
+	if !exist {
+		report.SchemaMismatches = append(report.SchemaMismatches, fmt.Sprintf("%s: collection does not exist, can't skip creating it", targetDBCollectionName))
+		return nil
+	}
+	coll, err := b.getMilvusClient().DescribeCollection(ctx, targetDBName, targetCollectionName)
 	if err != nil {
-		return task, err
+		return err
 	}
-	wp.Start()
-	log.Info("Start collection level restore pool", zap.Int("parallelism", b.params.BackupCfg.RestoreParallelism))
-
-	id := task.GetId()
-	b.restoreTasks[id] = task
-	task.StateCode = backuppb.RestoreTaskStateCode_EXECUTING
-
-	log.Info("executeRestoreBackupTask start",
-		zap.String("backup_name", backup.GetName()),
-		zap.String("backupBucketName", backupBucketName),
-		zap.String("backupPath", backupPath))
-	updateRestoreTaskFunc := func(id string, task *backuppb.RestoreBackupTask) {
-		b.restoreTasks[id] = task
+	if isPartitionKeyCollection(collBackup.GetSchema()) {
+		partitions, err := b.getMilvusClient().ShowPartitions(ctx, targetDBName, targetCollectionName)
+		if err != nil {
+			return err
+		}
+		if !samePartitions(collBackup, partitions) {
+			report.Repartitioned = append(report.Repartitioned, fmt.Sprintf("%s: %d partitions in backup, %d in collection",
+				targetDBCollectionName, len(collBackup.GetPartitionBackups()), len(partitions)))
+		}
 	}
-	defer updateRestoreTaskFunc(id, task)
+	_, _, mismatches := mapCollectionSchema(collBackup.GetSchema(), coll.Schema, request.GetSchemaPolicy(), request.GetFieldMappings())
+	for _, mismatch := range mismatches {
+		report.SchemaMismatches = append(report.SchemaMismatches, targetDBCollectionName+": "+mismatch)
+	}
+	return nil
+}
 
-	restoreCollectionTasks := task.GetCollectionRestoreTasks()
+// compareCollectionSchema returns the differences between the schema in backup and the schema of an existing collection
+// which make the backup data can't be bulk inserted into the collection
+func compareCollectionSchema(backupSchema *backuppb.CollectionSchema, schema *entity.Schema) []string {
+	_, _, mismatches := mapCollectionSchema(backupSchema, schema, SchemaPolicyStrict, nil)
+	return mismatches
+}
 
-	// 3, execute restoreCollectionTasks
-	for _, restoreCollectionTask := range restoreCollectionTasks {
-		restoreCollectionTaskClone := restoreCollectionTask
-		job := func(ctx context.Context) error {
-			endTask, err := b.executeRestoreCollectionTask(ctx, backupBucketName, backupPath, restoreCollectionTaskClone, id)
-			if err != nil {
-				log.Error("executeRestoreCollectionTask failed",
-					zap.String("TargetDBName", restoreCollectionTaskClone.GetTargetDbName()),
-					zap.String("TargetCollectionName", restoreCollectionTaskClone.GetTargetCollectionName()),
-					zap.Error(err))
-				return err
+// mapCollectionSchema maps the fields in backup to the fields of an existing collection by name, or by the
+// field mappings of backup field name to collection field name. It returns the field ids of the collection
+// for the backup fields whose ids differ, the ids of the backup fields not restored, and the differences
+// not allowed by the schema policy.
+func mapCollectionSchema(backupSchema *backuppb.CollectionSchema, schema *entity.Schema, policy string, mappings map[string]string) (map[int64]int64, []int64, []string) {
+	mismatches := make([]string, 0)
+	if schema == nil {
+		return nil, nil, append(mismatches, "schema of collection is unknown")
+	}
+	compatible := policy == SchemaPolicyCompatible
+	fields := make(map[string]*entity.Field, len(schema.Fields))
+	for _, field := range schema.Fields {
+		fields[field.Name] = field
+	}
+	fieldIDs := make(map[int64]int64)
+	skippedFields := make([]int64, 0)
+	mappedFields := make(map[string]bool, len(backupSchema.GetFields()))
+	backupFields := make(map[string]bool, len(backupSchema.GetFields()))
+	for _, backupField := range backupSchema.GetFields() {
+		backupFields[backupField.GetName()] = true
+		name := backupField.GetName()
+		if mapping, ok := mappings[name]; ok {
+			name = mapping
+		}
+		field, ok := fields[name]
+		if !ok {
+			if compatible && !backupField.GetIsPrimaryKey() {
+				skippedFields = append(skippedFields, backupField.GetFieldID())
+				continue
 			}
-			log.Info("finish restore collection",
-				zap.String("db_name", restoreCollectionTaskClone.GetTargetDbName()),
-				zap.String("collection_name", restoreCollectionTaskClone.GetTargetCollectionName()))
-			restoreCollectionTaskClone.StateCode = backuppb.RestoreTaskStateCode_SUCCESS
-			task.RestoredSize += endTask.RestoredSize
-			if task.GetToRestoreSize() == 0 {
-				task.Progress = 100
+			if name != backupField.GetName() {
+				mismatches = append(mismatches, fmt.Sprintf("field %s mapped from %s does not exist", name, backupField.GetName()))
 			} else {
-				task.Progress = int32(100 * task.GetRestoredSize() / task.GetToRestoreSize())
+				mismatches = append(mismatches, fmt.Sprintf("field %s does not exist", name))
 			}
-			updateRestoreTaskFunc(id, task)
-			return nil
+			continue
+		}
+		mappedFields[field.Name] = true
+		if field.DataType != entity.FieldType(backupField.GetDataType()) {
+			mismatches = append(mismatches, fmt.Sprintf("field %s has data type %s, %s in backup", field.Name, field.DataType.Name(), entity.FieldType(backupField.GetDataType()).Name()))
+			continue
+		}
+		backupDim := utils.KvPairsMap(backupField.GetTypeParams())["dim"]
+		if dim := field.TypeParams["dim"]; dim != backupDim {
+			mismatches = append(mismatches, fmt.Sprintf("field %s has dim %s, %s in backup", field.Name, dim, backupDim))
+		}
+		if field.PrimaryKey != backupField.GetIsPrimaryKey() {
+			mismatches = append(mismatches, fmt.Sprintf("field %s primary key mismatch", field.Name))
+		}
+		if field.ID != backupField.GetFieldID() {
+			fieldIDs[backupField.GetFieldID()] = field.ID
 		}
-		wp.Submit(job)
 	}
-	wp.Done()
-	if err := wp.Wait(); err != nil {
-		return task, err
+	for name := range mappings {
+		if !backupFields[name] {
+			mismatches = append(mismatches, fmt.Sprintf("field %s to map does not exist in backup", name))
+		}
+	}
+	// fields only in the collection, including its dynamic field, are filled by milvus if compatible
+	if !compatible {
+		for _, field := range schema.Fields {
+			if !mappedFields[field.Name] {
+				mismatches = append(mismatches, fmt.Sprintf("field %s does not exist in backup", field.Name))
+			}
+		}
+		if schema.EnableDynamicField != backupSchema.GetEnableDynamicField() {
+			mismatches = append(mismatches, fmt.Sprintf("enable dynamic field is %t, %t in backup", schema.EnableDynamicField, backupSchema.GetEnableDynamicField()))
+		}
 	}
+	return fieldIDs, skippedFields, mismatches
+}
 
-	task.StateCode = backuppb.RestoreTaskStateCode_SUCCESS
-	task.EndTime = time.Now().Unix()
-	return task, nil
+// dryRunRestoreBackup checks the binlogs of the partitions to restore exist in backup storage and estimates the duration,
+// returns the restore task as the plan without submitting it
+func (b *BackupContext) dryRunRestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest, backupBucketName string, backupPath string, task *backuppb.RestoreBackupTask, report *backuppb.RestoreDryRunReport) *backuppb.RestoreBackupResponse {
+	resp := &backuppb.RestoreBackupResponse{
+		RequestId: request.GetRequestId(),
+		Data:      task,
+	}
+
+	if !request.GetMetaOnly() {
+		for _, collTask := range task.GetCollectionRestoreTasks() {
+			for _, partition := range collTask.GetCollBackup().GetPartitionBackups() {
+				if len(partition.GetSegmentBackups()) == 0 {
+					continue
+				}
+				// binlogs of groups unchanged in incremental backup are stored in the referenced backups
+				paths := map[string]bool{backupPath: true}
+				for _, refBackupName := range collectRefBackupsFromSegments(partition.GetSegmentBackups()) {
+					paths[path.Dir(backupPath)+SEPERATOR+refBackupName] = true
+				}
+				for partitionBackupPath := range paths {
+					insertPath := BinlogPartitionDirPath(path.Dir(partitionBackupPath), path.Base(partitionBackupPath), collTask.GetCollBackup(), INSERT_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId())
+					exist, err := b.getBackupStorageClient().Exist(ctx, backupBucketName, insertPath)
+					if err != nil {
+						log.Error("fail to check binlog exist", zap.String("path", insertPath), zap.Error(err))
+						resp.Code = backuppb.ResponseCode_Fail
+						resp.Msg = err.Error()
+						return resp
+					}
+					if !exist {
+						report.MissingFiles = append(report.MissingFiles, insertPath)
+					}
+				}
+			}
+		}
+		if limit := b.params.BackupCfg.BandwidthLimit; limit > 0 {
+			// binlogs are read from backup storage and written to milvus storage
+			report.EstimatedSeconds = int64(math.Ceil(float64(task.GetToRestoreSize()) / float64(limit*1024*1024)))
+		}
+	}
+	resp.DryRunReport = report
+
+	if len(report.GetConflicts()) > 0 || len(report.GetSchemaMismatches()) > 0 || len(report.GetMissingFiles()) > 0 || len(report.GetAliasConflicts()) > 0 || len(report.GetCorruptedFiles()) > 0 {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = fmt.Sprintf("dry run found problems, %d conflicts, %d schema mismatches, %d missing files, %d alias conflicts, %d corrupted files",
+			len(report.GetConflicts()), len(report.GetSchemaMismatches()), len(report.GetMissingFiles()), len(report.GetAliasConflicts()), len(report.GetCorruptedFiles()))
+	} else {
+		resp.Code = backuppb.ResponseCode_Success
+		resp.Msg = "dry run success"
+	}
+	log.Info("dry run restore",
+		zap.String("backupName", request.GetBackupName()),
+		zap.String("msg", resp.GetMsg()),
+		zap.Int("collections", len(task.GetCollectionRestoreTasks())),
+		zap.Int64("size", task.GetToRestoreSize()),
+		zap.Strings("conflicts", report.GetConflicts()),
+		zap.Strings("schemaMismatches", report.GetSchemaMismatches()),
+		zap.Strings("databasesToCreate", report.GetDatabasesToCreate()),
+		zap.Strings("missingFiles", report.GetMissingFiles()),
+		zap.Strings("corruptedFiles", report.GetCorruptedFiles()),
+		zap.Strings("aliasConflicts", report.GetAliasConflicts()),
+		zap.Strings("repartitioned", report.GetRepartitioned()))
+	return resp
 }
 
-func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backupBucketName string, backupPath string, task *backuppb.RestoreCollectionTask, parentTaskID string) (*backuppb.RestoreCollectionTask, error) {
-	targetDBName := task.GetTargetDbName()
-	targetCollectionName := task.GetTargetCollectionName()
-	task.StateCode = backuppb.RestoreTaskStateCode_EXECUTING
-	log.Info("start restore",
-		zap.String("db_name", targetDBName),
-		zap.String("collection_name", targetCollectionName),
-		zap.String("backupBucketName", backupBucketName),
+// resumeRestoreBackup continues an interrupted restore task from the checkpoint written during its execution,
+// the collections, partitions and segment groups already restored are skipped
+func (b *BackupContext) resumeRestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest, backupBucketName string, backupPath string, backup *backuppb.BackupInfo) *backuppb.RestoreBackupResponse {
+	resp := &backuppb.RestoreBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if value, ok := b.restoreTasks[request.GetResumeTaskId()]; ok && value.GetStateCode() == backuppb.RestoreTaskStateCode_EXECUTING {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("restore task is still executing: %s", request.GetResumeTaskId())
+		return resp
+	}
+
+	task, err := b.readRestoreCheckpoint(ctx, backupBucketName, backupPath, request.GetResumeTaskId())
+	if err != nil {
+		log.Error("fail to read restore checkpoint", zap.String("taskId", request.GetResumeTaskId()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if task == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = fmt.Sprintf("no checkpoint of restore task %s in backup %s", request.GetResumeTaskId(), request.GetBackupName())
+		return resp
+	}
+	if task.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("restore task is already finished: %s", request.GetResumeTaskId())
+		resp.Data = task
+		return resp
+	}
+
+	prepareRestoreTaskForResume(task)
+	log.Info("resume restore from checkpoint",
+		zap.String("taskId", task.GetId()),
+		zap.String("backupName", backup.GetName()),
+		zap.Int64("restoredSize", task.GetRestoredSize()),
+		zap.Int64("toRestoreSize", task.GetToRestoreSize()))
+	return b.submitRestoreBackup(ctx, request, backupBucketName, backupPath, backup, task)
+}
+
+// prepareRestoreTaskForResume resets the state of an interrupted restore task. The collections already created
+// are not dropped or created again, neither are their indexes unless deferred after the data.
+func prepareRestoreTaskForResume(task *backuppb.RestoreBackupTask) {
+	task.StateCode = backuppb.RestoreTaskStateCode_INITIAL
+	task.ErrorMessage = ""
+	task.EndTime = 0
+	for _, collectionTask := range task.GetCollectionRestoreTasks() {
+		if collectionTask.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			continue
+		}
+		collectionTask.StateCode = backuppb.RestoreTaskStateCode_INITIAL
+		collectionTask.ErrorMessage = ""
+		if collectionTask.GetMetaRestored() {
+			collectionTask.DropExistCollection = false
+			collectionTask.SkipCreateCollection = true
+			collectionTask.DropExistIndex = false
+			// deferred indexes are created after the data, not yet
+			if collectionTask.GetIndexMode() != IndexModeDeferred {
+				collectionTask.RestoreIndex = false
+			}
+		}
+	}
+}
+
+func (b *BackupContext) submitRestoreBackup(ctx context.Context, request *backuppb.RestoreBackupRequest, backupBucketName string, backupPath string, backup *backuppb.BackupInfo, task *backuppb.RestoreBackupTask) *backuppb.RestoreBackupResponse {
+	resp := &backuppb.RestoreBackupResponse{
+		RequestId: request.GetRequestId(),
+		JobId:     task.GetId(),
+	}
+	// the job is resumed from the checkpoint in the backup restored from
+	var jobBucketName, jobPath string
+	if request.GetBucketName() != "" && request.GetPath() != "" {
+		jobBucketName, jobPath = request.GetBucketName(), request.GetPath()
+	}
+	ctx, finishJob := b.startJob(ctx, task.GetId(), metrics.RestoreTaskLabel, backup.GetName(), jobBucketName, jobPath)
+	if request.Async {
+		go func() {
+			_, err := b.runRestoreBackupTask(ctx, request, backupBucketName, backupPath, backup, task)
+			finishJob(err)
+		}()
+		asyncResp := &backuppb.RestoreBackupResponse{
+			RequestId: request.GetRequestId(),
+			Code:      backuppb.ResponseCode_Success,
+			Msg:       "restore backup is executing asynchronously",
+			Data:      task,
+			JobId:     task.GetId(),
+		}
+		return asyncResp
+	} else {
+		endTask, err := b.runRestoreBackupTask(ctx, request, backupBucketName, backupPath, backup, task)
+		finishJob(err)
+		resp.Data = endTask
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			log.Error("execute restore collection fail", zap.String("backupId", backup.GetId()), zap.Error(err))
+			resp.Msg = err.Error()
+		} else {
+			resp.Code = backuppb.ResponseCode_Success
+			resp.Msg = "success"
+			if mismatches := RestoreRowCountMismatches(endTask); len(mismatches) > 0 {
+				resp.Msg += ", row counts mismatch: " + strings.Join(mismatches, "; ")
+			}
+			if mismatches := RestoreSampleMismatches(endTask); len(mismatches) > 0 {
+				resp.Msg += ", sampled rows mismatch: " + strings.Join(mismatches, "; ")
+			}
+		}
+		return resp
+	}
+}
+
+// runRestoreBackupTask executes the restore task, records the result in metrics and notifies the webhooks
+func (b *BackupContext) runRestoreBackupTask(ctx context.Context, request *backuppb.RestoreBackupRequest, backupBucketName string, backupPath string, backup *backuppb.BackupInfo, task *backuppb.RestoreBackupTask) (*backuppb.RestoreBackupTask, error) {
+	start := time.Now()
+	ctx, span := trace.Start(ctx, "RestoreBackup",
+		attribute.String("backup.name", backup.GetName()),
+		attribute.String("restore.task_id", task.GetId()))
+	stopWatch := b.watchRestore(backup.GetName(), task, start)
+	recorder := newRunRecorder()
+	endTask, err := b.executeRestoreBackupTask(withRunRecorder(ctx, recorder), request, backupBucketName, backupPath, backup, task)
+	stopWatch()
+	// ctx may have been canceled, the summary is still written
+	b.writeRunSummary(b.ctx, backupBucketName, backupPath, restoreRunSummary(recorder, backup.GetName(), endTask))
+	trace.End(span, err)
+	metrics.ObserveTask(metrics.RestoreTaskLabel, start, err)
+	b.notifyRestore(backup.GetName(), endTask, start, err)
+	return endTask, err
+}
+
+func (b *BackupContext) executeRestoreBackupTask(ctx context.Context, request *backuppb.RestoreBackupRequest, backupBucketName string, backupPath string, backup *backuppb.BackupInfo, task *backuppb.RestoreBackupTask) (*backuppb.RestoreBackupTask, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	parallelism := b.params.BackupCfg.RestoreParallelism
+	if request.GetCollectionParallelism() > 0 {
+		parallelism = int(request.GetCollectionParallelism())
+	}
+	concurrency := b.params.BackupCfg.BulkInsertConcurrency
+	if request.GetBulkinsertParallelism() > 0 {
+		concurrency = int(request.GetBulkinsertParallelism())
+	}
+	// collections are created by parallelism, the segment groups of all collections are bulk inserted by concurrency
+	scheduler := newRestoreScheduler(map[string]int{
+		restoreJobCollection: parallelism,
+		restoreJobBulkInsert: concurrency,
+	})
+	log.Info("Start restore scheduler", zap.Int("collectionParallelism", parallelism), zap.Int("bulkInsertConcurrency", concurrency))
+	// limits the import tasks executing in milvus during the restore
+	if maxJobs := b.params.BackupCfg.BulkInsertMaxJobs; maxJobs > 0 {
+		b.bulkInsertLimiter = common.NewAdaptiveLimiter(maxJobs)
+		defer func() { b.bulkInsertLimiter = nil }()
+	}
+
+	id := task.GetId()
+	b.restoreTasks[id] = task
+	task.StateCode = backuppb.RestoreTaskStateCode_EXECUTING
+
+	log.Info("executeRestoreBackupTask start",
+		zap.String("backup_name", backup.GetName()),
+		zap.String("backupBucketName", backupBucketName),
+		zap.String("backupPath", backupPath))
+	updateRestoreTaskFunc := func(id string, task *backuppb.RestoreBackupTask) {
+		b.restoreTasks[id] = task
+	}
+	defer updateRestoreTaskFunc(id, task)
+
+	restoreCollectionTasks := task.GetCollectionRestoreTasks()
+	b.writeRestoreCheckpoint(ctx, backupBucketName, backupPath, task)
+	recorder := runRecorderFromContext(ctx)
+	recorder.startPhase("prepare")
+
+	// roles are restored before collections, the grants on collections don't depend on their existence
+	if request.GetRbac() {
+		if backup.GetRbacMeta() == nil {
+			log.Warn("rbac is not backed up, skip restore rbac", zap.String("backup_name", backup.GetName()))
+		} else if err := b.restoreRBAC(ctx, backup.GetRbacMeta(), request.GetRbacUserPassword()); err != nil {
+			task.StateCode = backuppb.RestoreTaskStateCode_FAIL
+			task.ErrorMessage = err.Error()
+			b.writeRestoreCheckpoint(ctx, backupBucketName, backupPath, task)
+			return task, err
+		}
+	}
+
+	// binlogs encrypted by customer key are read and copied with the key
+	sse, err := b.backupServerSideEncryption(backup, request.GetSseCustomerKey())
+	if err != nil {
+		task.StateCode = backuppb.RestoreTaskStateCode_FAIL
+		task.ErrorMessage = err.Error()
+		b.writeRestoreCheckpoint(ctx, backupBucketName, backupPath, task)
+		return task, err
+	}
+	ctx = storage.WithSourceServerSideEncryption(ctx, sse)
+
+	// archived binlogs can't be copied, all of them are requested to be restored before waiting
+	if err := b.rehydrateBackupFiles(ctx, backupBucketName, backupPath, task); err != nil {
+		task.StateCode = backuppb.RestoreTaskStateCode_FAIL
+		task.ErrorMessage = err.Error()
+		b.writeRestoreCheckpoint(b.ctx, backupBucketName, backupPath, task)
+		return task, err
+	}
+
+	// 3, execute restoreCollectionTasks
+	recorder.startPhase("restore")
+	restoredBefore := task.GetRestoredSize()
+	runs := make([]*restoreCollectionRun, 0, len(restoreCollectionTasks))
+	for _, restoreCollectionTask := range restoreCollectionTasks {
+		// restored before the restore task is resumed
+		if restoreCollectionTask.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			log.Info("skip restored collection",
+				zap.String("db_name", restoreCollectionTask.GetTargetDbName()),
+				zap.String("collection_name", restoreCollectionTask.GetTargetCollectionName()))
+			continue
+		}
+		runs = append(runs, b.scheduleRestoreCollection(scheduler, backupBucketName, backupPath, restoreCollectionTask, task))
+	}
+	err = scheduler.Run(ctx)
+	// temporary files of the collections failed or canceled are removed, those of the restored ones are removed already
+	for _, run := range runs {
+		b.removeRestoreTempDir(run)
+	}
+	b.restoreCheckpointMu.Lock()
+	recorder.endPhase(task.GetRestoredSize() - restoredBefore)
+	b.restoreCheckpointMu.Unlock()
+	if err != nil {
+		task.StateCode = backuppb.RestoreTaskStateCode_FAIL
+		task.ErrorMessage = err.Error()
+		task.EndTime = time.Now().Unix()
+		// ctx may have been canceled, the checkpoint is still written so that the restore can be resumed
+		b.writeRestoreCheckpoint(b.ctx, backupBucketName, backupPath, task)
+		return task, err
+	}
+
+	task.StateCode = backuppb.RestoreTaskStateCode_SUCCESS
+	task.EndTime = time.Now().Unix()
+	b.writeRestoreCheckpoint(ctx, backupBucketName, backupPath, task)
+	return task, nil
+}
+
+// restoreCollectionRun is the state of a collection shared by the jobs restoring it
+type restoreCollectionRun struct {
+	task             *backuppb.RestoreCollectionTask
+	parentTask       *backuppb.RestoreBackupTask
+	backupBucketName string
+	backupPath       string
+	// segment groups bulk inserted before the restore task is resumed
+	restoredGroups map[string]bool
+
+	// set by the collection job, read by the jobs depending on it
+	collectionSchema *entity.Schema
+	isSameBucket     bool
+	encodedGroups    map[int64]*backuppb.SegmentBackupInfo
+	tempDir          string
+	// the temporary dir is written, and removed once the collection ends
+	useTempDir     bool
+	removeTempOnce sync.Once
+	// partition to bulk insert into of each partition in backup by its id, empty to dispatch by the partition key
+	targetPartitions map[int64]string
+}
+
+// scheduleRestoreCollection adds the jobs restoring a collection into the scheduler. The collection job creates the
+// collection, its indexes and partitions, a bulk insert job of each segment group not restored yet runs after it,
+// and the finish job after all of them checks the rows, creates the deferred indexes and aliases and loads it.
+func (b *BackupContext) scheduleRestoreCollection(scheduler *restoreScheduler, backupBucketName string, backupPath string, task *backuppb.RestoreCollectionTask, parentTask *backuppb.RestoreBackupTask) *restoreCollectionRun {
+	run := &restoreCollectionRun{
+		task:             task,
+		parentTask:       parentTask,
+		backupBucketName: backupBucketName,
+		backupPath:       backupPath,
+		restoredGroups:   make(map[string]bool),
+		targetPartitions: make(map[int64]string),
+	}
+	for _, key := range task.GetRestoredGroups() {
+		run.restoredGroups[key] = true
+	}
+	name := task.GetTargetDbName() + "." + task.GetTargetCollectionName()
+	collectionAttributes := []attribute.KeyValue{
+		attribute.String("collection.db", task.GetTargetDbName()),
+		attribute.String("collection.name", task.GetTargetCollectionName()),
+	}
+
+	collectionJob := scheduler.add("create "+name, restoreJobCollection, func(ctx context.Context) error {
+		ctx, span := trace.Start(ctx, "restoreCollection", collectionAttributes...)
+		task.StartTime = time.Now().Unix()
+		err := b.prepareRestoreCollection(ctx, run)
+		trace.End(span, err)
+		return b.failRestoreCollection(run, err)
+	})
+	dependencies := []*restoreJob{collectionJob}
+	if !task.GetMetaOnly() {
+		for _, partitionBackup := range task.GetCollBackup().GetPartitionBackups() {
+			partitionBackup := partitionBackup
+			groupIds := collectGroupIdsFromSegments(partitionBackup.GetSegmentBackups())
+			// backward compatible old backup without group id, the whole partition is bulk inserted at once
+			legacy := len(groupIds) == 1 && groupIds[0] == 0
+			for _, groupId := range groupIds {
+				groupId := groupId
+				if run.restoredGroups[restoredGroupKey(partitionBackup.GetPartitionId(), groupId)] {
+					log.Info("skip restored segment group",
+						zap.String("targetCollectionName", task.GetTargetCollectionName()),
+						zap.String("partition", partitionBackup.GetPartitionName()),
+						zap.Int64("groupId", groupId))
+					continue
+				}
+				groupJob := scheduler.add(fmt.Sprintf("bulkinsert %s/%s/%d", name, partitionBackup.GetPartitionName(), groupId), restoreJobBulkInsert, func(ctx context.Context) error {
+					ctx, span := trace.Start(ctx, "restoreSegmentGroup", append(collectionAttributes,
+						attribute.String("partition.name", partitionBackup.GetPartitionName()),
+						attribute.Int64("group.id", groupId))...)
+					err := b.restoreSegmentGroup(ctx, run, partitionBackup, groupId, legacy)
+					trace.End(span, err)
+					return b.failRestoreCollection(run, err)
+				}, collectionJob)
+				dependencies = append(dependencies, groupJob)
+			}
+		}
+	}
+	scheduler.add("finish "+name, restoreJobFinish, func(ctx context.Context) error {
+		ctx, span := trace.Start(ctx, "finishRestoreCollection", collectionAttributes...)
+		err := b.finishRestoreCollection(ctx, run)
+		trace.End(span, err)
+		return b.failRestoreCollection(run, err)
+	}, dependencies...)
+	return run
+}
+
+// failRestoreCollection marks the collection failed by the error of one of its jobs, if the error is not nil
+func (b *BackupContext) failRestoreCollection(run *restoreCollectionRun, err error) error {
+	if err == nil {
+		return nil
+	}
+	task := run.task
+	log.Error("fail to restore collection",
+		zap.String("TargetDBName", task.GetTargetDbName()),
+		zap.String("TargetCollectionName", task.GetTargetCollectionName()),
+		zap.Error(err))
+	b.restoreCheckpointMu.Lock()
+	defer b.restoreCheckpointMu.Unlock()
+	if task.GetStateCode() != backuppb.RestoreTaskStateCode_FAIL {
+		task.StateCode = backuppb.RestoreTaskStateCode_FAIL
+		task.ErrorMessage = err.Error()
+		task.EndTime = time.Now().Unix()
+	}
+	return err
+}
+
+// prepareRestoreCollection creates the collection, its indexes unless deferred and its partitions
+func (b *BackupContext) prepareRestoreCollection(ctx context.Context, run *restoreCollectionRun) error {
+	task, parentTask := run.task, run.parentTask
+	backupBucketName, backupPath := run.backupBucketName, run.backupPath
+	targetDBName := task.GetTargetDbName()
+	targetCollectionName := task.GetTargetCollectionName()
+	task.StateCode = backuppb.RestoreTaskStateCode_EXECUTING
+	log.Info("start restore",
+		zap.String("db_name", targetDBName),
+		zap.String("collection_name", targetCollectionName),
+		zap.String("backupBucketName", backupBucketName),
 		zap.String("backupPath", backupPath))
 	// create collection
 	fields := make([]*entity.Field, 0)
@@ -421,17 +1317,35 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 	}
 
 	if task.GetDropExistCollection() {
-		err := b.milvusClient.DropCollection(ctx, targetDBName, targetCollectionName)
+		err := b.getMilvusClient().DropCollection(ctx, targetDBName, targetCollectionName)
 		if err != nil {
 			errorMsg := fmt.Sprintf("fail to drop collection, CollectionName: %s.%s err: %s", targetDBName, targetCollectionName, err)
 			log.Error(errorMsg)
 			task.StateCode = backuppb.RestoreTaskStateCode_FAIL
 			task.ErrorMessage = errorMsg
-			return task, err
+			return err
 		}
 	}
 	if !task.GetSkipCreateCollection() {
 		err := retry.Do(ctx, func() error {
+			if needRawSchema(task.GetCollBackup().GetSchema()) {
+				// functions and sparse vectors are not supported by sdk
+				var partitionNum int64
+				if hasPartitionKey {
+					partitionNum = int64(len(task.GetCollBackup().GetPartitionBackups()))
+				}
+				schema := collectionSchema.ProtoMessage()
+				if err := writeSchemaFunctions(schema, task.GetCollBackup().GetSchema()); err != nil {
+					return retry.Unrecoverable(err)
+				}
+				return b.getMilvusClient().CreateCollectionWithSchema(
+					ctx,
+					targetDBName,
+					schema,
+					task.GetCollBackup().GetShardsNum(),
+					commonpb.ConsistencyLevel(task.GetCollBackup().GetConsistencyLevel()),
+					partitionNum)
+			}
 			if hasPartitionKey {
 				partitionNum := len(task.GetCollBackup().GetPartitionBackups())
 				return b.getMilvusClient().CreateCollection(
@@ -454,12 +1368,24 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 			log.Error(errorMsg)
 			task.StateCode = backuppb.RestoreTaskStateCode_FAIL
 			task.ErrorMessage = errorMsg
-			return task, err
+			return err
 		}
 		log.Info("create collection",
 			zap.String("database", targetDBName),
 			zap.String("collectionName", targetCollectionName),
 			zap.Bool("hasPartitionKey", hasPartitionKey))
+		if len(task.GetProperties()) > 0 {
+			if err := b.getMilvusClient().AlterCollectionProperties(ctx, targetDBName, targetCollectionName, task.GetProperties()); err != nil {
+				errorMsg := fmt.Sprintf("fail to set collection properties, targetCollectionName: %s err: %s", targetCollectionName, err)
+				log.Error(errorMsg)
+				task.StateCode = backuppb.RestoreTaskStateCode_FAIL
+				task.ErrorMessage = errorMsg
+				return err
+			}
+			log.Info("set collection properties",
+				zap.String("collectionName", targetCollectionName),
+				zap.Any("properties", task.GetProperties()))
+		}
 	} else {
 		log.Info("skip create collection",
 			zap.String("database", targetDBName),
@@ -480,17 +1406,17 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 					continue
 				} else {
 					log.Error("fail in DescribeIndex", zap.Error(err))
-					return task, err
+					return err
 				}
 			}
 			for _, fieldIndex := range fieldIndexs {
-				err = b.milvusClient.DropIndex(ctx, targetDBName, targetCollectionName, fieldIndex.Name())
+				err = b.getMilvusClient().DropIndex(ctx, targetDBName, targetCollectionName, fieldIndex.Name())
 				if err != nil {
 					log.Warn("Fail to drop index",
 						zap.String("db", targetDBName),
 						zap.String("collection", targetCollectionName),
 						zap.Error(err))
-					return task, err
+					return err
 				}
 				log.Info("drop index",
 					zap.String("collection_name", targetCollectionName),
@@ -500,208 +1426,551 @@ func (b *BackupContext) executeRestoreCollectionTask(ctx context.Context, backup
 		}
 	}
 
-	if task.GetRestoreIndex() {
-		vectorFields := make(map[string]bool, 0)
-		for _, field := range collectionSchema.Fields {
-			if strings.HasSuffix(strings.ToLower(field.DataType.Name()), "vector") {
-				vectorFields[field.Name] = true
-			}
+	// indexes of restore tasks created before index modes are created immediately
+	if task.GetRestoreIndex() && task.GetIndexMode() != IndexModeDeferred {
+		if err := b.restoreIndexes(ctx, task, targetDBName, targetCollectionName, collectionSchema); err != nil {
+			return err
 		}
-		indexes := task.GetCollBackup().GetIndexInfos()
-		for _, index := range indexes {
-			var idx entity.Index
-			log.Info("source index",
-				zap.String("indexName", index.GetIndexName()),
-				zap.String("indexType", index.GetIndexType()),
-				zap.Any("params", index.GetParams()))
-			if _, ok := vectorFields[index.GetFieldName()]; ok && task.GetUseAutoIndex() {
-				log.Info("use auto index")
-				params := make(map[string]string, 0)
-				// auto index only support index_type and metric_type in params
-				params["index_type"] = "AUTOINDEX"
-				params["metric_type"] = index.GetParams()["metric_type"]
-				idx = entity.NewGenericIndex(index.GetIndexName(), entity.AUTOINDEX, index.GetFieldName(), params)
-			} else {
-				log.Info("not auto index")
-				indexType := index.GetIndexType()
-				if indexType == "marisa-trie" {
-					indexType = "Trie"
-				}
-				params := index.GetParams()
-				if params["index_type"] == "marisa-trie" {
-					params["index_type"] = "Trie"
-				}
-				idx = entity.NewGenericIndex(index.GetIndexName(), entity.IndexType(indexType), index.GetFieldName(), index.GetParams())
-			}
-			err := b.getMilvusClient().CreateIndex(ctx, targetDBName, targetCollectionName, index.GetFieldName(), idx, true)
-			if err != nil {
-				log.Warn("Fail to restore index", zap.Error(err))
-				return task, err
-			}
+	}
+
+	// the rows before restore are recorded in the checkpoint, a resumed restore compares with them
+	if task.GetCheckRowCount() && len(task.GetRowCountChecks()) == 0 {
+		task.RowCountChecks = restoreRowCountChecks(ctx, task, b.milvusRowCounter(targetDBName, targetCollectionName))
+	}
+	task.MetaRestored = true
+	b.writeRestoreCheckpoint(ctx, backupBucketName, backupPath, parentTask)
+
+	run.tempDir = fmt.Sprintf("restore-temp-%s-%s-%s%s", parentTask.GetId(), task.TargetDbName, task.TargetCollectionName, SEPERATOR)
+	b.planRestoreData(ctx, run)
+	log.Info("restore collection data",
+		zap.String("targetCollectionName", targetCollectionName),
+		zap.Bool("inPlace", task.GetInPlace()))
+	run.collectionSchema = collectionSchema
+
+	for _, partitionBackup := range task.GetCollBackup().GetPartitionBackups() {
+		targetPartitionName, err := b.createRestorePartition(ctx, targetDBName, targetCollectionName, partitionBackup, task)
+		if err != nil {
+			return err
 		}
+		run.targetPartitions[partitionBackup.GetPartitionId()] = targetPartitionName
+	}
+	if task.GetMetaOnly() {
+		task.Progress = 100
 	}
+	return nil
+}
 
-	tempDir := fmt.Sprintf("restore-temp-%s-%s-%s%s", parentTaskID, task.TargetDbName, task.TargetCollectionName, SEPERATOR)
-	isSameBucket := b.milvusBucketName == backupBucketName
-	// clean the temporary file
-	defer func() {
-		if !isSameBucket && !b.params.BackupCfg.KeepTempFiles {
-			log.Info("Delete temporary file", zap.String("dir", tempDir))
-			err := b.getStorageClient().RemoveWithPrefix(ctx, b.milvusBucketName, tempDir)
-			if err != nil {
-				log.Warn("Delete temporary file failed", zap.Error(err))
-			}
+// finishRestoreCollection runs the steps after the data of the collection is restored, and marks it restored
+func (b *BackupContext) finishRestoreCollection(ctx context.Context, run *restoreCollectionRun) error {
+	task, parentTask := run.task, run.parentTask
+	targetDBName := task.GetTargetDbName()
+	targetCollectionName := task.GetTargetCollectionName()
+	collectionSchema := run.collectionSchema
+	backupBucketName, backupPath := run.backupBucketName, run.backupPath
+	if task.GetCheckRowCount() {
+		compareRestoredRows(ctx, task, b.milvusRowCounter(targetDBName, targetCollectionName))
+	}
+	if task.GetRestoreIndex() && task.GetIndexMode() == IndexModeDeferred {
+		log.Info("create deferred indexes after data restored",
+			zap.String("targetDBName", targetDBName),
+			zap.String("targetCollectionName", targetCollectionName))
+		if err := b.restoreIndexes(ctx, task, targetDBName, targetCollectionName, collectionSchema); err != nil {
+			return err
 		}
-	}()
+	}
+	if err := b.restoreAliases(ctx, task, targetDBName, targetCollectionName); err != nil {
+		return err
+	}
+	if task.GetLoadCollection() {
+		log.Info("load restored collection",
+			zap.String("targetDBName", targetDBName),
+			zap.String("targetCollectionName", targetCollectionName),
+			zap.Int32("replicaNumber", task.GetReplicaNumber()),
+			zap.Strings("resourceGroups", task.GetResourceGroups()))
+		if err := b.getMilvusClient().LoadCollection(ctx, targetDBName, targetCollectionName, task.GetReplicaNumber(), task.GetResourceGroups()); err != nil {
+			return fmt.Errorf("fail to load collection %s.%s: %w", targetDBName, targetCollectionName, err)
+		}
+	}
+	if task.GetVerifySampleRows() > 0 {
+		task.SampleVerification = b.verifyRestoredSample(ctx, backupBucketName, backupPath, task)
+	}
+	b.removeRestoreTempDir(run)
 
-	jobIds := make([]int64, 0)
-	for _, partitionBackup := range task.GetCollBackup().GetPartitionBackups() {
-		partitionBackup2 := partitionBackup
-		job := func(ctx context.Context) error {
-			log.Info("start restore partition",
-				zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-				zap.String("targetDBName", targetDBName),
-				zap.String("targetCollectionName", targetCollectionName),
-				zap.String("partition", partitionBackup2.GetPartitionName()))
-			_, err := b.restorePartition(ctx, targetDBName, targetCollectionName, partitionBackup2, task, isSameBucket, backupBucketName, backupPath, tempDir)
+	log.Info("finish restore collection",
+		zap.String("db_name", targetDBName),
+		zap.String("collection_name", targetCollectionName))
+	b.restoreCheckpointMu.Lock()
+	task.EndTime = time.Now().Unix()
+	task.StateCode = backuppb.RestoreTaskStateCode_SUCCESS
+	task.Progress = 100
+	UpdateRestoreBackupTask(parentTask)
+	b.restoreCheckpointMu.Unlock()
+	b.restoreTasks[parentTask.GetId()] = parentTask
+	b.writeRestoreCheckpoint(ctx, backupBucketName, backupPath, parentTask)
+	return nil
+}
+
+// removeRestoreTempDir removes the temporary files of the collection in milvus bucket, also if the restore fails
+// or is canceled
+func (b *BackupContext) removeRestoreTempDir(run *restoreCollectionRun) {
+	run.removeTempOnce.Do(func() {
+		if !run.useTempDir || b.params.BackupCfg.KeepTempFiles {
+			return
+		}
+		log.Info("Delete temporary file", zap.String("dir", run.tempDir))
+		err := b.getStorageClient().RemoveWithPrefix(b.ctx, b.milvusBucketName, run.tempDir)
+		if err != nil {
+			log.Warn("Delete temporary file failed", zap.Error(err))
+		}
+	})
+}
+
+// restoreCollectionProperties returns the properties in backup with the overrides of the request
+func restoreCollectionProperties(collBackup *backuppb.CollectionBackupInfo, overrides map[string]string) map[string]string {
+	properties := utils.KvPairsMap(collBackup.GetProperties())
+	for key, value := range overrides {
+		properties[key] = value
+	}
+	return properties
+}
+
+// restoreAliases creates the aliases of the collection in backup on the target collection after its data is
+// restored, existing aliases are altered to point at it if repoint, so that they are switched to a complete one
+func (b *BackupContext) restoreAliases(ctx context.Context, task *backuppb.RestoreCollectionTask, targetDBName, targetCollectionName string) error {
+	mode := task.GetRestoreAliases()
+	if mode == "" || mode == AliasModeSkip {
+		return nil
+	}
+	for _, alias := range task.GetCollBackup().GetAliases() {
+		exist := false
+		if mode == AliasModeRepoint {
+			var err error
+			// an alias is described as the collection it points at
+			exist, err = b.getMilvusClient().HasCollection(ctx, targetDBName, alias)
 			if err != nil {
-				log.Error("fail to restore partition",
-					zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-					zap.String("targetDBName", targetDBName),
-					zap.String("targetCollectionName", targetCollectionName),
-					zap.String("partition", partitionBackup2.GetPartitionName()),
-					zap.Error(err))
 				return err
 			}
-			log.Info("finish restore partition",
-				zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-				zap.String("targetDBName", targetDBName),
-				zap.String("targetCollectionName", targetCollectionName),
-				zap.String("partition", partitionBackup2.GetPartitionName()))
+		}
+		if exist {
+			if err := b.getMilvusClient().AlterAlias(ctx, targetDBName, targetCollectionName, alias); err != nil {
+				log.Error("fail to repoint alias", zap.String("alias", alias), zap.Error(err))
+				return fmt.Errorf("fail to repoint alias %s to %s.%s: %w", alias, targetDBName, targetCollectionName, err)
+			}
+		} else {
+			if err := b.getMilvusClient().CreateAlias(ctx, targetDBName, targetCollectionName, alias); err != nil {
+				log.Error("fail to create alias", zap.String("alias", alias), zap.Error(err))
+				return fmt.Errorf("fail to create alias %s of %s.%s: %w", alias, targetDBName, targetCollectionName, err)
+			}
+		}
+		log.Info("restore alias",
+			zap.String("alias", alias),
+			zap.String("targetDBName", targetDBName),
+			zap.String("targetCollectionName", targetCollectionName),
+			zap.Bool("repointed", exist))
+	}
+	return nil
+}
+
+// restoreIndexes creates the indexes recorded in backup on the target collection, vector indexes are replaced by
+// autoindex if UseAutoIndex. Indexes are built asynchronously by milvus.
+func (b *BackupContext) restoreIndexes(ctx context.Context, task *backuppb.RestoreCollectionTask, targetDBName, targetCollectionName string, collectionSchema *entity.Schema) error {
+	vectorFields := make(map[string]bool, 0)
+	for _, field := range collectionSchema.Fields {
+		if strings.HasSuffix(strings.ToLower(field.DataType.Name()), "vector") || field.DataType == FieldTypeSparseFloatVector {
+			vectorFields[field.Name] = true
+		}
+	}
+	for _, index := range task.GetCollBackup().GetIndexInfos() {
+		log.Info("source index",
+			zap.String("indexName", index.GetIndexName()),
+			zap.String("indexType", index.GetIndexType()),
+			zap.Bool("mmapEnabled", index.GetMmapEnabled()),
+			zap.Any("params", index.GetParams()))
+		_, isVector := vectorFields[index.GetFieldName()]
+		idx := restoreIndex(index, isVector && task.GetUseAutoIndex())
+		err := b.getMilvusClient().CreateIndex(ctx, targetDBName, targetCollectionName, index.GetFieldName(), idx, true)
+		if err != nil {
+			log.Warn("Fail to restore index", zap.Error(err))
 			return err
 		}
-		jobId := b.getRestoreWorkerPool().SubmitWithId(job)
-		jobIds = append(jobIds, jobId)
 	}
+	return nil
+}
 
-	err := b.getRestoreWorkerPool().WaitJobs(jobIds)
-	return task, err
+// restoreIndex returns the index to create from the index recorded in backup
+func restoreIndex(index *backuppb.IndexInfo, useAutoIndex bool) entity.Index {
+	params := make(map[string]string, len(index.GetParams()))
+	if useAutoIndex {
+		log.Info("use auto index")
+		// auto index only support index_type and metric_type in params
+		params["index_type"] = "AUTOINDEX"
+		params["metric_type"] = index.GetParams()["metric_type"]
+		if index.GetMmapEnabled() {
+			params[MMAP_ENABLED_KEY] = "true"
+		}
+		return entity.NewGenericIndex(index.GetIndexName(), entity.AUTOINDEX, index.GetFieldName(), params)
+	}
+	log.Info("not auto index")
+	for k, v := range index.GetParams() {
+		params[k] = v
+	}
+	indexType := index.GetIndexType()
+	if indexType == "marisa-trie" {
+		indexType = "Trie"
+	}
+	if params["index_type"] == "marisa-trie" {
+		params["index_type"] = "Trie"
+	}
+	if index.GetMmapEnabled() {
+		params[MMAP_ENABLED_KEY] = "true"
+	}
+	return entity.NewGenericIndex(index.GetIndexName(), entity.IndexType(indexType), index.GetFieldName(), params)
 }
 
-func (b *BackupContext) restorePartition(ctx context.Context, targetDBName, targetCollectionName string,
-	partitionBackup *backuppb.PartitionBackupInfo, task *backuppb.RestoreCollectionTask, isSameBucket bool, backupBucketName string, backupPath string, tempDir string) (*backuppb.RestoreCollectionTask, error) {
+// createRestorePartition creates the partition if it doesn't exist, and returns the partition to bulk insert into,
+// empty to let milvus dispatch the rows by the partition key
+func (b *BackupContext) createRestorePartition(ctx context.Context, targetDBName, targetCollectionName string,
+	partitionBackup *backuppb.PartitionBackupInfo, task *backuppb.RestoreCollectionTask) (string, error) {
 	exist, err := b.getMilvusClient().HasPartition(ctx, targetDBName, targetCollectionName, partitionBackup.GetPartitionName())
 	if err != nil {
 		log.Error("fail to check has partition", zap.Error(err))
-		return task, err
+		return "", err
 	}
-	if !exist {
-		err = retry.Do(ctx, func() error {
-			return b.getMilvusClient().CreatePartition(ctx, targetDBName, targetCollectionName, partitionBackup.GetPartitionName())
-		}, retry.Attempts(10), retry.Sleep(1*time.Second))
-		if err != nil {
-			log.Error("fail to create partition", zap.Error(err))
-			return task, err
+	// partition to bulk insert into, empty to let milvus dispatch the rows by the partition key
+	targetPartitionName := partitionBackup.GetPartitionName()
+	if isPartitionKeyCollection(task.GetCollBackup().GetSchema()) {
+		// partitions of partition key collections are managed by milvus and can't be created,
+		// the target collection has a different partition number if they don't exist
+		if !exist {
+			targetPartitionName = ""
 		}
-	}
-	log.Info("create partition",
-		zap.String("collectionName", targetCollectionName),
-		zap.String("partitionName", partitionBackup.GetPartitionName()))
-
-	// bulk insert
-	copyAndBulkInsert := func(files []string) error {
-		realFiles := make([]string, len(files))
-		// if milvus bucket and backup bucket are not the same, should copy the data first
-		if !isSameBucket {
-			log.Info("milvus bucket and backup bucket are not the same, copy the data first", zap.Strings("files", files))
-			for i, file := range files {
-				// empty delta file, no need to copy
-				if file == "" {
-					realFiles[i] = file
-				} else {
-					log.Debug("Copy temporary restore file", zap.String("from", file), zap.String("to", tempDir+file))
-					err := b.getStorageClient().Copy(ctx, backupBucketName, b.milvusBucketName, file, tempDir+file)
-					if err != nil {
-						log.Error("fail to copy backup date from backup bucket to restore target milvus bucket", zap.Error(err))
-						return err
-					}
-					realFiles[i] = tempDir + file
-				}
+		log.Info("restore partition of partition key collection",
+			zap.String("collectionName", targetCollectionName),
+			zap.String("partitionName", partitionBackup.GetPartitionName()),
+			zap.Bool("dispatchByPartitionKey", !exist))
+	} else {
+		if !exist {
+			err = retry.Do(ctx, func() error {
+				return b.getMilvusClient().CreatePartition(ctx, targetDBName, targetCollectionName, partitionBackup.GetPartitionName())
+			}, retry.Attempts(10), retry.Sleep(1*time.Second))
+			if err != nil {
+				log.Error("fail to create partition", zap.Error(err))
+				return "", err
 			}
-		} else {
-			realFiles = files
 		}
+		log.Info("create partition",
+			zap.String("collectionName", targetCollectionName),
+			zap.String("partitionName", partitionBackup.GetPartitionName()))
+	}
+
+	return targetPartitionName, nil
+}
 
-		err = b.executeBulkInsert(ctx, targetDBName, targetCollectionName, partitionBackup.GetPartitionName(), realFiles, int64(task.GetCollBackup().BackupTimestamp))
+// restoreSegmentGroup bulk inserts a segment group of the partition, or the whole partition of an old backup
+// without group id if legacy
+func (b *BackupContext) restoreSegmentGroup(ctx context.Context, run *restoreCollectionRun, partitionBackup *backuppb.PartitionBackupInfo, groupId int64, legacy bool) error {
+	task := run.task
+	backupBucketName, backupPath := run.backupBucketName, run.backupPath
+	groupKey := restoredGroupKey(partitionBackup.GetPartitionId(), groupId)
+	if legacy {
+		files, err := b.getBackupPartitionPaths(ctx, backupBucketName, backupPath, task.GetCollBackup(), partitionBackup)
 		if err != nil {
-			log.Error("fail to bulk insert to partition",
+			log.Error("fail to get partition backup binlog files",
+				zap.Error(err),
+				zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
+				zap.String("targetCollectionName", task.GetTargetCollectionName()),
+				zap.String("partition", partitionBackup.GetPartitionName()))
+			return err
+		}
+		if err := b.copyAndBulkInsert(ctx, run, partitionBackup, 0, files, nil); err != nil {
+			log.Error("fail to (copy and) bulkinsert data",
+				zap.Error(err),
 				zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-				zap.String("targetDBName", targetDBName),
-				zap.String("targetCollectionName", targetCollectionName),
+				zap.String("targetCollectionName", task.GetTargetCollectionName()),
+				zap.String("partition", partitionBackup.GetPartitionName()))
+			return err
+		}
+		b.markRestoredGroup(ctx, run, groupKey, segmentGroupSize(partitionBackup, -1))
+		return nil
+	}
+
+	if collectDedupGroupsFromSegments(partitionBackup.GetSegmentBackups())[groupId] {
+		// binlogs stored as dedup objects are written into the layout of bulk insert first
+		realFiles, err := b.materializeDedupGroup(ctx, backupBucketName, backupPath, partitionBackup, groupId, run.tempDir, task)
+		if err == nil {
+			err = b.bulkInsertGroup(ctx, run, partitionBackup, groupId, realFiles)
+		}
+		if err != nil {
+			log.Error("fail to restore dedup objects of segment group",
+				zap.Error(err),
+				zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
+				zap.String("targetCollectionName", task.GetTargetCollectionName()),
 				zap.String("partition", partitionBackup.GetPartitionName()),
-				zap.Error(err))
+				zap.Int64("groupId", groupId))
 			return err
 		}
+		b.markRestoredGroup(ctx, run, groupKey, segmentGroupSize(partitionBackup, groupId))
 		return nil
 	}
 
-	if task.GetMetaOnly() {
-		task.Progress = 100
-	} else {
-		groupIds := collectGroupIdsFromSegments(partitionBackup.GetSegmentBackups())
-		if len(groupIds) == 1 && groupIds[0] == 0 {
-			// backward compatible old backup without group id
-			files, err := b.getBackupPartitionPaths(ctx, backupBucketName, backupPath, partitionBackup)
-			if err != nil {
-				log.Error("fail to get partition backup binlog files",
-					zap.Error(err),
-					zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-					zap.String("targetCollectionName", targetCollectionName),
-					zap.String("partition", partitionBackup.GetPartitionName()))
-				return task, err
-			}
-			err = copyAndBulkInsert(files)
-			if err != nil {
-				log.Error("fail to (copy and) bulkinsert data",
-					zap.Error(err),
-					zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-					zap.String("targetCollectionName", targetCollectionName),
-					zap.String("partition", partitionBackup.GetPartitionName()))
-				return task, err
+	groupBackupPath := backupPath
+	// segment unchanged in incremental backup, binlogs are stored in the referenced backup
+	if refBackupName, ok := collectRefBackupsFromSegments(partitionBackup.GetSegmentBackups())[groupId]; ok {
+		groupBackupPath = path.Dir(backupPath) + SEPERATOR + refBackupName
+	}
+	files, err := b.getBackupPartitionPathsWithGroupID(ctx, backupBucketName, groupBackupPath, task.GetCollBackup(), partitionBackup, groupId)
+	if err != nil {
+		log.Error("fail to get partition backup binlog files",
+			zap.Error(err),
+			zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
+			zap.String("targetCollectionName", task.GetTargetCollectionName()),
+			zap.String("partition", partitionBackup.GetPartitionName()))
+		return err
+	}
+	if err := b.copyAndBulkInsert(ctx, run, partitionBackup, groupId, files, run.encodedGroups[groupId]); err != nil {
+		log.Error("fail to (copy and) bulkinsert data",
+			zap.Error(err),
+			zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
+			zap.String("targetCollectionName", task.GetTargetCollectionName()),
+			zap.String("partition", partitionBackup.GetPartitionName()))
+		return err
+	}
+	b.markRestoredGroup(ctx, run, groupKey, segmentGroupSize(partitionBackup, groupId))
+	return nil
+}
+
+// copyAndBulkInsert copies the files of a segment group into milvus bucket if needed and bulk inserts them,
+// encodedSegment is not nil if the binlogs are compressed or encrypted
+func (b *BackupContext) copyAndBulkInsert(ctx context.Context, run *restoreCollectionRun, partitionBackup *backuppb.PartitionBackupInfo, groupId int64, files []string, encodedSegment *backuppb.SegmentBackupInfo) error {
+	task := run.task
+	backupBucketName := run.backupBucketName
+	realFiles := make([]string, len(files))
+	// the fields of an existing collection differ from backup, the insert binlogs are written under its field ids
+	mapFields := len(task.GetFieldIdMappings()) > 0 || len(task.GetSkippedFieldIds()) > 0
+	if encodedSegment != nil {
+		log.Info("backup data is compressed or encrypted, decode the data first",
+			zap.Strings("files", files),
+			zap.String("compression", encodedSegment.GetCompression()),
+			zap.Bool("encrypted", encodedSegment.GetEncrypted()))
+	} else if !run.isSameBucket {
+		log.Info("milvus bucket and backup bucket are not the same, copy the data first", zap.Strings("files", files))
+	}
+	for i, file := range files {
+		switch {
+		case file == "":
+			// empty delta file, no need to copy
+			realFiles[i] = file
+		case i == 0 && mapFields:
+			log.Info("map insert binlogs into the fields of the existing collection", zap.String("from", file), zap.String("to", run.tempDir+file))
+			if err := b.mapBinlogFiles(ctx, encodedSegment, backupBucketName, file, run.tempDir+file, task); err != nil {
+				log.Error("fail to map backup data into restore target milvus bucket", zap.Error(err))
+				return err
 			}
-		} else {
-			// bulk insert by segment groups
-			for _, groupId := range groupIds {
-				files, err := b.getBackupPartitionPathsWithGroupID(ctx, backupBucketName, backupPath, partitionBackup, groupId)
-				if err != nil {
-					log.Error("fail to get partition backup binlog files",
-						zap.Error(err),
-						zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-						zap.String("targetCollectionName", targetCollectionName),
-						zap.String("partition", partitionBackup.GetPartitionName()))
-					return task, err
-				}
-				err = copyAndBulkInsert(files)
-				if err != nil {
-					log.Error("fail to (copy and) bulkinsert data",
-						zap.Error(err),
-						zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
-						zap.String("targetCollectionName", targetCollectionName),
-						zap.String("partition", partitionBackup.GetPartitionName()))
-					return task, err
-				}
+			realFiles[i] = run.tempDir + file
+		case encodedSegment != nil:
+			// if the data is compressed or encrypted, should decode the data into milvus bucket first
+			if err := b.decodeBinlogFiles(ctx, encodedSegment, backupBucketName, file, run.tempDir+file); err != nil {
+				log.Error("fail to decode backup data into restore target milvus bucket", zap.Error(err))
+				return err
 			}
+			realFiles[i] = run.tempDir + file
+		case !run.isSameBucket:
+			// if milvus bucket and backup bucket are not the same, should copy the data first
+			log.Debug("Copy temporary restore file", zap.String("from", file), zap.String("to", run.tempDir+file))
+			if err := b.copyBackupFiles(ctx, backupBucketName, file, run.tempDir+file); err != nil {
+				log.Error("fail to copy backup date from backup bucket to restore target milvus bucket", zap.Error(err))
+				return err
+			}
+			realFiles[i] = run.tempDir + file
+		default:
+			realFiles[i] = file
 		}
-		task.RestoredSize = task.RestoredSize + partitionBackup.GetSize()
-		if task.ToRestoreSize == 0 {
-			task.Progress = 100
-		} else {
-			task.Progress = int32(100 * task.RestoredSize / task.ToRestoreSize)
+	}
+	return b.bulkInsertGroup(ctx, run, partitionBackup, groupId, realFiles)
+}
+
+// planRestoreData decides how the binlogs of the collection are bulk inserted. They are restored in place if milvus
+// can read them in the backup bucket as they are, otherwise they are written into the temporary dir of milvus bucket.
+func (b *BackupContext) planRestoreData(ctx context.Context, run *restoreCollectionRun) {
+	task := run.task
+	// data in backup storage is always copied into milvus bucket before bulkinsert, so is data encrypted by customer key
+	// which milvus can't read
+	_, customerEncrypted := storage.SourceServerSideEncryption(ctx)
+	run.isSameBucket = b.milvusBucketName == run.backupBucketName && !b.params.BackupStorageCfg.Enabled() && !customerEncrypted
+	// compressed or encrypted data is decoded into temporary dir before bulkinsert
+	run.encodedGroups = collectEncodedGroupsFromCollection(task.GetCollBackup())
+	// so are the binlogs stored as dedup objects
+	hasDedupGroups := false
+	for _, partition := range task.GetCollBackup().GetPartitionBackups() {
+		hasDedupGroups = hasDedupGroups || len(collectDedupGroupsFromSegments(partition.GetSegmentBackups())) > 0
+	}
+	// binlogs mapped into the fields of the existing collection are written into the temporary dir too
+	mapFields := len(task.GetFieldIdMappings()) > 0 || len(task.GetSkippedFieldIds()) > 0
+	task.InPlace = run.isSameBucket && len(run.encodedGroups) == 0 && !hasDedupGroups && !mapFields
+	run.useTempDir = !task.InPlace
+}
+
+// bulkInsertGroup bulk inserts the files of a segment group in milvus bucket, the temporary files are removed once imported
+func (b *BackupContext) bulkInsertGroup(ctx context.Context, run *restoreCollectionRun, partitionBackup *backuppb.PartitionBackupInfo, groupId int64, realFiles []string) error {
+	task := run.task
+	targetDBName, targetCollectionName := task.GetTargetDbName(), task.GetTargetCollectionName()
+	targetPartitionName := run.targetPartitions[partitionBackup.GetPartitionId()]
+	endTime := int64(task.GetCollBackup().BackupTimestamp)
+	if task.GetRestoreTimestamp() != 0 {
+		endTime = int64(utils.ComposeTS(int64(task.GetRestoreTimestamp()), 0))
+	}
+	if err := b.executeBulkInsert(ctx, task, targetDBName, targetCollectionName, targetPartitionName, groupId, realFiles, endTime); err != nil {
+		log.Error("fail to bulk insert to partition",
+			zap.String("backupCollectionName", task.GetCollBackup().GetCollectionName()),
+			zap.String("targetDBName", targetDBName),
+			zap.String("targetCollectionName", targetCollectionName),
+			zap.String("partition", partitionBackup.GetPartitionName()),
+			zap.Error(err))
+		return err
+	}
+	// temporary files of the group are removed once imported, so that only the groups being imported take
+	// temporary space in milvus bucket instead of the whole collection
+	if !b.params.BackupCfg.KeepTempFiles {
+		for _, file := range realFiles {
+			if file == "" || !strings.HasPrefix(file, run.tempDir) {
+				continue
+			}
+			if err := b.getStorageClient().RemoveWithPrefix(ctx, b.milvusBucketName, file); err != nil {
+				log.Warn("fail to delete temporary files of segment group, deleted after the collection is restored",
+					zap.String("dir", file), zap.Error(err))
+			}
 		}
 	}
+	return nil
+}
+
+// markRestoredGroup records the segment group bulk inserted in the checkpoint, so that it is skipped if resumed
+func (b *BackupContext) markRestoredGroup(ctx context.Context, run *restoreCollectionRun, key string, size int64) {
+	task := run.task
+	b.restoreCheckpointMu.Lock()
+	task.RestoredGroups = append(task.RestoredGroups, key)
+	task.RestoredSize += size
+	task.Progress = progressPercent(task.GetRestoredSize(), task.GetToRestoreSize())
+	b.restoreCheckpointMu.Unlock()
+	metrics.CopiedBytes.WithLabelValues(metrics.RestoreTaskLabel).Add(float64(size))
+	b.writeRestoreCheckpoint(ctx, run.backupBucketName, run.backupPath, run.parentTask)
+}
+
+// restoredGroupKey returns the key of a segment group in RestoreCollectionTask.restored_groups
+func restoredGroupKey(partitionId int64, groupId int64) string {
+	return fmt.Sprintf("%d/%d", partitionId, groupId)
+}
+
+// writeRestoreCheckpoint writes the restore task into the backup, so that the task can be resumed if it is interrupted.
+// Failure is only logged, the backup bucket may be read only, which should not fail the restore.
+func (b *BackupContext) writeRestoreCheckpoint(ctx context.Context, backupBucketName string, backupPath string, task *backuppb.RestoreBackupTask) {
+	b.restoreCheckpointMu.Lock()
+	defer b.restoreCheckpointMu.Unlock()
+	checkpointPath := RestoreCheckpointPath(backupPath, task.GetId())
+	data, err := json.Marshal(task)
+	if err == nil {
+		data, err = b.encodeBackupFile(data)
+	}
+	if err == nil {
+		err = b.getBackupStorageClient().Write(ctx, backupBucketName, checkpointPath, data)
+	}
+	if err != nil {
+		log.Warn("fail to write restore checkpoint", zap.String("path", checkpointPath), zap.Error(err))
+		return
+	}
+	log.Debug("write restore checkpoint", zap.String("path", checkpointPath))
+}
+
+// readRestoreCheckpoint reads the restore task written by writeRestoreCheckpoint, returns nil if not exist
+func (b *BackupContext) readRestoreCheckpoint(ctx context.Context, backupBucketName string, backupPath string, taskId string) (*backuppb.RestoreBackupTask, error) {
+	checkpointPath := RestoreCheckpointPath(backupPath, taskId)
+	exist, err := b.getBackupStorageClient().Exist(ctx, backupBucketName, checkpointPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, nil
+	}
+	data, err := b.readBackupFile(ctx, backupBucketName, checkpointPath)
+	if err != nil {
+		return nil, err
+	}
+	task := &backuppb.RestoreBackupTask{}
+	if err := json.Unmarshal(data, task); err != nil {
+		return nil, errors.Wrapf(err, "fail to unmarshal restore checkpoint %s", checkpointPath)
+	}
 	return task, nil
 }
 
+// skippedCollections returns db.collection_name of the collections in backup not to restore
+func skippedCollections(backup *backuppb.BackupInfo, toRestore []*backuppb.CollectionBackupInfo) []string {
+	restoring := make(map[*backuppb.CollectionBackupInfo]bool, len(toRestore))
+	for _, collectionBackup := range toRestore {
+		restoring[collectionBackup] = true
+	}
+	skipped := make([]string, 0)
+	for _, collectionBackup := range backup.GetCollectionBackups() {
+		if !restoring[collectionBackup] {
+			skipped = append(skipped, collectionBackup.GetDbName()+"."+collectionBackup.GetCollectionName())
+		}
+	}
+	return skipped
+}
+
+// isPartitionKeyCollection returns true if the collection has a partition key field, its partitions are created
+// by milvus with the collection and rows are dispatched into them by the hash of the partition key
+func isPartitionKeyCollection(schema *backuppb.CollectionSchema) bool {
+	for _, field := range schema.GetFields() {
+		if field.GetIsPartitionKey() {
+			return true
+		}
+	}
+	return false
+}
+
+// samePartitions returns true if the partitions of the collection are the partitions in backup
+func samePartitions(collBackup *backuppb.CollectionBackupInfo, partitions []*entity.Partition) bool {
+	if len(partitions) != len(collBackup.GetPartitionBackups()) {
+		return false
+	}
+	names := make(map[string]bool, len(partitions))
+	for _, partition := range partitions {
+		names[partition.Name] = true
+	}
+	for _, partition := range collBackup.GetPartitionBackups() {
+		if !names[partition.GetPartitionName()] {
+			return false
+		}
+	}
+	return true
+}
+
+// renderCollectionName replaces {{db}}, {{name}} and {{date}} in the template with the database, name of the collection and date
+func renderCollectionName(template string, db string, name string, date string) string {
+	return strings.NewReplacer("{{db}}", db, "{{name}}", name, "{{date}}", date).Replace(template)
+}
+
+// selectBackupPartitions returns a copy of the collection backup with the selected partitions only
+func selectBackupPartitions(collectionBackup *backuppb.CollectionBackupInfo, partitionNames []string) (*backuppb.CollectionBackupInfo, error) {
+	if isPartitionKeyCollection(collectionBackup.GetSchema()) {
+		return nil, fmt.Errorf("can not select partitions of collection %s.%s with partition key", collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
+	}
+	selected := proto.Clone(collectionBackup).(*backuppb.CollectionBackupInfo)
+	partitionDict := make(map[string]*backuppb.PartitionBackupInfo, len(selected.GetPartitionBackups()))
+	for _, partition := range selected.GetPartitionBackups() {
+		partitionDict[partition.GetPartitionName()] = partition
+	}
+	selected.PartitionBackups = make([]*backuppb.PartitionBackupInfo, 0, len(partitionNames))
+	for _, name := range partitionNames {
+		partition, ok := partitionDict[name]
+		if !ok {
+			return nil, fmt.Errorf("partition %s does not exist in backup of collection %s.%s", name, collectionBackup.GetDbName(), collectionBackup.GetCollectionName())
+		}
+		selected.PartitionBackups = append(selected.PartitionBackups, partition)
+	}
+	return selected, nil
+}
+
 func collectGroupIdsFromSegments(segments []*backuppb.SegmentBackupInfo) []int64 {
 	dict := make(map[int64]bool)
 	res := make([]int64, 0)
@@ -714,15 +1983,316 @@ func collectGroupIdsFromSegments(segments []*backuppb.SegmentBackupInfo) []int64
 	return res
 }
 
-func (b *BackupContext) executeBulkInsert(ctx context.Context, db, coll string, partition string, files []string, endTime int64) error {
+// collectRefBackupsFromSegments returns the referenced backup name of groups whose binlogs are not stored in current backup
+func collectRefBackupsFromSegments(segments []*backuppb.SegmentBackupInfo) map[int64]string {
+	res := make(map[int64]string)
+	for _, seg := range segments {
+		if seg.GetRefBackupName() != "" {
+			res[seg.GetGroupId()] = seg.GetRefBackupName()
+		}
+	}
+	return res
+}
+
+// collectDedupGroupsFromSegments returns the groups whose binlogs are stored as dedup objects
+func collectDedupGroupsFromSegments(segments []*backuppb.SegmentBackupInfo) map[int64]bool {
+	res := make(map[int64]bool)
+	for _, seg := range segments {
+		if len(segmentDedupObjects(seg)) > 0 {
+			res[seg.GetGroupId()] = true
+		}
+	}
+	return res
+}
+
+// collectEncodedGroupsFromCollection returns a segment of each group whose binlogs are compressed or encrypted
+func collectEncodedGroupsFromCollection(collection *backuppb.CollectionBackupInfo) map[int64]*backuppb.SegmentBackupInfo {
+	res := make(map[int64]*backuppb.SegmentBackupInfo)
+	for _, partition := range collection.GetPartitionBackups() {
+		for _, seg := range partition.GetSegmentBackups() {
+			if seg.GetCompression() != utils.CompressionNone || seg.GetEncrypted() {
+				res[seg.GetGroupId()] = seg
+			}
+		}
+	}
+	return res
+}
+
+// collectRehydratePrefixes returns the binlog dirs of the partitions to restore, including the dirs in the referenced
+// backups of incremental backups and the dedup objects
+func collectRehydratePrefixes(backupPath string, task *backuppb.RestoreBackupTask) []string {
+	prefixes := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, collTask := range task.GetCollectionRestoreTasks() {
+		if collTask.GetMetaOnly() || collTask.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			continue
+		}
+		for _, partition := range collTask.GetCollBackup().GetPartitionBackups() {
+			if len(partition.GetSegmentBackups()) == 0 {
+				continue
+			}
+			paths := []string{backupPath}
+			for _, refBackupName := range collectRefBackupsFromSegments(partition.GetSegmentBackups()) {
+				paths = append(paths, path.Dir(backupPath)+SEPERATOR+refBackupName)
+			}
+			for _, partitionBackupPath := range paths {
+				for _, logDir := range []string{INSERT_LOG_DIR, DELTA_LOG_DIR} {
+					prefix := BinlogPartitionDirPath(path.Dir(partitionBackupPath), path.Base(partitionBackupPath), collTask.GetCollBackup(), logDir, partition.GetCollectionId(), partition.GetPartitionId())
+					if !seen[prefix] {
+						seen[prefix] = true
+						prefixes = append(prefixes, prefix)
+					}
+				}
+			}
+			// dedup objects are shared by backups, only the objects referenced are rehydrated
+			for _, segment := range partition.GetSegmentBackups() {
+				for _, object := range segmentDedupObjects(segment) {
+					prefix := DedupObjectPath(path.Dir(backupPath), object)
+					if !seen[prefix] {
+						seen[prefix] = true
+						prefixes = append(prefixes, prefix)
+					}
+				}
+			}
+		}
+	}
+	return prefixes
+}
+
+// rehydrateBackupFiles requests the archived binlogs to restore to be readable and waits until all of them are
+// restored from the archive storage classes, which takes hours for GLACIER and DEEP_ARCHIVE
+func (b *BackupContext) rehydrateBackupFiles(ctx context.Context, backupBucketName string, backupPath string, task *backuppb.RestoreBackupTask) error {
+	prefixes := collectRehydratePrefixes(backupPath, task)
+	cfg := b.params.BackupCfg
+	start := time.Now()
+	for {
+		pending := 0
+		for _, prefix := range prefixes {
+			n, err := b.getBackupStorageClient().Rehydrate(ctx, backupBucketName, prefix, cfg.RehydrateDays, cfg.RehydrateTier)
+			if err != nil {
+				return errors.Wrapf(err, "fail to rehydrate archived binlogs %s", prefix)
+			}
+			pending += n
+		}
+		if pending == 0 {
+			return nil
+		}
+		if cfg.RehydrateTimeout > 0 && time.Since(start) >= cfg.RehydrateTimeout {
+			return fmt.Errorf("%d archived binlogs are not rehydrated in %s", pending, cfg.RehydrateTimeout)
+		}
+		log.Info("wait for archived binlogs to be rehydrated",
+			zap.String("backupPath", backupPath),
+			zap.Int("pending", pending),
+			zap.String("tier", cfg.RehydrateTier),
+			zap.Duration("elapsed", time.Since(start)))
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cfg.RehydratePollInterval):
+		}
+	}
+}
+
+// copyBackupFiles copies all the files with prefix fromPath in backup bucket into toPath of milvus bucket. The files
+// are copied by storage unless backup data is stored in backupStorage, in which case they are read and written by backup tool.
+func (b *BackupContext) copyBackupFiles(ctx context.Context, backupBucketName string, fromPath string, toPath string) error {
+	if !b.params.BackupStorageCfg.Enabled() {
+		return b.getStorageClient().Copy(ctx, backupBucketName, b.milvusBucketName, fromPath, toPath)
+	}
+	return b.getBackupStorageClient().WalkWithPrefix(ctx, backupBucketName, fromPath, true, func(file string, size int64) error {
+		return b.withMemory(ctx, size, func() error {
+			data, err := b.getBackupStorageClient().Read(ctx, backupBucketName, file)
+			if err != nil {
+				return err
+			}
+			target := strings.Replace(file, fromPath, toPath, 1)
+			return b.getStorageClient().Write(ctx, b.milvusBucketName, target, data)
+		})
+	})
+}
+
+// decodeBinlogFiles decrypts and decompresses all the files with prefix fromPath in backup bucket into toPath of milvus bucket
+func (b *BackupContext) decodeBinlogFiles(ctx context.Context, segment *backuppb.SegmentBackupInfo, backupBucketName string, fromPath string, toPath string) error {
+	return b.getBackupStorageClient().WalkWithPrefix(ctx, backupBucketName, fromPath, true, func(file string, size int64) error {
+		target := strings.Replace(file, fromPath, toPath, 1)
+		log.Debug("Decode temporary restore file", zap.String("from", file), zap.String("to", target))
+		return b.writeDecodedFile(ctx, segment, backupBucketName, file, size, target)
+	})
+}
+
+// mapBinlogFiles writes the insert binlogs with prefix fromPath of backup bucket into toPath of milvus bucket under the
+// field ids of the existing collection, decoded if the segment is compressed or encrypted. Binlogs of the fields
+// not restored are not written.
+func (b *BackupContext) mapBinlogFiles(ctx context.Context, segment *backuppb.SegmentBackupInfo, backupBucketName string, fromPath string, toPath string, task *backuppb.RestoreCollectionTask) error {
+	skippedFields := make(map[int64]bool, len(task.GetSkippedFieldIds()))
+	for _, fieldID := range task.GetSkippedFieldIds() {
+		skippedFields[fieldID] = true
+	}
+	return b.getBackupStorageClient().WalkWithPrefix(ctx, backupBucketName, fromPath, true, func(file string, size int64) error {
+		target, ok := mapBinlogPath(file, fromPath, toPath, task.GetFieldIdMappings(), skippedFields)
+		if !ok {
+			return nil
+		}
+		log.Debug("Map temporary restore file", zap.String("from", file), zap.String("to", target))
+		return b.writeDecodedFile(ctx, segment, backupBucketName, file, size, target)
+	})
+}
+
+// writeDecodedFile writes a file of backup bucket into target of milvus bucket decrypted and decompressed, the file
+// and its decoded data are held in memory until written
+func (b *BackupContext) writeDecodedFile(ctx context.Context, segment *backuppb.SegmentBackupInfo, backupBucketName string, file string, size int64, target string) error {
+	return b.withMemory(ctx, 2*size, func() error {
+		data, err := b.readSegmentFile(ctx, segment, backupBucketName, file)
+		if err != nil {
+			return err
+		}
+		decompressed, err := utils.Decompress(segment.GetCompression(), data)
+		if err != nil {
+			return errors.Wrapf(err, "fail to decompress file %s", file)
+		}
+		return b.getStorageClient().Write(ctx, b.milvusBucketName, target, decompressed)
+	})
+}
+
+// materializeDedupGroup writes the binlogs of a segment group stored as dedup objects into tempDir of milvus bucket, in
+// the layout of the binlog dir of backup, decompressed and mapped into the fields of the existing collection if needed.
+// It returns the insert and delta log dirs to bulk insert, the delta log dir is empty if the group has no delta logs.
+func (b *BackupContext) materializeDedupGroup(ctx context.Context, backupBucketName string, backupPath string, partition *backuppb.PartitionBackupInfo, groupId int64, tempDir string, task *backuppb.RestoreCollectionTask) ([]string, error) {
+	skippedFields := make(map[int64]bool, len(task.GetSkippedFieldIds()))
+	for _, fieldID := range task.GetSkippedFieldIds() {
+		skippedFields[fieldID] = true
+	}
+	backupRootPath := path.Dir(backupPath)
+	insertPath := BinlogGroupDirPath(backupRootPath, path.Base(backupPath), task.GetCollBackup(), INSERT_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId(), groupId)
+	deltaPath := BinlogGroupDirPath(backupRootPath, path.Base(backupPath), task.GetCollBackup(), DELTA_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId(), groupId)
+	hasDeltalogs := false
+	for _, segment := range partition.GetSegmentBackups() {
+		if segment.GetGroupId() != groupId {
+			continue
+		}
+		logDirs := []struct {
+			logs    []*backuppb.FieldBinlog
+			logDir  string
+			dirPath string
+		}{
+			{segment.GetBinlogs(), INSERT_LOG_DIR, insertPath},
+			{segment.GetDeltalogs(), DELTA_LOG_DIR, deltaPath},
+		}
+		for _, logDir := range logDirs {
+			for _, fieldBinlog := range logDir.logs {
+				for _, binlog := range fieldBinlog.GetBinlogs() {
+					file := logDir.dirPath + dedupBinlogRelativePath(binlog.GetLogPath(), logDir.logDir)
+					target, ok := tempDir+file, true
+					if logDir.logDir == INSERT_LOG_DIR {
+						target, ok = mapBinlogPath(file, logDir.dirPath, tempDir+logDir.dirPath, task.GetFieldIdMappings(), skippedFields)
+					}
+					if !ok {
+						continue
+					}
+					objectPath := DedupObjectPath(backupRootPath, binlog.GetObjectName())
+					log.Debug("Write dedup object into temporary restore file", zap.String("from", objectPath), zap.String("to", target))
+					err := b.withMemory(ctx, binlog.GetBackupSize()+binlog.GetLogSize(), func() error {
+						data, err := b.readSegmentFile(ctx, segment, backupBucketName, objectPath)
+						if err != nil {
+							return errors.Wrapf(err, "fail to read dedup object %s", objectPath)
+						}
+						decompressed, err := utils.Decompress(segment.GetCompression(), data)
+						if err != nil {
+							return errors.Wrapf(err, "fail to decompress dedup object %s", objectPath)
+						}
+						return b.getStorageClient().Write(ctx, b.milvusBucketName, target, decompressed)
+					})
+					if err != nil {
+						return nil, err
+					}
+					hasDeltalogs = hasDeltalogs || logDir.logDir == DELTA_LOG_DIR
+				}
+			}
+		}
+	}
+	if !hasDeltalogs {
+		return []string{tempDir + insertPath, ""}, nil
+	}
+	return []string{tempDir + insertPath, tempDir + deltaPath}, nil
+}
+
+// dedupBinlogRelativePath returns the path of a binlog of milvus relative to its partition dir, like
+// segment_id/field_id/log_id of insert_log/collection_id/partition_id/segment_id/field_id/log_id
+func dedupBinlogRelativePath(logPath string, logDir string) string {
+	parts := strings.Split(logPath, SEPERATOR)
+	for i, part := range parts {
+		if part == logDir && i+3 <= len(parts) {
+			return strings.Join(parts[i+3:], SEPERATOR)
+		}
+	}
+	return path.Base(logPath)
+}
+
+// mapBinlogPath returns the path under toPath of an insert binlog under fromPath, like segment_id/field_id/log_id,
+// with the field id of the existing collection. It returns false if the field is not restored.
+func mapBinlogPath(file string, fromPath string, toPath string, fieldIDs map[int64]int64, skippedFields map[int64]bool) (string, bool) {
+	parts := strings.Split(strings.TrimPrefix(file, fromPath), SEPERATOR)
+	if len(parts) < 2 {
+		return toPath + strings.TrimPrefix(file, fromPath), true
+	}
+	fieldID, err := strconv.ParseInt(parts[len(parts)-2], 10, 64)
+	if err != nil {
+		return toPath + strings.TrimPrefix(file, fromPath), true
+	}
+	if skippedFields[fieldID] {
+		return "", false
+	}
+	if mapped, ok := fieldIDs[fieldID]; ok {
+		parts[len(parts)-2] = strconv.FormatInt(mapped, 10)
+	}
+	return toPath + strings.Join(parts, SEPERATOR), true
+}
+
+// executeBulkInsert bulk inserts a segment group and waits for the import task, the task is retried if it fails,
+// up to backup.bulkinsert.maxAttempts. The import tasks are recorded in the restore task.
+func (b *BackupContext) executeBulkInsert(ctx context.Context, task *backuppb.RestoreCollectionTask, db, coll string, partition string, groupId int64, files []string, endTime int64) error {
+	maxAttempts := b.params.BackupCfg.BulkInsertMaxAttempts
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		err = b.bulkInsertOnce(ctx, task, db, coll, partition, groupId, files, endTime, int32(attempt))
+		// a timeout task may still be running in milvus, only failed tasks are retried
+		if err == nil || !errors.Is(err, errBulkInsertFailed) {
+			return err
+		}
+		if attempt < maxAttempts {
+			log.Warn("bulk insert failed, retry",
+				zap.String("collectionName", coll),
+				zap.String("partitionName", partition),
+				zap.Int64("groupId", groupId),
+				zap.Int("attempt", attempt),
+				zap.Error(err))
+		}
+	}
+	return err
+}
+
+func (b *BackupContext) bulkInsertOnce(ctx context.Context, task *backuppb.RestoreCollectionTask, db, coll string, partition string, groupId int64, files []string, endTime int64, attempt int32) error {
 	log.Info("execute bulk insert",
 		zap.String("db", db),
 		zap.String("collection", coll),
 		zap.String("partition", partition),
 		zap.Strings("files", files),
-		zap.Int64("endTime", endTime))
+		zap.Int64("endTime", endTime),
+		zap.Int32("attempt", attempt))
 	var taskId int64
 	var err error
+	ctx, span := trace.Start(ctx, "bulkInsert",
+		attribute.String("collection.db", db),
+		attribute.String("collection.name", coll),
+		attribute.String("partition.name", partition),
+		attribute.StringSlice("bulkinsert.files", files))
+	defer func() { trace.End(span, err) }()
+
+	limiter := b.bulkInsertLimiter
+	if err = limiter.Acquire(ctx); err != nil {
+		return err
+	}
+	defer limiter.Release()
 	if endTime == 0 {
 		taskId, err = b.getMilvusClient().BulkInsert(ctx, db, coll, partition, files, gomilvus.IsBackup())
 	} else {
@@ -737,7 +2307,27 @@ func (b *BackupContext) executeBulkInsert(ctx context.Context, db, coll string,
 			zap.Error(err))
 		return err
 	}
-	err = b.watchBulkInsertState(ctx, taskId, BULKINSERT_TIMEOUT, BULKINSERT_SLEEP_INTERVAL)
+	job := &backuppb.BulkInsertJob{
+		TaskId:        taskId,
+		PartitionName: partition,
+		GroupId:       groupId,
+		State:         BulkInsertJobExecuting,
+		Attempt:       attempt,
+		StartTime:     time.Now().Unix(),
+	}
+	b.restoreCheckpointMu.Lock()
+	task.BulkInsertJobs = append(task.BulkInsertJobs, job)
+	b.restoreCheckpointMu.Unlock()
+
+	var state string
+	state, err = b.watchBulkInsertState(ctx, taskId)
+	b.restoreCheckpointMu.Lock()
+	job.State = state
+	if err != nil {
+		job.ErrorMessage = err.Error()
+	}
+	job.EndTime = time.Now().Unix()
+	b.restoreCheckpointMu.Unlock()
 	if err != nil {
 		log.Error("fail or timeout to bulk insert",
 			zap.Error(err),
@@ -749,56 +2339,67 @@ func (b *BackupContext) executeBulkInsert(ctx context.Context, db, coll string,
 	return nil
 }
 
-func (b *BackupContext) watchBulkInsertState(ctx context.Context, taskId int64, timeout int64, sleepSeconds int) error {
+// watchBulkInsertState waits for the import task to reach backup.bulkinsert.waitState, and returns the state of
+// the BulkInsertJob. It fails if the progress doesn't change for backup.bulkinsert.timeout.
+func (b *BackupContext) watchBulkInsertState(ctx context.Context, taskId int64) (string, error) {
+	timeout := b.params.BackupCfg.BulkInsertTimeout
+	interval := b.params.BackupCfg.BulkInsertPollInterval
+	waitPersisted := b.params.BackupCfg.BulkInsertWaitState == BulkInsertJobPersisted
 	lastProgress := 0
-	lastUpdateTime := time.Now().Unix()
+	lastUpdateTime := time.Now()
 	for {
 		importTaskState, err := b.getMilvusClient().GetBulkInsertState(ctx, taskId)
-		currentTimestamp := time.Now().Unix()
 		if err != nil {
-			return err
+			return BulkInsertJobFailed, err
 		}
 		log.Info("bulkinsert task state",
 			zap.Int64("id", taskId),
 			zap.Int32("state", int32(importTaskState.State)),
 			zap.Any("state", importTaskState),
 			zap.Int("progress", importTaskState.Progress()),
-			zap.Int64("currentTimestamp", currentTimestamp),
-			zap.Int64("lastUpdateTime", lastUpdateTime))
+			zap.Time("lastUpdateTime", lastUpdateTime))
 		switch importTaskState.State {
-		case entity.BulkInsertFailed:
+		case entity.BulkInsertFailed, entity.BulkInsertFailedAndCleaned:
 			if value, ok := importTaskState.Infos["failed_reason"]; ok {
-				return errors.New("bulk insert fail, info: " + value)
+				return BulkInsertJobFailed, fmt.Errorf("%w, info: %s", errBulkInsertFailed, value)
 			} else {
-				return errors.New("bulk insert fail")
+				return BulkInsertJobFailed, errBulkInsertFailed
 			}
 		case entity.BulkInsertCompleted:
-			return nil
-		default:
-			currentProgress := importTaskState.Progress()
-			if currentProgress > lastProgress {
-				lastUpdateTime = time.Now().Unix()
-			} else if (currentTimestamp - lastUpdateTime) >= timeout {
-				log.Warn(fmt.Sprintf("bulkinsert task state progress hang for more than %d s", timeout))
-				return errors.New("import task timeout")
-			}
-			time.Sleep(time.Second * time.Duration(sleepSeconds))
-			continue
+			return BulkInsertJobCompleted, nil
+		case entity.BulkInsertPersisted:
+			if waitPersisted {
+				return BulkInsertJobPersisted, nil
+			}
+		}
+		currentProgress := importTaskState.Progress()
+		if currentProgress > lastProgress {
+			lastProgress = currentProgress
+			lastUpdateTime = time.Now()
+		} else if time.Since(lastUpdateTime) >= timeout {
+			log.Warn(fmt.Sprintf("bulkinsert task state progress hang for more than %s", timeout))
+			return BulkInsertJobTimeout, errors.New("import task timeout")
+		}
+		select {
+		case <-ctx.Done():
+			// milvus has no api to stop a bulkinsert task, it keeps running but is not waited any more
+			log.Warn("stop waiting bulkinsert task", zap.Int64("taskId", taskId), zap.Error(ctx.Err()))
+			return BulkInsertJobExecuting, ctx.Err()
+		case <-time.After(interval):
 		}
 	}
-	return errors.New("import task timeout")
 }
 
-func (b *BackupContext) getBackupPartitionPaths(ctx context.Context, bucketName string, backupPath string, partition *backuppb.PartitionBackupInfo) ([]string, error) {
+func (b *BackupContext) getBackupPartitionPaths(ctx context.Context, bucketName string, backupPath string, collection *backuppb.CollectionBackupInfo, partition *backuppb.PartitionBackupInfo) ([]string, error) {
 	log.Info("getBackupPartitionPaths",
 		zap.String("bucketName", bucketName),
 		zap.String("backupPath", backupPath),
 		zap.Int64("partitionID", partition.PartitionId))
 
-	insertPath := fmt.Sprintf("%s/%s/%s/%v/%v/", backupPath, BINGLOG_DIR, INSERT_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId())
-	deltaPath := fmt.Sprintf("%s/%s/%s/%v/%v/", backupPath, BINGLOG_DIR, DELTA_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId())
+	insertPath := BinlogPartitionDirPath(path.Dir(backupPath), path.Base(backupPath), collection, INSERT_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId())
+	deltaPath := BinlogPartitionDirPath(path.Dir(backupPath), path.Base(backupPath), collection, DELTA_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId())
 
-	exist, err := b.getStorageClient().Exist(ctx, bucketName, deltaPath)
+	exist, err := b.getBackupStorageClient().Exist(ctx, bucketName, deltaPath)
 	if err != nil {
 		log.Warn("check binlog exist fail", zap.Error(err))
 		return []string{}, err
@@ -813,17 +2414,17 @@ func (b *BackupContext) getBackupPartitionPaths(ctx context.Context, bucketName
 	return []string{insertPath, deltaPath}, nil
 }
 
-func (b *BackupContext) getBackupPartitionPathsWithGroupID(ctx context.Context, bucketName string, backupPath string, partition *backuppb.PartitionBackupInfo, groupId int64) ([]string, error) {
+func (b *BackupContext) getBackupPartitionPathsWithGroupID(ctx context.Context, bucketName string, backupPath string, collection *backuppb.CollectionBackupInfo, partition *backuppb.PartitionBackupInfo, groupId int64) ([]string, error) {
 	log.Info("getBackupPartitionPaths",
 		zap.String("bucketName", bucketName),
 		zap.String("backupPath", backupPath),
 		zap.Int64("partitionID", partition.GetPartitionId()),
 		zap.Int64("groupId", groupId))
 
-	insertPath := fmt.Sprintf("%s/%s/%s/%v/%v/%d/", backupPath, BINGLOG_DIR, INSERT_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId(), groupId)
-	deltaPath := fmt.Sprintf("%s/%s/%s/%v/%v/%d/", backupPath, BINGLOG_DIR, DELTA_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId(), groupId)
+	insertPath := BinlogGroupDirPath(path.Dir(backupPath), path.Base(backupPath), collection, INSERT_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId(), groupId)
+	deltaPath := BinlogGroupDirPath(path.Dir(backupPath), path.Base(backupPath), collection, DELTA_LOG_DIR, partition.GetCollectionId(), partition.GetPartitionId(), groupId)
 
-	exist, err := b.getStorageClient().Exist(ctx, bucketName, deltaPath)
+	exist, err := b.getBackupStorageClient().Exist(ctx, bucketName, deltaPath)
 	if err != nil {
 		log.Warn("check binlog exist fail", zap.Error(err))
 		return []string{}, err
@@ -0,0 +1,402 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+func TestRestoreCheckpoint(t *testing.T) {
+	var storageClient storage.ChunkManager = &memoryChunkManager{files: map[string][]byte{}}
+	b := &BackupContext{storageClient: &storageClient}
+	ctx := context.Background()
+
+	task, err := b.readRestoreCheckpoint(ctx, "bucket", "backup/test_backup", "task_id")
+	assert.NoError(t, err)
+	assert.Nil(t, task)
+
+	b.writeRestoreCheckpoint(ctx, "bucket", "backup/test_backup", &backuppb.RestoreBackupTask{
+		Id:        "task_id",
+		StateCode: backuppb.RestoreTaskStateCode_FAIL,
+		CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{{
+			TargetCollectionName: "coll",
+			MetaRestored:         true,
+			RestoredGroups:       []string{restoredGroupKey(1, 2)},
+		}},
+	})
+	task, err = b.readRestoreCheckpoint(ctx, "bucket", "backup/test_backup", "task_id")
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.RestoreTaskStateCode_FAIL, task.GetStateCode())
+	assert.True(t, task.GetCollectionRestoreTasks()[0].GetMetaRestored())
+	assert.Equal(t, []string{"1/2"}, task.GetCollectionRestoreTasks()[0].GetRestoredGroups())
+}
+
+func TestPrepareRestoreTaskForResume(t *testing.T) {
+	task := &backuppb.RestoreBackupTask{
+		StateCode:    backuppb.RestoreTaskStateCode_FAIL,
+		ErrorMessage: "bulk insert fail",
+		CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{
+			{StateCode: backuppb.RestoreTaskStateCode_SUCCESS, MetaRestored: true, RestoreIndex: true},
+			{StateCode: backuppb.RestoreTaskStateCode_FAIL, MetaRestored: true, RestoreIndex: true, DropExistCollection: true},
+			{StateCode: backuppb.RestoreTaskStateCode_INITIAL, RestoreIndex: true},
+			{StateCode: backuppb.RestoreTaskStateCode_FAIL, MetaRestored: true, RestoreIndex: true, IndexMode: IndexModeDeferred},
+		},
+	}
+	prepareRestoreTaskForResume(task)
+	assert.Equal(t, backuppb.RestoreTaskStateCode_INITIAL, task.GetStateCode())
+	assert.Empty(t, task.GetErrorMessage())
+
+	done, failed, notStarted := task.GetCollectionRestoreTasks()[0], task.GetCollectionRestoreTasks()[1], task.GetCollectionRestoreTasks()[2]
+	assert.Equal(t, backuppb.RestoreTaskStateCode_SUCCESS, done.GetStateCode())
+	assert.Equal(t, backuppb.RestoreTaskStateCode_INITIAL, failed.GetStateCode())
+	assert.True(t, failed.GetSkipCreateCollection())
+	assert.False(t, failed.GetDropExistCollection())
+	assert.False(t, failed.GetRestoreIndex())
+	assert.True(t, notStarted.GetRestoreIndex())
+	assert.False(t, notStarted.GetSkipCreateCollection())
+	// deferred indexes are created after the data is restored
+	assert.True(t, task.GetCollectionRestoreTasks()[3].GetRestoreIndex())
+}
+
+func TestRestoreBackupModes(t *testing.T) {
+	b := &BackupContext{started: true}
+	resp := b.RestoreBackup(context.Background(), &backuppb.RestoreBackupRequest{BackupName: "backup", IndexMode: "later"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	assert.Contains(t, resp.GetMsg(), "illegal index mode")
+	resp = b.RestoreBackup(context.Background(), &backuppb.RestoreBackupRequest{BackupName: "backup", RestoreAliases: "move"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	assert.Contains(t, resp.GetMsg(), "illegal restore aliases")
+	resp = b.RestoreBackup(context.Background(), &backuppb.RestoreBackupRequest{BackupName: "backup", ReplicaNumber: -1})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+}
+
+func TestRestoreCollectionProperties(t *testing.T) {
+	collBackup := &backuppb.CollectionBackupInfo{Properties: []*backuppb.KeyValuePair{
+		{Key: "collection.ttl.seconds", Value: "86400"},
+		{Key: MMAP_ENABLED_KEY, Value: "true"},
+	}}
+	assert.Equal(t, map[string]string{"collection.ttl.seconds": "0", MMAP_ENABLED_KEY: "true"},
+		restoreCollectionProperties(collBackup, map[string]string{"collection.ttl.seconds": "0"}))
+	assert.Empty(t, restoreCollectionProperties(&backuppb.CollectionBackupInfo{}, nil))
+}
+
+func TestRestoreIndex(t *testing.T) {
+	assert.Equal(t, IndexModeSkip, restoreIndexMode(&backuppb.RestoreBackupRequest{}))
+	assert.Equal(t, IndexModeImmediate, restoreIndexMode(&backuppb.RestoreBackupRequest{RestoreIndex: true}))
+	assert.Equal(t, IndexModeDeferred, restoreIndexMode(&backuppb.RestoreBackupRequest{RestoreIndex: true, IndexMode: IndexModeDeferred}))
+
+	index := &backuppb.IndexInfo{
+		FieldName:   "vector",
+		IndexName:   "vector_index",
+		IndexType:   "HNSW",
+		Params:      map[string]string{"index_type": "HNSW", "metric_type": "L2", "params": `{"M":8,"efConstruction":64}`},
+		MmapEnabled: true,
+	}
+	idx := restoreIndex(index, false)
+	assert.Equal(t, "vector_index", idx.Name())
+	assert.Equal(t, entity.IndexType("HNSW"), idx.IndexType())
+	assert.Equal(t, "true", idx.Params()[MMAP_ENABLED_KEY])
+	assert.Equal(t, `{"M":8,"efConstruction":64}`, idx.Params()["params"])
+	// the index in backup is not changed
+	assert.NotContains(t, index.GetParams(), MMAP_ENABLED_KEY)
+
+	idx = restoreIndex(index, true)
+	assert.Equal(t, entity.AUTOINDEX, idx.IndexType())
+	assert.Equal(t, map[string]string{"index_type": "AUTOINDEX", "metric_type": "L2", MMAP_ENABLED_KEY: "true"}, idx.Params())
+
+	idx = restoreIndex(&backuppb.IndexInfo{FieldName: "text", IndexType: "marisa-trie", Params: map[string]string{"index_type": "marisa-trie"}}, false)
+	assert.Equal(t, entity.IndexType("Trie"), idx.IndexType())
+	assert.Equal(t, "Trie", idx.Params()["index_type"])
+}
+
+func TestSelectBackupPartitions(t *testing.T) {
+	collection := &backuppb.CollectionBackupInfo{
+		DbName:         "default",
+		CollectionName: "coll",
+		PartitionBackups: []*backuppb.PartitionBackupInfo{
+			{PartitionName: "_default"},
+			{PartitionName: "p1", Size: 10},
+		},
+	}
+	selected, err := selectBackupPartitions(collection, []string{"p1"})
+	assert.NoError(t, err)
+	assert.Len(t, selected.GetPartitionBackups(), 1)
+	assert.Equal(t, int64(10), selected.GetPartitionBackups()[0].GetSize())
+	// the backup is not changed
+	assert.Len(t, collection.GetPartitionBackups(), 2)
+
+	_, err = selectBackupPartitions(collection, []string{"p2"})
+	assert.Error(t, err)
+}
+
+func TestPartitionKeyCollection(t *testing.T) {
+	schema := &backuppb.CollectionSchema{Fields: []*backuppb.FieldSchema{
+		{Name: "id", IsPrimaryKey: true},
+		{Name: "tenant", IsPartitionKey: true},
+	}}
+	assert.True(t, isPartitionKeyCollection(schema))
+	assert.False(t, isPartitionKeyCollection(&backuppb.CollectionSchema{Fields: schema.GetFields()[:1]}))
+
+	collBackup := &backuppb.CollectionBackupInfo{
+		Schema:           schema,
+		PartitionBackups: []*backuppb.PartitionBackupInfo{{PartitionName: "_default_0"}, {PartitionName: "_default_1"}},
+	}
+	_, err := selectBackupPartitions(collBackup, []string{"_default_0"})
+	assert.Error(t, err)
+	assert.True(t, samePartitions(collBackup, []*entity.Partition{{Name: "_default_1"}, {Name: "_default_0"}}))
+	assert.False(t, samePartitions(collBackup, []*entity.Partition{{Name: "_default_0"}, {Name: "_default_1"}, {Name: "_default_2"}}))
+	assert.False(t, samePartitions(collBackup, []*entity.Partition{{Name: "_default_0"}, {Name: "_default_2"}}))
+}
+
+func TestRenderCollectionName(t *testing.T) {
+	assert.Equal(t, "coll_restored_20240102", renderCollectionName("{{name}}_restored_{{date}}", "default", "coll", "20240102"))
+	assert.Equal(t, "db1_coll", renderCollectionName("{{db}}_{{name}}", "db1", "coll", "20240102"))
+	assert.Equal(t, "coll_copy", renderCollectionName("coll_copy", "db1", "coll", "20240102"))
+}
+
+func TestCompareCollectionSchema(t *testing.T) {
+	backupSchema := &backuppb.CollectionSchema{
+		Fields: []*backuppb.FieldSchema{
+			{Name: "id", IsPrimaryKey: true, DataType: backuppb.DataType_Int64},
+			{Name: "vector", DataType: backuppb.DataType_FloatVector, TypeParams: []*backuppb.KeyValuePair{{Key: "dim", Value: "4"}}},
+		},
+	}
+	schema := entity.NewSchema().
+		WithField(entity.NewField().WithName("id").WithIsPrimaryKey(true).WithDataType(entity.FieldTypeInt64)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(4))
+	assert.Empty(t, compareCollectionSchema(backupSchema, schema))
+
+	schema = entity.NewSchema().
+		WithField(entity.NewField().WithName("id").WithIsPrimaryKey(true).WithDataType(entity.FieldTypeVarChar)).
+		WithField(entity.NewField().WithName("vector").WithDataType(entity.FieldTypeFloatVector).WithDim(8)).
+		WithField(entity.NewField().WithName("extra").WithDataType(entity.FieldTypeInt32))
+	assert.Len(t, compareCollectionSchema(backupSchema, schema), 3)
+
+	assert.NotEmpty(t, compareCollectionSchema(backupSchema, nil))
+}
+
+func TestMapCollectionSchema(t *testing.T) {
+	backupSchema := &backuppb.CollectionSchema{
+		Fields: []*backuppb.FieldSchema{
+			{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: backuppb.DataType_Int64},
+			{FieldID: 101, Name: "vector", DataType: backuppb.DataType_FloatVector, TypeParams: []*backuppb.KeyValuePair{{Key: "dim", Value: "4"}}},
+			{FieldID: 102, Name: "tag", DataType: backuppb.DataType_VarChar},
+		},
+	}
+	schema := entity.NewSchema().
+		WithField(entity.NewField().WithName("id").WithIsPrimaryKey(true).WithDataType(entity.FieldTypeInt64)).
+		WithField(entity.NewField().WithName("embedding").WithDataType(entity.FieldTypeFloatVector).WithDim(4)).
+		WithField(entity.NewField().WithName("extra").WithDataType(entity.FieldTypeInt32))
+	schema.Fields[0].ID = 100
+	schema.Fields[1].ID = 105
+	schema.Fields[2].ID = 106
+
+	_, _, mismatches := mapCollectionSchema(backupSchema, schema, SchemaPolicyStrict, map[string]string{"vector": "embedding"})
+	assert.Len(t, mismatches, 2)
+
+	fieldIDs, skippedFields, mismatches := mapCollectionSchema(backupSchema, schema, SchemaPolicyCompatible, map[string]string{"vector": "embedding"})
+	assert.Empty(t, mismatches)
+	assert.Equal(t, map[int64]int64{101: 105}, fieldIDs)
+	assert.Equal(t, []int64{102}, skippedFields)
+
+	// the primary key can't be skipped, a field can't be mapped into one of another type
+	_, _, mismatches = mapCollectionSchema(backupSchema, schema, SchemaPolicyCompatible, map[string]string{"id": "pk", "tag": "extra", "missing": "id"})
+	assert.Len(t, mismatches, 3)
+}
+
+func TestMapBinlogPath(t *testing.T) {
+	fieldIDs := map[int64]int64{101: 105}
+	skippedFields := map[int64]bool{102: true}
+	from := "backup/b1/binlogs/insert_log/1/2/"
+	to := "restore-temp-b1/backup/b1/binlogs/insert_log/1/2/"
+
+	path, ok := mapBinlogPath(from+"10/101/1000", from, to, fieldIDs, skippedFields)
+	assert.True(t, ok)
+	assert.Equal(t, to+"10/105/1000", path)
+	path, ok = mapBinlogPath(from+"10/100/1000", from, to, fieldIDs, skippedFields)
+	assert.True(t, ok)
+	assert.Equal(t, to+"10/100/1000", path)
+	_, ok = mapBinlogPath(from+"10/102/1000", from, to, fieldIDs, skippedFields)
+	assert.False(t, ok)
+}
+
+// archivedChunkManager reports pending files of each prefix until called rounds times
+type archivedChunkManager struct {
+	storage.ChunkManager
+	rounds   int
+	prefixes map[string]int
+}
+
+func (m *archivedChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error) {
+	m.prefixes[prefix]++
+	if m.prefixes[prefix] < m.rounds {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+func TestRehydrateBackupFiles(t *testing.T) {
+	partition := &backuppb.PartitionBackupInfo{
+		CollectionId: 1,
+		PartitionId:  2,
+		SegmentBackups: []*backuppb.SegmentBackupInfo{
+			{SegmentId: 10, GroupId: 3},
+			{SegmentId: 11, GroupId: 4, RefBackupName: "base_backup"},
+		},
+	}
+	task := &backuppb.RestoreBackupTask{CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{
+		{CollBackup: &backuppb.CollectionBackupInfo{PartitionBackups: []*backuppb.PartitionBackupInfo{partition}}},
+		// meta only and restored collections have no binlogs to rehydrate
+		{MetaOnly: true, CollBackup: &backuppb.CollectionBackupInfo{PartitionBackups: []*backuppb.PartitionBackupInfo{{CollectionId: 5, PartitionId: 6, SegmentBackups: partition.SegmentBackups}}}},
+		{StateCode: backuppb.RestoreTaskStateCode_SUCCESS, CollBackup: &backuppb.CollectionBackupInfo{PartitionBackups: []*backuppb.PartitionBackupInfo{{CollectionId: 7, PartitionId: 8, SegmentBackups: partition.SegmentBackups}}}},
+	}}
+	assert.Equal(t, []string{
+		"backup/incr_backup/binlogs/insert_log/1/2/",
+		"backup/incr_backup/binlogs/delta_log/1/2/",
+		"backup/base_backup/binlogs/insert_log/1/2/",
+		"backup/base_backup/binlogs/delta_log/1/2/",
+	}, collectRehydratePrefixes("backup/incr_backup", task))
+
+	cm := &archivedChunkManager{rounds: 3, prefixes: map[string]int{}}
+	var storageClient storage.ChunkManager = cm
+	b := &BackupContext{storageClient: &storageClient}
+	b.params.BackupCfg.RehydratePollInterval = time.Millisecond
+	assert.NoError(t, b.rehydrateBackupFiles(context.Background(), "bucket", "backup/incr_backup", task))
+	assert.Equal(t, 3, cm.prefixes["backup/base_backup/binlogs/insert_log/1/2/"])
+
+	cm = &archivedChunkManager{rounds: 1000, prefixes: map[string]int{}}
+	storageClient = cm
+	b.params.BackupCfg.RehydrateTimeout = 5 * time.Millisecond
+	assert.Error(t, b.rehydrateBackupFiles(context.Background(), "bucket", "backup/incr_backup", task))
+}
+
+func TestSkippedCollections(t *testing.T) {
+	coll1 := &backuppb.CollectionBackupInfo{DbName: "default", CollectionName: "a"}
+	coll2 := &backuppb.CollectionBackupInfo{DbName: "default", CollectionName: "b"}
+	coll3 := &backuppb.CollectionBackupInfo{DbName: "db1", CollectionName: "a"}
+	backup := &backuppb.BackupInfo{CollectionBackups: []*backuppb.CollectionBackupInfo{coll1, coll2, coll3}}
+	assert.Equal(t, []string{"default.b"}, skippedCollections(backup, []*backuppb.CollectionBackupInfo{coll1, coll3}))
+	assert.Empty(t, skippedCollections(backup, backup.GetCollectionBackups()))
+	assert.Equal(t, []string{"default.a", "default.b", "db1.a"}, skippedCollections(backup, nil))
+}
+
+func TestPlanRestoreData(t *testing.T) {
+	b := &BackupContext{milvusBucketName: "milvus-bucket"}
+	plan := func(ctx context.Context, bucketName string, segment *backuppb.SegmentBackupInfo, task *backuppb.RestoreCollectionTask) *restoreCollectionRun {
+		task.CollBackup = &backuppb.CollectionBackupInfo{PartitionBackups: []*backuppb.PartitionBackupInfo{{
+			SegmentBackups: []*backuppb.SegmentBackupInfo{segment},
+		}}}
+		run := &restoreCollectionRun{task: task, backupBucketName: bucketName}
+		b.planRestoreData(ctx, run)
+		return run
+	}
+	ctx := context.Background()
+	plain := &backuppb.SegmentBackupInfo{SegmentId: 1, GroupId: 1}
+
+	run := plan(ctx, "milvus-bucket", plain, &backuppb.RestoreCollectionTask{})
+	assert.True(t, run.task.GetInPlace())
+	assert.False(t, run.useTempDir)
+
+	// copied into the temporary dir from another bucket
+	run = plan(ctx, "backup-bucket", plain, &backuppb.RestoreCollectionTask{})
+	assert.False(t, run.task.GetInPlace())
+	assert.True(t, run.useTempDir)
+	sseCtx := storage.WithSourceServerSideEncryption(ctx, storage.ServerSideEncryption{Type: storage.SSETypeCustomer, CustomerKey: []byte("key")})
+	run = plan(sseCtx, "milvus-bucket", plain, &backuppb.RestoreCollectionTask{})
+	assert.False(t, run.task.GetInPlace())
+	assert.True(t, run.useTempDir)
+
+	// decoded into the temporary dir
+	run = plan(ctx, "milvus-bucket", &backuppb.SegmentBackupInfo{SegmentId: 1, GroupId: 1, Compression: utils.CompressionZstd}, &backuppb.RestoreCollectionTask{})
+	assert.False(t, run.task.GetInPlace())
+	assert.True(t, run.useTempDir)
+	assert.Contains(t, run.encodedGroups, int64(1))
+	run = plan(ctx, "milvus-bucket", &backuppb.SegmentBackupInfo{SegmentId: 1, GroupId: 1, Encrypted: true}, &backuppb.RestoreCollectionTask{})
+	assert.False(t, run.task.GetInPlace())
+
+	// dedup objects are materialized into the temporary dir
+	dedup := &backuppb.SegmentBackupInfo{SegmentId: 1, GroupId: 1, Binlogs: []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: []*backuppb.Binlog{{LogPath: "log", ObjectName: "object"}}}}}
+	run = plan(ctx, "milvus-bucket", dedup, &backuppb.RestoreCollectionTask{})
+	assert.False(t, run.task.GetInPlace())
+	assert.True(t, run.useTempDir)
+
+	// mapped into the fields of the existing collection in the temporary dir
+	run = plan(ctx, "milvus-bucket", plain, &backuppb.RestoreCollectionTask{FieldIdMappings: map[int64]int64{100: 101}})
+	assert.False(t, run.task.GetInPlace())
+	assert.True(t, run.useTempDir)
+	run = plan(ctx, "milvus-bucket", plain, &backuppb.RestoreCollectionTask{SkippedFieldIds: []int64{102}})
+	assert.False(t, run.task.GetInPlace())
+	assert.True(t, run.useTempDir)
+}
+
+// fakeBulkInsertClient completes every bulk insert immediately
+type fakeBulkInsertClient struct {
+	gomilvus.Client
+	files [][]string
+}
+
+func (c *fakeBulkInsertClient) UsingDatabase(ctx context.Context, dbName string) error {
+	return nil
+}
+
+func (c *fakeBulkInsertClient) BulkInsert(ctx context.Context, collName string, partitionName string, files []string, opts ...gomilvus.BulkInsertOption) (int64, error) {
+	c.files = append(c.files, files)
+	return int64(len(c.files)), nil
+}
+
+func (c *fakeBulkInsertClient) GetBulkInsertState(ctx context.Context, taskID int64) (*entity.BulkInsertTaskState, error) {
+	return &entity.BulkInsertTaskState{ID: taskID, State: entity.BulkInsertCompleted}, nil
+}
+
+func TestBulkInsertGroupRemovesTempFiles(t *testing.T) {
+	files := map[string][]byte{
+		"restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/3/100/1": []byte("temp"),
+		"restore-temp-task-db-coll/backup/b/binlogs/delta_log/1/2/3/1":      []byte("temp"),
+		// the temporary files of another group and the binlogs of the backup in milvus bucket are kept
+		"restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/4/100/1": []byte("temp"),
+		"backup/b/binlogs/insert_log/1/2/3/100/1":                           []byte("binlog"),
+	}
+	var storageClient storage.ChunkManager = &memoryChunkManager{files: files}
+	milvusClient := &fakeBulkInsertClient{}
+	b := &BackupContext{storageClient: &storageClient, milvusBucketName: "milvus-bucket", milvusClient: &MilvusClient{client: milvusClient}}
+	b.params.BackupCfg.BulkInsertMaxAttempts = 1
+	ctx := context.Background()
+	run := &restoreCollectionRun{
+		task: &backuppb.RestoreCollectionTask{
+			TargetDbName:         "db",
+			TargetCollectionName: "coll",
+			CollBackup:           &backuppb.CollectionBackupInfo{},
+		},
+		tempDir:          "restore-temp-task-db-coll/",
+		targetPartitions: map[int64]string{2: "_default"},
+	}
+	partition := &backuppb.PartitionBackupInfo{CollectionId: 1, PartitionId: 2}
+
+	// restored in place, the files out of the temporary dir are not removed
+	assert.NoError(t, b.bulkInsertGroup(ctx, run, partition, 3, []string{"backup/b/binlogs/insert_log/1/2/3/", ""}))
+	assert.Contains(t, files, "backup/b/binlogs/insert_log/1/2/3/100/1")
+
+	assert.NoError(t, b.bulkInsertGroup(ctx, run, partition, 3, []string{
+		"restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/3/",
+		"restore-temp-task-db-coll/backup/b/binlogs/delta_log/1/2/3/",
+	}))
+	assert.Len(t, milvusClient.files, 2)
+	assert.NotContains(t, files, "restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/3/100/1")
+	assert.NotContains(t, files, "restore-temp-task-db-coll/backup/b/binlogs/delta_log/1/2/3/1")
+	assert.Contains(t, files, "restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/4/100/1")
+	assert.Contains(t, files, "backup/b/binlogs/insert_log/1/2/3/100/1")
+
+	// kept for debugging
+	files["restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/3/100/1"] = []byte("temp")
+	b.params.BackupCfg.KeepTempFiles = true
+	assert.NoError(t, b.bulkInsertGroup(ctx, run, partition, 3, []string{"restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/3/", ""}))
+	assert.Contains(t, files, "restore-temp-task-db-coll/backup/b/binlogs/insert_log/1/2/3/100/1")
+}
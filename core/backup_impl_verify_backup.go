@@ -0,0 +1,226 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+func (b *BackupContext) VerifyBackup(ctx context.Context, request *backuppb.VerifyBackupRequest) *backuppb.VerifyBackupResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive VerifyBackupRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("backupName", request.GetBackupName()))
+
+	resp := &backuppb.VerifyBackupResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	if !b.started {
+		err := b.Start()
+		if err != nil {
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+	}
+
+	if request.GetBackupName() == "" {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = "empty backup name"
+		return resp
+	}
+
+	getResp := b.GetBackup(ctx, &backuppb.GetBackupRequest{
+		BackupName: request.GetBackupName(),
+	})
+	if getResp.GetCode() != backuppb.ResponseCode_Success {
+		resp.Code = getResp.GetCode()
+		resp.Msg = getResp.GetMsg()
+		return resp
+	}
+	if getResp.GetData() == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = fmt.Sprintf("backup does not exist: %s", request.GetBackupName())
+		return resp
+	}
+	backup := getResp.GetData()
+	// binlogs encrypted by customer key can't be stat or read without the key
+	sse, err := b.backupServerSideEncryption(backup, request.GetSseCustomerKey())
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = err.Error()
+		return resp
+	}
+	ctx = storage.WithSourceServerSideEncryption(ctx, sse)
+
+	// the manifest lists the size and checksum of every file of backup, backups without it are checked by meta
+	manifest, err := b.readBackupManifest(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backup.GetName())
+	if err != nil {
+		log.Error("Fail to read backup manifest", zap.String("backupName", backup.GetName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if manifest != nil {
+		result, err := b.checkManifestFiles(ctx, b.backupBucketName, b.backupRootPath, manifest, nil, true)
+		if err != nil {
+			log.Error("Fail to verify backup", zap.String("backupName", backup.GetName()), zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		resp.Manifest = true
+		resp.CheckedFiles = result.checkedFiles
+		resp.ChecksumFiles = result.checksumFiles
+		resp.MissingFiles = result.missingFiles
+		resp.CorruptedFiles = result.corruptedFiles
+		return finishVerifyBackup(resp)
+	}
+
+	var mu sync.Mutex
+	report := func(update func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		update()
+	}
+
+	jobIds := make([]int64, 0)
+	for _, collection := range backup.GetCollectionBackups() {
+		// binlogs of the collections failed in a partial backup are not all copied
+		if isFailedCollection(collection) {
+			continue
+		}
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				// binlogs of an incremental backup may be stored in its base backups
+				backupName := backup.GetName()
+				if segment.GetRefBackupName() != "" {
+					backupName = segment.GetRefBackupName()
+				}
+				fieldBinlogs := append(append([]*backuppb.FieldBinlog{}, segment.GetBinlogs()...), segment.GetDeltalogs()...)
+				for _, fieldBinlog := range fieldBinlogs {
+					for _, binlog := range fieldBinlog.GetBinlogs() {
+						binlog := binlog
+						segment := segment
+						filePath := b.binlogBackupPath(backupName, collection, segment, binlog.GetLogPath())
+						if binlog.GetObjectName() != "" {
+							filePath = DedupObjectPath(b.backupRootPath, binlog.GetObjectName())
+						}
+						job := func(ctx context.Context) error {
+							missing, corrupted, checksum, err := b.verifyBinlogFile(ctx, b.backupBucketName, segment, binlog, filePath)
+							if err != nil {
+								return err
+							}
+							report(func() {
+								resp.CheckedFiles++
+								if checksum {
+									resp.ChecksumFiles++
+								}
+								if missing {
+									resp.MissingFiles = append(resp.MissingFiles, filePath)
+								}
+								if corrupted {
+									resp.CorruptedFiles = append(resp.CorruptedFiles, filePath)
+								}
+							})
+							return nil
+						}
+						jobId := b.getCopyDataWorkerPool().SubmitWithId(common.WithRequest(ctx, job))
+						jobIds = append(jobIds, jobId)
+					}
+				}
+			}
+		}
+	}
+	defer b.getCopyDataWorkerPool().ForgetJobs(jobIds)
+	if err := b.getCopyDataWorkerPool().WaitJobs(jobIds); err != nil {
+		log.Error("Fail to verify backup", zap.String("backupName", backup.GetName()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	return finishVerifyBackup(resp)
+}
+
+// finishVerifyBackup sets the code of a verify response by the files missing or corrupted
+func finishVerifyBackup(resp *backuppb.VerifyBackupResponse) *backuppb.VerifyBackupResponse {
+	if len(resp.GetMissingFiles()) > 0 || len(resp.GetCorruptedFiles()) > 0 {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = fmt.Sprintf("backup is broken, %d files missing, %d files corrupted", len(resp.GetMissingFiles()), len(resp.GetCorruptedFiles()))
+	} else {
+		resp.Code = backuppb.ResponseCode_Success
+		resp.Msg = "success"
+	}
+	log.Info("return VerifyBackupResponse",
+		zap.String("requestId", resp.GetRequestId()),
+		zap.Int32("code", int32(resp.GetCode())),
+		zap.String("msg", resp.GetMsg()),
+		zap.Int64("checkedFiles", resp.GetCheckedFiles()),
+		zap.Int64("checksumFiles", resp.GetChecksumFiles()),
+		zap.Bool("manifest", resp.GetManifest()),
+		zap.Strings("missingFiles", resp.GetMissingFiles()),
+		zap.Strings("corruptedFiles", resp.GetCorruptedFiles()))
+	return resp
+}
+
+// verifyBinlogFile checks a binlog file in backup, returns whether the file is missing or corrupted,
+// and whether it is checked by checksum. A file without checksum recorded is only checked by size.
+func (b *BackupContext) verifyBinlogFile(ctx context.Context, bucketName string, segment *backuppb.SegmentBackupInfo, binlog *backuppb.Binlog, filePath string) (missing bool, corrupted bool, checksum bool, err error) {
+	exist, err := b.getBackupStorageClient().Exist(ctx, bucketName, filePath)
+	if err != nil {
+		log.Error("Fail to check file exist", zap.String("file", filePath), zap.Error(err))
+		return false, false, false, err
+	}
+	if !exist {
+		log.Warn("backup file not exist", zap.String("file", filePath))
+		return true, false, false, nil
+	}
+
+	expectedSize := binlog.GetBackupSize()
+	if expectedSize == 0 && segment.GetCompression() == utils.CompressionNone && !segment.GetEncrypted() {
+		// copied by storage as it is
+		expectedSize = binlog.GetLogSize()
+	}
+
+	if binlog.GetCrc32C() == "" {
+		if expectedSize == 0 {
+			return false, false, false, nil
+		}
+		size, err := b.getBackupStorageClient().Size(ctx, bucketName, filePath)
+		if err != nil {
+			log.Error("Fail to get file size", zap.String("file", filePath), zap.Error(err))
+			return false, false, false, err
+		}
+		if size != expectedSize {
+			log.Warn("backup file size mismatch", zap.String("file", filePath), zap.Int64("expected", expectedSize), zap.Int64("actual", size))
+			return false, true, false, nil
+		}
+		return false, false, false, nil
+	}
+
+	data, err := b.getBackupStorageClient().Read(ctx, bucketName, filePath)
+	if err != nil {
+		log.Error("Fail to read file", zap.String("file", filePath), zap.Error(err))
+		return false, false, true, err
+	}
+	if int64(len(data)) != expectedSize {
+		log.Warn("backup file size mismatch", zap.String("file", filePath), zap.Int64("expected", expectedSize), zap.Int("actual", len(data)))
+		return false, true, true, nil
+	}
+	if sum := utils.Crc32c(data); sum != binlog.GetCrc32C() {
+		log.Warn("backup file checksum mismatch", zap.String("file", filePath), zap.String("expected", binlog.GetCrc32C()), zap.String("actual", sum))
+		return false, true, true, nil
+	}
+	return false, false, true, nil
+}
@@ -0,0 +1,144 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+// memoryChunkManager keeps files in memory, only implements the methods used by tests
+type memoryChunkManager struct {
+	storage.ChunkManager
+	files map[string][]byte
+}
+
+// Exist is true if any file has the prefix filePath, like minio
+func (m *memoryChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	for file := range m.files {
+		if strings.HasPrefix(file, filePath) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memoryChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	return int64(len(m.files[filePath])), nil
+}
+
+func (m *memoryChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	m.files[filePath] = content
+	return nil
+}
+
+func (m *memoryChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	return m.files[filePath], nil
+}
+
+func (m *memoryChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	delete(m.files, filePath)
+	return nil
+}
+
+func (m *memoryChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	for file := range m.files {
+		if strings.HasPrefix(file, prefix) {
+			delete(m.files, file)
+		}
+	}
+	return nil
+}
+
+func (m *memoryChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	paths := make([]string, 0)
+	sizes := make([]int64, 0)
+	dirs := make(map[string]bool)
+	for file, content := range m.files {
+		if !strings.HasPrefix(file, prefix) {
+			continue
+		}
+		// the dirs right under prefix if not recursive, like minio
+		if i := strings.Index(file[len(prefix):], "/"); !recursive && i >= 0 {
+			dir := file[:len(prefix)+i+1]
+			if !dirs[dir] {
+				dirs[dir] = true
+				paths = append(paths, dir)
+				sizes = append(sizes, 0)
+			}
+			continue
+		}
+		paths = append(paths, file)
+		sizes = append(sizes, int64(len(content)))
+	}
+	return paths, sizes, nil
+}
+
+func (m *memoryChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc storage.WalkFunc) error {
+	paths, sizes, _ := m.ListWithPrefix(ctx, bucketName, prefix, recursive)
+	for i, path := range paths {
+		if err := walkFunc(path, sizes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestBinlogBackupPath(t *testing.T) {
+	b := &BackupContext{milvusRootPath: "files", backupRootPath: "backup"}
+	segment := &backuppb.SegmentBackupInfo{PartitionId: 2, SegmentId: 3, GroupId: 3}
+	assert.Equal(t, "backup/test_backup/binlogs/insert_log/1/2/3/3/100/1",
+		b.binlogBackupPath("test_backup", nil, segment, "files/insert_log/1/2/3/100/1"))
+
+	b = &BackupContext{backupRootPath: "backup"}
+	segment.GroupId = 0
+	assert.Equal(t, "backup/test_backup/binlogs/delta_log/1/2/3/100/1",
+		b.binlogBackupPath("test_backup", nil, segment, "delta_log/1/2/3/100/1"))
+
+	segment = &backuppb.SegmentBackupInfo{CollectionId: 1, PartitionId: 2, SegmentId: 3, GroupId: 3}
+	collection := &backuppb.CollectionBackupInfo{DbName: "db1", CollectionName: "coll",
+		BackupPathTemplate: "{{backup}}/{{database}}/{{collection}}/{{logType}}/{{partitionID}}/{{groupID}}"}
+	assert.Equal(t, "backup/test_backup/db1/coll/delta_log/2/3/3/100/1",
+		b.binlogBackupPath("test_backup", collection, segment, "delta_log/1/2/3/100/1"))
+}
+
+func TestVerifyBinlogFile(t *testing.T) {
+	content := []byte("binlog content")
+	var storageClient storage.ChunkManager = &memoryChunkManager{files: map[string][]byte{
+		"raw":      content,
+		"checksum": content,
+	}}
+	b := &BackupContext{storageClient: &storageClient}
+	ctx := context.Background()
+	segment := &backuppb.SegmentBackupInfo{}
+
+	// checked by size of milvus binlog
+	missing, corrupted, checksum, err := b.verifyBinlogFile(ctx, "", segment, &backuppb.Binlog{LogSize: int64(len(content))}, "raw")
+	assert.NoError(t, err)
+	assert.False(t, missing || corrupted || checksum)
+
+	_, corrupted, _, err = b.verifyBinlogFile(ctx, "", segment, &backuppb.Binlog{LogSize: 1}, "raw")
+	assert.NoError(t, err)
+	assert.True(t, corrupted)
+
+	missing, _, _, err = b.verifyBinlogFile(ctx, "", segment, &backuppb.Binlog{LogSize: 1}, "not_exist")
+	assert.NoError(t, err)
+	assert.True(t, missing)
+
+	// checked by checksum
+	binlog := &backuppb.Binlog{BackupSize: int64(len(content)), Crc32C: utils.Crc32c(content)}
+	missing, corrupted, checksum, err = b.verifyBinlogFile(ctx, "", segment, binlog, "checksum")
+	assert.NoError(t, err)
+	assert.False(t, missing || corrupted)
+	assert.True(t, checksum)
+
+	binlog.Crc32C = utils.Crc32c([]byte("other content"))
+	_, corrupted, _, err = b.verifyBinlogFile(ctx, "", segment, binlog, "checksum")
+	assert.NoError(t, err)
+	assert.True(t, corrupted)
+}
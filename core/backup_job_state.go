@@ -0,0 +1,208 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+// a running job is interrupted if its record is not updated for the intervals
+const jobStateStaleIntervals = 3
+
+// jobInstance returns host:pid of this server, recorded in the jobs it runs
+func jobInstance() string {
+	host, _ := os.Hostname()
+	return fmt.Sprintf("%s:%d", host, os.Getpid())
+}
+
+// jobStateContext is the context to read and write job records, they are written without the object lock and the
+// customer key of backups, so that they can be removed and read by other servers
+func (b *BackupContext) jobStateContext() context.Context {
+	return storage.WithoutCustomerKey(b.ctx)
+}
+
+func (b *BackupContext) writeJobState(info *backuppb.JobInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return b.getBackupStorageClient().Write(b.jobStateContext(), b.backupBucketName, JobStatePath(b.backupRootPath, info.GetId()), data)
+}
+
+// readJobState reads the record of the job in backup storage, nil if it doesn't exist
+func (b *BackupContext) readJobState(id string) (*backuppb.JobInfo, error) {
+	path := JobStatePath(b.backupRootPath, id)
+	exist, err := b.getBackupStorageClient().Exist(b.jobStateContext(), b.backupBucketName, path)
+	if err != nil || !exist {
+		return nil, err
+	}
+	return b.readJobStateFile(path)
+}
+
+func (b *BackupContext) readJobStateFile(path string) (*backuppb.JobInfo, error) {
+	data, err := b.getBackupStorageClient().Read(b.jobStateContext(), b.backupBucketName, path)
+	if err != nil {
+		return nil, err
+	}
+	info := &backuppb.JobInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, fmt.Errorf("invalid job record %s: %w", path, err)
+	}
+	markInterruptedJob(info, time.Now(), b.params.BackupCfg.JobStateInterval)
+	return info, nil
+}
+
+// listJobStates reads the records of all jobs in backup storage, the records which can't be read are skipped
+func (b *BackupContext) listJobStates() ([]*backuppb.JobInfo, error) {
+	paths, _, err := b.getBackupStorageClient().ListWithPrefix(b.jobStateContext(), b.backupBucketName, b.backupRootPath+SEPERATOR+JOB_DIR+SEPERATOR, true)
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]*backuppb.JobInfo, 0, len(paths))
+	for _, path := range paths {
+		info, err := b.readJobStateFile(path)
+		if err != nil {
+			log.Warn("fail to read job record", zap.String("path", path), zap.Error(err))
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// markInterruptedJob turns a running job whose record is not updated for a while to JOB_INTERRUPTED,
+// the server running it is gone
+func markInterruptedJob(info *backuppb.JobInfo, now time.Time, interval time.Duration) {
+	switch info.GetStateCode() {
+	case backuppb.JobStateCode_JOB_RUNNING, backuppb.JobStateCode_JOB_CANCELING:
+	default:
+		return
+	}
+	if now.Sub(time.Unix(info.GetUpdateTime(), 0)) <= jobStateStaleIntervals*interval {
+		return
+	}
+	info.StateCode = backuppb.JobStateCode_JOB_INTERRUPTED
+	info.ErrorMessage = fmt.Sprintf("server %s stopped updating the job at %s", info.GetInstance(),
+		time.Unix(info.GetUpdateTime(), 0).Format(time.RFC3339))
+}
+
+// persistJob writes the record of the job into backup storage every interval until the returned func is called,
+// which writes the final record. Failures are only logged, the job goes on without its record.
+func (b *BackupContext) persistJob(job *backupJob) func() {
+	save := func() {
+		job.mu.Lock()
+		info := proto.Clone(job.info).(*backuppb.JobInfo)
+		job.mu.Unlock()
+		b.fillJobProgress(info)
+		info.UpdateTime = time.Now().Unix()
+		if err := b.writeJobState(info); err != nil {
+			log.Warn("fail to write job record", zap.String("jobId", info.GetId()), zap.Error(err))
+		}
+	}
+	save()
+
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(b.params.BackupCfg.JobStateInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stop:
+				return
+			case <-b.ctx.Done():
+				return
+			case <-ticker.C:
+				save()
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+		save()
+		b.cleanJobStates()
+	}
+}
+
+// cleanJobStates removes the records of the jobs ended or interrupted before the retention
+func (b *BackupContext) cleanJobStates() {
+	infos, err := b.listJobStates()
+	if err != nil {
+		log.Warn("fail to list job records", zap.Error(err))
+		return
+	}
+	for _, info := range expiredJobStates(infos, time.Now(), b.params.BackupCfg.JobStateRetention) {
+		if err := b.getBackupStorageClient().Remove(b.jobStateContext(), b.backupBucketName, JobStatePath(b.backupRootPath, info.GetId())); err != nil {
+			log.Warn("fail to remove expired job record", zap.String("jobId", info.GetId()), zap.Error(err))
+		}
+	}
+}
+
+// expiredJobStates returns the jobs ended, or last updated if interrupted, before the retention
+func expiredJobStates(infos []*backuppb.JobInfo, now time.Time, retention time.Duration) []*backuppb.JobInfo {
+	expired := make([]*backuppb.JobInfo, 0)
+	for _, info := range infos {
+		var endTime int64
+		switch info.GetStateCode() {
+		case backuppb.JobStateCode_JOB_RUNNING, backuppb.JobStateCode_JOB_CANCELING:
+			continue
+		case backuppb.JobStateCode_JOB_INTERRUPTED:
+			endTime = info.GetUpdateTime()
+		default:
+			endTime = info.GetEndTime()
+		}
+		if now.Sub(time.Unix(endTime, 0)) > retention {
+			expired = append(expired, info)
+		}
+	}
+	return expired
+}
+
+// restoreTaskOfJob reads the restore task of a job recorded in backup storage from the checkpoint in the backup restored
+// from, nil if job state is disabled or the job is not a recorded restore. The task of an interrupted job is failed.
+func (b *BackupContext) restoreTaskOfJob(ctx context.Context, id string) (*backuppb.RestoreBackupTask, error) {
+	if !b.params.BackupCfg.JobStateEnable {
+		return nil, nil
+	}
+	info, err := b.readJobState(id)
+	if err != nil || info == nil || info.GetType() != metrics.RestoreTaskLabel {
+		return nil, err
+	}
+	bucketName, backupPath := b.backupBucketName, b.backupRootPath+SEPERATOR+info.GetBackupName()
+	if info.GetBucketName() != "" && info.GetPath() != "" {
+		bucketName, backupPath = info.GetBucketName(), info.GetPath()+SEPERATOR+info.GetBackupName()
+	}
+	task, err := b.readRestoreCheckpoint(ctx, bucketName, backupPath, id)
+	if err != nil || task == nil {
+		return nil, err
+	}
+	if info.GetStateCode() == backuppb.JobStateCode_JOB_INTERRUPTED && task.GetStateCode() != backuppb.RestoreTaskStateCode_SUCCESS {
+		task.StateCode = backuppb.RestoreTaskStateCode_FAIL
+		task.ErrorMessage = info.GetErrorMessage()
+	}
+	return task, nil
+}
+
+// sortJobs sorts the jobs by start time, the latest first
+func sortJobs(jobs []*backuppb.JobInfo) {
+	sort.SliceStable(jobs, func(i, j int) bool {
+		if jobs[i].GetStartTime() != jobs[j].GetStartTime() {
+			return jobs[i].GetStartTime() > jobs[j].GetStartTime()
+		}
+		return jobs[i].GetId() < jobs[j].GetId()
+	})
+}
@@ -0,0 +1,116 @@
+package core
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+func newJobStateBackupContext(chunkManager *memoryChunkManager) *BackupContext {
+	b := newManifestBackupContext(chunkManager)
+	b.restoreTasks = make(map[string]*backuppb.RestoreBackupTask)
+	b.params.BackupCfg.JobStateEnable = true
+	b.params.BackupCfg.JobStateInterval = time.Minute
+	b.params.BackupCfg.JobStateRetention = 24 * time.Hour
+	return b
+}
+
+func TestPersistedJobs(t *testing.T) {
+	chunkManager := &memoryChunkManager{files: make(map[string][]byte)}
+	b := newJobStateBackupContext(chunkManager)
+	ctx := context.Background()
+
+	b.backupTasks.Store("backup1", &backuppb.BackupInfo{Id: "backup1", Size: 400, CopiedSize: 100, Progress: 25})
+	_, finish := b.startJob(ctx, "backup1", metrics.BackupTaskLabel, "b1", "", "")
+	record, err := b.readJobState("backup1")
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.JobStateCode_JOB_RUNNING, record.GetStateCode())
+	assert.Equal(t, jobInstance(), record.GetInstance())
+	assert.Equal(t, int64(100), record.GetCopiedSize())
+	finish(nil)
+
+	// another server sharing the backup storage reports the job by its record
+	standby := newJobStateBackupContext(chunkManager)
+	resp := standby.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, backuppb.JobStateCode_JOB_SUCCESS, resp.GetData().GetStateCode())
+	assert.Equal(t, int32(100), resp.GetData().GetProgress())
+
+	now := time.Now().Unix()
+	assert.NoError(t, b.writeJobState(&backuppb.JobInfo{
+		Id: "backup2", Type: metrics.BackupTaskLabel, BackupName: "b2", StartTime: now - 7200, UpdateTime: now - 3600, Instance: "gone:1",
+	}))
+	assert.NoError(t, b.writeJobState(&backuppb.JobInfo{
+		Id: "restore1", Type: metrics.RestoreTaskLabel, BackupName: "b1", StartTime: now + 1, UpdateTime: now, Instance: "other:1",
+	}))
+
+	// a running job not updated for a while is interrupted
+	resp = standby.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup2"})
+	assert.Equal(t, backuppb.JobStateCode_JOB_INTERRUPTED, resp.GetData().GetStateCode())
+	assert.Contains(t, resp.GetData().GetErrorMessage(), "gone:1")
+
+	listResp := standby.ListJobs(ctx, &backuppb.ListJobsRequest{})
+	assert.Equal(t, backuppb.ResponseCode_Success, listResp.GetCode())
+	ids := make([]string, 0)
+	for _, job := range listResp.GetData() {
+		ids = append(ids, job.GetId())
+	}
+	assert.Equal(t, []string{"restore1", "backup1", "backup2"}, ids)
+	listResp = standby.ListJobs(ctx, &backuppb.ListJobsRequest{Type: metrics.RestoreTaskLabel})
+	assert.Len(t, listResp.GetData(), 1)
+
+	// the job running in another server is canceled or resumed there
+	resp = standby.CancelJob(ctx, &backuppb.CancelJobRequest{JobId: "restore1"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	assert.Contains(t, resp.GetMsg(), "other:1")
+	resp = standby.ResumeJob(ctx, &backuppb.ResumeJobRequest{JobId: "restore1"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	resp = standby.ResumeJob(ctx, &backuppb.ResumeJobRequest{JobId: "backup1"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+	resp = standby.ResumeJob(ctx, &backuppb.ResumeJobRequest{JobId: "not_exist"})
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, resp.GetCode())
+
+	// the records are not read without job state
+	resp = newManifestBackupContext(chunkManager).GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup2"})
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, resp.GetCode())
+}
+
+func TestRestoreTaskOfJob(t *testing.T) {
+	b := newJobStateBackupContext(&memoryChunkManager{files: make(map[string][]byte)})
+	ctx := context.Background()
+	now := time.Now().Unix()
+	assert.NoError(t, b.writeJobState(&backuppb.JobInfo{
+		Id: "restore1", Type: metrics.RestoreTaskLabel, BackupName: "b1", StartTime: now - 7200, UpdateTime: now - 3600, Instance: "gone:1",
+	}))
+	task, err := b.restoreTaskOfJob(ctx, "restore1")
+	assert.NoError(t, err)
+	assert.Nil(t, task)
+
+	b.writeRestoreCheckpoint(ctx, "", "backup/b1", &backuppb.RestoreBackupTask{
+		Id:        "restore1",
+		StateCode: backuppb.RestoreTaskStateCode_EXECUTING,
+	})
+	task, err = b.restoreTaskOfJob(ctx, "restore1")
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.RestoreTaskStateCode_FAIL, task.GetStateCode())
+	assert.Contains(t, task.GetErrorMessage(), "gone:1")
+}
+
+func TestExpiredJobStates(t *testing.T) {
+	now := time.Unix(100000, 0)
+	jobs := []*backuppb.JobInfo{
+		{Id: "running", StateCode: backuppb.JobStateCode_JOB_RUNNING, StartTime: 1},
+		{Id: "old", StateCode: backuppb.JobStateCode_JOB_SUCCESS, EndTime: now.Unix() - 7200},
+		{Id: "recent", StateCode: backuppb.JobStateCode_JOB_FAIL, EndTime: now.Unix() - 60},
+		{Id: "interrupted", StateCode: backuppb.JobStateCode_JOB_INTERRUPTED, UpdateTime: now.Unix() - 7200},
+	}
+	expired := expiredJobStates(jobs, now, time.Hour)
+	assert.Len(t, expired, 2)
+	assert.Equal(t, "old", expired[0].GetId())
+	assert.Equal(t, "interrupted", expired[1].GetId())
+}
@@ -0,0 +1,317 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+// backupJob is a backup or restore task executing in the context, which can be canceled by the job api.
+// The id of the job is the id of the task.
+type backupJob struct {
+	mu   sync.Mutex
+	info *backuppb.JobInfo
+	// cancels the context of the task
+	cancel context.CancelFunc
+}
+
+// markCanceling turns a running job to JOB_CANCELING, so that it ends as JOB_CANCELED.
+// The current state is returned with false if the job is not running.
+func (j *backupJob) markCanceling() (backuppb.JobStateCode, bool) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+	if j.info.GetStateCode() != backuppb.JobStateCode_JOB_RUNNING {
+		return j.info.GetStateCode(), false
+	}
+	j.info.StateCode = backuppb.JobStateCode_JOB_CANCELING
+	return j.info.GetStateCode(), true
+}
+
+// startJob registers a job of the task, ctx of the task should be replaced by the returned one to be cancelable.
+// finish should be called when the task ends. bucketName and path are the backup storage restored from, empty if it is
+// the one of config. The job is recorded in backup storage if job state is enabled.
+func (b *BackupContext) startJob(ctx context.Context, id string, jobType string, backupName string, bucketName string, path string) (context.Context, func(err error)) {
+	ctx, cancel := context.WithCancel(ctx)
+	job := &backupJob{
+		info: &backuppb.JobInfo{
+			Id:         id,
+			Type:       jobType,
+			StateCode:  backuppb.JobStateCode_JOB_RUNNING,
+			StartTime:  time.Now().Unix(),
+			BackupName: backupName,
+			Instance:   jobInstance(),
+			BucketName: bucketName,
+			Path:       path,
+		},
+		cancel: cancel,
+	}
+	b.jobs.Store(id, job)
+	stopPersist := func() {}
+	if b.params.BackupCfg.JobStateEnable {
+		stopPersist = b.persistJob(job)
+	}
+	finish := func(err error) {
+		job.mu.Lock()
+		job.info.EndTime = time.Now().Unix()
+		switch {
+		case err == nil:
+			// finished before the cancellation takes effect
+			job.info.StateCode = backuppb.JobStateCode_JOB_SUCCESS
+		case job.info.GetStateCode() == backuppb.JobStateCode_JOB_CANCELING:
+			job.info.StateCode = backuppb.JobStateCode_JOB_CANCELED
+			job.info.ErrorMessage = err.Error()
+		default:
+			job.info.StateCode = backuppb.JobStateCode_JOB_FAIL
+			job.info.ErrorMessage = err.Error()
+		}
+		job.mu.Unlock()
+		cancel()
+		stopPersist()
+	}
+	return ctx, finish
+}
+
+// fillJobProgress fills the bytes copied by a backup or restored by a restore into the job
+func (b *BackupContext) fillJobProgress(info *backuppb.JobInfo) {
+	switch info.GetType() {
+	case metrics.BackupTaskLabel:
+		if value, ok := b.backupTasks.Load(info.GetId()); ok {
+			backup := value.(*backuppb.BackupInfo)
+			info.Progress = backup.GetProgress()
+			info.Size = backup.GetSize()
+			info.CopiedSize = backup.GetCopiedSize()
+		}
+	case metrics.RestoreTaskLabel:
+		if task, ok := b.restoreTasks[info.GetId()]; ok {
+			task = UpdateRestoreBackupTask(task)
+			info.Progress = task.GetProgress()
+			info.Size = task.GetToRestoreSize()
+			info.CopiedSize = task.GetRestoredSize()
+		}
+	}
+	if info.GetStateCode() == backuppb.JobStateCode_JOB_SUCCESS {
+		info.Progress = 100
+	}
+}
+
+func (b *BackupContext) GetJob(ctx context.Context, request *backuppb.GetJobRequest) *backuppb.JobResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	resp := &backuppb.JobResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	info, _, err := b.findJob(request.GetJobId())
+	if err != nil {
+		log.Error("fail to read job record", zap.String("jobId", request.GetJobId()), zap.Error(err))
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if info == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = fmt.Sprintf("job not exist: %s", request.GetJobId())
+		return resp
+	}
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	resp.Data = info
+	return resp
+}
+
+// localJobInfo returns the job running or ended in this server with its progress
+func (b *BackupContext) localJobInfo(job *backupJob) *backuppb.JobInfo {
+	job.mu.Lock()
+	info := proto.Clone(job.info).(*backuppb.JobInfo)
+	job.mu.Unlock()
+	b.fillJobProgress(info)
+	return info
+}
+
+// findJob returns the job of this server, or the record of the job in backup storage if job state is enabled,
+// like a job of another server. local is true if the job is of this server, the job is nil if not found.
+func (b *BackupContext) findJob(id string) (*backuppb.JobInfo, bool, error) {
+	if value, ok := b.jobs.Load(id); ok {
+		return b.localJobInfo(value.(*backupJob)), true, nil
+	}
+	if !b.params.BackupCfg.JobStateEnable {
+		return nil, false, nil
+	}
+	info, err := b.readJobState(id)
+	return info, false, err
+}
+
+// ListJobs lists the jobs of this server, and the jobs recorded in backup storage by the servers sharing it
+// if job state is enabled
+func (b *BackupContext) ListJobs(ctx context.Context, request *backuppb.ListJobsRequest) *backuppb.ListJobsResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	resp := &backuppb.ListJobsResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	jobs := make([]*backuppb.JobInfo, 0)
+	local := make(map[string]bool)
+	b.jobs.Range(func(key, value interface{}) bool {
+		info := b.localJobInfo(value.(*backupJob))
+		local[info.GetId()] = true
+		jobs = append(jobs, info)
+		return true
+	})
+	if b.params.BackupCfg.JobStateEnable {
+		records, err := b.listJobStates()
+		if err != nil {
+			log.Error("fail to list job records", zap.Error(err))
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+			return resp
+		}
+		for _, record := range records {
+			if !local[record.GetId()] {
+				jobs = append(jobs, record)
+			}
+		}
+	}
+
+	filtered := make([]*backuppb.JobInfo, 0, len(jobs))
+	for _, job := range jobs {
+		if request.GetType() == "" || job.GetType() == request.GetType() {
+			filtered = append(filtered, job)
+		}
+	}
+	sortJobs(filtered)
+
+	resp.Code = backuppb.ResponseCode_Success
+	resp.Msg = "success"
+	resp.Data = filtered
+	return resp
+}
+
+// CancelJob cancels the context of a running job, the job stops its workers and turns to JOB_CANCELED.
+// Bulkinsert tasks already submitted to milvus can't be stopped, they are not waited any more.
+func (b *BackupContext) CancelJob(ctx context.Context, request *backuppb.CancelJobRequest) *backuppb.JobResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive CancelJobRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("jobId", request.GetJobId()))
+	resp := &backuppb.JobResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	value, ok := b.jobs.Load(request.GetJobId())
+	if !ok {
+		info, _, err := b.findJob(request.GetJobId())
+		switch {
+		case err != nil:
+			resp.Code = backuppb.ResponseCode_Fail
+			resp.Msg = err.Error()
+		case info == nil:
+			resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+			resp.Msg = fmt.Sprintf("job not exist: %s", request.GetJobId())
+		case info.GetStateCode() == backuppb.JobStateCode_JOB_RUNNING:
+			// the context of the job is only in the server running it
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = fmt.Sprintf("job %s runs in server %s, cancel it there", request.GetJobId(), info.GetInstance())
+		default:
+			resp.Code = backuppb.ResponseCode_Parameter_Error
+			resp.Msg = fmt.Sprintf("job %s is not running, state: %s", request.GetJobId(), info.GetStateCode())
+		}
+		return resp
+	}
+	job := value.(*backupJob)
+	if state, ok := job.markCanceling(); !ok {
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("job %s is not running, state: %s", request.GetJobId(), state)
+		return resp
+	}
+	job.cancel()
+	log.Info("cancel job", zap.String("jobId", request.GetJobId()))
+
+	return b.GetJob(ctx, &backuppb.GetJobRequest{RequestId: request.GetRequestId(), JobId: request.GetJobId()})
+}
+
+// ResumeJob continues an interrupted, failed or canceled job from its checkpoint in this server, like a job of a server
+// which is gone. A backup is resumed by ResumeBackup as a new job, a restore is resumed by its task id with the
+// checkpoint in the backup restored from, without the options of the original request.
+func (b *BackupContext) ResumeJob(ctx context.Context, request *backuppb.ResumeJobRequest) *backuppb.JobResponse {
+	if request.GetRequestId() == "" {
+		request.RequestId = utils.UUID()
+	}
+	log.Info("receive ResumeJobRequest",
+		zap.String("requestId", request.GetRequestId()),
+		zap.String("jobId", request.GetJobId()))
+	resp := &backuppb.JobResponse{
+		RequestId: request.GetRequestId(),
+	}
+
+	info, _, err := b.findJob(request.GetJobId())
+	if err != nil {
+		resp.Code = backuppb.ResponseCode_Fail
+		resp.Msg = err.Error()
+		return resp
+	}
+	if info == nil {
+		resp.Code = backuppb.ResponseCode_Request_Object_Not_Found
+		resp.Msg = fmt.Sprintf("job not exist: %s", request.GetJobId())
+		return resp
+	}
+	switch info.GetStateCode() {
+	case backuppb.JobStateCode_JOB_INTERRUPTED, backuppb.JobStateCode_JOB_FAIL, backuppb.JobStateCode_JOB_CANCELED:
+	default:
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("job %s can't be resumed, state: %s", request.GetJobId(), info.GetStateCode())
+		return resp
+	}
+
+	var jobId string
+	switch info.GetType() {
+	case metrics.BackupTaskLabel:
+		backupResp := b.ResumeBackup(ctx, &backuppb.ResumeBackupRequest{
+			RequestId:  request.GetRequestId(),
+			BackupName: info.GetBackupName(),
+			Async:      true,
+		})
+		if backupResp.GetCode() != backuppb.ResponseCode_Success {
+			resp.Code = backupResp.GetCode()
+			resp.Msg = backupResp.GetMsg()
+			return resp
+		}
+		jobId = backupResp.GetJobId()
+	case metrics.RestoreTaskLabel:
+		restoreResp := b.RestoreBackup(ctx, &backuppb.RestoreBackupRequest{
+			RequestId:    request.GetRequestId(),
+			BackupName:   info.GetBackupName(),
+			BucketName:   info.GetBucketName(),
+			Path:         info.GetPath(),
+			ResumeTaskId: info.GetId(),
+			Async:        true,
+		})
+		if restoreResp.GetCode() != backuppb.ResponseCode_Success {
+			resp.Code = restoreResp.GetCode()
+			resp.Msg = restoreResp.GetMsg()
+			return resp
+		}
+		jobId = restoreResp.GetJobId()
+	default:
+		resp.Code = backuppb.ResponseCode_Parameter_Error
+		resp.Msg = fmt.Sprintf("unknown type of job %s: %s", request.GetJobId(), info.GetType())
+		return resp
+	}
+	log.Info("resume job", zap.String("jobId", request.GetJobId()), zap.String("resumedJobId", jobId))
+
+	return b.GetJob(ctx, &backuppb.GetJobRequest{RequestId: request.GetRequestId(), JobId: jobId})
+}
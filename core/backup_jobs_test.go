@@ -0,0 +1,59 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+func TestBackupJobs(t *testing.T) {
+	b := &BackupContext{restoreTasks: make(map[string]*backuppb.RestoreBackupTask)}
+	ctx := context.Background()
+
+	b.backupTasks.Store("backup1", &backuppb.BackupInfo{
+		Id:         "backup1",
+		Size:       400,
+		CopiedSize: 100,
+		Progress:   25,
+	})
+	jobCtx, finish := b.startJob(ctx, "backup1", metrics.BackupTaskLabel, "b1", "", "")
+	resp := b.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, backuppb.JobStateCode_JOB_RUNNING, resp.GetData().GetStateCode())
+	assert.Equal(t, int32(25), resp.GetData().GetProgress())
+	assert.Equal(t, int64(400), resp.GetData().GetSize())
+	assert.Equal(t, int64(100), resp.GetData().GetCopiedSize())
+	assert.Equal(t, "b1", resp.GetData().GetBackupName())
+
+	resp = b.CancelJob(ctx, &backuppb.CancelJobRequest{JobId: "backup1"})
+	assert.Equal(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, backuppb.JobStateCode_JOB_CANCELING, resp.GetData().GetStateCode())
+	assert.ErrorIs(t, jobCtx.Err(), context.Canceled)
+	finish(jobCtx.Err())
+	resp = b.GetJob(ctx, &backuppb.GetJobRequest{JobId: "backup1"})
+	assert.Equal(t, backuppb.JobStateCode_JOB_CANCELED, resp.GetData().GetStateCode())
+	assert.Equal(t, "context canceled", resp.GetData().GetErrorMessage())
+
+	// finished jobs can't be canceled
+	resp = b.CancelJob(ctx, &backuppb.CancelJobRequest{JobId: "backup1"})
+	assert.Equal(t, backuppb.ResponseCode_Parameter_Error, resp.GetCode())
+
+	b.restoreTasks["restore1"] = &backuppb.RestoreBackupTask{
+		Id:                     "restore1",
+		ToRestoreSize:          100,
+		CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{{RestoredSize: 40}},
+	}
+	_, finish = b.startJob(ctx, "restore1", metrics.RestoreTaskLabel, "b1", "", "")
+	assert.Equal(t, int32(40), b.GetJob(ctx, &backuppb.GetJobRequest{JobId: "restore1"}).GetData().GetProgress())
+	finish(errors.New("bulkinsert fail"))
+	resp = b.GetJob(ctx, &backuppb.GetJobRequest{JobId: "restore1"})
+	assert.Equal(t, backuppb.JobStateCode_JOB_FAIL, resp.GetData().GetStateCode())
+
+	resp = b.GetJob(ctx, &backuppb.GetJobRequest{JobId: "not_exist"})
+	assert.Equal(t, backuppb.ResponseCode_Request_Object_Not_Found, resp.GetCode())
+}
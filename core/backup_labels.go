@@ -0,0 +1,143 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+// label selector operators, a requirement without value checks whether the label exists or not
+const (
+	labelEquals    = "="
+	labelNotEquals = "!="
+	labelExists    = "exists"
+	labelNotExists = "!exists"
+)
+
+// labelRequirement is a requirement of a label selector, like env=prod
+type labelRequirement struct {
+	key      string
+	operator string
+	value    string
+}
+
+// labelSelector selects backups whose labels match all its requirements, an empty selector matches all backups
+type labelSelector []labelRequirement
+
+// parseLabelSelector parses a comma separated selector like env=prod,app!=search,tier,!temp.
+// key=value and key==value match the backups with the label, key!=value the ones without it or with another value,
+// key the ones with the label of any value, and !key the ones without the label.
+func parseLabelSelector(selector string) (labelSelector, error) {
+	requirements := make(labelSelector, 0)
+	for _, item := range strings.Split(selector, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+		var requirement labelRequirement
+		switch {
+		case strings.Contains(item, "!="):
+			parts := strings.SplitN(item, "!=", 2)
+			requirement = labelRequirement{key: parts[0], operator: labelNotEquals, value: parts[1]}
+		case strings.Contains(item, "=="):
+			parts := strings.SplitN(item, "==", 2)
+			requirement = labelRequirement{key: parts[0], operator: labelEquals, value: parts[1]}
+		case strings.Contains(item, "="):
+			parts := strings.SplitN(item, "=", 2)
+			requirement = labelRequirement{key: parts[0], operator: labelEquals, value: parts[1]}
+		case strings.HasPrefix(item, "!"):
+			requirement = labelRequirement{key: item[1:], operator: labelNotExists}
+		default:
+			requirement = labelRequirement{key: item, operator: labelExists}
+		}
+		requirement.key = strings.TrimSpace(requirement.key)
+		requirement.value = strings.TrimSpace(requirement.value)
+		if err := validateLabel(requirement.key, requirement.value); err != nil {
+			return nil, fmt.Errorf("invalid label selector %s: %w", item, err)
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements, nil
+}
+
+// matches returns whether the labels match all requirements of the selector
+func (s labelSelector) matches(labels map[string]string) bool {
+	for _, requirement := range s {
+		value, ok := labels[requirement.key]
+		switch requirement.operator {
+		case labelEquals:
+			if !ok || value != requirement.value {
+				return false
+			}
+		case labelNotEquals:
+			if ok && value == requirement.value {
+				return false
+			}
+		case labelExists:
+			if !ok {
+				return false
+			}
+		case labelNotExists:
+			if ok {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// matchesBackup returns whether the labels of backup match the selector
+func (s labelSelector) matchesBackup(backup *backuppb.BackupInfo) bool {
+	return s.matches(backup.GetLabels())
+}
+
+// validateBackupLabels validates the labels attached to a backup at creation
+func validateBackupLabels(labels map[string]string) error {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if err := validateLabel(key, labels[key]); err != nil {
+			return fmt.Errorf("invalid label %s=%s: %w", key, labels[key], err)
+		}
+	}
+	return nil
+}
+
+// validateLabel checks that the key is not empty, and both key and value only contain letters, numbers, '-', '_',
+// '.' and '/', at most utils.MaxNameLength characters
+func validateLabel(key string, value string) error {
+	if key == "" {
+		return fmt.Errorf("label key should not be empty")
+	}
+	for _, s := range []string{key, value} {
+		if len(s) > utils.MaxNameLength {
+			return fmt.Errorf("label %s is longer than %d characters", s, utils.MaxNameLength)
+		}
+		for _, c := range s {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || strings.ContainsRune("-_./", c)) {
+				return fmt.Errorf("label %s can only contain letters, numbers, '-', '_', '.' and '/'", s)
+			}
+		}
+	}
+	return nil
+}
+
+// latestBackupMatchingLabels returns the latest successful backup whose labels match the selector, nil if none
+func latestBackupMatchingLabels(backups []*backuppb.BackupInfo, selector labelSelector) *backuppb.BackupInfo {
+	var latest *backuppb.BackupInfo
+	for _, backup := range backups {
+		if backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS || !selector.matchesBackup(backup) {
+			continue
+		}
+		if latest == nil || backupTime(backup).After(backupTime(latest)) {
+			latest = backup
+		}
+	}
+	return latest
+}
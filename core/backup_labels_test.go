@@ -0,0 +1,68 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestLabelSelector(t *testing.T) {
+	labels := map[string]string{"env": "prod", "app": "search"}
+	cases := []struct {
+		selector string
+		matched  bool
+	}{
+		{"", true},
+		{"env=prod", true},
+		{"env==prod, app=search", true},
+		{"env=dev", false},
+		{"env!=dev", true},
+		{"app!=search", false},
+		{"tier!=gold", true},
+		{"app", true},
+		{"tier", false},
+		{"!tier", true},
+		{"!app", false},
+	}
+	for _, c := range cases {
+		selector, err := parseLabelSelector(c.selector)
+		assert.NoError(t, err)
+		assert.Equal(t, c.matched, selector.matches(labels), c.selector)
+	}
+
+	for _, selector := range []string{"=prod", "env=pr od", "!"} {
+		_, err := parseLabelSelector(selector)
+		assert.Error(t, err, selector)
+	}
+
+	assert.NoError(t, validateBackupLabels(map[string]string{"env": "prod", "team/owner": "search-1.0", "empty": ""}))
+	assert.Error(t, validateBackupLabels(map[string]string{"": "prod"}))
+	assert.Error(t, validateBackupLabels(map[string]string{"env": "prod,dev"}))
+}
+
+func TestLatestBackupMatchingLabels(t *testing.T) {
+	now := time.Now()
+	newBackup := func(name string, at time.Time, env string, state backuppb.BackupTaskStateCode) *backuppb.BackupInfo {
+		return &backuppb.BackupInfo{
+			Name:            name,
+			StateCode:       state,
+			BackupTimestamp: uint64(at.UnixMilli()),
+			Labels:          map[string]string{"env": env},
+		}
+	}
+	backups := []*backuppb.BackupInfo{
+		newBackup("prod_1", now.Add(-2*time.Hour), "prod", backuppb.BackupTaskStateCode_BACKUP_SUCCESS),
+		newBackup("prod_2", now.Add(-time.Hour), "prod", backuppb.BackupTaskStateCode_BACKUP_SUCCESS),
+		newBackup("prod_3", now, "prod", backuppb.BackupTaskStateCode_BACKUP_FAIL),
+		newBackup("dev_1", now, "dev", backuppb.BackupTaskStateCode_BACKUP_SUCCESS),
+	}
+	selector, err := parseLabelSelector("env=prod")
+	assert.NoError(t, err)
+	assert.Equal(t, "prod_2", latestBackupMatchingLabels(backups, selector).GetName())
+	selector, err = parseLabelSelector("env=test")
+	assert.NoError(t, err)
+	assert.Nil(t, latestBackupMatchingLabels(backups, selector))
+}
@@ -0,0 +1,93 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+// nextCopyParallelism halves the parallelism if the probe latency exceeds threshold,
+// otherwise raises it by an eighth of max, within [min, max]
+func nextCopyParallelism(current, min, max int, latency, threshold time.Duration) int {
+	next := current
+	if latency > threshold {
+		next = current / 2
+	} else {
+		step := max / 8
+		if step < 1 {
+			step = 1
+		}
+		next = current + step
+	}
+	if next > max {
+		next = max
+	}
+	if next < min {
+		next = min
+	}
+	return next
+}
+
+// startLoadThrottle limits the copy parallelism of backup by the load of milvus if backup.loadThrottle is enabled,
+// the returned limiter is nil if not. The load is measured by the latency of listing collections, which is served
+// by proxy and rootcoord of milvus. The throttle stops when the returned function is called.
+func (b *BackupContext) startLoadThrottle(ctx context.Context, parallelism int) (*common.AdaptiveLimiter, func()) {
+	cfg := b.params.BackupCfg
+	if !cfg.LoadThrottleEnable {
+		return nil, func() {}
+	}
+	min := cfg.LoadThrottleMinParallelism
+	if min > parallelism {
+		min = parallelism
+	}
+	limiter := common.NewAdaptiveLimiter(parallelism)
+	metrics.CopyParallelism.Set(float64(parallelism))
+
+	throttleCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(cfg.LoadThrottleInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-throttleCtx.Done():
+				return
+			case <-ticker.C:
+			}
+			probeCtx, probeCancel := context.WithTimeout(throttleCtx, cfg.LoadThrottleInterval)
+			start := time.Now()
+			_, err := b.getMilvusClient().ListCollections(probeCtx, "")
+			latency := time.Since(start)
+			probeCancel()
+			if throttleCtx.Err() != nil {
+				return
+			}
+			if err != nil {
+				// milvus too busy to answer is regarded as heavy load
+				log.Warn("fail to probe the load of milvus", zap.Error(err))
+				latency = cfg.LoadThrottleLatency + 1
+			}
+			current := limiter.Limit()
+			next := nextCopyParallelism(current, min, parallelism, latency, cfg.LoadThrottleLatency)
+			if next != current {
+				log.Info("adjust copy parallelism by the load of milvus",
+					zap.Duration("latency", latency),
+					zap.Int("from", current),
+					zap.Int("to", next))
+				limiter.SetLimit(next)
+				metrics.CopyParallelism.Set(float64(next))
+			}
+		}
+	}()
+	return limiter, func() {
+		cancel()
+		<-done
+		metrics.CopyParallelism.Set(0)
+	}
+}
@@ -0,0 +1,18 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextCopyParallelism(t *testing.T) {
+	threshold := 500 * time.Millisecond
+	assert.Equal(t, 64, nextCopyParallelism(128, 1, 128, time.Second, threshold))
+	assert.Equal(t, 4, nextCopyParallelism(6, 4, 128, time.Second, threshold))
+	assert.Equal(t, 80, nextCopyParallelism(64, 1, 128, 10*time.Millisecond, threshold))
+	assert.Equal(t, 128, nextCopyParallelism(120, 1, 128, 10*time.Millisecond, threshold))
+	assert.Equal(t, 2, nextCopyParallelism(1, 1, 4, 10*time.Millisecond, threshold))
+	assert.Equal(t, 1, nextCopyParallelism(1, 1, 4, time.Second, threshold))
+}
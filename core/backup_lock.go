@@ -0,0 +1,275 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// backupLockInfo is the content of a lock object in backup storage
+type backupLockInfo struct {
+	// owner is unique for every acquisition of the lock
+	Owner       string `json:"owner"`
+	Host        string `json:"host"`
+	Pid         int    `json:"pid"`
+	BackupName  string `json:"backup_name"`
+	AcquireTime int64  `json:"acquire_time"`
+	// unix seconds the lock is stale after if it is not renewed, like the process holding it crashed
+	ExpireTime int64 `json:"expire_time"`
+}
+
+func (info *backupLockInfo) String() string {
+	return fmt.Sprintf("backup %s of host %s pid %d until %s", info.BackupName, info.Host, info.Pid,
+		time.Unix(info.ExpireTime, 0).Format(time.RFC3339))
+}
+
+// storageLock is a lock object in backup storage held by this process, renewed in background until released.
+// Storage has no conditional writes, a lock written at the same time by another process is found when the lock is
+// read back or renewed, then the backups holding it are canceled.
+type storageLock struct {
+	path string
+	info backupLockInfo
+	// cancels the backups holding the lock if it is taken by another process, by holder id
+	mu     sync.Mutex
+	holder map[string]context.CancelFunc
+	// the lock is taken by another process
+	taken  bool
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// clusterLockName is the name of the lock of the cluster in backup root
+func (b *BackupContext) clusterLockName() string {
+	return "cluster_" + b.params.BackupCfg.ClusterName
+}
+
+// acquireBackupLocks acquires the locks of the cluster and the backup name before the backup executes, so that another
+// process can't run a backup of the same cluster or name at the same time. Backups executing in this process share the
+// lock of the cluster. The returned ctx is canceled if a lock is taken by another process during the backup, and the
+// locks are released by the returned func. A lock held by another process is taken over if it is stale or force is set.
+func (b *BackupContext) acquireBackupLocks(ctx context.Context, backupName string, force bool) (context.Context, func(), error) {
+	if !b.params.BackupCfg.LockEnable {
+		return ctx, func() {}, nil
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	// the name of a backup failing to lock may be held by another backup of this process
+	holderId := utils.UUID()
+
+	b.lockMu.Lock()
+	if b.clusterLock != nil && b.clusterLock.isTaken() {
+		b.clusterLock = nil
+	}
+	if b.clusterLock == nil {
+		lock, err := b.lockStorage(BackupLockPath(b.backupRootPath, b.clusterLockName()), backupName, force)
+		if err != nil {
+			b.lockMu.Unlock()
+			cancel()
+			return nil, nil, err
+		}
+		b.clusterLock = lock
+	}
+	clusterLock := b.clusterLock
+	clusterLock.addHolder(holderId, cancel)
+	b.lockMu.Unlock()
+
+	nameLock, err := b.lockStorage(BackupLockPath(b.backupRootPath, "backup_"+backupName), backupName, force)
+	if err != nil {
+		b.releaseClusterLock(clusterLock, holderId)
+		cancel()
+		return nil, nil, err
+	}
+	nameLock.addHolder(holderId, cancel)
+
+	return ctx, func() {
+		b.unlockStorage(nameLock)
+		b.releaseClusterLock(clusterLock, holderId)
+		cancel()
+	}, nil
+}
+
+// releaseClusterLock releases the lock of the cluster once no backup of this process holds it
+func (b *BackupContext) releaseClusterLock(lock *storageLock, holderId string) {
+	b.lockMu.Lock()
+	defer b.lockMu.Unlock()
+	if lock.removeHolder(holderId) > 0 {
+		return
+	}
+	b.unlockStorage(lock)
+	if b.clusterLock == lock {
+		b.clusterLock = nil
+	}
+}
+
+func (l *storageLock) addHolder(holderId string, cancel context.CancelFunc) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.holder[holderId] = cancel
+}
+
+// removeHolder removes the backup from the holders of the lock, and returns the number of the remaining holders
+func (l *storageLock) removeHolder(holderId string) int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	delete(l.holder, holderId)
+	return len(l.holder)
+}
+
+func (l *storageLock) isTaken() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.taken
+}
+
+// lost cancels all backups holding the lock taken by another process
+func (l *storageLock) lost() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.taken = true
+	for _, cancel := range l.holder {
+		cancel()
+	}
+}
+
+// lockContext is the context to read and write lock objects, they are written without the object lock and the customer
+// key of backups, so that they can be deleted and read by other processes
+func (b *BackupContext) lockContext() context.Context {
+	return storage.WithoutCustomerKey(b.ctx)
+}
+
+// readStorageLock reads the lock object at path, nil if it doesn't exist
+func (b *BackupContext) readStorageLock(path string) (*backupLockInfo, error) {
+	ctx := b.lockContext()
+	exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, path)
+	if err != nil || !exist {
+		return nil, err
+	}
+	data, err := b.getBackupStorageClient().Read(ctx, b.backupBucketName, path)
+	if err != nil {
+		return nil, err
+	}
+	info := &backupLockInfo{}
+	if err := json.Unmarshal(data, info); err != nil {
+		return nil, fmt.Errorf("invalid backup lock %s: %w", path, err)
+	}
+	return info, nil
+}
+
+func (b *BackupContext) writeStorageLock(path string, info backupLockInfo) error {
+	data, err := json.Marshal(info)
+	if err != nil {
+		return err
+	}
+	return b.getBackupStorageClient().Write(b.lockContext(), b.backupBucketName, path, data)
+}
+
+// lockStorage writes the lock object at path for the backup and renews it in background
+func (b *BackupContext) lockStorage(path string, backupName string, force bool) (*storageLock, error) {
+	held, err := b.readStorageLock(path)
+	if err != nil && !force {
+		return nil, fmt.Errorf("fail to read backup lock %s: %w", path, err)
+	}
+	now := time.Now()
+	if held != nil {
+		switch {
+		case force:
+			log.Warn("force to take over backup lock", zap.String("path", path), zap.Stringer("heldBy", held))
+		case held.ExpireTime <= now.Unix():
+			log.Warn("take over stale backup lock", zap.String("path", path), zap.Stringer("heldBy", held))
+		default:
+			return nil, fmt.Errorf("backup lock %s is held by %s, use force_unlock if the process holding it is gone", path, held)
+		}
+	}
+
+	host, _ := os.Hostname()
+	info := backupLockInfo{
+		Owner:       utils.UUID(),
+		Host:        host,
+		Pid:         os.Getpid(),
+		BackupName:  backupName,
+		AcquireTime: now.Unix(),
+		ExpireTime:  now.Add(b.params.BackupCfg.LockTTL).Unix(),
+	}
+	if err := b.writeStorageLock(path, info); err != nil {
+		return nil, fmt.Errorf("fail to write backup lock %s: %w", path, err)
+	}
+	// another process may write the lock at the same time, the last write wins
+	current, err := b.readStorageLock(path)
+	if err != nil {
+		return nil, fmt.Errorf("fail to read backup lock %s: %w", path, err)
+	}
+	if current == nil || current.Owner != info.Owner {
+		return nil, fmt.Errorf("backup lock %s is acquired by another process at the same time", path)
+	}
+	log.Info("acquire backup lock", zap.String("path", path), zap.String("backupName", backupName))
+
+	renewCtx, cancel := context.WithCancel(b.ctx)
+	lock := &storageLock{
+		path:   path,
+		info:   info,
+		holder: make(map[string]context.CancelFunc),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	go b.renewStorageLock(renewCtx, lock)
+	return lock, nil
+}
+
+// renewStorageLock extends the expire time of the lock until renewCtx is canceled, the backups holding the lock are
+// canceled if it is taken by another process
+func (b *BackupContext) renewStorageLock(renewCtx context.Context, lock *storageLock) {
+	defer close(lock.done)
+	// renew well before the lock is stale, a renew failed by storage is retried at the next tick
+	ticker := time.NewTicker(b.params.BackupCfg.LockTTL / 3)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-renewCtx.Done():
+			return
+		case <-ticker.C:
+			current, err := b.readStorageLock(lock.path)
+			if err != nil {
+				log.Warn("fail to read backup lock to renew", zap.String("path", lock.path), zap.Error(err))
+				continue
+			}
+			if current == nil || current.Owner != lock.info.Owner {
+				log.Error("backup lock is taken by another process, cancel the backups holding it",
+					zap.String("path", lock.path),
+					zap.Any("heldBy", current))
+				lock.lost()
+				return
+			}
+			lock.info.ExpireTime = time.Now().Add(b.params.BackupCfg.LockTTL).Unix()
+			if err := b.writeStorageLock(lock.path, lock.info); err != nil {
+				log.Warn("fail to renew backup lock", zap.String("path", lock.path), zap.Error(err))
+			}
+		}
+	}
+}
+
+// unlockStorage stops renewing the lock and removes it if it is still held by this process
+func (b *BackupContext) unlockStorage(lock *storageLock) {
+	lock.cancel()
+	<-lock.done
+	current, err := b.readStorageLock(lock.path)
+	if err != nil {
+		log.Warn("fail to read backup lock to release, it is stale after ttl", zap.String("path", lock.path), zap.Error(err))
+		return
+	}
+	if current == nil || current.Owner != lock.info.Owner {
+		return
+	}
+	if err := b.getBackupStorageClient().Remove(b.lockContext(), b.backupBucketName, lock.path); err != nil {
+		log.Warn("fail to release backup lock, it is stale after ttl", zap.String("path", lock.path), zap.Error(err))
+		return
+	}
+	log.Info("release backup lock", zap.String("path", lock.path))
+}
@@ -0,0 +1,154 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func newLockBackupContext(chunkManager *memoryChunkManager) *BackupContext {
+	b := newManifestBackupContext(chunkManager)
+	b.params.BackupCfg.LockEnable = true
+	b.params.BackupCfg.LockTTL = time.Minute
+	b.params.BackupCfg.ClusterName = "prod"
+	return b
+}
+
+// lockedChunkManager guards the files of memoryChunkManager used by the renew of locks in background
+type lockedChunkManager struct {
+	*memoryChunkManager
+	mu sync.Mutex
+}
+
+func (m *lockedChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.memoryChunkManager.Exist(ctx, bucketName, filePath)
+}
+
+func (m *lockedChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.memoryChunkManager.Write(ctx, bucketName, filePath, content)
+}
+
+func (m *lockedChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.memoryChunkManager.Read(ctx, bucketName, filePath)
+}
+
+func (m *lockedChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.memoryChunkManager.Remove(ctx, bucketName, filePath)
+}
+
+func TestAcquireBackupLocks(t *testing.T) {
+	chunkManager := &memoryChunkManager{files: make(map[string][]byte)}
+	b := newLockBackupContext(chunkManager)
+	other := newLockBackupContext(chunkManager)
+	ctx := context.Background()
+	clusterLockPath := BackupLockPath("backup", "cluster_prod")
+
+	_, unlock1, err := b.acquireBackupLocks(ctx, "daily_1", false)
+	assert.NoError(t, err)
+	assert.Contains(t, chunkManager.files, clusterLockPath)
+	assert.Contains(t, chunkManager.files, BackupLockPath("backup", "backup_daily_1"))
+
+	// backups of the same process share the lock of the cluster, but not the lock of the name
+	_, unlock2, err := b.acquireBackupLocks(ctx, "daily_2", false)
+	assert.NoError(t, err)
+	_, _, err = b.acquireBackupLocks(ctx, "daily_2", false)
+	assert.Error(t, err)
+
+	// another process can't backup the cluster
+	_, _, err = other.acquireBackupLocks(ctx, "daily_3", false)
+	assert.Error(t, err)
+
+	unlock1()
+	assert.Contains(t, chunkManager.files, clusterLockPath)
+	assert.NotContains(t, chunkManager.files, BackupLockPath("backup", "backup_daily_1"))
+	unlock2()
+	assert.Empty(t, chunkManager.files)
+
+	_, unlock3, err := other.acquireBackupLocks(ctx, "daily_3", false)
+	assert.NoError(t, err)
+	unlock3()
+
+	// locking is disabled
+	b.params.BackupCfg.LockEnable = false
+	_, unlock, err := b.acquireBackupLocks(ctx, "daily_4", false)
+	assert.NoError(t, err)
+	assert.Empty(t, chunkManager.files)
+	unlock()
+}
+
+func TestStaleBackupLock(t *testing.T) {
+	chunkManager := &memoryChunkManager{files: make(map[string][]byte)}
+	b := newLockBackupContext(chunkManager)
+	ctx := context.Background()
+	lockPath := BackupLockPath("backup", "cluster_prod")
+
+	writeLock := func(expireTime time.Time) {
+		data, err := json.Marshal(backupLockInfo{Owner: "crashed", Host: "host1", BackupName: "daily_0", ExpireTime: expireTime.Unix()})
+		assert.NoError(t, err)
+		chunkManager.files[lockPath] = data
+	}
+
+	writeLock(time.Now().Add(time.Minute))
+	_, _, err := b.acquireBackupLocks(ctx, "daily_1", false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "host1")
+
+	// taken over by force
+	_, unlock, err := b.acquireBackupLocks(ctx, "daily_1", true)
+	assert.NoError(t, err)
+	unlock()
+
+	// taken over if stale
+	writeLock(time.Now().Add(-time.Second))
+	_, unlock, err = b.acquireBackupLocks(ctx, "daily_1", false)
+	assert.NoError(t, err)
+	unlock()
+	assert.Empty(t, chunkManager.files)
+}
+
+func TestRenewBackupLock(t *testing.T) {
+	chunkManager := &lockedChunkManager{memoryChunkManager: &memoryChunkManager{files: make(map[string][]byte)}}
+	b := newLockBackupContext(chunkManager.memoryChunkManager)
+	var storageClient storage.ChunkManager = chunkManager
+	b.storageClient = &storageClient
+	b.params.BackupCfg.LockTTL = 30 * time.Millisecond
+	lockPath := BackupLockPath("backup", "backup_daily_1")
+	readLock := func() *backupLockInfo {
+		info, err := b.readStorageLock(lockPath)
+		assert.NoError(t, err)
+		return info
+	}
+
+	ctx, unlock, err := b.acquireBackupLocks(context.Background(), "daily_1", false)
+	assert.NoError(t, err)
+	acquired := readLock().ExpireTime
+
+	// renewed in background
+	assert.Eventually(t, func() bool { return readLock().ExpireTime > acquired }, 5*time.Second, 10*time.Millisecond)
+	assert.NoError(t, ctx.Err())
+
+	// the backup is canceled once the lock is taken by another process
+	assert.NoError(t, b.writeStorageLock(lockPath, backupLockInfo{Owner: "other", ExpireTime: time.Now().Add(time.Minute).Unix()}))
+	select {
+	case <-ctx.Done():
+	case <-time.After(5 * time.Second):
+		t.Fatal("backup is not canceled")
+	}
+	unlock()
+	// the lock of the other process is kept
+	assert.Equal(t, "other", readLock().Owner)
+}
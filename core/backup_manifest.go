@@ -0,0 +1,160 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// buildBackupManifest lists the binlog files of backup with their sizes and checksums, sorted by path
+func (b *BackupContext) buildBackupManifest(backupInfo *backuppb.BackupInfo) *backuppb.BackupManifest {
+	manifest := &backuppb.BackupManifest{
+		BackupName: backupInfo.GetName(),
+		CreateTime: time.Now().Unix(),
+	}
+	for _, collection := range backupInfo.GetCollectionBackups() {
+		// the collections failed in a partial backup are not restored
+		if isFailedCollection(collection) {
+			continue
+		}
+		for _, partition := range collection.GetPartitionBackups() {
+			for _, segment := range partition.GetSegmentBackups() {
+				// binlogs of an incremental backup may be stored in its base backups
+				backupName := backupInfo.GetName()
+				if segment.GetRefBackupName() != "" {
+					backupName = segment.GetRefBackupName()
+				}
+				for _, fieldBinlog := range append(append([]*backuppb.FieldBinlog{}, segment.GetBinlogs()...), segment.GetDeltalogs()...) {
+					for _, binlog := range fieldBinlog.GetBinlogs() {
+						filePath := b.binlogBackupPath(backupName, collection, segment, binlog.GetLogPath())
+						if binlog.GetObjectName() != "" {
+							filePath = DedupObjectPath(b.backupRootPath, binlog.GetObjectName())
+						}
+						size := binlog.GetBackupSize()
+						if size == 0 && segment.GetCompression() == utils.CompressionNone && !segment.GetEncrypted() {
+							// copied by storage as it is
+							size = binlog.GetLogSize()
+						}
+						manifest.Files = append(manifest.Files, &backuppb.ManifestFile{
+							Path:         strings.TrimPrefix(filePath, b.backupRootPath+SEPERATOR),
+							Size:         size,
+							Crc32C:       binlog.GetCrc32C(),
+							SourcePath:   binlog.GetLogPath(),
+							CollectionId: segment.GetCollectionId(),
+							PartitionId:  segment.GetPartitionId(),
+							SegmentId:    segment.GetSegmentId(),
+							GroupId:      segment.GetGroupId(),
+						})
+						manifest.Size += size
+					}
+				}
+			}
+		}
+	}
+	sort.Slice(manifest.Files, func(i, j int) bool { return manifest.Files[i].GetPath() < manifest.Files[j].GetPath() })
+	return manifest
+}
+
+// writeBackupManifest writes the manifest of a successful backup into its meta dir
+func (b *BackupContext) writeBackupManifest(ctx context.Context, backupInfo *backuppb.BackupInfo) error {
+	manifest := b.buildBackupManifest(backupInfo)
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return err
+	}
+	// like meta, the manifest is read without the customer key of the backup
+	ctx = storage.WithoutCustomerKey(ctx)
+	if err := b.writeBackupFile(ctx, ManifestPath(b.backupRootPath, backupInfo.GetName()), data); err != nil {
+		return err
+	}
+	log.Info("write backup manifest",
+		zap.String("backupName", backupInfo.GetName()),
+		zap.Int("files", len(manifest.GetFiles())),
+		zap.Int64("size", manifest.GetSize()))
+	return nil
+}
+
+// readBackupManifest reads the manifest of the backup at backupPath, nil if the backup has no manifest,
+// like the backups created before manifests are written
+func (b *BackupContext) readBackupManifest(ctx context.Context, bucketName string, backupPath string) (*backuppb.BackupManifest, error) {
+	manifestPath := backupPath + SEPERATOR + META_PREFIX + SEPERATOR + MANIFEST_FILE
+	exist, err := b.getBackupStorageClient().Exist(ctx, bucketName, manifestPath)
+	if err != nil || !exist {
+		return nil, err
+	}
+	data, err := b.readBackupFile(storage.WithoutCustomerKey(ctx), bucketName, manifestPath)
+	if err != nil {
+		return nil, err
+	}
+	manifest := &backuppb.BackupManifest{}
+	if err := json.Unmarshal(data, manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+// manifestCheckResult is the result of checking the files of a manifest in backup storage
+type manifestCheckResult struct {
+	mu             sync.Mutex
+	checkedFiles   int64
+	checksumFiles  int64
+	missingFiles   []string
+	corruptedFiles []string
+}
+
+// checkManifestFiles checks the files in manifest of the collections accepted by filter, all if filter is nil,
+// against the backup storage under backupRootPath. Files are checked by their checksum if checksum is true,
+// otherwise only by size.
+func (b *BackupContext) checkManifestFiles(ctx context.Context, bucketName string, backupRootPath string, manifest *backuppb.BackupManifest, filter func(collectionId int64) bool, checksum bool) (*manifestCheckResult, error) {
+	result := &manifestCheckResult{}
+	jobIds := make([]int64, 0)
+	for _, file := range manifest.GetFiles() {
+		if filter != nil && !filter(file.GetCollectionId()) {
+			continue
+		}
+		filePath := backupRootPath + SEPERATOR + file.GetPath()
+		binlog := &backuppb.Binlog{BackupSize: file.GetSize()}
+		if checksum {
+			binlog.Crc32C = file.GetCrc32C()
+		}
+		job := func(ctx context.Context) error {
+			missing, corrupted, checked, err := b.verifyBinlogFile(ctx, bucketName, &backuppb.SegmentBackupInfo{}, binlog, filePath)
+			if err != nil {
+				return err
+			}
+			result.mu.Lock()
+			defer result.mu.Unlock()
+			result.checkedFiles++
+			if checked {
+				result.checksumFiles++
+			}
+			if missing {
+				result.missingFiles = append(result.missingFiles, filePath)
+			}
+			if corrupted {
+				result.corruptedFiles = append(result.corruptedFiles, filePath)
+			}
+			return nil
+		}
+		jobId := b.getCopyDataWorkerPool().SubmitWithId(common.WithRequest(ctx, job))
+		jobIds = append(jobIds, jobId)
+	}
+	defer b.getCopyDataWorkerPool().ForgetJobs(jobIds)
+	if err := b.getCopyDataWorkerPool().WaitJobs(jobIds); err != nil {
+		return nil, err
+	}
+	sort.Strings(result.missingFiles)
+	sort.Strings(result.corruptedFiles)
+	return result, nil
+}
@@ -0,0 +1,125 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+func newManifestBackupContext(chunkManager *memoryChunkManager) *BackupContext {
+	var storageClient storage.ChunkManager = chunkManager
+	var params paramtable.BackupParams
+	params.BackupCfg.BackupCopyDataParallelism = 2
+	return &BackupContext{
+		ctx:            context.Background(),
+		params:         params,
+		storageClient:  &storageClient,
+		milvusRootPath: "files",
+		backupRootPath: "backup",
+	}
+}
+
+func TestBuildBackupManifest(t *testing.T) {
+	b := newManifestBackupContext(&memoryChunkManager{files: make(map[string][]byte)})
+	backupInfo := &backuppb.BackupInfo{
+		Name: "daily_2",
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{SegmentBackups: []*backuppb.SegmentBackupInfo{
+				{
+					CollectionId: 11,
+					PartitionId:  22,
+					SegmentId:    33,
+					GroupId:      33,
+					Binlogs: []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: []*backuppb.Binlog{
+						{LogPath: "files/insert_log/11/22/33/100/1", LogSize: 10, Crc32C: "crc1"},
+					}}},
+					Deltalogs: []*backuppb.FieldBinlog{{Binlogs: []*backuppb.Binlog{
+						{LogPath: "files/delta_log/11/22/33/1", LogSize: 20, BackupSize: 5, ObjectName: "aa01"},
+					}}},
+				},
+				{
+					CollectionId:  11,
+					PartitionId:   22,
+					SegmentId:     44,
+					GroupId:       44,
+					RefBackupName: "daily_1",
+					Binlogs: []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: []*backuppb.Binlog{
+						{LogPath: "files/insert_log/11/22/44/100/1", LogSize: 30},
+					}}},
+				},
+			}}},
+		}},
+	}
+
+	manifest := b.buildBackupManifest(backupInfo)
+	assert.Equal(t, "daily_2", manifest.GetBackupName())
+	assert.Equal(t, int64(45), manifest.GetSize())
+	paths := make([]string, 0)
+	for _, file := range manifest.GetFiles() {
+		paths = append(paths, file.GetPath())
+	}
+	assert.Equal(t, []string{
+		"daily_1/binlogs/insert_log/11/22/44/44/100/1",
+		"daily_2/binlogs/insert_log/11/22/33/33/100/1",
+		"dedup-objects/aa/aa01",
+	}, paths)
+	assert.Equal(t, "crc1", manifest.GetFiles()[1].GetCrc32C())
+	assert.Equal(t, int64(10), manifest.GetFiles()[1].GetSize())
+	assert.Equal(t, "files/delta_log/11/22/33/1", manifest.GetFiles()[2].GetSourcePath())
+	assert.Equal(t, int64(5), manifest.GetFiles()[2].GetSize())
+	assert.Equal(t, int64(33), manifest.GetFiles()[2].GetSegmentId())
+}
+
+func TestCheckManifestFiles(t *testing.T) {
+	chunkManager := &memoryChunkManager{files: make(map[string][]byte)}
+	b := newManifestBackupContext(chunkManager)
+	ctx := context.Background()
+
+	content := []byte("binlog content")
+	chunkManager.files["backup/daily_1/binlogs/insert_log/11/22/33/33/100/1"] = content
+	chunkManager.files["backup/daily_1/binlogs/insert_log/11/22/33/33/100/2"] = []byte("other content")
+	chunkManager.files["backup/daily_1/binlogs/insert_log/55/66/77/77/100/1"] = content
+	manifest := &backuppb.BackupManifest{
+		BackupName: "daily_1",
+		Files: []*backuppb.ManifestFile{
+			{Path: "daily_1/binlogs/insert_log/11/22/33/33/100/1", Size: int64(len(content)), Crc32C: utils.Crc32c(content), CollectionId: 11},
+			{Path: "daily_1/binlogs/insert_log/11/22/33/33/100/2", Size: int64(len("other content")), Crc32C: utils.Crc32c(content), CollectionId: 11},
+			{Path: "daily_1/binlogs/insert_log/11/22/33/33/100/3", Size: 10, CollectionId: 11},
+			{Path: "daily_1/binlogs/insert_log/55/66/77/77/100/1", Size: 1, CollectionId: 55},
+		},
+	}
+	data, err := json.Marshal(manifest)
+	assert.NoError(t, err)
+	chunkManager.files[ManifestPath("backup", "daily_1")] = data
+
+	read, err := b.readBackupManifest(ctx, "", "backup/daily_1")
+	assert.NoError(t, err)
+	assert.Len(t, read.GetFiles(), 4)
+	noManifest, err := b.readBackupManifest(ctx, "", "backup/daily_2")
+	assert.NoError(t, err)
+	assert.Nil(t, noManifest)
+
+	// sizes only, the files of collection 55 are filtered out
+	result, err := b.checkManifestFiles(ctx, "", "backup", read, func(collectionId int64) bool { return collectionId == 11 }, false)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), result.checkedFiles)
+	assert.Equal(t, int64(0), result.checksumFiles)
+	assert.Equal(t, []string{"backup/daily_1/binlogs/insert_log/11/22/33/33/100/3"}, result.missingFiles)
+	assert.Empty(t, result.corruptedFiles)
+
+	result, err = b.checkManifestFiles(ctx, "", "backup", read, nil, true)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), result.checkedFiles)
+	assert.Equal(t, int64(2), result.checksumFiles)
+	assert.Equal(t, []string{
+		"backup/daily_1/binlogs/insert_log/11/22/33/33/100/2",
+		"backup/daily_1/binlogs/insert_log/55/66/77/77/100/1",
+	}, result.corruptedFiles)
+}
@@ -0,0 +1,21 @@
+package core
+
+import (
+	"context"
+
+	"github.com/zilliztech/milvus-backup/internal/common"
+)
+
+// withMemory runs fn, which holds about size bytes of binlog data in memory, within backup.maxMemoryMB shared by
+// all backups and restores of the process. fn waits until the data held by the others fits into the budget.
+func (b *BackupContext) withMemory(ctx context.Context, size int64, fn func() error) error {
+	b.memoryBudgetOnce.Do(func() {
+		b.memoryBudget = common.NewMemoryBudget(int64(b.params.BackupCfg.MaxMemoryMB) * 1024 * 1024)
+	})
+	held, err := b.memoryBudget.Acquire(ctx, size)
+	if err != nil {
+		return err
+	}
+	defer b.memoryBudget.Release(held)
+	return fn()
+}
@@ -0,0 +1,65 @@
+package core
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func TestWithMemory(t *testing.T) {
+	b := &BackupContext{params: paramtable.BackupParams{BackupCfg: paramtable.BackupConfig{MaxMemoryMB: 1}}}
+	ctx := context.Background()
+
+	// copies larger than the budget are held one at a time
+	var mu sync.Mutex
+	holding, peak := 0, 0
+	var wg sync.WaitGroup
+	for i := 0; i < 3; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := b.withMemory(ctx, 2*1024*1024, func() error {
+				mu.Lock()
+				holding++
+				if holding > peak {
+					peak = holding
+				}
+				mu.Unlock()
+				time.Sleep(10 * time.Millisecond)
+				mu.Lock()
+				holding--
+				mu.Unlock()
+				return nil
+			})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, peak)
+	assert.Equal(t, int64(0), b.memoryBudget.Used())
+
+	// unlimited by default
+	b = &BackupContext{}
+	assert.NoError(t, b.withMemory(ctx, 1<<40, func() error { return nil }))
+}
+
+func TestDecodeBinlogFiles(t *testing.T) {
+	files := map[string][]byte{
+		"backup/b1/binlogs/insert_log/1/2/3/3/100/1": []byte("insert"),
+		"backup/b1/binlogs/insert_log/1/2/3/3/101/1": []byte("vector"),
+	}
+	var storageClient storage.ChunkManager = &memoryChunkManager{files: files}
+	b := &BackupContext{storageClient: &storageClient, params: paramtable.BackupParams{BackupCfg: paramtable.BackupConfig{MaxMemoryMB: 1}}}
+
+	err := b.decodeBinlogFiles(context.Background(), &backuppb.SegmentBackupInfo{}, "", "backup/b1/binlogs/insert_log/1/2/3/3/", "temp/insert_log/1/2/3/3/")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("insert"), files["temp/insert_log/1/2/3/3/100/1"])
+	assert.Equal(t, []byte("vector"), files["temp/insert_log/1/2/3/3/101/1"])
+}
@@ -3,8 +3,10 @@ package core
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
+	"github.com/zilliztech/milvus-backup/core/paramtable"
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
 )
 
@@ -15,13 +17,33 @@ const (
 	PARTITION_META_FILE  = "partition_meta.json"
 	SEGMENT_META_FILE    = "segment_meta.json"
 	FULL_META_FILE       = "full_meta.json"
-	SEPERATOR            = "/"
+	MANIFEST_FILE        = "manifest.json"
+	ETCD_SNAPSHOT_FILE   = "etcd_snapshot.json"
+	// written by pause backup, the running backup stops once it finds the file
+	PAUSED_FILE = "paused"
+	SEPERATOR   = "/"
+
+	RESTORE_CHECKPOINT_DIR = "restore"
+	// dir of the rows sampled of the collections to verify a restore
+	ROW_SAMPLE_DIR = "row_samples"
+	// dir of the summaries of the backup run and the restore runs from the backup
+	RUN_SUMMARY_DIR     = "summary"
+	BACKUP_SUMMARY_FILE = "backup.json"
 
 	BINGLOG_DIR    = "binlogs"
 	INSERT_LOG_DIR = "insert_log"
 	DELTA_LOG_DIR  = "delta_log"
 	STATS_LOG_DIR  = "stats_log"
 
+	// dir of the dedup objects shared by backups, reserved from backup names
+	DEDUP_DIR        = "dedup-objects"
+	DEDUP_INDEX_FILE = "index.json"
+
+	// dir of the lock objects of executing backups, not a valid backup name
+	LOCK_DIR = ".locks"
+	// dir of the records of backup and restore jobs, not a valid backup name
+	JOB_DIR = ".jobs"
+
 	LoadState_NotExist = "NotExist"
 	LoadState_NotLoad  = "NotLoad"
 	LoadState_Loading  = "Loading"
@@ -51,6 +73,8 @@ func treeToLevel(backup *backuppb.BackupInfo) (LeveledBackupInfo, error) {
 
 	for _, collectionBack := range backup.GetCollectionBackups() {
 		cloneCollectionBackup := &backuppb.CollectionBackupInfo{
+			StateCode:               collectionBack.GetStateCode(),
+			ErrorMessage:            collectionBack.GetErrorMessage(),
 			CollectionId:            collectionBack.GetCollectionId(),
 			DbName:                  collectionBack.GetDbName(),
 			CollectionName:          collectionBack.GetCollectionName(),
@@ -59,10 +83,20 @@ func treeToLevel(backup *backuppb.BackupInfo) (LeveledBackupInfo, error) {
 			ConsistencyLevel:        collectionBack.GetConsistencyLevel(),
 			BackupTimestamp:         collectionBack.GetBackupTimestamp(),
 			Size:                    collectionBack.GetSize(),
+			CopiedSize:              collectionBack.GetCopiedSize(),
+			Progress:                collectionBack.GetProgress(),
 			HasIndex:                collectionBack.GetHasIndex(),
 			IndexInfos:              collectionBack.GetIndexInfos(),
+			Aliases:                 collectionBack.GetAliases(),
+			Properties:              collectionBack.GetProperties(),
+			ReplicaNumber:           collectionBack.GetReplicaNumber(),
+			ResourceGroups:          collectionBack.GetResourceGroups(),
+			FlushState:              collectionBack.GetFlushState(),
+			FlushStartTime:          collectionBack.GetFlushStartTime(),
+			FlushEndTime:            collectionBack.GetFlushEndTime(),
 			LoadState:               collectionBack.GetLoadState(),
 			BackupPhysicalTimestamp: collectionBack.GetBackupPhysicalTimestamp(),
+			BackupPathTemplate:      collectionBack.GetBackupPathTemplate(),
 		}
 		collections = append(collections, cloneCollectionBackup)
 
@@ -92,16 +126,28 @@ func treeToLevel(backup *backuppb.BackupInfo) (LeveledBackupInfo, error) {
 		Infos: segments,
 	}
 	backupLevel := &backuppb.BackupInfo{
-		Id:              backup.GetId(),
-		StateCode:       backup.GetStateCode(),
-		ErrorMessage:    backup.GetErrorMessage(),
-		StartTime:       backup.GetStartTime(),
-		EndTime:         backup.GetEndTime(),
-		Progress:        backup.GetProgress(),
-		Name:            backup.GetName(),
-		BackupTimestamp: backup.GetBackupTimestamp(),
-		Size:            backup.GetSize(),
-		MilvusVersion:   backup.GetMilvusVersion(),
+		Id:                    backup.GetId(),
+		StateCode:             backup.GetStateCode(),
+		ErrorMessage:          backup.GetErrorMessage(),
+		StartTime:             backup.GetStartTime(),
+		EndTime:               backup.GetEndTime(),
+		Progress:              backup.GetProgress(),
+		Name:                  backup.GetName(),
+		BackupTimestamp:       backup.GetBackupTimestamp(),
+		Size:                  backup.GetSize(),
+		CopiedSize:            backup.GetCopiedSize(),
+		MilvusVersion:         backup.GetMilvusVersion(),
+		BaseBackupName:        backup.GetBaseBackupName(),
+		Compression:           backup.GetCompression(),
+		Encrypted:             backup.GetEncrypted(),
+		RbacMeta:              backup.GetRbacMeta(),
+		ObjectLockMode:        backup.GetObjectLockMode(),
+		ObjectLockRetainUntil: backup.GetObjectLockRetainUntil(),
+		SseType:               backup.GetSseType(),
+		SseKmsKeyId:           backup.GetSseKmsKeyId(),
+		SseCustomerKeyMd5:     backup.GetSseCustomerKeyMd5(),
+		MetaVersion:           backup.GetMetaVersion(),
+		Labels:                backup.GetLabels(),
 	}
 
 	return LeveledBackupInfo{
@@ -129,11 +175,15 @@ func serialize(backup *backuppb.BackupInfo) (*BackupMetaBytes, error) {
 	if err != nil {
 		return nil, err
 	}
+	// meta is always written in the current layout, the backup itself may be read by others meanwhile
+	level.backupLevel.MetaVersion = BACKUP_META_VERSION
 	backupMetaBytes, err := json.Marshal(level.backupLevel)
 	if err != nil {
 		return nil, err
 	}
-	fullMetaBytes, err := json.Marshal(backup)
+	fullMeta := *backup
+	fullMeta.MetaVersion = BACKUP_META_VERSION
+	fullMetaBytes, err := json.Marshal(&fullMeta)
 	if err != nil {
 		return nil, err
 	}
@@ -150,16 +200,28 @@ func serialize(backup *backuppb.BackupInfo) (*BackupMetaBytes, error) {
 // levelToTree rebuild complete tree structure BackupInfo from backup-collection-partition-segment 4-level structure
 func levelToTree(level *LeveledBackupInfo) (*backuppb.BackupInfo, error) {
 	backupInfo := &backuppb.BackupInfo{
-		Id:              level.backupLevel.GetId(),
-		StateCode:       level.backupLevel.GetStateCode(),
-		ErrorMessage:    level.backupLevel.GetErrorMessage(),
-		StartTime:       level.backupLevel.GetStartTime(),
-		EndTime:         level.backupLevel.GetEndTime(),
-		Progress:        level.backupLevel.GetProgress(),
-		Name:            level.backupLevel.GetName(),
-		BackupTimestamp: level.backupLevel.GetBackupTimestamp(),
-		Size:            level.backupLevel.GetSize(),
-		MilvusVersion:   level.backupLevel.GetMilvusVersion(),
+		Id:                    level.backupLevel.GetId(),
+		StateCode:             level.backupLevel.GetStateCode(),
+		ErrorMessage:          level.backupLevel.GetErrorMessage(),
+		StartTime:             level.backupLevel.GetStartTime(),
+		EndTime:               level.backupLevel.GetEndTime(),
+		Progress:              level.backupLevel.GetProgress(),
+		Name:                  level.backupLevel.GetName(),
+		BackupTimestamp:       level.backupLevel.GetBackupTimestamp(),
+		Size:                  level.backupLevel.GetSize(),
+		CopiedSize:            level.backupLevel.GetCopiedSize(),
+		MilvusVersion:         level.backupLevel.GetMilvusVersion(),
+		BaseBackupName:        level.backupLevel.GetBaseBackupName(),
+		Compression:           level.backupLevel.GetCompression(),
+		Encrypted:             level.backupLevel.GetEncrypted(),
+		RbacMeta:              level.backupLevel.GetRbacMeta(),
+		ObjectLockMode:        level.backupLevel.GetObjectLockMode(),
+		ObjectLockRetainUntil: level.backupLevel.GetObjectLockRetainUntil(),
+		SseType:               level.backupLevel.GetSseType(),
+		SseKmsKeyId:           level.backupLevel.GetSseKmsKeyId(),
+		SseCustomerKeyMd5:     level.backupLevel.GetSseCustomerKeyMd5(),
+		MetaVersion:           level.backupLevel.GetMetaVersion(),
+		Labels:                level.backupLevel.GetLabels(),
 	}
 	segmentDict := make(map[string][]*backuppb.SegmentBackupInfo, len(level.segmentLevel.GetInfos()))
 	for _, segment := range level.segmentLevel.GetInfos() {
@@ -221,6 +283,10 @@ func BackupMetaDirPath(backupRootPath, backupName string) string {
 	return backupRootPath + SEPERATOR + backupName + SEPERATOR + META_PREFIX
 }
 
+func BackupPausedFilePath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + PAUSED_FILE
+}
+
 func BackupMetaPath(backupRootPath, backupName string) string {
 	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + BACKUP_META_FILE
 }
@@ -241,23 +307,123 @@ func FullMetaPath(backupRootPath, backupName string) string {
 	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + FULL_META_FILE
 }
 
+func ManifestPath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + MANIFEST_FILE
+}
+
+func EtcdSnapshotPath(backupRootPath, backupName string) string {
+	return BackupMetaDirPath(backupRootPath, backupName) + SEPERATOR + ETCD_SNAPSHOT_FILE
+}
+
+// RestoreCheckpointPath returns the path of the checkpoint of a restore task, which is stored in the backup restored from
+func RestoreCheckpointPath(backupPath, taskId string) string {
+	return backupPath + SEPERATOR + META_PREFIX + SEPERATOR + RESTORE_CHECKPOINT_DIR + SEPERATOR + taskId + ".json"
+}
+
+// RowSamplePath returns the path of the rows sampled of a collection in the backup at backupPath
+func RowSamplePath(backupPath string, collectionID int64) string {
+	return backupPath + SEPERATOR + META_PREFIX + SEPERATOR + ROW_SAMPLE_DIR + SEPERATOR + strconv.FormatInt(collectionID, 10) + ".json"
+}
+
+// RunSummaryDirPath returns the dir of the run summaries in the backup at backupPath
+func RunSummaryDirPath(backupPath string) string {
+	return backupPath + SEPERATOR + META_PREFIX + SEPERATOR + RUN_SUMMARY_DIR + SEPERATOR
+}
+
+// RunSummaryPath returns the path of the summary of the backup run, or of the restore run of restoreId if it is not empty
+func RunSummaryPath(backupPath, restoreId string) string {
+	if restoreId == "" {
+		return RunSummaryDirPath(backupPath) + BACKUP_SUMMARY_FILE
+	}
+	return RunSummaryDirPath(backupPath) + RunKindRestore + "_" + restoreId + ".json"
+}
+
 func BackupBinlogDirPath(backupRootPath, backupName string) string {
 	return backupRootPath + SEPERATOR + backupName + SEPERATOR + BINGLOG_DIR
 }
 
+// BinlogGroupDirPath returns the dir of the logs of logType, insert_log or delta_log, of a segment group in the backup
+// of backupName, rendered by the path template recorded in the collection backup, the default layout if it has none
+func BinlogGroupDirPath(backupRootPath, backupName string, collection *backuppb.CollectionBackupInfo, logType string, collectionID, partitionID, groupID int64) string {
+	dir := renderBackupPathTemplate(backupName, collection, logType, collectionID, partitionID)
+	return backupRootPath + SEPERATOR + strings.Replace(dir, paramtable.BackupPathGroupID, strconv.FormatInt(groupID, 10), 1) + SEPERATOR
+}
+
+// BinlogPartitionDirPath returns the dir of the segment groups of logType of a partition in the backup, the parent of
+// the dir of the group id in the path template. It holds the groups of other partitions or log types as well if the
+// template has the group id before them.
+func BinlogPartitionDirPath(backupRootPath, backupName string, collection *backuppb.CollectionBackupInfo, logType string, collectionID, partitionID int64) string {
+	dir := renderBackupPathTemplate(backupName, collection, logType, collectionID, partitionID)
+	dir = dir[:strings.Index(dir, paramtable.BackupPathGroupID)]
+	return backupRootPath + SEPERATOR + dir[:strings.LastIndex(dir, SEPERATOR)+1]
+}
+
+// renderBackupPathTemplate renders the path template of the collection backup except the group id
+func renderBackupPathTemplate(backupName string, collection *backuppb.CollectionBackupInfo, logType string, collectionID, partitionID int64) string {
+	template := collection.GetBackupPathTemplate()
+	if template == "" {
+		template = paramtable.DefaultBackupPathTemplate
+	}
+	return strings.NewReplacer(
+		paramtable.BackupPathBackup, backupName,
+		paramtable.BackupPathDatabase, collection.GetDbName(),
+		paramtable.BackupPathCollection, collection.GetCollectionName(),
+		paramtable.BinlogPathLogType, logType,
+		paramtable.BinlogPathCollectionID, strconv.FormatInt(collectionID, 10),
+		paramtable.BinlogPathPartitionID, strconv.FormatInt(partitionID, 10),
+	).Replace(template)
+}
+
+// sameBinlogGroupDirs returns whether the segment groups of two backups of a collection are in the same dirs, so that
+// an incremental backup can reference the binlogs of its base backup
+func sameBinlogGroupDirs(base, current *backuppb.CollectionBackupInfo) bool {
+	return BinlogGroupDirPath("", "", base, INSERT_LOG_DIR, current.GetCollectionId(), 1, 2) ==
+		BinlogGroupDirPath("", "", current, INSERT_LOG_DIR, current.GetCollectionId(), 1, 2)
+}
+
+// DedupObjectPath returns the path of a dedup object in backup root, objects are spread into dirs by the first
+// two characters of their names
+func DedupObjectPath(backupRootPath, objectName string) string {
+	return backupRootPath + SEPERATOR + DEDUP_DIR + SEPERATOR + objectName[:2] + SEPERATOR + objectName
+}
+
+// BackupLockPath returns the path of a lock object in backup root
+func BackupLockPath(backupRootPath, lockName string) string {
+	return backupRootPath + SEPERATOR + LOCK_DIR + SEPERATOR + lockName + ".json"
+}
+
+// JobStatePath returns the path of the record of a backup or restore job in backup root
+func JobStatePath(backupRootPath, jobId string) string {
+	return backupRootPath + SEPERATOR + JOB_DIR + SEPERATOR + jobId + ".json"
+}
+
+func DedupIndexPath(backupRootPath string) string {
+	return backupRootPath + SEPERATOR + DEDUP_DIR + SEPERATOR + DEDUP_INDEX_FILE
+}
+
 func SimpleListBackupsResponse(input *backuppb.ListBackupsResponse) *backuppb.ListBackupsResponse {
 	simpleBackupInfos := make([]*backuppb.BackupInfo, 0)
 	for _, backup := range input.GetData() {
 		simpleBackupInfos = append(simpleBackupInfos, &backuppb.BackupInfo{
-			Id:              backup.GetId(),
-			Name:            backup.GetName(),
-			StateCode:       backup.GetStateCode(),
-			ErrorMessage:    backup.GetErrorMessage(),
-			BackupTimestamp: backup.GetBackupTimestamp(),
-			Size:            backup.GetSize(),
-			StartTime:       backup.GetStartTime(),
-			EndTime:         backup.GetEndTime(),
-			MilvusVersion:   backup.GetMilvusVersion(),
+			Id:                    backup.GetId(),
+			Name:                  backup.GetName(),
+			StateCode:             backup.GetStateCode(),
+			ErrorMessage:          backup.GetErrorMessage(),
+			BackupTimestamp:       backup.GetBackupTimestamp(),
+			Size:                  backup.GetSize(),
+			StartTime:             backup.GetStartTime(),
+			EndTime:               backup.GetEndTime(),
+			MilvusVersion:         backup.GetMilvusVersion(),
+			BaseBackupName:        backup.GetBaseBackupName(),
+			Compression:           backup.GetCompression(),
+			Encrypted:             backup.GetEncrypted(),
+			ObjectLockMode:        backup.GetObjectLockMode(),
+			ObjectLockRetainUntil: backup.GetObjectLockRetainUntil(),
+			SseType:               backup.GetSseType(),
+			SseKmsKeyId:           backup.GetSseKmsKeyId(),
+			SseCustomerKeyMd5:     backup.GetSseCustomerKeyMd5(),
+			MetaVersion:           backup.GetMetaVersion(),
+			Labels:                backup.GetLabels(),
 		})
 	}
 	return &backuppb.ListBackupsResponse{
@@ -265,6 +431,7 @@ func SimpleListBackupsResponse(input *backuppb.ListBackupsResponse) *backuppb.Li
 		Code:      input.GetCode(),
 		Msg:       input.GetMsg(),
 		Data:      simpleBackupInfos,
+		Total:     input.GetTotal(),
 	}
 }
 
@@ -282,25 +449,48 @@ func SimpleBackupResponse(input *backuppb.BackupInfoResponse) *backuppb.BackupIn
 			BackupTimestamp:         coll.GetBackupTimestamp(),
 			HasIndex:                coll.GetHasIndex(),
 			IndexInfos:              coll.GetIndexInfos(),
+			Aliases:                 coll.GetAliases(),
+			Properties:              coll.GetProperties(),
+			ReplicaNumber:           coll.GetReplicaNumber(),
+			ResourceGroups:          coll.GetResourceGroups(),
+			FlushState:              coll.GetFlushState(),
+			FlushStartTime:          coll.GetFlushStartTime(),
+			FlushEndTime:            coll.GetFlushEndTime(),
 			LoadState:               coll.GetLoadState(),
 			Schema:                  coll.GetSchema(),
 			Size:                    coll.GetSize(),
+			CopiedSize:              coll.GetCopiedSize(),
 			Progress:                coll.GetProgress(),
 			BackupPhysicalTimestamp: coll.GetBackupPhysicalTimestamp(),
+			RowCountChecks:          coll.GetRowCountChecks(),
+			SampledRows:             coll.GetSampledRows(),
+			BinlogScan:              coll.GetBinlogScan(),
 		})
 	}
 	simpleBackupInfo := &backuppb.BackupInfo{
-		Id:                backup.GetId(),
-		Name:              backup.GetName(),
-		StateCode:         backup.GetStateCode(),
-		ErrorMessage:      backup.GetErrorMessage(),
-		BackupTimestamp:   backup.GetBackupTimestamp(),
-		CollectionBackups: collections,
-		MilvusVersion:     backup.GetMilvusVersion(),
-		StartTime:         backup.GetStartTime(),
-		EndTime:           backup.GetEndTime(),
-		Progress:          backup.GetProgress(),
-		Size:              backup.GetSize(),
+		Id:                    backup.GetId(),
+		Name:                  backup.GetName(),
+		StateCode:             backup.GetStateCode(),
+		ErrorMessage:          backup.GetErrorMessage(),
+		BackupTimestamp:       backup.GetBackupTimestamp(),
+		CollectionBackups:     collections,
+		MilvusVersion:         backup.GetMilvusVersion(),
+		BaseBackupName:        backup.GetBaseBackupName(),
+		Compression:           backup.GetCompression(),
+		Encrypted:             backup.GetEncrypted(),
+		RbacMeta:              backup.GetRbacMeta(),
+		StartTime:             backup.GetStartTime(),
+		EndTime:               backup.GetEndTime(),
+		Progress:              backup.GetProgress(),
+		Size:                  backup.GetSize(),
+		CopiedSize:            backup.GetCopiedSize(),
+		ObjectLockMode:        backup.GetObjectLockMode(),
+		ObjectLockRetainUntil: backup.GetObjectLockRetainUntil(),
+		SseType:               backup.GetSseType(),
+		SseKmsKeyId:           backup.GetSseKmsKeyId(),
+		SseCustomerKeyMd5:     backup.GetSseCustomerKeyMd5(),
+		MetaVersion:           backup.GetMetaVersion(),
+		Labels:                backup.GetLabels(),
 	}
 	return &backuppb.BackupInfoResponse{
 		RequestId: input.GetRequestId(),
@@ -326,6 +516,8 @@ func SimpleRestoreResponse(input *backuppb.RestoreBackupResponse) *backuppb.Rest
 			TargetDbName:         coll.GetTargetDbName(),
 			ToRestoreSize:        coll.GetToRestoreSize(),
 			RestoredSize:         coll.GetRestoredSize(),
+			RowCountChecks:       coll.GetRowCountChecks(),
+			SampleVerification:   coll.GetSampleVerification(),
 		})
 	}
 
@@ -337,6 +529,10 @@ func SimpleRestoreResponse(input *backuppb.RestoreBackupResponse) *backuppb.Rest
 		EndTime:                restore.GetEndTime(),
 		CollectionRestoreTasks: collectionRestores,
 		Progress:               restore.GetProgress(),
+		ToRestoreSize:          restore.GetToRestoreSize(),
+		RestoredSize:           restore.GetRestoredSize(),
+		SkippedCollections:     restore.GetSkippedCollections(),
+		BackupName:             restore.GetBackupName(),
 	}
 
 	return &backuppb.RestoreBackupResponse{
@@ -352,6 +548,7 @@ func UpdateRestoreBackupTask(input *backuppb.RestoreBackupTask) *backuppb.Restor
 	for _, coll := range input.GetCollectionRestoreTasks() {
 		storedSize += coll.GetRestoredSize()
 	}
+	input.RestoredSize = storedSize
 	if input.ToRestoreSize == 0 {
 		input.Progress = 100
 	} else {
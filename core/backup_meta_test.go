@@ -126,6 +126,7 @@ func TestBackupSerialize(t *testing.T) {
 		ConsistencyLevel: backuppb.ConsistencyLevel_Strong,
 		BackupTimestamp:  0,
 		PartitionBackups: []*backuppb.PartitionBackupInfo{partition1, partition2},
+		Aliases:          []string{"hello_alias"},
 	}
 
 	backup := &backuppb.BackupInfo{
@@ -143,6 +144,8 @@ func TestBackupSerialize(t *testing.T) {
 
 	deserBackup, err := deserialize(serData)
 	log.Info(deserBackup.String())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"hello_alias"}, deserBackup.GetCollectionBackups()[0].GetAliases())
 }
 
 func TestDbCollectionJson(t *testing.T) {
@@ -231,3 +234,89 @@ func TestReadBackupFile(t *testing.T) {
 	fmt.Sprintf(segmentMetaStr)
 	//log.Info("segment meta", zap.String("value", string(output.SegmentMetaBytes)))
 }
+
+func TestIsSameSegmentFiles(t *testing.T) {
+	newSegment := func(deltaSize int64) *backuppb.SegmentBackupInfo {
+		segment := &backuppb.SegmentBackupInfo{
+			SegmentId: 1,
+			Binlogs: []*backuppb.FieldBinlog{
+				{FieldID: 100, Binlogs: []*backuppb.Binlog{{LogPath: "insert_log/1/2/1/100/1", LogSize: 10}}},
+			},
+			Deltalogs: []*backuppb.FieldBinlog{{FieldID: 0}},
+			Size:      10,
+		}
+		if deltaSize > 0 {
+			segment.Deltalogs = []*backuppb.FieldBinlog{
+				{FieldID: 0, Binlogs: []*backuppb.Binlog{{LogPath: "delta_log/1/2/1/1", LogSize: deltaSize}}},
+			}
+			segment.Size += deltaSize
+		}
+		return segment
+	}
+
+	assert.True(t, isSameSegmentFiles(newSegment(0), newSegment(0)))
+	assert.True(t, isSameSegmentFiles(newSegment(5), newSegment(5)))
+	// new delta log generated since base backup
+	assert.False(t, isSameSegmentFiles(newSegment(0), newSegment(5)))
+
+	compacted := newSegment(0)
+	compacted.SegmentId = 2
+	assert.False(t, isSameSegmentFiles(newSegment(0), compacted))
+
+	base := &backuppb.BackupInfo{
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{PartitionBackups: []*backuppb.PartitionBackupInfo{
+				{SegmentBackups: []*backuppb.SegmentBackupInfo{newSegment(0), compacted}},
+			}},
+		},
+	}
+	segments := baseBackupSegments(base, &backuppb.BackupInfo{})
+	assert.Equal(t, 2, len(segments))
+	assert.Equal(t, int64(2), segments[2].GetSegmentId())
+
+	// segments in other dirs by the path template of the incremental backup are not referenced
+	base.CollectionBackups[0].CollectionName = "coll"
+	incremental := &backuppb.BackupInfo{CollectionBackups: []*backuppb.CollectionBackupInfo{{CollectionName: "renamed"}}}
+	assert.Len(t, baseBackupSegments(base, incremental), 2)
+	incremental.CollectionBackups[0].BackupPathTemplate = "{{backup}}/{{database}}/{{collection}}/{{logType}}/{{partitionID}}/{{groupID}}"
+	assert.Empty(t, baseBackupSegments(base, incremental))
+}
+
+func TestBinlogGroupDirPath(t *testing.T) {
+	collection := &backuppb.CollectionBackupInfo{DbName: "db1", CollectionName: "coll"}
+	assert.Equal(t, "backup/b1/binlogs/insert_log/1/2/3/", BinlogGroupDirPath("backup", "b1", collection, INSERT_LOG_DIR, 1, 2, 3))
+	assert.Equal(t, "backup/b1/binlogs/delta_log/1/2/", BinlogPartitionDirPath("backup", "b1", collection, DELTA_LOG_DIR, 1, 2))
+
+	collection.BackupPathTemplate = "{{backup}}/{{database}}/{{collection}}/{{logType}}/{{partitionID}}/{{groupID}}"
+	assert.Equal(t, "backup/b1/db1/coll/insert_log/2/3/", BinlogGroupDirPath("backup", "b1", collection, INSERT_LOG_DIR, 1, 2, 3))
+	assert.Equal(t, "backup/b1/db1/coll/insert_log/2/", BinlogPartitionDirPath("backup", "b1", collection, INSERT_LOG_DIR, 1, 2))
+	// the groups of all partitions are in the dir of the log type
+	collection.BackupPathTemplate = "{{backup}}/{{collectionID}}/{{logType}}/{{groupID}}-{{partitionID}}"
+	assert.Equal(t, "backup/b1/1/delta_log/3-2/", BinlogGroupDirPath("backup", "b1", collection, DELTA_LOG_DIR, 1, 2, 3))
+	assert.Equal(t, "backup/b1/1/delta_log/", BinlogPartitionDirPath("backup", "b1", collection, DELTA_LOG_DIR, 1, 2))
+}
+
+func TestBackupSerializeRBAC(t *testing.T) {
+	backup := &backuppb.BackupInfo{
+		Name: "backup",
+		RbacMeta: &backuppb.RBACMeta{
+			Users: []*backuppb.UserEntity{{User: "user1", Roles: []string{"role1"}}},
+			Roles: []*backuppb.RoleEntity{{Name: "role1"}},
+			Grants: []*backuppb.GrantEntity{{
+				Role:       "role1",
+				Object:     "Collection",
+				ObjectName: "hello_milvus",
+				Privilege:  "Search",
+				DbName:     "default",
+			}},
+		},
+	}
+
+	serData, err := serialize(backup)
+	assert.NoError(t, err)
+	deserBackup, err := deserialize(serData)
+	assert.NoError(t, err)
+	assert.Equal(t, "user1", deserBackup.GetRbacMeta().GetUsers()[0].GetUser())
+	assert.Equal(t, []string{"role1"}, deserBackup.GetRbacMeta().GetUsers()[0].GetRoles())
+	assert.Equal(t, "Search", deserBackup.GetRbacMeta().GetGrants()[0].GetPrivilege())
+}
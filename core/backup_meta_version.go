@@ -0,0 +1,133 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// BACKUP_META_VERSION is the version of the layout of the meta files written by this milvus-backup.
+// Increase it together with an upgrade step in metaUpgrades once the meta written before can't be used as it is.
+const BACKUP_META_VERSION int32 = 1
+
+var errUnsupportedMetaVersion = errors.New("unsupported backup meta version")
+
+// metaUpgrades[v] upgrades the meta of version v into version v+1 in place
+var metaUpgrades = []func(backup *backuppb.BackupInfo){
+	upgradeLegacyMeta,
+}
+
+// upgradeBackupMeta upgrades the meta read from the meta files into the current version in memory, and returns
+// whether it is upgraded. Meta of a newer version than this milvus-backup supports is rejected, as fields it
+// doesn't know may be needed to restore the backup correctly.
+func upgradeBackupMeta(backup *backuppb.BackupInfo) (bool, error) {
+	version := backup.GetMetaVersion()
+	if version > BACKUP_META_VERSION || version < 0 {
+		return false, fmt.Errorf("%w: meta version of backup %s is %d, this milvus-backup supports up to %d, please upgrade milvus-backup",
+			errUnsupportedMetaVersion, backup.GetName(), version, BACKUP_META_VERSION)
+	}
+	for v := version; v < BACKUP_META_VERSION; v++ {
+		metaUpgrades[v](backup)
+	}
+	backup.MetaVersion = BACKUP_META_VERSION
+	return version < BACKUP_META_VERSION, nil
+}
+
+// upgradeLegacyMeta fills the fields missing in the meta written before the version is recorded:
+// the database of collections backed up before milvus supported databases, the sizes of segments and
+// partitions, and the copied state of the segments of finished backups, which resume and progress rely on
+func upgradeLegacyMeta(backup *backuppb.BackupInfo) {
+	finished := backup.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_SUCCESS
+	var backupSize int64
+	for _, collection := range backup.GetCollectionBackups() {
+		if collection.GetDbName() == "" {
+			collection.DbName = "default"
+		}
+		var collectionSize int64
+		for _, partition := range collection.GetPartitionBackups() {
+			var partitionSize int64
+			for _, segment := range partition.GetSegmentBackups() {
+				if segment.GetSize() == 0 {
+					segment.Size = segmentBinlogSize(segment)
+				}
+				if finished {
+					segment.Backuped = true
+				}
+				partitionSize += segment.GetSize()
+			}
+			if partition.GetSize() == 0 {
+				partition.Size = partitionSize
+			}
+			collectionSize += partition.GetSize()
+		}
+		if collection.GetSize() == 0 {
+			collection.Size = collectionSize
+		}
+		backupSize += collection.GetSize()
+	}
+	if backup.GetSize() == 0 {
+		backup.Size = backupSize
+	}
+	if finished {
+		if backup.GetCopiedSize() == 0 {
+			backup.CopiedSize = backup.GetSize()
+		}
+		backup.Progress = 100
+	}
+}
+
+// segmentBinlogSize is the total size of the insert, delta and stats logs of a segment in milvus
+func segmentBinlogSize(segment *backuppb.SegmentBackupInfo) int64 {
+	var size int64
+	for _, fieldBinlogs := range [][]*backuppb.FieldBinlog{segment.GetBinlogs(), segment.GetDeltalogs(), segment.GetStatslogs()} {
+		for _, fieldBinlog := range fieldBinlogs {
+			for _, binlog := range fieldBinlog.GetBinlogs() {
+				size += binlog.GetLogSize()
+			}
+		}
+	}
+	return size
+}
+
+// MigrateBackupMeta rewrites the meta files of a backup written by an older milvus-backup in the current layout,
+// and returns the version it is migrated from. Nothing is written if the meta is of the current version or dryRun is true.
+func (b *BackupContext) MigrateBackupMeta(ctx context.Context, backupName string, dryRun bool) (int32, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return 0, err
+		}
+	}
+	if backupName == "" {
+		return 0, fmt.Errorf("empty backup name")
+	}
+	backup, err := b.readBackupMeta(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return 0, err
+	}
+	if backup == nil {
+		return 0, fmt.Errorf("backup does not exist: %s", backupName)
+	}
+	version := backup.GetMetaVersion()
+	upgraded, err := upgradeBackupMeta(backup)
+	if err != nil || !upgraded || dryRun {
+		return version, err
+	}
+
+	output, err := serialize(backup)
+	if err != nil {
+		return version, err
+	}
+	if err := b.writeBackupMeta(ctx, backupName, output); err != nil {
+		return version, err
+	}
+	log.Info("migrate backup meta",
+		zap.String("backupName", backupName),
+		zap.Int32("from", version),
+		zap.Int32("to", BACKUP_META_VERSION))
+	return version, nil
+}
@@ -0,0 +1,118 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+func legacyBackup() *backuppb.BackupInfo {
+	return &backuppb.BackupInfo{
+		Name:      "legacy_backup",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId:   1,
+			CollectionName: "coll",
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:  1,
+				CollectionId: 1,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{
+					SegmentId:    1,
+					CollectionId: 1,
+					PartitionId:  1,
+					Binlogs:      []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: []*backuppb.Binlog{{LogSize: 30}, {LogSize: 50}}}},
+					Deltalogs:    []*backuppb.FieldBinlog{{Binlogs: []*backuppb.Binlog{{LogSize: 20}}}},
+				}},
+			}},
+		}},
+	}
+}
+
+func TestUpgradeBackupMeta(t *testing.T) {
+	assert.Len(t, metaUpgrades, int(BACKUP_META_VERSION))
+
+	backup := legacyBackup()
+	upgraded, err := upgradeBackupMeta(backup)
+	assert.NoError(t, err)
+	assert.True(t, upgraded)
+	assert.Equal(t, BACKUP_META_VERSION, backup.GetMetaVersion())
+	collection := backup.GetCollectionBackups()[0]
+	assert.Equal(t, "default", collection.GetDbName())
+	segment := collection.GetPartitionBackups()[0].GetSegmentBackups()[0]
+	assert.Equal(t, int64(100), segment.GetSize())
+	assert.True(t, segment.GetBackuped())
+	assert.Equal(t, int64(100), collection.GetPartitionBackups()[0].GetSize())
+	assert.Equal(t, int64(100), collection.GetSize())
+	assert.Equal(t, int64(100), backup.GetSize())
+	assert.Equal(t, int64(100), backup.GetCopiedSize())
+	assert.Equal(t, int32(100), backup.GetProgress())
+
+	// the segments of an unfinished backup are still to copy
+	backup = legacyBackup()
+	backup.StateCode = backuppb.BackupTaskStateCode_BACKUP_PAUSED
+	_, err = upgradeBackupMeta(backup)
+	assert.NoError(t, err)
+	assert.False(t, backup.GetCollectionBackups()[0].GetPartitionBackups()[0].GetSegmentBackups()[0].GetBackuped())
+
+	upgraded, err = upgradeBackupMeta(backup)
+	assert.NoError(t, err)
+	assert.False(t, upgraded)
+
+	_, err = upgradeBackupMeta(&backuppb.BackupInfo{Name: "future_backup", MetaVersion: BACKUP_META_VERSION + 1})
+	assert.ErrorIs(t, err, errUnsupportedMetaVersion)
+}
+
+func TestMigrateBackupMeta(t *testing.T) {
+	ctx := context.Background()
+	files := make(map[string][]byte)
+	var storageClient storage.ChunkManager = &memoryChunkManager{files: files}
+	b := &BackupContext{storageClient: &storageClient, backupRootPath: "backup", started: true}
+
+	// meta written before the version is recorded
+	output, err := serialize(legacyBackup())
+	assert.NoError(t, err)
+	assert.NoError(t, b.writeBackupMeta(ctx, "legacy_backup", output))
+	legacyMeta, err := json.Marshal(&backuppb.BackupInfo{Name: "legacy_backup", StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS})
+	assert.NoError(t, err)
+	files[BackupMetaPath("backup", "legacy_backup")] = legacyMeta
+
+	// read as the current version without rewriting the meta
+	backup, err := b.readBackup(ctx, "", "backup/legacy_backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "default", backup.GetCollectionBackups()[0].GetDbName())
+
+	version, err := b.MigrateBackupMeta(ctx, "legacy_backup", true)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), version)
+	stored, err := b.readBackupMeta(ctx, "", "backup/legacy_backup")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), stored.GetMetaVersion())
+
+	version, err = b.MigrateBackupMeta(ctx, "legacy_backup", false)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(0), version)
+	stored, err = b.readBackupMeta(ctx, "", "backup/legacy_backup")
+	assert.NoError(t, err)
+	assert.Equal(t, BACKUP_META_VERSION, stored.GetMetaVersion())
+	assert.Equal(t, "default", stored.GetCollectionBackups()[0].GetDbName())
+	assert.Equal(t, int64(100), stored.GetSize())
+
+	version, err = b.MigrateBackupMeta(ctx, "legacy_backup", false)
+	assert.NoError(t, err)
+	assert.Equal(t, BACKUP_META_VERSION, version)
+
+	// meta of a newer milvus-backup is rejected
+	futureMeta, err := json.Marshal(&backuppb.BackupInfo{Name: "legacy_backup", MetaVersion: BACKUP_META_VERSION + 1})
+	assert.NoError(t, err)
+	files[BackupMetaPath("backup", "legacy_backup")] = futureMeta
+	_, err = b.MigrateBackupMeta(ctx, "legacy_backup", false)
+	assert.ErrorIs(t, err, errUnsupportedMetaVersion)
+	resp := b.GetBackup(ctx, &backuppb.GetBackupRequest{BackupName: "legacy_backup"})
+	assert.Equal(t, backuppb.ResponseCode_Not_Support, resp.GetCode())
+	assert.Contains(t, resp.GetMsg(), "please upgrade milvus-backup")
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// attempts to append a number to a generated backup name taken by an existing backup
+const maxBackupNameAttempts = 100
+
+// renderBackupName renders {{date}}, {{time}} and {{cluster}} of the backup name template, in UTC
+func renderBackupName(template string, cluster string, now time.Time) string {
+	now = now.UTC()
+	return strings.NewReplacer(
+		"{{date}}", now.Format("20060102"),
+		"{{time}}", now.Format("150405"),
+		"{{cluster}}", cluster,
+	).Replace(template)
+}
+
+// generateBackupName generates the name of a backup created without name, by the template of request, or
+// backup.nameTemplate in config if not set. backup_<timestamp> is used if neither is set. If the name is taken by an
+// existing backup, a number is appended to it, like nightly_20240101_2.
+func (b *BackupContext) generateBackupName(ctx context.Context, template string) (string, error) {
+	if template == "" {
+		template = b.params.BackupCfg.NameTemplate
+	}
+	now := time.Now()
+	name := "backup_" + now.UTC().Format("2006_01_02_15_04_05_") + fmt.Sprint(now.Nanosecond())
+	if template != "" {
+		name = renderBackupName(template, b.params.BackupCfg.ClusterName, now)
+	}
+
+	for i := 1; i <= maxBackupNameAttempts; i++ {
+		candidate := name
+		if i > 1 {
+			candidate = fmt.Sprintf("%s_%d", name, i)
+		}
+		exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+candidate+SEPERATOR)
+		if err != nil {
+			return "", fmt.Errorf("fail to check whether exist backup with name: %s, %w", candidate, err)
+		}
+		if !exist {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("backups already exist with the name %s and %d numbered names", name, maxBackupNameAttempts-1)
+}
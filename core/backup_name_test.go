@@ -0,0 +1,54 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+func TestRenderBackupName(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	assert.Equal(t, "nightly-20240102-prod", renderBackupName("nightly-{{date}}-{{cluster}}", "prod", now))
+	assert.Equal(t, "hourly_20240102_030405", renderBackupName("hourly_{{date}}_{{time}}", "prod", now))
+	// rendered in UTC
+	assert.Equal(t, "daily_20240102", renderBackupName("daily_{{date}}", "prod", now.In(time.FixedZone("UTC-8", -8*3600))))
+
+	assert.NoError(t, utils.ValidateBackupName("nightly-20240102-prod", BACKUP_NAME))
+	assert.Error(t, utils.ValidateBackupName("-nightly", BACKUP_NAME))
+	assert.Error(t, utils.ValidateType("nightly-20240102", COLLECTION_NAME))
+}
+
+func TestGenerateBackupName(t *testing.T) {
+	chunkManager := &memoryChunkManager{files: make(map[string][]byte)}
+	b := newManifestBackupContext(chunkManager)
+	b.params.BackupCfg.ClusterName = "prod"
+	ctx := context.Background()
+	date := time.Now().UTC().Format("20060102")
+
+	name, err := b.generateBackupName(ctx, "")
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(name, "backup_"))
+
+	name, err = b.generateBackupName(ctx, "nightly-{{date}}-{{cluster}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "nightly-"+date+"-prod", name)
+
+	// the template in config is used if the request has none
+	b.params.BackupCfg.NameTemplate = "nightly-{{date}}-{{cluster}}"
+	chunkManager.files["backup/nightly-"+date+"-prod/meta/backup_meta.json"] = []byte("{}")
+	chunkManager.files["backup/nightly-"+date+"-prod_2/meta/backup_meta.json"] = []byte("{}")
+	name, err = b.generateBackupName(ctx, "")
+	assert.NoError(t, err)
+	assert.Equal(t, "nightly-"+date+"-prod_3", name)
+
+	// a backup whose name has the generated name as prefix is not a collision
+	chunkManager.files["backup/weekly-"+date+"1/meta/backup_meta.json"] = []byte("{}")
+	name, err = b.generateBackupName(ctx, "weekly-{{date}}")
+	assert.NoError(t, err)
+	assert.Equal(t, "weekly-"+date, name)
+}
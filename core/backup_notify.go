@@ -0,0 +1,104 @@
+package core
+
+import (
+	"context"
+	"time"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+	"github.com/zilliztech/milvus-backup/internal/notify"
+)
+
+func newNotifier(cfg paramtable.NotificationConfig) *notify.Notifier {
+	webhooks := make([]notify.Webhook, 0, len(cfg.Webhooks))
+	for _, webhook := range cfg.Webhooks {
+		webhooks = append(webhooks, notify.Webhook{
+			Name:       webhook.Name,
+			URL:        webhook.URL,
+			Format:     webhook.Format,
+			Events:     webhook.Events,
+			RoutingKey: webhook.RoutingKey,
+		})
+	}
+	return notify.NewNotifier(webhooks, time.Duration(cfg.DurationThreshold)*time.Second)
+}
+
+func eventType(err error) string {
+	if err != nil {
+		return notify.EventFail
+	}
+	return notify.EventSuccess
+}
+
+// watchBackup notifies if the backup is still running after the duration threshold, stop should be called when it finishes
+func (b *BackupContext) watchBackup(backupInfo *backuppb.BackupInfo, start time.Time) (stop func()) {
+	// backupInfo is updated by the backup, only the fields fixed before it starts are read
+	event := notify.Event{
+		Task:       metrics.BackupTaskLabel,
+		Id:         backupInfo.GetId(),
+		BackupName: backupInfo.GetName(),
+		StartTime:  start.Unix(),
+	}
+	return b.notifier.WatchDuration(func() notify.Event {
+		event.State = backuppb.BackupTaskStateCode_BACKUP_EXECUTING.String()
+		event.Duration = int64(time.Since(start).Seconds())
+		return event
+	})
+}
+
+// notifyBackup sends the result of the backup, not canceled with the request as the failure may be caused by it
+func (b *BackupContext) notifyBackup(backupInfo *backuppb.BackupInfo, start time.Time, err error) {
+	collections := make([]string, 0, len(backupInfo.GetCollectionBackups()))
+	for _, coll := range backupInfo.GetCollectionBackups() {
+		collections = append(collections, coll.GetDbName()+"."+coll.GetCollectionName())
+	}
+	b.notifier.Notify(context.Background(), notify.Event{
+		Type:        eventType(err),
+		Task:        metrics.BackupTaskLabel,
+		Id:          backupInfo.GetId(),
+		BackupName:  backupInfo.GetName(),
+		State:       backupInfo.GetStateCode().String(),
+		Error:       backupInfo.GetErrorMessage(),
+		StartTime:   start.Unix(),
+		Duration:    int64(time.Since(start).Seconds()),
+		Size:        backupInfo.GetSize(),
+		Collections: collections,
+	})
+}
+
+// watchRestore notifies if the restore is still running after the duration threshold, stop should be called when it finishes
+func (b *BackupContext) watchRestore(backupName string, task *backuppb.RestoreBackupTask, start time.Time) (stop func()) {
+	event := notify.Event{
+		Task:       metrics.RestoreTaskLabel,
+		Id:         task.GetId(),
+		BackupName: backupName,
+		StartTime:  start.Unix(),
+		Size:       task.GetToRestoreSize(),
+	}
+	return b.notifier.WatchDuration(func() notify.Event {
+		event.State = backuppb.RestoreTaskStateCode_EXECUTING.String()
+		event.Duration = int64(time.Since(start).Seconds())
+		return event
+	})
+}
+
+// notifyRestore sends the result of the restore, not canceled with the request as the failure may be caused by it
+func (b *BackupContext) notifyRestore(backupName string, task *backuppb.RestoreBackupTask, start time.Time, err error) {
+	collections := make([]string, 0, len(task.GetCollectionRestoreTasks()))
+	for _, coll := range task.GetCollectionRestoreTasks() {
+		collections = append(collections, coll.GetTargetDbName()+"."+coll.GetTargetCollectionName())
+	}
+	b.notifier.Notify(context.Background(), notify.Event{
+		Type:        eventType(err),
+		Task:        metrics.RestoreTaskLabel,
+		Id:          task.GetId(),
+		BackupName:  backupName,
+		State:       task.GetStateCode().String(),
+		Error:       task.GetErrorMessage(),
+		StartTime:   start.Unix(),
+		Duration:    int64(time.Since(start).Seconds()),
+		Size:        task.GetRestoredSize(),
+		Collections: collections,
+	})
+}
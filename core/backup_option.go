@@ -5,6 +5,12 @@ import "strings"
 // BackupConfig for setting params used by backup context and server.
 type BackupConfig struct {
 	port string
+	// run the schedule jobs in server
+	schedule bool
+	// run continuous backup in server
+	continuous bool
+	// run the controller of the backup objects in kubernetes
+	controller bool
 }
 
 func newDefaultBackupConfig() *BackupConfig {
@@ -24,3 +30,25 @@ func Port(port string) BackupOption {
 		c.port = port
 	}
 }
+
+// Schedule enables the scheduler to run the schedule jobs in config
+func Schedule(enable bool) BackupOption {
+	return func(c *BackupConfig) {
+		c.schedule = enable
+	}
+}
+
+// Continuous enables continuous backup in config to keep a chain of incremental backups
+func Continuous(enable bool) BackupOption {
+	return func(c *BackupConfig) {
+		c.continuous = enable
+	}
+}
+
+// Controller enables the controller to run the backups of the MilvusBackup and MilvusBackupSchedule objects
+// in kubernetes, the server must run in a pod
+func Controller(enable bool) BackupOption {
+	return func(c *BackupConfig) {
+		c.controller = enable
+	}
+}
@@ -0,0 +1,794 @@
+package core
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/numpy"
+	"github.com/zilliztech/milvus-backup/core/parquet"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// PackageOptions is a dataset of local files to package into a backup of a collection
+type PackageOptions struct {
+	BackupName string
+	// database of the collection, default if empty
+	DbName string
+	// name of the collection, the name in schema if empty
+	CollectionName string
+	// json file of the backuppb.CollectionSchema of the collection, like the _schema.json written by export
+	SchemaFile string
+	// parquet, json or jsonl files of rows, or dirs of a numpy file per field named by the field, like
+	// vector.npy, a segment per file or dir
+	Files []string
+}
+
+// PackageBackup writes the rows of a dataset produced out of milvus into a backup of a collection, binlogs in the
+// layout of milvus with the meta of a backup, so the dataset is restored by bulk insert like a backup of milvus.
+// Every file becomes a segment of the default partition, and the rows are visible since the backup timestamp.
+func (b *BackupContext) PackageBackup(ctx context.Context, opts PackageOptions) (*backuppb.BackupInfo, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return nil, err
+		}
+	}
+	if err := utils.ValidateBackupName(opts.BackupName, BACKUP_NAME); err != nil {
+		return nil, err
+	}
+	switch opts.BackupName {
+	case DEDUP_DIR, LOCK_DIR, JOB_DIR:
+		return nil, fmt.Errorf("backup name %s is reserved", opts.BackupName)
+	}
+	if len(opts.Files) == 0 {
+		return nil, fmt.Errorf("no files to package")
+	}
+	exist, err := b.getBackupStorageClient().Exist(ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, opts.BackupName))
+	if err != nil {
+		return nil, err
+	}
+	if exist {
+		return nil, fmt.Errorf("backup already exists: %s", opts.BackupName)
+	}
+	schema, err := readPackageSchema(opts.SchemaFile)
+	if err != nil {
+		return nil, err
+	}
+	if opts.CollectionName != "" {
+		schema.Name = opts.CollectionName
+	}
+	if schema.GetName() == "" {
+		return nil, fmt.Errorf("empty collection name")
+	}
+	if opts.DbName == "" {
+		opts.DbName = "default"
+	}
+
+	now := time.Now()
+	// in seconds like the collections of the backups created from milvus
+	backupTs := utils.ComposeTS(now.Unix(), 0)
+	// ids are allocated from the timestamp in milliseconds like milvus, so they don't collide with the paths of each other
+	collectionID := int64(utils.ComposeTS(now.UnixNano()/int64(time.Millisecond), 0))
+	partitionID := collectionID + 1
+	collection := &backuppb.CollectionBackupInfo{
+		Id:                      utils.UUID(),
+		StateCode:               backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		StartTime:               now.Unix(),
+		CollectionId:            collectionID,
+		DbName:                  opts.DbName,
+		CollectionName:          schema.GetName(),
+		Schema:                  schema,
+		ShardsNum:               1,
+		ConsistencyLevel:        backuppb.ConsistencyLevel_Bounded,
+		BackupTimestamp:         backupTs,
+		BackupPhysicalTimestamp: uint64(now.Unix()),
+		FlushState:              FlushStateSkipped,
+		BackupPathTemplate:      b.params.MinioCfg.BackupPathTemplate,
+	}
+	partition := &backuppb.PartitionBackupInfo{
+		PartitionId:   partitionID,
+		PartitionName: "_default",
+		CollectionId:  collectionID,
+	}
+	collection.PartitionBackups = []*backuppb.PartitionBackupInfo{partition}
+	// the backup timestamp is in milliseconds like the backups created from milvus, read by prune and continuous backup
+	backup := &backuppb.BackupInfo{
+		Id:                utils.UUID(),
+		StateCode:         backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		StartTime:         now.UnixNano() / int64(time.Millisecond),
+		Name:              opts.BackupName,
+		BackupTimestamp:   uint64(now.UnixNano() / int64(time.Millisecond)),
+		Encrypted:         b.encryptionKey != nil,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{collection},
+	}
+
+	var rowID int64
+	for i, file := range opts.Files {
+		segment := &backuppb.SegmentBackupInfo{
+			SegmentId:    partitionID + 1 + int64(i),
+			CollectionId: collectionID,
+			PartitionId:  partitionID,
+			Encrypted:    backup.GetEncrypted(),
+			Backuped:     true,
+		}
+		// a segment per group, bulk inserted one by one
+		segment.GroupId = segment.GetSegmentId()
+		columns, numRows, err := readPackageFile(file, schema)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read %s: %w", file, err)
+		}
+		if numRows == 0 {
+			log.Warn("skip packaging empty file", zap.String("file", file))
+			continue
+		}
+		rowIDs := make([]interface{}, numRows)
+		timestamps := make([]interface{}, numRows)
+		for j := range rowIDs {
+			rowIDs[j] = rowID
+			timestamps[j] = int64(backupTs)
+			rowID++
+		}
+		columns[common.RowIDField] = rowIDs
+		columns[common.TimeStampField] = timestamps
+		fields := append([]*backuppb.FieldSchema{
+			{FieldID: common.RowIDField, Name: "RowID", DataType: backuppb.DataType_Int64},
+			{FieldID: common.TimeStampField, Name: "Timestamp", DataType: backuppb.DataType_Int64},
+		}, schema.GetFields()...)
+		for _, field := range fields {
+			if err := b.writePackageBinlog(ctx, backup, segment, field, columns[field.GetFieldID()]); err != nil {
+				return nil, fmt.Errorf("fail to write binlog of field %s of %s: %w", field.GetName(), file, err)
+			}
+		}
+		segment.NumOfRows = int64(numRows)
+		partition.SegmentBackups = append(partition.SegmentBackups, segment)
+		partition.Size += segment.GetSize()
+		log.Info("package file",
+			zap.String("file", file),
+			zap.Int64("segmentID", segment.GetSegmentId()),
+			zap.Int("rows", numRows),
+			zap.Int64("size", segment.GetSize()))
+	}
+	if len(partition.GetSegmentBackups()) == 0 {
+		return nil, fmt.Errorf("no rows to package")
+	}
+
+	collection.Size = partition.GetSize()
+	collection.CopiedSize = collection.GetSize()
+	collection.Progress = 100
+	collection.EndTime = time.Now().Unix()
+	backup.Size = collection.GetSize()
+	backup.CopiedSize = backup.GetSize()
+	backup.Progress = 100
+	backup.EndTime = time.Now().UnixNano() / int64(time.Millisecond)
+	if err := b.writeBackupManifest(ctx, backup); err != nil {
+		log.Warn("fail to write backup manifest", zap.String("backupName", backup.GetName()), zap.Error(err))
+	}
+	output, err := serialize(backup)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.writeBackupMeta(ctx, backup.GetName(), output); err != nil {
+		return nil, err
+	}
+	b.refreshBackupCache(backup)
+	log.Info("package backup",
+		zap.String("backupName", backup.GetName()),
+		zap.String("collection", opts.DbName+"."+schema.GetName()),
+		zap.Int("segments", len(partition.GetSegmentBackups())),
+		zap.Int64("rows", rowID),
+		zap.Int64("size", backup.GetSize()))
+	return backup, nil
+}
+
+// readPackageSchema reads the schema of a collection to package, the system fields are removed, and user fields
+// without ids are given ids after the others
+func readPackageSchema(file string) (*backuppb.CollectionSchema, error) {
+	if file == "" {
+		return nil, fmt.Errorf("empty schema file")
+	}
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	schema := &backuppb.CollectionSchema{}
+	if err := json.Unmarshal(data, schema); err != nil {
+		return nil, fmt.Errorf("illegal schema %s: %w", file, err)
+	}
+	fields := make([]*backuppb.FieldSchema, 0, len(schema.GetFields()))
+	nextID := int64(common.StartOfUserFieldID)
+	for _, field := range schema.GetFields() {
+		// row ids and timestamps in the schemas of exported collections
+		if field.GetFieldID() > 0 && field.GetFieldID() < common.StartOfUserFieldID {
+			continue
+		}
+		if field.GetFieldID() == 0 && (field.GetName() == "RowID" || field.GetName() == "Timestamp") {
+			continue
+		}
+		if field.GetFieldID() >= nextID {
+			nextID = field.GetFieldID() + 1
+		}
+		fields = append(fields, field)
+	}
+	names := make(map[string]bool, len(fields))
+	var pks int
+	for _, field := range fields {
+		if field.GetFieldID() == 0 {
+			field.FieldID = nextID
+			nextID++
+		}
+		if field.GetName() == "" {
+			return nil, fmt.Errorf("illegal schema %s: empty field name", file)
+		}
+		if names[field.GetName()] {
+			return nil, fmt.Errorf("illegal schema %s: duplicated field %s", file, field.GetName())
+		}
+		names[field.GetName()] = true
+		if field.GetIsPrimaryKey() {
+			pks++
+		}
+		if _, err := binlogColumn(field); err != nil {
+			return nil, fmt.Errorf("illegal schema %s: %w", file, err)
+		}
+	}
+	if pks != 1 {
+		return nil, fmt.Errorf("illegal schema %s: %d primary keys, expect 1", file, pks)
+	}
+	schema.Fields = fields
+	return schema, nil
+}
+
+// readPackageFile reads the rows of a file or a dir of numpy files, returns the binlog values of the user fields
+// by field id, converted by packageValue, and the number of rows
+func readPackageFile(file string, schema *backuppb.CollectionSchema) (map[int64][]interface{}, int, error) {
+	info, err := os.Stat(file)
+	if err != nil {
+		return nil, 0, err
+	}
+	var raw map[string][]interface{}
+	switch ext := strings.ToLower(filepath.Ext(file)); {
+	case info.IsDir():
+		raw, err = readPackageNumpy(file, schema)
+	case ext == ".parquet":
+		raw, err = readPackageParquet(file)
+	case ext == ".json" || ext == ".jsonl":
+		raw, err = readPackageJSON(file, schema, ext == ".jsonl")
+	default:
+		return nil, 0, fmt.Errorf("unsupported file type %s, support parquet, json, jsonl or dirs of numpy files", ext)
+	}
+	if err != nil {
+		return nil, 0, err
+	}
+
+	numRows := -1
+	columns := make(map[int64][]interface{}, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		values, ok := raw[field.GetName()]
+		if !ok {
+			return nil, 0, fmt.Errorf("no values of field %s", field.GetName())
+		}
+		if numRows < 0 {
+			numRows = len(values)
+		} else if len(values) != numRows {
+			return nil, 0, fmt.Errorf("%d values of field %s, expect %d", len(values), field.GetName(), numRows)
+		}
+		for i, value := range values {
+			if values[i], err = packageValue(field, value); err != nil {
+				return nil, 0, fmt.Errorf("illegal value of field %s of row %d: %w", field.GetName(), i, err)
+			}
+		}
+		columns[field.GetFieldID()] = values
+	}
+	return columns, numRows, nil
+}
+
+// readPackageNumpy reads the numpy files of the fields in dir, <field name>.npy
+func readPackageNumpy(dir string, schema *backuppb.CollectionSchema) (map[string][]interface{}, error) {
+	raw := make(map[string][]interface{}, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		file := filepath.Join(dir, field.GetName()+".npy")
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		array, err := numpy.Read(data)
+		if err != nil {
+			return nil, fmt.Errorf("fail to read %s: %w", file, err)
+		}
+		if raw[field.GetName()], err = array.Values(); err != nil {
+			return nil, fmt.Errorf("fail to read %s: %w", file, err)
+		}
+	}
+	return raw, nil
+}
+
+// readPackageParquet reads the columns of a parquet file by name
+func readPackageParquet(file string) (map[string][]interface{}, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+	f, err := parquet.Open(data)
+	if err != nil {
+		return nil, err
+	}
+	raw := make(map[string][]interface{})
+	for i, column := range f.Columns() {
+		if raw[column.Name], err = f.ReadColumn(i); err != nil {
+			return nil, err
+		}
+	}
+	return raw, nil
+}
+
+// readPackageJSON reads the rows of a json file, an array of rows or an object of rows like bulk insert of milvus,
+// {"rows": [...]}, or of a jsonl file of a row per line. Keys of a row not in the schema are put into the dynamic
+// field if the collection has one.
+func readPackageJSON(file string, schema *backuppb.CollectionSchema, lines bool) (map[string][]interface{}, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var rows []map[string]interface{}
+	if lines {
+		scanner := bufio.NewScanner(f)
+		scanner.Buffer(make([]byte, 0, 64*1024), math.MaxInt32)
+		for line := 1; scanner.Scan(); line++ {
+			if len(bytes.TrimSpace(scanner.Bytes())) == 0 {
+				continue
+			}
+			decoder := json.NewDecoder(bytes.NewReader(scanner.Bytes()))
+			decoder.UseNumber()
+			var row map[string]interface{}
+			if err := decoder.Decode(&row); err != nil {
+				return nil, fmt.Errorf("illegal row of line %d: %w", line, err)
+			}
+			rows = append(rows, row)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, err
+		}
+	} else {
+		decoder := json.NewDecoder(bufio.NewReader(f))
+		decoder.UseNumber()
+		var content interface{}
+		if err := decoder.Decode(&content); err != nil {
+			return nil, fmt.Errorf("illegal json: %w", err)
+		}
+		if object, ok := content.(map[string]interface{}); ok {
+			content = object["rows"]
+		}
+		array, ok := content.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("illegal json, expect an array of rows or an object of rows")
+		}
+		for i, element := range array {
+			row, ok := element.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("illegal row %d, expect an object", i)
+			}
+			rows = append(rows, row)
+		}
+	}
+
+	var dynamic *backuppb.FieldSchema
+	fields := make(map[string]bool, len(schema.GetFields()))
+	for _, field := range schema.GetFields() {
+		fields[field.GetName()] = true
+		if field.GetIsDynamic() {
+			dynamic = field
+		}
+	}
+	raw := make(map[string][]interface{}, len(schema.GetFields()))
+	for i, row := range rows {
+		var extra map[string]interface{}
+		for key, value := range row {
+			if fields[key] {
+				raw[key] = append(raw[key], value)
+				continue
+			}
+			if dynamic == nil {
+				return nil, fmt.Errorf("field %s of row %d is not in schema", key, i)
+			}
+			if extra == nil {
+				extra = make(map[string]interface{})
+			}
+			extra[key] = value
+		}
+		if dynamic != nil {
+			if _, ok := row[dynamic.GetName()]; !ok {
+				if extra == nil {
+					extra = make(map[string]interface{})
+				}
+				raw[dynamic.GetName()] = append(raw[dynamic.GetName()], extra)
+			} else if extra != nil {
+				return nil, fmt.Errorf("row %d has both field %s and keys not in schema", i, dynamic.GetName())
+			}
+		}
+		for _, field := range schema.GetFields() {
+			if len(raw[field.GetName()]) != i+1 {
+				return nil, fmt.Errorf("no value of field %s of row %d", field.GetName(), i)
+			}
+		}
+	}
+	return raw, nil
+}
+
+// binlogColumn returns the parquet column of the payloads of binlogs of a field written by milvus
+func binlogColumn(field *backuppb.FieldSchema) (parquet.Column, error) {
+	column := parquet.Column{Name: "val"}
+	switch field.GetDataType() {
+	case backuppb.DataType_Bool:
+		column.Type = parquet.Boolean
+	case backuppb.DataType_Int8:
+		column.Type, column.LogicalType = parquet.Int32, parquet.LogicalInt8
+	case backuppb.DataType_Int16:
+		column.Type, column.LogicalType = parquet.Int32, parquet.LogicalInt16
+	case backuppb.DataType_Int32:
+		column.Type = parquet.Int32
+	case backuppb.DataType_Int64:
+		column.Type = parquet.Int64
+	case backuppb.DataType_Float:
+		column.Type = parquet.Float
+	case backuppb.DataType_Double:
+		column.Type = parquet.Double
+	case backuppb.DataType_VarChar, backuppb.DataType_String:
+		column.Type, column.LogicalType = parquet.ByteArray, parquet.LogicalString
+	case backuppb.DataType_Json, backuppb.DataType_Array, backuppb.DataType_SparseFloatVector:
+		column.Type = parquet.ByteArray
+	case backuppb.DataType_FloatVector, backuppb.DataType_BinaryVector:
+		dim, err := strconv.Atoi(utils.KvPairsMap(field.GetTypeParams())["dim"])
+		if err != nil || dim <= 0 {
+			return column, fmt.Errorf("illegal dim of vector field %s", field.GetName())
+		}
+		column.Type, column.TypeLength = parquet.FixedLenByteArray, dim*4
+		if field.GetDataType() == backuppb.DataType_BinaryVector {
+			if dim%8 != 0 {
+				return column, fmt.Errorf("dim %d of binary vector field %s is not a multiple of 8", dim, field.GetName())
+			}
+			column.TypeLength = dim / 8
+		}
+	default:
+		return column, fmt.Errorf("unsupported data type %s of field %s", field.GetDataType(), field.GetName())
+	}
+	return column, nil
+}
+
+// writePackageBinlog writes the values of a field of a segment into a binlog of backup
+func (b *BackupContext) writePackageBinlog(ctx context.Context, backup *backuppb.BackupInfo, segment *backuppb.SegmentBackupInfo, field *backuppb.FieldSchema, values []interface{}) error {
+	column, err := binlogColumn(field)
+	if err != nil {
+		return err
+	}
+	descriptor := binlogDescriptor{
+		collectionID: segment.GetCollectionId(),
+		partitionID:  segment.GetPartitionId(),
+		segmentID:    segment.GetSegmentId(),
+		fieldID:      field.GetFieldID(),
+		dataType:     field.GetDataType(),
+		startTs:      backup.GetBackupTimestamp(),
+		endTs:        backup.GetBackupTimestamp(),
+	}
+	data, err := writeBinlogFile(descriptor, binlogInsertEvent, column, values)
+	if err != nil {
+		return err
+	}
+	// recorded as a path in the bucket of milvus, like the binlogs of backups of milvus
+	logPath := path.Join(b.milvusRootPath, INSERT_LOG_DIR, strconv.FormatInt(segment.GetCollectionId(), 10), strconv.FormatInt(segment.GetPartitionId(), 10),
+		strconv.FormatInt(segment.GetSegmentId(), 10), strconv.FormatInt(field.GetFieldID(), 10), "1")
+	encoded, err := b.encodeBackupFile(data)
+	if err != nil {
+		return err
+	}
+	if err := b.getBackupStorageClient().Write(ctx, b.backupBucketName, b.binlogBackupPath(backup.GetName(), backup.GetCollectionBackups()[0], segment, logPath), encoded); err != nil {
+		return err
+	}
+	segment.Binlogs = append(segment.Binlogs, &backuppb.FieldBinlog{
+		FieldID: field.GetFieldID(),
+		Binlogs: []*backuppb.Binlog{{
+			EntriesNum:    int64(len(values)),
+			TimestampFrom: backup.GetBackupTimestamp(),
+			TimestampTo:   backup.GetBackupTimestamp(),
+			LogPath:       logPath,
+			LogSize:       int64(len(data)),
+			BackupSize:    int64(len(encoded)),
+			Crc32C:        utils.Crc32c(encoded),
+		}},
+	})
+	segment.Size += int64(len(data))
+	return nil
+}
+
+// packageValue converts a value of a field read from a dataset into the value of binlog. Values are those decoded
+// from json with numbers, read from parquet or numpy, and the values exported by export: json, arrays and sparse
+// vectors as json strings, float vectors as lists, binary vectors as bytes or base64.
+func packageValue(field *backuppb.FieldSchema, value interface{}) (interface{}, error) {
+	if value == nil {
+		return nil, fmt.Errorf("null value")
+	}
+	switch field.GetDataType() {
+	case backuppb.DataType_Bool:
+		if v, ok := value.(bool); ok {
+			return v, nil
+		}
+	case backuppb.DataType_Int8, backuppb.DataType_Int16, backuppb.DataType_Int32:
+		v, err := packageInt(value)
+		if err != nil {
+			return nil, err
+		}
+		bits := map[backuppb.DataType]uint{backuppb.DataType_Int8: 8, backuppb.DataType_Int16: 16, backuppb.DataType_Int32: 32}[field.GetDataType()]
+		if v < -(1<<(bits-1)) || v >= 1<<(bits-1) {
+			return nil, fmt.Errorf("%d out of range of %s", v, field.GetDataType())
+		}
+		return int32(v), nil
+	case backuppb.DataType_Int64:
+		return packageInt(value)
+	case backuppb.DataType_Float:
+		v, err := packageFloat(value)
+		return float32(v), err
+	case backuppb.DataType_Double:
+		return packageFloat(value)
+	case backuppb.DataType_VarChar, backuppb.DataType_String:
+		switch v := value.(type) {
+		case string:
+			return v, nil
+		case []byte:
+			return v, nil
+		}
+	case backuppb.DataType_Json:
+		switch v := value.(type) {
+		case string:
+			// a json text like bulk insert of milvus, or a json string embedded like jsonl of export
+			if json.Valid([]byte(v)) {
+				return []byte(v), nil
+			}
+			return json.Marshal(v)
+		case []byte:
+			if !json.Valid(v) {
+				return nil, fmt.Errorf("illegal json %q", v)
+			}
+			return v, nil
+		default:
+			return json.Marshal(v)
+		}
+	case backuppb.DataType_Array:
+		elements, err := packageList(value)
+		if err != nil {
+			return nil, err
+		}
+		return packageArray(field, elements)
+	case backuppb.DataType_FloatVector:
+		if data, ok := value.([]byte); ok {
+			return data, nil
+		}
+		elements, err := packageList(value)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, 4*len(elements))
+		for i, element := range elements {
+			v, err := packageFloat(element)
+			if err != nil {
+				return nil, err
+			}
+			binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(float32(v)))
+		}
+		return data, nil
+	case backuppb.DataType_BinaryVector:
+		switch v := value.(type) {
+		case []byte:
+			return v, nil
+		case string:
+			return base64.StdEncoding.DecodeString(v)
+		}
+		elements, err := packageList(value)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]byte, len(elements))
+		for i, element := range elements {
+			v, err := packageInt(element)
+			if err != nil {
+				return nil, err
+			}
+			if v < 0 || v > math.MaxUint8 {
+				return nil, fmt.Errorf("%d out of range of a byte of binary vector", v)
+			}
+			data[i] = byte(v)
+		}
+		return data, nil
+	case backuppb.DataType_SparseFloatVector:
+		return packageSparseVector(value)
+	}
+	return nil, fmt.Errorf("unexpected value %T of %s", value, field.GetDataType())
+}
+
+func packageInt(value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case json.Number:
+		return v.Int64()
+	case float64:
+		if v == math.Trunc(v) && math.Abs(v) < 1<<53 {
+			return int64(v), nil
+		}
+		return 0, fmt.Errorf("%v is not an integer", v)
+	}
+	return 0, fmt.Errorf("unexpected value %T of integer", value)
+}
+
+func packageFloat(value interface{}) (float64, error) {
+	switch v := value.(type) {
+	case int32:
+		return float64(v), nil
+	case int64:
+		return float64(v), nil
+	case float32:
+		return float64(v), nil
+	case float64:
+		return v, nil
+	case json.Number:
+		return v.Float64()
+	}
+	return 0, fmt.Errorf("unexpected value %T of float", value)
+}
+
+// packageList returns the elements of a list, or of a json array in a string
+func packageList(value interface{}) ([]interface{}, error) {
+	switch v := value.(type) {
+	case []interface{}:
+		return v, nil
+	case string, []byte:
+		var data []byte
+		if s, ok := v.(string); ok {
+			data = []byte(s)
+		} else {
+			data = v.([]byte)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		var elements []interface{}
+		if err := decoder.Decode(&elements); err != nil {
+			return nil, fmt.Errorf("illegal json array %q: %w", data, err)
+		}
+		return elements, nil
+	}
+	return nil, fmt.Errorf("unexpected value %T of list", value)
+}
+
+// packageArray encodes the elements of an array field as schemapb.ScalarField
+func packageArray(field *backuppb.FieldSchema, elements []interface{}) ([]byte, error) {
+	scalar := &schemapb.ScalarField{}
+	switch field.GetElementType() {
+	case backuppb.DataType_Bool:
+		data := make([]bool, 0, len(elements))
+		for _, element := range elements {
+			v, ok := element.(bool)
+			if !ok {
+				return nil, fmt.Errorf("unexpected element %T of bool array", element)
+			}
+			data = append(data, v)
+		}
+		scalar.Data = &schemapb.ScalarField_BoolData{BoolData: &schemapb.BoolArray{Data: data}}
+	case backuppb.DataType_Int8, backuppb.DataType_Int16, backuppb.DataType_Int32:
+		data := make([]int32, 0, len(elements))
+		for _, element := range elements {
+			v, err := packageValue(&backuppb.FieldSchema{DataType: field.GetElementType()}, element)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, v.(int32))
+		}
+		scalar.Data = &schemapb.ScalarField_IntData{IntData: &schemapb.IntArray{Data: data}}
+	case backuppb.DataType_Int64:
+		data := make([]int64, 0, len(elements))
+		for _, element := range elements {
+			v, err := packageInt(element)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, v)
+		}
+		scalar.Data = &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: data}}
+	case backuppb.DataType_Float:
+		data := make([]float32, 0, len(elements))
+		for _, element := range elements {
+			v, err := packageFloat(element)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, float32(v))
+		}
+		scalar.Data = &schemapb.ScalarField_FloatData{FloatData: &schemapb.FloatArray{Data: data}}
+	case backuppb.DataType_Double:
+		data := make([]float64, 0, len(elements))
+		for _, element := range elements {
+			v, err := packageFloat(element)
+			if err != nil {
+				return nil, err
+			}
+			data = append(data, v)
+		}
+		scalar.Data = &schemapb.ScalarField_DoubleData{DoubleData: &schemapb.DoubleArray{Data: data}}
+	case backuppb.DataType_VarChar, backuppb.DataType_String:
+		data := make([]string, 0, len(elements))
+		for _, element := range elements {
+			v, ok := element.(string)
+			if !ok {
+				return nil, fmt.Errorf("unexpected element %T of string array", element)
+			}
+			data = append(data, v)
+		}
+		scalar.Data = &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: data}}
+	default:
+		return nil, fmt.Errorf("unsupported element type %s of array field %s", field.GetElementType(), field.GetName())
+	}
+	return proto.Marshal(scalar)
+}
+
+// packageSparseVector encodes a sparse vector of an object of index to value, or of a json string of it, as pairs
+// of uint32 index and float32 value in the order of indices
+func packageSparseVector(value interface{}) ([]byte, error) {
+	var object map[string]interface{}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		object = v
+	case string, []byte:
+		var data []byte
+		if s, ok := v.(string); ok {
+			data = []byte(s)
+		} else {
+			data = v.([]byte)
+		}
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.UseNumber()
+		if err := decoder.Decode(&object); err != nil {
+			return nil, fmt.Errorf("illegal sparse vector %q: %w", data, err)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected value %T of sparse vector", value)
+	}
+	indices := make([]uint32, 0, len(object))
+	values := make(map[uint32]float32, len(object))
+	for key, element := range object {
+		index, err := strconv.ParseUint(key, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("illegal index %s of sparse vector", key)
+		}
+		v, err := packageFloat(element)
+		if err != nil {
+			return nil, err
+		}
+		indices = append(indices, uint32(index))
+		values[uint32(index)] = float32(v)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	data := make([]byte, 8*len(indices))
+	for i, index := range indices {
+		binary.LittleEndian.PutUint32(data[8*i:], index)
+		binary.LittleEndian.PutUint32(data[8*i+4:], math.Float32bits(values[index]))
+	}
+	return data, nil
+}
@@ -0,0 +1,152 @@
+package core
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+// testNpyFile writes a .npy file of version 1 like numpy.save
+func testNpyFile(t *testing.T, file string, header string, data []byte) {
+	header += strings.Repeat(" ", 64-(10+len(header)+1)%64) + "\n"
+	npy := append([]byte("\x93NUMPY"), 1, 0, 0, 0)
+	binary.LittleEndian.PutUint16(npy[8:], uint16(len(header)))
+	assert.NoError(t, os.WriteFile(file, append(append(npy, header...), data...), 0o644))
+}
+
+func utf32Bytes(values ...string) []byte {
+	data := make([]byte, 0)
+	for _, v := range values {
+		for _, r := range v {
+			data = appendUint32(data, uint32(r))
+		}
+	}
+	return data
+}
+
+func TestPackageBackup(t *testing.T) {
+	ctx := context.Background()
+	files := make(map[string][]byte)
+	var client storage.ChunkManager = &memoryChunkManager{files: files}
+	b := &BackupContext{storageClient: &client, backupRootPath: "backup", started: true}
+
+	dir := t.TempDir()
+	schema := &backuppb.CollectionSchema{Name: "coll", EnableDynamicField: true, Fields: []*backuppb.FieldSchema{
+		{FieldID: 100, Name: "id", DataType: backuppb.DataType_Int64, IsPrimaryKey: true},
+		{Name: "name", DataType: backuppb.DataType_VarChar, TypeParams: []*backuppb.KeyValuePair{{Key: "max_length", Value: "16"}}},
+		{Name: "vector", DataType: backuppb.DataType_FloatVector, TypeParams: []*backuppb.KeyValuePair{{Key: "dim", Value: "2"}}},
+		{Name: "meta", DataType: backuppb.DataType_Json},
+		{Name: "tags", DataType: backuppb.DataType_Array, ElementType: backuppb.DataType_VarChar},
+		{Name: "flag", DataType: backuppb.DataType_Bool},
+		{Name: "$meta", DataType: backuppb.DataType_Json, IsDynamic: true},
+	}}
+	data, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	schemaFile := filepath.Join(dir, "schema.json")
+	assert.NoError(t, os.WriteFile(schemaFile, data, 0o644))
+
+	jsonlFile := filepath.Join(dir, "rows.jsonl")
+	assert.NoError(t, os.WriteFile(jsonlFile, []byte(`{"id":9007199254740993,"name":"one","vector":[1,2],"meta":{"k":1},"tags":["a"],"flag":true,"color":"red"}`+"\n"+
+		`{"id":2,"name":"two","vector":[3,4],"meta":"s","tags":[],"flag":false}`+"\n"), 0o644))
+	// a parquet file exported from a backup
+	parquetFile := filepath.Join(dir, "rows.parquet")
+	packaged, err := readPackageSchema(schemaFile)
+	assert.NoError(t, err)
+	assert.NoError(t, writeExportFile(parquetFile, ExportFormatParquet, packaged.GetFields(), [][]interface{}{
+		{int64(3)}, {"three"}, {[]float32{5, 6}}, {json.RawMessage(`[1]`)}, {json.RawMessage(`["b","c"]`)}, {nil}, {json.RawMessage(`{}`)},
+	}))
+	numpyDir := filepath.Join(dir, "numpy")
+	assert.NoError(t, os.Mkdir(numpyDir, 0o755))
+	testNpyFile(t, filepath.Join(numpyDir, "id.npy"), "{'descr': '<i8', 'fortran_order': False, 'shape': (1,), }", []byte{4, 0, 0, 0, 0, 0, 0, 0})
+	testNpyFile(t, filepath.Join(numpyDir, "name.npy"), "{'descr': '<U4', 'fortran_order': False, 'shape': (1,), }", utf32Bytes("four"))
+	testNpyFile(t, filepath.Join(numpyDir, "vector.npy"), "{'descr': '<f4', 'fortran_order': False, 'shape': (1, 2), }", float32Bytes(7, 8))
+	testNpyFile(t, filepath.Join(numpyDir, "meta.npy"), "{'descr': '<U4', 'fortran_order': False, 'shape': (1,), }", utf32Bytes("null"))
+	testNpyFile(t, filepath.Join(numpyDir, "tags.npy"), "{'descr': '<U1', 'fortran_order': False, 'shape': (1, 1), }", utf32Bytes("d"))
+	testNpyFile(t, filepath.Join(numpyDir, "flag.npy"), "{'descr': '|b1', 'fortran_order': False, 'shape': (1,), }", []byte{1})
+	testNpyFile(t, filepath.Join(numpyDir, "$meta.npy"), "{'descr': '<U2', 'fortran_order': False, 'shape': (1,), }", utf32Bytes("{}"))
+
+	opts := PackageOptions{BackupName: "dataset", DbName: "db1", SchemaFile: schemaFile, Files: []string{jsonlFile, parquetFile}}
+	backup, err := b.PackageBackup(ctx, opts)
+	assert.ErrorContains(t, err, "illegal value of field flag of row 0")
+	opts.Files = []string{jsonlFile, parquetFile, numpyDir}
+	assert.NoError(t, os.Remove(parquetFile))
+	assert.NoError(t, writeExportFile(parquetFile, ExportFormatParquet, packaged.GetFields(), [][]interface{}{
+		{int64(3)}, {"three"}, {[]float32{5, 6}}, {json.RawMessage(`[1]`)}, {json.RawMessage(`["b","c"]`)}, {false}, {json.RawMessage(`{}`)},
+	}))
+	start := time.Now().Unix()
+	backup, err = b.PackageBackup(ctx, opts)
+	assert.NoError(t, err)
+	collection := backup.GetCollectionBackups()[0]
+	assert.Equal(t, "coll", collection.GetCollectionName())
+	// the backup in milliseconds and its collection in seconds like the backups created from milvus
+	assert.WithinDuration(t, time.Now(), backupTime(backup), time.Minute)
+	assert.GreaterOrEqual(t, int64(collection.GetBackupPhysicalTimestamp()), start)
+	assert.LessOrEqual(t, int64(collection.GetBackupPhysicalTimestamp()), time.Now().Unix())
+	assert.Equal(t, utils.ComposeTS(int64(collection.GetBackupPhysicalTimestamp()), 0), collection.GetBackupTimestamp())
+	segments := collection.GetPartitionBackups()[0].GetSegmentBackups()
+	assert.Len(t, segments, 3)
+	assert.Equal(t, segments[0].GetSegmentId(), segments[0].GetGroupId())
+	// row ids, timestamps and the user fields
+	assert.Len(t, segments[0].GetBinlogs(), 9)
+	rowIDs, err := readBinlogFile(files["backup/dataset/binlogs/insert_log/"+fmt.Sprintf("%d/%d/%d/%d/0/1",
+		collection.GetCollectionId(), segments[2].GetPartitionId(), segments[2].GetGroupId(), segments[2].GetSegmentId())])
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(3)}, rowIDs.values)
+
+	exported, err := b.ExportBackup(ctx, "dataset", ExportOptions{Format: ExportFormatJSONL, OutputDir: dir})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), exported[0].Rows)
+	var rows []string
+	for _, file := range exported[0].Files {
+		data, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		rows = append(rows, strings.Split(strings.TrimSpace(string(data)), "\n")...)
+	}
+	assert.Equal(t, []string{
+		`{"id":9007199254740993,"name":"one","vector":[1,2],"meta":{"k":1},"tags":["a"],"flag":true,"$meta":{"color":"red"}}`,
+		`{"id":2,"name":"two","vector":[3,4],"meta":"s","tags":[],"flag":false,"$meta":{}}`,
+		`{"id":3,"name":"three","vector":[5,6],"meta":[1],"tags":["b","c"],"flag":false,"$meta":{}}`,
+		`{"id":4,"name":"four","vector":[7,8],"meta":null,"tags":["d"],"flag":true,"$meta":{}}`,
+	}, rows)
+
+	_, err = b.PackageBackup(ctx, opts)
+	assert.ErrorContains(t, err, "backup already exists")
+	opts.BackupName = "dataset2"
+	opts.Files = []string{schemaFile}
+	_, err = b.PackageBackup(ctx, opts)
+	assert.ErrorContains(t, err, "expect an array of rows")
+}
+
+func TestPackageValue(t *testing.T) {
+	value, err := packageValue(&backuppb.FieldSchema{DataType: backuppb.DataType_Int8}, json.Number("-128"))
+	assert.NoError(t, err)
+	assert.Equal(t, int32(-128), value)
+	_, err = packageValue(&backuppb.FieldSchema{DataType: backuppb.DataType_Int8}, json.Number("128"))
+	assert.ErrorContains(t, err, "out of range")
+	_, err = packageValue(&backuppb.FieldSchema{DataType: backuppb.DataType_Int64}, json.Number("1.5"))
+	assert.Error(t, err)
+
+	value, err = packageValue(&backuppb.FieldSchema{DataType: backuppb.DataType_SparseFloatVector}, `{"10":2,"3":0.5}`)
+	assert.NoError(t, err)
+	vector, err := sparseVectorJSON(value.([]byte))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"3":0.5,"10":2}`, string(vector))
+
+	value, err = packageValue(&backuppb.FieldSchema{DataType: backuppb.DataType_BinaryVector}, []interface{}{json.Number("255"), int64(1)})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte{255, 1}, value)
+	_, err = packageValue(&backuppb.FieldSchema{DataType: backuppb.DataType_Bool}, nil)
+	assert.ErrorContains(t, err, "null value")
+}
@@ -0,0 +1,124 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/common"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// collectionFailures records the collections failed in a backup with allow_partial
+type collectionFailures struct {
+	mu     sync.Mutex
+	failed map[collectionStruct]error
+}
+
+func newCollectionFailures() *collectionFailures {
+	return &collectionFailures{failed: make(map[collectionStruct]error)}
+}
+
+func (f *collectionFailures) add(collection collectionStruct, err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed[collection] = err
+}
+
+func (f *collectionFailures) len() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.failed)
+}
+
+// names returns db.collection of the failed collections, sorted
+func (f *collectionFailures) names() []string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	names := make([]string, 0, len(f.failed))
+	for collection := range f.failed {
+		names = append(names, collection.db+"."+collection.collectionName)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// remaining returns the collections not failed yet
+func (f *collectionFailures) remaining(collections []collectionStruct) []collectionStruct {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	remaining := make([]collectionStruct, 0, len(collections))
+	for _, collection := range collections {
+		if _, ok := f.failed[collection]; !ok {
+			remaining = append(remaining, collection)
+		}
+	}
+	return remaining
+}
+
+// remainingCollections returns the collections not failed, an error if all of them failed
+func remainingCollections(collections []collectionStruct, failures *collectionFailures) ([]collectionStruct, error) {
+	remaining := failures.remaining(collections)
+	if len(remaining) == 0 && failures.len() > 0 {
+		return nil, fmt.Errorf("all collections failed to backup: %s", strings.Join(failures.names(), ", "))
+	}
+	return remaining, nil
+}
+
+// isolateCollectionJob wraps the job of a collection in a backup with allow_partial. The error of the job is recorded
+// in the collection backup instead of failing the collection pool, so that the other collections go on. The job still
+// fails if ctx of the backup is canceled, like the backup is paused.
+func (b *BackupContext) isolateCollectionJob(ctx context.Context, allowPartial bool, backupInfo *backuppb.BackupInfo, collection collectionStruct, failures *collectionFailures, job common.Job) common.Job {
+	if !allowPartial {
+		return job
+	}
+	return func(jobCtx context.Context) error {
+		err := job(jobCtx)
+		if err == nil || ctx.Err() != nil {
+			return err
+		}
+		log.Warn("fail to backup collection, the other collections go on",
+			zap.String("backupName", backupInfo.GetName()),
+			zap.String("db", collection.db),
+			zap.String("collection", collection.collectionName),
+			zap.Error(err))
+		failures.add(collection, err)
+		b.markCollectionFailed(backupInfo, collection, err)
+		return nil
+	}
+}
+
+// markCollectionFailed records the failure in the collection backup. A collection failed before its meta is
+// backed up is recorded by name only.
+func (b *BackupContext) markCollectionFailed(backupInfo *backuppb.BackupInfo, collection collectionStruct, err error) {
+	b.progressMu.Lock()
+	defer b.progressMu.Unlock()
+	collectionBackup := findCollectionBackup(backupInfo, collection)
+	if collectionBackup == nil {
+		collectionBackup = &backuppb.CollectionBackupInfo{
+			Id:             utils.UUID(),
+			DbName:         collection.db,
+			CollectionName: collection.collectionName,
+		}
+		backupInfo.CollectionBackups = append(backupInfo.CollectionBackups, collectionBackup)
+	}
+	collectionBackup.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+	collectionBackup.ErrorMessage = err.Error()
+}
+
+// isFailedCollection returns true if the collection failed in a partial backup, its data is incomplete
+func isFailedCollection(collection *backuppb.CollectionBackupInfo) bool {
+	return collection.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_FAIL
+}
+
+// isFinishedBackup returns true if the backup is successful or partial, whose collections not failed can be restored
+func isFinishedBackup(backup *backuppb.BackupInfo) bool {
+	return backup.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_SUCCESS ||
+		backup.GetStateCode() == backuppb.BackupTaskStateCode_BACKUP_PARTIAL
+}
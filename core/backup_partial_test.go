@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestIsolateCollectionJob(t *testing.T) {
+	b := &BackupContext{}
+	coll1 := collectionStruct{"default", "coll1"}
+	coll2 := collectionStruct{"db1", "coll2"}
+	backupInfo := &backuppb.BackupInfo{
+		Name:              "partial",
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{DbName: "default", CollectionName: "coll1", Schema: &backuppb.CollectionSchema{}}},
+	}
+	failures := newCollectionFailures()
+	ctx := context.Background()
+	failed := func(ctx context.Context) error { return errors.New("describe collection failed") }
+
+	// without allow_partial the job fails the pool as before
+	assert.Error(t, b.isolateCollectionJob(ctx, false, backupInfo, coll1, failures, failed)(ctx))
+	assert.Equal(t, 0, failures.len())
+
+	assert.NoError(t, b.isolateCollectionJob(ctx, true, backupInfo, coll1, failures, failed)(ctx))
+	assert.NoError(t, b.isolateCollectionJob(ctx, true, backupInfo, coll2, failures, failed)(ctx))
+	assert.Equal(t, []string{"db1.coll2", "default.coll1"}, failures.names())
+	assert.Len(t, backupInfo.GetCollectionBackups(), 2)
+	assert.True(t, isFailedCollection(backupInfo.GetCollectionBackups()[0]))
+	assert.Equal(t, "describe collection failed", backupInfo.GetCollectionBackups()[0].GetErrorMessage())
+	// a collection failed before its meta is backed up is recorded by name
+	assert.Equal(t, "coll2", backupInfo.GetCollectionBackups()[1].GetCollectionName())
+	assert.True(t, isFailedCollection(backupInfo.GetCollectionBackups()[1]))
+
+	// the backup is canceled, e.g. paused
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	coll3 := collectionStruct{"default", "coll3"}
+	assert.Error(t, b.isolateCollectionJob(canceledCtx, true, backupInfo, coll3, failures, failed)(canceledCtx))
+	assert.Equal(t, 2, failures.len())
+
+	remaining, err := remainingCollections([]collectionStruct{coll1, coll2, coll3}, failures)
+	assert.NoError(t, err)
+	assert.Equal(t, []collectionStruct{coll3}, remaining)
+	_, err = remainingCollections([]collectionStruct{coll1, coll2}, failures)
+	assert.Error(t, err)
+	remaining, err = remainingCollections([]collectionStruct{}, newCollectionFailures())
+	assert.NoError(t, err)
+	assert.Empty(t, remaining)
+}
+
+func TestPartialBackupFinished(t *testing.T) {
+	succeeded := &backuppb.CollectionBackupInfo{Size: 100}
+	failed := &backuppb.CollectionBackupInfo{Size: 200, CopiedSize: 50, Progress: 25, StateCode: backuppb.BackupTaskStateCode_BACKUP_FAIL}
+	backupInfo := &backuppb.BackupInfo{
+		StateCode:         backuppb.BackupTaskStateCode_BACKUP_PARTIAL,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{succeeded, failed},
+	}
+	finishBackupProgress(backupInfo)
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_SUCCESS, succeeded.GetStateCode())
+	assert.Equal(t, int32(100), succeeded.GetProgress())
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_FAIL, failed.GetStateCode())
+	assert.Equal(t, int32(25), failed.GetProgress())
+	assert.True(t, isFinishedBackup(backupInfo))
+	assert.False(t, isFinishedBackup(&backuppb.BackupInfo{StateCode: backuppb.BackupTaskStateCode_BACKUP_FAIL}))
+
+	// the state of collections is kept in meta
+	level, err := treeToLevel(backupInfo)
+	assert.NoError(t, err)
+	assert.Equal(t, backuppb.BackupTaskStateCode_BACKUP_FAIL, level.collectionLevel.GetInfos()[1].GetStateCode())
+}
+
+func TestSelectOrphanedFilesOfPartialBackup(t *testing.T) {
+	b := &BackupContext{milvusRootPath: "files", backupRootPath: "backup"}
+	newCollection := func(id string, state backuppb.BackupTaskStateCode) *backuppb.CollectionBackupInfo {
+		return &backuppb.CollectionBackupInfo{
+			StateCode: state,
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{SegmentBackups: []*backuppb.SegmentBackupInfo{{
+				PartitionId: 2,
+				Binlogs: []*backuppb.FieldBinlog{{
+					Binlogs: []*backuppb.Binlog{{LogPath: "files/insert_log/1/2/" + id + "/100/1"}},
+				}},
+			}}}},
+		}
+	}
+	backups := []*backuppb.BackupInfo{{
+		Name:      "partial",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_PARTIAL,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			newCollection("3", backuppb.BackupTaskStateCode_BACKUP_SUCCESS),
+			// only some binlogs of the failed collection are copied
+			newCollection("4", backuppb.BackupTaskStateCode_BACKUP_FAIL),
+		},
+	}}
+	paths := []string{
+		"backup/partial/meta/backup_meta.json",
+		"backup/partial/binlogs/insert_log/1/2/3/100/1",
+		"backup/partial/binlogs/insert_log/1/2/4/100/1",
+	}
+	dirs, files := b.selectOrphanedFiles(paths, backups, map[string]bool{})
+	assert.Empty(t, dirs)
+	assert.Equal(t, []string{"backup/partial/binlogs/insert_log/1/2/4/100/1"}, files)
+}
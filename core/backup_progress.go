@@ -0,0 +1,80 @@
+package core
+
+import (
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// progressPercent returns the percentage of done in total, 0 if total is unknown
+func progressPercent(done, total int64) int32 {
+	if total <= 0 {
+		return 0
+	}
+	if done >= total {
+		return 100
+	}
+	return int32(100 * done / total)
+}
+
+// initBackupProgress sums the sizes of segments into partitions, collections and the backup, after the binlogs
+// of segments are listed. Segments copied before the backup is resumed are counted as copied.
+func initBackupProgress(backupInfo *backuppb.BackupInfo) {
+	var backupSize, backupCopied int64
+	for _, collection := range backupInfo.GetCollectionBackups() {
+		var collectionSize, collectionCopied int64
+		for _, partition := range collection.GetPartitionBackups() {
+			var partitionSize int64
+			for _, segment := range partition.GetSegmentBackups() {
+				partitionSize += segment.GetSize()
+				if segment.GetBackuped() {
+					collectionCopied += segment.GetSize()
+				}
+			}
+			partition.Size = partitionSize
+			collectionSize += partitionSize
+		}
+		collection.Size = collectionSize
+		collection.CopiedSize = collectionCopied
+		collection.Progress = progressPercent(collectionCopied, collectionSize)
+		backupSize += collectionSize
+		backupCopied += collectionCopied
+	}
+	backupInfo.Size = backupSize
+	backupInfo.CopiedSize = backupCopied
+	backupInfo.Progress = progressPercent(backupCopied, backupSize)
+}
+
+// addCopiedSize records the bytes copied into backup of a collection
+func (b *BackupContext) addCopiedSize(backupInfo *backuppb.BackupInfo, collectionBackup *backuppb.CollectionBackupInfo, size int64) {
+	b.progressMu.Lock()
+	defer b.progressMu.Unlock()
+	collectionBackup.CopiedSize += size
+	collectionBackup.Progress = progressPercent(collectionBackup.GetCopiedSize(), collectionBackup.GetSize())
+	backupInfo.CopiedSize += size
+	backupInfo.Progress = progressPercent(backupInfo.GetCopiedSize(), backupInfo.GetSize())
+}
+
+// finishBackupProgress marks the backup and all its collections completed, except the failed collections of a
+// partial backup
+func finishBackupProgress(backupInfo *backuppb.BackupInfo) {
+	for _, collection := range backupInfo.GetCollectionBackups() {
+		if isFailedCollection(collection) {
+			continue
+		}
+		collection.StateCode = backuppb.BackupTaskStateCode_BACKUP_SUCCESS
+		collection.CopiedSize = collection.GetSize()
+		collection.Progress = 100
+	}
+	backupInfo.CopiedSize = backupInfo.GetSize()
+	backupInfo.Progress = 100
+}
+
+// segmentGroupSize returns the size of the segments of a group in the partition, groupId -1 means all segments
+func segmentGroupSize(partition *backuppb.PartitionBackupInfo, groupId int64) int64 {
+	var size int64
+	for _, segment := range partition.GetSegmentBackups() {
+		if groupId == -1 || segment.GetGroupId() == groupId {
+			size += segment.GetSize()
+		}
+	}
+	return size
+}
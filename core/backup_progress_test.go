@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestBackupProgress(t *testing.T) {
+	coll1 := &backuppb.CollectionBackupInfo{
+		PartitionBackups: []*backuppb.PartitionBackupInfo{{
+			SegmentBackups: []*backuppb.SegmentBackupInfo{{Size: 100, Backuped: true}, {Size: 300}},
+		}},
+	}
+	coll2 := &backuppb.CollectionBackupInfo{
+		PartitionBackups: []*backuppb.PartitionBackupInfo{
+			{SegmentBackups: []*backuppb.SegmentBackupInfo{{Size: 200}}},
+			{SegmentBackups: []*backuppb.SegmentBackupInfo{{Size: 400}}},
+		},
+	}
+	backupInfo := &backuppb.BackupInfo{CollectionBackups: []*backuppb.CollectionBackupInfo{coll1, coll2}}
+
+	// segments copied before resumed are counted
+	initBackupProgress(backupInfo)
+	assert.Equal(t, int64(400), coll1.GetSize())
+	assert.Equal(t, int64(100), coll1.GetCopiedSize())
+	assert.Equal(t, int32(25), coll1.GetProgress())
+	assert.Equal(t, int64(600), coll2.GetSize())
+	assert.Equal(t, int64(200), coll2.GetPartitionBackups()[0].GetSize())
+	assert.Equal(t, int64(1000), backupInfo.GetSize())
+	assert.Equal(t, int32(10), backupInfo.GetProgress())
+
+	b := &BackupContext{}
+	b.addCopiedSize(backupInfo, coll2, 300)
+	assert.Equal(t, int32(50), coll2.GetProgress())
+	assert.Equal(t, int64(400), backupInfo.GetCopiedSize())
+	assert.Equal(t, int32(40), backupInfo.GetProgress())
+
+	finishBackupProgress(backupInfo)
+	assert.Equal(t, int32(100), coll1.GetProgress())
+	assert.Equal(t, int64(1000), backupInfo.GetCopiedSize())
+	assert.Equal(t, int32(100), backupInfo.GetProgress())
+
+	// empty backup
+	assert.Equal(t, int32(0), progressPercent(0, 0))
+	assert.Equal(t, int64(400), segmentGroupSize(coll1.GetPartitionBackups()[0], -1))
+	assert.Equal(t, int64(0), segmentGroupSize(coll1.GetPartitionBackups()[0], 1))
+}
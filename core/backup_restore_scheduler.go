@@ -0,0 +1,149 @@
+package core
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// kinds of the jobs of a restore, each kind has its own budget of jobs running at the same time
+const (
+	// creates the collection, its partitions and indexes
+	restoreJobCollection = "collection"
+	// bulk inserts a segment group into milvus
+	restoreJobBulkInsert = "bulkinsert"
+	// checks row counts, creates deferred indexes and aliases, and loads the collection after all groups are restored
+	restoreJobFinish = "finish"
+)
+
+// restoreJob is a node of the DAG of a restore, it runs after all the jobs it depends on succeed
+type restoreJob struct {
+	name string
+	kind string
+	run  func(ctx context.Context) error
+
+	// jobs it depends on which have not ended
+	pending  int
+	children []*restoreJob
+}
+
+// restoreScheduler runs the jobs of a restore by their dependencies. A job is queued once its dependencies succeed,
+// and started as soon as its kind has a free slot of budget, so that the bulk insert jobs of all collections and
+// partitions keep the import workers of milvus busy, instead of waiting the collections to be restored one by one.
+// The restore fails with the first error, the jobs running are waited and the others are not started.
+type restoreScheduler struct {
+	// budget of each kind of jobs, unlimited if not positive
+	budgets map[string]int
+
+	mu      sync.Mutex
+	jobs    []*restoreJob
+	queues  map[string][]*restoreJob
+	running map[string]int
+	// jobs neither ended nor dropped
+	remaining int
+	// most jobs of each kind running at the same time, logged after restore
+	peaks map[string]int
+	err   error
+	done  chan struct{}
+}
+
+func newRestoreScheduler(budgets map[string]int) *restoreScheduler {
+	return &restoreScheduler{
+		budgets: budgets,
+		queues:  make(map[string][]*restoreJob),
+		running: make(map[string]int),
+		peaks:   make(map[string]int),
+		done:    make(chan struct{}),
+	}
+}
+
+// add adds a job which runs after the jobs it depends on, should be called before Run
+func (s *restoreScheduler) add(name string, kind string, run func(ctx context.Context) error, dependencies ...*restoreJob) *restoreJob {
+	job := &restoreJob{name: name, kind: kind, run: run, pending: len(dependencies)}
+	for _, dependency := range dependencies {
+		dependency.children = append(dependency.children, job)
+	}
+	s.jobs = append(s.jobs, job)
+	return job
+}
+
+// Run runs all jobs and blocks until they end, returns the first error of the jobs
+func (s *restoreScheduler) Run(ctx context.Context) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	s.mu.Lock()
+	s.remaining = len(s.jobs)
+	if s.remaining == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	for _, job := range s.jobs {
+		if job.pending == 0 {
+			s.queues[job.kind] = append(s.queues[job.kind], job)
+		}
+	}
+	s.dispatch(ctx, cancel)
+	s.mu.Unlock()
+
+	<-s.done
+	log.Info("restore jobs end", zap.Int("jobs", len(s.jobs)), zap.Any("peakRunning", s.peaks), zap.Error(s.err))
+	return s.err
+}
+
+// dispatch starts the queued jobs into the free slots of their budgets, should be called with lock
+func (s *restoreScheduler) dispatch(ctx context.Context, cancel context.CancelFunc) {
+	if s.err != nil {
+		return
+	}
+	for kind, queue := range s.queues {
+		for len(queue) > 0 && (s.budgets[kind] <= 0 || s.running[kind] < s.budgets[kind]) {
+			job := queue[0]
+			queue = queue[1:]
+			s.running[kind]++
+			if s.running[kind] > s.peaks[kind] {
+				s.peaks[kind] = s.running[kind]
+			}
+			go s.execute(ctx, cancel, job)
+		}
+		s.queues[kind] = queue
+	}
+}
+
+func (s *restoreScheduler) execute(ctx context.Context, cancel context.CancelFunc, job *restoreJob) {
+	err := ctx.Err()
+	if err == nil {
+		err = job.run(ctx)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running[job.kind]--
+	s.remaining--
+	if err != nil {
+		if s.err == nil {
+			log.Warn("restore job fails, stop starting more jobs", zap.String("job", job.name), zap.Error(err))
+			s.err = err
+			cancel()
+		}
+	} else {
+		for _, child := range job.children {
+			child.pending--
+			if child.pending == 0 {
+				s.queues[child.kind] = append(s.queues[child.kind], child)
+			}
+		}
+	}
+	s.dispatch(ctx, cancel)
+
+	running := 0
+	for _, count := range s.running {
+		running += count
+	}
+	if s.remaining == 0 || (s.err != nil && running == 0) {
+		close(s.done)
+	}
+}
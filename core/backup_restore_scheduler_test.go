@@ -0,0 +1,73 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRestoreScheduler(t *testing.T) {
+	t.Run("dependencies and budgets", func(t *testing.T) {
+		scheduler := newRestoreScheduler(map[string]int{restoreJobCollection: 1, restoreJobBulkInsert: 3})
+		var mu sync.Mutex
+		ended := make(map[string]bool)
+		order := make([]string, 0)
+		record := func(name string, deps ...string) func(ctx context.Context) error {
+			return func(ctx context.Context) error {
+				time.Sleep(5 * time.Millisecond)
+				mu.Lock()
+				defer mu.Unlock()
+				for _, dep := range deps {
+					assert.True(t, ended[dep], "%s runs before %s", name, dep)
+				}
+				ended[name] = true
+				order = append(order, name)
+				return nil
+			}
+		}
+		for _, collection := range []string{"c1", "c2"} {
+			create := scheduler.add("create "+collection, restoreJobCollection, record("create "+collection))
+			groups := []*restoreJob{create}
+			groupNames := make([]string, 0)
+			for _, group := range []string{"g1", "g2", "g3", "g4"} {
+				name := collection + "/" + group
+				groups = append(groups, scheduler.add(name, restoreJobBulkInsert, record(name, "create "+collection), create))
+				groupNames = append(groupNames, name)
+			}
+			scheduler.add("finish "+collection, restoreJobFinish, record("finish "+collection, groupNames...), groups...)
+		}
+
+		assert.NoError(t, scheduler.Run(context.Background()))
+		assert.Len(t, order, 12)
+		assert.Equal(t, 1, scheduler.peaks[restoreJobCollection])
+		assert.LessOrEqual(t, scheduler.peaks[restoreJobBulkInsert], 3)
+	})
+
+	t.Run("first error stops", func(t *testing.T) {
+		scheduler := newRestoreScheduler(map[string]int{restoreJobBulkInsert: 1})
+		failure := errors.New("bulkinsert fails")
+		started := 0
+		create := scheduler.add("create", restoreJobCollection, func(ctx context.Context) error { return nil })
+		for i := 0; i < 3; i++ {
+			i := i
+			scheduler.add("bulkinsert", restoreJobBulkInsert, func(ctx context.Context) error {
+				started++
+				if i == 0 {
+					return failure
+				}
+				return nil
+			}, create)
+		}
+
+		assert.ErrorIs(t, scheduler.Run(context.Background()), failure)
+		assert.Equal(t, 1, started)
+	})
+
+	t.Run("no jobs", func(t *testing.T) {
+		assert.NoError(t, newRestoreScheduler(nil).Run(context.Background()))
+	})
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// states of a row count check
+const (
+	RowCountMatched    = "matched"
+	RowCountMismatched = "mismatched"
+	RowCountSkipped    = "skipped"
+)
+
+// rowCounter counts the rows of a partition in milvus, of the whole collection if the partition is empty
+type rowCounter func(ctx context.Context, partitionName string) (int64, error)
+
+// milvusRowCounter counts the rows of the partitions of a collection by the statistics of milvus, a partition not
+// exist has no rows
+func (b *BackupContext) milvusRowCounter(db, collectionName string) rowCounter {
+	return func(ctx context.Context, partitionName string) (int64, error) {
+		if partitionName != "" {
+			exist, err := b.getMilvusClient().HasPartition(ctx, db, collectionName, partitionName)
+			if err != nil {
+				return 0, err
+			}
+			if !exist {
+				return 0, nil
+			}
+		}
+		return b.getMilvusClient().GetRowCount(ctx, db, collectionName, partitionName)
+	}
+}
+
+// segmentRows sums the rows of the segments
+func segmentRows(segments []*backuppb.SegmentBackupInfo) int64 {
+	var rows int64
+	for _, segment := range segments {
+		rows += segment.GetNumOfRows()
+	}
+	return rows
+}
+
+// countMilvusRows records the rows of the partitions of the collection in milvus, called right after the segments to
+// backup are listed so that the rows inserted later are not counted
+func countMilvusRows(ctx context.Context, collectionBackup *backuppb.CollectionBackupInfo, count rowCounter) {
+	checks := make([]*backuppb.RowCountCheck, 0, len(collectionBackup.GetPartitionBackups()))
+	for _, partition := range collectionBackup.GetPartitionBackups() {
+		check := &backuppb.RowCountCheck{PartitionName: partition.GetPartitionName()}
+		rows, err := count(ctx, partition.GetPartitionName())
+		if err != nil {
+			check.State = RowCountSkipped
+			check.Detail = "fail to count the rows in milvus: " + err.Error()
+		} else {
+			check.MilvusRows = rows
+		}
+		checks = append(checks, check)
+	}
+	collectionBackup.RowCountChecks = checks
+}
+
+// compareBackupRows compares the rows in milvus counted before the copy with the rows of the segments backed up,
+// which may be other segments than listed if the segments are compacted during backup
+func compareBackupRows(collectionBackup *backuppb.CollectionBackupInfo) {
+	partitions := make(map[string]*backuppb.PartitionBackupInfo)
+	for _, partition := range collectionBackup.GetPartitionBackups() {
+		partitions[partition.GetPartitionName()] = partition
+	}
+	for _, check := range collectionBackup.GetRowCountChecks() {
+		if check.GetState() == RowCountSkipped {
+			continue
+		}
+		check.BackupRows = segmentRows(partitions[check.GetPartitionName()].GetSegmentBackups())
+		switch {
+		case check.GetBackupRows() == check.GetMilvusRows():
+			check.State = RowCountMatched
+			check.Detail = ""
+		case check.GetMilvusRows() > check.GetBackupRows() && collectionBackup.GetFlushState() != FlushStateFlushed:
+			check.State = RowCountMismatched
+			check.Detail = fmt.Sprintf("%d rows in milvus, %d rows in backup, the collection is not flushed (%s) and the rows not persisted are not backed up",
+				check.GetMilvusRows(), check.GetBackupRows(), collectionBackup.GetFlushState())
+		case check.GetMilvusRows() > check.GetBackupRows():
+			check.State = RowCountMismatched
+			check.Detail = fmt.Sprintf("%d rows in milvus, %d rows in backup, rows inserted during the flush are not backed up or segments are missed",
+				check.GetMilvusRows(), check.GetBackupRows())
+		default:
+			check.State = RowCountMismatched
+			check.Detail = fmt.Sprintf("%d rows in milvus, %d rows in backup, segments may be compacted during backup",
+				check.GetMilvusRows(), check.GetBackupRows())
+		}
+	}
+	logRowCountMismatches(collectionBackup.GetDbName(), collectionBackup.GetCollectionName(), collectionBackup.GetRowCountChecks())
+}
+
+// restoreRowCountChecks records the rows of the target partitions before the data is restored, partitions of
+// partition key collections are counted together as milvus may dispatch the rows into other partitions. Rows restored
+// to a point in time are not compared, neither are those of a restore resumed without the rows counted before it.
+func restoreRowCountChecks(ctx context.Context, task *backuppb.RestoreCollectionTask, count rowCounter) []*backuppb.RowCountCheck {
+	checks := make([]*backuppb.RowCountCheck, 0)
+	if isPartitionKeyCollection(task.GetCollBackup().GetSchema()) {
+		var rows int64
+		for _, partition := range task.GetCollBackup().GetPartitionBackups() {
+			rows += segmentRows(partition.GetSegmentBackups())
+		}
+		checks = append(checks, &backuppb.RowCountCheck{BackupRows: rows})
+	} else {
+		for _, partition := range task.GetCollBackup().GetPartitionBackups() {
+			checks = append(checks, &backuppb.RowCountCheck{PartitionName: partition.GetPartitionName(), BackupRows: segmentRows(partition.GetSegmentBackups())})
+		}
+	}
+	for _, check := range checks {
+		switch {
+		case task.GetRestoreTimestamp() != 0:
+			check.State = RowCountSkipped
+			check.Detail = "the data is restored to a point in time, rows after it are not restored"
+		case len(task.GetRestoredGroups()) > 0:
+			check.State = RowCountSkipped
+			check.Detail = "the restore is resumed, the rows before it are not counted"
+		default:
+			rows, err := count(ctx, check.GetPartitionName())
+			if err != nil {
+				check.State = RowCountSkipped
+				check.Detail = "fail to count the rows in milvus before restore: " + err.Error()
+			}
+			check.BaseRows = rows
+		}
+	}
+	return checks
+}
+
+// compareRestoredRows compares the rows of the target partitions after the data is restored with the rows before
+// restore and the rows in backup
+func compareRestoredRows(ctx context.Context, task *backuppb.RestoreCollectionTask, count rowCounter) {
+	for _, check := range task.GetRowCountChecks() {
+		if check.GetState() == RowCountSkipped {
+			continue
+		}
+		rows, err := count(ctx, check.GetPartitionName())
+		if err != nil {
+			check.State = RowCountSkipped
+			check.Detail = "fail to count the rows in milvus after restore: " + err.Error()
+			continue
+		}
+		check.MilvusRows = rows
+		expected := check.GetBaseRows() + check.GetBackupRows()
+		if rows == expected {
+			check.State = RowCountMatched
+			check.Detail = ""
+			continue
+		}
+		check.State = RowCountMismatched
+		check.Detail = fmt.Sprintf("%d rows in milvus after restore, expected %d rows in backup", rows, check.GetBackupRows())
+		if check.GetBaseRows() > 0 {
+			check.Detail = fmt.Sprintf("%d rows in milvus after restore, expected %d rows before restore and %d rows in backup",
+				rows, check.GetBaseRows(), check.GetBackupRows())
+		}
+	}
+	logRowCountMismatches(task.GetTargetDbName(), task.GetTargetCollectionName(), task.GetRowCountChecks())
+}
+
+// rowCountMismatches returns the mismatched checks of a collection as db.collection/partition: detail
+func rowCountMismatches(db, collectionName string, checks []*backuppb.RowCountCheck) []string {
+	mismatches := make([]string, 0)
+	for _, check := range checks {
+		if check.GetState() != RowCountMismatched {
+			continue
+		}
+		name := db + "." + collectionName
+		if check.GetPartitionName() != "" {
+			name += "/" + check.GetPartitionName()
+		}
+		mismatches = append(mismatches, name+": "+check.GetDetail())
+	}
+	return mismatches
+}
+
+// BackupRowCountMismatches returns the mismatched row count checks of all collections of a backup
+func BackupRowCountMismatches(backup *backuppb.BackupInfo) []string {
+	mismatches := make([]string, 0)
+	for _, collection := range backup.GetCollectionBackups() {
+		mismatches = append(mismatches, rowCountMismatches(collection.GetDbName(), collection.GetCollectionName(), collection.GetRowCountChecks())...)
+	}
+	return mismatches
+}
+
+// RestoreRowCountMismatches returns the mismatched row count checks of all collections of a restore
+func RestoreRowCountMismatches(task *backuppb.RestoreBackupTask) []string {
+	mismatches := make([]string, 0)
+	for _, collection := range task.GetCollectionRestoreTasks() {
+		mismatches = append(mismatches, rowCountMismatches(collection.GetTargetDbName(), collection.GetTargetCollectionName(), collection.GetRowCountChecks())...)
+	}
+	return mismatches
+}
+
+func logRowCountMismatches(db, collectionName string, checks []*backuppb.RowCountCheck) {
+	for _, mismatch := range rowCountMismatches(db, collectionName, checks) {
+		log.Warn("row count mismatch", zap.String("detail", mismatch))
+	}
+}
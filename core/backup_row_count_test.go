@@ -0,0 +1,85 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// staticRowCounter counts the rows of partitions by a map, a partition not in it fails to be counted
+func staticRowCounter(rows map[string]int64) rowCounter {
+	return func(ctx context.Context, partitionName string) (int64, error) {
+		count, ok := rows[partitionName]
+		if !ok {
+			return 0, errors.New("partition not found")
+		}
+		return count, nil
+	}
+}
+
+func rowCountCollection() *backuppb.CollectionBackupInfo {
+	return &backuppb.CollectionBackupInfo{
+		DbName:         "db1",
+		CollectionName: "coll1",
+		FlushState:     FlushStateFlushed,
+		PartitionBackups: []*backuppb.PartitionBackupInfo{
+			{PartitionName: "_default", SegmentBackups: []*backuppb.SegmentBackupInfo{{NumOfRows: 100}, {NumOfRows: 50}}},
+			{PartitionName: "p1", SegmentBackups: []*backuppb.SegmentBackupInfo{{NumOfRows: 10}}},
+			{PartitionName: "p2"},
+		},
+	}
+}
+
+func TestCompareBackupRows(t *testing.T) {
+	collection := rowCountCollection()
+	countMilvusRows(context.Background(), collection, staticRowCounter(map[string]int64{"_default": 150, "p1": 12}))
+	assert.Len(t, collection.GetRowCountChecks(), 3)
+	compareBackupRows(collection)
+	checks := collection.GetRowCountChecks()
+	assert.Equal(t, RowCountMatched, checks[0].GetState())
+	assert.Equal(t, int64(150), checks[0].GetBackupRows())
+	assert.Equal(t, RowCountMismatched, checks[1].GetState())
+	assert.Equal(t, int64(12), checks[1].GetMilvusRows())
+	assert.Equal(t, int64(10), checks[1].GetBackupRows())
+	assert.Contains(t, checks[1].GetDetail(), "rows inserted during the flush")
+	assert.Equal(t, RowCountSkipped, checks[2].GetState())
+	assert.Contains(t, checks[2].GetDetail(), "partition not found")
+
+	assert.Equal(t, []string{"db1.coll1/p1: " + checks[1].GetDetail()},
+		BackupRowCountMismatches(&backuppb.BackupInfo{CollectionBackups: []*backuppb.CollectionBackupInfo{collection}}))
+
+	// rows not persisted are not in backup of a collection not flushed
+	collection.FlushState = FlushStateSkipped
+	compareBackupRows(collection)
+	assert.Contains(t, checks[1].GetDetail(), "not flushed (skipped)")
+}
+
+func TestRestoreRowCountChecks(t *testing.T) {
+	task := &backuppb.RestoreCollectionTask{TargetDbName: "db2", TargetCollectionName: "coll2", CollBackup: rowCountCollection()}
+	task.RowCountChecks = restoreRowCountChecks(context.Background(), task, staticRowCounter(map[string]int64{"_default": 0, "p1": 5, "p2": 0}))
+	assert.Len(t, task.GetRowCountChecks(), 3)
+	assert.Equal(t, int64(5), task.GetRowCountChecks()[1].GetBaseRows())
+
+	compareRestoredRows(context.Background(), task, staticRowCounter(map[string]int64{"_default": 150, "p1": 10, "p2": 0}))
+	checks := task.GetRowCountChecks()
+	assert.Equal(t, RowCountMatched, checks[0].GetState())
+	assert.Equal(t, RowCountMismatched, checks[1].GetState())
+	assert.Equal(t, "10 rows in milvus after restore, expected 5 rows before restore and 10 rows in backup", checks[1].GetDetail())
+	assert.Equal(t, RowCountMatched, checks[2].GetState())
+	assert.Len(t, RestoreRowCountMismatches(&backuppb.RestoreBackupTask{CollectionRestoreTasks: []*backuppb.RestoreCollectionTask{task}}), 1)
+
+	// partitions of partition key collections are counted together
+	task.CollBackup.Schema = &backuppb.CollectionSchema{Fields: []*backuppb.FieldSchema{{Name: "key", IsPartitionKey: true}}}
+	checks = restoreRowCountChecks(context.Background(), task, staticRowCounter(map[string]int64{"": 0}))
+	assert.Len(t, checks, 1)
+	assert.Equal(t, int64(160), checks[0].GetBackupRows())
+
+	// rows restored to a point in time are not compared
+	task.RestoreTimestamp = 1700000000
+	checks = restoreRowCountChecks(context.Background(), task, staticRowCounter(nil))
+	assert.Equal(t, RowCountSkipped, checks[0].GetState())
+}
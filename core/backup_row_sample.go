@@ -0,0 +1,380 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/protobuf/jsonpb"
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// states of a sample verification
+const (
+	SampleVerified = "verified"
+	SampleSkipped  = "skipped"
+)
+
+const (
+	// primary keys queried to sample the rows from, the max query window of milvus by default
+	sampleQueryWindow = 16384
+	// time to wait for a restored collection to be loaded before its rows are verified
+	sampleLoadTimeout    = 10 * time.Minute
+	sampleLoadCheckDelay = 2 * time.Second
+)
+
+// sampleCollectionRows samples n rows of a loaded collection right after it is flushed and writes them into the
+// backup, the rows are picked at random from the persisted rows of the first primary keys queried. A failure of
+// sampling doesn't fail the backup, the collection just has no rows sampled.
+func (b *BackupContext) sampleCollectionRows(ctx context.Context, backupName string, collectionBackup *backuppb.CollectionBackupInfo, n int) {
+	db, collectionName := collectionBackup.GetDbName(), collectionBackup.GetCollectionName()
+	if collectionBackup.GetLoadState() == LoadState_NotLoad {
+		log.Warn("collection is not loaded, rows are not sampled", zap.String("db", db), zap.String("collection", collectionName))
+		return
+	}
+	pk := primaryKeyField(collectionBackup.GetSchema())
+	if pk == nil {
+		return
+	}
+	pks, err := b.getMilvusClient().Query(ctx, db, collectionName, allRowsExpr(pk.GetName(), pk.GetDataType()), []string{pk.GetName()}, sampleQueryWindow, true)
+	if err != nil {
+		log.Warn("fail to query the primary keys to sample", zap.String("db", db), zap.String("collection", collectionName), zap.Error(err))
+		return
+	}
+	pkData := fieldDataById(pks, pk.GetFieldID())
+	if fieldRowNum(pkData) == 0 {
+		return
+	}
+	sampled := pickRows(pkData, n, rand.New(rand.NewSource(time.Now().UnixNano())))
+	rows, err := b.getMilvusClient().Query(ctx, db, collectionName, pksExpr(pk.GetName(), sampled), []string{"*"}, 0, true)
+	if err != nil {
+		log.Warn("fail to query the rows sampled", zap.String("db", db), zap.String("collection", collectionName), zap.Error(err))
+		return
+	}
+	data, err := (&jsonpb.Marshaler{}).MarshalToString(&milvuspb.QueryResults{CollectionName: collectionName, FieldsData: rows})
+	if err != nil {
+		log.Warn("fail to marshal the rows sampled", zap.String("collection", collectionName), zap.Error(err))
+		return
+	}
+	// the rows are user data, encrypted like the binlogs
+	samplePath := RowSamplePath(b.backupRootPath+SEPERATOR+backupName, collectionBackup.GetCollectionId())
+	if err := b.writeBackupFile(ctx, samplePath, []byte(data)); err != nil {
+		log.Warn("fail to write the rows sampled", zap.String("path", samplePath), zap.Error(err))
+		return
+	}
+	collectionBackup.SampledRows = int32(fieldRowNum(fieldDataById(rows, pk.GetFieldID())))
+	log.Info("sample rows of collection", zap.String("db", db), zap.String("collection", collectionName), zap.Int32("rows", collectionBackup.GetSampledRows()))
+}
+
+// verifyRestoredSample queries the rows sampled in backup in the restored collection once it is loaded, and compares
+// their fields with backup
+func (b *BackupContext) verifyRestoredSample(ctx context.Context, backupBucketName string, backupPath string, task *backuppb.RestoreCollectionTask) *backuppb.SampleVerification {
+	db, collectionName := task.GetTargetDbName(), task.GetTargetCollectionName()
+	skipped := func(detail string) *backuppb.SampleVerification {
+		log.Warn("skip the verification of the rows sampled", zap.String("db", db), zap.String("collection", collectionName), zap.String("reason", detail))
+		return &backuppb.SampleVerification{State: SampleSkipped, Detail: detail}
+	}
+	if task.GetCollBackup().GetSampledRows() == 0 {
+		return skipped("no rows are sampled in backup, create the backup with sample_rows")
+	}
+	if task.GetRestoreTimestamp() != 0 {
+		return skipped("the data is restored to a point in time, rows sampled may be written after it")
+	}
+	data, err := b.readBackupFile(ctx, backupBucketName, RowSamplePath(backupPath, task.GetCollBackup().GetCollectionId()))
+	if err != nil {
+		return skipped("fail to read the rows sampled: " + err.Error())
+	}
+	sample := &milvuspb.QueryResults{}
+	if err := jsonpb.UnmarshalString(string(data), sample); err != nil {
+		return skipped("fail to unmarshal the rows sampled: " + err.Error())
+	}
+	if err := b.waitCollectionLoaded(ctx, db, collectionName, task.GetLoadCollection()); err != nil {
+		return skipped(err.Error())
+	}
+	collection, err := b.getMilvusClient().DescribeCollection(ctx, db, collectionName)
+	if err != nil {
+		return skipped("fail to describe the restored collection: " + err.Error())
+	}
+	pk := primaryKeyField(task.GetCollBackup().GetSchema())
+	pkData := fieldDataById(sample.GetFieldsData(), pk.GetFieldID())
+	if n := int(task.GetVerifySampleRows()); n < fieldRowNum(pkData) {
+		pkData = pickRows(pkData, n, nil)
+	}
+	restored, err := b.getMilvusClient().Query(ctx, db, collectionName, pksExpr(collection.Schema.PKFieldName(), pkData), []string{"*"}, 0, false)
+	if err != nil {
+		return skipped("fail to query the rows sampled in the restored collection: " + err.Error())
+	}
+	skippedFields := make(map[int64]bool)
+	for _, id := range task.GetSkippedFieldIds() {
+		skippedFields[id] = true
+	}
+	verification := compareSampleRows(sample.GetFieldsData(), pk.GetFieldID(), pkData, restored, task.GetFieldIdMappings(), skippedFields)
+	log.Info("verify the rows sampled in restored collection",
+		zap.String("db", db),
+		zap.String("collection", collectionName),
+		zap.Int32("checkedRows", verification.GetCheckedRows()),
+		zap.Int32("matchedRows", verification.GetMatchedRows()))
+	return verification
+}
+
+// verifySampleRows returns the rows to verify of the request, no data of a meta only restore is verified
+func verifySampleRows(request *backuppb.RestoreBackupRequest) int32 {
+	if request.GetMetaOnly() {
+		return 0
+	}
+	return request.GetVerifySampleRows()
+}
+
+// waitCollectionLoaded waits for a collection being loaded by the restore until sampleLoadTimeout, other collections
+// are only verified if they are loaded already
+func (b *BackupContext) waitCollectionLoaded(ctx context.Context, db, collectionName string, loading bool) error {
+	deadline := time.Now().Add(sampleLoadTimeout)
+	for {
+		progress, err := b.getMilvusClient().GetLoadingProgress(ctx, db, collectionName, nil)
+		if err == nil && progress == 100 {
+			return nil
+		}
+		if !loading {
+			return fmt.Errorf("the restored collection is not loaded, restore with load_collection to verify the rows sampled")
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("the restored collection is not loaded in %s, progress %d", sampleLoadTimeout, progress)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(sampleLoadCheckDelay):
+		}
+	}
+}
+
+// compareSampleRows compares the rows sampled of the primary keys pkData with the rows restored, fields of backup are
+// found in the restored rows by the field ids mapped, the fields skipped and the fields not returned are not compared
+func compareSampleRows(sample []*schemapb.FieldData, pkFieldId int64, pkData *schemapb.FieldData, restored []*schemapb.FieldData,
+	fieldIdMappings map[int64]int64, skippedFields map[int64]bool) *backuppb.SampleVerification {
+	targetFieldId := func(id int64) int64 {
+		if target, ok := fieldIdMappings[id]; ok {
+			return target
+		}
+		return id
+	}
+	sampleRows := rowsByPk(fieldDataById(sample, pkFieldId))
+	restoredRows := rowsByPk(fieldDataById(restored, targetFieldId(pkFieldId)))
+	verification := &backuppb.SampleVerification{State: SampleVerified}
+	for _, pk := range pkStrings(pkData) {
+		verification.CheckedRows++
+		restoredRow, ok := restoredRows[pk]
+		if !ok {
+			verification.MissingPks = append(verification.MissingPks, pk)
+			continue
+		}
+		mismatched := make([]string, 0)
+		for _, field := range sample {
+			if skippedFields[field.GetFieldId()] {
+				continue
+			}
+			target := fieldDataById(restored, targetFieldId(field.GetFieldId()))
+			if target == nil {
+				continue
+			}
+			expected, ok := fieldRowValue(field, sampleRows[pk])
+			if !ok {
+				continue
+			}
+			actual, _ := fieldRowValue(target, restoredRow)
+			if !sameFieldValue(expected, actual) {
+				mismatched = append(mismatched, field.GetFieldName())
+			}
+		}
+		if len(mismatched) > 0 {
+			verification.MismatchedRows = append(verification.MismatchedRows, pk+": "+strings.Join(mismatched, ", "))
+			continue
+		}
+		verification.MatchedRows++
+	}
+	verification.Score = 1
+	if verification.GetCheckedRows() > 0 {
+		verification.Score = float64(verification.GetMatchedRows()) / float64(verification.GetCheckedRows())
+	}
+	return verification
+}
+
+// RestoreSampleMismatches returns the collections of a restore whose rows sampled mismatch, with their scores
+func RestoreSampleMismatches(task *backuppb.RestoreBackupTask) []string {
+	mismatches := make([]string, 0)
+	for _, collection := range task.GetCollectionRestoreTasks() {
+		verification := collection.GetSampleVerification()
+		if verification.GetState() != SampleVerified || verification.GetMatchedRows() == verification.GetCheckedRows() {
+			continue
+		}
+		mismatches = append(mismatches, fmt.Sprintf("%s.%s: %d of %d rows match, score %.2f", collection.GetTargetDbName(),
+			collection.GetTargetCollectionName(), verification.GetMatchedRows(), verification.GetCheckedRows(), verification.GetScore()))
+	}
+	return mismatches
+}
+
+func primaryKeyField(schema *backuppb.CollectionSchema) *backuppb.FieldSchema {
+	for _, field := range schema.GetFields() {
+		if field.GetIsPrimaryKey() {
+			return field
+		}
+	}
+	return nil
+}
+
+func fieldDataById(fields []*schemapb.FieldData, fieldId int64) *schemapb.FieldData {
+	for _, field := range fields {
+		if field.GetFieldId() == fieldId {
+			return field
+		}
+	}
+	return nil
+}
+
+// allRowsExpr matches all the rows by the primary key
+func allRowsExpr(pkName string, dataType backuppb.DataType) string {
+	if dataType == backuppb.DataType_VarChar {
+		return pkName + ` >= ""`
+	}
+	return fmt.Sprintf("%s < 0 || %s >= 0", pkName, pkName)
+}
+
+// pksExpr matches the rows of the primary keys
+func pksExpr(pkName string, pkData *schemapb.FieldData) string {
+	values := make([]string, 0)
+	if strs := pkData.GetScalars().GetStringData(); strs != nil {
+		for _, value := range strs.GetData() {
+			values = append(values, strconv.Quote(value))
+		}
+	} else {
+		values = pkStrings(pkData)
+	}
+	return pkName + " in [" + strings.Join(values, ",") + "]"
+}
+
+func pkStrings(pkData *schemapb.FieldData) []string {
+	if strs := pkData.GetScalars().GetStringData(); strs != nil {
+		return strs.GetData()
+	}
+	values := make([]string, 0)
+	for _, value := range pkData.GetScalars().GetLongData().GetData() {
+		values = append(values, strconv.FormatInt(value, 10))
+	}
+	return values
+}
+
+func rowsByPk(pkData *schemapb.FieldData) map[string]int {
+	rows := make(map[string]int)
+	for row, pk := range pkStrings(pkData) {
+		rows[pk] = row
+	}
+	return rows
+}
+
+// pickRows returns n primary keys of pkData, picked at random by r, the first n if r is nil
+func pickRows(pkData *schemapb.FieldData, n int, r *rand.Rand) *schemapb.FieldData {
+	total := fieldRowNum(pkData)
+	indexes := make([]int, total)
+	for i := range indexes {
+		indexes[i] = i
+	}
+	if r != nil {
+		r.Shuffle(total, func(i, j int) { indexes[i], indexes[j] = indexes[j], indexes[i] })
+	}
+	if n < total {
+		indexes = indexes[:n]
+	}
+	picked := &schemapb.FieldData{Type: pkData.GetType(), FieldName: pkData.GetFieldName(), FieldId: pkData.GetFieldId()}
+	if strs := pkData.GetScalars().GetStringData(); strs != nil {
+		values := make([]string, 0, len(indexes))
+		for _, i := range indexes {
+			values = append(values, strs.GetData()[i])
+		}
+		picked.Field = &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: values}}}}
+		return picked
+	}
+	values := make([]int64, 0, len(indexes))
+	for _, i := range indexes {
+		values = append(values, pkData.GetScalars().GetLongData().GetData()[i])
+	}
+	picked.Field = &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: values}}}}
+	return picked
+}
+
+// fieldRowNum returns the number of rows of a primary key field
+func fieldRowNum(pkData *schemapb.FieldData) int {
+	return len(pkStrings(pkData))
+}
+
+// fieldRowValue returns the value of a row of the field, false if the type is not supported to compare
+func fieldRowValue(field *schemapb.FieldData, row int) (interface{}, bool) {
+	if scalars := field.GetScalars(); scalars != nil {
+		var values reflect.Value
+		switch data := scalars.GetData().(type) {
+		case *schemapb.ScalarField_BoolData:
+			values = reflect.ValueOf(data.BoolData.GetData())
+		case *schemapb.ScalarField_IntData:
+			values = reflect.ValueOf(data.IntData.GetData())
+		case *schemapb.ScalarField_LongData:
+			values = reflect.ValueOf(data.LongData.GetData())
+		case *schemapb.ScalarField_FloatData:
+			values = reflect.ValueOf(data.FloatData.GetData())
+		case *schemapb.ScalarField_DoubleData:
+			values = reflect.ValueOf(data.DoubleData.GetData())
+		case *schemapb.ScalarField_StringData:
+			values = reflect.ValueOf(data.StringData.GetData())
+		case *schemapb.ScalarField_BytesData:
+			values = reflect.ValueOf(data.BytesData.GetData())
+		case *schemapb.ScalarField_ArrayData:
+			values = reflect.ValueOf(data.ArrayData.GetData())
+		case *schemapb.ScalarField_JsonData:
+			values = reflect.ValueOf(data.JsonData.GetData())
+		default:
+			return nil, false
+		}
+		if row >= values.Len() {
+			return nil, true
+		}
+		return values.Index(row).Interface(), true
+	}
+	vectors := field.GetVectors()
+	dim := int(vectors.GetDim())
+	var values []byte
+	var width int
+	switch data := vectors.GetData().(type) {
+	case *schemapb.VectorField_FloatVector:
+		floats := data.FloatVector.GetData()
+		if (row+1)*dim > len(floats) {
+			return nil, true
+		}
+		return floats[row*dim : (row+1)*dim], true
+	case *schemapb.VectorField_BinaryVector:
+		values, width = data.BinaryVector, dim/8
+	case *schemapb.VectorField_Float16Vector:
+		values, width = data.Float16Vector, dim*2
+	default:
+		return nil, false
+	}
+	if (row+1)*width > len(values) {
+		return nil, true
+	}
+	return values[row*width : (row+1)*width], true
+}
+
+func sameFieldValue(expected, actual interface{}) bool {
+	if message, ok := expected.(proto.Message); ok {
+		other, ok := actual.(proto.Message)
+		return ok && proto.Equal(message, other)
+	}
+	return reflect.DeepEqual(expected, actual)
+}
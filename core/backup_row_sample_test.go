@@ -0,0 +1,85 @@
+package core
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func longField(id int64, name string, values ...int64) *schemapb.FieldData {
+	return &schemapb.FieldData{Type: schemapb.DataType_Int64, FieldName: name, FieldId: id,
+		Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{Data: &schemapb.ScalarField_LongData{LongData: &schemapb.LongArray{Data: values}}}}}
+}
+
+func floatVectorField(id int64, name string, dim int64, values ...float32) *schemapb.FieldData {
+	return &schemapb.FieldData{Type: schemapb.DataType_FloatVector, FieldName: name, FieldId: id,
+		Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{Dim: dim, Data: &schemapb.VectorField_FloatVector{FloatVector: &schemapb.FloatArray{Data: values}}}}}
+}
+
+func TestFieldRowValue(t *testing.T) {
+	value, ok := fieldRowValue(longField(1, "id", 5, 6), 1)
+	assert.True(t, ok)
+	assert.Equal(t, int64(6), value)
+
+	value, _ = fieldRowValue(floatVectorField(2, "vector", 2, 1, 2, 3, 4), 1)
+	assert.Equal(t, []float32{3, 4}, value)
+
+	binary := &schemapb.FieldData{Field: &schemapb.FieldData_Vectors{Vectors: &schemapb.VectorField{Dim: 16,
+		Data: &schemapb.VectorField_BinaryVector{BinaryVector: []byte{1, 2, 3, 4}}}}}
+	value, _ = fieldRowValue(binary, 1)
+	assert.Equal(t, []byte{3, 4}, value)
+
+	// a row out of the data has no value to compare
+	value, ok = fieldRowValue(longField(1, "id", 5), 1)
+	assert.True(t, ok)
+	assert.Nil(t, value)
+	_, ok = fieldRowValue(&schemapb.FieldData{}, 0)
+	assert.False(t, ok)
+}
+
+func TestPickRows(t *testing.T) {
+	pks := longField(100, "id", 1, 2, 3, 4, 5)
+	picked := pickRows(pks, 3, rand.New(rand.NewSource(1)))
+	assert.Equal(t, 3, fieldRowNum(picked))
+	assert.Equal(t, int64(100), picked.GetFieldId())
+	assert.Equal(t, []string{"1", "2"}, pkStrings(pickRows(pks, 2, nil)))
+	assert.Equal(t, "id in [1,2]", pksExpr("id", pickRows(pks, 2, nil)))
+
+	strs := &schemapb.FieldData{Field: &schemapb.FieldData_Scalars{Scalars: &schemapb.ScalarField{
+		Data: &schemapb.ScalarField_StringData{StringData: &schemapb.StringArray{Data: []string{"a", `b"c`}}}}}}
+	assert.Equal(t, `pk in ["a","b\"c"]`, pksExpr("pk", strs))
+	assert.Equal(t, `pk >= ""`, allRowsExpr("pk", backuppb.DataType_VarChar))
+	assert.Equal(t, "id < 0 || id >= 0", allRowsExpr("id", backuppb.DataType_Int64))
+}
+
+func TestCompareSampleRows(t *testing.T) {
+	sample := []*schemapb.FieldData{
+		longField(100, "id", 1, 2, 3),
+		longField(101, "age", 10, 20, 30),
+		floatVectorField(102, "vector", 2, 1, 1, 2, 2, 3, 3),
+		longField(103, "dropped", 7, 8, 9),
+	}
+	// rows restored in another order into fields of other ids, id 3 is missing and the vector of id 2 differs
+	restored := []*schemapb.FieldData{
+		longField(200, "id", 2, 1),
+		longField(201, "age", 20, 10),
+		floatVectorField(202, "vector", 2, 2, 2.5, 1, 1),
+	}
+	mappings := map[int64]int64{100: 200, 101: 201, 102: 202, 103: 203}
+	verification := compareSampleRows(sample, 100, sample[0], restored, mappings, nil)
+	assert.Equal(t, SampleVerified, verification.GetState())
+	assert.Equal(t, int32(3), verification.GetCheckedRows())
+	assert.Equal(t, int32(1), verification.GetMatchedRows())
+	assert.Equal(t, []string{"3"}, verification.GetMissingPks())
+	assert.Equal(t, []string{"2: vector"}, verification.GetMismatchedRows())
+	assert.InDelta(t, 1.0/3, verification.GetScore(), 1e-9)
+
+	// fields skipped are not compared
+	verification = compareSampleRows(sample, 100, pickRows(sample[0], 2, nil), restored, mappings, map[int64]bool{102: true})
+	assert.Equal(t, int32(2), verification.GetMatchedRows())
+	assert.Equal(t, float64(1), verification.GetScore())
+}
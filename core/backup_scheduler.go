@@ -0,0 +1,140 @@
+package core
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// Scheduler creates backups periodically by the cron expressions of schedule jobs,
+// and prunes the expired backups after backup if the job requires.
+type Scheduler struct {
+	backupContext *BackupContext
+	cron          *cron.Cron
+
+	mu   sync.RWMutex
+	jobs []*scheduleJob
+}
+
+type scheduleJob struct {
+	config  paramtable.ScheduleJobConfig
+	entryID cron.EntryID
+	status  *backuppb.ScheduleJobStatus
+}
+
+// NewScheduler creates a scheduler with the schedule jobs in config, return error if any cron expression is invalid
+func NewScheduler(backupContext *BackupContext, cfg paramtable.ScheduleConfig) (*Scheduler, error) {
+	s := &Scheduler{
+		backupContext: backupContext,
+		// skip the run if the last run of the same job is still running
+		cron: cron.New(cron.WithChain(cron.SkipIfStillRunning(cron.DiscardLogger))),
+		jobs: make([]*scheduleJob, 0, len(cfg.Jobs)),
+	}
+
+	for _, jobCfg := range cfg.Jobs {
+		if err := utils.ValidateBackupName(jobCfg.Name, BACKUP_NAME); err != nil {
+			return nil, fmt.Errorf("invalid schedule job name: %w", err)
+		}
+		job := &scheduleJob{
+			config: jobCfg,
+			status: &backuppb.ScheduleJobStatus{
+				Name:            jobCfg.Name,
+				Cron:            jobCfg.Cron,
+				CollectionNames: jobCfg.CollectionNames,
+				Prune:           jobCfg.Prune,
+			},
+		}
+		entryID, err := s.cron.AddFunc(jobCfg.Cron, func() { s.run(job) })
+		if err != nil {
+			return nil, fmt.Errorf("invalid cron of schedule job %s: %w", jobCfg.Name, err)
+		}
+		job.entryID = entryID
+		s.jobs = append(s.jobs, job)
+	}
+	return s, nil
+}
+
+// Start starts to run the schedule jobs in background
+func (s *Scheduler) Start() {
+	log.Info("start backup scheduler", zap.Int("jobs", len(s.jobs)))
+	s.cron.Start()
+}
+
+// Stop stops the scheduler and waits for the running jobs to finish
+func (s *Scheduler) Stop() {
+	<-s.cron.Stop().Done()
+	log.Info("backup scheduler stopped")
+}
+
+// Status returns the status of all schedule jobs
+func (s *Scheduler) Status() []*backuppb.ScheduleJobStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	statuses := make([]*backuppb.ScheduleJobStatus, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		status := *job.status
+		if entry := s.cron.Entry(job.entryID); entry.Schedule != nil {
+			status.NextRunTime = entry.Schedule.Next(time.Now()).Unix()
+		}
+		statuses = append(statuses, &status)
+	}
+	return statuses
+}
+
+func (s *Scheduler) run(job *scheduleJob) {
+	backupName := job.config.Name + "_" + time.Now().UTC().Format("2006_01_02_15_04_05")
+	log.Info("schedule job start", zap.String("job", job.config.Name), zap.String("backupName", backupName))
+	s.updateStatus(job, func(status *backuppb.ScheduleJobStatus) {
+		status.Running = true
+		status.LastStartTime = time.Now().Unix()
+		status.LastBackupName = backupName
+	})
+
+	code, msg := s.backupAndPrune(job, backupName)
+
+	log.Info("schedule job finish",
+		zap.String("job", job.config.Name),
+		zap.String("backupName", backupName),
+		zap.Int32("code", int32(code)),
+		zap.String("msg", msg))
+	s.updateStatus(job, func(status *backuppb.ScheduleJobStatus) {
+		status.Running = false
+		status.LastEndTime = time.Now().Unix()
+		status.LastCode = code
+		status.LastMsg = msg
+	})
+}
+
+func (s *Scheduler) backupAndPrune(job *scheduleJob, backupName string) (backuppb.ResponseCode, string) {
+	ctx := s.backupContext.ctx
+	createResp := s.backupContext.CreateBackup(ctx, &backuppb.CreateBackupRequest{
+		BackupName:      backupName,
+		CollectionNames: job.config.CollectionNames,
+	})
+	if createResp.GetCode() != backuppb.ResponseCode_Success {
+		return createResp.GetCode(), createResp.GetMsg()
+	}
+
+	if !job.config.Prune || !s.backupContext.params.RetentionCfg.Enabled() {
+		return createResp.GetCode(), createResp.GetMsg()
+	}
+	pruneResp := s.backupContext.PruneBackups(ctx, &backuppb.PruneBackupsRequest{})
+	if pruneResp.GetCode() != backuppb.ResponseCode_Success {
+		return pruneResp.GetCode(), "backup success but fail to prune: " + pruneResp.GetMsg()
+	}
+	return pruneResp.GetCode(), pruneResp.GetMsg()
+}
+
+func (s *Scheduler) updateStatus(job *scheduleJob, update func(status *backuppb.ScheduleJobStatus)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(job.status)
+}
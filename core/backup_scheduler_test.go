@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+func TestNewScheduler(t *testing.T) {
+	scheduler, err := NewScheduler(nil, paramtable.ScheduleConfig{
+		Jobs: []paramtable.ScheduleJobConfig{
+			{Name: "daily", Cron: "0 2 * * *", CollectionNames: []string{"coll"}, Prune: true},
+			{Name: "hourly", Cron: "@every 1h"},
+		},
+	})
+	assert.NoError(t, err)
+	scheduler.Start()
+	defer scheduler.Stop()
+
+	statuses := scheduler.Status()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "daily", statuses[0].GetName())
+	assert.Equal(t, []string{"coll"}, statuses[0].GetCollectionNames())
+	assert.True(t, statuses[0].GetPrune())
+	assert.NotZero(t, statuses[0].GetNextRunTime())
+	assert.Zero(t, statuses[0].GetLastStartTime())
+	assert.Equal(t, "hourly", statuses[1].GetName())
+
+	_, err = NewScheduler(nil, paramtable.ScheduleConfig{
+		Jobs: []paramtable.ScheduleJobConfig{{Name: "broken", Cron: "0 2 * *"}},
+	})
+	assert.Error(t, err)
+
+	// job names are the prefixes of backup names, which can have hyphens
+	_, err = NewScheduler(nil, paramtable.ScheduleConfig{
+		Jobs: []paramtable.ScheduleJobConfig{{Name: "daily-job", Cron: "0 2 * * *"}},
+	})
+	assert.NoError(t, err)
+
+	_, err = NewScheduler(nil, paramtable.ScheduleConfig{
+		Jobs: []paramtable.ScheduleJobConfig{{Name: "daily.job", Cron: "0 2 * * *"}},
+	})
+	assert.Error(t, err)
+}
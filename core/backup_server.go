@@ -2,15 +2,28 @@ package core
 
 import (
 	"context"
+	"fmt"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
+	"github.com/swaggo/swag"
 	"github.com/zilliztech/milvus-backup/core/paramtable"
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/kube"
 	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+	"github.com/zilliztech/milvus-backup/internal/openapi"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"net"
 	"net/http"
 	"net/http/pprof"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 )
 
 const (
@@ -19,21 +32,61 @@ const (
 	LIST_BACKUPS_API   = "/list"
 	GET_BACKUP_API     = "/get_backup"
 	DELETE_BACKUP_API  = "/delete"
+	PAUSE_BACKUP_API   = "/pause"
+	RESUME_BACKUP_API  = "/resume"
+	PRUNE_BACKUPS_API  = "/prune"
+	GC_API             = "/gc"
+	VERIFY_BACKUP_API  = "/verify"
+	ESTIMATE_API       = "/estimate"
 	RESTORE_BACKUP_API = "/restore"
 	GET_RESTORE_API    = "/get_restore"
+	GET_SCHEDULE_API   = "/schedule"
+	JOB_API            = "/jobs/:id"
+	LIST_JOBS_API      = "/jobs"
+	RESUME_JOB_API     = "/jobs/:id/resume"
 
 	API_V1_PREFIX = "/api/v1"
 
 	DOCS_API = "/docs/*any"
+	// OpenAPI 3.0 spec converted from the swagger 2.0 one of the docs
+	OPENAPI_API = "/openapi.json"
 
 	CHECK_API = "/check"
+
+	CHECK_COMPATIBILITY_API = "/check_compatibility"
+
+	// recovery point of continuous backup
+	GET_RECOVERY_POINT_API = "/recovery_point"
+
+	// metrics are served out of API_V1_PREFIX, the default path scraped by prometheus
+	METRICS_API = "/metrics"
+
+	// probes of kubernetes and monitoring, served out of API_V1_PREFIX without authentication
+	HEALTHZ_API = "/healthz"
+	READYZ_API  = "/readyz"
+	VERSION_API = "/version"
 )
 
+// httpLog writes the logs of the api server as the http module, at the level of log.modules.http
+var httpLog = log.NewModuleLogger(log.ModuleHTTP)
+
 // Server is the Backup Server
 type Server struct {
 	backupContext *BackupContext
 	engine        *gin.Engine
 	config        *BackupConfig
+	// nil if schedule is not enabled
+	scheduler *Scheduler
+	// nil if continuous backup is not enabled
+	continuous *ContinuousBackup
+	// nil if controller is not enabled
+	controller *BackupController
+	// nil if tls is not enabled
+	certReloader *certReloader
+	// nil if authentication is not enabled
+	auth *authenticator
+	// nil if grpc is not enabled
+	grpcServer *grpc.Server
 }
 
 func NewServer(ctx context.Context, params paramtable.BackupParams, opts ...BackupOption) (*Server, error) {
@@ -46,24 +99,72 @@ func NewServer(ctx context.Context, params paramtable.BackupParams, opts ...Back
 	if err != nil {
 		return nil, err
 	}
-	return &Server{
+	server := &Server{
 		backupContext: backupContext,
 		config:        c,
-	}, nil
+	}
+	if c.schedule {
+		scheduler, err := NewScheduler(backupContext, params.ScheduleCfg)
+		if err != nil {
+			return nil, err
+		}
+		server.scheduler = scheduler
+	}
+	if c.continuous {
+		continuous, err := NewContinuousBackup(backupContext, params.ContinuousCfg)
+		if err != nil {
+			return nil, err
+		}
+		server.continuous = continuous
+	}
+	if c.controller {
+		client, err := kube.NewInClusterClient()
+		if err != nil {
+			return nil, err
+		}
+		server.controller = NewBackupController(backupContext, client, params.ControllerCfg)
+	}
+	return server, nil
 }
 
 func (s *Server) Init() {
 	s.registerHTTPServer()
+	s.registerGRPCServer()
 }
 
 func (s *Server) Start() {
 	s.registerProfilePort()
-	err := s.engine.Run(s.config.port)
+	if s.scheduler != nil {
+		s.scheduler.Start()
+	}
+	if s.continuous != nil {
+		s.continuous.Start()
+	}
+	if s.controller != nil {
+		go s.controller.Run(s.backupContext.ctx)
+	}
+	if s.grpcServer != nil {
+		s.startGRPCServer()
+	}
+	var err error
+	if s.certReloader != nil {
+		httpLog.Info("serve http api over tls", zap.String("port", s.config.port),
+			zap.String("clientAuth", s.backupContext.params.HTTPCfg.TLS.ClientAuth))
+		server := &http.Server{
+			Addr:      s.config.port,
+			Handler:   s.engine,
+			TLSConfig: s.certReloader.TLSConfig(),
+		}
+		// the certificates are taken from TLSConfig
+		err = server.ListenAndServeTLS("", "")
+	} else {
+		err = s.engine.Run(s.config.port)
+	}
 	if err != nil {
-		log.Error("Failed to start server", zap.Error(err))
+		httpLog.Error("Failed to start server", zap.Error(err))
 		panic(err)
 	}
-	log.Info("Start backup server backend")
+	httpLog.Info("Start backup server backend")
 }
 
 // registerHTTPServer register the http server, panic when failed
@@ -71,14 +172,72 @@ func (s *Server) registerHTTPServer() {
 	if !s.backupContext.params.HTTPCfg.DebugMode {
 		gin.SetMode(gin.ReleaseMode)
 	}
-	ginHandler := gin.Default()
+	ginHandler := gin.New()
+	ginHandler.Use(logRequest(), gin.Recovery())
 	apiv1 := ginHandler.Group(API_V1_PREFIX)
 	ginHandler.Any("", wrapHandler(handleHello))
-	NewHandlers(s.backupContext).RegisterRoutesTo(apiv1)
+	ginHandler.GET(METRICS_API, gin.WrapH(metrics.Handler()))
+	ginHandler.GET(HEALTHZ_API, wrapHandler(handleHealthz))
+	ginHandler.GET(READYZ_API, wrapHandler(s.handleReadyz))
+	ginHandler.GET(VERSION_API, wrapHandler(handleVersion))
+	handlers := NewHandlers(s.backupContext)
+	handlers.scheduler = s.scheduler
+	handlers.continuous = s.continuous
+	if authCfg := s.backupContext.params.HTTPCfg.Auth; authCfg.Enabled() {
+		httpLog.Info("http api authentication is enabled",
+			zap.Int("apiKeys", len(authCfg.APIKeys)),
+			zap.Int("users", len(authCfg.Users)),
+			zap.Bool("jwt", authCfg.JWTSecret != "" || authCfg.JWTPublicKey != ""))
+		s.auth = newAuthenticator(authCfg)
+	}
+	handlers.auth = s.auth
+	if tlsCfg := s.backupContext.params.HTTPCfg.TLS; tlsCfg.Enabled() {
+		reloader, err := newCertReloader(tlsCfg)
+		if err != nil {
+			httpLog.Error("fail to load http tls certificate", zap.Error(err))
+			panic(err)
+		}
+		s.certReloader = reloader
+	}
+	handlers.RegisterRoutesTo(apiv1)
 	http.Handle("/", ginHandler)
 	s.engine = ginHandler
 }
 
+// registerGRPCServer registers MilvusBackupService to the grpc server if grpc is enabled,
+// it shares the authentication and tls of the http server
+func (s *Server) registerGRPCServer() {
+	grpcCfg := s.backupContext.params.GRPCCfg
+	if !grpcCfg.Enabled {
+		return
+	}
+	opts := []grpc.ServerOption{
+		grpc.ChainUnaryInterceptor(grpcUnaryAuthorize(s.auth)),
+		grpc.ChainStreamInterceptor(grpcStreamAuthorize(s.auth)),
+	}
+	if s.certReloader != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(s.certReloader.TLSConfig())))
+	}
+	s.grpcServer = grpc.NewServer(opts...)
+	backuppb.RegisterMilvusBackupServiceServer(s.grpcServer, newGRPCHandlers(s.backupContext, grpcCfg.WatchInterval))
+}
+
+// startGRPCServer serves grpc in the background, panic when failed to listen
+func (s *Server) startGRPCServer() {
+	address := ":" + strconv.Itoa(s.backupContext.params.GRPCCfg.Port)
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		httpLog.Error("Failed to listen grpc", zap.String("address", address), zap.Error(err))
+		panic(err)
+	}
+	httpLog.Info("Start backup grpc server", zap.String("address", address), zap.Bool("tls", s.certReloader != nil))
+	go func() {
+		if err := s.grpcServer.Serve(listener); err != nil {
+			httpLog.Error("grpc server stopped", zap.Error(err))
+		}
+	}()
+}
+
 // registerHTTPServer register the http server, panic when failed
 func (s *Server) registerProfilePort() {
 	go func() {
@@ -92,28 +251,132 @@ func handleHello(c *gin.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// handleHealthz tells the server is alive, it doesn't check milvus or storage
+func handleHealthz(c *gin.Context) (interface{}, error) {
+	c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	return nil, nil
+}
+
+// handleReadyz checks milvus and the buckets, 503 if any of them fails
+func (s *Server) handleReadyz(c *gin.Context) (interface{}, error) {
+	writeReadiness(c, s.backupContext.CheckReadiness(c.Request.Context()))
+	return nil, nil
+}
+
+func writeReadiness(c *gin.Context, results []ReadinessResult) {
+	ready := true
+	for _, result := range results {
+		ready = ready && result.Ready
+	}
+	status := http.StatusOK
+	if !ready {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, gin.H{"ready": ready, "checks": results})
+}
+
+func handleVersion(c *gin.Context) (interface{}, error) {
+	c.JSON(http.StatusOK, GetBuildInfo())
+	return nil, nil
+}
+
 type Handlers struct {
 	backupContext *BackupContext
+	scheduler     *Scheduler
+	continuous    *ContinuousBackup
+	// nil if authentication is not enabled
+	auth *authenticator
+	// backups of the callers of tenants
+	tenants *tenantBackups
 }
 
 // NewHandlers creates a new Handlers
 func NewHandlers(backupContext *BackupContext) *Handlers {
 	return &Handlers{
 		backupContext: backupContext,
+		tenants:       newTenantBackups(backupContext),
 	}
 }
 
 // RegisterRouters registers routes to given router
 func (h *Handlers) RegisterRoutesTo(router gin.IRouter) {
+	// read can list and get, admin can also create, delete and restore backups
+	read := authorize(h.auth, paramtable.AuthRoleRead)
+	admin := authorize(h.auth, paramtable.AuthRoleAdmin)
+	// the BackupContext serving the caller is resolved once its tenant is known by authorize
+	backups := h.resolveBackupContext
 	router.GET(HELLO_API, wrapHandler(handleHello))
-	router.POST(CREATE_BACKUP_API, wrapHandler(h.handleCreateBackup))
-	router.GET(LIST_BACKUPS_API, wrapHandler(h.handleListBackups))
-	router.GET(GET_BACKUP_API, wrapHandler(h.handleGetBackup))
-	router.DELETE(DELETE_BACKUP_API, wrapHandler(h.handleDeleteBackup))
-	router.POST(RESTORE_BACKUP_API, wrapHandler(h.handleRestoreBackup))
-	router.GET(GET_RESTORE_API, wrapHandler(h.handleGetRestore))
-	router.GET(CHECK_API, wrapHandler(h.handleCheck))
-	router.GET(DOCS_API, ginSwagger.WrapHandler(swaggerFiles.Handler))
+	router.POST(CREATE_BACKUP_API, admin, backups, wrapHandler(h.handleCreateBackup))
+	router.GET(LIST_BACKUPS_API, read, backups, wrapHandler(h.handleListBackups))
+	router.GET(GET_BACKUP_API, read, backups, wrapHandler(h.handleGetBackup))
+	router.DELETE(DELETE_BACKUP_API, admin, backups, wrapHandler(h.handleDeleteBackup))
+	router.POST(PAUSE_BACKUP_API, admin, backups, wrapHandler(h.handlePauseBackup))
+	router.POST(RESUME_BACKUP_API, admin, backups, wrapHandler(h.handleResumeBackup))
+	router.POST(PRUNE_BACKUPS_API, admin, backups, wrapHandler(h.handlePruneBackups))
+	router.POST(GC_API, admin, backups, wrapHandler(h.handleGarbageCollect))
+	router.GET(VERIFY_BACKUP_API, read, backups, wrapHandler(h.handleVerifyBackup))
+	router.POST(ESTIMATE_API, read, backups, wrapHandler(h.handleEstimateBackup))
+	router.POST(RESTORE_BACKUP_API, admin, backups, wrapHandler(h.handleRestoreBackup))
+	router.GET(GET_RESTORE_API, read, backups, wrapHandler(h.handleGetRestore))
+	router.GET(GET_SCHEDULE_API, read, denyTenants, wrapHandler(h.handleGetSchedule))
+	router.GET(GET_RECOVERY_POINT_API, read, denyTenants, wrapHandler(h.handleGetRecoveryPoint))
+	router.GET(JOB_API, read, backups, wrapHandler(h.handleGetJob))
+	router.DELETE(JOB_API, admin, backups, wrapHandler(h.handleCancelJob))
+	router.GET(LIST_JOBS_API, read, backups, wrapHandler(h.handleListJobs))
+	router.POST(RESUME_JOB_API, admin, backups, wrapHandler(h.handleResumeJob))
+	router.GET(CHECK_API, read, backups, wrapHandler(h.handleCheck))
+	router.GET(CHECK_COMPATIBILITY_API, read, backups, wrapHandler(h.handleCheckCompatibility))
+	router.GET(OPENAPI_API, read, wrapHandler(handleOpenAPI))
+	if h.backupContext.params.HTTPCfg.SwaggerUI {
+		// relative to /docs/index.html, so the spec is found under any prefix
+		router.GET(DOCS_API, read, ginSwagger.WrapHandler(swaggerFiles.Handler, ginSwagger.URL("../openapi.json")))
+	}
+}
+
+var (
+	openAPIOnce sync.Once
+	openAPISpec []byte
+	openAPIErr  error
+)
+
+// handleOpenAPI serves the OpenAPI 3.0 spec, converted from the swagger 2.0 spec registered by the docs package
+func handleOpenAPI(c *gin.Context) (interface{}, error) {
+	openAPIOnce.Do(func() {
+		doc, err := swag.ReadDoc()
+		if err != nil {
+			openAPIErr = err
+			return
+		}
+		openAPISpec, openAPIErr = openapi.FromSwagger2([]byte(doc))
+	})
+	if openAPIErr != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": openAPIErr.Error()})
+		return nil, nil
+	}
+	c.Data(http.StatusOK, "application/json", openAPISpec)
+	return nil, nil
+}
+
+// logRequest logs the http requests in place of the logger of gin, so that they are in the format of the other logs
+func logRequest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+		fields := []zap.Field{
+			zap.String("method", c.Request.Method),
+			zap.String("path", c.Request.URL.Path),
+			zap.Int("status", c.Writer.Status()),
+			zap.Duration("latency", time.Since(start)),
+			zap.String("clientIP", c.ClientIP()),
+		}
+		// the probes repeated every few seconds are only logged when failing
+		path := c.Request.URL.Path
+		if (path == HEALTHZ_API || path == READYZ_API) && c.Writer.Status() == http.StatusOK {
+			httpLog.Debug("http request", fields...)
+			return
+		}
+		httpLog.Info("http request", fields...)
+	}
 }
 
 // handlerFunc handles http request with gin context
@@ -143,7 +406,14 @@ func (h *Handlers) handleCreateBackup(c *gin.Context) (interface{}, error) {
 		return nil, nil
 	}
 	requestBody.RequestId = c.GetHeader("request_id")
-	resp := h.backupContext.CreateBackup(h.backupContext.ctx, &requestBody)
+	backupContext := contextOf(c)
+	msg, unlock := h.checkTenantQuota(h.backupContext.ctx, c, backupContext)
+	if msg != "" {
+		c.JSON(http.StatusOK, &backuppb.BackupInfoResponse{RequestId: requestBody.GetRequestId(), Code: backuppb.ResponseCode_No_Permission, Msg: msg})
+		return nil, nil
+	}
+	resp := backupContext.CreateBackup(h.backupContext.ctx, &requestBody)
+	unlock()
 	if h.backupContext.params.HTTPCfg.SimpleResponse {
 		resp = SimpleBackupResponse(resp)
 	}
@@ -158,14 +428,58 @@ func (h *Handlers) handleCreateBackup(c *gin.Context) (interface{}, error) {
 // @Produce application/json
 // @Param request_id header string false "request_id"
 // @Param collection_name query string true "collection_name"
+// @Param databases query []string false "databases"
+// @Param start_time query int false "start_time"
+// @Param end_time query int false "end_time"
+// @Param states query []string false "states"
+// @Param order_by query string false "order_by"
+// @Param desc query bool false "desc"
+// @Param offset query int false "offset"
+// @Param limit query int false "limit"
+// @Param without_detail query bool false "without_detail"
+// @Param label_selector query string false "label_selector"
 // @Success 200 {object} backuppb.ListBackupsResponse
 // @Router /list [get]
 func (h *Handlers) handleListBackups(c *gin.Context) (interface{}, error) {
 	req := backuppb.ListBackupsRequest{
 		RequestId:      c.GetHeader("request_id"),
 		CollectionName: c.Query("collection_name"),
+		Databases:      c.QueryArray("databases"),
+		OrderBy:        c.Query("order_by"),
+		LabelSelector:  c.Query("label_selector"),
+	}
+	var err error
+	if req.StartTime, err = parseQueryInt(c, "start_time", 64); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil
+	}
+	if req.EndTime, err = parseQueryInt(c, "end_time", 64); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil
+	}
+	offset, err := parseQueryInt(c, "offset", 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil
+	}
+	limit, err := parseQueryInt(c, "limit", 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, nil
 	}
-	resp := h.backupContext.ListBackups(h.backupContext.ctx, &req)
+	req.Offset, req.Limit = int32(offset), int32(limit)
+	for _, state := range c.QueryArray("states") {
+		code, ok := backuppb.BackupTaskStateCode_value[state]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid state " + state})
+			return nil, nil
+		}
+		req.States = append(req.States, backuppb.BackupTaskStateCode(code))
+	}
+	req.Desc, _ = strconv.ParseBool(c.Query("desc"))
+	req.WithoutDetail, _ = strconv.ParseBool(c.Query("without_detail"))
+	backupContext := contextOf(c)
+	resp := backupContext.ListBackups(h.backupContext.ctx, &req)
 	if h.backupContext.params.HTTPCfg.SimpleResponse {
 		resp = SimpleListBackupsResponse(resp)
 	}
@@ -173,6 +487,19 @@ func (h *Handlers) handleListBackups(c *gin.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// parseQueryInt parses an integer query parameter of bitSize, 0 if not set
+func parseQueryInt(c *gin.Context, key string, bitSize int) (int64, error) {
+	value := c.Query(key)
+	if value == "" {
+		return 0, nil
+	}
+	i, err := strconv.ParseInt(value, 10, bitSize)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s: %s", key, value)
+	}
+	return i, nil
+}
+
 // RestoreBackup Get backup interface
 // @Summary Get backup interface
 // @Description Get the backup with the given name or id
@@ -189,7 +516,8 @@ func (h *Handlers) handleGetBackup(c *gin.Context) (interface{}, error) {
 		BackupName: c.Query("backup_name"),
 		BackupId:   c.Query("backup_id"),
 	}
-	resp := h.backupContext.GetBackup(h.backupContext.ctx, &req)
+	backupContext := contextOf(c)
+	resp := backupContext.GetBackup(h.backupContext.ctx, &req)
 	if h.backupContext.params.HTTPCfg.SimpleResponse {
 		resp = SimpleBackupResponse(resp)
 	}
@@ -199,19 +527,161 @@ func (h *Handlers) handleGetBackup(c *gin.Context) (interface{}, error) {
 
 // DeleteBackup Delete backup interface
 // @Summary Delete backup interface
-// @Description Delete a backup with the given name
+// @Description Delete a backup with the given name, a backup referenced by incremental backups is only deleted together with them if force is set
 // @Tags Backup
 // @Produce application/json
 // @Param request_id header string false "request_id"
 // @Param backup_name query string true "backup_name"
+// @Param force query bool false "force"
 // @Success 200 {object} backuppb.DeleteBackupResponse
 // @Router /delete [delete]
 func (h *Handlers) handleDeleteBackup(c *gin.Context) (interface{}, error) {
+	force, _ := strconv.ParseBool(c.Query("force"))
 	req := backuppb.DeleteBackupRequest{
 		RequestId:  c.GetHeader("request_id"),
 		BackupName: c.Query("backup_name"),
+		Force:      force,
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.DeleteBackup(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// PruneBackups Prune backups interface
+// @Summary Prune backups interface
+// @Description Delete the backups expired by the retention policy
+// @Tags Backup
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param dry_run query bool false "dry_run"
+// @Param label_selector query string false "label_selector"
+// @Success 200 {object} backuppb.PruneBackupsResponse
+// @Router /prune [post]
+func (h *Handlers) handlePruneBackups(c *gin.Context) (interface{}, error) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+	req := backuppb.PruneBackupsRequest{
+		RequestId:     c.GetHeader("request_id"),
+		DryRun:        dryRun,
+		LabelSelector: c.Query("label_selector"),
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.PruneBackups(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// GarbageCollect Garbage collect interface
+// @Summary Garbage collect interface
+// @Description Remove the orphaned files in backup storage not referenced by any backup
+// @Tags Backup
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param dry_run query bool false "dry_run"
+// @Success 200 {object} backuppb.GarbageCollectResponse
+// @Router /gc [post]
+func (h *Handlers) handleGarbageCollect(c *gin.Context) (interface{}, error) {
+	dryRun, _ := strconv.ParseBool(c.Query("dry_run"))
+	req := backuppb.GarbageCollectRequest{
+		RequestId: c.GetHeader("request_id"),
+		DryRun:    dryRun,
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.GarbageCollect(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// PauseBackup Pause backup interface
+// @Summary Pause backup interface
+// @Description Pause a running backup, it stops copying data and turns to BACKUP_PAUSED once its checkpoint is written
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.PauseBackupRequest   true  "PauseBackupRequest JSON"
+// @Success 200 {object} backuppb.PauseBackupResponse
+// @Router /pause [post]
+func (h *Handlers) handlePauseBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.PauseBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
 	}
-	resp := h.backupContext.DeleteBackup(h.backupContext.ctx, &req)
+	requestBody.RequestId = c.GetHeader("request_id")
+	backupContext := contextOf(c)
+	resp := backupContext.PauseBackup(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// ResumeBackup Resume backup interface
+// @Summary Resume backup interface
+// @Description Resume a paused or interrupted backup from its checkpoint
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.ResumeBackupRequest   true  "ResumeBackupRequest JSON"
+// @Success 200 {object} backuppb.BackupInfoResponse
+// @Router /resume [post]
+func (h *Handlers) handleResumeBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.ResumeBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	backupContext := contextOf(c)
+	resp := backupContext.ResumeBackup(h.backupContext.ctx, &requestBody)
+	if h.backupContext.params.HTTPCfg.SimpleResponse {
+		resp = SimpleBackupResponse(resp)
+	}
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// EstimateBackup Estimate backup interface
+// @Summary Estimate backup interface
+// @Description List the collections a backup would contain with their segment numbers and sizes, nothing is written
+// @Tags Backup
+// @Accept application/json
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param object body backuppb.CreateBackupRequest   true  "CreateBackupRequest JSON"
+// @Success 200 {object} backuppb.EstimateBackupResponse
+// @Router /estimate [post]
+func (h *Handlers) handleEstimateBackup(c *gin.Context) (interface{}, error) {
+	requestBody := backuppb.CreateBackupRequest{}
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body"})
+		return nil, nil
+	}
+	requestBody.RequestId = c.GetHeader("request_id")
+	backupContext := contextOf(c)
+	resp := backupContext.EstimateBackup(h.backupContext.ctx, &requestBody)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// VerifyBackup Verify backup interface
+// @Summary Verify backup interface
+// @Description Check the existence, size and checksum of all files recorded in the backup meta
+// @Tags Backup
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param backup_name query string true "backup_name"
+// @Param sse_customer_key header string false "customer key of backup encrypted by sse type customer"
+// @Success 200 {object} backuppb.VerifyBackupResponse
+// @Router /verify [get]
+func (h *Handlers) handleVerifyBackup(c *gin.Context) (interface{}, error) {
+	req := backuppb.VerifyBackupRequest{
+		RequestId:      c.GetHeader("request_id"),
+		BackupName:     c.Query("backup_name"),
+		SseCustomerKey: c.GetHeader("sse_customer_key"),
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.VerifyBackup(h.backupContext.ctx, &req)
 	c.JSON(http.StatusOK, resp)
 	return nil, nil
 }
@@ -237,8 +707,12 @@ func (h *Handlers) handleRestoreBackup(c *gin.Context) (interface{}, error) {
 		return nil, nil
 	}
 
+	if denyTenantPath(c, requestBody.GetBucketName(), requestBody.GetPath()) {
+		return nil, nil
+	}
 	requestBody.RequestId = c.GetHeader("request_id")
-	resp := h.backupContext.RestoreBackup(h.backupContext.ctx, &requestBody)
+	backupContext := contextOf(c)
+	resp := backupContext.RestoreBackup(h.backupContext.ctx, &requestBody)
 	if h.backupContext.params.HTTPCfg.SimpleResponse {
 		resp = SimpleRestoreResponse(resp)
 	}
@@ -260,7 +734,8 @@ func (h *Handlers) handleGetRestore(c *gin.Context) (interface{}, error) {
 		RequestId: c.GetHeader("request_id"),
 		Id:        c.Query("id"),
 	}
-	resp := h.backupContext.GetRestore(h.backupContext.ctx, &req)
+	backupContext := contextOf(c)
+	resp := backupContext.GetRestore(h.backupContext.ctx, &req)
 	if h.backupContext.params.HTTPCfg.SimpleResponse {
 		resp = SimpleRestoreResponse(resp)
 	}
@@ -268,8 +743,180 @@ func (h *Handlers) handleGetRestore(c *gin.Context) (interface{}, error) {
 	return nil, nil
 }
 
+// GetSchedule Get schedule interface
+// @Summary Get schedule interface
+// @Description Get the status of the schedule jobs, including the last run and the next run time
+// @Tags Schedule
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Success 200 {object} backuppb.GetScheduleResponse
+// @Router /schedule [get]
+func (h *Handlers) handleGetSchedule(c *gin.Context) (interface{}, error) {
+	requestId := c.GetHeader("request_id")
+	if requestId == "" {
+		requestId = utils.UUID()
+	}
+	resp := &backuppb.GetScheduleResponse{
+		RequestId: requestId,
+	}
+	if h.scheduler == nil {
+		resp.Code = backuppb.ResponseCode_Not_Support
+		resp.Msg = "schedule is not enabled, start with `milvus-backup schedule`"
+	} else {
+		resp.Code = backuppb.ResponseCode_Success
+		resp.Msg = "success"
+		resp.Jobs = h.scheduler.Status()
+	}
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// GetRecoveryPoint Get recovery point interface
+// @Summary Get recovery point interface
+// @Description Get the status of continuous backup, including the recovery point and the backup to restore to recover to it
+// @Tags Continuous
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Success 200 {object} backuppb.GetRecoveryPointResponse
+// @Router /recovery_point [get]
+func (h *Handlers) handleGetRecoveryPoint(c *gin.Context) (interface{}, error) {
+	requestId := c.GetHeader("request_id")
+	if requestId == "" {
+		requestId = utils.UUID()
+	}
+	resp := &backuppb.GetRecoveryPointResponse{
+		RequestId: requestId,
+	}
+	if h.continuous == nil {
+		resp.Code = backuppb.ResponseCode_Not_Support
+		resp.Msg = "continuous backup is not enabled, start with `milvus-backup continuous`"
+	} else {
+		resp.Code = backuppb.ResponseCode_Success
+		resp.Msg = "success"
+		resp.Data = h.continuous.Status()
+	}
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// GetJob Get job interface
+// @Summary Get job interface
+// @Description Get the state and progress of a backup or restore job, the job id is returned by create and restore
+// @Tags Job
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param id path string true "job id"
+// @Success 200 {object} backuppb.JobResponse
+// @Router /jobs/{id} [get]
+func (h *Handlers) handleGetJob(c *gin.Context) (interface{}, error) {
+	req := backuppb.GetJobRequest{
+		RequestId: c.GetHeader("request_id"),
+		JobId:     c.Param("id"),
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.GetJob(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// CancelJob Cancel job interface
+// @Summary Cancel job interface
+// @Description Cancel a running backup or restore job, the copy workers and the waiting of bulkinsert tasks are stopped
+// @Tags Job
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param id path string true "job id"
+// @Success 200 {object} backuppb.JobResponse
+// @Router /jobs/{id} [delete]
+func (h *Handlers) handleCancelJob(c *gin.Context) (interface{}, error) {
+	req := backuppb.CancelJobRequest{
+		RequestId: c.GetHeader("request_id"),
+		JobId:     c.Param("id"),
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.CancelJob(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// ListJobs List jobs interface
+// @Summary List jobs interface
+// @Description List the backup and restore jobs of this server, and the jobs recorded in backup storage by the servers sharing it if job state is enabled
+// @Tags Job
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param type query string false "backup or restore, all if empty"
+// @Success 200 {object} backuppb.ListJobsResponse
+// @Router /jobs [get]
+func (h *Handlers) handleListJobs(c *gin.Context) (interface{}, error) {
+	req := backuppb.ListJobsRequest{
+		RequestId: c.GetHeader("request_id"),
+		Type:      c.Query("type"),
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.ListJobs(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// ResumeJob Resume job interface
+// @Summary Resume job interface
+// @Description Resume an interrupted, failed or canceled job from its checkpoint in this server, like a job of a server which is gone
+// @Tags Job
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param id path string true "job id"
+// @Success 200 {object} backuppb.JobResponse
+// @Router /jobs/{id}/resume [post]
+func (h *Handlers) handleResumeJob(c *gin.Context) (interface{}, error) {
+	req := backuppb.ResumeJobRequest{
+		RequestId: c.GetHeader("request_id"),
+		JobId:     c.Param("id"),
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.ResumeJob(h.backupContext.ctx, &req)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
 func (h *Handlers) handleCheck(c *gin.Context) (interface{}, error) {
-	resp := h.backupContext.Check(h.backupContext.ctx)
+	backupContext := contextOf(c)
+	resp := backupContext.Check(h.backupContext.ctx)
+	c.JSON(http.StatusOK, resp)
+	return nil, nil
+}
+
+// CheckCompatibility Check compatibility interface
+// @Summary Check compatibility interface
+// @Description Check the features used by the collections of a backup against a target milvus version before restoring it
+// @Tags Restore
+// @Produce application/json
+// @Param request_id header string false "request_id"
+// @Param backup_name query string true "backup_name"
+// @Param target_version query string false "milvus version to check against, like v2.3.5, the version of the connected milvus if not set"
+// @Param bucket_name query string false "bucket_name"
+// @Param path query string false "path"
+// @Param collection_names query string false "collections to check, separated by comma"
+// @Param target_db_name query string false "database the collections are restored into"
+// @Success 200 {object} backuppb.CheckCompatibilityResponse
+// @Router /check_compatibility [get]
+func (h *Handlers) handleCheckCompatibility(c *gin.Context) (interface{}, error) {
+	req := backuppb.CheckCompatibilityRequest{
+		RequestId:     c.GetHeader("request_id"),
+		BackupName:    c.Query("backup_name"),
+		TargetVersion: c.Query("target_version"),
+		BucketName:    c.Query("bucket_name"),
+		Path:          c.Query("path"),
+		TargetDbName:  c.Query("target_db_name"),
+	}
+	if collectionNames := c.Query("collection_names"); collectionNames != "" {
+		req.CollectionNames = strings.Split(collectionNames, ",")
+	}
+	if denyTenantPath(c, req.GetBucketName(), req.GetPath()) {
+		return nil, nil
+	}
+	backupContext := contextOf(c)
+	resp := backupContext.CheckCompatibility(h.backupContext.ctx, &req)
 	c.JSON(http.StatusOK, resp)
 	return nil, nil
 }
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+const (
+	apiKeyHeader = "X-API-Key"
+
+	// api keys, passwords and jwt keys referred by env: or file: are read again after it, so they can be rotated
+	authSecretRefreshInterval = time.Minute
+	// request bodies larger than it aren't parsed for the backup name in audit logs
+	auditMaxBodySize = 64 * 1024
+)
+
+var (
+	errUnauthenticated = errors.New("missing or invalid credentials")
+	errForbidden       = errors.New("permission denied")
+)
+
+// principal is the authenticated caller of the http api
+type principal struct {
+	Name string
+	Role string
+	// how the caller is authenticated: api_key, basic or jwt
+	Method string
+	// the caller only sees the backups of the tenant if set
+	Tenant string
+}
+
+func (p principal) can(role string) bool {
+	return p.Role == paramtable.AuthRoleAdmin || p.Role == role
+}
+
+type authCredential struct {
+	name   string
+	secret *utils.Secret
+	role   string
+	tenant string
+}
+
+// authenticator authenticates the callers of the http api by the credentials of HTTPAuthConfig
+type authenticator struct {
+	apiKeys []authCredential
+	users   map[string]authCredential
+
+	jwtKey       *utils.Secret
+	jwtHMAC      bool
+	jwtOptions   []jwt.ParserOption
+	roleClaim    string
+	subjectClaim string
+}
+
+func newAuthenticator(cfg paramtable.HTTPAuthConfig) *authenticator {
+	a := &authenticator{
+		users:        make(map[string]authCredential),
+		roleClaim:    cfg.JWTRoleClaim,
+		subjectClaim: cfg.JWTSubjectClaim,
+	}
+	for _, key := range cfg.APIKeys {
+		a.apiKeys = append(a.apiKeys, authCredential{name: key.Name, secret: utils.NewSecret(key.Secret, authSecretRefreshInterval), role: key.Role, tenant: key.Tenant})
+	}
+	for _, user := range cfg.Users {
+		a.users[user.Name] = authCredential{name: user.Name, secret: utils.NewSecret(user.Secret, authSecretRefreshInterval), role: user.Role, tenant: user.Tenant}
+	}
+	switch {
+	case cfg.JWTSecret != "":
+		a.jwtKey = utils.NewSecret(cfg.JWTSecret, authSecretRefreshInterval)
+		a.jwtHMAC = true
+		a.jwtOptions = append(a.jwtOptions, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	case cfg.JWTPublicKey != "":
+		a.jwtKey = utils.NewSecret(cfg.JWTPublicKey, authSecretRefreshInterval)
+		a.jwtOptions = append(a.jwtOptions, jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}))
+	}
+	if cfg.JWTIssuer != "" {
+		a.jwtOptions = append(a.jwtOptions, jwt.WithIssuer(cfg.JWTIssuer))
+	}
+	if cfg.JWTAudience != "" {
+		a.jwtOptions = append(a.jwtOptions, jwt.WithAudience(cfg.JWTAudience))
+	}
+	return a
+}
+
+// authenticate returns the caller of the request, by X-API-Key, basic auth or a bearer token,
+// which is an api key or a jwt
+func (a *authenticator) authenticate(r *http.Request) (principal, error) {
+	if key := r.Header.Get(apiKeyHeader); key != "" {
+		return a.authenticateAPIKey(key)
+	}
+	if username, password, ok := r.BasicAuth(); ok {
+		return a.authenticateUser(username, password)
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		return principal{}, errUnauthenticated
+	}
+	token := strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	// jwt has three segments separated by dots
+	if a.jwtKey != nil && strings.Count(token, ".") == 2 {
+		return a.authenticateJWT(token)
+	}
+	return a.authenticateAPIKey(token)
+}
+
+func (a *authenticator) authenticateAPIKey(key string) (principal, error) {
+	for _, credential := range a.apiKeys {
+		if secretEqual(credential.secret, key) {
+			return principal{Name: credential.name, Role: credential.role, Method: "api_key", Tenant: credential.tenant}, nil
+		}
+	}
+	return principal{}, errUnauthenticated
+}
+
+func (a *authenticator) authenticateUser(username, password string) (principal, error) {
+	credential, ok := a.users[username]
+	if !ok || !secretEqual(credential.secret, password) {
+		return principal{}, errUnauthenticated
+	}
+	return principal{Name: credential.name, Role: credential.role, Method: "basic", Tenant: credential.tenant}, nil
+}
+
+func (a *authenticator) authenticateJWT(tokenString string) (principal, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, a.jwtVerifyKey, a.jwtOptions...)
+	if err != nil {
+		httpLog.Warn("invalid jwt", zap.Error(err))
+		return principal{}, errUnauthenticated
+	}
+	name, _ := claims[a.subjectClaim].(string)
+	role := ""
+	// the highest role in the claim, which is a string or a list of strings
+	switch value := claims[a.roleClaim].(type) {
+	case string:
+		role = jwtRole(role, value)
+	case []interface{}:
+		for _, v := range value {
+			if s, ok := v.(string); ok {
+				role = jwtRole(role, s)
+			}
+		}
+	}
+	if role == "" {
+		return principal{}, fmt.Errorf("%w: no role in claim %s", errForbidden, a.roleClaim)
+	}
+	return principal{Name: name, Role: role, Method: "jwt"}, nil
+}
+
+func (a *authenticator) jwtVerifyKey(token *jwt.Token) (interface{}, error) {
+	key, err := a.jwtKey.Value()
+	if err != nil {
+		return nil, err
+	}
+	if a.jwtHMAC {
+		return []byte(key), nil
+	}
+	switch token.Method.(type) {
+	case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+		return jwt.ParseRSAPublicKeyFromPEM([]byte(key))
+	case *jwt.SigningMethodECDSA:
+		return jwt.ParseECPublicKeyFromPEM([]byte(key))
+	default:
+		return jwt.ParseEdPublicKeyFromPEM([]byte(key))
+	}
+}
+
+func jwtRole(current, role string) string {
+	role = strings.ToLower(role)
+	if role == paramtable.AuthRoleAdmin || current == paramtable.AuthRoleAdmin {
+		return paramtable.AuthRoleAdmin
+	}
+	if role == paramtable.AuthRoleRead {
+		return role
+	}
+	return current
+}
+
+func secretEqual(secret *utils.Secret, value string) bool {
+	expected, err := secret.Value()
+	if err != nil || expected == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(expected), []byte(value)) == 1
+}
+
+// authorize returns the middleware which only lets the callers with role through, the callers of admin apis
+// and the rejected ones are audit logged. The tenant of the caller is set into the context as tenantKey.
+// Everyone is let through if auth is nil.
+func authorize(auth *authenticator, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if auth == nil {
+			c.Next()
+			return
+		}
+		caller, err := auth.authenticate(c.Request)
+		if err == nil && !caller.can(role) {
+			err = errForbidden
+		}
+		if err != nil {
+			status := http.StatusUnauthorized
+			if errors.Is(err, errForbidden) {
+				status = http.StatusForbidden
+			} else if len(auth.users) > 0 {
+				c.Header("WWW-Authenticate", `Basic realm="milvus-backup"`)
+			}
+			audit(c, caller, "", status)
+			c.AbortWithStatusJSON(status, gin.H{"error": err.Error()})
+			return
+		}
+		c.Set(tenantKey, caller.Tenant)
+		if role != paramtable.AuthRoleAdmin {
+			c.Next()
+			return
+		}
+		target := auditTarget(c)
+		c.Next()
+		audit(c, caller, target, c.Writer.Status())
+	}
+}
+
+// auditTarget returns the backup the request is about, from the query or the json body
+func auditTarget(c *gin.Context) string {
+	for _, key := range []string{"backup_name", "name"} {
+		if name := c.Query(key); name != "" {
+			return name
+		}
+	}
+	if c.Request.Body == nil || c.Request.ContentLength <= 0 || c.Request.ContentLength > auditMaxBodySize {
+		return ""
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	// the handler reads the body again
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return ""
+	}
+	var request struct {
+		BackupName string `json:"backup_name"`
+	}
+	_ = json.Unmarshal(body, &request)
+	return request.BackupName
+}
+
+func audit(c *gin.Context, caller principal, target string, status int) {
+	httpLog.Info("audit",
+		zap.String("principal", caller.Name),
+		zap.String("role", caller.Role),
+		zap.String("auth", caller.Method),
+		zap.String("tenant", caller.Tenant),
+		zap.String("method", c.Request.Method),
+		zap.String("path", c.Request.URL.Path),
+		zap.String("backup", target),
+		zap.String("requestId", c.GetHeader("request_id")),
+		zap.String("clientIP", c.ClientIP()),
+		zap.Int("status", status))
+}
@@ -0,0 +1,100 @@
+package core
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+func TestAuthorize(t *testing.T) {
+	t.Setenv("TEST_BACKUP_ADMIN_KEY", "admin-key")
+	auth := newAuthenticator(paramtable.HTTPAuthConfig{
+		APIKeys: []paramtable.AuthCredentialConfig{
+			{Name: "ops", Secret: "env:TEST_BACKUP_ADMIN_KEY", Role: paramtable.AuthRoleAdmin},
+			{Name: "dashboard", Secret: "read-key", Role: paramtable.AuthRoleRead},
+		},
+		Users:           []paramtable.AuthCredentialConfig{{Name: "alice", Secret: "password", Role: paramtable.AuthRoleRead}},
+		JWTSecret:       "jwt-secret",
+		JWTIssuer:       "https://idp.example.com",
+		JWTRoleClaim:    "roles",
+		JWTSubjectClaim: "sub",
+	})
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	var deleted string
+	router.GET("/list", authorize(auth, paramtable.AuthRoleRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	router.POST("/delete", authorize(auth, paramtable.AuthRoleAdmin), func(c *gin.Context) {
+		var req struct {
+			BackupName string `json:"backup_name"`
+		}
+		// the body is still readable after audit
+		assert.NoError(t, c.ShouldBindJSON(&req))
+		deleted = req.BackupName
+		c.Status(http.StatusOK)
+	})
+
+	do := func(method, path string, header http.Header) int {
+		req := httptest.NewRequest(method, path, strings.NewReader(`{"backup_name":"daily"}`))
+		for key, values := range header {
+			for _, value := range values {
+				req.Header.Add(key, value)
+			}
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w.Code
+	}
+	bearer := func(token string) http.Header {
+		return http.Header{"Authorization": []string{"Bearer " + token}}
+	}
+	sign := func(claims jwt.MapClaims, secret string) string {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+		assert.NoError(t, err)
+		return token
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/list", nil))
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/list", http.Header{apiKeyHeader: []string{"wrong"}}))
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/list", http.Header{apiKeyHeader: []string{"read-key"}}))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/delete", http.Header{apiKeyHeader: []string{"read-key"}}))
+	assert.Equal(t, "", deleted)
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/delete", bearer("admin-key")))
+	assert.Equal(t, "daily", deleted)
+
+	basic := httptest.NewRequest(http.MethodGet, "/list", nil)
+	basic.SetBasicAuth("alice", "password")
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/list", basic.Header))
+	basic.SetBasicAuth("alice", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/list", basic.Header))
+
+	exp := time.Now().Add(time.Hour).Unix()
+	admin := sign(jwt.MapClaims{"sub": "ci", "iss": "https://idp.example.com", "exp": exp, "roles": []string{"read", "admin"}}, "jwt-secret")
+	assert.Equal(t, http.StatusOK, do(http.MethodPost, "/delete", bearer(admin)))
+	reader := sign(jwt.MapClaims{"sub": "bob", "iss": "https://idp.example.com", "exp": exp, "roles": "read"}, "jwt-secret")
+	assert.Equal(t, http.StatusOK, do(http.MethodGet, "/list", bearer(reader)))
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, "/delete", bearer(reader)))
+	noRole := sign(jwt.MapClaims{"sub": "bob", "iss": "https://idp.example.com", "exp": exp}, "jwt-secret")
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, "/list", bearer(noRole)))
+	expired := sign(jwt.MapClaims{"sub": "ci", "iss": "https://idp.example.com", "exp": time.Now().Add(-time.Hour).Unix(), "roles": "admin"}, "jwt-secret")
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/list", bearer(expired)))
+	otherIssuer := sign(jwt.MapClaims{"sub": "ci", "iss": "https://other.example.com", "exp": exp, "roles": "admin"}, "jwt-secret")
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/list", bearer(otherIssuer)))
+	forged := sign(jwt.MapClaims{"sub": "ci", "iss": "https://idp.example.com", "exp": exp, "roles": "admin"}, "other-secret")
+	assert.Equal(t, http.StatusUnauthorized, do(http.MethodGet, "/list", bearer(forged)))
+
+	// everyone is let through without auth
+	open := gin.New()
+	open.GET("/list", authorize(nil, paramtable.AuthRoleRead), func(c *gin.Context) { c.Status(http.StatusOK) })
+	w := httptest.NewRecorder()
+	open.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/list", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+}
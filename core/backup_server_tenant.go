@@ -0,0 +1,167 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"path"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+const (
+	// tenantKey is the key of the tenant of the caller in gin.Context, set by authorize
+	tenantKey = "tenant"
+	// backupContextKey is the key of the BackupContext serving the caller in gin.Context, set by resolveBackupContext
+	backupContextKey = "backupContext"
+)
+
+var errTenantForbidden = fmt.Errorf("%w: not available to the callers of a tenant", errForbidden)
+
+// tenantBackups holds the BackupContext of each tenant, whose backups are under <http.tenantRootPath>/<tenant> of the
+// backup bucket, so that the callers of a tenant only see, delete and restore the backups of the tenant
+type tenantBackups struct {
+	base *BackupContext
+
+	mu       sync.Mutex
+	contexts map[string]*BackupContext
+	// locks of the quotas by tenant, held from checking the quota of a tenant until its new backup is created
+	quotaLocks map[string]*sync.Mutex
+}
+
+func newTenantBackups(base *BackupContext) *tenantBackups {
+	return &tenantBackups{base: base, contexts: make(map[string]*BackupContext), quotaLocks: make(map[string]*sync.Mutex)}
+}
+
+// get returns the BackupContext of the tenant, created on the first request of the tenant. It shares the storage
+// clients, the milvus client and the worker pools of the base context, so that the backups of all tenants are limited
+// together by the parallelism in config. The backups, restores and jobs are of the tenant.
+func (t *tenantBackups) get(tenant string) (*BackupContext, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if b, ok := t.contexts[tenant]; ok {
+		return b, nil
+	}
+	params := t.base.params
+	params.MinioCfg.BackupRootPath = path.Join(params.HTTPCfg.TenantRootPath, tenant)
+	b := CreateBackupContext(t.base.ctx, params)
+	b.shared = t.base
+	if databases := params.HTTPCfg.Tenants[tenant].Databases; len(databases) > 0 {
+		b.databases = make(map[string]bool)
+		for _, db := range databases {
+			b.databases[db] = true
+		}
+	}
+	if err := b.Start(); err != nil {
+		return nil, fmt.Errorf("fail to start the backups of tenant %s: %w", tenant, err)
+	}
+	storageClient := t.base.getStorageClient()
+	b.storageClient = &storageClient
+	if params.BackupStorageCfg.Enabled() {
+		backupStorageClient := t.base.getBackupStorageClient()
+		b.backupStorageClient = &backupStorageClient
+	}
+	t.contexts[tenant] = b
+	httpLog.Info("serve the backups of tenant", zap.String("tenant", tenant), zap.String("rootPath", b.backupRootPath),
+		zap.Strings("databases", params.HTTPCfg.Tenants[tenant].Databases))
+	return b, nil
+}
+
+// quotaLock returns the lock of the quota of the tenant
+func (t *tenantBackups) quotaLock(tenant string) *sync.Mutex {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	lock, ok := t.quotaLocks[tenant]
+	if !ok {
+		lock = &sync.Mutex{}
+		t.quotaLocks[tenant] = lock
+	}
+	return lock
+}
+
+// tenantOf returns the tenant of the caller, empty if the caller doesn't belong to a tenant
+func tenantOf(c *gin.Context) string {
+	return c.GetString(tenantKey)
+}
+
+// resolveBackupContext is the middleware setting the BackupContext serving the caller into gin.Context, of its tenant
+// if it belongs to one, the request fails if the context of the tenant fails to start
+func (h *Handlers) resolveBackupContext(c *gin.Context) {
+	b := h.backupContext
+	if tenant := tenantOf(c); tenant != "" {
+		var err error
+		if b, err = h.tenants.get(tenant); err != nil {
+			c.AbortWithStatusJSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+	c.Set(backupContextKey, b)
+	c.Next()
+}
+
+// contextOf returns the BackupContext serving the caller, set by resolveBackupContext
+func contextOf(c *gin.Context) *BackupContext {
+	return c.MustGet(backupContextKey).(*BackupContext)
+}
+
+// denyTenants is the middleware rejecting the callers of a tenant, for the apis out of the backups of a tenant like
+// schedule and continuous backup
+func denyTenants(c *gin.Context) {
+	if tenantOf(c) != "" {
+		c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": errTenantForbidden.Error()})
+		return
+	}
+	c.Next()
+}
+
+// denyTenantPath rejects the callers of a tenant reading backups out of the backup root of the tenant by bucket and path,
+// returns whether the request is rejected
+func denyTenantPath(c *gin.Context, bucketName, backupPath string) bool {
+	if tenantOf(c) == "" || bucketName == "" && backupPath == "" {
+		return false
+	}
+	c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("%s: bucket_name and path", errTenantForbidden.Error())})
+	return true
+}
+
+// checkTenantQuota returns why a new backup of the caller is rejected by the quota of its tenant, empty if it isn't.
+// Backups are rejected once the total size of the backups of the tenant reaches the quota, so the last backup
+// admitted may exceed it. If it isn't rejected, the quota of the tenant is locked until the returned unlock is called
+// after the backup is created, so that the backups of a tenant with a quota are admitted and created one by one.
+func (h *Handlers) checkTenantQuota(ctx context.Context, c *gin.Context, b *BackupContext) (string, func()) {
+	tenant := tenantOf(c)
+	quota := h.backupContext.params.HTTPCfg.Tenants[tenant].QuotaBytes
+	if tenant == "" || quota <= 0 {
+		return "", func() {}
+	}
+	lock := h.tenants.quotaLock(tenant)
+	lock.Lock()
+	used, err := tenantUsage(ctx, b)
+	if err != nil {
+		lock.Unlock()
+		return "fail to get the backup usage of tenant " + tenant + ": " + err.Error(), nil
+	}
+	if used >= quota {
+		lock.Unlock()
+		return fmt.Sprintf("backups of tenant %s are %d bytes, reach the quota of %d bytes", tenant, used, quota), nil
+	}
+	return "", lock.Unlock
+}
+
+// tenantUsage returns the total size of the backups of a tenant
+func tenantUsage(ctx context.Context, b *BackupContext) (int64, error) {
+	resp := b.ListBackups(ctx, &backuppb.ListBackupsRequest{})
+	if resp.GetCode() != backuppb.ResponseCode_Success {
+		return 0, errors.New(resp.GetMsg())
+	}
+	var used int64
+	for _, backup := range resp.GetData() {
+		used += backup.GetSize()
+	}
+	return used, nil
+}
@@ -0,0 +1,175 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+)
+
+// fakeCollectionsClient has the collection coll in every database
+type fakeCollectionsClient struct {
+	gomilvus.Client
+}
+
+func (c *fakeCollectionsClient) GetVersion(ctx context.Context) (string, error) {
+	return "v2.4.0", nil
+}
+
+func (c *fakeCollectionsClient) HasCollection(ctx context.Context, collName string) (bool, error) {
+	return collName == "coll", nil
+}
+
+func (c *fakeCollectionsClient) UsingDatabase(ctx context.Context, dbName string) error {
+	return nil
+}
+
+func (c *fakeCollectionsClient) ListCollections(ctx context.Context) ([]*entity.Collection, error) {
+	return []*entity.Collection{{Name: "coll"}}, nil
+}
+
+func TestTenantBackups(t *testing.T) {
+	ctx := context.Background()
+	files := make(map[string][]byte)
+	var client storage.ChunkManager = &memoryChunkManager{files: files}
+	b := &BackupContext{ctx: ctx, storageClient: &client, backupRootPath: "backup", started: true,
+		milvusClient: &MilvusClient{client: &fakeCollectionsClient{}}}
+	b.params.BackupCfg.ListMetaParallelism = 2
+	b.params.BackupCfg.BackupCopyDataParallelism = 2
+	b.params.HTTPCfg.TenantRootPath = "tenants"
+	b.params.HTTPCfg.Tenants = map[string]paramtable.TenantConfig{
+		"team-a": {Name: "team-a", QuotaBytes: 100, Databases: []string{"db1"}},
+		"team-d": {Name: "team-d", QuotaBytes: 100, Databases: []string{"db1"}},
+	}
+
+	h := NewHandlers(b)
+	h.auth = newAuthenticator(paramtable.HTTPAuthConfig{APIKeys: []paramtable.AuthCredentialConfig{
+		{Name: "ops", Secret: "ops-key", Role: paramtable.AuthRoleAdmin},
+		{Name: "a", Secret: "a-key", Role: paramtable.AuthRoleAdmin, Tenant: "team-a"},
+		{Name: "b", Secret: "b-key", Role: paramtable.AuthRoleAdmin, Tenant: "team-b"},
+		{Name: "c", Secret: "c-key", Role: paramtable.AuthRoleAdmin, Tenant: "team-c"},
+		{Name: "d", Secret: "d-key", Role: paramtable.AuthRoleAdmin, Tenant: "team-d"},
+	}})
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	h.RegisterRoutesTo(router)
+
+	importBackup := func(b *BackupContext, name string, size int64) {
+		data, err := json.Marshal(&backuppb.BackupInfo{Name: name, Size: size, StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+			CollectionBackups: []*backuppb.CollectionBackupInfo{{CollectionId: 1, CollectionName: "coll", Size: size,
+				PartitionBackups: []*backuppb.PartitionBackupInfo{{CollectionId: 1, PartitionId: 2, Size: size}}}}})
+		assert.NoError(t, err)
+		_, err = b.ImportBackupMeta(ctx, data, false)
+		assert.NoError(t, err)
+	}
+	importBackup(b, "ops_backup", 10)
+	teamA, err := h.tenants.get("team-a")
+	assert.NoError(t, err)
+	importBackup(teamA, "a_backup", 100)
+	// the worker pools of the base context are shared by tenants
+	assert.Same(t, b.getCopyDataWorkerPool(), teamA.getCopyDataWorkerPool())
+	assert.Same(t, b.getRequestWorkerPool("copydata", 3, b.getCopyDataWorkerPool), teamA.getRequestWorkerPool("copydata", 3, teamA.getCopyDataWorkerPool))
+	assert.Contains(t, files, BackupMetaPath("tenants/team-a", "a_backup"))
+
+	do := func(method, path, key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(apiKeyHeader, key)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+	list := func(key string) []string {
+		w := do(http.MethodGet, LIST_BACKUPS_API, key, "")
+		assert.Equal(t, http.StatusOK, w.Code)
+		resp := &backuppb.ListBackupsResponse{}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+		names := make([]string, 0)
+		for _, backup := range resp.GetData() {
+			names = append(names, backup.GetName())
+		}
+		return names
+	}
+	assert.Equal(t, []string{"ops_backup"}, list("ops-key"))
+	assert.Equal(t, []string{"a_backup"}, list("a-key"))
+	assert.Equal(t, []string{}, list("b-key"))
+
+	// the backups out of the tenant are invisible
+	w := do(http.MethodGet, GET_BACKUP_API+"?backup_name=ops_backup", "a-key", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	resp := &backuppb.BackupInfoResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.NotEqual(t, backuppb.ResponseCode_Success, resp.GetCode())
+	assert.Equal(t, http.StatusForbidden, do(http.MethodPost, RESTORE_BACKUP_API, "a-key", `{"backup_name":"ops_backup","path":"backup"}`).Code)
+	assert.Equal(t, http.StatusForbidden, do(http.MethodGet, GET_SCHEDULE_API, "a-key", "").Code)
+
+	// team-a reaches its quota
+	w = do(http.MethodPost, CREATE_BACKUP_API, "a-key", `{"backup_name":"a_backup2"}`)
+	resp = &backuppb.BackupInfoResponse{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), resp))
+	assert.Equal(t, backuppb.ResponseCode_No_Permission, resp.GetCode())
+	assert.Contains(t, resp.GetMsg(), "reach the quota of 100 bytes")
+
+	// team-a and team-d only back up and restore the collections of db1
+	create := func(key, body string) *backuppb.BackupInfoResponse {
+		resp := &backuppb.BackupInfoResponse{}
+		assert.NoError(t, json.Unmarshal(do(http.MethodPost, CREATE_BACKUP_API, key, body).Body.Bytes(), resp))
+		return resp
+	}
+	for _, body := range []string{`{"collection_names":["db2.coll"]}`, `{"collection_names":["coll"]}`,
+		`{"db_collections":{"db2":[]}}`, `{"collection_names":["db1.coll"],"rbac":true}`} {
+		resp = create("d-key", body)
+		assert.Equal(t, backuppb.ResponseCode_No_Permission, resp.GetCode(), body)
+		assert.Contains(t, resp.GetMsg(), errDatabaseOutOfScope.Error(), body)
+	}
+	restore := func(key, body string) *backuppb.RestoreBackupResponse {
+		resp := &backuppb.RestoreBackupResponse{}
+		assert.NoError(t, json.Unmarshal(do(http.MethodPost, RESTORE_BACKUP_API, key, body).Body.Bytes(), resp))
+		return resp
+	}
+	for _, body := range []string{`{"backup_name":"a_backup","drop_exist_collection":true}`,
+		`{"backup_name":"a_backup","target_db_name":"db2"}`, `{"backup_name":"a_backup","target_db_name":"db1","rbac":true}`} {
+		restoreResp := restore("a-key", body)
+		assert.Equal(t, backuppb.ResponseCode_No_Permission, restoreResp.GetCode(), body)
+		assert.Contains(t, restoreResp.GetMsg(), errDatabaseOutOfScope.Error(), body)
+	}
+
+	// the quota of team-d is locked from its check until the backup is created
+	gc, _ := gin.CreateTestContext(httptest.NewRecorder())
+	gc.Set(tenantKey, "team-d")
+	teamD, err := h.tenants.get("team-d")
+	assert.NoError(t, err)
+	msg, unlock := h.checkTenantQuota(ctx, gc, teamD)
+	assert.Empty(t, msg)
+	assert.False(t, h.tenants.quotaLock("team-d").TryLock())
+	unlock()
+	assert.True(t, h.tenants.quotaLock("team-d").TryLock())
+	h.tenants.quotaLock("team-d").Unlock()
+
+	deleteResp := &backuppb.DeleteBackupResponse{}
+	assert.NoError(t, json.Unmarshal(do(http.MethodDelete, DELETE_BACKUP_API+"?backup_name=a_backup", "b-key", "").Body.Bytes(), deleteResp))
+	assert.NotEqual(t, backuppb.ResponseCode_Success, deleteResp.GetCode())
+	assert.Contains(t, files, BackupMetaPath("tenants/team-a", "a_backup"))
+	deleteResp = &backuppb.DeleteBackupResponse{}
+	assert.NoError(t, json.Unmarshal(do(http.MethodDelete, DELETE_BACKUP_API+"?backup_name=a_backup", "a-key", "").Body.Bytes(), deleteResp))
+	assert.Equal(t, backuppb.ResponseCode_Success, deleteResp.GetCode(), deleteResp.GetMsg())
+	assert.Equal(t, []string{}, list("a-key"))
+	assert.Equal(t, []string{"ops_backup"}, list("ops-key"))
+
+	// the context of a tenant fails to start
+	b.params.BackupCfg.EncryptionKey = "invalid"
+	w = do(http.MethodGet, LIST_BACKUPS_API, "c-key", "")
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "fail to start the backups of tenant team-c")
+	assert.NotContains(t, h.tenants.contexts, "team-c")
+}
@@ -2,13 +2,17 @@ package core
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
+	"net/http/httptest"
 	"net/http/pprof"
 	"testing"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 	"github.com/zilliztech/milvus-backup/core/paramtable"
+	_ "github.com/zilliztech/milvus-backup/docs"
 )
 
 func TestBackupService(t *testing.T) {
@@ -34,3 +38,21 @@ func TestProfileService(t *testing.T) {
 	time.Sleep(1000 * time.Second)
 
 }
+
+func TestHandleOpenAPI(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.GET(API_V1_PREFIX+OPENAPI_API, wrapHandler(handleOpenAPI))
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, API_V1_PREFIX+OPENAPI_API, nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var spec struct {
+		OpenAPI string                 `json:"openapi"`
+		Paths   map[string]interface{} `json:"paths"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &spec))
+	assert.Equal(t, "3.0.3", spec.OpenAPI)
+	assert.Contains(t, spec.Paths, CREATE_BACKUP_API)
+	assert.Contains(t, spec.Paths, RESTORE_BACKUP_API)
+}
@@ -0,0 +1,142 @@
+package core
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	paramtable.HTTPClientAuthNone:    tls.NoClientCert,
+	paramtable.HTTPClientAuthRequest: tls.VerifyClientCertIfGiven,
+	paramtable.HTTPClientAuthRequire: tls.RequireAndVerifyClientCert,
+}
+
+// certReloader serves the certificate and the client ca of HTTPTLSConfig, they are loaded again
+// on a handshake after the reload interval once any of the files is modified.
+// The ones loaded before are kept if the new files are invalid, like a half-written secret.
+type certReloader struct {
+	cfg paramtable.HTTPTLSConfig
+
+	mu        sync.RWMutex
+	cert      *tls.Certificate
+	clientCAs *x509.CertPool
+	// modification time of the files loaded
+	modTimes []time.Time
+	checked  time.Time
+}
+
+func newCertReloader(cfg paramtable.HTTPTLSConfig) (*certReloader, error) {
+	r := &certReloader{cfg: cfg}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+func (r *certReloader) files() []string {
+	files := []string{r.cfg.CertFile, r.cfg.KeyFile}
+	if r.cfg.ClientCAFile != "" {
+		files = append(files, r.cfg.ClientCAFile)
+	}
+	return files
+}
+
+// reload loads the files again if any of them is modified since the last load
+func (r *certReloader) reload() error {
+	files := r.files()
+	modTimes := make([]time.Time, 0, len(files))
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			return err
+		}
+		modTimes = append(modTimes, info.ModTime())
+	}
+	r.mu.RLock()
+	modified := !equalTimes(r.modTimes, modTimes)
+	r.mu.RUnlock()
+	if !modified {
+		return nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load http tls certificate: %w", err)
+	}
+	var clientCAs *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read http tls client ca: %w", err)
+		}
+		clientCAs = x509.NewCertPool()
+		if !clientCAs.AppendCertsFromPEM(pem) {
+			return errors.New("no certificate in http tls client ca " + r.cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.clientCAs = clientCAs
+	r.modTimes = modTimes
+	return nil
+}
+
+// maybeReload reloads the files if the reload interval passed since the last check
+func (r *certReloader) maybeReload() {
+	r.mu.Lock()
+	if time.Since(r.checked) < r.cfg.ReloadInterval {
+		r.mu.Unlock()
+		return
+	}
+	r.checked = time.Now()
+	r.mu.Unlock()
+
+	if err := r.reload(); err != nil {
+		httpLog.Warn("fail to reload http tls certificate, keep using the loaded one", zap.Error(err))
+	}
+}
+
+func (r *certReloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.maybeReload()
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{*r.cert},
+		ClientCAs:    r.clientCAs,
+		ClientAuth:   clientAuthTypes[r.cfg.ClientAuth],
+		// the grpc server runs on http2 too
+		NextProtos: []string{"h2", "http/1.1"},
+	}, nil
+}
+
+// TLSConfig returns the config of the server, whose certificates are taken from the files on every handshake
+func (r *certReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		GetConfigForClient: r.configForClient,
+	}
+}
+
+func equalTimes(a, b []time.Time) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
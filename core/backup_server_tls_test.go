@@ -0,0 +1,134 @@
+package core
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert returns a certificate of name signed by parent, self signed ca if parent is nil
+func newTestCert(t *testing.T, name string, parent *testCert) *testCert {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	assert.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+	}
+	signerCert, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signerCert, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signerCert, &key.PublicKey, signerKey)
+	assert.NoError(t, err)
+	cert, err := x509.ParseCertificate(der)
+	assert.NoError(t, err)
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	assert.NoError(t, err)
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func TestCertReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	writeCert := func(cert *testCert, modTime time.Time) {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), cert.certPEM, 0600))
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.key"), cert.keyPEM, 0600))
+		assert.NoError(t, os.Chtimes(filepath.Join(dir, "tls.crt"), modTime, modTime))
+	}
+	writeCert(newTestCert(t, "server-v1", ca), time.Now().Add(-time.Minute))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "ca.crt"), ca.certPEM, 0600))
+
+	reloader, err := newCertReloader(paramtable.HTTPTLSConfig{
+		CertFile:       filepath.Join(dir, "tls.crt"),
+		KeyFile:        filepath.Join(dir, "tls.key"),
+		ClientCAFile:   filepath.Join(dir, "ca.crt"),
+		ClientAuth:     paramtable.HTTPClientAuthRequire,
+		ReloadInterval: time.Millisecond,
+	})
+	assert.NoError(t, err)
+
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.TLS = reloader.TLSConfig()
+	server.StartTLS()
+	defer server.Close()
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+	client := newTestCert(t, "client", ca)
+	clientCert, err := tls.X509KeyPair(client.certPEM, client.keyPEM)
+	assert.NoError(t, err)
+	get := func(certs ...tls.Certificate) (string, error) {
+		c := &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{RootCAs: roots, Certificates: certs},
+		}}
+		resp, err := c.Get(server.URL)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		return resp.TLS.PeerCertificates[0].Subject.CommonName, nil
+	}
+
+	serverName, err := get(clientCert)
+	assert.NoError(t, err)
+	assert.Equal(t, "server-v1", serverName)
+	// client certificate is required
+	_, err = get()
+	assert.Error(t, err)
+	other := newTestCert(t, "other-client", newTestCert(t, "other-ca", nil))
+	otherCert, err := tls.X509KeyPair(other.certPEM, other.keyPEM)
+	assert.NoError(t, err)
+	_, err = get(otherCert)
+	assert.Error(t, err)
+
+	// rotated certificate is served without restarting
+	writeCert(newTestCert(t, "server-v2", ca), time.Now())
+	time.Sleep(2 * time.Millisecond)
+	serverName, err = get(clientCert)
+	assert.NoError(t, err)
+	assert.Equal(t, "server-v2", serverName)
+
+	// the loaded certificate is kept if the new one is invalid
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "tls.crt"), []byte("invalid"), 0600))
+	time.Sleep(2 * time.Millisecond)
+	serverName, err = get(clientCert)
+	assert.NoError(t, err)
+	assert.Equal(t, "server-v2", serverName)
+}
@@ -0,0 +1,309 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const (
+	RunKindBackup  = "backup"
+	RunKindRestore = "restore"
+
+	// number of the slowest collections kept in a run summary
+	slowestCollectionNum = 5
+)
+
+// runRecorder records the phases and retries of a backup or restore run, which are summarized after the run
+type runRecorder struct {
+	mu         sync.Mutex
+	start      time.Time
+	phases     []*backuppb.PhaseSummary
+	phaseName  string
+	phaseStart time.Time
+
+	storageRetries          *storage.RetryCounter
+	compactedSegmentRetries int64
+}
+
+type runRecorderKey struct{}
+
+func newRunRecorder() *runRecorder {
+	return &runRecorder{start: time.Now(), storageRetries: &storage.RetryCounter{}}
+}
+
+// withRunRecorder returns a context with which the phases and the storage retries of the run are recorded by recorder
+func withRunRecorder(ctx context.Context, recorder *runRecorder) context.Context {
+	ctx = storage.WithRetryCounter(ctx, recorder.storageRetries)
+	return context.WithValue(ctx, runRecorderKey{}, recorder)
+}
+
+// runRecorderFromContext returns nil if the run is not recorded, the methods of a nil recorder do nothing
+func runRecorderFromContext(ctx context.Context) *runRecorder {
+	recorder, _ := ctx.Value(runRecorderKey{}).(*runRecorder)
+	return recorder
+}
+
+// startPhase starts a phase of the run named name, the current phase is ended without bytes
+func (r *runRecorder) startPhase(name string) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endPhaseLocked(0)
+	r.phaseName = name
+	r.phaseStart = time.Now()
+}
+
+// endPhase ends the current phase, which has moved bytes of data
+func (r *runRecorder) endPhase(bytes int64) {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endPhaseLocked(bytes)
+}
+
+func (r *runRecorder) endPhaseLocked(bytes int64) {
+	if r.phaseName == "" {
+		return
+	}
+	duration := time.Since(r.phaseStart).Milliseconds()
+	r.phases = append(r.phases, &backuppb.PhaseSummary{
+		Name:       r.phaseName,
+		DurationMs: duration,
+		Bytes:      bytes,
+		Throughput: throughput(bytes, duration),
+	})
+	r.phaseName = ""
+}
+
+// retryCompactedSegments records the segments of a collection are listed again after compaction
+func (r *runRecorder) retryCompactedSegments() {
+	if r == nil {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.compactedSegmentRetries++
+}
+
+// summary ends the current phase and returns the summary of the run with the phases and retries recorded
+func (r *runRecorder) summary(kind string) *backuppb.RunSummary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.endPhaseLocked(0)
+	return &backuppb.RunSummary{
+		Kind:      kind,
+		StartTime: r.start.UnixMilli(),
+		EndTime:   time.Now().UnixMilli(),
+		Phases:    r.phases,
+		Retries: &backuppb.RetrySummary{
+			Storage:           r.storageRetries.Count(),
+			CompactedSegments: r.compactedSegmentRetries,
+		},
+	}
+}
+
+// throughput returns the bytes per second moved in duration milliseconds
+func throughput(bytes int64, durationMs int64) int64 {
+	if durationMs <= 0 {
+		return 0
+	}
+	return bytes * 1000 / durationMs
+}
+
+// binlogFileCount returns the number of the binlog files of the segments of a collection
+func binlogFileCount(collection *backuppb.CollectionBackupInfo) int64 {
+	var count int64
+	for _, partition := range collection.GetPartitionBackups() {
+		for _, segment := range partition.GetSegmentBackups() {
+			for _, fieldBinlog := range segment.GetBinlogs() {
+				count += int64(len(fieldBinlog.GetBinlogs()))
+			}
+			for _, fieldBinlog := range segment.GetDeltalogs() {
+				count += int64(len(fieldBinlog.GetBinlogs()))
+			}
+		}
+	}
+	return count
+}
+
+// slowestCollections returns the collections taking the longest, slowest first
+func slowestCollections(collections []*backuppb.CollectionRunSummary) []*backuppb.CollectionRunSummary {
+	sort.SliceStable(collections, func(i, j int) bool {
+		return collections[i].GetDurationMs() > collections[j].GetDurationMs()
+	})
+	if len(collections) > slowestCollectionNum {
+		collections = collections[:slowestCollectionNum]
+	}
+	return collections
+}
+
+// collectionDuration returns the milliseconds between start and end in unix seconds, 0 if not ended
+func collectionDuration(start, end int64) int64 {
+	if start <= 0 || end < start {
+		return 0
+	}
+	return (end - start) * 1000
+}
+
+// backupRunSummary summarizes a backup run recorded by recorder
+func backupRunSummary(recorder *runRecorder, backupInfo *backuppb.BackupInfo) *backuppb.RunSummary {
+	summary := recorder.summary(RunKindBackup)
+	summary.BackupName = backupInfo.GetName()
+	summary.State = backupInfo.GetStateCode().String()
+	summary.ErrorMessage = backupInfo.GetErrorMessage()
+	collections := make([]*backuppb.CollectionRunSummary, 0, len(backupInfo.GetCollectionBackups()))
+	for _, collection := range backupInfo.GetCollectionBackups() {
+		if isFailedCollection(collection) {
+			continue
+		}
+		summary.TotalBytes += collection.GetSize()
+		summary.FileCount += binlogFileCount(collection)
+		collections = append(collections, &backuppb.CollectionRunSummary{
+			DbName:         collection.GetDbName(),
+			CollectionName: collection.GetCollectionName(),
+			DurationMs:     collectionDuration(collection.GetStartTime(), collection.GetEndTime()),
+			Size:           collection.GetSize(),
+		})
+	}
+	summary.SlowestCollections = slowestCollections(collections)
+	summary.Throughput = throughput(summary.GetTotalBytes(), summary.GetEndTime()-summary.GetStartTime())
+	return summary
+}
+
+// restoreRunSummary summarizes a restore run recorded by recorder, the import tasks executed again are counted
+// in the retries
+func restoreRunSummary(recorder *runRecorder, backupName string, task *backuppb.RestoreBackupTask) *backuppb.RunSummary {
+	summary := recorder.summary(RunKindRestore)
+	summary.BackupName = backupName
+	summary.RestoreId = task.GetId()
+	summary.State = task.GetStateCode().String()
+	summary.ErrorMessage = task.GetErrorMessage()
+	summary.TotalBytes = task.GetRestoredSize()
+	collections := make([]*backuppb.CollectionRunSummary, 0, len(task.GetCollectionRestoreTasks()))
+	for _, collectionTask := range task.GetCollectionRestoreTasks() {
+		if collectionTask.GetStateCode() == backuppb.RestoreTaskStateCode_SUCCESS {
+			summary.FileCount += binlogFileCount(collectionTask.GetCollBackup())
+		}
+		for _, job := range collectionTask.GetBulkInsertJobs() {
+			if job.GetAttempt() > 1 {
+				summary.Retries.BulkInsert++
+			}
+		}
+		collections = append(collections, &backuppb.CollectionRunSummary{
+			DbName:         collectionTask.GetTargetDbName(),
+			CollectionName: collectionTask.GetTargetCollectionName(),
+			DurationMs:     collectionDuration(collectionTask.GetStartTime(), collectionTask.GetEndTime()),
+			Size:           collectionTask.GetRestoredSize(),
+		})
+	}
+	summary.SlowestCollections = slowestCollections(collections)
+	summary.Throughput = throughput(summary.GetTotalBytes(), summary.GetEndTime()-summary.GetStartTime())
+	return summary
+}
+
+// writeRunSummary logs the summary of a run and writes it into the backup at backupPath. Failure is only logged,
+// the summary is not necessary to use the backup.
+func (b *BackupContext) writeRunSummary(ctx context.Context, bucketName string, backupPath string, summary *backuppb.RunSummary) {
+	logRunSummary(summary)
+	summaryPath := RunSummaryPath(backupPath, summary.GetRestoreId())
+	data, err := json.Marshal(summary)
+	if err == nil {
+		data, err = b.encodeBackupFile(data)
+	}
+	if err == nil {
+		// like meta, the summary is read without the customer key of the backup
+		err = b.getBackupStorageClient().Write(storage.WithoutCustomerKey(ctx), bucketName, summaryPath, data)
+	}
+	if err != nil {
+		log.Warn("fail to write run summary", zap.String("path", summaryPath), zap.Error(err))
+	}
+}
+
+func logRunSummary(summary *backuppb.RunSummary) {
+	slowest := make([]string, 0, len(summary.GetSlowestCollections()))
+	for _, collection := range summary.GetSlowestCollections() {
+		slowest = append(slowest, fmt.Sprintf("%s.%s:%dms", collection.GetDbName(), collection.GetCollectionName(), collection.GetDurationMs()))
+	}
+	phases := make([]string, 0, len(summary.GetPhases()))
+	for _, phase := range summary.GetPhases() {
+		phases = append(phases, fmt.Sprintf("%s:%dms", phase.GetName(), phase.GetDurationMs()))
+	}
+	log.Info("run summary",
+		zap.String("kind", summary.GetKind()),
+		zap.String("backupName", summary.GetBackupName()),
+		zap.String("restoreId", summary.GetRestoreId()),
+		zap.String("state", summary.GetState()),
+		zap.Int64("durationMs", summary.GetEndTime()-summary.GetStartTime()),
+		zap.Int64("totalBytes", summary.GetTotalBytes()),
+		zap.Int64("fileCount", summary.GetFileCount()),
+		zap.Int64("throughput", summary.GetThroughput()),
+		zap.String("phases", strings.Join(phases, ",")),
+		zap.String("slowestCollections", strings.Join(slowest, ",")),
+		zap.Int64("storageRetries", summary.GetRetries().GetStorage()),
+		zap.Int64("compactedSegmentRetries", summary.GetRetries().GetCompactedSegments()),
+		zap.Int64("bulkInsertRetries", summary.GetRetries().GetBulkInsert()))
+}
+
+// ReadRunSummaries reads the summaries of the runs of a backup, the one of the backup first and those of the
+// restores from it in the order they start
+func (b *BackupContext) ReadRunSummaries(ctx context.Context, backupName string) ([]*backuppb.RunSummary, error) {
+	backupPath := b.backupRootPath + SEPERATOR + backupName
+	paths, _, err := b.getBackupStorageClient().ListWithPrefix(ctx, b.backupBucketName, RunSummaryDirPath(backupPath), true)
+	if err != nil {
+		return nil, err
+	}
+	summaries := make([]*backuppb.RunSummary, 0, len(paths))
+	for _, path := range paths {
+		if !strings.HasSuffix(path, ".json") {
+			continue
+		}
+		data, err := b.readBackupFile(storage.WithoutCustomerKey(ctx), b.backupBucketName, path)
+		if err != nil {
+			return nil, err
+		}
+		summary := &backuppb.RunSummary{}
+		if err := json.Unmarshal(data, summary); err != nil {
+			return nil, fmt.Errorf("fail to parse run summary %s: %w", path, err)
+		}
+		summaries = append(summaries, summary)
+	}
+	if len(summaries) == 0 {
+		return nil, fmt.Errorf("backup %s has no run summary", backupName)
+	}
+	sort.SliceStable(summaries, func(i, j int) bool {
+		if summaries[i].GetKind() != summaries[j].GetKind() {
+			return summaries[i].GetKind() == RunKindBackup
+		}
+		return summaries[i].GetStartTime() < summaries[j].GetStartTime()
+	})
+	return summaries, nil
+}
+
+// ReadRunSummary reads the summary of the backup run, or of the restore run of restoreId if it is not empty
+func (b *BackupContext) ReadRunSummary(ctx context.Context, backupName string, restoreId string) (*backuppb.RunSummary, error) {
+	summaryPath := RunSummaryPath(b.backupRootPath+SEPERATOR+backupName, restoreId)
+	data, err := b.readBackupFile(storage.WithoutCustomerKey(ctx), b.backupBucketName, summaryPath)
+	if err != nil {
+		return nil, err
+	}
+	summary := &backuppb.RunSummary{}
+	if err := json.Unmarshal(data, summary); err != nil {
+		return nil, fmt.Errorf("fail to parse run summary %s: %w", summaryPath, err)
+	}
+	return summary, nil
+}
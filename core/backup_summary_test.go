@@ -0,0 +1,153 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestRunRecorder(t *testing.T) {
+	recorder := newRunRecorder()
+	ctx := withRunRecorder(context.Background(), recorder)
+	assert.Same(t, recorder, runRecorderFromContext(ctx))
+
+	runRecorderFromContext(ctx).startPhase("list")
+	runRecorderFromContext(ctx).startPhase("copy")
+	time.Sleep(10 * time.Millisecond)
+	runRecorderFromContext(ctx).endPhase(1000)
+	runRecorderFromContext(ctx).retryCompactedSegments()
+	runRecorderFromContext(ctx).startPhase("meta")
+
+	summary := recorder.summary(RunKindBackup)
+	assert.Equal(t, RunKindBackup, summary.GetKind())
+	names := make([]string, 0)
+	for _, phase := range summary.GetPhases() {
+		names = append(names, phase.GetName())
+	}
+	assert.Equal(t, []string{"list", "copy", "meta"}, names)
+	assert.Equal(t, int64(1000), summary.GetPhases()[1].GetBytes())
+	assert.Greater(t, summary.GetPhases()[1].GetThroughput(), int64(0))
+	assert.Equal(t, int64(0), summary.GetPhases()[2].GetBytes())
+	assert.Equal(t, int64(1), summary.GetRetries().GetCompactedSegments())
+
+	// the run is not recorded
+	nobody := runRecorderFromContext(context.Background())
+	assert.Nil(t, nobody)
+	nobody.startPhase("copy")
+	nobody.endPhase(1)
+	nobody.retryCompactedSegments()
+}
+
+func summaryCollection(name string, start, end, size int64, binlogs int) *backuppb.CollectionBackupInfo {
+	logs := make([]*backuppb.Binlog, binlogs)
+	for i := range logs {
+		logs[i] = &backuppb.Binlog{LogPath: fmt.Sprintf("insert_log/1/2/3/100/%d", i)}
+	}
+	return &backuppb.CollectionBackupInfo{
+		DbName:         "default",
+		CollectionName: name,
+		StartTime:      start,
+		EndTime:        end,
+		Size:           size,
+		PartitionBackups: []*backuppb.PartitionBackupInfo{{SegmentBackups: []*backuppb.SegmentBackupInfo{{
+			Binlogs:   []*backuppb.FieldBinlog{{FieldID: 100, Binlogs: logs}},
+			Deltalogs: []*backuppb.FieldBinlog{{FieldID: 0, Binlogs: []*backuppb.Binlog{{LogPath: "delta_log/1/2/3/100/1"}}}},
+		}}}},
+	}
+}
+
+func TestBackupRunSummary(t *testing.T) {
+	backupInfo := &backuppb.BackupInfo{
+		Name:      "summary",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_PARTIAL,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			summaryCollection("fast", 100, 101, 10, 1),
+			summaryCollection("slow", 100, 110, 20, 2),
+			summaryCollection("unfinished", 100, 0, 30, 3),
+		},
+	}
+	failed := summaryCollection("failed", 100, 200, 40, 4)
+	failed.StateCode = backuppb.BackupTaskStateCode_BACKUP_FAIL
+	backupInfo.CollectionBackups = append(backupInfo.CollectionBackups, failed)
+
+	summary := backupRunSummary(newRunRecorder(), backupInfo)
+	assert.Equal(t, "summary", summary.GetBackupName())
+	assert.Equal(t, "BACKUP_PARTIAL", summary.GetState())
+	assert.Equal(t, int64(60), summary.GetTotalBytes())
+	assert.Equal(t, int64(9), summary.GetFileCount())
+	assert.Len(t, summary.GetSlowestCollections(), 3)
+	assert.Equal(t, "slow", summary.GetSlowestCollections()[0].GetCollectionName())
+	assert.Equal(t, int64(10000), summary.GetSlowestCollections()[0].GetDurationMs())
+	assert.Equal(t, int64(0), summary.GetSlowestCollections()[2].GetDurationMs())
+}
+
+func TestRestoreRunSummary(t *testing.T) {
+	collections := make([]*backuppb.RestoreCollectionTask, 0)
+	for i := int64(0); i < 7; i++ {
+		collections = append(collections, &backuppb.RestoreCollectionTask{
+			StateCode:            backuppb.RestoreTaskStateCode_SUCCESS,
+			TargetDbName:         "default",
+			TargetCollectionName: fmt.Sprintf("coll%d", i),
+			StartTime:            100,
+			EndTime:              100 + i,
+			RestoredSize:         i,
+			CollBackup:           summaryCollection("coll", 0, 0, i, 1),
+			BulkInsertJobs:       []*backuppb.BulkInsertJob{{Attempt: 1}, {Attempt: 2}},
+		})
+	}
+	collections[0].StateCode = backuppb.RestoreTaskStateCode_FAIL
+	task := &backuppb.RestoreBackupTask{
+		Id:                     "restore-1",
+		StateCode:              backuppb.RestoreTaskStateCode_FAIL,
+		ErrorMessage:           "bulk insert failed",
+		RestoredSize:           21,
+		CollectionRestoreTasks: collections,
+	}
+
+	summary := restoreRunSummary(newRunRecorder(), "summary", task)
+	assert.Equal(t, RunKindRestore, summary.GetKind())
+	assert.Equal(t, "restore-1", summary.GetRestoreId())
+	assert.Equal(t, "FAIL", summary.GetState())
+	assert.Equal(t, "bulk insert failed", summary.GetErrorMessage())
+	assert.Equal(t, int64(21), summary.GetTotalBytes())
+	// the binlogs of the failed collection are not restored
+	assert.Equal(t, int64(12), summary.GetFileCount())
+	assert.Equal(t, int64(7), summary.GetRetries().GetBulkInsert())
+	assert.Len(t, summary.GetSlowestCollections(), slowestCollectionNum)
+	assert.Equal(t, "coll6", summary.GetSlowestCollections()[0].GetCollectionName())
+	assert.Equal(t, "coll2", summary.GetSlowestCollections()[4].GetCollectionName())
+}
+
+func TestReadRunSummaries(t *testing.T) {
+	ctx := context.Background()
+	b := newManifestBackupContext(&memoryChunkManager{files: make(map[string][]byte)})
+	_, err := b.ReadRunSummaries(ctx, "summary")
+	assert.Error(t, err)
+
+	backupPath := b.backupRootPath + SEPERATOR + "summary"
+	assert.Equal(t, "backup/summary/meta/summary/backup.json", RunSummaryPath(backupPath, ""))
+	assert.Equal(t, "backup/summary/meta/summary/restore_r1.json", RunSummaryPath(backupPath, "r1"))
+	b.writeRunSummary(ctx, b.backupBucketName, backupPath, &backuppb.RunSummary{Kind: RunKindRestore, BackupName: "summary", RestoreId: "r2", StartTime: 300})
+	b.writeRunSummary(ctx, b.backupBucketName, backupPath, &backuppb.RunSummary{Kind: RunKindRestore, BackupName: "summary", RestoreId: "r1", StartTime: 200})
+	b.writeRunSummary(ctx, b.backupBucketName, backupPath, &backuppb.RunSummary{Kind: RunKindBackup, BackupName: "summary", StartTime: 100, TotalBytes: 10})
+
+	summaries, err := b.ReadRunSummaries(ctx, "summary")
+	assert.NoError(t, err)
+	ids := make([]string, 0)
+	for _, summary := range summaries {
+		ids = append(ids, summary.GetKind()+":"+summary.GetRestoreId())
+	}
+	assert.Equal(t, []string{"backup:", "restore:r1", "restore:r2"}, ids)
+
+	summary, err := b.ReadRunSummary(ctx, "summary", "")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(10), summary.GetTotalBytes())
+	summary, err = b.ReadRunSummary(ctx, "summary", "r2")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(300), summary.GetStartTime())
+}
@@ -0,0 +1,438 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// written into the local dir next to the files of a backup downloaded completely, with the files to upload
+const TRANSFER_INDEX_SUFFIX = ".download.json"
+
+// TransferOptions are the options of downloading, uploading and copying a backup
+type TransferOptions struct {
+	// max bandwidth in MB/s of the data transferred, 0 means not limited other than by backup.bandwidthLimit
+	BandwidthLimit int
+	// reference of the customer key of a backup encrypted by sse type customer, the key in config if empty
+	SseCustomerKey string
+	// upload or copy a backup existing in the target storage, the files of the same sizes are not transferred again
+	Force bool
+	// read every file written back to compare its checksum with the file read
+	Verify bool
+	// called after every file with the bytes transferred or skipped and the bytes of all files
+	Progress func(done int64, total int64)
+}
+
+// TransferResult is the result of downloading, uploading or copying a backup
+type TransferResult struct {
+	BackupName string
+	// files transferred, and the files skipped as they exist with the same sizes
+	Files        int
+	SkippedFiles int
+	Size         int64
+	SkippedSize  int64
+}
+
+// transferIndex lists the files of a downloaded backup relative to the backup root, with the server side encryption
+// of the backup to upload it with
+type transferIndex struct {
+	BackupName        string          `json:"backup_name"`
+	SseType           string          `json:"sse_type,omitempty"`
+	SseKmsKeyId       string          `json:"sse_kms_key_id,omitempty"`
+	SseCustomerKeyMd5 string          `json:"sse_customer_key_md5,omitempty"`
+	Files             []*transferFile `json:"files"`
+}
+
+type transferFile struct {
+	Path string `json:"path"`
+	Size int64  `json:"size"`
+	// checksum of the binlogs recorded in backup, empty if not recorded
+	Crc32C string `json:"crc32c,omitempty"`
+}
+
+// DownloadBackup downloads the complete backup, meta and binlogs, into localDir in the layout of the backup root,
+// including the binlogs of its base backups and its dedup objects. Files downloaded already with the same sizes are
+// skipped, so an interrupted download is resumed by downloading again. The index of the files is written at last,
+// a backup is only uploaded by UploadBackup once it is downloaded completely.
+func (b *BackupContext) DownloadBackup(ctx context.Context, backupName string, localDir string, opts TransferOptions) (*TransferResult, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return nil, err
+		}
+	}
+	if backupName == "" {
+		return nil, fmt.Errorf("empty backup name")
+	}
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil {
+		return nil, fmt.Errorf("backup does not exist: %s", backupName)
+	}
+	if backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS && backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_PARTIAL {
+		return nil, fmt.Errorf("backup %s is not complete, state %s", backupName, backup.GetStateCode())
+	}
+	sse, err := b.backupServerSideEncryption(backup, opts.SseCustomerKey)
+	if err != nil {
+		return nil, err
+	}
+	files, err := b.backupTransferFiles(ctx, backup)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(localDir, os.ModePerm); err != nil {
+		return nil, err
+	}
+	local, err := b.localStorage(ctx, localDir)
+	if err != nil {
+		return nil, err
+	}
+	source := limitTransfer(b.getBackupStorageClient(), opts.BandwidthLimit)
+	log.Info("download backup", zap.String("backupName", backupName), zap.String("localDir", localDir), zap.Int("files", len(files)))
+	result, err := transferBackupFiles(ctx, storage.WithSourceServerSideEncryption(ctx, sse), backupName,
+		source, b.backupBucketName, b.backupRootPath, local, "", "", files, opts)
+	if err != nil {
+		return result, err
+	}
+	data, err := json.Marshal(&transferIndex{
+		BackupName:        backupName,
+		SseType:           backup.GetSseType(),
+		SseKmsKeyId:       backup.GetSseKmsKeyId(),
+		SseCustomerKeyMd5: backup.GetSseCustomerKeyMd5(),
+		Files:             files,
+	})
+	if err != nil {
+		return result, err
+	}
+	if err := local.Write(ctx, "", backupName+TRANSFER_INDEX_SUFFIX, data); err != nil {
+		return result, err
+	}
+	log.Info("downloaded backup",
+		zap.String("backupName", backupName),
+		zap.Int("files", result.Files),
+		zap.Int("skippedFiles", result.SkippedFiles),
+		zap.Int64("size", result.Size))
+	return result, nil
+}
+
+// UploadBackup uploads a backup downloaded completely by DownloadBackup from localDir into the backup storage.
+// The meta is uploaded after the binlogs, so the backup is only listed once all of its files are uploaded. Files
+// uploaded already with the same sizes are skipped, so an interrupted upload is resumed by uploading again.
+func (b *BackupContext) UploadBackup(ctx context.Context, localDir string, backupName string, opts TransferOptions) (*TransferResult, error) {
+	if !b.started {
+		if err := b.Start(); err != nil {
+			return nil, err
+		}
+	}
+	if backupName == "" {
+		return nil, fmt.Errorf("empty backup name")
+	}
+	local, err := b.localStorage(ctx, localDir)
+	if err != nil {
+		return nil, err
+	}
+	indexPath := backupName + TRANSFER_INDEX_SUFFIX
+	exist, err := local.Exist(ctx, "", indexPath)
+	if err != nil {
+		return nil, err
+	}
+	if !exist {
+		return nil, fmt.Errorf("backup %s is not downloaded completely into %s, %s not found", backupName, localDir, indexPath)
+	}
+	data, err := local.Read(ctx, "", indexPath)
+	if err != nil {
+		return nil, err
+	}
+	index := &transferIndex{}
+	if err := json.Unmarshal(data, index); err != nil {
+		return nil, fmt.Errorf("illegal download index %s: %w", indexPath, err)
+	}
+	exist, err = b.getBackupStorageClient().Exist(ctx, b.backupBucketName, BackupMetaPath(b.backupRootPath, backupName))
+	if err != nil {
+		return nil, err
+	}
+	if exist && !opts.Force {
+		return nil, fmt.Errorf("backup already exists: %s", backupName)
+	}
+	// binlogs of a backup encrypted by customer key are encrypted by the key again
+	sse, err := b.backupServerSideEncryption(&backuppb.BackupInfo{
+		Name:              backupName,
+		SseType:           index.SseType,
+		SseKmsKeyId:       index.SseKmsKeyId,
+		SseCustomerKeyMd5: index.SseCustomerKeyMd5,
+	}, opts.SseCustomerKey)
+	if err != nil {
+		return nil, err
+	}
+	target := limitTransfer(b.getBackupStorageClient(), opts.BandwidthLimit)
+	log.Info("upload backup", zap.String("backupName", backupName), zap.String("localDir", localDir), zap.Int("files", len(index.Files)))
+	result, err := transferBackupFiles(ctx, storage.WithServerSideEncryption(ctx, sse), backupName,
+		local, "", "", target, b.backupBucketName, b.backupRootPath, index.Files, opts)
+	if err != nil {
+		return result, err
+	}
+	if err := b.registerTransferredBackup(ctx, backupName); err != nil {
+		return result, err
+	}
+	log.Info("uploaded backup",
+		zap.String("backupName", backupName),
+		zap.Int("files", result.Files),
+		zap.Int("skippedFiles", result.SkippedFiles),
+		zap.Int64("size", result.Size))
+	return result, nil
+}
+
+// CopyBackup copies the complete backup, meta and binlogs, from the backup storage into the backup storage of target,
+// another bucket, region or provider, without backing up milvus again. The binlogs are checked by the checksums
+// recorded in backup when read, files of the same sizes in target are skipped like an interrupted copy, and the meta is
+// copied after the binlogs. Files are copied as they are stored, the backup is read in target with the same
+// encryption key.
+func (b *BackupContext) CopyBackup(ctx context.Context, target *BackupContext, backupName string, opts TransferOptions) (*TransferResult, error) {
+	for _, backupContext := range []*BackupContext{b, target} {
+		if !backupContext.started {
+			if err := backupContext.Start(); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if backupName == "" {
+		return nil, fmt.Errorf("empty backup name")
+	}
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return nil, err
+	}
+	if backup == nil {
+		return nil, fmt.Errorf("backup does not exist: %s", backupName)
+	}
+	if backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_SUCCESS && backup.GetStateCode() != backuppb.BackupTaskStateCode_BACKUP_PARTIAL {
+		return nil, fmt.Errorf("backup %s is not complete, state %s", backupName, backup.GetStateCode())
+	}
+	sse, err := b.backupServerSideEncryption(backup, opts.SseCustomerKey)
+	if err == nil {
+		err = validateServerSideEncryption(target.backupStorageType(), sse)
+	}
+	if err != nil {
+		return nil, err
+	}
+	exist, err := target.getBackupStorageClient().Exist(ctx, target.backupBucketName, BackupMetaPath(target.backupRootPath, backupName))
+	if err != nil {
+		return nil, err
+	}
+	if exist && !opts.Force {
+		return nil, fmt.Errorf("backup already exists in target: %s", backupName)
+	}
+	files, err := b.backupTransferFiles(ctx, backup)
+	if err != nil {
+		return nil, err
+	}
+	// binlogs encrypted by customer key are read and written with the key
+	keyCtx := storage.WithServerSideEncryption(storage.WithSourceServerSideEncryption(ctx, sse), sse)
+	log.Info("copy backup", zap.String("backupName", backupName), zap.String("targetBucket", target.backupBucketName), zap.Int("files", len(files)))
+	result, err := transferBackupFiles(ctx, keyCtx, backupName,
+		limitTransfer(b.getBackupStorageClient(), opts.BandwidthLimit), b.backupBucketName, b.backupRootPath,
+		target.getBackupStorageClient(), target.backupBucketName, target.backupRootPath, files, opts)
+	if err != nil {
+		return result, err
+	}
+	if err := target.registerTransferredBackup(ctx, backupName); err != nil {
+		return result, err
+	}
+	log.Info("copied backup",
+		zap.String("backupName", backupName),
+		zap.String("targetBucket", target.backupBucketName),
+		zap.Int("files", result.Files),
+		zap.Int("skippedFiles", result.SkippedFiles),
+		zap.Int64("size", result.Size))
+	return result, nil
+}
+
+// registerTransferredBackup reads the backup uploaded or copied into the backup storage, counts its dedup objects and
+// caches it
+func (b *BackupContext) registerTransferredBackup(ctx context.Context, backupName string) error {
+	backup, err := b.readBackup(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+backupName)
+	if err != nil {
+		return err
+	}
+	if backup == nil {
+		return fmt.Errorf("backup %s is not found after transferred", backupName)
+	}
+	b.registerDedupObjects(ctx, backup)
+	b.refreshBackupCache(backup)
+	return nil
+}
+
+// backupTransferFiles lists the files of a backup relative to the backup root, the files in its dir and the binlogs
+// stored out of it, in the base backups or as dedup objects. Meta files are transferred last, the backup meta file at
+// the end.
+func (b *BackupContext) backupTransferFiles(ctx context.Context, backup *backuppb.BackupInfo) ([]*transferFile, error) {
+	client := b.getBackupStorageClient()
+	paths, sizes, err := client.ListWithPrefix(ctx, b.backupBucketName, BackupDirPath(b.backupRootPath, backup.GetName()), true)
+	if err != nil {
+		return nil, err
+	}
+	manifest := b.buildBackupManifest(backup)
+	checksums := make(map[string]string, len(manifest.GetFiles()))
+	for _, file := range manifest.GetFiles() {
+		checksums[file.GetPath()] = file.GetCrc32C()
+	}
+	files := make([]*transferFile, 0, len(paths))
+	listed := make(map[string]bool, len(paths))
+	for i, filePath := range paths {
+		relativePath := strings.TrimPrefix(filePath, b.backupRootPath+SEPERATOR)
+		files = append(files, &transferFile{Path: relativePath, Size: sizes[i], Crc32C: checksums[relativePath]})
+		listed[relativePath] = true
+	}
+	for _, file := range manifest.GetFiles() {
+		if listed[file.GetPath()] {
+			continue
+		}
+		size, err := client.Size(ctx, b.backupBucketName, b.backupRootPath+SEPERATOR+file.GetPath())
+		if err != nil {
+			return nil, fmt.Errorf("fail to read the size of %s: %w", file.GetPath(), err)
+		}
+		files = append(files, &transferFile{Path: file.GetPath(), Size: size, Crc32C: file.GetCrc32C()})
+		listed[file.GetPath()] = true
+	}
+	sortTransferFiles(backup.GetName(), files)
+	return files, nil
+}
+
+// sortTransferFiles sorts the files by path, with the meta files of the backup after the others and the backup meta
+// file at last
+func sortTransferFiles(backupName string, files []*transferFile) {
+	metaDir := backupName + SEPERATOR + META_PREFIX + SEPERATOR
+	rank := func(file *transferFile) int {
+		switch {
+		case file.Path == metaDir+BACKUP_META_FILE:
+			return 2
+		case strings.HasPrefix(file.Path, metaDir):
+			return 1
+		default:
+			return 0
+		}
+	}
+	sort.SliceStable(files, func(i, j int) bool {
+		if rank(files[i]) != rank(files[j]) {
+			return rank(files[i]) < rank(files[j])
+		}
+		return files[i].Path < files[j].Path
+	})
+}
+
+// customerKeyEncrypted returns whether a file of a backup is encrypted by the customer key of the backup, the binlogs
+// and the rows sampled are, the other files are written without the key to be read without it. The files of a backup
+// or its base backups besides their meta are binlogs, whatever the path templates of their collections.
+func customerKeyEncrypted(backupName string, relativePath string) bool {
+	_, backupRelativePath, _ := strings.Cut(relativePath, SEPERATOR)
+	return strings.HasPrefix(relativePath, DEDUP_DIR+SEPERATOR) ||
+		!strings.HasPrefix(backupRelativePath, META_PREFIX+SEPERATOR) ||
+		strings.HasPrefix(relativePath, backupName+SEPERATOR+META_PREFIX+SEPERATOR+ROW_SAMPLE_DIR+SEPERATOR)
+}
+
+// transferBackupFiles copies the files relative to fromRoot into toRoot, the files encrypted by customer key are
+// read and written with keyCtx. A file existing in toRoot with the same size is skipped, and a file whose size or
+// checksum differs from listed fails the transfer, as does a file read back different if opts.Verify.
+func transferBackupFiles(ctx context.Context, keyCtx context.Context, backupName string,
+	from storage.ChunkManager, fromBucket string, fromRoot string,
+	to storage.ChunkManager, toBucket string, toRoot string,
+	files []*transferFile, opts TransferOptions) (*TransferResult, error) {
+	result := &TransferResult{BackupName: backupName}
+	var total, done int64
+	for _, file := range files {
+		total += file.Size
+	}
+	fullPath := func(root string, relativePath string) string {
+		if root == "" {
+			return relativePath
+		}
+		return root + SEPERATOR + relativePath
+	}
+	for _, file := range files {
+		fileCtx := storage.WithoutCustomerKey(ctx)
+		if customerKeyEncrypted(backupName, file.Path) {
+			fileCtx = keyCtx
+		}
+		fromPath, toPath := fullPath(fromRoot, file.Path), fullPath(toRoot, file.Path)
+		exist, err := to.Exist(fileCtx, toBucket, toPath)
+		if err != nil {
+			return result, err
+		}
+		if exist {
+			size, err := to.Size(fileCtx, toBucket, toPath)
+			if err != nil {
+				return result, err
+			}
+			if size == file.Size {
+				result.SkippedFiles++
+				result.SkippedSize += size
+				done += size
+				if opts.Progress != nil {
+					opts.Progress(done, total)
+				}
+				continue
+			}
+		}
+		data, err := from.Read(fileCtx, fromBucket, fromPath)
+		if err != nil {
+			return result, fmt.Errorf("fail to read %s: %w", fromPath, err)
+		}
+		if int64(len(data)) != file.Size {
+			return result, fmt.Errorf("size of %s is %d, expected %d", fromPath, len(data), file.Size)
+		}
+		checksum := utils.Crc32c(data)
+		if file.Crc32C != "" && checksum != file.Crc32C {
+			return result, fmt.Errorf("checksum of %s is %s, expected %s, the file is corrupted", fromPath, checksum, file.Crc32C)
+		}
+		if err := to.Write(fileCtx, toBucket, toPath, data); err != nil {
+			return result, fmt.Errorf("fail to write %s: %w", toPath, err)
+		}
+		if opts.Verify {
+			written, err := to.Read(fileCtx, toBucket, toPath)
+			if err != nil {
+				return result, fmt.Errorf("fail to read %s back: %w", toPath, err)
+			}
+			if sum := utils.Crc32c(written); sum != checksum {
+				return result, fmt.Errorf("checksum of %s written is %s, expected %s", toPath, sum, checksum)
+			}
+		}
+		result.Files++
+		result.Size += file.Size
+		done += file.Size
+		if opts.Progress != nil {
+			opts.Progress(done, total)
+		}
+	}
+	return result, nil
+}
+
+// localStorage returns the storage of the files in localDir, the object keys are the paths relative to it
+func (b *BackupContext) localStorage(ctx context.Context, localDir string) (storage.ChunkManager, error) {
+	params := b.params
+	params.MinioCfg.StorageType = paramtable.Local
+	params.MinioCfg.LocalPath = filepath.Clean(localDir)
+	// the bandwidth is limited on the side of the backup storage
+	params.BackupCfg.BandwidthLimit = 0
+	return storage.NewChunkManager(ctx, params)
+}
+
+// limitTransfer limits the bandwidth of a transfer to bandwidthLimit MB/s, not limited more if it is 0
+func limitTransfer(client storage.ChunkManager, bandwidthLimit int) storage.ChunkManager {
+	if bandwidthLimit <= 0 {
+		return client
+	}
+	return storage.NewRateLimitedChunkManager(client, bandwidthLimit*1024*1024)
+}
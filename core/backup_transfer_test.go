@@ -0,0 +1,159 @@
+package core
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+	"github.com/zilliztech/milvus-backup/core/storage"
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+func TestSortTransferFiles(t *testing.T) {
+	files := []*transferFile{
+		{Path: "b1/meta/backup_meta.json"},
+		{Path: "b1/meta/collection_meta.json"},
+		{Path: "dedup-objects/ab/abcd"},
+		{Path: "b1/binlogs/insert_log/1/1/1/1"},
+		{Path: "b0/binlogs/insert_log/1/1/1/1"},
+	}
+	sortTransferFiles("b1", files)
+	paths := make([]string, 0)
+	for _, file := range files {
+		paths = append(paths, file.Path)
+	}
+	assert.Equal(t, []string{
+		"b0/binlogs/insert_log/1/1/1/1",
+		"b1/binlogs/insert_log/1/1/1/1",
+		"dedup-objects/ab/abcd",
+		"b1/meta/collection_meta.json",
+		"b1/meta/backup_meta.json",
+	}, paths)
+
+	assert.True(t, customerKeyEncrypted("b1", "b0/binlogs/insert_log/1/1/1/1"))
+	assert.True(t, customerKeyEncrypted("b1", "dedup-objects/ab/abcd"))
+	assert.True(t, customerKeyEncrypted("b1", "b1/db1/coll/insert_log/1/1/1/1"))
+	assert.True(t, customerKeyEncrypted("b1", "b1/meta/row_samples/1.json"))
+	assert.False(t, customerKeyEncrypted("b1", "b1/meta/backup_meta.json"))
+}
+
+func TestDownloadUploadBackup(t *testing.T) {
+	ctx := context.Background()
+	source := &memoryChunkManager{files: map[string][]byte{
+		"backup/test_backup/binlogs/insert_log/1/1/1/100/1": []byte("binlog"),
+		"backup/dedup-objects/ab/abcdef":                    []byte("dedup object"),
+		"backup/other_backup/meta/backup_meta.json":         []byte("{}"),
+	}}
+	var sourceClient storage.ChunkManager = source
+	sourceContext := &BackupContext{storageClient: &sourceClient, backupRootPath: "backup", started: true,
+		params: paramtable.BackupParams{MinioCfg: paramtable.MinioConfig{StorageType: paramtable.Minio}}}
+	backupInfo := &backuppb.BackupInfo{
+		Name:      "test_backup",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId:   1,
+			CollectionName: "coll",
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:  1,
+				CollectionId: 1,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: 100, CollectionId: 1, PartitionId: 1,
+					Binlogs: []*backuppb.FieldBinlog{{FieldID: 1, Binlogs: []*backuppb.Binlog{{LogPath: "files/insert_log/1/1/100/1", ObjectName: "abcdef"}}}}}},
+			}},
+		}},
+	}
+	assert.NoError(t, sourceContext.writeBackupCheckpoint(ctx, backupInfo, true))
+
+	_, err := sourceContext.DownloadBackup(ctx, "not_exist", t.TempDir(), TransferOptions{})
+	assert.Error(t, err)
+
+	localDir := t.TempDir()
+	var progressed int64
+	result, err := sourceContext.DownloadBackup(ctx, "test_backup", localDir, TransferOptions{Progress: func(done, total int64) { progressed = done }})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, result.SkippedFiles)
+	assert.Equal(t, result.Size, progressed)
+	data, err := os.ReadFile(filepath.Join(localDir, "dedup-objects/ab/abcdef"))
+	assert.NoError(t, err)
+	assert.Equal(t, "dedup object", string(data))
+	assert.FileExists(t, filepath.Join(localDir, "test_backup/binlogs/insert_log/1/1/1/100/1"))
+	assert.FileExists(t, filepath.Join(localDir, "test_backup"+TRANSFER_INDEX_SUFFIX))
+	assert.NoFileExists(t, filepath.Join(localDir, "other_backup/meta/backup_meta.json"))
+
+	// downloaded again, the files downloaded already are skipped
+	resumed, err := sourceContext.DownloadBackup(ctx, "test_backup", localDir, TransferOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, resumed.Files)
+	assert.Equal(t, result.Files, resumed.SkippedFiles)
+
+	var targetClient storage.ChunkManager = &memoryChunkManager{files: make(map[string][]byte)}
+	targetContext := &BackupContext{storageClient: &targetClient, backupRootPath: "restored", started: true,
+		params: paramtable.BackupParams{MinioCfg: paramtable.MinioConfig{StorageType: paramtable.Minio}}}
+	_, err = targetContext.UploadBackup(ctx, localDir, "other_backup", TransferOptions{})
+	assert.Error(t, err)
+	uploaded, err := targetContext.UploadBackup(ctx, localDir, "test_backup", TransferOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, result.Files, uploaded.Files)
+	backup, err := targetContext.readBackup(ctx, "", "restored/test_backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "coll", backup.GetCollectionBackups()[0].GetCollectionName())
+	data, err = targetClient.Read(ctx, "", "restored/dedup-objects/ab/abcdef")
+	assert.NoError(t, err)
+	assert.Equal(t, "dedup object", string(data))
+
+	// an existing backup is only uploaded again by force
+	_, err = targetContext.UploadBackup(ctx, localDir, "test_backup", TransferOptions{})
+	assert.Error(t, err)
+	uploaded, err = targetContext.UploadBackup(ctx, localDir, "test_backup", TransferOptions{Force: true})
+	assert.NoError(t, err)
+	assert.Equal(t, 0, uploaded.Files)
+}
+
+func TestCopyBackup(t *testing.T) {
+	ctx := context.Background()
+	binlog := []byte("binlog")
+	var sourceClient storage.ChunkManager = &memoryChunkManager{files: map[string][]byte{
+		"backup/dedup-objects/ab/abcdef": binlog,
+	}}
+	source := &BackupContext{storageClient: &sourceClient, backupRootPath: "backup", started: true,
+		params: paramtable.BackupParams{MinioCfg: paramtable.MinioConfig{StorageType: paramtable.Minio}}}
+	backupInfo := &backuppb.BackupInfo{
+		Name:      "test_backup",
+		StateCode: backuppb.BackupTaskStateCode_BACKUP_SUCCESS,
+		CollectionBackups: []*backuppb.CollectionBackupInfo{{
+			CollectionId:   1,
+			CollectionName: "coll",
+			PartitionBackups: []*backuppb.PartitionBackupInfo{{
+				PartitionId:  1,
+				CollectionId: 1,
+				SegmentBackups: []*backuppb.SegmentBackupInfo{{SegmentId: 100, CollectionId: 1, PartitionId: 1,
+					Binlogs: []*backuppb.FieldBinlog{{FieldID: 1, Binlogs: []*backuppb.Binlog{{LogPath: "files/insert_log/1/1/100/1", ObjectName: "abcdef", Crc32C: utils.Crc32c(binlog)}}}}}},
+			}},
+		}},
+	}
+	assert.NoError(t, source.writeBackupCheckpoint(ctx, backupInfo, true))
+
+	var targetClient storage.ChunkManager = &memoryChunkManager{files: make(map[string][]byte)}
+	target := &BackupContext{storageClient: &targetClient, backupRootPath: "dr", backupBucketName: "dr-bucket", started: true,
+		params: paramtable.BackupParams{MinioCfg: paramtable.MinioConfig{StorageType: paramtable.CloudProviderGCP}}}
+	result, err := source.CopyBackup(ctx, target, "test_backup", TransferOptions{Verify: true})
+	assert.NoError(t, err)
+	assert.Greater(t, result.Files, 1)
+	copied, err := target.readBackup(ctx, "dr-bucket", "dr/test_backup")
+	assert.NoError(t, err)
+	assert.Equal(t, "coll", copied.GetCollectionBackups()[0].GetCollectionName())
+	_, err = source.CopyBackup(ctx, target, "test_backup", TransferOptions{})
+	assert.Error(t, err)
+
+	// a corrupted binlog is not copied, unless it is skipped as copied already
+	sourceClient.Write(ctx, "", "backup/dedup-objects/ab/abcdef", []byte("BINLOG"))
+	_, err = source.CopyBackup(ctx, target, "test_backup", TransferOptions{Force: true})
+	assert.NoError(t, err)
+	targetClient.Remove(ctx, "dr-bucket", "dr/dedup-objects/ab/abcdef")
+	_, err = source.CopyBackup(ctx, target, "test_backup", TransferOptions{Force: true})
+	assert.ErrorContains(t, err, "the file is corrupted")
+}
@@ -0,0 +1,107 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// VeleroBackupItemKey is the key of the backup item in the data of the configmap written by the Velero hooks
+const VeleroBackupItemKey = "backup.json"
+
+// VeleroBackupItem is the metadata of the backup created by the pre-backup hook of Velero. It is recorded in a
+// configmap backed up by Velero, so that the post-restore hook finds the backup of the same point in time.
+type VeleroBackupItem struct {
+	BackupName      string            `json:"backupName"`
+	BucketName      string            `json:"bucketName"`
+	Path            string            `json:"path"`
+	BackupTimestamp uint64            `json:"backupTimestamp"`
+	MetaOnly        bool              `json:"metaOnly"`
+	Size            int64             `json:"size"`
+	MilvusVersion   string            `json:"milvusVersion"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	// db.collection_name of the collections backed up, sorted
+	Collections []string `json:"collections"`
+}
+
+// NewVeleroBackupItem records the backup in bucketName/path, the collections failed in a partial backup are left out
+func NewVeleroBackupItem(backup *backuppb.BackupInfo, bucketName string, path string, metaOnly bool) *VeleroBackupItem {
+	item := &VeleroBackupItem{
+		BackupName:      backup.GetName(),
+		BucketName:      bucketName,
+		Path:            path,
+		BackupTimestamp: backup.GetBackupTimestamp(),
+		MetaOnly:        metaOnly,
+		Size:            backup.GetSize(),
+		MilvusVersion:   backup.GetMilvusVersion(),
+		Labels:          backup.GetLabels(),
+		Collections:     make([]string, 0, len(backup.GetCollectionBackups())),
+	}
+	for _, collectionBackup := range backup.GetCollectionBackups() {
+		if isFailedCollection(collectionBackup) {
+			continue
+		}
+		db := collectionBackup.GetDbName()
+		if db == "" {
+			db = "default"
+		}
+		item.Collections = append(item.Collections, db+"."+collectionBackup.GetCollectionName())
+	}
+	sort.Strings(item.Collections)
+	return item
+}
+
+// Marshal returns the json of the item stored in the configmap
+func (item *VeleroBackupItem) Marshal() (string, error) {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// UnmarshalVeleroBackupItem parses the item stored in the configmap
+func UnmarshalVeleroBackupItem(data string) (*VeleroBackupItem, error) {
+	item := &VeleroBackupItem{}
+	if err := json.Unmarshal([]byte(data), item); err != nil {
+		return nil, fmt.Errorf("fail to parse velero backup item: %w", err)
+	}
+	if item.BackupName == "" {
+		return nil, fmt.Errorf("velero backup item has no backup name")
+	}
+	return item, nil
+}
+
+// VeleroMissingCollections returns the collections of the item which don't exist in milvus after Velero restores
+// the volumes, and the ones which exist
+func (b *BackupContext) VeleroMissingCollections(ctx context.Context, item *VeleroBackupItem) ([]string, []string, error) {
+	return splitExistingCollections(item.Collections, func(db string, collectionName string) (bool, error) {
+		return b.getMilvusClient().HasCollection(ctx, db, collectionName)
+	})
+}
+
+// splitExistingCollections splits db.collection_name into the missing ones and the existing ones
+func splitExistingCollections(collections []string, exists func(db string, collectionName string) (bool, error)) ([]string, []string, error) {
+	missing := make([]string, 0)
+	existing := make([]string, 0)
+	for _, collection := range collections {
+		splits := strings.SplitN(collection, ".", 2)
+		if len(splits) != 2 {
+			return nil, nil, fmt.Errorf("illegal collection %s, format: db.collection_name", collection)
+		}
+		exist, err := exists(splits[0], splits[1])
+		if err != nil {
+			return nil, nil, fmt.Errorf("fail to check if collection %s exists: %w", collection, err)
+		}
+		if exist {
+			existing = append(existing, collection)
+		} else {
+			missing = append(missing, collection)
+		}
+	}
+	return missing, existing, nil
+}
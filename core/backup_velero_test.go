@@ -0,0 +1,56 @@
+package core
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestVeleroBackupItem(t *testing.T) {
+	backup := &backuppb.BackupInfo{
+		Name:            "velero_20240101_020000",
+		BackupTimestamp: 1704074400000,
+		Size:            1024,
+		MilvusVersion:   "v2.4.0",
+		Labels:          map[string]string{"source": "velero"},
+		CollectionBackups: []*backuppb.CollectionBackupInfo{
+			{DbName: "db1", CollectionName: "coll2"},
+			{CollectionName: "coll1"},
+			{DbName: "db1", CollectionName: "failed", StateCode: backuppb.BackupTaskStateCode_BACKUP_FAIL},
+		},
+	}
+	item := NewVeleroBackupItem(backup, "bucket", "backup", true)
+	assert.Equal(t, []string{"db1.coll2", "default.coll1"}, item.Collections)
+
+	data, err := item.Marshal()
+	assert.NoError(t, err)
+	parsed, err := UnmarshalVeleroBackupItem(data)
+	assert.NoError(t, err)
+	assert.Equal(t, item, parsed)
+
+	_, err = UnmarshalVeleroBackupItem(`{"bucketName": "bucket"}`)
+	assert.Error(t, err)
+	_, err = UnmarshalVeleroBackupItem("")
+	assert.Error(t, err)
+}
+
+func TestSplitExistingCollections(t *testing.T) {
+	exists := func(db string, collectionName string) (bool, error) {
+		if collectionName == "broken" {
+			return false, errors.New("milvus is down")
+		}
+		return db == "db1", nil
+	}
+	missing, existing, err := splitExistingCollections([]string{"db1.coll1", "default.coll2", "db1.coll3"}, exists)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"default.coll2"}, missing)
+	assert.Equal(t, []string{"db1.coll1", "db1.coll3"}, existing)
+
+	_, _, err = splitExistingCollections([]string{"default.broken"}, exists)
+	assert.Error(t, err)
+	_, _, err = splitExistingCollections([]string{"coll1"}, exists)
+	assert.Error(t, err)
+}
@@ -0,0 +1,204 @@
+package core
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"github.com/zilliztech/milvus-backup/core/parquet"
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// layout of the binlog files of milvus: a magic number, a descriptor event of the segment and field, then insert or
+// delete events of the start and end timestamps followed by a parquet payload of a column
+const (
+	binlogMagicNumber uint32 = 0xfffabc
+	// timestamp, type code, event length and next position
+	binlogEventHeaderSize = 17
+	// start and end timestamps of insert and delete events
+	binlogEventDataSize = 16
+	// collection, partition, segment and field ids, start and end timestamps and payload data type of descriptor
+	binlogDescriptorFixedSize = 52
+
+	binlogDescriptorEvent = 0
+	binlogInsertEvent     = 1
+	binlogDeleteEvent     = 2
+	// event types of milvus, a post header length is written for each of them in descriptor
+	binlogEventTypes = 8
+)
+
+// binlogFile is the values of a field in a binlog file
+type binlogFile struct {
+	fieldID  int64
+	dataType backuppb.DataType
+	// bool, int32, int64, float32, float64 or []byte by the data type, nil for nulls
+	values []interface{}
+}
+
+// binlogDescriptor is the segment and field of a binlog file with the timestamps of its values
+type binlogDescriptor struct {
+	collectionID int64
+	partitionID  int64
+	segmentID    int64
+	fieldID      int64
+	dataType     backuppb.DataType
+	startTs      uint64
+	endTs        uint64
+}
+
+// writeBinlogFile encodes the values of a field into a binlog file of an event of eventType like milvus, values
+// are in the parquet column of the payload
+func writeBinlogFile(descriptor binlogDescriptor, eventType byte, column parquet.Column, values []interface{}) ([]byte, error) {
+	var payload bytes.Buffer
+	w, err := parquet.NewWriter(&payload, []parquet.Column{column})
+	if err != nil {
+		return nil, err
+	}
+	if err := w.WriteRowGroup([][]interface{}{values}); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+
+	le := binary.LittleEndian
+	data := make([]byte, 4, 4+2*binlogEventHeaderSize+binlogDescriptorFixedSize+binlogEventTypes+64+binlogEventDataSize+payload.Len())
+	le.PutUint32(data, binlogMagicNumber)
+	event := func(typeCode byte, body []byte) {
+		header := make([]byte, binlogEventHeaderSize)
+		le.PutUint64(header, descriptor.endTs)
+		header[8] = typeCode
+		le.PutUint32(header[9:], uint32(binlogEventHeaderSize+len(body)))
+		le.PutUint32(header[13:], uint32(len(data)+binlogEventHeaderSize+len(body)))
+		data = append(append(data, header...), body...)
+	}
+
+	body := make([]byte, binlogDescriptorFixedSize, binlogDescriptorFixedSize+binlogEventTypes+64)
+	le.PutUint64(body, uint64(descriptor.collectionID))
+	le.PutUint64(body[8:], uint64(descriptor.partitionID))
+	le.PutUint64(body[16:], uint64(descriptor.segmentID))
+	le.PutUint64(body[24:], uint64(descriptor.fieldID))
+	le.PutUint64(body[32:], descriptor.startTs)
+	le.PutUint64(body[40:], descriptor.endTs)
+	le.PutUint32(body[48:], uint32(descriptor.dataType))
+	// sizes of the fixed parts of the data of events by type
+	body = append(body, binlogDescriptorFixedSize)
+	for i := 1; i < binlogEventTypes; i++ {
+		body = append(body, binlogEventDataSize)
+	}
+	// milvus estimates the memory of the values by original_size
+	extras, err := json.Marshal(map[string]string{"original_size": strconv.Itoa(payload.Len())})
+	if err != nil {
+		return nil, err
+	}
+	var length [4]byte
+	le.PutUint32(length[:], uint32(len(extras)))
+	body = append(append(body, length[:]...), extras...)
+	event(binlogDescriptorEvent, body)
+
+	body = make([]byte, binlogEventDataSize, binlogEventDataSize+payload.Len())
+	le.PutUint64(body, descriptor.startTs)
+	le.PutUint64(body[8:], descriptor.endTs)
+	event(eventType, append(body, payload.Bytes()...))
+	return data, nil
+}
+
+// readBinlogFile decodes a binlog file of insert or delete events written by milvus
+func readBinlogFile(data []byte) (*binlogFile, error) {
+	if len(data) < 4+binlogEventHeaderSize+binlogDescriptorFixedSize || binary.LittleEndian.Uint32(data) != binlogMagicNumber {
+		return nil, fmt.Errorf("not a binlog file")
+	}
+	file := &binlogFile{}
+	for pos := 4; pos < len(data); {
+		if len(data)-pos < binlogEventHeaderSize {
+			return nil, fmt.Errorf("truncated binlog event at %d", pos)
+		}
+		typeCode := data[pos+8]
+		length := int(int32(binary.LittleEndian.Uint32(data[pos+9:])))
+		if length < binlogEventHeaderSize || length > len(data)-pos {
+			return nil, fmt.Errorf("illegal length %d of binlog event at %d", length, pos)
+		}
+		event := data[pos+binlogEventHeaderSize : pos+length]
+		switch typeCode {
+		case binlogDescriptorEvent:
+			if len(event) < binlogDescriptorFixedSize {
+				return nil, fmt.Errorf("truncated binlog descriptor event")
+			}
+			file.fieldID = int64(binary.LittleEndian.Uint64(event[24:]))
+			file.dataType = backuppb.DataType(int32(binary.LittleEndian.Uint32(event[48:])))
+		case binlogInsertEvent, binlogDeleteEvent:
+			if len(event) < binlogEventDataSize {
+				return nil, fmt.Errorf("truncated binlog event at %d", pos)
+			}
+			payload, err := parquet.Open(event[binlogEventDataSize:])
+			if err != nil {
+				return nil, fmt.Errorf("illegal payload of binlog event at %d: %w", pos, err)
+			}
+			values, err := payload.ReadColumn(0)
+			if err != nil {
+				return nil, fmt.Errorf("illegal payload of binlog event at %d: %w", pos, err)
+			}
+			file.values = append(file.values, values...)
+		default:
+			return nil, fmt.Errorf("unsupported binlog event type %d", typeCode)
+		}
+		pos += length
+	}
+	return file, nil
+}
+
+// readDeltalogFile returns the deleted primary keys of a delta log with the timestamps of deletions, int64 keys are
+// formatted in decimal
+func readDeltalogFile(data []byte) (map[string]uint64, error) {
+	file, err := readBinlogFile(data)
+	if err != nil {
+		return nil, err
+	}
+	deletes := make(map[string]uint64, len(file.values))
+	for _, value := range file.values {
+		record, ok := value.([]byte)
+		if !ok {
+			return nil, fmt.Errorf("illegal delete log %v", value)
+		}
+		pk, ts, err := parseDeleteLog(record)
+		if err != nil {
+			return nil, err
+		}
+		if ts > deletes[pk] {
+			deletes[pk] = ts
+		}
+	}
+	return deletes, nil
+}
+
+// parseDeleteLog parses a delete log of json like {"pk":1,"ts":2,"pkType":5}, or of pk,ts by milvus before 2.2
+func parseDeleteLog(record []byte) (string, uint64, error) {
+	if bytes.HasPrefix(record, []byte("{")) {
+		var deleteLog struct {
+			Pk json.RawMessage `json:"pk"`
+			Ts uint64          `json:"ts"`
+		}
+		if err := json.Unmarshal(record, &deleteLog); err != nil {
+			return "", 0, fmt.Errorf("illegal delete log %s: %w", record, err)
+		}
+		if bytes.HasPrefix(deleteLog.Pk, []byte(`"`)) {
+			var pk string
+			if err := json.Unmarshal(deleteLog.Pk, &pk); err != nil {
+				return "", 0, fmt.Errorf("illegal delete log %s: %w", record, err)
+			}
+			return pk, deleteLog.Ts, nil
+		}
+		return string(deleteLog.Pk), deleteLog.Ts, nil
+	}
+	sep := bytes.LastIndexByte(record, ',')
+	if sep < 0 {
+		return "", 0, fmt.Errorf("illegal delete log %s", record)
+	}
+	ts, err := strconv.ParseUint(string(record[sep+1:]), 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("illegal delete log %s: %w", record, err)
+	}
+	return string(record[:sep]), ts, nil
+}
@@ -0,0 +1,89 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// management api of milvus datacoord to pause and resume garbage collection, added in milvus 2.4
+const (
+	gcPausePath  = "/management/datacoord/garbage_collection/pause"
+	gcResumePath = "/management/datacoord/garbage_collection/resume"
+)
+
+// callMilvusGC calls the gc management api of milvus at address
+func callMilvusGC(ctx context.Context, address string, path string, query string) error {
+	url := address + path
+	if query != "" {
+		url += "?" + query
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returns %d: %s", path, resp.StatusCode, string(body))
+	}
+	return nil
+}
+
+// pauseMilvusGC pauses the garbage collection of milvus if backup.gcPause is enabled, and renews the pause until
+// the returned function is called, which resumes it. Failing to pause doesn't fail the backup, binlogs deleted
+// are handled by copying the segments compacted to.
+func (b *BackupContext) pauseMilvusGC(ctx context.Context) func() {
+	if !b.params.BackupCfg.GCPauseEnable {
+		return func() {}
+	}
+	address := b.params.BackupCfg.GCPauseAddress
+	seconds := b.params.BackupCfg.GCPauseSeconds
+	query := fmt.Sprintf("pause_seconds=%d", seconds)
+	if err := callMilvusGC(ctx, address, gcPausePath, query); err != nil {
+		log.Warn("fail to pause gc of milvus, backup without pause", zap.String("address", address), zap.Error(err))
+		return func() {}
+	}
+	log.Info("pause gc of milvus", zap.String("address", address), zap.Int("seconds", seconds))
+
+	renewCtx, cancel := context.WithCancel(ctx)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		// renew before the pause expires
+		ticker := time.NewTicker(time.Duration(seconds) * time.Second / 2)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-renewCtx.Done():
+				return
+			case <-ticker.C:
+				if err := callMilvusGC(renewCtx, address, gcPausePath, query); err != nil {
+					log.Warn("fail to renew the pause of milvus gc", zap.String("address", address), zap.Error(err))
+				}
+			}
+		}
+	}()
+	return func() {
+		cancel()
+		<-done
+		// ctx may have been canceled, gc is resumed anyway
+		resumeCtx, resumeCancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer resumeCancel()
+		if err := callMilvusGC(resumeCtx, address, gcResumePath, ""); err != nil {
+			log.Warn("fail to resume gc of milvus, it is resumed after the pause expires", zap.String("address", address), zap.Error(err))
+			return
+		}
+		log.Info("resume gc of milvus", zap.String("address", address))
+	}
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/milvus-io/milvus-sdk-go/v2/entity"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+// FieldTypeSparseFloatVector is the data type of sparse float vectors, added in milvus 2.4 and unknown to sdk
+const FieldTypeSparseFloatVector entity.FieldType = 104
+
+// numbers of the fields in the schema of milvus unknown to the milvus-proto used here, they are read from and
+// written into the unknown fields of the schema
+const (
+	collectionSchemaFunctionsNumber   protowire.Number = 7
+	fieldSchemaIsFunctionOutputNumber protowire.Number = 16
+)
+
+// readSchemaFunctions returns the functions of a collection schema described from milvus, like BM25
+func readSchemaFunctions(schema *schemapb.CollectionSchema) ([]*backuppb.FunctionSchema, error) {
+	functions := make([]*backuppb.FunctionSchema, 0)
+	unknown := proto.MessageReflect(schema).GetUnknown()
+	for len(unknown) > 0 {
+		number, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return nil, fmt.Errorf("illegal collection schema: %w", protowire.ParseError(n))
+		}
+		unknown = unknown[n:]
+		if number == collectionSchemaFunctionsNumber && typ == protowire.BytesType {
+			data, m := protowire.ConsumeBytes(unknown)
+			if m < 0 {
+				return nil, fmt.Errorf("illegal function schema: %w", protowire.ParseError(m))
+			}
+			// backuppb.FunctionSchema has the same layout as the FunctionSchema of milvus
+			function := &backuppb.FunctionSchema{}
+			if err := proto.Unmarshal(data, function); err != nil {
+				return nil, fmt.Errorf("illegal function schema: %w", err)
+			}
+			functions = append(functions, function)
+		}
+		m := protowire.ConsumeFieldValue(number, typ, unknown)
+		if m < 0 {
+			return nil, fmt.Errorf("illegal collection schema: %w", protowire.ParseError(m))
+		}
+		unknown = unknown[m:]
+	}
+	return functions, nil
+}
+
+// readFunctionOutput returns true if the field described from milvus is the output of a function
+func readFunctionOutput(field *schemapb.FieldSchema) bool {
+	output := false
+	unknown := proto.MessageReflect(field).GetUnknown()
+	for len(unknown) > 0 {
+		number, typ, n := protowire.ConsumeTag(unknown)
+		if n < 0 {
+			return output
+		}
+		unknown = unknown[n:]
+		if number == fieldSchemaIsFunctionOutputNumber && typ == protowire.VarintType {
+			value, _ := protowire.ConsumeVarint(unknown)
+			output = protowire.DecodeBool(value)
+		}
+		m := protowire.ConsumeFieldValue(number, typ, unknown)
+		if m < 0 {
+			return output
+		}
+		unknown = unknown[m:]
+	}
+	return output
+}
+
+// writeSchemaFunctions writes the functions and function output fields in backup into the unknown fields of the
+// collection schema to create, the fields of schema are in the same order as the fields in backup
+func writeSchemaFunctions(schema *schemapb.CollectionSchema, backupSchema *backuppb.CollectionSchema) error {
+	var unknown []byte
+	for _, function := range backupSchema.GetFunctions() {
+		// ids are assigned by milvus, functions refer to fields by names
+		function = proto.Clone(function).(*backuppb.FunctionSchema)
+		function.Id = 0
+		function.InputFieldIds = nil
+		function.OutputFieldIds = nil
+		data, err := proto.Marshal(function)
+		if err != nil {
+			return err
+		}
+		unknown = protowire.AppendTag(unknown, collectionSchemaFunctionsNumber, protowire.BytesType)
+		unknown = protowire.AppendBytes(unknown, data)
+	}
+	proto.MessageReflect(schema).SetUnknown(unknown)
+	for i, field := range backupSchema.GetFields() {
+		if field.GetIsFunctionOutput() && i < len(schema.GetFields()) {
+			var fieldUnknown []byte
+			fieldUnknown = protowire.AppendTag(fieldUnknown, fieldSchemaIsFunctionOutputNumber, protowire.VarintType)
+			fieldUnknown = protowire.AppendVarint(fieldUnknown, protowire.EncodeBool(true))
+			proto.MessageReflect(schema.GetFields()[i]).SetUnknown(fieldUnknown)
+		}
+	}
+	return nil
+}
+
+// needRawSchema returns true if the collection can't be created by sdk, which drops functions and requires
+// a dense vector field
+func needRawSchema(schema *backuppb.CollectionSchema) bool {
+	if len(schema.GetFunctions()) > 0 {
+		return true
+	}
+	for _, field := range schema.GetFields() {
+		if field.GetDataType() == backuppb.DataType_SparseFloatVector || field.GetIsFunctionOutput() {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,66 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
+)
+
+func TestSchemaFunctions(t *testing.T) {
+	backupSchema := &backuppb.CollectionSchema{
+		Name: "docs",
+		Fields: []*backuppb.FieldSchema{
+			{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: backuppb.DataType_Int64},
+			{FieldID: 101, Name: "text", DataType: backuppb.DataType_VarChar},
+			{FieldID: 102, Name: "sparse", DataType: backuppb.DataType_SparseFloatVector, IsFunctionOutput: true},
+		},
+		Functions: []*backuppb.FunctionSchema{{
+			Name:             "text_bm25",
+			Id:               1,
+			Type:             backuppb.FunctionType_BM25,
+			InputFieldNames:  []string{"text"},
+			InputFieldIds:    []int64{101},
+			OutputFieldNames: []string{"sparse"},
+			OutputFieldIds:   []int64{102},
+			Params:           []*backuppb.KeyValuePair{{Key: "analyzer", Value: "standard"}},
+		}},
+	}
+	assert.True(t, needRawSchema(backupSchema))
+	assert.False(t, needRawSchema(&backuppb.CollectionSchema{Fields: backupSchema.GetFields()[:2]}))
+
+	schema := &schemapb.CollectionSchema{Name: "docs", Fields: []*schemapb.FieldSchema{
+		{FieldID: 100, Name: "id", IsPrimaryKey: true, DataType: schemapb.DataType_Int64},
+		{FieldID: 101, Name: "text", DataType: schemapb.DataType_VarChar},
+		{FieldID: 102, Name: "sparse", DataType: schemapb.DataType(104)},
+	}}
+	assert.NoError(t, writeSchemaFunctions(schema, backupSchema))
+	data, err := proto.Marshal(schema)
+	assert.NoError(t, err)
+
+	// described from milvus
+	described := &schemapb.CollectionSchema{}
+	assert.NoError(t, proto.Unmarshal(data, described))
+	functions, err := readSchemaFunctions(described)
+	assert.NoError(t, err)
+	assert.Len(t, functions, 1)
+	assert.Equal(t, "text_bm25", functions[0].GetName())
+	assert.Equal(t, backuppb.FunctionType_BM25, functions[0].GetType())
+	assert.Equal(t, []string{"text"}, functions[0].GetInputFieldNames())
+	assert.Equal(t, []string{"sparse"}, functions[0].GetOutputFieldNames())
+	assert.Equal(t, "standard", functions[0].GetParams()[0].GetValue())
+	// ids are assigned by milvus on create
+	assert.Empty(t, functions[0].GetInputFieldIds())
+	assert.Equal(t, int64(1), backupSchema.GetFunctions()[0].GetId())
+
+	assert.False(t, readFunctionOutput(described.GetFields()[1]))
+	assert.True(t, readFunctionOutput(described.GetFields()[2]))
+	assert.Equal(t, schemapb.DataType(104), described.GetFields()[2].GetDataType())
+
+	functions, err = readSchemaFunctions(&schemapb.CollectionSchema{Name: "plain"})
+	assert.NoError(t, err)
+	assert.Empty(t, functions)
+}
@@ -2,9 +2,15 @@ package core
 
 import (
 	"context"
+	"errors"
+	"github.com/golang/protobuf/proto"
+	"github.com/milvus-io/milvus-proto/go-api/v2/commonpb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/milvuspb"
+	"github.com/milvus-io/milvus-proto/go-api/v2/schemapb"
 	gomilvus "github.com/milvus-io/milvus-sdk-go/v2/client"
 	"github.com/milvus-io/milvus-sdk-go/v2/entity"
 	"github.com/zilliztech/milvus-backup/internal/util/retry"
+	"strconv"
 	"sync"
 	"time"
 )
@@ -192,3 +198,283 @@ func (m *MilvusClient) DropIndex(ctx context.Context, db, collName string, index
 	}
 	return m.client.DropIndex(ctx, collName, "", gomilvus.WithIndexName(indexName))
 }
+
+// DescribeCollectionInfo describes a collection with its aliases and properties, which sdk doesn't return
+func (m *MilvusClient) DescribeCollectionInfo(ctx context.Context, db, collName string) (*milvuspb.DescribeCollectionResponse, error) {
+	service, err := m.service()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := service.DescribeCollection(ctx, &milvuspb.DescribeCollectionRequest{DbName: db, CollectionName: collName})
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// CreateCollectionWithSchema creates a collection of a schema sdk doesn't support, like functions and sparse vectors
+func (m *MilvusClient) CreateCollectionWithSchema(ctx context.Context, db string, schema *schemapb.CollectionSchema, shardsNum int32, consistencyLevel commonpb.ConsistencyLevel, numPartitions int64) error {
+	service, err := m.service()
+	if err != nil {
+		return err
+	}
+	bs, err := proto.Marshal(schema)
+	if err != nil {
+		return err
+	}
+	// add retry to make sure won't be block by rate control
+	return retry.Do(ctx, func() error {
+		status, err := service.CreateCollection(ctx, &milvuspb.CreateCollectionRequest{
+			DbName:           db,
+			CollectionName:   schema.GetName(),
+			Schema:           bs,
+			ShardsNum:        shardsNum,
+			ConsistencyLevel: consistencyLevel,
+			NumPartitions:    numPartitions,
+		})
+		if err != nil {
+			return err
+		}
+		return statusError(status)
+	}, retry.Sleep(2*time.Second), retry.Attempts(10))
+}
+
+// AlterCollectionProperties sets the properties of a collection, like collection.ttl.seconds and mmap.enabled
+func (m *MilvusClient) AlterCollectionProperties(ctx context.Context, db, collName string, properties map[string]string) error {
+	service, err := m.service()
+	if err != nil {
+		return err
+	}
+	status, err := service.AlterCollection(ctx, &milvuspb.AlterCollectionRequest{DbName: db, CollectionName: collName, Properties: entity.MapKvPairs(properties)})
+	if err != nil {
+		return err
+	}
+	return statusError(status)
+}
+
+// GetReplicas lists the replicas of a loaded collection
+func (m *MilvusClient) GetReplicas(ctx context.Context, db, collName string) ([]*milvuspb.ReplicaInfo, error) {
+	service, err := m.service()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := service.GetReplicas(ctx, &milvuspb.GetReplicasRequest{DbName: db, CollectionName: collName})
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetReplicas(), nil
+}
+
+// LoadCollection loads a collection with replicas in the resource groups without waiting for it, sdk doesn't support
+// resource groups
+func (m *MilvusClient) LoadCollection(ctx context.Context, db, collName string, replicaNumber int32, resourceGroups []string) error {
+	service, err := m.service()
+	if err != nil {
+		return err
+	}
+	status, err := service.LoadCollection(ctx, &milvuspb.LoadCollectionRequest{
+		DbName:         db,
+		CollectionName: collName,
+		ReplicaNumber:  replicaNumber,
+		ResourceGroups: resourceGroups,
+	})
+	if err != nil {
+		return err
+	}
+	return statusError(status)
+}
+
+// CreateAlias creates an alias of a collection in database db
+func (m *MilvusClient) CreateAlias(ctx context.Context, db, collName string, alias string) error {
+	service, err := m.service()
+	if err != nil {
+		return err
+	}
+	status, err := service.CreateAlias(ctx, &milvuspb.CreateAliasRequest{DbName: db, CollectionName: collName, Alias: alias})
+	if err != nil {
+		return err
+	}
+	return statusError(status)
+}
+
+// AlterAlias points an existing alias in database db at another collection
+func (m *MilvusClient) AlterAlias(ctx context.Context, db, collName string, alias string) error {
+	service, err := m.service()
+	if err != nil {
+		return err
+	}
+	status, err := service.AlterAlias(ctx, &milvuspb.AlterAliasRequest{DbName: db, CollectionName: collName, Alias: alias})
+	if err != nil {
+		return err
+	}
+	return statusError(status)
+}
+
+// GetRowCount returns the rows of a partition in the statistics of milvus, of the whole collection if partitionName
+// is empty. Rows of growing segments are counted as well, rows deleted are until the segments are compacted.
+func (m *MilvusClient) GetRowCount(ctx context.Context, db, collName string, partitionName string) (int64, error) {
+	service, err := m.service()
+	if err != nil {
+		return 0, err
+	}
+	var stats []*commonpb.KeyValuePair
+	if partitionName == "" {
+		resp, err := service.GetCollectionStatistics(ctx, &milvuspb.GetCollectionStatisticsRequest{DbName: db, CollectionName: collName})
+		if err != nil {
+			return 0, err
+		}
+		if err := statusError(resp.GetStatus()); err != nil {
+			return 0, err
+		}
+		stats = resp.GetStats()
+	} else {
+		resp, err := service.GetPartitionStatistics(ctx, &milvuspb.GetPartitionStatisticsRequest{DbName: db, CollectionName: collName, PartitionName: partitionName})
+		if err != nil {
+			return 0, err
+		}
+		if err := statusError(resp.GetStatus()); err != nil {
+			return 0, err
+		}
+		stats = resp.GetStats()
+	}
+	for _, stat := range stats {
+		if stat.GetKey() == "row_count" {
+			return strconv.ParseInt(stat.GetValue(), 10, 64)
+		}
+	}
+	return 0, errors.New("row_count is not in the statistics of " + collName)
+}
+
+// Query returns the fields of the rows matching expr in a loaded collection of database db, at most limit rows if
+// limit is positive. Rows of growing segments are not queried with ignoreGrowing, only the persisted ones.
+func (m *MilvusClient) Query(ctx context.Context, db, collName string, expr string, outputFields []string, limit int64, ignoreGrowing bool) ([]*schemapb.FieldData, error) {
+	service, err := m.service()
+	if err != nil {
+		return nil, err
+	}
+	params := make([]*commonpb.KeyValuePair, 0, 2)
+	if limit > 0 {
+		params = append(params, &commonpb.KeyValuePair{Key: "limit", Value: strconv.FormatInt(limit, 10)})
+	}
+	if ignoreGrowing {
+		params = append(params, &commonpb.KeyValuePair{Key: "ignore_growing", Value: "true"})
+	}
+	resp, err := service.Query(ctx, &milvuspb.QueryRequest{
+		DbName:           db,
+		CollectionName:   collName,
+		Expr:             expr,
+		OutputFields:     outputFields,
+		QueryParams:      params,
+		ConsistencyLevel: commonpb.ConsistencyLevel_Strong,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetFieldsData(), nil
+}
+
+// service returns the grpc stub of milvus, used by the APIs not supported by sdk, like listing grants
+func (m *MilvusClient) service() (milvuspb.MilvusServiceClient, error) {
+	grpcClient, ok := m.client.(*gomilvus.GrpcClient)
+	if !ok || grpcClient.Service == nil {
+		return nil, errors.New("milvus grpc service is not available")
+	}
+	return grpcClient.Service, nil
+}
+
+func statusError(status *commonpb.Status) error {
+	if status.GetErrorCode() != commonpb.ErrorCode_Success {
+		return errors.New(status.GetReason())
+	}
+	return nil
+}
+
+func (m *MilvusClient) ListRoles(ctx context.Context) ([]entity.Role, error) {
+	return m.client.ListRoles(ctx)
+}
+
+func (m *MilvusClient) CreateRole(ctx context.Context, name string) error {
+	return m.client.CreateRole(ctx, name)
+}
+
+func (m *MilvusClient) ListCredUsers(ctx context.Context) ([]string, error) {
+	return m.client.ListCredUsers(ctx)
+}
+
+func (m *MilvusClient) CreateCredential(ctx context.Context, username string, password string) error {
+	return m.client.CreateCredential(ctx, username, password)
+}
+
+func (m *MilvusClient) AddUserRole(ctx context.Context, username string, role string) error {
+	return m.client.AddUserRole(ctx, username, role)
+}
+
+// SelectUsers lists users with the roles granted to them
+func (m *MilvusClient) SelectUsers(ctx context.Context) ([]*milvuspb.UserResult, error) {
+	service, err := m.service()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := service.SelectUser(ctx, &milvuspb.SelectUserRequest{IncludeRoleInfo: true})
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetResults(), nil
+}
+
+// SelectGrants lists the privileges granted to the role in database db
+func (m *MilvusClient) SelectGrants(ctx context.Context, db, role string) ([]*milvuspb.GrantEntity, error) {
+	service, err := m.service()
+	if err != nil {
+		return nil, err
+	}
+	resp, err := service.SelectGrant(ctx, &milvuspb.SelectGrantRequest{
+		Entity: &milvuspb.GrantEntity{
+			Role:   &milvuspb.RoleEntity{Name: role},
+			DbName: db,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := statusError(resp.GetStatus()); err != nil {
+		return nil, err
+	}
+	return resp.GetEntities(), nil
+}
+
+// GrantPrivilege grants a privilege on an object to the role, the sdk Grant doesn't support specifying the privilege
+func (m *MilvusClient) GrantPrivilege(ctx context.Context, db, role, object, objectName, privilege string) error {
+	service, err := m.service()
+	if err != nil {
+		return err
+	}
+	status, err := service.OperatePrivilege(ctx, &milvuspb.OperatePrivilegeRequest{
+		Entity: &milvuspb.GrantEntity{
+			Role:       &milvuspb.RoleEntity{Name: role},
+			Object:     &milvuspb.ObjectEntity{Name: object},
+			ObjectName: objectName,
+			Grantor: &milvuspb.GrantorEntity{
+				Privilege: &milvuspb.PrivilegeEntity{Name: privilege},
+			},
+			DbName: db,
+		},
+		Type: milvuspb.OperatePrivilegeType_Grant,
+	})
+	if err != nil {
+		return err
+	}
+	return statusError(status)
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"google.golang.org/grpc"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+// milvusTLSConfig returns the tls config to connect to milvus, nil if tls is disabled or the default one
+// of the sdk, which verifies milvus by the system roots, is enough
+func milvusTLSConfig(cfg paramtable.MilvusConfig) (*tls.Config, error) {
+	switch cfg.TLSMode {
+	case 0:
+		return nil, nil
+	case 1, 2:
+	default:
+		return nil, errors.New("milvus.TLSMode is not illegal, support value 0, 1, 2")
+	}
+	if cfg.TLSMode == 2 && cfg.MTLSCertPath == "" {
+		return nil, errors.New("milvus.mtlsCertPath and milvus.mtlsKeyPath are required by milvus.tlsMode 2")
+	}
+	if cfg.CACertPath == "" && cfg.ServerName == "" && cfg.MTLSCertPath == "" {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: cfg.ServerName,
+		// grpc runs on http2
+		NextProtos: []string{"h2"},
+	}
+	if tlsConfig.ServerName == "" {
+		tlsConfig.ServerName = cfg.Address
+	}
+	if cfg.CACertPath != "" {
+		pem, err := os.ReadFile(cfg.CACertPath)
+		if err != nil {
+			return nil, fmt.Errorf("read milvus ca cert: %w", err)
+		}
+		tlsConfig.RootCAs = x509.NewCertPool()
+		if !tlsConfig.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, errors.New("no certificate in milvus ca cert " + cfg.CACertPath)
+		}
+	}
+	if cfg.MTLSCertPath != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.MTLSCertPath, cfg.MTLSKeyPath)
+		if err != nil {
+			return nil, fmt.Errorf("load milvus mtls cert: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+	return tlsConfig, nil
+}
+
+// withTLSDialer dials milvus over tls by the config. The sdk always appends its own transport credentials,
+// which can't carry a custom ca or client certificate, so the tls handshake is done by the dialer
+// under the insecure credentials of the sdk.
+func withTLSDialer(tlsConfig *tls.Config) grpc.DialOption {
+	dialer := &tls.Dialer{Config: tlsConfig}
+	return grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+		return dialer.DialContext(ctx, "tcp", addr)
+	})
+}
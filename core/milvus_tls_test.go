@@ -0,0 +1,95 @@
+package core
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+func TestMilvusTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "ca", nil)
+	serverCert := newTestCert(t, "milvus", ca)
+	clientCert := newTestCert(t, "backup", ca)
+	writeFile := func(name string, data []byte) string {
+		path := filepath.Join(dir, name)
+		assert.NoError(t, os.WriteFile(path, data, 0600))
+		return path
+	}
+	cfg := paramtable.MilvusConfig{
+		Address:      "127.0.0.1",
+		TLSMode:      2,
+		CACertPath:   writeFile("ca.pem", ca.certPEM),
+		ServerName:   "localhost",
+		MTLSCertPath: writeFile("client.pem", clientCert.certPEM),
+		MTLSKeyPath:  writeFile("client.key", clientCert.keyPEM),
+	}
+
+	// milvus of mutual tls
+	pair, err := tls.X509KeyPair(serverCert.certPEM, serverCert.keyPEM)
+	assert.NoError(t, err)
+	clientCAs := x509.NewCertPool()
+	clientCAs.AddCert(ca.cert)
+	server := grpc.NewServer(grpc.Creds(credentials.NewTLS(&tls.Config{
+		Certificates: []tls.Certificate{pair},
+		ClientCAs:    clientCAs,
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+	})))
+	grpc_health_v1.RegisterHealthServer(server, health.NewServer())
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	check := func(cfg paramtable.MilvusConfig) error {
+		tlsConfig, err := milvusTLSConfig(cfg)
+		assert.NoError(t, err)
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		// the sdk appends insecure credentials if EnableTLSAuth is false
+		conn, err := grpc.DialContext(ctx, listener.Addr().String(), withTLSDialer(tlsConfig),
+			grpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		defer conn.Close()
+		_, err = grpc_health_v1.NewHealthClient(conn).Check(ctx, &grpc_health_v1.HealthCheckRequest{})
+		return err
+	}
+	assert.NoError(t, check(cfg))
+
+	// without client certificate
+	oneWay := cfg
+	oneWay.TLSMode, oneWay.MTLSCertPath, oneWay.MTLSKeyPath = 1, "", ""
+	assert.Error(t, check(oneWay))
+	// the certificate of milvus isn't issued to the server name
+	otherName := cfg
+	otherName.ServerName = "milvus.example.com"
+	assert.Error(t, check(otherName))
+
+	// the default of the sdk is used without custom ca, server name or client certificate
+	tlsConfig, err := milvusTLSConfig(paramtable.MilvusConfig{TLSMode: 1})
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+	tlsConfig, err = milvusTLSConfig(paramtable.MilvusConfig{CACertPath: cfg.CACertPath})
+	assert.NoError(t, err)
+	assert.Nil(t, tlsConfig)
+	_, err = milvusTLSConfig(paramtable.MilvusConfig{TLSMode: 2})
+	assert.Error(t, err)
+	_, err = milvusTLSConfig(paramtable.MilvusConfig{TLSMode: 3})
+	assert.Error(t, err)
+	_, err = milvusTLSConfig(paramtable.MilvusConfig{TLSMode: 1, CACertPath: filepath.Join(dir, "not-exist")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,225 @@
+// Package numpy reads the .npy files of numpy arrays, of booleans, integers, floats and unicode strings in little
+// endian, in memory. It is used to package numpy files of the fields of a dataset, the format of bulk insert of
+// milvus, into backups.
+package numpy
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+const magic = "\x93NUMPY"
+
+// Array is an array of a .npy file, of one or two dimensions
+type Array struct {
+	// kind of elements by the descr of header: b for booleans, i and u for integers, f for floats and U for unicode
+	Kind byte
+	// bytes of an element, 4 bytes per character of unicode
+	ItemSize int
+	Shape    []int
+	data     []byte
+}
+
+// Read parses a .npy file of version 1, 2 or 3
+func Read(data []byte) (*Array, error) {
+	if len(data) < len(magic)+4 || string(data[:len(magic)]) != magic {
+		return nil, fmt.Errorf("numpy: not a npy file")
+	}
+	major := data[len(magic)]
+	pos := len(magic) + 2
+	var headerSize int
+	switch major {
+	case 1:
+		headerSize = int(binary.LittleEndian.Uint16(data[pos:]))
+		pos += 2
+	case 2, 3:
+		if len(data) < pos+4 {
+			return nil, fmt.Errorf("numpy: truncated header")
+		}
+		headerSize = int(binary.LittleEndian.Uint32(data[pos:]))
+		pos += 4
+	default:
+		return nil, fmt.Errorf("numpy: unsupported version %d", major)
+	}
+	if headerSize > len(data)-pos {
+		return nil, fmt.Errorf("numpy: truncated header")
+	}
+	header := string(data[pos : pos+headerSize])
+	a := &Array{data: data[pos+headerSize:]}
+
+	descr, err := headerValue(header, "descr")
+	if err != nil {
+		return nil, err
+	}
+	descr = strings.Trim(descr, `'"`)
+	if len(descr) < 3 {
+		return nil, fmt.Errorf("numpy: illegal descr %s", descr)
+	}
+	if descr[0] == '>' {
+		return nil, fmt.Errorf("numpy: big endian descr %s is not supported", descr)
+	}
+	a.Kind = descr[1]
+	if a.ItemSize, err = strconv.Atoi(descr[2:]); err != nil || a.ItemSize <= 0 {
+		return nil, fmt.Errorf("numpy: illegal descr %s", descr)
+	}
+	switch {
+	case a.Kind == 'b' && a.ItemSize == 1:
+	case a.Kind == 'i' || a.Kind == 'u':
+		if a.ItemSize != 1 && a.ItemSize != 2 && a.ItemSize != 4 && a.ItemSize != 8 {
+			return nil, fmt.Errorf("numpy: unsupported descr %s", descr)
+		}
+	case a.Kind == 'f':
+		if a.ItemSize != 4 && a.ItemSize != 8 {
+			return nil, fmt.Errorf("numpy: unsupported descr %s", descr)
+		}
+	case a.Kind == 'U':
+		a.ItemSize *= 4
+	default:
+		return nil, fmt.Errorf("numpy: unsupported descr %s", descr)
+	}
+
+	fortran, err := headerValue(header, "fortran_order")
+	if err != nil {
+		return nil, err
+	}
+	if fortran == "True" {
+		return nil, fmt.Errorf("numpy: fortran order is not supported")
+	}
+
+	shape, err := headerValue(header, "shape")
+	if err != nil {
+		return nil, err
+	}
+	size := 1
+	for _, dim := range strings.Split(strings.Trim(shape, "()"), ",") {
+		dim = strings.TrimSpace(dim)
+		if dim == "" {
+			continue
+		}
+		n, err := strconv.Atoi(dim)
+		if err != nil || n < 0 {
+			return nil, fmt.Errorf("numpy: illegal shape %s", shape)
+		}
+		a.Shape = append(a.Shape, n)
+		size *= n
+	}
+	if len(a.Shape) == 0 || len(a.Shape) > 2 {
+		return nil, fmt.Errorf("numpy: unsupported shape %s, arrays of 1 or 2 dimensions are supported", shape)
+	}
+	if len(a.data) < size*a.ItemSize {
+		return nil, fmt.Errorf("numpy: %d bytes of data, expect %d", len(a.data), size*a.ItemSize)
+	}
+	a.data = a.data[:size*a.ItemSize]
+	return a, nil
+}
+
+// headerValue returns the value of key in the python dict of header, like {'descr': '<f4', 'shape': (3, 2), }
+func headerValue(header string, key string) (string, error) {
+	start := strings.Index(header, "'"+key+"'")
+	if start < 0 {
+		return "", fmt.Errorf("numpy: no %s in header", key)
+	}
+	value := strings.TrimLeft(header[start+len(key)+2:], " :")
+	var end int
+	switch {
+	case strings.HasPrefix(value, "("):
+		end = strings.IndexByte(value, ')') + 1
+	case strings.HasPrefix(value, "'"):
+		end = strings.IndexByte(value[1:], '\'') + 2
+	default:
+		end = strings.IndexAny(value, ",}")
+	}
+	if end <= 0 {
+		return "", fmt.Errorf("numpy: illegal %s in header", key)
+	}
+	return strings.TrimSpace(value[:end]), nil
+}
+
+// Rows returns the size of the first dimension
+func (a *Array) Rows() int {
+	return a.Shape[0]
+}
+
+// Values returns the elements of the rows of a one dimension array, or []interface{} of the elements of a row of a
+// two dimensions array. Elements are bool, int64, float64 or string by kind.
+func (a *Array) Values() ([]interface{}, error) {
+	width := 1
+	if len(a.Shape) == 2 {
+		width = a.Shape[1]
+	}
+	values := make([]interface{}, 0, a.Rows())
+	for i := 0; i < a.Rows(); i++ {
+		row := make([]interface{}, 0, width)
+		for j := 0; j < width; j++ {
+			offset := (i*width + j) * a.ItemSize
+			value, err := a.element(a.data[offset : offset+a.ItemSize])
+			if err != nil {
+				return nil, err
+			}
+			row = append(row, value)
+		}
+		if len(a.Shape) == 1 {
+			values = append(values, row[0])
+		} else {
+			values = append(values, row)
+		}
+	}
+	return values, nil
+}
+
+func (a *Array) element(data []byte) (interface{}, error) {
+	le := binary.LittleEndian
+	switch a.Kind {
+	case 'b':
+		return data[0] != 0, nil
+	case 'i':
+		switch a.ItemSize {
+		case 1:
+			return int64(int8(data[0])), nil
+		case 2:
+			return int64(int16(le.Uint16(data))), nil
+		case 4:
+			return int64(int32(le.Uint32(data))), nil
+		default:
+			return int64(le.Uint64(data)), nil
+		}
+	case 'u':
+		switch a.ItemSize {
+		case 1:
+			return int64(data[0]), nil
+		case 2:
+			return int64(le.Uint16(data)), nil
+		case 4:
+			return int64(le.Uint32(data)), nil
+		default:
+			v := le.Uint64(data)
+			if v > math.MaxInt64 {
+				return nil, fmt.Errorf("numpy: uint64 %d out of range of int64", v)
+			}
+			return int64(v), nil
+		}
+	case 'f':
+		if a.ItemSize == 4 {
+			return float64(math.Float32frombits(le.Uint32(data))), nil
+		}
+		return math.Float64frombits(le.Uint64(data)), nil
+	default:
+		// utf-32 characters padded by zeros
+		var sb strings.Builder
+		for i := 0; i < len(data); i += 4 {
+			r := rune(le.Uint32(data[i:]))
+			if r == 0 {
+				break
+			}
+			if !utf8.ValidRune(r) {
+				return nil, fmt.Errorf("numpy: illegal character %d", r)
+			}
+			sb.WriteRune(r)
+		}
+		return sb.String(), nil
+	}
+}
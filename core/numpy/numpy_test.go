@@ -0,0 +1,73 @@
+package numpy
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// npyFile writes a .npy file of version 1 like numpy.save
+func npyFile(header string, data []byte) []byte {
+	// the header is padded by spaces and ends with a newline, aligned by 64 bytes
+	padding := 64 - (len(magic)+4+len(header)+1)%64
+	header += strings.Repeat(" ", padding) + "\n"
+	file := append([]byte(magic), 1, 0, 0, 0)
+	binary.LittleEndian.PutUint16(file[len(magic)+2:], uint16(len(header)))
+	return append(append(file, header...), data...)
+}
+
+func appendUint32(data []byte, v uint32) []byte {
+	var b [4]byte
+	binary.LittleEndian.PutUint32(b[:], v)
+	return append(data, b[:]...)
+}
+
+func TestRead(t *testing.T) {
+	data := make([]byte, 0)
+	for _, v := range []float32{1, 2, 3, 4, 5, 6} {
+		data = appendUint32(data, math.Float32bits(v))
+	}
+	a, err := Read(npyFile("{'descr': '<f4', 'fortran_order': False, 'shape': (3, 2), }", data))
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, a.Shape)
+	values, err := a.Values()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]interface{}{1.0, 2.0}, []interface{}{3.0, 4.0}, []interface{}{5.0, 6.0}}, values)
+
+	a, err = Read(npyFile("{'descr': '|i1', 'fortran_order': False, 'shape': (3,), }", []byte{1, 0xff, 127}))
+	assert.NoError(t, err)
+	values, err = a.Values()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{int64(1), int64(-1), int64(127)}, values)
+
+	a, err = Read(npyFile("{'descr': '|b1', 'fortran_order': False, 'shape': (2,), }", []byte{1, 0}))
+	assert.NoError(t, err)
+	values, err = a.Values()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{true, false}, values)
+
+	// strings of at most 2 characters
+	data = make([]byte, 0)
+	for _, r := range []rune{'a', 'b', '中', 0} {
+		data = appendUint32(data, uint32(r))
+	}
+	a, err = Read(npyFile("{'descr': '<U2', 'fortran_order': False, 'shape': (2,), }", data))
+	assert.NoError(t, err)
+	values, err = a.Values()
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{"ab", "中"}, values)
+
+	_, err = Read(npyFile("{'descr': '>i4', 'fortran_order': False, 'shape': (1,), }", make([]byte, 4)))
+	assert.ErrorContains(t, err, "big endian")
+	_, err = Read(npyFile("{'descr': '<i4', 'fortran_order': True, 'shape': (1,), }", make([]byte, 4)))
+	assert.ErrorContains(t, err, "fortran order")
+	_, err = Read(npyFile("{'descr': '<i4', 'fortran_order': False, 'shape': (2,), }", make([]byte, 4)))
+	assert.ErrorContains(t, err, "expect 8")
+	_, err = Read(npyFile("{'descr': '<c8', 'fortran_order': False, 'shape': (1,), }", make([]byte, 8)))
+	assert.ErrorContains(t, err, "unsupported descr")
+	_, err = Read([]byte("not numpy"))
+	assert.ErrorContains(t, err, "not a npy file")
+}
@@ -23,6 +23,7 @@ import (
 
 	"github.com/spf13/cast"
 	"github.com/spf13/viper"
+	"go.uber.org/zap/zapcore"
 
 	"github.com/zilliztech/milvus-backup/core/proto/backuppb"
 	memkv "github.com/zilliztech/milvus-backup/internal/kv/mem"
@@ -53,8 +54,13 @@ const (
 	DefaultMinioBackupBucketName = "a-bucket"
 	DefaultMinioBackupRootPath   = "backup"
 
+	DefaultTenantRootPath = "tenants"
+
 	DefaultStorageType = "minio"
 
+	DefaultMultipartConcurrency = 4
+	DefaultSFTPMaxConnections   = 4
+
 	DefaultMilvusAddress              = "localhost"
 	DefaultMilvusPort                 = "19530"
 	DefaultMilvusAuthorizationEnabled = "false"
@@ -65,11 +71,32 @@ const (
 
 var defaultYaml = DefaultBackupYaml
 
+// ProfilesKey is the section of the config holding the profiles, each with the keys it sets over the config
+const ProfilesKey = "profiles"
+
+// ProfileKey is the key of the profile applied to the config, set by the profile field, env or the --profile flag
+const ProfileKey = "profile"
+
+// EnvPrefix is the prefix of the env vars setting any param, see EnvName
+const EnvPrefix = "MILVUS_BACKUP_"
+
+// overrides are the params set by command line flags, they take precedence over env and yaml
+var overrides = make(map[string]string)
+
+// Override sets a param of the param tables initialized afterwards, like the log level set by a command line flag
+func Override(key, value string) {
+	overrides[key] = value
+}
+
 // BaseTable the basics of paramtable
 type BaseTable struct {
 	once      sync.Once
 	params    *memkv.MemoryKV
 	configDir string
+	// yaml overrides the global default yaml if set
+	yaml string
+	// profile overrides the profile of ProfileKey if set
+	profile string
 
 	RoleName   string
 	Log        log.Config
@@ -91,13 +118,22 @@ func (gp *BaseTable) GlobalInitWithYaml(yaml string) {
 func (gp *BaseTable) Init() {
 	gp.params = memkv.NewMemoryKV()
 	gp.configDir = gp.initConfPath()
-	gp.loadFromYaml(defaultYaml)
+	gp.loadFromYaml(gp.yamlFile())
+	gp.loadProfile()
 	gp.tryLoadFromEnv()
 	gp.InitLogCfg()
 	gp.SetLogConfig()
 	gp.SetLogger()
 }
 
+// yamlFile returns the yaml to load the param table from
+func (gp *BaseTable) yamlFile() string {
+	if gp.yaml != "" {
+		return gp.yaml
+	}
+	return defaultYaml
+}
+
 // GetConfigDir returns the config directory
 func (gp *BaseTable) GetConfigDir() string {
 	return gp.configDir
@@ -118,8 +154,8 @@ func (gp *BaseTable) initConfPath() string {
 	// check if user set conf dir through env
 	configDir, find := syscall.Getenv("MILVUSCONF")
 	if !find {
-		if _, err := os.Stat(defaultYaml); err == nil {
-			return path.Dir(defaultYaml)
+		if _, err := os.Stat(gp.yamlFile()); err == nil {
+			return path.Dir(gp.yamlFile())
 		}
 
 		runPath, err := os.Getwd()
@@ -142,9 +178,68 @@ func (gp *BaseTable) loadFromYaml(file string) {
 	}
 }
 
+// profileName returns the profile to apply, the one of the param table first, then the one of ProfileKey set by
+// flag, env or yaml. The flag and env select the profile of the global yaml, not of another one like the target
+// of a migration.
+func (gp *BaseTable) profileName() string {
+	if gp.profile != "" {
+		return gp.profile
+	}
+	if gp.yaml != "" {
+		return gp.LoadWithDefault(ProfileKey, "")
+	}
+	if profile, ok := overrides[ProfileKey]; ok {
+		return profile
+	}
+	if profile, ok := os.LookupEnv(EnvName(ProfileKey)); ok {
+		return profile
+	}
+	return gp.LoadWithDefault(ProfileKey, "")
+}
+
+// loadProfile sets the keys of the profile over those of yaml, env and flags are still applied over them
+func (gp *BaseTable) loadProfile() {
+	profile := gp.profileName()
+	if profile == "" {
+		return
+	}
+	prefix := strings.ToLower(ProfilesKey + "." + profile + ".")
+	keys, values, err := gp.params.LoadWithPrefix(prefix)
+	if err != nil {
+		panic(err)
+	}
+	if len(keys) == 0 {
+		panic(fmt.Sprintf("profile %s is not found in %s, profiles: %s", profile, gp.yamlFile(), strings.Join(gp.Profiles(), ",")))
+	}
+	for i, key := range keys {
+		_ = gp.params.Save(strings.TrimPrefix(key, prefix), values[i])
+	}
+	_ = gp.Save(ProfileKey, profile)
+}
+
+// Profiles returns the names of the profiles in the config
+func (gp *BaseTable) Profiles() []string {
+	prefix := ProfilesKey + "."
+	keys, _, _ := gp.params.LoadWithPrefix(prefix)
+	profiles := make([]string, 0)
+	for _, key := range keys {
+		name := strings.SplitN(strings.TrimPrefix(key, prefix), ".", 2)[0]
+		if len(profiles) == 0 || profiles[len(profiles)-1] != name {
+			profiles = append(profiles, name)
+		}
+	}
+	return profiles
+}
+
 func (gp *BaseTable) tryLoadFromEnv() {
 	gp.loadMinioConfig()
 	gp.loadMilvusConfig()
+	gp.loadBackupConfig()
+	gp.loadLogConfig()
+	gp.loadPrefixedEnv()
+	for key, value := range overrides {
+		_ = gp.Save(key, value)
+	}
 }
 
 // Load loads an object with @key.
@@ -186,6 +281,11 @@ func (gp *BaseTable) LoadRange(key, endKey string, limit int) ([]string, []strin
 	return gp.params.LoadRange(strings.ToLower(key), strings.ToLower(endKey), limit)
 }
 
+// LoadWithPrefix loads all objects whose key has the @prefix.
+func (gp *BaseTable) LoadWithPrefix(prefix string) ([]string, []string, error) {
+	return gp.params.LoadWithPrefix(strings.ToLower(prefix))
+}
+
 func (gp *BaseTable) LoadYaml(fileName string) error {
 	config := viper.New()
 	configFile := fmt.Sprintf("%s/%s", strings.TrimRight(gp.configDir, "/"), path.Base(fileName))
@@ -369,14 +469,67 @@ func (gp *BaseTable) ParseDataSizeWithDefault(key string, defaultValue string) (
 func (gp *BaseTable) InitLogCfg() {
 	gp.Log = log.Config{}
 	format := gp.LoadWithDefault("log.format", "text")
+	if format != "text" && format != "console" && format != "json" {
+		panic(fmt.Sprintf("illegal log.format %s, support text, console and json", format))
+	}
 	gp.Log.Format = format
 	level := gp.LoadWithDefault("log.level", "info")
+	if err := validateLogLevel(level); err != nil {
+		panic(fmt.Sprintf("illegal log.level: %s", err.Error()))
+	}
 	gp.Log.Level = level
 	gp.Log.Console = gp.ParseBool("log.console", false)
 	gp.Log.File.Filename = gp.LoadWithDefault("log.file.rootPath", "backup.log")
 	gp.Log.File.MaxSize = gp.ParseIntWithDefault("log.file.maxSize", 300)
 	gp.Log.File.MaxBackups = gp.ParseIntWithDefault("log.file.maxBackups", 20)
 	gp.Log.File.MaxDays = gp.ParseIntWithDefault("log.file.maxAge", 10)
+	gp.Log.File.Compress = gp.ParseBool("log.file.compress", false)
+	for _, module := range log.Modules {
+		moduleLevel := gp.LoadWithDefault("log.modules."+module, "")
+		if moduleLevel == "" {
+			continue
+		}
+		if err := validateLogLevel(moduleLevel); err != nil {
+			panic(fmt.Sprintf("illegal log.modules.%s: %s", module, err.Error()))
+		}
+		if gp.Log.Modules == nil {
+			gp.Log.Modules = make(map[string]string)
+		}
+		gp.Log.Modules[module] = moduleLevel
+	}
+}
+
+func validateLogLevel(level string) error {
+	var zapLevel zapcore.Level
+	return zapLevel.UnmarshalText([]byte(level))
+}
+
+// ParseLogModules parses the log levels of modules in the format of storage=debug,http=warn into the params of
+// log.modules
+func ParseLogModules(value string) (map[string]string, error) {
+	params := make(map[string]string)
+	for _, item := range strings.Split(value, ",") {
+		if strings.TrimSpace(item) == "" {
+			continue
+		}
+		splits := strings.SplitN(item, "=", 2)
+		if len(splits) != 2 {
+			return nil, fmt.Errorf("illegal log module level: %s, format: module=level", item)
+		}
+		module, level := strings.TrimSpace(splits[0]), strings.TrimSpace(splits[1])
+		known := false
+		for _, name := range log.Modules {
+			known = known || name == module
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown log module %s, support %s", module, strings.Join(log.Modules, ", "))
+		}
+		if err := validateLogLevel(level); err != nil {
+			return nil, fmt.Errorf("illegal log level of module %s: %w", module, err)
+		}
+		params["log.modules."+module] = level
+	}
+	return params, nil
 }
 
 // SetLogConfig set log config of the base table
@@ -452,6 +605,16 @@ func (gp *BaseTable) loadMinioConfig() {
 		_ = gp.Save("minio.iamEndpoint", minioIAMEndpoint)
 	}
 
+	minioRoleArn := os.Getenv("MINIO_ROLE_ARN")
+	if minioRoleArn != "" {
+		_ = gp.Save("minio.assumeRole.roleArn", minioRoleArn)
+	}
+
+	minioWebIdentityTokenFile := os.Getenv("MINIO_WEB_IDENTITY_TOKEN_FILE")
+	if minioWebIdentityTokenFile != "" {
+		_ = gp.Save("minio.assumeRole.webIdentityTokenFile", minioWebIdentityTokenFile)
+	}
+
 	minioRootPath := os.Getenv("MINIO_ROOT_PATH")
 	if minioRootPath != "" {
 		_ = gp.Save("minio.rootPath", minioRootPath)
@@ -489,6 +652,26 @@ func (gp *BaseTable) loadMilvusConfig() {
 		_ = gp.Save("milvus.tlsMode", milvusTlsMode)
 	}
 
+	milvusCACertPath := os.Getenv("MILVUS_CA_CERT_PATH")
+	if milvusCACertPath != "" {
+		_ = gp.Save("milvus.caCertPath", milvusCACertPath)
+	}
+
+	milvusServerName := os.Getenv("MILVUS_SERVER_NAME")
+	if milvusServerName != "" {
+		_ = gp.Save("milvus.serverName", milvusServerName)
+	}
+
+	milvusMTLSCertPath := os.Getenv("MILVUS_MTLS_CERT_PATH")
+	if milvusMTLSCertPath != "" {
+		_ = gp.Save("milvus.mtlsCertPath", milvusMTLSCertPath)
+	}
+
+	milvusMTLSKeyPath := os.Getenv("MILVUS_MTLS_KEY_PATH")
+	if milvusMTLSKeyPath != "" {
+		_ = gp.Save("milvus.mtlsKeyPath", milvusMTLSKeyPath)
+	}
+
 	milvusUser := os.Getenv("MILVUS_USER")
 	if milvusUser != "" {
 		_ = gp.Save("milvus.user", milvusUser)
@@ -499,3 +682,72 @@ func (gp *BaseTable) loadMilvusConfig() {
 		_ = gp.Save("milvus.password", milvusPassword)
 	}
 }
+
+func (gp *BaseTable) loadLogConfig() {
+	for env, key := range map[string]string{
+		"LOG_LEVEL":   "log.level",
+		"LOG_FORMAT":  "log.format",
+		"LOG_CONSOLE": "log.console",
+		"LOG_FILE":    "log.file.rootPath",
+	} {
+		if value := os.Getenv(env); value != "" {
+			_ = gp.Save(key, value)
+		}
+	}
+
+	logModules := os.Getenv("LOG_MODULES")
+	if logModules != "" {
+		params, err := ParseLogModules(logModules)
+		if err != nil {
+			panic(fmt.Sprintf("illegal LOG_MODULES: %s", err.Error()))
+		}
+		for key, value := range params {
+			_ = gp.Save(key, value)
+		}
+	}
+}
+
+// EnvName returns the env var setting the param of key, the sections of key are joined by _ after EnvPrefix in
+// upper case, like MILVUS_BACKUP_MINIO_ACCESSKEYID for minio.accessKeyID
+func EnvName(key string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+}
+
+// envParamKey returns the key of the param set by the env var named name, the reverse of EnvName
+func envParamKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(name, EnvPrefix), "_", "."))
+}
+
+// loadPrefixedEnv sets the params by the env vars with EnvPrefix, after the env vars of the old names so that the
+// new names win. An env var set to empty sets the param to empty.
+func (gp *BaseTable) loadPrefixedEnv() {
+	for _, env := range os.Environ() {
+		name, value, ok := strings.Cut(env, "=")
+		if !ok || !strings.HasPrefix(name, EnvPrefix) || name == EnvPrefix {
+			continue
+		}
+		_ = gp.Save(envParamKey(name), value)
+	}
+}
+
+// ParseOverride parses a param set on the command line as key=value
+func ParseOverride(setting string) (string, string, error) {
+	key, value, ok := strings.Cut(setting, "=")
+	key = strings.TrimSpace(key)
+	if !ok || key == "" {
+		return "", "", fmt.Errorf("illegal setting %q, should be key=value, like minio.address=localhost", setting)
+	}
+	return key, value, nil
+}
+
+func (gp *BaseTable) loadBackupConfig() {
+	backupEncryptionKey := os.Getenv("BACKUP_ENCRYPTION_KEY")
+	if backupEncryptionKey != "" {
+		_ = gp.Save("backup.encryption.key", backupEncryptionKey)
+	}
+
+	sseCustomerKey := os.Getenv("BACKUP_SSE_CUSTOMER_KEY")
+	if sseCustomerKey != "" {
+		_ = gp.Save("backup.serverSideEncryption.customerKey", sseCustomerKey)
+	}
+}
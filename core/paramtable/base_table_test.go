@@ -1,9 +1,12 @@
 package paramtable
 
 import (
+	"os"
+	"path/filepath"
+	"testing"
+
 	"github.com/stretchr/testify/assert"
 	memkv "github.com/zilliztech/milvus-backup/internal/kv/mem"
-	"testing"
 )
 
 func TestParseDataSizeWithDefault(t *testing.T) {
@@ -37,3 +40,134 @@ func TestParseDataSizeWithDefault(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, size1024000, int64(1024000))
 }
+
+func TestLogParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+	assert.Equal(t, "info", base.Log.Level)
+	assert.Empty(t, base.Log.Modules)
+
+	base.Save("log.format", "json")
+	base.Save("log.file.compress", "true")
+	base.Save("log.modules.storage", "debug")
+	base.Save("log.modules.http", "warn")
+	base.InitLogCfg()
+	assert.Equal(t, "json", base.Log.Format)
+	assert.True(t, base.Log.File.Compress)
+	assert.Equal(t, map[string]string{"storage": "debug", "http": "warn"}, base.Log.Modules)
+
+	base.Save("log.modules.milvus", "verbose")
+	assert.Panics(t, func() { base.InitLogCfg() })
+	base.Save("log.modules.milvus", "error")
+	base.Save("log.format", "xml")
+	assert.Panics(t, func() { base.InitLogCfg() })
+
+	// flags take precedence over env and yaml
+	t.Setenv("LOG_LEVEL", "warn")
+	t.Setenv("LOG_MODULES", "milvus=debug")
+	base.Init()
+	assert.Equal(t, "warn", base.Log.Level)
+	assert.Equal(t, map[string]string{"milvus": "debug"}, base.Log.Modules)
+	Override("log.level", "error")
+	defer delete(overrides, "log.level")
+	base.Init()
+	assert.Equal(t, "error", base.Log.Level)
+}
+
+func TestPrefixedEnv(t *testing.T) {
+	assert.Equal(t, "MILVUS_BACKUP_MINIO_ACCESSKEYID", EnvName("minio.accessKeyID"))
+	assert.Equal(t, "minio.accesskeyid", envParamKey("MILVUS_BACKUP_MINIO_ACCESSKEYID"))
+
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	t.Setenv("MINIO_ACCESS_KEY", "old")
+	t.Setenv(EnvName("minio.accessKeyID"), "new")
+	t.Setenv(EnvName("log.file.rootPath"), "")
+	t.Setenv(EnvName("backup.parallelism.copydata"), "64")
+	base.Init()
+	assert.Equal(t, "new", base.LoadWithDefault("minio.accessKeyID", ""))
+	assert.Equal(t, "", base.LoadWithDefault("log.file.rootPath", "logs/backup.log"))
+	assert.Equal(t, "64", base.LoadWithDefault("backup.parallelism.copydata", ""))
+
+	// flags take precedence over env
+	Override("minio.accessKeyID", "flag")
+	defer delete(overrides, "minio.accessKeyID")
+	base.Init()
+	assert.Equal(t, "flag", base.LoadWithDefault("minio.accessKeyID", ""))
+}
+
+func TestProfile(t *testing.T) {
+	yaml := filepath.Join(t.TempDir(), "profiles.yaml")
+	err := os.WriteFile(yaml, []byte(`
+log:
+  file:
+    rootPath: ""
+milvus:
+  address: localhost
+  port: 19530
+profiles:
+  prod:
+    milvus:
+      address: milvus.prod
+  Staging:
+    milvus:
+      address: milvus.staging
+    minio:
+      bucketName: staging-bucket
+`), 0o644)
+	assert.NoError(t, err)
+
+	base := &BaseTable{yaml: yaml}
+	base.Init()
+	assert.Equal(t, "localhost", base.LoadWithDefault("milvus.address", ""))
+	assert.Equal(t, []string{"prod", "staging"}, base.Profiles())
+
+	base = &BaseTable{yaml: yaml, profile: "staging"}
+	base.Init()
+	assert.Equal(t, "milvus.staging", base.LoadWithDefault("milvus.address", ""))
+	assert.Equal(t, "19530", base.LoadWithDefault("milvus.port", ""))
+	assert.Equal(t, "staging-bucket", base.LoadWithDefault("minio.bucketName", ""))
+	assert.Equal(t, "staging", base.LoadWithDefault(ProfileKey, ""))
+
+	// env is applied over the profile
+	t.Setenv(EnvName("milvus.address"), "milvus.env")
+	base = &BaseTable{yaml: yaml, profile: "prod"}
+	base.Init()
+	assert.Equal(t, "milvus.env", base.LoadWithDefault("milvus.address", ""))
+
+	base = &BaseTable{yaml: yaml, profile: "dev"}
+	assert.PanicsWithValue(t, "profile dev is not found in "+yaml+", profiles: prod,staging", func() { base.Init() })
+}
+
+func TestParseOverride(t *testing.T) {
+	key, value, err := ParseOverride("minio.address=a=b")
+	assert.NoError(t, err)
+	assert.Equal(t, "minio.address", key)
+	assert.Equal(t, "a=b", value)
+
+	key, value, err = ParseOverride(" log.file.rootPath =")
+	assert.NoError(t, err)
+	assert.Equal(t, "log.file.rootPath", key)
+	assert.Equal(t, "", value)
+
+	_, _, err = ParseOverride("minio.address")
+	assert.Error(t, err)
+	_, _, err = ParseOverride("=localhost")
+	assert.Error(t, err)
+}
+
+func TestParseLogModules(t *testing.T) {
+	params, err := ParseLogModules("storage=debug, http = warn,")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"log.modules.storage": "debug", "log.modules.http": "warn"}, params)
+
+	_, err = ParseLogModules("storage")
+	assert.Error(t, err)
+	_, err = ParseLogModules("etcd=debug")
+	assert.ErrorContains(t, err, "unknown log module")
+	_, err = ParseLogModules("storage=loud")
+	assert.Error(t, err)
+}
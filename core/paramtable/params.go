@@ -1,7 +1,13 @@
 package paramtable
 
 import (
+	"fmt"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
 )
 
 // BackupParams
@@ -9,9 +15,22 @@ type BackupParams struct {
 	BaseTable
 
 	HTTPCfg   HTTPConfig
+	GRPCCfg   GRPCConfig
 	MilvusCfg MilvusConfig
+	EtcdCfg   EtcdConfig
 	MinioCfg  MinioConfig
 	BackupCfg BackupConfig
+
+	BackupStorageCfg BackupStorageConfig
+
+	RetentionCfg RetentionConfig
+	ScheduleCfg  ScheduleConfig
+	TraceCfg     TraceConfig
+
+	ContinuousCfg ContinuousConfig
+
+	NotificationCfg NotificationConfig
+	ControllerCfg   ControllerConfig
 }
 
 func (p *BackupParams) InitOnce() {
@@ -20,13 +39,58 @@ func (p *BackupParams) InitOnce() {
 	})
 }
 
+// InitWithYaml initializes the params with the given yaml instead of the global one,
+// so that more than one config can be loaded in a process, like the source and target of a migration.
+func (p *BackupParams) InitWithYaml(yaml string) {
+	p.yaml = yaml
+	p.Init()
+}
+
+// InitWithProfile initializes the params with a profile of the yaml instead of the global one, like the cluster
+// migrated into, the global yaml is used if yaml is empty.
+func (p *BackupParams) InitWithProfile(yaml string, profile string) {
+	p.yaml = yaml
+	p.profile = profile
+	p.Init()
+}
+
 func (p *BackupParams) Init() {
 	p.BaseTable.Init()
 
 	p.HTTPCfg.init(&p.BaseTable)
+	p.GRPCCfg.init(&p.BaseTable)
 	p.MilvusCfg.init(&p.BaseTable)
+	p.EtcdCfg.init(&p.BaseTable)
 	p.MinioCfg.init(&p.BaseTable)
 	p.BackupCfg.init(&p.BaseTable)
+	p.BackupStorageCfg.init(&p.BaseTable)
+	p.RetentionCfg.init(&p.BaseTable)
+	p.ScheduleCfg.init(&p.BaseTable)
+	p.TraceCfg.init(&p.BaseTable)
+	p.ContinuousCfg.init(&p.BaseTable)
+	p.NotificationCfg.init(&p.BaseTable)
+	p.ControllerCfg.init(&p.BaseTable)
+
+	if p.MilvusCfg.ConfigFile != "" {
+		if err := p.MinioCfg.ApplyMilvusConfigFile(p.MilvusCfg.ConfigFile); err != nil {
+			panic("fail to read milvus.configFile " + p.MilvusCfg.ConfigFile + ": " + err.Error())
+		}
+	}
+	p.MergeBackupStorage()
+}
+
+// MergeBackupStorage disables backupStorage if it is the storage of milvus accessed with the same credentials.
+// Backup data is accessed by the storage client of milvus then, so that binlogs are copied by storage server side
+// instead of downloaded and uploaded by the backup tool.
+func (p *BackupParams) MergeBackupStorage() {
+	if !p.BackupStorageCfg.Enabled() || !p.BackupStorageCfg.SameStorage(&p.MinioCfg) {
+		return
+	}
+	p.MinioCfg.BackupAccessKeyID = p.BackupStorageCfg.AccessKeyID
+	p.MinioCfg.BackupSecretAccessKey = p.BackupStorageCfg.SecretAccessKey
+	p.MinioCfg.BackupAzureConnectionString = p.BackupStorageCfg.AzureConnectionString
+	p.MinioCfg.BackupAzureSASToken = p.BackupStorageCfg.AzureSASToken
+	p.BackupStorageCfg = BackupStorageConfig{Base: p.BackupStorageCfg.Base}
 }
 
 type BackupConfig struct {
@@ -34,11 +98,99 @@ type BackupConfig struct {
 
 	MaxSegmentGroupSize int64
 
-	BackupCollectionParallelism int
-	BackupCopyDataParallelism   int
-	RestoreParallelism          int
+	BackupCollectionParallelism  int
+	BackupCopyDataParallelism    int
+	RestoreParallelism           int
+	RestoreBulkInsertParallelism int
+	ListMetaParallelism          int
+
+	// max bandwidth in MB/s of data transferred from and to storage, 0 means unlimited
+	BandwidthLimit int
+	// max MB of binlog data held in memory by the copies of backup and restore, 0 means unlimited
+	MaxMemoryMB int
 
 	KeepTempFiles bool
+
+	Compression string
+
+	// reference of the key to encrypt backup data, empty means not encrypt
+	EncryptionKey string
+
+	// record the checksum of every binlog copied, used by verify
+	Checksum bool
+
+	// store binlogs as content-addressed dedup objects shared by backups
+	DedupEnable bool
+
+	// retry of storage operations failed by transient errors, 1 attempt means no retry
+	StorageRetryAttempts       int
+	StorageRetryInitialBackoff time.Duration
+	StorageRetryMaxBackoff     time.Duration
+	// consecutive failed storage operations to open the circuit breaker, 0 disables it
+	StorageCircuitBreakerThreshold int
+	StorageCircuitBreakerCooldown  time.Duration
+
+	// s3 storage class of the binlogs of backups, empty means the default class of the bucket
+	StorageClass string
+	// archived binlogs are restored for RehydrateDays with RehydrateTier before they are restored into milvus
+	RehydrateDays         int
+	RehydrateTier         string
+	RehydratePollInterval time.Duration
+	// 0 means waiting until all binlogs are rehydrated
+	RehydrateTimeout time.Duration
+
+	// s3 object lock of the files written by backups, empty mode means not locked
+	ObjectLockMode          string
+	ObjectLockRetentionDays int
+
+	// server side encryption of the files written by backups: kms or customer, empty means the default of the bucket
+	SSEType     string
+	SSEKMSKeyID string
+	// reference of the customer key, in the same formats as EncryptionKey
+	SSECustomerKey string
+
+	// pause the garbage collection of milvus while copying binlogs, by the management api at GCPauseAddress
+	GCPauseEnable  bool
+	GCPauseSeconds int
+	GCPauseAddress string
+
+	// lower the copy parallelism of backup while the latency of probe calls to milvus exceeds LoadThrottleLatency
+	LoadThrottleEnable         bool
+	LoadThrottleInterval       time.Duration
+	LoadThrottleLatency        time.Duration
+	LoadThrottleMinParallelism int
+
+	// segment groups of a partition bulk inserted concurrently during restore, only used to compute the default
+	// of BulkInsertConcurrency
+	BulkInsertBatchSize int
+	// bulk insert jobs of a restore running at the same time across all collections and partitions
+	BulkInsertConcurrency int
+	// max import tasks of restore executing in milvus at the same time, 0 means unlimited
+	BulkInsertMaxJobs int
+	// import tasks are done when they reach the state: completed, or persisted without waiting for the indexes
+	BulkInsertWaitState string
+	// an import task fails if its progress doesn't change for BulkInsertTimeout
+	BulkInsertTimeout      time.Duration
+	BulkInsertPollInterval time.Duration
+	// attempts of a segment group whose import task fails, 1 means no retry
+	BulkInsertMaxAttempts int
+
+	// template of the backup names generated if not set, empty means backup_<timestamp>
+	NameTemplate string
+	// name of the milvus cluster rendered by {{cluster}} of NameTemplate, and of the lock of the cluster
+	ClusterName string
+
+	// lock objects in backup storage preventing overlapping backups of the same cluster or name by other processes,
+	// a lock not renewed for LockTTL is stale
+	LockEnable bool
+	LockTTL    time.Duration
+
+	// records of backup and restore jobs in backup storage, so that other server instances sharing the storage can
+	// list, report and resume them. a running job whose record is not updated for 3 intervals is interrupted, and the
+	// records of ended jobs are removed after JobStateRetention
+	JobStateEnable    bool
+	JobStateInterval  time.Duration
+	JobStateRetention time.Duration
 }
 
 func (p *BackupConfig) init(base *BaseTable) {
@@ -48,7 +200,26 @@ func (p *BackupConfig) init(base *BaseTable) {
 	p.initBackupCollectionParallelism()
 	p.initRestoreParallelism()
 	p.initBackupCopyDataParallelism()
+	p.initRestoreBulkInsertParallelism()
+	p.initListMetaParallelism()
+	p.initBandwidthLimit()
+	p.initMaxMemory()
 	p.initKeepTempFiles()
+	p.initCompression()
+	p.initEncryptionKey()
+	p.initChecksum()
+	p.initDedup()
+	p.initStorageRetry()
+	p.initStorageClass()
+	p.initRehydrate()
+	p.initObjectLock()
+	p.initServerSideEncryption()
+	p.initGCPause()
+	p.initLoadThrottle()
+	p.initBulkInsert()
+	p.initNameTemplate()
+	p.initLock()
+	p.initJobState()
 }
 
 func (p *BackupConfig) initMaxSegmentGroupSize() {
@@ -60,18 +231,48 @@ func (p *BackupConfig) initMaxSegmentGroupSize() {
 }
 
 func (p *BackupConfig) initBackupCollectionParallelism() {
-	size := p.Base.ParseIntWithDefault("backup.parallelism.backupCollection", 1)
-	p.BackupCollectionParallelism = size
+	p.BackupCollectionParallelism = p.parseParallelism("backup.parallelism.backupCollection", 1)
 }
 
 func (p *BackupConfig) initRestoreParallelism() {
-	size := p.Base.ParseIntWithDefault("backup.parallelism.restoreCollection", 1)
-	p.RestoreParallelism = size
+	p.RestoreParallelism = p.parseParallelism("backup.parallelism.restoreCollection", 1)
 }
 
 func (p *BackupConfig) initBackupCopyDataParallelism() {
-	size := p.Base.ParseIntWithDefault("backup.parallelism.copydata", 128)
-	p.BackupCopyDataParallelism = size
+	p.BackupCopyDataParallelism = p.parseParallelism("backup.parallelism.copydata", 128)
+}
+
+// partitions are bulk inserted by the same parallelism of collections if not set
+func (p *BackupConfig) initRestoreBulkInsertParallelism() {
+	p.RestoreBulkInsertParallelism = p.parseParallelism("backup.parallelism.bulkinsert", p.RestoreParallelism)
+}
+
+func (p *BackupConfig) initListMetaParallelism() {
+	p.ListMetaParallelism = p.parseParallelism("backup.parallelism.listMeta", 16)
+}
+
+func (p *BackupConfig) initBandwidthLimit() {
+	limit := p.Base.ParseIntWithDefault("backup.bandwidthLimit", 0)
+	if limit < 0 {
+		panic("invalid backup.bandwidthLimit: " + strconv.Itoa(limit))
+	}
+	p.BandwidthLimit = limit
+}
+
+func (p *BackupConfig) initMaxMemory() {
+	maxMemory := p.Base.ParseIntWithDefault("backup.maxMemoryMB", 0)
+	if maxMemory < 0 {
+		panic("invalid backup.maxMemoryMB: " + strconv.Itoa(maxMemory))
+	}
+	p.MaxMemoryMB = maxMemory
+}
+
+func (p *BackupConfig) parseParallelism(key string, defaultValue int) int {
+	size := p.Base.ParseIntWithDefault(key, defaultValue)
+	if size <= 0 {
+		panic("invalid " + key + ": " + strconv.Itoa(size))
+	}
+	return size
 }
 
 func (p *BackupConfig) initKeepTempFiles() {
@@ -79,6 +280,415 @@ func (p *BackupConfig) initKeepTempFiles() {
 	p.KeepTempFiles, _ = strconv.ParseBool(keepTempFiles)
 }
 
+func (p *BackupConfig) initNameTemplate() {
+	p.NameTemplate = strings.TrimSpace(p.Base.LoadWithDefault("backup.nameTemplate", ""))
+	p.ClusterName = strings.TrimSpace(p.Base.LoadWithDefault("backup.clusterName", "milvus"))
+}
+
+func (p *BackupConfig) initLock() {
+	p.LockEnable = p.Base.ParseBool("backup.lock.enable", true)
+	p.LockTTL = time.Duration(p.Base.ParseIntWithDefault("backup.lock.ttl", 60)) * time.Second
+	if p.LockTTL <= 0 {
+		panic("invalid backup.lock.ttl, it should be positive")
+	}
+}
+
+func (p *BackupConfig) initJobState() {
+	p.JobStateEnable = p.Base.ParseBool("backup.jobState.enable", false)
+	p.JobStateInterval = time.Duration(p.Base.ParseIntWithDefault("backup.jobState.interval", 10)) * time.Second
+	if p.JobStateInterval <= 0 {
+		panic("invalid backup.jobState.interval, it should be positive")
+	}
+	p.JobStateRetention = time.Duration(p.Base.ParseIntWithDefault("backup.jobState.retentionHours", 168)) * time.Hour
+	if p.JobStateRetention <= 0 {
+		panic("invalid backup.jobState.retentionHours, it should be positive")
+	}
+}
+
+func (p *BackupConfig) initCompression() {
+	compression := strings.ToLower(p.Base.LoadWithDefault("backup.compression", ""))
+	if compression == "none" {
+		compression = ""
+	}
+	if compression != "" && compression != "zstd" && compression != "gzip" {
+		panic("unsupported compression:" + compression)
+	}
+	p.Compression = compression
+}
+
+func (p *BackupConfig) initEncryptionKey() {
+	p.EncryptionKey = p.Base.LoadWithDefault("backup.encryption.key", "")
+}
+
+func (p *BackupConfig) initChecksum() {
+	p.Checksum = p.Base.ParseBool("backup.checksum", false)
+}
+
+func (p *BackupConfig) initDedup() {
+	p.DedupEnable = p.Base.ParseBool("backup.dedup.enable", false)
+}
+
+func (p *BackupConfig) initStorageRetry() {
+	p.StorageRetryAttempts = p.Base.ParseIntWithDefault("backup.storageRetry.maxAttempts", 5)
+	if p.StorageRetryAttempts <= 0 {
+		panic("invalid backup.storageRetry.maxAttempts: " + strconv.Itoa(p.StorageRetryAttempts))
+	}
+	p.StorageRetryInitialBackoff = time.Duration(p.Base.ParseIntWithDefault("backup.storageRetry.initialBackoff", 200)) * time.Millisecond
+	p.StorageRetryMaxBackoff = time.Duration(p.Base.ParseIntWithDefault("backup.storageRetry.maxBackoff", 10000)) * time.Millisecond
+	if p.StorageRetryMaxBackoff < p.StorageRetryInitialBackoff {
+		p.StorageRetryMaxBackoff = p.StorageRetryInitialBackoff
+	}
+	p.StorageCircuitBreakerThreshold = p.Base.ParseIntWithDefault("backup.storageRetry.circuitBreakerThreshold", 0)
+	p.StorageCircuitBreakerCooldown = time.Duration(p.Base.ParseIntWithDefault("backup.storageRetry.circuitBreakerCooldown", 30)) * time.Second
+}
+
+var supportedStorageClass = map[string]bool{
+	"STANDARD":            true,
+	"REDUCED_REDUNDANCY":  true,
+	"STANDARD_IA":         true,
+	"ONEZONE_IA":          true,
+	"INTELLIGENT_TIERING": true,
+	"GLACIER":             true,
+	"GLACIER_IR":          true,
+	"DEEP_ARCHIVE":        true,
+}
+
+func (p *BackupConfig) initStorageClass() {
+	storageClass := strings.ToUpper(p.Base.LoadWithDefault("backup.storageClass", ""))
+	if storageClass != "" && !supportedStorageClass[storageClass] {
+		panic("unsupported backup.storageClass: " + storageClass)
+	}
+	p.StorageClass = storageClass
+}
+
+var supportedRehydrateTier = map[string]bool{
+	"Standard":  true,
+	"Bulk":      true,
+	"Expedited": true,
+}
+
+func (p *BackupConfig) initRehydrate() {
+	p.RehydrateDays = p.Base.ParseIntWithDefault("backup.rehydrate.days", 1)
+	if p.RehydrateDays <= 0 {
+		panic("invalid backup.rehydrate.days: " + strconv.Itoa(p.RehydrateDays))
+	}
+	p.RehydrateTier = p.Base.LoadWithDefault("backup.rehydrate.tier", "Standard")
+	if !supportedRehydrateTier[p.RehydrateTier] {
+		panic("unsupported backup.rehydrate.tier: " + p.RehydrateTier)
+	}
+	p.RehydratePollInterval = time.Duration(p.Base.ParseIntWithDefault("backup.rehydrate.pollInterval", 60)) * time.Second
+	if p.RehydratePollInterval <= 0 {
+		panic("invalid backup.rehydrate.pollInterval: " + p.RehydratePollInterval.String())
+	}
+	p.RehydrateTimeout = time.Duration(p.Base.ParseIntWithDefault("backup.rehydrate.timeout", 0)) * time.Second
+}
+
+func (p *BackupConfig) initObjectLock() {
+	p.ObjectLockMode = strings.ToUpper(p.Base.LoadWithDefault("backup.objectLock.mode", ""))
+	if p.ObjectLockMode != "" && p.ObjectLockMode != "GOVERNANCE" && p.ObjectLockMode != "COMPLIANCE" {
+		panic("unsupported backup.objectLock.mode: " + p.ObjectLockMode)
+	}
+	p.ObjectLockRetentionDays = p.Base.ParseIntWithDefault("backup.objectLock.retentionDays", 0)
+	if p.ObjectLockMode != "" && p.ObjectLockRetentionDays <= 0 {
+		panic("invalid backup.objectLock.retentionDays: " + strconv.Itoa(p.ObjectLockRetentionDays))
+	}
+}
+
+func (p *BackupConfig) initServerSideEncryption() {
+	p.SSEType = strings.ToLower(p.Base.LoadWithDefault("backup.serverSideEncryption.type", ""))
+	if p.SSEType != "" && p.SSEType != "kms" && p.SSEType != "customer" {
+		panic("unsupported backup.serverSideEncryption.type: " + p.SSEType)
+	}
+	p.SSEKMSKeyID = p.Base.LoadWithDefault("backup.serverSideEncryption.kmsKeyId", "")
+	p.SSECustomerKey = p.Base.LoadWithDefault("backup.serverSideEncryption.customerKey", "")
+	if p.SSEType == "customer" && p.SSECustomerKey == "" {
+		panic("backup.serverSideEncryption.customerKey is required by type customer")
+	}
+}
+
+func (p *BackupConfig) initGCPause() {
+	p.GCPauseEnable = p.Base.ParseBool("backup.gcPause.enable", false)
+	p.GCPauseSeconds = p.Base.ParseIntWithDefault("backup.gcPause.seconds", 7200)
+	if p.GCPauseSeconds <= 0 {
+		panic("invalid backup.gcPause.seconds: " + strconv.Itoa(p.GCPauseSeconds))
+	}
+	p.GCPauseAddress = strings.TrimSuffix(p.Base.LoadWithDefault("backup.gcPause.address", "http://localhost:9091"), "/")
+}
+
+func (p *BackupConfig) initLoadThrottle() {
+	p.LoadThrottleEnable = p.Base.ParseBool("backup.loadThrottle.enable", false)
+	p.LoadThrottleInterval = time.Duration(p.Base.ParseIntWithDefault("backup.loadThrottle.interval", 10)) * time.Second
+	p.LoadThrottleLatency = time.Duration(p.Base.ParseIntWithDefault("backup.loadThrottle.latencyThreshold", 500)) * time.Millisecond
+	if p.LoadThrottleInterval <= 0 || p.LoadThrottleLatency <= 0 {
+		panic("invalid backup.loadThrottle, interval and latencyThreshold should be positive")
+	}
+	p.LoadThrottleMinParallelism = p.Base.ParseIntWithDefault("backup.loadThrottle.minParallelism", 1)
+	if p.LoadThrottleMinParallelism <= 0 {
+		panic("invalid backup.loadThrottle.minParallelism: " + strconv.Itoa(p.LoadThrottleMinParallelism))
+	}
+}
+
+func (p *BackupConfig) initBulkInsert() {
+	p.BulkInsertBatchSize = p.Base.ParseIntWithDefault("backup.bulkinsert.batchSize", 1)
+	if p.BulkInsertBatchSize <= 0 {
+		panic("invalid backup.bulkinsert.batchSize: " + strconv.Itoa(p.BulkInsertBatchSize))
+	}
+	p.BulkInsertConcurrency = p.Base.ParseIntWithDefault("backup.bulkinsert.concurrency", 0)
+	if p.BulkInsertConcurrency < 0 {
+		panic("invalid backup.bulkinsert.concurrency: " + strconv.Itoa(p.BulkInsertConcurrency))
+	}
+	if p.BulkInsertConcurrency == 0 {
+		p.BulkInsertConcurrency = p.RestoreBulkInsertParallelism * p.BulkInsertBatchSize
+	}
+	p.BulkInsertMaxJobs = p.Base.ParseIntWithDefault("backup.bulkinsert.maxJobs", 0)
+	if p.BulkInsertMaxJobs < 0 {
+		panic("invalid backup.bulkinsert.maxJobs: " + strconv.Itoa(p.BulkInsertMaxJobs))
+	}
+	p.BulkInsertWaitState = strings.ToLower(p.Base.LoadWithDefault("backup.bulkinsert.waitState", "completed"))
+	if p.BulkInsertWaitState != "completed" && p.BulkInsertWaitState != "persisted" {
+		panic("unsupported backup.bulkinsert.waitState: " + p.BulkInsertWaitState)
+	}
+	p.BulkInsertTimeout = time.Duration(p.Base.ParseIntWithDefault("backup.bulkinsert.timeout", 3600)) * time.Second
+	p.BulkInsertPollInterval = time.Duration(p.Base.ParseIntWithDefault("backup.bulkinsert.pollInterval", 5)) * time.Second
+	if p.BulkInsertTimeout <= 0 || p.BulkInsertPollInterval <= 0 {
+		panic("invalid backup.bulkinsert, timeout and pollInterval should be positive")
+	}
+	p.BulkInsertMaxAttempts = p.Base.ParseIntWithDefault("backup.bulkinsert.maxAttempts", 1)
+	if p.BulkInsertMaxAttempts <= 0 {
+		panic("invalid backup.bulkinsert.maxAttempts: " + strconv.Itoa(p.BulkInsertMaxAttempts))
+	}
+}
+
+// RetentionConfig is the policy to prune backups, a backup is kept if any of the rules keeps it.
+// 0 disables a rule, nothing is pruned if all rules are disabled.
+type RetentionConfig struct {
+	Base *BaseTable
+
+	// keep the latest n backups
+	KeepLast int
+	// keep the latest backup of each of the latest n days
+	KeepDaily int
+	// keep the latest backup of each of the latest n weeks
+	KeepWeekly int
+	// keep the latest backup of each of the latest n months
+	KeepMonthly int
+}
+
+func (p *RetentionConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.KeepLast = p.initKeep("retention.keepLast")
+	p.KeepDaily = p.initKeep("retention.keepDaily")
+	p.KeepWeekly = p.initKeep("retention.keepWeekly")
+	p.KeepMonthly = p.initKeep("retention.keepMonthly")
+}
+
+func (p *RetentionConfig) initKeep(key string) int {
+	keep := p.Base.ParseIntWithDefault(key, 0)
+	if keep < 0 {
+		panic("invalid " + key + ": " + strconv.Itoa(keep))
+	}
+	return keep
+}
+
+// Enabled returns whether any retention rule is configured
+func (p *RetentionConfig) Enabled() bool {
+	return p.KeepLast > 0 || p.KeepDaily > 0 || p.KeepWeekly > 0 || p.KeepMonthly > 0
+}
+
+// ScheduleConfig contains the jobs to create backups periodically, configured as schedule.jobs.<name>
+type ScheduleConfig struct {
+	Base *BaseTable
+
+	Jobs []ScheduleJobConfig
+}
+
+type ScheduleJobConfig struct {
+	// name of the job, also the prefix of the backups created by the job
+	Name string
+	// cron expression, for example "0 2 * * *", "@every 6h" or "CRON_TZ=Asia/Shanghai 0 2 * * *"
+	Cron string
+	// collections to backup, empty to backup all
+	CollectionNames []string
+	// prune the backups expired by the retention policy after each backup
+	Prune bool
+}
+
+const scheduleJobsPrefix = "schedule.jobs."
+
+func (p *ScheduleConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.initJobs()
+}
+
+func (p *ScheduleConfig) initJobs() {
+	keys, _, err := p.Base.LoadWithPrefix(scheduleJobsPrefix)
+	if err != nil {
+		panic(err)
+	}
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		name := strings.Split(strings.TrimPrefix(key, scheduleJobsPrefix), ".")[0]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	jobs := make([]ScheduleJobConfig, 0, len(names))
+	for _, name := range names {
+		prefix := scheduleJobsPrefix + name + "."
+		job := ScheduleJobConfig{
+			Name:            name,
+			Cron:            p.Base.LoadWithDefault(prefix+"cron", ""),
+			CollectionNames: make([]string, 0),
+			Prune:           p.Base.ParseBool(prefix+"prune", false),
+		}
+		if job.Cron == "" {
+			panic("cron of schedule job " + name + " is required")
+		}
+		for _, collection := range strings.Split(p.Base.LoadWithDefault(prefix+"collections", ""), ",") {
+			if collection = strings.TrimSpace(collection); collection != "" {
+				job.CollectionNames = append(job.CollectionNames, collection)
+			}
+		}
+		jobs = append(jobs, job)
+	}
+	p.Jobs = jobs
+}
+
+// ContinuousConfig contains the chain of incremental backups created by continuous backup, configured as continuous.*
+type ContinuousConfig struct {
+	Base *BaseTable
+
+	// prefix of the backups created by continuous backup
+	Name string
+	// how often an incremental backup of the segments and delta logs added since the last backup is created
+	Interval time.Duration
+	// how often a full backup starts a new chain of incremental backups, 0 to never start a new chain
+	FullInterval time.Duration
+	// collections to backup, empty to backup all
+	CollectionNames []string
+	// prune the backups expired by the retention policy after each backup
+	Prune bool
+}
+
+func (p *ContinuousConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.Name = p.Base.LoadWithDefault("continuous.name", "continuous")
+	interval := p.Base.ParseIntWithDefault("continuous.interval", 300)
+	if interval <= 0 {
+		panic("invalid continuous.interval: " + strconv.Itoa(interval))
+	}
+	p.Interval = time.Duration(interval) * time.Second
+	fullInterval := p.Base.ParseIntWithDefault("continuous.fullInterval", 86400)
+	if fullInterval < 0 {
+		panic("invalid continuous.fullInterval: " + strconv.Itoa(fullInterval))
+	}
+	p.FullInterval = time.Duration(fullInterval) * time.Second
+	p.CollectionNames = make([]string, 0)
+	for _, collection := range strings.Split(p.Base.LoadWithDefault("continuous.collections", ""), ",") {
+		if collection = strings.TrimSpace(collection); collection != "" {
+			p.CollectionNames = append(p.CollectionNames, collection)
+		}
+	}
+	p.Prune = p.Base.ParseBool("continuous.prune", false)
+}
+
+// NotificationConfig contains the webhooks notified when tasks finish, configured as notification.webhooks.<name>
+type NotificationConfig struct {
+	Base *BaseTable
+
+	// seconds, tasks running longer than it are notified, 0 to disable
+	DurationThreshold int
+	Webhooks          []WebhookConfig
+}
+
+type WebhookConfig struct {
+	Name string
+	URL  string
+	// generic, slack or pagerduty
+	Format string
+	// success, fail or slow, empty to subscribe all
+	Events []string
+	// integration key of PagerDuty
+	RoutingKey string
+}
+
+const notificationWebhooksPrefix = "notification.webhooks."
+
+var (
+	webhookFormats = map[string]bool{"generic": true, "slack": true, "pagerduty": true}
+	webhookEvents  = map[string]bool{"success": true, "fail": true, "slow": true}
+)
+
+func (p *NotificationConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.initDurationThreshold()
+	p.initWebhooks()
+}
+
+func (p *NotificationConfig) initDurationThreshold() {
+	threshold := p.Base.ParseIntWithDefault("notification.durationThreshold", 0)
+	if threshold < 0 {
+		panic("invalid notification.durationThreshold: " + strconv.Itoa(threshold))
+	}
+	p.DurationThreshold = threshold
+}
+
+func (p *NotificationConfig) initWebhooks() {
+	keys, _, err := p.Base.LoadWithPrefix(notificationWebhooksPrefix)
+	if err != nil {
+		panic(err)
+	}
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		name := strings.Split(strings.TrimPrefix(key, notificationWebhooksPrefix), ".")[0]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	webhooks := make([]WebhookConfig, 0, len(names))
+	for _, name := range names {
+		prefix := notificationWebhooksPrefix + name + "."
+		webhook := WebhookConfig{
+			Name:       name,
+			URL:        p.Base.LoadWithDefault(prefix+"url", ""),
+			Format:     p.Base.LoadWithDefault(prefix+"format", "generic"),
+			Events:     make([]string, 0),
+			RoutingKey: p.Base.LoadWithDefault(prefix+"routingKey", ""),
+		}
+		if !webhookFormats[webhook.Format] {
+			panic("invalid format of webhook " + name + ": " + webhook.Format + ", support generic, slack and pagerduty")
+		}
+		if webhook.Format == "pagerduty" {
+			if webhook.RoutingKey == "" {
+				panic("routingKey of pagerduty webhook " + name + " is required")
+			}
+		} else if webhook.URL == "" {
+			panic("url of webhook " + name + " is required")
+		}
+		for _, event := range strings.Split(p.Base.LoadWithDefault(prefix+"events", ""), ",") {
+			if event = strings.TrimSpace(event); event == "" {
+				continue
+			}
+			if !webhookEvents[event] {
+				panic("invalid event of webhook " + name + ": " + event + ", support success, fail and slow")
+			}
+			webhook.Events = append(webhook.Events, event)
+		}
+		webhooks = append(webhooks, webhook)
+	}
+	p.Webhooks = webhooks
+}
+
 type MilvusConfig struct {
 	Base *BaseTable
 
@@ -88,6 +698,17 @@ type MilvusConfig struct {
 	Password             string
 	AuthorizationEnabled bool
 	TLSMode              int
+
+	// pem of the ca to verify the certificate of milvus instead of the system roots
+	CACertPath string
+	// name to verify the certificate of milvus, the address by default
+	ServerName string
+	// certificate and key to authenticate to milvus of tlsMode 2
+	MTLSCertPath string
+	MTLSKeyPath  string
+
+	// milvus.yaml of the milvus, the bucket and root path of minio are read from it if set
+	ConfigFile string
 }
 
 func (p *MilvusConfig) init(base *BaseTable) {
@@ -99,6 +720,8 @@ func (p *MilvusConfig) init(base *BaseTable) {
 	p.initPassword()
 	p.initAuthorizationEnabled()
 	p.initTLSMode()
+	p.initTLSFiles()
+	p.ConfigFile = p.Base.LoadWithDefault("milvus.configFile", "")
 }
 
 func (p *MilvusConfig) initAddress() {
@@ -141,28 +764,132 @@ func (p *MilvusConfig) initTLSMode() {
 	p.TLSMode = p.Base.ParseIntWithDefault("milvus.tlsMode", 0)
 }
 
+func (p *MilvusConfig) initTLSFiles() {
+	p.CACertPath = p.Base.LoadWithDefault("milvus.caCertPath", "")
+	p.ServerName = p.Base.LoadWithDefault("milvus.serverName", "")
+	p.MTLSCertPath = p.Base.LoadWithDefault("milvus.mtlsCertPath", "")
+	p.MTLSKeyPath = p.Base.LoadWithDefault("milvus.mtlsKeyPath", "")
+	if (p.MTLSCertPath == "") != (p.MTLSKeyPath == "") {
+		panic("both milvus.mtlsCertPath and milvus.mtlsKeyPath are required")
+	}
+}
+
+// EtcdConfig configures the etcd of milvus, read by backups with etcd_snapshot. It is compatible to milvus.yaml.
+type EtcdConfig struct {
+	Base *BaseTable
+
+	Endpoints []string
+	// keys of milvus meta are under RootPath/MetaSubPath
+	RootPath    string
+	MetaSubPath string
+	Username    string
+	Password    string
+	DialTimeout time.Duration
+
+	SSLEnabled bool
+	TLSCert    string
+	TLSKey     string
+	TLSCACert  string
+}
+
+func (p *EtcdConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.Endpoints = make([]string, 0)
+	for _, endpoint := range strings.Split(p.Base.LoadWithDefault("etcd.endpoints", "localhost:2379"), ",") {
+		if endpoint = strings.TrimSpace(endpoint); endpoint != "" {
+			p.Endpoints = append(p.Endpoints, endpoint)
+		}
+	}
+	p.RootPath = strings.Trim(p.Base.LoadWithDefault("etcd.rootPath", "by-dev"), "/")
+	p.MetaSubPath = strings.Trim(p.Base.LoadWithDefault("etcd.metaSubPath", "meta"), "/")
+	p.Username = p.Base.LoadWithDefault("etcd.auth.userName", "")
+	p.Password = p.Base.LoadWithDefault("etcd.auth.password", "")
+	p.DialTimeout = time.Duration(p.Base.ParseIntWithDefault("etcd.dialTimeout", 5)) * time.Second
+	p.initSSL()
+}
+
+func (p *EtcdConfig) initSSL() {
+	p.SSLEnabled = p.Base.ParseBool("etcd.ssl.enabled", false)
+	p.TLSCert = p.Base.LoadWithDefault("etcd.ssl.tlsCert", "")
+	p.TLSKey = p.Base.LoadWithDefault("etcd.ssl.tlsKey", "")
+	p.TLSCACert = p.Base.LoadWithDefault("etcd.ssl.tlsCACert", "")
+	if (p.TLSCert == "") != (p.TLSKey == "") {
+		panic("both etcd.ssl.tlsCert and etcd.ssl.tlsKey are required")
+	}
+}
+
+// MetaRootPath is the prefix of the meta keys of milvus, like by-dev/meta
+func (p *EtcdConfig) MetaRootPath() string {
+	if p.MetaSubPath == "" {
+		return p.RootPath
+	}
+	return p.RootPath + "/" + p.MetaSubPath
+}
+
 // /////////////////////////////////////////////////////////////////////////////
 // --- minio ---
 const (
-	Local               = "local"
-	Minio               = "minio"
-	S3                  = "s3"
-	CloudProviderAWS    = "aws"
-	CloudProviderGCP    = "gcp"
-	CloudProviderAli    = "ali"
-	CloudProviderAliyun = "aliyun"
-	CloudProviderAzure  = "azure"
+	Local                   = "local"
+	Minio                   = "minio"
+	S3                      = "s3"
+	CloudProviderAWS        = "aws"
+	CloudProviderGCP        = "gcp"
+	CloudProviderAli        = "ali"
+	CloudProviderAliyun     = "aliyun"
+	CloudProviderAzure      = "azure"
+	CloudProviderTencent    = "tencent"
+	CloudProviderBackblaze  = "b2"
+	CloudProviderCloudflare = "r2"
+	HDFS                    = "hdfs"
+	SFTP                    = "sftp"
 )
 
 var supportedStorageType = map[string]bool{
-	Local:               true,
-	Minio:               true,
-	S3:                  true,
-	CloudProviderAWS:    true,
-	CloudProviderGCP:    true,
-	CloudProviderAli:    true,
-	CloudProviderAliyun: true,
-	CloudProviderAzure:  true,
+	Local:                   true,
+	Minio:                   true,
+	S3:                      true,
+	CloudProviderAWS:        true,
+	CloudProviderGCP:        true,
+	CloudProviderAli:        true,
+	CloudProviderAliyun:     true,
+	CloudProviderAzure:      true,
+	CloudProviderTencent:    true,
+	CloudProviderBackblaze:  true,
+	CloudProviderCloudflare: true,
+	HDFS:                    true,
+	SFTP:                    true,
+}
+
+var (
+	storageTypesMu sync.RWMutex
+	// storage types registered by RegisterStorageType, accessed by clients of their own
+	customStorageType = make(map[string]bool)
+)
+
+// RegisterStorageType makes a storage type not built in accepted by the config, like a backend registered
+// by storage.Register. Such a storage is accessed by a client of its own, not as an s3 compatible storage.
+func RegisterStorageType(storageType string) {
+	storageTypesMu.Lock()
+	defer storageTypesMu.Unlock()
+	if !supportedStorageType[storageType] {
+		customStorageType[storageType] = true
+	}
+}
+
+func isSupportedStorageType(storageType string) bool {
+	if supportedStorageType[storageType] {
+		return true
+	}
+	storageTypesMu.RLock()
+	defer storageTypesMu.RUnlock()
+	return customStorageType[storageType]
+}
+
+func isCustomStorageType(storageType string) bool {
+	storageTypesMu.RLock()
+	defer storageTypesMu.RUnlock()
+	return customStorageType[storageType]
 }
 
 type MinioConfig struct {
@@ -178,13 +905,51 @@ type MinioConfig struct {
 	UseIAM          bool
 	CloudProvider   string
 	IAMEndpoint     string
+	// only for s3 compatible storages, the role assumed instead of using the credentials directly
+	AssumeRole AssumeRoleConfig
+	// how often the credentials referred by env: or file: are read again, of both minio and backupStorage
+	CredentialRefreshInterval time.Duration
+
+	// more root paths of the binlogs of milvus besides RootPath, like the prefixes of tenants sharing the bucket
+	ExtraRootPaths []string
+	// dir of the binlogs of a segment under a root path, see DefaultBinlogPathTemplate
+	BinlogPathTemplate string
 
 	BackupAccessKeyID     string
 	BackupSecretAccessKey string
 	BackupBucketName      string
 	BackupRootPath        string
+	// dir of the binlogs of a segment group in backup, see DefaultBackupPathTemplate
+	BackupPathTemplate string
 
 	StorageType string
+
+	// only for local, the dir of rootPath and backupRootPath, a mounted path like nfs, empty means the working dir
+	LocalPath string
+
+	// only for azure
+	AzureConnectionString       string
+	AzureSASToken               string
+	BackupAzureConnectionString string
+	BackupAzureSASToken         string
+
+	// only for gcp
+	GcpCredentialJSON           string
+	GcpKmsKeyName               string
+	GcpUniformBucketLevelAccess bool
+	GcpUploadChunkSize          int64
+
+	// only for hdfs, the user of simple auth and the delegation token of a kerberized cluster
+	HdfsUser            string
+	HdfsDelegationToken string
+
+	// only for sftp
+	SFTP SFTPConfig
+
+	// multipart upload and copy of large objects
+	MultipartPartSize    int64
+	MultipartConcurrency int
+	DisableMultipart     bool
 }
 
 func (p *MinioConfig) init(base *BaseTable) {
@@ -198,14 +963,35 @@ func (p *MinioConfig) init(base *BaseTable) {
 	p.initUseSSL()
 	p.initBucketName()
 	p.initRootPath()
+	p.initBinlogLayout()
 	p.initUseIAM()
 	p.initCloudProvider()
 	p.initIAMEndpoint()
+	p.AssumeRole.init(base, "minio")
+	p.initCredentialRefreshInterval()
 
 	p.initBackupAccessKeyID()
 	p.initBackupSecretAccessKey()
 	p.initBackupBucketName()
 	p.initBackupRootPath()
+	p.initBackupPathTemplate()
+
+	p.initLocalPath()
+
+	p.initAzureConnectionString()
+	p.initAzureSASToken()
+	p.initBackupAzureConnectionString()
+	p.initBackupAzureSASToken()
+
+	p.initGcpCredentialJSON()
+	p.initGcpKmsKeyName()
+	p.initGcpUniformBucketLevelAccess()
+	p.initGcpUploadChunkSize()
+
+	p.initHdfs()
+	p.SFTP.init(base, "minio")
+
+	p.initMultipart()
 }
 
 func (p *MinioConfig) initAddress() {
@@ -243,6 +1029,50 @@ func (p *MinioConfig) initRootPath() {
 	p.RootPath = rootPath
 }
 
+// placeholders of minio.binlogPathTemplate
+const (
+	BinlogPathRootPath     = "{{rootPath}}"
+	BinlogPathLogType      = "{{logType}}"
+	BinlogPathCollectionID = "{{collectionID}}"
+	BinlogPathPartitionID  = "{{partitionID}}"
+	BinlogPathSegmentID    = "{{segmentID}}"
+
+	// the layout of milvus, like files/insert_log/collection_id/partition_id/segment_id
+	DefaultBinlogPathTemplate = BinlogPathRootPath + "/" + BinlogPathLogType + "/" + BinlogPathCollectionID + "/" + BinlogPathPartitionID + "/" + BinlogPathSegmentID
+)
+
+func (p *MinioConfig) initBinlogLayout() {
+	p.ExtraRootPaths = make([]string, 0)
+	for _, rootPath := range strings.Split(p.Base.LoadWithDefault("minio.extraRootPaths", ""), ",") {
+		if rootPath = strings.Trim(strings.TrimSpace(rootPath), "/"); rootPath != "" && rootPath != p.RootPath {
+			p.ExtraRootPaths = append(p.ExtraRootPaths, rootPath)
+		}
+	}
+	p.BinlogPathTemplate = strings.Trim(p.Base.LoadWithDefault("minio.binlogPathTemplate", DefaultBinlogPathTemplate), "/")
+	for _, placeholder := range []string{BinlogPathRootPath, BinlogPathLogType, BinlogPathCollectionID, BinlogPathPartitionID, BinlogPathSegmentID} {
+		if !strings.Contains(p.BinlogPathTemplate, placeholder) {
+			panic("invalid minio.binlogPathTemplate " + p.BinlogPathTemplate + ", " + placeholder + " is required")
+		}
+	}
+}
+
+// ApplyMilvusConfigFile reads the bucket and root path of milvus from its milvus.yaml, so that they don't have to
+// be copied into backup.yaml. The keys not in the file are kept.
+func (p *MinioConfig) ApplyMilvusConfigFile(file string) error {
+	config := viper.New()
+	config.SetConfigFile(file)
+	if err := config.ReadInConfig(); err != nil {
+		return err
+	}
+	if config.IsSet("minio.bucketName") {
+		p.BucketName = config.GetString("minio.bucketName")
+	}
+	if config.IsSet("minio.rootPath") {
+		p.RootPath = config.GetString("minio.rootPath")
+	}
+	return nil
+}
+
 func (p *MinioConfig) initUseIAM() {
 	useIAM := p.Base.LoadWithDefault("minio.useIAM", DefaultMinioUseIAM)
 	var err error
@@ -284,22 +1114,354 @@ func (p *MinioConfig) initBackupRootPath() {
 	p.BackupRootPath = rootPath
 }
 
+// placeholders of minio.backupPathTemplate besides {{logType}}, {{collectionID}} and {{partitionID}} of
+// minio.binlogPathTemplate
+const (
+	BackupPathBackup     = "{{backup}}"
+	BackupPathDatabase   = "{{database}}"
+	BackupPathCollection = "{{collection}}"
+	BackupPathGroupID    = "{{groupID}}"
+
+	// the layout of backups, like backup_name/binlogs/insert_log/collection_id/partition_id/group_id
+	DefaultBackupPathTemplate = BackupPathBackup + "/binlogs/" + BinlogPathLogType + "/" + BinlogPathCollectionID + "/" + BinlogPathPartitionID + "/" + BackupPathGroupID
+)
+
+func (p *MinioConfig) initBackupPathTemplate() {
+	p.BackupPathTemplate = strings.Trim(p.Base.LoadWithDefault("minio.backupPathTemplate", DefaultBackupPathTemplate), "/")
+	if err := ValidateBackupPathTemplate(p.BackupPathTemplate); err != nil {
+		panic("invalid minio.backupPathTemplate " + p.BackupPathTemplate + ", " + err.Error())
+	}
+}
+
+// ValidateBackupPathTemplate checks that the dirs of the segment groups rendered by a template are in the dir of
+// their backup apart from its meta, and are different for every segment group of the backup
+func ValidateBackupPathTemplate(template string) error {
+	rest := strings.TrimPrefix(template, BackupPathBackup+"/")
+	if rest == template || strings.Contains(rest, BackupPathBackup) {
+		return fmt.Errorf("it should start with %s/ and have it only once", BackupPathBackup)
+	}
+	if rest == "meta" || strings.HasPrefix(rest, "meta/") {
+		return fmt.Errorf("the meta dir of backup is reserved")
+	}
+	for _, placeholder := range []string{BinlogPathLogType, BinlogPathPartitionID, BackupPathGroupID} {
+		if !strings.Contains(rest, placeholder) {
+			return fmt.Errorf("%s is required", placeholder)
+		}
+	}
+	if !strings.Contains(rest, BinlogPathCollectionID) && !(strings.Contains(rest, BackupPathDatabase) && strings.Contains(rest, BackupPathCollection)) {
+		return fmt.Errorf("%s, or %s and %s are required", BinlogPathCollectionID, BackupPathDatabase, BackupPathCollection)
+	}
+	return nil
+}
+
+func (p *MinioConfig) initLocalPath() {
+	p.LocalPath = p.Base.LoadWithDefault("minio.localPath", "")
+}
+
+func (p *MinioConfig) initAzureConnectionString() {
+	p.AzureConnectionString = p.Base.LoadWithDefault("minio.azureConnectionString", "")
+}
+
+func (p *MinioConfig) initAzureSASToken() {
+	p.AzureSASToken = p.Base.LoadWithDefault("minio.azureSASToken", "")
+}
+
+func (p *MinioConfig) initBackupAzureConnectionString() {
+	p.BackupAzureConnectionString = p.Base.LoadWithDefault("minio.backupAzureConnectionString", "")
+}
+
+func (p *MinioConfig) initBackupAzureSASToken() {
+	p.BackupAzureSASToken = p.Base.LoadWithDefault("minio.backupAzureSASToken", "")
+}
+
+func (p *MinioConfig) initGcpCredentialJSON() {
+	p.GcpCredentialJSON = p.Base.LoadWithDefault("minio.gcpCredentialJSON", "")
+}
+
+func (p *MinioConfig) initGcpKmsKeyName() {
+	p.GcpKmsKeyName = p.Base.LoadWithDefault("minio.gcpKmsKeyName", "")
+}
+
+func (p *MinioConfig) initGcpUniformBucketLevelAccess() {
+	p.GcpUniformBucketLevelAccess = p.Base.ParseBool("minio.gcpUniformBucketLevelAccess", true)
+}
+
+func (p *MinioConfig) initHdfs() {
+	p.HdfsUser = p.Base.LoadWithDefault("minio.hdfsUser", "")
+	p.HdfsDelegationToken = p.Base.LoadWithDefault("minio.hdfsDelegationToken", "")
+}
+
+func (p *MinioConfig) initGcpUploadChunkSize() {
+	size, err := p.Base.ParseDataSizeWithDefault("minio.gcpUploadChunkSize", "16m")
+	if err != nil {
+		panic(err)
+	}
+	p.GcpUploadChunkSize = size
+}
+
+func (p *MinioConfig) initCredentialRefreshInterval() {
+	p.CredentialRefreshInterval = time.Duration(p.Base.ParseIntWithDefault("minio.credentialRefreshInterval", 300)) * time.Second
+	if p.CredentialRefreshInterval <= 0 {
+		panic("invalid minio.credentialRefreshInterval: " + p.CredentialRefreshInterval.String())
+	}
+}
+
+// SFTPConfig is the ssh login of a sftp server, authenticated by a private key. The host key of the server is
+// verified by a known_hosts file.
+type SFTPConfig struct {
+	User string
+	// the private key in PEM or OpenSSH format
+	PrivateKeyFile string
+	// can refer to env: or file:, empty if the key is not encrypted
+	PrivateKeyPassphrase string
+	// empty means ~/.ssh/known_hosts
+	KnownHostsFile string
+	// ssh connections opened at most, each has a sftp session of its own
+	MaxConnections int
+}
+
+func (p *SFTPConfig) init(base *BaseTable, section string) {
+	prefix := section + ".sftp."
+	p.User = base.LoadWithDefault(prefix+"user", "")
+	p.PrivateKeyFile = base.LoadWithDefault(prefix+"privateKeyFile", "")
+	p.PrivateKeyPassphrase = base.LoadWithDefault(prefix+"privateKeyPassphrase", "")
+	p.KnownHostsFile = base.LoadWithDefault(prefix+"knownHostsFile", "")
+	p.MaxConnections = base.ParseIntWithDefault(prefix+"maxConnections", DefaultSFTPMaxConnections)
+	if p.MaxConnections <= 0 {
+		panic("sftp maxConnections must be positive")
+	}
+}
+
+// AssumeRoleConfig is the iam role assumed by sts to access s3 compatible storages. The temporary credentials of
+// the role are refreshed before they expire, so no long-lived access keys are needed in config.
+type AssumeRoleConfig struct {
+	RoleArn     string
+	ExternalID  string
+	SessionName string
+	// 0 means the default of sts
+	DurationSeconds int
+	STSEndpoint     string
+	// the role is assumed with the web identity token in the file, like the service account token of eks,
+	// otherwise with the access keys or the iam credentials of useIAM
+	WebIdentityTokenFile string
+}
+
+func (p *AssumeRoleConfig) init(base *BaseTable, section string) {
+	prefix := section + ".assumeRole."
+	p.RoleArn = base.LoadWithDefault(prefix+"roleArn", "")
+	p.ExternalID = base.LoadWithDefault(prefix+"externalId", "")
+	p.SessionName = base.LoadWithDefault(prefix+"sessionName", "milvus-backup")
+	p.DurationSeconds = base.ParseIntWithDefault(prefix+"durationSeconds", 0)
+	p.STSEndpoint = base.LoadWithDefault(prefix+"stsEndpoint", "")
+	p.WebIdentityTokenFile = base.LoadWithDefault(prefix+"webIdentityTokenFile", "")
+	// sts accepts durations from 15 minutes to 12 hours
+	if p.DurationSeconds != 0 && (p.DurationSeconds < 900 || p.DurationSeconds > 43200) {
+		panic("invalid " + prefix + "durationSeconds: " + strconv.Itoa(p.DurationSeconds))
+	}
+	if p.WebIdentityTokenFile != "" && p.RoleArn == "" {
+		panic(prefix + "roleArn is required to assume role with web identity")
+	}
+}
+
+// Enabled returns whether a role is assumed to access the storage
+func (p *AssumeRoleConfig) Enabled() bool {
+	return p.RoleArn != ""
+}
+
+func (p *MinioConfig) initMultipart() {
+	size, err := p.Base.ParseDataSizeWithDefault("minio.multipartPartSize", "0")
+	if err != nil {
+		panic(err)
+	}
+	p.MultipartPartSize = size
+	p.MultipartConcurrency = p.Base.ParseIntWithDefault("minio.multipartConcurrency", DefaultMultipartConcurrency)
+	p.DisableMultipart = p.Base.ParseBool("minio.disableMultipart", false)
+}
+
 func (p *MinioConfig) initStorageType() {
 	engine := p.Base.LoadWithDefault("storage.storageType",
 		p.Base.LoadWithDefault("minio.storageType",
 			p.Base.LoadWithDefault("minio.cloudProvider", DefaultStorageType)))
-	if !supportedStorageType[engine] {
+	if !isSupportedStorageType(engine) {
 		panic("unsupported storage type:" + engine)
 	}
 	p.StorageType = engine
 }
 
+// BackupStorageConfig is the storage to store backup data if it is different from the storage of milvus,
+// such as backup in s3 and restore to milvus on gcp. The bucket and root path are still minio.backupBucketName
+// and minio.backupRootPath. Backup data is stored in the storage of milvus if storageType is not set.
+type BackupStorageConfig struct {
+	Base *BaseTable
+
+	StorageType     string
+	Address         string
+	Port            string
+	AccessKeyID     string
+	SecretAccessKey string
+	UseSSL          bool
+	UseIAM          bool
+	IAMEndpoint     string
+	AssumeRole      AssumeRoleConfig
+
+	// only for local
+	LocalPath string
+
+	// only for azure
+	AzureConnectionString string
+	AzureSASToken         string
+
+	// only for gcp
+	GcpCredentialJSON           string
+	GcpKmsKeyName               string
+	GcpUniformBucketLevelAccess bool
+	GcpUploadChunkSize          int64
+
+	// only for hdfs
+	HdfsUser            string
+	HdfsDelegationToken string
+
+	// only for sftp
+	SFTP SFTPConfig
+
+	// multipart upload and copy of large objects
+	MultipartPartSize    int64
+	MultipartConcurrency int
+	DisableMultipart     bool
+}
+
+func (p *BackupStorageConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.StorageType = p.Base.LoadWithDefault("backupStorage.storageType", "")
+	if p.StorageType != "" && !isSupportedStorageType(p.StorageType) {
+		panic("unsupported backup storage type:" + p.StorageType)
+	}
+	p.Address = p.Base.LoadWithDefault("backupStorage.address", DefaultMinioAddress)
+	p.Port = p.Base.LoadWithDefault("backupStorage.port", DefaultMinioPort)
+	p.AccessKeyID = p.Base.LoadWithDefault("backupStorage.accessKeyID", "")
+	p.SecretAccessKey = p.Base.LoadWithDefault("backupStorage.secretAccessKey", "")
+	p.UseSSL = p.Base.ParseBool("backupStorage.useSSL", false)
+	p.UseIAM = p.Base.ParseBool("backupStorage.useIAM", false)
+	p.IAMEndpoint = p.Base.LoadWithDefault("backupStorage.iamEndpoint", DefaultMinioIAMEndpoint)
+	p.AssumeRole.init(base, "backupStorage")
+
+	p.LocalPath = p.Base.LoadWithDefault("backupStorage.localPath", "")
+
+	p.AzureConnectionString = p.Base.LoadWithDefault("backupStorage.azureConnectionString", "")
+	p.AzureSASToken = p.Base.LoadWithDefault("backupStorage.azureSASToken", "")
+
+	p.GcpCredentialJSON = p.Base.LoadWithDefault("backupStorage.gcpCredentialJSON", "")
+	p.GcpKmsKeyName = p.Base.LoadWithDefault("backupStorage.gcpKmsKeyName", "")
+	p.GcpUniformBucketLevelAccess = p.Base.ParseBool("backupStorage.gcpUniformBucketLevelAccess", true)
+	size, err := p.Base.ParseDataSizeWithDefault("backupStorage.gcpUploadChunkSize", "16m")
+	if err != nil {
+		panic(err)
+	}
+	p.GcpUploadChunkSize = size
+
+	p.HdfsUser = p.Base.LoadWithDefault("backupStorage.hdfsUser", "")
+	p.HdfsDelegationToken = p.Base.LoadWithDefault("backupStorage.hdfsDelegationToken", "")
+	p.SFTP.init(base, "backupStorage")
+
+	partSize, err := p.Base.ParseDataSizeWithDefault("backupStorage.multipartPartSize", "0")
+	if err != nil {
+		panic(err)
+	}
+	p.MultipartPartSize = partSize
+	p.MultipartConcurrency = p.Base.ParseIntWithDefault("backupStorage.multipartConcurrency", DefaultMultipartConcurrency)
+	p.DisableMultipart = p.Base.ParseBool("backupStorage.disableMultipart", false)
+}
+
+// Enabled returns whether backup data is stored in a storage different from milvus
+func (p *BackupStorageConfig) Enabled() bool {
+	return p.StorageType != ""
+}
+
+// SameStorage returns whether the backup storage is the storage of milvus accessed with the same credentials,
+// in which case objects can be copied between the milvus bucket and backup bucket by storage server side.
+func (p *BackupStorageConfig) SameStorage(minioCfg *MinioConfig) bool {
+	if storageFamily(p.StorageType) != storageFamily(minioCfg.StorageType) {
+		return false
+	}
+	switch storageFamily(p.StorageType) {
+	case Local:
+		return p.LocalPath == minioCfg.LocalPath
+	case CloudProviderGCP:
+		return p.UseIAM == minioCfg.UseIAM &&
+			p.GcpCredentialJSON == minioCfg.GcpCredentialJSON &&
+			p.AccessKeyID == minioCfg.AccessKeyID &&
+			p.SecretAccessKey == minioCfg.SecretAccessKey
+	case HDFS:
+		return p.Address == minioCfg.Address &&
+			p.Port == minioCfg.Port &&
+			p.UseSSL == minioCfg.UseSSL &&
+			p.HdfsUser == minioCfg.HdfsUser &&
+			p.HdfsDelegationToken == minioCfg.HdfsDelegationToken
+	case SFTP:
+		return p.Address == minioCfg.Address && p.Port == minioCfg.Port && p.SFTP == minioCfg.SFTP
+	case CloudProviderAzure:
+		return p.Address == minioCfg.Address &&
+			p.UseIAM == minioCfg.UseIAM &&
+			p.AccessKeyID == minioCfg.AccessKeyID &&
+			p.SecretAccessKey == minioCfg.SecretAccessKey &&
+			p.AzureConnectionString == minioCfg.AzureConnectionString &&
+			p.AzureSASToken == minioCfg.AzureSASToken
+	default:
+		if p.Address != minioCfg.Address || p.Port != minioCfg.Port || p.UseIAM != minioCfg.UseIAM ||
+			p.AssumeRole != minioCfg.AssumeRole {
+			return false
+		}
+		if p.UseIAM {
+			return p.IAMEndpoint == minioCfg.IAMEndpoint
+		}
+		return p.AccessKeyID == minioCfg.AccessKeyID && p.SecretAccessKey == minioCfg.SecretAccessKey
+	}
+}
+
+// IsS3Compatible returns whether the storage type is accessed by the s3 api
+func IsS3Compatible(storageType string) bool {
+	return storageFamily(storageType) == S3
+}
+
+// storageFamily returns the client used for the storage type, s3 compatible storages share the minio client
+func storageFamily(storageType string) string {
+	switch storageType {
+	case Local, CloudProviderGCP, CloudProviderAzure, HDFS, SFTP:
+		return storageType
+	}
+	if isCustomStorageType(storageType) {
+		return storageType
+	}
+	return S3
+}
+
 type HTTPConfig struct {
 	Base *BaseTable
 
 	Enabled        bool
 	DebugMode      bool
 	SimpleResponse bool
+	// serve the swagger ui of the OpenAPI spec at /api/v1/docs/index.html
+	SwaggerUI bool
+
+	Auth HTTPAuthConfig
+	TLS  HTTPTLSConfig
+
+	// the backups of each tenant are under <TenantRootPath>/<tenant> in the backup bucket, isolated from the backups
+	// under minio.backupRootPath
+	TenantRootPath string
+	// quotas and databases of the tenants by name, a tenant of api keys or users not configured is not limited
+	Tenants map[string]TenantConfig
+}
+
+// TenantConfig is a tenant of the backups served by the http api, see AuthCredentialConfig.Tenant
+type TenantConfig struct {
+	Name string
+	// max total size in bytes of the backups of the tenant, new backups are rejected once it is reached, 0 means unlimited
+	QuotaBytes int64
+	// the databases the tenant backs up and restores into, all databases if empty
+	Databases []string
 }
 
 func (p *HTTPConfig) init(base *BaseTable) {
@@ -308,6 +1470,10 @@ func (p *HTTPConfig) init(base *BaseTable) {
 	p.initHTTPEnabled()
 	p.initHTTPDebugMode()
 	p.initHTTPSimpleResponse()
+	p.initHTTPSwaggerUI()
+	p.Auth.init(base)
+	p.TLS.init(base)
+	p.initTenants()
 }
 
 func (p *HTTPConfig) initHTTPEnabled() {
@@ -321,3 +1487,314 @@ func (p *HTTPConfig) initHTTPDebugMode() {
 func (p *HTTPConfig) initHTTPSimpleResponse() {
 	p.SimpleResponse = p.Base.ParseBool("http.simpleResponse", false)
 }
+
+func (p *HTTPConfig) initHTTPSwaggerUI() {
+	p.SwaggerUI = p.Base.ParseBool("http.swaggerUI", true)
+}
+
+func (p *HTTPConfig) initTenants() {
+	p.TenantRootPath = strings.Trim(p.Base.LoadWithDefault("http.tenantRootPath", DefaultTenantRootPath), "/")
+	if p.TenantRootPath == "" {
+		panic("http.tenantRootPath can't be empty")
+	}
+	backupRootPath := strings.Trim(p.Base.LoadWithDefault("minio.backupRootPath", DefaultMinioBackupRootPath), "/")
+	if p.Auth.HasTenants() && (strings.HasPrefix(p.TenantRootPath+"/", backupRootPath+"/") || strings.HasPrefix(backupRootPath+"/", p.TenantRootPath+"/")) {
+		panic("http.tenantRootPath " + p.TenantRootPath + " can't overlap minio.backupRootPath " + backupRootPath)
+	}
+
+	keys, _, err := p.Base.LoadWithPrefix(httpTenantsPrefix)
+	if err != nil {
+		panic(err)
+	}
+	p.Tenants = make(map[string]TenantConfig)
+	for _, key := range keys {
+		name := strings.Split(strings.TrimPrefix(key, httpTenantsPrefix), ".")[0]
+		if _, ok := p.Tenants[name]; ok {
+			continue
+		}
+		if !validTenant(name) {
+			panic("invalid tenant of " + httpTenantsPrefix + name + ", support lower case letters, digits, - and _")
+		}
+		quotaMB := p.Base.ParseIntWithDefault(httpTenantsPrefix+name+".quotaMB", 0)
+		if quotaMB < 0 {
+			panic("invalid " + httpTenantsPrefix + name + ".quotaMB: " + strconv.Itoa(quotaMB))
+		}
+		databases := make([]string, 0)
+		for _, db := range strings.Split(p.Base.LoadWithDefault(httpTenantsPrefix+name+".databases", ""), ",") {
+			if db = strings.TrimSpace(db); db != "" {
+				databases = append(databases, db)
+			}
+		}
+		p.Tenants[name] = TenantConfig{Name: name, QuotaBytes: int64(quotaMB) * 1024 * 1024, Databases: databases}
+	}
+}
+
+// validTenant returns whether the tenant can be a dir of http.tenantRootPath
+func validTenant(tenant string) bool {
+	if tenant == "" {
+		return false
+	}
+	for _, r := range tenant {
+		if !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_') {
+			return false
+		}
+	}
+	return true
+}
+
+// GRPCConfig serves the api by the grpc MilvusBackupService besides http,
+// with the authentication and tls of the http api
+type GRPCConfig struct {
+	Enabled bool
+	Port    int
+	// how often WatchJob checks the progress of the job
+	WatchInterval time.Duration
+}
+
+func (p *GRPCConfig) init(base *BaseTable) {
+	p.Enabled = base.ParseBool("grpc.enabled", false)
+	p.Port = base.ParseIntWithDefault("grpc.port", 50051)
+	if p.Port <= 0 || p.Port > 65535 {
+		panic("invalid grpc.port: " + strconv.Itoa(p.Port))
+	}
+	p.WatchInterval = time.Duration(base.ParseIntWithDefault("grpc.watchInterval", 1000)) * time.Millisecond
+	if p.WatchInterval <= 0 {
+		panic("invalid grpc.watchInterval: " + p.WatchInterval.String())
+	}
+}
+
+const (
+	// AuthRoleRead can only call the apis which don't change backups, like list, get and verify
+	AuthRoleRead = "read"
+	// AuthRoleAdmin can call all the apis
+	AuthRoleAdmin = "admin"
+
+	// keys of config are lower cased
+	httpAuthAPIKeysPrefix = "http.auth.apikeys."
+	httpAuthUsersPrefix   = "http.auth.users."
+	httpTenantsPrefix     = "http.tenants."
+)
+
+var authRoles = map[string]bool{
+	AuthRoleRead:  true,
+	AuthRoleAdmin: true,
+}
+
+// AuthCredentialConfig is an api key or a user of basic auth, the secret can refer to env: or file:
+type AuthCredentialConfig struct {
+	Name   string
+	Secret string
+	Role   string
+	// the caller only sees the backups of the tenant if set, see HTTPConfig.Tenants
+	Tenant string
+}
+
+// HTTPAuthConfig is how the callers of the http api are authenticated, by api keys, users of basic auth or jwt.
+// The api is open to everyone if none of them is configured.
+type HTTPAuthConfig struct {
+	APIKeys []AuthCredentialConfig
+	Users   []AuthCredentialConfig
+
+	// secret of HMAC or PEM public key of RSA, ECDSA or Ed25519 to verify jwt, can refer to env: or file:
+	JWTSecret    string
+	JWTPublicKey string
+	JWTIssuer    string
+	JWTAudience  string
+	// claim of the role, a string or a list of strings, and of the name of the caller in audit logs
+	JWTRoleClaim    string
+	JWTSubjectClaim string
+}
+
+func (p *HTTPAuthConfig) init(base *BaseTable) {
+	p.APIKeys = loadAuthCredentials(base, httpAuthAPIKeysPrefix, "key")
+	p.Users = loadAuthCredentials(base, httpAuthUsersPrefix, "password")
+
+	p.JWTSecret = base.LoadWithDefault("http.auth.jwt.secret", "")
+	p.JWTPublicKey = base.LoadWithDefault("http.auth.jwt.publicKey", "")
+	if p.JWTSecret != "" && p.JWTPublicKey != "" {
+		panic("only one of http.auth.jwt.secret and http.auth.jwt.publicKey can be set")
+	}
+	p.JWTIssuer = base.LoadWithDefault("http.auth.jwt.issuer", "")
+	p.JWTAudience = base.LoadWithDefault("http.auth.jwt.audience", "")
+	p.JWTRoleClaim = base.LoadWithDefault("http.auth.jwt.roleClaim", "role")
+	p.JWTSubjectClaim = base.LoadWithDefault("http.auth.jwt.subjectClaim", "sub")
+}
+
+// loadAuthCredentials loads the credentials configured as prefix.<name>.<secretKey> and prefix.<name>.role
+func loadAuthCredentials(base *BaseTable, prefix string, secretKey string) []AuthCredentialConfig {
+	keys, _, err := base.LoadWithPrefix(prefix)
+	if err != nil {
+		panic(err)
+	}
+	names := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		name := strings.Split(strings.TrimPrefix(key, prefix), ".")[0]
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+
+	credentials := make([]AuthCredentialConfig, 0, len(names))
+	for _, name := range names {
+		credential := AuthCredentialConfig{
+			Name:   name,
+			Secret: base.LoadWithDefault(prefix+name+"."+secretKey, ""),
+			Role:   strings.ToLower(base.LoadWithDefault(prefix+name+".role", AuthRoleRead)),
+			Tenant: strings.ToLower(base.LoadWithDefault(prefix+name+".tenant", "")),
+		}
+		if credential.Secret == "" {
+			panic(secretKey + " of " + prefix + name + " is required")
+		}
+		if !authRoles[credential.Role] {
+			panic("invalid role of " + prefix + name + ": " + credential.Role + ", support read and admin")
+		}
+		if credential.Tenant != "" && !validTenant(credential.Tenant) {
+			panic("invalid tenant of " + prefix + name + ": " + credential.Tenant + ", support lower case letters, digits, - and _")
+		}
+		credentials = append(credentials, credential)
+	}
+	return credentials
+}
+
+// Enabled returns whether the callers of the http api are authenticated
+func (p *HTTPAuthConfig) Enabled() bool {
+	return len(p.APIKeys) > 0 || len(p.Users) > 0 || p.JWTSecret != "" || p.JWTPublicKey != ""
+}
+
+// HasTenants returns whether any api key or user belongs to a tenant
+func (p *HTTPAuthConfig) HasTenants() bool {
+	for _, credential := range append(append([]AuthCredentialConfig{}, p.APIKeys...), p.Users...) {
+		if credential.Tenant != "" {
+			return true
+		}
+	}
+	return false
+}
+
+const (
+	// HTTPClientAuthNone doesn't ask the clients for certificates
+	HTTPClientAuthNone = "none"
+	// HTTPClientAuthRequest verifies the certificates of the clients which send one
+	HTTPClientAuthRequest = "request"
+	// HTTPClientAuthRequire only accepts the clients with a certificate signed by the client ca
+	HTTPClientAuthRequire = "require"
+)
+
+var httpClientAuths = map[string]bool{
+	HTTPClientAuthNone:    true,
+	HTTPClientAuthRequest: true,
+	HTTPClientAuthRequire: true,
+}
+
+// HTTPTLSConfig serves the http api over tls, with the client certificates verified by the client ca for mtls.
+// The files are reloaded once they change, so the certificates rotated by cert-manager are used without restarting.
+type HTTPTLSConfig struct {
+	CertFile     string
+	KeyFile      string
+	ClientCAFile string
+	ClientAuth   string
+	// how often the files are checked for changes
+	ReloadInterval time.Duration
+}
+
+func (p *HTTPTLSConfig) init(base *BaseTable) {
+	p.CertFile = base.LoadWithDefault("http.tls.certFile", "")
+	p.KeyFile = base.LoadWithDefault("http.tls.keyFile", "")
+	if (p.CertFile == "") != (p.KeyFile == "") {
+		panic("both http.tls.certFile and http.tls.keyFile are required to enable tls")
+	}
+	p.ClientCAFile = base.LoadWithDefault("http.tls.clientCAFile", "")
+
+	defaultClientAuth := HTTPClientAuthNone
+	if p.ClientCAFile != "" {
+		defaultClientAuth = HTTPClientAuthRequire
+	}
+	p.ClientAuth = strings.ToLower(base.LoadWithDefault("http.tls.clientAuth", defaultClientAuth))
+	if !httpClientAuths[p.ClientAuth] {
+		panic("invalid http.tls.clientAuth: " + p.ClientAuth + ", support none, request and require")
+	}
+	if p.ClientAuth != HTTPClientAuthNone && p.ClientCAFile == "" {
+		panic("http.tls.clientCAFile is required to verify client certificates")
+	}
+	if p.CertFile == "" && p.ClientCAFile != "" {
+		panic("http.tls.certFile and http.tls.keyFile are required to verify client certificates")
+	}
+
+	p.ReloadInterval = time.Duration(base.ParseIntWithDefault("http.tls.reloadInterval", 60)) * time.Second
+	if p.ReloadInterval <= 0 {
+		panic("invalid http.tls.reloadInterval: " + p.ReloadInterval.String())
+	}
+}
+
+// Enabled returns whether the http api is served over tls
+func (p *HTTPTLSConfig) Enabled() bool {
+	return p.CertFile != ""
+}
+
+// TraceConfig configures the OTLP exporter of the spans, tracing is disabled if Endpoint is empty
+type TraceConfig struct {
+	Base *BaseTable
+
+	Endpoint       string
+	Insecure       bool
+	SampleFraction float64
+	ServiceName    string
+}
+
+func (p *TraceConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.Endpoint = p.Base.LoadWithDefault("trace.endpoint", "")
+	p.Insecure = p.Base.ParseBool("trace.insecure", true)
+	p.ServiceName = p.Base.LoadWithDefault("trace.serviceName", "milvus-backup")
+	p.initSampleFraction()
+}
+
+func (p *TraceConfig) initSampleFraction() {
+	p.SampleFraction = p.Base.ParseFloatWithDefault("trace.sampleFraction", 1)
+	if p.SampleFraction < 0 || p.SampleFraction > 1 {
+		panic("invalid trace.sampleFraction: " + strconv.FormatFloat(p.SampleFraction, 'f', -1, 64) + ", should be between 0 and 1")
+	}
+}
+
+// ControllerConfig configures `milvus-backup controller`, which runs the backups of the MilvusBackup and
+// MilvusBackupSchedule objects in kubernetes
+type ControllerConfig struct {
+	Base *BaseTable
+
+	// namespace of the objects, the namespace of the pod if empty
+	Namespace string
+	// how often the objects are listed and reconciled
+	ResyncInterval time.Duration
+
+	// only the controller holding the lease reconciles, so that more than one replica can run
+	LeaderElection bool
+	LeaseName      string
+	LeaseDuration  time.Duration
+	RenewDeadline  time.Duration
+	RetryPeriod    time.Duration
+}
+
+func (p *ControllerConfig) init(base *BaseTable) {
+	p.Base = base
+
+	p.Namespace = p.Base.LoadWithDefault("controller.namespace", "")
+	p.ResyncInterval = time.Duration(p.Base.ParseIntWithDefault("controller.resyncInterval", 10)) * time.Second
+	if p.ResyncInterval <= 0 {
+		panic("invalid controller.resyncInterval, it should be positive")
+	}
+	p.initLeaderElection()
+}
+
+func (p *ControllerConfig) initLeaderElection() {
+	p.LeaderElection = p.Base.ParseBool("controller.leaderElection.enable", true)
+	p.LeaseName = p.Base.LoadWithDefault("controller.leaderElection.leaseName", "milvus-backup-controller")
+	p.LeaseDuration = time.Duration(p.Base.ParseIntWithDefault("controller.leaderElection.leaseDuration", 15)) * time.Second
+	p.RenewDeadline = time.Duration(p.Base.ParseIntWithDefault("controller.leaderElection.renewDeadline", 10)) * time.Second
+	p.RetryPeriod = time.Duration(p.Base.ParseIntWithDefault("controller.leaderElection.retryPeriod", 2)) * time.Second
+	if p.RetryPeriod <= 0 || p.RenewDeadline <= p.RetryPeriod || p.LeaseDuration <= p.RenewDeadline {
+		panic("invalid controller.leaderElection, it should be leaseDuration > renewDeadline > retryPeriod > 0")
+	}
+}
@@ -1,7 +1,14 @@
 package paramtable
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	memkv "github.com/zilliztech/milvus-backup/internal/kv/mem"
 )
 
 func TestRootPathParams(t *testing.T) {
@@ -13,3 +20,611 @@ func TestRootPathParams(t *testing.T) {
 	//cfg.initRootPath()
 	println(params.MinioCfg.RootPath)
 }
+
+func TestScheduleJobsParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+	base.Save("schedule.jobs.daily.cron", "0 2 * * *")
+	base.Save("schedule.jobs.daily.collections", "coll1, coll2")
+	base.Save("schedule.jobs.daily.prune", "true")
+	base.Save("schedule.jobs.hourly.cron", "@every 1h")
+
+	var cfg ScheduleConfig
+	cfg.init(base)
+	assert.Equal(t, []ScheduleJobConfig{
+		{Name: "daily", Cron: "0 2 * * *", CollectionNames: []string{"coll1", "coll2"}, Prune: true},
+		{Name: "hourly", Cron: "@every 1h", CollectionNames: []string{}},
+	}, cfg.Jobs)
+
+	base.Save("schedule.jobs.broken.prune", "true")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestContinuousParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg ContinuousConfig
+	cfg.init(base)
+	assert.Equal(t, "continuous", cfg.Name)
+	assert.Equal(t, 5*time.Minute, cfg.Interval)
+	assert.Equal(t, 24*time.Hour, cfg.FullInterval)
+	assert.Empty(t, cfg.CollectionNames)
+
+	base.Save("continuous.interval", "60")
+	base.Save("continuous.fullInterval", "0")
+	base.Save("continuous.collections", "coll1, coll2")
+	cfg.init(base)
+	assert.Equal(t, time.Minute, cfg.Interval)
+	assert.Equal(t, time.Duration(0), cfg.FullInterval)
+	assert.Equal(t, []string{"coll1", "coll2"}, cfg.CollectionNames)
+
+	base.Save("continuous.interval", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestParallelismParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+	base.Save("backup.parallelism.restoreCollection", "3")
+	base.Remove("backup.parallelism.bulkinsert")
+
+	var cfg BackupConfig
+	cfg.init(base)
+	assert.Equal(t, 3, cfg.RestoreParallelism)
+	// bulkinsert uses the parallelism of restoreCollection by default
+	assert.Equal(t, 3, cfg.RestoreBulkInsertParallelism)
+
+	base.Save("backup.parallelism.bulkinsert", "8")
+	base.Save("backup.parallelism.listMeta", "4")
+	cfg.init(base)
+	assert.Equal(t, 8, cfg.RestoreBulkInsertParallelism)
+	assert.Equal(t, 4, cfg.ListMetaParallelism)
+
+	base.Save("backup.parallelism.copydata", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestMaxMemoryParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+	base.Remove("backup.maxMemoryMB")
+
+	var cfg BackupConfig
+	cfg.init(base)
+	assert.Equal(t, 0, cfg.MaxMemoryMB)
+
+	base.Save("backup.maxMemoryMB", "2048")
+	cfg.init(base)
+	assert.Equal(t, 2048, cfg.MaxMemoryMB)
+
+	base.Save("backup.maxMemoryMB", "-1")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestStorageRetryParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+	base.Save("backup.storageRetry.maxAttempts", "3")
+	base.Save("backup.storageRetry.initialBackoff", "500")
+	base.Save("backup.storageRetry.maxBackoff", "100")
+
+	var cfg BackupConfig
+	cfg.init(base)
+	assert.Equal(t, 3, cfg.StorageRetryAttempts)
+	assert.Equal(t, 500*time.Millisecond, cfg.StorageRetryInitialBackoff)
+	// max backoff is never less than the initial backoff
+	assert.Equal(t, 500*time.Millisecond, cfg.StorageRetryMaxBackoff)
+
+	base.Save("backup.storageRetry.maxAttempts", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestStorageClassParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg BackupConfig
+	cfg.init(base)
+	assert.Equal(t, "", cfg.StorageClass)
+	assert.Equal(t, 1, cfg.RehydrateDays)
+	assert.Equal(t, "Standard", cfg.RehydrateTier)
+	assert.Equal(t, time.Minute, cfg.RehydratePollInterval)
+
+	base.Save("backup.storageClass", "deep_archive")
+	base.Save("backup.rehydrate.tier", "Bulk")
+	cfg.init(base)
+	assert.Equal(t, "DEEP_ARCHIVE", cfg.StorageClass)
+	assert.Equal(t, "Bulk", cfg.RehydrateTier)
+
+	base.Save("backup.storageClass", "COLD")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("backup.storageClass", "GLACIER_IR")
+	base.Save("backup.rehydrate.tier", "Fast")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestObjectLockParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg BackupConfig
+	cfg.init(base)
+	assert.Equal(t, "", cfg.ObjectLockMode)
+
+	base.Save("backup.objectLock.mode", "compliance")
+	base.Save("backup.objectLock.retentionDays", "30")
+	cfg.init(base)
+	assert.Equal(t, "COMPLIANCE", cfg.ObjectLockMode)
+	assert.Equal(t, 30, cfg.ObjectLockRetentionDays)
+
+	base.Save("backup.objectLock.retentionDays", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("backup.objectLock.mode", "LEGAL_HOLD")
+	base.Save("backup.objectLock.retentionDays", "30")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestServerSideEncryptionParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg BackupConfig
+	cfg.init(base)
+	assert.Equal(t, "", cfg.SSEType)
+
+	base.Save("backup.serverSideEncryption.type", "KMS")
+	base.Save("backup.serverSideEncryption.kmsKeyId", "arn:aws:kms:us-east-1:111122223333:key/backup")
+	cfg.init(base)
+	assert.Equal(t, "kms", cfg.SSEType)
+	assert.Equal(t, "arn:aws:kms:us-east-1:111122223333:key/backup", cfg.SSEKMSKeyID)
+
+	base.Save("backup.serverSideEncryption.type", "customer")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("backup.serverSideEncryption.customerKey", "env:SSE_KEY")
+	cfg.init(base)
+	assert.Equal(t, "env:SSE_KEY", cfg.SSECustomerKey)
+
+	base.Save("backup.serverSideEncryption.type", "aes256")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestBackupStorageParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg BackupStorageConfig
+	cfg.init(base)
+	assert.False(t, cfg.Enabled())
+
+	base.Save("backupStorage.storageType", "gcp")
+	base.Save("backupStorage.gcpCredentialJSON", "/path/to/key.json")
+	cfg.init(base)
+	assert.True(t, cfg.Enabled())
+	assert.Equal(t, "/path/to/key.json", cfg.GcpCredentialJSON)
+
+	base.Save("backupStorage.storageType", "local")
+	base.Save("backupStorage.localPath", "/mnt/nfs")
+	cfg.init(base)
+	assert.Equal(t, "/mnt/nfs", cfg.LocalPath)
+
+	base.Save("backupStorage.storageType", "hdfs")
+	base.Save("backupStorage.hdfsUser", "milvus")
+	base.Save("backupStorage.hdfsDelegationToken", "file:/etc/hdfs/token")
+	cfg.init(base)
+	assert.Equal(t, "milvus", cfg.HdfsUser)
+	assert.Equal(t, "file:/etc/hdfs/token", cfg.HdfsDelegationToken)
+	assert.False(t, IsS3Compatible(HDFS))
+
+	base.Save("backupStorage.storageType", "sftp")
+	base.Save("backupStorage.sftp.user", "backup")
+	base.Save("backupStorage.sftp.privateKeyFile", "/etc/milvus-backup/id_ed25519")
+	cfg.init(base)
+	assert.Equal(t, SFTPConfig{User: "backup", PrivateKeyFile: "/etc/milvus-backup/id_ed25519", MaxConnections: 4}, cfg.SFTP)
+	base.Save("backupStorage.sftp.maxConnections", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Remove("backupStorage.sftp.maxConnections")
+
+	base.Save("backupStorage.storageType", "unknown")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestRegisterStorageType(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg BackupStorageConfig
+	base.Save("backupStorage.storageType", "rados")
+	assert.Panics(t, func() { cfg.init(base) })
+
+	RegisterStorageType("rados")
+	defer func() {
+		storageTypesMu.Lock()
+		delete(customStorageType, "rados")
+		storageTypesMu.Unlock()
+	}()
+	cfg.init(base)
+	assert.Equal(t, "rados", cfg.StorageType)
+	assert.False(t, IsS3Compatible("rados"))
+	assert.False(t, cfg.SameStorage(&MinioConfig{StorageType: Minio}))
+	assert.True(t, cfg.SameStorage(&MinioConfig{StorageType: "rados", Address: cfg.Address, Port: cfg.Port, AssumeRole: cfg.AssumeRole}))
+
+	// built in storage types stay in their families
+	RegisterStorageType(CloudProviderAli)
+	assert.True(t, IsS3Compatible(CloudProviderAli))
+}
+
+func TestAssumeRoleParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg MinioConfig
+	cfg.init(base)
+	assert.False(t, cfg.AssumeRole.Enabled())
+
+	base.Save("minio.assumeRole.roleArn", "arn:aws:iam::111122223333:role/backup")
+	base.Save("minio.assumeRole.durationSeconds", "7200")
+	base.Save("minio.assumeRole.webIdentityTokenFile", "/var/run/secrets/eks.amazonaws.com/serviceaccount/token")
+	cfg.init(base)
+	assert.Equal(t, AssumeRoleConfig{
+		RoleArn:              "arn:aws:iam::111122223333:role/backup",
+		SessionName:          "milvus-backup",
+		DurationSeconds:      7200,
+		WebIdentityTokenFile: "/var/run/secrets/eks.amazonaws.com/serviceaccount/token",
+	}, cfg.AssumeRole)
+
+	base.Save("minio.assumeRole.durationSeconds", "60")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("minio.assumeRole.durationSeconds", "0")
+	base.Save("minio.assumeRole.roleArn", "")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestCredentialRefreshParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg MinioConfig
+	cfg.init(base)
+	assert.Equal(t, 5*time.Minute, cfg.CredentialRefreshInterval)
+
+	base.Save("minio.credentialRefreshInterval", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestMergeBackupStorage(t *testing.T) {
+	var params BackupParams
+	params.MinioCfg = MinioConfig{StorageType: "minio", Address: "localhost", Port: "9000", AccessKeyID: "ak", SecretAccessKey: "sk"}
+
+	// the same endpoint and credentials, accessed by the storage client of milvus
+	params.BackupStorageCfg = BackupStorageConfig{StorageType: "s3", Address: "localhost", Port: "9000", AccessKeyID: "ak", SecretAccessKey: "sk"}
+	assert.True(t, params.BackupStorageCfg.SameStorage(&params.MinioCfg))
+	params.MergeBackupStorage()
+	assert.False(t, params.BackupStorageCfg.Enabled())
+	assert.Equal(t, "ak", params.MinioCfg.BackupAccessKeyID)
+
+	// other credentials
+	params.BackupStorageCfg = BackupStorageConfig{StorageType: "s3", Address: "localhost", Port: "9000", AccessKeyID: "ak2", SecretAccessKey: "sk2"}
+	params.MergeBackupStorage()
+	assert.True(t, params.BackupStorageCfg.Enabled())
+
+	// the same keys assuming another role
+	params.BackupStorageCfg = BackupStorageConfig{StorageType: "s3", Address: "localhost", Port: "9000", AccessKeyID: "ak", SecretAccessKey: "sk"}
+	params.BackupStorageCfg.AssumeRole.RoleArn = "arn:aws:iam::111122223333:role/backup"
+	assert.False(t, params.BackupStorageCfg.SameStorage(&params.MinioCfg))
+
+	// other storage
+	params.BackupStorageCfg = BackupStorageConfig{StorageType: "gcp", Address: "localhost", Port: "9000"}
+	assert.False(t, params.BackupStorageCfg.SameStorage(&params.MinioCfg))
+
+	params.MinioCfg = MinioConfig{StorageType: "gcp", GcpCredentialJSON: "/path/to/key.json"}
+	params.BackupStorageCfg = BackupStorageConfig{StorageType: "gcp", GcpCredentialJSON: "/path/to/key.json"}
+	assert.True(t, params.BackupStorageCfg.SameStorage(&params.MinioCfg))
+	params.BackupStorageCfg.GcpCredentialJSON = "/path/to/other.json"
+	assert.False(t, params.BackupStorageCfg.SameStorage(&params.MinioCfg))
+
+	// hmac keys of gcs
+	params.MinioCfg = MinioConfig{StorageType: "gcp", AccessKeyID: "ak", SecretAccessKey: "sk"}
+	params.BackupStorageCfg = BackupStorageConfig{StorageType: "gcp", AccessKeyID: "ak", SecretAccessKey: "sk"}
+	assert.True(t, params.BackupStorageCfg.SameStorage(&params.MinioCfg))
+	params.BackupStorageCfg.AccessKeyID = "ak2"
+	assert.False(t, params.BackupStorageCfg.SameStorage(&params.MinioCfg))
+}
+
+func TestHTTPAuthParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg HTTPAuthConfig
+	cfg.init(base)
+	assert.False(t, cfg.Enabled())
+
+	base.Save("http.auth.apiKeys.ops.key", "env:BACKUP_OPS_API_KEY")
+	base.Save("http.auth.apiKeys.ops.role", "Admin")
+	base.Save("http.auth.users.alice.password", "file:/etc/milvus-backup/alice")
+	base.Save("http.auth.jwt.secret", "env:BACKUP_JWT_SECRET")
+	cfg.init(base)
+	assert.True(t, cfg.Enabled())
+	assert.Equal(t, []AuthCredentialConfig{{Name: "ops", Secret: "env:BACKUP_OPS_API_KEY", Role: AuthRoleAdmin}}, cfg.APIKeys)
+	// read by default
+	assert.Equal(t, []AuthCredentialConfig{{Name: "alice", Secret: "file:/etc/milvus-backup/alice", Role: AuthRoleRead}}, cfg.Users)
+	assert.Equal(t, "role", cfg.JWTRoleClaim)
+
+	base.Save("http.auth.jwt.publicKey", "file:/etc/milvus-backup/jwt.pem")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Remove("http.auth.jwt.publicKey")
+	base.Save("http.auth.apiKeys.ops.role", "write")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("http.auth.apiKeys.ops.role", "admin")
+	base.Save("http.auth.apiKeys.ci.role", "admin")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestHTTPTenantsParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	cfg := HTTPConfig{Base: base}
+	base.Save("http.tenants.team-a.quotaMB", "2")
+	base.Save("http.tenants.team-a.databases", "db1, db2")
+	base.Save("http.tenants.team-b.quotaMB", "0")
+	cfg.initTenants()
+	assert.Equal(t, "tenants", cfg.TenantRootPath)
+	assert.Equal(t, map[string]TenantConfig{
+		"team-a": {Name: "team-a", QuotaBytes: 2 * 1024 * 1024, Databases: []string{"db1", "db2"}},
+		// all databases
+		"team-b": {Name: "team-b", Databases: []string{}},
+	}, cfg.Tenants)
+
+	base.Save("http.tenants.team-b.quotaMB", "-1")
+	assert.Panics(t, func() { cfg.initTenants() })
+}
+
+func TestNotificationParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+	base.Save("notification.durationThreshold", "3600")
+	base.Save("notification.webhooks.ops.url", "http://localhost/hook")
+	base.Save("notification.webhooks.ops.events", "fail, slow")
+	base.Save("notification.webhooks.pd.format", "pagerduty")
+	base.Save("notification.webhooks.pd.routingKey", "key")
+
+	var cfg NotificationConfig
+	cfg.init(base)
+	assert.Equal(t, 3600, cfg.DurationThreshold)
+	assert.Equal(t, []WebhookConfig{
+		{Name: "ops", URL: "http://localhost/hook", Format: "generic", Events: []string{"fail", "slow"}},
+		{Name: "pd", Format: "pagerduty", Events: []string{}, RoutingKey: "key"},
+	}, cfg.Webhooks)
+
+	base.Save("notification.webhooks.ops.events", "done")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("notification.webhooks.ops.events", "fail")
+	base.Save("notification.webhooks.slack.format", "slack")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestHTTPTLSParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg HTTPTLSConfig
+	cfg.init(base)
+	assert.False(t, cfg.Enabled())
+
+	base.Save("http.tls.certFile", "/etc/milvus-backup/tls.crt")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("http.tls.keyFile", "/etc/milvus-backup/tls.key")
+	cfg.init(base)
+	assert.True(t, cfg.Enabled())
+	assert.Equal(t, HTTPClientAuthNone, cfg.ClientAuth)
+	assert.Equal(t, time.Minute, cfg.ReloadInterval)
+
+	// client certificates are required once the client ca is set
+	base.Save("http.tls.clientCAFile", "/etc/milvus-backup/ca.crt")
+	cfg.init(base)
+	assert.Equal(t, HTTPClientAuthRequire, cfg.ClientAuth)
+	base.Save("http.tls.clientAuth", "Request")
+	cfg.init(base)
+	assert.Equal(t, HTTPClientAuthRequest, cfg.ClientAuth)
+
+	base.Save("http.tls.clientAuth", "verify")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Remove("http.tls.clientCAFile")
+	base.Save("http.tls.clientAuth", "require")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Remove("http.tls.clientAuth")
+	base.Save("http.tls.reloadInterval", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestMilvusTLSParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	cfg := MilvusConfig{Base: base}
+	cfg.initTLSFiles()
+	assert.Equal(t, "", cfg.MTLSCertPath)
+
+	base.Save("milvus.caCertPath", "/etc/milvus-backup/milvus/ca.pem")
+	base.Save("milvus.serverName", "milvus.example.com")
+	base.Save("milvus.mtlsCertPath", "/etc/milvus-backup/milvus/client.pem")
+	assert.Panics(t, func() { cfg.initTLSFiles() })
+	base.Save("milvus.mtlsKeyPath", "/etc/milvus-backup/milvus/client.key")
+	cfg.initTLSFiles()
+	assert.Equal(t, "/etc/milvus-backup/milvus/ca.pem", cfg.CACertPath)
+	assert.Equal(t, "milvus.example.com", cfg.ServerName)
+	assert.Equal(t, "/etc/milvus-backup/milvus/client.key", cfg.MTLSKeyPath)
+}
+
+func TestGRPCParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg GRPCConfig
+	cfg.init(base)
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, 50051, cfg.Port)
+	assert.Equal(t, time.Second, cfg.WatchInterval)
+
+	base.Save("grpc.enabled", "true")
+	base.Save("grpc.port", "9091")
+	base.Save("grpc.watchInterval", "200")
+	cfg.init(base)
+	assert.True(t, cfg.Enabled)
+	assert.Equal(t, 9091, cfg.Port)
+	assert.Equal(t, 200*time.Millisecond, cfg.WatchInterval)
+
+	base.Save("grpc.port", "70000")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestBulkInsertParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg BackupConfig
+	cfg.init(base)
+	assert.Equal(t, 1, cfg.BulkInsertBatchSize)
+	assert.Equal(t, cfg.RestoreBulkInsertParallelism, cfg.BulkInsertConcurrency)
+	assert.Equal(t, 0, cfg.BulkInsertMaxJobs)
+	assert.Equal(t, "completed", cfg.BulkInsertWaitState)
+	assert.Equal(t, time.Hour, cfg.BulkInsertTimeout)
+	assert.Equal(t, 5*time.Second, cfg.BulkInsertPollInterval)
+	assert.Equal(t, 1, cfg.BulkInsertMaxAttempts)
+
+	base.Save("backup.bulkinsert.batchSize", "4")
+	base.Save("backup.bulkinsert.maxJobs", "8")
+	base.Save("backup.bulkinsert.waitState", "Persisted")
+	base.Save("backup.bulkinsert.maxAttempts", "3")
+	cfg.init(base)
+	assert.Equal(t, cfg.RestoreBulkInsertParallelism*4, cfg.BulkInsertConcurrency)
+	base.Save("backup.bulkinsert.concurrency", "16")
+	cfg.init(base)
+	assert.Equal(t, 16, cfg.BulkInsertConcurrency)
+	assert.Equal(t, 4, cfg.BulkInsertBatchSize)
+	assert.Equal(t, 8, cfg.BulkInsertMaxJobs)
+	assert.Equal(t, "persisted", cfg.BulkInsertWaitState)
+	assert.Equal(t, 3, cfg.BulkInsertMaxAttempts)
+
+	base.Save("backup.bulkinsert.waitState", "started")
+	assert.Panics(t, func() { cfg.init(base) })
+	base.Save("backup.bulkinsert.waitState", "completed")
+	base.Save("backup.bulkinsert.batchSize", "0")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestEtcdParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	var cfg EtcdConfig
+	cfg.init(base)
+	assert.Equal(t, []string{"localhost:2379"}, cfg.Endpoints)
+	assert.Equal(t, "by-dev/meta", cfg.MetaRootPath())
+	assert.Equal(t, 5*time.Second, cfg.DialTimeout)
+
+	base.Save("etcd.endpoints", "etcd-0:2379, etcd-1:2379,")
+	base.Save("etcd.rootPath", "/prod/")
+	base.Save("etcd.metaSubPath", "")
+	cfg.init(base)
+	assert.Equal(t, []string{"etcd-0:2379", "etcd-1:2379"}, cfg.Endpoints)
+	assert.Equal(t, "prod", cfg.MetaRootPath())
+
+	base.Save("etcd.ssl.tlsCert", "/etc/milvus-backup/etcd/client.pem")
+	assert.Panics(t, func() { cfg.init(base) })
+}
+
+func TestBinlogLayoutParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	cfg := MinioConfig{Base: base, RootPath: "files"}
+	cfg.initBinlogLayout()
+	assert.Empty(t, cfg.ExtraRootPaths)
+	assert.Equal(t, DefaultBinlogPathTemplate, cfg.BinlogPathTemplate)
+
+	base.Save("minio.extraRootPaths", "tenant-a/files/, files, tenant-b")
+	base.Save("minio.binlogPathTemplate", "{{rootPath}}/{{collectionID}}/{{logType}}/{{partitionID}}/{{segmentID}}/")
+	cfg.initBinlogLayout()
+	assert.Equal(t, []string{"tenant-a/files", "tenant-b"}, cfg.ExtraRootPaths)
+	assert.Equal(t, "{{rootPath}}/{{collectionID}}/{{logType}}/{{partitionID}}/{{segmentID}}", cfg.BinlogPathTemplate)
+
+	base.Save("minio.binlogPathTemplate", "{{rootPath}}/{{logType}}/{{collectionID}}/{{segmentID}}")
+	assert.Panics(t, func() { cfg.initBinlogLayout() })
+}
+
+func TestBackupPathTemplateParams(t *testing.T) {
+	base := &BaseTable{
+		params: memkv.NewMemoryKV(),
+	}
+	base.Init()
+
+	cfg := MinioConfig{Base: base}
+	cfg.initBackupPathTemplate()
+	assert.Equal(t, DefaultBackupPathTemplate, cfg.BackupPathTemplate)
+
+	base.Save("minio.backupPathTemplate", "/{{backup}}/{{database}}/{{collection}}/{{logType}}/{{partitionID}}/{{groupID}}/")
+	cfg.initBackupPathTemplate()
+	assert.Equal(t, "{{backup}}/{{database}}/{{collection}}/{{logType}}/{{partitionID}}/{{groupID}}", cfg.BackupPathTemplate)
+
+	assert.ErrorContains(t, ValidateBackupPathTemplate("binlogs/{{backup}}/{{logType}}/{{collectionID}}/{{partitionID}}/{{groupID}}"), "should start with")
+	assert.ErrorContains(t, ValidateBackupPathTemplate("{{backup}}/meta/{{logType}}/{{collectionID}}/{{partitionID}}/{{groupID}}"), "reserved")
+	assert.ErrorContains(t, ValidateBackupPathTemplate("{{backup}}/{{logType}}/{{collectionID}}/{{partitionID}}"), "{{groupID}} is required")
+	assert.ErrorContains(t, ValidateBackupPathTemplate("{{backup}}/{{collection}}/{{logType}}/{{partitionID}}/{{groupID}}"), "{{database}}")
+	base.Save("minio.backupPathTemplate", "{{backup}}/{{logType}}/{{segmentID}}")
+	assert.Panics(t, func() { cfg.initBackupPathTemplate() })
+}
+
+func TestApplyMilvusConfigFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "milvus.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("minio:\n  rootPath: tenant-a/files\n"), 0o600))
+	cfg := MinioConfig{BucketName: "a-bucket", RootPath: "files"}
+	assert.NoError(t, cfg.ApplyMilvusConfigFile(file))
+	assert.Equal(t, "tenant-a/files", cfg.RootPath)
+	assert.Equal(t, "a-bucket", cfg.BucketName)
+
+	assert.Error(t, cfg.ApplyMilvusConfigFile(filepath.Join(t.TempDir(), "missing.yaml")))
+}
@@ -0,0 +1,69 @@
+// Package parquet reads and writes the parquet files of the binlogs of milvus and of exported backups by apache
+// arrow: flat columns of the physical types and lists of values, as rows of plain go values.
+package parquet
+
+import "fmt"
+
+const magic = "PAR1"
+
+// Type is the physical type of the values of a column
+type Type int32
+
+const (
+	Boolean           Type = 0
+	Int32             Type = 1
+	Int64             Type = 2
+	Int96             Type = 3
+	Float             Type = 4
+	Double            Type = 5
+	ByteArray         Type = 6
+	FixedLenByteArray Type = 7
+)
+
+func (t Type) String() string {
+	switch t {
+	case Boolean:
+		return "BOOLEAN"
+	case Int32:
+		return "INT32"
+	case Int64:
+		return "INT64"
+	case Int96:
+		return "INT96"
+	case Float:
+		return "FLOAT"
+	case Double:
+		return "DOUBLE"
+	case ByteArray:
+		return "BYTE_ARRAY"
+	case FixedLenByteArray:
+		return "FIXED_LEN_BYTE_ARRAY"
+	default:
+		return fmt.Sprintf("Type(%d)", int32(t))
+	}
+}
+
+// LogicalType annotates how the values of a physical type are interpreted
+type LogicalType int
+
+const (
+	LogicalNone LogicalType = iota
+	// utf8 strings stored as ByteArray
+	LogicalString
+	// 8 and 16 bits signed integers stored as Int32
+	LogicalInt8
+	LogicalInt16
+)
+
+// Column describes a column of a parquet file
+type Column struct {
+	Name string
+	Type Type
+	// length of the values of FixedLenByteArray
+	TypeLength  int
+	LogicalType LogicalType
+	// values may be nil
+	Optional bool
+	// every value is a list of values of Type, in the 3-level LIST layout
+	List bool
+}
@@ -0,0 +1,133 @@
+package parquet
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	pq "github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/compress"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteRead(t *testing.T) {
+	columns := []Column{
+		{Name: "id", Type: Int64},
+		{Name: "flag", Type: Boolean, Optional: true},
+		{Name: "small", Type: Int32, LogicalType: LogicalInt8},
+		{Name: "score", Type: Float, Optional: true},
+		{Name: "ratio", Type: Double},
+		{Name: "name", Type: ByteArray, LogicalType: LogicalString, Optional: true},
+		{Name: "bits", Type: FixedLenByteArray, TypeLength: 2},
+		{Name: "vector", Type: Float, List: true},
+	}
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, columns)
+	assert.NoError(t, err)
+	// the names of the second row group take more than a page
+	long := strings.Repeat("x", pageSize/2)
+	groups := [][][]interface{}{
+		{
+			{int64(1), int64(2), int64(3)},
+			{true, nil, false},
+			{int32(-1), int32(0), int32(127)},
+			{float32(0.5), float32(1.5), nil},
+			{0.25, 0.5, 0.75},
+			{"a", nil, []byte("c")},
+			{[]byte{1, 2}, []byte{3, 4}, []byte{5, 6}},
+			{[]float32{1, 2}, []float32{}, []interface{}{float32(3)}},
+		},
+		{
+			{int64(4), int64(5), int64(6)},
+			{nil, nil, true},
+			{int32(1), int32(2), int32(3)},
+			{nil, nil, nil},
+			{1.0, 2.0, 3.0},
+			{long, long, long},
+			{[]byte{7, 8}, []byte{9, 10}, []byte{11, 12}},
+			{[]float32{4}, []float32{5}, []float32{6}},
+		},
+	}
+	for _, group := range groups {
+		assert.NoError(t, w.WriteRowGroup(group))
+	}
+	assert.NoError(t, w.Close())
+
+	f, err := Open(buf.Bytes())
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), f.NumRows())
+	read := f.Columns()
+	assert.Len(t, read, len(columns))
+	assert.True(t, read[1].Optional)
+	assert.False(t, read[0].Optional)
+	assert.True(t, read[7].List)
+	for i := 0; i < len(columns)-1; i++ {
+		values, err := f.ReadColumn(i)
+		assert.NoError(t, err)
+		expected := append(append([]interface{}{}, groups[0][i]...), groups[1][i]...)
+		for j, value := range expected {
+			if s, ok := value.(string); ok {
+				expected[j] = []byte(s)
+			}
+		}
+		assert.Equal(t, expected, values, columns[i].Name)
+	}
+	vectors, err := f.ReadColumn(7)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{float32(1), float32(2)}, []interface{}{}, []interface{}{float32(3)},
+		[]interface{}{float32(4)}, []interface{}{float32(5)}, []interface{}{float32(6)},
+	}, vectors)
+
+	// values of other types
+	w, err = NewWriter(&bytes.Buffer{}, columns[:1])
+	assert.NoError(t, err)
+	assert.ErrorContains(t, w.WriteRowGroup([][]interface{}{{int32(1)}}), "value int32 of column id is not INT64")
+	assert.ErrorContains(t, w.WriteRowGroup([][]interface{}{{nil}}), "null value of required column id")
+}
+
+// dictionaryFile builds a file of a column like the payloads of milvus binlogs: an optional string column of two
+// row groups compressed by zstd in dictionary encoded data pages v2
+func dictionaryFile(t *testing.T) []byte {
+	node, err := schema.NewPrimitiveNodeLogical("val", pq.Repetitions.Optional, schema.StringLogicalType{}, pq.Types.ByteArray, -1, -1)
+	assert.NoError(t, err)
+	root, err := schema.NewGroupNode("schema", pq.Repetitions.Required, schema.FieldList{node}, -1)
+	assert.NoError(t, err)
+	props := pq.NewWriterProperties(
+		pq.WithCompression(compress.Codecs.Zstd),
+		pq.WithDictionaryDefault(true),
+		pq.WithDataPageVersion(pq.DataPageV2))
+	var buf bytes.Buffer
+	w := file.NewParquetWriter(&buf, root, file.WithWriterProps(props))
+	for _, group := range []struct {
+		values []pq.ByteArray
+		defs   []int16
+	}{
+		{values: []pq.ByteArray{pq.ByteArray("b"), pq.ByteArray("a"), pq.ByteArray("b")}, defs: []int16{1, 0, 1, 1}},
+		{values: []pq.ByteArray{pq.ByteArray("c")}, defs: []int16{0, 1}},
+	} {
+		rowGroup := w.AppendRowGroup()
+		column, err := rowGroup.NextColumn()
+		assert.NoError(t, err)
+		_, err = column.(*file.ByteArrayColumnChunkWriter).WriteBatch(group.values, group.defs, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, rowGroup.Close())
+	}
+	assert.NoError(t, w.Close())
+	return buf.Bytes()
+}
+
+func TestReadDictionary(t *testing.T) {
+	f, err := Open(dictionaryFile(t))
+	assert.NoError(t, err)
+	values, err := f.ReadColumn(0)
+	assert.NoError(t, err)
+	assert.Equal(t, []interface{}{[]byte("b"), nil, []byte("a"), []byte("b"), nil, []byte("c")}, values)
+
+	_, err = Open([]byte("PAR1garbagePAR1"))
+	assert.Error(t, err)
+	_, err = Open([]byte("not parquet"))
+	assert.ErrorContains(t, err, "not a parquet file")
+}
@@ -0,0 +1,242 @@
+package parquet
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	pq "github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/schema"
+)
+
+// values read from a column chunk at a time
+const batchSize = 1024
+
+// File is a parquet file read in memory
+type File struct {
+	reader *file.Reader
+	leaves []leaf
+}
+
+// leaf is a column of the schema with the levels of its path
+type leaf struct {
+	Column
+	path   []string
+	maxDef int
+	maxRep int
+	// definition level of the repeated element of a list
+	repDef int
+}
+
+// Open parses the footer of a parquet file
+func Open(data []byte) (*File, error) {
+	if len(data) < 2*len(magic)+4 || string(data[:len(magic)]) != magic || string(data[len(data)-len(magic):]) != magic {
+		return nil, fmt.Errorf("parquet: not a parquet file")
+	}
+	reader, err := file.NewParquetReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	f := &File{reader: reader}
+	fileSchema := reader.MetaData().Schema
+	for i := 0; i < fileSchema.NumColumns(); i++ {
+		column := fileSchema.Column(i)
+		path := column.ColumnPath()
+		f.leaves = append(f.leaves, leaf{
+			Column: Column{
+				Name:       path[0],
+				Type:       Type(column.PhysicalType()),
+				TypeLength: column.TypeLength(),
+				Optional:   column.MaxDefinitionLevel() > 0,
+				List:       column.MaxRepetitionLevel() > 0,
+			},
+			path:   path,
+			maxDef: int(column.MaxDefinitionLevel()),
+			maxRep: int(column.MaxRepetitionLevel()),
+			repDef: repeatedDefinitionLevel(fileSchema.ColumnRoot(i), column.SchemaNode()),
+		})
+	}
+	return f, nil
+}
+
+// repeatedDefinitionLevel returns the definition level of the innermost repeated node on the path from root to
+// node, 0 if there is none
+func repeatedDefinitionLevel(root schema.Node, node schema.Node) int {
+	var nodes []schema.Node
+	for n := node; n != nil; n = n.Parent() {
+		nodes = append(nodes, n)
+		if n == root {
+			break
+		}
+	}
+	def, repDef := 0, 0
+	for i := len(nodes) - 1; i >= 0; i-- {
+		switch nodes[i].RepetitionType() {
+		case pq.Repetitions.Optional:
+			def++
+		case pq.Repetitions.Repeated:
+			def++
+			repDef = def
+		}
+	}
+	return repDef
+}
+
+// NumRows returns the rows of the file
+func (f *File) NumRows() int64 {
+	return f.reader.NumRows()
+}
+
+// Columns returns the leaf columns of the file
+func (f *File) Columns() []Column {
+	columns := make([]Column, 0, len(f.leaves))
+	for _, leaf := range f.leaves {
+		columns = append(columns, leaf.Column)
+	}
+	return columns
+}
+
+// ReadColumn reads all the values of the leaf column index, nil for nulls. Values are bool, int32, int64,
+// float32, float64 or []byte by the type of column. Values of a list column are []interface{} of its elements.
+func (f *File) ReadColumn(index int) ([]interface{}, error) {
+	if index < 0 || index >= len(f.leaves) {
+		return nil, fmt.Errorf("parquet: column %d out of range", index)
+	}
+	leaf := f.leaves[index]
+	if leaf.maxRep > 1 {
+		return nil, fmt.Errorf("parquet: nested column %s is not supported", strings.Join(leaf.path, "."))
+	}
+	values := make([]interface{}, 0, f.NumRows())
+	var levels *listLevels
+	if leaf.maxRep > 0 {
+		levels = &listLevels{}
+	}
+	for i := 0; i < f.reader.NumRowGroups(); i++ {
+		reader, err := f.reader.RowGroup(i).Column(index)
+		if err != nil {
+			return nil, err
+		}
+		if values, err = readChunk(leaf, reader, values, levels); err != nil {
+			return nil, err
+		}
+	}
+	if levels != nil {
+		return levels.rows(leaf, values)
+	}
+	return values, nil
+}
+
+// listLevels is the repetition and definition levels of the values of a list column
+type listLevels struct {
+	reps []int16
+	defs []int16
+}
+
+// rows assembles the values of a list column into the lists of rows, a row starts by a value of repetition level 0
+func (l *listLevels) rows(leaf leaf, values []interface{}) ([]interface{}, error) {
+	if len(l.reps) != len(values) || len(l.defs) != len(values) {
+		return nil, fmt.Errorf("parquet: %d levels of %d values of column %s", len(l.reps), len(values), leaf.Name)
+	}
+	rows := make([]interface{}, 0)
+	var row []interface{}
+	for i, value := range values {
+		if l.reps[i] == 0 {
+			if i > 0 {
+				rows = appendList(rows, row)
+			}
+			switch {
+			case int(l.defs[i]) < leaf.repDef-1:
+				// null list
+				row = nil
+				continue
+			case int(l.defs[i]) == leaf.repDef-1:
+				// empty list
+				row = []interface{}{}
+				continue
+			}
+			row = make([]interface{}, 0, 1)
+		} else if row == nil {
+			return nil, fmt.Errorf("parquet: repeated value of null list of column %s", leaf.Name)
+		}
+		row = append(row, value)
+	}
+	if len(values) > 0 {
+		rows = appendList(rows, row)
+	}
+	return rows, nil
+}
+
+// appendList appends a list as an interface, nil for a null list instead of a typed nil
+func appendList(rows []interface{}, row []interface{}) []interface{} {
+	if row == nil {
+		return append(rows, nil)
+	}
+	return append(rows, row)
+}
+
+// readChunk appends the values of a column chunk, and their levels into levels if the column is a list
+func readChunk(leaf leaf, reader file.ColumnChunkReader, values []interface{}, levels *listLevels) ([]interface{}, error) {
+	defs := make([]int16, batchSize)
+	reps := make([]int16, batchSize)
+	// read reads a batch of values into a buffer, value returns the i-th value of the buffer
+	var read func() (int64, int, error)
+	var value func(i int) interface{}
+	switch r := reader.(type) {
+	case *file.BooleanColumnChunkReader:
+		buf := make([]bool, batchSize)
+		read = func() (int64, int, error) { return r.ReadBatch(batchSize, buf, defs, reps) }
+		value = func(i int) interface{} { return buf[i] }
+	case *file.Int32ColumnChunkReader:
+		buf := make([]int32, batchSize)
+		read = func() (int64, int, error) { return r.ReadBatch(batchSize, buf, defs, reps) }
+		value = func(i int) interface{} { return buf[i] }
+	case *file.Int64ColumnChunkReader:
+		buf := make([]int64, batchSize)
+		read = func() (int64, int, error) { return r.ReadBatch(batchSize, buf, defs, reps) }
+		value = func(i int) interface{} { return buf[i] }
+	case *file.Float32ColumnChunkReader:
+		buf := make([]float32, batchSize)
+		read = func() (int64, int, error) { return r.ReadBatch(batchSize, buf, defs, reps) }
+		value = func(i int) interface{} { return buf[i] }
+	case *file.Float64ColumnChunkReader:
+		buf := make([]float64, batchSize)
+		read = func() (int64, int, error) { return r.ReadBatch(batchSize, buf, defs, reps) }
+		value = func(i int) interface{} { return buf[i] }
+	case *file.ByteArrayColumnChunkReader:
+		buf := make([]pq.ByteArray, batchSize)
+		read = func() (int64, int, error) { return r.ReadBatch(batchSize, buf, defs, reps) }
+		// the buffers of pages are reused by the reader
+		value = func(i int) interface{} { return append([]byte{}, buf[i]...) }
+	case *file.FixedLenByteArrayColumnChunkReader:
+		buf := make([]pq.FixedLenByteArray, batchSize)
+		read = func() (int64, int, error) { return r.ReadBatch(batchSize, buf, defs, reps) }
+		value = func(i int) interface{} { return append([]byte{}, buf[i]...) }
+	default:
+		return nil, fmt.Errorf("parquet: unsupported type %s of column %s", leaf.Type, leaf.Name)
+	}
+	for reader.HasNext() {
+		total, _, err := read()
+		if err != nil {
+			return nil, err
+		}
+		if levels != nil {
+			levels.reps = append(levels.reps, reps[:total]...)
+			levels.defs = append(levels.defs, defs[:total]...)
+		}
+		// the values of nulls are not read
+		next := 0
+		for i := 0; i < int(total); i++ {
+			if leaf.maxDef > 0 && int(defs[i]) < leaf.maxDef {
+				values = append(values, nil)
+				continue
+			}
+			values = append(values, value(next))
+			next++
+		}
+	}
+	if err := reader.Err(); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
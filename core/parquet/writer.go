@@ -0,0 +1,294 @@
+package parquet
+
+import (
+	"fmt"
+	"io"
+
+	pq "github.com/apache/arrow/go/v11/parquet"
+	"github.com/apache/arrow/go/v11/parquet/compress"
+	"github.com/apache/arrow/go/v11/parquet/file"
+	"github.com/apache/arrow/go/v11/parquet/schema"
+)
+
+// values of a data page are flushed at the first row boundary after the size
+const pageSize = 1024 * 1024
+
+// Writer writes a parquet file by row groups, values are PLAIN encoded into data pages v1 compressed by snappy
+type Writer struct {
+	writer  *file.Writer
+	columns []Column
+}
+
+// noCloseWriter hides the Close of a writer from the parquet writer, which closes its writer when closed
+type noCloseWriter struct {
+	io.Writer
+}
+
+// NewWriter writes the header of a parquet file of the columns to w
+func NewWriter(w io.Writer, columns []Column) (writer *Writer, err error) {
+	fields := make(schema.FieldList, 0, len(columns))
+	for _, column := range columns {
+		if column.Name == "" {
+			return nil, fmt.Errorf("parquet: empty column name")
+		}
+		if column.Type == FixedLenByteArray && column.TypeLength <= 0 {
+			return nil, fmt.Errorf("parquet: illegal length %d of column %s", column.TypeLength, column.Name)
+		}
+		field, err := columnNode(column)
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, field)
+	}
+	root, err := schema.NewGroupNode("schema", pq.Repetitions.Required, fields, -1)
+	if err != nil {
+		return nil, err
+	}
+	props := pq.NewWriterProperties(
+		pq.WithCompression(compress.Codecs.Snappy),
+		pq.WithDictionaryDefault(false),
+		pq.WithDataPageVersion(pq.DataPageV1),
+		pq.WithDataPageSize(pageSize),
+		pq.WithCreatedBy("milvus-backup"))
+	// the parquet writer panics if it fails to write the header
+	defer func() {
+		if r := recover(); r != nil {
+			writer, err = nil, fmt.Errorf("parquet: %v", r)
+		}
+	}()
+	return &Writer{
+		writer:  file.NewParquetWriter(noCloseWriter{w}, root, file.WithWriterProps(props)),
+		columns: columns,
+	}, nil
+}
+
+// columnNode returns the schema node of a column, a list column is in the 3-level LIST layout
+func columnNode(column Column) (schema.Node, error) {
+	repetition := pq.Repetitions.Required
+	if column.Optional {
+		repetition = pq.Repetitions.Optional
+	}
+	name := column.Name
+	if column.List {
+		name = "element"
+	}
+	var logicalType schema.LogicalType = schema.NoLogicalType{}
+	switch column.LogicalType {
+	case LogicalString:
+		logicalType = schema.StringLogicalType{}
+	case LogicalInt8:
+		logicalType = schema.NewIntLogicalType(8, true)
+	case LogicalInt16:
+		logicalType = schema.NewIntLogicalType(16, true)
+	}
+	typeLength := -1
+	if column.Type == FixedLenByteArray {
+		typeLength = column.TypeLength
+	}
+	leafRepetition := repetition
+	if column.List {
+		leafRepetition = pq.Repetitions.Required
+	}
+	node, err := schema.NewPrimitiveNodeLogical(name, leafRepetition, logicalType, pq.Type(column.Type), typeLength, -1)
+	if err != nil || !column.List {
+		return node, err
+	}
+	list, err := schema.NewGroupNode("list", pq.Repetitions.Repeated, schema.FieldList{node}, -1)
+	if err != nil {
+		return nil, err
+	}
+	return schema.NewGroupNodeLogical(column.Name, repetition, schema.FieldList{list}, schema.NewListLogicalType(), -1)
+}
+
+// WriteRowGroup writes a row group of the values of every column, nil for nulls of optional columns. Values are
+// bool, int32, int64, float32, float64, []byte or string by the type of column, values of list columns are slices
+// of them, []float32 or []interface{}.
+func (w *Writer) WriteRowGroup(values [][]interface{}) error {
+	if len(values) != len(w.columns) {
+		return fmt.Errorf("parquet: %d columns of row group, expect %d", len(values), len(w.columns))
+	}
+	numRows := -1
+	for i, columnValues := range values {
+		if numRows >= 0 && len(columnValues) != numRows {
+			return fmt.Errorf("parquet: %d rows of column %s, expect %d", len(columnValues), w.columns[i].Name, numRows)
+		}
+		numRows = len(columnValues)
+	}
+	// the values are checked before any of them is written, a row group can't be discarded once appended
+	chunks := make([]*chunk, 0, len(w.columns))
+	for i, column := range w.columns {
+		c := newChunk(column)
+		for _, value := range values[i] {
+			if err := c.appendRow(value); err != nil {
+				return err
+			}
+		}
+		chunks = append(chunks, c)
+	}
+	rowGroup := w.writer.AppendRowGroup()
+	for _, c := range chunks {
+		writer, err := rowGroup.NextColumn()
+		if err != nil {
+			return err
+		}
+		if err := c.write(writer); err != nil {
+			return err
+		}
+	}
+	return rowGroup.Close()
+}
+
+// chunk holds the levels and values of a column chunk being written
+type chunk struct {
+	column  Column
+	maxDef  int16
+	maxRep  int16
+	defs    []int16
+	reps    []int16
+	bools   []bool
+	int32s  []int32
+	int64s  []int64
+	floats  []float32
+	doubles []float64
+	bytes   []pq.ByteArray
+	fixed   []pq.FixedLenByteArray
+}
+
+func newChunk(column Column) *chunk {
+	c := &chunk{column: column}
+	if column.Optional {
+		c.maxDef++
+	}
+	if column.List {
+		c.maxDef++
+		c.maxRep++
+	}
+	return c
+}
+
+func (c *chunk) appendValue(value interface{}, def int16, rep int16) error {
+	if c.maxDef > 0 {
+		c.defs = append(c.defs, def)
+	}
+	if c.maxRep > 0 {
+		c.reps = append(c.reps, rep)
+	}
+	if def < c.maxDef {
+		return nil
+	}
+	ok := false
+	switch v := value.(type) {
+	case bool:
+		if ok = c.column.Type == Boolean; ok {
+			c.bools = append(c.bools, v)
+		}
+	case int32:
+		if ok = c.column.Type == Int32; ok {
+			c.int32s = append(c.int32s, v)
+		}
+	case int64:
+		if ok = c.column.Type == Int64; ok {
+			c.int64s = append(c.int64s, v)
+		}
+	case float32:
+		if ok = c.column.Type == Float; ok {
+			c.floats = append(c.floats, v)
+		}
+	case float64:
+		if ok = c.column.Type == Double; ok {
+			c.doubles = append(c.doubles, v)
+		}
+	case []byte:
+		if c.column.Type == ByteArray {
+			ok = true
+			c.bytes = append(c.bytes, v)
+		} else if ok = c.column.Type == FixedLenByteArray && len(v) == c.column.TypeLength; ok {
+			c.fixed = append(c.fixed, v)
+		}
+	case string:
+		if ok = c.column.Type == ByteArray; ok {
+			c.bytes = append(c.bytes, pq.ByteArray(v))
+		}
+	}
+	if !ok {
+		return fmt.Errorf("parquet: value %T of column %s is not %s", value, c.column.Name, c.column.Type)
+	}
+	return nil
+}
+
+// appendRow appends the levels and values of a row, the element of a list is required
+func (c *chunk) appendRow(value interface{}) error {
+	if value == nil {
+		if !c.column.Optional {
+			return fmt.Errorf("parquet: null value of required column %s", c.column.Name)
+		}
+		return c.appendValue(nil, 0, 0)
+	}
+	if !c.column.List {
+		return c.appendValue(value, c.maxDef, 0)
+	}
+	// the list is defined but empty
+	emptyDef := c.maxDef - 1
+	switch list := value.(type) {
+	case []float32:
+		if len(list) == 0 {
+			return c.appendValue(nil, emptyDef, 0)
+		}
+		for i, v := range list {
+			if err := c.appendValue(v, c.maxDef, int16(min(i, 1))); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if len(list) == 0 {
+			return c.appendValue(nil, emptyDef, 0)
+		}
+		for i, v := range list {
+			if v == nil {
+				return fmt.Errorf("parquet: null element of list column %s", c.column.Name)
+			}
+			if err := c.appendValue(v, c.maxDef, int16(min(i, 1))); err != nil {
+				return err
+			}
+		}
+	default:
+		return fmt.Errorf("parquet: value %T of list column %s is not a list", value, c.column.Name)
+	}
+	return nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// write writes the levels and values of the chunk by the column writer of its type
+func (c *chunk) write(writer file.ColumnChunkWriter) error {
+	var err error
+	switch w := writer.(type) {
+	case *file.BooleanColumnChunkWriter:
+		_, err = w.WriteBatch(c.bools, c.defs, c.reps)
+	case *file.Int32ColumnChunkWriter:
+		_, err = w.WriteBatch(c.int32s, c.defs, c.reps)
+	case *file.Int64ColumnChunkWriter:
+		_, err = w.WriteBatch(c.int64s, c.defs, c.reps)
+	case *file.Float32ColumnChunkWriter:
+		_, err = w.WriteBatch(c.floats, c.defs, c.reps)
+	case *file.Float64ColumnChunkWriter:
+		_, err = w.WriteBatch(c.doubles, c.defs, c.reps)
+	case *file.ByteArrayColumnChunkWriter:
+		_, err = w.WriteBatch(c.bytes, c.defs, c.reps)
+	case *file.FixedLenByteArrayColumnChunkWriter:
+		_, err = w.WriteBatch(c.fixed, c.defs, c.reps)
+	default:
+		return fmt.Errorf("parquet: unsupported type %s of column %s", c.column.Type, c.column.Name)
+	}
+	return err
+}
+
+// Close writes the footer of the file, it doesn't close the underlying writer
+func (w *Writer) Close() error {
+	return w.writer.Close()
+}
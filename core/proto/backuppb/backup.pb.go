@@ -70,6 +70,10 @@ const (
 	BackupTaskStateCode_BACKUP_SUCCESS   BackupTaskStateCode = 2
 	BackupTaskStateCode_BACKUP_FAIL      BackupTaskStateCode = 3
 	BackupTaskStateCode_BACKUP_TIMEOUT   BackupTaskStateCode = 4
+	// paused by the pause api, can be resumed from the checkpoint
+	BackupTaskStateCode_BACKUP_PAUSED BackupTaskStateCode = 5
+	// some collections failed with allow_partial, the others are backed up
+	BackupTaskStateCode_BACKUP_PARTIAL BackupTaskStateCode = 6
 )
 
 var BackupTaskStateCode_name = map[int32]string{
@@ -78,6 +82,8 @@ var BackupTaskStateCode_name = map[int32]string{
 	2: "BACKUP_SUCCESS",
 	3: "BACKUP_FAIL",
 	4: "BACKUP_TIMEOUT",
+	5: "BACKUP_PAUSED",
+	6: "BACKUP_PARTIAL",
 }
 
 var BackupTaskStateCode_value = map[string]int32{
@@ -86,6 +92,8 @@ var BackupTaskStateCode_value = map[string]int32{
 	"BACKUP_SUCCESS":   2,
 	"BACKUP_FAIL":      3,
 	"BACKUP_TIMEOUT":   4,
+	"BACKUP_PAUSED":    5,
+	"BACKUP_PARTIAL":   6,
 }
 
 func (x BackupTaskStateCode) String() string {
@@ -96,6 +104,45 @@ func (BackupTaskStateCode) EnumDescriptor() ([]byte, []int) {
 	return fileDescriptor_65240d19de191688, []int{1}
 }
 
+type JobStateCode int32
+
+const (
+	JobStateCode_JOB_RUNNING JobStateCode = 0
+	JobStateCode_JOB_SUCCESS JobStateCode = 1
+	JobStateCode_JOB_FAIL    JobStateCode = 2
+	// cancel is requested, the job is stopping its workers
+	JobStateCode_JOB_CANCELING JobStateCode = 3
+	JobStateCode_JOB_CANCELED  JobStateCode = 4
+	// the server running the job stopped updating its record in backup storage, e.g. it crashed or restarted
+	JobStateCode_JOB_INTERRUPTED JobStateCode = 5
+)
+
+var JobStateCode_name = map[int32]string{
+	0: "JOB_RUNNING",
+	1: "JOB_SUCCESS",
+	2: "JOB_FAIL",
+	3: "JOB_CANCELING",
+	4: "JOB_CANCELED",
+	5: "JOB_INTERRUPTED",
+}
+
+var JobStateCode_value = map[string]int32{
+	"JOB_RUNNING":     0,
+	"JOB_SUCCESS":     1,
+	"JOB_FAIL":        2,
+	"JOB_CANCELING":   3,
+	"JOB_CANCELED":    4,
+	"JOB_INTERRUPTED": 5,
+}
+
+func (x JobStateCode) String() string {
+	return proto.EnumName(JobStateCode_name, int32(x))
+}
+
+func (JobStateCode) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{2}
+}
+
 type RestoreTaskStateCode int32
 
 const (
@@ -127,7 +174,7 @@ func (x RestoreTaskStateCode) String() string {
 }
 
 func (RestoreTaskStateCode) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{2}
+	return fileDescriptor_65240d19de191688, []int{3}
 }
 
 type ConsistencyLevel int32
@@ -161,7 +208,7 @@ func (x ConsistencyLevel) String() string {
 }
 
 func (ConsistencyLevel) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{3}
+	return fileDescriptor_65240d19de191688, []int{4}
 }
 
 //*
@@ -169,20 +216,21 @@ func (ConsistencyLevel) EnumDescriptor() ([]byte, []int) {
 type DataType int32
 
 const (
-	DataType_None         DataType = 0
-	DataType_Bool         DataType = 1
-	DataType_Int8         DataType = 2
-	DataType_Int16        DataType = 3
-	DataType_Int32        DataType = 4
-	DataType_Int64        DataType = 5
-	DataType_Float        DataType = 10
-	DataType_Double       DataType = 11
-	DataType_String       DataType = 20
-	DataType_VarChar      DataType = 21
-	DataType_Array        DataType = 22
-	DataType_Json         DataType = 23
-	DataType_BinaryVector DataType = 100
-	DataType_FloatVector  DataType = 101
+	DataType_None              DataType = 0
+	DataType_Bool              DataType = 1
+	DataType_Int8              DataType = 2
+	DataType_Int16             DataType = 3
+	DataType_Int32             DataType = 4
+	DataType_Int64             DataType = 5
+	DataType_Float             DataType = 10
+	DataType_Double            DataType = 11
+	DataType_String            DataType = 20
+	DataType_VarChar           DataType = 21
+	DataType_Array             DataType = 22
+	DataType_Json              DataType = 23
+	DataType_BinaryVector      DataType = 100
+	DataType_FloatVector       DataType = 101
+	DataType_SparseFloatVector DataType = 104
 )
 
 var DataType_name = map[int32]string{
@@ -200,23 +248,25 @@ var DataType_name = map[int32]string{
 	23:  "Json",
 	100: "BinaryVector",
 	101: "FloatVector",
+	104: "SparseFloatVector",
 }
 
 var DataType_value = map[string]int32{
-	"None":         0,
-	"Bool":         1,
-	"Int8":         2,
-	"Int16":        3,
-	"Int32":        4,
-	"Int64":        5,
-	"Float":        10,
-	"Double":       11,
-	"String":       20,
-	"VarChar":      21,
-	"Array":        22,
-	"Json":         23,
-	"BinaryVector": 100,
-	"FloatVector":  101,
+	"None":              0,
+	"Bool":              1,
+	"Int8":              2,
+	"Int16":             3,
+	"Int32":             4,
+	"Int64":             5,
+	"Float":             10,
+	"Double":            11,
+	"String":            20,
+	"VarChar":           21,
+	"Array":             22,
+	"Json":              23,
+	"BinaryVector":      100,
+	"FloatVector":       101,
+	"SparseFloatVector": 104,
 }
 
 func (x DataType) String() string {
@@ -224,7 +274,7 @@ func (x DataType) String() string {
 }
 
 func (DataType) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{4}
+	return fileDescriptor_65240d19de191688, []int{5}
 }
 
 type FieldState int32
@@ -255,17 +305,47 @@ func (x FieldState) String() string {
 }
 
 func (FieldState) EnumDescriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{5}
+	return fileDescriptor_65240d19de191688, []int{6}
+}
+
+type FunctionType int32
+
+const (
+	FunctionType_FunctionUnknown FunctionType = 0
+	FunctionType_BM25            FunctionType = 1
+	FunctionType_TextEmbedding   FunctionType = 2
+)
+
+var FunctionType_name = map[int32]string{
+	0: "FunctionUnknown",
+	1: "BM25",
+	2: "TextEmbedding",
+}
+
+var FunctionType_value = map[string]int32{
+	"FunctionUnknown": 0,
+	"BM25":            1,
+	"TextEmbedding":   2,
+}
+
+func (x FunctionType) String() string {
+	return proto.EnumName(FunctionType_name, int32(x))
+}
+
+func (FunctionType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{7}
 }
 
 type IndexInfo struct {
-	FieldName            string            `protobuf:"bytes,1,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"`
-	IndexName            string            `protobuf:"bytes,2,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
-	IndexType            string            `protobuf:"bytes,3,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`
-	Params               map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+	FieldName string            `protobuf:"bytes,1,opt,name=field_name,json=fieldName,proto3" json:"field_name,omitempty"`
+	IndexName string            `protobuf:"bytes,2,opt,name=index_name,json=indexName,proto3" json:"index_name,omitempty"`
+	IndexType string            `protobuf:"bytes,3,opt,name=index_type,json=indexType,proto3" json:"index_type,omitempty"`
+	Params    map[string]string `protobuf:"bytes,4,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// if true the index is memory mapped, mmap.enabled in params
+	MmapEnabled          bool     `protobuf:"varint,5,opt,name=mmap_enabled,json=mmapEnabled,proto3" json:"mmap_enabled,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *IndexInfo) Reset()         { *m = IndexInfo{} }
@@ -321,6 +401,13 @@ func (m *IndexInfo) GetParams() map[string]string {
 	return nil
 }
 
+func (m *IndexInfo) GetMmapEnabled() bool {
+	if m != nil {
+		return m.MmapEnabled
+	}
+	return false
+}
+
 //*
 // lite version of Collection info
 type CollectionBackupInfo struct {
@@ -344,10 +431,34 @@ type CollectionBackupInfo struct {
 	IndexInfos      []*IndexInfo `protobuf:"bytes,17,rep,name=index_infos,json=indexInfos,proto3" json:"index_infos,omitempty"`
 	LoadState       string       `protobuf:"bytes,18,opt,name=load_state,json=loadState,proto3" json:"load_state,omitempty"`
 	// physical unix time of backup
-	BackupPhysicalTimestamp uint64   `protobuf:"varint,19,opt,name=backup_physical_timestamp,json=backupPhysicalTimestamp,proto3" json:"backup_physical_timestamp,omitempty"`
-	XXX_NoUnkeyedLiteral    struct{} `json:"-"`
-	XXX_unrecognized        []byte   `json:"-"`
-	XXX_sizecache           int32    `json:"-"`
+	BackupPhysicalTimestamp uint64 `protobuf:"varint,19,opt,name=backup_physical_timestamp,json=backupPhysicalTimestamp,proto3" json:"backup_physical_timestamp,omitempty"`
+	// bytes of binlogs copied into backup, progress is the percentage of it in size
+	CopiedSize int64 `protobuf:"varint,20,opt,name=copied_size,json=copiedSize,proto3" json:"copied_size,omitempty"`
+	// aliases pointing at the collection when backed up
+	Aliases []string `protobuf:"bytes,21,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	// properties of the collection, like collection.ttl.seconds and mmap.enabled
+	Properties []*KeyValuePair `protobuf:"bytes,22,rep,name=properties,proto3" json:"properties,omitempty"`
+	// number of replicas and their resource groups if the collection is loaded
+	ReplicaNumber  int32    `protobuf:"varint,23,opt,name=replica_number,json=replicaNumber,proto3" json:"replica_number,omitempty"`
+	ResourceGroups []string `protobuf:"bytes,24,rep,name=resource_groups,json=resourceGroups,proto3" json:"resource_groups,omitempty"`
+	// flushed, skipped or timeout, how the collection is flushed before backup
+	FlushState string `protobuf:"bytes,25,opt,name=flush_state,json=flushState,proto3" json:"flush_state,omitempty"`
+	// unix time in milliseconds the flush starts and ends, the data inserted before the start is in backup if flushed
+	FlushStartTime int64 `protobuf:"varint,26,opt,name=flush_start_time,json=flushStartTime,proto3" json:"flush_start_time,omitempty"`
+	FlushEndTime   int64 `protobuf:"varint,27,opt,name=flush_end_time,json=flushEndTime,proto3" json:"flush_end_time,omitempty"`
+	// rows of the partitions in milvus compared with the rows in backup, only if check_row_count is requested
+	RowCountChecks []*RowCountCheck `protobuf:"bytes,28,rep,name=row_count_checks,json=rowCountChecks,proto3" json:"row_count_checks,omitempty"`
+	// rows sampled by sample_rows of the request, stored in meta/row_samples to verify the restored collections
+	SampledRows int32 `protobuf:"varint,29,opt,name=sampled_rows,json=sampledRows,proto3" json:"sampled_rows,omitempty"`
+	// binlogs of the collection in the bucket of milvus compared with its persistent segments, only if scan_binlogs is
+	// requested
+	BinlogScan *BinlogScan `protobuf:"bytes,30,opt,name=binlog_scan,json=binlogScan,proto3" json:"binlog_scan,omitempty"`
+	// template of the dirs of the segment groups of the collection in backup by minio.backupPathTemplate, the binlogs
+	// referenced from base backups are in the same dirs of their backups, empty for the default layout
+	BackupPathTemplate   string   `protobuf:"bytes,31,opt,name=backup_path_template,json=backupPathTemplate,proto3" json:"backup_path_template,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *CollectionBackupInfo) Reset()         { *m = CollectionBackupInfo{} }
@@ -508,6 +619,288 @@ func (m *CollectionBackupInfo) GetBackupPhysicalTimestamp() uint64 {
 	return 0
 }
 
+func (m *CollectionBackupInfo) GetCopiedSize() int64 {
+	if m != nil {
+		return m.CopiedSize
+	}
+	return 0
+}
+
+func (m *CollectionBackupInfo) GetAliases() []string {
+	if m != nil {
+		return m.Aliases
+	}
+	return nil
+}
+
+func (m *CollectionBackupInfo) GetProperties() []*KeyValuePair {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *CollectionBackupInfo) GetReplicaNumber() int32 {
+	if m != nil {
+		return m.ReplicaNumber
+	}
+	return 0
+}
+
+func (m *CollectionBackupInfo) GetResourceGroups() []string {
+	if m != nil {
+		return m.ResourceGroups
+	}
+	return nil
+}
+
+func (m *CollectionBackupInfo) GetFlushState() string {
+	if m != nil {
+		return m.FlushState
+	}
+	return ""
+}
+
+func (m *CollectionBackupInfo) GetFlushStartTime() int64 {
+	if m != nil {
+		return m.FlushStartTime
+	}
+	return 0
+}
+
+func (m *CollectionBackupInfo) GetFlushEndTime() int64 {
+	if m != nil {
+		return m.FlushEndTime
+	}
+	return 0
+}
+
+func (m *CollectionBackupInfo) GetRowCountChecks() []*RowCountCheck {
+	if m != nil {
+		return m.RowCountChecks
+	}
+	return nil
+}
+
+func (m *CollectionBackupInfo) GetSampledRows() int32 {
+	if m != nil {
+		return m.SampledRows
+	}
+	return 0
+}
+
+func (m *CollectionBackupInfo) GetBinlogScan() *BinlogScan {
+	if m != nil {
+		return m.BinlogScan
+	}
+	return nil
+}
+
+func (m *CollectionBackupInfo) GetBackupPathTemplate() string {
+	if m != nil {
+		return m.BackupPathTemplate
+	}
+	return ""
+}
+
+// BinlogScan reports the binlogs of a collection in the bucket of milvus not referenced by any persistent segment,
+// like the leftovers of compaction and dropped segments not collected yet, and the segments without binlogs
+type BinlogScan struct {
+	// scanned or skipped
+	State string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	// why the binlogs are not scanned
+	Detail string `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+	// insert and delta logs of the persistent segments
+	ReferencedFiles int64 `protobuf:"varint,3,opt,name=referenced_files,json=referencedFiles,proto3" json:"referenced_files,omitempty"`
+	ReferencedSize  int64 `protobuf:"varint,4,opt,name=referenced_size,json=referencedSize,proto3" json:"referenced_size,omitempty"`
+	// insert and delta logs of segments which are not persistent
+	OrphanFiles    int64 `protobuf:"varint,5,opt,name=orphan_files,json=orphanFiles,proto3" json:"orphan_files,omitempty"`
+	OrphanSize     int64 `protobuf:"varint,6,opt,name=orphan_size,json=orphanSize,proto3" json:"orphan_size,omitempty"`
+	OrphanSegments int64 `protobuf:"varint,7,opt,name=orphan_segments,json=orphanSegments,proto3" json:"orphan_segments,omitempty"`
+	// ids of the first 100 orphan segments
+	OrphanSegmentIds []int64 `protobuf:"varint,8,rep,packed,name=orphan_segment_ids,json=orphanSegmentIds,proto3" json:"orphan_segment_ids,omitempty"`
+	// persistent segments without insert logs in the bucket
+	MissingSegmentIds    []int64  `protobuf:"varint,9,rep,packed,name=missing_segment_ids,json=missingSegmentIds,proto3" json:"missing_segment_ids,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BinlogScan) Reset()         { *m = BinlogScan{} }
+func (m *BinlogScan) String() string { return proto.CompactTextString(m) }
+func (*BinlogScan) ProtoMessage()    {}
+func (*BinlogScan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{2}
+}
+
+func (m *BinlogScan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BinlogScan.Unmarshal(m, b)
+}
+func (m *BinlogScan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BinlogScan.Marshal(b, m, deterministic)
+}
+func (m *BinlogScan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BinlogScan.Merge(m, src)
+}
+func (m *BinlogScan) XXX_Size() int {
+	return xxx_messageInfo_BinlogScan.Size(m)
+}
+func (m *BinlogScan) XXX_DiscardUnknown() {
+	xxx_messageInfo_BinlogScan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BinlogScan proto.InternalMessageInfo
+
+func (m *BinlogScan) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *BinlogScan) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+func (m *BinlogScan) GetReferencedFiles() int64 {
+	if m != nil {
+		return m.ReferencedFiles
+	}
+	return 0
+}
+
+func (m *BinlogScan) GetReferencedSize() int64 {
+	if m != nil {
+		return m.ReferencedSize
+	}
+	return 0
+}
+
+func (m *BinlogScan) GetOrphanFiles() int64 {
+	if m != nil {
+		return m.OrphanFiles
+	}
+	return 0
+}
+
+func (m *BinlogScan) GetOrphanSize() int64 {
+	if m != nil {
+		return m.OrphanSize
+	}
+	return 0
+}
+
+func (m *BinlogScan) GetOrphanSegments() int64 {
+	if m != nil {
+		return m.OrphanSegments
+	}
+	return 0
+}
+
+func (m *BinlogScan) GetOrphanSegmentIds() []int64 {
+	if m != nil {
+		return m.OrphanSegmentIds
+	}
+	return nil
+}
+
+func (m *BinlogScan) GetMissingSegmentIds() []int64 {
+	if m != nil {
+		return m.MissingSegmentIds
+	}
+	return nil
+}
+
+// RowCountCheck compares the rows of a partition in milvus with the rows of its segments in backup
+type RowCountCheck struct {
+	// partition of the collection, empty for the whole collection, like the partitions of partition key collections
+	// restored together
+	PartitionName string `protobuf:"bytes,1,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
+	// rows of the segments whose binlogs are copied into backup
+	BackupRows int64 `protobuf:"varint,2,opt,name=backup_rows,json=backupRows,proto3" json:"backup_rows,omitempty"`
+	// rows in the statistics of milvus, right after the flush of backup or after the data is restored
+	MilvusRows int64 `protobuf:"varint,3,opt,name=milvus_rows,json=milvusRows,proto3" json:"milvus_rows,omitempty"`
+	// rows of the target partition before restore, milvus_rows is expected to be base_rows + backup_rows after restore
+	BaseRows int64 `protobuf:"varint,4,opt,name=base_rows,json=baseRows,proto3" json:"base_rows,omitempty"`
+	// matched, mismatched or skipped
+	State string `protobuf:"bytes,5,opt,name=state,proto3" json:"state,omitempty"`
+	// the discrepancy, or why the rows are not compared
+	Detail               string   `protobuf:"bytes,6,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RowCountCheck) Reset()         { *m = RowCountCheck{} }
+func (m *RowCountCheck) String() string { return proto.CompactTextString(m) }
+func (*RowCountCheck) ProtoMessage()    {}
+func (*RowCountCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{3}
+}
+
+func (m *RowCountCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RowCountCheck.Unmarshal(m, b)
+}
+func (m *RowCountCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RowCountCheck.Marshal(b, m, deterministic)
+}
+func (m *RowCountCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RowCountCheck.Merge(m, src)
+}
+func (m *RowCountCheck) XXX_Size() int {
+	return xxx_messageInfo_RowCountCheck.Size(m)
+}
+func (m *RowCountCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_RowCountCheck.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RowCountCheck proto.InternalMessageInfo
+
+func (m *RowCountCheck) GetPartitionName() string {
+	if m != nil {
+		return m.PartitionName
+	}
+	return ""
+}
+
+func (m *RowCountCheck) GetBackupRows() int64 {
+	if m != nil {
+		return m.BackupRows
+	}
+	return 0
+}
+
+func (m *RowCountCheck) GetMilvusRows() int64 {
+	if m != nil {
+		return m.MilvusRows
+	}
+	return 0
+}
+
+func (m *RowCountCheck) GetBaseRows() int64 {
+	if m != nil {
+		return m.BaseRows
+	}
+	return 0
+}
+
+func (m *RowCountCheck) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *RowCountCheck) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
 type PartitionBackupInfo struct {
 	PartitionId   int64  `protobuf:"varint,1,opt,name=partition_id,json=partitionId,proto3" json:"partition_id,omitempty"`
 	PartitionName string `protobuf:"bytes,2,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
@@ -525,7 +918,7 @@ func (m *PartitionBackupInfo) Reset()         { *m = PartitionBackupInfo{} }
 func (m *PartitionBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*PartitionBackupInfo) ProtoMessage()    {}
 func (*PartitionBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{2}
+	return fileDescriptor_65240d19de191688, []int{4}
 }
 
 func (m *PartitionBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -602,7 +995,16 @@ type SegmentBackupInfo struct {
 	// separate segments into multi groups by size,
 	// segments in one group will be copied into one directory during backup
 	// and will bulkinsert in one call during restore
-	GroupId              int64    `protobuf:"varint,9,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	GroupId int64 `protobuf:"varint,9,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// name of the backup which actually stores the binlogs of this segment,
+	// empty means the binlogs are stored in current backup. Set by incremental backup
+	RefBackupName string `protobuf:"bytes,10,opt,name=ref_backup_name,json=refBackupName,proto3" json:"ref_backup_name,omitempty"`
+	// compression algorithm of the binlogs in backup, empty means not compressed
+	Compression string `protobuf:"bytes,11,opt,name=compression,proto3" json:"compression,omitempty"`
+	// the binlogs in backup are encrypted with AES-256-GCM
+	Encrypted bool `protobuf:"varint,12,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	// all binlogs of the segment have been copied into backup, used to resume an interrupted backup
+	Backuped             bool     `protobuf:"varint,13,opt,name=backuped,proto3" json:"backuped,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -612,7 +1014,7 @@ func (m *SegmentBackupInfo) Reset()         { *m = SegmentBackupInfo{} }
 func (m *SegmentBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*SegmentBackupInfo) ProtoMessage()    {}
 func (*SegmentBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{3}
+	return fileDescriptor_65240d19de191688, []int{5}
 }
 
 func (m *SegmentBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -696,6 +1098,34 @@ func (m *SegmentBackupInfo) GetGroupId() int64 {
 	return 0
 }
 
+func (m *SegmentBackupInfo) GetRefBackupName() string {
+	if m != nil {
+		return m.RefBackupName
+	}
+	return ""
+}
+
+func (m *SegmentBackupInfo) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *SegmentBackupInfo) GetEncrypted() bool {
+	if m != nil {
+		return m.Encrypted
+	}
+	return false
+}
+
+func (m *SegmentBackupInfo) GetBackuped() bool {
+	if m != nil {
+		return m.Backuped
+	}
+	return false
+}
+
 //*
 // root of backup
 type BackupInfo struct {
@@ -709,19 +1139,45 @@ type BackupInfo struct {
 	// backup timestamp
 	BackupTimestamp uint64 `protobuf:"varint,8,opt,name=backup_timestamp,json=backupTimestamp,proto3" json:"backup_timestamp,omitempty"`
 	// array of collection backup
-	CollectionBackups    []*CollectionBackupInfo `protobuf:"bytes,9,rep,name=collection_backups,json=collectionBackups,proto3" json:"collection_backups,omitempty"`
-	Size                 int64                   `protobuf:"varint,10,opt,name=size,proto3" json:"size,omitempty"`
-	MilvusVersion        string                  `protobuf:"bytes,11,opt,name=milvus_version,json=milvusVersion,proto3" json:"milvus_version,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
+	CollectionBackups []*CollectionBackupInfo `protobuf:"bytes,9,rep,name=collection_backups,json=collectionBackups,proto3" json:"collection_backups,omitempty"`
+	Size              int64                   `protobuf:"varint,10,opt,name=size,proto3" json:"size,omitempty"`
+	MilvusVersion     string                  `protobuf:"bytes,11,opt,name=milvus_version,json=milvusVersion,proto3" json:"milvus_version,omitempty"`
+	// base backup name of an incremental backup, empty means it is a full backup
+	BaseBackupName string `protobuf:"bytes,12,opt,name=base_backup_name,json=baseBackupName,proto3" json:"base_backup_name,omitempty"`
+	// compression algorithm of the backup data, empty means not compressed
+	Compression string `protobuf:"bytes,13,opt,name=compression,proto3" json:"compression,omitempty"`
+	// backup files are encrypted with AES-256-GCM
+	Encrypted bool `protobuf:"varint,14,opt,name=encrypted,proto3" json:"encrypted,omitempty"`
+	// users, roles and grants, only backed up if requested
+	RbacMeta *RBACMeta `protobuf:"bytes,15,opt,name=rbac_meta,json=rbacMeta,proto3" json:"rbac_meta,omitempty"`
+	// bytes of binlogs copied into backup, progress is the percentage of it in size
+	CopiedSize int64 `protobuf:"varint,16,opt,name=copied_size,json=copiedSize,proto3" json:"copied_size,omitempty"`
+	// s3 object lock mode of the backup files, GOVERNANCE or COMPLIANCE, empty means not locked
+	ObjectLockMode string `protobuf:"bytes,17,opt,name=object_lock_mode,json=objectLockMode,proto3" json:"object_lock_mode,omitempty"`
+	// unix seconds until when the backup files can't be deleted or overwritten
+	ObjectLockRetainUntil int64 `protobuf:"varint,18,opt,name=object_lock_retain_until,json=objectLockRetainUntil,proto3" json:"object_lock_retain_until,omitempty"`
+	// server side encryption of the backup files, kms or customer, empty means the default of the bucket
+	SseType string `protobuf:"bytes,19,opt,name=sse_type,json=sseType,proto3" json:"sse_type,omitempty"`
+	// kms key encrypting the backup files, empty means the aws managed key
+	SseKmsKeyId string `protobuf:"bytes,20,opt,name=sse_kms_key_id,json=sseKmsKeyId,proto3" json:"sse_kms_key_id,omitempty"`
+	// base64 encoded MD5 of the customer key encrypting the binlogs, the key itself is never stored
+	SseCustomerKeyMd5 string `protobuf:"bytes,21,opt,name=sse_customer_key_md5,json=sseCustomerKeyMd5,proto3" json:"sse_customer_key_md5,omitempty"`
+	// version of the layout of the meta files, 0 means written before the version is recorded
+	MetaVersion int32 `protobuf:"varint,22,opt,name=meta_version,json=metaVersion,proto3" json:"meta_version,omitempty"`
+	// key/value labels attached at creation, like env=prod, used to select backups by label selectors
+	Labels map[string]string `protobuf:"bytes,23,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// the etcd meta snapshot of the collections, only taken if requested
+	EtcdSnapshot         *EtcdSnapshotInfo `protobuf:"bytes,24,opt,name=etcd_snapshot,json=etcdSnapshot,proto3" json:"etcd_snapshot,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
 func (m *BackupInfo) Reset()         { *m = BackupInfo{} }
 func (m *BackupInfo) String() string { return proto.CompactTextString(m) }
 func (*BackupInfo) ProtoMessage()    {}
 func (*BackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{4}
+	return fileDescriptor_65240d19de191688, []int{6}
 }
 
 func (m *BackupInfo) XXX_Unmarshal(b []byte) error {
@@ -819,20 +1275,325 @@ func (m *BackupInfo) GetMilvusVersion() string {
 	return ""
 }
 
-//*
-// For level storage
-type CollectionLevelBackupInfo struct {
-	Infos                []*CollectionBackupInfo `protobuf:"bytes,1,rep,name=infos,proto3" json:"infos,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
-	XXX_unrecognized     []byte                  `json:"-"`
-	XXX_sizecache        int32                   `json:"-"`
-}
+func (m *BackupInfo) GetBaseBackupName() string {
+	if m != nil {
+		return m.BaseBackupName
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetEncrypted() bool {
+	if m != nil {
+		return m.Encrypted
+	}
+	return false
+}
+
+func (m *BackupInfo) GetRbacMeta() *RBACMeta {
+	if m != nil {
+		return m.RbacMeta
+	}
+	return nil
+}
+
+func (m *BackupInfo) GetCopiedSize() int64 {
+	if m != nil {
+		return m.CopiedSize
+	}
+	return 0
+}
+
+func (m *BackupInfo) GetObjectLockMode() string {
+	if m != nil {
+		return m.ObjectLockMode
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetObjectLockRetainUntil() int64 {
+	if m != nil {
+		return m.ObjectLockRetainUntil
+	}
+	return 0
+}
+
+func (m *BackupInfo) GetSseType() string {
+	if m != nil {
+		return m.SseType
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetSseKmsKeyId() string {
+	if m != nil {
+		return m.SseKmsKeyId
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetSseCustomerKeyMd5() string {
+	if m != nil {
+		return m.SseCustomerKeyMd5
+	}
+	return ""
+}
+
+func (m *BackupInfo) GetMetaVersion() int32 {
+	if m != nil {
+		return m.MetaVersion
+	}
+	return 0
+}
+
+func (m *BackupInfo) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *BackupInfo) GetEtcdSnapshot() *EtcdSnapshotInfo {
+	if m != nil {
+		return m.EtcdSnapshot
+	}
+	return nil
+}
+
+type RBACMeta struct {
+	Users                []*UserEntity  `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	Roles                []*RoleEntity  `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	Grants               []*GrantEntity `protobuf:"bytes,3,rep,name=grants,proto3" json:"grants,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RBACMeta) Reset()         { *m = RBACMeta{} }
+func (m *RBACMeta) String() string { return proto.CompactTextString(m) }
+func (*RBACMeta) ProtoMessage()    {}
+func (*RBACMeta) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{7}
+}
+
+func (m *RBACMeta) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RBACMeta.Unmarshal(m, b)
+}
+func (m *RBACMeta) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RBACMeta.Marshal(b, m, deterministic)
+}
+func (m *RBACMeta) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RBACMeta.Merge(m, src)
+}
+func (m *RBACMeta) XXX_Size() int {
+	return xxx_messageInfo_RBACMeta.Size(m)
+}
+func (m *RBACMeta) XXX_DiscardUnknown() {
+	xxx_messageInfo_RBACMeta.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RBACMeta proto.InternalMessageInfo
+
+func (m *RBACMeta) GetUsers() []*UserEntity {
+	if m != nil {
+		return m.Users
+	}
+	return nil
+}
+
+func (m *RBACMeta) GetRoles() []*RoleEntity {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+func (m *RBACMeta) GetGrants() []*GrantEntity {
+	if m != nil {
+		return m.Grants
+	}
+	return nil
+}
+
+type UserEntity struct {
+	User string `protobuf:"bytes,1,opt,name=user,proto3" json:"user,omitempty"`
+	// names of roles granted to the user
+	Roles                []string `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UserEntity) Reset()         { *m = UserEntity{} }
+func (m *UserEntity) String() string { return proto.CompactTextString(m) }
+func (*UserEntity) ProtoMessage()    {}
+func (*UserEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{8}
+}
+
+func (m *UserEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UserEntity.Unmarshal(m, b)
+}
+func (m *UserEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UserEntity.Marshal(b, m, deterministic)
+}
+func (m *UserEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UserEntity.Merge(m, src)
+}
+func (m *UserEntity) XXX_Size() int {
+	return xxx_messageInfo_UserEntity.Size(m)
+}
+func (m *UserEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_UserEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UserEntity proto.InternalMessageInfo
+
+func (m *UserEntity) GetUser() string {
+	if m != nil {
+		return m.User
+	}
+	return ""
+}
+
+func (m *UserEntity) GetRoles() []string {
+	if m != nil {
+		return m.Roles
+	}
+	return nil
+}
+
+type RoleEntity struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RoleEntity) Reset()         { *m = RoleEntity{} }
+func (m *RoleEntity) String() string { return proto.CompactTextString(m) }
+func (*RoleEntity) ProtoMessage()    {}
+func (*RoleEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{9}
+}
+
+func (m *RoleEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RoleEntity.Unmarshal(m, b)
+}
+func (m *RoleEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RoleEntity.Marshal(b, m, deterministic)
+}
+func (m *RoleEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RoleEntity.Merge(m, src)
+}
+func (m *RoleEntity) XXX_Size() int {
+	return xxx_messageInfo_RoleEntity.Size(m)
+}
+func (m *RoleEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_RoleEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RoleEntity proto.InternalMessageInfo
+
+func (m *RoleEntity) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type GrantEntity struct {
+	Role string `protobuf:"bytes,1,opt,name=role,proto3" json:"role,omitempty"`
+	// object type, such as Global, Collection and User
+	Object               string   `protobuf:"bytes,2,opt,name=object,proto3" json:"object,omitempty"`
+	ObjectName           string   `protobuf:"bytes,3,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
+	Privilege            string   `protobuf:"bytes,4,opt,name=privilege,proto3" json:"privilege,omitempty"`
+	DbName               string   `protobuf:"bytes,5,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GrantEntity) Reset()         { *m = GrantEntity{} }
+func (m *GrantEntity) String() string { return proto.CompactTextString(m) }
+func (*GrantEntity) ProtoMessage()    {}
+func (*GrantEntity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{10}
+}
+
+func (m *GrantEntity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GrantEntity.Unmarshal(m, b)
+}
+func (m *GrantEntity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GrantEntity.Marshal(b, m, deterministic)
+}
+func (m *GrantEntity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GrantEntity.Merge(m, src)
+}
+func (m *GrantEntity) XXX_Size() int {
+	return xxx_messageInfo_GrantEntity.Size(m)
+}
+func (m *GrantEntity) XXX_DiscardUnknown() {
+	xxx_messageInfo_GrantEntity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GrantEntity proto.InternalMessageInfo
+
+func (m *GrantEntity) GetRole() string {
+	if m != nil {
+		return m.Role
+	}
+	return ""
+}
+
+func (m *GrantEntity) GetObject() string {
+	if m != nil {
+		return m.Object
+	}
+	return ""
+}
+
+func (m *GrantEntity) GetObjectName() string {
+	if m != nil {
+		return m.ObjectName
+	}
+	return ""
+}
+
+func (m *GrantEntity) GetPrivilege() string {
+	if m != nil {
+		return m.Privilege
+	}
+	return ""
+}
+
+func (m *GrantEntity) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+//*
+// For level storage
+type CollectionLevelBackupInfo struct {
+	Infos                []*CollectionBackupInfo `protobuf:"bytes,1,rep,name=infos,proto3" json:"infos,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
 
 func (m *CollectionLevelBackupInfo) Reset()         { *m = CollectionLevelBackupInfo{} }
 func (m *CollectionLevelBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*CollectionLevelBackupInfo) ProtoMessage()    {}
 func (*CollectionLevelBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{5}
+	return fileDescriptor_65240d19de191688, []int{11}
 }
 
 func (m *CollectionLevelBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -873,7 +1634,7 @@ func (m *PartitionLevelBackupInfo) Reset()         { *m = PartitionLevelBackupIn
 func (m *PartitionLevelBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*PartitionLevelBackupInfo) ProtoMessage()    {}
 func (*PartitionLevelBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{6}
+	return fileDescriptor_65240d19de191688, []int{12}
 }
 
 func (m *PartitionLevelBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -914,7 +1675,7 @@ func (m *SegmentLevelBackupInfo) Reset()         { *m = SegmentLevelBackupInfo{}
 func (m *SegmentLevelBackupInfo) String() string { return proto.CompactTextString(m) }
 func (*SegmentLevelBackupInfo) ProtoMessage()    {}
 func (*SegmentLevelBackupInfo) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{7}
+	return fileDescriptor_65240d19de191688, []int{13}
 }
 
 func (m *SegmentLevelBackupInfo) XXX_Unmarshal(b []byte) error {
@@ -944,12 +1705,51 @@ func (m *SegmentLevelBackupInfo) GetInfos() []*SegmentBackupInfo {
 
 //*
 // Create Backup in milvus
+type PartitionNames struct {
+	Names                []string `protobuf:"bytes,1,rep,name=names,proto3" json:"names,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PartitionNames) Reset()         { *m = PartitionNames{} }
+func (m *PartitionNames) String() string { return proto.CompactTextString(m) }
+func (*PartitionNames) ProtoMessage()    {}
+func (*PartitionNames) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{14}
+}
+
+func (m *PartitionNames) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PartitionNames.Unmarshal(m, b)
+}
+func (m *PartitionNames) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PartitionNames.Marshal(b, m, deterministic)
+}
+func (m *PartitionNames) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PartitionNames.Merge(m, src)
+}
+func (m *PartitionNames) XXX_Size() int {
+	return xxx_messageInfo_PartitionNames.Size(m)
+}
+func (m *PartitionNames) XXX_DiscardUnknown() {
+	xxx_messageInfo_PartitionNames.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PartitionNames proto.InternalMessageInfo
+
+func (m *PartitionNames) GetNames() []string {
+	if m != nil {
+		return m.Names
+	}
+	return nil
+}
+
 type CreateBackupRequest struct {
 	// uuid of request, will generate one if not set
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
 	// backup name, will generate one if not set
 	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
-	// collection names to backup, empty to backup all
+	// collection names to backup, empty to backup all, support wildcard patterns like prod_*
 	CollectionNames []string `protobuf:"bytes,3,rep,name=collection_names,json=collectionNames,proto3" json:"collection_names,omitempty"`
 	// async or not
 	Async bool `protobuf:"varint,4,opt,name=async,proto3" json:"async,omitempty"`
@@ -958,7 +1758,60 @@ type CreateBackupRequest struct {
 	// force backup skip flush, Should make sure data has been stored into disk when using it
 	Force bool `protobuf:"varint,6,opt,name=force,proto3" json:"force,omitempty"`
 	// only backup meta, including collection schema and index info
-	MetaOnly             bool     `protobuf:"varint,7,opt,name=meta_only,json=metaOnly,proto3" json:"meta_only,omitempty"`
+	MetaOnly bool `protobuf:"varint,7,opt,name=meta_only,json=metaOnly,proto3" json:"meta_only,omitempty"`
+	// incremental backup, only copy segments added or changed since the base backup
+	Incremental bool `protobuf:"varint,8,opt,name=incremental,proto3" json:"incremental,omitempty"`
+	// base backup of incremental backup, required if incremental is true
+	BaseBackupName string `protobuf:"bytes,9,opt,name=base_backup_name,json=baseBackupName,proto3" json:"base_backup_name,omitempty"`
+	// compress binlogs while copying, support zstd and gzip. if not set, use backup.compression in config
+	Compression string `protobuf:"bytes,10,opt,name=compression,proto3" json:"compression,omitempty"`
+	// resume an interrupted backup with the backup_name from its checkpoint, segments copied will be skipped
+	Resume bool `protobuf:"varint,11,opt,name=resume,proto3" json:"resume,omitempty"`
+	// collection level parallelism of this backup, 0 means backup.parallelism.backupCollection in config
+	CollectionParallelism int32 `protobuf:"varint,12,opt,name=collection_parallelism,json=collectionParallelism,proto3" json:"collection_parallelism,omitempty"`
+	// parallelism to copy data of this backup, 0 means backup.parallelism.copydata in config
+	CopyParallelism int32 `protobuf:"varint,13,opt,name=copy_parallelism,json=copyParallelism,proto3" json:"copy_parallelism,omitempty"`
+	// backup users, roles and grants as well
+	Rbac bool `protobuf:"varint,14,opt,name=rbac,proto3" json:"rbac,omitempty"`
+	// backup collections of all databases whose names match the regex, in addition to collection_names
+	CollectionRegex string `protobuf:"bytes,15,opt,name=collection_regex,json=collectionRegex,proto3" json:"collection_regex,omitempty"`
+	// partitions to backup, key is collection name or db.collection_name, collections not in it backup all partitions.
+	// only the collections in it are backed up if collection_names, db_collections and collection_regex are not set
+	Partitions map[string]*PartitionNames `protobuf:"bytes,16,rep,name=partitions,proto3" json:"partitions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// server side encryption of the backup files, kms, customer or none. if not set, use backup.serverSideEncryption in config
+	SseType string `protobuf:"bytes,17,opt,name=sse_type,json=sseType,proto3" json:"sse_type,omitempty"`
+	// kms key of sse type kms, key id or arn of aws kms, or resource name of cloud kms key for gcs
+	SseKmsKeyId string `protobuf:"bytes,18,opt,name=sse_kms_key_id,json=sseKmsKeyId,proto3" json:"sse_kms_key_id,omitempty"`
+	// customer key of sse type customer, base64 or hex encoded 32 bytes, or reference like env:NAME and file:PATH
+	SseCustomerKey string `protobuf:"bytes,19,opt,name=sse_customer_key,json=sseCustomerKey,proto3" json:"sse_customer_key,omitempty"`
+	// how to flush collections before backup, wait, skip or timeout.
+	// wait: flush and wait until the data is persisted, the default.
+	// skip: don't flush, only the data already persisted is backed up, the same as force.
+	// timeout: flush and wait at most flush_timeout seconds, the data already persisted is backed up if it times out.
+	FlushPolicy string `protobuf:"bytes,20,opt,name=flush_policy,json=flushPolicy,proto3" json:"flush_policy,omitempty"`
+	// seconds to wait for the flush of each collection with flush policy timeout
+	FlushTimeout int32 `protobuf:"varint,21,opt,name=flush_timeout,json=flushTimeout,proto3" json:"flush_timeout,omitempty"`
+	// a failed collection doesn't fail the others, the backup is partial if only some collections fail
+	AllowPartial bool `protobuf:"varint,22,opt,name=allow_partial,json=allowPartial,proto3" json:"allow_partial,omitempty"`
+	// template of the backup name generated if backup_name is not set, support {{date}}, {{time}} and {{cluster}},
+	// backup.nameTemplate in config is used if not set
+	NameTemplate string `protobuf:"bytes,23,opt,name=name_template,json=nameTemplate,proto3" json:"name_template,omitempty"`
+	// key/value labels attached to the backup, like env=prod and app=search
+	Labels map[string]string `protobuf:"bytes,24,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// take over the locks of the cluster and the backup name held by another process, only use it if the process
+	// holding them is known to be gone
+	ForceUnlock bool `protobuf:"varint,25,opt,name=force_unlock,json=forceUnlock,proto3" json:"force_unlock,omitempty"`
+	// snapshot the etcd meta of the collections in milvus after they are flushed, requires etcd in config
+	EtcdSnapshot bool `protobuf:"varint,26,opt,name=etcd_snapshot,json=etcdSnapshot,proto3" json:"etcd_snapshot,omitempty"`
+	// compare the rows of each partition in milvus right after the flush with the rows of the segments backed up,
+	// discrepancies are recorded in row_count_checks of the collections
+	CheckRowCount bool `protobuf:"varint,27,opt,name=check_row_count,json=checkRowCount,proto3" json:"check_row_count,omitempty"`
+	// rows of each collection to sample right after the flush, stored in backup to verify the restored collections by
+	// verify_sample_rows of restore, only loaded collections can be sampled
+	SampleRows int32 `protobuf:"varint,28,opt,name=sample_rows,json=sampleRows,proto3" json:"sample_rows,omitempty"`
+	// scan the binlogs of each collection in the bucket of milvus before backup, report the ones not referenced by any
+	// persistent segment and the segments without binlogs in binlog_scan of the collections
+	ScanBinlogs          bool     `protobuf:"varint,29,opt,name=scan_binlogs,json=scanBinlogs,proto3" json:"scan_binlogs,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -968,7 +1821,7 @@ func (m *CreateBackupRequest) Reset()         { *m = CreateBackupRequest{} }
 func (m *CreateBackupRequest) String() string { return proto.CompactTextString(m) }
 func (*CreateBackupRequest) ProtoMessage()    {}
 func (*CreateBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{8}
+	return fileDescriptor_65240d19de191688, []int{15}
 }
 
 func (m *CreateBackupRequest) XXX_Unmarshal(b []byte) error {
@@ -1038,37 +1891,193 @@ func (m *CreateBackupRequest) GetMetaOnly() bool {
 	return false
 }
 
-//*
-// BackupInfoResponse
-type BackupInfoResponse struct {
-	// uuid of the request to response
-	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
-	// response code. 0 means success. others are fail
-	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
-	// error msg if fail
-	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
-	// backup info entity
-	Data                 *BackupInfo `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
-	XXX_unrecognized     []byte      `json:"-"`
-	XXX_sizecache        int32       `json:"-"`
+func (m *CreateBackupRequest) GetIncremental() bool {
+	if m != nil {
+		return m.Incremental
+	}
+	return false
 }
 
-func (m *BackupInfoResponse) Reset()         { *m = BackupInfoResponse{} }
-func (m *BackupInfoResponse) String() string { return proto.CompactTextString(m) }
-func (*BackupInfoResponse) ProtoMessage()    {}
-func (*BackupInfoResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{9}
+func (m *CreateBackupRequest) GetBaseBackupName() string {
+	if m != nil {
+		return m.BaseBackupName
+	}
+	return ""
 }
 
-func (m *BackupInfoResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_BackupInfoResponse.Unmarshal(m, b)
+func (m *CreateBackupRequest) GetCompression() string {
+	if m != nil {
+		return m.Compression
+	}
+	return ""
 }
-func (m *BackupInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_BackupInfoResponse.Marshal(b, m, deterministic)
+
+func (m *CreateBackupRequest) GetResume() bool {
+	if m != nil {
+		return m.Resume
+	}
+	return false
 }
-func (m *BackupInfoResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_BackupInfoResponse.Merge(m, src)
+
+func (m *CreateBackupRequest) GetCollectionParallelism() int32 {
+	if m != nil {
+		return m.CollectionParallelism
+	}
+	return 0
+}
+
+func (m *CreateBackupRequest) GetCopyParallelism() int32 {
+	if m != nil {
+		return m.CopyParallelism
+	}
+	return 0
+}
+
+func (m *CreateBackupRequest) GetRbac() bool {
+	if m != nil {
+		return m.Rbac
+	}
+	return false
+}
+
+func (m *CreateBackupRequest) GetCollectionRegex() string {
+	if m != nil {
+		return m.CollectionRegex
+	}
+	return ""
+}
+
+func (m *CreateBackupRequest) GetPartitions() map[string]*PartitionNames {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+func (m *CreateBackupRequest) GetSseType() string {
+	if m != nil {
+		return m.SseType
+	}
+	return ""
+}
+
+func (m *CreateBackupRequest) GetSseKmsKeyId() string {
+	if m != nil {
+		return m.SseKmsKeyId
+	}
+	return ""
+}
+
+func (m *CreateBackupRequest) GetSseCustomerKey() string {
+	if m != nil {
+		return m.SseCustomerKey
+	}
+	return ""
+}
+
+func (m *CreateBackupRequest) GetFlushPolicy() string {
+	if m != nil {
+		return m.FlushPolicy
+	}
+	return ""
+}
+
+func (m *CreateBackupRequest) GetFlushTimeout() int32 {
+	if m != nil {
+		return m.FlushTimeout
+	}
+	return 0
+}
+
+func (m *CreateBackupRequest) GetAllowPartial() bool {
+	if m != nil {
+		return m.AllowPartial
+	}
+	return false
+}
+
+func (m *CreateBackupRequest) GetNameTemplate() string {
+	if m != nil {
+		return m.NameTemplate
+	}
+	return ""
+}
+
+func (m *CreateBackupRequest) GetLabels() map[string]string {
+	if m != nil {
+		return m.Labels
+	}
+	return nil
+}
+
+func (m *CreateBackupRequest) GetForceUnlock() bool {
+	if m != nil {
+		return m.ForceUnlock
+	}
+	return false
+}
+
+func (m *CreateBackupRequest) GetEtcdSnapshot() bool {
+	if m != nil {
+		return m.EtcdSnapshot
+	}
+	return false
+}
+
+func (m *CreateBackupRequest) GetCheckRowCount() bool {
+	if m != nil {
+		return m.CheckRowCount
+	}
+	return false
+}
+
+func (m *CreateBackupRequest) GetSampleRows() int32 {
+	if m != nil {
+		return m.SampleRows
+	}
+	return 0
+}
+
+func (m *CreateBackupRequest) GetScanBinlogs() bool {
+	if m != nil {
+		return m.ScanBinlogs
+	}
+	return false
+}
+
+//*
+// BackupInfoResponse
+type BackupInfoResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// backup info entity
+	Data *BackupInfo `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// id of the job executing the backup, to query or cancel it by the job api
+	JobId                string   `protobuf:"bytes,5,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BackupInfoResponse) Reset()         { *m = BackupInfoResponse{} }
+func (m *BackupInfoResponse) String() string { return proto.CompactTextString(m) }
+func (*BackupInfoResponse) ProtoMessage()    {}
+func (*BackupInfoResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{16}
+}
+
+func (m *BackupInfoResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupInfoResponse.Unmarshal(m, b)
+}
+func (m *BackupInfoResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupInfoResponse.Marshal(b, m, deterministic)
+}
+func (m *BackupInfoResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupInfoResponse.Merge(m, src)
 }
 func (m *BackupInfoResponse) XXX_Size() int {
 	return xxx_messageInfo_BackupInfoResponse.Size(m)
@@ -1107,6 +2116,13 @@ func (m *BackupInfoResponse) GetData() *BackupInfo {
 	return nil
 }
 
+func (m *BackupInfoResponse) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
 type GetBackupRequest struct {
 	// uuid of request, will generate one if not set
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
@@ -1129,7 +2145,7 @@ func (m *GetBackupRequest) Reset()         { *m = GetBackupRequest{} }
 func (m *GetBackupRequest) String() string { return proto.CompactTextString(m) }
 func (*GetBackupRequest) ProtoMessage()    {}
 func (*GetBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{10}
+	return fileDescriptor_65240d19de191688, []int{17}
 }
 
 func (m *GetBackupRequest) XXX_Unmarshal(b []byte) error {
@@ -1196,7 +2212,27 @@ type ListBackupsRequest struct {
 	// uuid of request, will generate one if not set
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
 	// if collection_name is set, will only return backups contains this collection
-	CollectionName       string   `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	CollectionName string `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// if databases is set, will only return backups contains collections in these databases
+	Databases []string `protobuf:"bytes,3,rep,name=databases,proto3" json:"databases,omitempty"`
+	// only return backups started at or after this time, unix timestamp in seconds, 0 means no limit
+	StartTime int64 `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	// only return backups started before this time, unix timestamp in seconds, 0 means no limit
+	EndTime int64 `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// only return backups in these states, empty means all states
+	States []BackupTaskStateCode `protobuf:"varint,6,rep,packed,name=states,proto3,enum=milvus.proto.backup.BackupTaskStateCode" json:"states,omitempty"`
+	// sort backups by name, start_time or size, default is name
+	OrderBy string `protobuf:"bytes,7,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	// sort backups in descending order
+	Desc bool `protobuf:"varint,8,opt,name=desc,proto3" json:"desc,omitempty"`
+	// number of backups to skip after filtered and sorted
+	Offset int32 `protobuf:"varint,9,opt,name=offset,proto3" json:"offset,omitempty"`
+	// max number of backups to return, 0 means no limit
+	Limit int32 `protobuf:"varint,10,opt,name=limit,proto3" json:"limit,omitempty"`
+	// only return the summary of backups, like size, state, start and end time, without collection details
+	WithoutDetail bool `protobuf:"varint,11,opt,name=without_detail,json=withoutDetail,proto3" json:"without_detail,omitempty"`
+	// only return backups whose labels match the selector, like env=prod,app!=search, see label selectors in README
+	LabelSelector        string   `protobuf:"bytes,12,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1206,7 +2242,7 @@ func (m *ListBackupsRequest) Reset()         { *m = ListBackupsRequest{} }
 func (m *ListBackupsRequest) String() string { return proto.CompactTextString(m) }
 func (*ListBackupsRequest) ProtoMessage()    {}
 func (*ListBackupsRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{11}
+	return fileDescriptor_65240d19de191688, []int{18}
 }
 
 func (m *ListBackupsRequest) XXX_Unmarshal(b []byte) error {
@@ -1241,6 +2277,76 @@ func (m *ListBackupsRequest) GetCollectionName() string {
 	return ""
 }
 
+func (m *ListBackupsRequest) GetDatabases() []string {
+	if m != nil {
+		return m.Databases
+	}
+	return nil
+}
+
+func (m *ListBackupsRequest) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *ListBackupsRequest) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *ListBackupsRequest) GetStates() []BackupTaskStateCode {
+	if m != nil {
+		return m.States
+	}
+	return nil
+}
+
+func (m *ListBackupsRequest) GetOrderBy() string {
+	if m != nil {
+		return m.OrderBy
+	}
+	return ""
+}
+
+func (m *ListBackupsRequest) GetDesc() bool {
+	if m != nil {
+		return m.Desc
+	}
+	return false
+}
+
+func (m *ListBackupsRequest) GetOffset() int32 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *ListBackupsRequest) GetLimit() int32 {
+	if m != nil {
+		return m.Limit
+	}
+	return 0
+}
+
+func (m *ListBackupsRequest) GetWithoutDetail() bool {
+	if m != nil {
+		return m.WithoutDetail
+	}
+	return false
+}
+
+func (m *ListBackupsRequest) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
 type ListBackupsResponse struct {
 	// uuid of the request to response
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
@@ -1249,17 +2355,19 @@ type ListBackupsResponse struct {
 	// error msg if fail
 	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
 	// backup info entities
-	Data                 []*BackupInfo `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
-	XXX_unrecognized     []byte        `json:"-"`
-	XXX_sizecache        int32         `json:"-"`
+	Data []*BackupInfo `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
+	// number of backups matched before offset and limit applied
+	Total                int32    `protobuf:"varint,5,opt,name=total,proto3" json:"total,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
 func (m *ListBackupsResponse) Reset()         { *m = ListBackupsResponse{} }
 func (m *ListBackupsResponse) String() string { return proto.CompactTextString(m) }
 func (*ListBackupsResponse) ProtoMessage()    {}
 func (*ListBackupsResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{12}
+	return fileDescriptor_65240d19de191688, []int{19}
 }
 
 func (m *ListBackupsResponse) XXX_Unmarshal(b []byte) error {
@@ -1308,11 +2416,21 @@ func (m *ListBackupsResponse) GetData() []*BackupInfo {
 	return nil
 }
 
+func (m *ListBackupsResponse) GetTotal() int32 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
 type DeleteBackupRequest struct {
 	// uuid of request, will generate one if not set
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
 	// backup name
-	BackupName           string   `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// delete the incremental backups referencing the backup as well,
+	// otherwise a backup referenced by other backups can't be deleted
+	Force                bool     `protobuf:"varint,3,opt,name=force,proto3" json:"force,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1322,7 +2440,7 @@ func (m *DeleteBackupRequest) Reset()         { *m = DeleteBackupRequest{} }
 func (m *DeleteBackupRequest) String() string { return proto.CompactTextString(m) }
 func (*DeleteBackupRequest) ProtoMessage()    {}
 func (*DeleteBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{13}
+	return fileDescriptor_65240d19de191688, []int{20}
 }
 
 func (m *DeleteBackupRequest) XXX_Unmarshal(b []byte) error {
@@ -1357,13 +2475,22 @@ func (m *DeleteBackupRequest) GetBackupName() string {
 	return ""
 }
 
+func (m *DeleteBackupRequest) GetForce() bool {
+	if m != nil {
+		return m.Force
+	}
+	return false
+}
+
 type DeleteBackupResponse struct {
 	// uuid of the request to response
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
 	// response code. 0 means success. others are fail
 	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
 	// error msg if fail
-	Msg                  string   `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// backups deleted, including the incremental backups referencing the backup if force is set
+	DeletedBackups       []string `protobuf:"bytes,4,rep,name=deleted_backups,json=deletedBackups,proto3" json:"deleted_backups,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -1373,7 +2500,7 @@ func (m *DeleteBackupResponse) Reset()         { *m = DeleteBackupResponse{} }
 func (m *DeleteBackupResponse) String() string { return proto.CompactTextString(m) }
 func (*DeleteBackupResponse) ProtoMessage()    {}
 func (*DeleteBackupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{14}
+	return fileDescriptor_65240d19de191688, []int{21}
 }
 
 func (m *DeleteBackupResponse) XXX_Unmarshal(b []byte) error {
@@ -1415,1492 +2542,5709 @@ func (m *DeleteBackupResponse) GetMsg() string {
 	return ""
 }
 
-type RestoreBackupRequest struct {
+func (m *DeleteBackupResponse) GetDeletedBackups() []string {
+	if m != nil {
+		return m.DeletedBackups
+	}
+	return nil
+}
+
+type PauseBackupRequest struct {
 	// uuid of request, will generate one if not set
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
-	// backup name to restore
-	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
-	// collections to restore
-	CollectionNames []string `protobuf:"bytes,3,rep,name=collection_names,json=collectionNames,proto3" json:"collection_names,omitempty"`
-	// Support two ways to rename the collections while recover
-	// 1, set a suffix
-	CollectionSuffix string `protobuf:"bytes,4,opt,name=collection_suffix,json=collectionSuffix,proto3" json:"collection_suffix,omitempty"`
-	// 2, give a map to rename the collections, if not given, use the original name.
-	// collection_renames has higher priority than collection_suffix
-	CollectionRenames map[string]string `protobuf:"bytes,5,rep,name=collection_renames,json=collectionRenames,proto3" json:"collection_renames,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
-	// execute asynchronously or not
-	Async bool `protobuf:"varint,6,opt,name=async,proto3" json:"async,omitempty"`
-	// if bucket_name and path is set. will override bucket/path in config.
-	BucketName string `protobuf:"bytes,7,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
-	// if bucket_name and path is set. will override bucket/path in config.
-	Path string `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`
-	// database and collections to restore. A json string. To support database. 2023.7.7
-	DbCollections *_struct.Value `protobuf:"bytes,9,opt,name=db_collections,json=dbCollections,proto3" json:"db_collections,omitempty"`
-	// if true only restore meta, not restore data
-	MetaOnly bool `protobuf:"varint,10,opt,name=metaOnly,proto3" json:"metaOnly,omitempty"`
-	// if true restore index info
-	RestoreIndex bool `protobuf:"varint,11,opt,name=restoreIndex,proto3" json:"restoreIndex,omitempty"`
-	// if true use autoindex when restore vector index
-	UseAutoIndex bool `protobuf:"varint,12,opt,name=useAutoIndex,proto3" json:"useAutoIndex,omitempty"`
-	// if true, drop existing target collection before create
-	DropExistCollection bool `protobuf:"varint,13,opt,name=dropExistCollection,proto3" json:"dropExistCollection,omitempty"`
-	// if true, drop existing index of target collection before create
-	DropExistIndex bool `protobuf:"varint,14,opt,name=dropExistIndex,proto3" json:"dropExistIndex,omitempty"`
-	// if true, will skip collection, use when collection exist, restore index or data
-	SkipCreateCollection bool     `protobuf:"varint,15,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
+	// name of the running backup
+	BackupName           string   `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreBackupRequest) Reset()         { *m = RestoreBackupRequest{} }
-func (m *RestoreBackupRequest) String() string { return proto.CompactTextString(m) }
-func (*RestoreBackupRequest) ProtoMessage()    {}
-func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{15}
+func (m *PauseBackupRequest) Reset()         { *m = PauseBackupRequest{} }
+func (m *PauseBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*PauseBackupRequest) ProtoMessage()    {}
+func (*PauseBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{22}
 }
 
-func (m *RestoreBackupRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestoreBackupRequest.Unmarshal(m, b)
+func (m *PauseBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseBackupRequest.Unmarshal(m, b)
 }
-func (m *RestoreBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RestoreBackupRequest.Marshal(b, m, deterministic)
+func (m *PauseBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseBackupRequest.Marshal(b, m, deterministic)
 }
-func (m *RestoreBackupRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RestoreBackupRequest.Merge(m, src)
+func (m *PauseBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseBackupRequest.Merge(m, src)
 }
-func (m *RestoreBackupRequest) XXX_Size() int {
-	return xxx_messageInfo_RestoreBackupRequest.Size(m)
+func (m *PauseBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_PauseBackupRequest.Size(m)
 }
-func (m *RestoreBackupRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_RestoreBackupRequest.DiscardUnknown(m)
+func (m *PauseBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseBackupRequest.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RestoreBackupRequest proto.InternalMessageInfo
+var xxx_messageInfo_PauseBackupRequest proto.InternalMessageInfo
 
-func (m *RestoreBackupRequest) GetRequestId() string {
+func (m *PauseBackupRequest) GetRequestId() string {
 	if m != nil {
 		return m.RequestId
 	}
 	return ""
 }
 
-func (m *RestoreBackupRequest) GetBackupName() string {
+func (m *PauseBackupRequest) GetBackupName() string {
 	if m != nil {
 		return m.BackupName
 	}
 	return ""
 }
 
-func (m *RestoreBackupRequest) GetCollectionNames() []string {
-	if m != nil {
-		return m.CollectionNames
-	}
-	return nil
+type PauseBackupResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg                  string   `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreBackupRequest) GetCollectionSuffix() string {
-	if m != nil {
-		return m.CollectionSuffix
-	}
-	return ""
+func (m *PauseBackupResponse) Reset()         { *m = PauseBackupResponse{} }
+func (m *PauseBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*PauseBackupResponse) ProtoMessage()    {}
+func (*PauseBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{23}
 }
 
-func (m *RestoreBackupRequest) GetCollectionRenames() map[string]string {
+func (m *PauseBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PauseBackupResponse.Unmarshal(m, b)
+}
+func (m *PauseBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PauseBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *PauseBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PauseBackupResponse.Merge(m, src)
+}
+func (m *PauseBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_PauseBackupResponse.Size(m)
+}
+func (m *PauseBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PauseBackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PauseBackupResponse proto.InternalMessageInfo
+
+func (m *PauseBackupResponse) GetRequestId() string {
 	if m != nil {
-		return m.CollectionRenames
+		return m.RequestId
 	}
-	return nil
+	return ""
 }
 
-func (m *RestoreBackupRequest) GetAsync() bool {
+func (m *PauseBackupResponse) GetCode() ResponseCode {
 	if m != nil {
-		return m.Async
+		return m.Code
 	}
-	return false
+	return ResponseCode_Success
 }
 
-func (m *RestoreBackupRequest) GetBucketName() string {
+func (m *PauseBackupResponse) GetMsg() string {
 	if m != nil {
-		return m.BucketName
+		return m.Msg
 	}
 	return ""
 }
 
-func (m *RestoreBackupRequest) GetPath() string {
+type ResumeBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// name of the paused backup
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// execute asynchronously or not
+	Async                bool     `protobuf:"varint,3,opt,name=async,proto3" json:"async,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeBackupRequest) Reset()         { *m = ResumeBackupRequest{} }
+func (m *ResumeBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeBackupRequest) ProtoMessage()    {}
+func (*ResumeBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{24}
+}
+
+func (m *ResumeBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeBackupRequest.Unmarshal(m, b)
+}
+func (m *ResumeBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *ResumeBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeBackupRequest.Merge(m, src)
+}
+func (m *ResumeBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeBackupRequest.Size(m)
+}
+func (m *ResumeBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeBackupRequest proto.InternalMessageInfo
+
+func (m *ResumeBackupRequest) GetRequestId() string {
 	if m != nil {
-		return m.Path
+		return m.RequestId
 	}
 	return ""
 }
 
-func (m *RestoreBackupRequest) GetDbCollections() *_struct.Value {
+func (m *ResumeBackupRequest) GetBackupName() string {
 	if m != nil {
-		return m.DbCollections
+		return m.BackupName
 	}
-	return nil
+	return ""
 }
 
-func (m *RestoreBackupRequest) GetMetaOnly() bool {
+func (m *ResumeBackupRequest) GetAsync() bool {
 	if m != nil {
-		return m.MetaOnly
+		return m.Async
 	}
 	return false
 }
 
-func (m *RestoreBackupRequest) GetRestoreIndex() bool {
-	if m != nil {
-		return m.RestoreIndex
-	}
-	return false
+type PruneBackupsRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// only return the backups to delete, do not delete them
+	DryRun bool `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// only apply the retention policy to the backups whose labels match the selector, empty means all backups
+	LabelSelector        string   `protobuf:"bytes,3,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreBackupRequest) GetUseAutoIndex() bool {
-	if m != nil {
-		return m.UseAutoIndex
-	}
-	return false
+func (m *PruneBackupsRequest) Reset()         { *m = PruneBackupsRequest{} }
+func (m *PruneBackupsRequest) String() string { return proto.CompactTextString(m) }
+func (*PruneBackupsRequest) ProtoMessage()    {}
+func (*PruneBackupsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{25}
 }
 
-func (m *RestoreBackupRequest) GetDropExistCollection() bool {
+func (m *PruneBackupsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneBackupsRequest.Unmarshal(m, b)
+}
+func (m *PruneBackupsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneBackupsRequest.Marshal(b, m, deterministic)
+}
+func (m *PruneBackupsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneBackupsRequest.Merge(m, src)
+}
+func (m *PruneBackupsRequest) XXX_Size() int {
+	return xxx_messageInfo_PruneBackupsRequest.Size(m)
+}
+func (m *PruneBackupsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneBackupsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PruneBackupsRequest proto.InternalMessageInfo
+
+func (m *PruneBackupsRequest) GetRequestId() string {
 	if m != nil {
-		return m.DropExistCollection
+		return m.RequestId
 	}
-	return false
+	return ""
 }
 
-func (m *RestoreBackupRequest) GetDropExistIndex() bool {
+func (m *PruneBackupsRequest) GetDryRun() bool {
 	if m != nil {
-		return m.DropExistIndex
+		return m.DryRun
 	}
 	return false
 }
 
-func (m *RestoreBackupRequest) GetSkipCreateCollection() bool {
+func (m *PruneBackupsRequest) GetLabelSelector() string {
 	if m != nil {
-		return m.SkipCreateCollection
+		return m.LabelSelector
 	}
-	return false
+	return ""
 }
 
-type RestorePartitionTask struct {
-	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	StateCode            RestoreTaskStateCode `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code,omitempty"`
-	ErrorMessage         string               `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
-	StartTime            int64                `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime              int64                `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	Progress             int32                `protobuf:"varint,6,opt,name=progress,proto3" json:"progress,omitempty"`
-	PartBackup           *PartitionBackupInfo `protobuf:"bytes,7,opt,name=part_backup,json=partBackup,proto3" json:"part_backup,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
-	XXX_unrecognized     []byte               `json:"-"`
-	XXX_sizecache        int32                `json:"-"`
+type PruneBackupsResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// backups deleted, or to delete in dry run mode
+	DeletedBackups []string `protobuf:"bytes,4,rep,name=deleted_backups,json=deletedBackups,proto3" json:"deleted_backups,omitempty"`
+	// backups kept by the retention policy
+	KeptBackups          []string `protobuf:"bytes,5,rep,name=kept_backups,json=keptBackups,proto3" json:"kept_backups,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestorePartitionTask) Reset()         { *m = RestorePartitionTask{} }
-func (m *RestorePartitionTask) String() string { return proto.CompactTextString(m) }
-func (*RestorePartitionTask) ProtoMessage()    {}
-func (*RestorePartitionTask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{16}
+func (m *PruneBackupsResponse) Reset()         { *m = PruneBackupsResponse{} }
+func (m *PruneBackupsResponse) String() string { return proto.CompactTextString(m) }
+func (*PruneBackupsResponse) ProtoMessage()    {}
+func (*PruneBackupsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{26}
 }
 
-func (m *RestorePartitionTask) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestorePartitionTask.Unmarshal(m, b)
+func (m *PruneBackupsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PruneBackupsResponse.Unmarshal(m, b)
 }
-func (m *RestorePartitionTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RestorePartitionTask.Marshal(b, m, deterministic)
+func (m *PruneBackupsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PruneBackupsResponse.Marshal(b, m, deterministic)
 }
-func (m *RestorePartitionTask) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RestorePartitionTask.Merge(m, src)
+func (m *PruneBackupsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PruneBackupsResponse.Merge(m, src)
 }
-func (m *RestorePartitionTask) XXX_Size() int {
-	return xxx_messageInfo_RestorePartitionTask.Size(m)
+func (m *PruneBackupsResponse) XXX_Size() int {
+	return xxx_messageInfo_PruneBackupsResponse.Size(m)
 }
-func (m *RestorePartitionTask) XXX_DiscardUnknown() {
-	xxx_messageInfo_RestorePartitionTask.DiscardUnknown(m)
+func (m *PruneBackupsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_PruneBackupsResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RestorePartitionTask proto.InternalMessageInfo
+var xxx_messageInfo_PruneBackupsResponse proto.InternalMessageInfo
 
-func (m *RestorePartitionTask) GetId() string {
+func (m *PruneBackupsResponse) GetRequestId() string {
 	if m != nil {
-		return m.Id
+		return m.RequestId
 	}
 	return ""
 }
 
-func (m *RestorePartitionTask) GetStateCode() RestoreTaskStateCode {
+func (m *PruneBackupsResponse) GetCode() ResponseCode {
 	if m != nil {
-		return m.StateCode
+		return m.Code
 	}
-	return RestoreTaskStateCode_INITIAL
+	return ResponseCode_Success
 }
 
-func (m *RestorePartitionTask) GetErrorMessage() string {
+func (m *PruneBackupsResponse) GetMsg() string {
 	if m != nil {
-		return m.ErrorMessage
+		return m.Msg
 	}
 	return ""
 }
 
-func (m *RestorePartitionTask) GetStartTime() int64 {
+func (m *PruneBackupsResponse) GetDeletedBackups() []string {
 	if m != nil {
-		return m.StartTime
+		return m.DeletedBackups
 	}
-	return 0
+	return nil
 }
 
-func (m *RestorePartitionTask) GetEndTime() int64 {
+func (m *PruneBackupsResponse) GetKeptBackups() []string {
 	if m != nil {
-		return m.EndTime
+		return m.KeptBackups
 	}
-	return 0
+	return nil
 }
 
-func (m *RestorePartitionTask) GetProgress() int32 {
+type GarbageCollectRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// only return the orphaned files, do not remove them
+	DryRun               bool     `protobuf:"varint,2,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GarbageCollectRequest) Reset()         { *m = GarbageCollectRequest{} }
+func (m *GarbageCollectRequest) String() string { return proto.CompactTextString(m) }
+func (*GarbageCollectRequest) ProtoMessage()    {}
+func (*GarbageCollectRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{27}
+}
+
+func (m *GarbageCollectRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GarbageCollectRequest.Unmarshal(m, b)
+}
+func (m *GarbageCollectRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GarbageCollectRequest.Marshal(b, m, deterministic)
+}
+func (m *GarbageCollectRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GarbageCollectRequest.Merge(m, src)
+}
+func (m *GarbageCollectRequest) XXX_Size() int {
+	return xxx_messageInfo_GarbageCollectRequest.Size(m)
+}
+func (m *GarbageCollectRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GarbageCollectRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GarbageCollectRequest proto.InternalMessageInfo
+
+func (m *GarbageCollectRequest) GetRequestId() string {
 	if m != nil {
-		return m.Progress
+		return m.RequestId
 	}
-	return 0
+	return ""
 }
 
-func (m *RestorePartitionTask) GetPartBackup() *PartitionBackupInfo {
+func (m *GarbageCollectRequest) GetDryRun() bool {
 	if m != nil {
-		return m.PartBackup
+		return m.DryRun
 	}
-	return nil
+	return false
 }
 
-type RestoreCollectionTask struct {
-	Id                    string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	StateCode             RestoreTaskStateCode    `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code,omitempty"`
-	ErrorMessage          string                  `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
-	StartTime             int64                   `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime               int64                   `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	CollBackup            *CollectionBackupInfo   `protobuf:"bytes,6,opt,name=coll_backup,json=collBackup,proto3" json:"coll_backup,omitempty"`
-	TargetCollectionName  string                  `protobuf:"bytes,7,opt,name=target_collection_name,json=targetCollectionName,proto3" json:"target_collection_name,omitempty"`
-	PartitionRestoreTasks []*RestorePartitionTask `protobuf:"bytes,8,rep,name=partition_restore_tasks,json=partitionRestoreTasks,proto3" json:"partition_restore_tasks,omitempty"`
-	RestoredSize          int64                   `protobuf:"varint,9,opt,name=restored_size,json=restoredSize,proto3" json:"restored_size,omitempty"`
-	ToRestoreSize         int64                   `protobuf:"varint,10,opt,name=to_restore_size,json=toRestoreSize,proto3" json:"to_restore_size,omitempty"`
-	Progress              int32                   `protobuf:"varint,11,opt,name=progress,proto3" json:"progress,omitempty"`
-	TargetDbName          string                  `protobuf:"bytes,12,opt,name=target_db_name,json=targetDbName,proto3" json:"target_db_name,omitempty"`
-	// if true only restore meta
-	MetaOnly bool `protobuf:"varint,13,opt,name=metaOnly,proto3" json:"metaOnly,omitempty"`
-	// if true restore index info
-	RestoreIndex bool `protobuf:"varint,14,opt,name=restoreIndex,proto3" json:"restoreIndex,omitempty"`
-	// if true use autoindex when restore vector index
-	UseAutoIndex bool `protobuf:"varint,15,opt,name=useAutoIndex,proto3" json:"useAutoIndex,omitempty"`
-	// if true drop the collections
-	DropExistCollection bool `protobuf:"varint,16,opt,name=dropExistCollection,proto3" json:"dropExistCollection,omitempty"`
-	// if true drop index info
-	DropExistIndex bool `protobuf:"varint,17,opt,name=dropExistIndex,proto3" json:"dropExistIndex,omitempty"`
-	// if true will skip create collections
-	SkipCreateCollection bool     `protobuf:"varint,18,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
+type GarbageCollectResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// orphaned files removed, or to remove in dry run mode
+	RemovedFiles []string `protobuf:"bytes,4,rep,name=removed_files,json=removedFiles,proto3" json:"removed_files,omitempty"`
+	// total size of the orphaned files in bytes
+	RemovedSize          int64    `protobuf:"varint,5,opt,name=removed_size,json=removedSize,proto3" json:"removed_size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreCollectionTask) Reset()         { *m = RestoreCollectionTask{} }
-func (m *RestoreCollectionTask) String() string { return proto.CompactTextString(m) }
-func (*RestoreCollectionTask) ProtoMessage()    {}
-func (*RestoreCollectionTask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{17}
+func (m *GarbageCollectResponse) Reset()         { *m = GarbageCollectResponse{} }
+func (m *GarbageCollectResponse) String() string { return proto.CompactTextString(m) }
+func (*GarbageCollectResponse) ProtoMessage()    {}
+func (*GarbageCollectResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{28}
 }
 
-func (m *RestoreCollectionTask) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestoreCollectionTask.Unmarshal(m, b)
+func (m *GarbageCollectResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GarbageCollectResponse.Unmarshal(m, b)
 }
-func (m *RestoreCollectionTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RestoreCollectionTask.Marshal(b, m, deterministic)
+func (m *GarbageCollectResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GarbageCollectResponse.Marshal(b, m, deterministic)
 }
-func (m *RestoreCollectionTask) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RestoreCollectionTask.Merge(m, src)
+func (m *GarbageCollectResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GarbageCollectResponse.Merge(m, src)
 }
-func (m *RestoreCollectionTask) XXX_Size() int {
-	return xxx_messageInfo_RestoreCollectionTask.Size(m)
+func (m *GarbageCollectResponse) XXX_Size() int {
+	return xxx_messageInfo_GarbageCollectResponse.Size(m)
 }
-func (m *RestoreCollectionTask) XXX_DiscardUnknown() {
-	xxx_messageInfo_RestoreCollectionTask.DiscardUnknown(m)
+func (m *GarbageCollectResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GarbageCollectResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RestoreCollectionTask proto.InternalMessageInfo
+var xxx_messageInfo_GarbageCollectResponse proto.InternalMessageInfo
 
-func (m *RestoreCollectionTask) GetId() string {
+func (m *GarbageCollectResponse) GetRequestId() string {
 	if m != nil {
-		return m.Id
+		return m.RequestId
 	}
 	return ""
 }
 
-func (m *RestoreCollectionTask) GetStateCode() RestoreTaskStateCode {
+func (m *GarbageCollectResponse) GetCode() ResponseCode {
 	if m != nil {
-		return m.StateCode
+		return m.Code
 	}
-	return RestoreTaskStateCode_INITIAL
+	return ResponseCode_Success
 }
 
-func (m *RestoreCollectionTask) GetErrorMessage() string {
+func (m *GarbageCollectResponse) GetMsg() string {
 	if m != nil {
-		return m.ErrorMessage
+		return m.Msg
 	}
 	return ""
 }
 
-func (m *RestoreCollectionTask) GetStartTime() int64 {
+func (m *GarbageCollectResponse) GetRemovedFiles() []string {
 	if m != nil {
-		return m.StartTime
+		return m.RemovedFiles
 	}
-	return 0
+	return nil
 }
 
-func (m *RestoreCollectionTask) GetEndTime() int64 {
+func (m *GarbageCollectResponse) GetRemovedSize() int64 {
 	if m != nil {
-		return m.EndTime
+		return m.RemovedSize
 	}
 	return 0
 }
 
-func (m *RestoreCollectionTask) GetCollBackup() *CollectionBackupInfo {
-	if m != nil {
-		return m.CollBackup
-	}
-	return nil
+type VerifyBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// backup name
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// customer key of the backup encrypted by sse type customer, backup.serverSideEncryption.customerKey in config if not set
+	SseCustomerKey       string   `protobuf:"bytes,3,opt,name=sse_customer_key,json=sseCustomerKey,proto3" json:"sse_customer_key,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreCollectionTask) GetTargetCollectionName() string {
+func (m *VerifyBackupRequest) Reset()         { *m = VerifyBackupRequest{} }
+func (m *VerifyBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*VerifyBackupRequest) ProtoMessage()    {}
+func (*VerifyBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{29}
+}
+
+func (m *VerifyBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyBackupRequest.Unmarshal(m, b)
+}
+func (m *VerifyBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *VerifyBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyBackupRequest.Merge(m, src)
+}
+func (m *VerifyBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_VerifyBackupRequest.Size(m)
+}
+func (m *VerifyBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyBackupRequest proto.InternalMessageInfo
+
+func (m *VerifyBackupRequest) GetRequestId() string {
 	if m != nil {
-		return m.TargetCollectionName
+		return m.RequestId
 	}
 	return ""
 }
 
-func (m *RestoreCollectionTask) GetPartitionRestoreTasks() []*RestorePartitionTask {
+func (m *VerifyBackupRequest) GetBackupName() string {
 	if m != nil {
-		return m.PartitionRestoreTasks
+		return m.BackupName
 	}
-	return nil
+	return ""
 }
 
-func (m *RestoreCollectionTask) GetRestoredSize() int64 {
+func (m *VerifyBackupRequest) GetSseCustomerKey() string {
 	if m != nil {
-		return m.RestoredSize
+		return m.SseCustomerKey
 	}
-	return 0
+	return ""
 }
 
-func (m *RestoreCollectionTask) GetToRestoreSize() int64 {
-	if m != nil {
-		return m.ToRestoreSize
-	}
-	return 0
+type VerifyBackupResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// number of files checked
+	CheckedFiles int64 `protobuf:"varint,4,opt,name=checked_files,json=checkedFiles,proto3" json:"checked_files,omitempty"`
+	// number of files checked by checksum, the others are only checked by size
+	ChecksumFiles int64 `protobuf:"varint,5,opt,name=checksum_files,json=checksumFiles,proto3" json:"checksum_files,omitempty"`
+	// files recorded in backup meta but not exist in storage
+	MissingFiles []string `protobuf:"bytes,6,rep,name=missing_files,json=missingFiles,proto3" json:"missing_files,omitempty"`
+	// files whose size or checksum mismatch the backup meta
+	CorruptedFiles []string `protobuf:"bytes,7,rep,name=corrupted_files,json=corruptedFiles,proto3" json:"corrupted_files,omitempty"`
+	// files are checked against the manifest of backup instead of backup meta
+	Manifest             bool     `protobuf:"varint,8,opt,name=manifest,proto3" json:"manifest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreCollectionTask) GetProgress() int32 {
+func (m *VerifyBackupResponse) Reset()         { *m = VerifyBackupResponse{} }
+func (m *VerifyBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*VerifyBackupResponse) ProtoMessage()    {}
+func (*VerifyBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{30}
+}
+
+func (m *VerifyBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_VerifyBackupResponse.Unmarshal(m, b)
+}
+func (m *VerifyBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_VerifyBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *VerifyBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_VerifyBackupResponse.Merge(m, src)
+}
+func (m *VerifyBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_VerifyBackupResponse.Size(m)
+}
+func (m *VerifyBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_VerifyBackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_VerifyBackupResponse proto.InternalMessageInfo
+
+func (m *VerifyBackupResponse) GetRequestId() string {
 	if m != nil {
-		return m.Progress
+		return m.RequestId
 	}
-	return 0
+	return ""
 }
 
-func (m *RestoreCollectionTask) GetTargetDbName() string {
+func (m *VerifyBackupResponse) GetCode() ResponseCode {
 	if m != nil {
-		return m.TargetDbName
+		return m.Code
 	}
-	return ""
+	return ResponseCode_Success
 }
 
-func (m *RestoreCollectionTask) GetMetaOnly() bool {
+func (m *VerifyBackupResponse) GetMsg() string {
 	if m != nil {
-		return m.MetaOnly
+		return m.Msg
 	}
-	return false
+	return ""
 }
 
-func (m *RestoreCollectionTask) GetRestoreIndex() bool {
+func (m *VerifyBackupResponse) GetCheckedFiles() int64 {
 	if m != nil {
-		return m.RestoreIndex
+		return m.CheckedFiles
 	}
-	return false
+	return 0
 }
 
-func (m *RestoreCollectionTask) GetUseAutoIndex() bool {
+func (m *VerifyBackupResponse) GetChecksumFiles() int64 {
 	if m != nil {
-		return m.UseAutoIndex
+		return m.ChecksumFiles
 	}
-	return false
+	return 0
 }
 
-func (m *RestoreCollectionTask) GetDropExistCollection() bool {
+func (m *VerifyBackupResponse) GetMissingFiles() []string {
 	if m != nil {
-		return m.DropExistCollection
+		return m.MissingFiles
 	}
-	return false
+	return nil
 }
 
-func (m *RestoreCollectionTask) GetDropExistIndex() bool {
+func (m *VerifyBackupResponse) GetCorruptedFiles() []string {
 	if m != nil {
-		return m.DropExistIndex
+		return m.CorruptedFiles
 	}
-	return false
+	return nil
 }
 
-func (m *RestoreCollectionTask) GetSkipCreateCollection() bool {
+func (m *VerifyBackupResponse) GetManifest() bool {
 	if m != nil {
-		return m.SkipCreateCollection
+		return m.Manifest
 	}
 	return false
 }
 
-type RestoreBackupTask struct {
-	Id                     string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	StateCode              RestoreTaskStateCode     `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code,omitempty"`
-	ErrorMessage           string                   `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
-	StartTime              int64                    `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
-	EndTime                int64                    `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
-	CollectionRestoreTasks []*RestoreCollectionTask `protobuf:"bytes,6,rep,name=collection_restore_tasks,json=collectionRestoreTasks,proto3" json:"collection_restore_tasks,omitempty"`
-	RestoredSize           int64                    `protobuf:"varint,7,opt,name=restored_size,json=restoredSize,proto3" json:"restored_size,omitempty"`
-	ToRestoreSize          int64                    `protobuf:"varint,8,opt,name=to_restore_size,json=toRestoreSize,proto3" json:"to_restore_size,omitempty"`
-	Progress               int32                    `protobuf:"varint,9,opt,name=progress,proto3" json:"progress,omitempty"`
-	XXX_NoUnkeyedLiteral   struct{}                 `json:"-"`
-	XXX_unrecognized       []byte                   `json:"-"`
-	XXX_sizecache          int32                    `json:"-"`
+type CollectionEstimate struct {
+	DbName         string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	CollectionName string `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// number of partitions to backup
+	PartitionNum int32 `protobuf:"varint,3,opt,name=partition_num,json=partitionNum,proto3" json:"partition_num,omitempty"`
+	// number of persisted segments to backup
+	SegmentNum int64 `protobuf:"varint,4,opt,name=segment_num,json=segmentNum,proto3" json:"segment_num,omitempty"`
+	NumOfRows  int64 `protobuf:"varint,5,opt,name=num_of_rows,json=numOfRows,proto3" json:"num_of_rows,omitempty"`
+	// size in bytes of the binlogs to backup
+	Size                 int64    `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreBackupTask) Reset()         { *m = RestoreBackupTask{} }
-func (m *RestoreBackupTask) String() string { return proto.CompactTextString(m) }
-func (*RestoreBackupTask) ProtoMessage()    {}
-func (*RestoreBackupTask) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{18}
+func (m *CollectionEstimate) Reset()         { *m = CollectionEstimate{} }
+func (m *CollectionEstimate) String() string { return proto.CompactTextString(m) }
+func (*CollectionEstimate) ProtoMessage()    {}
+func (*CollectionEstimate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{31}
 }
 
-func (m *RestoreBackupTask) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestoreBackupTask.Unmarshal(m, b)
+func (m *CollectionEstimate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionEstimate.Unmarshal(m, b)
 }
-func (m *RestoreBackupTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RestoreBackupTask.Marshal(b, m, deterministic)
+func (m *CollectionEstimate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionEstimate.Marshal(b, m, deterministic)
 }
-func (m *RestoreBackupTask) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RestoreBackupTask.Merge(m, src)
+func (m *CollectionEstimate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionEstimate.Merge(m, src)
 }
-func (m *RestoreBackupTask) XXX_Size() int {
-	return xxx_messageInfo_RestoreBackupTask.Size(m)
+func (m *CollectionEstimate) XXX_Size() int {
+	return xxx_messageInfo_CollectionEstimate.Size(m)
 }
-func (m *RestoreBackupTask) XXX_DiscardUnknown() {
-	xxx_messageInfo_RestoreBackupTask.DiscardUnknown(m)
+func (m *CollectionEstimate) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionEstimate.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RestoreBackupTask proto.InternalMessageInfo
+var xxx_messageInfo_CollectionEstimate proto.InternalMessageInfo
 
-func (m *RestoreBackupTask) GetId() string {
+func (m *CollectionEstimate) GetDbName() string {
 	if m != nil {
-		return m.Id
+		return m.DbName
 	}
 	return ""
 }
 
-func (m *RestoreBackupTask) GetStateCode() RestoreTaskStateCode {
-	if m != nil {
-		return m.StateCode
-	}
-	return RestoreTaskStateCode_INITIAL
-}
-
-func (m *RestoreBackupTask) GetErrorMessage() string {
+func (m *CollectionEstimate) GetCollectionName() string {
 	if m != nil {
-		return m.ErrorMessage
+		return m.CollectionName
 	}
 	return ""
 }
 
-func (m *RestoreBackupTask) GetStartTime() int64 {
-	if m != nil {
-		return m.StartTime
-	}
-	return 0
-}
-
-func (m *RestoreBackupTask) GetEndTime() int64 {
+func (m *CollectionEstimate) GetPartitionNum() int32 {
 	if m != nil {
-		return m.EndTime
+		return m.PartitionNum
 	}
 	return 0
 }
 
-func (m *RestoreBackupTask) GetCollectionRestoreTasks() []*RestoreCollectionTask {
-	if m != nil {
-		return m.CollectionRestoreTasks
-	}
-	return nil
-}
-
-func (m *RestoreBackupTask) GetRestoredSize() int64 {
+func (m *CollectionEstimate) GetSegmentNum() int64 {
 	if m != nil {
-		return m.RestoredSize
+		return m.SegmentNum
 	}
 	return 0
 }
 
-func (m *RestoreBackupTask) GetToRestoreSize() int64 {
+func (m *CollectionEstimate) GetNumOfRows() int64 {
 	if m != nil {
-		return m.ToRestoreSize
+		return m.NumOfRows
 	}
 	return 0
 }
 
-func (m *RestoreBackupTask) GetProgress() int32 {
+func (m *CollectionEstimate) GetSize() int64 {
 	if m != nil {
-		return m.Progress
+		return m.Size
 	}
 	return 0
 }
 
-type RestoreBackupResponse struct {
+type EstimateBackupResponse struct {
 	// uuid of the request to response
 	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
 	// response code. 0 means success. others are fail
 	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
 	// error msg if fail
 	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
-	// restore task info entity
-	Data                 *RestoreBackupTask `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
-	XXX_unrecognized     []byte             `json:"-"`
-	XXX_sizecache        int32              `json:"-"`
+	// collections would be backed up
+	Collections []*CollectionEstimate `protobuf:"bytes,4,rep,name=collections,proto3" json:"collections,omitempty"`
+	// total number of segments
+	SegmentNum int64 `protobuf:"varint,5,opt,name=segment_num,json=segmentNum,proto3" json:"segment_num,omitempty"`
+	// total size in bytes, before compression
+	Size                 int64    `protobuf:"varint,6,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *RestoreBackupResponse) Reset()         { *m = RestoreBackupResponse{} }
-func (m *RestoreBackupResponse) String() string { return proto.CompactTextString(m) }
-func (*RestoreBackupResponse) ProtoMessage()    {}
-func (*RestoreBackupResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{19}
+func (m *EstimateBackupResponse) Reset()         { *m = EstimateBackupResponse{} }
+func (m *EstimateBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*EstimateBackupResponse) ProtoMessage()    {}
+func (*EstimateBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{32}
 }
 
-func (m *RestoreBackupResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_RestoreBackupResponse.Unmarshal(m, b)
+func (m *EstimateBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EstimateBackupResponse.Unmarshal(m, b)
 }
-func (m *RestoreBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_RestoreBackupResponse.Marshal(b, m, deterministic)
+func (m *EstimateBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EstimateBackupResponse.Marshal(b, m, deterministic)
 }
-func (m *RestoreBackupResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_RestoreBackupResponse.Merge(m, src)
+func (m *EstimateBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EstimateBackupResponse.Merge(m, src)
 }
-func (m *RestoreBackupResponse) XXX_Size() int {
-	return xxx_messageInfo_RestoreBackupResponse.Size(m)
+func (m *EstimateBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_EstimateBackupResponse.Size(m)
 }
-func (m *RestoreBackupResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_RestoreBackupResponse.DiscardUnknown(m)
+func (m *EstimateBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_EstimateBackupResponse.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_RestoreBackupResponse proto.InternalMessageInfo
+var xxx_messageInfo_EstimateBackupResponse proto.InternalMessageInfo
 
-func (m *RestoreBackupResponse) GetRequestId() string {
+func (m *EstimateBackupResponse) GetRequestId() string {
 	if m != nil {
 		return m.RequestId
 	}
 	return ""
 }
 
-func (m *RestoreBackupResponse) GetCode() ResponseCode {
+func (m *EstimateBackupResponse) GetCode() ResponseCode {
 	if m != nil {
 		return m.Code
 	}
 	return ResponseCode_Success
 }
 
-func (m *RestoreBackupResponse) GetMsg() string {
+func (m *EstimateBackupResponse) GetMsg() string {
 	if m != nil {
 		return m.Msg
 	}
 	return ""
 }
 
-func (m *RestoreBackupResponse) GetData() *RestoreBackupTask {
+func (m *EstimateBackupResponse) GetCollections() []*CollectionEstimate {
 	if m != nil {
-		return m.Data
+		return m.Collections
 	}
 	return nil
 }
 
-type GetRestoreStateRequest struct {
-	// uuid of request, will generate one if not set
-	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
-	// restore task id to query
-	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+func (m *EstimateBackupResponse) GetSegmentNum() int64 {
+	if m != nil {
+		return m.SegmentNum
+	}
+	return 0
+}
+
+func (m *EstimateBackupResponse) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type ScheduleJobStatus struct {
+	// name of the schedule job
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// cron expression of the job
+	Cron string `protobuf:"bytes,2,opt,name=cron,proto3" json:"cron,omitempty"`
+	// collections to backup, empty means all
+	CollectionNames []string `protobuf:"bytes,3,rep,name=collection_names,json=collectionNames,proto3" json:"collection_names,omitempty"`
+	// prune expired backups after backup
+	Prune bool `protobuf:"varint,4,opt,name=prune,proto3" json:"prune,omitempty"`
+	// unix timestamp of the next run
+	NextRunTime int64 `protobuf:"varint,5,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	// unix timestamp of the start of the last run, 0 means never run
+	LastStartTime int64 `protobuf:"varint,6,opt,name=last_start_time,json=lastStartTime,proto3" json:"last_start_time,omitempty"`
+	// unix timestamp of the end of the last run
+	LastEndTime int64 `protobuf:"varint,7,opt,name=last_end_time,json=lastEndTime,proto3" json:"last_end_time,omitempty"`
+	// backup created by the last run
+	LastBackupName string `protobuf:"bytes,8,opt,name=last_backup_name,json=lastBackupName,proto3" json:"last_backup_name,omitempty"`
+	// response code of the last run
+	LastCode ResponseCode `protobuf:"varint,9,opt,name=last_code,json=lastCode,proto3,enum=milvus.proto.backup.ResponseCode" json:"last_code,omitempty"`
+	// error msg of the last run if fail
+	LastMsg string `protobuf:"bytes,10,opt,name=last_msg,json=lastMsg,proto3" json:"last_msg,omitempty"`
+	// whether the job is running
+	Running              bool     `protobuf:"varint,11,opt,name=running,proto3" json:"running,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *GetRestoreStateRequest) Reset()         { *m = GetRestoreStateRequest{} }
-func (m *GetRestoreStateRequest) String() string { return proto.CompactTextString(m) }
-func (*GetRestoreStateRequest) ProtoMessage()    {}
-func (*GetRestoreStateRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{20}
+func (m *ScheduleJobStatus) Reset()         { *m = ScheduleJobStatus{} }
+func (m *ScheduleJobStatus) String() string { return proto.CompactTextString(m) }
+func (*ScheduleJobStatus) ProtoMessage()    {}
+func (*ScheduleJobStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{33}
 }
 
-func (m *GetRestoreStateRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_GetRestoreStateRequest.Unmarshal(m, b)
+func (m *ScheduleJobStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScheduleJobStatus.Unmarshal(m, b)
 }
-func (m *GetRestoreStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_GetRestoreStateRequest.Marshal(b, m, deterministic)
+func (m *ScheduleJobStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScheduleJobStatus.Marshal(b, m, deterministic)
 }
-func (m *GetRestoreStateRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_GetRestoreStateRequest.Merge(m, src)
+func (m *ScheduleJobStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScheduleJobStatus.Merge(m, src)
 }
-func (m *GetRestoreStateRequest) XXX_Size() int {
-	return xxx_messageInfo_GetRestoreStateRequest.Size(m)
+func (m *ScheduleJobStatus) XXX_Size() int {
+	return xxx_messageInfo_ScheduleJobStatus.Size(m)
 }
-func (m *GetRestoreStateRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_GetRestoreStateRequest.DiscardUnknown(m)
+func (m *ScheduleJobStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScheduleJobStatus.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_GetRestoreStateRequest proto.InternalMessageInfo
+var xxx_messageInfo_ScheduleJobStatus proto.InternalMessageInfo
 
-func (m *GetRestoreStateRequest) GetRequestId() string {
+func (m *ScheduleJobStatus) GetName() string {
 	if m != nil {
-		return m.RequestId
+		return m.Name
 	}
 	return ""
 }
 
-func (m *GetRestoreStateRequest) GetId() string {
+func (m *ScheduleJobStatus) GetCron() string {
 	if m != nil {
-		return m.Id
+		return m.Cron
 	}
 	return ""
 }
 
-// copied from milvus data_coord.proto
-type FieldBinlog struct {
-	FieldID              int64     `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
-	Binlogs              []*Binlog `protobuf:"bytes,2,rep,name=binlogs,proto3" json:"binlogs,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
-	XXX_unrecognized     []byte    `json:"-"`
-	XXX_sizecache        int32     `json:"-"`
+func (m *ScheduleJobStatus) GetCollectionNames() []string {
+	if m != nil {
+		return m.CollectionNames
+	}
+	return nil
 }
 
-func (m *FieldBinlog) Reset()         { *m = FieldBinlog{} }
-func (m *FieldBinlog) String() string { return proto.CompactTextString(m) }
-func (*FieldBinlog) ProtoMessage()    {}
-func (*FieldBinlog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{21}
+func (m *ScheduleJobStatus) GetPrune() bool {
+	if m != nil {
+		return m.Prune
+	}
+	return false
 }
 
-func (m *FieldBinlog) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FieldBinlog.Unmarshal(m, b)
-}
-func (m *FieldBinlog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FieldBinlog.Marshal(b, m, deterministic)
-}
-func (m *FieldBinlog) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FieldBinlog.Merge(m, src)
-}
-func (m *FieldBinlog) XXX_Size() int {
-	return xxx_messageInfo_FieldBinlog.Size(m)
-}
-func (m *FieldBinlog) XXX_DiscardUnknown() {
-	xxx_messageInfo_FieldBinlog.DiscardUnknown(m)
+func (m *ScheduleJobStatus) GetNextRunTime() int64 {
+	if m != nil {
+		return m.NextRunTime
+	}
+	return 0
 }
 
-var xxx_messageInfo_FieldBinlog proto.InternalMessageInfo
-
-func (m *FieldBinlog) GetFieldID() int64 {
+func (m *ScheduleJobStatus) GetLastStartTime() int64 {
 	if m != nil {
-		return m.FieldID
+		return m.LastStartTime
+	}
+	return 0
+}
+
+func (m *ScheduleJobStatus) GetLastEndTime() int64 {
+	if m != nil {
+		return m.LastEndTime
+	}
+	return 0
+}
+
+func (m *ScheduleJobStatus) GetLastBackupName() string {
+	if m != nil {
+		return m.LastBackupName
+	}
+	return ""
+}
+
+func (m *ScheduleJobStatus) GetLastCode() ResponseCode {
+	if m != nil {
+		return m.LastCode
+	}
+	return ResponseCode_Success
+}
+
+func (m *ScheduleJobStatus) GetLastMsg() string {
+	if m != nil {
+		return m.LastMsg
+	}
+	return ""
+}
+
+func (m *ScheduleJobStatus) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+type GetScheduleResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// status of the schedule jobs
+	Jobs                 []*ScheduleJobStatus `protobuf:"bytes,4,rep,name=jobs,proto3" json:"jobs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *GetScheduleResponse) Reset()         { *m = GetScheduleResponse{} }
+func (m *GetScheduleResponse) String() string { return proto.CompactTextString(m) }
+func (*GetScheduleResponse) ProtoMessage()    {}
+func (*GetScheduleResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{34}
+}
+
+func (m *GetScheduleResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetScheduleResponse.Unmarshal(m, b)
+}
+func (m *GetScheduleResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetScheduleResponse.Marshal(b, m, deterministic)
+}
+func (m *GetScheduleResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetScheduleResponse.Merge(m, src)
+}
+func (m *GetScheduleResponse) XXX_Size() int {
+	return xxx_messageInfo_GetScheduleResponse.Size(m)
+}
+func (m *GetScheduleResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetScheduleResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetScheduleResponse proto.InternalMessageInfo
+
+func (m *GetScheduleResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *GetScheduleResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *GetScheduleResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *GetScheduleResponse) GetJobs() []*ScheduleJobStatus {
+	if m != nil {
+		return m.Jobs
+	}
+	return nil
+}
+
+type ContinuousBackupStatus struct {
+	// prefix of the backups created by continuous backup
+	Name string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	// seconds between incremental backups
+	Interval int64 `protobuf:"varint,2,opt,name=interval,proto3" json:"interval,omitempty"`
+	// seconds between full backups starting new chains
+	FullInterval int64 `protobuf:"varint,3,opt,name=full_interval,json=fullInterval,proto3" json:"full_interval,omitempty"`
+	// collections to backup, empty means all
+	CollectionNames []string `protobuf:"bytes,4,rep,name=collection_names,json=collectionNames,proto3" json:"collection_names,omitempty"`
+	// full backup the current chain of incremental backups is built on
+	ChainBaseName string `protobuf:"bytes,5,opt,name=chain_base_name,json=chainBaseName,proto3" json:"chain_base_name,omitempty"`
+	// backups in the current chain, including the full backup
+	ChainLength int32 `protobuf:"varint,6,opt,name=chain_length,json=chainLength,proto3" json:"chain_length,omitempty"`
+	// last backup succeeded, the one to restore to recover to the recovery point
+	RecoveryBackupName string `protobuf:"bytes,7,opt,name=recovery_backup_name,json=recoveryBackupName,proto3" json:"recovery_backup_name,omitempty"`
+	// unix timestamp in seconds of the recovery point, data written before it is backed up, 0 means no backup yet
+	RecoveryTime int64 `protobuf:"varint,8,opt,name=recovery_time,json=recoveryTime,proto3" json:"recovery_time,omitempty"`
+	// seconds of the data written since the recovery point, which would be lost if milvus is lost now
+	RecoveryLag int64 `protobuf:"varint,9,opt,name=recovery_lag,json=recoveryLag,proto3" json:"recovery_lag,omitempty"`
+	// unix timestamp of the next run
+	NextRunTime int64 `protobuf:"varint,10,opt,name=next_run_time,json=nextRunTime,proto3" json:"next_run_time,omitempty"`
+	// unix timestamp of the start of the last run, 0 means never run
+	LastStartTime int64 `protobuf:"varint,11,opt,name=last_start_time,json=lastStartTime,proto3" json:"last_start_time,omitempty"`
+	// unix timestamp of the end of the last run
+	LastEndTime int64 `protobuf:"varint,12,opt,name=last_end_time,json=lastEndTime,proto3" json:"last_end_time,omitempty"`
+	// backup created by the last run
+	LastBackupName string `protobuf:"bytes,13,opt,name=last_backup_name,json=lastBackupName,proto3" json:"last_backup_name,omitempty"`
+	// response code of the last run
+	LastCode ResponseCode `protobuf:"varint,14,opt,name=last_code,json=lastCode,proto3,enum=milvus.proto.backup.ResponseCode" json:"last_code,omitempty"`
+	// error msg of the last run if fail
+	LastMsg string `protobuf:"bytes,15,opt,name=last_msg,json=lastMsg,proto3" json:"last_msg,omitempty"`
+	// whether a backup is running
+	Running              bool     `protobuf:"varint,16,opt,name=running,proto3" json:"running,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ContinuousBackupStatus) Reset()         { *m = ContinuousBackupStatus{} }
+func (m *ContinuousBackupStatus) String() string { return proto.CompactTextString(m) }
+func (*ContinuousBackupStatus) ProtoMessage()    {}
+func (*ContinuousBackupStatus) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{35}
+}
+
+func (m *ContinuousBackupStatus) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ContinuousBackupStatus.Unmarshal(m, b)
+}
+func (m *ContinuousBackupStatus) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ContinuousBackupStatus.Marshal(b, m, deterministic)
+}
+func (m *ContinuousBackupStatus) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ContinuousBackupStatus.Merge(m, src)
+}
+func (m *ContinuousBackupStatus) XXX_Size() int {
+	return xxx_messageInfo_ContinuousBackupStatus.Size(m)
+}
+func (m *ContinuousBackupStatus) XXX_DiscardUnknown() {
+	xxx_messageInfo_ContinuousBackupStatus.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ContinuousBackupStatus proto.InternalMessageInfo
+
+func (m *ContinuousBackupStatus) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *ContinuousBackupStatus) GetInterval() int64 {
+	if m != nil {
+		return m.Interval
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetFullInterval() int64 {
+	if m != nil {
+		return m.FullInterval
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetCollectionNames() []string {
+	if m != nil {
+		return m.CollectionNames
+	}
+	return nil
+}
+
+func (m *ContinuousBackupStatus) GetChainBaseName() string {
+	if m != nil {
+		return m.ChainBaseName
+	}
+	return ""
+}
+
+func (m *ContinuousBackupStatus) GetChainLength() int32 {
+	if m != nil {
+		return m.ChainLength
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetRecoveryBackupName() string {
+	if m != nil {
+		return m.RecoveryBackupName
+	}
+	return ""
+}
+
+func (m *ContinuousBackupStatus) GetRecoveryTime() int64 {
+	if m != nil {
+		return m.RecoveryTime
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetRecoveryLag() int64 {
+	if m != nil {
+		return m.RecoveryLag
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetNextRunTime() int64 {
+	if m != nil {
+		return m.NextRunTime
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetLastStartTime() int64 {
+	if m != nil {
+		return m.LastStartTime
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetLastEndTime() int64 {
+	if m != nil {
+		return m.LastEndTime
+	}
+	return 0
+}
+
+func (m *ContinuousBackupStatus) GetLastBackupName() string {
+	if m != nil {
+		return m.LastBackupName
+	}
+	return ""
+}
+
+func (m *ContinuousBackupStatus) GetLastCode() ResponseCode {
+	if m != nil {
+		return m.LastCode
+	}
+	return ResponseCode_Success
+}
+
+func (m *ContinuousBackupStatus) GetLastMsg() string {
+	if m != nil {
+		return m.LastMsg
+	}
+	return ""
+}
+
+func (m *ContinuousBackupStatus) GetRunning() bool {
+	if m != nil {
+		return m.Running
+	}
+	return false
+}
+
+type GetRecoveryPointResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// status of continuous backup
+	Data                 *ContinuousBackupStatus `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
+}
+
+func (m *GetRecoveryPointResponse) Reset()         { *m = GetRecoveryPointResponse{} }
+func (m *GetRecoveryPointResponse) String() string { return proto.CompactTextString(m) }
+func (*GetRecoveryPointResponse) ProtoMessage()    {}
+func (*GetRecoveryPointResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{36}
+}
+
+func (m *GetRecoveryPointResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRecoveryPointResponse.Unmarshal(m, b)
+}
+func (m *GetRecoveryPointResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRecoveryPointResponse.Marshal(b, m, deterministic)
+}
+func (m *GetRecoveryPointResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRecoveryPointResponse.Merge(m, src)
+}
+func (m *GetRecoveryPointResponse) XXX_Size() int {
+	return xxx_messageInfo_GetRecoveryPointResponse.Size(m)
+}
+func (m *GetRecoveryPointResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRecoveryPointResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRecoveryPointResponse proto.InternalMessageInfo
+
+func (m *GetRecoveryPointResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *GetRecoveryPointResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *GetRecoveryPointResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *GetRecoveryPointResponse) GetData() *ContinuousBackupStatus {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type JobInfo struct {
+	// id of the backup or restore task
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// backup or restore
+	Type      string       `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	StateCode JobStateCode `protobuf:"varint,3,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.JobStateCode" json:"state_code,omitempty"`
+	// percentage of the data copied or restored
+	Progress     int32  `protobuf:"varint,4,opt,name=progress,proto3" json:"progress,omitempty"`
+	ErrorMessage string `protobuf:"bytes,5,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	// unix seconds
+	StartTime  int64  `protobuf:"varint,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime    int64  `protobuf:"varint,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	BackupName string `protobuf:"bytes,8,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// bytes to copy or restore
+	Size int64 `protobuf:"varint,9,opt,name=size,proto3" json:"size,omitempty"`
+	// bytes copied or restored
+	CopiedSize int64 `protobuf:"varint,10,opt,name=copied_size,json=copiedSize,proto3" json:"copied_size,omitempty"`
+	// host:pid of the server running the job
+	Instance string `protobuf:"bytes,11,opt,name=instance,proto3" json:"instance,omitempty"`
+	// unix seconds the record of the job in backup storage is updated
+	UpdateTime int64 `protobuf:"varint,12,opt,name=update_time,json=updateTime,proto3" json:"update_time,omitempty"`
+	// bucket and root path of the backup restored from, empty if it is the backup storage of config
+	BucketName           string   `protobuf:"bytes,13,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	Path                 string   `protobuf:"bytes,14,opt,name=path,proto3" json:"path,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobInfo) Reset()         { *m = JobInfo{} }
+func (m *JobInfo) String() string { return proto.CompactTextString(m) }
+func (*JobInfo) ProtoMessage()    {}
+func (*JobInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{37}
+}
+
+func (m *JobInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobInfo.Unmarshal(m, b)
+}
+func (m *JobInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobInfo.Marshal(b, m, deterministic)
+}
+func (m *JobInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobInfo.Merge(m, src)
+}
+func (m *JobInfo) XXX_Size() int {
+	return xxx_messageInfo_JobInfo.Size(m)
+}
+func (m *JobInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobInfo proto.InternalMessageInfo
+
+func (m *JobInfo) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *JobInfo) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+func (m *JobInfo) GetStateCode() JobStateCode {
+	if m != nil {
+		return m.StateCode
+	}
+	return JobStateCode_JOB_RUNNING
+}
+
+func (m *JobInfo) GetProgress() int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *JobInfo) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *JobInfo) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *JobInfo) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *JobInfo) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *JobInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *JobInfo) GetCopiedSize() int64 {
+	if m != nil {
+		return m.CopiedSize
+	}
+	return 0
+}
+
+func (m *JobInfo) GetInstance() string {
+	if m != nil {
+		return m.Instance
+	}
+	return ""
+}
+
+func (m *JobInfo) GetUpdateTime() int64 {
+	if m != nil {
+		return m.UpdateTime
+	}
+	return 0
+}
+
+func (m *JobInfo) GetBucketName() string {
+	if m != nil {
+		return m.BucketName
+	}
+	return ""
+}
+
+func (m *JobInfo) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+type GetJobRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId            string   `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	JobId                string   `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetJobRequest) Reset()         { *m = GetJobRequest{} }
+func (m *GetJobRequest) String() string { return proto.CompactTextString(m) }
+func (*GetJobRequest) ProtoMessage()    {}
+func (*GetJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{38}
+}
+
+func (m *GetJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetJobRequest.Unmarshal(m, b)
+}
+func (m *GetJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetJobRequest.Marshal(b, m, deterministic)
+}
+func (m *GetJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetJobRequest.Merge(m, src)
+}
+func (m *GetJobRequest) XXX_Size() int {
+	return xxx_messageInfo_GetJobRequest.Size(m)
+}
+func (m *GetJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetJobRequest proto.InternalMessageInfo
+
+func (m *GetJobRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *GetJobRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type CancelJobRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId            string   `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	JobId                string   `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelJobRequest) Reset()         { *m = CancelJobRequest{} }
+func (m *CancelJobRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelJobRequest) ProtoMessage()    {}
+func (*CancelJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{39}
+}
+
+func (m *CancelJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelJobRequest.Unmarshal(m, b)
+}
+func (m *CancelJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelJobRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelJobRequest.Merge(m, src)
+}
+func (m *CancelJobRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelJobRequest.Size(m)
+}
+func (m *CancelJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelJobRequest proto.InternalMessageInfo
+
+func (m *CancelJobRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CancelJobRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type ListJobsRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// backup or restore, all if empty
+	Type                 string   `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ListJobsRequest) Reset()         { *m = ListJobsRequest{} }
+func (m *ListJobsRequest) String() string { return proto.CompactTextString(m) }
+func (*ListJobsRequest) ProtoMessage()    {}
+func (*ListJobsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{40}
+}
+
+func (m *ListJobsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsRequest.Unmarshal(m, b)
+}
+func (m *ListJobsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsRequest.Marshal(b, m, deterministic)
+}
+func (m *ListJobsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsRequest.Merge(m, src)
+}
+func (m *ListJobsRequest) XXX_Size() int {
+	return xxx_messageInfo_ListJobsRequest.Size(m)
+}
+func (m *ListJobsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsRequest proto.InternalMessageInfo
+
+func (m *ListJobsRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ListJobsRequest) GetType() string {
+	if m != nil {
+		return m.Type
+	}
+	return ""
+}
+
+type ListJobsResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// sorted by start time, the latest first
+	Data                 []*JobInfo `protobuf:"bytes,4,rep,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *ListJobsResponse) Reset()         { *m = ListJobsResponse{} }
+func (m *ListJobsResponse) String() string { return proto.CompactTextString(m) }
+func (*ListJobsResponse) ProtoMessage()    {}
+func (*ListJobsResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{41}
+}
+
+func (m *ListJobsResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ListJobsResponse.Unmarshal(m, b)
+}
+func (m *ListJobsResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ListJobsResponse.Marshal(b, m, deterministic)
+}
+func (m *ListJobsResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ListJobsResponse.Merge(m, src)
+}
+func (m *ListJobsResponse) XXX_Size() int {
+	return xxx_messageInfo_ListJobsResponse.Size(m)
+}
+func (m *ListJobsResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_ListJobsResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ListJobsResponse proto.InternalMessageInfo
+
+func (m *ListJobsResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ListJobsResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *ListJobsResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *ListJobsResponse) GetData() []*JobInfo {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type ResumeJobRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId            string   `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	JobId                string   `protobuf:"bytes,2,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ResumeJobRequest) Reset()         { *m = ResumeJobRequest{} }
+func (m *ResumeJobRequest) String() string { return proto.CompactTextString(m) }
+func (*ResumeJobRequest) ProtoMessage()    {}
+func (*ResumeJobRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{42}
+}
+
+func (m *ResumeJobRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ResumeJobRequest.Unmarshal(m, b)
+}
+func (m *ResumeJobRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ResumeJobRequest.Marshal(b, m, deterministic)
+}
+func (m *ResumeJobRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ResumeJobRequest.Merge(m, src)
+}
+func (m *ResumeJobRequest) XXX_Size() int {
+	return xxx_messageInfo_ResumeJobRequest.Size(m)
+}
+func (m *ResumeJobRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ResumeJobRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ResumeJobRequest proto.InternalMessageInfo
+
+func (m *ResumeJobRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *ResumeJobRequest) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type JobResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg                  string   `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	Data                 *JobInfo `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *JobResponse) Reset()         { *m = JobResponse{} }
+func (m *JobResponse) String() string { return proto.CompactTextString(m) }
+func (*JobResponse) ProtoMessage()    {}
+func (*JobResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{43}
+}
+
+func (m *JobResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_JobResponse.Unmarshal(m, b)
+}
+func (m *JobResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_JobResponse.Marshal(b, m, deterministic)
+}
+func (m *JobResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_JobResponse.Merge(m, src)
+}
+func (m *JobResponse) XXX_Size() int {
+	return xxx_messageInfo_JobResponse.Size(m)
+}
+func (m *JobResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_JobResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_JobResponse proto.InternalMessageInfo
+
+func (m *JobResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *JobResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *JobResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *JobResponse) GetData() *JobInfo {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type RestoreBackupRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// backup name to restore
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// collections to restore
+	CollectionNames []string `protobuf:"bytes,3,rep,name=collection_names,json=collectionNames,proto3" json:"collection_names,omitempty"`
+	// Support two ways to rename the collections while recover
+	// 1, set a suffix
+	CollectionSuffix string `protobuf:"bytes,4,opt,name=collection_suffix,json=collectionSuffix,proto3" json:"collection_suffix,omitempty"`
+	// 2, give a map to rename the collections, if not given, use the original name.
+	// collection_renames has higher priority than collection_suffix
+	CollectionRenames map[string]string `protobuf:"bytes,5,rep,name=collection_renames,json=collectionRenames,proto3" json:"collection_renames,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// execute asynchronously or not
+	Async bool `protobuf:"varint,6,opt,name=async,proto3" json:"async,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	BucketName string `protobuf:"bytes,7,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	Path string `protobuf:"bytes,8,opt,name=path,proto3" json:"path,omitempty"`
+	// database and collections to restore. A json string. To support database. 2023.7.7
+	DbCollections *_struct.Value `protobuf:"bytes,9,opt,name=db_collections,json=dbCollections,proto3" json:"db_collections,omitempty"`
+	// if true only restore meta, not restore data
+	MetaOnly bool `protobuf:"varint,10,opt,name=metaOnly,proto3" json:"metaOnly,omitempty"`
+	// if true restore index info
+	RestoreIndex bool `protobuf:"varint,11,opt,name=restoreIndex,proto3" json:"restoreIndex,omitempty"`
+	// if true use autoindex when restore vector index
+	UseAutoIndex bool `protobuf:"varint,12,opt,name=useAutoIndex,proto3" json:"useAutoIndex,omitempty"`
+	// if true, drop existing target collection before create
+	DropExistCollection bool `protobuf:"varint,13,opt,name=dropExistCollection,proto3" json:"dropExistCollection,omitempty"`
+	// if true, drop existing index of target collection before create
+	DropExistIndex bool `protobuf:"varint,14,opt,name=dropExistIndex,proto3" json:"dropExistIndex,omitempty"`
+	// if true, will skip collection, use when collection exist, restore index or data
+	SkipCreateCollection bool `protobuf:"varint,15,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
+	// restore data to a consistent point in time, physical timestamp in seconds, same as backup_physical_timestamp.
+	// data written after it will not be restored. 0 means restore to the backup timestamp of each collection
+	Timestamp uint64 `protobuf:"varint,16,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	// id of an interrupted restore task to resume, the data already bulk inserted is skipped.
+	// other options are taken from the restore task, backup_name, bucket_name and path should be the same as the task
+	ResumeTaskId string `protobuf:"bytes,17,opt,name=resume_task_id,json=resumeTaskId,proto3" json:"resume_task_id,omitempty"`
+	// collection level parallelism of this restore, 0 means backup.parallelism.restoreCollection in config
+	CollectionParallelism int32 `protobuf:"varint,18,opt,name=collection_parallelism,json=collectionParallelism,proto3" json:"collection_parallelism,omitempty"`
+	// segment groups bulk inserted at the same time across all collections of this restore, 0 means
+	// backup.bulkinsert.concurrency in config
+	BulkinsertParallelism int32 `protobuf:"varint,19,opt,name=bulkinsert_parallelism,json=bulkinsertParallelism,proto3" json:"bulkinsert_parallelism,omitempty"`
+	// restore users, roles and grants in backup as well
+	Rbac bool `protobuf:"varint,20,opt,name=rbac,proto3" json:"rbac,omitempty"`
+	// password of the users created by rbac restore, passwords are not backed up. users not exist are skipped if not set
+	RbacUserPassword string `protobuf:"bytes,21,opt,name=rbac_user_password,json=rbacUserPassword,proto3" json:"rbac_user_password,omitempty"`
+	// partitions to restore, key is collection name or db.collection_name in backup, collections not in it restore all partitions
+	Partitions map[string]*PartitionNames `protobuf:"bytes,22,rep,name=partitions,proto3" json:"partitions,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// template of target collection names, support {{db}}, {{name}} and {{date}}, like {{name}}_restored_{{date}}.
+	// collection_renames has higher priority, can't be used with collection_suffix
+	CollectionNameTemplate string `protobuf:"bytes,23,opt,name=collection_name_template,json=collectionNameTemplate,proto3" json:"collection_name_template,omitempty"`
+	// restore collections into this database instead of their original ones, created if not exist.
+	// collection_renames with database has higher priority
+	TargetDbName string `protobuf:"bytes,24,opt,name=target_db_name,json=targetDbName,proto3" json:"target_db_name,omitempty"`
+	// only check the restore and return the plan in data with a dry run report, nothing is written to milvus
+	DryRun bool `protobuf:"varint,25,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	// customer key of the backup encrypted by sse type customer, backup.serverSideEncryption.customerKey in config if not set
+	SseCustomerKey string `protobuf:"bytes,26,opt,name=sse_customer_key,json=sseCustomerKey,proto3" json:"sse_customer_key,omitempty"`
+	// how the schema of an existing collection with skip_create_collection may differ from backup, strict or compatible.
+	// strict by default: the fields should be the same as backup.
+	// compatible: fields only in backup are not restored, fields only in collection are filled by milvus
+	// if they are nullable or have a default value, fields of the same name are restored under their field ids of the collection
+	SchemaPolicy string `protobuf:"bytes,27,opt,name=schema_policy,json=schemaPolicy,proto3" json:"schema_policy,omitempty"`
+	// restore fields of backup into the fields of other names of the existing collection, backup field name -> collection field name
+	FieldMappings map[string]string `protobuf:"bytes,28,rep,name=field_mappings,json=fieldMappings,proto3" json:"field_mappings,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// when to create the indexes recorded in backup, immediate, deferred or skip.
+	// immediate: created with the collection before data is restored, milvus builds them while bulk inserting.
+	// deferred: created after all data of the collection is restored, indexes are built once.
+	// skip: indexes are not restored.
+	// immediate if restore_index is true and skip if not, when not set
+	IndexMode string `protobuf:"bytes,29,opt,name=index_mode,json=indexMode,proto3" json:"index_mode,omitempty"`
+	// how to restore the aliases of collections in backup, repoint, create or skip.
+	// repoint: aliases are created, or altered to point at the restored collections if they exist.
+	// create: aliases are created, the restore fails if an alias exists.
+	// skip by default: aliases are not restored.
+	// aliases are restored after the data of the collection is restored.
+	RestoreAliases string `protobuf:"bytes,30,opt,name=restore_aliases,json=restoreAliases,proto3" json:"restore_aliases,omitempty"`
+	// properties of collections set on restore over the properties in backup, like collection.ttl.seconds: 0 to
+	// keep old data from expiring
+	CollectionProperties map[string]string `protobuf:"bytes,31,rep,name=collection_properties,json=collectionProperties,proto3" json:"collection_properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// load the restored collections loaded when backed up, after their data and indexes are restored
+	LoadCollection bool `protobuf:"varint,32,opt,name=load_collection,json=loadCollection,proto3" json:"load_collection,omitempty"`
+	// replicas to load the collections with if load_collection, the replica number in backup if not set
+	ReplicaNumber int32 `protobuf:"varint,33,opt,name=replica_number,json=replicaNumber,proto3" json:"replica_number,omitempty"`
+	// resource groups to load the collections into if load_collection, the resource groups in backup if not set
+	ResourceGroups []string `protobuf:"bytes,34,rep,name=resource_groups,json=resourceGroups,proto3" json:"resource_groups,omitempty"`
+	// check the sizes of the files to restore against the manifest of backup before restoring anything, the restore
+	// fails at once if any file is missing or its size mismatches
+	CheckManifest bool `protobuf:"varint,35,opt,name=check_manifest,json=checkManifest,proto3" json:"check_manifest,omitempty"`
+	// restore the latest successful backup whose labels match the selector if backup_name is not set, otherwise
+	// the backup named must match it
+	LabelSelector string `protobuf:"bytes,36,opt,name=label_selector,json=labelSelector,proto3" json:"label_selector,omitempty"`
+	// restore the collections using features the target milvus doesn't support without them, like the fields of types
+	// not supported, whose data is not restored. the restore fails if a collection uses such features and it is not set
+	DowngradeFeatures bool `protobuf:"varint,37,opt,name=downgrade_features,json=downgradeFeatures,proto3" json:"downgrade_features,omitempty"`
+	// compare the rows of each partition restored in milvus with the rows in backup after the data is restored,
+	// discrepancies are recorded in row_count_checks of the collection tasks
+	CheckRowCount bool `protobuf:"varint,38,opt,name=check_row_count,json=checkRowCount,proto3" json:"check_row_count,omitempty"`
+	// rows sampled in backup to query in each restored collection after it is restored and loaded, the fields are
+	// compared with backup and the result is in sample_verification of the collection tasks
+	VerifySampleRows     int32    `protobuf:"varint,39,opt,name=verify_sample_rows,json=verifySampleRows,proto3" json:"verify_sample_rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBackupRequest) Reset()         { *m = RestoreBackupRequest{} }
+func (m *RestoreBackupRequest) String() string { return proto.CompactTextString(m) }
+func (*RestoreBackupRequest) ProtoMessage()    {}
+func (*RestoreBackupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{44}
+}
+
+func (m *RestoreBackupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBackupRequest.Unmarshal(m, b)
+}
+func (m *RestoreBackupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBackupRequest.Marshal(b, m, deterministic)
+}
+func (m *RestoreBackupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBackupRequest.Merge(m, src)
+}
+func (m *RestoreBackupRequest) XXX_Size() int {
+	return xxx_messageInfo_RestoreBackupRequest.Size(m)
+}
+func (m *RestoreBackupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBackupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBackupRequest proto.InternalMessageInfo
+
+func (m *RestoreBackupRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetCollectionNames() []string {
+	if m != nil {
+		return m.CollectionNames
+	}
+	return nil
+}
+
+func (m *RestoreBackupRequest) GetCollectionSuffix() string {
+	if m != nil {
+		return m.CollectionSuffix
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetCollectionRenames() map[string]string {
+	if m != nil {
+		return m.CollectionRenames
+	}
+	return nil
+}
+
+func (m *RestoreBackupRequest) GetAsync() bool {
+	if m != nil {
+		return m.Async
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetBucketName() string {
+	if m != nil {
+		return m.BucketName
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetDbCollections() *_struct.Value {
+	if m != nil {
+		return m.DbCollections
+	}
+	return nil
+}
+
+func (m *RestoreBackupRequest) GetMetaOnly() bool {
+	if m != nil {
+		return m.MetaOnly
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetRestoreIndex() bool {
+	if m != nil {
+		return m.RestoreIndex
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetUseAutoIndex() bool {
+	if m != nil {
+		return m.UseAutoIndex
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetDropExistCollection() bool {
+	if m != nil {
+		return m.DropExistCollection
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetDropExistIndex() bool {
+	if m != nil {
+		return m.DropExistIndex
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetSkipCreateCollection() bool {
+	if m != nil {
+		return m.SkipCreateCollection
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetTimestamp() uint64 {
+	if m != nil {
+		return m.Timestamp
+	}
+	return 0
+}
+
+func (m *RestoreBackupRequest) GetResumeTaskId() string {
+	if m != nil {
+		return m.ResumeTaskId
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetCollectionParallelism() int32 {
+	if m != nil {
+		return m.CollectionParallelism
+	}
+	return 0
+}
+
+func (m *RestoreBackupRequest) GetBulkinsertParallelism() int32 {
+	if m != nil {
+		return m.BulkinsertParallelism
+	}
+	return 0
+}
+
+func (m *RestoreBackupRequest) GetRbac() bool {
+	if m != nil {
+		return m.Rbac
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetRbacUserPassword() string {
+	if m != nil {
+		return m.RbacUserPassword
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetPartitions() map[string]*PartitionNames {
+	if m != nil {
+		return m.Partitions
+	}
+	return nil
+}
+
+func (m *RestoreBackupRequest) GetCollectionNameTemplate() string {
+	if m != nil {
+		return m.CollectionNameTemplate
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetTargetDbName() string {
+	if m != nil {
+		return m.TargetDbName
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetDryRun() bool {
+	if m != nil {
+		return m.DryRun
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetSseCustomerKey() string {
+	if m != nil {
+		return m.SseCustomerKey
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetSchemaPolicy() string {
+	if m != nil {
+		return m.SchemaPolicy
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetFieldMappings() map[string]string {
+	if m != nil {
+		return m.FieldMappings
+	}
+	return nil
+}
+
+func (m *RestoreBackupRequest) GetIndexMode() string {
+	if m != nil {
+		return m.IndexMode
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetRestoreAliases() string {
+	if m != nil {
+		return m.RestoreAliases
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetCollectionProperties() map[string]string {
+	if m != nil {
+		return m.CollectionProperties
+	}
+	return nil
+}
+
+func (m *RestoreBackupRequest) GetLoadCollection() bool {
+	if m != nil {
+		return m.LoadCollection
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetReplicaNumber() int32 {
+	if m != nil {
+		return m.ReplicaNumber
+	}
+	return 0
+}
+
+func (m *RestoreBackupRequest) GetResourceGroups() []string {
+	if m != nil {
+		return m.ResourceGroups
+	}
+	return nil
+}
+
+func (m *RestoreBackupRequest) GetCheckManifest() bool {
+	if m != nil {
+		return m.CheckManifest
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetLabelSelector() string {
+	if m != nil {
+		return m.LabelSelector
+	}
+	return ""
+}
+
+func (m *RestoreBackupRequest) GetDowngradeFeatures() bool {
+	if m != nil {
+		return m.DowngradeFeatures
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetCheckRowCount() bool {
+	if m != nil {
+		return m.CheckRowCount
+	}
+	return false
+}
+
+func (m *RestoreBackupRequest) GetVerifySampleRows() int32 {
+	if m != nil {
+		return m.VerifySampleRows
+	}
+	return 0
+}
+
+type RestorePartitionTask struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StateCode            RestoreTaskStateCode `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code,omitempty"`
+	ErrorMessage         string               `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	StartTime            int64                `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              int64                `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	Progress             int32                `protobuf:"varint,6,opt,name=progress,proto3" json:"progress,omitempty"`
+	PartBackup           *PartitionBackupInfo `protobuf:"bytes,7,opt,name=part_backup,json=partBackup,proto3" json:"part_backup,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *RestorePartitionTask) Reset()         { *m = RestorePartitionTask{} }
+func (m *RestorePartitionTask) String() string { return proto.CompactTextString(m) }
+func (*RestorePartitionTask) ProtoMessage()    {}
+func (*RestorePartitionTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{45}
+}
+
+func (m *RestorePartitionTask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestorePartitionTask.Unmarshal(m, b)
+}
+func (m *RestorePartitionTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestorePartitionTask.Marshal(b, m, deterministic)
+}
+func (m *RestorePartitionTask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestorePartitionTask.Merge(m, src)
+}
+func (m *RestorePartitionTask) XXX_Size() int {
+	return xxx_messageInfo_RestorePartitionTask.Size(m)
+}
+func (m *RestorePartitionTask) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestorePartitionTask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestorePartitionTask proto.InternalMessageInfo
+
+func (m *RestorePartitionTask) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RestorePartitionTask) GetStateCode() RestoreTaskStateCode {
+	if m != nil {
+		return m.StateCode
+	}
+	return RestoreTaskStateCode_INITIAL
+}
+
+func (m *RestorePartitionTask) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *RestorePartitionTask) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RestorePartitionTask) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *RestorePartitionTask) GetProgress() int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *RestorePartitionTask) GetPartBackup() *PartitionBackupInfo {
+	if m != nil {
+		return m.PartBackup
+	}
+	return nil
+}
+
+type RestoreCollectionTask struct {
+	Id                    string                  `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StateCode             RestoreTaskStateCode    `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code,omitempty"`
+	ErrorMessage          string                  `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	StartTime             int64                   `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime               int64                   `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	CollBackup            *CollectionBackupInfo   `protobuf:"bytes,6,opt,name=coll_backup,json=collBackup,proto3" json:"coll_backup,omitempty"`
+	TargetCollectionName  string                  `protobuf:"bytes,7,opt,name=target_collection_name,json=targetCollectionName,proto3" json:"target_collection_name,omitempty"`
+	PartitionRestoreTasks []*RestorePartitionTask `protobuf:"bytes,8,rep,name=partition_restore_tasks,json=partitionRestoreTasks,proto3" json:"partition_restore_tasks,omitempty"`
+	RestoredSize          int64                   `protobuf:"varint,9,opt,name=restored_size,json=restoredSize,proto3" json:"restored_size,omitempty"`
+	ToRestoreSize         int64                   `protobuf:"varint,10,opt,name=to_restore_size,json=toRestoreSize,proto3" json:"to_restore_size,omitempty"`
+	Progress              int32                   `protobuf:"varint,11,opt,name=progress,proto3" json:"progress,omitempty"`
+	TargetDbName          string                  `protobuf:"bytes,12,opt,name=target_db_name,json=targetDbName,proto3" json:"target_db_name,omitempty"`
+	// if true only restore meta
+	MetaOnly bool `protobuf:"varint,13,opt,name=metaOnly,proto3" json:"metaOnly,omitempty"`
+	// if true restore index info
+	RestoreIndex bool `protobuf:"varint,14,opt,name=restoreIndex,proto3" json:"restoreIndex,omitempty"`
+	// if true use autoindex when restore vector index
+	UseAutoIndex bool `protobuf:"varint,15,opt,name=useAutoIndex,proto3" json:"useAutoIndex,omitempty"`
+	// if true drop the collections
+	DropExistCollection bool `protobuf:"varint,16,opt,name=dropExistCollection,proto3" json:"dropExistCollection,omitempty"`
+	// if true drop index info
+	DropExistIndex bool `protobuf:"varint,17,opt,name=dropExistIndex,proto3" json:"dropExistIndex,omitempty"`
+	// if true will skip create collections
+	SkipCreateCollection bool `protobuf:"varint,18,opt,name=skipCreateCollection,proto3" json:"skipCreateCollection,omitempty"`
+	// restore data to this point in time, see RestoreBackupRequest.timestamp
+	RestoreTimestamp uint64 `protobuf:"varint,19,opt,name=restore_timestamp,json=restoreTimestamp,proto3" json:"restore_timestamp,omitempty"`
+	// if true the collection and index have been created, skipped when resume
+	MetaRestored bool `protobuf:"varint,20,opt,name=meta_restored,json=metaRestored,proto3" json:"meta_restored,omitempty"`
+	// partitionID/groupID of the segment groups which have been bulk inserted, skipped when resume
+	RestoredGroups []string `protobuf:"bytes,21,rep,name=restored_groups,json=restoredGroups,proto3" json:"restored_groups,omitempty"`
+	// field id in backup -> field id of the existing collection, for the fields whose ids differ
+	FieldIdMappings map[int64]int64 `protobuf:"bytes,22,rep,name=field_id_mappings,json=fieldIdMappings,proto3" json:"field_id_mappings,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	// ids of the fields in backup not restored into the existing collection
+	SkippedFieldIds []int64 `protobuf:"varint,23,rep,packed,name=skipped_field_ids,json=skippedFieldIds,proto3" json:"skipped_field_ids,omitempty"`
+	// when to create the indexes, see RestoreBackupRequest.index_mode
+	IndexMode string `protobuf:"bytes,24,opt,name=index_mode,json=indexMode,proto3" json:"index_mode,omitempty"`
+	// how to restore the aliases, see RestoreBackupRequest.restore_aliases
+	RestoreAliases string `protobuf:"bytes,25,opt,name=restore_aliases,json=restoreAliases,proto3" json:"restore_aliases,omitempty"`
+	// properties to set on the collection, the properties in backup with the overrides of the request
+	Properties map[string]string `protobuf:"bytes,26,rep,name=properties,proto3" json:"properties,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	// load the collection with the replicas in the resource groups after it is restored
+	LoadCollection bool     `protobuf:"varint,27,opt,name=load_collection,json=loadCollection,proto3" json:"load_collection,omitempty"`
+	ReplicaNumber  int32    `protobuf:"varint,28,opt,name=replica_number,json=replicaNumber,proto3" json:"replica_number,omitempty"`
+	ResourceGroups []string `protobuf:"bytes,29,rep,name=resource_groups,json=resourceGroups,proto3" json:"resource_groups,omitempty"`
+	// import tasks of milvus executed to bulk insert the data of the collection
+	BulkInsertJobs []*BulkInsertJob `protobuf:"bytes,30,rep,name=bulk_insert_jobs,json=bulkInsertJobs,proto3" json:"bulk_insert_jobs,omitempty"`
+	// true if the binlogs are bulk inserted from backup in place, without copying them into milvus bucket first
+	InPlace bool `protobuf:"varint,31,opt,name=in_place,json=inPlace,proto3" json:"in_place,omitempty"`
+	// features of the collection in backup not supported by the target milvus, restored without them
+	DowngradedFeatures []*CompatIssue `protobuf:"bytes,32,rep,name=downgraded_features,json=downgradedFeatures,proto3" json:"downgraded_features,omitempty"`
+	// compare the rows restored with the rows in backup after the data is restored
+	CheckRowCount  bool             `protobuf:"varint,33,opt,name=check_row_count,json=checkRowCount,proto3" json:"check_row_count,omitempty"`
+	RowCountChecks []*RowCountCheck `protobuf:"bytes,34,rep,name=row_count_checks,json=rowCountChecks,proto3" json:"row_count_checks,omitempty"`
+	// rows sampled in backup to verify after the collection is restored
+	VerifySampleRows     int32               `protobuf:"varint,35,opt,name=verify_sample_rows,json=verifySampleRows,proto3" json:"verify_sample_rows,omitempty"`
+	SampleVerification   *SampleVerification `protobuf:"bytes,36,opt,name=sample_verification,json=sampleVerification,proto3" json:"sample_verification,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *RestoreCollectionTask) Reset()         { *m = RestoreCollectionTask{} }
+func (m *RestoreCollectionTask) String() string { return proto.CompactTextString(m) }
+func (*RestoreCollectionTask) ProtoMessage()    {}
+func (*RestoreCollectionTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{46}
+}
+
+func (m *RestoreCollectionTask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreCollectionTask.Unmarshal(m, b)
+}
+func (m *RestoreCollectionTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreCollectionTask.Marshal(b, m, deterministic)
+}
+func (m *RestoreCollectionTask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreCollectionTask.Merge(m, src)
+}
+func (m *RestoreCollectionTask) XXX_Size() int {
+	return xxx_messageInfo_RestoreCollectionTask.Size(m)
+}
+func (m *RestoreCollectionTask) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreCollectionTask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreCollectionTask proto.InternalMessageInfo
+
+func (m *RestoreCollectionTask) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RestoreCollectionTask) GetStateCode() RestoreTaskStateCode {
+	if m != nil {
+		return m.StateCode
+	}
+	return RestoreTaskStateCode_INITIAL
+}
+
+func (m *RestoreCollectionTask) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *RestoreCollectionTask) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetCollBackup() *CollectionBackupInfo {
+	if m != nil {
+		return m.CollBackup
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetTargetCollectionName() string {
+	if m != nil {
+		return m.TargetCollectionName
+	}
+	return ""
+}
+
+func (m *RestoreCollectionTask) GetPartitionRestoreTasks() []*RestorePartitionTask {
+	if m != nil {
+		return m.PartitionRestoreTasks
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetRestoredSize() int64 {
+	if m != nil {
+		return m.RestoredSize
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetToRestoreSize() int64 {
+	if m != nil {
+		return m.ToRestoreSize
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetProgress() int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetTargetDbName() string {
+	if m != nil {
+		return m.TargetDbName
+	}
+	return ""
+}
+
+func (m *RestoreCollectionTask) GetMetaOnly() bool {
+	if m != nil {
+		return m.MetaOnly
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetRestoreIndex() bool {
+	if m != nil {
+		return m.RestoreIndex
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetUseAutoIndex() bool {
+	if m != nil {
+		return m.UseAutoIndex
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetDropExistCollection() bool {
+	if m != nil {
+		return m.DropExistCollection
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetDropExistIndex() bool {
+	if m != nil {
+		return m.DropExistIndex
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetSkipCreateCollection() bool {
+	if m != nil {
+		return m.SkipCreateCollection
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetRestoreTimestamp() uint64 {
+	if m != nil {
+		return m.RestoreTimestamp
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetMetaRestored() bool {
+	if m != nil {
+		return m.MetaRestored
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetRestoredGroups() []string {
+	if m != nil {
+		return m.RestoredGroups
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetFieldIdMappings() map[int64]int64 {
+	if m != nil {
+		return m.FieldIdMappings
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetSkippedFieldIds() []int64 {
+	if m != nil {
+		return m.SkippedFieldIds
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetIndexMode() string {
+	if m != nil {
+		return m.IndexMode
+	}
+	return ""
+}
+
+func (m *RestoreCollectionTask) GetRestoreAliases() string {
+	if m != nil {
+		return m.RestoreAliases
+	}
+	return ""
+}
+
+func (m *RestoreCollectionTask) GetProperties() map[string]string {
+	if m != nil {
+		return m.Properties
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetLoadCollection() bool {
+	if m != nil {
+		return m.LoadCollection
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetReplicaNumber() int32 {
+	if m != nil {
+		return m.ReplicaNumber
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetResourceGroups() []string {
+	if m != nil {
+		return m.ResourceGroups
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetBulkInsertJobs() []*BulkInsertJob {
+	if m != nil {
+		return m.BulkInsertJobs
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetInPlace() bool {
+	if m != nil {
+		return m.InPlace
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetDowngradedFeatures() []*CompatIssue {
+	if m != nil {
+		return m.DowngradedFeatures
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetCheckRowCount() bool {
+	if m != nil {
+		return m.CheckRowCount
+	}
+	return false
+}
+
+func (m *RestoreCollectionTask) GetRowCountChecks() []*RowCountCheck {
+	if m != nil {
+		return m.RowCountChecks
+	}
+	return nil
+}
+
+func (m *RestoreCollectionTask) GetVerifySampleRows() int32 {
+	if m != nil {
+		return m.VerifySampleRows
+	}
+	return 0
+}
+
+func (m *RestoreCollectionTask) GetSampleVerification() *SampleVerification {
+	if m != nil {
+		return m.SampleVerification
+	}
+	return nil
+}
+
+// SampleVerification is the result of querying the rows sampled in backup in the restored collection
+type SampleVerification struct {
+	// verified, or skipped with the reason in detail
+	State  string `protobuf:"bytes,1,opt,name=state,proto3" json:"state,omitempty"`
+	Detail string `protobuf:"bytes,2,opt,name=detail,proto3" json:"detail,omitempty"`
+	// rows sampled in backup and queried
+	CheckedRows int32 `protobuf:"varint,3,opt,name=checked_rows,json=checkedRows,proto3" json:"checked_rows,omitempty"`
+	// rows found with all the fields equal to backup
+	MatchedRows int32 `protobuf:"varint,4,opt,name=matched_rows,json=matchedRows,proto3" json:"matched_rows,omitempty"`
+	// matched_rows / checked_rows, 1 if all the rows match
+	Score float64 `protobuf:"fixed64,5,opt,name=score,proto3" json:"score,omitempty"`
+	// primary keys of the rows not found in the restored collection
+	MissingPks []string `protobuf:"bytes,6,rep,name=missing_pks,json=missingPks,proto3" json:"missing_pks,omitempty"`
+	// primary keys of the rows whose fields differ, with the fields, like 12: vector, age
+	MismatchedRows       []string `protobuf:"bytes,7,rep,name=mismatched_rows,json=mismatchedRows,proto3" json:"mismatched_rows,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SampleVerification) Reset()         { *m = SampleVerification{} }
+func (m *SampleVerification) String() string { return proto.CompactTextString(m) }
+func (*SampleVerification) ProtoMessage()    {}
+func (*SampleVerification) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{47}
+}
+
+func (m *SampleVerification) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SampleVerification.Unmarshal(m, b)
+}
+func (m *SampleVerification) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SampleVerification.Marshal(b, m, deterministic)
+}
+func (m *SampleVerification) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SampleVerification.Merge(m, src)
+}
+func (m *SampleVerification) XXX_Size() int {
+	return xxx_messageInfo_SampleVerification.Size(m)
+}
+func (m *SampleVerification) XXX_DiscardUnknown() {
+	xxx_messageInfo_SampleVerification.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SampleVerification proto.InternalMessageInfo
+
+func (m *SampleVerification) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *SampleVerification) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+func (m *SampleVerification) GetCheckedRows() int32 {
+	if m != nil {
+		return m.CheckedRows
+	}
+	return 0
+}
+
+func (m *SampleVerification) GetMatchedRows() int32 {
+	if m != nil {
+		return m.MatchedRows
+	}
+	return 0
+}
+
+func (m *SampleVerification) GetScore() float64 {
+	if m != nil {
+		return m.Score
+	}
+	return 0
+}
+
+func (m *SampleVerification) GetMissingPks() []string {
+	if m != nil {
+		return m.MissingPks
+	}
+	return nil
+}
+
+func (m *SampleVerification) GetMismatchedRows() []string {
+	if m != nil {
+		return m.MismatchedRows
+	}
+	return nil
+}
+
+// BulkInsertJob is an import task of milvus executed to restore a segment group
+type BulkInsertJob struct {
+	// id of the import task in milvus
+	TaskId        int64  `protobuf:"varint,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	PartitionName string `protobuf:"bytes,2,opt,name=partition_name,json=partitionName,proto3" json:"partition_name,omitempty"`
+	GroupId       int64  `protobuf:"varint,3,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	// executing, persisted, completed, failed or timeout
+	State        string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	ErrorMessage string `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// attempt of the segment group the task is executed by, starting from 1
+	Attempt              int32    `protobuf:"varint,6,opt,name=attempt,proto3" json:"attempt,omitempty"`
+	StartTime            int64    `protobuf:"varint,7,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime              int64    `protobuf:"varint,8,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BulkInsertJob) Reset()         { *m = BulkInsertJob{} }
+func (m *BulkInsertJob) String() string { return proto.CompactTextString(m) }
+func (*BulkInsertJob) ProtoMessage()    {}
+func (*BulkInsertJob) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{48}
+}
+
+func (m *BulkInsertJob) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BulkInsertJob.Unmarshal(m, b)
+}
+func (m *BulkInsertJob) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BulkInsertJob.Marshal(b, m, deterministic)
+}
+func (m *BulkInsertJob) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BulkInsertJob.Merge(m, src)
+}
+func (m *BulkInsertJob) XXX_Size() int {
+	return xxx_messageInfo_BulkInsertJob.Size(m)
+}
+func (m *BulkInsertJob) XXX_DiscardUnknown() {
+	xxx_messageInfo_BulkInsertJob.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BulkInsertJob proto.InternalMessageInfo
+
+func (m *BulkInsertJob) GetTaskId() int64 {
+	if m != nil {
+		return m.TaskId
+	}
+	return 0
+}
+
+func (m *BulkInsertJob) GetPartitionName() string {
+	if m != nil {
+		return m.PartitionName
+	}
+	return ""
+}
+
+func (m *BulkInsertJob) GetGroupId() int64 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+func (m *BulkInsertJob) GetState() string {
+	if m != nil {
+		return m.State
+	}
+	return ""
+}
+
+func (m *BulkInsertJob) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *BulkInsertJob) GetAttempt() int32 {
+	if m != nil {
+		return m.Attempt
+	}
+	return 0
+}
+
+func (m *BulkInsertJob) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *BulkInsertJob) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+type RestoreBackupTask struct {
+	Id                     string                   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	StateCode              RestoreTaskStateCode     `protobuf:"varint,2,opt,name=state_code,json=stateCode,proto3,enum=milvus.proto.backup.RestoreTaskStateCode" json:"state_code,omitempty"`
+	ErrorMessage           string                   `protobuf:"bytes,3,opt,name=errorMessage,proto3" json:"errorMessage,omitempty"`
+	StartTime              int64                    `protobuf:"varint,4,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime                int64                    `protobuf:"varint,5,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	CollectionRestoreTasks []*RestoreCollectionTask `protobuf:"bytes,6,rep,name=collection_restore_tasks,json=collectionRestoreTasks,proto3" json:"collection_restore_tasks,omitempty"`
+	RestoredSize           int64                    `protobuf:"varint,7,opt,name=restored_size,json=restoredSize,proto3" json:"restored_size,omitempty"`
+	ToRestoreSize          int64                    `protobuf:"varint,8,opt,name=to_restore_size,json=toRestoreSize,proto3" json:"to_restore_size,omitempty"`
+	Progress               int32                    `protobuf:"varint,9,opt,name=progress,proto3" json:"progress,omitempty"`
+	// db.collection_name of the collections in backup not restored, not requested or failed in a partial backup
+	SkippedCollections []string `protobuf:"bytes,10,rep,name=skipped_collections,json=skippedCollections,proto3" json:"skipped_collections,omitempty"`
+	// name of the restored backup, the one selected by label_selector of the request if backup_name is not set
+	BackupName           string   `protobuf:"bytes,11,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBackupTask) Reset()         { *m = RestoreBackupTask{} }
+func (m *RestoreBackupTask) String() string { return proto.CompactTextString(m) }
+func (*RestoreBackupTask) ProtoMessage()    {}
+func (*RestoreBackupTask) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{49}
+}
+
+func (m *RestoreBackupTask) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBackupTask.Unmarshal(m, b)
+}
+func (m *RestoreBackupTask) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBackupTask.Marshal(b, m, deterministic)
+}
+func (m *RestoreBackupTask) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBackupTask.Merge(m, src)
+}
+func (m *RestoreBackupTask) XXX_Size() int {
+	return xxx_messageInfo_RestoreBackupTask.Size(m)
+}
+func (m *RestoreBackupTask) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBackupTask.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBackupTask proto.InternalMessageInfo
+
+func (m *RestoreBackupTask) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *RestoreBackupTask) GetStateCode() RestoreTaskStateCode {
+	if m != nil {
+		return m.StateCode
+	}
+	return RestoreTaskStateCode_INITIAL
+}
+
+func (m *RestoreBackupTask) GetErrorMessage() string {
+	if m != nil {
+		return m.ErrorMessage
+	}
+	return ""
+}
+
+func (m *RestoreBackupTask) GetStartTime() int64 {
+	if m != nil {
+		return m.StartTime
+	}
+	return 0
+}
+
+func (m *RestoreBackupTask) GetEndTime() int64 {
+	if m != nil {
+		return m.EndTime
+	}
+	return 0
+}
+
+func (m *RestoreBackupTask) GetCollectionRestoreTasks() []*RestoreCollectionTask {
+	if m != nil {
+		return m.CollectionRestoreTasks
+	}
+	return nil
+}
+
+func (m *RestoreBackupTask) GetRestoredSize() int64 {
+	if m != nil {
+		return m.RestoredSize
+	}
+	return 0
+}
+
+func (m *RestoreBackupTask) GetToRestoreSize() int64 {
+	if m != nil {
+		return m.ToRestoreSize
+	}
+	return 0
+}
+
+func (m *RestoreBackupTask) GetProgress() int32 {
+	if m != nil {
+		return m.Progress
+	}
+	return 0
+}
+
+func (m *RestoreBackupTask) GetSkippedCollections() []string {
+	if m != nil {
+		return m.SkippedCollections
+	}
+	return nil
+}
+
+func (m *RestoreBackupTask) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+type RestoreBackupResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// restore task info entity
+	Data *RestoreBackupTask `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`
+	// problems found by a dry run restore
+	DryRunReport *RestoreDryRunReport `protobuf:"bytes,5,opt,name=dry_run_report,json=dryRunReport,proto3" json:"dry_run_report,omitempty"`
+	// id of the job executing the restore, to query or cancel it by the job api
+	JobId                string   `protobuf:"bytes,6,opt,name=job_id,json=jobId,proto3" json:"job_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RestoreBackupResponse) Reset()         { *m = RestoreBackupResponse{} }
+func (m *RestoreBackupResponse) String() string { return proto.CompactTextString(m) }
+func (*RestoreBackupResponse) ProtoMessage()    {}
+func (*RestoreBackupResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{50}
+}
+
+func (m *RestoreBackupResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreBackupResponse.Unmarshal(m, b)
+}
+func (m *RestoreBackupResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreBackupResponse.Marshal(b, m, deterministic)
+}
+func (m *RestoreBackupResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreBackupResponse.Merge(m, src)
+}
+func (m *RestoreBackupResponse) XXX_Size() int {
+	return xxx_messageInfo_RestoreBackupResponse.Size(m)
+}
+func (m *RestoreBackupResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreBackupResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreBackupResponse proto.InternalMessageInfo
+
+func (m *RestoreBackupResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *RestoreBackupResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *RestoreBackupResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *RestoreBackupResponse) GetData() *RestoreBackupTask {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *RestoreBackupResponse) GetDryRunReport() *RestoreDryRunReport {
+	if m != nil {
+		return m.DryRunReport
+	}
+	return nil
+}
+
+func (m *RestoreBackupResponse) GetJobId() string {
+	if m != nil {
+		return m.JobId
+	}
+	return ""
+}
+
+type RestoreDryRunReport struct {
+	// target collections already exist
+	Conflicts []string `protobuf:"bytes,1,rep,name=conflicts,proto3" json:"conflicts,omitempty"`
+	// target collections exist with a schema different from backup, checked when skip_create_collection
+	SchemaMismatches []string `protobuf:"bytes,2,rep,name=schema_mismatches,json=schemaMismatches,proto3" json:"schema_mismatches,omitempty"`
+	// target databases not exist, will be created by restore
+	DatabasesToCreate []string `protobuf:"bytes,3,rep,name=databases_to_create,json=databasesToCreate,proto3" json:"databases_to_create,omitempty"`
+	// backup paths of partitions not found in backup storage
+	MissingFiles []string `protobuf:"bytes,4,rep,name=missing_files,json=missingFiles,proto3" json:"missing_files,omitempty"`
+	// estimated seconds to copy the data at backup.bandwidthLimit, 0 if the bandwidth is unlimited
+	EstimatedSeconds int64 `protobuf:"varint,5,opt,name=estimated_seconds,json=estimatedSeconds,proto3" json:"estimated_seconds,omitempty"`
+	// aliases in backup already exist in the target databases, checked when restore_aliases is create
+	AliasConflicts []string `protobuf:"bytes,6,rep,name=alias_conflicts,json=aliasConflicts,proto3" json:"alias_conflicts,omitempty"`
+	// existing partition key collections whose partitions differ from backup, rows are dispatched into their
+	// partitions by the partition key instead of restored partition by partition
+	Repartitioned []string `protobuf:"bytes,7,rep,name=repartitioned,proto3" json:"repartitioned,omitempty"`
+	// files whose size mismatches the manifest of backup, checked when check_manifest
+	CorruptedFiles []string `protobuf:"bytes,8,rep,name=corrupted_files,json=corruptedFiles,proto3" json:"corrupted_files,omitempty"`
+	// features of the collections not supported by the target milvus
+	CompatIssues         []*CompatIssue `protobuf:"bytes,9,rep,name=compat_issues,json=compatIssues,proto3" json:"compat_issues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *RestoreDryRunReport) Reset()         { *m = RestoreDryRunReport{} }
+func (m *RestoreDryRunReport) String() string { return proto.CompactTextString(m) }
+func (*RestoreDryRunReport) ProtoMessage()    {}
+func (*RestoreDryRunReport) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{51}
+}
+
+func (m *RestoreDryRunReport) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RestoreDryRunReport.Unmarshal(m, b)
+}
+func (m *RestoreDryRunReport) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RestoreDryRunReport.Marshal(b, m, deterministic)
+}
+func (m *RestoreDryRunReport) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RestoreDryRunReport.Merge(m, src)
+}
+func (m *RestoreDryRunReport) XXX_Size() int {
+	return xxx_messageInfo_RestoreDryRunReport.Size(m)
+}
+func (m *RestoreDryRunReport) XXX_DiscardUnknown() {
+	xxx_messageInfo_RestoreDryRunReport.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RestoreDryRunReport proto.InternalMessageInfo
+
+func (m *RestoreDryRunReport) GetConflicts() []string {
+	if m != nil {
+		return m.Conflicts
+	}
+	return nil
+}
+
+func (m *RestoreDryRunReport) GetSchemaMismatches() []string {
+	if m != nil {
+		return m.SchemaMismatches
+	}
+	return nil
+}
+
+func (m *RestoreDryRunReport) GetDatabasesToCreate() []string {
+	if m != nil {
+		return m.DatabasesToCreate
+	}
+	return nil
+}
+
+func (m *RestoreDryRunReport) GetMissingFiles() []string {
+	if m != nil {
+		return m.MissingFiles
+	}
+	return nil
+}
+
+func (m *RestoreDryRunReport) GetEstimatedSeconds() int64 {
+	if m != nil {
+		return m.EstimatedSeconds
+	}
+	return 0
+}
+
+func (m *RestoreDryRunReport) GetAliasConflicts() []string {
+	if m != nil {
+		return m.AliasConflicts
+	}
+	return nil
+}
+
+func (m *RestoreDryRunReport) GetRepartitioned() []string {
+	if m != nil {
+		return m.Repartitioned
+	}
+	return nil
+}
+
+func (m *RestoreDryRunReport) GetCorruptedFiles() []string {
+	if m != nil {
+		return m.CorruptedFiles
+	}
+	return nil
+}
+
+func (m *RestoreDryRunReport) GetCompatIssues() []*CompatIssue {
+	if m != nil {
+		return m.CompatIssues
+	}
+	return nil
+}
+
+type GetRestoreStateRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// restore task id to query
+	Id                   string   `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetRestoreStateRequest) Reset()         { *m = GetRestoreStateRequest{} }
+func (m *GetRestoreStateRequest) String() string { return proto.CompactTextString(m) }
+func (*GetRestoreStateRequest) ProtoMessage()    {}
+func (*GetRestoreStateRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{52}
+}
+
+func (m *GetRestoreStateRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetRestoreStateRequest.Unmarshal(m, b)
+}
+func (m *GetRestoreStateRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetRestoreStateRequest.Marshal(b, m, deterministic)
+}
+func (m *GetRestoreStateRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetRestoreStateRequest.Merge(m, src)
+}
+func (m *GetRestoreStateRequest) XXX_Size() int {
+	return xxx_messageInfo_GetRestoreStateRequest.Size(m)
+}
+func (m *GetRestoreStateRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetRestoreStateRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetRestoreStateRequest proto.InternalMessageInfo
+
+func (m *GetRestoreStateRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *GetRestoreStateRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+// copied from milvus data_coord.proto
+type FieldBinlog struct {
+	FieldID              int64     `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	Binlogs              []*Binlog `protobuf:"bytes,2,rep,name=binlogs,proto3" json:"binlogs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *FieldBinlog) Reset()         { *m = FieldBinlog{} }
+func (m *FieldBinlog) String() string { return proto.CompactTextString(m) }
+func (*FieldBinlog) ProtoMessage()    {}
+func (*FieldBinlog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{53}
+}
+
+func (m *FieldBinlog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldBinlog.Unmarshal(m, b)
+}
+func (m *FieldBinlog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldBinlog.Marshal(b, m, deterministic)
+}
+func (m *FieldBinlog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldBinlog.Merge(m, src)
+}
+func (m *FieldBinlog) XXX_Size() int {
+	return xxx_messageInfo_FieldBinlog.Size(m)
+}
+func (m *FieldBinlog) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldBinlog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldBinlog proto.InternalMessageInfo
+
+func (m *FieldBinlog) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
 	}
 	return 0
 }
 
 func (m *FieldBinlog) GetBinlogs() []*Binlog {
 	if m != nil {
-		return m.Binlogs
+		return m.Binlogs
+	}
+	return nil
+}
+
+type Binlog struct {
+	EntriesNum    int64  `protobuf:"varint,1,opt,name=entries_num,json=entriesNum,proto3" json:"entries_num,omitempty"`
+	TimestampFrom uint64 `protobuf:"varint,2,opt,name=timestamp_from,json=timestampFrom,proto3" json:"timestamp_from,omitempty"`
+	TimestampTo   uint64 `protobuf:"varint,3,opt,name=timestamp_to,json=timestampTo,proto3" json:"timestamp_to,omitempty"`
+	LogPath       string `protobuf:"bytes,4,opt,name=log_path,json=logPath,proto3" json:"log_path,omitempty"`
+	LogSize       int64  `protobuf:"varint,5,opt,name=log_size,json=logSize,proto3" json:"log_size,omitempty"`
+	// size of the file in backup, differs from log_size if compressed or encrypted. 0 means not recorded
+	BackupSize int64 `protobuf:"varint,6,opt,name=backup_size,json=backupSize,proto3" json:"backup_size,omitempty"`
+	// hex encoded CRC32C of the file in backup, empty means not recorded
+	Crc32C string `protobuf:"bytes,7,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	// name of the dedup object storing the file, shared by all backups of the same content. empty means the file is
+	// stored in the binlog dir of backup
+	ObjectName           string   `protobuf:"bytes,8,opt,name=object_name,json=objectName,proto3" json:"object_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Binlog) Reset()         { *m = Binlog{} }
+func (m *Binlog) String() string { return proto.CompactTextString(m) }
+func (*Binlog) ProtoMessage()    {}
+func (*Binlog) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{54}
+}
+
+func (m *Binlog) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Binlog.Unmarshal(m, b)
+}
+func (m *Binlog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Binlog.Marshal(b, m, deterministic)
+}
+func (m *Binlog) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Binlog.Merge(m, src)
+}
+func (m *Binlog) XXX_Size() int {
+	return xxx_messageInfo_Binlog.Size(m)
+}
+func (m *Binlog) XXX_DiscardUnknown() {
+	xxx_messageInfo_Binlog.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Binlog proto.InternalMessageInfo
+
+func (m *Binlog) GetEntriesNum() int64 {
+	if m != nil {
+		return m.EntriesNum
+	}
+	return 0
+}
+
+func (m *Binlog) GetTimestampFrom() uint64 {
+	if m != nil {
+		return m.TimestampFrom
+	}
+	return 0
+}
+
+func (m *Binlog) GetTimestampTo() uint64 {
+	if m != nil {
+		return m.TimestampTo
+	}
+	return 0
+}
+
+func (m *Binlog) GetLogPath() string {
+	if m != nil {
+		return m.LogPath
+	}
+	return ""
+}
+
+func (m *Binlog) GetLogSize() int64 {
+	if m != nil {
+		return m.LogSize
+	}
+	return 0
+}
+
+func (m *Binlog) GetBackupSize() int64 {
+	if m != nil {
+		return m.BackupSize
+	}
+	return 0
+}
+
+func (m *Binlog) GetCrc32C() string {
+	if m != nil {
+		return m.Crc32C
+	}
+	return ""
+}
+
+func (m *Binlog) GetObjectName() string {
+	if m != nil {
+		return m.ObjectName
+	}
+	return ""
+}
+
+// files of a backup with their sizes and checksums, written as meta/manifest.json after the backup succeeds
+type BackupManifest struct {
+	BackupName string `protobuf:"bytes,1,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// unix time in seconds the manifest is written
+	CreateTime int64 `protobuf:"varint,2,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// total size of the files
+	Size                 int64           `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`
+	Files                []*ManifestFile `protobuf:"bytes,4,rep,name=files,proto3" json:"files,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *BackupManifest) Reset()         { *m = BackupManifest{} }
+func (m *BackupManifest) String() string { return proto.CompactTextString(m) }
+func (*BackupManifest) ProtoMessage()    {}
+func (*BackupManifest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{55}
+}
+
+func (m *BackupManifest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupManifest.Unmarshal(m, b)
+}
+func (m *BackupManifest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupManifest.Marshal(b, m, deterministic)
+}
+func (m *BackupManifest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupManifest.Merge(m, src)
+}
+func (m *BackupManifest) XXX_Size() int {
+	return xxx_messageInfo_BackupManifest.Size(m)
+}
+func (m *BackupManifest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupManifest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BackupManifest proto.InternalMessageInfo
+
+func (m *BackupManifest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *BackupManifest) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+func (m *BackupManifest) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *BackupManifest) GetFiles() []*ManifestFile {
+	if m != nil {
+		return m.Files
+	}
+	return nil
+}
+
+type ManifestFile struct {
+	// path of the file relative to the backup root, files of incremental backups may be stored in the backups
+	// they reference, and dedup objects in the dedup dir
+	Path string `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	// size of the file in backup storage
+	Size int64 `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`
+	// hex encoded CRC32C of the file in backup storage, empty means not recorded
+	Crc32C string `protobuf:"bytes,3,opt,name=crc32c,proto3" json:"crc32c,omitempty"`
+	// path of the binlog in milvus the file is copied from
+	SourcePath           string   `protobuf:"bytes,4,opt,name=source_path,json=sourcePath,proto3" json:"source_path,omitempty"`
+	CollectionId         int64    `protobuf:"varint,5,opt,name=collection_id,json=collectionId,proto3" json:"collection_id,omitempty"`
+	PartitionId          int64    `protobuf:"varint,6,opt,name=partition_id,json=partitionId,proto3" json:"partition_id,omitempty"`
+	SegmentId            int64    `protobuf:"varint,7,opt,name=segment_id,json=segmentId,proto3" json:"segment_id,omitempty"`
+	GroupId              int64    `protobuf:"varint,8,opt,name=group_id,json=groupId,proto3" json:"group_id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ManifestFile) Reset()         { *m = ManifestFile{} }
+func (m *ManifestFile) String() string { return proto.CompactTextString(m) }
+func (*ManifestFile) ProtoMessage()    {}
+func (*ManifestFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{56}
+}
+
+func (m *ManifestFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ManifestFile.Unmarshal(m, b)
+}
+func (m *ManifestFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ManifestFile.Marshal(b, m, deterministic)
+}
+func (m *ManifestFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ManifestFile.Merge(m, src)
+}
+func (m *ManifestFile) XXX_Size() int {
+	return xxx_messageInfo_ManifestFile.Size(m)
+}
+func (m *ManifestFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_ManifestFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ManifestFile proto.InternalMessageInfo
+
+func (m *ManifestFile) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *ManifestFile) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+func (m *ManifestFile) GetCrc32C() string {
+	if m != nil {
+		return m.Crc32C
+	}
+	return ""
+}
+
+func (m *ManifestFile) GetSourcePath() string {
+	if m != nil {
+		return m.SourcePath
+	}
+	return ""
+}
+
+func (m *ManifestFile) GetCollectionId() int64 {
+	if m != nil {
+		return m.CollectionId
+	}
+	return 0
+}
+
+func (m *ManifestFile) GetPartitionId() int64 {
+	if m != nil {
+		return m.PartitionId
+	}
+	return 0
+}
+
+func (m *ManifestFile) GetSegmentId() int64 {
+	if m != nil {
+		return m.SegmentId
+	}
+	return 0
+}
+
+func (m *ManifestFile) GetGroupId() int64 {
+	if m != nil {
+		return m.GroupId
+	}
+	return 0
+}
+
+// EtcdSnapshotInfo summarizes the etcd snapshot written into meta/etcd_snapshot.json of the backup
+type EtcdSnapshotInfo struct {
+	// etcd revision all the keys are read at
+	Revision int64 `protobuf:"varint,1,opt,name=revision,proto3" json:"revision,omitempty"`
+	// root path of milvus in etcd, like by-dev/meta
+	MetaRootPath string `protobuf:"bytes,2,opt,name=meta_root_path,json=metaRootPath,proto3" json:"meta_root_path,omitempty"`
+	KeyCount     int32  `protobuf:"varint,3,opt,name=key_count,json=keyCount,proto3" json:"key_count,omitempty"`
+	// bytes of the values
+	Size                 int64    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EtcdSnapshotInfo) Reset()         { *m = EtcdSnapshotInfo{} }
+func (m *EtcdSnapshotInfo) String() string { return proto.CompactTextString(m) }
+func (*EtcdSnapshotInfo) ProtoMessage()    {}
+func (*EtcdSnapshotInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{57}
+}
+
+func (m *EtcdSnapshotInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EtcdSnapshotInfo.Unmarshal(m, b)
+}
+func (m *EtcdSnapshotInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EtcdSnapshotInfo.Marshal(b, m, deterministic)
+}
+func (m *EtcdSnapshotInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EtcdSnapshotInfo.Merge(m, src)
+}
+func (m *EtcdSnapshotInfo) XXX_Size() int {
+	return xxx_messageInfo_EtcdSnapshotInfo.Size(m)
+}
+func (m *EtcdSnapshotInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_EtcdSnapshotInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EtcdSnapshotInfo proto.InternalMessageInfo
+
+func (m *EtcdSnapshotInfo) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *EtcdSnapshotInfo) GetMetaRootPath() string {
+	if m != nil {
+		return m.MetaRootPath
+	}
+	return ""
+}
+
+func (m *EtcdSnapshotInfo) GetKeyCount() int32 {
+	if m != nil {
+		return m.KeyCount
+	}
+	return 0
+}
+
+func (m *EtcdSnapshotInfo) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+// EtcdSnapshot is the etcd meta of the collections in a backup read at one revision, so that the states of
+// segments, channel checkpoints and collection meta are consistent with each other
+type EtcdSnapshot struct {
+	BackupName   string `protobuf:"bytes,1,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	Revision     int64  `protobuf:"varint,2,opt,name=revision,proto3" json:"revision,omitempty"`
+	MetaRootPath string `protobuf:"bytes,3,opt,name=meta_root_path,json=metaRootPath,proto3" json:"meta_root_path,omitempty"`
+	// unix time in seconds the snapshot is taken
+	CreateTime int64 `protobuf:"varint,4,opt,name=create_time,json=createTime,proto3" json:"create_time,omitempty"`
+	// sorted by key
+	Kvs                  []*EtcdKeyValue `protobuf:"bytes,5,rep,name=kvs,proto3" json:"kvs,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *EtcdSnapshot) Reset()         { *m = EtcdSnapshot{} }
+func (m *EtcdSnapshot) String() string { return proto.CompactTextString(m) }
+func (*EtcdSnapshot) ProtoMessage()    {}
+func (*EtcdSnapshot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{58}
+}
+
+func (m *EtcdSnapshot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EtcdSnapshot.Unmarshal(m, b)
+}
+func (m *EtcdSnapshot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EtcdSnapshot.Marshal(b, m, deterministic)
+}
+func (m *EtcdSnapshot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EtcdSnapshot.Merge(m, src)
+}
+func (m *EtcdSnapshot) XXX_Size() int {
+	return xxx_messageInfo_EtcdSnapshot.Size(m)
+}
+func (m *EtcdSnapshot) XXX_DiscardUnknown() {
+	xxx_messageInfo_EtcdSnapshot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EtcdSnapshot proto.InternalMessageInfo
+
+func (m *EtcdSnapshot) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *EtcdSnapshot) GetRevision() int64 {
+	if m != nil {
+		return m.Revision
+	}
+	return 0
+}
+
+func (m *EtcdSnapshot) GetMetaRootPath() string {
+	if m != nil {
+		return m.MetaRootPath
+	}
+	return ""
+}
+
+func (m *EtcdSnapshot) GetCreateTime() int64 {
+	if m != nil {
+		return m.CreateTime
+	}
+	return 0
+}
+
+func (m *EtcdSnapshot) GetKvs() []*EtcdKeyValue {
+	if m != nil {
+		return m.Kvs
+	}
+	return nil
+}
+
+type EtcdKeyValue struct {
+	// key relative to meta_root_path
+	Key string `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	// the value as stored by milvus, mostly marshaled protobuf
+	Value                []byte   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	ModRevision          int64    `protobuf:"varint,3,opt,name=mod_revision,json=modRevision,proto3" json:"mod_revision,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EtcdKeyValue) Reset()         { *m = EtcdKeyValue{} }
+func (m *EtcdKeyValue) String() string { return proto.CompactTextString(m) }
+func (*EtcdKeyValue) ProtoMessage()    {}
+func (*EtcdKeyValue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{59}
+}
+
+func (m *EtcdKeyValue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EtcdKeyValue.Unmarshal(m, b)
+}
+func (m *EtcdKeyValue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EtcdKeyValue.Marshal(b, m, deterministic)
+}
+func (m *EtcdKeyValue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EtcdKeyValue.Merge(m, src)
+}
+func (m *EtcdKeyValue) XXX_Size() int {
+	return xxx_messageInfo_EtcdKeyValue.Size(m)
+}
+func (m *EtcdKeyValue) XXX_DiscardUnknown() {
+	xxx_messageInfo_EtcdKeyValue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EtcdKeyValue proto.InternalMessageInfo
+
+func (m *EtcdKeyValue) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *EtcdKeyValue) GetValue() []byte {
+	if m != nil {
+		return m.Value
+	}
+	return nil
+}
+
+func (m *EtcdKeyValue) GetModRevision() int64 {
+	if m != nil {
+		return m.ModRevision
+	}
+	return 0
+}
+
+// copied from milvus common.proto
+type KeyValuePair struct {
+	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyValuePair) Reset()         { *m = KeyValuePair{} }
+func (m *KeyValuePair) String() string { return proto.CompactTextString(m) }
+func (*KeyValuePair) ProtoMessage()    {}
+func (*KeyValuePair) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{60}
+}
+
+func (m *KeyValuePair) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyValuePair.Unmarshal(m, b)
+}
+func (m *KeyValuePair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyValuePair.Marshal(b, m, deterministic)
+}
+func (m *KeyValuePair) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyValuePair.Merge(m, src)
+}
+func (m *KeyValuePair) XXX_Size() int {
+	return xxx_messageInfo_KeyValuePair.Size(m)
+}
+func (m *KeyValuePair) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyValuePair.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyValuePair proto.InternalMessageInfo
+
+func (m *KeyValuePair) GetKey() string {
+	if m != nil {
+		return m.Key
+	}
+	return ""
+}
+
+func (m *KeyValuePair) GetValue() string {
+	if m != nil {
+		return m.Value
+	}
+	return ""
+}
+
+type ValueField struct {
+	// Types that are valid to be assigned to Data:
+	//	*ValueField_BoolData
+	//	*ValueField_IntData
+	//	*ValueField_LongData
+	//	*ValueField_FloatData
+	//	*ValueField_DoubleData
+	//	*ValueField_StringData
+	//	*ValueField_BytesData
+	Data                 isValueField_Data `protobuf_oneof:"data"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ValueField) Reset()         { *m = ValueField{} }
+func (m *ValueField) String() string { return proto.CompactTextString(m) }
+func (*ValueField) ProtoMessage()    {}
+func (*ValueField) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{61}
+}
+
+func (m *ValueField) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ValueField.Unmarshal(m, b)
+}
+func (m *ValueField) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ValueField.Marshal(b, m, deterministic)
+}
+func (m *ValueField) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ValueField.Merge(m, src)
+}
+func (m *ValueField) XXX_Size() int {
+	return xxx_messageInfo_ValueField.Size(m)
+}
+func (m *ValueField) XXX_DiscardUnknown() {
+	xxx_messageInfo_ValueField.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ValueField proto.InternalMessageInfo
+
+type isValueField_Data interface {
+	isValueField_Data()
+}
+
+type ValueField_BoolData struct {
+	BoolData bool `protobuf:"varint,1,opt,name=bool_data,json=boolData,proto3,oneof"`
+}
+
+type ValueField_IntData struct {
+	IntData int32 `protobuf:"varint,2,opt,name=int_data,json=intData,proto3,oneof"`
+}
+
+type ValueField_LongData struct {
+	LongData int64 `protobuf:"varint,3,opt,name=long_data,json=longData,proto3,oneof"`
+}
+
+type ValueField_FloatData struct {
+	FloatData float32 `protobuf:"fixed32,4,opt,name=float_data,json=floatData,proto3,oneof"`
+}
+
+type ValueField_DoubleData struct {
+	DoubleData float64 `protobuf:"fixed64,5,opt,name=double_data,json=doubleData,proto3,oneof"`
+}
+
+type ValueField_StringData struct {
+	StringData string `protobuf:"bytes,6,opt,name=string_data,json=stringData,proto3,oneof"`
+}
+
+type ValueField_BytesData struct {
+	BytesData []byte `protobuf:"bytes,7,opt,name=bytes_data,json=bytesData,proto3,oneof"`
+}
+
+func (*ValueField_BoolData) isValueField_Data() {}
+
+func (*ValueField_IntData) isValueField_Data() {}
+
+func (*ValueField_LongData) isValueField_Data() {}
+
+func (*ValueField_FloatData) isValueField_Data() {}
+
+func (*ValueField_DoubleData) isValueField_Data() {}
+
+func (*ValueField_StringData) isValueField_Data() {}
+
+func (*ValueField_BytesData) isValueField_Data() {}
+
+func (m *ValueField) GetData() isValueField_Data {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *ValueField) GetBoolData() bool {
+	if x, ok := m.GetData().(*ValueField_BoolData); ok {
+		return x.BoolData
+	}
+	return false
+}
+
+func (m *ValueField) GetIntData() int32 {
+	if x, ok := m.GetData().(*ValueField_IntData); ok {
+		return x.IntData
+	}
+	return 0
+}
+
+func (m *ValueField) GetLongData() int64 {
+	if x, ok := m.GetData().(*ValueField_LongData); ok {
+		return x.LongData
+	}
+	return 0
+}
+
+func (m *ValueField) GetFloatData() float32 {
+	if x, ok := m.GetData().(*ValueField_FloatData); ok {
+		return x.FloatData
+	}
+	return 0
+}
+
+func (m *ValueField) GetDoubleData() float64 {
+	if x, ok := m.GetData().(*ValueField_DoubleData); ok {
+		return x.DoubleData
+	}
+	return 0
+}
+
+func (m *ValueField) GetStringData() string {
+	if x, ok := m.GetData().(*ValueField_StringData); ok {
+		return x.StringData
+	}
+	return ""
+}
+
+func (m *ValueField) GetBytesData() []byte {
+	if x, ok := m.GetData().(*ValueField_BytesData); ok {
+		return x.BytesData
+	}
+	return nil
+}
+
+// XXX_OneofWrappers is for the internal use of the proto package.
+func (*ValueField) XXX_OneofWrappers() []interface{} {
+	return []interface{}{
+		(*ValueField_BoolData)(nil),
+		(*ValueField_IntData)(nil),
+		(*ValueField_LongData)(nil),
+		(*ValueField_FloatData)(nil),
+		(*ValueField_DoubleData)(nil),
+		(*ValueField_StringData)(nil),
+		(*ValueField_BytesData)(nil),
+	}
+}
+
+//*
+// @brief Field schema. The same as https://github.com/milvus-io/milvus-proto schema.proto
+type FieldSchema struct {
+	FieldID              int64           `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
+	Name                 string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsPrimaryKey         bool            `protobuf:"varint,3,opt,name=is_primary_key,json=isPrimaryKey,proto3" json:"is_primary_key,omitempty"`
+	Description          string          `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	DataType             DataType        `protobuf:"varint,5,opt,name=data_type,json=dataType,proto3,enum=milvus.proto.backup.DataType" json:"data_type,omitempty"`
+	TypeParams           []*KeyValuePair `protobuf:"bytes,6,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
+	IndexParams          []*KeyValuePair `protobuf:"bytes,7,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
+	AutoID               bool            `protobuf:"varint,8,opt,name=autoID,proto3" json:"autoID,omitempty"`
+	State                FieldState      `protobuf:"varint,9,opt,name=state,proto3,enum=milvus.proto.backup.FieldState" json:"state,omitempty"`
+	ElementType          DataType        `protobuf:"varint,10,opt,name=element_type,json=elementType,proto3,enum=milvus.proto.backup.DataType" json:"element_type,omitempty"`
+	DefaultValue         *ValueField     `protobuf:"bytes,11,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
+	IsDynamic            bool            `protobuf:"varint,12,opt,name=is_dynamic,json=isDynamic,proto3" json:"is_dynamic,omitempty"`
+	IsPartitionKey       bool            `protobuf:"varint,13,opt,name=is_partition_key,json=isPartitionKey,proto3" json:"is_partition_key,omitempty"`
+	IsFunctionOutput     bool            `protobuf:"varint,16,opt,name=is_function_output,json=isFunctionOutput,proto3" json:"is_function_output,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FieldSchema) Reset()         { *m = FieldSchema{} }
+func (m *FieldSchema) String() string { return proto.CompactTextString(m) }
+func (*FieldSchema) ProtoMessage()    {}
+func (*FieldSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{62}
+}
+
+func (m *FieldSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FieldSchema.Unmarshal(m, b)
+}
+func (m *FieldSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FieldSchema.Marshal(b, m, deterministic)
+}
+func (m *FieldSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FieldSchema.Merge(m, src)
+}
+func (m *FieldSchema) XXX_Size() int {
+	return xxx_messageInfo_FieldSchema.Size(m)
+}
+func (m *FieldSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_FieldSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FieldSchema proto.InternalMessageInfo
+
+func (m *FieldSchema) GetFieldID() int64 {
+	if m != nil {
+		return m.FieldID
+	}
+	return 0
+}
+
+func (m *FieldSchema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FieldSchema) GetIsPrimaryKey() bool {
+	if m != nil {
+		return m.IsPrimaryKey
+	}
+	return false
+}
+
+func (m *FieldSchema) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *FieldSchema) GetDataType() DataType {
+	if m != nil {
+		return m.DataType
+	}
+	return DataType_None
+}
+
+func (m *FieldSchema) GetTypeParams() []*KeyValuePair {
+	if m != nil {
+		return m.TypeParams
+	}
+	return nil
+}
+
+func (m *FieldSchema) GetIndexParams() []*KeyValuePair {
+	if m != nil {
+		return m.IndexParams
+	}
+	return nil
+}
+
+func (m *FieldSchema) GetAutoID() bool {
+	if m != nil {
+		return m.AutoID
+	}
+	return false
+}
+
+func (m *FieldSchema) GetState() FieldState {
+	if m != nil {
+		return m.State
+	}
+	return FieldState_FieldCreated
+}
+
+func (m *FieldSchema) GetElementType() DataType {
+	if m != nil {
+		return m.ElementType
+	}
+	return DataType_None
+}
+
+func (m *FieldSchema) GetDefaultValue() *ValueField {
+	if m != nil {
+		return m.DefaultValue
+	}
+	return nil
+}
+
+func (m *FieldSchema) GetIsDynamic() bool {
+	if m != nil {
+		return m.IsDynamic
+	}
+	return false
+}
+
+func (m *FieldSchema) GetIsPartitionKey() bool {
+	if m != nil {
+		return m.IsPartitionKey
+	}
+	return false
+}
+
+func (m *FieldSchema) GetIsFunctionOutput() bool {
+	if m != nil {
+		return m.IsFunctionOutput
+	}
+	return false
+}
+
+//*
+// @brief Function generating an output field from input fields, the same layout as milvus FunctionSchema
+type FunctionSchema struct {
+	Name                 string          `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Id                   int64           `protobuf:"varint,2,opt,name=id,proto3" json:"id,omitempty"`
+	Description          string          `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Type                 FunctionType    `protobuf:"varint,4,opt,name=type,proto3,enum=milvus.proto.backup.FunctionType" json:"type,omitempty"`
+	InputFieldNames      []string        `protobuf:"bytes,5,rep,name=input_field_names,json=inputFieldNames,proto3" json:"input_field_names,omitempty"`
+	InputFieldIds        []int64         `protobuf:"varint,6,rep,packed,name=input_field_ids,json=inputFieldIds,proto3" json:"input_field_ids,omitempty"`
+	OutputFieldNames     []string        `protobuf:"bytes,7,rep,name=output_field_names,json=outputFieldNames,proto3" json:"output_field_names,omitempty"`
+	OutputFieldIds       []int64         `protobuf:"varint,8,rep,packed,name=output_field_ids,json=outputFieldIds,proto3" json:"output_field_ids,omitempty"`
+	Params               []*KeyValuePair `protobuf:"bytes,9,rep,name=params,proto3" json:"params,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FunctionSchema) Reset()         { *m = FunctionSchema{} }
+func (m *FunctionSchema) String() string { return proto.CompactTextString(m) }
+func (*FunctionSchema) ProtoMessage()    {}
+func (*FunctionSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{63}
+}
+
+func (m *FunctionSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FunctionSchema.Unmarshal(m, b)
+}
+func (m *FunctionSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FunctionSchema.Marshal(b, m, deterministic)
+}
+func (m *FunctionSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FunctionSchema.Merge(m, src)
+}
+func (m *FunctionSchema) XXX_Size() int {
+	return xxx_messageInfo_FunctionSchema.Size(m)
+}
+func (m *FunctionSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_FunctionSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FunctionSchema proto.InternalMessageInfo
+
+func (m *FunctionSchema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *FunctionSchema) GetId() int64 {
+	if m != nil {
+		return m.Id
+	}
+	return 0
+}
+
+func (m *FunctionSchema) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *FunctionSchema) GetType() FunctionType {
+	if m != nil {
+		return m.Type
+	}
+	return FunctionType_FunctionUnknown
+}
+
+func (m *FunctionSchema) GetInputFieldNames() []string {
+	if m != nil {
+		return m.InputFieldNames
+	}
+	return nil
+}
+
+func (m *FunctionSchema) GetInputFieldIds() []int64 {
+	if m != nil {
+		return m.InputFieldIds
+	}
+	return nil
+}
+
+func (m *FunctionSchema) GetOutputFieldNames() []string {
+	if m != nil {
+		return m.OutputFieldNames
+	}
+	return nil
+}
+
+func (m *FunctionSchema) GetOutputFieldIds() []int64 {
+	if m != nil {
+		return m.OutputFieldIds
+	}
+	return nil
+}
+
+func (m *FunctionSchema) GetParams() []*KeyValuePair {
+	if m != nil {
+		return m.Params
+	}
+	return nil
+}
+
+//*
+// @brief Collection schema
+type CollectionSchema struct {
+	Name                 string            `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Description          string            `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
+	AutoID               bool              `protobuf:"varint,3,opt,name=autoID,proto3" json:"autoID,omitempty"`
+	Fields               []*FieldSchema    `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
+	EnableDynamicField   bool              `protobuf:"varint,5,opt,name=enable_dynamic_field,json=enableDynamicField,proto3" json:"enable_dynamic_field,omitempty"`
+	Functions            []*FunctionSchema `protobuf:"bytes,7,rep,name=functions,proto3" json:"functions,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *CollectionSchema) Reset()         { *m = CollectionSchema{} }
+func (m *CollectionSchema) String() string { return proto.CompactTextString(m) }
+func (*CollectionSchema) ProtoMessage()    {}
+func (*CollectionSchema) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{64}
+}
+
+func (m *CollectionSchema) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionSchema.Unmarshal(m, b)
+}
+func (m *CollectionSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionSchema.Marshal(b, m, deterministic)
+}
+func (m *CollectionSchema) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionSchema.Merge(m, src)
+}
+func (m *CollectionSchema) XXX_Size() int {
+	return xxx_messageInfo_CollectionSchema.Size(m)
+}
+func (m *CollectionSchema) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionSchema.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectionSchema proto.InternalMessageInfo
+
+func (m *CollectionSchema) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *CollectionSchema) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CollectionSchema) GetAutoID() bool {
+	if m != nil {
+		return m.AutoID
+	}
+	return false
+}
+
+func (m *CollectionSchema) GetFields() []*FieldSchema {
+	if m != nil {
+		return m.Fields
+	}
+	return nil
+}
+
+func (m *CollectionSchema) GetEnableDynamicField() bool {
+	if m != nil {
+		return m.EnableDynamicField
+	}
+	return false
+}
+
+func (m *CollectionSchema) GetFunctions() []*FunctionSchema {
+	if m != nil {
+		return m.Functions
+	}
+	return nil
+}
+
+type CheckRequest struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
+func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckRequest) ProtoMessage()    {}
+func (*CheckRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{65}
+}
+
+func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckRequest.Unmarshal(m, b)
+}
+func (m *CheckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckRequest.Merge(m, src)
+}
+func (m *CheckRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckRequest.Size(m)
+}
+func (m *CheckRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckRequest proto.InternalMessageInfo
+
+// CompatIssue is a feature used by a collection in backup which the target milvus doesn't support
+type CompatIssue struct {
+	// db.collection_name in backup
+	Collection string `protobuf:"bytes,1,opt,name=collection,proto3" json:"collection,omitempty"`
+	// database, json field, dynamic field, partition key, array field, sparse vector field, multiple vector fields or functions
+	Feature string `protobuf:"bytes,2,opt,name=feature,proto3" json:"feature,omitempty"`
+	// the first milvus version supporting the feature
+	SinceVersion string `protobuf:"bytes,3,opt,name=since_version,json=sinceVersion,proto3" json:"since_version,omitempty"`
+	// the collection can be restored without the feature by downgrade_features
+	Downgradable bool `protobuf:"varint,4,opt,name=downgradable,proto3" json:"downgradable,omitempty"`
+	// what is not supported, and what is not restored if downgraded
+	Detail               string   `protobuf:"bytes,5,opt,name=detail,proto3" json:"detail,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CompatIssue) Reset()         { *m = CompatIssue{} }
+func (m *CompatIssue) String() string { return proto.CompactTextString(m) }
+func (*CompatIssue) ProtoMessage()    {}
+func (*CompatIssue) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{66}
+}
+
+func (m *CompatIssue) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompatIssue.Unmarshal(m, b)
+}
+func (m *CompatIssue) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompatIssue.Marshal(b, m, deterministic)
+}
+func (m *CompatIssue) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompatIssue.Merge(m, src)
+}
+func (m *CompatIssue) XXX_Size() int {
+	return xxx_messageInfo_CompatIssue.Size(m)
+}
+func (m *CompatIssue) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompatIssue.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompatIssue proto.InternalMessageInfo
+
+func (m *CompatIssue) GetCollection() string {
+	if m != nil {
+		return m.Collection
+	}
+	return ""
+}
+
+func (m *CompatIssue) GetFeature() string {
+	if m != nil {
+		return m.Feature
+	}
+	return ""
+}
+
+func (m *CompatIssue) GetSinceVersion() string {
+	if m != nil {
+		return m.SinceVersion
+	}
+	return ""
+}
+
+func (m *CompatIssue) GetDowngradable() bool {
+	if m != nil {
+		return m.Downgradable
+	}
+	return false
+}
+
+func (m *CompatIssue) GetDetail() string {
+	if m != nil {
+		return m.Detail
+	}
+	return ""
+}
+
+type CheckCompatibilityRequest struct {
+	// uuid of request, will generate one if not set
+	RequestId  string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// milvus version to check against, like v2.3.5, the version of the connected milvus if not set
+	TargetVersion string `protobuf:"bytes,3,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	BucketName string `protobuf:"bytes,4,opt,name=bucket_name,json=bucketName,proto3" json:"bucket_name,omitempty"`
+	// if bucket_name and path is set. will override bucket/path in config.
+	Path string `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	// collections to check, db.collection_name or collection_name of the default database, all collections if not set
+	CollectionNames []string `protobuf:"bytes,6,rep,name=collection_names,json=collectionNames,proto3" json:"collection_names,omitempty"`
+	// database the collections are restored into, their original databases if not set
+	TargetDbName         string   `protobuf:"bytes,7,opt,name=target_db_name,json=targetDbName,proto3" json:"target_db_name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CheckCompatibilityRequest) Reset()         { *m = CheckCompatibilityRequest{} }
+func (m *CheckCompatibilityRequest) String() string { return proto.CompactTextString(m) }
+func (*CheckCompatibilityRequest) ProtoMessage()    {}
+func (*CheckCompatibilityRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{67}
+}
+
+func (m *CheckCompatibilityRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCompatibilityRequest.Unmarshal(m, b)
+}
+func (m *CheckCompatibilityRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCompatibilityRequest.Marshal(b, m, deterministic)
+}
+func (m *CheckCompatibilityRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCompatibilityRequest.Merge(m, src)
+}
+func (m *CheckCompatibilityRequest) XXX_Size() int {
+	return xxx_messageInfo_CheckCompatibilityRequest.Size(m)
+}
+func (m *CheckCompatibilityRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCompatibilityRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCompatibilityRequest proto.InternalMessageInfo
+
+func (m *CheckCompatibilityRequest) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityRequest) GetBackupName() string {
+	if m != nil {
+		return m.BackupName
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityRequest) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityRequest) GetBucketName() string {
+	if m != nil {
+		return m.BucketName
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityRequest) GetPath() string {
+	if m != nil {
+		return m.Path
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityRequest) GetCollectionNames() []string {
+	if m != nil {
+		return m.CollectionNames
+	}
+	return nil
+}
+
+func (m *CheckCompatibilityRequest) GetTargetDbName() string {
+	if m != nil {
+		return m.TargetDbName
+	}
+	return ""
+}
+
+type CheckCompatibilityResponse struct {
+	// uuid of the request to response
+	RequestId string `protobuf:"bytes,1,opt,name=requestId,proto3" json:"requestId,omitempty"`
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,2,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,3,opt,name=msg,proto3" json:"msg,omitempty"`
+	// the milvus version checked against
+	TargetVersion string `protobuf:"bytes,4,opt,name=target_version,json=targetVersion,proto3" json:"target_version,omitempty"`
+	// the features not supported by the target version, the backup is compatible if empty
+	Issues               []*CompatIssue `protobuf:"bytes,5,rep,name=issues,proto3" json:"issues,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *CheckCompatibilityResponse) Reset()         { *m = CheckCompatibilityResponse{} }
+func (m *CheckCompatibilityResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckCompatibilityResponse) ProtoMessage()    {}
+func (*CheckCompatibilityResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{68}
+}
+
+func (m *CheckCompatibilityResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckCompatibilityResponse.Unmarshal(m, b)
+}
+func (m *CheckCompatibilityResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckCompatibilityResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckCompatibilityResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckCompatibilityResponse.Merge(m, src)
+}
+func (m *CheckCompatibilityResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckCompatibilityResponse.Size(m)
+}
+func (m *CheckCompatibilityResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckCompatibilityResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckCompatibilityResponse proto.InternalMessageInfo
+
+func (m *CheckCompatibilityResponse) GetRequestId() string {
+	if m != nil {
+		return m.RequestId
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *CheckCompatibilityResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityResponse) GetTargetVersion() string {
+	if m != nil {
+		return m.TargetVersion
+	}
+	return ""
+}
+
+func (m *CheckCompatibilityResponse) GetIssues() []*CompatIssue {
+	if m != nil {
+		return m.Issues
+	}
+	return nil
+}
+
+type CheckResponse struct {
+	// response code. 0 means success. others are fail
+	Code ResponseCode `protobuf:"varint,1,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
+	// error msg if fail
+	Msg string `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+	// checks of the milvus storage and the backup storage
+	StorageChecks        []*StorageCheck `protobuf:"bytes,3,rep,name=storage_checks,json=storageChecks,proto3" json:"storage_checks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
+func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
+func (*CheckResponse) ProtoMessage()    {}
+func (*CheckResponse) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{69}
+}
+
+func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
+}
+func (m *CheckResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CheckResponse.Marshal(b, m, deterministic)
+}
+func (m *CheckResponse) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CheckResponse.Merge(m, src)
+}
+func (m *CheckResponse) XXX_Size() int {
+	return xxx_messageInfo_CheckResponse.Size(m)
+}
+func (m *CheckResponse) XXX_DiscardUnknown() {
+	xxx_messageInfo_CheckResponse.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CheckResponse proto.InternalMessageInfo
+
+func (m *CheckResponse) GetCode() ResponseCode {
+	if m != nil {
+		return m.Code
+	}
+	return ResponseCode_Success
+}
+
+func (m *CheckResponse) GetMsg() string {
+	if m != nil {
+		return m.Msg
+	}
+	return ""
+}
+
+func (m *CheckResponse) GetStorageChecks() []*StorageCheck {
+	if m != nil {
+		return m.StorageChecks
 	}
 	return nil
 }
 
-type Binlog struct {
-	EntriesNum           int64    `protobuf:"varint,1,opt,name=entries_num,json=entriesNum,proto3" json:"entries_num,omitempty"`
-	TimestampFrom        uint64   `protobuf:"varint,2,opt,name=timestamp_from,json=timestampFrom,proto3" json:"timestamp_from,omitempty"`
-	TimestampTo          uint64   `protobuf:"varint,3,opt,name=timestamp_to,json=timestampTo,proto3" json:"timestamp_to,omitempty"`
-	LogPath              string   `protobuf:"bytes,4,opt,name=log_path,json=logPath,proto3" json:"log_path,omitempty"`
-	LogSize              int64    `protobuf:"varint,5,opt,name=log_size,json=logSize,proto3" json:"log_size,omitempty"`
+// StorageCheck is a preflight check of a storage, like the reachability of its endpoint or a permission on its bucket
+type StorageCheck struct {
+	// milvus for the storage of milvus, backup for the backup bucket
+	Storage string `protobuf:"bytes,1,opt,name=storage,proto3" json:"storage,omitempty"`
+	// endpoint, tls, credentials, region, list, write, read, delete, copy
+	Name string `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// pass, warn, fail or skip
+	State string `protobuf:"bytes,3,opt,name=state,proto3" json:"state,omitempty"`
+	// what is checked, and the error if failed
+	Detail string `protobuf:"bytes,4,opt,name=detail,proto3" json:"detail,omitempty"`
+	// how to fix a check which is not passed
+	Hint                 string   `protobuf:"bytes,5,opt,name=hint,proto3" json:"hint,omitempty"`
+	DurationMs           int64    `protobuf:"varint,6,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *Binlog) Reset()         { *m = Binlog{} }
-func (m *Binlog) String() string { return proto.CompactTextString(m) }
-func (*Binlog) ProtoMessage()    {}
-func (*Binlog) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{22}
+func (m *StorageCheck) Reset()         { *m = StorageCheck{} }
+func (m *StorageCheck) String() string { return proto.CompactTextString(m) }
+func (*StorageCheck) ProtoMessage()    {}
+func (*StorageCheck) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{70}
 }
 
-func (m *Binlog) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_Binlog.Unmarshal(m, b)
+func (m *StorageCheck) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_StorageCheck.Unmarshal(m, b)
 }
-func (m *Binlog) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_Binlog.Marshal(b, m, deterministic)
+func (m *StorageCheck) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_StorageCheck.Marshal(b, m, deterministic)
 }
-func (m *Binlog) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_Binlog.Merge(m, src)
+func (m *StorageCheck) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_StorageCheck.Merge(m, src)
 }
-func (m *Binlog) XXX_Size() int {
-	return xxx_messageInfo_Binlog.Size(m)
+func (m *StorageCheck) XXX_Size() int {
+	return xxx_messageInfo_StorageCheck.Size(m)
 }
-func (m *Binlog) XXX_DiscardUnknown() {
-	xxx_messageInfo_Binlog.DiscardUnknown(m)
+func (m *StorageCheck) XXX_DiscardUnknown() {
+	xxx_messageInfo_StorageCheck.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_Binlog proto.InternalMessageInfo
+var xxx_messageInfo_StorageCheck proto.InternalMessageInfo
 
-func (m *Binlog) GetEntriesNum() int64 {
+func (m *StorageCheck) GetStorage() string {
 	if m != nil {
-		return m.EntriesNum
+		return m.Storage
 	}
-	return 0
+	return ""
 }
 
-func (m *Binlog) GetTimestampFrom() uint64 {
+func (m *StorageCheck) GetName() string {
 	if m != nil {
-		return m.TimestampFrom
+		return m.Name
 	}
-	return 0
+	return ""
 }
 
-func (m *Binlog) GetTimestampTo() uint64 {
+func (m *StorageCheck) GetState() string {
 	if m != nil {
-		return m.TimestampTo
+		return m.State
 	}
-	return 0
+	return ""
 }
 
-func (m *Binlog) GetLogPath() string {
+func (m *StorageCheck) GetDetail() string {
 	if m != nil {
-		return m.LogPath
+		return m.Detail
 	}
 	return ""
 }
 
-func (m *Binlog) GetLogSize() int64 {
+func (m *StorageCheck) GetHint() string {
 	if m != nil {
-		return m.LogSize
+		return m.Hint
+	}
+	return ""
+}
+
+func (m *StorageCheck) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
 	}
 	return 0
 }
 
-// copied from milvus common.proto
-type KeyValuePair struct {
-	Key                  string   `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Value                string   `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
-	XXX_NoUnkeyedLiteral struct{} `json:"-"`
-	XXX_unrecognized     []byte   `json:"-"`
-	XXX_sizecache        int32    `json:"-"`
+// BackupDiff is the difference from a backup to another, printed by `milvus-backup diff`
+type BackupDiff struct {
+	FromBackupName string `protobuf:"bytes,1,opt,name=from_backup_name,json=fromBackupName,proto3" json:"from_backup_name,omitempty"`
+	ToBackupName   string `protobuf:"bytes,2,opt,name=to_backup_name,json=toBackupName,proto3" json:"to_backup_name,omitempty"`
+	// backup timestamps in milliseconds
+	FromBackupTimestamp uint64 `protobuf:"varint,3,opt,name=from_backup_timestamp,json=fromBackupTimestamp,proto3" json:"from_backup_timestamp,omitempty"`
+	ToBackupTimestamp   uint64 `protobuf:"varint,4,opt,name=to_backup_timestamp,json=toBackupTimestamp,proto3" json:"to_backup_timestamp,omitempty"`
+	FromSize            int64  `protobuf:"varint,5,opt,name=from_size,json=fromSize,proto3" json:"from_size,omitempty"`
+	ToSize              int64  `protobuf:"varint,6,opt,name=to_size,json=toSize,proto3" json:"to_size,omitempty"`
+	FromRows            int64  `protobuf:"varint,7,opt,name=from_rows,json=fromRows,proto3" json:"from_rows,omitempty"`
+	ToRows              int64  `protobuf:"varint,8,opt,name=to_rows,json=toRows,proto3" json:"to_rows,omitempty"`
+	// the to backup is an incremental backup based on the from backup, directly or by a chain of incremental backups
+	IncrementalOf bool `protobuf:"varint,9,opt,name=incremental_of,json=incrementalOf,proto3" json:"incremental_of,omitempty"`
+	// collections in both or either of the backups, sorted by name
+	Collections          []*CollectionDiff `protobuf:"bytes,10,rep,name=collections,proto3" json:"collections,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
 }
 
-func (m *KeyValuePair) Reset()         { *m = KeyValuePair{} }
-func (m *KeyValuePair) String() string { return proto.CompactTextString(m) }
-func (*KeyValuePair) ProtoMessage()    {}
-func (*KeyValuePair) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{23}
+func (m *BackupDiff) Reset()         { *m = BackupDiff{} }
+func (m *BackupDiff) String() string { return proto.CompactTextString(m) }
+func (*BackupDiff) ProtoMessage()    {}
+func (*BackupDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{71}
 }
 
-func (m *KeyValuePair) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_KeyValuePair.Unmarshal(m, b)
+func (m *BackupDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BackupDiff.Unmarshal(m, b)
 }
-func (m *KeyValuePair) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_KeyValuePair.Marshal(b, m, deterministic)
+func (m *BackupDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BackupDiff.Marshal(b, m, deterministic)
 }
-func (m *KeyValuePair) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_KeyValuePair.Merge(m, src)
+func (m *BackupDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BackupDiff.Merge(m, src)
 }
-func (m *KeyValuePair) XXX_Size() int {
-	return xxx_messageInfo_KeyValuePair.Size(m)
+func (m *BackupDiff) XXX_Size() int {
+	return xxx_messageInfo_BackupDiff.Size(m)
 }
-func (m *KeyValuePair) XXX_DiscardUnknown() {
-	xxx_messageInfo_KeyValuePair.DiscardUnknown(m)
+func (m *BackupDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_BackupDiff.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_KeyValuePair proto.InternalMessageInfo
+var xxx_messageInfo_BackupDiff proto.InternalMessageInfo
 
-func (m *KeyValuePair) GetKey() string {
+func (m *BackupDiff) GetFromBackupName() string {
 	if m != nil {
-		return m.Key
+		return m.FromBackupName
 	}
 	return ""
 }
 
-func (m *KeyValuePair) GetValue() string {
+func (m *BackupDiff) GetToBackupName() string {
 	if m != nil {
-		return m.Value
+		return m.ToBackupName
 	}
 	return ""
 }
 
-type ValueField struct {
-	// Types that are valid to be assigned to Data:
-	//	*ValueField_BoolData
-	//	*ValueField_IntData
-	//	*ValueField_LongData
-	//	*ValueField_FloatData
-	//	*ValueField_DoubleData
-	//	*ValueField_StringData
-	//	*ValueField_BytesData
-	Data                 isValueField_Data `protobuf_oneof:"data"`
-	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
-	XXX_unrecognized     []byte            `json:"-"`
-	XXX_sizecache        int32             `json:"-"`
+func (m *BackupDiff) GetFromBackupTimestamp() uint64 {
+	if m != nil {
+		return m.FromBackupTimestamp
+	}
+	return 0
 }
 
-func (m *ValueField) Reset()         { *m = ValueField{} }
-func (m *ValueField) String() string { return proto.CompactTextString(m) }
-func (*ValueField) ProtoMessage()    {}
-func (*ValueField) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{24}
+func (m *BackupDiff) GetToBackupTimestamp() uint64 {
+	if m != nil {
+		return m.ToBackupTimestamp
+	}
+	return 0
 }
 
-func (m *ValueField) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_ValueField.Unmarshal(m, b)
-}
-func (m *ValueField) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_ValueField.Marshal(b, m, deterministic)
-}
-func (m *ValueField) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_ValueField.Merge(m, src)
-}
-func (m *ValueField) XXX_Size() int {
-	return xxx_messageInfo_ValueField.Size(m)
-}
-func (m *ValueField) XXX_DiscardUnknown() {
-	xxx_messageInfo_ValueField.DiscardUnknown(m)
+func (m *BackupDiff) GetFromSize() int64 {
+	if m != nil {
+		return m.FromSize
+	}
+	return 0
 }
 
-var xxx_messageInfo_ValueField proto.InternalMessageInfo
-
-type isValueField_Data interface {
-	isValueField_Data()
+func (m *BackupDiff) GetToSize() int64 {
+	if m != nil {
+		return m.ToSize
+	}
+	return 0
 }
 
-type ValueField_BoolData struct {
-	BoolData bool `protobuf:"varint,1,opt,name=bool_data,json=boolData,proto3,oneof"`
+func (m *BackupDiff) GetFromRows() int64 {
+	if m != nil {
+		return m.FromRows
+	}
+	return 0
 }
 
-type ValueField_IntData struct {
-	IntData int32 `protobuf:"varint,2,opt,name=int_data,json=intData,proto3,oneof"`
+func (m *BackupDiff) GetToRows() int64 {
+	if m != nil {
+		return m.ToRows
+	}
+	return 0
 }
 
-type ValueField_LongData struct {
-	LongData int64 `protobuf:"varint,3,opt,name=long_data,json=longData,proto3,oneof"`
+func (m *BackupDiff) GetIncrementalOf() bool {
+	if m != nil {
+		return m.IncrementalOf
+	}
+	return false
 }
 
-type ValueField_FloatData struct {
-	FloatData float32 `protobuf:"fixed32,4,opt,name=float_data,json=floatData,proto3,oneof"`
+func (m *BackupDiff) GetCollections() []*CollectionDiff {
+	if m != nil {
+		return m.Collections
+	}
+	return nil
 }
 
-type ValueField_DoubleData struct {
-	DoubleData float64 `protobuf:"fixed64,5,opt,name=double_data,json=doubleData,proto3,oneof"`
+type CollectionDiff struct {
+	DbName         string `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	CollectionName string `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	// added, removed, changed or unchanged
+	Change string `protobuf:"bytes,3,opt,name=change,proto3" json:"change,omitempty"`
+	// fields added, removed or changed, and changes of the collection schema, like "field age: added"
+	SchemaChanges     []string `protobuf:"bytes,4,rep,name=schema_changes,json=schemaChanges,proto3" json:"schema_changes,omitempty"`
+	AddedPartitions   []string `protobuf:"bytes,5,rep,name=added_partitions,json=addedPartitions,proto3" json:"added_partitions,omitempty"`
+	RemovedPartitions []string `protobuf:"bytes,6,rep,name=removed_partitions,json=removedPartitions,proto3" json:"removed_partitions,omitempty"`
+	FromRows          int64    `protobuf:"varint,7,opt,name=from_rows,json=fromRows,proto3" json:"from_rows,omitempty"`
+	ToRows            int64    `protobuf:"varint,8,opt,name=to_rows,json=toRows,proto3" json:"to_rows,omitempty"`
+	FromSize          int64    `protobuf:"varint,9,opt,name=from_size,json=fromSize,proto3" json:"from_size,omitempty"`
+	ToSize            int64    `protobuf:"varint,10,opt,name=to_size,json=toSize,proto3" json:"to_size,omitempty"`
+	FromSegments      int32    `protobuf:"varint,11,opt,name=from_segments,json=fromSegments,proto3" json:"from_segments,omitempty"`
+	ToSegments        int32    `protobuf:"varint,12,opt,name=to_segments,json=toSegments,proto3" json:"to_segments,omitempty"`
+	// segments only in the to backup, like segments flushed or compacted since the from backup
+	AddedSegments int32 `protobuf:"varint,13,opt,name=added_segments,json=addedSegments,proto3" json:"added_segments,omitempty"`
+	// segments only in the from backup, like segments compacted or dropped since the from backup
+	RemovedSegments int32 `protobuf:"varint,14,opt,name=removed_segments,json=removedSegments,proto3" json:"removed_segments,omitempty"`
+	// segments in both backups with different binlogs, like segments with delete logs added
+	ChangedSegments      int32    `protobuf:"varint,15,opt,name=changed_segments,json=changedSegments,proto3" json:"changed_segments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type ValueField_StringData struct {
-	StringData string `protobuf:"bytes,6,opt,name=string_data,json=stringData,proto3,oneof"`
+func (m *CollectionDiff) Reset()         { *m = CollectionDiff{} }
+func (m *CollectionDiff) String() string { return proto.CompactTextString(m) }
+func (*CollectionDiff) ProtoMessage()    {}
+func (*CollectionDiff) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{72}
 }
 
-type ValueField_BytesData struct {
-	BytesData []byte `protobuf:"bytes,7,opt,name=bytes_data,json=bytesData,proto3,oneof"`
+func (m *CollectionDiff) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionDiff.Unmarshal(m, b)
+}
+func (m *CollectionDiff) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionDiff.Marshal(b, m, deterministic)
+}
+func (m *CollectionDiff) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionDiff.Merge(m, src)
+}
+func (m *CollectionDiff) XXX_Size() int {
+	return xxx_messageInfo_CollectionDiff.Size(m)
+}
+func (m *CollectionDiff) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionDiff.DiscardUnknown(m)
 }
 
-func (*ValueField_BoolData) isValueField_Data() {}
-
-func (*ValueField_IntData) isValueField_Data() {}
+var xxx_messageInfo_CollectionDiff proto.InternalMessageInfo
 
-func (*ValueField_LongData) isValueField_Data() {}
+func (m *CollectionDiff) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
 
-func (*ValueField_FloatData) isValueField_Data() {}
+func (m *CollectionDiff) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
 
-func (*ValueField_DoubleData) isValueField_Data() {}
+func (m *CollectionDiff) GetChange() string {
+	if m != nil {
+		return m.Change
+	}
+	return ""
+}
 
-func (*ValueField_StringData) isValueField_Data() {}
+func (m *CollectionDiff) GetSchemaChanges() []string {
+	if m != nil {
+		return m.SchemaChanges
+	}
+	return nil
+}
 
-func (*ValueField_BytesData) isValueField_Data() {}
+func (m *CollectionDiff) GetAddedPartitions() []string {
+	if m != nil {
+		return m.AddedPartitions
+	}
+	return nil
+}
 
-func (m *ValueField) GetData() isValueField_Data {
+func (m *CollectionDiff) GetRemovedPartitions() []string {
 	if m != nil {
-		return m.Data
+		return m.RemovedPartitions
 	}
 	return nil
 }
 
-func (m *ValueField) GetBoolData() bool {
-	if x, ok := m.GetData().(*ValueField_BoolData); ok {
-		return x.BoolData
+func (m *CollectionDiff) GetFromRows() int64 {
+	if m != nil {
+		return m.FromRows
 	}
-	return false
+	return 0
 }
 
-func (m *ValueField) GetIntData() int32 {
-	if x, ok := m.GetData().(*ValueField_IntData); ok {
-		return x.IntData
+func (m *CollectionDiff) GetToRows() int64 {
+	if m != nil {
+		return m.ToRows
 	}
 	return 0
 }
 
-func (m *ValueField) GetLongData() int64 {
-	if x, ok := m.GetData().(*ValueField_LongData); ok {
-		return x.LongData
+func (m *CollectionDiff) GetFromSize() int64 {
+	if m != nil {
+		return m.FromSize
 	}
 	return 0
 }
 
-func (m *ValueField) GetFloatData() float32 {
-	if x, ok := m.GetData().(*ValueField_FloatData); ok {
-		return x.FloatData
+func (m *CollectionDiff) GetToSize() int64 {
+	if m != nil {
+		return m.ToSize
 	}
 	return 0
 }
 
-func (m *ValueField) GetDoubleData() float64 {
-	if x, ok := m.GetData().(*ValueField_DoubleData); ok {
-		return x.DoubleData
+func (m *CollectionDiff) GetFromSegments() int32 {
+	if m != nil {
+		return m.FromSegments
 	}
 	return 0
 }
 
-func (m *ValueField) GetStringData() string {
-	if x, ok := m.GetData().(*ValueField_StringData); ok {
-		return x.StringData
+func (m *CollectionDiff) GetToSegments() int32 {
+	if m != nil {
+		return m.ToSegments
 	}
-	return ""
+	return 0
 }
 
-func (m *ValueField) GetBytesData() []byte {
-	if x, ok := m.GetData().(*ValueField_BytesData); ok {
-		return x.BytesData
+func (m *CollectionDiff) GetAddedSegments() int32 {
+	if m != nil {
+		return m.AddedSegments
 	}
-	return nil
+	return 0
 }
 
-// XXX_OneofWrappers is for the internal use of the proto package.
-func (*ValueField) XXX_OneofWrappers() []interface{} {
-	return []interface{}{
-		(*ValueField_BoolData)(nil),
-		(*ValueField_IntData)(nil),
-		(*ValueField_LongData)(nil),
-		(*ValueField_FloatData)(nil),
-		(*ValueField_DoubleData)(nil),
-		(*ValueField_StringData)(nil),
-		(*ValueField_BytesData)(nil),
+func (m *CollectionDiff) GetRemovedSegments() int32 {
+	if m != nil {
+		return m.RemovedSegments
+	}
+	return 0
+}
+
+func (m *CollectionDiff) GetChangedSegments() int32 {
+	if m != nil {
+		return m.ChangedSegments
 	}
+	return 0
 }
 
-//*
-// @brief Field schema. The same as https://github.com/milvus-io/milvus-proto schema.proto
-type FieldSchema struct {
-	FieldID              int64           `protobuf:"varint,1,opt,name=fieldID,proto3" json:"fieldID,omitempty"`
-	Name                 string          `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	IsPrimaryKey         bool            `protobuf:"varint,3,opt,name=is_primary_key,json=isPrimaryKey,proto3" json:"is_primary_key,omitempty"`
-	Description          string          `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	DataType             DataType        `protobuf:"varint,5,opt,name=data_type,json=dataType,proto3,enum=milvus.proto.backup.DataType" json:"data_type,omitempty"`
-	TypeParams           []*KeyValuePair `protobuf:"bytes,6,rep,name=type_params,json=typeParams,proto3" json:"type_params,omitempty"`
-	IndexParams          []*KeyValuePair `protobuf:"bytes,7,rep,name=index_params,json=indexParams,proto3" json:"index_params,omitempty"`
-	AutoID               bool            `protobuf:"varint,8,opt,name=autoID,proto3" json:"autoID,omitempty"`
-	State                FieldState      `protobuf:"varint,9,opt,name=state,proto3,enum=milvus.proto.backup.FieldState" json:"state,omitempty"`
-	ElementType          DataType        `protobuf:"varint,10,opt,name=element_type,json=elementType,proto3,enum=milvus.proto.backup.DataType" json:"element_type,omitempty"`
-	DefaultValue         *ValueField     `protobuf:"bytes,11,opt,name=default_value,json=defaultValue,proto3" json:"default_value,omitempty"`
-	IsDynamic            bool            `protobuf:"varint,12,opt,name=is_dynamic,json=isDynamic,proto3" json:"is_dynamic,omitempty"`
-	IsPartitionKey       bool            `protobuf:"varint,13,opt,name=is_partition_key,json=isPartitionKey,proto3" json:"is_partition_key,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
-	XXX_unrecognized     []byte          `json:"-"`
-	XXX_sizecache        int32           `json:"-"`
+// RunSummary is the speed report of a backup or restore run, written into meta/summary of the backup after the run
+type RunSummary struct {
+	// backup or restore
+	Kind       string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	BackupName string `protobuf:"bytes,2,opt,name=backup_name,json=backupName,proto3" json:"backup_name,omitempty"`
+	// id of the restore task, empty for a backup
+	RestoreId string `protobuf:"bytes,3,opt,name=restore_id,json=restoreId,proto3" json:"restore_id,omitempty"`
+	// state code of the task when the run ends
+	State        string `protobuf:"bytes,4,opt,name=state,proto3" json:"state,omitempty"`
+	ErrorMessage string `protobuf:"bytes,5,opt,name=error_message,json=errorMessage,proto3" json:"error_message,omitempty"`
+	// unix time in milliseconds
+	StartTime int64 `protobuf:"varint,6,opt,name=start_time,json=startTime,proto3" json:"start_time,omitempty"`
+	EndTime   int64 `protobuf:"varint,7,opt,name=end_time,json=endTime,proto3" json:"end_time,omitempty"`
+	// bytes and binlog files backed up or restored
+	TotalBytes int64 `protobuf:"varint,8,opt,name=total_bytes,json=totalBytes,proto3" json:"total_bytes,omitempty"`
+	FileCount  int64 `protobuf:"varint,9,opt,name=file_count,json=fileCount,proto3" json:"file_count,omitempty"`
+	// bytes per second of the whole run
+	Throughput int64 `protobuf:"varint,10,opt,name=throughput,proto3" json:"throughput,omitempty"`
+	// phases of the run in order
+	Phases []*PhaseSummary `protobuf:"bytes,11,rep,name=phases,proto3" json:"phases,omitempty"`
+	// the collections taking the longest, slowest first
+	SlowestCollections   []*CollectionRunSummary `protobuf:"bytes,12,rep,name=slowest_collections,json=slowestCollections,proto3" json:"slowest_collections,omitempty"`
+	Retries              *RetrySummary           `protobuf:"bytes,13,opt,name=retries,proto3" json:"retries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}                `json:"-"`
+	XXX_unrecognized     []byte                  `json:"-"`
+	XXX_sizecache        int32                   `json:"-"`
 }
 
-func (m *FieldSchema) Reset()         { *m = FieldSchema{} }
-func (m *FieldSchema) String() string { return proto.CompactTextString(m) }
-func (*FieldSchema) ProtoMessage()    {}
-func (*FieldSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{25}
+func (m *RunSummary) Reset()         { *m = RunSummary{} }
+func (m *RunSummary) String() string { return proto.CompactTextString(m) }
+func (*RunSummary) ProtoMessage()    {}
+func (*RunSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{73}
 }
 
-func (m *FieldSchema) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_FieldSchema.Unmarshal(m, b)
+func (m *RunSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RunSummary.Unmarshal(m, b)
 }
-func (m *FieldSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_FieldSchema.Marshal(b, m, deterministic)
+func (m *RunSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RunSummary.Marshal(b, m, deterministic)
 }
-func (m *FieldSchema) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_FieldSchema.Merge(m, src)
+func (m *RunSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RunSummary.Merge(m, src)
 }
-func (m *FieldSchema) XXX_Size() int {
-	return xxx_messageInfo_FieldSchema.Size(m)
+func (m *RunSummary) XXX_Size() int {
+	return xxx_messageInfo_RunSummary.Size(m)
 }
-func (m *FieldSchema) XXX_DiscardUnknown() {
-	xxx_messageInfo_FieldSchema.DiscardUnknown(m)
+func (m *RunSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RunSummary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_FieldSchema proto.InternalMessageInfo
+var xxx_messageInfo_RunSummary proto.InternalMessageInfo
 
-func (m *FieldSchema) GetFieldID() int64 {
+func (m *RunSummary) GetKind() string {
 	if m != nil {
-		return m.FieldID
+		return m.Kind
 	}
-	return 0
+	return ""
 }
 
-func (m *FieldSchema) GetName() string {
+func (m *RunSummary) GetBackupName() string {
 	if m != nil {
-		return m.Name
+		return m.BackupName
 	}
 	return ""
 }
 
-func (m *FieldSchema) GetIsPrimaryKey() bool {
+func (m *RunSummary) GetRestoreId() string {
 	if m != nil {
-		return m.IsPrimaryKey
+		return m.RestoreId
 	}
-	return false
+	return ""
 }
 
-func (m *FieldSchema) GetDescription() string {
+func (m *RunSummary) GetState() string {
 	if m != nil {
-		return m.Description
+		return m.State
 	}
 	return ""
 }
 
-func (m *FieldSchema) GetDataType() DataType {
+func (m *RunSummary) GetErrorMessage() string {
 	if m != nil {
-		return m.DataType
+		return m.ErrorMessage
 	}
-	return DataType_None
+	return ""
 }
 
-func (m *FieldSchema) GetTypeParams() []*KeyValuePair {
+func (m *RunSummary) GetStartTime() int64 {
 	if m != nil {
-		return m.TypeParams
+		return m.StartTime
 	}
-	return nil
+	return 0
 }
 
-func (m *FieldSchema) GetIndexParams() []*KeyValuePair {
+func (m *RunSummary) GetEndTime() int64 {
 	if m != nil {
-		return m.IndexParams
+		return m.EndTime
 	}
-	return nil
+	return 0
 }
 
-func (m *FieldSchema) GetAutoID() bool {
+func (m *RunSummary) GetTotalBytes() int64 {
 	if m != nil {
-		return m.AutoID
+		return m.TotalBytes
 	}
-	return false
+	return 0
 }
 
-func (m *FieldSchema) GetState() FieldState {
+func (m *RunSummary) GetFileCount() int64 {
 	if m != nil {
-		return m.State
+		return m.FileCount
 	}
-	return FieldState_FieldCreated
+	return 0
 }
 
-func (m *FieldSchema) GetElementType() DataType {
+func (m *RunSummary) GetThroughput() int64 {
 	if m != nil {
-		return m.ElementType
+		return m.Throughput
 	}
-	return DataType_None
+	return 0
 }
 
-func (m *FieldSchema) GetDefaultValue() *ValueField {
+func (m *RunSummary) GetPhases() []*PhaseSummary {
 	if m != nil {
-		return m.DefaultValue
+		return m.Phases
 	}
 	return nil
 }
 
-func (m *FieldSchema) GetIsDynamic() bool {
+func (m *RunSummary) GetSlowestCollections() []*CollectionRunSummary {
 	if m != nil {
-		return m.IsDynamic
+		return m.SlowestCollections
 	}
-	return false
+	return nil
 }
 
-func (m *FieldSchema) GetIsPartitionKey() bool {
+func (m *RunSummary) GetRetries() *RetrySummary {
 	if m != nil {
-		return m.IsPartitionKey
+		return m.Retries
 	}
-	return false
+	return nil
 }
 
-//*
-// @brief Collection schema
-type CollectionSchema struct {
-	Name                 string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	Description          string         `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	AutoID               bool           `protobuf:"varint,3,opt,name=autoID,proto3" json:"autoID,omitempty"`
-	Fields               []*FieldSchema `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`
-	EnableDynamicField   bool           `protobuf:"varint,5,opt,name=enable_dynamic_field,json=enableDynamicField,proto3" json:"enable_dynamic_field,omitempty"`
-	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
-	XXX_unrecognized     []byte         `json:"-"`
-	XXX_sizecache        int32          `json:"-"`
+type PhaseSummary struct {
+	// like prepare, list, copy and meta of a backup
+	Name       string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	DurationMs int64  `protobuf:"varint,2,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	// bytes copied or restored in the phase, 0 if it doesn't move data
+	Bytes int64 `protobuf:"varint,3,opt,name=bytes,proto3" json:"bytes,omitempty"`
+	// bytes per second of the phase
+	Throughput           int64    `protobuf:"varint,4,opt,name=throughput,proto3" json:"throughput,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CollectionSchema) Reset()         { *m = CollectionSchema{} }
-func (m *CollectionSchema) String() string { return proto.CompactTextString(m) }
-func (*CollectionSchema) ProtoMessage()    {}
-func (*CollectionSchema) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{26}
+func (m *PhaseSummary) Reset()         { *m = PhaseSummary{} }
+func (m *PhaseSummary) String() string { return proto.CompactTextString(m) }
+func (*PhaseSummary) ProtoMessage()    {}
+func (*PhaseSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{74}
 }
 
-func (m *CollectionSchema) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CollectionSchema.Unmarshal(m, b)
+func (m *PhaseSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PhaseSummary.Unmarshal(m, b)
 }
-func (m *CollectionSchema) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CollectionSchema.Marshal(b, m, deterministic)
+func (m *PhaseSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PhaseSummary.Marshal(b, m, deterministic)
 }
-func (m *CollectionSchema) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CollectionSchema.Merge(m, src)
+func (m *PhaseSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PhaseSummary.Merge(m, src)
 }
-func (m *CollectionSchema) XXX_Size() int {
-	return xxx_messageInfo_CollectionSchema.Size(m)
+func (m *PhaseSummary) XXX_Size() int {
+	return xxx_messageInfo_PhaseSummary.Size(m)
 }
-func (m *CollectionSchema) XXX_DiscardUnknown() {
-	xxx_messageInfo_CollectionSchema.DiscardUnknown(m)
+func (m *PhaseSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_PhaseSummary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CollectionSchema proto.InternalMessageInfo
+var xxx_messageInfo_PhaseSummary proto.InternalMessageInfo
 
-func (m *CollectionSchema) GetName() string {
+func (m *PhaseSummary) GetName() string {
 	if m != nil {
 		return m.Name
 	}
 	return ""
 }
 
-func (m *CollectionSchema) GetDescription() string {
-	if m != nil {
-		return m.Description
-	}
-	return ""
-}
-
-func (m *CollectionSchema) GetAutoID() bool {
+func (m *PhaseSummary) GetDurationMs() int64 {
 	if m != nil {
-		return m.AutoID
+		return m.DurationMs
 	}
-	return false
+	return 0
 }
 
-func (m *CollectionSchema) GetFields() []*FieldSchema {
+func (m *PhaseSummary) GetBytes() int64 {
 	if m != nil {
-		return m.Fields
+		return m.Bytes
 	}
-	return nil
+	return 0
 }
 
-func (m *CollectionSchema) GetEnableDynamicField() bool {
+func (m *PhaseSummary) GetThroughput() int64 {
 	if m != nil {
-		return m.EnableDynamicField
+		return m.Throughput
 	}
-	return false
+	return 0
 }
 
-type CheckRequest struct {
+type CollectionRunSummary struct {
+	DbName               string   `protobuf:"bytes,1,opt,name=db_name,json=dbName,proto3" json:"db_name,omitempty"`
+	CollectionName       string   `protobuf:"bytes,2,opt,name=collection_name,json=collectionName,proto3" json:"collection_name,omitempty"`
+	DurationMs           int64    `protobuf:"varint,3,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	Size                 int64    `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CheckRequest) Reset()         { *m = CheckRequest{} }
-func (m *CheckRequest) String() string { return proto.CompactTextString(m) }
-func (*CheckRequest) ProtoMessage()    {}
-func (*CheckRequest) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{27}
+func (m *CollectionRunSummary) Reset()         { *m = CollectionRunSummary{} }
+func (m *CollectionRunSummary) String() string { return proto.CompactTextString(m) }
+func (*CollectionRunSummary) ProtoMessage()    {}
+func (*CollectionRunSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{75}
 }
 
-func (m *CheckRequest) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CheckRequest.Unmarshal(m, b)
+func (m *CollectionRunSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectionRunSummary.Unmarshal(m, b)
 }
-func (m *CheckRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CheckRequest.Marshal(b, m, deterministic)
+func (m *CollectionRunSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectionRunSummary.Marshal(b, m, deterministic)
 }
-func (m *CheckRequest) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckRequest.Merge(m, src)
+func (m *CollectionRunSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectionRunSummary.Merge(m, src)
 }
-func (m *CheckRequest) XXX_Size() int {
-	return xxx_messageInfo_CheckRequest.Size(m)
+func (m *CollectionRunSummary) XXX_Size() int {
+	return xxx_messageInfo_CollectionRunSummary.Size(m)
 }
-func (m *CheckRequest) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckRequest.DiscardUnknown(m)
+func (m *CollectionRunSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectionRunSummary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckRequest proto.InternalMessageInfo
+var xxx_messageInfo_CollectionRunSummary proto.InternalMessageInfo
 
-type CheckResponse struct {
-	// response code. 0 means success. others are fail
-	Code ResponseCode `protobuf:"varint,1,opt,name=code,proto3,enum=milvus.proto.backup.ResponseCode" json:"code,omitempty"`
-	// error msg if fail
-	Msg                  string   `protobuf:"bytes,2,opt,name=msg,proto3" json:"msg,omitempty"`
+func (m *CollectionRunSummary) GetDbName() string {
+	if m != nil {
+		return m.DbName
+	}
+	return ""
+}
+
+func (m *CollectionRunSummary) GetCollectionName() string {
+	if m != nil {
+		return m.CollectionName
+	}
+	return ""
+}
+
+func (m *CollectionRunSummary) GetDurationMs() int64 {
+	if m != nil {
+		return m.DurationMs
+	}
+	return 0
+}
+
+func (m *CollectionRunSummary) GetSize() int64 {
+	if m != nil {
+		return m.Size
+	}
+	return 0
+}
+
+type RetrySummary struct {
+	// storage operations retried after transient errors
+	Storage int64 `protobuf:"varint,1,opt,name=storage,proto3" json:"storage,omitempty"`
+	// times the segments of collections are listed again after binlogs are deleted by compaction
+	CompactedSegments int64 `protobuf:"varint,2,opt,name=compacted_segments,json=compactedSegments,proto3" json:"compacted_segments,omitempty"`
+	// import tasks of milvus executed again after failures
+	BulkInsert           int64    `protobuf:"varint,3,opt,name=bulk_insert,json=bulkInsert,proto3" json:"bulk_insert,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
 }
 
-func (m *CheckResponse) Reset()         { *m = CheckResponse{} }
-func (m *CheckResponse) String() string { return proto.CompactTextString(m) }
-func (*CheckResponse) ProtoMessage()    {}
-func (*CheckResponse) Descriptor() ([]byte, []int) {
-	return fileDescriptor_65240d19de191688, []int{28}
+func (m *RetrySummary) Reset()         { *m = RetrySummary{} }
+func (m *RetrySummary) String() string { return proto.CompactTextString(m) }
+func (*RetrySummary) ProtoMessage()    {}
+func (*RetrySummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_65240d19de191688, []int{76}
 }
 
-func (m *CheckResponse) XXX_Unmarshal(b []byte) error {
-	return xxx_messageInfo_CheckResponse.Unmarshal(m, b)
+func (m *RetrySummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RetrySummary.Unmarshal(m, b)
 }
-func (m *CheckResponse) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
-	return xxx_messageInfo_CheckResponse.Marshal(b, m, deterministic)
+func (m *RetrySummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RetrySummary.Marshal(b, m, deterministic)
 }
-func (m *CheckResponse) XXX_Merge(src proto.Message) {
-	xxx_messageInfo_CheckResponse.Merge(m, src)
+func (m *RetrySummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RetrySummary.Merge(m, src)
 }
-func (m *CheckResponse) XXX_Size() int {
-	return xxx_messageInfo_CheckResponse.Size(m)
+func (m *RetrySummary) XXX_Size() int {
+	return xxx_messageInfo_RetrySummary.Size(m)
 }
-func (m *CheckResponse) XXX_DiscardUnknown() {
-	xxx_messageInfo_CheckResponse.DiscardUnknown(m)
+func (m *RetrySummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_RetrySummary.DiscardUnknown(m)
 }
 
-var xxx_messageInfo_CheckResponse proto.InternalMessageInfo
+var xxx_messageInfo_RetrySummary proto.InternalMessageInfo
 
-func (m *CheckResponse) GetCode() ResponseCode {
+func (m *RetrySummary) GetStorage() int64 {
 	if m != nil {
-		return m.Code
+		return m.Storage
 	}
-	return ResponseCode_Success
+	return 0
 }
 
-func (m *CheckResponse) GetMsg() string {
+func (m *RetrySummary) GetCompactedSegments() int64 {
 	if m != nil {
-		return m.Msg
+		return m.CompactedSegments
 	}
-	return ""
+	return 0
+}
+
+func (m *RetrySummary) GetBulkInsert() int64 {
+	if m != nil {
+		return m.BulkInsert
+	}
+	return 0
 }
 
 func init() {
 	proto.RegisterEnum("milvus.proto.backup.ResponseCode", ResponseCode_name, ResponseCode_value)
 	proto.RegisterEnum("milvus.proto.backup.BackupTaskStateCode", BackupTaskStateCode_name, BackupTaskStateCode_value)
+	proto.RegisterEnum("milvus.proto.backup.JobStateCode", JobStateCode_name, JobStateCode_value)
 	proto.RegisterEnum("milvus.proto.backup.RestoreTaskStateCode", RestoreTaskStateCode_name, RestoreTaskStateCode_value)
 	proto.RegisterEnum("milvus.proto.backup.ConsistencyLevel", ConsistencyLevel_name, ConsistencyLevel_value)
 	proto.RegisterEnum("milvus.proto.backup.DataType", DataType_name, DataType_value)
 	proto.RegisterEnum("milvus.proto.backup.FieldState", FieldState_name, FieldState_value)
+	proto.RegisterEnum("milvus.proto.backup.FunctionType", FunctionType_name, FunctionType_value)
 	proto.RegisterType((*IndexInfo)(nil), "milvus.proto.backup.IndexInfo")
 	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.IndexInfo.ParamsEntry")
 	proto.RegisterType((*CollectionBackupInfo)(nil), "milvus.proto.backup.CollectionBackupInfo")
+	proto.RegisterType((*BinlogScan)(nil), "milvus.proto.backup.BinlogScan")
+	proto.RegisterType((*RowCountCheck)(nil), "milvus.proto.backup.RowCountCheck")
 	proto.RegisterType((*PartitionBackupInfo)(nil), "milvus.proto.backup.PartitionBackupInfo")
 	proto.RegisterType((*SegmentBackupInfo)(nil), "milvus.proto.backup.SegmentBackupInfo")
 	proto.RegisterType((*BackupInfo)(nil), "milvus.proto.backup.BackupInfo")
+	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.BackupInfo.LabelsEntry")
+	proto.RegisterType((*RBACMeta)(nil), "milvus.proto.backup.RBACMeta")
+	proto.RegisterType((*UserEntity)(nil), "milvus.proto.backup.UserEntity")
+	proto.RegisterType((*RoleEntity)(nil), "milvus.proto.backup.RoleEntity")
+	proto.RegisterType((*GrantEntity)(nil), "milvus.proto.backup.GrantEntity")
 	proto.RegisterType((*CollectionLevelBackupInfo)(nil), "milvus.proto.backup.CollectionLevelBackupInfo")
 	proto.RegisterType((*PartitionLevelBackupInfo)(nil), "milvus.proto.backup.PartitionLevelBackupInfo")
 	proto.RegisterType((*SegmentLevelBackupInfo)(nil), "milvus.proto.backup.SegmentLevelBackupInfo")
+	proto.RegisterType((*PartitionNames)(nil), "milvus.proto.backup.PartitionNames")
 	proto.RegisterType((*CreateBackupRequest)(nil), "milvus.proto.backup.CreateBackupRequest")
+	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.CreateBackupRequest.LabelsEntry")
+	proto.RegisterMapType((map[string]*PartitionNames)(nil), "milvus.proto.backup.CreateBackupRequest.PartitionsEntry")
 	proto.RegisterType((*BackupInfoResponse)(nil), "milvus.proto.backup.BackupInfoResponse")
 	proto.RegisterType((*GetBackupRequest)(nil), "milvus.proto.backup.GetBackupRequest")
 	proto.RegisterType((*ListBackupsRequest)(nil), "milvus.proto.backup.ListBackupsRequest")
 	proto.RegisterType((*ListBackupsResponse)(nil), "milvus.proto.backup.ListBackupsResponse")
 	proto.RegisterType((*DeleteBackupRequest)(nil), "milvus.proto.backup.DeleteBackupRequest")
 	proto.RegisterType((*DeleteBackupResponse)(nil), "milvus.proto.backup.DeleteBackupResponse")
+	proto.RegisterType((*PauseBackupRequest)(nil), "milvus.proto.backup.PauseBackupRequest")
+	proto.RegisterType((*PauseBackupResponse)(nil), "milvus.proto.backup.PauseBackupResponse")
+	proto.RegisterType((*ResumeBackupRequest)(nil), "milvus.proto.backup.ResumeBackupRequest")
+	proto.RegisterType((*PruneBackupsRequest)(nil), "milvus.proto.backup.PruneBackupsRequest")
+	proto.RegisterType((*PruneBackupsResponse)(nil), "milvus.proto.backup.PruneBackupsResponse")
+	proto.RegisterType((*GarbageCollectRequest)(nil), "milvus.proto.backup.GarbageCollectRequest")
+	proto.RegisterType((*GarbageCollectResponse)(nil), "milvus.proto.backup.GarbageCollectResponse")
+	proto.RegisterType((*VerifyBackupRequest)(nil), "milvus.proto.backup.VerifyBackupRequest")
+	proto.RegisterType((*VerifyBackupResponse)(nil), "milvus.proto.backup.VerifyBackupResponse")
+	proto.RegisterType((*CollectionEstimate)(nil), "milvus.proto.backup.CollectionEstimate")
+	proto.RegisterType((*EstimateBackupResponse)(nil), "milvus.proto.backup.EstimateBackupResponse")
+	proto.RegisterType((*ScheduleJobStatus)(nil), "milvus.proto.backup.ScheduleJobStatus")
+	proto.RegisterType((*GetScheduleResponse)(nil), "milvus.proto.backup.GetScheduleResponse")
+	proto.RegisterType((*ContinuousBackupStatus)(nil), "milvus.proto.backup.ContinuousBackupStatus")
+	proto.RegisterType((*GetRecoveryPointResponse)(nil), "milvus.proto.backup.GetRecoveryPointResponse")
+	proto.RegisterType((*JobInfo)(nil), "milvus.proto.backup.JobInfo")
+	proto.RegisterType((*GetJobRequest)(nil), "milvus.proto.backup.GetJobRequest")
+	proto.RegisterType((*CancelJobRequest)(nil), "milvus.proto.backup.CancelJobRequest")
+	proto.RegisterType((*ListJobsRequest)(nil), "milvus.proto.backup.ListJobsRequest")
+	proto.RegisterType((*ListJobsResponse)(nil), "milvus.proto.backup.ListJobsResponse")
+	proto.RegisterType((*ResumeJobRequest)(nil), "milvus.proto.backup.ResumeJobRequest")
+	proto.RegisterType((*JobResponse)(nil), "milvus.proto.backup.JobResponse")
 	proto.RegisterType((*RestoreBackupRequest)(nil), "milvus.proto.backup.RestoreBackupRequest")
+	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.RestoreBackupRequest.CollectionPropertiesEntry")
 	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.RestoreBackupRequest.CollectionRenamesEntry")
+	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.RestoreBackupRequest.FieldMappingsEntry")
+	proto.RegisterMapType((map[string]*PartitionNames)(nil), "milvus.proto.backup.RestoreBackupRequest.PartitionsEntry")
 	proto.RegisterType((*RestorePartitionTask)(nil), "milvus.proto.backup.RestorePartitionTask")
 	proto.RegisterType((*RestoreCollectionTask)(nil), "milvus.proto.backup.RestoreCollectionTask")
+	proto.RegisterMapType((map[int64]int64)(nil), "milvus.proto.backup.RestoreCollectionTask.FieldIdMappingsEntry")
+	proto.RegisterMapType((map[string]string)(nil), "milvus.proto.backup.RestoreCollectionTask.PropertiesEntry")
+	proto.RegisterType((*SampleVerification)(nil), "milvus.proto.backup.SampleVerification")
+	proto.RegisterType((*BulkInsertJob)(nil), "milvus.proto.backup.BulkInsertJob")
 	proto.RegisterType((*RestoreBackupTask)(nil), "milvus.proto.backup.RestoreBackupTask")
 	proto.RegisterType((*RestoreBackupResponse)(nil), "milvus.proto.backup.RestoreBackupResponse")
+	proto.RegisterType((*RestoreDryRunReport)(nil), "milvus.proto.backup.RestoreDryRunReport")
 	proto.RegisterType((*GetRestoreStateRequest)(nil), "milvus.proto.backup.GetRestoreStateRequest")
 	proto.RegisterType((*FieldBinlog)(nil), "milvus.proto.backup.FieldBinlog")
 	proto.RegisterType((*Binlog)(nil), "milvus.proto.backup.Binlog")
+	proto.RegisterType((*BackupManifest)(nil), "milvus.proto.backup.BackupManifest")
+	proto.RegisterType((*ManifestFile)(nil), "milvus.proto.backup.ManifestFile")
+	proto.RegisterType((*EtcdSnapshotInfo)(nil), "milvus.proto.backup.EtcdSnapshotInfo")
+	proto.RegisterType((*EtcdSnapshot)(nil), "milvus.proto.backup.EtcdSnapshot")
+	proto.RegisterType((*EtcdKeyValue)(nil), "milvus.proto.backup.EtcdKeyValue")
 	proto.RegisterType((*KeyValuePair)(nil), "milvus.proto.backup.KeyValuePair")
 	proto.RegisterType((*ValueField)(nil), "milvus.proto.backup.ValueField")
 	proto.RegisterType((*FieldSchema)(nil), "milvus.proto.backup.FieldSchema")
+	proto.RegisterType((*FunctionSchema)(nil), "milvus.proto.backup.FunctionSchema")
 	proto.RegisterType((*CollectionSchema)(nil), "milvus.proto.backup.CollectionSchema")
 	proto.RegisterType((*CheckRequest)(nil), "milvus.proto.backup.CheckRequest")
+	proto.RegisterType((*CompatIssue)(nil), "milvus.proto.backup.CompatIssue")
+	proto.RegisterType((*CheckCompatibilityRequest)(nil), "milvus.proto.backup.CheckCompatibilityRequest")
+	proto.RegisterType((*CheckCompatibilityResponse)(nil), "milvus.proto.backup.CheckCompatibilityResponse")
 	proto.RegisterType((*CheckResponse)(nil), "milvus.proto.backup.CheckResponse")
+	proto.RegisterType((*StorageCheck)(nil), "milvus.proto.backup.StorageCheck")
+	proto.RegisterType((*BackupDiff)(nil), "milvus.proto.backup.BackupDiff")
+	proto.RegisterType((*CollectionDiff)(nil), "milvus.proto.backup.CollectionDiff")
+	proto.RegisterType((*RunSummary)(nil), "milvus.proto.backup.RunSummary")
+	proto.RegisterType((*PhaseSummary)(nil), "milvus.proto.backup.PhaseSummary")
+	proto.RegisterType((*CollectionRunSummary)(nil), "milvus.proto.backup.CollectionRunSummary")
+	proto.RegisterType((*RetrySummary)(nil), "milvus.proto.backup.RetrySummary")
 }
 
 func init() { proto.RegisterFile("backup.proto", fileDescriptor_65240d19de191688) }
 
 var fileDescriptor_65240d19de191688 = []byte{
-	// 2787 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x5a, 0x4b, 0x73, 0x1b, 0xc7,
-	0x11, 0x26, 0xde, 0x40, 0xe3, 0xc1, 0xe5, 0x90, 0xa6, 0x20, 0xca, 0xb2, 0xa8, 0x8d, 0x2d, 0x53,
-	0x74, 0x85, 0x72, 0xe8, 0x47, 0x6c, 0x55, 0xfc, 0x10, 0x5f, 0x12, 0x2c, 0x99, 0x62, 0x2d, 0x29,
-	0x96, 0xca, 0x79, 0x6c, 0x2d, 0xb0, 0x43, 0x70, 0xc3, 0xc5, 0x0e, 0xb2, 0x33, 0x90, 0x05, 0x55,
-	0x25, 0xb7, 0x54, 0x72, 0xcc, 0x21, 0xa7, 0xfc, 0x83, 0xdc, 0xe2, 0x4a, 0xf9, 0x92, 0x7f, 0x90,
-	0x54, 0x2e, 0xf9, 0x07, 0xb9, 0xa5, 0xf2, 0x0b, 0x72, 0x4d, 0x4d, 0xcf, 0xec, 0x62, 0x01, 0x2e,
-	0x49, 0x30, 0xe5, 0x8a, 0xe2, 0xdc, 0x66, 0xbe, 0xe9, 0xee, 0x99, 0xe9, 0xd7, 0xf4, 0x36, 0x00,
-	0xb5, 0xb6, 0xd3, 0x39, 0x19, 0xf4, 0xd7, 0xfa, 0x21, 0x13, 0x8c, 0xcc, 0xf7, 0x3c, 0xff, 0xd9,
-	0x80, 0xab, 0xd9, 0x9a, 0x5a, 0x5a, 0x7a, 0xb5, 0xcb, 0x58, 0xd7, 0xa7, 0x77, 0x10, 0x6c, 0x0f,
-	0x8e, 0xee, 0x70, 0x11, 0x0e, 0x3a, 0x42, 0x11, 0x99, 0xff, 0xc8, 0x40, 0xa5, 0x15, 0xb8, 0xf4,
-	0x79, 0x2b, 0x38, 0x62, 0xe4, 0x3a, 0xc0, 0x91, 0x47, 0x7d, 0xd7, 0x0e, 0x9c, 0x1e, 0x6d, 0x66,
-	0x96, 0x33, 0x2b, 0x15, 0xab, 0x82, 0xc8, 0xae, 0xd3, 0xa3, 0x72, 0xd9, 0x93, 0xb4, 0x6a, 0x39,
-	0xab, 0x96, 0x11, 0x19, 0x5f, 0x16, 0xc3, 0x3e, 0x6d, 0xe6, 0x12, 0xcb, 0x07, 0xc3, 0x3e, 0x25,
-	0x1b, 0x50, 0xec, 0x3b, 0xa1, 0xd3, 0xe3, 0xcd, 0xfc, 0x72, 0x6e, 0xa5, 0xba, 0xbe, 0xba, 0x96,
-	0x72, 0xdc, 0xb5, 0xf8, 0x30, 0x6b, 0x7b, 0x48, 0xbc, 0x1d, 0x88, 0x70, 0x68, 0x69, 0xce, 0xa5,
-	0x0f, 0xa1, 0x9a, 0x80, 0x89, 0x01, 0xb9, 0x13, 0x3a, 0xd4, 0x07, 0x95, 0x43, 0xb2, 0x00, 0x85,
-	0x67, 0x8e, 0x3f, 0x88, 0x4e, 0xa7, 0x26, 0x77, 0xb3, 0x1f, 0x64, 0xcc, 0xbf, 0x15, 0x61, 0x61,
-	0x93, 0xf9, 0x3e, 0xed, 0x08, 0x8f, 0x05, 0x1b, 0xb8, 0x1b, 0x5e, 0xba, 0x01, 0x59, 0xcf, 0xd5,
-	0x32, 0xb2, 0x9e, 0x4b, 0xee, 0x03, 0x70, 0xe1, 0x08, 0x6a, 0x77, 0x98, 0xab, 0xe4, 0x34, 0xd6,
-	0x57, 0x52, 0xcf, 0xaa, 0x84, 0x1c, 0x38, 0xfc, 0x64, 0x5f, 0x32, 0x6c, 0x32, 0x97, 0x5a, 0x15,
-	0x1e, 0x0d, 0x89, 0x09, 0x35, 0x1a, 0x86, 0x2c, 0xfc, 0x9c, 0x72, 0xee, 0x74, 0x23, 0x8d, 0x8c,
-	0x61, 0x52, 0x67, 0x5c, 0x38, 0xa1, 0xb0, 0x85, 0xd7, 0xa3, 0xcd, 0xfc, 0x72, 0x66, 0x25, 0x87,
-	0x22, 0x42, 0x71, 0xe0, 0xf5, 0x28, 0xb9, 0x0a, 0x65, 0x1a, 0xb8, 0x6a, 0xb1, 0x80, 0x8b, 0x25,
-	0x1a, 0xb8, 0xb8, 0xb4, 0x04, 0xe5, 0x7e, 0xc8, 0xba, 0x21, 0xe5, 0xbc, 0x59, 0x5c, 0xce, 0xac,
-	0x14, 0xac, 0x78, 0x4e, 0xbe, 0x03, 0xf5, 0x4e, 0x7c, 0x55, 0xdb, 0x73, 0x9b, 0x25, 0xe4, 0xad,
-	0x8d, 0xc0, 0x96, 0x4b, 0xae, 0x40, 0xc9, 0x6d, 0x2b, 0x53, 0x96, 0xf1, 0x64, 0x45, 0xb7, 0x8d,
-	0x76, 0x7c, 0x13, 0x66, 0x13, 0xdc, 0x48, 0x50, 0x41, 0x82, 0xc6, 0x08, 0x46, 0xc2, 0x8f, 0xa0,
-	0xc8, 0x3b, 0xc7, 0xb4, 0xe7, 0x34, 0x61, 0x39, 0xb3, 0x52, 0x5d, 0x7f, 0x23, 0x55, 0x4b, 0x23,
-	0xa5, 0xef, 0x23, 0xb1, 0xa5, 0x99, 0xf0, 0xee, 0xc7, 0x4e, 0xe8, 0x72, 0x3b, 0x18, 0xf4, 0x9a,
-	0x55, 0xbc, 0x43, 0x45, 0x21, 0xbb, 0x83, 0x1e, 0xb1, 0x60, 0xae, 0xc3, 0x02, 0xee, 0x71, 0x41,
-	0x83, 0xce, 0xd0, 0xf6, 0xe9, 0x33, 0xea, 0x37, 0x6b, 0x68, 0x8e, 0xb3, 0x36, 0x8a, 0xa9, 0x1f,
-	0x49, 0x62, 0xcb, 0xe8, 0x4c, 0x20, 0xe4, 0x09, 0xcc, 0xf5, 0x9d, 0x50, 0x78, 0x78, 0x33, 0xc5,
-	0xc6, 0x9b, 0x75, 0x74, 0xc7, 0x74, 0x13, 0xef, 0x45, 0xd4, 0x23, 0x87, 0xb1, 0x8c, 0xfe, 0x38,
-	0xc8, 0xc9, 0x6d, 0x30, 0x14, 0x3d, 0x5a, 0x8a, 0x0b, 0xa7, 0xd7, 0x6f, 0x36, 0x96, 0x33, 0x2b,
-	0x79, 0x6b, 0x56, 0xe1, 0x07, 0x11, 0x4c, 0x08, 0xe4, 0xb9, 0xf7, 0x82, 0x36, 0x67, 0xd1, 0x22,
-	0x38, 0x26, 0xd7, 0xa0, 0x72, 0xec, 0x70, 0x1b, 0x43, 0xa5, 0x69, 0x2c, 0x67, 0x56, 0xca, 0x56,
-	0xf9, 0xd8, 0xe1, 0x18, 0x0a, 0xe4, 0x13, 0xa8, 0xaa, 0xa8, 0xf2, 0x82, 0x23, 0xc6, 0x9b, 0x73,
-	0x78, 0xd8, 0xd7, 0xce, 0x8f, 0x1d, 0x4b, 0x05, 0xa2, 0x1c, 0x72, 0xa9, 0x66, 0x9f, 0x39, 0xae,
-	0x8d, 0x8e, 0xd9, 0x24, 0x2a, 0x2c, 0x25, 0x82, 0x4e, 0x4b, 0xee, 0xc2, 0x55, 0x7d, 0xf6, 0xfe,
-	0xf1, 0x90, 0x7b, 0x1d, 0xc7, 0x4f, 0x5c, 0x62, 0x1e, 0x2f, 0x71, 0x45, 0x11, 0xec, 0xe9, 0xf5,
-	0xf8, 0x32, 0xe6, 0xaf, 0xb3, 0x30, 0x9f, 0xa2, 0x21, 0x72, 0x13, 0x6a, 0x23, 0x35, 0xeb, 0xe0,
-	0xca, 0x59, 0xd5, 0x18, 0x6b, 0xb9, 0xe4, 0x0d, 0x68, 0x8c, 0x48, 0x12, 0xf9, 0xa4, 0x1e, 0xa3,
-	0xe8, 0x62, 0xa7, 0x3c, 0x39, 0x97, 0xe2, 0xc9, 0x8f, 0x61, 0x96, 0xd3, 0x6e, 0x8f, 0x06, 0x22,
-	0xb6, 0xa9, 0x4a, 0x31, 0xb7, 0x52, 0xd5, 0xb4, 0xaf, 0x68, 0x13, 0x16, 0x6d, 0xf0, 0x24, 0xc4,
-	0x63, 0x23, 0x15, 0x12, 0x46, 0x1a, 0x57, 0x63, 0x71, 0x42, 0x8d, 0xe6, 0x2f, 0x73, 0x30, 0x77,
-	0x4a, 0x30, 0xba, 0xb8, 0x3e, 0x59, 0xac, 0x86, 0x8a, 0x46, 0x5a, 0xee, 0xe9, 0xdb, 0x65, 0x53,
-	0x6e, 0x37, 0xa9, 0xcc, 0xdc, 0x69, 0x65, 0xbe, 0x06, 0xd5, 0x60, 0xd0, 0xb3, 0xd9, 0x91, 0x1d,
-	0xb2, 0x2f, 0x79, 0x94, 0x46, 0x82, 0x41, 0xef, 0xf1, 0x91, 0xc5, 0xbe, 0xe4, 0xe4, 0x2e, 0x94,
-	0xda, 0x5e, 0xe0, 0xb3, 0x2e, 0x6f, 0x16, 0x50, 0x31, 0xcb, 0xa9, 0x8a, 0xd9, 0x91, 0x99, 0x7e,
-	0x03, 0x09, 0xad, 0x88, 0x81, 0x7c, 0x0c, 0x98, 0xd2, 0x38, 0x72, 0x17, 0xa7, 0xe4, 0x1e, 0xb1,
-	0x48, 0x7e, 0x97, 0xfa, 0xc2, 0x41, 0xfe, 0xd2, 0xb4, 0xfc, 0x31, 0x4b, 0x6c, 0x8b, 0x72, 0xc2,
-	0x16, 0x57, 0xa1, 0xdc, 0x0d, 0xd9, 0xa0, 0x2f, 0xd5, 0x51, 0x51, 0x69, 0x11, 0xe7, 0x2d, 0xd7,
-	0xfc, 0x63, 0x0e, 0xe0, 0xff, 0x3b, 0xb9, 0x13, 0xc8, 0x63, 0xbc, 0x94, 0x70, 0x47, 0x1c, 0xa7,
-	0x26, 0xa0, 0x72, 0x7a, 0x02, 0x7a, 0x0a, 0x24, 0xe1, 0x73, 0x51, 0xbc, 0x54, 0xd0, 0x30, 0xb7,
-	0x2f, 0x48, 0xe0, 0x89, 0x90, 0x99, 0xeb, 0x4c, 0xa0, 0x23, 0x4b, 0x41, 0xc2, 0x52, 0x6f, 0x40,
-	0x43, 0x89, 0xb4, 0x9f, 0xd1, 0x90, 0x7b, 0x2c, 0xc0, 0x3c, 0x5f, 0xb1, 0xea, 0x0a, 0x3d, 0x54,
-	0xa0, 0xf9, 0x23, 0xb8, 0x3a, 0xda, 0x05, 0x53, 0x75, 0xc2, 0x86, 0x9f, 0x40, 0x41, 0xe5, 0xbe,
-	0xcc, 0x65, 0x0f, 0xa9, 0xf8, 0xcc, 0x2f, 0xa0, 0x19, 0x67, 0xa9, 0x49, 0xe1, 0x1f, 0x8f, 0x0b,
-	0x9f, 0xfe, 0x15, 0xd0, 0xb2, 0x0f, 0x61, 0x51, 0x87, 0xfd, 0xa4, 0xe4, 0x1f, 0x8c, 0x4b, 0x9e,
-	0x36, 0x17, 0x69, 0xb9, 0xbf, 0xca, 0xc2, 0xfc, 0x66, 0x48, 0x1d, 0x41, 0xd5, 0x9a, 0x45, 0x7f,
-	0x36, 0xa0, 0x5c, 0x90, 0x57, 0xa1, 0x12, 0xaa, 0x61, 0x2b, 0xf2, 0xeb, 0x11, 0x40, 0x6e, 0x40,
-	0x55, 0xfb, 0x41, 0x22, 0xa5, 0x82, 0x82, 0x76, 0xb5, 0xa3, 0x4c, 0xbc, 0xed, 0xbc, 0x99, 0x5b,
-	0xce, 0xad, 0x54, 0xac, 0xd9, 0xf1, 0xc7, 0x9d, 0xcb, 0x52, 0xca, 0xe1, 0xc3, 0xa0, 0x83, 0x8e,
-	0x5b, 0xb6, 0xd4, 0x84, 0x7c, 0x04, 0x0d, 0xb7, 0x6d, 0x8f, 0x68, 0x39, 0xba, 0x6e, 0x75, 0x7d,
-	0x71, 0x4d, 0xd5, 0x99, 0x6b, 0x51, 0x9d, 0xb9, 0x76, 0x28, 0x4b, 0x2f, 0xab, 0xee, 0xb6, 0x47,
-	0xa6, 0x41, 0xa1, 0x47, 0x2c, 0xec, 0xa8, 0x04, 0x5a, 0xb6, 0xd4, 0x44, 0x3e, 0x80, 0x3d, 0x2a,
-	0x1c, 0x9b, 0x05, 0xfe, 0x10, 0xfd, 0xba, 0x6c, 0x95, 0x25, 0xf0, 0x38, 0xf0, 0x87, 0xe6, 0x1f,
-	0x32, 0x40, 0x12, 0xfa, 0xa1, 0xbc, 0xcf, 0x02, 0x4e, 0x2f, 0x50, 0xc4, 0x7b, 0x90, 0x4f, 0x44,
-	0xf8, 0xcd, 0x54, 0xdd, 0x47, 0xa2, 0x30, 0xb4, 0x91, 0x5c, 0x16, 0x94, 0x3d, 0xde, 0xd5, 0xc1,
-	0x2c, 0x87, 0xe4, 0x1d, 0xc8, 0xbb, 0x8e, 0x70, 0x50, 0x09, 0xd5, 0xf5, 0x1b, 0xe7, 0xa4, 0x0a,
-	0x3c, 0x1d, 0x12, 0x9b, 0x7f, 0xc9, 0x80, 0x71, 0x9f, 0x8a, 0x6f, 0xd4, 0x72, 0xd7, 0xa0, 0xa2,
-	0x09, 0xf4, 0x1b, 0x50, 0xb1, 0xca, 0x0a, 0xd0, 0xdc, 0x83, 0xce, 0x09, 0x15, 0x8a, 0x3b, 0xaf,
-	0xb9, 0x11, 0x42, 0x6e, 0x02, 0xf9, 0xbe, 0x23, 0x8e, 0xd1, 0x58, 0x15, 0x0b, 0xc7, 0x32, 0x36,
-	0xbf, 0xf4, 0xc4, 0x31, 0x1b, 0x08, 0xdb, 0xa5, 0xc2, 0xf1, 0x7c, 0x6d, 0x94, 0xba, 0x46, 0xb7,
-	0x10, 0x34, 0x7f, 0x08, 0xe4, 0x91, 0xc7, 0xa3, 0xb7, 0x71, 0xba, 0xdb, 0xa4, 0x94, 0x90, 0xd9,
-	0xb4, 0x12, 0xd2, 0xfc, 0x2a, 0x03, 0xf3, 0x63, 0xd2, 0x5f, 0x96, 0x75, 0x73, 0xd3, 0x5b, 0xf7,
-	0x00, 0xe6, 0xb7, 0xa8, 0x4f, 0xbf, 0xd9, 0xc8, 0x34, 0x7f, 0x0e, 0x0b, 0xe3, 0x52, 0xff, 0xab,
-	0x9a, 0x30, 0xff, 0x5e, 0x80, 0x05, 0x8b, 0x72, 0xc1, 0xc2, 0x97, 0x96, 0x70, 0xde, 0x82, 0xc4,
-	0xa3, 0x62, 0xf3, 0xc1, 0xd1, 0x91, 0xf7, 0x5c, 0xbb, 0x72, 0x42, 0xc6, 0x3e, 0xe2, 0x84, 0x8d,
-	0x3d, 0x63, 0x21, 0x55, 0x92, 0x55, 0x75, 0xf3, 0xe9, 0x59, 0x6a, 0x38, 0x75, 0xbb, 0xc4, 0xb3,
-	0x61, 0x29, 0x11, 0xea, 0x7b, 0x33, 0x71, 0x10, 0x8d, 0x8f, 0xd2, 0x61, 0x31, 0x99, 0x0e, 0x27,
-	0x02, 0xaf, 0x74, 0x66, 0xe0, 0x95, 0x13, 0x81, 0x77, 0x3a, 0x87, 0x56, 0x2e, 0x93, 0x43, 0x97,
-	0x20, 0x4e, 0x8e, 0xf8, 0xd6, 0x26, 0x92, 0xa5, 0x2c, 0x4b, 0x42, 0x75, 0x4f, 0xfc, 0x18, 0xc0,
-	0xd7, 0xb6, 0x6c, 0x8d, 0x61, 0x92, 0x66, 0xc0, 0xe9, 0xbd, 0x81, 0x60, 0x8a, 0xa6, 0xa6, 0x68,
-	0x92, 0x18, 0x79, 0x1b, 0xe6, 0xdd, 0x90, 0xf5, 0xb7, 0x9f, 0x7b, 0x5c, 0x8c, 0xf6, 0x6e, 0xd6,
-	0x91, 0x34, 0x6d, 0x89, 0xdc, 0x82, 0x46, 0x0c, 0x2b, 0xb9, 0x0d, 0x24, 0x9e, 0x40, 0xc9, 0x3a,
-	0x2c, 0xf0, 0x13, 0xaf, 0xaf, 0xde, 0xb6, 0x84, 0xe8, 0x59, 0xa4, 0x4e, 0x5d, 0x5b, 0xda, 0x82,
-	0xc5, 0x74, 0x43, 0x5d, 0xaa, 0x03, 0xf0, 0x75, 0x36, 0x76, 0xf1, 0xf8, 0x41, 0x97, 0xf5, 0xdd,
-	0xa9, 0x22, 0xf1, 0x41, 0x4a, 0x91, 0x78, 0xfb, 0x3c, 0x9f, 0xfa, 0x1f, 0xac, 0x12, 0x5b, 0x80,
-	0x5f, 0x08, 0xba, 0xc0, 0x43, 0xc7, 0xbc, 0x4c, 0x75, 0x03, 0x92, 0x59, 0xcd, 0xcd, 0xaf, 0x8b,
-	0xf0, 0x8a, 0xbe, 0xe8, 0xc8, 0x0a, 0xdf, 0x6a, 0xc5, 0x7d, 0x06, 0x55, 0x19, 0x7d, 0x91, 0x72,
-	0x8a, 0xa8, 0x9c, 0x4b, 0xd4, 0x95, 0x20, 0xb9, 0xd5, 0x9c, 0xbc, 0x0b, 0x8b, 0xc2, 0x09, 0xbb,
-	0x54, 0xd8, 0x93, 0x2f, 0x9e, 0x4a, 0x06, 0x0b, 0x6a, 0x75, 0x73, 0xbc, 0x75, 0xe2, 0xc0, 0x95,
-	0xd1, 0x47, 0x9d, 0x8e, 0x4e, 0x5b, 0x38, 0xfc, 0x84, 0x37, 0xcb, 0xe7, 0x54, 0xb9, 0x69, 0xee,
-	0x6b, 0xbd, 0x12, 0x4b, 0x4a, 0x68, 0x15, 0x9b, 0x40, 0x5a, 0xb0, 0x6b, 0x63, 0x5d, 0xae, 0xbe,
-	0x94, 0xa2, 0x5c, 0xe0, 0xee, 0xcb, 0xfa, 0xfc, 0x16, 0xcc, 0x0a, 0x16, 0x1f, 0x20, 0x51, 0xbe,
-	0xd7, 0x05, 0xd3, 0xd2, 0x90, 0x2e, 0xe9, 0x6a, 0xd5, 0x09, 0x57, 0x7b, 0x1d, 0x1a, 0x5a, 0x03,
-	0x51, 0x3f, 0xa9, 0xa6, 0xac, 0xa5, 0xd0, 0x2d, 0xd5, 0x55, 0x4a, 0x66, 0xad, 0xfa, 0x05, 0x59,
-	0xab, 0x31, 0x45, 0xd6, 0x9a, 0x9d, 0x3e, 0x6b, 0x19, 0x97, 0xc9, 0x5a, 0x73, 0x97, 0xca, 0x5a,
-	0xe4, 0xec, 0xac, 0x65, 0xfe, 0x2e, 0x07, 0x73, 0x63, 0x8f, 0xce, 0xb7, 0x3a, 0x66, 0x5c, 0x68,
-	0x8e, 0x3d, 0xb8, 0x49, 0x97, 0x2d, 0x9e, 0xd3, 0xd0, 0x4d, 0xcd, 0x1c, 0xd6, 0x62, 0xf2, 0x81,
-	0x3d, 0xcf, 0x69, 0x4b, 0xd3, 0x39, 0x6d, 0xf9, 0x22, 0xa7, 0xad, 0x8c, 0x3b, 0xad, 0xf9, 0xa7,
-	0x4c, 0x9c, 0xd4, 0x5e, 0x4a, 0xc1, 0x45, 0xee, 0x8e, 0x7d, 0x58, 0xdc, 0xba, 0xb8, 0x64, 0x41,
-	0xbd, 0xa9, 0x0a, 0x74, 0x07, 0x16, 0xef, 0x53, 0x11, 0x5d, 0x55, 0x3a, 0xc0, 0x74, 0xd5, 0x9a,
-	0xf2, 0xbd, 0x6c, 0xe4, 0x7b, 0xe6, 0x4f, 0xa0, 0x9a, 0xe8, 0xba, 0x90, 0x26, 0x94, 0xb0, 0xd9,
-	0xdf, 0xda, 0xd2, 0xad, 0xaa, 0x68, 0x4a, 0xde, 0x1b, 0x35, 0x90, 0xb2, 0x68, 0xeb, 0x6b, 0xe9,
-	0xa5, 0xf2, 0x78, 0xef, 0xc8, 0xfc, 0x7d, 0x06, 0x8a, 0x5a, 0xf6, 0x0d, 0xa8, 0xd2, 0x40, 0x84,
-	0x1e, 0x55, 0xdd, 0x5e, 0x25, 0x1f, 0x34, 0xb4, 0x3b, 0xe8, 0xc9, 0xaf, 0x91, 0xb8, 0x77, 0x61,
-	0x1f, 0x85, 0xac, 0x87, 0xe7, 0xcc, 0x5b, 0xf5, 0x18, 0xdd, 0x09, 0x59, 0x8f, 0xdc, 0x84, 0xda,
-	0x88, 0x4c, 0x30, 0xd4, 0x68, 0xde, 0xaa, 0xc6, 0xd8, 0x01, 0x93, 0x4e, 0xec, 0xb3, 0xae, 0x8d,
-	0x65, 0x97, 0x2a, 0x1f, 0x4b, 0x3e, 0xeb, 0xee, 0xc9, 0xca, 0x4b, 0x2f, 0x25, 0x9a, 0x7b, 0x72,
-	0x49, 0x3a, 0x8b, 0xf9, 0x3e, 0xd4, 0x1e, 0xd2, 0x21, 0x16, 0x5c, 0x7b, 0x8e, 0x17, 0x4e, 0x5b,
-	0x59, 0x98, 0xff, 0xca, 0x00, 0x20, 0x17, 0x6a, 0x92, 0x5c, 0x87, 0x4a, 0x9b, 0x31, 0xdf, 0x46,
-	0xdb, 0x4a, 0xe6, 0xf2, 0x83, 0x19, 0xab, 0x2c, 0xa1, 0x2d, 0x47, 0x38, 0xe4, 0x1a, 0x94, 0xbd,
-	0x40, 0xa8, 0x55, 0x29, 0xa6, 0xf0, 0x60, 0xc6, 0x2a, 0x79, 0x81, 0xc0, 0xc5, 0xeb, 0x50, 0xf1,
-	0x59, 0xd0, 0x55, 0xab, 0xd8, 0xe6, 0x93, 0xbc, 0x12, 0xc2, 0xe5, 0x1b, 0x00, 0x47, 0x3e, 0x73,
-	0x34, 0xb7, 0xbc, 0x59, 0xf6, 0xc1, 0x8c, 0x55, 0x41, 0x0c, 0x09, 0x6e, 0x42, 0xd5, 0x65, 0x83,
-	0xb6, 0x4f, 0x15, 0x85, 0xbc, 0x60, 0xe6, 0xc1, 0x8c, 0x05, 0x0a, 0x8c, 0x48, 0xb8, 0x08, 0xbd,
-	0x68, 0x13, 0x6c, 0x63, 0x4a, 0x12, 0x05, 0x46, 0xdb, 0xb4, 0x87, 0x82, 0x72, 0x45, 0x21, 0xe3,
-	0xaf, 0x26, 0xb7, 0x41, 0x4c, 0x12, 0x6c, 0x14, 0x95, 0xe7, 0x9a, 0xff, 0xcc, 0x6b, 0xf7, 0x51,
-	0x7d, 0xfd, 0x73, 0xdc, 0x27, 0x6a, 0x59, 0x65, 0x13, 0x2d, 0xab, 0xd7, 0xa1, 0xe1, 0x71, 0xbb,
-	0x1f, 0x7a, 0x3d, 0x27, 0x1c, 0xda, 0x52, 0xd5, 0x39, 0x95, 0xd1, 0x3d, 0xbe, 0xa7, 0xc0, 0x87,
-	0x74, 0x48, 0x96, 0xa1, 0xea, 0x52, 0xde, 0x09, 0xbd, 0x3e, 0xa6, 0x5b, 0x65, 0xce, 0x24, 0x44,
-	0xee, 0x42, 0x45, 0x9e, 0x46, 0xfd, 0xe8, 0x54, 0xc0, 0xa8, 0xbc, 0x9e, 0xea, 0x9c, 0xf2, 0xec,
-	0x07, 0xc3, 0x3e, 0xb5, 0xca, 0xae, 0x1e, 0x91, 0x0d, 0xa8, 0x4a, 0x36, 0x5b, 0xff, 0x2e, 0xa5,
-	0xd2, 0x58, 0x7a, 0x4c, 0x27, 0x7d, 0xc3, 0x02, 0xc9, 0xa5, 0x7e, 0x88, 0x22, 0x5b, 0x50, 0x53,
-	0xfd, 0x79, 0x2d, 0xa4, 0x34, 0xad, 0x10, 0xd5, 0xd6, 0xd7, 0x52, 0x16, 0xa1, 0xe8, 0xc8, 0x67,
-	0x6c, 0x0b, 0x33, 0x59, 0xd9, 0xd2, 0x33, 0xf2, 0x1e, 0x14, 0x54, 0xc3, 0xb9, 0x82, 0x37, 0xbb,
-	0x71, 0x76, 0xe7, 0x54, 0xa5, 0x01, 0x45, 0x4d, 0x3e, 0x85, 0x1a, 0xf5, 0x29, 0xf6, 0x9d, 0x51,
-	0x2f, 0x30, 0x8d, 0x5e, 0xaa, 0x9a, 0x05, 0x55, 0xb3, 0x05, 0x75, 0x97, 0x1e, 0x39, 0x03, 0x5f,
-	0xd8, 0xca, 0xe9, 0xab, 0xe7, 0x34, 0x40, 0x46, 0xfe, 0x6f, 0xd5, 0x34, 0x17, 0x42, 0xf8, 0x93,
-	0x20, 0xb7, 0xdd, 0x61, 0xe0, 0xf4, 0xbc, 0x8e, 0xfe, 0xd0, 0xa8, 0x78, 0x7c, 0x4b, 0x01, 0x64,
-	0x05, 0x0c, 0xe9, 0x03, 0x71, 0x21, 0x24, 0xbd, 0x40, 0xd5, 0x06, 0x0d, 0x8f, 0xc7, 0x45, 0xce,
-	0x43, 0x3a, 0x34, 0xff, 0x9a, 0x01, 0x63, 0xf2, 0x87, 0xa4, 0xd8, 0xad, 0x32, 0x09, 0xb7, 0x9a,
-	0x70, 0x98, 0xec, 0x69, 0x87, 0x19, 0xa9, 0x3a, 0x37, 0xa6, 0xea, 0x0f, 0xa0, 0x88, 0xfe, 0x1a,
-	0xfd, 0x78, 0x70, 0x4e, 0x97, 0x3a, 0xfa, 0x21, 0x4b, 0xd1, 0x93, 0xb7, 0x61, 0x81, 0x06, 0x0e,
-	0xc6, 0x9d, 0xba, 0x98, 0x8d, 0x0b, 0xe8, 0x8d, 0x65, 0x8b, 0xa8, 0x35, 0x7d, 0x67, 0xe4, 0x37,
-	0x1b, 0x50, 0xdb, 0x3c, 0xa6, 0x9d, 0x13, 0x9d, 0xb6, 0xcd, 0xa7, 0x50, 0xd7, 0x73, 0xfd, 0x08,
-	0x45, 0xcf, 0x4c, 0xe6, 0x3f, 0x7a, 0x66, 0xb2, 0xf1, 0x33, 0xb3, 0xfa, 0x0b, 0xa8, 0x25, 0xe9,
-	0x48, 0x15, 0x4a, 0xfb, 0x83, 0x4e, 0x87, 0x72, 0x6e, 0xcc, 0x90, 0x59, 0xa8, 0xee, 0x32, 0x61,
-	0xef, 0x0f, 0xfa, 0x7d, 0x16, 0x0a, 0x23, 0x43, 0xe6, 0xa0, 0xbe, 0xcb, 0xec, 0x3d, 0x1a, 0xf6,
-	0x3c, 0xce, 0x3d, 0x16, 0x18, 0x59, 0x52, 0x86, 0xfc, 0x8e, 0xe3, 0xf9, 0x46, 0x8e, 0x2c, 0xc0,
-	0x2c, 0x7a, 0x2b, 0x15, 0x34, 0xb4, 0xb7, 0x65, 0x55, 0x61, 0xfc, 0x26, 0x47, 0xae, 0x43, 0x53,
-	0xdf, 0xc2, 0x7e, 0xdc, 0xfe, 0x29, 0xed, 0x08, 0x5b, 0x8a, 0xdc, 0x61, 0x83, 0xc0, 0x35, 0x7e,
-	0x9b, 0x5b, 0x7d, 0x0e, 0xf3, 0x29, 0x9d, 0x74, 0x42, 0xa0, 0xb1, 0x71, 0x6f, 0xf3, 0xe1, 0x93,
-	0x3d, 0xbb, 0xb5, 0xdb, 0x3a, 0x68, 0xdd, 0x7b, 0x64, 0xcc, 0x90, 0x05, 0x30, 0x34, 0xb6, 0xfd,
-	0x74, 0x7b, 0xf3, 0xc9, 0x41, 0x6b, 0xf7, 0xbe, 0x91, 0x49, 0x50, 0xee, 0x3f, 0xd9, 0xdc, 0xdc,
-	0xde, 0xdf, 0x37, 0xb2, 0xf2, 0xdc, 0x1a, 0xdb, 0xb9, 0xd7, 0x7a, 0x64, 0xe4, 0x12, 0x44, 0x07,
-	0xad, 0xcf, 0xb7, 0x1f, 0x3f, 0x39, 0x30, 0xf2, 0xab, 0x87, 0xf1, 0xd7, 0xde, 0xf8, 0xd6, 0x55,
-	0x28, 0x8d, 0xf6, 0xac, 0x43, 0x25, 0xb9, 0x99, 0xd4, 0x4e, 0xbc, 0x8b, 0xbc, 0xb9, 0x12, 0x5f,
-	0x85, 0xd2, 0x48, 0xee, 0x53, 0xe9, 0x89, 0x13, 0xbf, 0x2b, 0x02, 0x14, 0xf7, 0x45, 0xc8, 0x82,
-	0xae, 0x31, 0x83, 0x32, 0xa8, 0xd2, 0x1e, 0x0a, 0xdc, 0x90, 0xaa, 0xa0, 0xae, 0x91, 0x25, 0x0d,
-	0x80, 0xed, 0x67, 0x34, 0x10, 0x03, 0xc7, 0xf7, 0x87, 0x46, 0x4e, 0xce, 0x37, 0x07, 0x5c, 0xb0,
-	0x9e, 0xf7, 0x82, 0xba, 0x46, 0x7e, 0xf5, 0xab, 0x0c, 0x94, 0xa3, 0x68, 0x94, 0xbb, 0xef, 0xb2,
-	0x80, 0x1a, 0x33, 0x72, 0xb4, 0xc1, 0x98, 0x6f, 0x64, 0xe4, 0xa8, 0x15, 0x88, 0x0f, 0x8c, 0x2c,
-	0xa9, 0x40, 0xa1, 0x15, 0x88, 0xef, 0xbd, 0x6f, 0xe4, 0xf4, 0xf0, 0x9d, 0x75, 0x23, 0xaf, 0x87,
-	0xef, 0xbf, 0x6b, 0x14, 0xe4, 0x70, 0x47, 0x3e, 0x0c, 0x06, 0xc8, 0xc3, 0x6d, 0xe1, 0x0b, 0x60,
-	0x54, 0xf5, 0x41, 0xbd, 0xa0, 0x6b, 0x2c, 0xc8, 0xb3, 0x1d, 0x3a, 0xe1, 0xe6, 0xb1, 0x13, 0x1a,
-	0xaf, 0x48, 0xfa, 0x7b, 0x61, 0xe8, 0x0c, 0x8d, 0x45, 0xb9, 0xcb, 0x67, 0x9c, 0x05, 0xc6, 0x15,
-	0x62, 0x40, 0x6d, 0xc3, 0x0b, 0x9c, 0x70, 0x78, 0x48, 0x3b, 0x82, 0x85, 0x86, 0x2b, 0x35, 0x8f,
-	0x62, 0x35, 0x40, 0x57, 0x0f, 0x01, 0x46, 0xe9, 0x47, 0x32, 0xe0, 0x4c, 0xd5, 0xc2, 0xae, 0x31,
-	0x23, 0x3d, 0x6a, 0x84, 0xc8, 0x7d, 0x33, 0x31, 0xb4, 0x15, 0xb2, 0x7e, 0x5f, 0x42, 0xd9, 0x98,
-	0x0f, 0x21, 0xea, 0x1a, 0xb9, 0xf5, 0x3f, 0x17, 0x60, 0xfe, 0x73, 0x74, 0x7a, 0xe5, 0x3e, 0xfb,
-	0x34, 0x7c, 0xe6, 0x75, 0x28, 0xe9, 0x40, 0x2d, 0xd9, 0x16, 0x27, 0xe9, 0x9f, 0xb4, 0x29, 0x9d,
-	0xf3, 0xa5, 0x37, 0x2f, 0xea, 0xee, 0xe9, 0x30, 0x31, 0x67, 0xc8, 0x8f, 0xa1, 0x12, 0xb7, 0x6f,
-	0x49, 0xfa, 0x8f, 0xcd, 0x93, 0xed, 0xdd, 0xcb, 0x88, 0x6f, 0x43, 0x35, 0xd1, 0xf3, 0x24, 0xe9,
-	0x9c, 0xa7, 0x7b, 0xae, 0x4b, 0x2b, 0x17, 0x13, 0xc6, 0x7b, 0x50, 0xa8, 0x25, 0xdb, 0x89, 0x67,
-	0xe8, 0x29, 0xa5, 0x8f, 0xb9, 0x74, 0x7b, 0x0a, 0xca, 0x78, 0x9b, 0x63, 0xa8, 0x8f, 0x15, 0xa9,
-	0xe4, 0xf6, 0xd4, 0xbd, 0xb7, 0xa5, 0xd5, 0x69, 0x48, 0xe3, 0x9d, 0xba, 0x00, 0xa3, 0x9a, 0x97,
-	0xbc, 0x75, 0x96, 0x51, 0x52, 0x8a, 0xe2, 0x4b, 0x6e, 0xb4, 0x07, 0x05, 0xcc, 0xc5, 0x24, 0x3d,
-	0xeb, 0x26, 0xf3, 0xf6, 0x92, 0x79, 0x1e, 0x49, 0x24, 0x71, 0xe3, 0xc3, 0x2f, 0xbe, 0xdf, 0xf5,
-	0xc4, 0xf1, 0xa0, 0xbd, 0xd6, 0x61, 0xbd, 0x3b, 0x2f, 0x3c, 0xdf, 0xf7, 0x5e, 0x08, 0xda, 0x39,
-	0xbe, 0xa3, 0x98, 0xbf, 0xab, 0xd8, 0xee, 0x74, 0x58, 0xa8, 0xff, 0xa6, 0x73, 0x47, 0x21, 0xfd,
-	0x76, 0xbb, 0x88, 0xf3, 0x77, 0xfe, 0x1d, 0x00, 0x00, 0xff, 0xff, 0x8d, 0x3c, 0x62, 0xdc, 0xe9,
-	0x23, 0x00, 0x00,
+	// 7419 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xdc, 0x7d, 0x4d, 0x6c, 0x1c, 0x49,
+	0x96, 0x9e, 0xea, 0x87, 0xc5, 0xaa, 0x57, 0x3f, 0x2c, 0x26, 0x29, 0x76, 0x35, 0xd5, 0x6a, 0x51,
+	0xa5, 0x56, 0x37, 0x5b, 0x3d, 0x23, 0x8d, 0xd5, 0xa3, 0x99, 0xee, 0xf6, 0xce, 0x4c, 0x4b, 0x24,
+	0xa5, 0x66, 0xb7, 0xa4, 0xa6, 0x93, 0x92, 0xdc, 0x33, 0xb0, 0x9d, 0xc8, 0xca, 0x0c, 0x92, 0xd9,
+	0xcc, 0xca, 0x2c, 0x67, 0x64, 0x49, 0x62, 0xc3, 0xd8, 0x8b, 0x61, 0xf8, 0x0f, 0x86, 0x6d, 0xc0,
+	0x80, 0x81, 0xbd, 0xac, 0xd7, 0x86, 0x17, 0x06, 0x7c, 0xb2, 0x0d, 0x03, 0xbe, 0xf8, 0x62, 0x1f,
+	0xd6, 0xf6, 0xc9, 0xd7, 0xbd, 0xef, 0xc1, 0x87, 0xbd, 0x18, 0xb0, 0x61, 0xf8, 0x64, 0xe3, 0xbd,
+	0x17, 0x99, 0x19, 0x59, 0x95, 0x2c, 0x16, 0x5b, 0x82, 0x7a, 0x67, 0x4f, 0xac, 0xf8, 0xe2, 0x45,
+	0x64, 0xc4, 0x8b, 0x17, 0x2f, 0x5e, 0xbc, 0x17, 0x11, 0x84, 0xd6, 0xc0, 0x76, 0x8e, 0xc7, 0xa3,
+	0x9b, 0xa3, 0x28, 0x8c, 0x43, 0x63, 0x65, 0xe8, 0xf9, 0xcf, 0xc7, 0x92, 0x53, 0x37, 0x39, 0x6b,
+	0xfd, 0x9d, 0xc3, 0x30, 0x3c, 0xf4, 0xc5, 0x2d, 0x02, 0x07, 0xe3, 0x83, 0x5b, 0x32, 0x8e, 0xc6,
+	0x4e, 0xcc, 0x44, 0xfd, 0xbf, 0x57, 0x86, 0xc6, 0x6e, 0xe0, 0x8a, 0x97, 0xbb, 0xc1, 0x41, 0x68,
+	0x5c, 0x06, 0x38, 0xf0, 0x84, 0xef, 0x5a, 0x81, 0x3d, 0x14, 0xbd, 0xd2, 0x46, 0x69, 0xb3, 0x61,
+	0x36, 0x08, 0x79, 0x6c, 0x0f, 0x05, 0x66, 0x7b, 0x48, 0xcb, 0xd9, 0x65, 0xce, 0x26, 0x24, 0x9f,
+	0x1d, 0x9f, 0x8c, 0x44, 0xaf, 0xa2, 0x65, 0x3f, 0x39, 0x19, 0x09, 0xe3, 0x1e, 0xd4, 0x46, 0x76,
+	0x64, 0x0f, 0x65, 0xaf, 0xba, 0x51, 0xd9, 0x6c, 0xde, 0xbe, 0x71, 0xb3, 0xa0, 0xb9, 0x37, 0xd3,
+	0xc6, 0xdc, 0xdc, 0x23, 0xe2, 0x9d, 0x20, 0x8e, 0x4e, 0x4c, 0x55, 0xd2, 0xb8, 0x0a, 0xad, 0xe1,
+	0xd0, 0x1e, 0x59, 0x22, 0xb0, 0x07, 0xbe, 0x70, 0x7b, 0x0b, 0x1b, 0xa5, 0xcd, 0xba, 0xd9, 0x44,
+	0x6c, 0x87, 0xa1, 0xf5, 0x4f, 0xa1, 0xa9, 0x95, 0x34, 0xba, 0x50, 0x39, 0x16, 0x27, 0xaa, 0x2f,
+	0xf8, 0xd3, 0x58, 0x85, 0x85, 0xe7, 0xb6, 0x3f, 0x4e, 0x3a, 0xc0, 0x89, 0xcf, 0xca, 0x9f, 0x94,
+	0xfa, 0xff, 0x1b, 0x60, 0x75, 0x2b, 0xf4, 0x7d, 0xe1, 0xc4, 0x5e, 0x18, 0xdc, 0xa3, 0x06, 0x11,
+	0x5f, 0x3a, 0x50, 0xf6, 0x5c, 0x55, 0x47, 0xd9, 0x73, 0x8d, 0x07, 0x00, 0x32, 0xb6, 0x63, 0x61,
+	0x39, 0xa1, 0xcb, 0xf5, 0x74, 0x6e, 0x6f, 0x16, 0x76, 0x87, 0x2b, 0x79, 0x62, 0xcb, 0xe3, 0x7d,
+	0x2c, 0xb0, 0x15, 0xba, 0xc2, 0x6c, 0xc8, 0xe4, 0xa7, 0xd1, 0x87, 0x96, 0x88, 0xa2, 0x30, 0x7a,
+	0x24, 0xa4, 0xb4, 0x0f, 0x13, 0xa6, 0xe5, 0x30, 0x64, 0xab, 0x8c, 0xed, 0x28, 0xb6, 0x62, 0x6f,
+	0x28, 0x7a, 0xd5, 0x8d, 0xd2, 0x66, 0x85, 0xaa, 0x88, 0xe2, 0x27, 0xde, 0x50, 0x18, 0x6f, 0x43,
+	0x5d, 0x04, 0x2e, 0x67, 0x2e, 0x50, 0xe6, 0xa2, 0x08, 0x5c, 0xca, 0x5a, 0x87, 0xfa, 0x28, 0x0a,
+	0x0f, 0x23, 0x21, 0x65, 0xaf, 0xb6, 0x51, 0xda, 0x5c, 0x30, 0xd3, 0xb4, 0x71, 0x0d, 0xda, 0x4e,
+	0xda, 0x55, 0xcb, 0x73, 0x7b, 0x8b, 0x54, 0xb6, 0x95, 0x81, 0xbb, 0xae, 0xf1, 0x16, 0x2c, 0xba,
+	0x03, 0x1e, 0xed, 0x3a, 0xb5, 0xac, 0xe6, 0x0e, 0x68, 0xa8, 0x3f, 0x80, 0x25, 0xad, 0x34, 0x11,
+	0x34, 0x88, 0xa0, 0x93, 0xc1, 0x44, 0xf8, 0x0b, 0xa8, 0x49, 0xe7, 0x48, 0x0c, 0xed, 0x1e, 0x6c,
+	0x94, 0x36, 0x9b, 0xb7, 0xaf, 0x17, 0x72, 0x29, 0x63, 0xfa, 0x3e, 0x11, 0x9b, 0xaa, 0x10, 0xf5,
+	0xfd, 0xc8, 0x8e, 0x5c, 0x69, 0x05, 0xe3, 0x61, 0xaf, 0x49, 0x7d, 0x68, 0x30, 0xf2, 0x78, 0x3c,
+	0x34, 0x4c, 0x58, 0x76, 0xc2, 0x40, 0x7a, 0x32, 0x16, 0x81, 0x73, 0x62, 0xf9, 0xe2, 0xb9, 0xf0,
+	0x7b, 0x2d, 0x1a, 0x8e, 0xd3, 0x3e, 0x94, 0x52, 0x3f, 0x44, 0x62, 0xb3, 0xeb, 0x4c, 0x20, 0xc6,
+	0x53, 0x58, 0x1e, 0xd9, 0x51, 0xec, 0x51, 0xcf, 0xb8, 0x98, 0xec, 0xb5, 0x49, 0x62, 0x8b, 0x87,
+	0x78, 0x2f, 0xa1, 0xce, 0x04, 0xc6, 0xec, 0x8e, 0xf2, 0xa0, 0x34, 0x3e, 0x84, 0x2e, 0xd3, 0xd3,
+	0x48, 0xc9, 0xd8, 0x1e, 0x8e, 0x7a, 0x9d, 0x8d, 0xd2, 0x66, 0xd5, 0x5c, 0x62, 0xfc, 0x49, 0x02,
+	0x1b, 0x06, 0x54, 0xa5, 0xf7, 0x9d, 0xe8, 0x2d, 0xd1, 0x88, 0xd0, 0x6f, 0xe3, 0x12, 0x34, 0x8e,
+	0x6c, 0x69, 0xd1, 0x6c, 0xea, 0x75, 0x49, 0xea, 0xeb, 0x47, 0xb6, 0xa4, 0xd9, 0x62, 0xfc, 0x0a,
+	0x9a, 0x3c, 0xf1, 0xbc, 0xe0, 0x20, 0x94, 0xbd, 0x65, 0x6a, 0xec, 0xbb, 0xb3, 0xa7, 0x97, 0x09,
+	0x5e, 0xf2, 0x53, 0x22, 0x9b, 0xfd, 0xd0, 0x76, 0x2d, 0x12, 0xcc, 0x9e, 0xc1, 0x33, 0x17, 0x11,
+	0x12, 0x5a, 0xe3, 0x33, 0x78, 0x5b, 0xb5, 0x7d, 0x74, 0x74, 0x22, 0x3d, 0xc7, 0xf6, 0xb5, 0x4e,
+	0xac, 0x50, 0x27, 0xde, 0x62, 0x82, 0x3d, 0x95, 0x9f, 0x75, 0xe6, 0x0a, 0x34, 0x9d, 0x70, 0xe4,
+	0x09, 0xd7, 0xa2, 0x3e, 0xad, 0x52, 0x9f, 0x80, 0xa1, 0x7d, 0xec, 0x59, 0x0f, 0x16, 0x6d, 0xdf,
+	0xb3, 0xa5, 0x90, 0xbd, 0x8b, 0x1b, 0x95, 0xcd, 0x86, 0x99, 0x24, 0x8d, 0xbb, 0x00, 0xa3, 0x28,
+	0x1c, 0x89, 0x28, 0xf6, 0x84, 0xec, 0xad, 0x51, 0xaf, 0xae, 0x16, 0xf6, 0xea, 0x2b, 0x71, 0xf2,
+	0x0c, 0x67, 0xf1, 0x9e, 0xed, 0x45, 0xa6, 0x56, 0xc8, 0xb8, 0x0e, 0x9d, 0x48, 0x8c, 0x7c, 0xcf,
+	0xb1, 0x51, 0x80, 0x06, 0x22, 0xea, 0xbd, 0x45, 0x32, 0xd4, 0x56, 0xe8, 0x63, 0x02, 0x51, 0x9c,
+	0x23, 0x21, 0xc3, 0x71, 0xe4, 0x08, 0xeb, 0x30, 0x0a, 0x71, 0xc4, 0x7b, 0xd4, 0x96, 0x4e, 0x02,
+	0x3f, 0x20, 0x14, 0x7b, 0x73, 0xe0, 0x8f, 0xe5, 0x91, 0xe2, 0xd4, 0xdb, 0xc4, 0x29, 0x20, 0x88,
+	0x59, 0xb5, 0x09, 0xdd, 0x94, 0x20, 0x99, 0xb2, 0xeb, 0xd4, 0xe7, 0x4e, 0x42, 0xa5, 0xe6, 0xed,
+	0x7b, 0xc0, 0x88, 0x95, 0xce, 0xde, 0x4b, 0x3c, 0x03, 0x09, 0xdd, 0x51, 0x53, 0xf8, 0x21, 0x74,
+	0xa3, 0xf0, 0x85, 0xe5, 0x84, 0xe3, 0x20, 0xb6, 0x9c, 0x23, 0xe1, 0x1c, 0xcb, 0xde, 0x3b, 0xc4,
+	0x89, 0x7e, 0x21, 0x27, 0xcc, 0xf0, 0xc5, 0x16, 0xd2, 0x6e, 0x21, 0xa9, 0xd9, 0x89, 0xf4, 0x24,
+	0xa9, 0x4f, 0x69, 0x0f, 0x47, 0xbe, 0x70, 0xad, 0x28, 0x7c, 0x21, 0x7b, 0x97, 0x89, 0x19, 0x4d,
+	0x85, 0x99, 0xe1, 0x0b, 0x69, 0x7c, 0x0e, 0xcd, 0x81, 0x17, 0xf8, 0xe1, 0xa1, 0x25, 0x1d, 0x3b,
+	0xe8, 0xbd, 0x4b, 0xb3, 0xf6, 0x4a, 0xb1, 0x6e, 0x23, 0xba, 0x7d, 0xc7, 0x0e, 0x4c, 0x18, 0xa4,
+	0xbf, 0x8d, 0x9f, 0xc0, 0x6a, 0x22, 0x2d, 0x76, 0x7c, 0x64, 0xc5, 0x62, 0x38, 0xf2, 0x91, 0x59,
+	0x57, 0x88, 0x59, 0x86, 0x12, 0x14, 0x3b, 0x3e, 0x7a, 0xa2, 0x72, 0xfa, 0x7f, 0x5c, 0x06, 0xc8,
+	0x2a, 0x43, 0x05, 0xcd, 0xec, 0x65, 0x85, 0xcb, 0x09, 0x63, 0x0d, 0x6a, 0xae, 0x88, 0x6d, 0xcf,
+	0x57, 0x7a, 0x5b, 0xa5, 0x70, 0x62, 0x45, 0xe2, 0x40, 0x44, 0x22, 0x70, 0x84, 0x6b, 0x1d, 0x78,
+	0xbe, 0x90, 0xa4, 0x46, 0x2b, 0xe6, 0x52, 0x86, 0xdf, 0x47, 0x98, 0x87, 0x39, 0x25, 0x25, 0x79,
+	0x64, 0x75, 0xda, 0xc9, 0x60, 0x92, 0xc9, 0xab, 0xd0, 0x0a, 0xa3, 0xd1, 0x91, 0x1d, 0xa8, 0xfa,
+	0x58, 0xaf, 0x36, 0x19, 0xe3, 0xba, 0xae, 0x80, 0x4a, 0x72, 0x3d, 0x35, 0x96, 0x6b, 0x86, 0xa8,
+	0x8e, 0x0f, 0x60, 0x29, 0x21, 0x10, 0x87, 0x43, 0x11, 0xc4, 0x52, 0xa9, 0xd8, 0x8e, 0x22, 0x52,
+	0xa8, 0xf1, 0x23, 0x30, 0xf2, 0x84, 0x96, 0xe7, 0xca, 0x5e, 0x7d, 0xa3, 0xb2, 0x59, 0x31, 0xbb,
+	0x39, 0xda, 0x5d, 0x57, 0x1a, 0x37, 0x61, 0x65, 0xe8, 0x49, 0xe9, 0x05, 0x87, 0x39, 0xf2, 0x06,
+	0x91, 0x2f, 0xab, 0xac, 0x8c, 0xbe, 0xff, 0x9f, 0x4b, 0xd0, 0xce, 0x09, 0x05, 0xce, 0x89, 0x4c,
+	0xc1, 0x69, 0x0b, 0x7d, 0x3b, 0x45, 0x49, 0x73, 0x5f, 0x81, 0xa6, 0x1a, 0x46, 0x12, 0x95, 0x32,
+	0x77, 0x90, 0x21, 0x92, 0x94, 0x2b, 0xd0, 0x64, 0xa9, 0x60, 0x02, 0xe6, 0x39, 0x30, 0x44, 0x04,
+	0x97, 0xa0, 0x31, 0xb0, 0xa5, 0xe0, 0x6c, 0x66, 0x74, 0x1d, 0x01, 0xca, 0x4c, 0x07, 0x79, 0xa1,
+	0x78, 0x90, 0x6b, 0xfa, 0x20, 0xf7, 0xff, 0x4e, 0x19, 0x56, 0x0a, 0xf4, 0x2c, 0x0e, 0x54, 0xd6,
+	0x17, 0xb5, 0x44, 0x57, 0xcc, 0x66, 0x8a, 0xed, 0xba, 0x05, 0xdd, 0x2d, 0x17, 0x75, 0x77, 0x6a,
+	0x3d, 0xac, 0x14, 0xac, 0x87, 0x5f, 0xc3, 0x52, 0xc2, 0xf4, 0x64, 0x65, 0x60, 0x5b, 0xe6, 0xfd,
+	0xc2, 0x09, 0xa2, 0x86, 0x41, 0x5b, 0x17, 0x3a, 0x52, 0x87, 0x64, 0xaa, 0xea, 0x17, 0x34, 0x55,
+	0x9f, 0x57, 0xc6, 0xb5, 0x09, 0x65, 0xdc, 0xff, 0xbb, 0x55, 0x58, 0x9e, 0xaa, 0x18, 0x0b, 0x65,
+	0xe2, 0xa0, 0xd8, 0xd0, 0x90, 0x89, 0x18, 0x4c, 0xf7, 0xae, 0x5c, 0xd0, 0xbb, 0x49, 0x66, 0x56,
+	0xa6, 0x99, 0xf9, 0x2e, 0x34, 0x83, 0xf1, 0xd0, 0x0a, 0x0f, 0xf4, 0x41, 0x6d, 0x04, 0xe3, 0xe1,
+	0xd7, 0x07, 0x34, 0xaa, 0x9f, 0xc1, 0x22, 0x6b, 0x02, 0x9c, 0x33, 0xc8, 0x98, 0x8d, 0x42, 0xc6,
+	0xdc, 0x47, 0x93, 0x92, 0x67, 0xbc, 0x99, 0x14, 0x30, 0x7e, 0x09, 0x64, 0x18, 0x49, 0x2a, 0x5d,
+	0x9b, 0xb3, 0x74, 0x56, 0x04, 0xcb, 0xbb, 0xc2, 0x8f, 0x6d, 0x2a, 0xbf, 0x38, 0x6f, 0xf9, 0xb4,
+	0x48, 0x3a, 0x16, 0x75, 0x6d, 0x2c, 0xde, 0x86, 0x3a, 0xad, 0x07, 0xc8, 0x8e, 0x06, 0x1b, 0x57,
+	0x94, 0xde, 0x75, 0x8d, 0xf7, 0x49, 0x99, 0x28, 0x39, 0x60, 0xc1, 0x02, 0x16, 0xac, 0x48, 0x1c,
+	0xf0, 0xc8, 0x90, 0x60, 0x6d, 0xe0, 0x02, 0x38, 0x1c, 0x45, 0x42, 0x4a, 0x2f, 0x0c, 0xc8, 0x86,
+	0x69, 0x98, 0x3a, 0x64, 0xbc, 0x03, 0x0d, 0x11, 0x38, 0xd1, 0xc9, 0x28, 0x16, 0x2e, 0x59, 0x2f,
+	0x75, 0x33, 0x03, 0xd0, 0x88, 0xe3, 0x6f, 0x08, 0xb7, 0xd7, 0xe6, 0x85, 0x3f, 0x49, 0xf7, 0xff,
+	0x63, 0x1d, 0xe0, 0xcf, 0xb7, 0x99, 0x6a, 0x40, 0x95, 0x58, 0xbb, 0x48, 0x5f, 0xa4, 0xdf, 0x85,
+	0xa6, 0x54, 0xbd, 0xd8, 0x94, 0xfa, 0x06, 0x0c, 0x4d, 0xee, 0x93, 0x39, 0xdb, 0x20, 0xe1, 0xf8,
+	0xf0, 0x0c, 0x53, 0x54, 0x9b, 0xb6, 0xcb, 0xce, 0x04, 0x9a, 0x49, 0x0b, 0x68, 0xd2, 0x72, 0x1d,
+	0x3a, 0x4a, 0x23, 0x3e, 0x17, 0x91, 0x36, 0xda, 0x6d, 0x46, 0x9f, 0x31, 0x88, 0x36, 0x02, 0xe9,
+	0x45, 0x5d, 0x74, 0x5a, 0x6c, 0x3d, 0x23, 0x7e, 0xba, 0xec, 0xb4, 0xcf, 0x90, 0x9d, 0xce, 0xa4,
+	0xec, 0x7c, 0x06, 0x8d, 0x68, 0x60, 0x3b, 0xd6, 0x50, 0xc4, 0x36, 0x99, 0x93, 0xcd, 0xdb, 0x97,
+	0x8b, 0xcd, 0x86, 0x7b, 0x77, 0xb7, 0x1e, 0x89, 0xd8, 0x36, 0xeb, 0x48, 0x8f, 0xbf, 0x26, 0x0d,
+	0xb7, 0xee, 0x94, 0xe1, 0xb6, 0x09, 0xdd, 0x70, 0xf0, 0xad, 0x70, 0x62, 0xcb, 0x0f, 0x9d, 0x63,
+	0x6b, 0x88, 0x32, 0xb6, 0xcc, 0xdd, 0x60, 0xfc, 0x61, 0xe8, 0x1c, 0x3f, 0x42, 0xf1, 0xf9, 0x39,
+	0xf4, 0x74, 0xca, 0x08, 0x75, 0x7a, 0x60, 0x8d, 0x83, 0xd8, 0xf3, 0xc9, 0xd8, 0xac, 0x98, 0x17,
+	0xb3, 0x12, 0x26, 0xe5, 0x3e, 0xc5, 0x4c, 0x14, 0x1a, 0x29, 0x05, 0xef, 0x27, 0x57, 0xa8, 0xea,
+	0x45, 0x29, 0x05, 0xed, 0x26, 0xaf, 0x41, 0x07, 0xb3, 0x8e, 0x87, 0xd2, 0x3a, 0x16, 0x27, 0x38,
+	0x3f, 0x57, 0x99, 0x3b, 0x52, 0x8a, 0xaf, 0x86, 0xf2, 0x2b, 0x71, 0xb2, 0xeb, 0x1a, 0xb7, 0x60,
+	0x15, 0x89, 0x9c, 0xb1, 0x8c, 0xc3, 0xa1, 0x88, 0x88, 0x72, 0xe8, 0xde, 0xe9, 0x5d, 0x24, 0xd2,
+	0x65, 0x29, 0xc5, 0x96, 0xca, 0xfa, 0x4a, 0x9c, 0x3c, 0x72, 0xef, 0xd0, 0xfe, 0x52, 0xc4, 0x76,
+	0x3a, 0x7e, 0x6b, 0x6c, 0x20, 0x21, 0x96, 0x8c, 0xde, 0x16, 0xd4, 0x7c, 0x7b, 0x20, 0x7c, 0xd9,
+	0x7b, 0x8b, 0xc4, 0xe8, 0xa3, 0x19, 0x13, 0x8a, 0xf6, 0xb1, 0x0f, 0x89, 0x5a, 0xed, 0x63, 0xb9,
+	0xa8, 0xf1, 0x25, 0xb4, 0x45, 0xec, 0xb8, 0x96, 0x0c, 0xec, 0x91, 0x3c, 0x0a, 0xe3, 0x5e, 0x6f,
+	0xc6, 0xee, 0x68, 0x27, 0x76, 0xdc, 0x7d, 0x45, 0x48, 0xe2, 0xd8, 0x12, 0x1a, 0x82, 0x1b, 0x5e,
+	0xed, 0x13, 0xe7, 0xda, 0xf0, 0xfe, 0xdb, 0x12, 0xd4, 0x93, 0xa1, 0x37, 0xee, 0xc0, 0xc2, 0x58,
+	0x8a, 0x48, 0xf6, 0x4a, 0x1b, 0x95, 0x53, 0x6d, 0xbe, 0xa7, 0x52, 0x44, 0x3b, 0x41, 0xec, 0xc5,
+	0x27, 0x26, 0x53, 0x63, 0xb1, 0x28, 0x44, 0x23, 0xa9, 0x3c, 0xa3, 0x98, 0x19, 0xfa, 0x22, 0x29,
+	0x46, 0xd4, 0xc6, 0x27, 0x50, 0x3b, 0x8c, 0x6c, 0xb4, 0x8a, 0x2a, 0x33, 0x54, 0xf5, 0x03, 0x24,
+	0x51, 0x05, 0x15, 0x7d, 0xff, 0x67, 0x00, 0x59, 0x2b, 0x70, 0x1e, 0x62, 0x3b, 0x54, 0x7f, 0xe9,
+	0x37, 0x76, 0x38, 0x6b, 0x52, 0x43, 0x7d, 0xb1, 0xbf, 0x01, 0x90, 0x35, 0x23, 0x55, 0x2c, 0xa5,
+	0x4c, 0xb1, 0xf4, 0xff, 0x71, 0x09, 0x9a, 0xda, 0x17, 0x91, 0x06, 0x8b, 0x26, 0x34, 0xf8, 0x1b,
+	0x2d, 0x14, 0x96, 0xd5, 0xc4, 0x0c, 0xe5, 0x14, 0xd9, 0x83, 0xf4, 0x8b, 0xe7, 0x33, 0x6b, 0x48,
+	0x60, 0x88, 0xe6, 0xf2, 0x3b, 0xd0, 0x18, 0x45, 0xde, 0x73, 0xcf, 0x17, 0x87, 0xac, 0x1e, 0x1b,
+	0x66, 0x06, 0xe8, 0x3b, 0xed, 0x05, 0x7d, 0xa7, 0xdd, 0xff, 0x2b, 0xf0, 0x76, 0xa6, 0x92, 0x68,
+	0x87, 0xaa, 0x29, 0xfc, 0x5f, 0xc1, 0x02, 0x6f, 0xf9, 0x4a, 0xe7, 0xd5, 0x68, 0x5c, 0xae, 0xff,
+	0x1b, 0xe8, 0xa5, 0x66, 0xd5, 0x64, 0xe5, 0xbf, 0xcc, 0x57, 0x3e, 0xff, 0xe6, 0x57, 0xd5, 0xfd,
+	0x0c, 0xd6, 0x94, 0x9d, 0x32, 0x59, 0xf3, 0xef, 0xe4, 0x6b, 0x9e, 0xd7, 0x78, 0x52, 0xf5, 0xbe,
+	0x0f, 0x9d, 0x3d, 0xdd, 0x74, 0x23, 0x5b, 0x12, 0x39, 0xc7, 0xf5, 0x35, 0x4c, 0x4e, 0xf4, 0xff,
+	0x25, 0xc0, 0xca, 0x56, 0x24, 0xec, 0x58, 0x69, 0x54, 0x53, 0xfc, 0xf5, 0xb1, 0x90, 0x31, 0x0e,
+	0x44, 0xc4, 0x3f, 0x77, 0x93, 0xc5, 0x32, 0x03, 0x34, 0xb3, 0x57, 0xb3, 0x15, 0x95, 0xd9, 0xfb,
+	0x58, 0xad, 0x3e, 0x13, 0xae, 0x0f, 0x16, 0xe1, 0x86, 0xb9, 0x94, 0xf7, 0x7d, 0x50, 0xbb, 0x6c,
+	0x79, 0x12, 0x38, 0x34, 0xdc, 0x75, 0x93, 0x13, 0xc6, 0x2f, 0xa0, 0xe3, 0x0e, 0xac, 0x8c, 0x96,
+	0xb7, 0x17, 0xcd, 0xdb, 0x6b, 0x37, 0xd9, 0x53, 0x77, 0x33, 0xf1, 0xd4, 0xdd, 0xa4, 0x3d, 0xad,
+	0xd9, 0x76, 0x07, 0xd9, 0x10, 0x52, 0xa5, 0x07, 0x61, 0xe4, 0xb0, 0x65, 0x58, 0x37, 0x39, 0x81,
+	0xb6, 0x36, 0x29, 0xae, 0x30, 0xf0, 0x4f, 0x68, 0xb1, 0xac, 0x9b, 0x75, 0x04, 0xbe, 0x0e, 0xfc,
+	0x13, 0x5c, 0x46, 0xbc, 0xc0, 0x89, 0x04, 0xf2, 0xd3, 0xf6, 0x69, 0xad, 0xac, 0x9b, 0x3a, 0x54,
+	0xb8, 0x24, 0x35, 0xe6, 0x59, 0x92, 0x60, 0x7a, 0x49, 0x5a, 0x83, 0x5a, 0x24, 0xe4, 0x78, 0x28,
+	0x68, 0xf5, 0xab, 0x9b, 0x2a, 0x65, 0xdc, 0x81, 0x35, 0x8d, 0x71, 0xe8, 0xd0, 0xf3, 0x7d, 0xe1,
+	0x7b, 0x72, 0x48, 0x8b, 0xdf, 0x82, 0x79, 0x31, 0xcb, 0xdd, 0xcb, 0x32, 0x99, 0xdf, 0xa3, 0x93,
+	0x5c, 0x81, 0x36, 0x15, 0x58, 0x42, 0x5c, 0x27, 0xc5, 0xf9, 0x3a, 0xb0, 0x1d, 0xb5, 0x0e, 0xd2,
+	0xef, 0x89, 0xe1, 0x8a, 0xc4, 0xa1, 0x78, 0x49, 0x2b, 0x61, 0x6e, 0xb8, 0x4c, 0x84, 0x8d, 0x6f,
+	0x00, 0x52, 0x5b, 0x57, 0xf6, 0xba, 0x24, 0x9b, 0x9f, 0x14, 0x4f, 0xa9, 0x69, 0xb1, 0xca, 0x66,
+	0x82, 0x52, 0xf5, 0x5a, 0x5d, 0xb9, 0x75, 0x6c, 0xf9, 0xac, 0x75, 0xcc, 0x98, 0x5e, 0xc7, 0x36,
+	0xa1, 0x3b, 0xb9, 0x8e, 0xa9, 0xf5, 0xb0, 0x93, 0x5f, 0xc3, 0x70, 0x01, 0x63, 0xaf, 0xc2, 0x28,
+	0xf4, 0x3d, 0xe7, 0x24, 0x59, 0x14, 0x09, 0xdb, 0x23, 0x08, 0xf7, 0x02, 0x4c, 0x82, 0xd6, 0x53,
+	0x38, 0x8e, 0x69, 0x35, 0x5c, 0x50, 0x7e, 0x87, 0x27, 0x8c, 0x21, 0x91, 0xed, 0xfb, 0xe1, 0x0b,
+	0x8b, 0x7a, 0x61, 0xfb, 0xb4, 0x12, 0xd6, 0xcd, 0x16, 0x81, 0x7b, 0x8c, 0x21, 0x11, 0x4a, 0x4a,
+	0xb6, 0xc5, 0x7f, 0x8b, 0xed, 0x42, 0x04, 0x93, 0xcd, 0xbd, 0xf1, 0x30, 0x5d, 0x2f, 0x7b, 0xc4,
+	0xd1, 0x9f, 0xce, 0xcd, 0xd1, 0xa2, 0x85, 0x13, 0xfb, 0x87, 0x02, 0x6f, 0x8d, 0x03, 0xb4, 0x25,
+	0xc8, 0x03, 0x53, 0x37, 0x9b, 0x84, 0x3d, 0x25, 0x08, 0x5b, 0x95, 0x5f, 0x5b, 0xd7, 0xb9, 0xe9,
+	0xfa, 0xa2, 0x89, 0xd6, 0x3b, 0x79, 0x53, 0xac, 0xd4, 0xbb, 0x42, 0xee, 0x97, 0xba, 0xd9, 0x26,
+	0x38, 0xd9, 0x31, 0xa3, 0x3a, 0x60, 0xef, 0x08, 0x6f, 0x78, 0xde, 0x21, 0x56, 0x01, 0x43, 0xb4,
+	0xe3, 0x41, 0x97, 0x8a, 0x63, 0x07, 0x56, 0xb2, 0xed, 0xb9, 0xcc, 0x0d, 0x42, 0x8c, 0xb7, 0x18,
+	0x72, 0x7d, 0x00, 0x4b, 0x13, 0xc2, 0x51, 0xb0, 0x48, 0x7f, 0xaa, 0x2f, 0xd2, 0xcd, 0xdb, 0xd7,
+	0x66, 0x6b, 0x5b, 0xd2, 0x2f, 0xda, 0x4a, 0xfe, 0x2a, 0x46, 0xc0, 0x1f, 0x95, 0xc0, 0xd0, 0xb4,
+	0xac, 0x90, 0xa3, 0x30, 0x90, 0xe2, 0x0c, 0x35, 0x79, 0x07, 0xaa, 0xda, 0xa6, 0xa2, 0xd8, 0x2b,
+	0x97, 0x54, 0x45, 0xbb, 0x09, 0x22, 0xc7, 0x76, 0x0d, 0xe5, 0xa1, 0x5a, 0x1d, 0xf1, 0xa7, 0xf1,
+	0x31, 0x54, 0x5d, 0x3b, 0xb6, 0x49, 0x45, 0x9e, 0xea, 0x68, 0xca, 0x5a, 0x47, 0xc4, 0xc6, 0x45,
+	0xa8, 0x7d, 0x1b, 0x0e, 0x70, 0xb2, 0x28, 0xef, 0xc1, 0xb7, 0xe1, 0x60, 0xd7, 0xed, 0xff, 0xb7,
+	0x12, 0x74, 0x1f, 0x88, 0xf8, 0xb5, 0xaa, 0x7b, 0x72, 0x62, 0x10, 0x81, 0xda, 0x11, 0x37, 0x92,
+	0xfd, 0x97, 0x2a, 0x3d, 0x76, 0x8e, 0x85, 0x5a, 0xf4, 0xab, 0xaa, 0x34, 0x41, 0x54, 0xda, 0x80,
+	0x2a, 0x3a, 0xc1, 0x54, 0x33, 0xe9, 0x37, 0xee, 0x12, 0x5e, 0x78, 0xf1, 0x51, 0x38, 0x8e, 0x2d,
+	0xcd, 0xd7, 0x51, 0x37, 0xdb, 0x0a, 0xdd, 0x26, 0xb0, 0xff, 0xcf, 0x2a, 0x60, 0x3c, 0xf4, 0xa4,
+	0xea, 0x8d, 0x9c, 0xaf, 0x3b, 0x05, 0x7e, 0xf9, 0x72, 0xa1, 0x5f, 0xfe, 0x1d, 0x68, 0x20, 0x27,
+	0x07, 0xb6, 0x4c, 0x97, 0xaf, 0x0c, 0x78, 0x85, 0xbd, 0xdc, 0xe7, 0x50, 0xa3, 0x6d, 0x23, 0xef,
+	0xe0, 0xcf, 0xb3, 0xdd, 0x54, 0xe5, 0xb0, 0xf2, 0x30, 0x72, 0x45, 0x64, 0x0d, 0x4e, 0xd4, 0xae,
+	0x6f, 0x91, 0xd2, 0xf7, 0xc8, 0x1e, 0x73, 0x85, 0x74, 0xd4, 0x02, 0x46, 0xbf, 0xc9, 0x1e, 0x3b,
+	0x38, 0x90, 0x22, 0xa6, 0xf5, 0x6a, 0xc1, 0x54, 0x29, 0x94, 0x77, 0xdf, 0x1b, 0x7a, 0x31, 0xad,
+	0x50, 0x0b, 0x26, 0x27, 0x0a, 0x78, 0xdf, 0x2c, 0xe0, 0x3d, 0x92, 0x91, 0xbe, 0xb1, 0xa4, 0x40,
+	0xa6, 0x85, 0x91, 0xda, 0x9f, 0xb5, 0x09, 0xdd, 0x57, 0x20, 0xce, 0x9c, 0x95, 0xdc, 0x10, 0xfd,
+	0x50, 0x53, 0xa7, 0x32, 0xff, 0xd4, 0x59, 0x85, 0x85, 0x38, 0x44, 0x2b, 0x60, 0x81, 0xf9, 0x42,
+	0x89, 0xfe, 0xb7, 0xb0, 0xb2, 0x2d, 0x7c, 0xf1, 0x9a, 0x4d, 0xa5, 0xd4, 0x54, 0xa9, 0x68, 0xa6,
+	0x4a, 0xff, 0x0f, 0x4b, 0xb0, 0x9a, 0xff, 0xd8, 0x9b, 0x65, 0xdb, 0x07, 0xb0, 0xe4, 0xd2, 0xe7,
+	0xdd, 0x9c, 0x13, 0xaf, 0x61, 0x76, 0x14, 0xac, 0x86, 0xb3, 0xbf, 0x0f, 0xc6, 0x9e, 0x3d, 0x96,
+	0xaf, 0x95, 0x27, 0xfd, 0xbf, 0x01, 0x2b, 0xb9, 0x4a, 0xdf, 0x68, 0xdf, 0x71, 0x9c, 0x4d, 0xb2,
+	0xc6, 0x5e, 0xf7, 0x38, 0xb3, 0x9d, 0x5b, 0xd1, 0xec, 0xdc, 0xbe, 0x84, 0x95, 0xbd, 0x68, 0x1c,
+	0x88, 0x73, 0x29, 0x30, 0xdc, 0x07, 0x45, 0x27, 0x56, 0x34, 0x0e, 0xe8, 0x3b, 0x75, 0xb3, 0xe6,
+	0x46, 0x27, 0xe6, 0x38, 0x28, 0x98, 0x92, 0x95, 0xa2, 0x29, 0xf9, 0x5f, 0x4b, 0xb0, 0x9a, 0xff,
+	0xea, 0x9f, 0x4d, 0xe1, 0x42, 0xbb, 0xe1, 0x58, 0x8c, 0x32, 0x3f, 0xf2, 0x02, 0x51, 0x35, 0x11,
+	0x4b, 0xe4, 0xef, 0x31, 0x5c, 0x7c, 0x60, 0x47, 0x03, 0xfb, 0x50, 0x28, 0xfb, 0xff, 0xd5, 0x58,
+	0x88, 0xea, 0x6a, 0x6d, 0xb2, 0xc2, 0x37, 0xcb, 0x9d, 0x6b, 0xd0, 0x8e, 0xc4, 0x30, 0x7c, 0x9e,
+	0x06, 0x6a, 0x98, 0x37, 0x2d, 0x05, 0x72, 0x64, 0xe5, 0x2a, 0x24, 0x69, 0x4b, 0xf3, 0x8d, 0x37,
+	0x15, 0x86, 0xae, 0xa7, 0xfe, 0xef, 0xc2, 0xca, 0x33, 0x11, 0x79, 0x07, 0x27, 0xaf, 0x55, 0x8c,
+	0x8b, 0xac, 0xec, 0x4a, 0x91, 0x95, 0xdd, 0xff, 0x77, 0x65, 0x58, 0xcd, 0x37, 0xe0, 0x8d, 0xf3,
+	0x91, 0xcc, 0x54, 0x8d, 0x8f, 0xec, 0xce, 0x67, 0x90, 0xf9, 0x78, 0x1d, 0x3a, 0x94, 0x96, 0xe3,
+	0x61, 0x2e, 0x8c, 0xd5, 0x4e, 0x50, 0x26, 0xbb, 0x06, 0xed, 0x24, 0xa0, 0xc4, 0x54, 0x35, 0x1e,
+	0x13, 0x05, 0xa6, 0x91, 0x33, 0x27, 0x8c, 0xa2, 0x31, 0x7a, 0x15, 0x15, 0xd9, 0x22, 0x8b, 0x75,
+	0x0a, 0x33, 0xe1, 0x3a, 0xd4, 0x87, 0x76, 0xe0, 0x1d, 0x08, 0x19, 0xab, 0x65, 0x3a, 0x4d, 0xf7,
+	0xff, 0x7b, 0x09, 0x8c, 0x6c, 0x27, 0xbb, 0x23, 0x63, 0x6f, 0x88, 0x1b, 0x04, 0xcd, 0xf5, 0x51,
+	0x3a, 0xeb, 0x90, 0x41, 0xb1, 0x31, 0x73, 0x0d, 0xda, 0x5a, 0x88, 0x67, 0x3c, 0x24, 0x56, 0x2d,
+	0x98, 0x59, 0x34, 0x03, 0xcf, 0x0a, 0xa0, 0x25, 0xaf, 0x22, 0x24, 0x48, 0xc2, 0x1c, 0x4b, 0x82,
+	0x26, 0x48, 0x30, 0x11, 0xdb, 0x58, 0x98, 0x8c, 0x6d, 0x24, 0x1e, 0xdf, 0x5a, 0xe6, 0xf1, 0xed,
+	0xff, 0xbf, 0x12, 0xac, 0x25, 0x1d, 0xf9, 0x61, 0x44, 0x61, 0x17, 0x9a, 0x19, 0x37, 0x92, 0x70,
+	0xd4, 0x07, 0x67, 0x38, 0x82, 0x92, 0x26, 0x9b, 0x7a, 0xd9, 0x49, 0x0e, 0x2d, 0x4c, 0x71, 0xa8,
+	0x88, 0x03, 0x7f, 0xbf, 0x02, 0xcb, 0x78, 0x68, 0xc3, 0x1d, 0xfb, 0xe2, 0xcb, 0x70, 0x80, 0xf6,
+	0xdc, 0x58, 0x16, 0x79, 0xd7, 0x10, 0x73, 0xa2, 0x30, 0x50, 0x63, 0x48, 0xbf, 0xcf, 0xe9, 0x4c,
+	0x19, 0xa1, 0x62, 0x4f, 0x9c, 0x29, 0x94, 0x30, 0xfa, 0xd0, 0x0e, 0xc4, 0xcb, 0x18, 0xb5, 0x9d,
+	0x6e, 0x8f, 0x36, 0x11, 0x34, 0xc7, 0x01, 0xd9, 0xa4, 0xef, 0xc3, 0x92, 0x6f, 0xcb, 0x58, 0x0f,
+	0xc9, 0x73, 0x0f, 0xda, 0x08, 0x67, 0x11, 0xf9, 0x3e, 0x10, 0x90, 0x05, 0xe4, 0x39, 0x5e, 0xdb,
+	0x44, 0x30, 0x89, 0xc7, 0x6f, 0x42, 0x97, 0x68, 0x74, 0x4d, 0xc2, 0x47, 0x63, 0x3a, 0x88, 0x6b,
+	0x8e, 0x92, 0x5f, 0x42, 0x83, 0x28, 0x69, 0x98, 0x1b, 0xf3, 0x0e, 0x73, 0x1d, 0xcb, 0xe0, 0x2f,
+	0xb4, 0x83, 0xa9, 0x3c, 0x8e, 0x37, 0x7b, 0x59, 0x16, 0x31, 0xfd, 0x48, 0x1e, 0xe2, 0x91, 0x89,
+	0x68, 0x1c, 0x04, 0x5e, 0x70, 0xa8, 0xcc, 0xd7, 0x24, 0xd9, 0xff, 0x0f, 0x25, 0x58, 0x79, 0x20,
+	0xe2, 0x64, 0x40, 0xde, 0xb4, 0x30, 0x7e, 0x06, 0xd5, 0x6f, 0xc3, 0xc1, 0x19, 0x41, 0xd1, 0x49,
+	0x61, 0x31, 0xa9, 0x4c, 0xff, 0x4f, 0xab, 0xb0, 0xb6, 0x15, 0x06, 0xb1, 0x17, 0x8c, 0xc3, 0xb1,
+	0x64, 0x46, 0xce, 0x90, 0xa6, 0x75, 0xa8, 0x7b, 0x41, 0x2c, 0xa2, 0xe7, 0xb6, 0xaf, 0x82, 0x99,
+	0x69, 0x9a, 0x3c, 0x1c, 0x63, 0xdf, 0xb7, 0x52, 0x02, 0x15, 0xcb, 0x45, 0x70, 0x37, 0x21, 0x2a,
+	0x12, 0xbd, 0x6a, 0xb1, 0xe8, 0x91, 0xb3, 0x00, 0x43, 0x16, 0xe4, 0x23, 0xd3, 0x9c, 0xb4, 0x6d,
+	0x82, 0xef, 0xd9, 0x52, 0xd0, 0x90, 0x5f, 0x85, 0x16, 0xd3, 0xf9, 0x22, 0x38, 0x8c, 0x8f, 0x54,
+	0x30, 0xab, 0x49, 0xd8, 0x43, 0x82, 0xf0, 0x70, 0x44, 0x24, 0x9c, 0xf0, 0xb9, 0x88, 0x4e, 0x72,
+	0x32, 0xc4, 0x3b, 0x1d, 0x23, 0xc9, 0xd3, 0xe4, 0x88, 0xd6, 0x4c, 0x55, 0x22, 0xf6, 0x94, 0xb8,
+	0x55, 0xcc, 0x56, 0x02, 0x92, 0x58, 0x5e, 0x85, 0x34, 0x6d, 0xf9, 0xf6, 0xa1, 0x8a, 0x55, 0x36,
+	0x13, 0xec, 0xa1, 0x7d, 0x38, 0x3d, 0x53, 0x60, 0xae, 0x99, 0xd2, 0x9c, 0x6b, 0xa6, 0xb4, 0xe6,
+	0x9b, 0x29, 0xed, 0xb3, 0x67, 0x4a, 0xe7, 0xd5, 0x66, 0xca, 0xd2, 0xa9, 0x33, 0xa5, 0x9b, 0x9f,
+	0x29, 0xff, 0xa9, 0x04, 0xbd, 0x07, 0x22, 0x36, 0x15, 0x87, 0xf6, 0x42, 0x2f, 0x78, 0xe3, 0xe6,
+	0xd0, 0xaf, 0x72, 0xbe, 0x8f, 0x8f, 0x4e, 0x3b, 0xb1, 0x56, 0x30, 0x25, 0x78, 0x33, 0xd7, 0xff,
+	0x37, 0x15, 0x58, 0xfc, 0x32, 0x1c, 0x14, 0x06, 0x7f, 0x0d, 0xa8, 0x92, 0xbf, 0x51, 0xa9, 0x5b,
+	0xfc, 0x6d, 0x7c, 0x9e, 0x0b, 0x08, 0x57, 0x66, 0xb4, 0x5f, 0xcd, 0xce, 0xa9, 0x48, 0xb0, 0x1e,
+	0xab, 0xad, 0x4e, 0xc4, 0x6a, 0x27, 0xa3, 0xc4, 0x0b, 0x67, 0x46, 0x89, 0x6b, 0xb3, 0x3c, 0x0b,
+	0x8b, 0x79, 0xcf, 0xc2, 0x84, 0xf9, 0x56, 0x9f, 0x32, 0xdf, 0x92, 0xd5, 0xa9, 0xa1, 0x45, 0x64,
+	0x27, 0x82, 0x98, 0x30, 0x15, 0xc4, 0x24, 0x35, 0x22, 0x63, 0x3b, 0x70, 0x84, 0x0a, 0xd6, 0xa6,
+	0x69, 0x2c, 0x3c, 0x1e, 0xb9, 0xc8, 0x2e, 0x4d, 0xc6, 0x81, 0xa1, 0xb4, 0x49, 0x9a, 0xfb, 0xa7,
+	0x7d, 0xaa, 0xfb, 0xa7, 0x93, 0xb9, 0x7f, 0xfa, 0xdb, 0xd0, 0x7e, 0x20, 0xe2, 0x2f, 0xc3, 0xc1,
+	0x7c, 0x56, 0x6b, 0xe6, 0xea, 0x2a, 0xeb, 0xae, 0xae, 0x07, 0xd0, 0xdd, 0xc2, 0x46, 0xfa, 0xaf,
+	0x5a, 0xd1, 0x16, 0x2c, 0xa1, 0x0b, 0xe3, 0xcb, 0x70, 0x30, 0xe7, 0x0e, 0xad, 0x40, 0xae, 0xfa,
+	0xff, 0xba, 0x04, 0xdd, 0xac, 0x96, 0x37, 0x3b, 0x89, 0x7e, 0x92, 0xf3, 0x82, 0xbc, 0x73, 0x9a,
+	0x34, 0x67, 0x2e, 0x10, 0xe4, 0x1d, 0x6f, 0x82, 0x5f, 0x95, 0x77, 0x7f, 0x58, 0x82, 0x26, 0xd5,
+	0xf1, 0x43, 0xf5, 0xb8, 0x34, 0x67, 0x8f, 0xff, 0xb8, 0x0b, 0xab, 0xa6, 0x90, 0x71, 0x18, 0xfd,
+	0x60, 0xb1, 0xb0, 0x8f, 0x40, 0x3b, 0x44, 0x61, 0xc9, 0xf1, 0xc1, 0x81, 0xf7, 0x52, 0x39, 0x4c,
+	0xb5, 0x3a, 0xf6, 0x09, 0x37, 0xc2, 0xdc, 0xb1, 0x8d, 0x48, 0x70, 0xcd, 0x7c, 0xa2, 0xe8, 0xf3,
+	0xd3, 0x18, 0x37, 0xd5, 0x3b, 0xcd, 0xe0, 0x35, 0xb9, 0x0a, 0x8e, 0x25, 0x2c, 0x3b, 0x93, 0x78,
+	0xe6, 0xc1, 0xa8, 0xe9, 0x91, 0xba, 0x89, 0xf9, 0xbd, 0x78, 0xea, 0xfc, 0xae, 0x6b, 0xee, 0xdd,
+	0xe9, 0xf0, 0x5e, 0xe3, 0x3c, 0xe1, 0xbd, 0x75, 0x48, 0xe3, 0x76, 0x3d, 0x50, 0x1b, 0x28, 0x95,
+	0x46, 0x05, 0x1b, 0x71, 0x3f, 0xe9, 0x18, 0xaf, 0x32, 0xfe, 0x72, 0x18, 0xd2, 0x8c, 0xa5, 0xb8,
+	0x3b, 0x8e, 0x43, 0xa6, 0xe1, 0xf3, 0x44, 0x39, 0xcc, 0xf8, 0x09, 0xac, 0xb8, 0x51, 0x38, 0xda,
+	0x79, 0xe9, 0xc9, 0x38, 0xfb, 0xb6, 0x3a, 0x5d, 0x54, 0x94, 0x65, 0xbc, 0x0f, 0x9d, 0x14, 0xe6,
+	0x7a, 0x39, 0xc6, 0x36, 0x81, 0x1a, 0xb7, 0x61, 0x55, 0x1e, 0x7b, 0x23, 0x8e, 0xe6, 0x68, 0x55,
+	0x2f, 0x11, 0x75, 0x61, 0x1e, 0xca, 0x60, 0x76, 0x8e, 0xa7, 0x4b, 0xe7, 0x78, 0x32, 0x00, 0x8f,
+	0xc9, 0x72, 0xfc, 0xd0, 0x8a, 0x6d, 0x79, 0x8c, 0x53, 0x90, 0x03, 0x68, 0x2d, 0x46, 0xd1, 0x87,
+	0xbc, 0xeb, 0xce, 0x88, 0x2d, 0x1a, 0xb3, 0x62, 0x8b, 0x77, 0x60, 0x6d, 0x30, 0xf6, 0x8f, 0xbd,
+	0x40, 0x8a, 0x28, 0xce, 0x15, 0x5b, 0xe1, 0x62, 0x59, 0x6e, 0x51, 0x9c, 0x71, 0x55, 0x8b, 0x33,
+	0xfe, 0x08, 0x0c, 0xfc, 0x6b, 0x8d, 0xa5, 0x88, 0xac, 0x91, 0x2d, 0xe5, 0x8b, 0x30, 0x72, 0xd5,
+	0x41, 0x93, 0x2e, 0xe6, 0xe0, 0x99, 0x85, 0x3d, 0x85, 0x1b, 0xbf, 0xce, 0x85, 0x1a, 0xf9, 0x68,
+	0xf3, 0xa7, 0xf3, 0x0b, 0xf6, 0xac, 0x58, 0xe3, 0x27, 0xd0, 0x9b, 0x98, 0x93, 0x93, 0xf1, 0xb9,
+	0xb5, 0xfc, 0xdc, 0x4c, 0x23, 0x75, 0xef, 0x41, 0x27, 0xb6, 0xa3, 0x43, 0x11, 0x5b, 0xc9, 0x7e,
+	0xbc, 0xc7, 0xac, 0x66, 0x74, 0x9b, 0x77, 0xe5, 0x9a, 0x7b, 0xe9, 0xed, 0x9c, 0x87, 0xae, 0xc8,
+	0x7d, 0xb2, 0x5e, 0x18, 0xa4, 0xbc, 0x06, 0x6d, 0x3e, 0xdf, 0x9f, 0x44, 0x29, 0x2f, 0xf1, 0x77,
+	0x18, 0x54, 0x61, 0x4a, 0x07, 0x3a, 0x7c, 0x17, 0x65, 0x68, 0x8f, 0x46, 0x5e, 0x70, 0x98, 0x9c,
+	0x7b, 0xfe, 0x9d, 0xf9, 0xd9, 0x44, 0x07, 0xfd, 0x1e, 0xa9, 0xe2, 0xcc, 0xa9, 0xf6, 0x81, 0x8e,
+	0x65, 0x57, 0x56, 0xe8, 0xf4, 0xd2, 0x65, 0xed, 0xca, 0x0a, 0x1d, 0x5c, 0xe2, 0x73, 0xe1, 0x58,
+	0xb1, 0x95, 0x9c, 0x51, 0x7f, 0x97, 0x7b, 0xa4, 0xe0, 0xbb, 0x8c, 0x1a, 0x2f, 0xe1, 0xa2, 0x2e,
+	0x7f, 0xd9, 0xa9, 0xf5, 0x2b, 0xd4, 0xe6, 0xad, 0xef, 0xa3, 0xb3, 0xf6, 0xd2, 0x5a, 0xb8, 0xe9,
+	0xab, 0x4e, 0x41, 0x16, 0x36, 0x91, 0x4e, 0x8b, 0x66, 0x99, 0xbd, 0x0d, 0x9e, 0x9a, 0x08, 0x6b,
+	0xd3, 0x6c, 0xfa, 0x28, 0xfc, 0xd5, 0x39, 0x8f, 0xc2, 0xf7, 0x0b, 0x8f, 0xc2, 0x27, 0xee, 0x25,
+	0x2b, 0xf5, 0xf7, 0x5c, 0xd3, 0x02, 0xa8, 0x8f, 0x14, 0x58, 0xe0, 0xb7, 0x7d, 0xaf, 0xc0, 0x6f,
+	0x6b, 0xfc, 0x18, 0x0c, 0x37, 0x7c, 0x11, 0x1c, 0x46, 0xb6, 0x2b, 0xac, 0x03, 0x61, 0xc7, 0xe3,
+	0x48, 0xc8, 0xde, 0x75, 0xaa, 0x71, 0x39, 0xcd, 0xb9, 0xaf, 0x32, 0x8a, 0xc2, 0xb7, 0xef, 0x17,
+	0x85, 0x6f, 0x7f, 0x04, 0xc6, 0x73, 0xf2, 0xd3, 0x59, 0x7a, 0x14, 0xf7, 0x03, 0xea, 0x78, 0x97,
+	0x73, 0xf6, 0xd3, 0x58, 0xee, 0xfa, 0x36, 0xac, 0x65, 0x0c, 0xd3, 0x97, 0x8c, 0xf3, 0xc4, 0x53,
+	0xdf, 0x48, 0xb8, 0xf7, 0x73, 0x30, 0xa6, 0x85, 0xfb, 0x5c, 0xad, 0x7c, 0xa0, 0x9f, 0x2b, 0x9a,
+	0x10, 0xb5, 0x73, 0x85, 0x8f, 0xff, 0x7d, 0x39, 0xb5, 0x2d, 0xd2, 0xf6, 0xa2, 0x56, 0x9e, 0xda,
+	0x90, 0x7c, 0x51, 0x70, 0x1a, 0xf5, 0xc3, 0x59, 0x13, 0xe3, 0xcf, 0xe0, 0x71, 0xd4, 0x5d, 0xa0,
+	0xe3, 0xd0, 0x6a, 0x53, 0x4b, 0x16, 0xc1, 0x79, 0x4e, 0x46, 0x91, 0x9e, 0xe6, 0x74, 0xff, 0x5f,
+	0x2c, 0xc1, 0x45, 0xd5, 0xd1, 0x6c, 0x20, 0x7e, 0xab, 0x19, 0xf7, 0x25, 0xbb, 0x22, 0x13, 0xe6,
+	0xd4, 0x88, 0x39, 0xe7, 0x38, 0x93, 0x06, 0x58, 0x9a, 0xd3, 0xc6, 0x4f, 0x61, 0x4d, 0xad, 0x45,
+	0x93, 0x2e, 0x60, 0xb6, 0xc2, 0x56, 0x39, 0x77, 0x2b, 0xef, 0x08, 0xb6, 0xe1, 0xad, 0xcc, 0x11,
+	0x9c, 0x68, 0x6e, 0xb4, 0x1b, 0xf8, 0x3e, 0x45, 0x73, 0x36, 0xdb, 0x72, 0xe2, 0x6b, 0x5e, 0x4c,
+	0x6b, 0xd2, 0xb8, 0x2a, 0xd9, 0x1d, 0x43, 0x69, 0xb5, 0xa7, 0x6c, 0x24, 0xee, 0x18, 0x06, 0x69,
+	0x57, 0xf9, 0x3e, 0x2c, 0xc5, 0x61, 0xda, 0x00, 0x6d, 0xeb, 0xd9, 0x8e, 0x43, 0x55, 0x5b, 0xb2,
+	0xfb, 0x4c, 0x45, 0xad, 0x39, 0x21, 0x6a, 0xd3, 0xab, 0x71, 0xab, 0x60, 0x35, 0xd6, 0xcd, 0xc5,
+	0xf6, 0x19, 0xe6, 0x62, 0x67, 0x0e, 0x73, 0x71, 0x69, 0x7e, 0x73, 0xb1, 0x7b, 0x1e, 0x73, 0x71,
+	0xf9, 0x5c, 0xe6, 0xa2, 0x31, 0xc3, 0x5c, 0xfc, 0x08, 0x96, 0xd3, 0x91, 0x9d, 0xb8, 0x84, 0xd6,
+	0x55, 0x19, 0xd9, 0xf9, 0x6f, 0x0c, 0x6e, 0x88, 0xd8, 0x4e, 0x86, 0xc2, 0x55, 0x26, 0x1b, 0x1d,
+	0xf2, 0x55, 0x03, 0xe1, 0x6a, 0xab, 0xbc, 0x9b, 0x2c, 0x79, 0x17, 0xd3, 0x25, 0x8f, 0x60, 0xb5,
+	0xe4, 0x1d, 0xc3, 0x32, 0x9b, 0x24, 0x9e, 0x66, 0x95, 0xb0, 0xf1, 0xf6, 0xab, 0x59, 0x82, 0x95,
+	0x9f, 0xdf, 0x6c, 0x96, 0xec, 0x4e, 0x18, 0x26, 0x4b, 0x07, 0x79, 0xd4, 0xb8, 0x01, 0xcb, 0xd8,
+	0xff, 0x11, 0x05, 0x5c, 0xf8, 0xa3, 0x7c, 0xe4, 0xb8, 0x62, 0x2e, 0xa9, 0x0c, 0x55, 0xd1, 0xa4,
+	0x19, 0xd3, 0x9b, 0xc3, 0x8c, 0x79, 0xbb, 0xd0, 0x8c, 0xf9, 0x4d, 0xee, 0xc6, 0xdd, 0x3a, 0xf5,
+	0xec, 0xb3, 0x73, 0xf4, 0x6c, 0xd2, 0x64, 0xd1, 0x6a, 0x2b, 0x32, 0x54, 0x2e, 0xcd, 0x69, 0xa8,
+	0xbc, 0x33, 0xa7, 0xa1, 0x72, 0xb9, 0xd0, 0x50, 0x79, 0x08, 0x5d, 0x34, 0xe3, 0x2d, 0x65, 0xe5,
+	0x93, 0x83, 0xfa, 0xdd, 0x19, 0x57, 0xe8, 0xee, 0x8d, 0xfd, 0xe3, 0x5d, 0xa2, 0xc5, 0xbd, 0x7d,
+	0x67, 0xa0, 0x27, 0xe9, 0x78, 0x8a, 0x17, 0x58, 0x23, 0xdf, 0x76, 0xf8, 0x46, 0x5b, 0xdd, 0x5c,
+	0xf4, 0x82, 0x3d, 0x4c, 0x1a, 0x7f, 0x09, 0x56, 0x52, 0x4b, 0xc5, 0xcd, 0x8c, 0x98, 0x8d, 0x19,
+	0xe7, 0x9b, 0xb7, 0xc2, 0xe1, 0xc8, 0x8e, 0x77, 0xa5, 0x1c, 0x0b, 0x33, 0x33, 0x80, 0xdc, 0x59,
+	0x76, 0xce, 0xd5, 0x22, 0x3b, 0xa7, 0xe8, 0x9a, 0x60, 0xff, 0x7b, 0x5f, 0x13, 0x2c, 0xb6, 0x9a,
+	0xae, 0x15, 0x5b, 0x4d, 0xc6, 0x37, 0xb0, 0xa2, 0xc8, 0x28, 0xcb, 0x73, 0x6c, 0x1a, 0xdc, 0xf7,
+	0x36, 0x4a, 0xa7, 0x46, 0xa2, 0xb8, 0xf4, 0x33, 0x8d, 0xdc, 0x34, 0xe4, 0x14, 0xb6, 0x7e, 0x0f,
+	0x56, 0x8b, 0xe6, 0x8a, 0x6e, 0x9e, 0x54, 0x0a, 0xcc, 0x93, 0x8a, 0x6e, 0xe7, 0xfc, 0x02, 0x96,
+	0x5e, 0xc5, 0xba, 0xf9, 0x1f, 0x25, 0x30, 0xa6, 0x5b, 0x7b, 0xce, 0x2b, 0x8a, 0x57, 0x21, 0x89,
+	0xcc, 0x66, 0x57, 0xe5, 0x28, 0x2e, 0x40, 0x58, 0x72, 0x8c, 0x70, 0x68, 0xc7, 0xce, 0x51, 0x42,
+	0xc2, 0xbe, 0xd5, 0xa6, 0xc2, 0xd2, 0x1b, 0x73, 0x4e, 0x18, 0xf1, 0xb2, 0x5b, 0x32, 0x39, 0xc1,
+	0xb7, 0xf0, 0x38, 0x7c, 0x3b, 0x3a, 0x4e, 0x82, 0xb7, 0xa0, 0xa0, 0xbd, 0x63, 0x9a, 0x77, 0x43,
+	0x4f, 0xe6, 0x2a, 0x57, 0xa1, 0xdb, 0x0c, 0xc6, 0xfa, 0xfb, 0xff, 0xb7, 0x04, 0xed, 0x9c, 0xec,
+	0xe3, 0x56, 0x2f, 0xd9, 0x74, 0x33, 0xaf, 0x6b, 0x31, 0x6f, 0xb7, 0xe7, 0xbc, 0x53, 0xa7, 0xdf,
+	0x9e, 0xaa, 0xe4, 0x6f, 0x4f, 0xa5, 0x0c, 0xac, 0xea, 0x0c, 0xc4, 0xa3, 0x9b, 0x68, 0x8b, 0x58,
+	0xc3, 0x19, 0x2e, 0x64, 0xbc, 0x30, 0x1c, 0xe3, 0x96, 0x36, 0x56, 0xe6, 0x59, 0x92, 0x9c, 0x30,
+	0x5d, 0x16, 0x67, 0x99, 0x2e, 0xf5, 0x9c, 0xe9, 0xd2, 0xff, 0xd3, 0x0a, 0x2c, 0xe7, 0xb6, 0x63,
+	0xbf, 0xd5, 0x86, 0x98, 0x9b, 0x73, 0x01, 0xe4, 0xed, 0xa0, 0xda, 0x8c, 0xb7, 0x17, 0x0a, 0x95,
+	0xba, 0xee, 0x2e, 0x98, 0x6d, 0x09, 0x2d, 0xce, 0x67, 0x09, 0xd5, 0xcf, 0xb2, 0x84, 0x1a, 0x13,
+	0x96, 0xd0, 0x2d, 0x58, 0x49, 0x56, 0x42, 0xdd, 0xad, 0x06, 0x24, 0xc5, 0x86, 0xca, 0xda, 0xca,
+	0x07, 0xb2, 0x75, 0xbf, 0x65, 0x73, 0xea, 0x10, 0xd6, 0x1f, 0x94, 0xe1, 0x62, 0x6e, 0xb8, 0x7f,
+	0x80, 0x40, 0xa9, 0xe6, 0xc2, 0x7d, 0xff, 0x6c, 0xf7, 0x00, 0x8d, 0x04, 0x95, 0x31, 0x1e, 0x43,
+	0x47, 0x39, 0x60, 0xac, 0x48, 0x8c, 0xc2, 0x28, 0xee, 0x2d, 0xcc, 0xd8, 0x86, 0xa8, 0x5a, 0xb6,
+	0xc9, 0x47, 0x63, 0x12, 0xbd, 0xd9, 0x72, 0xb5, 0x94, 0xe6, 0xdc, 0xae, 0xe9, 0xce, 0xed, 0x3f,
+	0xa8, 0xc0, 0x4a, 0x41, 0x61, 0xe4, 0x90, 0x13, 0x06, 0x07, 0xbe, 0xe7, 0xc4, 0xc9, 0x85, 0x8b,
+	0x0c, 0x40, 0xeb, 0x4c, 0xb9, 0x76, 0x52, 0xed, 0x92, 0x5c, 0xc3, 0xe9, 0x72, 0xc6, 0xa3, 0x14,
+	0xc7, 0xbb, 0xcc, 0xe9, 0x99, 0x53, 0x2b, 0x0e, 0x2d, 0x87, 0x6c, 0x3d, 0xe5, 0x41, 0x5e, 0x4e,
+	0xb3, 0x9e, 0x84, 0x6c, 0x04, 0x4e, 0x1f, 0x55, 0xa9, 0x16, 0x1c, 0x55, 0xf9, 0x08, 0x96, 0x85,
+	0x3a, 0xde, 0xe0, 0x5a, 0x52, 0x38, 0x61, 0xe0, 0x26, 0x87, 0x39, 0xba, 0x69, 0xc6, 0x3e, 0xe3,
+	0xa8, 0x1c, 0xc9, 0x22, 0xb2, 0xb2, 0x2e, 0xb1, 0x06, 0xed, 0x10, 0xbc, 0x95, 0xf6, 0xeb, 0x3d,
+	0x14, 0xf6, 0x54, 0xbb, 0x09, 0x57, 0xe9, 0xd0, 0x3c, 0x58, 0x74, 0x4c, 0xa6, 0x5e, 0x78, 0x4c,
+	0x66, 0x07, 0xef, 0xe3, 0xe2, 0xd2, 0x6f, 0x79, 0xb8, 0xf6, 0x27, 0x57, 0x12, 0xcf, 0x36, 0x12,
+	0x5a, 0x4e, 0x96, 0x90, 0xfd, 0xfb, 0xb0, 0x46, 0x31, 0x4c, 0x9e, 0x47, 0xa8, 0x5d, 0xe6, 0x73,
+	0xec, 0xb3, 0x62, 0x2b, 0x27, 0x8a, 0xad, 0xff, 0xd7, 0xa0, 0xa9, 0x5d, 0x8a, 0x45, 0x0d, 0xcb,
+	0xd6, 0xe8, 0xb6, 0xd2, 0xfb, 0x49, 0xd2, 0xb8, 0x93, 0xdd, 0xef, 0xe5, 0xeb, 0x5e, 0x97, 0x66,
+	0xbc, 0x0c, 0x90, 0x5e, 0xed, 0xed, 0xff, 0xcd, 0x32, 0xd4, 0x54, 0xdd, 0x57, 0xa0, 0x29, 0x82,
+	0x38, 0xf2, 0x04, 0x3f, 0xe9, 0xc1, 0xf5, 0x83, 0x82, 0xf0, 0x90, 0xc9, 0x75, 0xe8, 0xa4, 0x76,
+	0xbd, 0x75, 0x10, 0x85, 0x43, 0x6a, 0x67, 0xd5, 0x6c, 0xa7, 0xe8, 0xfd, 0x28, 0x1c, 0xe2, 0x82,
+	0x99, 0x91, 0xc5, 0x21, 0x4d, 0xae, 0xaa, 0xd9, 0x4c, 0xb1, 0x27, 0x21, 0xc5, 0x85, 0xc3, 0x43,
+	0x7a, 0x85, 0x40, 0x2d, 0x33, 0x8b, 0x7e, 0x78, 0x88, 0x2f, 0x0f, 0x24, 0x59, 0xda, 0xf9, 0x32,
+	0xcc, 0xda, 0x57, 0x21, 0x43, 0xa5, 0x3c, 0xf4, 0x8b, 0xfd, 0x0c, 0x11, 0xc1, 0x1a, 0xd4, 0x9c,
+	0xc8, 0xf9, 0xf8, 0xb6, 0xa3, 0xb6, 0xa2, 0x2a, 0x35, 0x79, 0x03, 0xac, 0x3e, 0x79, 0x03, 0xac,
+	0xff, 0xfb, 0x25, 0xe8, 0xf0, 0x6c, 0x4e, 0xbd, 0x63, 0x13, 0x9a, 0xaa, 0x34, 0x15, 0x61, 0xc1,
+	0x00, 0x26, 0x09, 0x3f, 0x2b, 0x7a, 0x75, 0x0b, 0x9f, 0x21, 0xd2, 0xf5, 0x49, 0xd4, 0xb3, 0xa2,
+	0x45, 0x3d, 0x7f, 0x0e, 0x0b, 0xd9, 0xfc, 0x38, 0xed, 0xcd, 0x8c, 0xa4, 0x0d, 0x28, 0x90, 0x26,
+	0xd3, 0xf7, 0xff, 0x67, 0x09, 0x5a, 0x3a, 0x9e, 0x06, 0x38, 0x4a, 0x5a, 0x80, 0x23, 0xf9, 0x62,
+	0x59, 0xfb, 0x62, 0xc6, 0x93, 0xca, 0x24, 0x4f, 0x94, 0x89, 0xae, 0x8d, 0x02, 0x30, 0x44, 0x03,
+	0x31, 0x75, 0x31, 0x7d, 0x61, 0x8e, 0x8b, 0xe9, 0xb5, 0xe9, 0x8b, 0xe9, 0xf9, 0xfb, 0xef, 0x8b,
+	0x93, 0xf7, 0xdf, 0x75, 0x4b, 0xa4, 0x9e, 0xb3, 0x44, 0xfa, 0x7f, 0xab, 0x04, 0xdd, 0xc9, 0x1b,
+	0x96, 0xb8, 0x1c, 0x45, 0xe2, 0xb9, 0x47, 0x57, 0x9c, 0x58, 0x44, 0xd3, 0x34, 0x6e, 0xcc, 0x79,
+	0x4f, 0x19, 0x86, 0x31, 0x77, 0x8b, 0x27, 0x12, 0x6f, 0x2a, 0xc3, 0x30, 0xa6, 0x8e, 0x5d, 0x82,
+	0x06, 0xde, 0xe7, 0x61, 0x9b, 0x9d, 0x0d, 0xbe, 0xfa, 0xb1, 0x38, 0x61, 0x73, 0x3d, 0x61, 0x61,
+	0x55, 0x3b, 0x48, 0xf5, 0x47, 0x25, 0x68, 0xe9, 0xed, 0x38, 0x5b, 0x36, 0xf4, 0x46, 0x96, 0xcf,
+	0x6c, 0x64, 0xa5, 0xa0, 0x91, 0x13, 0xd2, 0x55, 0x9d, 0x92, 0xae, 0x8f, 0xa1, 0x72, 0xfc, 0x3c,
+	0x89, 0xbc, 0x5d, 0x3d, 0xf5, 0x76, 0x6a, 0xf2, 0xfe, 0x8a, 0x89, 0xd4, 0xfd, 0x5f, 0x43, 0x4b,
+	0x07, 0xcf, 0xb2, 0xb7, 0x5b, 0xca, 0xde, 0x26, 0x1b, 0x38, 0x74, 0xad, 0xb4, 0x4f, 0xea, 0xfd,
+	0x81, 0x61, 0xe8, 0x9a, 0x0a, 0xea, 0xff, 0x0c, 0x5a, 0xfa, 0x5b, 0x2f, 0xf3, 0x9a, 0xf2, 0xfd,
+	0xff, 0x53, 0x02, 0xa0, 0x52, 0xa4, 0xe6, 0x8c, 0xcb, 0xd0, 0x18, 0x84, 0xa1, 0x6f, 0xd1, 0x1a,
+	0x8c, 0x85, 0xeb, 0x5f, 0x5c, 0x30, 0xeb, 0x08, 0x6d, 0xe3, 0x0a, 0x7b, 0x89, 0xce, 0x16, 0x71,
+	0x2e, 0x56, 0xb3, 0xf0, 0xc5, 0x05, 0xdc, 0xe5, 0xc5, 0x94, 0x79, 0x19, 0x1a, 0x7e, 0x18, 0x1c,
+	0x72, 0x2e, 0x35, 0x11, 0xcb, 0x22, 0x44, 0xd9, 0x57, 0x00, 0x0e, 0xfc, 0xd0, 0x56, 0xa5, 0x91,
+	0xa3, 0xe5, 0x2f, 0x2e, 0x98, 0x0d, 0xc2, 0x88, 0xe0, 0x2a, 0x34, 0xdd, 0x70, 0x3c, 0xf0, 0x05,
+	0x53, 0x90, 0x31, 0xff, 0xc5, 0x05, 0x13, 0x18, 0x4c, 0x48, 0x64, 0x1c, 0x79, 0xc9, 0x47, 0x68,
+	0x59, 0x46, 0x12, 0x06, 0x93, 0xcf, 0x0c, 0x4e, 0x62, 0x21, 0x99, 0x02, 0xe5, 0xbd, 0x85, 0x9f,
+	0x21, 0x0c, 0x09, 0xee, 0xd5, 0xd8, 0xc2, 0xe8, 0xff, 0xd3, 0x05, 0xa5, 0xdb, 0xf9, 0x65, 0xa5,
+	0x19, 0xba, 0x3d, 0x39, 0x65, 0x55, 0xd6, 0x4e, 0x59, 0xbd, 0x07, 0x1d, 0x4f, 0x5a, 0xa3, 0xc8,
+	0x1b, 0xda, 0xd1, 0x49, 0x7a, 0x20, 0xb6, 0x6e, 0xb6, 0x3c, 0xb9, 0xc7, 0x20, 0xc6, 0x73, 0x36,
+	0xa0, 0xe9, 0x0a, 0xe9, 0x44, 0xde, 0x88, 0x76, 0x7e, 0x3c, 0xcb, 0x75, 0x08, 0x2f, 0xa2, 0x63,
+	0x6b, 0xf8, 0x06, 0xdc, 0x02, 0x59, 0x4f, 0xc5, 0x17, 0xd1, 0xb1, 0xed, 0x78, 0x2f, 0xce, 0xac,
+	0xbb, 0xea, 0x97, 0x71, 0x0f, 0x9a, 0x58, 0xcc, 0x52, 0x8f, 0x87, 0xd5, 0xe6, 0x7e, 0x07, 0x08,
+	0x4b, 0xf1, 0x53, 0x60, 0xc6, 0x36, 0xb4, 0xd8, 0x41, 0xa2, 0x2a, 0x59, 0x9c, 0xb7, 0x12, 0x7e,
+	0x58, 0x49, 0xd5, 0xb2, 0x06, 0x35, 0x1b, 0xbd, 0x62, 0xdb, 0xea, 0x68, 0xab, 0x4a, 0xe1, 0x15,
+	0x68, 0xde, 0xcc, 0xf0, 0x31, 0xbf, 0x2b, 0xa7, 0xbf, 0x3a, 0xc1, 0x6b, 0x34, 0x53, 0x1b, 0x9f,
+	0x43, 0x4b, 0xf8, 0x74, 0x03, 0x93, 0xf9, 0x02, 0xf3, 0xf0, 0xa5, 0xa9, 0x8a, 0x60, 0xc2, 0xd8,
+	0x86, 0xb6, 0x2b, 0x0e, 0xec, 0xb1, 0x1f, 0x5b, 0x2c, 0xf4, 0xcd, 0x19, 0xb7, 0xa8, 0x32, 0xf9,
+	0x37, 0x5b, 0xaa, 0x14, 0x41, 0xe4, 0x3d, 0x92, 0x96, 0x7b, 0x12, 0xd8, 0x43, 0xcf, 0x49, 0x1e,
+	0xa0, 0xf0, 0xe4, 0x36, 0x03, 0x18, 0xd7, 0x43, 0x19, 0x48, 0x15, 0xf0, 0xb1, 0x48, 0x5c, 0x8d,
+	0x1d, 0x4f, 0xa6, 0x3e, 0x53, 0x94, 0x83, 0x1f, 0x81, 0xe1, 0x49, 0xeb, 0x60, 0x1c, 0xb0, 0x36,
+	0x0f, 0xc7, 0xf1, 0x68, 0x1c, 0x2b, 0x3f, 0x61, 0xd7, 0x93, 0xf7, 0x55, 0xc6, 0xd7, 0x84, 0xf7,
+	0xff, 0x57, 0x19, 0x3a, 0x09, 0xa4, 0x84, 0xb3, 0xe8, 0xa0, 0x5f, 0x66, 0xab, 0x54, 0x68, 0x13,
+	0x36, 0x21, 0x6c, 0x95, 0x69, 0x61, 0xbb, 0xa3, 0x4e, 0xa8, 0x54, 0x67, 0x58, 0xe9, 0xc9, 0x87,
+	0x89, 0xa7, 0x44, 0x8e, 0x0e, 0x37, 0x2f, 0x18, 0x8d, 0x63, 0x2b, 0x7b, 0x02, 0x2f, 0x39, 0x96,
+	0xbf, 0x44, 0x19, 0xf7, 0x93, 0x87, 0xf0, 0xc8, 0x2f, 0xa3, 0xd3, 0x7a, 0x2e, 0xcb, 0x65, 0xc5,
+	0x6c, 0x67, 0x94, 0xbb, 0x2e, 0xbf, 0xed, 0x33, 0x8e, 0x33, 0x42, 0xae, 0x94, 0x6d, 0xc7, 0x2e,
+	0xe7, 0x68, 0xb5, 0x6e, 0x82, 0xc2, 0xb4, 0x6a, 0xf9, 0x1d, 0xa0, 0x8e, 0x46, 0x8b, 0xf5, 0x7e,
+	0x9a, 0xbe, 0xa5, 0xd7, 0x98, 0x57, 0x92, 0x55, 0x81, 0xfe, 0x3f, 0x2c, 0x43, 0x77, 0xf2, 0xbd,
+	0xb5, 0x42, 0xc6, 0x4f, 0x30, 0xba, 0x3c, 0xcd, 0xe8, 0x6c, 0x3e, 0x54, 0x72, 0xf3, 0xe1, 0x13,
+	0xa8, 0x51, 0x07, 0x12, 0x03, 0x64, 0xc6, 0x33, 0x2c, 0xc9, 0x7b, 0x6f, 0x4c, 0x8f, 0xc7, 0x23,
+	0xf9, 0x69, 0xbf, 0x44, 0x1c, 0x99, 0x13, 0xea, 0x9d, 0x3f, 0x83, 0xf3, 0x94, 0x60, 0xb2, 0x2a,
+	0xbf, 0x0b, 0x8d, 0x44, 0xe0, 0x92, 0x69, 0x7d, 0x6d, 0xe6, 0x88, 0xab, 0x2f, 0x66, 0xa5, 0xfa,
+	0x1d, 0x68, 0xb1, 0x1f, 0x8c, 0xed, 0xe3, 0xfe, 0xbf, 0x2a, 0x41, 0x53, 0xb3, 0xb9, 0x8d, 0x77,
+	0x01, 0x34, 0xa7, 0xa5, 0x5a, 0x87, 0x33, 0x84, 0x54, 0x2a, 0x3b, 0xec, 0x14, 0x93, 0x92, 0x24,
+	0x05, 0xba, 0xbd, 0xc0, 0x11, 0xe9, 0x7b, 0x12, 0x6a, 0x11, 0x26, 0x30, 0x79, 0x50, 0xa2, 0x0f,
+	0xad, 0xc4, 0xf3, 0x87, 0xbd, 0x53, 0xe7, 0x9b, 0x73, 0x98, 0xe6, 0x59, 0x5a, 0xc8, 0xbd, 0x8b,
+	0xf4, 0xb7, 0xcb, 0xf0, 0x36, 0xb5, 0x9d, 0xdb, 0xeb, 0x0d, 0x3c, 0x1f, 0x9f, 0x4a, 0x78, 0x3d,
+	0xa7, 0x7b, 0xae, 0xa7, 0x11, 0x88, 0x7c, 0xf3, 0xdb, 0x8c, 0x26, 0xed, 0xff, 0x5e, 0x97, 0x20,
+	0x8b, 0x4e, 0x0e, 0xd5, 0x8a, 0x4f, 0x0e, 0x4d, 0x07, 0x42, 0x16, 0xa7, 0x03, 0x21, 0xfd, 0x3f,
+	0x29, 0xc1, 0x7a, 0x11, 0x27, 0xde, 0xec, 0xbe, 0x7e, 0x9a, 0x65, 0xd5, 0x22, 0x96, 0x7d, 0x02,
+	0x35, 0xb5, 0xef, 0x5b, 0x98, 0x73, 0xdf, 0xa7, 0xe8, 0xfb, 0xff, 0xbc, 0x04, 0x6d, 0x25, 0xac,
+	0xaa, 0x67, 0x49, 0xdb, 0x4b, 0xdf, 0xab, 0xed, 0xe5, 0xac, 0xed, 0x5f, 0x40, 0x47, 0xc6, 0x61,
+	0x64, 0x1f, 0x8a, 0xc4, 0x83, 0x5c, 0x99, 0xa1, 0x5b, 0xf6, 0x99, 0x94, 0xdb, 0xd2, 0x96, 0x5a,
+	0x4a, 0xe2, 0x46, 0xa7, 0xa5, 0xe7, 0xe3, 0x0c, 0x51, 0x14, 0x8a, 0xf7, 0x49, 0xb2, 0xd0, 0xe8,
+	0x48, 0x7d, 0x83, 0x95, 0x62, 0xe7, 0x6a, 0x35, 0xe7, 0x5c, 0x35, 0xa0, 0x7a, 0xe4, 0x05, 0x71,
+	0x22, 0x5d, 0xf8, 0x1b, 0x45, 0xd2, 0x1d, 0x47, 0xe4, 0xaa, 0xb5, 0x86, 0x32, 0xd9, 0xc3, 0x25,
+	0xd0, 0x23, 0xd9, 0xff, 0xbd, 0x4a, 0xf2, 0x70, 0xd2, 0xb6, 0x77, 0x70, 0x40, 0xaf, 0xf6, 0x45,
+	0xe1, 0xd0, 0x9a, 0xb6, 0xb7, 0x3b, 0x88, 0x6b, 0x67, 0x95, 0x51, 0x18, 0x43, 0x6b, 0x7a, 0xde,
+	0xb4, 0xe2, 0x50, 0xa3, 0xba, 0x0d, 0x17, 0xf5, 0xfa, 0xb2, 0x38, 0x15, 0xef, 0x52, 0x57, 0xb2,
+	0x4a, 0xb3, 0x50, 0xd5, 0x4d, 0x58, 0x89, 0xc3, 0xe9, 0x12, 0x55, 0x2a, 0xb1, 0x1c, 0x87, 0x93,
+	0xf4, 0x97, 0xa0, 0x41, 0xdf, 0xd0, 0xf6, 0xb0, 0x75, 0x04, 0x68, 0x8f, 0x8a, 0x9e, 0xdb, 0x50,
+	0xdf, 0xc0, 0xd6, 0xe2, 0x70, 0x5f, 0xbd, 0x23, 0x49, 0xa5, 0x94, 0x1f, 0x38, 0x2d, 0x45, 0x1e,
+	0x66, 0x2e, 0x45, 0x59, 0xf5, 0xa4, 0x14, 0x65, 0x5c, 0x87, 0x8e, 0xf6, 0x5a, 0x84, 0x15, 0x1e,
+	0x90, 0xa5, 0x53, 0x37, 0xdb, 0x1a, 0xfa, 0xf5, 0x81, 0xb1, 0x03, 0xcd, 0x49, 0x07, 0xdd, 0x69,
+	0xda, 0x38, 0x5b, 0x81, 0x70, 0x00, 0x72, 0xf7, 0x50, 0xfa, 0xbf, 0x57, 0x85, 0x4e, 0x3e, 0xff,
+	0x35, 0xdc, 0x11, 0xc2, 0x1d, 0xea, 0x91, 0x1d, 0xa4, 0xbe, 0x56, 0x95, 0xc2, 0xae, 0x29, 0x87,
+	0x15, 0x03, 0x89, 0x53, 0x49, 0x9d, 0x50, 0xda, 0x62, 0x10, 0xf5, 0x95, 0xed, 0x62, 0x58, 0x47,
+	0x3b, 0xb6, 0xa5, 0x6c, 0x03, 0xc2, 0xb3, 0x13, 0x1f, 0x78, 0x94, 0x25, 0xb9, 0xbf, 0xa6, 0x11,
+	0xb3, 0x72, 0x5b, 0x56, 0x39, 0x1a, 0xf9, 0xf7, 0x1b, 0x91, 0xdc, 0xe8, 0x37, 0x4e, 0x1f, 0x7d,
+	0xc8, 0x8d, 0x3e, 0x5e, 0x8c, 0xa0, 0x52, 0xc9, 0x8b, 0x84, 0x4d, 0xf5, 0xf4, 0x03, 0x96, 0x54,
+	0x18, 0x4e, 0x1e, 0x2c, 0x9d, 0x90, 0xf0, 0xe3, 0x1c, 0x10, 0x87, 0x29, 0xc1, 0x75, 0xe8, 0x30,
+	0x2f, 0x52, 0x1a, 0x7e, 0x8f, 0xa3, 0x4d, 0x68, 0x4a, 0x46, 0x0f, 0x33, 0x32, 0x1f, 0x52, 0xc2,
+	0x0e, 0x11, 0x2e, 0x29, 0x5c, 0x27, 0x65, 0xee, 0x6b, 0xa4, 0x4b, 0x4c, 0xaa, 0xf0, 0x84, 0x14,
+	0x9f, 0xbf, 0x03, 0x73, 0x1c, 0xec, 0x8f, 0x87, 0xb8, 0xfb, 0xc0, 0xd9, 0x7f, 0xec, 0x05, 0x89,
+	0x4a, 0xa7, 0xdf, 0x67, 0x2f, 0x6c, 0x97, 0x01, 0x12, 0x8f, 0x74, 0x7a, 0xa9, 0xbf, 0xa1, 0x90,
+	0x57, 0x8b, 0x4d, 0xbc, 0xd2, 0xf1, 0x76, 0xba, 0x8a, 0x6d, 0xd1, 0x16, 0x4e, 0x8d, 0x36, 0x10,
+	0x74, 0x0f, 0x11, 0x7e, 0x9b, 0xd9, 0x17, 0xca, 0xa3, 0xc0, 0x43, 0xde, 0x40, 0x84, 0x5d, 0x0a,
+	0xef, 0x02, 0xc4, 0x47, 0x51, 0x38, 0x3e, 0x3c, 0x42, 0x9b, 0x1b, 0x54, 0xf1, 0x14, 0x21, 0x8b,
+	0xf1, 0x88, 0x42, 0xbf, 0xcd, 0x19, 0x5a, 0x7d, 0x0f, 0x49, 0x14, 0x6f, 0x4d, 0x55, 0xc0, 0xf8,
+	0x0d, 0xac, 0x48, 0x3f, 0x7c, 0x21, 0x64, 0x9c, 0xf3, 0xbf, 0xb7, 0xe6, 0x7a, 0x73, 0x28, 0x1b,
+	0x2b, 0xd3, 0x50, 0xb5, 0x64, 0x99, 0xd2, 0xf8, 0x8b, 0xb0, 0x18, 0x09, 0xf2, 0xfd, 0x91, 0x10,
+	0x35, 0x4f, 0x5d, 0xc0, 0xe2, 0xe8, 0x24, 0xa9, 0x27, 0x29, 0xd1, 0x3f, 0x81, 0x96, 0xde, 0xe0,
+	0x42, 0x2b, 0x76, 0x62, 0x29, 0x28, 0x4f, 0x2e, 0x05, 0x38, 0xda, 0xcc, 0x72, 0x76, 0x37, 0x70,
+	0x62, 0x82, 0x9d, 0xd5, 0x49, 0x76, 0xf6, 0xff, 0x41, 0x09, 0x56, 0x8b, 0x3a, 0xf9, 0x1a, 0x34,
+	0xd5, 0x44, 0x8b, 0x2b, 0x53, 0x2d, 0x2e, 0xf2, 0x1e, 0xbd, 0x84, 0x96, 0xce, 0xa3, 0xc9, 0x15,
+	0xb7, 0x92, 0xad, 0xb8, 0x3f, 0x06, 0x83, 0x7c, 0xc8, 0x4e, 0xac, 0xcf, 0x36, 0xe6, 0xcb, 0x72,
+	0x9a, 0xa3, 0x6b, 0x03, 0x2d, 0x7a, 0x9e, 0xb4, 0x26, 0x0b, 0x8a, 0xdf, 0xf8, 0x5d, 0x68, 0xe9,
+	0xe6, 0x85, 0xd1, 0x84, 0xc5, 0xfd, 0xb1, 0xe3, 0x08, 0x29, 0xbb, 0x17, 0x8c, 0x25, 0x68, 0x3e,
+	0x0e, 0x63, 0x6b, 0x7f, 0x3c, 0x1a, 0x85, 0x51, 0xdc, 0x2d, 0x19, 0xcb, 0xd0, 0x7e, 0x1c, 0x5a,
+	0x7b, 0x22, 0x22, 0x9f, 0x7d, 0x18, 0x74, 0xcb, 0x46, 0x1d, 0xaa, 0xf7, 0x6d, 0xcf, 0xef, 0x56,
+	0x8c, 0x55, 0x3a, 0x39, 0x67, 0x0f, 0x45, 0x2c, 0x22, 0x6b, 0x07, 0xe7, 0x55, 0xf7, 0x1f, 0x55,
+	0x8c, 0xcb, 0xd0, 0x53, 0x06, 0xad, 0xf5, 0x35, 0xfb, 0x57, 0xb1, 0xca, 0xfb, 0xe1, 0x38, 0x70,
+	0xbb, 0xff, 0xa4, 0x72, 0xe3, 0xf7, 0x4b, 0xb0, 0x52, 0xf0, 0x9e, 0x84, 0x61, 0x40, 0xe7, 0xde,
+	0xdd, 0xad, 0xaf, 0x9e, 0xee, 0x59, 0xbb, 0x8f, 0x77, 0x9f, 0xec, 0xde, 0x7d, 0xd8, 0xbd, 0x60,
+	0xac, 0x42, 0x57, 0x61, 0x3b, 0xdf, 0xec, 0x6c, 0x3d, 0x7d, 0xb2, 0xfb, 0xf8, 0x41, 0xb7, 0xa4,
+	0x51, 0xee, 0x3f, 0xdd, 0xda, 0xda, 0xd9, 0xdf, 0xef, 0x96, 0xb1, 0xe1, 0x0a, 0xbb, 0x7f, 0x77,
+	0xf7, 0x61, 0xb7, 0xa2, 0x11, 0x3d, 0xd9, 0x7d, 0xb4, 0xf3, 0xf5, 0xd3, 0x27, 0xdd, 0x2a, 0x76,
+	0x46, 0x61, 0x7b, 0x77, 0x9f, 0xee, 0xef, 0x6c, 0x77, 0x17, 0x34, 0xb2, 0xbd, 0xbb, 0x26, 0x7d,
+	0xb5, 0x76, 0xe3, 0x25, 0xb4, 0xf4, 0xeb, 0x34, 0x58, 0xf7, 0x97, 0x5f, 0xdf, 0xb3, 0xcc, 0xa7,
+	0x8f, 0x1f, 0x63, 0x03, 0x2e, 0x24, 0x40, 0xf2, 0xf5, 0x92, 0xd1, 0x82, 0x3a, 0x02, 0xf4, 0xe9,
+	0x32, 0x7e, 0x06, 0x53, 0x5b, 0x77, 0x1f, 0x6f, 0xed, 0x3c, 0xc4, 0x12, 0x15, 0xa3, 0x0b, 0xad,
+	0x0c, 0xda, 0xd9, 0xee, 0x56, 0x8d, 0x15, 0x58, 0x42, 0x64, 0xf7, 0xf1, 0x93, 0x1d, 0xd3, 0x7c,
+	0xba, 0xf7, 0x04, 0x5b, 0x73, 0xe3, 0x59, 0x7a, 0x34, 0x2f, 0xcf, 0x9b, 0x26, 0x2c, 0x66, 0x4c,
+	0x69, 0x43, 0x43, 0xe7, 0x06, 0x8e, 0x5f, 0xca, 0x06, 0x1c, 0x1b, 0xee, 0x7f, 0x13, 0x16, 0xd3,
+	0x8e, 0xdf, 0xf8, 0x06, 0xb7, 0x90, 0x13, 0xef, 0x66, 0x03, 0xd4, 0xf6, 0xe3, 0x28, 0x0c, 0x0e,
+	0xbb, 0x17, 0xa8, 0x0e, 0x7e, 0x0d, 0x8a, 0x2b, 0xbc, 0x87, 0x83, 0x25, 0xdc, 0x6e, 0xd9, 0xe8,
+	0x00, 0xec, 0x3c, 0x17, 0x41, 0x3c, 0xb6, 0x7d, 0xff, 0xa4, 0x5b, 0xc1, 0x34, 0x9f, 0x0c, 0xf6,
+	0xbe, 0x13, 0x6e, 0xb7, 0x7a, 0xe3, 0xbf, 0x94, 0xa0, 0x9e, 0xf8, 0x3a, 0xf0, 0xeb, 0x8f, 0xc3,
+	0x40, 0x74, 0x2f, 0xe0, 0xaf, 0x7b, 0x61, 0xe8, 0x77, 0x4b, 0xf8, 0x6b, 0x37, 0x88, 0x3f, 0xe9,
+	0x96, 0x8d, 0x06, 0x2c, 0xec, 0x06, 0xf1, 0x5f, 0xf8, 0x59, 0xb7, 0xa2, 0x7e, 0x7e, 0x7c, 0xbb,
+	0x5b, 0x55, 0x3f, 0x7f, 0xf6, 0xd3, 0xee, 0x02, 0xfe, 0xbc, 0xef, 0x87, 0x76, 0xdc, 0x05, 0x6c,
+	0xdc, 0x36, 0xf9, 0xd7, 0xba, 0x4d, 0xd5, 0x50, 0x2f, 0x38, 0xec, 0xae, 0x62, 0xdb, 0x9e, 0xd9,
+	0xd1, 0xd6, 0x91, 0x1d, 0x75, 0x2f, 0x22, 0xfd, 0xdd, 0x28, 0xb2, 0x4f, 0xba, 0x6b, 0xf8, 0x95,
+	0x2f, 0x65, 0x18, 0x74, 0xdf, 0x42, 0x4e, 0xdf, 0xf3, 0x02, 0x3b, 0x3a, 0x79, 0x46, 0x07, 0x55,
+	0xbb, 0x2e, 0x8e, 0x16, 0x55, 0xab, 0x00, 0x61, 0x5c, 0x84, 0xe5, 0xfd, 0x91, 0x1d, 0x49, 0xa1,
+	0xc3, 0x47, 0x37, 0x9e, 0x01, 0x64, 0x3e, 0x1f, 0xac, 0x87, 0x52, 0x1c, 0xca, 0x72, 0xbb, 0x17,
+	0x70, 0x58, 0x33, 0x04, 0x9b, 0x53, 0x4a, 0xa1, 0xed, 0x28, 0xa4, 0xf3, 0x0e, 0xdd, 0x72, 0x5a,
+	0x8e, 0x20, 0xe1, 0x76, 0x2b, 0x37, 0x3e, 0x87, 0x96, 0xee, 0xbd, 0xc0, 0x91, 0x4f, 0xd2, 0x4f,
+	0x83, 0xe3, 0x20, 0x7c, 0x11, 0x28, 0x86, 0x3d, 0xba, 0x7d, 0x87, 0xeb, 0x7c, 0x22, 0x5e, 0xc6,
+	0x3b, 0xc3, 0x81, 0x70, 0x5d, 0xaa, 0xf3, 0xf6, 0x9f, 0x74, 0x60, 0xe5, 0x11, 0x69, 0x59, 0x75,
+	0xa7, 0x4c, 0x44, 0xcf, 0x3d, 0x47, 0x18, 0x0e, 0xb4, 0xf4, 0x77, 0x98, 0x8c, 0xcd, 0x79, 0x9f,
+	0x6a, 0x5a, 0xff, 0xe0, 0xac, 0xc7, 0x47, 0x94, 0x86, 0xe8, 0x5f, 0x30, 0xfe, 0x2a, 0x34, 0xd2,
+	0x37, 0x7a, 0x8c, 0xe2, 0x07, 0x0f, 0x27, 0xdf, 0xf0, 0x39, 0x4f, 0xf5, 0x03, 0x68, 0x6a, 0x4f,
+	0xb2, 0x18, 0xc5, 0x25, 0xa7, 0xdf, 0xd5, 0x59, 0xdf, 0x3c, 0x9b, 0x30, 0xfd, 0x86, 0x80, 0x96,
+	0xfe, 0x80, 0xc9, 0x29, 0x7c, 0x2a, 0x78, 0x50, 0x65, 0xfd, 0xc3, 0x39, 0x28, 0xf5, 0xae, 0x68,
+	0x4f, 0x85, 0x9c, 0xd2, 0x95, 0xe9, 0x17, 0x4a, 0xd6, 0x37, 0xcf, 0x26, 0x4c, 0xbf, 0xe1, 0x40,
+	0x4b, 0x7f, 0x10, 0xc4, 0x38, 0x35, 0x88, 0x3c, 0xf9, 0x66, 0xc8, 0x79, 0xc6, 0x44, 0x40, 0x4b,
+	0x7f, 0x93, 0xe3, 0x94, 0x8f, 0x14, 0x3c, 0x16, 0xb2, 0xfe, 0xe1, 0x1c, 0x94, 0xe9, 0x67, 0x8e,
+	0xa1, 0x93, 0x7f, 0xde, 0xc2, 0x28, 0x3e, 0xe6, 0x50, 0xf8, 0xa8, 0xc6, 0xfa, 0x47, 0x73, 0xd1,
+	0xea, 0x7d, 0xd2, 0x5f, 0x80, 0x38, 0xa5, 0x4f, 0x05, 0xaf, 0x54, 0xac, 0x7f, 0x38, 0x07, 0x65,
+	0xfa, 0x19, 0x0f, 0x3a, 0xf9, 0xf7, 0x05, 0xce, 0x31, 0x29, 0x8b, 0x7b, 0x54, 0xfc, 0x5c, 0x41,
+	0xff, 0x82, 0x71, 0x04, 0xed, 0xdc, 0x91, 0x03, 0xe3, 0xc3, 0xb9, 0x6f, 0x2d, 0xac, 0xdf, 0x98,
+	0x87, 0x34, 0xfd, 0xd2, 0x21, 0x40, 0x16, 0xb6, 0x36, 0x3e, 0x3a, 0x4d, 0x07, 0x14, 0xc4, 0xb5,
+	0xcf, 0xf9, 0xa1, 0x3d, 0xa8, 0xf1, 0x5d, 0x4b, 0xa3, 0x7f, 0xda, 0x47, 0xb2, 0x3b, 0x80, 0xeb,
+	0x1b, 0xa7, 0xdd, 0xa4, 0xd3, 0x6a, 0x7c, 0x06, 0x8d, 0xf4, 0xde, 0xe5, 0x29, 0xda, 0x6b, 0xf2,
+	0x5e, 0xe6, 0x5c, 0xf5, 0x3e, 0x81, 0xfa, 0x5f, 0xc6, 0x53, 0x11, 0xaf, 0xb1, 0xad, 0x3f, 0x29,
+	0x19, 0xbf, 0x86, 0x7a, 0x72, 0x2d, 0xd3, 0x78, 0xef, 0x54, 0x05, 0xa7, 0xdd, 0xfd, 0x5c, 0xbf,
+	0x7e, 0x06, 0x95, 0xce, 0x88, 0xf4, 0x12, 0xe5, 0x29, 0x8c, 0x98, 0xbc, 0x64, 0x39, 0x17, 0x23,
+	0xf6, 0x60, 0x81, 0x7d, 0x46, 0xc5, 0x1b, 0x01, 0xdd, 0x51, 0xbb, 0xde, 0x9f, 0x45, 0x92, 0xd6,
+	0xf8, 0x02, 0x8c, 0x69, 0xc7, 0xa0, 0x71, 0xf3, 0xf4, 0xb2, 0x45, 0xbe, 0xd4, 0xf5, 0x5b, 0x73,
+	0xd3, 0x27, 0x1f, 0xbe, 0xf7, 0xe9, 0x6f, 0x7e, 0x7e, 0xe8, 0xc5, 0x47, 0xe3, 0xc1, 0x4d, 0x27,
+	0x1c, 0xde, 0xfa, 0xce, 0xf3, 0x7d, 0xef, 0xbb, 0x58, 0x38, 0x47, 0xb7, 0xb8, 0xa6, 0x1f, 0x73,
+	0x1d, 0xb7, 0x9c, 0x30, 0x52, 0xff, 0x9d, 0xe7, 0x16, 0x23, 0xa3, 0xc1, 0xa0, 0x46, 0xe9, 0x8f,
+	0xff, 0xff, 0x00, 0xc4, 0x66, 0xd6, 0x05, 0xe0, 0x67, 0x00, 0x00,
 }
 
 // Reference imports to suppress errors if they are not otherwise used.
@@ -2923,52 +8267,130 @@ type MilvusBackupServiceClient interface {
 	ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error)
 	// Delete backup by given backup name
 	DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error)
+	// Pause a running backup, it stops copying data until resumed
+	PauseBackup(ctx context.Context, in *PauseBackupRequest, opts ...grpc.CallOption) (*PauseBackupResponse, error)
+	// Resume a paused or interrupted backup from its checkpoint
+	ResumeBackup(ctx context.Context, in *ResumeBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error)
+	// Delete backups expired by the retention policy
+	PruneBackups(ctx context.Context, in *PruneBackupsRequest, opts ...grpc.CallOption) (*PruneBackupsResponse, error)
+	// Remove orphaned files in backup storage not referenced by any backup
+	GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error)
+	// Check the existence, size and checksum of all files of a backup
+	VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error)
+	// Estimate the collections, segments and size a backup would contain, nothing is written
+	EstimateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*EstimateBackupResponse, error)
 	// Restore backup to milvus, return backup restore report
 	RestoreBackup(ctx context.Context, in *RestoreBackupRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
 	// Get restore state by given id
 	GetRestore(ctx context.Context, in *GetRestoreStateRequest, opts ...grpc.CallOption) (*RestoreBackupResponse, error)
+	// Get the state and progress of a backup or restore job
+	GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*JobResponse, error)
+	// Cancel a running backup or restore job
+	CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*JobResponse, error)
+	// Stream the state and progress of a backup or restore job whenever they change, until the job ends
+	WatchJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (MilvusBackupService_WatchJobClient, error)
+	// List the backup and restore jobs of this server, and of the other servers sharing the backup storage
+	ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error)
+	// Resume an interrupted, failed or canceled job from its checkpoint, like a job of a server which is gone
+	ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (*JobResponse, error)
 	// Check connections
 	Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error)
+	// Check the features used by the collections of a backup against a target milvus version before restoring it
+	CheckCompatibility(ctx context.Context, in *CheckCompatibilityRequest, opts ...grpc.CallOption) (*CheckCompatibilityResponse, error)
 }
 
 type milvusBackupServiceClient struct {
 	cc *grpc.ClientConn
 }
 
-func NewMilvusBackupServiceClient(cc *grpc.ClientConn) MilvusBackupServiceClient {
-	return &milvusBackupServiceClient{cc}
+func NewMilvusBackupServiceClient(cc *grpc.ClientConn) MilvusBackupServiceClient {
+	return &milvusBackupServiceClient{cc}
+}
+
+func (c *milvusBackupServiceClient) CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
+	out := new(BackupInfoResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CreateBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
+	out := new(BackupInfoResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
+	out := new(ListBackupsResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ListBackups", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error) {
+	out := new(DeleteBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/DeleteBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) PauseBackup(ctx context.Context, in *PauseBackupRequest, opts ...grpc.CallOption) (*PauseBackupResponse, error) {
+	out := new(PauseBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/PauseBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) ResumeBackup(ctx context.Context, in *ResumeBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
+	out := new(BackupInfoResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ResumeBackup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
 }
 
-func (c *milvusBackupServiceClient) CreateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
-	out := new(BackupInfoResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CreateBackup", in, out, opts...)
+func (c *milvusBackupServiceClient) PruneBackups(ctx context.Context, in *PruneBackupsRequest, opts ...grpc.CallOption) (*PruneBackupsResponse, error) {
+	out := new(PruneBackupsResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/PruneBackups", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) GetBackup(ctx context.Context, in *GetBackupRequest, opts ...grpc.CallOption) (*BackupInfoResponse, error) {
-	out := new(BackupInfoResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetBackup", in, out, opts...)
+func (c *milvusBackupServiceClient) GarbageCollect(ctx context.Context, in *GarbageCollectRequest, opts ...grpc.CallOption) (*GarbageCollectResponse, error) {
+	out := new(GarbageCollectResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GarbageCollect", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) ListBackups(ctx context.Context, in *ListBackupsRequest, opts ...grpc.CallOption) (*ListBackupsResponse, error) {
-	out := new(ListBackupsResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ListBackups", in, out, opts...)
+func (c *milvusBackupServiceClient) VerifyBackup(ctx context.Context, in *VerifyBackupRequest, opts ...grpc.CallOption) (*VerifyBackupResponse, error) {
+	out := new(VerifyBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/VerifyBackup", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *milvusBackupServiceClient) DeleteBackup(ctx context.Context, in *DeleteBackupRequest, opts ...grpc.CallOption) (*DeleteBackupResponse, error) {
-	out := new(DeleteBackupResponse)
-	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/DeleteBackup", in, out, opts...)
+func (c *milvusBackupServiceClient) EstimateBackup(ctx context.Context, in *CreateBackupRequest, opts ...grpc.CallOption) (*EstimateBackupResponse, error) {
+	out := new(EstimateBackupResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/EstimateBackup", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -2993,6 +8415,74 @@ func (c *milvusBackupServiceClient) GetRestore(ctx context.Context, in *GetResto
 	return out, nil
 }
 
+func (c *milvusBackupServiceClient) GetJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (*JobResponse, error) {
+	out := new(JobResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/GetJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) CancelJob(ctx context.Context, in *CancelJobRequest, opts ...grpc.CallOption) (*JobResponse, error) {
+	out := new(JobResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CancelJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) WatchJob(ctx context.Context, in *GetJobRequest, opts ...grpc.CallOption) (MilvusBackupService_WatchJobClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_MilvusBackupService_serviceDesc.Streams[0], "/milvus.proto.backup.MilvusBackupService/WatchJob", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &milvusBackupServiceWatchJobClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MilvusBackupService_WatchJobClient interface {
+	Recv() (*JobResponse, error)
+	grpc.ClientStream
+}
+
+type milvusBackupServiceWatchJobClient struct {
+	grpc.ClientStream
+}
+
+func (x *milvusBackupServiceWatchJobClient) Recv() (*JobResponse, error) {
+	m := new(JobResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *milvusBackupServiceClient) ListJobs(ctx context.Context, in *ListJobsRequest, opts ...grpc.CallOption) (*ListJobsResponse, error) {
+	out := new(ListJobsResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ListJobs", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *milvusBackupServiceClient) ResumeJob(ctx context.Context, in *ResumeJobRequest, opts ...grpc.CallOption) (*JobResponse, error) {
+	out := new(JobResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/ResumeJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *milvusBackupServiceClient) Check(ctx context.Context, in *CheckRequest, opts ...grpc.CallOption) (*CheckResponse, error) {
 	out := new(CheckResponse)
 	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/Check", in, out, opts...)
@@ -3002,6 +8492,15 @@ func (c *milvusBackupServiceClient) Check(ctx context.Context, in *CheckRequest,
 	return out, nil
 }
 
+func (c *milvusBackupServiceClient) CheckCompatibility(ctx context.Context, in *CheckCompatibilityRequest, opts ...grpc.CallOption) (*CheckCompatibilityResponse, error) {
+	out := new(CheckCompatibilityResponse)
+	err := c.cc.Invoke(ctx, "/milvus.proto.backup.MilvusBackupService/CheckCompatibility", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MilvusBackupServiceServer is the server API for MilvusBackupService service.
 type MilvusBackupServiceServer interface {
 	// Create backup
@@ -3012,12 +8511,36 @@ type MilvusBackupServiceServer interface {
 	ListBackups(context.Context, *ListBackupsRequest) (*ListBackupsResponse, error)
 	// Delete backup by given backup name
 	DeleteBackup(context.Context, *DeleteBackupRequest) (*DeleteBackupResponse, error)
+	// Pause a running backup, it stops copying data until resumed
+	PauseBackup(context.Context, *PauseBackupRequest) (*PauseBackupResponse, error)
+	// Resume a paused or interrupted backup from its checkpoint
+	ResumeBackup(context.Context, *ResumeBackupRequest) (*BackupInfoResponse, error)
+	// Delete backups expired by the retention policy
+	PruneBackups(context.Context, *PruneBackupsRequest) (*PruneBackupsResponse, error)
+	// Remove orphaned files in backup storage not referenced by any backup
+	GarbageCollect(context.Context, *GarbageCollectRequest) (*GarbageCollectResponse, error)
+	// Check the existence, size and checksum of all files of a backup
+	VerifyBackup(context.Context, *VerifyBackupRequest) (*VerifyBackupResponse, error)
+	// Estimate the collections, segments and size a backup would contain, nothing is written
+	EstimateBackup(context.Context, *CreateBackupRequest) (*EstimateBackupResponse, error)
 	// Restore backup to milvus, return backup restore report
 	RestoreBackup(context.Context, *RestoreBackupRequest) (*RestoreBackupResponse, error)
 	// Get restore state by given id
 	GetRestore(context.Context, *GetRestoreStateRequest) (*RestoreBackupResponse, error)
+	// Get the state and progress of a backup or restore job
+	GetJob(context.Context, *GetJobRequest) (*JobResponse, error)
+	// Cancel a running backup or restore job
+	CancelJob(context.Context, *CancelJobRequest) (*JobResponse, error)
+	// Stream the state and progress of a backup or restore job whenever they change, until the job ends
+	WatchJob(*GetJobRequest, MilvusBackupService_WatchJobServer) error
+	// List the backup and restore jobs of this server, and of the other servers sharing the backup storage
+	ListJobs(context.Context, *ListJobsRequest) (*ListJobsResponse, error)
+	// Resume an interrupted, failed or canceled job from its checkpoint, like a job of a server which is gone
+	ResumeJob(context.Context, *ResumeJobRequest) (*JobResponse, error)
 	// Check connections
 	Check(context.Context, *CheckRequest) (*CheckResponse, error)
+	// Check the features used by the collections of a backup against a target milvus version before restoring it
+	CheckCompatibility(context.Context, *CheckCompatibilityRequest) (*CheckCompatibilityResponse, error)
 }
 
 // UnimplementedMilvusBackupServiceServer can be embedded to have forward compatible implementations.
@@ -3036,15 +8559,51 @@ func (*UnimplementedMilvusBackupServiceServer) ListBackups(ctx context.Context,
 func (*UnimplementedMilvusBackupServiceServer) DeleteBackup(ctx context.Context, req *DeleteBackupRequest) (*DeleteBackupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method DeleteBackup not implemented")
 }
+func (*UnimplementedMilvusBackupServiceServer) PauseBackup(ctx context.Context, req *PauseBackupRequest) (*PauseBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PauseBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) ResumeBackup(ctx context.Context, req *ResumeBackupRequest) (*BackupInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) PruneBackups(ctx context.Context, req *PruneBackupsRequest) (*PruneBackupsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PruneBackups not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) GarbageCollect(ctx context.Context, req *GarbageCollectRequest) (*GarbageCollectResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GarbageCollect not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) VerifyBackup(ctx context.Context, req *VerifyBackupRequest) (*VerifyBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyBackup not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) EstimateBackup(ctx context.Context, req *CreateBackupRequest) (*EstimateBackupResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method EstimateBackup not implemented")
+}
 func (*UnimplementedMilvusBackupServiceServer) RestoreBackup(ctx context.Context, req *RestoreBackupRequest) (*RestoreBackupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method RestoreBackup not implemented")
 }
 func (*UnimplementedMilvusBackupServiceServer) GetRestore(ctx context.Context, req *GetRestoreStateRequest) (*RestoreBackupResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRestore not implemented")
 }
+func (*UnimplementedMilvusBackupServiceServer) GetJob(ctx context.Context, req *GetJobRequest) (*JobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetJob not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) CancelJob(ctx context.Context, req *CancelJobRequest) (*JobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelJob not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) WatchJob(req *GetJobRequest, srv MilvusBackupService_WatchJobServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchJob not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) ListJobs(ctx context.Context, req *ListJobsRequest) (*ListJobsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJobs not implemented")
+}
+func (*UnimplementedMilvusBackupServiceServer) ResumeJob(ctx context.Context, req *ResumeJobRequest) (*JobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ResumeJob not implemented")
+}
 func (*UnimplementedMilvusBackupServiceServer) Check(ctx context.Context, req *CheckRequest) (*CheckResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Check not implemented")
 }
+func (*UnimplementedMilvusBackupServiceServer) CheckCompatibility(ctx context.Context, req *CheckCompatibilityRequest) (*CheckCompatibilityResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckCompatibility not implemented")
+}
 
 func RegisterMilvusBackupServiceServer(s *grpc.Server, srv MilvusBackupServiceServer) {
 	s.RegisterService(&_MilvusBackupService_serviceDesc, srv)
@@ -3122,6 +8681,114 @@ func _MilvusBackupService_DeleteBackup_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MilvusBackupService_PauseBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PauseBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).PauseBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/PauseBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).PauseBackup(ctx, req.(*PauseBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_ResumeBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).ResumeBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/ResumeBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).ResumeBackup(ctx, req.(*ResumeBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_PruneBackups_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PruneBackupsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).PruneBackups(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/PruneBackups",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).PruneBackups(ctx, req.(*PruneBackupsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_GarbageCollect_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GarbageCollectRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).GarbageCollect(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/GarbageCollect",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).GarbageCollect(ctx, req.(*GarbageCollectRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_VerifyBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).VerifyBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/VerifyBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).VerifyBackup(ctx, req.(*VerifyBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_EstimateBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateBackupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).EstimateBackup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/EstimateBackup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).EstimateBackup(ctx, req.(*CreateBackupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MilvusBackupService_RestoreBackup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RestoreBackupRequest)
 	if err := dec(in); err != nil {
@@ -3158,6 +8825,99 @@ func _MilvusBackupService_GetRestore_Handler(srv interface{}, ctx context.Contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MilvusBackupService_GetJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).GetJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/GetJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).GetJob(ctx, req.(*GetJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_CancelJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).CancelJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/CancelJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).CancelJob(ctx, req.(*CancelJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_WatchJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MilvusBackupServiceServer).WatchJob(m, &milvusBackupServiceWatchJobServer{stream})
+}
+
+type MilvusBackupService_WatchJobServer interface {
+	Send(*JobResponse) error
+	grpc.ServerStream
+}
+
+type milvusBackupServiceWatchJobServer struct {
+	grpc.ServerStream
+}
+
+func (x *milvusBackupServiceWatchJobServer) Send(m *JobResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _MilvusBackupService_ListJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).ListJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/ListJobs",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).ListJobs(ctx, req.(*ListJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MilvusBackupService_ResumeJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResumeJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).ResumeJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/ResumeJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).ResumeJob(ctx, req.(*ResumeJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _MilvusBackupService_Check_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CheckRequest)
 	if err := dec(in); err != nil {
@@ -3176,6 +8936,24 @@ func _MilvusBackupService_Check_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MilvusBackupService_CheckCompatibility_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckCompatibilityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MilvusBackupServiceServer).CheckCompatibility(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/milvus.proto.backup.MilvusBackupService/CheckCompatibility",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MilvusBackupServiceServer).CheckCompatibility(ctx, req.(*CheckCompatibilityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _MilvusBackupService_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "milvus.proto.backup.MilvusBackupService",
 	HandlerType: (*MilvusBackupServiceServer)(nil),
@@ -3196,6 +8974,30 @@ var _MilvusBackupService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteBackup",
 			Handler:    _MilvusBackupService_DeleteBackup_Handler,
 		},
+		{
+			MethodName: "PauseBackup",
+			Handler:    _MilvusBackupService_PauseBackup_Handler,
+		},
+		{
+			MethodName: "ResumeBackup",
+			Handler:    _MilvusBackupService_ResumeBackup_Handler,
+		},
+		{
+			MethodName: "PruneBackups",
+			Handler:    _MilvusBackupService_PruneBackups_Handler,
+		},
+		{
+			MethodName: "GarbageCollect",
+			Handler:    _MilvusBackupService_GarbageCollect_Handler,
+		},
+		{
+			MethodName: "VerifyBackup",
+			Handler:    _MilvusBackupService_VerifyBackup_Handler,
+		},
+		{
+			MethodName: "EstimateBackup",
+			Handler:    _MilvusBackupService_EstimateBackup_Handler,
+		},
 		{
 			MethodName: "RestoreBackup",
 			Handler:    _MilvusBackupService_RestoreBackup_Handler,
@@ -3204,11 +9006,37 @@ var _MilvusBackupService_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetRestore",
 			Handler:    _MilvusBackupService_GetRestore_Handler,
 		},
+		{
+			MethodName: "GetJob",
+			Handler:    _MilvusBackupService_GetJob_Handler,
+		},
+		{
+			MethodName: "CancelJob",
+			Handler:    _MilvusBackupService_CancelJob_Handler,
+		},
+		{
+			MethodName: "ListJobs",
+			Handler:    _MilvusBackupService_ListJobs_Handler,
+		},
+		{
+			MethodName: "ResumeJob",
+			Handler:    _MilvusBackupService_ResumeJob_Handler,
+		},
 		{
 			MethodName: "Check",
 			Handler:    _MilvusBackupService_Check_Handler,
 		},
+		{
+			MethodName: "CheckCompatibility",
+			Handler:    _MilvusBackupService_CheckCompatibility_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchJob",
+			Handler:       _MilvusBackupService_WatchJob_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "backup.proto",
 }
@@ -0,0 +1,226 @@
+package aws
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	minioCred "github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/signer"
+)
+
+const (
+	// STSEndpoint is the global endpoint of aws sts, which signs requests in us-east-1
+	STSEndpoint = "https://sts.amazonaws.com"
+
+	defaultRegion      = "us-east-1"
+	defaultSessionName = "milvus-backup"
+	stsVersion         = "2011-06-15"
+
+	requestTimeout = 10 * time.Second
+	// credentials are refreshed before they expire
+	expiryWindow = time.Minute
+)
+
+// RoleOptions is the iam role to assume and how to assume it
+type RoleOptions struct {
+	RoleArn string
+	// external id required by the trust policy of the role, only for AssumeRole
+	ExternalID  string
+	SessionName string
+	// 0 means the default of sts, one hour
+	DurationSeconds int
+	// empty means the global endpoint, set the regional endpoint like https://sts.us-west-2.amazonaws.com
+	// or the endpoint of minio
+	STSEndpoint string
+	// the role is assumed by AssumeRoleWithWebIdentity with the token in the file if set, like the projected
+	// service account token of eks, otherwise by AssumeRole signed with the base credentials
+	WebIdentityTokenFile string
+}
+
+// NewCredentials returns the credentials of the assumed role, which are refreshed before they expire.
+// base signs AssumeRole, it isn't used by AssumeRoleWithWebIdentity.
+func NewCredentials(opts RoleOptions, base *minioCred.Credentials) *minioCred.Credentials {
+	if opts.STSEndpoint == "" {
+		opts.STSEndpoint = STSEndpoint
+	}
+	if opts.SessionName == "" {
+		opts.SessionName = defaultSessionName
+	}
+	client := &http.Client{Timeout: requestTimeout}
+	if opts.WebIdentityTokenFile != "" {
+		return minioCred.New(&WebIdentityProvider{RoleOptions: opts, client: client})
+	}
+	return minioCred.New(&AssumeRoleProvider{RoleOptions: opts, Base: base, client: client})
+}
+
+// AssumeRoleProvider implements "github.com/minio/minio-go/v7/pkg/credentials".Provider
+// with the credential of the role assumed by AssumeRole, signed with the base credentials.
+// The base credentials can be temporary, like the role of the ec2 instance, so roles can be chained.
+type AssumeRoleProvider struct {
+	minioCred.Expiry
+	RoleOptions
+	Base *minioCred.Credentials
+
+	client *http.Client
+}
+
+// Retrieve returns nil if it successfully retrieved the value.
+// Error is returned if the value were not obtainable, or empty.
+func (p *AssumeRoleProvider) Retrieve() (minioCred.Value, error) {
+	if p.Base == nil {
+		return minioCred.Value{}, errors.New("base credentials are required to assume role")
+	}
+	base, err := p.Base.Get()
+	if err != nil {
+		return minioCred.Value{}, errors.Wrap(err, "failed to get base credentials to assume role")
+	}
+	if base.AccessKeyID == "" || base.SecretAccessKey == "" {
+		return minioCred.Value{}, errors.New("base credentials to assume role are empty")
+	}
+
+	values := url.Values{}
+	values.Set("Action", "AssumeRole")
+	values.Set("Version", stsVersion)
+	values.Set("RoleArn", p.RoleArn)
+	values.Set("RoleSessionName", p.SessionName)
+	if p.ExternalID != "" {
+		values.Set("ExternalId", p.ExternalID)
+	}
+	if p.DurationSeconds > 0 {
+		values.Set("DurationSeconds", strconv.Itoa(p.DurationSeconds))
+	}
+	req, err := newRequest(p.STSEndpoint, values)
+	if err != nil {
+		return minioCred.Value{}, err
+	}
+	// the hash of the payload is signed though the header isn't sent by SignV4STS
+	sum := sha256.Sum256([]byte(values.Encode()))
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	// SignV4STS doesn't sign session token, it is signed as a header set before signing
+	if base.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", base.SessionToken)
+	}
+	req = signer.SignV4STS(*req, base.AccessKeyID, base.SecretAccessKey, stsRegion(p.STSEndpoint))
+
+	var result minioCred.AssumeRoleResponse
+	if err := do(p.client, req, &result); err != nil {
+		return minioCred.Value{}, errors.Wrapf(err, "failed to assume role %s", p.RoleArn)
+	}
+	creds := result.Result.Credentials
+	p.SetExpiration(creds.Expiration, expiryWindow)
+	return minioCred.Value{
+		AccessKeyID:     creds.AccessKey,
+		SecretAccessKey: creds.SecretKey,
+		SessionToken:    creds.SessionToken,
+		SignerType:      minioCred.SignatureV4,
+	}, nil
+}
+
+// WebIdentityProvider implements "github.com/minio/minio-go/v7/pkg/credentials".Provider
+// with the credential of the role assumed by AssumeRoleWithWebIdentity, like iam roles for service accounts of eks.
+// The token file is read on every refresh, as the projected token is rotated.
+type WebIdentityProvider struct {
+	minioCred.Expiry
+	RoleOptions
+
+	client *http.Client
+}
+
+// Retrieve returns nil if it successfully retrieved the value.
+// Error is returned if the value were not obtainable, or empty.
+func (p *WebIdentityProvider) Retrieve() (minioCred.Value, error) {
+	token, err := os.ReadFile(p.WebIdentityTokenFile)
+	if err != nil {
+		return minioCred.Value{}, errors.Wrap(err, "failed to read web identity token")
+	}
+
+	values := url.Values{}
+	values.Set("Action", "AssumeRoleWithWebIdentity")
+	values.Set("Version", stsVersion)
+	values.Set("RoleArn", p.RoleArn)
+	values.Set("RoleSessionName", p.SessionName)
+	values.Set("WebIdentityToken", strings.TrimSpace(string(token)))
+	if p.DurationSeconds > 0 {
+		values.Set("DurationSeconds", strconv.Itoa(p.DurationSeconds))
+	}
+	// AssumeRoleWithWebIdentity is authenticated by the token instead of a signature
+	req, err := newRequest(p.STSEndpoint, values)
+	if err != nil {
+		return minioCred.Value{}, err
+	}
+
+	var result minioCred.AssumeRoleWithWebIdentityResponse
+	if err := do(p.client, req, &result); err != nil {
+		return minioCred.Value{}, errors.Wrapf(err, "failed to assume role %s with web identity", p.RoleArn)
+	}
+	creds := result.Result.Credentials
+	p.SetExpiration(creds.Expiration, expiryWindow)
+	return minioCred.Value{
+		AccessKeyID:     creds.AccessKey,
+		SecretAccessKey: creds.SecretKey,
+		SessionToken:    creds.SessionToken,
+		SignerType:      minioCred.SignatureV4,
+	}, nil
+}
+
+func newRequest(endpoint string, values url.Values) (*http.Request, error) {
+	body := values.Encode()
+	req, err := http.NewRequest(http.MethodPost, endpoint, strings.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, nil
+}
+
+func do(client *http.Client, req *http.Request, result interface{}) error {
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var stsErr struct {
+			Error struct {
+				Code    string
+				Message string
+			}
+		}
+		if xml.Unmarshal(body, &stsErr) == nil && stsErr.Error.Code != "" {
+			return fmt.Errorf("sts responds %s: %s %s", resp.Status, stsErr.Error.Code, stsErr.Error.Message)
+		}
+		return fmt.Errorf("sts responds %s", resp.Status)
+	}
+	return xml.Unmarshal(body, result)
+}
+
+// stsRegion returns the region to sign requests to the endpoint with, regional endpoints of aws are like
+// sts.us-west-2.amazonaws.com, the global endpoint and others are signed in us-east-1
+func stsRegion(endpoint string) string {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return defaultRegion
+	}
+	labels := strings.Split(u.Hostname(), ".")
+	if len(labels) >= 4 && labels[0] == "sts" && strings.HasPrefix(strings.Join(labels[2:], "."), "amazonaws.com") {
+		return labels[1]
+	}
+	return defaultRegion
+}
@@ -0,0 +1,118 @@
+package aws
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	minioCred "github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+const assumeRoleResponse = `<AssumeRoleResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><AssumeRoleResult>
+<Credentials><AccessKeyId>id</AccessKeyId><SecretAccessKey>key</SecretAccessKey><SessionToken>token</SessionToken>
+<Expiration>%s</Expiration></Credentials></AssumeRoleResult></AssumeRoleResponse>`
+
+const webIdentityResponse = `<AssumeRoleWithWebIdentityResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/">
+<AssumeRoleWithWebIdentityResult><Credentials><AccessKeyId>id</AccessKeyId><SecretAccessKey>key</SecretAccessKey>
+<SessionToken>token</SessionToken><Expiration>%s</Expiration></Credentials></AssumeRoleWithWebIdentityResult>
+</AssumeRoleWithWebIdentityResponse>`
+
+const errorResponse = `<ErrorResponse xmlns="https://sts.amazonaws.com/doc/2011-06-15/"><Error><Type>Sender</Type>
+<Code>AccessDenied</Code><Message>not authorized to perform sts:AssumeRole</Message></Error></ErrorResponse>`
+
+func TestAssumeRoleProvider(t *testing.T) {
+	expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRole", r.PostForm.Get("Action"))
+		assert.Equal(t, "milvus-backup", r.PostForm.Get("RoleSessionName"))
+		assert.Equal(t, "3600", r.PostForm.Get("DurationSeconds"))
+		// signed by the temporary base credentials, like the role of the ec2 instance
+		assert.Contains(t, r.Header.Get("Authorization"), "Credential=base-id/")
+		assert.Contains(t, r.Header.Get("Authorization"), "/us-east-1/sts/aws4_request")
+		assert.Contains(t, r.Header.Get("Authorization"), "x-amz-security-token")
+		assert.Equal(t, "base-token", r.Header.Get("X-Amz-Security-Token"))
+		if r.PostForm.Get("ExternalId") != "external" {
+			w.WriteHeader(http.StatusForbidden)
+			fmt.Fprint(w, errorResponse)
+			return
+		}
+		fmt.Fprintf(w, assumeRoleResponse, expiration)
+	}))
+	defer server.Close()
+
+	p := &AssumeRoleProvider{
+		RoleOptions: RoleOptions{
+			RoleArn:         "arn:aws:iam::111122223333:role/backup",
+			ExternalID:      "external",
+			SessionName:     "milvus-backup",
+			DurationSeconds: 3600,
+			STSEndpoint:     server.URL,
+		},
+		Base:   minioCred.NewStaticV4("base-id", "base-key", "base-token"),
+		client: server.Client(),
+	}
+	assert.True(t, p.IsExpired())
+	value, err := p.Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "id", value.AccessKeyID)
+	assert.Equal(t, "key", value.SecretAccessKey)
+	assert.Equal(t, "token", value.SessionToken)
+	assert.False(t, p.IsExpired())
+
+	p.ExternalID = "other"
+	_, err = p.Retrieve()
+	assert.ErrorContains(t, err, "AccessDenied")
+
+	p.Base = nil
+	_, err = p.Retrieve()
+	assert.Error(t, err)
+}
+
+func TestWebIdentityProvider(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("web-identity-token\n"), 0o600))
+
+	expiration := time.Now().Add(time.Hour).UTC().Format(time.RFC3339)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.NoError(t, r.ParseForm())
+		assert.Equal(t, "AssumeRoleWithWebIdentity", r.PostForm.Get("Action"))
+		assert.Equal(t, "arn:aws:iam::111122223333:role/backup", r.PostForm.Get("RoleArn"))
+		assert.Empty(t, r.Header.Get("Authorization"))
+		if r.PostForm.Get("WebIdentityToken") != "web-identity-token" {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, errorResponse)
+			return
+		}
+		fmt.Fprintf(w, webIdentityResponse, expiration)
+	}))
+	defer server.Close()
+
+	creds := NewCredentials(RoleOptions{
+		RoleArn:              "arn:aws:iam::111122223333:role/backup",
+		STSEndpoint:          server.URL,
+		WebIdentityTokenFile: tokenFile,
+	}, nil)
+	value, err := creds.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "id", value.AccessKeyID)
+	assert.Equal(t, "token", value.SessionToken)
+
+	// the token is rotated
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("expired-token"), 0o600))
+	creds.Expire()
+	_, err = creds.Get()
+	assert.Error(t, err)
+}
+
+func TestSTSRegion(t *testing.T) {
+	assert.Equal(t, "us-east-1", stsRegion(STSEndpoint))
+	assert.Equal(t, "us-west-2", stsRegion("https://sts.us-west-2.amazonaws.com"))
+	assert.Equal(t, "cn-north-1", stsRegion("https://sts.cn-north-1.amazonaws.com.cn"))
+	assert.Equal(t, "us-east-1", stsRegion("http://minio:9000"))
+}
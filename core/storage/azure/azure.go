@@ -0,0 +1,121 @@
+package azure
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+	"github.com/cockroachdb/errors"
+
+	"github.com/zilliztech/milvus-backup/core/utils"
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const AzureDefaultEndpointSuffix = "core.windows.net"
+
+// ClientConfig contains the ways to authenticate to azure blob storage, the first configured one is used:
+//  1. ConnectionString
+//  2. SASToken, a shared access signature of the storage account
+//  3. AccountKey, a shared key of the storage account
+//  4. UseIAM, workload identity if AZURE_FEDERATED_TOKEN_FILE is set, otherwise managed identity
+//
+// The secrets can refer to env: or file:, sas token and account key are read again after RefreshInterval,
+// connection string is only read when the client is created.
+type ClientConfig struct {
+	// EndpointSuffix of the storage account, for example core.windows.net
+	EndpointSuffix   string
+	AccountName      string
+	AccountKey       string
+	ConnectionString string
+	SASToken         string
+	UseIAM           bool
+	RefreshInterval  time.Duration
+}
+
+// NewServiceClient returns a azure blob service client
+func NewServiceClient(cfg ClientConfig) (*service.Client, error) {
+	if cfg.ConnectionString != "" {
+		log.Info("create azure client with connection string")
+		connectionString, err := utils.ResolveSecret(cfg.ConnectionString)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read azure connection string")
+		}
+		return service.NewClientFromConnectionString(connectionString, &service.ClientOptions{})
+	}
+
+	if cfg.AccountName == "" {
+		return nil, errors.New("azure storage account name is required")
+	}
+	serviceURL := ServiceURL(cfg.AccountName, cfg.EndpointSuffix)
+	switch {
+	case cfg.SASToken != "":
+		log.Info("create azure client with sas token")
+		secret := utils.NewSecret(cfg.SASToken, cfg.RefreshInterval)
+		sasToken, err := secret.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read azure sas token")
+		}
+		opts := &service.ClientOptions{}
+		if utils.IsSecretRef(cfg.SASToken) {
+			sas, err := newSASPolicy(secret, sasToken)
+			if err != nil {
+				return nil, errors.Wrap(err, "failed to parse azure sas token")
+			}
+			opts.PerCallPolicies = append(opts.PerCallPolicies, sas)
+		}
+		return service.NewClientWithNoCredential(serviceURL+"?"+strings.TrimPrefix(sasToken, "?"), opts)
+	case cfg.UseIAM:
+		cred, err := NewIdentityCredential()
+		if err != nil {
+			return nil, err
+		}
+		return service.NewClient(serviceURL, cred, &service.ClientOptions{})
+	default:
+		log.Info("create azure client with account key")
+		secret := utils.NewSecret(cfg.AccountKey, cfg.RefreshInterval)
+		accountKey, err := secret.Value()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read azure account key")
+		}
+		cred, err := service.NewSharedKeyCredential(cfg.AccountName, accountKey)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create azure shared key credential")
+		}
+		opts := &service.ClientOptions{}
+		if utils.IsSecretRef(cfg.AccountKey) {
+			opts.PerCallPolicies = append(opts.PerCallPolicies, &accountKeyPolicy{cred: cred, secret: secret, key: accountKey})
+		}
+		return service.NewClientWithSharedKeyCredential(serviceURL, cred, opts)
+	}
+}
+
+// NewIdentityCredential returns a workload identity credential when running in AKS with workload identity enabled,
+// otherwise a managed identity credential, AZURE_CLIENT_ID is used to select a user-assigned identity
+func NewIdentityCredential() (azcore.TokenCredential, error) {
+	if os.Getenv("AZURE_FEDERATED_TOKEN_FILE") != "" {
+		log.Info("create azure client with workload identity")
+		return azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
+			ClientID:      os.Getenv("AZURE_CLIENT_ID"),
+			TenantID:      os.Getenv("AZURE_TENANT_ID"),
+			TokenFilePath: os.Getenv("AZURE_FEDERATED_TOKEN_FILE"),
+		})
+	}
+	log.Info("create azure client with managed identity")
+	opts := &azidentity.ManagedIdentityCredentialOptions{}
+	if clientID := os.Getenv("AZURE_CLIENT_ID"); clientID != "" {
+		opts.ID = azidentity.ClientID(clientID)
+	}
+	return azidentity.NewManagedIdentityCredential(opts)
+}
+
+// ServiceURL returns the blob service url of the storage account
+func ServiceURL(accountName, endpointSuffix string) string {
+	if endpointSuffix == "" {
+		endpointSuffix = AzureDefaultEndpointSuffix
+	}
+	return fmt.Sprintf("https://%s.blob.%s/", accountName, endpointSuffix)
+}
@@ -0,0 +1,109 @@
+package azure
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+// accountKeyPolicy replaces the key of the shared key credential before a request is signed,
+// when the key read from env or file is rotated
+type accountKeyPolicy struct {
+	cred   *service.SharedKeyCredential
+	secret *utils.Secret
+
+	mu  sync.Mutex
+	key string
+}
+
+func (p *accountKeyPolicy) Do(req *policy.Request) (*http.Response, error) {
+	key, err := p.secret.Value()
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	if key != p.key {
+		if err := p.cred.SetAccountKey(key); err != nil {
+			p.mu.Unlock()
+			return nil, err
+		}
+		p.key = key
+	}
+	p.mu.Unlock()
+	return req.Next()
+}
+
+// sasPolicy replaces the sas token in the query of every request with the one read from env or file,
+// the parameters of the token rotated out are removed
+type sasPolicy struct {
+	secret *utils.Secret
+
+	mu sync.Mutex
+	// parameters of all the tokens used
+	params map[string]bool
+}
+
+func newSASPolicy(secret *utils.Secret, initial string) (*sasPolicy, error) {
+	p := &sasPolicy{secret: secret, params: map[string]bool{}}
+	if _, err := p.token(initial); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (p *sasPolicy) Do(req *policy.Request) (*http.Response, error) {
+	value, err := p.secret.Value()
+	if err != nil {
+		return nil, err
+	}
+	token, err := p.token(value)
+	if err != nil {
+		return nil, err
+	}
+	raw := req.Raw()
+	query := raw.URL.Query()
+	p.mu.Lock()
+	for param := range p.params {
+		query.Del(param)
+	}
+	p.mu.Unlock()
+	for param, values := range token {
+		query[param] = values
+	}
+	raw.URL.RawQuery = query.Encode()
+	return req.Next()
+}
+
+// token parses the sas token and records its parameters
+func (p *sasPolicy) token(value string) (url.Values, error) {
+	token, err := url.ParseQuery(strings.TrimPrefix(value, "?"))
+	if err != nil {
+		return nil, err
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for param := range token {
+		p.params[param] = true
+	}
+	return token, nil
+}
+
+// WithSASToken returns the url with the current sas token instead of the one in its query
+func WithSASToken(rawURL string, secret *utils.Secret) (string, error) {
+	token, err := secret.Value()
+	if err != nil {
+		return "", err
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	u.RawQuery = strings.TrimPrefix(token, "?")
+	return u.String(), nil
+}
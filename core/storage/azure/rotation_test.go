@@ -0,0 +1,51 @@
+package azure
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+func TestSASPolicy(t *testing.T) {
+	var sigs []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sigs = append(sigs, r.URL.Query().Get("sig"))
+		assert.Equal(t, "properties", r.URL.Query().Get("comp"))
+		// the parameters only in the old token are removed
+		assert.Empty(t, r.URL.Query().Get("st"))
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(`<?xml version="1.0" encoding="utf-8"?><StorageServiceProperties></StorageServiceProperties>`))
+	}))
+	defer server.Close()
+
+	tokenFile := filepath.Join(t.TempDir(), "sas")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("?sv=2021-08-06&sig=old\n"), 0o600))
+	secret := utils.NewSecret("file:"+tokenFile, time.Millisecond)
+	p, err := newSASPolicy(secret, "sv=2021-08-06&st=2024-01-01&sig=old")
+	assert.NoError(t, err)
+	client, err := service.NewClientWithNoCredential(server.URL+"/?sv=2021-08-06&st=2024-01-01&sig=old",
+		&service.ClientOptions{ClientOptions: policy.ClientOptions{PerCallPolicies: []policy.Policy{p}}})
+	assert.NoError(t, err)
+
+	_, err = client.GetProperties(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("sv=2021-08-06&sig=new"), 0o600))
+	time.Sleep(2 * time.Millisecond)
+	_, err = client.GetProperties(context.Background(), nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"old", "new"}, sigs)
+
+	url, err := WithSASToken(server.URL+"/container/blob?sig=old", secret)
+	assert.NoError(t, err)
+	assert.Equal(t, server.URL+"/container/blob?sv=2021-08-06&sig=new", url)
+}
@@ -30,7 +30,6 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/sync/errgroup"
 
-	"github.com/zilliztech/milvus-backup/internal/log"
 	"github.com/zilliztech/milvus-backup/internal/util/errorutil"
 )
 
@@ -88,6 +87,11 @@ func (mcm *AzureChunkManager) Copy(ctx context.Context, fromBucketName string, t
 	return nil
 }
 
+// Rehydrate does nothing, blobs in the archive tier are not supported
+func (mcm *AzureChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error) {
+	return 0, nil
+}
+
 // Path returns the path of minio data if exists.
 func (mcm *AzureChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
 	exist, err := mcm.Exist(ctx, bucketName, filePath)
@@ -338,6 +342,21 @@ func (mcm *AzureChunkManager) ListWithPrefix(ctx context.Context, bucketName str
 	}
 }
 
+// WalkWithPrefix walks objects with provided prefix page by page.
+func (mcm *AzureChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	return walkPages(ctx, mcm, bucketName, prefix, recursive, walkFunc)
+}
+
+// ListPageWithPrefix lists a page of objects with provided prefix, the token is the marker of azure.
+func (mcm *AzureChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	paths, sizes, nextToken, err := mcm.aos.ListObjectsPage(ctx, bucketName, prefix, recursive, token, pageSize)
+	if err != nil {
+		log.Warn("failed to list with prefix", zap.String("bucket", bucketName), zap.String("prefix", prefix), zap.Error(err))
+		return nil, err
+	}
+	return &ListPage{Paths: paths, Sizes: sizes, NextToken: nextToken}, nil
+}
+
 func (mcm *AzureChunkManager) getObject(ctx context.Context, bucketName, objectName string, offset int64, size int64) (FileReader, error) {
 	//resp, err := mcm.cli.DownloadStream(ctx, bucketName, objectName, nil)
 	//if err != nil {
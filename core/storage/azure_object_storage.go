@@ -20,37 +20,52 @@ import (
 	"context"
 	"fmt"
 	"io"
-	"os"
+	"net/url"
+	"sort"
 	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/streaming"
 	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
+	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blockblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/container"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/sas"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/service"
 
+	"github.com/zilliztech/milvus-backup/core/storage/azure"
+	"github.com/zilliztech/milvus-backup/core/utils"
 	"github.com/zilliztech/milvus-backup/internal/util/retry"
 )
 
-const sasSignMinute = 60
+const (
+	sasSignMinute = 60
+	// interval to check the status of a pending copy
+	copyPollInterval = time.Second
+)
 
 type innerAzureClient struct {
 	client *service.Client
 
-	bucketName        string
-	accessKeyID       string
-	secretAccessKeyID string
-	createBucket      bool
+	bucketName string
+	// the service url already contains a sas token,
+	// which can be used to access the blob by url directly
+	useSASToken bool
+	// the sas token read again from env or file, nil if it doesn't refer to env or file
+	sasToken     *utils.Secret
+	createBucket bool
 }
 
 type AzureObjectStorage struct {
 	//Client *service.Client
 	clients map[string]*innerAzureClient
 	//config *config
+
+	blockSize        int64
+	concurrency      int
+	disableMultipart bool
 }
 
 //func NewAzureClient(ctx context.Context, cfg *config) (*azblob.Client, error) {
@@ -68,11 +83,27 @@ type AzureObjectStorage struct {
 //}
 
 func newAzureObjectStorageWithConfig(ctx context.Context, c *config) (*AzureObjectStorage, error) {
-	client, err := newAzureObjectClient(ctx, c.address, c.accessKeyID, c.secretAccessKeyID, c.bucketName, c.useIAM, c.createBucket)
+	client, err := newAzureObjectClient(ctx, azure.ClientConfig{
+		EndpointSuffix:   c.address,
+		AccountName:      c.accessKeyID,
+		AccountKey:       c.secretAccessKeyID,
+		ConnectionString: c.azureConnectionString,
+		SASToken:         c.azureSASToken,
+		UseIAM:           c.useIAM,
+		RefreshInterval:  credentialRefreshInterval(c),
+	}, c.bucketName, c.createBucket)
 	if err != nil {
 		return nil, err
 	}
-	backupClient, err := newAzureObjectClient(ctx, c.address, c.backupAccessKeyID, c.backupSecretAccessKeyID, c.backupBucketName, c.useIAM, c.createBucket)
+	backupClient, err := newAzureObjectClient(ctx, azure.ClientConfig{
+		EndpointSuffix:   c.address,
+		AccountName:      c.backupAccessKeyID,
+		AccountKey:       c.backupSecretAccessKeyID,
+		ConnectionString: c.backupAzureConnectionString,
+		SASToken:         c.backupAzureSASToken,
+		UseIAM:           c.useIAM,
+		RefreshInterval:  credentialRefreshInterval(c),
+	}, c.backupBucketName, c.createBucket)
 	if err != nil {
 		return nil, err
 	}
@@ -80,30 +111,24 @@ func newAzureObjectStorageWithConfig(ctx context.Context, c *config) (*AzureObje
 		c.bucketName:       client,
 		c.backupBucketName: backupClient,
 	}
-	return &AzureObjectStorage{
+	aos := &AzureObjectStorage{
 		clients: clients,
 		//config: c,
-	}, nil
+		blockSize:        c.multipartPartSize,
+		concurrency:      c.multipartConcurrency,
+		disableMultipart: c.disableMultipart,
+	}
+	if aos.blockSize <= 0 {
+		aos.blockSize = defaultAzureBlockSize
+	}
+	if aos.concurrency <= 0 {
+		aos.concurrency = defaultMultipartConcurrency
+	}
+	return aos, nil
 }
 
-func newAzureObjectClient(ctx context.Context, address, accessKeyID, secretAccessKeyID, bucketName string, useIAM, createBucket bool) (*innerAzureClient, error) {
-	var client *service.Client
-	var err error
-	if useIAM {
-		cred, credErr := azidentity.NewWorkloadIdentityCredential(&azidentity.WorkloadIdentityCredentialOptions{
-			ClientID:      os.Getenv("AZURE_CLIENT_ID"),
-			TenantID:      os.Getenv("AZURE_TENANT_ID"),
-			TokenFilePath: os.Getenv("AZURE_FEDERATED_TOKEN_FILE"),
-		})
-		if credErr != nil {
-			return nil, credErr
-		}
-		client, err = service.NewClient("https://"+accessKeyID+".blob."+address+"/", cred, &service.ClientOptions{})
-	} else {
-		connectionString := "DefaultEndpointsProtocol=https;AccountName=" + accessKeyID +
-			";AccountKey=" + secretAccessKeyID + ";EndpointSuffix=" + address
-		client, err = service.NewClientFromConnectionString(connectionString, &service.ClientOptions{})
-	}
+func newAzureObjectClient(ctx context.Context, cfg azure.ClientConfig, bucketName string, createBucket bool) (*innerAzureClient, error) {
+	client, err := azure.NewServiceClient(cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -131,13 +156,16 @@ func newAzureObjectClient(ctx context.Context, address, accessKeyID, secretAcces
 	if err != nil {
 		return nil, err
 	}
-	return &innerAzureClient{
-		client:            client,
-		bucketName:        bucketName,
-		accessKeyID:       accessKeyID,
-		secretAccessKeyID: secretAccessKeyID,
-		createBucket:      createBucket,
-	}, nil
+	inner := &innerAzureClient{
+		client:       client,
+		bucketName:   bucketName,
+		useSASToken:  cfg.ConnectionString == "" && cfg.SASToken != "",
+		createBucket: createBucket,
+	}
+	if inner.useSASToken && utils.IsSecretRef(cfg.SASToken) {
+		inner.sasToken = utils.NewSecret(cfg.SASToken, cfg.RefreshInterval)
+	}
+	return inner, nil
 }
 
 func (aos *AzureObjectStorage) getClient(ctx context.Context, bucketName string) *service.Client {
@@ -160,8 +188,18 @@ func (aos *AzureObjectStorage) GetObject(ctx context.Context, bucketName, object
 	return object.Body, nil
 }
 
+// PutObject uploads the object in blocks staged in parallel, the block size is enlarged for large objects
+// to fit in the 50000 blocks of a blob. A single request is used if multipart is disabled and the object fits in it.
 func (aos *AzureObjectStorage) PutObject(ctx context.Context, bucketName, objectName string, reader io.Reader, objectSize int64) error {
-	_, err := aos.clients[bucketName].client.NewContainerClient(bucketName).NewBlockBlobClient(objectName).UploadStream(ctx, reader, &azblob.UploadStreamOptions{})
+	blobClient := aos.clients[bucketName].client.NewContainerClient(bucketName).NewBlockBlobClient(objectName)
+	if seeker, ok := reader.(io.ReadSeeker); ok && aos.disableMultipart && objectSize <= blockblob.MaxUploadBlobBytes {
+		_, err := blobClient.Upload(ctx, streaming.NopCloser(seeker), nil)
+		return err
+	}
+	_, err := blobClient.UploadStream(ctx, reader, &azblob.UploadStreamOptions{
+		BlockSize:   fitPartSize(objectSize, aos.blockSize, blockblob.MaxBlocks),
+		Concurrency: aos.concurrency,
+	})
 	return err
 }
 
@@ -182,8 +220,8 @@ func (aos *AzureObjectStorage) ListObjects(ctx context.Context, bucketName strin
 	// })
 
 	objects := map[string]int64{}
-	if pager.More() {
-		pageResp, err := pager.NextPage(context.Background())
+	for pager.More() {
+		pageResp, err := pager.NextPage(ctx)
 		if err != nil {
 			return nil, err
 		}
@@ -194,25 +232,137 @@ func (aos *AzureObjectStorage) ListObjects(ctx context.Context, bucketName strin
 	return objects, nil
 }
 
+// ListObjectsPage lists a page of at most maxResults blobs with the prefix from marker, 0 means the default of azure.
+// If not recursive, blobs under sub directories are returned as the directory with a "/" suffix. It returns the
+// marker of the next page, empty if it is the last page.
+func (aos *AzureObjectStorage) ListObjectsPage(ctx context.Context, bucketName string, prefix string, recursive bool, marker string, maxResults int) ([]string, []int64, string, error) {
+	containerClient := aos.clients[bucketName].client.NewContainerClient(bucketName)
+	var markerOpt *string
+	if marker != "" {
+		markerOpt = &marker
+	}
+	var maxResultsOpt *int32
+	if maxResults > 0 {
+		value := int32(maxResults)
+		maxResultsOpt = &value
+	}
+
+	objects := map[string]int64{}
+	var nextMarker *string
+	if recursive {
+		pageResp, err := containerClient.NewListBlobsFlatPager(&container.ListBlobsFlatOptions{
+			Prefix:     &prefix,
+			Marker:     markerOpt,
+			MaxResults: maxResultsOpt,
+		}).NextPage(ctx)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		for _, blob := range pageResp.Segment.BlobItems {
+			objects[*blob.Name] = *blob.Properties.ContentLength
+		}
+		nextMarker = pageResp.NextMarker
+	} else {
+		pageResp, err := containerClient.NewListBlobsHierarchyPager("/", &container.ListBlobsHierarchyOptions{
+			Prefix:     &prefix,
+			Marker:     markerOpt,
+			MaxResults: maxResultsOpt,
+		}).NextPage(ctx)
+		if err != nil {
+			return nil, nil, "", err
+		}
+		for _, blobPrefix := range pageResp.Segment.BlobPrefixes {
+			objects[*blobPrefix.Name] = 0
+		}
+		for _, blob := range pageResp.Segment.BlobItems {
+			objects[*blob.Name] = *blob.Properties.ContentLength
+		}
+		nextMarker = pageResp.NextMarker
+	}
+
+	// blobs and directories of a page are returned apart
+	names := make([]string, 0, len(objects))
+	for name := range objects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	sizes := make([]int64, 0, len(names))
+	for _, name := range names {
+		sizes = append(sizes, objects[name])
+	}
+	if nextMarker == nil {
+		return names, sizes, "", nil
+	}
+	return names, sizes, *nextMarker, nil
+}
+
 func (aos *AzureObjectStorage) RemoveObject(ctx context.Context, bucketName, objectName string) error {
 	_, err := aos.clients[bucketName].client.NewContainerClient(bucketName).NewBlockBlobClient(objectName).Delete(ctx, &blob.DeleteOptions{})
 	return err
 }
 
 func (aos *AzureObjectStorage) CopyObject(ctx context.Context, fromBucketName, toBucketName, fromPath, toPath string) error {
-	if aos.clients[fromBucketName].accessKeyID == aos.clients[toBucketName].accessKeyID {
-		fromPathUrl := fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s", aos.clients[fromBucketName].accessKeyID, fromBucketName, fromPath)
-		_, err := aos.clients[toBucketName].client.NewContainerClient(toBucketName).NewBlockBlobClient(toPath).StartCopyFromURL(ctx, fromPathUrl, nil)
-		return err
-	} else {
+	fromClient := aos.clients[fromBucketName]
+	fromPathUrl := fromClient.client.NewContainerClient(fromBucketName).NewBlobClient(fromPath).URL()
+	if fromClient.sasToken != nil {
+		// the url of the client is still with the sas token it is created with
+		var err error
+		if fromPathUrl, err = azure.WithSASToken(fromPathUrl, fromClient.sasToken); err != nil {
+			return err
+		}
+	}
+	if !fromClient.useSASToken && !aos.isSameAccount(fromBucketName, toBucketName) {
 		srcSAS, err := aos.getSAS(fromBucketName)
 		if err != nil {
 			return err
 		}
-		fromPathUrl := fmt.Sprintf("https://%s.blob.core.windows.net/%s/%s?%s", aos.clients[fromBucketName].accessKeyID, fromBucketName, fromPath, srcSAS.Encode())
-		_, err = aos.clients[toBucketName].client.NewContainerClient(toBucketName).NewBlockBlobClient(toPath).StartCopyFromURL(ctx, fromPathUrl, nil)
+		fromPathUrl = fromPathUrl + "?" + srcSAS.Encode()
+	}
+	toClient := aos.clients[toBucketName].client.NewContainerClient(toBucketName).NewBlockBlobClient(toPath)
+	resp, err := toClient.StartCopyFromURL(ctx, fromPathUrl, nil)
+	if err != nil {
 		return err
 	}
+	return waitCopy(ctx, toClient, resp.CopyStatus)
+}
+
+// waitCopy waits until the copy to the blob ends. The copy is asynchronous, copies of large blobs,
+// especially across storage accounts, are still pending when StartCopyFromURL returns.
+func waitCopy(ctx context.Context, blobClient *blockblob.Client, status *blob.CopyStatusType) error {
+	ticker := time.NewTicker(copyPollInterval)
+	defer ticker.Stop()
+	for status != nil && *status == blob.CopyStatusTypePending {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+		props, err := blobClient.GetProperties(ctx, nil)
+		if err != nil {
+			return err
+		}
+		status = props.CopyStatus
+		if status != nil && (*status == blob.CopyStatusTypeFailed || *status == blob.CopyStatusTypeAborted) {
+			description := ""
+			if props.CopyStatusDescription != nil {
+				description = *props.CopyStatusDescription
+			}
+			return fmt.Errorf("copy to blob %s %s: %s", blobClient.URL(), *status, description)
+		}
+	}
+	return nil
+}
+
+// isSameAccount checks whether two buckets are in the same storage account
+func (aos *AzureObjectStorage) isSameAccount(bucketName, otherBucketName string) bool {
+	host := func(bucket string) string {
+		serviceURL, err := url.Parse(aos.clients[bucket].client.URL())
+		if err != nil {
+			return ""
+		}
+		return serviceURL.Host
+	}
+	return host(bucketName) == host(otherBucketName)
 }
 
 func (aos *AzureObjectStorage) getSAS(bucket string) (*sas.QueryParameters, error) {
@@ -4,18 +4,73 @@ import (
 	"context"
 
 	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/storage/aws"
 )
 
 func NewChunkManager(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
-	engine := params.MinioCfg.StorageType
-	switch engine {
-	case paramtable.Local:
-		return newLocalChunkManagerWithParams(ctx, params)
-	case paramtable.CloudProviderAzure:
-		return newAzureChunkManagerWithParams(ctx, params)
-	default:
-		return newMinioChunkManagerWithParams(ctx, params)
+	chunkManager, err := newChunkManager(ctx, params)
+	if err != nil {
+		return nil, err
 	}
+	chunkManager = NewMetricsChunkManager(chunkManager, params.MinioCfg.StorageType)
+	chunkManager = NewTracingChunkManager(chunkManager, params.MinioCfg.StorageType)
+	if limit := params.BackupCfg.BandwidthLimit; limit > 0 {
+		chunkManager = NewRateLimitedChunkManager(chunkManager, limit*1024*1024)
+	}
+	// every attempt is observed in metrics and limited by bandwidth
+	return NewRetryChunkManager(chunkManager, params.MinioCfg.StorageType, RetryPolicy{
+		MaxAttempts:             params.BackupCfg.StorageRetryAttempts,
+		InitialBackoff:          params.BackupCfg.StorageRetryInitialBackoff,
+		MaxBackoff:              params.BackupCfg.StorageRetryMaxBackoff,
+		CircuitBreakerThreshold: params.BackupCfg.StorageCircuitBreakerThreshold,
+		CircuitBreakerCooldown:  params.BackupCfg.StorageCircuitBreakerCooldown,
+	}), nil
+}
+
+// NewBackupChunkManager creates the chunk manager of backupStorage, which only accesses the backup bucket.
+// Should only be used if backupStorage is enabled, otherwise backup data is accessed by the chunk manager of milvus.
+func NewBackupChunkManager(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+	backupCfg := params.BackupStorageCfg
+	minioCfg := params.MinioCfg
+	minioCfg.StorageType = backupCfg.StorageType
+	minioCfg.Address = backupCfg.Address
+	minioCfg.Port = backupCfg.Port
+	minioCfg.AccessKeyID = backupCfg.AccessKeyID
+	minioCfg.SecretAccessKey = backupCfg.SecretAccessKey
+	minioCfg.BackupAccessKeyID = backupCfg.AccessKeyID
+	minioCfg.BackupSecretAccessKey = backupCfg.SecretAccessKey
+	minioCfg.UseSSL = backupCfg.UseSSL
+	minioCfg.UseIAM = backupCfg.UseIAM
+	minioCfg.IAMEndpoint = backupCfg.IAMEndpoint
+	minioCfg.AssumeRole = backupCfg.AssumeRole
+	minioCfg.LocalPath = backupCfg.LocalPath
+	// never touch the milvus bucket through the backup storage
+	minioCfg.BucketName = minioCfg.BackupBucketName
+	minioCfg.RootPath = minioCfg.BackupRootPath
+	minioCfg.AzureConnectionString = backupCfg.AzureConnectionString
+	minioCfg.AzureSASToken = backupCfg.AzureSASToken
+	minioCfg.BackupAzureConnectionString = backupCfg.AzureConnectionString
+	minioCfg.BackupAzureSASToken = backupCfg.AzureSASToken
+	minioCfg.GcpCredentialJSON = backupCfg.GcpCredentialJSON
+	minioCfg.GcpKmsKeyName = backupCfg.GcpKmsKeyName
+	minioCfg.GcpUniformBucketLevelAccess = backupCfg.GcpUniformBucketLevelAccess
+	minioCfg.GcpUploadChunkSize = backupCfg.GcpUploadChunkSize
+	minioCfg.HdfsUser = backupCfg.HdfsUser
+	minioCfg.HdfsDelegationToken = backupCfg.HdfsDelegationToken
+	minioCfg.SFTP = backupCfg.SFTP
+	minioCfg.MultipartPartSize = backupCfg.MultipartPartSize
+	minioCfg.MultipartConcurrency = backupCfg.MultipartConcurrency
+	minioCfg.DisableMultipart = backupCfg.DisableMultipart
+	params.MinioCfg = minioCfg
+	return NewChunkManager(ctx, params)
+}
+
+func newChunkManager(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+	factory, err := factoryOf(params.MinioCfg.StorageType)
+	if err != nil {
+		return nil, err
+	}
+	return factory(ctx, params)
 }
 
 func newMinioChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams) (*MinioChunkManager, error) {
@@ -30,10 +85,26 @@ func newMinioChunkManagerWithParams(ctx context.Context, params paramtable.Backu
 	c.storageType = params.MinioCfg.StorageType
 	c.useIAM = params.MinioCfg.UseIAM
 	c.iamEndpoint = params.MinioCfg.IAMEndpoint
+	c.assumeRole = roleOptions(params.MinioCfg.AssumeRole)
+	c.credentialRefreshInterval = params.MinioCfg.CredentialRefreshInterval
 	c.createBucket = true
+	c.multipartPartSize = params.MinioCfg.MultipartPartSize
+	c.multipartConcurrency = params.MinioCfg.MultipartConcurrency
+	c.disableMultipart = params.MinioCfg.DisableMultipart
 	return newMinioChunkManagerWithConfig(ctx, c)
 }
 
+func roleOptions(cfg paramtable.AssumeRoleConfig) aws.RoleOptions {
+	return aws.RoleOptions{
+		RoleArn:              cfg.RoleArn,
+		ExternalID:           cfg.ExternalID,
+		SessionName:          cfg.SessionName,
+		DurationSeconds:      cfg.DurationSeconds,
+		STSEndpoint:          cfg.STSEndpoint,
+		WebIdentityTokenFile: cfg.WebIdentityTokenFile,
+	}
+}
+
 func newAzureChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams) (*AzureChunkManager, error) {
 	c := newDefaultConfig()
 	c.address = params.MinioCfg.Address + ":" + params.MinioCfg.Port
@@ -53,15 +124,80 @@ func newAzureChunkManagerWithParams(ctx context.Context, params paramtable.Backu
 	c.backupBucketName = params.MinioCfg.BackupBucketName
 	c.backupRootPath = params.MinioCfg.BackupRootPath
 
+	c.azureConnectionString = params.MinioCfg.AzureConnectionString
+	c.azureSASToken = params.MinioCfg.AzureSASToken
+	c.backupAzureConnectionString = params.MinioCfg.BackupAzureConnectionString
+	c.backupAzureSASToken = params.MinioCfg.BackupAzureSASToken
+	c.credentialRefreshInterval = params.MinioCfg.CredentialRefreshInterval
+	c.multipartPartSize = params.MinioCfg.MultipartPartSize
+	c.multipartConcurrency = params.MinioCfg.MultipartConcurrency
+	c.disableMultipart = params.MinioCfg.DisableMultipart
+
 	return NewAzureChunkManager(ctx, c)
 }
 
+// useGCPHMACKeys returns whether gcs is accessed by the hmac keys of accessKeyID and secretAccessKey through its s3
+// compatible api, like the configs of milvus on gcp, instead of gcpCredentialJSON or the application default credentials
+func useGCPHMACKeys(cfg paramtable.MinioConfig) bool {
+	return !cfg.UseIAM && cfg.AccessKeyID != "" && cfg.GcpCredentialJSON == ""
+}
+
+func newGCPChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams) (*GCPChunkManager, error) {
+	c := newDefaultConfig()
+	c.address = params.MinioCfg.Address + ":" + params.MinioCfg.Port
+	c.useSSL = params.MinioCfg.UseSSL
+	c.bucketName = params.MinioCfg.BucketName
+	c.rootPath = params.MinioCfg.RootPath
+	c.storageType = params.MinioCfg.StorageType
+	c.useIAM = params.MinioCfg.UseIAM
+	c.createBucket = true
+
+	c.backupBucketName = params.MinioCfg.BackupBucketName
+	c.backupRootPath = params.MinioCfg.BackupRootPath
+
+	c.gcpCredentialJSON = params.MinioCfg.GcpCredentialJSON
+	c.gcpKmsKeyName = params.MinioCfg.GcpKmsKeyName
+	c.gcpUniformBucketLevelAccess = params.MinioCfg.GcpUniformBucketLevelAccess
+	c.gcpUploadChunkSize = params.MinioCfg.GcpUploadChunkSize
+	c.multipartPartSize = params.MinioCfg.MultipartPartSize
+	c.multipartConcurrency = params.MinioCfg.MultipartConcurrency
+	c.disableMultipart = params.MinioCfg.DisableMultipart
+
+	return NewGCPChunkManager(ctx, c)
+}
+
 func newLocalChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams) (*LocalChunkManager, error) {
 	c := newDefaultConfig()
 	c.rootPath = params.MinioCfg.RootPath
 	//c.cloudProvider = params.MinioCfg.CloudProvider
 	c.storageType = params.MinioCfg.StorageType
 	c.backupRootPath = params.MinioCfg.BackupRootPath
+	c.localPath = params.MinioCfg.LocalPath
 
 	return NewLocalChunkManager(ctx, c)
 }
+
+func newHDFSChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams) (*HDFSChunkManager, error) {
+	c := newDefaultConfig()
+	c.address = params.MinioCfg.Address + ":" + params.MinioCfg.Port
+	c.useSSL = params.MinioCfg.UseSSL
+	c.storageType = params.MinioCfg.StorageType
+	c.credentialRefreshInterval = params.MinioCfg.CredentialRefreshInterval
+	c.hdfsUser = params.MinioCfg.HdfsUser
+	c.hdfsDelegationToken = params.MinioCfg.HdfsDelegationToken
+
+	return NewHDFSChunkManager(ctx, c)
+}
+
+func newSFTPChunkManagerWithParams(ctx context.Context, params paramtable.BackupParams) (*SFTPChunkManager, error) {
+	c := newDefaultConfig()
+	c.address = params.MinioCfg.Address + ":" + params.MinioCfg.Port
+	c.storageType = params.MinioCfg.StorageType
+	c.sftpUser = params.MinioCfg.SFTP.User
+	c.sftpPrivateKeyFile = params.MinioCfg.SFTP.PrivateKeyFile
+	c.sftpPrivateKeyPassphrase = params.MinioCfg.SFTP.PrivateKeyPassphrase
+	c.sftpKnownHostsFile = params.MinioCfg.SFTP.KnownHostsFile
+	c.sftpMaxConnections = params.MinioCfg.SFTP.MaxConnections
+
+	return NewSFTPChunkManager(ctx, c)
+}
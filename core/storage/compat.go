@@ -0,0 +1,172 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/minio/minio-go/v7"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+// s3Profile is how the minio client is tweaked for an S3 compatible storage which doesn't behave exactly like S3
+type s3Profile struct {
+	// region to sign requests with, the client doesn't call GetBucketLocation if it is set
+	region       string
+	bucketLookup minio.BucketLookupType
+	// the storage only serves https
+	requireSSL bool
+	// send Content-MD5 with every upload, the payload isn't signed over https so the storage can only verify
+	// uploads by it
+	sendContentMD5 bool
+	// list page by page, continuing after the last key if a truncated page has no continuation token
+	pagedList bool
+}
+
+// s3ProfileOf returns the profile of the storage type, false if the storage is used as plain S3
+func s3ProfileOf(storageType string, address string) (s3Profile, bool) {
+	switch storageType {
+	case paramtable.CloudProviderBackblaze:
+		// endpoints of b2 are like s3.us-west-004.backblazeb2.com
+		return s3Profile{
+			region:         backblazeRegion(address),
+			bucketLookup:   minio.BucketLookupPath,
+			requireSSL:     true,
+			sendContentMD5: true,
+			pagedList:      true,
+		}, true
+	case paramtable.CloudProviderCloudflare:
+		// endpoints of r2 are like <account id>.r2.cloudflarestorage.com, all buckets are in region auto
+		return s3Profile{
+			region:         "auto",
+			bucketLookup:   minio.BucketLookupPath,
+			requireSSL:     true,
+			sendContentMD5: true,
+			pagedList:      true,
+		}, true
+	default:
+		return s3Profile{}, false
+	}
+}
+
+func backblazeRegion(address string) string {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		host = address
+	}
+	labels := strings.Split(host, ".")
+	if len(labels) >= 2 && labels[0] == "s3" && strings.HasSuffix(host, ".backblazeb2.com") {
+		return labels[1]
+	}
+	return ""
+}
+
+// validate returns an error if the config can't work with the storage of the profile
+func (p s3Profile) validate(c *config) error {
+	if p.requireSSL && !c.useSSL {
+		return fmt.Errorf("storage type %s only serves https, set useSSL to true", c.storageType)
+	}
+	if c.useIAM {
+		return fmt.Errorf("storage type %s doesn't support iam, set accessKeyID and secretAccessKey instead", c.storageType)
+	}
+	if c.assumeRole.RoleArn != "" {
+		return fmt.Errorf("storage type %s doesn't support assumeRole, set accessKeyID and secretAccessKey instead", c.storageType)
+	}
+	return nil
+}
+
+// startAfterToken starts a token continuing the listing after a key, for the pages without a continuation token
+const startAfterToken = "start-after:"
+
+// ListPageWithPrefix lists a page of the objects with the prefix by ListObjectsV2. Some S3 compatible storages return
+// truncated pages without a continuation token which the client rejects, the next page continues after the last key
+// of the page by start-after instead.
+func (mcm *MinioChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	core := minio.Core{Client: mcm.Client}
+	delimiter := "/"
+	if recursive {
+		delimiter = ""
+	}
+	var startAfter, continuationToken string
+	if strings.HasPrefix(token, startAfterToken) {
+		startAfter = strings.TrimPrefix(token, startAfterToken)
+	} else {
+		continuationToken = token
+	}
+	result, err := core.ListObjectsV2(bucketName, prefix, startAfter, continuationToken, delimiter, pageSize)
+	if err != nil {
+		// the client rejects the page, it is neither checked nor decoded
+		if !result.IsTruncated || result.NextContinuationToken != "" {
+			return nil, err
+		}
+		if result, err = decodeListResult(result); err != nil {
+			return nil, err
+		}
+	}
+	page := &ListPage{}
+	lastKey := ""
+	for _, object := range result.Contents {
+		// a prefix continued after is listed again
+		if object.Key <= startAfter {
+			continue
+		}
+		page.Paths = append(page.Paths, object.Key)
+		page.Sizes = append(page.Sizes, object.Size)
+		lastKey = maxKey(lastKey, object.Key)
+	}
+	for _, commonPrefix := range result.CommonPrefixes {
+		if commonPrefix.Prefix <= startAfter {
+			continue
+		}
+		page.Paths = append(page.Paths, commonPrefix.Prefix)
+		page.Sizes = append(page.Sizes, 0)
+		lastKey = maxKey(lastKey, commonPrefix.Prefix)
+	}
+	if !result.IsTruncated {
+		return page, nil
+	}
+	if result.NextContinuationToken != "" {
+		page.NextToken = result.NextContinuationToken
+		return page, nil
+	}
+	if lastKey == "" {
+		return nil, fmt.Errorf("list of %s is truncated without continuation token or keys", prefix)
+	}
+	page.NextToken = startAfterToken + lastKey
+	return page, nil
+}
+
+// decodeListResult decodes the keys of a page encoded by encoding-type=url
+func decodeListResult(result minio.ListBucketV2Result) (minio.ListBucketV2Result, error) {
+	if result.EncodingType != "url" {
+		return result, nil
+	}
+	for i, object := range result.Contents {
+		key, err := url.QueryUnescape(object.Key)
+		if err != nil {
+			return result, err
+		}
+		result.Contents[i].Key = key
+	}
+	for i, commonPrefix := range result.CommonPrefixes {
+		key, err := url.QueryUnescape(commonPrefix.Prefix)
+		if err != nil {
+			return result, err
+		}
+		result.CommonPrefixes[i].Prefix = key
+	}
+	return result, nil
+}
+
+func maxKey(a, b string) string {
+	if a > b {
+		return a
+	}
+	return b
+}
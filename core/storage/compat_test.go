@@ -0,0 +1,128 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+	"github.com/zilliztech/milvus-backup/core/storage/aws"
+)
+
+func TestS3ProfileOf(t *testing.T) {
+	profile, ok := s3ProfileOf(paramtable.CloudProviderBackblaze, "s3.us-west-004.backblazeb2.com:443")
+	assert.True(t, ok)
+	assert.Equal(t, "us-west-004", profile.region)
+	assert.Equal(t, minio.BucketLookupPath, profile.bucketLookup)
+	assert.True(t, profile.sendContentMD5)
+
+	profile, ok = s3ProfileOf(paramtable.CloudProviderCloudflare, "0123456789abcdef.r2.cloudflarestorage.com:443")
+	assert.True(t, ok)
+	assert.Equal(t, "auto", profile.region)
+
+	_, ok = s3ProfileOf(paramtable.CloudProviderAWS, "s3.amazonaws.com:443")
+	assert.False(t, ok)
+
+	assert.Error(t, profile.validate(&config{storageType: paramtable.CloudProviderCloudflare}))
+	assert.Error(t, profile.validate(&config{storageType: paramtable.CloudProviderCloudflare, useSSL: true, useIAM: true}))
+	assert.Error(t, profile.validate(&config{storageType: paramtable.CloudProviderCloudflare, useSSL: true,
+		assumeRole: aws.RoleOptions{RoleArn: "arn:aws:iam::111122223333:role/backup"}}))
+	assert.NoError(t, profile.validate(&config{storageType: paramtable.CloudProviderCloudflare, useSSL: true}))
+}
+
+const listV2Response = `<?xml version="1.0" encoding="UTF-8"?>
+<ListBucketResult xmlns="http://s3.amazonaws.com/doc/2006-03-01/">
+<Name>bucket</Name><Prefix>backup/</Prefix><KeyCount>%d</KeyCount><MaxKeys>1000</MaxKeys><IsTruncated>%t</IsTruncated>%s
+</ListBucketResult>`
+
+func TestListPagedTruncatedWithoutToken(t *testing.T) {
+	var startAfters []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query, _ := url.ParseQuery(r.URL.RawQuery)
+		startAfters = append(startAfters, query.Get("start-after"))
+		switch query.Get("start-after") {
+		case "":
+			// truncated but no NextContinuationToken
+			fmt.Fprintf(w, listV2Response, 2, true, "<Contents><Key>backup/a</Key><Size>1</Size></Contents><Contents><Key>backup/b</Key><Size>2</Size></Contents>")
+		case "backup/b":
+			fmt.Fprintf(w, listV2Response, 1, false, "<Contents><Key>backup/c</Key><Size>3</Size></Contents>")
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	client, err := minio.New(serverURL.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4("ak", "sk", ""),
+		Region:       "auto",
+		BucketLookup: minio.BucketLookupPath,
+	})
+	assert.NoError(t, err)
+	mcm := &MinioChunkManager{Client: client, pagedList: true}
+
+	paths, sizes, err := mcm.ListWithPrefix(context.Background(), "bucket", "backup/", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/a", "backup/b", "backup/c"}, paths)
+	assert.Equal(t, []int64{1, 2, 3}, sizes)
+	assert.Equal(t, []string{"", "backup/b"}, startAfters)
+
+	// the token of a page without continuation token continues after its last key
+	page, err := mcm.ListPageWithPrefix(context.Background(), "bucket", "backup/", true, "", 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/a", "backup/b"}, page.Paths)
+	assert.Equal(t, startAfterToken+"backup/b", page.NextToken)
+	page, err = mcm.ListPageWithPrefix(context.Background(), "bucket", "backup/", true, page.NextToken, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/c"}, page.Paths)
+	assert.Empty(t, page.NextToken)
+}
+
+// TestCompatibleStorage runs against a real b2 or r2 bucket, it is skipped unless the storage is configured by
+// COMPAT_STORAGE_TYPE, COMPAT_STORAGE_ADDRESS, COMPAT_STORAGE_ACCESS_KEY, COMPAT_STORAGE_SECRET_KEY and
+// COMPAT_STORAGE_BUCKET
+func TestCompatibleStorage(t *testing.T) {
+	storageType := os.Getenv("COMPAT_STORAGE_TYPE")
+	bucket := os.Getenv("COMPAT_STORAGE_BUCKET")
+	if storageType == "" || bucket == "" {
+		t.Skip("compatible storage is not configured")
+	}
+	ctx := context.Background()
+	mcm, err := NewMinioChunkManager(ctx,
+		Address(os.Getenv("COMPAT_STORAGE_ADDRESS")),
+		AccessKeyID(os.Getenv("COMPAT_STORAGE_ACCESS_KEY")),
+		SecretAccessKeyID(os.Getenv("COMPAT_STORAGE_SECRET_KEY")),
+		UseSSL(true),
+		BucketName(bucket),
+		func(c *config) { c.storageType = storageType },
+	)
+	assert.NoError(t, err)
+
+	prefix := fmt.Sprintf("milvus-backup-compat-test/%d/", os.Getpid())
+	defer mcm.RemoveWithPrefix(ctx, bucket, prefix)
+
+	for i := 0; i < 3; i++ {
+		assert.NoError(t, mcm.Write(ctx, bucket, fmt.Sprintf("%sfrom/dir%d/file", prefix, i), []byte("data")))
+	}
+	paths, _, err := mcm.ListWithPrefix(ctx, bucket, prefix+"from/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{prefix + "from/dir0/", prefix + "from/dir1/", prefix + "from/dir2/"}, paths)
+
+	assert.NoError(t, mcm.Copy(ctx, bucket, bucket, prefix+"from/", prefix+"to/"))
+	data, err := mcm.Read(ctx, bucket, prefix+"to/dir1/file")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	assert.NoError(t, mcm.RemoveWithPrefix(ctx, bucket, prefix))
+	paths, _, err = mcm.ListWithPrefix(ctx, bucket, prefix, true)
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+}
@@ -0,0 +1,62 @@
+package storage
+
+import (
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+// defaultCredentialRefreshInterval is how often the credentials referred by env: or file: are read again
+const defaultCredentialRefreshInterval = 5 * time.Minute
+
+// SecretCredentialProvider implements "github.com/minio/minio-go/v7/pkg/credentials".Provider
+// with the access keys of config, which are read again from env or file after the refresh interval,
+// so the keys rotated in a mounted secret are used without restarting
+type SecretCredentialProvider struct {
+	credentials.Expiry
+	accessKeyID     *utils.Secret
+	secretAccessKey *utils.Secret
+	interval        time.Duration
+}
+
+// Retrieve returns nil if it successfully retrieved the value.
+// Error is returned if the value were not obtainable, or empty.
+func (p *SecretCredentialProvider) Retrieve() (credentials.Value, error) {
+	accessKeyID, err := p.accessKeyID.Value()
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	secretAccessKey, err := p.secretAccessKey.Value()
+	if err != nil {
+		return credentials.Value{}, err
+	}
+	p.SetExpiration(time.Now().Add(p.interval), 0)
+	return credentials.Value{
+		AccessKeyID:     accessKeyID,
+		SecretAccessKey: secretAccessKey,
+		SignerType:      credentials.SignatureV4,
+	}, nil
+}
+
+// newStaticCredentials returns the credentials of the access keys of config, the keys are refreshed
+// if any of them refers to env or file
+func newStaticCredentials(c *config) *credentials.Credentials {
+	if !utils.IsSecretRef(c.accessKeyID) && !utils.IsSecretRef(c.secretAccessKeyID) {
+		return credentials.NewStaticV4(c.accessKeyID, c.secretAccessKeyID, "")
+	}
+	interval := credentialRefreshInterval(c)
+	return credentials.New(&SecretCredentialProvider{
+		accessKeyID:     utils.NewSecret(c.accessKeyID, interval),
+		secretAccessKey: utils.NewSecret(c.secretAccessKeyID, interval),
+		interval:        interval,
+	})
+}
+
+func credentialRefreshInterval(c *config) time.Duration {
+	if c.credentialRefreshInterval <= 0 {
+		return defaultCredentialRefreshInterval
+	}
+	return c.credentialRefreshInterval
+}
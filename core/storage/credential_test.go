@@ -0,0 +1,42 @@
+package storage
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaticCredentialsRotation(t *testing.T) {
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "secret-key")
+	assert.NoError(t, os.WriteFile(keyFile, []byte("sk1\n"), 0o600))
+	t.Setenv("TEST_BACKUP_ACCESS_KEY", "ak")
+
+	creds := newStaticCredentials(&config{
+		accessKeyID:               "env:TEST_BACKUP_ACCESS_KEY",
+		secretAccessKeyID:         "file:" + keyFile,
+		credentialRefreshInterval: time.Millisecond,
+	})
+	value, err := creds.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "ak", value.AccessKeyID)
+	assert.Equal(t, "sk1", value.SecretAccessKey)
+
+	// the rotated key is used after the refresh interval without creating the client again
+	assert.NoError(t, os.WriteFile(keyFile, []byte("sk2\n"), 0o600))
+	time.Sleep(2 * time.Millisecond)
+	assert.True(t, creds.IsExpired())
+	value, err = creds.Get()
+	assert.NoError(t, err)
+	assert.Equal(t, "sk2", value.SecretAccessKey)
+
+	// literal keys never expire
+	creds = newStaticCredentials(&config{accessKeyID: "minioadmin", secretAccessKeyID: "minioadmin"})
+	_, err = creds.Get()
+	assert.NoError(t, err)
+	time.Sleep(2 * time.Millisecond)
+	assert.False(t, creds.IsExpired())
+}
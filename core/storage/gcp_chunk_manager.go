@@ -0,0 +1,371 @@
+// Licensed to the LF AI & Data foundation under one
+// or more contributor license agreements. See the NOTICE file
+// distributed with this work for additional information
+// regarding copyright ownership. The ASF licenses this file
+// to you under the Apache License, Version 2.0 (the
+// "License"); you may not use this file except in compliance
+// with the License. You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	gcs "cloud.google.com/go/storage"
+	"github.com/cockroachdb/errors"
+	"go.uber.org/zap"
+	"golang.org/x/oauth2/google"
+	"golang.org/x/sync/errgroup"
+	"google.golang.org/api/iterator"
+	"google.golang.org/api/option"
+
+	"github.com/zilliztech/milvus-backup/internal/util/retry"
+)
+
+const GcsDefaultAddress = "storage.googleapis.com"
+
+// GCPChunkManager is responsible for read and write data stored in google cloud storage.
+// It is built on the native GCS client instead of the S3 compatible api,
+// supports resumable uploads, customer-managed encryption keys and uniform bucket-level access.
+type GCPChunkManager struct {
+	client *gcs.Client
+
+	// customer-managed encryption key of written objects, empty means google-managed key
+	kmsKeyName string
+	// chunk size of resumable upload, 0 means upload in a single request
+	uploadChunkSize int
+}
+
+var _ ChunkManager = (*GCPChunkManager)(nil)
+
+func NewGCPChunkManager(ctx context.Context, c *config) (*GCPChunkManager, error) {
+	var creds *google.Credentials
+	var err error
+	if c.gcpCredentialJSON != "" {
+		jsonKey, err := os.ReadFile(c.gcpCredentialJSON)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to read gcp credential json")
+		}
+		creds, err = google.CredentialsFromJSON(ctx, jsonKey, gcs.ScopeFullControl)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse gcp credential json")
+		}
+	} else {
+		// use application default credentials, including workload identity of GKE and metadata server of GCE
+		creds, err = google.FindDefaultCredentials(ctx, gcs.ScopeFullControl)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find gcp default credentials")
+		}
+	}
+
+	opts := []option.ClientOption{option.WithCredentials(creds)}
+	// custom endpoint, for example a private service connect endpoint or a gcs emulator
+	if c.address != "" && !strings.Contains(c.address, GcsDefaultAddress) {
+		scheme := "http"
+		if c.useSSL {
+			scheme = "https"
+		}
+		opts = append(opts, option.WithEndpoint(fmt.Sprintf("%s://%s/storage/v1/", scheme, c.address)))
+	}
+	client, err := gcs.NewClient(ctx, opts...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create gcs client")
+	}
+
+	for _, bucketName := range []string{c.bucketName, c.backupBucketName} {
+		if bucketName == "" {
+			continue
+		}
+		bucketName := bucketName
+		checkBucketFn := func() error {
+			_, err := client.Bucket(bucketName).Attrs(ctx)
+			if err == nil {
+				return nil
+			}
+			if !errors.Is(err, gcs.ErrBucketNotExist) {
+				log.Warn("failed to check gcs bucket exist", zap.String("bucket", bucketName), zap.Error(err))
+				return err
+			}
+			if !c.createBucket || creds.ProjectID == "" {
+				return retry.Unrecoverable(fmt.Errorf("bucket %s not Existed", bucketName))
+			}
+			log.Info("gcs bucket not exist, create bucket.", zap.String("bucket name", bucketName))
+			attrs := &gcs.BucketAttrs{
+				UniformBucketLevelAccess: gcs.UniformBucketLevelAccess{Enabled: c.gcpUniformBucketLevelAccess},
+			}
+			if c.gcpKmsKeyName != "" {
+				attrs.Encryption = &gcs.BucketEncryption{DefaultKMSKeyName: c.gcpKmsKeyName}
+			}
+			err = client.Bucket(bucketName).Create(ctx, creds.ProjectID, attrs)
+			if err != nil {
+				log.Warn("failed to create gcs bucket", zap.String("bucket", bucketName), zap.Error(err))
+				return err
+			}
+			return nil
+		}
+		err = retry.Do(ctx, checkBucketFn, retry.Attempts(CheckBucketRetryAttempts))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gcm := &GCPChunkManager{
+		client:          client,
+		kmsKeyName:      c.gcpKmsKeyName,
+		uploadChunkSize: int(c.gcpUploadChunkSize),
+	}
+	// the chunks of a resumable upload are sent one by one, multipartConcurrency doesn't apply to gcs
+	if c.multipartPartSize > 0 {
+		gcm.uploadChunkSize = int(c.multipartPartSize)
+	}
+	if c.disableMultipart {
+		gcm.uploadChunkSize = 0
+	}
+	log.Info("GCP chunk manager init success.", zap.String("bucketname", c.bucketName), zap.String("backupBucketName", c.backupBucketName))
+	return gcm, nil
+}
+
+// Path returns the path of gcs data if exists.
+func (gcm *GCPChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	exist, err := gcm.Exist(ctx, bucketName, filePath)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", errors.New("gcs file manage cannot be found with filePath:" + filePath)
+	}
+	return filePath, nil
+}
+
+func (gcm *GCPChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	attrs, err := gcm.sourceObject(ctx, bucketName, filePath).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, gcs.ErrObjectNotExist) {
+			return 0, WrapErrNoSuchKey(filePath)
+		}
+		log.Warn("failed to stat object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return 0, err
+	}
+	return attrs.Size, nil
+}
+
+// Write writes the data to gcs storage, use resumable upload if the content is larger than upload chunk size.
+func (gcm *GCPChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	object, kmsKeyName := gcm.destObject(ctx, bucketName, filePath)
+	writer := object.NewWriter(ctx)
+	writer.ChunkSize = gcm.uploadChunkSize
+	writer.KMSKeyName = kmsKeyName
+	if _, err := writer.Write(content); err != nil {
+		writer.Close()
+		log.Warn("failed to put object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		log.Warn("failed to put object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// Exist checks whether any object is stored with prefix @filePath.
+func (gcm *GCPChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	it := gcm.client.Bucket(bucketName).Objects(ctx, &gcs.Query{Prefix: filePath})
+	_, err := it.Next()
+	if err == iterator.Done {
+		return false, nil
+	}
+	if err != nil {
+		log.Warn("failed to stat object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return false, err
+	}
+	return true, nil
+}
+
+// Read reads the gcs storage data if exists.
+func (gcm *GCPChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	reader, err := gcm.sourceObject(ctx, bucketName, filePath).NewReader(ctx)
+	if err != nil {
+		if errors.Is(err, gcs.ErrObjectNotExist) {
+			return nil, WrapErrNoSuchKey(filePath)
+		}
+		log.Warn("failed to get object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		log.Warn("failed to read object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return nil, err
+	}
+	return data, nil
+}
+
+// ListWithPrefix returns objects with provided prefix.
+// If not recursive, objects under sub directories are returned as the directory with a "/" suffix.
+func (gcm *GCPChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	var objectsKeys []string
+	var sizes []int64
+	err := gcm.WalkWithPrefix(ctx, bucketName, prefix, recursive, func(filePath string, size int64) error {
+		objectsKeys = append(objectsKeys, filePath)
+		sizes = append(sizes, size)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return objectsKeys, sizes, nil
+}
+
+// WalkWithPrefix walks objects with provided prefix page by page, like ListWithPrefix.
+func (gcm *GCPChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	query := &gcs.Query{Prefix: prefix}
+	if !recursive {
+		query.Delimiter = "/"
+	}
+	it := gcm.client.Bucket(bucketName).Objects(ctx, query)
+	for {
+		attrs, err := it.Next()
+		if err == iterator.Done {
+			return nil
+		}
+		if err != nil {
+			log.Warn("failed to list with prefix", zap.String("bucket", bucketName), zap.String("prefix", prefix), zap.Error(err))
+			return err
+		}
+		if attrs.Prefix != "" {
+			// sub directory in non-recursive mode
+			err = walkFunc(attrs.Prefix, 0)
+		} else {
+			err = walkFunc(attrs.Name, attrs.Size)
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// ListPageWithPrefix lists a page of objects with provided prefix, the token is the page token of GCS.
+func (gcm *GCPChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	query := &gcs.Query{Prefix: prefix}
+	if !recursive {
+		query.Delimiter = "/"
+	}
+	var objects []*gcs.ObjectAttrs
+	nextToken, err := iterator.NewPager(gcm.client.Bucket(bucketName).Objects(ctx, query), pageSize, token).NextPage(&objects)
+	if err != nil {
+		log.Warn("failed to list with prefix", zap.String("bucket", bucketName), zap.String("prefix", prefix), zap.Error(err))
+		return nil, err
+	}
+	page := &ListPage{NextToken: nextToken}
+	for _, attrs := range objects {
+		if attrs.Prefix != "" {
+			// sub directory in non-recursive mode
+			page.Paths = append(page.Paths, attrs.Prefix)
+			page.Sizes = append(page.Sizes, 0)
+		} else {
+			page.Paths = append(page.Paths, attrs.Name)
+			page.Sizes = append(page.Sizes, attrs.Size)
+		}
+	}
+	return page, nil
+}
+
+// Remove deletes an object with @filePath.
+func (gcm *GCPChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	err := gcm.client.Bucket(bucketName).Object(filePath).Delete(ctx)
+	if err != nil && !errors.Is(err, gcs.ErrObjectNotExist) {
+		log.Warn("failed to remove object", zap.String("bucket", bucketName), zap.String("path", filePath), zap.Error(err))
+		return err
+	}
+	return nil
+}
+
+// RemoveWithPrefix removes all objects with the same prefix @prefix from gcs.
+func (gcm *GCPChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	removeKeys, _, err := gcm.ListWithPrefix(ctx, bucketName, prefix, true)
+	if err != nil {
+		return err
+	}
+	i := 0
+	maxGoroutine := 10
+	for i < len(removeKeys) {
+		runningGroup, groupCtx := errgroup.WithContext(ctx)
+		for j := 0; j < maxGoroutine && i < len(removeKeys); j++ {
+			key := removeKeys[i]
+			runningGroup.Go(func() error {
+				return gcm.Remove(groupCtx, bucketName, key)
+			})
+			i++
+		}
+		if err := runningGroup.Wait(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Copy copies objects with prefix @fromPath to @toPath by server side rewrite, no data passes through the backup tool.
+// Large objects may take several rewrite requests, which are repeated by the copier until the object is copied.
+func (gcm *GCPChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	objectKeys, _, err := gcm.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+	if err != nil {
+		log.Warn("listWithPrefix error", zap.String("prefix", fromPath), zap.Error(err))
+		return err
+	}
+	for _, objectKey := range objectKeys {
+		dstObjectKey := strings.Replace(objectKey, fromPath, toPath, 1)
+		src := gcm.sourceObject(ctx, fromBucketName, objectKey)
+		dst, kmsKeyName := gcm.destObject(ctx, toBucketName, dstObjectKey)
+		copier := dst.CopierFrom(src)
+		copier.DestinationKMSKeyName = kmsKeyName
+		if _, err := copier.Run(ctx); err != nil {
+			log.Error("copyObject error", zap.String("srcObjectKey", objectKey), zap.String("dstObjectKey", dstObjectKey), zap.Error(err))
+			return err
+		}
+	}
+	return nil
+}
+
+// destObject returns the handle of an object written with ctx and the kms key to encrypt it with,
+// the customer key of ctx is set to the handle
+func (gcm *GCPChunkManager) destObject(ctx context.Context, bucketName string, filePath string) (*gcs.ObjectHandle, string) {
+	object := gcm.client.Bucket(bucketName).Object(filePath)
+	sse, ok := sseFromContext(ctx)
+	switch {
+	case !ok:
+		return object, gcm.kmsKeyName
+	case sse.Type == SSETypeCustomer:
+		return object.Key(sse.CustomerKey), ""
+	case sse.KMSKeyID != "":
+		return object, sse.KMSKeyID
+	default:
+		return object, gcm.kmsKeyName
+	}
+}
+
+// sourceObject returns the handle of an object read with ctx, with the customer key of ctx if any
+func (gcm *GCPChunkManager) sourceObject(ctx context.Context, bucketName string, filePath string) *gcs.ObjectHandle {
+	object := gcm.client.Bucket(bucketName).Object(filePath)
+	if sse, ok := SourceServerSideEncryption(ctx); ok {
+		return object.Key(sse.CustomerKey)
+	}
+	return object
+}
+
+// Rehydrate does nothing, objects of all the storage classes of GCS are readable at once
+func (gcm *GCPChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error) {
+	return 0, nil
+}
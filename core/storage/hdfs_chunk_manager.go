@@ -0,0 +1,455 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+// HDFSChunkManager is responsible for read and write data stored in HDFS, by the WebHDFS rest api of the namenode,
+// so that no hadoop client or native library is needed. The objects of a bucket are the files in the directory
+// /<bucket name>, objects are read and written by the datanodes the namenode redirects to.
+// A kerberized cluster is accessed by a delegation token, which can refer to env: or file: to be rotated.
+type HDFSChunkManager struct {
+	client *http.Client
+	// the WebHDFS api of the namenode, like http://namenode:9870/webhdfs/v1
+	endpoint string
+	// the user of simple auth, ignored by the namenode if a delegation token is used
+	user  string
+	token *utils.Secret
+}
+
+var _ ChunkManager = (*HDFSChunkManager)(nil)
+
+// HDFSError is an error response of WebHDFS, with the java exception thrown by hdfs
+type HDFSError struct {
+	StatusCode int
+	Exception  string
+	Message    string
+}
+
+func (e *HDFSError) Error() string {
+	return fmt.Sprintf("hdfs %s: %s, status %d", e.Exception, e.Message, e.StatusCode)
+}
+
+type hdfsFileStatus struct {
+	PathSuffix string `json:"pathSuffix"`
+	Type       string `json:"type"`
+	Length     int64  `json:"length"`
+}
+
+func NewHDFSChunkManager(ctx context.Context, c *config) (*HDFSChunkManager, error) {
+	if strings.HasPrefix(c.address, ":") {
+		return nil, errors.New("address of the hdfs namenode is not set")
+	}
+	scheme := "http"
+	if c.useSSL {
+		scheme = "https"
+	}
+	return &HDFSChunkManager{
+		client: &http.Client{
+			// redirects to datanodes are followed by ourselves, a body can't be sent again by the client
+			CheckRedirect: func(req *http.Request, via []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		endpoint: scheme + "://" + c.address + "/webhdfs/v1",
+		user:     c.hdfsUser,
+		token:    utils.NewSecret(c.hdfsDelegationToken, c.credentialRefreshInterval),
+	}, nil
+}
+
+// fullPath returns the path in hdfs of a key
+func (hcm *HDFSChunkManager) fullPath(bucketName string, key string) string {
+	fullPath := "/" + strings.Trim(bucketName, "/")
+	if key != "" {
+		fullPath = strings.TrimSuffix(fullPath, "/") + "/" + key
+	}
+	if len(fullPath) > 1 {
+		fullPath = strings.TrimSuffix(fullPath, "/")
+	}
+	return fullPath
+}
+
+// url returns the url of an operation on the namenode, authenticated by the user or the delegation token
+func (hcm *HDFSChunkManager) url(fullPath string, op string, params url.Values) (string, error) {
+	if params == nil {
+		params = url.Values{}
+	}
+	params.Set("op", op)
+	if hcm.user != "" {
+		params.Set("user.name", hcm.user)
+	}
+	token, err := hcm.token.Value()
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read hdfs delegation token")
+	}
+	if token != "" {
+		params.Set("delegation", token)
+	}
+	return hcm.endpoint + (&url.URL{Path: fullPath}).EscapedPath() + "?" + params.Encode(), nil
+}
+
+// send sends a request, the response is returned if it is not an error
+func (hcm *HDFSChunkManager) send(ctx context.Context, method string, rawURL string, fullPath string, body io.Reader, size int64) (*http.Response, error) {
+	if body == nil || size == 0 {
+		body = http.NoBody
+	}
+	req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != http.NoBody {
+		req.ContentLength = size
+		req.Header.Set("Content-Type", "application/octet-stream")
+	}
+	resp, err := hcm.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < http.StatusBadRequest {
+		return resp, nil
+	}
+	defer resp.Body.Close()
+	hdfsErr := &HDFSError{StatusCode: resp.StatusCode}
+	var remote struct {
+		RemoteException struct {
+			Exception string `json:"exception"`
+			Message   string `json:"message"`
+		} `json:"RemoteException"`
+	}
+	if data, err := io.ReadAll(resp.Body); err == nil && json.Unmarshal(data, &remote) == nil {
+		hdfsErr.Exception, hdfsErr.Message = remote.RemoteException.Exception, remote.RemoteException.Message
+	}
+	if resp.StatusCode == http.StatusNotFound || hdfsErr.Exception == "FileNotFoundException" {
+		return nil, fmt.Errorf("%w: %v", WrapErrFileNotFound(fullPath), hdfsErr)
+	}
+	return nil, hdfsErr
+}
+
+// call runs an operation of the namenode and decodes its json response into out
+func (hcm *HDFSChunkManager) call(ctx context.Context, method string, fullPath string, op string, params url.Values, out interface{}) error {
+	rawURL, err := hcm.url(fullPath, op, params)
+	if err != nil {
+		return err
+	}
+	resp, err := hcm.send(ctx, method, rawURL, fullPath, nil, 0)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if out == nil {
+		return nil
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// redirect sends an operation to the namenode and returns the datanode it redirects to
+func (hcm *HDFSChunkManager) redirect(ctx context.Context, method string, fullPath string, op string, params url.Values) (*http.Response, string, error) {
+	rawURL, err := hcm.url(fullPath, op, params)
+	if err != nil {
+		return nil, "", err
+	}
+	resp, err := hcm.send(ctx, method, rawURL, fullPath, nil, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	if resp.StatusCode != http.StatusTemporaryRedirect {
+		// served by the namenode itself, like a gateway of httpfs
+		return resp, "", nil
+	}
+	resp.Body.Close()
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, "", fmt.Errorf("hdfs %s of %s is redirected to no datanode", op, fullPath)
+	}
+	return nil, location, nil
+}
+
+func (hcm *HDFSChunkManager) status(ctx context.Context, fullPath string) (*hdfsFileStatus, error) {
+	var status struct {
+		FileStatus hdfsFileStatus `json:"FileStatus"`
+	}
+	if err := hcm.call(ctx, http.MethodGet, fullPath, "GETFILESTATUS", nil, &status); err != nil {
+		return nil, err
+	}
+	return &status.FileStatus, nil
+}
+
+// Path returns the path of the file if exists.
+func (hcm *HDFSChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	exist, err := hcm.Exist(ctx, bucketName, filePath)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", WrapErrFileNotFound(filePath)
+	}
+	return filePath, nil
+}
+
+func (hcm *HDFSChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	status, err := hcm.status(ctx, hcm.fullPath(bucketName, filePath))
+	if err != nil {
+		return 0, err
+	}
+	if status.Type != "FILE" {
+		return 0, WrapErrFileNotFound(filePath)
+	}
+	return status.Length, nil
+}
+
+// Write writes the data into a temporary file renamed to filePath at last, so that a file is never read partially
+// written, an existing file is removed right before it is replaced.
+func (hcm *HDFSChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	return hcm.write(ctx, hcm.fullPath(bucketName, filePath), bytes.NewReader(content), int64(len(content)))
+}
+
+func (hcm *HDFSChunkManager) write(ctx context.Context, fullPath string, body io.Reader, size int64) error {
+	tmpPath := fullPath + ".tmp-" + strconv.FormatInt(rand.Int63(), 36)
+	// parent directories are created by hdfs
+	resp, location, err := hcm.redirect(ctx, http.MethodPut, tmpPath, "CREATE", url.Values{"overwrite": {"true"}})
+	if err != nil {
+		return err
+	}
+	if resp != nil {
+		resp.Body.Close()
+		return fmt.Errorf("hdfs create of %s is not redirected to a datanode, status %d", fullPath, resp.StatusCode)
+	}
+	resp, err = hcm.send(ctx, http.MethodPut, location, tmpPath, body, size)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+
+	if err := hcm.rename(ctx, tmpPath, fullPath); err != nil {
+		hcm.delete(ctx, tmpPath, false)
+		return err
+	}
+	return nil
+}
+
+// rename renames the file to dest, replacing the file of dest if exists
+func (hcm *HDFSChunkManager) rename(ctx context.Context, fullPath string, dest string) error {
+	for attempt := 0; attempt < 2; attempt++ {
+		var result struct {
+			Boolean bool `json:"boolean"`
+		}
+		if err := hcm.call(ctx, http.MethodPut, fullPath, "RENAME", url.Values{"destination": {dest}}, &result); err != nil {
+			return err
+		}
+		if result.Boolean {
+			return nil
+		}
+		// rename fails if dest exists
+		if _, err := hcm.delete(ctx, dest, false); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("failed to rename hdfs file %s to %s", fullPath, dest)
+}
+
+// delete deletes a file or directory, returns whether it existed
+func (hcm *HDFSChunkManager) delete(ctx context.Context, fullPath string, recursive bool) (bool, error) {
+	var result struct {
+		Boolean bool `json:"boolean"`
+	}
+	err := hcm.call(ctx, http.MethodDelete, fullPath, "DELETE", url.Values{"recursive": {strconv.FormatBool(recursive)}}, &result)
+	return result.Boolean, err
+}
+
+// Exist returns whether filePath is a file, directories are not objects.
+func (hcm *HDFSChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	status, err := hcm.status(ctx, hcm.fullPath(bucketName, filePath))
+	if err != nil {
+		if errors.Is(err, ErrNoSuchKey) {
+			return false, nil
+		}
+		return false, err
+	}
+	return status.Type == "FILE", nil
+}
+
+func (hcm *HDFSChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	reader, err := hcm.open(ctx, hcm.fullPath(bucketName, filePath))
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func (hcm *HDFSChunkManager) open(ctx context.Context, fullPath string) (io.ReadCloser, error) {
+	resp, location, err := hcm.redirect(ctx, http.MethodGet, fullPath, "OPEN", nil)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		if resp, err = hcm.send(ctx, http.MethodGet, location, fullPath, nil, 0); err != nil {
+			return nil, err
+		}
+	}
+	return resp.Body, nil
+}
+
+// listStatus lists the entries of a directory by batches, nil if the directory doesn't exist
+func (hcm *HDFSChunkManager) listStatus(ctx context.Context, fullPath string) ([]hdfsFileStatus, error) {
+	var entries []hdfsFileStatus
+	startAfter := ""
+	for {
+		params := url.Values{}
+		if startAfter != "" {
+			params.Set("startAfter", startAfter)
+		}
+		var listing struct {
+			DirectoryListing struct {
+				PartialListing struct {
+					FileStatuses struct {
+						FileStatus []hdfsFileStatus `json:"FileStatus"`
+					} `json:"FileStatuses"`
+				} `json:"partialListing"`
+				RemainingEntries int `json:"remainingEntries"`
+			} `json:"DirectoryListing"`
+		}
+		if err := hcm.call(ctx, http.MethodGet, fullPath, "LISTSTATUS_BATCH", params, &listing); err != nil {
+			if errors.Is(err, ErrNoSuchKey) {
+				return nil, nil
+			}
+			return nil, err
+		}
+		batch := listing.DirectoryListing.PartialListing.FileStatuses.FileStatus
+		for _, entry := range batch {
+			// a file lists itself without name
+			if entry.PathSuffix != "" {
+				entries = append(entries, entry)
+			}
+		}
+		if listing.DirectoryListing.RemainingEntries == 0 || len(batch) == 0 {
+			return entries, nil
+		}
+		startAfter = batch[len(batch)-1].PathSuffix
+	}
+}
+
+// listDir lists a directory of the bucket for walkDirs
+func (hcm *HDFSChunkManager) listDir(ctx context.Context, bucketName string) listDirFunc {
+	return func(dir string) ([]dirEntry, error) {
+		statuses, err := hcm.listStatus(ctx, hcm.fullPath(bucketName, dir))
+		if err != nil {
+			return nil, err
+		}
+		entries := make([]dirEntry, 0, len(statuses))
+		for _, status := range statuses {
+			entries = append(entries, dirEntry{name: status.PathSuffix, dir: status.Type == "DIRECTORY", size: status.Length})
+		}
+		return entries, nil
+	}
+}
+
+// ListWithPrefix lists the files with prefix like object storage, directories listed not recursively end with '/'
+// and their sizes are 0.
+func (hcm *HDFSChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	var filePaths []string
+	var sizes []int64
+	err := hcm.WalkWithPrefix(ctx, bucketName, prefix, recursive, func(filePath string, size int64) error {
+		filePaths = append(filePaths, filePath)
+		sizes = append(sizes, size)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, sizes, nil
+}
+
+// WalkWithPrefix walks the files with prefix like ListWithPrefix, directory by directory.
+func (hcm *HDFSChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	return walkDirs(ctx, hcm.listDir(ctx, bucketName), prefix, recursive, "", walkFunc)
+}
+
+// ListPageWithPrefix lists a page of the files with prefix in the order of their keys, the token is the last key of
+// the previous page. The directories whose files are all before the token are skipped without listing them.
+func (hcm *HDFSChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	return listDirsPage(ctx, hcm.listDir(ctx, bucketName), prefix, recursive, token, pageSize)
+}
+
+func (hcm *HDFSChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	if _, err := hcm.delete(ctx, hcm.fullPath(bucketName, filePath), true); err != nil {
+		return err
+	}
+	hcm.removeEmptyDirs(ctx, bucketName, path.Dir(filePath))
+	return nil
+}
+
+// removeEmptyDirs removes dir and its parents in the bucket if they are empty, directories are implicit in object
+// storage, a directory left empty would be listed like a backup without meta
+func (hcm *HDFSChunkManager) removeEmptyDirs(ctx context.Context, bucketName string, dir string) {
+	for dir != "." && dir != "/" && dir != "" {
+		// fails if the dir is not empty
+		if deleted, err := hcm.delete(ctx, hcm.fullPath(bucketName, dir), false); err != nil || !deleted {
+			return
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+// RemoveWithPrefix removes the files and directories with prefix, a directory is removed by one request.
+func (hcm *HDFSChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	// an empty prefix would remove the whole bucket
+	if len(prefix) == 0 {
+		errMsg := "empty prefix is not allowed for ChunkManager remove operation"
+		log.Warn(errMsg)
+		return errors.New(errMsg)
+	}
+
+	err := walkDirs(ctx, hcm.listDir(ctx, bucketName), prefix, false, "", func(filePath string, size int64) error {
+		_, err := hcm.delete(ctx, hcm.fullPath(bucketName, filePath), true)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	dir, _ := splitPrefix(prefix)
+	hcm.removeEmptyDirs(ctx, bucketName, strings.TrimSuffix(dir, "/"))
+	return nil
+}
+
+// Copy copies the files with prefix fromPath to toPath, hdfs has no copy so the data is read and written by us
+func (hcm *HDFSChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	// listed first, toPath may have the prefix of fromPath
+	filePaths, sizes, err := hcm.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+	if err != nil {
+		return err
+	}
+	for i, filePath := range filePaths {
+		if err := hcm.copyFile(ctx, hcm.fullPath(fromBucketName, filePath),
+			hcm.fullPath(toBucketName, strings.Replace(filePath, fromPath, toPath, 1)), sizes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (hcm *HDFSChunkManager) copyFile(ctx context.Context, from string, to string, size int64) error {
+	reader, err := hcm.open(ctx, from)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	return hcm.write(ctx, to, reader, size)
+}
+
+// Rehydrate does nothing, hdfs files are never archived
+func (hcm *HDFSChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error) {
+	return 0, nil
+}
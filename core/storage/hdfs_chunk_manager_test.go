@@ -0,0 +1,237 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeWebHDFS serves the WebHDFS operations used by HDFSChunkManager on files in memory, with directories implied by
+// the paths of files, and the datanode on the same server
+type fakeWebHDFS struct {
+	t  *testing.T
+	mu sync.Mutex
+	// path in hdfs -> content
+	files     map[string][]byte
+	batchSize int
+	server    *httptest.Server
+}
+
+func newFakeWebHDFS(t *testing.T) *fakeWebHDFS {
+	fake := &fakeWebHDFS{t: t, files: make(map[string][]byte), batchSize: 2}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serve))
+	t.Cleanup(fake.server.Close)
+	return fake
+}
+
+func (f *fakeWebHDFS) isDir(fullPath string) bool {
+	for filePath := range f.files {
+		if strings.HasPrefix(filePath, strings.TrimSuffix(fullPath, "/")+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeWebHDFS) remoteException(w http.ResponseWriter, status int, exception string) {
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]interface{}{"RemoteException": map[string]string{"exception": exception, "message": exception}})
+}
+
+func (f *fakeWebHDFS) serve(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	fullPath := strings.TrimPrefix(r.URL.Path, "/webhdfs/v1")
+	query := r.URL.Query()
+	if query.Get("datanode") == "" {
+		assert.Equal(f.t, "milvus", query.Get("user.name"))
+		assert.Equal(f.t, "token", query.Get("delegation"))
+	}
+	switch query.Get("op") {
+	case "CREATE":
+		if query.Get("datanode") == "" {
+			w.Header().Set("Location", f.server.URL+r.URL.Path+"?op=CREATE&datanode=true")
+			w.WriteHeader(http.StatusTemporaryRedirect)
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		f.files[fullPath] = data
+		w.WriteHeader(http.StatusCreated)
+	case "OPEN":
+		data, ok := f.files[fullPath]
+		if !ok {
+			f.remoteException(w, http.StatusNotFound, "FileNotFoundException")
+			return
+		}
+		if query.Get("datanode") == "" {
+			w.Header().Set("Location", f.server.URL+r.URL.Path+"?op=OPEN&datanode=true")
+			w.WriteHeader(http.StatusTemporaryRedirect)
+			return
+		}
+		w.Write(data)
+	case "GETFILESTATUS":
+		if data, ok := f.files[fullPath]; ok {
+			json.NewEncoder(w).Encode(map[string]interface{}{"FileStatus": hdfsFileStatus{Type: "FILE", Length: int64(len(data))}})
+		} else if f.isDir(fullPath) {
+			json.NewEncoder(w).Encode(map[string]interface{}{"FileStatus": hdfsFileStatus{Type: "DIRECTORY"}})
+		} else {
+			f.remoteException(w, http.StatusNotFound, "FileNotFoundException")
+		}
+	case "LISTSTATUS_BATCH":
+		if !f.isDir(fullPath) {
+			f.remoteException(w, http.StatusNotFound, "FileNotFoundException")
+			return
+		}
+		children := make(map[string]hdfsFileStatus)
+		for filePath, data := range f.files {
+			name, rest, ok := strings.Cut(strings.TrimPrefix(filePath, strings.TrimSuffix(fullPath, "/")+"/"), "/")
+			if !strings.HasPrefix(filePath, strings.TrimSuffix(fullPath, "/")+"/") || name <= query.Get("startAfter") {
+				continue
+			}
+			if ok && rest != "" {
+				children[name] = hdfsFileStatus{PathSuffix: name, Type: "DIRECTORY"}
+			} else {
+				children[name] = hdfsFileStatus{PathSuffix: name, Type: "FILE", Length: int64(len(data))}
+			}
+		}
+		names := make([]string, 0, len(children))
+		for name := range children {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		batch := make([]hdfsFileStatus, 0)
+		for _, name := range names {
+			if len(batch) < f.batchSize {
+				batch = append(batch, children[name])
+			}
+		}
+		listing := map[string]interface{}{
+			"partialListing":   map[string]interface{}{"FileStatuses": map[string]interface{}{"FileStatus": batch}},
+			"remainingEntries": len(names) - len(batch),
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"DirectoryListing": listing})
+	case "RENAME":
+		dest := query.Get("destination")
+		data, ok := f.files[fullPath]
+		_, exist := f.files[dest]
+		if ok && !exist {
+			f.files[dest] = data
+			delete(f.files, fullPath)
+		}
+		json.NewEncoder(w).Encode(map[string]bool{"boolean": ok && !exist})
+	case "DELETE":
+		_, deleted := f.files[fullPath]
+		delete(f.files, fullPath)
+		if f.isDir(fullPath) {
+			if query.Get("recursive") != "true" {
+				f.remoteException(w, http.StatusForbidden, "PathIsNotEmptyDirectoryException")
+				return
+			}
+			for filePath := range f.files {
+				if strings.HasPrefix(filePath, fullPath+"/") {
+					delete(f.files, filePath)
+				}
+			}
+			deleted = true
+		}
+		json.NewEncoder(w).Encode(map[string]bool{"boolean": deleted})
+	default:
+		f.remoteException(w, http.StatusBadRequest, "UnsupportedOperationException")
+	}
+}
+
+func TestHDFSChunkManager(t *testing.T) {
+	ctx := context.Background()
+	fake := newFakeWebHDFS(t)
+	c := newDefaultConfig()
+	c.address = strings.TrimPrefix(fake.server.URL, "http://")
+	c.hdfsUser = "milvus"
+	c.hdfsDelegationToken = "token"
+	hcm, err := NewHDFSChunkManager(ctx, c)
+	assert.NoError(t, err)
+
+	for _, key := range []string{"a.txt", "a/b/1", "a/b/2", "a/c", "a/d/e/3", "z"} {
+		assert.NoError(t, hcm.Write(ctx, "backup", key, []byte("data of "+key)))
+	}
+	assert.Contains(t, fake.files, "/backup/a/b/1")
+
+	data, err := hcm.Read(ctx, "backup", "a/b/1")
+	assert.NoError(t, err)
+	assert.Equal(t, "data of a/b/1", string(data))
+	_, err = hcm.Read(ctx, "backup", "a/b/3")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	size, err := hcm.Size(ctx, "backup", "a/c")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len("data of a/c")), size)
+	exist, err := hcm.Exist(ctx, "backup", "a/b")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	exist, err = hcm.Exist(ctx, "backup", "a/b/2")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	// an existing file is replaced
+	assert.NoError(t, hcm.Write(ctx, "backup", "a.txt", []byte("new")))
+	data, err = hcm.Read(ctx, "backup", "a.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	// in the order of keys like object storage, not the order of names in directories
+	paths, sizes, err := hcm.ListWithPrefix(ctx, "backup", "a", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "a/b/1", "a/b/2", "a/c", "a/d/e/3"}, paths)
+	assert.Equal(t, int64(3), sizes[0])
+	paths, _, err = hcm.ListWithPrefix(ctx, "backup", "a/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/b/", "a/c", "a/d/"}, paths)
+	paths, _, err = hcm.ListWithPrefix(ctx, "backup", "missing/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+
+	page, err := hcm.ListPageWithPrefix(ctx, "backup", "a", true, "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "a/b/1"}, page.Paths)
+	assert.Equal(t, "a/b/1", page.NextToken)
+	page, err = hcm.ListPageWithPrefix(ctx, "backup", "a", true, "a/c", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/d/e/3"}, page.Paths)
+	assert.Empty(t, page.NextToken)
+	it := NewListIterator(ctx, hcm, "backup", "", true, "", 2)
+	paths = paths[:0]
+	for it.Next() {
+		paths = append(paths, it.Path())
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []string{"a.txt", "a/b/1", "a/b/2", "a/c", "a/d/e/3", "z"}, paths)
+
+	assert.NoError(t, hcm.Copy(ctx, "backup", "copy", "a/b/", "b/"))
+	data, err = hcm.Read(ctx, "copy", "b/2")
+	assert.NoError(t, err)
+	assert.Equal(t, "data of a/b/2", string(data))
+
+	// directories left empty are removed
+	assert.NoError(t, hcm.Remove(ctx, "backup", "a/d/e/3"))
+	paths, _, err = hcm.ListWithPrefix(ctx, "backup", "a/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/b/", "a/c"}, paths)
+
+	assert.Error(t, hcm.RemoveWithPrefix(ctx, "backup", ""))
+	assert.NoError(t, hcm.RemoveWithPrefix(ctx, "backup", "a/"))
+	paths, _, err = hcm.ListWithPrefix(ctx, "backup", "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "z"}, paths)
+}
+
+func TestHDFSError(t *testing.T) {
+	assert.True(t, IsRetryableError(&HDFSError{StatusCode: http.StatusForbidden, Exception: "RetriableException"}))
+	assert.True(t, IsRetryableError(&HDFSError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, IsRetryableError(&HDFSError{StatusCode: http.StatusForbidden, Exception: "AccessControlException"}))
+}
@@ -0,0 +1,198 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// DefaultListPageSize is the page size of ListIterator if not set, the max keys of a page of S3
+const DefaultListPageSize = 1000
+
+// ListIterator iterates the files with a prefix page by page, only a page is held in memory. A listing interrupted
+// can be resumed by a new iterator from Token.
+//
+//	it := NewListIterator(ctx, chunkManager, bucketName, prefix, true, "", 0)
+//	for it.Next() {
+//		fmt.Println(it.Path(), it.Size())
+//	}
+//	if err := it.Err(); err != nil {
+//		return err
+//	}
+type ListIterator struct {
+	ctx          context.Context
+	chunkManager ChunkManager
+	bucketName   string
+	prefix       string
+	recursive    bool
+	pageSize     int
+
+	// token of the current page and of the next one
+	token     string
+	nextToken string
+	page      *ListPage
+	index     int
+	err       error
+}
+
+// NewListIterator creates an iterator listing from token, empty to list from the start
+func NewListIterator(ctx context.Context, chunkManager ChunkManager, bucketName string, prefix string, recursive bool, token string, pageSize int) *ListIterator {
+	if pageSize <= 0 {
+		pageSize = DefaultListPageSize
+	}
+	return &ListIterator{
+		ctx:          ctx,
+		chunkManager: chunkManager,
+		bucketName:   bucketName,
+		prefix:       prefix,
+		recursive:    recursive,
+		pageSize:     pageSize,
+		nextToken:    token,
+		index:        -1,
+	}
+}
+
+// Next moves to the next file, the next page is listed after the files of the current page. It returns false when
+// all files are listed or the listing fails, see Err.
+func (it *ListIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.page == nil || it.index >= len(it.page.Paths) {
+		if it.page != nil && it.nextToken == "" {
+			return false
+		}
+		page, err := it.chunkManager.ListPageWithPrefix(it.ctx, it.bucketName, it.prefix, it.recursive, it.nextToken, it.pageSize)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.token = it.nextToken
+		it.nextToken = page.NextToken
+		it.page = page
+		it.index = 0
+	}
+	return true
+}
+
+// Path returns the path of the current file
+func (it *ListIterator) Path() string {
+	return it.page.Paths[it.index]
+}
+
+// Size returns the size of the current file, 0 for a directory listed not recursively
+func (it *ListIterator) Size() int64 {
+	return it.page.Sizes[it.index]
+}
+
+// Token returns the token of the page of the current file, an iterator created from it lists the page again
+// and the files after it
+func (it *ListIterator) Token() string {
+	return it.token
+}
+
+// Err returns the error with which the listing fails
+func (it *ListIterator) Err() error {
+	return it.err
+}
+
+// walkPages walks the files with prefix by ListPageWithPrefix, for the storages without a listing of their own
+func walkPages(ctx context.Context, chunkManager ChunkManager, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	it := NewListIterator(ctx, chunkManager, bucketName, prefix, recursive, "", 0)
+	for it.Next() {
+		if err := walkFunc(it.Path(), it.Size()); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
+
+// dirEntry is an entry of a directory of a storage with directories, like hdfs or sftp
+type dirEntry struct {
+	name string
+	dir  bool
+	size int64
+}
+
+// listDirFunc lists the entries of a directory by its key ending with '/', or empty for the bucket,
+// nil if the directory doesn't exist
+type listDirFunc func(dir string) ([]dirEntry, error)
+
+// splitPrefix splits a prefix into its directory and the prefix of the names in the directory
+func splitPrefix(prefix string) (string, string) {
+	i := strings.LastIndex(prefix, "/")
+	return prefix[:i+1], prefix[i+1:]
+}
+
+// walkDirs walks the files with prefix of a storage with directories, like ListWithPrefix of object storage, in
+// the order of keys and only those after the key after. Directories listed not recursively end with '/', and the
+// directories whose files are all before after are skipped without listing them.
+func walkDirs(ctx context.Context, listDir listDirFunc, prefix string, recursive bool, after string, walkFunc WalkFunc) error {
+	dir, base := splitPrefix(prefix)
+	return walkDir(ctx, listDir, dir, base, recursive, after, walkFunc)
+}
+
+func walkDir(ctx context.Context, listDir listDirFunc, dir string, base string, recursive bool, after string, walkFunc WalkFunc) error {
+	entries, err := listDir(dir)
+	if err != nil {
+		return err
+	}
+	children := make([]dirEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !strings.HasPrefix(entry.name, base) {
+			continue
+		}
+		entry.name = dir + entry.name
+		if entry.dir {
+			entry.name += "/"
+		}
+		children = append(children, entry)
+	}
+	// directories are ordered by their keys ending with '/', not by their names
+	sort.Slice(children, func(i, j int) bool { return children[i].name < children[j].name })
+
+	for _, child := range children {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		switch {
+		case !child.dir || !recursive:
+			if child.name > after {
+				err = walkFunc(child.name, child.size)
+			}
+		// all keys in the directory are before after
+		case child.name < after && !strings.HasPrefix(after, child.name):
+		default:
+			err = walkDir(ctx, listDir, child.name, "", true, after, walkFunc)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listDirsPage lists a page of the files with prefix of a storage with directories by walkDirs, the token is the
+// last key of the previous page.
+func listDirsPage(ctx context.Context, listDir listDirFunc, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	if pageSize <= 0 {
+		pageSize = DefaultListPageSize
+	}
+	page := &ListPage{}
+	errPageFull := errors.New("page is full")
+	err := walkDirs(ctx, listDir, prefix, recursive, token, func(filePath string, size int64) error {
+		if len(page.Paths) == pageSize {
+			page.NextToken = page.Paths[len(page.Paths)-1]
+			return errPageFull
+		}
+		page.Paths = append(page.Paths, filePath)
+		page.Sizes = append(page.Sizes, size)
+		return nil
+	})
+	if err != nil && !errors.Is(err, errPageFull) {
+		return nil, err
+	}
+	return page, nil
+}
@@ -0,0 +1,86 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedChunkManager pages keys by the index of the first key of the page, and fails the page of failAt
+type pagedChunkManager struct {
+	ChunkManager
+	keys   []string
+	failAt string
+	pages  int
+}
+
+func (p *pagedChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	p.pages++
+	if token != "" && token == p.failAt {
+		return nil, errors.New("list fails")
+	}
+	start := 0
+	if token != "" {
+		start, _ = strconv.Atoi(token)
+	}
+	page := &ListPage{}
+	end := start + pageSize
+	if end < len(p.keys) {
+		page.NextToken = strconv.Itoa(end)
+	} else {
+		end = len(p.keys)
+	}
+	for _, key := range p.keys[start:end] {
+		page.Paths = append(page.Paths, key)
+		page.Sizes = append(page.Sizes, int64(len(key)))
+	}
+	return page, nil
+}
+
+func TestListIterator(t *testing.T) {
+	ctx := context.Background()
+	cm := &pagedChunkManager{keys: []string{"a", "bb", "ccc", "dddd", "eeeee"}}
+	it := NewListIterator(ctx, cm, "bucket", "", true, "", 2)
+	paths := make([]string, 0)
+	tokens := make([]string, 0)
+	for it.Next() {
+		paths = append(paths, it.Path())
+		tokens = append(tokens, it.Token())
+		assert.Equal(t, int64(len(it.Path())), it.Size())
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, cm.keys, paths)
+	assert.Equal(t, []string{"", "", "2", "2", "4"}, tokens)
+	assert.Equal(t, 3, cm.pages)
+
+	// resumed from the token of a page
+	it = NewListIterator(ctx, cm, "bucket", "", true, "2", 2)
+	assert.True(t, it.Next())
+	assert.Equal(t, "ccc", it.Path())
+
+	// the listing stops at the page failed
+	cm = &pagedChunkManager{keys: cm.keys, failAt: "4"}
+	it = NewListIterator(ctx, cm, "bucket", "", true, "", 2)
+	paths = make([]string, 0)
+	for it.Next() {
+		paths = append(paths, it.Path())
+	}
+	assert.EqualError(t, it.Err(), "list fails")
+	assert.Equal(t, []string{"a", "bb", "ccc", "dddd"}, paths)
+	assert.False(t, it.Next())
+
+	// the walk stops with the error of walkFunc
+	stop := errors.New("stop")
+	err := walkPages(ctx, cm, "bucket", "", true, func(filePath string, size int64) error {
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+
+	// empty listing
+	it = NewListIterator(ctx, &pagedChunkManager{}, "bucket", "", true, "", 0)
+	assert.False(t, it.Next())
+	assert.NoError(t, it.Err())
+}
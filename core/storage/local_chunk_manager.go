@@ -24,11 +24,10 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/cockroachdb/errors"
-
-	"github.com/zilliztech/milvus-backup/internal/log"
 )
 
 func WrapErrFileNotFound(key string) error {
@@ -36,18 +35,33 @@ func WrapErrFileNotFound(key string) error {
 }
 
 // LocalChunkManager is responsible for read and write local file.
+// Files are stored in localPath as the object keys, like a mounted nfs or smb path for backup data,
+// paths are relative to the working dir if localPath is empty. The bucket name is ignored.
 type LocalChunkManager struct {
 	rootPath       string
 	backupRootPath string
+	localPath      string
 }
 
 var _ ChunkManager = (*LocalChunkManager)(nil)
 
 // NewLocalChunkManager create a new local manager object.
+// localPath must exist, an unmounted path is not silently replaced by the local disk.
 func NewLocalChunkManager(ctx context.Context, c *config) (*LocalChunkManager, error) {
+	localPath := strings.TrimRight(c.localPath, "/")
+	if localPath != "" {
+		info, err := os.Stat(localPath)
+		if err != nil {
+			return nil, fmt.Errorf("local path %s is not accessible: %w", localPath, err)
+		}
+		if !info.IsDir() {
+			return nil, fmt.Errorf("local path %s is not a directory", localPath)
+		}
+	}
 	return &LocalChunkManager{
 		rootPath:       c.rootPath,
 		backupRootPath: c.backupRootPath,
+		localPath:      localPath,
 	}, nil
 }
 
@@ -56,6 +70,22 @@ func (lcm *LocalChunkManager) RootPath() string {
 	return lcm.rootPath
 }
 
+// fullPath returns the path in file system of a key
+func (lcm *LocalChunkManager) fullPath(key string) string {
+	if lcm.localPath == "" {
+		return key
+	}
+	return lcm.localPath + "/" + key
+}
+
+// key returns the key of a path in file system
+func (lcm *LocalChunkManager) key(fullPath string) string {
+	if lcm.localPath == "" {
+		return fullPath
+	}
+	return strings.TrimPrefix(fullPath, lcm.localPath+"/")
+}
+
 // Path returns the path of local data if exists.
 func (lcm *LocalChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
 	exist, err := lcm.Exist(ctx, bucketName, filePath)
@@ -70,25 +100,34 @@ func (lcm *LocalChunkManager) Path(ctx context.Context, bucketName string, fileP
 	return filePath, nil
 }
 
-// Write writes the data to local storage.
+// Write writes the data to local storage. The data is written into a temporary file renamed to filePath at last,
+// so that a file is never read partially written, like meta read while being written by another process on nfs.
 func (lcm *LocalChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
-	dir := path.Dir(filePath)
-	exist, err := lcm.Exist(ctx, bucketName, dir)
+	fullPath := lcm.fullPath(filePath)
+	if err := os.MkdirAll(path.Dir(fullPath), os.ModePerm); err != nil {
+		return err
+	}
+	tmpFile, err := os.CreateTemp(path.Dir(fullPath), path.Base(fullPath)+".tmp-*")
 	if err != nil {
 		return err
 	}
-	if !exist {
-		err := os.MkdirAll(dir, os.ModePerm)
-		if err != nil {
-			return WrapErrFileNotFound(filePath)
-		}
+	defer os.Remove(tmpFile.Name())
+	if _, err := tmpFile.Write(content); err != nil {
+		tmpFile.Close()
+		return err
 	}
-	return WriteFile(filePath, content, os.ModePerm)
+	if err := tmpFile.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmpFile.Name(), os.ModePerm); err != nil {
+		return err
+	}
+	return os.Rename(tmpFile.Name(), fullPath)
 }
 
 // Exist checks whether chunk is saved to local storage.
 func (lcm *LocalChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
-	_, err := os.Stat(filePath)
+	_, err := os.Stat(lcm.fullPath(filePath))
 	if err != nil {
 		if os.IsNotExist(err) {
 			return false, nil
@@ -100,55 +139,102 @@ func (lcm *LocalChunkManager) Exist(ctx context.Context, bucketName string, file
 
 // Read reads the local storage data if exists.
 func (lcm *LocalChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
-	return ReadFile(filePath)
+	data, err := os.ReadFile(lcm.fullPath(filePath))
+	if os.IsNotExist(err) {
+		return nil, WrapErrFileNotFound(filePath)
+	} else if err != nil {
+		return nil, err
+	}
+	return data, nil
 }
 
+// ListWithPrefix lists the files with prefix like object storage, directories listed not recursively end with '/'
+// and their sizes are 0.
 func (lcm *LocalChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
 	var filePaths []string
 	var sizes []int64
+	err := lcm.WalkWithPrefix(ctx, bucketName, prefix, recursive, func(filePath string, size int64) error {
+		filePaths = append(filePaths, filePath)
+		sizes = append(sizes, size)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, sizes, nil
+}
+
+// WalkWithPrefix walks the files with prefix like ListWithPrefix, the files are walked recursively without listing
+// them all first.
+func (lcm *LocalChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	fullPrefix := lcm.fullPath(prefix)
 	if recursive {
-		dir := filepath.Dir(prefix)
-		err := filepath.Walk(dir, func(filePath string, f os.FileInfo, err error) error {
-			if strings.HasPrefix(filePath, prefix) && !f.IsDir() {
-				filePaths = append(filePaths, filePath)
+		dir := filepath.Dir(fullPrefix)
+		return filepath.Walk(dir, func(filePath string, f os.FileInfo, err error) error {
+			if err != nil {
+				if os.IsNotExist(err) {
+					return nil
+				}
+				return err
+			}
+			if strings.HasPrefix(filePath, fullPrefix) && !f.IsDir() {
+				return walkFunc(lcm.key(filePath), f.Size())
 			}
 			return nil
 		})
+	}
+
+	globPaths, err := filepath.Glob(fullPrefix + "*")
+	if err != nil {
+		return err
+	}
+	for _, globPath := range globPaths {
+		f, err := os.Stat(globPath)
 		if err != nil {
-			return nil, nil, err
+			return WrapErrFileNotFound(lcm.key(globPath))
 		}
-		for _, filePath := range filePaths {
-			size, err := lcm.Size(ctx, bucketName, filePath)
-			if err != nil {
-				return filePaths, nil, err
-			}
-			sizes = append(sizes, size)
+		if f.IsDir() {
+			err = walkFunc(lcm.key(globPath)+"/", 0)
+		} else {
+			err = walkFunc(lcm.key(globPath), f.Size())
+		}
+		if err != nil {
+			return err
 		}
-		return filePaths, sizes, nil
 	}
+	return nil
+}
 
-	globPaths, err := filepath.Glob(prefix + "*")
+// ListPageWithPrefix pages the files listed by ListWithPrefix in the order of their keys, the token is the last key
+// of the previous page. The files are listed for every page, local storage is not for deep prefixes.
+func (lcm *LocalChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	paths, sizes, err := lcm.ListWithPrefix(ctx, bucketName, prefix, recursive)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	filePaths = append(filePaths, globPaths...)
-	for _, filePath := range filePaths {
-		size, err := lcm.Size(ctx, bucketName, filePath)
-		if err != nil {
-			return filePaths, nil, err
+	files := make(map[string]int64, len(paths))
+	for i, filePath := range paths {
+		files[filePath] = sizes[i]
+	}
+	sort.Strings(paths)
+	if pageSize <= 0 {
+		pageSize = DefaultListPageSize
+	}
+	page := &ListPage{}
+	for _, filePath := range paths[sort.Search(len(paths), func(i int) bool { return paths[i] > token }):] {
+		if len(page.Paths) == pageSize {
+			page.NextToken = page.Paths[len(page.Paths)-1]
+			break
 		}
-		sizes = append(sizes, size)
+		page.Paths = append(page.Paths, filePath)
+		page.Sizes = append(page.Sizes, files[filePath])
 	}
-
-	return filePaths, sizes, nil
+	return page, nil
 }
 
 func (lcm *LocalChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
-	fi, err := os.Stat(filePath)
+	fi, err := os.Stat(lcm.fullPath(filePath))
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			return 0, WrapErrFileNotFound(filePath)
-		}
 		return 0, WrapErrFileNotFound(filePath)
 	}
 	// get the size
@@ -157,8 +243,27 @@ func (lcm *LocalChunkManager) Size(ctx context.Context, bucketName string, fileP
 }
 
 func (lcm *LocalChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
-	err := os.RemoveAll(filePath)
-	return err
+	err := os.RemoveAll(lcm.fullPath(filePath))
+	if err != nil {
+		return err
+	}
+	lcm.removeEmptyDirs(path.Dir(filePath))
+	return nil
+}
+
+// removeEmptyDirs removes dir and its parents in localPath if they are empty, directories are implicit in object
+// storage, a directory left empty would be listed like a backup without meta
+func (lcm *LocalChunkManager) removeEmptyDirs(dir string) {
+	if lcm.localPath == "" {
+		return
+	}
+	for dir != "." && dir != "/" && dir != "" {
+		// fails if the dir is not empty
+		if err := os.Remove(lcm.fullPath(dir)); err != nil {
+			return
+		}
+		dir = path.Dir(dir)
+	}
 }
 
 func (lcm *LocalChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
@@ -188,17 +293,27 @@ func (lcm *LocalChunkManager) MultiRemove(ctx context.Context, bucketName string
 	return nil
 }
 
+// Copy copies the files with prefix fromPath to toPath like object storage
 func (lcm *LocalChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
-	sourceFileStat, err := os.Stat(fromPath)
+	filePaths, _, err := lcm.ListWithPrefix(ctx, fromBucketName, fromPath, true)
 	if err != nil {
 		return err
 	}
-
-	if sourceFileStat.IsDir() {
-		return CopyDir(fromPath, toPath)
-	} else {
-		return CopyFile(fromPath, toPath)
+	for _, filePath := range filePaths {
+		dest := lcm.fullPath(strings.Replace(filePath, fromPath, toPath, 1))
+		if err := os.MkdirAll(path.Dir(dest), os.ModePerm); err != nil {
+			return err
+		}
+		if err := CopyFile(lcm.fullPath(filePath), dest); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// Rehydrate does nothing, local files are never archived
+func (lcm *LocalChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error) {
+	return 0, nil
 }
 
 func CopyDir(source string, dest string) (err error) {
@@ -0,0 +1,81 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalChunkManager(t *testing.T) {
+	ctx := context.Background()
+	localPath := t.TempDir()
+	lcm, err := NewLocalChunkManager(ctx, &config{localPath: localPath + "/"})
+	assert.NoError(t, err)
+
+	assert.NoError(t, lcm.Write(ctx, "bucket", "backup/b1/meta/backup_meta.json", []byte("meta")))
+	assert.NoError(t, lcm.Write(ctx, "bucket", "backup/b1/binlogs/insert_log/1", []byte("binlog")))
+	assert.FileExists(t, filepath.Join(localPath, "backup/b1/meta/backup_meta.json"))
+
+	data, err := lcm.Read(ctx, "bucket", "backup/b1/meta/backup_meta.json")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("meta"), data)
+	_, err = lcm.Read(ctx, "bucket", "backup/b2/meta/backup_meta.json")
+	assert.True(t, IsErrNoSuchKey(err))
+
+	// keys are relative to the local path, directories end with '/' like object storage
+	paths, sizes, err := lcm.ListWithPrefix(ctx, "bucket", "backup/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/b1/"}, paths)
+	assert.Equal(t, []int64{0}, sizes)
+	paths, sizes, err = lcm.ListWithPrefix(ctx, "bucket", "backup/", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/b1/binlogs/insert_log/1", "backup/b1/meta/backup_meta.json"}, paths)
+	assert.Equal(t, []int64{6, 4}, sizes)
+	paths, _, err = lcm.ListWithPrefix(ctx, "bucket", "nothing/", true)
+	assert.NoError(t, err)
+	assert.Empty(t, paths)
+
+	// the walk stops with the error of walkFunc
+	walked := make([]string, 0)
+	stop := errors.New("stop")
+	err = lcm.WalkWithPrefix(ctx, "bucket", "backup/", true, func(filePath string, size int64) error {
+		walked = append(walked, filePath)
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, []string{"backup/b1/binlogs/insert_log/1"}, walked)
+
+	page, err := lcm.ListPageWithPrefix(ctx, "bucket", "backup/", true, "", 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/b1/binlogs/insert_log/1"}, page.Paths)
+	assert.Equal(t, "backup/b1/binlogs/insert_log/1", page.NextToken)
+	page, err = lcm.ListPageWithPrefix(ctx, "bucket", "backup/", true, page.NextToken, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"backup/b1/meta/backup_meta.json"}, page.Paths)
+	assert.Equal(t, []int64{4}, page.Sizes)
+	assert.Empty(t, page.NextToken)
+
+	assert.NoError(t, lcm.Copy(ctx, "bucket", "bucket", "backup/b1/binlogs/", "backup/b2/binlogs/"))
+	data, err = lcm.Read(ctx, "bucket", "backup/b2/binlogs/insert_log/1")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("binlog"), data)
+
+	// no empty directory is left
+	assert.NoError(t, lcm.RemoveWithPrefix(ctx, "bucket", "backup/b1/"))
+	assert.NoDirExists(t, filepath.Join(localPath, "backup/b1"))
+	assert.DirExists(t, filepath.Join(localPath, "backup/b2"))
+}
+
+func TestLocalChunkManagerPathNotExist(t *testing.T) {
+	_, err := NewLocalChunkManager(context.Background(), &config{localPath: filepath.Join(t.TempDir(), "unmounted")})
+	assert.Error(t, err)
+
+	file := filepath.Join(t.TempDir(), "file")
+	assert.NoError(t, os.WriteFile(file, []byte("data"), 0o600))
+	_, err = NewLocalChunkManager(context.Background(), &config{localPath: file})
+	assert.Error(t, err)
+}
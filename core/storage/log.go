@@ -0,0 +1,8 @@
+package storage
+
+import (
+	logpkg "github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// log writes the logs of the storage package as the storage module, at the level of log.modules.storage
+var log = logpkg.NewModuleLogger(logpkg.ModuleStorage)
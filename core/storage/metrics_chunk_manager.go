@@ -0,0 +1,106 @@
+package storage
+
+import (
+	"context"
+	"time"
+
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+// makes sure MetricsChunkManager implements `ChunkManager`
+var _ ChunkManager = (*MetricsChunkManager)(nil)
+
+// MetricsChunkManager records the latency of the requests of the wrapped ChunkManager in metrics
+type MetricsChunkManager struct {
+	ChunkManager
+	storageType string
+}
+
+// NewMetricsChunkManager wraps chunkManager, storageType labels the requests in metrics
+func NewMetricsChunkManager(chunkManager ChunkManager, storageType string) *MetricsChunkManager {
+	return &MetricsChunkManager{
+		ChunkManager: chunkManager,
+		storageType:  storageType,
+	}
+}
+
+func (mcm *MetricsChunkManager) observe(operation string, start time.Time, err error) {
+	metrics.StorageRequestDuration.WithLabelValues(mcm.storageType, operation, metrics.StateLabel(err)).Observe(time.Since(start).Seconds())
+}
+
+func (mcm *MetricsChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	start := time.Now()
+	path, err := mcm.ChunkManager.Path(ctx, bucketName, filePath)
+	mcm.observe("path", start, err)
+	return path, err
+}
+
+func (mcm *MetricsChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	start := time.Now()
+	size, err := mcm.ChunkManager.Size(ctx, bucketName, filePath)
+	mcm.observe("size", start, err)
+	return size, err
+}
+
+func (mcm *MetricsChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	start := time.Now()
+	err := mcm.ChunkManager.Write(ctx, bucketName, filePath, content)
+	mcm.observe("write", start, err)
+	return err
+}
+
+func (mcm *MetricsChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	start := time.Now()
+	exist, err := mcm.ChunkManager.Exist(ctx, bucketName, filePath)
+	mcm.observe("exist", start, err)
+	return exist, err
+}
+
+func (mcm *MetricsChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	start := time.Now()
+	data, err := mcm.ChunkManager.Read(ctx, bucketName, filePath)
+	mcm.observe("read", start, err)
+	return data, err
+}
+
+func (mcm *MetricsChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	start := time.Now()
+	paths, sizes, err := mcm.ChunkManager.ListWithPrefix(ctx, bucketName, prefix, recursive)
+	mcm.observe("list", start, err)
+	return paths, sizes, err
+}
+
+func (mcm *MetricsChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	start := time.Now()
+	err := mcm.ChunkManager.WalkWithPrefix(ctx, bucketName, prefix, recursive, walkFunc)
+	mcm.observe("walk", start, err)
+	return err
+}
+
+func (mcm *MetricsChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	start := time.Now()
+	page, err := mcm.ChunkManager.ListPageWithPrefix(ctx, bucketName, prefix, recursive, token, pageSize)
+	mcm.observe("list_page", start, err)
+	return page, err
+}
+
+func (mcm *MetricsChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	start := time.Now()
+	err := mcm.ChunkManager.Remove(ctx, bucketName, filePath)
+	mcm.observe("remove", start, err)
+	return err
+}
+
+func (mcm *MetricsChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	start := time.Now()
+	err := mcm.ChunkManager.RemoveWithPrefix(ctx, bucketName, prefix)
+	mcm.observe("remove_with_prefix", start, err)
+	return err
+}
+
+func (mcm *MetricsChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	start := time.Now()
+	err := mcm.ChunkManager.Copy(ctx, fromBucketName, toBucketName, fromPath, toPath)
+	mcm.observe("copy", start, err)
+	return err
+}
@@ -0,0 +1,24 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+func TestMetricsChunkManager(t *testing.T) {
+	ctx := context.Background()
+	mcm := NewMetricsChunkManager(&fakeChunkManager{}, "test")
+
+	assert.NoError(t, mcm.Write(ctx, "bucket", "file", []byte("data")))
+	data, err := mcm.Read(ctx, "bucket", "file")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	// a series for each operation
+	assert.Equal(t, 2, testutil.CollectAndCount(metrics.StorageRequestDuration))
+}
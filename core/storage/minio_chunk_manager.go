@@ -7,16 +7,19 @@ import (
 	"fmt"
 	"golang.org/x/sync/errgroup"
 	"io"
+	"net/http"
 	"strings"
+	"sync/atomic"
 
 	"github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"go.uber.org/zap"
 
 	"github.com/zilliztech/milvus-backup/core/paramtable"
 	"github.com/zilliztech/milvus-backup/core/storage/aliyun"
-	"github.com/zilliztech/milvus-backup/core/storage/gcp"
-	"github.com/zilliztech/milvus-backup/internal/log"
+	"github.com/zilliztech/milvus-backup/core/storage/aws"
+	"github.com/zilliztech/milvus-backup/core/storage/tencent"
 	"github.com/zilliztech/milvus-backup/internal/util/errorutil"
 	"github.com/zilliztech/milvus-backup/internal/util/retry"
 )
@@ -44,6 +47,14 @@ type MinioChunkManager struct {
 	//	ctx        context.Context
 	bucketName string
 	rootPath   string
+
+	partSize         uint64
+	concurrency      int
+	disableMultipart bool
+
+	// tweaks of s3 compatible storages, see s3Profile
+	sendContentMD5 bool
+	pagedList      bool
 }
 
 var _ ChunkManager = (*MinioChunkManager)(nil)
@@ -63,27 +74,46 @@ func newMinioChunkManagerWithConfig(ctx context.Context, c *config) (*MinioChunk
 	var creds *credentials.Credentials
 	var newMinioFn = minio.New
 	var bucketLookupType = minio.BucketLookupAuto
+	address := c.address
 
+	if c.assumeRole.RoleArn != "" && !paramtable.IsS3Compatible(c.storageType) {
+		return nil, fmt.Errorf("storage type %s doesn't support assumeRole", c.storageType)
+	}
 	switch c.storageType {
-	case paramtable.CloudProviderAliyun:
-	case paramtable.CloudProviderAli:
+	case paramtable.CloudProviderAliyun, paramtable.CloudProviderAli:
 		// auto doesn't work for aliyun, so we set to dns deliberately
 		bucketLookupType = minio.BucketLookupDNS
 		if c.useIAM {
+			// ram role of ecs or rrsa oidc role of ack, by the default credential chain of aliyun
 			newMinioFn = aliyun.NewMinioClient
 		} else {
-			creds = credentials.NewStaticV4(c.accessKeyID, c.secretAccessKeyID, "")
+			creds = newStaticCredentials(c)
+		}
+	case paramtable.CloudProviderTencent:
+		// cos only supports virtual-hosted style
+		bucketLookupType = minio.BucketLookupDNS
+		if c.useIAM {
+			// cam role of cvm or oidc role of tke pod identity
+			newMinioFn = tencent.NewMinioClient
+		} else {
+			creds = newStaticCredentials(c)
 		}
 	case paramtable.CloudProviderGCP:
-		newMinioFn = gcp.NewMinioClient
-		if !c.useIAM {
-			creds = credentials.NewStaticV2(c.accessKeyID, c.secretAccessKeyID, "")
+		// hmac keys of the s3 compatible api of gcs, minio-go signs the requests to gcs by v2
+		creds = newStaticCredentials(c)
+		if strings.Contains(address, GcsDefaultAddress) {
+			// the port is removed, so that minio-go knows the endpoint is gcs
+			address = GcsDefaultAddress
 		}
-	default: // aws, minio
+	default: // aws, minio, b2, r2
 		if c.useIAM {
 			creds = credentials.NewIAM("")
 		} else {
-			creds = credentials.NewStaticV4(c.accessKeyID, c.secretAccessKeyID, "")
+			creds = newStaticCredentials(c)
+		}
+		if c.assumeRole.RoleArn != "" {
+			// temporary credentials of the role, assumed with the credentials above or the web identity token
+			creds = aws.NewCredentials(c.assumeRole, creds)
 		}
 	}
 	minioOpts := &minio.Options{
@@ -91,7 +121,15 @@ func newMinioChunkManagerWithConfig(ctx context.Context, c *config) (*MinioChunk
 		Creds:        creds,
 		Secure:       c.useSSL,
 	}
-	minIOClient, err := newMinioFn(c.address, minioOpts)
+	profile, compatible := s3ProfileOf(c.storageType, c.address)
+	if compatible {
+		if err := profile.validate(c); err != nil {
+			return nil, err
+		}
+		minioOpts.Region = profile.region
+		minioOpts.BucketLookup = profile.bucketLookup
+	}
+	minIOClient, err := newMinioFn(address, minioOpts)
 	// options nil or invalid formatted endpoint, don't need to retry
 	if err != nil {
 		return nil, err
@@ -124,8 +162,16 @@ func newMinioChunkManagerWithConfig(ctx context.Context, c *config) (*MinioChunk
 	}
 
 	mcm := &MinioChunkManager{
-		Client:     minIOClient,
-		bucketName: c.bucketName,
+		Client:           minIOClient,
+		bucketName:       c.bucketName,
+		partSize:         uint64(c.multipartPartSize),
+		concurrency:      c.multipartConcurrency,
+		disableMultipart: c.disableMultipart,
+		sendContentMD5:   profile.sendContentMD5,
+		pagedList:        profile.pagedList,
+	}
+	if mcm.concurrency <= 0 {
+		mcm.concurrency = defaultMultipartConcurrency
 	}
 	mcm.rootPath = mcm.normalizeRootPath(c.rootPath)
 	log.Info("minio chunk manager init success.", zap.String("bucketname", c.bucketName), zap.String("root", mcm.RootPath()))
@@ -163,7 +209,11 @@ func (mcm *MinioChunkManager) Path(ctx context.Context, bucketName string, fileP
 
 // Reader returns the path of minio data if exists.
 func (mcm *MinioChunkManager) Reader(ctx context.Context, bucketName string, filePath string) (FileReader, error) {
-	reader, err := mcm.Client.GetObject(ctx, bucketName, filePath, minio.GetObjectOptions{})
+	opts, err := getObjectOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	reader, err := mcm.Client.GetObject(ctx, bucketName, filePath, opts)
 	if err != nil {
 		log.Warn("failed to get object", zap.String("path", filePath), zap.Error(err))
 		return nil, err
@@ -172,7 +222,11 @@ func (mcm *MinioChunkManager) Reader(ctx context.Context, bucketName string, fil
 }
 
 func (mcm *MinioChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
-	objectInfo, err := mcm.Client.StatObject(ctx, bucketName, filePath, minio.StatObjectOptions{})
+	opts, err := getObjectOptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	objectInfo, err := mcm.Client.StatObject(ctx, bucketName, filePath, opts)
 	if err != nil {
 		log.Warn("failed to stat object", zap.String("path", filePath), zap.Error(err))
 		return 0, err
@@ -183,7 +237,26 @@ func (mcm *MinioChunkManager) Size(ctx context.Context, bucketName string, fileP
 
 // Write writes the data to minio storage.
 func (mcm *MinioChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
-	_, err := mcm.Client.PutObject(ctx, bucketName, filePath, bytes.NewReader(content), int64(len(content)), minio.PutObjectOptions{})
+	opts := minio.PutObjectOptions{
+		PartSize:   mcm.partSize,
+		NumThreads: uint(mcm.concurrency),
+		// objects larger than 5GB can only be uploaded in parts
+		DisableMultipart: mcm.disableMultipart && len(content) <= maxSingleObjectSize,
+		SendContentMd5:   mcm.sendContentMD5,
+		StorageClass:     storageClassFromContext(ctx),
+	}
+	if lock, ok := objectLockFromContext(ctx); ok {
+		opts.Mode = minio.RetentionMode(lock.Mode)
+		opts.RetainUntilDate = lock.RetainUntil
+		// s3 requires Content-MD5 of the uploads with retention
+		opts.SendContentMd5 = true
+	}
+	sse, err := minioWriteSSE(ctx)
+	if err != nil {
+		return err
+	}
+	opts.ServerSideEncryption = sse
+	_, err = mcm.Client.PutObject(ctx, bucketName, filePath, bytes.NewReader(content), int64(len(content)), opts)
 
 	if err != nil {
 		log.Warn("failed to put object", zap.String("path", filePath), zap.Error(err))
@@ -251,7 +324,11 @@ func (mcm *MinioChunkManager) Read(ctx context.Context, bucketName string, fileP
 	//	return nil, err
 	//}
 
-	objectInfo, err := mcm.Client.StatObject(ctx, bucketName, filePath, minio.StatObjectOptions{})
+	opts, err := getObjectOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	objectInfo, err := mcm.Client.StatObject(ctx, bucketName, filePath, opts)
 	if err != nil {
 		log.Warn("failed to stat object", zap.String("path", filePath), zap.Error(err))
 		errResponse := minio.ToErrorResponse(err)
@@ -260,7 +337,7 @@ func (mcm *MinioChunkManager) Read(ctx context.Context, bucketName string, fileP
 		}
 		return nil, err
 	}
-	object, err := mcm.Client.GetObject(ctx, bucketName, filePath, minio.GetObjectOptions{})
+	object, err := mcm.Client.GetObject(ctx, bucketName, filePath, opts)
 	if err != nil {
 		log.Warn("failed to get object", zap.String("path", filePath), zap.Error(err))
 		return nil, err
@@ -314,8 +391,11 @@ func (mcm *MinioChunkManager) ReadAt(ctx context.Context, bucketName string, fil
 		return nil, io.EOF
 	}
 
-	opts := minio.GetObjectOptions{}
-	err := opts.SetRange(off, off+length-1)
+	opts, err := getObjectOptions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = opts.SetRange(off, off+length-1)
 	if err != nil {
 		log.Warn("failed to set range", zap.String("path", filePath), zap.Error(err))
 		return nil, err
@@ -367,20 +447,12 @@ func (mcm *MinioChunkManager) MultiRemove(ctx context.Context, bucketName string
 
 // RemoveWithPrefix removes all objects with the same prefix @prefix from minio.
 func (mcm *MinioChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
-	objects := mcm.Client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true})
-	i := 0
 	maxGoroutine := 10
-	removeKeys := make([]string, 0, len(objects))
-	for object := range objects {
-		if object.Err != nil {
-			return object.Err
-		}
-		removeKeys = append(removeKeys, object.Key)
-	}
-	for i < len(removeKeys) {
+	removeKeys := make([]string, 0, maxGoroutine)
+	remove := func() error {
 		runningGroup, groupCtx := errgroup.WithContext(ctx)
-		for j := 0; j < maxGoroutine && i < len(removeKeys); j++ {
-			key := removeKeys[i]
+		for _, key := range removeKeys {
+			key := key
 			runningGroup.Go(func() error {
 				err := mcm.Client.RemoveObject(groupCtx, bucketName, key, minio.RemoveObjectOptions{})
 				if err != nil {
@@ -389,43 +461,90 @@ func (mcm *MinioChunkManager) RemoveWithPrefix(ctx context.Context, bucketName s
 				}
 				return nil
 			})
-			i++
 		}
-		if err := runningGroup.Wait(); err != nil {
-			return err
+		removeKeys = removeKeys[:0]
+		return runningGroup.Wait()
+	}
+	// objects are removed while listing, a batch of maxGoroutine at a time
+	err := mcm.WalkWithPrefix(ctx, bucketName, prefix, true, func(filePath string, size int64) error {
+		removeKeys = append(removeKeys, filePath)
+		if len(removeKeys) < maxGoroutine {
+			return nil
 		}
+		return remove()
+	})
+	if err != nil {
+		return err
 	}
-	return nil
+	return remove()
 }
 
 func (mcm *MinioChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
-	objects := mcm.Client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: recursive})
 	var objectsKeys []string
 	var sizes []int64
+	err := mcm.WalkWithPrefix(ctx, bucketName, prefix, recursive, func(filePath string, size int64) error {
+		objectsKeys = append(objectsKeys, filePath)
+		sizes = append(sizes, size)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return objectsKeys, sizes, nil
+}
 
+func (mcm *MinioChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	if mcm.pagedList {
+		if err := walkPages(ctx, mcm, bucketName, prefix, recursive, walkFunc); err != nil {
+			log.Warn("failed to list with prefix", zap.String("prefix", prefix), zap.Error(err))
+			return err
+		}
+		return nil
+	}
+	// the listing is stopped by canceling ctx if the walk returns early
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	objects := mcm.Client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: recursive})
 	for object := range objects {
 		if object.Err != nil {
 			log.Warn("failed to list with prefix", zap.String("prefix", prefix), zap.Error(object.Err))
-			return nil, nil, object.Err
+			return object.Err
+		}
+		if err := walkFunc(object.Key, object.Size); err != nil {
+			return err
 		}
-		objectsKeys = append(objectsKeys, object.Key)
-		sizes = append(sizes, object.Size)
 	}
-	return objectsKeys, sizes, nil
+	return nil
 }
 
 func (mcm *MinioChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
-	objectkeys, _, err := mcm.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+	srcOpts, err := getObjectOptions(ctx)
+	if err != nil {
+		return err
+	}
+	dstSSE, err := minioWriteSSE(ctx)
 	if err != nil {
-		log.Warn("listWithPrefix error", zap.String("prefix", fromPath), zap.Error(err))
 		return err
 	}
-	for _, objectkey := range objectkeys {
-		src := minio.CopySrcOptions{Bucket: fromBucketName, Object: objectkey}
+	copyObject := func(objectkey string, size int64) error {
 		dstObjectKey := strings.Replace(objectkey, fromPath, toPath, 1)
-		dst := minio.CopyDestOptions{Bucket: toBucketName, Object: dstObjectKey}
-
-		_, err = mcm.Client.CopyObject(ctx, dst, src)
+		var err error
+		if size > maxSingleObjectSize {
+			err = mcm.multipartCopy(ctx, fromBucketName, toBucketName, objectkey, dstObjectKey, size)
+		} else {
+			src := minio.CopySrcOptions{Bucket: fromBucketName, Object: objectkey, Encryption: srcOpts.ServerSideEncryption}
+			dst := minio.CopyDestOptions{Bucket: toBucketName, Object: dstObjectKey, Encryption: dstSSE}
+			if storageClass := storageClassFromContext(ctx); storageClass != "" {
+				// the storage class of the copy is a replaced header, binlogs have no user metadata to keep
+				dst.ReplaceMetadata = true
+				dst.UserMetadata = map[string]string{"X-Amz-Storage-Class": storageClass}
+			}
+			if lock, ok := objectLockFromContext(ctx); ok {
+				dst.Mode = minio.RetentionMode(lock.Mode)
+				dst.RetainUntilDate = lock.RetainUntil
+			}
+			_, err = mcm.Client.CopyObject(ctx, dst, src)
+		}
 		if err != nil {
 			log.Error("copyObject error",
 				zap.String("srcObjectKey", objectkey),
@@ -433,8 +552,140 @@ func (mcm *MinioChunkManager) Copy(ctx context.Context, fromBucketName string, t
 				zap.Error(err))
 			return err
 		}
+		return nil
 	}
-	return nil
+	if fromBucketName == toBucketName && strings.HasPrefix(toPath, fromPath) {
+		// the copies would be listed again, the objects are listed before copying
+		objectkeys, sizes, err := mcm.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+		if err != nil {
+			return err
+		}
+		for i, objectkey := range objectkeys {
+			if err := copyObject(objectkey, sizes[i]); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return mcm.WalkWithPrefix(ctx, fromBucketName, fromPath, true, copyObject)
+}
+
+// multipartCopy copies an object larger than 5GB, beyond the limit of CopyObject, by server side part by part
+// in a multipart upload, the parts are copied in parallel
+func (mcm *MinioChunkManager) multipartCopy(ctx context.Context, fromBucketName, toBucketName, fromPath, toPath string, size int64) error {
+	_, partSize, _, err := minio.OptimalPartInfo(size, mcm.partSize)
+	if err != nil {
+		return err
+	}
+	core := minio.Core{Client: mcm.Client}
+	opts := minio.PutObjectOptions{StorageClass: storageClassFromContext(ctx)}
+	if lock, ok := objectLockFromContext(ctx); ok {
+		opts.Mode = minio.RetentionMode(lock.Mode)
+		opts.RetainUntilDate = lock.RetainUntil
+	}
+	if opts.ServerSideEncryption, err = minioWriteSSE(ctx); err != nil {
+		return err
+	}
+	srcOpts, err := getObjectOptions(ctx)
+	if err != nil {
+		return err
+	}
+	uploadID, err := core.NewMultipartUpload(ctx, toBucketName, toPath, opts)
+	if err != nil {
+		return err
+	}
+	// the customer keys of the source and the upload are sent with every part
+	header := make(http.Header)
+	if srcOpts.ServerSideEncryption != nil {
+		encrypt.SSECopy(srcOpts.ServerSideEncryption).Marshal(header)
+	}
+	if opts.ServerSideEncryption != nil && opts.ServerSideEncryption.Type() == encrypt.SSEC {
+		opts.ServerSideEncryption.Marshal(header)
+	}
+	partHeaders := make(map[string]string, len(header))
+	for k := range header {
+		partHeaders[k] = header.Get(k)
+	}
+
+	parts := splitParts(size, partSize)
+	completeParts := make([]minio.CompletePart, len(parts))
+	g, subCtx := errgroup.WithContext(ctx)
+	g.SetLimit(mcm.concurrency)
+	for i, part := range parts {
+		i, part := i, part
+		g.Go(func() error {
+			completePart, err := core.CopyObjectPart(subCtx, fromBucketName, fromPath, toBucketName, toPath, uploadID, i+1, part.offset, part.length, partHeaders)
+			if err != nil {
+				return err
+			}
+			completeParts[i] = completePart
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		if abortErr := core.AbortMultipartUpload(ctx, toBucketName, toPath, uploadID); abortErr != nil {
+			log.Warn("fail to abort multipart upload", zap.String("object", toPath), zap.String("uploadID", uploadID), zap.Error(abortErr))
+		}
+		return err
+	}
+	_, err = core.CompleteMultipartUpload(ctx, toBucketName, toPath, uploadID, completeParts, minio.PutObjectOptions{})
+	return err
+}
+
+// Rehydrate issues restore requests of the objects with prefix in archive storage classes, GLACIER and DEEP_ARCHIVE,
+// which can't be read or copied until they are restored. Objects already being restored are only counted.
+func (mcm *MinioChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error) {
+	var archived []string
+	for object := range mcm.Client.ListObjects(ctx, bucketName, minio.ListObjectsOptions{Prefix: prefix, Recursive: true}) {
+		if object.Err != nil {
+			log.Warn("failed to list with prefix", zap.String("prefix", prefix), zap.Error(object.Err))
+			return 0, object.Err
+		}
+		if isArchiveStorageClass(object.StorageClass) {
+			archived = append(archived, object.Key)
+		}
+	}
+	if len(archived) == 0 {
+		return 0, nil
+	}
+
+	opts, err := getObjectOptions(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var pending int64
+	g, subCtx := errgroup.WithContext(ctx)
+	g.SetLimit(mcm.concurrency)
+	for _, key := range archived {
+		key := key
+		g.Go(func() error {
+			info, err := mcm.Client.StatObject(subCtx, bucketName, key, opts)
+			if err != nil {
+				return err
+			}
+			// restored and not expired yet
+			if info.Restore != nil && !info.Restore.OngoingRestore {
+				return nil
+			}
+			atomic.AddInt64(&pending, 1)
+			if info.Restore != nil {
+				return nil
+			}
+			req := minio.RestoreRequest{}
+			req.SetDays(days)
+			req.SetGlacierJobParameters(minio.GlacierJobParameters{Tier: minio.TierType(tier)})
+			err = mcm.Client.RestoreObject(subCtx, bucketName, key, "", req)
+			if err != nil && minio.ToErrorResponse(err).Code != "RestoreAlreadyInProgress" {
+				log.Warn("failed to restore archived object", zap.String("path", key), zap.Error(err))
+				return err
+			}
+			return nil
+		})
+	}
+	if err := g.Wait(); err != nil {
+		return 0, err
+	}
+	return int(atomic.LoadInt64(&pending)), nil
 }
 
 // Learn from file.ReadFile
@@ -0,0 +1,39 @@
+package storage
+
+const (
+	// maxSingleObjectSize is the largest object s3 accepts in a single PUT or CopyObject request,
+	// larger objects are uploaded or copied part by part in a multipart upload
+	maxSingleObjectSize = 5 * 1024 * 1024 * 1024
+
+	// defaultMultipartConcurrency is the number of parts uploaded or copied in parallel if not configured
+	defaultMultipartConcurrency = 4
+	// defaultAzureBlockSize is the block size of azure if not configured, the sdk default 1MB is too small for binlogs
+	defaultAzureBlockSize = 8 * 1024 * 1024
+)
+
+// partRange is the range of a part in an object
+type partRange struct {
+	offset int64
+	length int64
+}
+
+// splitParts splits an object of size into parts of partSize, the last part holds the rest
+func splitParts(size, partSize int64) []partRange {
+	parts := make([]partRange, 0, (size+partSize-1)/partSize)
+	for offset := int64(0); offset < size; offset += partSize {
+		length := partSize
+		if offset+length > size {
+			length = size - offset
+		}
+		parts = append(parts, partRange{offset: offset, length: length})
+	}
+	return parts
+}
+
+// fitPartSize returns partSize, enlarged if an object of size can't be split into at most maxParts parts of it
+func fitPartSize(size, partSize, maxParts int64) int64 {
+	if minSize := (size + maxParts - 1) / maxParts; partSize < minSize {
+		return minSize
+	}
+	return partSize
+}
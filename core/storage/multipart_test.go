@@ -0,0 +1,31 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSplitParts(t *testing.T) {
+	assert.Equal(t, []partRange{{0, 4}, {4, 4}, {8, 2}}, splitParts(10, 4))
+	assert.Equal(t, []partRange{{0, 4}, {4, 4}}, splitParts(8, 4))
+	assert.Equal(t, []partRange{{0, 3}}, splitParts(3, 4))
+	assert.Empty(t, splitParts(0, 4))
+
+	// a 20GB object copied in parts of 5GB
+	parts := splitParts(20*1024*1024*1024+1, maxSingleObjectSize)
+	assert.Len(t, parts, 5)
+	assert.Equal(t, int64(1), parts[4].length)
+}
+
+func TestFitPartSize(t *testing.T) {
+	assert.Equal(t, int64(8), fitPartSize(100, 8, 50))
+	assert.Equal(t, int64(2), fitPartSize(100, 1, 50))
+	assert.Equal(t, int64(3), fitPartSize(101, 1, 50))
+
+	// a 100GB blob needs blocks larger than 1MB to fit in 50000 blocks
+	size := int64(100 * 1024 * 1024 * 1024)
+	blockSize := fitPartSize(size, 1024*1024, 50000)
+	assert.Greater(t, blockSize, int64(1024*1024))
+	assert.LessOrEqual(t, len(splitParts(size, blockSize)), 50000)
+}
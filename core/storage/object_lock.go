@@ -0,0 +1,30 @@
+package storage
+
+import (
+	"context"
+	"time"
+)
+
+// ObjectLock is the s3 object lock retention of the object versions written, they can't be deleted or overwritten
+// until RetainUntil, even in GOVERNANCE mode without the permission to bypass it
+type ObjectLock struct {
+	// GOVERNANCE or COMPLIANCE
+	Mode        string
+	RetainUntil time.Time
+}
+
+type objectLockKey struct{}
+
+// WithObjectLock returns a context with which the files written or copied are locked, on the storages supporting it.
+// The bucket must be created with object lock enabled.
+func WithObjectLock(ctx context.Context, lock ObjectLock) context.Context {
+	if lock.Mode == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, objectLockKey{}, lock)
+}
+
+func objectLockFromContext(ctx context.Context) (ObjectLock, bool) {
+	lock, ok := ctx.Value(objectLockKey{}).(ObjectLock)
+	return lock, ok
+}
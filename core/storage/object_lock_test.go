@@ -0,0 +1,52 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObjectLockContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := objectLockFromContext(WithObjectLock(ctx, ObjectLock{}))
+	assert.False(t, ok)
+
+	until := time.Now().Add(time.Hour)
+	lock, ok := objectLockFromContext(WithObjectLock(ctx, ObjectLock{Mode: "GOVERNANCE", RetainUntil: until}))
+	assert.True(t, ok)
+	assert.Equal(t, "GOVERNANCE", lock.Mode)
+	assert.Equal(t, until, lock.RetainUntil)
+}
+
+func TestMinioWriteWithObjectLock(t *testing.T) {
+	var headers http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers = r.Header.Clone()
+		w.Header().Set("ETag", `"etag"`)
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	client, err := minio.New(serverURL.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4("ak", "sk", ""),
+		Region:       "us-east-1",
+		BucketLookup: minio.BucketLookupPath,
+	})
+	assert.NoError(t, err)
+	mcm := &MinioChunkManager{Client: client, concurrency: 1}
+
+	until := time.Date(2026, 11, 14, 0, 0, 0, 0, time.UTC)
+	ctx := WithObjectLock(WithStorageClass(context.Background(), "DEEP_ARCHIVE"), ObjectLock{Mode: "COMPLIANCE", RetainUntil: until})
+	assert.NoError(t, mcm.Write(ctx, "bucket", "backup/meta.json", []byte("meta")))
+	assert.Equal(t, "COMPLIANCE", headers.Get("X-Amz-Object-Lock-Mode"))
+	assert.Equal(t, until.Format(time.RFC3339), headers.Get("X-Amz-Object-Lock-Retain-Until-Date"))
+	assert.Equal(t, "DEEP_ARCHIVE", headers.Get("X-Amz-Storage-Class"))
+	assert.NotEmpty(t, headers.Get("Content-Md5"))
+}
@@ -1,5 +1,11 @@
 package storage
 
+import (
+	"time"
+
+	"github.com/zilliztech/milvus-backup/core/storage/aws"
+)
+
 // Option for setting params used by chunk manager client.
 type config struct {
 	address           string
@@ -11,6 +17,10 @@ type config struct {
 	rootPath          string
 	useIAM            bool
 	iamEndpoint       string
+	// how often the credentials referred by env: or file: are read again
+	credentialRefreshInterval time.Duration
+	// only for aws and minio, the role assumed by sts with the credentials above or a web identity token
+	assumeRole aws.RoleOptions
 
 	// deprecated
 	cloudProvider string
@@ -20,6 +30,32 @@ type config struct {
 	backupSecretAccessKeyID string
 	backupBucketName        string
 	backupRootPath          string
+
+	localPath string
+
+	azureConnectionString       string
+	azureSASToken               string
+	backupAzureConnectionString string
+	backupAzureSASToken         string
+
+	gcpCredentialJSON           string
+	gcpKmsKeyName               string
+	gcpUniformBucketLevelAccess bool
+	gcpUploadChunkSize          int64
+
+	hdfsUser            string
+	hdfsDelegationToken string
+
+	sftpUser                 string
+	sftpPrivateKeyFile       string
+	sftpPrivateKeyPassphrase string
+	sftpKnownHostsFile       string
+	sftpMaxConnections       int
+
+	// multipart upload and copy of large objects, 0 part size means the default of the storage
+	multipartPartSize    int64
+	multipartConcurrency int
+	disableMultipart     bool
 }
 
 func newDefaultConfig() *config {
@@ -81,3 +117,9 @@ func IAMEndpoint(iamEndpoint string) Option {
 		c.iamEndpoint = iamEndpoint
 	}
 }
+
+func AssumeRole(opts aws.RoleOptions) Option {
+	return func(c *config) {
+		c.assumeRole = opts
+	}
+}
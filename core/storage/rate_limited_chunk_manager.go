@@ -0,0 +1,77 @@
+package storage
+
+import (
+	"context"
+
+	"golang.org/x/time/rate"
+)
+
+// makes sure RateLimitedChunkManager implements `ChunkManager`
+var _ ChunkManager = (*RateLimitedChunkManager)(nil)
+
+// RateLimitedChunkManager limits the bandwidth of data read, written and copied by the wrapped ChunkManager
+// with a token bucket, so that backup and restore don't saturate the network shared with milvus.
+type RateLimitedChunkManager struct {
+	ChunkManager
+	limiter *rate.Limiter
+}
+
+// NewRateLimitedChunkManager wraps chunkManager, bytesPerSecond is the max bandwidth of all the data transferred
+func NewRateLimitedChunkManager(chunkManager ChunkManager, bytesPerSecond int) *RateLimitedChunkManager {
+	// allow a burst of one second of data
+	return &RateLimitedChunkManager{
+		ChunkManager: chunkManager,
+		limiter:      rate.NewLimiter(rate.Limit(bytesPerSecond), bytesPerSecond),
+	}
+}
+
+// wait blocks until n bytes are allowed, n can be larger than the burst of the limiter
+func (rcm *RateLimitedChunkManager) wait(ctx context.Context, n int64) error {
+	burst := int64(rcm.limiter.Burst())
+	for n > 0 {
+		tokens := n
+		if tokens > burst {
+			tokens = burst
+		}
+		if err := rcm.limiter.WaitN(ctx, int(tokens)); err != nil {
+			return err
+		}
+		n -= tokens
+	}
+	return nil
+}
+
+func (rcm *RateLimitedChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	if err := rcm.wait(ctx, int64(len(content))); err != nil {
+		return err
+	}
+	return rcm.ChunkManager.Write(ctx, bucketName, filePath, content)
+}
+
+// Read waits after the data is read, the size is unknown before. The bandwidth is still limited on average.
+func (rcm *RateLimitedChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	data, err := rcm.ChunkManager.Read(ctx, bucketName, filePath)
+	if err != nil {
+		return nil, err
+	}
+	if err := rcm.wait(ctx, int64(len(data))); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// Copy waits for the total size of the files to copy before copying them
+func (rcm *RateLimitedChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	_, sizes, err := rcm.ChunkManager.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, size := range sizes {
+		total += size
+	}
+	if err := rcm.wait(ctx, total); err != nil {
+		return err
+	}
+	return rcm.ChunkManager.Copy(ctx, fromBucketName, toBucketName, fromPath, toPath)
+}
@@ -0,0 +1,45 @@
+package storage
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeChunkManager only implements the methods used by RateLimitedChunkManager
+type fakeChunkManager struct {
+	ChunkManager
+	data []byte
+}
+
+func (f *fakeChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	return f.data, nil
+}
+
+func (f *fakeChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	f.data = content
+	return nil
+}
+
+func TestRateLimitedChunkManager(t *testing.T) {
+	ctx := context.Background()
+	rcm := NewRateLimitedChunkManager(&fakeChunkManager{}, 1000)
+
+	// the first second of data is allowed by burst
+	start := time.Now()
+	assert.NoError(t, rcm.Write(ctx, "bucket", "file", make([]byte, 1000)))
+	assert.Less(t, time.Since(start), 100*time.Millisecond)
+
+	// the following data waits for the bucket to refill
+	start = time.Now()
+	data, err := rcm.Read(ctx, "bucket", "file")
+	assert.NoError(t, err)
+	assert.Len(t, data, 1000)
+	assert.GreaterOrEqual(t, time.Since(start), 800*time.Millisecond)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.Error(t, rcm.Write(canceled, "bucket", "file", make([]byte, 1000)))
+}
@@ -0,0 +1,89 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+// Factory creates the ChunkManager of a storage type. The storage is configured by params.MinioCfg, which holds
+// the backupStorage section when the chunk manager of the backup storage is created, other keys of its own can be
+// read from params.BaseTable. The returned chunk manager is wrapped by metrics, tracing, rate limit and retry
+// like the built in ones.
+type Factory func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[string]Factory)
+)
+
+func init() {
+	Register(paramtable.Local, func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+		return newLocalChunkManagerWithParams(ctx, params)
+	})
+	Register(paramtable.CloudProviderAzure, func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+		return newAzureChunkManagerWithParams(ctx, params)
+	})
+	Register(paramtable.CloudProviderGCP, func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+		if useGCPHMACKeys(params.MinioCfg) {
+			return newMinioChunkManagerWithParams(ctx, params)
+		}
+		return newGCPChunkManagerWithParams(ctx, params)
+	})
+	Register(paramtable.HDFS, func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+		return newHDFSChunkManagerWithParams(ctx, params)
+	})
+	Register(paramtable.SFTP, func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+		return newSFTPChunkManagerWithParams(ctx, params)
+	})
+	for _, storageType := range []string{
+		paramtable.Minio,
+		paramtable.S3,
+		paramtable.CloudProviderAWS,
+		paramtable.CloudProviderAli,
+		paramtable.CloudProviderAliyun,
+		paramtable.CloudProviderTencent,
+		paramtable.CloudProviderBackblaze,
+		paramtable.CloudProviderCloudflare,
+	} {
+		Register(storageType, func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+			return newMinioChunkManagerWithParams(ctx, params)
+		})
+	}
+}
+
+// Register sets the factory of the chunk managers of storageType, so that a backend not built in, like HDFS,
+// Ceph RADOS or SFTP, can be configured by storageType in the minio and backupStorage sections.
+// A factory registered before is replaced, which also replaces a built in backend.
+// Should be called before the config is loaded, e.g. in an init function.
+func Register(storageType string, factory Factory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	factories[storageType] = factory
+	paramtable.RegisterStorageType(storageType)
+}
+
+// RegisteredStorageTypes returns the storage types having a factory, in order
+func RegisteredStorageTypes() []string {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	storageTypes := make([]string, 0, len(factories))
+	for storageType := range factories {
+		storageTypes = append(storageTypes, storageType)
+	}
+	sort.Strings(storageTypes)
+	return storageTypes
+}
+
+func factoryOf(storageType string) (Factory, error) {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
+	factory, ok := factories[storageType]
+	if !ok {
+		return nil, fmt.Errorf("unsupported storage type: %s", storageType)
+	}
+	return factory, nil
+}
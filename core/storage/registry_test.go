@@ -0,0 +1,59 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zilliztech/milvus-backup/core/paramtable"
+)
+
+func TestRegister(t *testing.T) {
+	ctx := context.Background()
+	for _, storageType := range []string{paramtable.Local, paramtable.Minio, paramtable.CloudProviderAzure, paramtable.CloudProviderGCP} {
+		assert.Contains(t, RegisteredStorageTypes(), storageType)
+	}
+
+	var params paramtable.BackupParams
+	params.MinioCfg.StorageType = "rados"
+	_, err := NewChunkManager(ctx, params)
+	assert.ErrorContains(t, err, "unsupported storage type: rados")
+
+	var created []string
+	fake := &fakeChunkManager{}
+	Register("rados", func(ctx context.Context, params paramtable.BackupParams) (ChunkManager, error) {
+		created = append(created, params.MinioCfg.Address)
+		return fake, nil
+	})
+	defer func() {
+		factoriesMu.Lock()
+		delete(factories, "rados")
+		factoriesMu.Unlock()
+	}()
+	assert.Contains(t, RegisteredStorageTypes(), "rados")
+
+	params.MinioCfg.Address = "milvus-storage"
+	cm, err := NewChunkManager(ctx, params)
+	assert.NoError(t, err)
+	// the custom backend is wrapped like the built in ones
+	_, ok := cm.(*RetryChunkManager)
+	assert.True(t, ok)
+	assert.NoError(t, cm.Write(ctx, "bucket", "file", []byte("data")))
+	assert.Equal(t, []byte("data"), fake.data)
+
+	params.MinioCfg.StorageType = paramtable.Minio
+	params.BackupStorageCfg.StorageType = "rados"
+	params.BackupStorageCfg.Address = "backup-storage"
+	_, err = NewBackupChunkManager(ctx, params)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"milvus-storage", "backup-storage"}, created)
+}
+
+func TestUseGCPHMACKeys(t *testing.T) {
+	assert.False(t, useGCPHMACKeys(paramtable.MinioConfig{StorageType: paramtable.CloudProviderGCP}))
+	assert.True(t, useGCPHMACKeys(paramtable.MinioConfig{StorageType: paramtable.CloudProviderGCP, AccessKeyID: "ak", SecretAccessKey: "sk"}))
+	// the credentials of gcp are used by the native client
+	assert.False(t, useGCPHMACKeys(paramtable.MinioConfig{StorageType: paramtable.CloudProviderGCP, AccessKeyID: "ak", SecretAccessKey: "sk", UseIAM: true}))
+	assert.False(t, useGCPHMACKeys(paramtable.MinioConfig{StorageType: paramtable.CloudProviderGCP, AccessKeyID: "ak", SecretAccessKey: "sk", GcpCredentialJSON: "/path/to/key.json"}))
+}
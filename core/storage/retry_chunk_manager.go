@@ -0,0 +1,336 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/minio/minio-go/v7"
+	"go.uber.org/zap"
+	"google.golang.org/api/googleapi"
+
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+// ErrCircuitOpen is returned without calling the storage while the circuit breaker is open
+var ErrCircuitOpen = errors.New("storage circuit breaker is open")
+
+// makes sure RetryChunkManager implements `ChunkManager`
+var _ ChunkManager = (*RetryChunkManager)(nil)
+
+// RetryPolicy is how the storage operations failed by transient errors are retried
+type RetryPolicy struct {
+	// attempts of an operation, 1 means no retry
+	MaxAttempts int
+	// backoff before the first retry, doubled after every retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// consecutive operations failed after all attempts to open the circuit breaker, 0 disables it
+	CircuitBreakerThreshold int
+	// operations fail fast with ErrCircuitOpen for cooldown after the circuit breaker opens
+	CircuitBreakerCooldown time.Duration
+}
+
+// RetryChunkManager retries the operations of the wrapped ChunkManager failed by transient errors, like throttling,
+// 5xx responses and connection resets, with exponential backoff. If operations keep failing, the circuit breaker
+// opens and operations fail fast for a while instead of piling up retries on an unavailable storage.
+type RetryChunkManager struct {
+	ChunkManager
+	storageType string
+	policy      RetryPolicy
+
+	mu sync.Mutex
+	// consecutive operations failed by transient errors after all attempts
+	failures  int
+	openUntil time.Time
+}
+
+// NewRetryChunkManager wraps chunkManager, storageType labels the retries in metrics
+func NewRetryChunkManager(chunkManager ChunkManager, storageType string, policy RetryPolicy) *RetryChunkManager {
+	if policy.MaxAttempts <= 0 {
+		policy.MaxAttempts = 1
+	}
+	if policy.MaxBackoff < policy.InitialBackoff {
+		policy.MaxBackoff = policy.InitialBackoff
+	}
+	return &RetryChunkManager{
+		ChunkManager: chunkManager,
+		storageType:  storageType,
+		policy:       policy,
+	}
+}
+
+type retryCounterKey struct{}
+
+// RetryCounter counts the retries of the storage operations executed with the context it is attached to, like all
+// the operations of a backup
+type RetryCounter struct {
+	retries int64
+}
+
+// WithRetryCounter returns a context with which the retries of storage operations are counted by counter
+func WithRetryCounter(ctx context.Context, counter *RetryCounter) context.Context {
+	return context.WithValue(ctx, retryCounterKey{}, counter)
+}
+
+// Count returns the retries counted
+func (c *RetryCounter) Count() int64 {
+	if c == nil {
+		return 0
+	}
+	return atomic.LoadInt64(&c.retries)
+}
+
+// IsRetryableError returns whether the error of a storage operation is transient and the operation can be retried
+func IsRetryableError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCircuitOpen) {
+		return false
+	}
+	if errors.Is(err, ErrNoSuchKey) || IsErrNoSuchKey(err) {
+		return false
+	}
+
+	var minioErr minio.ErrorResponse
+	if errors.As(err, &minioErr) {
+		switch minioErr.Code {
+		case "SlowDown", "Throttling", "ThrottlingException", "RequestTimeout", "InternalError", "ServiceUnavailable":
+			return true
+		}
+		if minioErr.StatusCode != 0 {
+			return isRetryableStatus(minioErr.StatusCode)
+		}
+	}
+	var azureErr *azcore.ResponseError
+	if errors.As(err, &azureErr) {
+		return isRetryableStatus(azureErr.StatusCode)
+	}
+	var gcsErr *googleapi.Error
+	if errors.As(err, &gcsErr) {
+		return isRetryableStatus(gcsErr.Code)
+	}
+	var hdfsErr *HDFSError
+	if errors.As(err, &hdfsErr) {
+		// thrown by the namenode in safe mode or too busy
+		return hdfsErr.Exception == "RetriableException" || isRetryableStatus(hdfsErr.StatusCode)
+	}
+
+	if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, syscall.ECONNRESET) || errors.Is(err, syscall.ECONNREFUSED) || errors.Is(err, syscall.EPIPE) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	// some clients only keep the message of the cause
+	msg := strings.ToLower(err.Error())
+	for _, transient := range []string{"connection reset", "broken pipe", "slow down", "please reduce your request rate", "service unavailable"} {
+		if strings.Contains(msg, transient) {
+			return true
+		}
+	}
+	return false
+}
+
+// isRetryableStatus returns whether the http status of a response is throttling or a server error
+func isRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status == http.StatusRequestTimeout || status >= http.StatusInternalServerError
+}
+
+// do runs fn until it succeeds, fails by an error not retryable or all attempts are used up
+func (rcm *RetryChunkManager) do(ctx context.Context, operation string, fn func() error) error {
+	if err := rcm.allow(); err != nil {
+		return err
+	}
+	backoff := rcm.policy.InitialBackoff
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || !IsRetryableError(err) || attempt >= rcm.policy.MaxAttempts {
+			break
+		}
+		metrics.StorageRetries.WithLabelValues(rcm.storageType, operation).Inc()
+		if counter, ok := ctx.Value(retryCounterKey{}).(*RetryCounter); ok {
+			atomic.AddInt64(&counter.retries, 1)
+		}
+		log.Warn("retry storage operation",
+			zap.String("storageType", rcm.storageType),
+			zap.String("operation", operation),
+			zap.Int("attempt", attempt),
+			zap.Duration("backoff", backoff),
+			zap.Error(err))
+		select {
+		case <-time.After(jitter(backoff)):
+		case <-ctx.Done():
+			return err
+		}
+		backoff *= 2
+		if backoff > rcm.policy.MaxBackoff {
+			backoff = rcm.policy.MaxBackoff
+		}
+	}
+	rcm.record(err)
+	return err
+}
+
+// jitter returns a random duration between half of backoff and backoff, so that the workers failed together
+// don't retry at the same time
+func jitter(backoff time.Duration) time.Duration {
+	if backoff <= 1 {
+		return backoff
+	}
+	half := backoff / 2
+	return half + time.Duration(rand.Int63n(int64(backoff-half)))
+}
+
+// allow returns ErrCircuitOpen if the circuit breaker is open. After the cooldown operations are allowed again,
+// the circuit breaker opens again on the next failure and closes on the next success.
+func (rcm *RetryChunkManager) allow() error {
+	if rcm.policy.CircuitBreakerThreshold <= 0 {
+		return nil
+	}
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	if time.Now().Before(rcm.openUntil) {
+		return ErrCircuitOpen
+	}
+	return nil
+}
+
+// record counts the consecutive operations failed by transient errors, errors not retryable like a missing key
+// prove the storage is available
+func (rcm *RetryChunkManager) record(err error) {
+	if rcm.policy.CircuitBreakerThreshold <= 0 {
+		return
+	}
+	rcm.mu.Lock()
+	defer rcm.mu.Unlock()
+	if !IsRetryableError(err) {
+		if rcm.failures >= rcm.policy.CircuitBreakerThreshold {
+			log.Info("storage circuit breaker closed", zap.String("storageType", rcm.storageType))
+			metrics.StorageCircuitBreakerOpen.WithLabelValues(rcm.storageType).Set(0)
+		}
+		rcm.failures = 0
+		return
+	}
+	rcm.failures++
+	if rcm.failures >= rcm.policy.CircuitBreakerThreshold {
+		rcm.openUntil = time.Now().Add(rcm.policy.CircuitBreakerCooldown)
+		log.Warn("storage circuit breaker opened",
+			zap.String("storageType", rcm.storageType),
+			zap.Int("failures", rcm.failures),
+			zap.Duration("cooldown", rcm.policy.CircuitBreakerCooldown),
+			zap.Error(err))
+		metrics.StorageCircuitBreakerOpen.WithLabelValues(rcm.storageType).Set(1)
+	}
+}
+
+func (rcm *RetryChunkManager) Path(ctx context.Context, bucketName string, filePath string) (path string, err error) {
+	err = rcm.do(ctx, "path", func() error {
+		path, err = rcm.ChunkManager.Path(ctx, bucketName, filePath)
+		return err
+	})
+	return path, err
+}
+
+func (rcm *RetryChunkManager) Size(ctx context.Context, bucketName string, filePath string) (size int64, err error) {
+	err = rcm.do(ctx, "size", func() error {
+		size, err = rcm.ChunkManager.Size(ctx, bucketName, filePath)
+		return err
+	})
+	return size, err
+}
+
+func (rcm *RetryChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	return rcm.do(ctx, "write", func() error {
+		return rcm.ChunkManager.Write(ctx, bucketName, filePath, content)
+	})
+}
+
+func (rcm *RetryChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (exist bool, err error) {
+	err = rcm.do(ctx, "exist", func() error {
+		exist, err = rcm.ChunkManager.Exist(ctx, bucketName, filePath)
+		return err
+	})
+	return exist, err
+}
+
+func (rcm *RetryChunkManager) Read(ctx context.Context, bucketName string, filePath string) (data []byte, err error) {
+	err = rcm.do(ctx, "read", func() error {
+		data, err = rcm.ChunkManager.Read(ctx, bucketName, filePath)
+		return err
+	})
+	return data, err
+}
+
+func (rcm *RetryChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) (paths []string, sizes []int64, err error) {
+	err = rcm.do(ctx, "list", func() error {
+		paths, sizes, err = rcm.ChunkManager.ListWithPrefix(ctx, bucketName, prefix, recursive)
+		return err
+	})
+	return paths, sizes, err
+}
+
+// WalkWithPrefix retries the walk only if it fails before any file is walked, so that a file is never walked twice
+func (rcm *RetryChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	walked := false
+	var walkErr error
+	err := rcm.do(ctx, "walk", func() error {
+		err := rcm.ChunkManager.WalkWithPrefix(ctx, bucketName, prefix, recursive, func(filePath string, size int64) error {
+			walked = true
+			return walkFunc(filePath, size)
+		})
+		if err != nil && walked {
+			walkErr = err
+			return nil
+		}
+		return err
+	})
+	if walkErr != nil {
+		return walkErr
+	}
+	return err
+}
+
+func (rcm *RetryChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (page *ListPage, err error) {
+	err = rcm.do(ctx, "list_page", func() error {
+		page, err = rcm.ChunkManager.ListPageWithPrefix(ctx, bucketName, prefix, recursive, token, pageSize)
+		return err
+	})
+	return page, err
+}
+
+func (rcm *RetryChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	return rcm.do(ctx, "remove", func() error {
+		return rcm.ChunkManager.Remove(ctx, bucketName, filePath)
+	})
+}
+
+func (rcm *RetryChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	return rcm.do(ctx, "remove_with_prefix", func() error {
+		return rcm.ChunkManager.RemoveWithPrefix(ctx, bucketName, prefix)
+	})
+}
+
+// Copy copies all objects with the prefix again if any of them fails, objects already copied are overwritten
+func (rcm *RetryChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	return rcm.do(ctx, "copy", func() error {
+		return rcm.ChunkManager.Copy(ctx, fromBucketName, toBucketName, fromPath, toPath)
+	})
+}
+
+func (rcm *RetryChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (pending int, err error) {
+	err = rcm.do(ctx, "rehydrate", func() error {
+		pending, err = rcm.ChunkManager.Rehydrate(ctx, bucketName, prefix, days, tier)
+		return err
+	})
+	return pending, err
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/stretchr/testify/assert"
+)
+
+// flakyChunkManager fails the first failures reads with err
+type flakyChunkManager struct {
+	ChunkManager
+	err      error
+	failures int
+	reads    int
+}
+
+func (f *flakyChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	f.reads++
+	if f.reads <= f.failures {
+		return nil, f.err
+	}
+	return []byte("data"), nil
+}
+
+func TestRetryChunkManager(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	unavailable := minio.ErrorResponse{Code: "ServiceUnavailable", StatusCode: http.StatusServiceUnavailable}
+
+	// transient errors are retried
+	flaky := &flakyChunkManager{err: unavailable, failures: 2}
+	data, err := NewRetryChunkManager(flaky, "test", policy).Read(ctx, "bucket", "file")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+	assert.Equal(t, 3, flaky.reads)
+
+	// until attempts are used up
+	flaky = &flakyChunkManager{err: unavailable, failures: 3}
+	counter := &RetryCounter{}
+	_, err = NewRetryChunkManager(flaky, "test", policy).Read(WithRetryCounter(ctx, counter), "bucket", "file")
+	assert.Equal(t, unavailable, err)
+	assert.Equal(t, 3, flaky.reads)
+	// the last attempt is not a retry
+	assert.Equal(t, int64(2), counter.Count())
+
+	// other errors are returned at once
+	flaky = &flakyChunkManager{err: WrapErrNoSuchKey("file"), failures: 1}
+	_, err = NewRetryChunkManager(flaky, "test", policy).Read(ctx, "bucket", "file")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	assert.Equal(t, 1, flaky.reads)
+}
+
+// flakyWalkChunkManager walks the files of walked before failing with err, for the first failures walks
+type flakyWalkChunkManager struct {
+	ChunkManager
+	err      error
+	failures int
+	walked   int
+	walks    int
+}
+
+func (f *flakyWalkChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	f.walks++
+	for i := 0; i < f.walked; i++ {
+		if err := walkFunc("file", 1); err != nil {
+			return err
+		}
+	}
+	if f.walks <= f.failures {
+		return f.err
+	}
+	return nil
+}
+
+func TestRetryChunkManagerWalk(t *testing.T) {
+	ctx := context.Background()
+	policy := RetryPolicy{MaxAttempts: 3, InitialBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	unavailable := minio.ErrorResponse{Code: "ServiceUnavailable", StatusCode: http.StatusServiceUnavailable}
+	walkFunc := func(filePath string, size int64) error { return nil }
+
+	// a walk failed before any file is walked is retried
+	flaky := &flakyWalkChunkManager{err: unavailable, failures: 2}
+	assert.NoError(t, NewRetryChunkManager(flaky, "test", policy).WalkWithPrefix(ctx, "bucket", "prefix", true, walkFunc))
+	assert.Equal(t, 3, flaky.walks)
+
+	// but not after files are walked
+	flaky = &flakyWalkChunkManager{err: unavailable, failures: 2, walked: 1}
+	assert.Equal(t, unavailable, NewRetryChunkManager(flaky, "test", policy).WalkWithPrefix(ctx, "bucket", "prefix", true, walkFunc))
+	assert.Equal(t, 1, flaky.walks)
+}
+
+func TestRetryChunkManagerCircuitBreaker(t *testing.T) {
+	ctx := context.Background()
+	flaky := &flakyChunkManager{err: syscall.ECONNRESET, failures: 2}
+	rcm := NewRetryChunkManager(flaky, "test", RetryPolicy{
+		MaxAttempts:             1,
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  50 * time.Millisecond,
+	})
+
+	_, err := rcm.Read(ctx, "bucket", "file")
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+	_, err = rcm.Read(ctx, "bucket", "file")
+	assert.ErrorIs(t, err, syscall.ECONNRESET)
+
+	// fails fast while the circuit breaker is open
+	_, err = rcm.Read(ctx, "bucket", "file")
+	assert.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Equal(t, 2, flaky.reads)
+
+	// allowed again after cooldown
+	time.Sleep(60 * time.Millisecond)
+	_, err = rcm.Read(ctx, "bucket", "file")
+	assert.NoError(t, err)
+	assert.Equal(t, 3, flaky.reads)
+}
+
+func TestIsRetryableError(t *testing.T) {
+	assert.True(t, IsRetryableError(minio.ErrorResponse{Code: "SlowDown", StatusCode: http.StatusServiceUnavailable}))
+	assert.True(t, IsRetryableError(minio.ErrorResponse{StatusCode: http.StatusInternalServerError}))
+	assert.True(t, IsRetryableError(syscall.ECONNRESET))
+	assert.True(t, IsRetryableError(errors.New("read tcp: connection reset by peer")))
+
+	assert.False(t, IsRetryableError(nil))
+	assert.False(t, IsRetryableError(minio.ErrorResponse{Code: "AccessDenied", StatusCode: http.StatusForbidden}))
+	assert.False(t, IsRetryableError(WrapErrNoSuchKey("file")))
+	assert.False(t, IsRetryableError(context.Canceled))
+	assert.False(t, IsRetryableError(ErrCircuitOpen))
+}
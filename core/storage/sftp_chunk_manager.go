@@ -0,0 +1,460 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"math/rand"
+	"net"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/pkg/sftp"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+
+	"github.com/zilliztech/milvus-backup/core/utils"
+)
+
+const sftpDialTimeout = 30 * time.Second
+
+// SFTPChunkManager is responsible for read and write data stored in a sftp server, logged in by a private key with
+// the host key verified by known_hosts. The objects of a bucket are the files in the directory of the bucket name,
+// relative to the login directory unless it starts with '/'. Connections are pooled, each operation takes one of
+// them, up to maxConnections are opened when operations run at the same time.
+type SFTPChunkManager struct {
+	address   string
+	sshConfig *ssh.ClientConfig
+
+	// idle connections
+	idle chan *sftpConn
+	// a slot is taken by each open connection
+	slots chan struct{}
+}
+
+var _ ChunkManager = (*SFTPChunkManager)(nil)
+
+type sftpConn struct {
+	ssh    *ssh.Client
+	client *sftp.Client
+	// closed once the sftp session ends
+	done chan struct{}
+}
+
+// broken returns whether the connection is closed
+func (conn *sftpConn) broken() bool {
+	select {
+	case <-conn.done:
+		return true
+	default:
+		return false
+	}
+}
+
+func (conn *sftpConn) close() {
+	conn.client.Close()
+	conn.ssh.Close()
+}
+
+func NewSFTPChunkManager(ctx context.Context, c *config) (*SFTPChunkManager, error) {
+	if strings.HasPrefix(c.address, ":") {
+		return nil, errors.New("address of the sftp server is not set")
+	}
+	key, err := os.ReadFile(c.sftpPrivateKeyFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read sftp private key")
+	}
+	passphrase, err := utils.NewSecret(c.sftpPrivateKeyPassphrase, 0).Value()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read sftp private key passphrase")
+	}
+	var signer ssh.Signer
+	if passphrase != "" {
+		signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase))
+	} else {
+		signer, err = ssh.ParsePrivateKey(key)
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to parse sftp private key")
+	}
+	knownHostsFile := c.sftpKnownHostsFile
+	if knownHostsFile == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to find known_hosts")
+		}
+		knownHostsFile = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	hostKeyCallback, err := knownhosts.New(knownHostsFile)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read known_hosts")
+	}
+	maxConnections := c.sftpMaxConnections
+	if maxConnections <= 0 {
+		maxConnections = 1
+	}
+	return &SFTPChunkManager{
+		address: c.address,
+		sshConfig: &ssh.ClientConfig{
+			User:            c.sftpUser,
+			Auth:            []ssh.AuthMethod{ssh.PublicKeys(signer)},
+			HostKeyCallback: hostKeyCallback,
+			Timeout:         sftpDialTimeout,
+		},
+		idle:  make(chan *sftpConn, maxConnections),
+		slots: make(chan struct{}, maxConnections),
+	}, nil
+}
+
+func (scm *SFTPChunkManager) dial(ctx context.Context) (*sftpConn, error) {
+	dialer := &net.Dialer{Timeout: sftpDialTimeout}
+	netConn, err := dialer.DialContext(ctx, "tcp", scm.address)
+	if err != nil {
+		return nil, err
+	}
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, scm.address, scm.sshConfig)
+	if err != nil {
+		netConn.Close()
+		return nil, errors.Wrapf(err, "failed to login sftp server %s", scm.address)
+	}
+	sshClient := ssh.NewClient(sshConn, chans, reqs)
+	// the files are written to temporary files renamed at last, so they can be written out of order
+	client, err := sftp.NewClient(sshClient, sftp.UseConcurrentWrites(true))
+	if err != nil {
+		sshClient.Close()
+		return nil, errors.Wrapf(err, "sftp is not served by %s", scm.address)
+	}
+	conn := &sftpConn{ssh: sshClient, client: client, done: make(chan struct{})}
+	go func() {
+		client.Wait()
+		close(conn.done)
+	}()
+	log.Debug("open sftp connection", zap.String("address", scm.address))
+	return conn, nil
+}
+
+// acquire takes an idle connection, or opens one if less than maxConnections are open
+func (scm *SFTPChunkManager) acquire(ctx context.Context) (*sftpConn, error) {
+	for {
+		select {
+		case conn := <-scm.idle:
+			if conn.broken() {
+				scm.drop(conn)
+				continue
+			}
+			return conn, nil
+		default:
+		}
+		select {
+		case conn := <-scm.idle:
+			if conn.broken() {
+				scm.drop(conn)
+				continue
+			}
+			return conn, nil
+		case scm.slots <- struct{}{}:
+			conn, err := scm.dial(ctx)
+			if err != nil {
+				<-scm.slots
+				return nil, err
+			}
+			return conn, nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (scm *SFTPChunkManager) release(conn *sftpConn) {
+	if conn.broken() {
+		scm.drop(conn)
+		return
+	}
+	scm.idle <- conn
+}
+
+func (scm *SFTPChunkManager) drop(conn *sftpConn) {
+	conn.close()
+	<-scm.slots
+}
+
+// with runs fn with a connection of the pool
+func (scm *SFTPChunkManager) with(ctx context.Context, fn func(client *sftp.Client) error) error {
+	conn, err := scm.acquire(ctx)
+	if err != nil {
+		return err
+	}
+	defer scm.release(conn)
+	return fn(conn.client)
+}
+
+// fullPath returns the path in the sftp server of a key
+func (scm *SFTPChunkManager) fullPath(bucketName string, key string) string {
+	fullPath := strings.TrimSuffix(bucketName, "/")
+	if key != "" {
+		if fullPath != "" {
+			fullPath += "/"
+		}
+		fullPath += strings.TrimSuffix(key, "/")
+	}
+	if fullPath == "" {
+		return "."
+	}
+	return fullPath
+}
+
+// notFound converts the status no such file into ErrNoSuchKey
+func notFound(err error, key string) error {
+	if os.IsNotExist(err) {
+		return fmt.Errorf("%w: %v", WrapErrFileNotFound(key), err)
+	}
+	return err
+}
+
+// Path returns the path of the file if exists.
+func (scm *SFTPChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	exist, err := scm.Exist(ctx, bucketName, filePath)
+	if err != nil {
+		return "", err
+	}
+	if !exist {
+		return "", WrapErrFileNotFound(filePath)
+	}
+	return filePath, nil
+}
+
+func (scm *SFTPChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	var size int64
+	err := scm.with(ctx, func(client *sftp.Client) error {
+		info, err := client.Stat(scm.fullPath(bucketName, filePath))
+		if err != nil {
+			return notFound(err, filePath)
+		}
+		if info.IsDir() {
+			return WrapErrFileNotFound(filePath)
+		}
+		size = info.Size()
+		return nil
+	})
+	return size, err
+}
+
+// Write writes the data into a temporary file renamed to filePath at last, so that a file is never read partially
+// written.
+func (scm *SFTPChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	return scm.with(ctx, func(client *sftp.Client) error {
+		return writeSFTPFile(client, scm.fullPath(bucketName, filePath), bytes.NewReader(content))
+	})
+}
+
+func writeSFTPFile(client *sftp.Client, fullPath string, reader io.Reader) error {
+	tmpPath := fullPath + ".tmp-" + strconv.FormatInt(rand.Int63(), 36)
+	file, err := client.Create(tmpPath)
+	if os.IsNotExist(err) {
+		// the directories are created only if they don't exist
+		if dir := path.Dir(fullPath); dir != "." && dir != "/" {
+			if err := client.MkdirAll(dir); err != nil {
+				return err
+			}
+		}
+		file, err = client.Create(tmpPath)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = file.ReadFrom(reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = renameSFTP(client, tmpPath, fullPath)
+	}
+	if err != nil {
+		client.Remove(tmpPath)
+		return err
+	}
+	return nil
+}
+
+// renameSFTP renames the file to newPath, replacing the file of newPath if exists. The rename of sftp v3 fails if
+// newPath exists, posix-rename@openssh.com replaces it if the server supports it.
+func renameSFTP(client *sftp.Client, oldPath string, newPath string) error {
+	if _, ok := client.HasExtension("posix-rename@openssh.com"); ok {
+		return client.PosixRename(oldPath, newPath)
+	}
+	if err := client.Rename(oldPath, newPath); err == nil {
+		return nil
+	}
+	if err := client.Remove(newPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return client.Rename(oldPath, newPath)
+}
+
+// Exist returns whether filePath is a file, directories are not objects.
+func (scm *SFTPChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	var exist bool
+	err := scm.with(ctx, func(client *sftp.Client) error {
+		info, err := client.Stat(scm.fullPath(bucketName, filePath))
+		if os.IsNotExist(err) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		exist = !info.IsDir()
+		return nil
+	})
+	return exist, err
+}
+
+func (scm *SFTPChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	var data []byte
+	err := scm.with(ctx, func(client *sftp.Client) error {
+		reader, err := client.Open(scm.fullPath(bucketName, filePath))
+		if err != nil {
+			return notFound(err, filePath)
+		}
+		defer reader.Close()
+		data, err = io.ReadAll(reader)
+		return err
+	})
+	return data, err
+}
+
+// listDir lists a directory of the bucket for walkDirs, the connection is not held while the entries are walked
+func (scm *SFTPChunkManager) listDir(ctx context.Context, bucketName string) listDirFunc {
+	return func(dir string) ([]dirEntry, error) {
+		var entries []dirEntry
+		err := scm.with(ctx, func(client *sftp.Client) error {
+			infos, err := client.ReadDir(scm.fullPath(bucketName, dir))
+			if os.IsNotExist(err) {
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			entries = make([]dirEntry, 0, len(infos))
+			for _, info := range infos {
+				entries = append(entries, dirEntry{name: info.Name(), dir: info.IsDir(), size: info.Size()})
+			}
+			return nil
+		})
+		return entries, err
+	}
+}
+
+// ListWithPrefix lists the files with prefix like object storage, directories listed not recursively end with '/'
+// and their sizes are 0.
+func (scm *SFTPChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	var filePaths []string
+	var sizes []int64
+	err := scm.WalkWithPrefix(ctx, bucketName, prefix, recursive, func(filePath string, size int64) error {
+		filePaths = append(filePaths, filePath)
+		sizes = append(sizes, size)
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return filePaths, sizes, nil
+}
+
+// WalkWithPrefix walks the files with prefix like ListWithPrefix, directory by directory.
+func (scm *SFTPChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	return walkDirs(ctx, scm.listDir(ctx, bucketName), prefix, recursive, "", walkFunc)
+}
+
+// ListPageWithPrefix lists a page of the files with prefix in the order of their keys, the token is the last key of
+// the previous page.
+func (scm *SFTPChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	return listDirsPage(ctx, scm.listDir(ctx, bucketName), prefix, recursive, token, pageSize)
+}
+
+func (scm *SFTPChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	return scm.with(ctx, func(client *sftp.Client) error {
+		if err := removeAllSFTP(client, scm.fullPath(bucketName, filePath)); err != nil {
+			return err
+		}
+		scm.removeEmptyDirs(client, bucketName, path.Dir(filePath))
+		return nil
+	})
+}
+
+// removeAllSFTP removes a file, or a directory with everything in it, nothing if it doesn't exist
+func removeAllSFTP(client *sftp.Client, fullPath string) error {
+	if err := client.RemoveAll(fullPath); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// removeEmptyDirs removes dir and its parents in the bucket if they are empty, directories are implicit in object
+// storage, a directory left empty would be listed like a backup without meta
+func (scm *SFTPChunkManager) removeEmptyDirs(client *sftp.Client, bucketName string, dir string) {
+	for dir != "." && dir != "/" && dir != "" {
+		// fails if the dir is not empty
+		if err := client.RemoveDirectory(scm.fullPath(bucketName, dir)); err != nil {
+			return
+		}
+		dir = path.Dir(dir)
+	}
+}
+
+func (scm *SFTPChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	// an empty prefix would remove the whole bucket
+	if len(prefix) == 0 {
+		errMsg := "empty prefix is not allowed for ChunkManager remove operation"
+		log.Warn(errMsg)
+		return errors.New(errMsg)
+	}
+
+	err := scm.WalkWithPrefix(ctx, bucketName, prefix, false, func(filePath string, size int64) error {
+		return scm.with(ctx, func(client *sftp.Client) error {
+			return removeAllSFTP(client, scm.fullPath(bucketName, filePath))
+		})
+	})
+	if err != nil {
+		return err
+	}
+	return scm.with(ctx, func(client *sftp.Client) error {
+		dir, _ := splitPrefix(prefix)
+		scm.removeEmptyDirs(client, bucketName, strings.TrimSuffix(dir, "/"))
+		return nil
+	})
+}
+
+// Copy copies the files with prefix fromPath to toPath, sftp has no copy so the data is read and written by us
+func (scm *SFTPChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	// listed first, toPath may have the prefix of fromPath
+	filePaths, _, err := scm.ListWithPrefix(ctx, fromBucketName, fromPath, true)
+	if err != nil {
+		return err
+	}
+	for _, filePath := range filePaths {
+		err := scm.with(ctx, func(client *sftp.Client) error {
+			reader, err := client.Open(scm.fullPath(fromBucketName, filePath))
+			if err != nil {
+				return notFound(err, filePath)
+			}
+			defer reader.Close()
+			return writeSFTPFile(client, scm.fullPath(toBucketName, strings.Replace(filePath, fromPath, toPath, 1)), reader)
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Rehydrate does nothing, sftp files are never archived
+func (scm *SFTPChunkManager) Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error) {
+	return 0, nil
+}
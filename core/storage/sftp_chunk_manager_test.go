@@ -0,0 +1,201 @@
+package storage
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/pkg/sftp"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// fakeSFTPServer serves sftp over ssh on the files of a temporary dir, the login dir. It only accepts the client key.
+type fakeSFTPServer struct {
+	root     string
+	listener net.Listener
+
+	mu          sync.Mutex
+	connections int
+}
+
+func newFakeSFTPServer(t *testing.T, hostKey ssh.Signer, clientKey ssh.PublicKey) *fakeSFTPServer {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := &fakeSFTPServer{root: t.TempDir(), listener: listener}
+	config := &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if conn.User() == "backup" && string(key.Marshal()) == string(clientKey.Marshal()) {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	}
+	config.AddHostKey(hostKey)
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serveConn(conn, config)
+		}
+	}()
+	t.Cleanup(func() { listener.Close() })
+	return server
+}
+
+func (s *fakeSFTPServer) serveConn(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		return
+	}
+	s.mu.Lock()
+	s.connections++
+	s.mu.Unlock()
+	go ssh.DiscardRequests(reqs)
+	for newChannel := range chans {
+		channel, requests, err := newChannel.Accept()
+		if err != nil {
+			return
+		}
+		go func() {
+			for req := range requests {
+				ok := req.Type == "subsystem" && string(req.Payload[4:]) == "sftp"
+				req.Reply(ok, nil)
+				if !ok {
+					continue
+				}
+				server, err := sftp.NewServer(channel, sftp.WithServerWorkingDirectory(s.root))
+				if err != nil {
+					channel.Close()
+					continue
+				}
+				go server.Serve()
+			}
+		}()
+	}
+}
+
+func TestSFTPChunkManager(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	_, hostPrivate, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	hostKey, err := ssh.NewSignerFromKey(hostPrivate)
+	assert.NoError(t, err)
+	_, clientPrivate, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	clientKey, err := ssh.NewSignerFromKey(clientPrivate)
+	assert.NoError(t, err)
+	server := newFakeSFTPServer(t, hostKey, clientKey.PublicKey())
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(clientPrivate)
+	assert.NoError(t, err)
+	keyFile := filepath.Join(dir, "id_ed25519")
+	assert.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), 0o600))
+	knownHostsFile := filepath.Join(dir, "known_hosts")
+	address := server.listener.Addr().String()
+	assert.NoError(t, os.WriteFile(knownHostsFile, []byte(knownhosts.Line([]string{address}, hostKey.PublicKey())+"\n"), 0o600))
+
+	c := newDefaultConfig()
+	c.address = address
+	c.sftpUser = "backup"
+	c.sftpPrivateKeyFile = keyFile
+	c.sftpKnownHostsFile = knownHostsFile
+	c.sftpMaxConnections = 2
+	scm, err := NewSFTPChunkManager(ctx, c)
+	assert.NoError(t, err)
+
+	large := make([]byte, 300*1024+123)
+	rand.Read(large)
+	for _, key := range []string{"a.txt", "a/b/1", "a/b/2", "a/c", "a/d/e/3", "z"} {
+		assert.NoError(t, scm.Write(ctx, "bucket", key, []byte("data of "+key)))
+	}
+	assert.NoError(t, scm.Write(ctx, "bucket", "large", large))
+	data, err := os.ReadFile(filepath.Join(server.root, "bucket", "a", "b", "1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "data of a/b/1", string(data))
+
+	data, err = scm.Read(ctx, "bucket", "large")
+	assert.NoError(t, err)
+	assert.Equal(t, large, data)
+	_, err = scm.Read(ctx, "bucket", "a/b/3")
+	assert.ErrorIs(t, err, ErrNoSuchKey)
+	size, err := scm.Size(ctx, "bucket", "a/c")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len("data of a/c")), size)
+	exist, err := scm.Exist(ctx, "bucket", "a/b")
+	assert.NoError(t, err)
+	assert.False(t, exist)
+	exist, err = scm.Exist(ctx, "bucket", "a/b/2")
+	assert.NoError(t, err)
+	assert.True(t, exist)
+
+	// an existing file is replaced
+	assert.NoError(t, scm.Write(ctx, "bucket", "a.txt", []byte("new")))
+	data, err = scm.Read(ctx, "bucket", "a.txt")
+	assert.NoError(t, err)
+	assert.Equal(t, "new", string(data))
+
+	paths, sizes, err := scm.ListWithPrefix(ctx, "bucket", "a", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "a/b/1", "a/b/2", "a/c", "a/d/e/3"}, paths)
+	assert.Equal(t, int64(3), sizes[0])
+	paths, _, err = scm.ListWithPrefix(ctx, "bucket", "a/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/b/", "a/c", "a/d/"}, paths)
+	page, err := scm.ListPageWithPrefix(ctx, "bucket", "a", true, "a/b/2", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/c", "a/d/e/3"}, page.Paths)
+
+	assert.NoError(t, scm.Copy(ctx, "bucket", "copy", "", "backup/"))
+	data, err = scm.Read(ctx, "copy", "backup/large")
+	assert.NoError(t, err)
+	assert.Equal(t, large, data)
+
+	assert.NoError(t, scm.Remove(ctx, "bucket", "a/d/e/3"))
+	paths, _, err = scm.ListWithPrefix(ctx, "bucket", "a/", false)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a/b/", "a/c"}, paths)
+	assert.Error(t, scm.RemoveWithPrefix(ctx, "bucket", ""))
+	assert.NoError(t, scm.RemoveWithPrefix(ctx, "bucket", "a/"))
+	paths, _, err = scm.ListWithPrefix(ctx, "bucket", "", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a.txt", "large", "z"}, paths)
+	_, err = os.Stat(filepath.Join(server.root, "bucket", "a"))
+	assert.True(t, os.IsNotExist(err))
+
+	// connections are pooled
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := scm.Read(ctx, "bucket", "large")
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+	server.mu.Lock()
+	assert.LessOrEqual(t, server.connections, 2)
+	server.mu.Unlock()
+
+	// the host key is verified
+	c.sftpKnownHostsFile = filepath.Join(dir, "other_hosts")
+	other, err := ssh.NewSignerFromKey(clientPrivate)
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(c.sftpKnownHostsFile, []byte(knownhosts.Line([]string{address}, other.PublicKey())+"\n"), 0o600))
+	scm, err = NewSFTPChunkManager(ctx, c)
+	assert.NoError(t, err)
+	_, err = scm.Read(ctx, "bucket", "z")
+	assert.ErrorContains(t, err, "key mismatch")
+}
@@ -0,0 +1,113 @@
+package storage
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/base64"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/encrypt"
+)
+
+const (
+	// SSETypeKMS encrypts objects by a key managed by the kms of the cloud, SSE-KMS of s3 or CMEK of gcs
+	SSETypeKMS = "kms"
+	// SSETypeCustomer encrypts objects by a key provided with every request, SSE-C of s3 or CSEK of gcs.
+	// The storage doesn't keep the key, objects can't be read without it.
+	SSETypeCustomer = "customer"
+)
+
+// ServerSideEncryption is how the storage encrypts the objects written, or how the objects read were encrypted
+type ServerSideEncryption struct {
+	Type string
+	// key id or arn of aws kms, resource name of cloud kms key for gcs.
+	// empty means the aws managed key on s3
+	KMSKeyID string
+	// AES-256 key of SSETypeCustomer
+	CustomerKey []byte
+}
+
+// CustomerKeyMD5 returns the base64 encoded MD5 of the customer key, which identifies the key without revealing it
+func (sse ServerSideEncryption) CustomerKeyMD5() string {
+	if len(sse.CustomerKey) == 0 {
+		return ""
+	}
+	sum := md5.Sum(sse.CustomerKey)
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+type sseKey struct{}
+
+type sourceSSEKey struct{}
+
+// WithServerSideEncryption returns a context with which the files written or copied to are encrypted by sse,
+// on the storages supporting it. Files are encrypted as the default of the bucket if the type of sse is empty.
+func WithServerSideEncryption(ctx context.Context, sse ServerSideEncryption) context.Context {
+	if sse.Type == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, sseKey{}, sse)
+}
+
+// WithoutCustomerKey returns a context with which the files written aren't encrypted by the customer key of ctx,
+// the files encrypted by kms are still encrypted
+func WithoutCustomerKey(ctx context.Context) context.Context {
+	if sse, ok := sseFromContext(ctx); ok && sse.Type == SSETypeCustomer {
+		return context.WithValue(ctx, sseKey{}, ServerSideEncryption{})
+	}
+	return ctx
+}
+
+// WithSourceServerSideEncryption returns a context with which the files read or copied from are decrypted by sse.
+// Only the files encrypted by customer key need it, kms keys are found by the storage.
+func WithSourceServerSideEncryption(ctx context.Context, sse ServerSideEncryption) context.Context {
+	if sse.Type != SSETypeCustomer {
+		return ctx
+	}
+	return context.WithValue(ctx, sourceSSEKey{}, sse)
+}
+
+func sseFromContext(ctx context.Context) (ServerSideEncryption, bool) {
+	sse, ok := ctx.Value(sseKey{}).(ServerSideEncryption)
+	return sse, ok && sse.Type != ""
+}
+
+// SourceServerSideEncryption returns the customer key the files read with ctx are decrypted by
+func SourceServerSideEncryption(ctx context.Context) (ServerSideEncryption, bool) {
+	sse, ok := ctx.Value(sourceSSEKey{}).(ServerSideEncryption)
+	return sse, ok
+}
+
+// minioSSE converts sse into the encryption of minio client, nil means the default of the bucket
+func minioSSE(sse ServerSideEncryption) (encrypt.ServerSide, error) {
+	switch sse.Type {
+	case SSETypeKMS:
+		return encrypt.NewSSEKMS(sse.KMSKeyID, nil)
+	case SSETypeCustomer:
+		return encrypt.NewSSEC(sse.CustomerKey)
+	default:
+		return nil, nil
+	}
+}
+
+// minioWriteSSE returns the encryption of the objects written with ctx
+func minioWriteSSE(ctx context.Context) (encrypt.ServerSide, error) {
+	sse, ok := sseFromContext(ctx)
+	if !ok {
+		return nil, nil
+	}
+	return minioSSE(sse)
+}
+
+// getObjectOptions returns the options to read or stat an object with ctx, with the customer key of the object
+func getObjectOptions(ctx context.Context) (minio.GetObjectOptions, error) {
+	sse, ok := SourceServerSideEncryption(ctx)
+	if !ok {
+		return minio.GetObjectOptions{}, nil
+	}
+	serverSide, err := minioSSE(sse)
+	if err != nil {
+		return minio.GetObjectOptions{}, err
+	}
+	return minio.GetObjectOptions{ServerSideEncryption: serverSide}, nil
+}
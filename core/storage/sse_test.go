@@ -0,0 +1,88 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServerSideEncryptionContext(t *testing.T) {
+	ctx := context.Background()
+	_, ok := sseFromContext(WithServerSideEncryption(ctx, ServerSideEncryption{}))
+	assert.False(t, ok)
+
+	customer := ServerSideEncryption{Type: SSETypeCustomer, CustomerKey: bytes.Repeat([]byte{1}, 32)}
+	sse, ok := sseFromContext(WithServerSideEncryption(ctx, customer))
+	assert.True(t, ok)
+	assert.Equal(t, customer, sse)
+	_, ok = sseFromContext(WithoutCustomerKey(WithServerSideEncryption(ctx, customer)))
+	assert.False(t, ok)
+
+	// kms keys are kept and not needed to read
+	kms := ServerSideEncryption{Type: SSETypeKMS, KMSKeyID: "backup-key"}
+	_, ok = sseFromContext(WithoutCustomerKey(WithServerSideEncryption(ctx, kms)))
+	assert.True(t, ok)
+	_, ok = SourceServerSideEncryption(WithSourceServerSideEncryption(ctx, kms))
+	assert.False(t, ok)
+	_, ok = SourceServerSideEncryption(WithSourceServerSideEncryption(ctx, customer))
+	assert.True(t, ok)
+
+	assert.Equal(t, "", kms.CustomerKeyMD5())
+	assert.Equal(t, 24, len(customer.CustomerKeyMD5()))
+}
+
+func TestMinioServerSideEncryption(t *testing.T) {
+	headers := map[string]http.Header{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		headers[r.Method] = r.Header.Clone()
+		w.Header().Set("ETag", `"etag"`)
+		switch {
+		case r.Method == http.MethodPut && r.Header.Get("X-Amz-Copy-Source") != "":
+			w.Write([]byte(`<CopyObjectResult><ETag>"etag"</ETag></CopyObjectResult>`))
+		case r.Method == http.MethodGet && r.URL.Query().Get("list-type") == "2":
+			w.Write([]byte(`<ListBucketResult><Name>bucket</Name><IsTruncated>false</IsTruncated>` +
+				`<Contents><Key>backup/binlog</Key><Size>6</Size></Contents></ListBucketResult>`))
+		default:
+			w.Header().Set("Content-Length", "6")
+			w.Header().Set("Last-Modified", "Mon, 02 Jan 2006 15:04:05 GMT")
+			w.Write([]byte("binlog"))
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	client, err := minio.New(serverURL.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4("ak", "sk", ""),
+		Region:       "us-east-1",
+		BucketLookup: minio.BucketLookupPath,
+	})
+	assert.NoError(t, err)
+	mcm := &MinioChunkManager{Client: client, concurrency: 1}
+
+	kms := ServerSideEncryption{Type: SSETypeKMS, KMSKeyID: "arn:aws:kms:us-east-1:111122223333:key/backup"}
+	assert.NoError(t, mcm.Write(WithServerSideEncryption(context.Background(), kms), "bucket", "backup/meta.json", []byte("meta")))
+	assert.Equal(t, "aws:kms", headers[http.MethodPut].Get("X-Amz-Server-Side-Encryption"))
+	assert.Equal(t, kms.KMSKeyID, headers[http.MethodPut].Get("X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"))
+
+	customer := ServerSideEncryption{Type: SSETypeCustomer, CustomerKey: bytes.Repeat([]byte{1}, 32)}
+	data, err := mcm.Read(WithSourceServerSideEncryption(context.Background(), customer), "bucket", "backup/binlog")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("binlog"), data)
+	for _, method := range []string{http.MethodHead, http.MethodGet} {
+		assert.Equal(t, "AES256", headers[method].Get("X-Amz-Server-Side-Encryption-Customer-Algorithm"))
+		assert.Equal(t, customer.CustomerKeyMD5(), headers[method].Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5"))
+	}
+
+	// restore copies the binlogs encrypted by customer key into milvus bucket as the default of the bucket
+	delete(headers, http.MethodPut)
+	assert.NoError(t, mcm.Copy(WithSourceServerSideEncryption(context.Background(), customer), "bucket", "milvus", "backup/", "restore/"))
+	assert.Equal(t, customer.CustomerKeyMD5(), headers[http.MethodPut].Get("X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key-Md5"))
+	assert.Empty(t, headers[http.MethodPut].Get("X-Amz-Server-Side-Encryption-Customer-Key-Md5"))
+}
@@ -0,0 +1,25 @@
+package storage
+
+import "context"
+
+type storageClassKey struct{}
+
+// WithStorageClass returns a context with which the files written or copied are stored in storageClass, on the
+// storages supporting it. Files are stored in the default class of the bucket if storageClass is empty.
+func WithStorageClass(ctx context.Context, storageClass string) context.Context {
+	if storageClass == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, storageClassKey{}, storageClass)
+}
+
+func storageClassFromContext(ctx context.Context) string {
+	storageClass, _ := ctx.Value(storageClassKey{}).(string)
+	return storageClass
+}
+
+// isArchiveStorageClass returns whether objects in the s3 storage class must be restored before being read,
+// GLACIER_IR is readable at once
+func isArchiveStorageClass(storageClass string) bool {
+	return storageClass == "GLACIER" || storageClass == "DEEP_ARCHIVE"
+}
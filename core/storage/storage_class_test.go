@@ -0,0 +1,64 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMinioRehydrate(t *testing.T) {
+	var restored []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprintf(w, listV2Response, 4, false, `
+<Contents><Key>binlogs/standard</Key><Size>1</Size><StorageClass>STANDARD</StorageClass></Contents>
+<Contents><Key>binlogs/archived</Key><Size>1</Size><StorageClass>DEEP_ARCHIVE</StorageClass></Contents>
+<Contents><Key>binlogs/restoring</Key><Size>1</Size><StorageClass>GLACIER</StorageClass></Contents>
+<Contents><Key>binlogs/restored</Key><Size>1</Size><StorageClass>GLACIER</StorageClass></Contents>`)
+		case http.MethodHead:
+			w.Header().Set("Last-Modified", "Wed, 14 Oct 2026 00:00:00 GMT")
+			w.Header().Set("ETag", `"etag"`)
+			w.Header().Set("Content-Length", "1")
+			switch r.URL.Path {
+			case "/bucket/binlogs/restoring":
+				w.Header().Set("x-amz-restore", `ongoing-request="true"`)
+			case "/bucket/binlogs/restored":
+				w.Header().Set("x-amz-restore", `ongoing-request="false", expiry-date="Fri, 16 Oct 2026 00:00:00 GMT"`)
+			}
+		case http.MethodPost:
+			restored = append(restored, r.URL.Path)
+			w.WriteHeader(http.StatusAccepted)
+		}
+	}))
+	defer server.Close()
+
+	serverURL, _ := url.Parse(server.URL)
+	client, err := minio.New(serverURL.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4("ak", "sk", ""),
+		Region:       "us-east-1",
+		BucketLookup: minio.BucketLookupPath,
+	})
+	assert.NoError(t, err)
+	mcm := &MinioChunkManager{Client: client, concurrency: 2}
+
+	// the restoring and the archived objects are pending, only the archived one is requested to restore
+	pending, err := mcm.Rehydrate(context.Background(), "bucket", "binlogs/", 1, "Bulk")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pending)
+	assert.Equal(t, []string{"/bucket/binlogs/archived"}, restored)
+}
+
+func TestStorageClassContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, "", storageClassFromContext(ctx))
+	assert.Equal(t, "", storageClassFromContext(WithStorageClass(ctx, "")))
+	assert.Equal(t, "DEEP_ARCHIVE", storageClassFromContext(WithStorageClass(ctx, "DEEP_ARCHIVE")))
+}
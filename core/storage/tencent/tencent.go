@@ -0,0 +1,221 @@
+package tencent
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/minio/minio-go/v7"
+	minioCred "github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+var (
+	// MetadataEndpoint is the metadata service of CVM to get the credential of the CAM role bound to the instance
+	MetadataEndpoint = "http://metadata.tencentyun.com/latest/meta-data/cam/security-credentials/"
+	// STSEndpoint is the endpoint to exchange the OIDC token of TKE pods for the credential of a CAM role
+	STSEndpoint = "https://sts.tencentcloudapi.com/"
+)
+
+const (
+	// env of TKE pod identity, set by the pod identity webhook
+	envRegion           = "TKE_REGION"
+	envProviderID       = "TKE_PROVIDER_ID"
+	envWebIdentityToken = "TKE_WEB_IDENTITY_TOKEN_FILE"
+	envRoleArn          = "TKE_ROLE_ARN"
+
+	requestTimeout = 10 * time.Second
+	// credentials are refreshed before they expire
+	expiryWindow = time.Minute
+)
+
+// NewMinioClient returns a minio.Client which is compatible for tencent COS. COS only supports virtual-hosted
+// style requests, creds are got from the CAM role if not set: the OIDC role of TKE pod identity if configured,
+// otherwise the role bound to the CVM instance.
+func NewMinioClient(address string, opts *minio.Options) (*minio.Client, error) {
+	if opts == nil {
+		opts = &minio.Options{}
+	}
+	if opts.Creds == nil {
+		opts.Creds = minioCred.New(NewCredentialProvider())
+	}
+	if address == "" {
+		return nil, errors.New("address of tencent cos is required, like cos.ap-guangzhou.myqcloud.com")
+	}
+	opts.BucketLookup = minio.BucketLookupDNS
+	return minio.New(address, opts)
+}
+
+// NewCredentialProvider returns the provider of the credential of the CAM role, the OIDC role if TKE pod identity
+// is configured by env, otherwise the role of the CVM instance
+func NewCredentialProvider() minioCred.Provider {
+	if os.Getenv(envWebIdentityToken) != "" && os.Getenv(envRoleArn) != "" {
+		return &OIDCRoleProvider{
+			Region:        os.Getenv(envRegion),
+			ProviderID:    os.Getenv(envProviderID),
+			TokenFile:     os.Getenv(envWebIdentityToken),
+			RoleArn:       os.Getenv(envRoleArn),
+			SessionName:   "milvus-backup",
+			client:        &http.Client{Timeout: requestTimeout},
+			stsEndpoint:   STSEndpoint,
+			durationInSec: 7200,
+		}
+	}
+	return &CVMRoleProvider{
+		client:   &http.Client{Timeout: requestTimeout},
+		endpoint: MetadataEndpoint,
+	}
+}
+
+// CVMRoleProvider implements "github.com/minio/minio-go/v7/pkg/credentials".Provider
+// with the temporary credential of the CAM role bound to the CVM instance
+type CVMRoleProvider struct {
+	minioCred.Expiry
+	client   *http.Client
+	endpoint string
+	// role name, got from the metadata service if empty
+	RoleName string
+}
+
+type cvmCredential struct {
+	TmpSecretId  string
+	TmpSecretKey string
+	Token        string
+	ExpiredTime  int64
+	Code         string
+}
+
+// Retrieve returns nil if it successfully retrieved the value.
+// Error is returned if the value were not obtainable, or empty.
+func (p *CVMRoleProvider) Retrieve() (minioCred.Value, error) {
+	if p.RoleName == "" {
+		roles, err := p.get(p.endpoint)
+		if err != nil {
+			return minioCred.Value{}, errors.Wrap(err, "failed to get cam role of cvm")
+		}
+		roleName := strings.TrimSpace(strings.SplitN(string(roles), "\n", 2)[0])
+		if roleName == "" {
+			return minioCred.Value{}, errors.New("no cam role is bound to cvm")
+		}
+		p.RoleName = roleName
+	}
+	body, err := p.get(p.endpoint + p.RoleName)
+	if err != nil {
+		return minioCred.Value{}, errors.Wrap(err, "failed to get credential of cvm cam role")
+	}
+	var cred cvmCredential
+	if err := json.Unmarshal(body, &cred); err != nil {
+		return minioCred.Value{}, errors.Wrap(err, "failed to parse credential of cvm cam role")
+	}
+	if cred.Code != "" && cred.Code != "Success" {
+		return minioCred.Value{}, fmt.Errorf("failed to get credential of cvm cam role %s: %s", p.RoleName, cred.Code)
+	}
+	p.SetExpiration(time.Unix(cred.ExpiredTime, 0), expiryWindow)
+	return minioCred.Value{
+		AccessKeyID:     cred.TmpSecretId,
+		SecretAccessKey: cred.TmpSecretKey,
+		SessionToken:    cred.Token,
+	}, nil
+}
+
+func (p *CVMRoleProvider) get(url string) ([]byte, error) {
+	resp, err := p.client.Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metadata service responds %s", resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// OIDCRoleProvider implements "github.com/minio/minio-go/v7/pkg/credentials".Provider
+// with the credential of a CAM role assumed with the OIDC token of TKE pod identity
+type OIDCRoleProvider struct {
+	minioCred.Expiry
+	Region      string
+	ProviderID  string
+	TokenFile   string
+	RoleArn     string
+	SessionName string
+
+	client        *http.Client
+	stsEndpoint   string
+	durationInSec int64
+}
+
+type assumeRoleResponse struct {
+	Response struct {
+		Credentials struct {
+			Token        string
+			TmpSecretId  string
+			TmpSecretKey string
+		}
+		ExpiredTime int64
+		Error       *struct {
+			Code    string
+			Message string
+		}
+	}
+}
+
+// Retrieve returns nil if it successfully retrieved the value.
+// Error is returned if the value were not obtainable, or empty.
+func (p *OIDCRoleProvider) Retrieve() (minioCred.Value, error) {
+	token, err := os.ReadFile(p.TokenFile)
+	if err != nil {
+		return minioCred.Value{}, errors.Wrap(err, "failed to read oidc token")
+	}
+	payload, err := json.Marshal(map[string]interface{}{
+		"ProviderId":       p.ProviderID,
+		"WebIdentityToken": strings.TrimSpace(string(token)),
+		"RoleArn":          p.RoleArn,
+		"RoleSessionName":  p.SessionName,
+		"DurationSeconds":  p.durationInSec,
+	})
+	if err != nil {
+		return minioCred.Value{}, err
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.stsEndpoint, bytes.NewReader(payload))
+	if err != nil {
+		return minioCred.Value{}, err
+	}
+	// AssumeRoleWithWebIdentity is authenticated by the token instead of a signature
+	req.Header.Set("Authorization", "SKIP")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-TC-Action", "AssumeRoleWithWebIdentity")
+	req.Header.Set("X-TC-Version", "2018-08-13")
+	req.Header.Set("X-TC-Region", p.Region)
+	req.Header.Set("X-TC-Timestamp", fmt.Sprint(time.Now().Unix()))
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return minioCred.Value{}, errors.Wrap(err, "failed to assume cam role with oidc token")
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return minioCred.Value{}, err
+	}
+	var result assumeRoleResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return minioCred.Value{}, errors.Wrap(err, "failed to parse response of assume role")
+	}
+	if result.Response.Error != nil {
+		return minioCred.Value{}, fmt.Errorf("failed to assume cam role %s: %s %s", p.RoleArn, result.Response.Error.Code, result.Response.Error.Message)
+	}
+	p.SetExpiration(time.Unix(result.Response.ExpiredTime, 0), expiryWindow)
+	return minioCred.Value{
+		AccessKeyID:     result.Response.Credentials.TmpSecretId,
+		SecretAccessKey: result.Response.Credentials.TmpSecretKey,
+		SessionToken:    result.Response.Credentials.Token,
+	}, nil
+}
@@ -0,0 +1,81 @@
+package tencent
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCVMRoleProvider(t *testing.T) {
+	expiredTime := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/":
+			fmt.Fprint(w, "backup-role\n")
+		case "/backup-role":
+			fmt.Fprintf(w, `{"TmpSecretId":"id","TmpSecretKey":"key","Token":"token","ExpiredTime":%d,"Code":"Success"}`, expiredTime)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	p := &CVMRoleProvider{client: server.Client(), endpoint: server.URL + "/"}
+	assert.True(t, p.IsExpired())
+	value, err := p.Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "backup-role", p.RoleName)
+	assert.Equal(t, "id", value.AccessKeyID)
+	assert.Equal(t, "key", value.SecretAccessKey)
+	assert.Equal(t, "token", value.SessionToken)
+	assert.False(t, p.IsExpired())
+
+	p = &CVMRoleProvider{client: server.Client(), endpoint: server.URL + "/", RoleName: "other-role"}
+	_, err = p.Retrieve()
+	assert.Error(t, err)
+}
+
+func TestOIDCRoleProvider(t *testing.T) {
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("oidc-token\n"), 0o600))
+
+	expiredTime := time.Now().Add(time.Hour).Unix()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "AssumeRoleWithWebIdentity", r.Header.Get("X-TC-Action"))
+		assert.Equal(t, "ap-guangzhou", r.Header.Get("X-TC-Region"))
+		var req map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+		if req["WebIdentityToken"] != "oidc-token" {
+			fmt.Fprint(w, `{"Response":{"Error":{"Code":"InvalidParameter","Message":"invalid token"}}}`)
+			return
+		}
+		fmt.Fprintf(w, `{"Response":{"Credentials":{"Token":"token","TmpSecretId":"id","TmpSecretKey":"key"},"ExpiredTime":%d}}`, expiredTime)
+	}))
+	defer server.Close()
+
+	p := &OIDCRoleProvider{
+		Region:      "ap-guangzhou",
+		TokenFile:   tokenFile,
+		RoleArn:     "qcs::cam::uin/100000000001:roleName/backup-role",
+		SessionName: "milvus-backup",
+		client:      server.Client(),
+		stsEndpoint: server.URL,
+	}
+	value, err := p.Retrieve()
+	assert.NoError(t, err)
+	assert.Equal(t, "id", value.AccessKeyID)
+	assert.Equal(t, "key", value.SecretAccessKey)
+	assert.Equal(t, "token", value.SessionToken)
+	assert.False(t, p.IsExpired())
+
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("expired-token"), 0o600))
+	_, err = p.Retrieve()
+	assert.Error(t, err)
+}
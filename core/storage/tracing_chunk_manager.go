@@ -0,0 +1,123 @@
+package storage
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	oteltrace "go.opentelemetry.io/otel/trace"
+
+	"github.com/zilliztech/milvus-backup/internal/trace"
+)
+
+// makes sure TracingChunkManager implements `ChunkManager`
+var _ ChunkManager = (*TracingChunkManager)(nil)
+
+// TracingChunkManager records the requests of the wrapped ChunkManager as spans
+type TracingChunkManager struct {
+	ChunkManager
+	storageType string
+}
+
+// NewTracingChunkManager wraps chunkManager, storageType is recorded in the attributes of the spans
+func NewTracingChunkManager(chunkManager ChunkManager, storageType string) *TracingChunkManager {
+	return &TracingChunkManager{
+		ChunkManager: chunkManager,
+		storageType:  storageType,
+	}
+}
+
+func (tcm *TracingChunkManager) start(ctx context.Context, operation string, bucketName string, path string) (context.Context, oteltrace.Span) {
+	return trace.Start(ctx, "storage."+operation,
+		attribute.String("storage.type", tcm.storageType),
+		attribute.String("storage.bucket", bucketName),
+		attribute.String("storage.path", path))
+}
+
+func (tcm *TracingChunkManager) Path(ctx context.Context, bucketName string, filePath string) (string, error) {
+	ctx, span := tcm.start(ctx, "path", bucketName, filePath)
+	path, err := tcm.ChunkManager.Path(ctx, bucketName, filePath)
+	trace.End(span, err)
+	return path, err
+}
+
+func (tcm *TracingChunkManager) Size(ctx context.Context, bucketName string, filePath string) (int64, error) {
+	ctx, span := tcm.start(ctx, "size", bucketName, filePath)
+	size, err := tcm.ChunkManager.Size(ctx, bucketName, filePath)
+	trace.End(span, err)
+	return size, err
+}
+
+func (tcm *TracingChunkManager) Write(ctx context.Context, bucketName string, filePath string, content []byte) error {
+	ctx, span := tcm.start(ctx, "write", bucketName, filePath)
+	span.SetAttributes(attribute.Int("storage.size", len(content)))
+	err := tcm.ChunkManager.Write(ctx, bucketName, filePath, content)
+	trace.End(span, err)
+	return err
+}
+
+func (tcm *TracingChunkManager) Exist(ctx context.Context, bucketName string, filePath string) (bool, error) {
+	ctx, span := tcm.start(ctx, "exist", bucketName, filePath)
+	exist, err := tcm.ChunkManager.Exist(ctx, bucketName, filePath)
+	trace.End(span, err)
+	return exist, err
+}
+
+func (tcm *TracingChunkManager) Read(ctx context.Context, bucketName string, filePath string) ([]byte, error) {
+	ctx, span := tcm.start(ctx, "read", bucketName, filePath)
+	data, err := tcm.ChunkManager.Read(ctx, bucketName, filePath)
+	span.SetAttributes(attribute.Int("storage.size", len(data)))
+	trace.End(span, err)
+	return data, err
+}
+
+func (tcm *TracingChunkManager) ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error) {
+	ctx, span := tcm.start(ctx, "list", bucketName, prefix)
+	paths, sizes, err := tcm.ChunkManager.ListWithPrefix(ctx, bucketName, prefix, recursive)
+	span.SetAttributes(attribute.Int("storage.count", len(paths)))
+	trace.End(span, err)
+	return paths, sizes, err
+}
+
+func (tcm *TracingChunkManager) WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error {
+	ctx, span := tcm.start(ctx, "walk", bucketName, prefix)
+	count := 0
+	err := tcm.ChunkManager.WalkWithPrefix(ctx, bucketName, prefix, recursive, func(filePath string, size int64) error {
+		count++
+		return walkFunc(filePath, size)
+	})
+	span.SetAttributes(attribute.Int("storage.count", count))
+	trace.End(span, err)
+	return err
+}
+
+func (tcm *TracingChunkManager) ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error) {
+	ctx, span := tcm.start(ctx, "list_page", bucketName, prefix)
+	page, err := tcm.ChunkManager.ListPageWithPrefix(ctx, bucketName, prefix, recursive, token, pageSize)
+	if page != nil {
+		span.SetAttributes(attribute.Int("storage.count", len(page.Paths)))
+	}
+	trace.End(span, err)
+	return page, err
+}
+
+func (tcm *TracingChunkManager) Remove(ctx context.Context, bucketName string, filePath string) error {
+	ctx, span := tcm.start(ctx, "remove", bucketName, filePath)
+	err := tcm.ChunkManager.Remove(ctx, bucketName, filePath)
+	trace.End(span, err)
+	return err
+}
+
+func (tcm *TracingChunkManager) RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error {
+	ctx, span := tcm.start(ctx, "remove_with_prefix", bucketName, prefix)
+	err := tcm.ChunkManager.RemoveWithPrefix(ctx, bucketName, prefix)
+	trace.End(span, err)
+	return err
+}
+
+func (tcm *TracingChunkManager) Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error {
+	ctx, span := tcm.start(ctx, "copy", fromBucketName, fromPath)
+	span.SetAttributes(attribute.String("storage.to_bucket", toBucketName), attribute.String("storage.to_path", toPath))
+	err := tcm.ChunkManager.Copy(ctx, fromBucketName, toBucketName, fromPath, toPath)
+	trace.End(span, err)
+	return err
+}
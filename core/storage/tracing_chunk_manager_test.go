@@ -0,0 +1,32 @@
+package storage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracingChunkManager(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+
+	ctx := context.Background()
+	tcm := NewTracingChunkManager(&fakeChunkManager{}, "test")
+
+	assert.NoError(t, tcm.Write(ctx, "bucket", "file", []byte("data")))
+	data, err := tcm.Read(ctx, "bucket", "file")
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("data"), data)
+
+	spans := recorder.Ended()
+	assert.Len(t, spans, 2)
+	assert.Equal(t, "storage.write", spans[0].Name())
+	assert.Equal(t, "storage.read", spans[1].Name())
+	assert.Contains(t, spans[1].Attributes(), attribute.String("storage.path", "file"))
+	assert.Contains(t, spans[1].Attributes(), attribute.Int("storage.size", 4))
+}
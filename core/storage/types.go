@@ -10,6 +10,17 @@ type FileReader interface {
 	io.Closer
 }
 
+// WalkFunc is called for every file listed by WalkWithPrefix, the walk stops with the error it returns
+type WalkFunc func(filePath string, size int64) error
+
+// ListPage is a page of the files with a prefix listed by ListPageWithPrefix, in the order of their keys
+type ListPage struct {
+	Paths []string
+	Sizes []int64
+	// continues the listing after this page, empty if it is the last page
+	NextToken string
+}
+
 // ChunkManager is to manager chunks.
 // Include Read, Write, Remove chunks.
 type ChunkManager interface {
@@ -34,6 +45,13 @@ type ChunkManager interface {
 	// MultiRead reads @filePath and returns content.
 	//MultiRead(ctx context.Context, bucketName string, filePaths []string) ([][]byte, error)
 	ListWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool) ([]string, []int64, error)
+	// WalkWithPrefix calls @walkFunc for the files with same @prefix in the order of ListWithPrefix, page by page
+	// on the storages supporting it, without holding the whole listing in memory.
+	WalkWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, walkFunc WalkFunc) error
+	// ListPageWithPrefix lists a page of at most @pageSize files with same @prefix, 0 means the default page size of
+	// the storage. The listing continues from @token, the NextToken of the previous page, empty for the first page.
+	// A token is opaque and only valid for the storage which returns it, see ListIterator.
+	ListPageWithPrefix(ctx context.Context, bucketName string, prefix string, recursive bool, token string, pageSize int) (*ListPage, error)
 	// ReadWithPrefix reads files with same @prefix and returns contents.
 	//ReadWithPrefix(ctx context.Context, bucketName string, prefix string) ([]string, [][]byte, error)
 	// Not use
@@ -50,4 +68,7 @@ type ChunkManager interface {
 	RemoveWithPrefix(ctx context.Context, bucketName string, prefix string) error
 	// Move move files from fromPath into toPath recursively
 	Copy(ctx context.Context, fromBucketName string, toBucketName string, fromPath string, toPath string) error
+	// Rehydrate requests the archived files with same @prefix to be readable for @days with retrieval @tier,
+	// returns the number of archived files not readable yet.
+	Rehydrate(ctx context.Context, bucketName string, prefix string, days int, tier string) (int, error)
 }
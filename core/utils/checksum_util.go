@@ -0,0 +1,14 @@
+package utils
+
+import (
+	"encoding/hex"
+	"hash/crc32"
+)
+
+var crc32cTable = crc32.MakeTable(crc32.Castagnoli)
+
+// Crc32c returns the hex encoded CRC32C checksum of data, the same algorithm used by GCS and S3 to check object integrity
+func Crc32c(data []byte) string {
+	sum := crc32.Checksum(data, crc32cTable)
+	return hex.EncodeToString([]byte{byte(sum >> 24), byte(sum >> 16), byte(sum >> 8), byte(sum)})
+}
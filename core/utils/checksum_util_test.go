@@ -0,0 +1,14 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrc32c(t *testing.T) {
+	assert.Equal(t, "00000000", Crc32c([]byte{}))
+	// check value of CRC-32C from RFC 3720
+	assert.Equal(t, "e3069283", Crc32c([]byte("123456789")))
+	assert.NotEqual(t, Crc32c([]byte("backup data")), Crc32c([]byte("backup datA")))
+}
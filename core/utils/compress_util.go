@@ -0,0 +1,82 @@
+package utils
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// compression algorithms of backup data
+const (
+	CompressionNone = ""
+	CompressionZstd = "zstd"
+	CompressionGzip = "gzip"
+)
+
+var supportedCompressions = map[string]bool{
+	CompressionNone: true,
+	CompressionZstd: true,
+	CompressionGzip: true,
+}
+
+func ValidateCompression(compression string) error {
+	if !supportedCompressions[compression] {
+		return fmt.Errorf("unsupported compression: %s, support: %s, %s", compression, CompressionZstd, CompressionGzip)
+	}
+	return nil
+}
+
+// Compress compresses data with the compression algorithm, return the data directly if compression is none
+func Compress(compression string, data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	var writer io.WriteCloser
+	var err error
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionZstd:
+		writer, err = zstd.NewWriter(&buf)
+	case CompressionGzip:
+		writer = gzip.NewWriter(&buf)
+	default:
+		return nil, ValidateCompression(compression)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if _, err := writer.Write(data); err != nil {
+		writer.Close()
+		return nil, err
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Decompress decompresses data compressed by Compress
+func Decompress(compression string, data []byte) ([]byte, error) {
+	switch compression {
+	case CompressionNone:
+		return data, nil
+	case CompressionZstd:
+		reader, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	case CompressionGzip:
+		reader, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	default:
+		return nil, ValidateCompression(compression)
+	}
+}
@@ -0,0 +1,27 @@
+package utils
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompress(t *testing.T) {
+	data := bytes.Repeat([]byte("milvus-backup"), 1024)
+	for _, compression := range []string{CompressionNone, CompressionZstd, CompressionGzip} {
+		compressed, err := Compress(compression, data)
+		assert.NoError(t, err)
+		if compression != CompressionNone {
+			assert.Less(t, len(compressed), len(data))
+		}
+		decompressed, err := Decompress(compression, compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+	}
+
+	_, err := Compress("lz4", data)
+	assert.Error(t, err)
+	_, err = Decompress("lz4", data)
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package utils
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// EncryptionKeySize is the key size of AES-256
+const EncryptionKeySize = 32
+
+// encryptedMagic is written at the head of every encrypted file,
+// followed by the nonce and the AES-256-GCM sealed data
+var encryptedMagic = []byte("MBKENC01")
+
+// ParseEncryptionKey resolves a key reference into a 32 bytes AES-256 key, support:
+//   - env:NAME, read the key from environment variable NAME
+//   - file:PATH, read the key from file PATH, for example a secret mounted by the KMS secret store CSI driver
+//   - otherwise the reference itself is the key
+//
+// see ResolveSecret.
+//
+// The key is base64 or hex encoded 32 bytes.
+func ParseEncryptionKey(ref string) ([]byte, error) {
+	encoded, err := ResolveSecret(strings.TrimSpace(ref))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key: %w", err)
+	}
+	encoded = strings.TrimSpace(encoded)
+
+	if key, err := base64.StdEncoding.DecodeString(encoded); err == nil && len(key) == EncryptionKeySize {
+		return key, nil
+	}
+	if key, err := hex.DecodeString(encoded); err == nil && len(key) == EncryptionKeySize {
+		return key, nil
+	}
+	return nil, fmt.Errorf("encryption key should be base64 or hex encoded %d bytes", EncryptionKeySize)
+}
+
+// IsEncrypted checks whether the data is encrypted by Encrypt
+func IsEncrypted(data []byte) bool {
+	return bytes.HasPrefix(data, encryptedMagic)
+}
+
+// Encrypt encrypts data with AES-256-GCM
+func Encrypt(key []byte, data []byte) ([]byte, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	res := make([]byte, 0, len(encryptedMagic)+len(nonce)+len(data)+aead.Overhead())
+	res = append(res, encryptedMagic...)
+	res = append(res, nonce...)
+	return aead.Seal(res, nonce, data, encryptedMagic), nil
+}
+
+// Decrypt decrypts data encrypted by Encrypt
+func Decrypt(key []byte, data []byte) ([]byte, error) {
+	if !IsEncrypted(data) {
+		return nil, errors.New("data is not encrypted")
+	}
+	aead, err := newAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	data = data[len(encryptedMagic):]
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("encrypted data is too short")
+	}
+	nonce, sealed := data[:aead.NonceSize()], data[aead.NonceSize():]
+	plain, err := aead.Open(nil, nonce, sealed, encryptedMagic)
+	if err != nil {
+		return nil, fmt.Errorf("decrypt data, maybe the encryption key is wrong: %w", err)
+	}
+	return plain, nil
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != EncryptionKeySize {
+		return nil, fmt.Errorf("encryption key should be %d bytes", EncryptionKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
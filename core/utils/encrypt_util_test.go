@@ -0,0 +1,61 @@
+package utils
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncrypt(t *testing.T) {
+	key := bytes.Repeat([]byte{1}, EncryptionKeySize)
+	data := []byte("milvus-backup")
+
+	encrypted, err := Encrypt(key, data)
+	assert.NoError(t, err)
+	assert.True(t, IsEncrypted(encrypted))
+	assert.False(t, IsEncrypted(data))
+
+	decrypted, err := Decrypt(key, encrypted)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decrypted)
+
+	_, err = Decrypt(bytes.Repeat([]byte{2}, EncryptionKeySize), encrypted)
+	assert.Error(t, err)
+	_, err = Decrypt(key, data)
+	assert.Error(t, err)
+	_, err = Encrypt([]byte("short"), data)
+	assert.Error(t, err)
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	key := bytes.Repeat([]byte{7}, EncryptionKeySize)
+
+	parsed, err := ParseEncryptionKey(base64.StdEncoding.EncodeToString(key))
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	parsed, err = ParseEncryptionKey(hex.EncodeToString(key))
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	t.Setenv("TEST_BACKUP_ENCRYPTION_KEY", base64.StdEncoding.EncodeToString(key))
+	parsed, err = ParseEncryptionKey("env:TEST_BACKUP_ENCRYPTION_KEY")
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	keyFile := filepath.Join(t.TempDir(), "key")
+	assert.NoError(t, os.WriteFile(keyFile, []byte(hex.EncodeToString(key)+"\n"), 0600))
+	parsed, err = ParseEncryptionKey("file:" + keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, key, parsed)
+
+	_, err = ParseEncryptionKey("env:TEST_BACKUP_ENCRYPTION_KEY_NOT_EXIST")
+	assert.Error(t, err)
+	_, err = ParseEncryptionKey("too-short")
+	assert.Error(t, err)
+}
@@ -0,0 +1,359 @@
+package utils
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// timeout of reading a secret from a secret manager
+const secretReadTimeout = 10 * time.Second
+
+// SecretProvider reads the secrets of the references of a scheme from a secret manager. A reference is
+// scheme://path#key, key selects a field of the secret if it is a json object.
+type SecretProvider interface {
+	ReadSecret(ctx context.Context, path string, key string) (string, error)
+}
+
+var (
+	secretProvidersMu sync.RWMutex
+	secretProviders   = map[string]SecretProvider{
+		"vault": vaultSecretProvider{},
+		"awssm": awsSecretProvider{},
+		"gcpsm": gcpSecretProvider{},
+	}
+)
+
+// RegisterSecretProvider sets the provider of the secret references of scheme, like the secret manager of
+// a platform not supported here, a provider registered before is replaced
+func RegisterSecretProvider(scheme string, provider SecretProvider) {
+	secretProvidersMu.Lock()
+	defer secretProvidersMu.Unlock()
+	secretProviders[scheme] = provider
+}
+
+// secretProviderOf returns the provider of a reference of a secret manager, with the path and key in the reference
+func secretProviderOf(ref string) (SecretProvider, string, string, bool) {
+	scheme, rest, ok := strings.Cut(ref, "://")
+	if !ok {
+		return nil, "", "", false
+	}
+	secretProvidersMu.RLock()
+	provider, ok := secretProviders[scheme]
+	secretProvidersMu.RUnlock()
+	if !ok {
+		return nil, "", "", false
+	}
+	path, key, _ := strings.Cut(rest, "#")
+	return provider, path, key, true
+}
+
+// readManagedSecret reads the secret of a reference of a secret manager
+func readManagedSecret(provider SecretProvider, ref, path, key string) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), secretReadTimeout)
+	defer cancel()
+	value, err := provider.ReadSecret(ctx, path, key)
+	if err != nil {
+		return "", fmt.Errorf("read secret %s: %w", ref, err)
+	}
+	return strings.TrimSpace(value), nil
+}
+
+// secretField returns the field key of a secret, the secret itself if key is empty
+func secretField(secret string, key string) (string, error) {
+	if key == "" {
+		return secret, nil
+	}
+	fields := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret is not a json object to read %s from", key)
+	}
+	return fieldOf(fields, key)
+}
+
+func fieldOf(fields map[string]interface{}, key string) (string, error) {
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("secret has no field %s", key)
+	}
+	if str, ok := value.(string); ok {
+		return str, nil
+	}
+	data, err := json.Marshal(value)
+	return string(data), err
+}
+
+// doSecretRequest sends a request to a secret manager and decodes the json response into out
+func doSecretRequest(req *http.Request, out interface{}) error {
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s %s: %s", req.Method, resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, out)
+}
+
+// vaultSecretProvider reads vault://path#key from the vault of VAULT_ADDR, path is the api path of the secret
+// like secret/data/milvus of a kv v2 engine mounted at secret. The token is VAULT_TOKEN, or the one of the
+// kubernetes auth of role VAULT_K8S_ROLE with the service account token of the pod.
+type vaultSecretProvider struct{}
+
+func (vaultSecretProvider) ReadSecret(ctx context.Context, path string, key string) (string, error) {
+	addr := strings.TrimRight(os.Getenv("VAULT_ADDR"), "/")
+	if addr == "" {
+		return "", fmt.Errorf("VAULT_ADDR is not set")
+	}
+	token, err := vaultToken(ctx, addr)
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, addr+"/v1/"+strings.TrimLeft(path, "/"), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("X-Vault-Token", token)
+	if namespace := os.Getenv("VAULT_NAMESPACE"); namespace != "" {
+		req.Header.Set("X-Vault-Namespace", namespace)
+	}
+	var resp struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := doSecretRequest(req, &resp); err != nil {
+		return "", err
+	}
+	data := resp.Data
+	// the fields of a kv v2 secret are under data, next to its metadata
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, ok := data["metadata"]; ok {
+			data = inner
+		}
+	}
+	if key == "" {
+		if len(data) != 1 {
+			return "", fmt.Errorf("vault secret %s has %d fields, select one by #key", path, len(data))
+		}
+		for field := range data {
+			key = field
+		}
+	}
+	return fieldOf(data, key)
+}
+
+func vaultToken(ctx context.Context, addr string) (string, error) {
+	if token := os.Getenv("VAULT_TOKEN"); token != "" {
+		return token, nil
+	}
+	role := os.Getenv("VAULT_K8S_ROLE")
+	if role == "" {
+		return "", fmt.Errorf("neither VAULT_TOKEN nor VAULT_K8S_ROLE is set")
+	}
+	tokenFile := os.Getenv("VAULT_K8S_TOKEN_FILE")
+	if tokenFile == "" {
+		tokenFile = "/var/run/secrets/kubernetes.io/serviceaccount/token"
+	}
+	jwt, err := os.ReadFile(tokenFile)
+	if err != nil {
+		return "", fmt.Errorf("read service account token: %w", err)
+	}
+	mount := os.Getenv("VAULT_K8S_MOUNT")
+	if mount == "" {
+		mount = "kubernetes"
+	}
+	body, err := json.Marshal(map[string]string{"role": role, "jwt": strings.TrimSpace(string(jwt))})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, addr+"/v1/auth/"+mount+"/login", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	var resp struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}
+	if err := doSecretRequest(req, &resp); err != nil {
+		return "", fmt.Errorf("vault kubernetes login: %w", err)
+	}
+	return resp.Auth.ClientToken, nil
+}
+
+// awsSecretsManagerEndpoint returns the endpoint of aws secrets manager in region
+var awsSecretsManagerEndpoint = func(region string) string {
+	return "https://secretsmanager." + region + ".amazonaws.com"
+}
+
+// awsSecretProvider reads awssm://id#key from aws secrets manager, id is the name or arn of the secret.
+// The region is the one of the arn, or AWS_REGION. The credentials are those of the env, the shared
+// credentials file, or the instance profile and IRSA.
+type awsSecretProvider struct{}
+
+func (awsSecretProvider) ReadSecret(ctx context.Context, path string, key string) (string, error) {
+	region := os.Getenv("AWS_REGION")
+	if region == "" {
+		region = os.Getenv("AWS_DEFAULT_REGION")
+	}
+	// arn:aws:secretsmanager:<region>:<account>:secret:<name>
+	if parts := strings.Split(path, ":"); len(parts) > 3 && parts[0] == "arn" {
+		region = parts[3]
+	}
+	if region == "" {
+		return "", fmt.Errorf("region of aws secret %s is unknown, set AWS_REGION", path)
+	}
+	creds, err := credentials.NewChainCredentials([]credentials.Provider{
+		&credentials.EnvAWS{},
+		&credentials.FileAWSCredentials{},
+		&credentials.IAM{Client: &http.Client{Transport: http.DefaultTransport}},
+	}).Get()
+	if err != nil {
+		return "", fmt.Errorf("aws credentials: %w", err)
+	}
+	body, err := json.Marshal(map[string]string{"SecretId": path})
+	if err != nil {
+		return "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, awsSecretsManagerEndpoint(region)+"/", bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	signAWSRequest(req, body, creds, region, "secretsmanager", time.Now())
+	var resp struct {
+		SecretString string `json:"SecretString"`
+		SecretBinary string `json:"SecretBinary"`
+	}
+	if err := doSecretRequest(req, &resp); err != nil {
+		return "", err
+	}
+	secret := resp.SecretString
+	if secret == "" && resp.SecretBinary != "" {
+		binary, err := base64.StdEncoding.DecodeString(resp.SecretBinary)
+		if err != nil {
+			return "", err
+		}
+		secret = string(binary)
+	}
+	return secretField(secret, key)
+}
+
+// signAWSRequest signs a request with a body by aws signature version 4
+func signAWSRequest(req *http.Request, body []byte, creds credentials.Value, region, service string, now time.Time) {
+	amzDate := now.UTC().Format("20060102T150405Z")
+	scope := amzDate[:8] + "/" + region + "/" + service + "/aws4_request"
+	req.Header.Set("X-Amz-Date", amzDate)
+	if creds.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", creds.SessionToken)
+	}
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	path := req.URL.EscapedPath()
+	if path == "" {
+		path = "/"
+	}
+	payloadHash := sha256.Sum256(body)
+	canonicalRequest := strings.Join([]string{req.Method, path, req.URL.RawQuery, canonicalHeaders.String(),
+		signedHeaders, hex.EncodeToString(payloadHash[:])}, "\n")
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := []byte("AWS4" + creds.SecretAccessKey)
+	for _, part := range []string{amzDate[:8], region, service, "aws4_request"} {
+		signingKey = hmacSHA256(signingKey, part)
+	}
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+	req.Header.Set("Authorization", "AWS4-HMAC-SHA256 Credential="+creds.AccessKeyID+"/"+scope+
+		", SignedHeaders="+signedHeaders+", Signature="+signature)
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// gcpSecretManagerEndpoint is the endpoint of gcp secret manager
+var gcpSecretManagerEndpoint = "https://secretmanager.googleapis.com"
+
+// gcpTokenSource returns the token source of the application default credentials
+var gcpTokenSource = func(ctx context.Context) (oauth2.TokenSource, error) {
+	creds, err := google.FindDefaultCredentials(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return nil, err
+	}
+	return creds.TokenSource, nil
+}
+
+// gcpSecretProvider reads gcpsm://projects/<project>/secrets/<name>[/versions/<version>]#key from gcp secret
+// manager with the application default credentials, like workload identity, the latest version by default
+type gcpSecretProvider struct{}
+
+func (gcpSecretProvider) ReadSecret(ctx context.Context, path string, key string) (string, error) {
+	name := strings.Trim(path, "/")
+	if !strings.Contains(name, "/versions/") {
+		name += "/versions/latest"
+	}
+	tokenSource, err := gcpTokenSource(ctx)
+	if err != nil {
+		return "", fmt.Errorf("gcp credentials: %w", err)
+	}
+	token, err := tokenSource.Token()
+	if err != nil {
+		return "", fmt.Errorf("gcp token: %w", err)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, gcpSecretManagerEndpoint+"/v1/"+name+":access", nil)
+	if err != nil {
+		return "", err
+	}
+	token.SetAuthHeader(req)
+	var resp struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := doSecretRequest(req, &resp); err != nil {
+		return "", err
+	}
+	data, err := base64.StdEncoding.DecodeString(resp.Payload.Data)
+	if err != nil {
+		return "", err
+	}
+	return secretField(string(data), key)
+}
@@ -0,0 +1,165 @@
+package utils
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+func TestVaultSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/auth/kubernetes/login":
+			var login map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&login)
+			if login["role"] != "backup" || login["jwt"] != "sa-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"auth":{"client_token":"k8s-token"}}`))
+		case "/v1/secret/data/milvus":
+			if r.Header.Get("X-Vault-Token") != "token" && r.Header.Get("X-Vault-Token") != "k8s-token" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			w.Write([]byte(`{"data":{"data":{"password":"p1","user":"root"},"metadata":{"version":3}}}`))
+		case "/v1/kv/minio":
+			w.Write([]byte(`{"data":{"secretAccessKey":"minio-sk"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	assert.True(t, IsSecretRef("vault://secret/data/milvus#password"))
+	_, err := ResolveSecret("vault://secret/data/milvus#password")
+	assert.ErrorContains(t, err, "VAULT_ADDR")
+
+	t.Setenv("VAULT_ADDR", server.URL)
+	t.Setenv("VAULT_TOKEN", "token")
+	value, err := ResolveSecret("vault://secret/data/milvus#password")
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", value)
+	// a secret of one field doesn't need the key
+	value, err = ResolveSecret("vault://kv/minio")
+	assert.NoError(t, err)
+	assert.Equal(t, "minio-sk", value)
+	_, err = ResolveSecret("vault://secret/data/milvus")
+	assert.ErrorContains(t, err, "select one by #key")
+	_, err = ResolveSecret("vault://secret/data/milvus#token")
+	assert.ErrorContains(t, err, "no field token")
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(tokenFile, []byte("sa-token\n"), 0600))
+	t.Setenv("VAULT_TOKEN", "")
+	t.Setenv("VAULT_K8S_ROLE", "backup")
+	t.Setenv("VAULT_K8S_TOKEN_FILE", tokenFile)
+	value, err = ResolveSecret("vault://secret/data/milvus#user")
+	assert.NoError(t, err)
+	assert.Equal(t, "root", value)
+}
+
+func TestAWSSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=ak/") || !strings.Contains(auth, "/eu-west-1/secretsmanager/aws4_request") ||
+			r.Header.Get("X-Amz-Target") != "secretsmanager.GetSecretValue" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		var input map[string]string
+		_ = json.NewDecoder(r.Body).Decode(&input)
+		switch input["SecretId"] {
+		case "arn:aws:secretsmanager:eu-west-1:123456789012:secret:minio":
+			w.Write([]byte(`{"SecretString":"{\"accessKeyID\":\"ak1\",\"secretAccessKey\":\"sk1\"}"}`))
+		case "milvus":
+			w.Write([]byte(`{"SecretBinary":"` + base64.StdEncoding.EncodeToString([]byte("p1")) + `"}`))
+		default:
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer server.Close()
+	endpoint := awsSecretsManagerEndpoint
+	awsSecretsManagerEndpoint = func(region string) string { return server.URL }
+	defer func() { awsSecretsManagerEndpoint = endpoint }()
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "ak")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "sk")
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	_, err := ResolveSecret("awssm://milvus")
+	assert.ErrorContains(t, err, "AWS_REGION")
+
+	// the region of the arn
+	value, err := ResolveSecret("awssm://arn:aws:secretsmanager:eu-west-1:123456789012:secret:minio#secretAccessKey")
+	assert.NoError(t, err)
+	assert.Equal(t, "sk1", value)
+
+	t.Setenv("AWS_REGION", "eu-west-1")
+	value, err = ResolveSecret("awssm://milvus")
+	assert.NoError(t, err)
+	assert.Equal(t, "p1", value)
+	_, err = ResolveSecret("awssm://milvus#password")
+	assert.ErrorContains(t, err, "not a json object")
+}
+
+func TestGCPSecret(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer gcp-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/v1/projects/p/secrets/milvus/versions/latest:access", "/v1/projects/p/secrets/milvus/versions/2:access":
+			w.Write([]byte(`{"payload":{"data":"` + base64.StdEncoding.EncodeToString([]byte(`{"password":"p2"}`)) + `"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	endpoint, tokenSource := gcpSecretManagerEndpoint, gcpTokenSource
+	gcpSecretManagerEndpoint = server.URL
+	gcpTokenSource = func(ctx context.Context) (oauth2.TokenSource, error) {
+		return oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "gcp-token"}), nil
+	}
+	defer func() { gcpSecretManagerEndpoint, gcpTokenSource = endpoint, tokenSource }()
+
+	value, err := ResolveSecret("gcpsm://projects/p/secrets/milvus#password")
+	assert.NoError(t, err)
+	assert.Equal(t, "p2", value)
+	value, err = ResolveSecret("gcpsm://projects/p/secrets/milvus/versions/2")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"password":"p2"}`, value)
+	_, err = ResolveSecret("gcpsm://projects/p/secrets/minio")
+	assert.ErrorContains(t, err, "404")
+}
+
+type staticSecretProvider map[string]string
+
+func (p staticSecretProvider) ReadSecret(ctx context.Context, path string, key string) (string, error) {
+	return p[path+"#"+key], nil
+}
+
+func TestRegisterSecretProvider(t *testing.T) {
+	assert.False(t, IsSecretRef("static://milvus#password"))
+	RegisterSecretProvider("static", staticSecretProvider{"milvus#password": " p3 "})
+	defer func() {
+		secretProvidersMu.Lock()
+		delete(secretProviders, "static")
+		secretProvidersMu.Unlock()
+	}()
+	assert.True(t, IsSecretRef("static://milvus#password"))
+	value, err := NewSecret("static://milvus#password", time.Hour).Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "p3", value)
+}
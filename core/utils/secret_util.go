@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// ResolveSecret resolves a secret reference of config, support:
+//   - env:NAME, read the secret from environment variable NAME
+//   - file:PATH, read the secret from file PATH, for example a kubernetes secret or a file rendered by vault agent
+//   - vault://PATH#KEY, awssm://ID#KEY and gcpsm://NAME#KEY, read the secret from a secret manager, see SecretProvider
+//   - otherwise the reference itself is the secret
+func ResolveSecret(ref string) (string, error) {
+	if provider, path, key, ok := secretProviderOf(ref); ok {
+		return readManagedSecret(provider, ref, path, key)
+	}
+	switch {
+	case strings.HasPrefix(ref, "env:"):
+		name := strings.TrimPrefix(ref, "env:")
+		value := os.Getenv(name)
+		if value == "" {
+			return "", fmt.Errorf("secret env %s is not set", name)
+		}
+		return strings.TrimSpace(value), nil
+	case strings.HasPrefix(ref, "file:"):
+		content, err := os.ReadFile(strings.TrimPrefix(ref, "file:"))
+		if err != nil {
+			return "", fmt.Errorf("read secret file: %w", err)
+		}
+		return strings.TrimSpace(string(content)), nil
+	default:
+		return ref, nil
+	}
+}
+
+// IsSecretRef checks whether the value of config refers to a secret read from env, file or a secret manager
+func IsSecretRef(ref string) bool {
+	if _, _, _, ok := secretProviderOf(ref); ok {
+		return true
+	}
+	return strings.HasPrefix(ref, "env:") || strings.HasPrefix(ref, "file:")
+}
+
+// Secret is a secret of config which is read from its reference again after the refresh interval,
+// so the credentials rotated in the file or the secret manager are used without restarting
+type Secret struct {
+	ref      string
+	interval time.Duration
+
+	mu     sync.Mutex
+	value  string
+	readAt time.Time
+}
+
+// NewSecret returns the secret of ref, it is read on the first call of Value.
+// A secret which isn't a reference never changes.
+func NewSecret(ref string, interval time.Duration) *Secret {
+	return &Secret{ref: ref, interval: interval}
+}
+
+// Value returns the current value of the secret. The last value is kept if the secret can't be read again,
+// such as a file being replaced, the error is only returned if the secret has never been read.
+func (s *Secret) Value() (string, error) {
+	if !IsSecretRef(s.ref) {
+		return s.ref, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.readAt.IsZero() && time.Since(s.readAt) < s.interval {
+		return s.value, nil
+	}
+	value, err := ResolveSecret(s.ref)
+	if err != nil {
+		if s.readAt.IsZero() {
+			return "", err
+		}
+		log.Warn("failed to refresh secret, keep using the last value", zap.String("ref", s.ref), zap.Error(err))
+		s.readAt = time.Now()
+		return s.value, nil
+	}
+	if s.value != value && !s.readAt.IsZero() {
+		log.Info("secret is rotated", zap.String("ref", s.ref))
+	}
+	s.value = value
+	s.readAt = time.Now()
+	return s.value, nil
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveSecret(t *testing.T) {
+	value, err := ResolveSecret("minioadmin")
+	assert.NoError(t, err)
+	assert.Equal(t, "minioadmin", value)
+	assert.False(t, IsSecretRef("minioadmin"))
+
+	t.Setenv("TEST_BACKUP_SECRET", "secret\n")
+	value, err = ResolveSecret("env:TEST_BACKUP_SECRET")
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", value)
+
+	_, err = ResolveSecret("env:TEST_BACKUP_SECRET_NOT_EXIST")
+	assert.Error(t, err)
+	_, err = ResolveSecret("file:" + filepath.Join(t.TempDir(), "not-exist"))
+	assert.Error(t, err)
+}
+
+func TestSecretRotation(t *testing.T) {
+	secretFile := filepath.Join(t.TempDir(), "secret")
+	assert.NoError(t, os.WriteFile(secretFile, []byte("v1\n"), 0600))
+
+	secret := NewSecret("file:"+secretFile, time.Hour)
+	value, err := secret.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "v1", value)
+
+	// cached until the refresh interval
+	assert.NoError(t, os.WriteFile(secretFile, []byte("v2"), 0600))
+	value, _ = secret.Value()
+	assert.Equal(t, "v1", value)
+
+	secret.interval = 0
+	value, _ = secret.Value()
+	assert.Equal(t, "v2", value)
+
+	// the last value is kept if the file is gone
+	assert.NoError(t, os.Remove(secretFile))
+	value, err = secret.Value()
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", value)
+
+	_, err = NewSecret("file:"+secretFile, time.Hour).Value()
+	assert.Error(t, err)
+}
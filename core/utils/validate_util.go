@@ -11,6 +11,15 @@ const (
 )
 
 func ValidateType(entity, entityType string) error {
+	return validateName(entity, entityType, false)
+}
+
+// ValidateBackupName validates backup names like ValidateType, except that hyphens are allowed, like nightly-20240101
+func ValidateBackupName(entity, entityType string) error {
+	return validateName(entity, entityType, true)
+}
+
+func validateName(entity, entityType string, allowHyphen bool) error {
 	entity = strings.TrimSpace(entity)
 
 	if entity == "" {
@@ -31,8 +40,14 @@ func ValidateType(entity, entityType string) error {
 
 	for i := 1; i < len(entity); i++ {
 		c := entity[i]
+		if allowHyphen && c == '-' {
+			continue
+		}
 		if c != '_' && !isAlpha(c) && !isNumber(c) {
 			msg := invalidMsg + fmt.Sprintf("%s can only contain numbers, letters and underscores.", entityType)
+			if allowHyphen {
+				msg = invalidMsg + fmt.Sprintf("%s can only contain numbers, letters, underscores and hyphens.", entityType)
+			}
 			return errors.New(msg)
 		}
 	}
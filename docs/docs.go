@@ -1,15 +1,15 @@
-// Package docs GENERATED BY SWAG; DO NOT EDIT
-// This file was generated by swaggo/swag
+// Code generated by swaggo/swag. DO NOT EDIT.
+
 package docs
 
 import "github.com/swaggo/swag"
 
 const docTemplate = `{
-    "schemes": {{ marshal .Schemes }},
+    "schemes": [[ marshal .Schemes ]],
     "swagger": "2.0",
     "info": {
-        "description": "{{escape .Description}}",
-        "title": "{{.Title}}",
+        "description": "[[escape .Description]]",
+        "title": "[[.Title]]",
         "contact": {
             "name": "wanganyang",
             "email": "wayasxxx@gmail.com"
@@ -18,11 +18,76 @@ const docTemplate = `{
             "name": "Apache 2.0",
             "url": "http://www.apache.org/licenses/LICENSE-2.0.html"
         },
-        "version": "{{.Version}}"
+        "version": "[[.Version]]"
     },
-    "host": "{{.Host}}",
-    "basePath": "{{.BasePath}}",
+    "host": "[[.Host]]",
+    "basePath": "[[.BasePath]]",
     "paths": {
+        "/check_compatibility": {
+            "get": {
+                "description": "Check the features used by the collections of a backup against a target milvus version before restoring it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restore"
+                ],
+                "summary": "Check compatibility interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "backup_name",
+                        "name": "backup_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "milvus version to check against, like v2.3.5, the version of the connected milvus if not set",
+                        "name": "target_version",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "bucket_name",
+                        "name": "bucket_name",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "path",
+                        "name": "path",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "collections to check, separated by comma",
+                        "name": "collection_names",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "database the collections are restored into",
+                        "name": "target_db_name",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.CheckCompatibilityResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/create": {
             "post": {
                 "description": "Create a backup with the given name and collections",
@@ -41,8 +106,7 @@ const docTemplate = `{
                         "type": "string",
                         "description": "request_id",
                         "name": "request_id",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
                         "description": "CreateBackupRequest JSON",
@@ -66,7 +130,7 @@ const docTemplate = `{
         },
         "/delete": {
             "delete": {
-                "description": "Delete a backup with the given name",
+                "description": "Delete a backup with the given name, a backup referenced by incremental backups is only deleted together with them if force is set",
                 "produces": [
                     "application/json"
                 ],
@@ -79,8 +143,7 @@ const docTemplate = `{
                         "type": "string",
                         "description": "request_id",
                         "name": "request_id",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
                         "type": "string",
@@ -88,6 +151,12 @@ const docTemplate = `{
                         "name": "backup_name",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "force",
+                        "name": "force",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -100,6 +169,80 @@ const docTemplate = `{
                 }
             }
         },
+        "/estimate": {
+            "post": {
+                "description": "List the collections a backup would contain with their segment numbers and sizes, nothing is written",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Estimate backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "CreateBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.CreateBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.EstimateBackupResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/gc": {
+            "post": {
+                "description": "Remove the orphaned files in backup storage not referenced by any backup",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Garbage collect interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "dry_run",
+                        "name": "dry_run",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.GarbageCollectResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/get_backup": {
             "get": {
                 "description": "Get the backup with the given name or id",
@@ -115,8 +258,7 @@ const docTemplate = `{
                         "type": "string",
                         "description": "request_id",
                         "name": "request_id",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
                         "type": "string",
@@ -158,8 +300,7 @@ const docTemplate = `{
                         "type": "string",
                         "description": "request_id",
                         "name": "request_id",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
                         "type": "string",
@@ -179,55 +320,50 @@ const docTemplate = `{
                 }
             }
         },
-        "/list": {
+        "/jobs": {
             "get": {
-                "description": "List all backups in current storage",
+                "description": "List the backup and restore jobs of this server, and the jobs recorded in backup storage by the servers sharing it if job state is enabled",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Backup"
+                    "Job"
                 ],
-                "summary": "List Backups interface",
+                "summary": "List jobs interface",
                 "parameters": [
                     {
                         "type": "string",
                         "description": "request_id",
                         "name": "request_id",
-                        "in": "header",
-                        "required": true
+                        "in": "header"
                     },
                     {
                         "type": "string",
-                        "description": "collection_name",
-                        "name": "collection_name",
-                        "in": "query",
-                        "required": true
+                        "description": "backup or restore, all if empty",
+                        "name": "type",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/backuppb.ListBackupsResponse"
+                            "$ref": "#/definitions/backuppb.ListJobsResponse"
                         }
                     }
                 }
             }
         },
-        "/restore": {
-            "post": {
-                "description": "Submit a request to restore the data from backup",
-                "consumes": [
-                    "application/json"
-                ],
+        "/jobs/{id}": {
+            "get": {
+                "description": "Get the state and progress of a backup or restore job, the job id is returned by create and restore",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Restore"
+                    "Job"
                 ],
-                "summary": "Restore interface",
+                "summary": "Get job interface",
                 "parameters": [
                     {
                         "type": "string",
@@ -236,318 +372,1696 @@ const docTemplate = `{
                         "in": "header"
                     },
                     {
-                        "description": "RestoreBackupRequest JSON",
-                        "name": "object",
-                        "in": "body",
-                        "required": true,
+                        "type": "string",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/backuppb.RestoreBackupRequest"
+                            "$ref": "#/definitions/backuppb.JobResponse"
                         }
                     }
+                }
+            },
+            "delete": {
+                "description": "Cancel a running backup or restore job, the copy workers and the waiting of bulkinsert tasks are stopped",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job"
+                ],
+                "summary": "Cancel job interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/backuppb.RestoreBackupResponse"
+                            "$ref": "#/definitions/backuppb.JobResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "backuppb.BackupInfo": {
-            "type": "object",
-            "properties": {
-                "backup_timestamp": {
-                    "description": "backup timestamp",
-                    "type": "integer"
-                },
-                "collection_backups": {
-                    "description": "array of collection backup",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.CollectionBackupInfo"
+        },
+        "/jobs/{id}/resume": {
+            "post": {
+                "description": "Resume an interrupted, failed or canceled job from its checkpoint in this server, like a job of a server which is gone",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Job"
+                ],
+                "summary": "Resume job interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "job id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.JobResponse"
+                        }
                     }
-                },
-                "end_time": {
-                    "type": "integer"
-                },
-                "errorMessage": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "milvus_version": {
-                    "type": "string"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "progress": {
-                    "type": "integer"
-                },
-                "size": {
-                    "type": "integer"
-                },
-                "start_time": {
-                    "type": "integer"
-                },
-                "state_code": {
-                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
                 }
             }
         },
-        "backuppb.BackupInfoResponse": {
-            "type": "object",
-            "properties": {
-                "code": {
-                    "description": "response code. 0 means success. others are fail",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/backuppb.ResponseCode"
-                        }
-                    ]
-                },
-                "data": {
-                    "description": "backup info entity",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/backuppb.BackupInfo"
-                        }
+        "/list": {
+            "get": {
+                "description": "List all backups in current storage",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "List Backups interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "collection_name",
+                        "name": "collection_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "databases",
+                        "name": "databases",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "start_time",
+                        "name": "start_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "end_time",
+                        "name": "end_time",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "states",
+                        "name": "states",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "order_by",
+                        "name": "order_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "desc",
+                        "name": "desc",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "offset",
+                        "name": "offset",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "without_detail",
+                        "name": "without_detail",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "label_selector",
+                        "name": "label_selector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ListBackupsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pause": {
+            "post": {
+                "description": "Pause a running backup, it stops copying data and turns to BACKUP_PAUSED once its checkpoint is written",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Pause backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "PauseBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.PauseBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.PauseBackupResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/prune": {
+            "post": {
+                "description": "Delete the backups expired by the retention policy",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Prune backups interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "dry_run",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "label_selector",
+                        "name": "label_selector",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.PruneBackupsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/recovery_point": {
+            "get": {
+                "description": "Get the status of continuous backup, including the recovery point and the backup to restore to recover to it",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Continuous"
+                ],
+                "summary": "Get recovery point interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.GetRecoveryPointResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/restore": {
+            "post": {
+                "description": "Submit a request to restore the data from backup",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Restore"
+                ],
+                "summary": "Restore interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "RestoreBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.RestoreBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.RestoreBackupResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/resume": {
+            "post": {
+                "description": "Resume a paused or interrupted backup from its checkpoint",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Resume backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "description": "ResumeBackupRequest JSON",
+                        "name": "object",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.ResumeBackupRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.BackupInfoResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/schedule": {
+            "get": {
+                "description": "Get the status of the schedule jobs, including the last run and the next run time",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Schedule"
+                ],
+                "summary": "Get schedule interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.GetScheduleResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/verify": {
+            "get": {
+                "description": "Check the existence, size and checksum of all files recorded in the backup meta",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Backup"
+                ],
+                "summary": "Verify backup interface",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "request_id",
+                        "name": "request_id",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "backup_name",
+                        "name": "backup_name",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "customer key of backup encrypted by sse type customer",
+                        "name": "sse_customer_key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/backuppb.VerifyBackupResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "backuppb.BackupInfo": {
+            "type": "object",
+            "properties": {
+                "backup_timestamp": {
+                    "description": "backup timestamp",
+                    "type": "integer"
+                },
+                "base_backup_name": {
+                    "description": "base backup name of an incremental backup, empty means it is a full backup",
+                    "type": "string"
+                },
+                "collection_backups": {
+                    "description": "array of collection backup",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.CollectionBackupInfo"
+                    }
+                },
+                "compression": {
+                    "description": "compression algorithm of the backup data, empty means not compressed",
+                    "type": "string"
+                },
+                "copied_size": {
+                    "description": "bytes of binlogs copied into backup, progress is the percentage of it in size",
+                    "type": "integer"
+                },
+                "encrypted": {
+                    "description": "backup files are encrypted with AES-256-GCM",
+                    "type": "boolean"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "etcd_snapshot": {
+                    "description": "the etcd meta snapshot of the collections, only taken if requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.EtcdSnapshotInfo"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "labels": {
+                    "description": "key/value labels attached at creation, like env=prod, used to select backups by label selectors",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "meta_version": {
+                    "description": "version of the layout of the meta files, 0 means written before the version is recorded",
+                    "type": "integer"
+                },
+                "milvus_version": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "object_lock_mode": {
+                    "description": "s3 object lock mode of the backup files, GOVERNANCE or COMPLIANCE, empty means not locked",
+                    "type": "string"
+                },
+                "object_lock_retain_until": {
+                    "description": "unix seconds until when the backup files can't be deleted or overwritten",
+                    "type": "integer"
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "rbac_meta": {
+                    "description": "users, roles and grants, only backed up if requested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.RBACMeta"
+                        }
+                    ]
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "sse_customer_key_md5": {
+                    "description": "base64 encoded MD5 of the customer key encrypting the binlogs, the key itself is never stored",
+                    "type": "string"
+                },
+                "sse_kms_key_id": {
+                    "description": "kms key encrypting the backup files, empty means the aws managed key",
+                    "type": "string"
+                },
+                "sse_type": {
+                    "description": "server side encryption of the backup files, kms or customer, empty means the default of the bucket",
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "integer"
+                },
+                "state_code": {
+                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
+                }
+            }
+        },
+        "backuppb.BackupInfoResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "data": {
+                    "description": "backup info entity",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.BackupInfo"
+                        }
+                    ]
+                },
+                "job_id": {
+                    "description": "id of the job executing the backup, to query or cancel it by the job api",
+                    "type": "string"
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.BackupTaskStateCode": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5,
+                6
+            ],
+            "x-enum-varnames": [
+                "BackupTaskStateCode_BACKUP_INITIAL",
+                "BackupTaskStateCode_BACKUP_EXECUTING",
+                "BackupTaskStateCode_BACKUP_SUCCESS",
+                "BackupTaskStateCode_BACKUP_FAIL",
+                "BackupTaskStateCode_BACKUP_TIMEOUT",
+                "BackupTaskStateCode_BACKUP_PAUSED",
+                "BackupTaskStateCode_BACKUP_PARTIAL"
+            ]
+        },
+        "backuppb.Binlog": {
+            "type": "object",
+            "properties": {
+                "backup_size": {
+                    "description": "size of the file in backup, differs from log_size if compressed or encrypted. 0 means not recorded",
+                    "type": "integer"
+                },
+                "crc32c": {
+                    "description": "hex encoded CRC32C of the file in backup, empty means not recorded",
+                    "type": "string"
+                },
+                "entries_num": {
+                    "type": "integer"
+                },
+                "log_path": {
+                    "type": "string"
+                },
+                "log_size": {
+                    "type": "integer"
+                },
+                "object_name": {
+                    "description": "name of the dedup object storing the file, shared by all backups of the same content. empty means the file is\nstored in the binlog dir of backup",
+                    "type": "string"
+                },
+                "timestamp_from": {
+                    "type": "integer"
+                },
+                "timestamp_to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.BinlogScan": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "why the binlogs are not scanned",
+                    "type": "string"
+                },
+                "missing_segment_ids": {
+                    "description": "persistent segments without insert logs in the bucket",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "orphan_files": {
+                    "description": "insert and delta logs of segments which are not persistent",
+                    "type": "integer"
+                },
+                "orphan_segment_ids": {
+                    "description": "ids of the first 100 orphan segments",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "orphan_segments": {
+                    "type": "integer"
+                },
+                "orphan_size": {
+                    "type": "integer"
+                },
+                "referenced_files": {
+                    "description": "insert and delta logs of the persistent segments",
+                    "type": "integer"
+                },
+                "referenced_size": {
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "scanned or skipped",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.BulkInsertJob": {
+            "type": "object",
+            "properties": {
+                "attempt": {
+                    "description": "attempt of the segment group the task is executed by, starting from 1",
+                    "type": "integer"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "error_message": {
+                    "type": "string"
+                },
+                "group_id": {
+                    "type": "integer"
+                },
+                "partition_name": {
+                    "type": "string"
+                },
+                "start_time": {
+                    "type": "integer"
+                },
+                "state": {
+                    "description": "executing, persisted, completed, failed or timeout",
+                    "type": "string"
+                },
+                "task_id": {
+                    "description": "id of the import task in milvus",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.CheckCompatibilityResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "issues": {
+                    "description": "the features not supported by the target version, the backup is compatible if empty",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.CompatIssue"
+                    }
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                },
+                "target_version": {
+                    "description": "the milvus version checked against",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.CollectionBackupInfo": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "description": "aliases pointing at the collection when backed up",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "backup_path_template": {
+                    "description": "template of the dirs of the segment groups of the collection in backup by minio.backupPathTemplate, the binlogs\nreferenced from base backups are in the same dirs of their backups, empty for the default layout",
+                    "type": "string"
+                },
+                "backup_physical_timestamp": {
+                    "description": "physical unix time of backup",
+                    "type": "integer"
+                },
+                "backup_timestamp": {
+                    "description": "logical time of backup, used for restore",
+                    "type": "integer"
+                },
+                "binlog_scan": {
+                    "description": "binlogs of the collection in the bucket of milvus compared with its persistent segments, only if scan_binlogs is\nrequested",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.BinlogScan"
+                        }
+                    ]
+                },
+                "collection_id": {
+                    "type": "integer"
+                },
+                "collection_name": {
+                    "type": "string"
+                },
+                "consistency_level": {
+                    "$ref": "#/definitions/backuppb.ConsistencyLevel"
+                },
+                "copied_size": {
+                    "description": "bytes of binlogs copied into backup, progress is the percentage of it in size",
+                    "type": "integer"
+                },
+                "db_name": {
+                    "type": "string"
+                },
+                "end_time": {
+                    "type": "integer"
+                },
+                "errorMessage": {
+                    "type": "string"
+                },
+                "flush_end_time": {
+                    "type": "integer"
+                },
+                "flush_start_time": {
+                    "description": "unix time in milliseconds the flush starts and ends, the data inserted before the start is in backup if flushed",
+                    "type": "integer"
+                },
+                "flush_state": {
+                    "description": "flushed, skipped or timeout, how the collection is flushed before backup",
+                    "type": "string"
+                },
+                "has_index": {
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "index_infos": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.IndexInfo"
+                    }
+                },
+                "load_state": {
+                    "type": "string"
+                },
+                "partition_backups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.PartitionBackupInfo"
+                    }
+                },
+                "progress": {
+                    "type": "integer"
+                },
+                "properties": {
+                    "description": "properties of the collection, like collection.ttl.seconds and mmap.enabled",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                    }
+                },
+                "replica_number": {
+                    "description": "number of replicas and their resource groups if the collection is loaded",
+                    "type": "integer"
+                },
+                "resource_groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "row_count_checks": {
+                    "description": "rows of the partitions in milvus compared with the rows in backup, only if check_row_count is requested",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.RowCountCheck"
+                    }
+                },
+                "sampled_rows": {
+                    "description": "rows sampled by sample_rows of the request, stored in meta/row_samples to verify the restored collections",
+                    "type": "integer"
+                },
+                "schema": {
+                    "$ref": "#/definitions/backuppb.CollectionSchema"
+                },
+                "shards_num": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "start_time": {
+                    "type": "integer"
+                },
+                "state_code": {
+                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
+                }
+            }
+        },
+        "backuppb.CollectionEstimate": {
+            "type": "object",
+            "properties": {
+                "collection_name": {
+                    "type": "string"
+                },
+                "db_name": {
+                    "type": "string"
+                },
+                "num_of_rows": {
+                    "type": "integer"
+                },
+                "partition_num": {
+                    "description": "number of partitions to backup",
+                    "type": "integer"
+                },
+                "segment_num": {
+                    "description": "number of persisted segments to backup",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "size in bytes of the binlogs to backup",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.CollectionSchema": {
+            "type": "object",
+            "properties": {
+                "autoID": {
+                    "type": "boolean"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enable_dynamic_field": {
+                    "type": "boolean"
+                },
+                "fields": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.FieldSchema"
+                    }
+                },
+                "functions": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.FunctionSchema"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.CompatIssue": {
+            "type": "object",
+            "properties": {
+                "collection": {
+                    "description": "db.collection_name in backup",
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "what is not supported, and what is not restored if downgraded",
+                    "type": "string"
+                },
+                "downgradable": {
+                    "description": "the collection can be restored without the feature by downgrade_features",
+                    "type": "boolean"
+                },
+                "feature": {
+                    "description": "database, json field, dynamic field, partition key, array field, sparse vector field, multiple vector fields or functions",
+                    "type": "string"
+                },
+                "since_version": {
+                    "description": "the first milvus version supporting the feature",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.ConsistencyLevel": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4
+            ],
+            "x-enum-varnames": [
+                "ConsistencyLevel_Strong",
+                "ConsistencyLevel_Session",
+                "ConsistencyLevel_Bounded",
+                "ConsistencyLevel_Eventually",
+                "ConsistencyLevel_Customized"
+            ]
+        },
+        "backuppb.ContinuousBackupStatus": {
+            "type": "object",
+            "properties": {
+                "chain_base_name": {
+                    "description": "full backup the current chain of incremental backups is built on",
+                    "type": "string"
+                },
+                "chain_length": {
+                    "description": "backups in the current chain, including the full backup",
+                    "type": "integer"
+                },
+                "collection_names": {
+                    "description": "collections to backup, empty means all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "full_interval": {
+                    "description": "seconds between full backups starting new chains",
+                    "type": "integer"
+                },
+                "interval": {
+                    "description": "seconds between incremental backups",
+                    "type": "integer"
+                },
+                "last_backup_name": {
+                    "description": "backup created by the last run",
+                    "type": "string"
+                },
+                "last_code": {
+                    "description": "response code of the last run",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "last_end_time": {
+                    "description": "unix timestamp of the end of the last run",
+                    "type": "integer"
+                },
+                "last_msg": {
+                    "description": "error msg of the last run if fail",
+                    "type": "string"
+                },
+                "last_start_time": {
+                    "description": "unix timestamp of the start of the last run, 0 means never run",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "prefix of the backups created by continuous backup",
+                    "type": "string"
+                },
+                "next_run_time": {
+                    "description": "unix timestamp of the next run",
+                    "type": "integer"
+                },
+                "recovery_backup_name": {
+                    "description": "last backup succeeded, the one to restore to recover to the recovery point",
+                    "type": "string"
+                },
+                "recovery_lag": {
+                    "description": "seconds of the data written since the recovery point, which would be lost if milvus is lost now",
+                    "type": "integer"
+                },
+                "recovery_time": {
+                    "description": "unix timestamp in seconds of the recovery point, data written before it is backed up, 0 means no backup yet",
+                    "type": "integer"
+                },
+                "running": {
+                    "description": "whether a backup is running",
+                    "type": "boolean"
+                }
+            }
+        },
+        "backuppb.CreateBackupRequest": {
+            "type": "object",
+            "properties": {
+                "allow_partial": {
+                    "description": "a failed collection doesn't fail the others, the backup is partial if only some collections fail",
+                    "type": "boolean"
+                },
+                "async": {
+                    "description": "async or not",
+                    "type": "boolean"
+                },
+                "backup_name": {
+                    "description": "backup name, will generate one if not set",
+                    "type": "string"
+                },
+                "base_backup_name": {
+                    "description": "base backup of incremental backup, required if incremental is true",
+                    "type": "string"
+                },
+                "check_row_count": {
+                    "description": "compare the rows of each partition in milvus right after the flush with the rows of the segments backed up,\ndiscrepancies are recorded in row_count_checks of the collections",
+                    "type": "boolean"
+                },
+                "collection_names": {
+                    "description": "collection names to backup, empty to backup all, support wildcard patterns like prod_*",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "collection_parallelism": {
+                    "description": "collection level parallelism of this backup, 0 means backup.parallelism.backupCollection in config",
+                    "type": "integer"
+                },
+                "collection_regex": {
+                    "description": "backup collections of all databases whose names match the regex, in addition to collection_names",
+                    "type": "string"
+                },
+                "compression": {
+                    "description": "compress binlogs while copying, support zstd and gzip. if not set, use backup.compression in config",
+                    "type": "string"
+                },
+                "copy_parallelism": {
+                    "description": "parallelism to copy data of this backup, 0 means backup.parallelism.copydata in config",
+                    "type": "integer"
+                },
+                "db_collections": {
+                    "description": "database and collections to backup. A json string. To support database. 2023.7.7",
+                    "type": "string"
+                },
+                "etcd_snapshot": {
+                    "description": "snapshot the etcd meta of the collections in milvus after they are flushed, requires etcd in config",
+                    "type": "boolean"
+                },
+                "flush_policy": {
+                    "description": "how to flush collections before backup, wait, skip or timeout.\nwait: flush and wait until the data is persisted, the default.\nskip: don't flush, only the data already persisted is backed up, the same as force.\ntimeout: flush and wait at most flush_timeout seconds, the data already persisted is backed up if it times out.",
+                    "type": "string"
+                },
+                "flush_timeout": {
+                    "description": "seconds to wait for the flush of each collection with flush policy timeout",
+                    "type": "integer"
+                },
+                "force": {
+                    "description": "force backup skip flush, Should make sure data has been stored into disk when using it",
+                    "type": "boolean"
+                },
+                "force_unlock": {
+                    "description": "take over the locks of the cluster and the backup name held by another process, only use it if the process\nholding them is known to be gone",
+                    "type": "boolean"
+                },
+                "incremental": {
+                    "description": "incremental backup, only copy segments added or changed since the base backup",
+                    "type": "boolean"
+                },
+                "labels": {
+                    "description": "key/value labels attached to the backup, like env=prod and app=search",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "meta_only": {
+                    "description": "only backup meta, including collection schema and index info",
+                    "type": "boolean"
+                },
+                "name_template": {
+                    "description": "template of the backup name generated if backup_name is not set, support {{date}}, {{time}} and {{cluster}},\nbackup.nameTemplate in config is used if not set",
+                    "type": "string"
+                },
+                "partitions": {
+                    "description": "partitions to backup, key is collection name or db.collection_name, collections not in it backup all partitions.\nonly the collections in it are backed up if collection_names, db_collections and collection_regex are not set",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/backuppb.PartitionNames"
+                    }
+                },
+                "rbac": {
+                    "description": "backup users, roles and grants as well",
+                    "type": "boolean"
+                },
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                },
+                "resume": {
+                    "description": "resume an interrupted backup with the backup_name from its checkpoint, segments copied will be skipped",
+                    "type": "boolean"
+                },
+                "sample_rows": {
+                    "description": "rows of each collection to sample right after the flush, stored in backup to verify the restored collections by\nverify_sample_rows of restore, only loaded collections can be sampled",
+                    "type": "integer"
+                },
+                "scan_binlogs": {
+                    "description": "scan the binlogs of each collection in the bucket of milvus before backup, report the ones not referenced by any\npersistent segment and the segments without binlogs in binlog_scan of the collections",
+                    "type": "boolean"
+                },
+                "sse_customer_key": {
+                    "description": "customer key of sse type customer, base64 or hex encoded 32 bytes, or reference like env:NAME and file:PATH",
+                    "type": "string"
+                },
+                "sse_kms_key_id": {
+                    "description": "kms key of sse type kms, key id or arn of aws kms, or resource name of cloud kms key for gcs",
+                    "type": "string"
+                },
+                "sse_type": {
+                    "description": "server side encryption of the backup files, kms, customer or none. if not set, use backup.serverSideEncryption in config",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.DataType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5,
+                10,
+                11,
+                20,
+                21,
+                22,
+                23,
+                100,
+                101,
+                104
+            ],
+            "x-enum-varnames": [
+                "DataType_None",
+                "DataType_Bool",
+                "DataType_Int8",
+                "DataType_Int16",
+                "DataType_Int32",
+                "DataType_Int64",
+                "DataType_Float",
+                "DataType_Double",
+                "DataType_String",
+                "DataType_VarChar",
+                "DataType_Array",
+                "DataType_Json",
+                "DataType_BinaryVector",
+                "DataType_FloatVector",
+                "DataType_SparseFloatVector"
+            ]
+        },
+        "backuppb.DeleteBackupResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "deleted_backups": {
+                    "description": "backups deleted, including the incremental backups referencing the backup if force is set",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.EstimateBackupResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "collections": {
+                    "description": "collections would be backed up",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.CollectionEstimate"
+                    }
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                },
+                "segment_num": {
+                    "description": "total number of segments",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "total size in bytes, before compression",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.EtcdSnapshotInfo": {
+            "type": "object",
+            "properties": {
+                "key_count": {
+                    "type": "integer"
+                },
+                "meta_root_path": {
+                    "description": "root path of milvus in etcd, like by-dev/meta",
+                    "type": "string"
+                },
+                "revision": {
+                    "description": "etcd revision all the keys are read at",
+                    "type": "integer"
+                },
+                "size": {
+                    "description": "bytes of the values",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.FieldBinlog": {
+            "type": "object",
+            "properties": {
+                "binlogs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.Binlog"
+                    }
+                },
+                "fieldID": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.FieldSchema": {
+            "type": "object",
+            "properties": {
+                "autoID": {
+                    "type": "boolean"
+                },
+                "data_type": {
+                    "$ref": "#/definitions/backuppb.DataType"
+                },
+                "default_value": {
+                    "$ref": "#/definitions/backuppb.ValueField"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "element_type": {
+                    "$ref": "#/definitions/backuppb.DataType"
+                },
+                "fieldID": {
+                    "type": "integer"
+                },
+                "index_params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                    }
+                },
+                "is_dynamic": {
+                    "type": "boolean"
+                },
+                "is_function_output": {
+                    "type": "boolean"
+                },
+                "is_partition_key": {
+                    "type": "boolean"
+                },
+                "is_primary_key": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "state": {
+                    "$ref": "#/definitions/backuppb.FieldState"
+                },
+                "type_params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                    }
+                }
+            }
+        },
+        "backuppb.FieldState": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3
+            ],
+            "x-enum-varnames": [
+                "FieldState_FieldCreated",
+                "FieldState_FieldCreating",
+                "FieldState_FieldDropping",
+                "FieldState_FieldDropped"
+            ]
+        },
+        "backuppb.FunctionSchema": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "input_field_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "input_field_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "output_field_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "output_field_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "params": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/backuppb.FunctionType"
+                }
+            }
+        },
+        "backuppb.FunctionType": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2
+            ],
+            "x-enum-varnames": [
+                "FunctionType_FunctionUnknown",
+                "FunctionType_BM25",
+                "FunctionType_TextEmbedding"
+            ]
+        },
+        "backuppb.GarbageCollectResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
                     ]
                 },
                 "msg": {
                     "description": "error msg if fail",
                     "type": "string"
                 },
+                "removed_files": {
+                    "description": "orphaned files removed, or to remove in dry run mode",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "removed_size": {
+                    "description": "total size of the orphaned files in bytes",
+                    "type": "integer"
+                },
                 "requestId": {
                     "description": "uuid of the request to response",
                     "type": "string"
                 }
             }
         },
-        "backuppb.BackupTaskStateCode": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-varnames": [
-                "BackupTaskStateCode_BACKUP_INITIAL",
-                "BackupTaskStateCode_BACKUP_EXECUTING",
-                "BackupTaskStateCode_BACKUP_SUCCESS",
-                "BackupTaskStateCode_BACKUP_FAIL",
-                "BackupTaskStateCode_BACKUP_TIMEOUT"
-            ]
-        },
-        "backuppb.Binlog": {
+        "backuppb.GetRecoveryPointResponse": {
             "type": "object",
             "properties": {
-                "entries_num": {
-                    "type": "integer"
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
                 },
-                "log_path": {
+                "data": {
+                    "description": "status of continuous backup",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ContinuousBackupStatus"
+                        }
+                    ]
+                },
+                "msg": {
+                    "description": "error msg if fail",
                     "type": "string"
                 },
-                "log_size": {
-                    "type": "integer"
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.GetScheduleResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
                 },
-                "timestamp_from": {
-                    "type": "integer"
+                "jobs": {
+                    "description": "status of the schedule jobs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.ScheduleJobStatus"
+                    }
                 },
-                "timestamp_to": {
-                    "type": "integer"
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
                 }
             }
         },
-        "backuppb.CollectionBackupInfo": {
+        "backuppb.GrantEntity": {
             "type": "object",
             "properties": {
-                "backup_physical_timestamp": {
-                    "description": "physical unix time of backup",
-                    "type": "integer"
+                "db_name": {
+                    "type": "string"
                 },
-                "backup_timestamp": {
-                    "description": "logical time of backup, used for restore",
-                    "type": "integer"
+                "object": {
+                    "description": "object type, such as Global, Collection and User",
+                    "type": "string"
                 },
-                "collection_id": {
-                    "type": "integer"
+                "object_name": {
+                    "type": "string"
                 },
-                "collection_name": {
+                "privilege": {
                     "type": "string"
                 },
-                "consistency_level": {
-                    "$ref": "#/definitions/backuppb.ConsistencyLevel"
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.IndexInfo": {
+            "type": "object",
+            "properties": {
+                "field_name": {
+                    "type": "string"
                 },
-                "db_name": {
+                "index_name": {
+                    "type": "string"
+                },
+                "index_type": {
+                    "type": "string"
+                },
+                "mmap_enabled": {
+                    "description": "if true the index is memory mapped, mmap.enabled in params",
+                    "type": "boolean"
+                },
+                "params": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "backuppb.JobInfo": {
+            "type": "object",
+            "properties": {
+                "backup_name": {
+                    "type": "string"
+                },
+                "bucket_name": {
+                    "description": "bucket and root path of the backup restored from, empty if it is the backup storage of config",
                     "type": "string"
                 },
+                "copied_size": {
+                    "description": "bytes copied or restored",
+                    "type": "integer"
+                },
                 "end_time": {
                     "type": "integer"
                 },
                 "errorMessage": {
                     "type": "string"
                 },
-                "has_index": {
-                    "type": "boolean"
-                },
                 "id": {
+                    "description": "id of the backup or restore task",
                     "type": "string"
                 },
-                "index_infos": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.IndexInfo"
-                    }
-                },
-                "load_state": {
+                "instance": {
+                    "description": "host:pid of the server running the job",
                     "type": "string"
                 },
-                "partition_backups": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.PartitionBackupInfo"
-                    }
+                "path": {
+                    "type": "string"
                 },
                 "progress": {
-                    "type": "integer"
-                },
-                "schema": {
-                    "$ref": "#/definitions/backuppb.CollectionSchema"
-                },
-                "shards_num": {
+                    "description": "percentage of the data copied or restored",
                     "type": "integer"
                 },
                 "size": {
+                    "description": "bytes to copy or restore",
                     "type": "integer"
                 },
                 "start_time": {
+                    "description": "unix seconds",
                     "type": "integer"
                 },
                 "state_code": {
-                    "$ref": "#/definitions/backuppb.BackupTaskStateCode"
-                }
-            }
-        },
-        "backuppb.CollectionSchema": {
-            "type": "object",
-            "properties": {
-                "autoID": {
-                    "type": "boolean"
+                    "$ref": "#/definitions/backuppb.JobStateCode"
                 },
-                "description": {
+                "type": {
+                    "description": "backup or restore",
                     "type": "string"
                 },
-                "enable_dynamic_field": {
-                    "type": "boolean"
-                },
-                "fields": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/backuppb.FieldSchema"
-                    }
-                },
-                "name": {
-                    "type": "string"
+                "update_time": {
+                    "description": "unix seconds the record of the job in backup storage is updated",
+                    "type": "integer"
                 }
             }
         },
-        "backuppb.ConsistencyLevel": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3,
-                4
-            ],
-            "x-enum-varnames": [
-                "ConsistencyLevel_Strong",
-                "ConsistencyLevel_Session",
-                "ConsistencyLevel_Bounded",
-                "ConsistencyLevel_Eventually",
-                "ConsistencyLevel_Customized"
-            ]
-        },
-        "backuppb.CreateBackupRequest": {
+        "backuppb.JobResponse": {
             "type": "object",
             "properties": {
-                "async": {
-                    "description": "async or not",
-                    "type": "boolean"
-                },
-                "backup_name": {
-                    "description": "backup name, will generate one if not set",
-                    "type": "string"
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
                 },
-                "collection_names": {
-                    "description": "collection names to backup, empty to backup all",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "data": {
+                    "$ref": "#/definitions/backuppb.JobInfo"
                 },
-                "db_collections": {
-                    "description": "database and collections to backup. A json string. To support database. 2023.7.7",
+                "msg": {
+                    "description": "error msg if fail",
                     "type": "string"
                 },
-                "force": {
-                    "description": "force backup skip flush, Should make sure data has been stored into disk when using it",
-                    "type": "boolean"
-                },
-                "meta_only": {
-                    "description": "only backup meta, including collection schema and index info",
-                    "type": "boolean"
-                },
                 "requestId": {
-                    "description": "uuid of request, will generate one if not set",
+                    "description": "uuid of the request to response",
                     "type": "string"
                 }
-            }
-        },
-        "backuppb.DataType": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3,
-                4,
-                5,
-                10,
-                11,
-                20,
-                21,
-                22,
-                23,
-                100,
-                101
-            ],
-            "x-enum-varnames": [
-                "DataType_None",
-                "DataType_Bool",
-                "DataType_Int8",
-                "DataType_Int16",
-                "DataType_Int32",
-                "DataType_Int64",
-                "DataType_Float",
-                "DataType_Double",
-                "DataType_String",
-                "DataType_VarChar",
-                "DataType_Array",
-                "DataType_Json",
-                "DataType_BinaryVector",
-                "DataType_FloatVector"
+            }
+        },
+        "backuppb.JobStateCode": {
+            "type": "integer",
+            "enum": [
+                0,
+                1,
+                2,
+                3,
+                4,
+                5
+            ],
+            "x-enum-varnames": [
+                "JobStateCode_JOB_RUNNING",
+                "JobStateCode_JOB_SUCCESS",
+                "JobStateCode_JOB_FAIL",
+                "JobStateCode_JOB_CANCELING",
+                "JobStateCode_JOB_CANCELED",
+                "JobStateCode_JOB_INTERRUPTED"
             ]
         },
-        "backuppb.DeleteBackupResponse": {
+        "backuppb.KeyValuePair": {
+            "type": "object",
+            "properties": {
+                "key": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.ListBackupsResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -558,6 +2072,13 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "data": {
+                    "description": "backup info entities",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.BackupInfo"
+                    }
+                },
                 "msg": {
                     "description": "error msg if fail",
                     "type": "string"
@@ -565,120 +2086,114 @@ const docTemplate = `{
                 "requestId": {
                     "description": "uuid of the request to response",
                     "type": "string"
+                },
+                "total": {
+                    "description": "number of backups matched before offset and limit applied",
+                    "type": "integer"
                 }
             }
         },
-        "backuppb.FieldBinlog": {
+        "backuppb.ListJobsResponse": {
             "type": "object",
             "properties": {
-                "binlogs": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "data": {
+                    "description": "sorted by start time, the latest first",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.Binlog"
+                        "$ref": "#/definitions/backuppb.JobInfo"
                     }
                 },
-                "fieldID": {
-                    "type": "integer"
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
                 }
             }
         },
-        "backuppb.FieldSchema": {
+        "backuppb.PartitionBackupInfo": {
             "type": "object",
             "properties": {
-                "autoID": {
-                    "type": "boolean"
-                },
-                "data_type": {
-                    "$ref": "#/definitions/backuppb.DataType"
-                },
-                "default_value": {
-                    "$ref": "#/definitions/backuppb.ValueField"
+                "collection_id": {
+                    "type": "integer"
                 },
-                "description": {
+                "load_state": {
                     "type": "string"
                 },
-                "element_type": {
-                    "$ref": "#/definitions/backuppb.DataType"
-                },
-                "fieldID": {
+                "partition_id": {
                     "type": "integer"
                 },
-                "index_params": {
+                "partition_name": {
+                    "type": "string"
+                },
+                "segment_backups": {
+                    "description": "array of segment backup",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                        "$ref": "#/definitions/backuppb.SegmentBackupInfo"
                     }
                 },
-                "is_dynamic": {
-                    "type": "boolean"
-                },
-                "is_partition_key": {
-                    "type": "boolean"
-                },
-                "is_primary_key": {
-                    "type": "boolean"
-                },
-                "name": {
-                    "type": "string"
-                },
-                "state": {
-                    "$ref": "#/definitions/backuppb.FieldState"
-                },
-                "type_params": {
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.PartitionNames": {
+            "type": "object",
+            "properties": {
+                "names": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.KeyValuePair"
+                        "type": "string"
                     }
                 }
             }
         },
-        "backuppb.FieldState": {
-            "type": "integer",
-            "enum": [
-                0,
-                1,
-                2,
-                3
-            ],
-            "x-enum-varnames": [
-                "FieldState_FieldCreated",
-                "FieldState_FieldCreating",
-                "FieldState_FieldDropping",
-                "FieldState_FieldDropped"
-            ]
-        },
-        "backuppb.IndexInfo": {
+        "backuppb.PauseBackupRequest": {
             "type": "object",
             "properties": {
-                "field_name": {
-                    "type": "string"
-                },
-                "index_name": {
+                "backup_name": {
+                    "description": "name of the running backup",
                     "type": "string"
                 },
-                "index_type": {
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
                     "type": "string"
-                },
-                "params": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
                 }
             }
         },
-        "backuppb.KeyValuePair": {
+        "backuppb.PauseBackupResponse": {
             "type": "object",
             "properties": {
-                "key": {
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "msg": {
+                    "description": "error msg if fail",
                     "type": "string"
                 },
-                "value": {
+                "requestId": {
+                    "description": "uuid of the request to response",
                     "type": "string"
                 }
             }
         },
-        "backuppb.ListBackupsResponse": {
+        "backuppb.PruneBackupsResponse": {
             "type": "object",
             "properties": {
                 "code": {
@@ -689,11 +2204,18 @@ const docTemplate = `{
                         }
                     ]
                 },
-                "data": {
-                    "description": "backup info entities",
+                "deleted_backups": {
+                    "description": "backups deleted, or to delete in dry run mode",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.BackupInfo"
+                        "type": "string"
+                    }
+                },
+                "kept_backups": {
+                    "description": "backups kept by the retention policy",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
                     }
                 },
                 "msg": {
@@ -706,30 +2228,26 @@ const docTemplate = `{
                 }
             }
         },
-        "backuppb.PartitionBackupInfo": {
+        "backuppb.RBACMeta": {
             "type": "object",
             "properties": {
-                "collection_id": {
-                    "type": "integer"
-                },
-                "load_state": {
-                    "type": "string"
-                },
-                "partition_id": {
-                    "type": "integer"
-                },
-                "partition_name": {
-                    "type": "string"
+                "grants": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.GrantEntity"
+                    }
                 },
-                "segment_backups": {
-                    "description": "array of segment backup",
+                "roles": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/backuppb.SegmentBackupInfo"
+                        "$ref": "#/definitions/backuppb.RoleEntity"
                     }
                 },
-                "size": {
-                    "type": "integer"
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.UserEntity"
+                    }
                 }
             }
         },
@@ -767,6 +2285,22 @@ const docTemplate = `{
                     "description": "if bucket_name and path is set. will override bucket/path in config.",
                     "type": "string"
                 },
+                "bulkinsert_parallelism": {
+                    "description": "segment groups bulk inserted at the same time across all collections of this restore, 0 means\nbackup.bulkinsert.concurrency in config",
+                    "type": "integer"
+                },
+                "check_manifest": {
+                    "description": "check the sizes of the files to restore against the manifest of backup before restoring anything, the restore\nfails at once if any file is missing or its size mismatches",
+                    "type": "boolean"
+                },
+                "check_row_count": {
+                    "description": "compare the rows of each partition restored in milvus with the rows in backup after the data is restored,\ndiscrepancies are recorded in row_count_checks of the collection tasks",
+                    "type": "boolean"
+                },
+                "collection_name_template": {
+                    "description": "template of target collection names, support {{db}}, {{name}} and {{date}}, like {{name}}_restored_{{date}}.\ncollection_renames has higher priority, can't be used with collection_suffix",
+                    "type": "string"
+                },
                 "collection_names": {
                     "description": "collections to restore",
                     "type": "array",
@@ -774,6 +2308,17 @@ const docTemplate = `{
                         "type": "string"
                     }
                 },
+                "collection_parallelism": {
+                    "description": "collection level parallelism of this restore, 0 means backup.parallelism.restoreCollection in config",
+                    "type": "integer"
+                },
+                "collection_properties": {
+                    "description": "properties of collections set on restore over the properties in backup, like collection.ttl.seconds: 0 to\nkeep old data from expiring",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
                 "collection_renames": {
                     "description": "2, give a map to rename the collections, if not given, use the original name.\ncollection_renames has higher priority than collection_suffix",
                     "type": "object",
@@ -789,6 +2334,10 @@ const docTemplate = `{
                     "description": "database and collections to restore. A json string. To support database. 2023.7.7",
                     "type": "string"
                 },
+                "downgrade_features": {
+                    "description": "restore the collections using features the target milvus doesn't support without them, like the fields of types\nnot supported, whose data is not restored. the restore fails if a collection uses such features and it is not set",
+                    "type": "boolean"
+                },
                 "dropExistCollection": {
                     "description": "if true, drop existing target collection before create",
                     "type": "boolean"
@@ -797,29 +2346,106 @@ const docTemplate = `{
                     "description": "if true, drop existing index of target collection before create",
                     "type": "boolean"
                 },
+                "dry_run": {
+                    "description": "only check the restore and return the plan in data with a dry run report, nothing is written to milvus",
+                    "type": "boolean"
+                },
+                "field_mappings": {
+                    "description": "restore fields of backup into the fields of other names of the existing collection, backup field name -\u003e collection field name",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "index_mode": {
+                    "description": "when to create the indexes recorded in backup, immediate, deferred or skip.\nimmediate: created with the collection before data is restored, milvus builds them while bulk inserting.\ndeferred: created after all data of the collection is restored, indexes are built once.\nskip: indexes are not restored.\nimmediate if restore_index is true and skip if not, when not set",
+                    "type": "string"
+                },
+                "label_selector": {
+                    "description": "restore the latest successful backup whose labels match the selector if backup_name is not set, otherwise\nthe backup named must match it",
+                    "type": "string"
+                },
+                "load_collection": {
+                    "description": "load the restored collections loaded when backed up, after their data and indexes are restored",
+                    "type": "boolean"
+                },
                 "metaOnly": {
                     "description": "if true only restore meta, not restore data",
                     "type": "boolean"
                 },
+                "partitions": {
+                    "description": "partitions to restore, key is collection name or db.collection_name in backup, collections not in it restore all partitions",
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/backuppb.PartitionNames"
+                    }
+                },
                 "path": {
                     "description": "if bucket_name and path is set. will override bucket/path in config.",
                     "type": "string"
                 },
+                "rbac": {
+                    "description": "restore users, roles and grants in backup as well",
+                    "type": "boolean"
+                },
+                "rbac_user_password": {
+                    "description": "password of the users created by rbac restore, passwords are not backed up. users not exist are skipped if not set",
+                    "type": "string"
+                },
+                "replica_number": {
+                    "description": "replicas to load the collections with if load_collection, the replica number in backup if not set",
+                    "type": "integer"
+                },
                 "requestId": {
                     "description": "uuid of request, will generate one if not set",
                     "type": "string"
                 },
+                "resource_groups": {
+                    "description": "resource groups to load the collections into if load_collection, the resource groups in backup if not set",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "restoreIndex": {
                     "description": "if true restore index info",
                     "type": "boolean"
                 },
+                "restore_aliases": {
+                    "description": "how to restore the aliases of collections in backup, repoint, create or skip.\nrepoint: aliases are created, or altered to point at the restored collections if they exist.\ncreate: aliases are created, the restore fails if an alias exists.\nskip by default: aliases are not restored.\naliases are restored after the data of the collection is restored.",
+                    "type": "string"
+                },
+                "resume_task_id": {
+                    "description": "id of an interrupted restore task to resume, the data already bulk inserted is skipped.\nother options are taken from the restore task, backup_name, bucket_name and path should be the same as the task",
+                    "type": "string"
+                },
+                "schema_policy": {
+                    "description": "how the schema of an existing collection with skip_create_collection may differ from backup, strict or compatible.\nstrict by default: the fields should be the same as backup.\ncompatible: fields only in backup are not restored, fields only in collection are filled by milvus\nif they are nullable or have a default value, fields of the same name are restored under their field ids of the collection",
+                    "type": "string"
+                },
                 "skipCreateCollection": {
                     "description": "if true, will skip collection, use when collection exist, restore index or data",
                     "type": "boolean"
                 },
+                "sse_customer_key": {
+                    "description": "customer key of the backup encrypted by sse type customer, backup.serverSideEncryption.customerKey in config if not set",
+                    "type": "string"
+                },
+                "target_db_name": {
+                    "description": "restore collections into this database instead of their original ones, created if not exist.\ncollection_renames with database has higher priority",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "restore data to a consistent point in time, physical timestamp in seconds, same as backup_physical_timestamp.\ndata written after it will not be restored. 0 means restore to the backup timestamp of each collection",
+                    "type": "integer"
+                },
                 "useAutoIndex": {
                     "description": "if true use autoindex when restore vector index",
                     "type": "boolean"
+                },
+                "verify_sample_rows": {
+                    "description": "rows sampled in backup to query in each restored collection after it is restored and loaded, the fields are\ncompared with backup and the result is in sample_verification of the collection tasks",
+                    "type": "integer"
                 }
             }
         },
@@ -842,6 +2468,18 @@ const docTemplate = `{
                         }
                     ]
                 },
+                "dry_run_report": {
+                    "description": "problems found by a dry run restore",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.RestoreDryRunReport"
+                        }
+                    ]
+                },
+                "job_id": {
+                    "description": "id of the job executing the restore, to query or cancel it by the job api",
+                    "type": "string"
+                },
                 "msg": {
                     "description": "error msg if fail",
                     "type": "string"
@@ -855,6 +2493,10 @@ const docTemplate = `{
         "backuppb.RestoreBackupTask": {
             "type": "object",
             "properties": {
+                "backup_name": {
+                    "description": "name of the restored backup, the one selected by label_selector of the request if backup_name is not set",
+                    "type": "string"
+                },
                 "collection_restore_tasks": {
                     "type": "array",
                     "items": {
@@ -876,6 +2518,13 @@ const docTemplate = `{
                 "restored_size": {
                     "type": "integer"
                 },
+                "skipped_collections": {
+                    "description": "db.collection_name of the collections in backup not restored, not requested or failed in a partial backup",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "start_time": {
                     "type": "integer"
                 },
@@ -890,9 +2539,27 @@ const docTemplate = `{
         "backuppb.RestoreCollectionTask": {
             "type": "object",
             "properties": {
+                "bulk_insert_jobs": {
+                    "description": "import tasks of milvus executed to bulk insert the data of the collection",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.BulkInsertJob"
+                    }
+                },
+                "check_row_count": {
+                    "description": "compare the rows restored with the rows in backup after the data is restored",
+                    "type": "boolean"
+                },
                 "coll_backup": {
                     "$ref": "#/definitions/backuppb.CollectionBackupInfo"
                 },
+                "downgraded_features": {
+                    "description": "features of the collection in backup not supported by the target milvus, restored without them",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.CompatIssue"
+                    }
+                },
                 "dropExistCollection": {
                     "description": "if true drop the collections",
                     "type": "boolean"
@@ -907,13 +2574,36 @@ const docTemplate = `{
                 "errorMessage": {
                     "type": "string"
                 },
+                "field_id_mappings": {
+                    "description": "field id in backup -\u003e field id of the existing collection, for the fields whose ids differ",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
                 "id": {
                     "type": "string"
                 },
+                "in_place": {
+                    "description": "true if the binlogs are bulk inserted from backup in place, without copying them into milvus bucket first",
+                    "type": "boolean"
+                },
+                "index_mode": {
+                    "description": "when to create the indexes, see RestoreBackupRequest.index_mode",
+                    "type": "string"
+                },
+                "load_collection": {
+                    "description": "load the collection with the replicas in the resource groups after it is restored",
+                    "type": "boolean"
+                },
                 "metaOnly": {
                     "description": "if true only restore meta",
                     "type": "boolean"
                 },
+                "meta_restored": {
+                    "description": "if true the collection and index have been created, skipped when resume",
+                    "type": "boolean"
+                },
                 "partition_restore_tasks": {
                     "type": "array",
                     "items": {
@@ -923,17 +2613,64 @@ const docTemplate = `{
                 "progress": {
                     "type": "integer"
                 },
+                "properties": {
+                    "description": "properties to set on the collection, the properties in backup with the overrides of the request",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "replica_number": {
+                    "type": "integer"
+                },
+                "resource_groups": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "restoreIndex": {
                     "description": "if true restore index info",
                     "type": "boolean"
                 },
+                "restore_aliases": {
+                    "description": "how to restore the aliases, see RestoreBackupRequest.restore_aliases",
+                    "type": "string"
+                },
+                "restore_timestamp": {
+                    "description": "restore data to this point in time, see RestoreBackupRequest.timestamp",
+                    "type": "integer"
+                },
+                "restored_groups": {
+                    "description": "partitionID/groupID of the segment groups which have been bulk inserted, skipped when resume",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
                 "restored_size": {
                     "type": "integer"
                 },
+                "row_count_checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.RowCountCheck"
+                    }
+                },
+                "sample_verification": {
+                    "$ref": "#/definitions/backuppb.SampleVerification"
+                },
                 "skipCreateCollection": {
                     "description": "if true will skip create collections",
                     "type": "boolean"
                 },
+                "skipped_field_ids": {
+                    "description": "ids of the fields in backup not restored into the existing collection",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
                 "start_time": {
                     "type": "integer"
                 },
@@ -952,6 +2689,75 @@ const docTemplate = `{
                 "useAutoIndex": {
                     "description": "if true use autoindex when restore vector index",
                     "type": "boolean"
+                },
+                "verify_sample_rows": {
+                    "description": "rows sampled in backup to verify after the collection is restored",
+                    "type": "integer"
+                }
+            }
+        },
+        "backuppb.RestoreDryRunReport": {
+            "type": "object",
+            "properties": {
+                "alias_conflicts": {
+                    "description": "aliases in backup already exist in the target databases, checked when restore_aliases is create",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "compat_issues": {
+                    "description": "features of the collections not supported by the target milvus",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/backuppb.CompatIssue"
+                    }
+                },
+                "conflicts": {
+                    "description": "target collections already exist",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "corrupted_files": {
+                    "description": "files whose size mismatches the manifest of backup, checked when check_manifest",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "databases_to_create": {
+                    "description": "target databases not exist, will be created by restore",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "estimated_seconds": {
+                    "description": "estimated seconds to copy the data at backup.bandwidthLimit, 0 if the bandwidth is unlimited",
+                    "type": "integer"
+                },
+                "missing_files": {
+                    "description": "backup paths of partitions not found in backup storage",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "repartitioned": {
+                    "description": "existing partition key collections whose partitions differ from backup, rows are dispatched into their\npartitions by the partition key instead of restored partition by partition",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "schema_mismatches": {
+                    "description": "target collections exist with a schema different from backup, checked when skip_create_collection",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -998,9 +2804,161 @@ const docTemplate = `{
                 "RestoreTaskStateCode_TIMEOUT"
             ]
         },
+        "backuppb.ResumeBackupRequest": {
+            "type": "object",
+            "properties": {
+                "async": {
+                    "description": "execute asynchronously or not",
+                    "type": "boolean"
+                },
+                "backup_name": {
+                    "description": "name of the paused backup",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of request, will generate one if not set",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.RoleEntity": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.RowCountCheck": {
+            "type": "object",
+            "properties": {
+                "backup_rows": {
+                    "description": "rows of the segments whose binlogs are copied into backup",
+                    "type": "integer"
+                },
+                "base_rows": {
+                    "description": "rows of the target partition before restore, milvus_rows is expected to be base_rows + backup_rows after restore",
+                    "type": "integer"
+                },
+                "detail": {
+                    "description": "the discrepancy, or why the rows are not compared",
+                    "type": "string"
+                },
+                "milvus_rows": {
+                    "description": "rows in the statistics of milvus, right after the flush of backup or after the data is restored",
+                    "type": "integer"
+                },
+                "partition_name": {
+                    "description": "partition of the collection, empty for the whole collection, like the partitions of partition key collections\nrestored together",
+                    "type": "string"
+                },
+                "state": {
+                    "description": "matched, mismatched or skipped",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.SampleVerification": {
+            "type": "object",
+            "properties": {
+                "checked_rows": {
+                    "description": "rows sampled in backup and queried",
+                    "type": "integer"
+                },
+                "detail": {
+                    "type": "string"
+                },
+                "matched_rows": {
+                    "description": "rows found with all the fields equal to backup",
+                    "type": "integer"
+                },
+                "mismatched_rows": {
+                    "description": "primary keys of the rows whose fields differ, with the fields, like 12: vector, age",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "missing_pks": {
+                    "description": "primary keys of the rows not found in the restored collection",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "description": "matched_rows / checked_rows, 1 if all the rows match",
+                    "type": "number"
+                },
+                "state": {
+                    "description": "verified, or skipped with the reason in detail",
+                    "type": "string"
+                }
+            }
+        },
+        "backuppb.ScheduleJobStatus": {
+            "type": "object",
+            "properties": {
+                "collection_names": {
+                    "description": "collections to backup, empty means all",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "cron": {
+                    "description": "cron expression of the job",
+                    "type": "string"
+                },
+                "last_backup_name": {
+                    "description": "backup created by the last run",
+                    "type": "string"
+                },
+                "last_code": {
+                    "description": "response code of the last run",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "last_end_time": {
+                    "description": "unix timestamp of the end of the last run",
+                    "type": "integer"
+                },
+                "last_msg": {
+                    "description": "error msg of the last run if fail",
+                    "type": "string"
+                },
+                "last_start_time": {
+                    "description": "unix timestamp of the start of the last run, 0 means never run",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "name of the schedule job",
+                    "type": "string"
+                },
+                "next_run_time": {
+                    "description": "unix timestamp of the next run",
+                    "type": "integer"
+                },
+                "prune": {
+                    "description": "prune expired backups after backup",
+                    "type": "boolean"
+                },
+                "running": {
+                    "description": "whether the job is running",
+                    "type": "boolean"
+                }
+            }
+        },
         "backuppb.SegmentBackupInfo": {
             "type": "object",
             "properties": {
+                "backuped": {
+                    "description": "all binlogs of the segment have been copied into backup, used to resume an interrupted backup",
+                    "type": "boolean"
+                },
                 "binlogs": {
                     "type": "array",
                     "items": {
@@ -1010,12 +2968,20 @@ const docTemplate = `{
                 "collection_id": {
                     "type": "integer"
                 },
+                "compression": {
+                    "description": "compression algorithm of the binlogs in backup, empty means not compressed",
+                    "type": "string"
+                },
                 "deltalogs": {
                     "type": "array",
                     "items": {
                         "$ref": "#/definitions/backuppb.FieldBinlog"
                     }
                 },
+                "encrypted": {
+                    "description": "the binlogs in backup are encrypted with AES-256-GCM",
+                    "type": "boolean"
+                },
                 "group_id": {
                     "description": "separate segments into multi groups by size,\nsegments in one group will be copied into one directory during backup\nand will bulkinsert in one call during restore",
                     "type": "integer"
@@ -1026,6 +2992,10 @@ const docTemplate = `{
                 "partition_id": {
                     "type": "integer"
                 },
+                "ref_backup_name": {
+                    "description": "name of the backup which actually stores the binlogs of this segment,\nempty means the binlogs are stored in current backup. Set by incremental backup",
+                    "type": "string"
+                },
                 "segment_id": {
                     "type": "integer"
                 },
@@ -1040,6 +3010,21 @@ const docTemplate = `{
                 }
             }
         },
+        "backuppb.UserEntity": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "description": "names of roles granted to the user",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user": {
+                    "type": "string"
+                }
+            }
+        },
         "backuppb.ValueField": {
             "type": "object",
             "properties": {
@@ -1047,6 +3032,53 @@ const docTemplate = `{
                     "description": "Types that are valid to be assigned to Data:\n\t*ValueField_BoolData\n\t*ValueField_IntData\n\t*ValueField_LongData\n\t*ValueField_FloatData\n\t*ValueField_DoubleData\n\t*ValueField_StringData\n\t*ValueField_BytesData"
                 }
             }
+        },
+        "backuppb.VerifyBackupResponse": {
+            "type": "object",
+            "properties": {
+                "checked_files": {
+                    "description": "number of files checked",
+                    "type": "integer"
+                },
+                "checksum_files": {
+                    "description": "number of files checked by checksum, the others are only checked by size",
+                    "type": "integer"
+                },
+                "code": {
+                    "description": "response code. 0 means success. others are fail",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/backuppb.ResponseCode"
+                        }
+                    ]
+                },
+                "corrupted_files": {
+                    "description": "files whose size or checksum mismatch the backup meta",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "manifest": {
+                    "description": "files are checked against the manifest of backup instead of backup meta",
+                    "type": "boolean"
+                },
+                "missing_files": {
+                    "description": "files recorded in backup meta but not exist in storage",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "msg": {
+                    "description": "error msg if fail",
+                    "type": "string"
+                },
+                "requestId": {
+                    "description": "uuid of the request to response",
+                    "type": "string"
+                }
+            }
         }
     }
 }`
@@ -1061,6 +3093,8 @@ var SwaggerInfo = &swag.Spec{
 	Description:      "A data backup & restore tool for Milvus",
 	InfoInstanceName: "swagger",
 	SwaggerTemplate:  docTemplate,
+	LeftDelim:        "[[",
+	RightDelim:       "]]",
 }
 
 func init() {
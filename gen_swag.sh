@@ -39,7 +39,10 @@ sed -i "" -e "s/size,omitempty/size/g" ./backuppb/backup.pb.go
 
 popd
 
-swag init
+# descriptions contain {{ }} of the collection name template
+swag init --templateDelims "[[,]]"
+# OpenAPI 3.0 spec for client codegen, converted from the swagger 2.0 one
+go run ./internal/openapi/gen docs/swagger.json docs/openapi.json
 
 pushd ${BACK_PROTO_DIR}
 
@@ -0,0 +1,80 @@
+package common
+
+import (
+	"context"
+	"sync"
+)
+
+// AdaptiveLimiter limits the number of concurrent operations by a limit which can be changed while they run,
+// operations running beyond a lowered limit are not interrupted. A nil limiter doesn't limit.
+type AdaptiveLimiter struct {
+	mu      sync.Mutex
+	limit   int
+	running int
+	// closed and replaced when an operation may acquire
+	changed chan struct{}
+}
+
+// NewAdaptiveLimiter build a limiter, the limit is at least 1
+func NewAdaptiveLimiter(limit int) *AdaptiveLimiter {
+	if limit < 1 {
+		limit = 1
+	}
+	return &AdaptiveLimiter{limit: limit, changed: make(chan struct{})}
+}
+
+// Acquire blocks until the operation can run within the limit or ctx is done
+func (l *AdaptiveLimiter) Acquire(ctx context.Context) error {
+	if l == nil {
+		return nil
+	}
+	for {
+		l.mu.Lock()
+		if l.running < l.limit {
+			l.running++
+			l.mu.Unlock()
+			return nil
+		}
+		changed := l.changed
+		l.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Release finishes an operation acquired before
+func (l *AdaptiveLimiter) Release() {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.running--
+	l.notify()
+}
+
+// SetLimit changes the limit, which is at least 1
+func (l *AdaptiveLimiter) SetLimit(limit int) {
+	if limit < 1 {
+		limit = 1
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limit = limit
+	l.notify()
+}
+
+// Limit returns the current limit
+func (l *AdaptiveLimiter) Limit() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.limit
+}
+
+func (l *AdaptiveLimiter) notify() {
+	close(l.changed)
+	l.changed = make(chan struct{})
+}
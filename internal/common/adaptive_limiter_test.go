@@ -0,0 +1,59 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdaptiveLimiter(t *testing.T) {
+	l := NewAdaptiveLimiter(2)
+	ctx := context.Background()
+	assert.Nil(t, l.Acquire(ctx))
+	assert.Nil(t, l.Acquire(ctx))
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, l.Acquire(timeoutCtx), context.DeadlineExceeded)
+
+	acquired := make(chan struct{})
+	go func() {
+		l.Acquire(ctx)
+		close(acquired)
+	}()
+	// raising the limit lets the waiting operation run
+	l.SetLimit(3)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("operation not acquired after the limit is raised")
+	}
+
+	// lowered limit blocks new operations until the running ones are released
+	l.SetLimit(0)
+	assert.Equal(t, 1, l.Limit())
+	acquired = make(chan struct{})
+	go func() {
+		l.Acquire(ctx)
+		close(acquired)
+	}()
+	l.Release()
+	l.Release()
+	select {
+	case <-acquired:
+		t.Fatal("operation acquired beyond the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+	l.Release()
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("operation not acquired after release")
+	}
+
+	var unlimited *AdaptiveLimiter
+	assert.Nil(t, unlimited.Acquire(ctx))
+	unlimited.Release()
+}
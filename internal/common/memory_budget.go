@@ -0,0 +1,75 @@
+package common
+
+import (
+	"context"
+	"sync"
+
+	"github.com/zilliztech/milvus-backup/internal/metrics"
+)
+
+// MemoryBudget limits the bytes of data held in memory at the same time, an acquire larger than the budget waits
+// until nothing else is held and holds the whole budget. A nil budget doesn't limit.
+type MemoryBudget struct {
+	mu    sync.Mutex
+	limit int64
+	used  int64
+	// closed and replaced when memory is released
+	released chan struct{}
+}
+
+// NewMemoryBudget build a budget of limit bytes, nil if limit is not positive
+func NewMemoryBudget(limit int64) *MemoryBudget {
+	if limit <= 0 {
+		return nil
+	}
+	return &MemoryBudget{limit: limit, released: make(chan struct{})}
+}
+
+// Acquire blocks until size bytes fit into the budget or ctx is done, returns the bytes held which should be released
+func (m *MemoryBudget) Acquire(ctx context.Context, size int64) (int64, error) {
+	if m == nil || size <= 0 {
+		return 0, nil
+	}
+	if size > m.limit {
+		size = m.limit
+	}
+	for {
+		m.mu.Lock()
+		if m.used+size <= m.limit {
+			m.used += size
+			m.mu.Unlock()
+			metrics.BufferedBytes.Add(float64(size))
+			return size, nil
+		}
+		released := m.released
+		m.mu.Unlock()
+		select {
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		case <-released:
+		}
+	}
+}
+
+// Release returns the bytes acquired before
+func (m *MemoryBudget) Release(size int64) {
+	if m == nil || size <= 0 {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.used -= size
+	metrics.BufferedBytes.Sub(float64(size))
+	close(m.released)
+	m.released = make(chan struct{})
+}
+
+// Used returns the bytes held
+func (m *MemoryBudget) Used() int64 {
+	if m == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.used
+}
@@ -0,0 +1,50 @@
+package common
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryBudget(t *testing.T) {
+	m := NewMemoryBudget(100)
+	ctx := context.Background()
+	held, err := m.Acquire(ctx, 60)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(60), held)
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	_, err = m.Acquire(timeoutCtx, 50)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	// larger than the budget, held alone
+	acquired := make(chan int64)
+	go func() {
+		held, _ := m.Acquire(ctx, 500)
+		acquired <- held
+	}()
+	select {
+	case <-acquired:
+		t.Fatal("acquired beyond the budget")
+	case <-time.After(50 * time.Millisecond):
+	}
+	m.Release(60)
+	select {
+	case held := <-acquired:
+		assert.Equal(t, int64(100), held)
+	case <-time.After(time.Second):
+		t.Fatal("not acquired after release")
+	}
+	assert.Equal(t, int64(100), m.Used())
+	m.Release(100)
+	assert.Equal(t, int64(0), m.Used())
+
+	unlimited := NewMemoryBudget(0)
+	assert.Nil(t, unlimited)
+	held, err = unlimited.Acquire(ctx, 1<<40)
+	assert.NoError(t, err)
+	unlimited.Release(held)
+}
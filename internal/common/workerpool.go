@@ -10,6 +10,8 @@ import (
 
 	"golang.org/x/sync/errgroup"
 	"golang.org/x/time/rate"
+
+	"github.com/zilliztech/milvus-backup/internal/metrics"
 )
 
 // WorkerPool a pool that can control the total amount and rate of concurrency
@@ -20,6 +22,8 @@ type WorkerPool struct {
 
 	workerNum int
 	lim       *rate.Limiter
+	// name of the pool in metrics, jobs of unnamed pools are not counted
+	name string
 
 	nextId     atomic.Int64
 	jobsStatus sync.Map
@@ -50,6 +54,9 @@ func NewWorkerPool(ctx context.Context, workerNum int, rps int32) (*WorkerPool,
 	return &WorkerPool{job: make(chan JobWithId), workerNum: workerNum, g: g, lim: lim, subCtx: subCtx}, nil
 }
 
+// SetName sets the name of the pool in metrics, should be called before Start
+func (p *WorkerPool) SetName(name string) { p.name = name }
+
 func (p *WorkerPool) Start() {
 	//p.jobsStatus = make(map[*Job]string)
 	//p.jobsError = make(map[*Job]error)
@@ -67,9 +74,17 @@ func (p *WorkerPool) work() error {
 				}
 			}
 
+			if p.name != "" {
+				metrics.WorkerPoolRunningJobs.WithLabelValues(p.name).Inc()
+				defer metrics.WorkerPoolRunningJobs.WithLabelValues(p.name).Dec()
+			}
 			if err := jobWithId.job(p.subCtx); err != nil {
 				p.jobsError.Store(jobWithId.id, err)
 				p.jobsStatus.Store(jobWithId.id, "done")
+				// canceled by its own request, see WithRequest, the other jobs of the pool are not affected
+				if errors.Is(err, context.Canceled) && p.subCtx.Err() == nil {
+					return nil
+				}
 				return fmt.Errorf("workerpool: execute job %w", err)
 			}
 			p.jobsStatus.Store(jobWithId.id, "done")
@@ -79,6 +94,31 @@ func (p *WorkerPool) work() error {
 	return nil
 }
 
+// WithRequest wraps a job of a request to be executed in a pool shared by requests. The job runs with ctx of
+// the request, which is also canceled if the pool is done. Once the request is canceled, the job fails with
+// context.Canceled, which fails its own request only instead of the whole pool.
+func WithRequest(ctx context.Context, job Job) Job {
+	return func(poolCtx context.Context) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		jobCtx, cancel := context.WithCancel(ctx)
+		defer cancel()
+		go func() {
+			select {
+			case <-poolCtx.Done():
+				cancel()
+			case <-jobCtx.Done():
+			}
+		}()
+		err := job(jobCtx)
+		if err != nil && ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return err
+	}
+}
+
 func (p *WorkerPool) Submit(job Job) {
 	jobId := p.nextId.Inc()
 	p.job <- JobWithId{job: job, id: jobId}
@@ -93,6 +133,15 @@ func (p *WorkerPool) SubmitWithId(job Job) int64 {
 	return jobId
 }
 
+// ForgetJobs removes the status of the jobs waited, so that a pool shared by requests doesn't keep the status of
+// every job it ever executed. The jobs should not be waited again.
+func (p *WorkerPool) ForgetJobs(jobIds []int64) {
+	for _, jobId := range jobIds {
+		p.jobsStatus.Delete(jobId)
+		p.jobsError.Delete(jobId)
+	}
+}
+
 func (p *WorkerPool) WaitJobs(jobIds []int64) error {
 	for {
 		var done = true
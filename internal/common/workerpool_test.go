@@ -76,3 +76,51 @@ func TestWaitJobs(t *testing.T) {
 	assert.True(t, duration >= 8)
 	//wp.Done()
 }
+
+func TestWithRequest(t *testing.T) {
+	wp, err := NewWorkerPool(context.Background(), 3, 0)
+	assert.Nil(t, err)
+	wp.Start()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	started := make(chan struct{})
+	canceledJob := wp.SubmitWithId(WithRequest(ctx, func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+		return errors.New("interrupted")
+	}))
+	<-started
+	cancel()
+	assert.ErrorIs(t, wp.WaitJobs([]int64{canceledJob}), context.Canceled)
+
+	// the pool still works for the other requests
+	job := wp.SubmitWithId(WithRequest(context.Background(), func(ctx context.Context) error {
+		return ctx.Err()
+	}))
+	assert.Nil(t, wp.WaitJobs([]int64{job}))
+
+	wp.Done()
+	assert.Nil(t, wp.Wait())
+}
+
+func TestForgetJobs(t *testing.T) {
+	wp, err := NewWorkerPool(context.Background(), 3, 0)
+	assert.Nil(t, err)
+	wp.Start()
+
+	jobs := make([]int64, 0)
+	for i := 0; i < 5; i++ {
+		jobs = append(jobs, wp.SubmitWithId(func(ctx context.Context) error {
+			return nil
+		}))
+	}
+	assert.Nil(t, wp.WaitJobs(jobs))
+	wp.ForgetJobs(jobs)
+	for _, job := range jobs {
+		_, ok := wp.jobsStatus.Load(job)
+		assert.False(t, ok)
+	}
+
+	wp.Done()
+	assert.Nil(t, wp.Wait())
+}
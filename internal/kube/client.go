@@ -0,0 +1,167 @@
+// Package kube is a minimal client of the Kubernetes api, for the controller mode of milvus-backup running in a pod.
+// Objects are read and written as json by their api paths, like
+// /apis/backup.milvus.io/v1alpha1/namespaces/default/milvusbackups/daily.
+package kube
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	requestTimeout    = 30 * time.Second
+)
+
+// Client calls the Kubernetes api with the token of a service account
+type Client struct {
+	host       string
+	token      string
+	namespace  string
+	httpClient *http.Client
+}
+
+// NewClient creates a client of the api at host, like https://10.0.0.1:443. namespace is the default namespace of
+// the objects, token is sent as the bearer token if it is not empty.
+func NewClient(host string, token string, namespace string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: requestTimeout}
+	}
+	return &Client{
+		host:       strings.TrimSuffix(host, "/"),
+		token:      token,
+		namespace:  namespace,
+		httpClient: httpClient,
+	}
+}
+
+// NewInClusterClient creates a client with the service account of the pod, the namespace is the one of the pod
+func NewInClusterClient() (*Client, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, errors.New("not running in kubernetes, KUBERNETES_SERVICE_HOST and KUBERNETES_SERVICE_PORT are not set")
+	}
+	token, err := os.ReadFile(serviceAccountDir + "/token")
+	if err != nil {
+		return nil, fmt.Errorf("fail to read service account token: %w", err)
+	}
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("fail to read service account ca: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, errors.New("invalid service account ca")
+	}
+	namespace, err := os.ReadFile(serviceAccountDir + "/namespace")
+	if err != nil {
+		return nil, fmt.Errorf("fail to read service account namespace: %w", err)
+	}
+	httpClient := &http.Client{
+		Timeout:   requestTimeout,
+		Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+	}
+	return NewClient("https://"+net.JoinHostPort(host, port), strings.TrimSpace(string(token)),
+		strings.TrimSpace(string(namespace)), httpClient), nil
+}
+
+// Namespace returns the default namespace of the client
+func (c *Client) Namespace() string {
+	return c.namespace
+}
+
+// StatusError is returned if the api doesn't respond 2xx
+type StatusError struct {
+	Code int
+	// reason of the Status returned by the api, like NotFound or Conflict
+	Reason  string
+	Message string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("kubernetes api responds %d %s: %s", e.Code, e.Reason, e.Message)
+}
+
+// IsNotFound returns true if the object doesn't exist
+func IsNotFound(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusNotFound
+}
+
+// IsConflict returns true if the object is changed since it is read, or it already exists
+func IsConflict(err error) bool {
+	var statusErr *StatusError
+	return errors.As(err, &statusErr) && statusErr.Code == http.StatusConflict
+}
+
+// Get reads the object at path into out
+func (c *Client) Get(ctx context.Context, path string, out interface{}) error {
+	return c.Do(ctx, http.MethodGet, path, nil, out)
+}
+
+// Create creates the object in the collection at path
+func (c *Client) Create(ctx context.Context, path string, in interface{}, out interface{}) error {
+	return c.Do(ctx, http.MethodPost, path, in, out)
+}
+
+// Update replaces the object at path, it fails with a conflict if the resourceVersion of in is not the current one
+func (c *Client) Update(ctx context.Context, path string, in interface{}, out interface{}) error {
+	return c.Do(ctx, http.MethodPut, path, in, out)
+}
+
+// Do sends in as the json body and decodes the response into out, both may be nil
+func (c *Client) Do(ctx context.Context, method string, path string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.host+path, body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		status := struct {
+			Reason  string `json:"reason"`
+			Message string `json:"message"`
+		}{}
+		if json.Unmarshal(data, &status) != nil || status.Message == "" {
+			status.Message = strings.TrimSpace(string(data))
+		}
+		return &StatusError{Code: resp.StatusCode, Reason: status.Reason, Message: status.Message}
+	}
+	if out == nil || len(data) == 0 {
+		return nil
+	}
+	return json.Unmarshal(data, out)
+}
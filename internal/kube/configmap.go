@@ -0,0 +1,35 @@
+package kube
+
+import (
+	"context"
+)
+
+func configMapPath(namespace string) string {
+	return "/api/v1/namespaces/" + namespace + "/configmaps"
+}
+
+// GetConfigMap reads the configmap in the namespace
+func (c *Client) GetConfigMap(ctx context.Context, namespace string, name string) (*ConfigMap, error) {
+	configMap := &ConfigMap{}
+	if err := c.Get(ctx, configMapPath(namespace)+"/"+name, configMap); err != nil {
+		return nil, err
+	}
+	return configMap, nil
+}
+
+// ApplyConfigMap creates the configmap, or replaces the data and labels of the existing one
+func (c *Client) ApplyConfigMap(ctx context.Context, configMap *ConfigMap) error {
+	configMap.APIVersion = "v1"
+	configMap.Kind = "ConfigMap"
+	namespace := configMap.Metadata.Namespace
+	err := c.Create(ctx, configMapPath(namespace), configMap, nil)
+	if !IsConflict(err) {
+		return err
+	}
+	existing, err := c.GetConfigMap(ctx, namespace, configMap.Metadata.Name)
+	if err != nil {
+		return err
+	}
+	configMap.Metadata.ResourceVersion = existing.Metadata.ResourceVersion
+	return c.Update(ctx, configMapPath(namespace)+"/"+configMap.Metadata.Name, configMap, nil)
+}
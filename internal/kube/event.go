@@ -0,0 +1,28 @@
+package kube
+
+import (
+	"context"
+	"time"
+)
+
+// RecordEvent creates an event about the object, shown by kubectl describe
+func (c *Client) RecordEvent(ctx context.Context, object ObjectReference, eventType string, reason string, message string, component string) error {
+	now := time.Now().UTC().Truncate(time.Second)
+	event := &Event{
+		APIVersion: "v1",
+		Kind:       "Event",
+		Metadata: ObjectMeta{
+			GenerateName: object.Name + ".",
+			Namespace:    object.Namespace,
+		},
+		InvolvedObject: object,
+		Reason:         reason,
+		Message:        message,
+		Type:           eventType,
+		Source:         EventSource{Component: component},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	return c.Create(ctx, "/api/v1/namespaces/"+object.Namespace+"/events", event, nil)
+}
@@ -0,0 +1,252 @@
+package kube
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// leaseServer serves the leases of a namespace in memory, writes with a stale resourceVersion are conflicts
+type leaseServer struct {
+	mu     sync.Mutex
+	leases map[string][]byte
+	token  string
+}
+
+func (s *leaseServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if r.Header.Get("Authorization") != "Bearer "+s.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	const prefix = "/apis/coordination.k8s.io/v1/namespaces/ns/leases"
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+	body, _ := io.ReadAll(r.Body)
+	lease := &Lease{}
+	if len(body) > 0 {
+		_ = json.Unmarshal(body, lease)
+		name = lease.Metadata.Name
+	}
+	current, exist := s.leases[name]
+	switch r.Method {
+	case http.MethodGet:
+		if !exist {
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"kind":"Status","reason":"NotFound","message":"leases \"` + name + `\" not found"}`))
+			return
+		}
+		_, _ = w.Write(current)
+		return
+	case http.MethodPost:
+		if exist {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"kind":"Status","reason":"AlreadyExists","message":"exists"}`))
+			return
+		}
+		lease.Metadata.ResourceVersion = "1"
+	case http.MethodPut:
+		held := &Lease{}
+		_ = json.Unmarshal(current, held)
+		if held.Metadata.ResourceVersion != lease.Metadata.ResourceVersion {
+			w.WriteHeader(http.StatusConflict)
+			_, _ = w.Write([]byte(`{"kind":"Status","reason":"Conflict","message":"modified"}`))
+			return
+		}
+		version, _ := strconv.Atoi(held.Metadata.ResourceVersion)
+		lease.Metadata.ResourceVersion = strconv.Itoa(version + 1)
+	}
+	data, _ := json.Marshal(lease)
+	s.leases[name] = data
+	_, _ = w.Write(data)
+}
+
+func (s *leaseServer) holder(t *testing.T, name string) *Lease {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lease := &Lease{}
+	assert.NoError(t, json.Unmarshal(s.leases[name], lease))
+	return lease
+}
+
+func TestClientErrors(t *testing.T) {
+	server := httptest.NewServer(&leaseServer{leases: make(map[string][]byte), token: "secret"})
+	defer server.Close()
+	ctx := context.Background()
+
+	client := NewClient(server.URL, "secret", "ns", nil)
+	err := client.Get(ctx, "/apis/coordination.k8s.io/v1/namespaces/ns/leases/missing", &Lease{})
+	assert.True(t, IsNotFound(err))
+	assert.Contains(t, err.Error(), `leases "missing" not found`)
+
+	lease := &Lease{Metadata: ObjectMeta{Name: "l1"}}
+	assert.NoError(t, client.Create(ctx, "/apis/coordination.k8s.io/v1/namespaces/ns/leases", lease, nil))
+	err = client.Create(ctx, "/apis/coordination.k8s.io/v1/namespaces/ns/leases", lease, nil)
+	assert.True(t, IsConflict(err))
+
+	err = NewClient(server.URL, "wrong", "ns", nil).Get(ctx, "/apis/coordination.k8s.io/v1/namespaces/ns/leases/l1", &Lease{})
+	assert.Error(t, err)
+	assert.False(t, IsNotFound(err))
+}
+
+func TestLeaderElector(t *testing.T) {
+	leases := &leaseServer{leases: make(map[string][]byte), token: "secret"}
+	server := httptest.NewServer(leases)
+	defer server.Close()
+	ctx := context.Background()
+
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	newElector := func(identity string) *LeaderElector {
+		return &LeaderElector{
+			Client:        NewClient(server.URL, "secret", "ns", nil),
+			Namespace:     "ns",
+			Name:          "controller",
+			Identity:      identity,
+			LeaseDuration: 15 * time.Second,
+			RenewDeadline: 10 * time.Second,
+			RetryPeriod:   2 * time.Second,
+			now:           func() time.Time { return now },
+		}
+	}
+	a, b := newElector("a"), newElector("b")
+
+	acquired, err := a.tryAcquireOrRenew(ctx)
+	assert.NoError(t, err)
+	assert.True(t, acquired)
+	acquired, err = b.tryAcquireOrRenew(ctx)
+	assert.NoError(t, err)
+	assert.False(t, acquired)
+
+	// renewed by the holder
+	now = now.Add(10 * time.Second)
+	acquired, _ = a.tryAcquireOrRenew(ctx)
+	assert.True(t, acquired)
+	now = now.Add(10 * time.Second)
+	acquired, _ = b.tryAcquireOrRenew(ctx)
+	assert.False(t, acquired)
+
+	// taken over once expired
+	now = now.Add(20 * time.Second)
+	acquired, _ = b.tryAcquireOrRenew(ctx)
+	assert.True(t, acquired)
+	lease := leases.holder(t, "controller")
+	assert.Equal(t, "b", lease.Spec.HolderIdentity)
+	assert.Equal(t, int32(1), lease.Spec.LeaseTransitions)
+	assert.Equal(t, now, lease.Spec.RenewTime.Time)
+	acquired, _ = a.tryAcquireOrRenew(ctx)
+	assert.False(t, acquired)
+
+	// released at once when the leader stops
+	b.release()
+	acquired, _ = a.tryAcquireOrRenew(ctx)
+	assert.True(t, acquired)
+}
+
+func TestLeaderElectorRun(t *testing.T) {
+	server := httptest.NewServer(&leaseServer{leases: make(map[string][]byte), token: "secret"})
+	defer server.Close()
+	elector := &LeaderElector{
+		Client:        NewClient(server.URL, "secret", "ns", nil),
+		Namespace:     "ns",
+		Name:          "controller",
+		Identity:      "a",
+		LeaseDuration: 3 * time.Second,
+		RenewDeadline: 2 * time.Second,
+		RetryPeriod:   10 * time.Millisecond,
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	leading := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		elector.Run(ctx, func(leaderCtx context.Context) {
+			close(leading)
+			<-leaderCtx.Done()
+		})
+	}()
+	<-leading
+	cancel()
+	<-done
+}
+
+func TestSetCondition(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	conditions := make([]Condition, 0)
+	assert.True(t, SetCondition(&conditions, Condition{Type: "Complete", Status: ConditionUnknown, Reason: "Running"}, now))
+	assert.False(t, SetCondition(&conditions, Condition{Type: "Complete", Status: ConditionUnknown, Reason: "Running"}, now.Add(time.Minute)))
+	assert.Equal(t, now, conditions[0].LastTransitionTime)
+
+	// the transition time only changes with the status
+	assert.True(t, SetCondition(&conditions, Condition{Type: "Complete", Status: ConditionUnknown, Reason: "Resumed"}, now.Add(time.Minute)))
+	assert.Equal(t, now, conditions[0].LastTransitionTime)
+	assert.True(t, SetCondition(&conditions, Condition{Type: "Complete", Status: ConditionTrue, Reason: "Succeeded"}, now.Add(time.Hour)))
+	assert.Equal(t, now.Add(time.Hour), conditions[0].LastTransitionTime)
+	assert.Len(t, conditions, 1)
+}
+
+func TestMicroTime(t *testing.T) {
+	moment := MicroTime{time.Date(2024, 1, 1, 0, 0, 0, 123456000, time.UTC)}
+	data, err := json.Marshal(moment)
+	assert.NoError(t, err)
+	assert.Equal(t, `"2024-01-01T00:00:00.123456Z"`, string(data))
+	parsed := MicroTime{}
+	assert.NoError(t, json.Unmarshal(data, &parsed))
+	assert.True(t, moment.Equal(parsed.Time))
+	assert.NoError(t, json.Unmarshal([]byte(`"2024-01-01T00:00:00Z"`), &parsed))
+	assert.Equal(t, 2024, parsed.Year())
+}
+
+func TestApplyConfigMap(t *testing.T) {
+	var mu sync.Mutex
+	stored := make(map[string]*ConfigMap)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		configMap := &ConfigMap{}
+		_ = json.NewDecoder(r.Body).Decode(configMap)
+		name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/v1/namespaces/ns/configmaps"), "/")
+		switch r.Method {
+		case http.MethodGet:
+			if stored[name] == nil {
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+			_ = json.NewEncoder(w).Encode(stored[name])
+		case http.MethodPost:
+			if stored[configMap.Metadata.Name] != nil {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			configMap.Metadata.ResourceVersion = "1"
+			stored[configMap.Metadata.Name] = configMap
+		case http.MethodPut:
+			if stored[name].Metadata.ResourceVersion != configMap.Metadata.ResourceVersion {
+				w.WriteHeader(http.StatusConflict)
+				return
+			}
+			configMap.Metadata.ResourceVersion = "2"
+			stored[name] = configMap
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	client := NewClient(server.URL, "", "ns", nil)
+
+	_, err := client.GetConfigMap(ctx, "ns", "meta")
+	assert.True(t, IsNotFound(err))
+	assert.NoError(t, client.ApplyConfigMap(ctx, &ConfigMap{Metadata: ObjectMeta{Name: "meta", Namespace: "ns"}, Data: map[string]string{"k": "v1"}}))
+	assert.NoError(t, client.ApplyConfigMap(ctx, &ConfigMap{Metadata: ObjectMeta{Name: "meta", Namespace: "ns"}, Data: map[string]string{"k": "v2"}}))
+	configMap, err := client.GetConfigMap(ctx, "ns", "meta")
+	assert.NoError(t, err)
+	assert.Equal(t, "v2", configMap.Data["k"])
+	assert.Equal(t, "2", configMap.Metadata.ResourceVersion)
+}
@@ -0,0 +1,185 @@
+package kube
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+// LeaderElector elects one leader among the processes sharing a lease, like the leader election of client-go
+type LeaderElector struct {
+	Client    *Client
+	Namespace string
+	// name of the lease
+	Name string
+	// unique of the process, like the pod name
+	Identity string
+	// the lease is taken over by another process if it is not renewed for LeaseDuration
+	LeaseDuration time.Duration
+	// the leader stops leading if it fails to renew the lease for RenewDeadline, which should be shorter than
+	// LeaseDuration, so that it stops before another process takes over
+	RenewDeadline time.Duration
+	// how often the lease is acquired or renewed
+	RetryPeriod time.Duration
+
+	now func() time.Time
+}
+
+func (e *LeaderElector) leasePath() string {
+	return "/apis/coordination.k8s.io/v1/namespaces/" + e.Namespace + "/leases"
+}
+
+func (e *LeaderElector) clock() time.Time {
+	if e.now != nil {
+		return e.now()
+	}
+	return time.Now()
+}
+
+// Run blocks until ctx is done. lead is called with a ctx canceled once the process stops leading, then the process
+// tries to acquire the lease again after lead returns.
+func (e *LeaderElector) Run(ctx context.Context, lead func(ctx context.Context)) {
+	for {
+		if !e.acquire(ctx) {
+			return
+		}
+		log.Info("start leading", zap.String("lease", e.Name), zap.String("identity", e.Identity))
+		leaderCtx, cancel := context.WithCancel(ctx)
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			lead(leaderCtx)
+		}()
+		e.renew(leaderCtx)
+		cancel()
+		<-done
+		log.Warn("stop leading", zap.String("lease", e.Name), zap.String("identity", e.Identity))
+		if ctx.Err() != nil {
+			e.release()
+			return
+		}
+	}
+}
+
+// acquire retries until the lease is acquired, false if ctx is done
+func (e *LeaderElector) acquire(ctx context.Context) bool {
+	ticker := time.NewTicker(e.RetryPeriod)
+	defer ticker.Stop()
+	for {
+		acquired, err := e.tryAcquireOrRenew(ctx)
+		if err != nil {
+			log.Warn("fail to acquire lease", zap.String("lease", e.Name), zap.Error(err))
+		}
+		if acquired {
+			return true
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case <-ticker.C:
+		}
+	}
+}
+
+// renew renews the lease until ctx is done or the lease isn't renewed for RenewDeadline
+func (e *LeaderElector) renew(ctx context.Context) {
+	ticker := time.NewTicker(e.RetryPeriod)
+	defer ticker.Stop()
+	lastRenew := e.clock()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		renewCtx, cancel := context.WithTimeout(ctx, e.RenewDeadline)
+		renewed, err := e.tryAcquireOrRenew(renewCtx)
+		cancel()
+		if err != nil {
+			log.Warn("fail to renew lease", zap.String("lease", e.Name), zap.Error(err))
+		}
+		if renewed {
+			lastRenew = e.clock()
+			continue
+		}
+		if err == nil || e.clock().Sub(lastRenew) >= e.RenewDeadline {
+			// taken by another process, or not renewed in time
+			return
+		}
+	}
+}
+
+// tryAcquireOrRenew writes the lease held by this process if it is free, expired or already held by this process
+func (e *LeaderElector) tryAcquireOrRenew(ctx context.Context) (bool, error) {
+	now := MicroTime{e.clock()}
+	lease := &Lease{}
+	err := e.Client.Get(ctx, e.leasePath()+"/"+e.Name, lease)
+	if IsNotFound(err) {
+		lease = &Lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   ObjectMeta{Name: e.Name, Namespace: e.Namespace},
+			Spec: LeaseSpec{
+				HolderIdentity:       e.Identity,
+				LeaseDurationSeconds: int32(e.LeaseDuration / time.Second),
+				AcquireTime:          &now,
+				RenewTime:            &now,
+			},
+		}
+		if err := e.Client.Create(ctx, e.leasePath(), lease, nil); err != nil {
+			if IsConflict(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	spec := &lease.Spec
+	if spec.HolderIdentity != e.Identity && spec.HolderIdentity != "" && !e.expired(spec, now.Time) {
+		return false, nil
+	}
+	if spec.HolderIdentity != e.Identity {
+		spec.HolderIdentity = e.Identity
+		spec.AcquireTime = &now
+		spec.LeaseTransitions++
+	}
+	spec.LeaseDurationSeconds = int32(e.LeaseDuration / time.Second)
+	spec.RenewTime = &now
+	if err := e.Client.Update(ctx, e.leasePath()+"/"+e.Name, lease, nil); err != nil {
+		if IsConflict(err) {
+			// written by another process at the same time
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (e *LeaderElector) expired(spec *LeaseSpec, now time.Time) bool {
+	if spec.RenewTime == nil {
+		return true
+	}
+	return now.Sub(spec.RenewTime.Time) > time.Duration(spec.LeaseDurationSeconds)*time.Second
+}
+
+// release clears the holder of the lease, so that another process takes over at once
+func (e *LeaderElector) release() {
+	ctx, cancel := context.WithTimeout(context.Background(), e.RenewDeadline)
+	defer cancel()
+	lease := &Lease{}
+	if err := e.Client.Get(ctx, e.leasePath()+"/"+e.Name, lease); err != nil || lease.Spec.HolderIdentity != e.Identity {
+		return
+	}
+	lease.Spec.HolderIdentity = ""
+	lease.Spec.RenewTime = nil
+	if err := e.Client.Update(ctx, e.leasePath()+"/"+e.Name, lease, nil); err != nil {
+		log.Warn("fail to release lease", zap.String("lease", e.Name), zap.Error(err))
+	}
+}
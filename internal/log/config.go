@@ -36,6 +36,8 @@ type FileLogConfig struct {
 	MaxDays int `toml:"max-days" json:"max-days"`
 	// Maximum number of old log files to retain.
 	MaxBackups int `toml:"max-backups" json:"max-backups"`
+	// Compress the rotated log files by gzip.
+	Compress bool `toml:"compress" json:"compress"`
 }
 
 // Config serializes log related config in toml/json.
@@ -48,6 +50,8 @@ type Config struct {
 	GrpcLevel string `toml:"grpc-level" json:"grpc-level"`
 	// Log format. one of json, text, or console.
 	Format string `toml:"format" json:"format"`
+	// Log levels of modules by module name, see Modules, the modules not in it are logged at Level.
+	Modules map[string]string `toml:"modules" json:"modules"`
 	// Disable automatic timestamps in output.
 	DisableTimestamp bool `toml:"disable-timestamp" json:"disable-timestamp"`
 	// File log config.
@@ -46,6 +46,14 @@ import (
 var _globalL, _globalP, _globalS, _globalR atomic.Value
 var rateLimiter *utils.ReconfigurableRateLimiter
 
+// consoleOutput is where the console logs are written
+var consoleOutput = "stdout"
+
+// SetConsoleOutput changes the output of the console logs of loggers initialized later, like stderr to leave stdout to the command output
+func SetConsoleOutput(output string) {
+	consoleOutput = output
+}
+
 func init() {
 	l, p := newStdLogger()
 	_globalL.Store(l)
@@ -68,7 +76,7 @@ func InitLogger(cfg *Config, opts ...zap.Option) (*zap.Logger, *ZapProperties, e
 		outputs = append(outputs, zapcore.AddSync(lg))
 	}
 	if cfg.Console {
-		stdOut, _, err := zap.Open([]string{"stdout"}...)
+		stdOut, _, err := zap.Open([]string{consoleOutput}...)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -97,7 +105,12 @@ func InitLoggerWithWriteSyncer(cfg *Config, output zapcore.WriteSyncer, opts ...
 	if err != nil {
 		return nil, nil, fmt.Errorf("initLoggerWithWriteSyncer UnmarshalText cfg.Level err:%w", err)
 	}
-	core := NewTextCore(newZapTextEncoder(cfg), output, level)
+	var core zapcore.Core = NewTextCore(newZapTextEncoder(cfg), output, level)
+	if len(cfg.Modules) > 0 {
+		if core, err = newModuleCore(core, level, cfg.Modules); err != nil {
+			return nil, nil, err
+		}
+	}
 	opts = append(cfg.buildOptions(output), opts...)
 	lg := zap.New(core, opts...)
 	r := &ZapProperties{
@@ -125,6 +138,7 @@ func initFileLog(cfg *FileLogConfig) (*lumberjack.Logger, error) {
 		MaxSize:    cfg.MaxSize,
 		MaxBackups: cfg.MaxBackups,
 		MaxAge:     cfg.MaxDays,
+		Compress:   cfg.Compress,
 		LocalTime:  true,
 	}, nil
 }
@@ -0,0 +1,112 @@
+package log
+
+import (
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ModuleKey is the field naming the module of the logs written by the logger of Module
+const ModuleKey = "module"
+
+// modules with their own levels in log.modules
+const (
+	// the storages of milvus and backup
+	ModuleStorage = "storage"
+	// the grpc client connecting to milvus
+	ModuleMilvus = "milvus"
+	// the http and grpc api server
+	ModuleHTTP = "http"
+)
+
+// Modules are the names of the modules supported in Config.Modules
+var Modules = []string{ModuleStorage, ModuleMilvus, ModuleHTTP}
+
+// Module returns the global Logger of a module, it writes the logs at the level of the module in Config.Modules,
+// or the level of the global Logger if the module has no level. The logs are marked by the module field.
+func Module(name string) *zap.Logger {
+	// the global Logger skips the frame of the functions in global.go, which is not called by the returned one
+	return L().WithOptions(zap.AddCallerSkip(-1)).With(zap.String(ModuleKey, name))
+}
+
+// ModuleLogger writes the logs of a package as a module, like the functions in global.go, so that a package can
+// declare a ModuleLogger named log instead of importing this package
+type ModuleLogger struct {
+	name string
+}
+
+// NewModuleLogger returns the logger of the module name
+func NewModuleLogger(name string) ModuleLogger {
+	return ModuleLogger{name: name}
+}
+
+// logger skips the frame of the methods of ModuleLogger like the global Logger
+func (m ModuleLogger) logger() *zap.Logger {
+	return L().With(zap.String(ModuleKey, m.name))
+}
+
+func (m ModuleLogger) Debug(msg string, fields ...zap.Field) {
+	m.logger().Debug(msg, fields...)
+}
+
+func (m ModuleLogger) Info(msg string, fields ...zap.Field) {
+	m.logger().Info(msg, fields...)
+}
+
+func (m ModuleLogger) Warn(msg string, fields ...zap.Field) {
+	m.logger().Warn(msg, fields...)
+}
+
+func (m ModuleLogger) Error(msg string, fields ...zap.Field) {
+	m.logger().Error(msg, fields...)
+}
+
+// With creates a child logger of the module and adds structured context to it
+func (m ModuleLogger) With(fields ...zap.Field) *zap.Logger {
+	return Module(m.name).With(fields...)
+}
+
+// moduleCore writes the logs at the level of their module, the module is set by the module field added to the
+// logger by With. The wrapped core is not asked for the level.
+type moduleCore struct {
+	zapcore.Core
+	level   zapcore.LevelEnabler
+	modules map[string]zapcore.LevelEnabler
+}
+
+// newModuleCore wraps core with the levels of modules, the logs without module are written at level
+func newModuleCore(core zapcore.Core, level zapcore.LevelEnabler, modules map[string]string) (zapcore.Core, error) {
+	enablers := make(map[string]zapcore.LevelEnabler, len(modules))
+	for module, text := range modules {
+		var moduleLevel zapcore.Level
+		if err := moduleLevel.UnmarshalText([]byte(text)); err != nil {
+			return nil, fmt.Errorf("illegal log level %s of module %s: %w", text, module, err)
+		}
+		enablers[module] = moduleLevel
+	}
+	return &moduleCore{Core: core, level: level, modules: enablers}, nil
+}
+
+func (c *moduleCore) Enabled(level zapcore.Level) bool {
+	return c.level.Enabled(level)
+}
+
+func (c *moduleCore) With(fields []zapcore.Field) zapcore.Core {
+	level := c.level
+	for _, field := range fields {
+		if field.Key == ModuleKey && field.Type == zapcore.StringType {
+			if moduleLevel, ok := c.modules[field.String]; ok {
+				level = moduleLevel
+			}
+		}
+	}
+	return &moduleCore{Core: c.Core.With(fields), level: level, modules: c.modules}
+}
+
+func (c *moduleCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
@@ -126,7 +126,7 @@ func NewTextEncoder(cfg *Config) zapcore.Encoder {
 		cc.TimeKey = ""
 	}
 	switch cfg.Format {
-	case "text", "":
+	case "text", "console", "":
 		return &textEncoder{
 			EncoderConfig:       &cc,
 			buf:                 _pool.Get(),
@@ -134,6 +134,8 @@ func NewTextEncoder(cfg *Config) zapcore.Encoder {
 			disableErrorVerbose: cfg.DisableErrorVerbose,
 		}
 	case "json":
+		// parsed as a date by log collectors like logstash and fluentd
+		cc.EncodeTime = zapcore.ISO8601TimeEncoder
 		return zapcore.NewJSONEncoder(cc)
 	default:
 		panic(fmt.Sprintf("unsupport log format: %s", cfg.Format))
@@ -0,0 +1,155 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const (
+	namespace = "milvus_backup"
+
+	SuccessLabel = "success"
+	FailLabel    = "fail"
+
+	BackupTaskLabel  = "backup"
+	RestoreTaskLabel = "restore"
+)
+
+var (
+	// TaskTotal counts the finished backup and restore tasks
+	TaskTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "tasks_total",
+			Help:      "Number of finished backup and restore tasks.",
+		}, []string{"task", "state"})
+
+	// TaskDuration observes the duration of finished backup and restore tasks
+	TaskDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "task_duration_seconds",
+			Help:      "Duration of finished backup and restore tasks.",
+			// from 1 second to about 9 hours
+			Buckets: prometheus.ExponentialBuckets(1, 2, 16),
+		}, []string{"task", "state"})
+
+	// LastTaskTimestamp is the finish time of the last backup and restore task, used to alert on missing backups
+	LastTaskTimestamp = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_task_timestamp_seconds",
+			Help:      "Unix time when the last backup or restore task finished.",
+		}, []string{"task", "state"})
+
+	// CopiedBytes counts the bytes of binlogs copied by backup and restored
+	CopiedBytes = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "copied_bytes_total",
+			Help:      "Bytes of binlogs copied by backup and restored.",
+		}, []string{"task"})
+
+	// DedupBytes counts the bytes of binlogs not written by backup since their content is stored in dedup objects
+	DedupBytes = prometheus.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "deduplicated_bytes_total",
+			Help:      "Bytes of binlogs not written by backup since they are stored in dedup objects.",
+		})
+
+	// WorkerPoolRunningJobs is the number of jobs executing in the worker pools
+	WorkerPoolRunningJobs = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "worker_pool_running_jobs",
+			Help:      "Number of jobs executing in worker pools.",
+		}, []string{"pool"})
+
+	// CopyParallelism is the copy parallelism of the executing backup, lowered while milvus is under heavy load
+	CopyParallelism = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "copy_parallelism",
+			Help:      "Copy parallelism of the executing backup adjusted by the load of milvus.",
+		})
+
+	// BufferedBytes is the bytes of binlog data held in memory by the copies of backup and restore
+	BufferedBytes = prometheus.NewGauge(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "buffered_bytes",
+			Help:      "Bytes of binlog data held in memory by the copies of backup and restore.",
+		})
+
+	// StorageRequestDuration observes the latency of storage client requests
+	StorageRequestDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "storage_request_duration_seconds",
+			Help:      "Latency of storage client requests.",
+			// from 5 milliseconds to about 40 seconds
+			Buckets: prometheus.ExponentialBuckets(0.005, 2, 14),
+		}, []string{"storage_type", "operation", "state"})
+
+	// StorageRetries counts the storage operations retried after transient errors
+	StorageRetries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "storage_retries_total",
+			Help:      "Number of storage operations retried after transient errors.",
+		}, []string{"storage_type", "operation"})
+
+	// StorageCircuitBreakerOpen is 1 while the circuit breaker of a storage is open
+	StorageCircuitBreakerOpen = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "storage_circuit_breaker_open",
+			Help:      "Whether the circuit breaker of the storage is open.",
+		}, []string{"storage_type"})
+)
+
+var registry = prometheus.NewRegistry()
+
+func init() {
+	registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		TaskTotal,
+		TaskDuration,
+		LastTaskTimestamp,
+		CopiedBytes,
+		DedupBytes,
+		WorkerPoolRunningJobs,
+		CopyParallelism,
+		BufferedBytes,
+		StorageRequestDuration,
+		StorageRetries,
+		StorageCircuitBreakerOpen,
+	)
+}
+
+// Handler serves the metrics in prometheus text format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(registry, promhttp.HandlerOpts{})
+}
+
+// StateLabel returns the state label of a task or request by its error
+func StateLabel(err error) string {
+	if err != nil {
+		return FailLabel
+	}
+	return SuccessLabel
+}
+
+// ObserveTask records a finished backup or restore task started at start
+func ObserveTask(task string, start time.Time, err error) {
+	state := StateLabel(err)
+	TaskTotal.WithLabelValues(task, state).Inc()
+	TaskDuration.WithLabelValues(task, state).Observe(time.Since(start).Seconds())
+	LastTaskTimestamp.WithLabelValues(task, state).SetToCurrentTime()
+}
@@ -0,0 +1,205 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const (
+	// EventSuccess is sent when a task succeeds
+	EventSuccess = "success"
+	// EventFail is sent when a task fails
+	EventFail = "fail"
+	// EventSlow is sent when a task is still running after the duration threshold
+	EventSlow = "slow"
+
+	FormatGeneric   = "generic"
+	FormatSlack     = "slack"
+	FormatPagerDuty = "pagerduty"
+
+	pagerDutyURL = "https://events.pagerduty.com/v2/enqueue"
+	sendTimeout  = 10 * time.Second
+)
+
+// Webhook receives the events of tasks
+type Webhook struct {
+	Name string
+	// URL to post the events, the events API of PagerDuty is used if empty and format is pagerduty
+	URL string
+	// payload format, generic, slack or pagerduty
+	Format string
+	// types of events to send, all events are sent if empty
+	Events []string
+	// integration key of the PagerDuty service
+	RoutingKey string
+}
+
+func (w Webhook) subscribes(eventType string) bool {
+	if len(w.Events) == 0 {
+		return true
+	}
+	for _, e := range w.Events {
+		if e == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Event is the summary of a backup or restore task
+type Event struct {
+	Type string `json:"type"`
+	// backup or restore
+	Task string `json:"task"`
+	// id of the backup or the restore task
+	Id         string `json:"id"`
+	BackupName string `json:"backup_name"`
+	State      string `json:"state"`
+	Error      string `json:"error,omitempty"`
+	// unix seconds
+	StartTime int64 `json:"start_time"`
+	// seconds the task has been running
+	Duration    int64    `json:"duration"`
+	Size        int64    `json:"size"`
+	Collections []string `json:"collections,omitempty"`
+}
+
+func (e Event) summary() string {
+	var action string
+	switch e.Type {
+	case EventSuccess:
+		action = "succeeded"
+	case EventFail:
+		action = "failed"
+	case EventSlow:
+		action = "is still running"
+	default:
+		action = e.Type
+	}
+	subject := "backup " + e.BackupName
+	if e.Task != "backup" {
+		subject = e.Task + " of backup " + e.BackupName
+	}
+	summary := fmt.Sprintf("milvus-backup: %s %s after %ds", subject, action, e.Duration)
+	if e.Error != "" {
+		summary += ": " + e.Error
+	}
+	return summary
+}
+
+// Notifier sends the events of tasks to webhooks
+type Notifier struct {
+	webhooks []Webhook
+	// tasks running longer than it are reported by EventSlow, disabled if not positive
+	durationThreshold time.Duration
+	client            *http.Client
+}
+
+func NewNotifier(webhooks []Webhook, durationThreshold time.Duration) *Notifier {
+	return &Notifier{
+		webhooks:          webhooks,
+		durationThreshold: durationThreshold,
+		client:            &http.Client{Timeout: sendTimeout},
+	}
+}
+
+// Notify sends event to the webhooks subscribe it. Failures are logged only, a task never fails for notification.
+func (n *Notifier) Notify(ctx context.Context, event Event) {
+	for _, webhook := range n.webhooks {
+		if !webhook.subscribes(event.Type) {
+			continue
+		}
+		if err := n.send(ctx, webhook, event); err != nil {
+			log.Warn("fail to send notification",
+				zap.String("webhook", webhook.Name),
+				zap.String("event", event.Type),
+				zap.String("id", event.Id),
+				zap.Error(err))
+		}
+	}
+}
+
+// WatchDuration sends the event returned by getEvent if the task is still running after the duration threshold.
+// The returned function should be called when the task finishes.
+func (n *Notifier) WatchDuration(getEvent func() Event) (stop func()) {
+	if n.durationThreshold <= 0 {
+		return func() {}
+	}
+	timer := time.AfterFunc(n.durationThreshold, func() {
+		event := getEvent()
+		event.Type = EventSlow
+		n.Notify(context.Background(), event)
+	})
+	return func() { timer.Stop() }
+}
+
+func (n *Notifier) send(ctx context.Context, webhook Webhook, event Event) error {
+	payload, err := buildPayload(webhook, event)
+	if err != nil {
+		return err
+	}
+	url := webhook.URL
+	if url == "" && webhook.Format == FormatPagerDuty {
+		url = pagerDutyURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("webhook responds %s: %s", resp.Status, string(body))
+	}
+	return nil
+}
+
+func buildPayload(webhook Webhook, event Event) ([]byte, error) {
+	switch webhook.Format {
+	case FormatSlack:
+		return json.Marshal(map[string]string{"text": event.summary()})
+	case FormatPagerDuty:
+		return json.Marshal(pagerDutyPayload(webhook, event))
+	default:
+		return json.Marshal(event)
+	}
+}
+
+// pagerDutyPayload triggers an alert for a failed or slow task, which is resolved when the task succeeds
+func pagerDutyPayload(webhook Webhook, event Event) map[string]interface{} {
+	payload := map[string]interface{}{
+		"routing_key": webhook.RoutingKey,
+		// the events of a task are grouped into one alert
+		"dedup_key": event.Task + "-" + event.Id,
+	}
+	if event.Type == EventSuccess {
+		payload["event_action"] = "resolve"
+		return payload
+	}
+	severity := "error"
+	if event.Type == EventSlow {
+		severity = "warning"
+	}
+	payload["event_action"] = "trigger"
+	payload["payload"] = map[string]interface{}{
+		"summary":        event.summary(),
+		"source":         "milvus-backup",
+		"severity":       severity,
+		"custom_details": event,
+	}
+	return payload
+}
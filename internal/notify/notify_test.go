@@ -0,0 +1,82 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotify(t *testing.T) {
+	var mu sync.Mutex
+	received := make(map[string][]map[string]interface{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		mu.Lock()
+		defer mu.Unlock()
+		received[r.URL.Path] = append(received[r.URL.Path], payload)
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier([]Webhook{
+		{Name: "generic", URL: server.URL + "/generic", Format: FormatGeneric},
+		{Name: "slack", URL: server.URL + "/slack", Format: FormatSlack, Events: []string{EventFail}},
+		{Name: "pagerduty", URL: server.URL + "/pagerduty", Format: FormatPagerDuty, RoutingKey: "key"},
+	}, 0)
+	ctx := context.Background()
+	notifier.Notify(ctx, Event{Type: EventFail, Task: "backup", Id: "id1", BackupName: "b1", Duration: 3, Error: "timeout"})
+	notifier.Notify(ctx, Event{Type: EventSuccess, Task: "backup", Id: "id1", BackupName: "b1", Duration: 5})
+
+	assert.Len(t, received["/generic"], 2)
+	assert.Equal(t, "b1", received["/generic"][0]["backup_name"])
+	assert.Equal(t, EventSuccess, received["/generic"][1]["type"])
+
+	// slack only subscribes failures
+	assert.Equal(t, []map[string]interface{}{
+		{"text": "milvus-backup: backup b1 failed after 3s: timeout"},
+	}, received["/slack"])
+
+	// the alert triggered by failure is resolved by success
+	assert.Len(t, received["/pagerduty"], 2)
+	assert.Equal(t, "trigger", received["/pagerduty"][0]["event_action"])
+	assert.Equal(t, "backup-id1", received["/pagerduty"][0]["dedup_key"])
+	assert.Equal(t, "error", received["/pagerduty"][0]["payload"].(map[string]interface{})["severity"])
+	assert.Equal(t, "resolve", received["/pagerduty"][1]["event_action"])
+	assert.Equal(t, "backup-id1", received["/pagerduty"][1]["dedup_key"])
+}
+
+func TestWatchDuration(t *testing.T) {
+	events := make(chan map[string]interface{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]interface{}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		events <- payload
+	}))
+	defer server.Close()
+
+	notifier := NewNotifier([]Webhook{{Name: "generic", URL: server.URL, Format: FormatGeneric}}, 100*time.Millisecond)
+	stop := notifier.WatchDuration(func() Event { return Event{Task: "restore", Id: "id1"} })
+	select {
+	case event := <-events:
+		assert.Equal(t, EventSlow, event["type"])
+		assert.Equal(t, "id1", event["id"])
+	case <-time.After(5 * time.Second):
+		assert.Fail(t, "slow event is not sent")
+	}
+	stop()
+
+	// finished before the threshold
+	stop = notifier.WatchDuration(func() Event { return Event{Task: "restore", Id: "id2"} })
+	stop()
+	select {
+	case event := <-events:
+		assert.Fail(t, "unexpected event", event)
+	case <-time.After(300 * time.Millisecond):
+	}
+}
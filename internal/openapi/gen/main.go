@@ -0,0 +1,31 @@
+// gen writes the OpenAPI 3.0 spec converted from the swagger 2.0 spec generated by swag,
+// run by gen_swag.sh after swag init: go run ./internal/openapi/gen docs/swagger.json docs/openapi.json
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/zilliztech/milvus-backup/internal/openapi"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Fprintln(os.Stderr, "usage: gen <swagger.json> <openapi.json>")
+		os.Exit(1)
+	}
+	doc, err := os.ReadFile(os.Args[1])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	spec, err := openapi.FromSwagger2(doc)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(os.Args[2], append(spec, '\n'), 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,231 @@
+// Package openapi converts the swagger 2.0 spec generated by swag from the handler annotations
+// into an OpenAPI 3.0 spec, so that the request/response structs stay the single source of both
+package openapi
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+const (
+	// Version of the converted spec
+	Version = "3.0.3"
+
+	definitionsRef = "#/definitions/"
+	schemasRef     = "#/components/schemas/"
+	defaultMedia   = "application/json"
+)
+
+// fields of a swagger 2.0 non-body parameter which are moved into its schema
+var parameterSchemaFields = []string{"type", "format", "items", "enum", "default", "minimum", "maximum", "pattern"}
+
+// FromSwagger2 converts the swagger 2.0 json spec into an OpenAPI 3.0 json spec
+func FromSwagger2(doc []byte) ([]byte, error) {
+	var swagger map[string]interface{}
+	if err := json.Unmarshal(doc, &swagger); err != nil {
+		return nil, err
+	}
+
+	spec := map[string]interface{}{
+		"openapi": Version,
+		"info":    swagger["info"],
+		"servers": []interface{}{map[string]interface{}{"url": serverURL(swagger)}},
+	}
+	if tags, ok := swagger["tags"]; ok {
+		spec["tags"] = tags
+	}
+	if definitions, ok := swagger["definitions"]; ok {
+		spec["components"] = map[string]interface{}{"schemas": definitions}
+	}
+
+	consumes := mediaTypes(swagger["consumes"])
+	produces := mediaTypes(swagger["produces"])
+	paths := make(map[string]interface{})
+	for path, value := range asMap(swagger["paths"]) {
+		item := make(map[string]interface{})
+		for method, op := range asMap(value) {
+			if method == "parameters" {
+				item[method] = convertParameters(op)
+				continue
+			}
+			item[method] = convertOperation(asMap(op), consumes, produces)
+		}
+		paths[path] = item
+	}
+	spec["paths"] = paths
+
+	return json.MarshalIndent(replaceRefs(spec), "", "    ")
+}
+
+// serverURL is the url of the api from the host, schemes and basePath of the swagger spec,
+// relative to the server of the spec if host is not set
+func serverURL(swagger map[string]interface{}) string {
+	basePath, _ := swagger["basePath"].(string)
+	host, _ := swagger["host"].(string)
+	if host == "" {
+		if basePath == "" {
+			return "/"
+		}
+		return basePath
+	}
+	scheme := "http"
+	if schemes := mediaTypes(swagger["schemes"]); len(schemes) > 0 {
+		scheme = schemes[0]
+	}
+	return scheme + "://" + host + basePath
+}
+
+func convertOperation(op map[string]interface{}, consumes, produces []string) map[string]interface{} {
+	if opConsumes := mediaTypes(op["consumes"]); len(opConsumes) > 0 {
+		consumes = opConsumes
+	}
+	if opProduces := mediaTypes(op["produces"]); len(opProduces) > 0 {
+		produces = opProduces
+	}
+
+	converted := make(map[string]interface{})
+	for key, value := range op {
+		switch key {
+		case "consumes", "produces", "parameters", "responses":
+		default:
+			converted[key] = value
+		}
+	}
+
+	var parameters []interface{}
+	for _, value := range asSlice(op["parameters"]) {
+		param := asMap(value)
+		switch param["in"] {
+		case "body":
+			requestBody := map[string]interface{}{"content": content(consumes, param["schema"])}
+			copyFields(requestBody, param, "description", "required")
+			converted["requestBody"] = requestBody
+		case "formData":
+			// not generated by the handlers, the api only accepts json
+		default:
+			parameters = append(parameters, convertParameter(param))
+		}
+	}
+	if len(parameters) > 0 {
+		converted["parameters"] = parameters
+	}
+
+	responses := make(map[string]interface{})
+	for code, value := range asMap(op["responses"]) {
+		resp := asMap(value)
+		response := make(map[string]interface{})
+		copyFields(response, resp, "description", "headers")
+		// description is required by OpenAPI 3
+		if _, ok := response["description"]; !ok {
+			response["description"] = ""
+		}
+		if schema, ok := resp["schema"]; ok {
+			response["content"] = content(produces, schema)
+		}
+		responses[code] = response
+	}
+	converted["responses"] = responses
+	return converted
+}
+
+func convertParameters(value interface{}) []interface{} {
+	var parameters []interface{}
+	for _, param := range asSlice(value) {
+		parameters = append(parameters, convertParameter(asMap(param)))
+	}
+	return parameters
+}
+
+// convertParameter moves the type of a query, header or path parameter into its schema
+func convertParameter(param map[string]interface{}) map[string]interface{} {
+	converted := make(map[string]interface{})
+	schema := make(map[string]interface{})
+	for key, value := range param {
+		switch {
+		case key == "collectionFormat":
+			// multi is the default form style with explode, csv is form without explode
+			if value == "csv" {
+				converted["explode"] = false
+			}
+		case contains(parameterSchemaFields, key):
+			schema[key] = value
+		default:
+			converted[key] = value
+		}
+	}
+	if len(schema) > 0 {
+		converted["schema"] = schema
+	}
+	// path parameters are always required in OpenAPI 3
+	if param["in"] == "path" {
+		converted["required"] = true
+	}
+	return converted
+}
+
+func content(mediaTypes []string, schema interface{}) map[string]interface{} {
+	if len(mediaTypes) == 0 {
+		mediaTypes = []string{defaultMedia}
+	}
+	content := make(map[string]interface{})
+	for _, mediaType := range mediaTypes {
+		content[mediaType] = map[string]interface{}{"schema": schema}
+	}
+	return content
+}
+
+// replaceRefs points the refs of swagger definitions to the schemas of components
+func replaceRefs(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if ref, ok := child.(string); ok && key == "$ref" {
+				v[key] = schemasRef + strings.TrimPrefix(ref, definitionsRef)
+				continue
+			}
+			v[key] = replaceRefs(child)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = replaceRefs(child)
+		}
+	}
+	return value
+}
+
+func copyFields(dst, src map[string]interface{}, keys ...string) {
+	for _, key := range keys {
+		if value, ok := src[key]; ok {
+			dst[key] = value
+		}
+	}
+}
+
+func mediaTypes(value interface{}) []string {
+	var types []string
+	for _, v := range asSlice(value) {
+		if s, ok := v.(string); ok {
+			types = append(types, s)
+		}
+	}
+	return types
+}
+
+func asMap(value interface{}) map[string]interface{} {
+	m, _ := value.(map[string]interface{})
+	return m
+}
+
+func asSlice(value interface{}) []interface{} {
+	s, _ := value.([]interface{})
+	return s
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSwagger2(t *testing.T) {
+	doc, err := os.ReadFile("../../docs/swagger.json")
+	assert.NoError(t, err)
+	converted, err := FromSwagger2(doc)
+	assert.NoError(t, err)
+	assert.NotContains(t, string(converted), "#/definitions/")
+
+	var spec map[string]interface{}
+	assert.NoError(t, json.Unmarshal(converted, &spec))
+	assert.Equal(t, Version, spec["openapi"])
+	assert.Nil(t, spec["swagger"])
+	assert.Equal(t, []interface{}{map[string]interface{}{"url": "/api/v1"}}, spec["servers"])
+	schemas := asMap(asMap(spec["components"])["schemas"])
+	assert.Contains(t, schemas, "backuppb.CreateBackupRequest")
+
+	create := asMap(asMap(asMap(spec["paths"])["/create"])["post"])
+	assert.NotContains(t, create, "consumes")
+	body := asMap(create["requestBody"])
+	assert.Equal(t, true, body["required"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/backuppb.CreateBackupRequest"},
+		asMap(asMap(body["content"])["application/json"])["schema"])
+	resp := asMap(asMap(create["responses"])["200"])
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/backuppb.BackupInfoResponse"},
+		asMap(asMap(resp["content"])["application/json"])["schema"])
+	for _, param := range asSlice(create["parameters"]) {
+		assert.NotEqual(t, "body", asMap(param)["in"])
+	}
+
+	// the type of parameters is moved into the schema
+	list := asMap(asMap(asMap(spec["paths"])["/list"])["get"])
+	for _, value := range asSlice(list["parameters"]) {
+		param := asMap(value)
+		assert.NotContains(t, param, "type")
+		assert.NotContains(t, param, "collectionFormat")
+		if param["name"] == "databases" {
+			assert.Equal(t, "array", asMap(param["schema"])["type"])
+		}
+	}
+	job := asMap(asMap(asMap(spec["paths"])["/jobs/{id}"])["get"])
+	for _, value := range asSlice(job["parameters"]) {
+		if param := asMap(value); param["in"] == "path" {
+			assert.Equal(t, true, param["required"])
+		}
+	}
+}
+
+func TestServerURL(t *testing.T) {
+	assert.Equal(t, "/", serverURL(map[string]interface{}{}))
+	assert.Equal(t, "https://backup.example.com/api/v1", serverURL(map[string]interface{}{
+		"host":     "backup.example.com",
+		"basePath": "/api/v1",
+		"schemes":  []interface{}{"https"},
+	}))
+
+	_, err := FromSwagger2([]byte("not json"))
+	assert.Error(t, err)
+}
+
+func TestGeneratedSpecUpToDate(t *testing.T) {
+	doc, err := os.ReadFile("../../docs/swagger.json")
+	assert.NoError(t, err)
+	converted, err := FromSwagger2(doc)
+	assert.NoError(t, err)
+	generated, err := os.ReadFile("../../docs/openapi.json")
+	assert.NoError(t, err)
+	assert.Equal(t, string(converted)+"\n", string(generated), "docs/openapi.json is outdated, run gen_swag.sh")
+}
@@ -0,0 +1,89 @@
+package trace
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.12.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+
+	"github.com/zilliztech/milvus-backup/internal/log"
+)
+
+const tracerName = "github.com/zilliztech/milvus-backup"
+
+// Config of the OTLP exporter, spans are not exported if Endpoint is empty
+type Config struct {
+	// address of the OTLP gRPC receiver, like localhost:4317
+	Endpoint string
+	// connect the receiver without TLS
+	Insecure bool
+	// fraction of the traces sampled, between 0 and 1
+	SampleFraction float64
+	ServiceName    string
+}
+
+var (
+	initOnce sync.Once
+	provider *sdktrace.TracerProvider
+)
+
+// Init sets the global tracer provider exporting spans by OTLP, only the first call takes effect.
+// Spans are dropped by the default no-op provider if it is not called or the endpoint is empty.
+func Init(ctx context.Context, cfg Config) error {
+	var err error
+	initOnce.Do(func() {
+		if cfg.Endpoint == "" {
+			return
+		}
+		opts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(cfg.Endpoint)}
+		if cfg.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		var exporter *otlptrace.Exporter
+		// the exporter connects the receiver in background, it doesn't fail if the receiver is unavailable
+		exporter, err = otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			log.Error("fail to create trace exporter", zap.String("endpoint", cfg.Endpoint), zap.Error(err))
+			return
+		}
+		provider = sdktrace.NewTracerProvider(
+			sdktrace.WithBatcher(exporter),
+			sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleFraction))),
+			sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceNameKey.String(cfg.ServiceName))),
+		)
+		otel.SetTracerProvider(provider)
+		log.Info("export traces", zap.String("endpoint", cfg.Endpoint), zap.Float64("sampleFraction", cfg.SampleFraction))
+	})
+	return err
+}
+
+// Shutdown exports the remaining spans, should be called before the process exits
+func Shutdown(ctx context.Context) error {
+	if provider == nil {
+		return nil
+	}
+	return provider.Shutdown(ctx)
+}
+
+// Start starts a span as a child of the span in ctx
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, oteltrace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, oteltrace.WithAttributes(attrs...))
+}
+
+// End records err in span if not nil and ends it
+func End(span oteltrace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -159,7 +159,8 @@ func SetupLogger(cfg *log.Config) {
 			logLevel = 0
 		}
 
-		wrapper := &zapWrapper{logger, logLevel}
+		// the logs of grpc are mostly of the client connecting to milvus
+		wrapper := &zapWrapper{logger.With(zap.String(log.ModuleKey, log.ModuleMilvus)), logLevel}
 		grpclog.SetLoggerV2(wrapper)
 
 		log.Info("Log directory", zap.String("configDir", cfg.File.RootPath))